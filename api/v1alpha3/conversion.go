@@ -47,6 +47,7 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.Topology != nil {
 		dst.Spec.Topology = restored.Spec.Topology
 	}
+	dst.Status.Machines = restored.Status.Machines
 
 	return nil
 }
@@ -97,6 +98,12 @@ func (src *Machine) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Status.NodeInfo = restored.Status.NodeInfo
+	dst.Spec.ReadinessGates = restored.Spec.ReadinessGates
+	dst.Spec.NodeDrainOptions = restored.Spec.NodeDrainOptions
+	dst.Spec.AddressPreference = restored.Spec.AddressPreference
+	dst.Status.CertificatesExpiryDate = restored.Status.CertificatesExpiryDate
+	dst.Status.Deletion = restored.Status.Deletion
+	dst.Status.InterruptibleInstance = restored.Status.InterruptibleInstance
 	return nil
 }
 
@@ -138,7 +145,15 @@ func (src *MachineSet) ConvertTo(dstRaw conversion.Hub) error {
 	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
 		return err
 	}
+	dst.Spec.Template.Spec.ReadinessGates = restored.Spec.Template.Spec.ReadinessGates
+	dst.Spec.Template.Spec.NodeDrainOptions = restored.Spec.Template.Spec.NodeDrainOptions
+	dst.Spec.Template.Spec.AddressPreference = restored.Spec.Template.Spec.AddressPreference
+	dst.Spec.MachineNamingStrategy = restored.Spec.MachineNamingStrategy
+	dst.Spec.InfrastructureFailurePolicy = restored.Spec.InfrastructureFailurePolicy
+	dst.Spec.InterruptionBudget = restored.Spec.InterruptionBudget
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Status.InfrastructureFailureRetries = restored.Status.InfrastructureFailureRetries
+	dst.Status.LastInfrastructureFailureRetryTime = restored.Status.LastInfrastructureFailureRetryTime
 	return nil
 }
 
@@ -191,7 +206,15 @@ func (src *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Strategy.RollingUpdate.DeletePolicy = restored.Spec.Strategy.RollingUpdate.DeletePolicy
 	}
 
+	dst.Spec.Template.Spec.ReadinessGates = restored.Spec.Template.Spec.ReadinessGates
+	dst.Spec.Template.Spec.NodeDrainOptions = restored.Spec.Template.Spec.NodeDrainOptions
+	dst.Spec.Template.Spec.AddressPreference = restored.Spec.Template.Spec.AddressPreference
+	dst.Spec.MachineNamingStrategy = restored.Spec.MachineNamingStrategy
+	dst.Spec.InfrastructureFailurePolicy = restored.Spec.InfrastructureFailurePolicy
+	dst.Spec.InterruptionBudget = restored.Spec.InterruptionBudget
+	dst.Spec.RolloutAfter = restored.Spec.RolloutAfter
 	dst.Status.Conditions = restored.Status.Conditions
+	dst.Status.InfrastructureFailureRetries = restored.Status.InfrastructureFailureRetries
 	return nil
 }
 
@@ -238,10 +261,27 @@ func (src *MachineHealthCheck) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.UnhealthyRange != nil {
 		dst.Spec.UnhealthyRange = restored.Spec.UnhealthyRange
 	}
+	dst.Spec.ControlPlaneNodeStartupTimeout = restored.Spec.ControlPlaneNodeStartupTimeout
+	dst.Spec.RemediationBackoff = restored.Spec.RemediationBackoff
+	dst.Status.LastRemediations = restored.Status.LastRemediations
+	restoreUnhealthyConditions(dst.Spec.UnhealthyConditions, restored.Spec.UnhealthyConditions)
 
 	return nil
 }
 
+// restoreUnhealthyConditions restores the StatusRegex of the MachineHealthCheck unhealthy conditions which have not
+// been changed while the object was stored using this API version.
+func restoreUnhealthyConditions(dst, restored []v1beta1.UnhealthyCondition) {
+	for i := range dst {
+		if i >= len(restored) || restored[i].StatusRegex == "" {
+			continue
+		}
+		if dst[i].Type == restored[i].Type && dst[i].Status == restored[i].Status {
+			dst[i].StatusRegex = restored[i].StatusRegex
+		}
+	}
+}
+
 func (dst *MachineHealthCheck) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.MachineHealthCheck)
 
@@ -291,15 +331,26 @@ func Convert_v1beta1_MachineHealthCheckSpec_To_v1alpha3_MachineHealthCheckSpec(i
 	return autoConvert_v1beta1_MachineHealthCheckSpec_To_v1alpha3_MachineHealthCheckSpec(in, out, s)
 }
 
+func Convert_v1beta1_MachineHealthCheckStatus_To_v1alpha3_MachineHealthCheckStatus(in *v1beta1.MachineHealthCheckStatus, out *MachineHealthCheckStatus, s apiconversion.Scope) error {
+	// NOTE: custom conversion func is required because status.lastRemediations does not exist in v1alpha3.
+	return autoConvert_v1beta1_MachineHealthCheckStatus_To_v1alpha3_MachineHealthCheckStatus(in, out, s)
+}
+
 func Convert_v1alpha3_ClusterStatus_To_v1beta1_ClusterStatus(in *ClusterStatus, out *v1beta1.ClusterStatus, s apiconversion.Scope) error {
 	return autoConvert_v1alpha3_ClusterStatus_To_v1beta1_ClusterStatus(in, out, s)
 }
 
+func Convert_v1beta1_ClusterStatus_To_v1alpha3_ClusterStatus(in *v1beta1.ClusterStatus, out *ClusterStatus, s apiconversion.Scope) error {
+	// NOTE: custom conversion func is required because status.Machines does not exists in v1alpha3
+	return autoConvert_v1beta1_ClusterStatus_To_v1alpha3_ClusterStatus(in, out, s)
+}
+
 func Convert_v1alpha3_ObjectMeta_To_v1beta1_ObjectMeta(in *ObjectMeta, out *v1beta1.ObjectMeta, s apiconversion.Scope) error {
 	return autoConvert_v1alpha3_ObjectMeta_To_v1beta1_ObjectMeta(in, out, s)
 }
 
 func Convert_v1beta1_MachineStatus_To_v1alpha3_MachineStatus(in *v1beta1.MachineStatus, out *MachineStatus, s apiconversion.Scope) error {
+	// MachineStatus.CertificatesExpiryDate, MachineStatus.Deletion and MachineStatus.InterruptibleInstance have been added with v1beta1.
 	return autoConvert_v1beta1_MachineStatus_To_v1alpha3_MachineStatus(in, out, s)
 }
 
@@ -312,3 +363,24 @@ func Convert_v1alpha3_MachineStatus_To_v1beta1_MachineStatus(in *MachineStatus,
 	// Status.version has been removed in v1beta1, thus requiring custom conversion function. the information will be dropped.
 	return autoConvert_v1alpha3_MachineStatus_To_v1beta1_MachineStatus(in, out, s)
 }
+
+func Convert_v1beta1_UnhealthyCondition_To_v1alpha3_UnhealthyCondition(in *v1beta1.UnhealthyCondition, out *UnhealthyCondition, s apiconversion.Scope) error {
+	// UnhealthyCondition.StatusRegex has been added with v1beta1.
+	return autoConvert_v1beta1_UnhealthyCondition_To_v1alpha3_UnhealthyCondition(in, out, s)
+}
+
+func Convert_v1beta1_MachineSpec_To_v1alpha3_MachineSpec(in *v1beta1.MachineSpec, out *MachineSpec, s apiconversion.Scope) error {
+	// MachineSpec.ReadinessGates, MachineSpec.NodeDrainOptions and MachineSpec.AddressPreference have been added with v1beta1.
+	return autoConvert_v1beta1_MachineSpec_To_v1alpha3_MachineSpec(in, out, s)
+}
+
+func Convert_v1beta1_MachineSetSpec_To_v1alpha3_MachineSetSpec(in *v1beta1.MachineSetSpec, out *MachineSetSpec, s apiconversion.Scope) error {
+	// MachineSetSpec.MachineNamingStrategy, MachineSetSpec.InfrastructureFailurePolicy and MachineSetSpec.InterruptionBudget have been added with v1beta1.
+	return autoConvert_v1beta1_MachineSetSpec_To_v1alpha3_MachineSetSpec(in, out, s)
+}
+
+func Convert_v1beta1_MachineDeploymentSpec_To_v1alpha3_MachineDeploymentSpec(in *v1beta1.MachineDeploymentSpec, out *MachineDeploymentSpec, s apiconversion.Scope) error {
+	// MachineDeploymentSpec.MachineNamingStrategy, MachineDeploymentSpec.RolloutAfter,
+	// MachineDeploymentSpec.InfrastructureFailurePolicy and MachineDeploymentSpec.InterruptionBudget have been added with v1beta1.
+	return autoConvert_v1beta1_MachineDeploymentSpec_To_v1alpha3_MachineDeploymentSpec(in, out, s)
+}
@@ -38,8 +38,30 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 
 	if restored.Spec.Topology != nil {
 		dst.Spec.Topology.Variables = restored.Spec.Topology.Variables
+		dst.Spec.Topology.ControlPlane.MachineHealthCheck = restored.Spec.Topology.ControlPlane.MachineHealthCheck
+
+		if restored.Spec.Topology.Workers != nil && dst.Spec.Topology.Workers != nil {
+			for i := range dst.Spec.Topology.Workers.MachineDeployments {
+				for _, restoredMD := range restored.Spec.Topology.Workers.MachineDeployments {
+					if dst.Spec.Topology.Workers.MachineDeployments[i].Name == restoredMD.Name {
+						dst.Spec.Topology.Workers.MachineDeployments[i].FailureDomain = restoredMD.FailureDomain
+						dst.Spec.Topology.Workers.MachineDeployments[i].MachineHealthCheck = restoredMD.MachineHealthCheck
+						dst.Spec.Topology.Workers.MachineDeployments[i].RolloutAfter = restoredMD.RolloutAfter
+					}
+				}
+			}
+		}
+
+		if restored.Spec.Topology.Workers != nil && len(restored.Spec.Topology.Workers.MachinePools) > 0 {
+			if dst.Spec.Topology.Workers == nil {
+				dst.Spec.Topology.Workers = &v1beta1.WorkersTopology{}
+			}
+			dst.Spec.Topology.Workers.MachinePools = restored.Spec.Topology.Workers.MachinePools
+		}
 	}
 
+	dst.Status.Machines = restored.Status.Machines
+
 	return nil
 }
 
@@ -83,8 +105,23 @@ func (src *ClusterClass) ConvertTo(dstRaw conversion.Hub) error {
 		return err
 	}
 
+	dst.Spec.Inherits = restored.Spec.Inherits
 	dst.Spec.Patches = restored.Spec.Patches
 	dst.Spec.Variables = restored.Spec.Variables
+	dst.Spec.VariablesDiscovery = restored.Spec.VariablesDiscovery
+	dst.Status = restored.Status
+	dst.Spec.ControlPlane.MachineHealthCheck = restored.Spec.ControlPlane.MachineHealthCheck
+
+	for i := range dst.Spec.Workers.MachineDeployments {
+		for _, restoredClass := range restored.Spec.Workers.MachineDeployments {
+			if dst.Spec.Workers.MachineDeployments[i].Class == restoredClass.Class {
+				dst.Spec.Workers.MachineDeployments[i].EnabledIf = restoredClass.EnabledIf
+				dst.Spec.Workers.MachineDeployments[i].MachineHealthCheck = restoredClass.MachineHealthCheck
+				dst.Spec.Workers.MachineDeployments[i].Constraints = restoredClass.Constraints
+			}
+		}
+	}
+	dst.Spec.Workers.MachinePools = restored.Spec.Workers.MachinePools
 
 	return nil
 }
@@ -119,13 +156,35 @@ func (dst *ClusterClassList) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *Machine) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.Machine)
 
-	return Convert_v1alpha4_Machine_To_v1beta1_Machine(src, dst, nil)
+	if err := Convert_v1alpha4_Machine_To_v1beta1_Machine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1beta1.Machine{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.ReadinessGates = restored.Spec.ReadinessGates
+	dst.Spec.NodeDrainOptions = restored.Spec.NodeDrainOptions
+	dst.Spec.AddressPreference = restored.Spec.AddressPreference
+	dst.Status.CertificatesExpiryDate = restored.Status.CertificatesExpiryDate
+	dst.Status.Deletion = restored.Status.Deletion
+	dst.Status.InterruptibleInstance = restored.Status.InterruptibleInstance
+
+	return nil
 }
 
 func (dst *Machine) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.Machine)
 
-	return Convert_v1beta1_Machine_To_v1alpha4_Machine(src, dst, nil)
+	if err := Convert_v1beta1_Machine_To_v1alpha4_Machine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *MachineList) ConvertTo(dstRaw conversion.Hub) error {
@@ -143,13 +202,37 @@ func (dst *MachineList) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *MachineSet) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.MachineSet)
 
-	return Convert_v1alpha4_MachineSet_To_v1beta1_MachineSet(src, dst, nil)
+	if err := Convert_v1alpha4_MachineSet_To_v1beta1_MachineSet(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1beta1.MachineSet{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.Template.Spec.ReadinessGates = restored.Spec.Template.Spec.ReadinessGates
+	dst.Spec.Template.Spec.NodeDrainOptions = restored.Spec.Template.Spec.NodeDrainOptions
+	dst.Spec.Template.Spec.AddressPreference = restored.Spec.Template.Spec.AddressPreference
+	dst.Spec.MachineNamingStrategy = restored.Spec.MachineNamingStrategy
+	dst.Spec.InfrastructureFailurePolicy = restored.Spec.InfrastructureFailurePolicy
+	dst.Spec.InterruptionBudget = restored.Spec.InterruptionBudget
+	dst.Status.InfrastructureFailureRetries = restored.Status.InfrastructureFailureRetries
+	dst.Status.LastInfrastructureFailureRetryTime = restored.Status.LastInfrastructureFailureRetryTime
+
+	return nil
 }
 
 func (dst *MachineSet) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.MachineSet)
 
-	return Convert_v1beta1_MachineSet_To_v1alpha4_MachineSet(src, dst, nil)
+	if err := Convert_v1beta1_MachineSet_To_v1alpha4_MachineSet(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *MachineSetList) ConvertTo(dstRaw conversion.Hub) error {
@@ -167,13 +250,37 @@ func (dst *MachineSetList) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.MachineDeployment)
 
-	return Convert_v1alpha4_MachineDeployment_To_v1beta1_MachineDeployment(src, dst, nil)
+	if err := Convert_v1alpha4_MachineDeployment_To_v1beta1_MachineDeployment(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1beta1.MachineDeployment{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.Template.Spec.ReadinessGates = restored.Spec.Template.Spec.ReadinessGates
+	dst.Spec.Template.Spec.NodeDrainOptions = restored.Spec.Template.Spec.NodeDrainOptions
+	dst.Spec.Template.Spec.AddressPreference = restored.Spec.Template.Spec.AddressPreference
+	dst.Spec.MachineNamingStrategy = restored.Spec.MachineNamingStrategy
+	dst.Spec.InfrastructureFailurePolicy = restored.Spec.InfrastructureFailurePolicy
+	dst.Spec.InterruptionBudget = restored.Spec.InterruptionBudget
+	dst.Spec.RolloutAfter = restored.Spec.RolloutAfter
+	dst.Status.InfrastructureFailureRetries = restored.Status.InfrastructureFailureRetries
+
+	return nil
 }
 
 func (dst *MachineDeployment) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.MachineDeployment)
 
-	return Convert_v1beta1_MachineDeployment_To_v1alpha4_MachineDeployment(src, dst, nil)
+	if err := Convert_v1beta1_MachineDeployment_To_v1alpha4_MachineDeployment(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *MachineDeploymentList) ConvertTo(dstRaw conversion.Hub) error {
@@ -191,13 +298,46 @@ func (dst *MachineDeploymentList) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *MachineHealthCheck) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.MachineHealthCheck)
 
-	return Convert_v1alpha4_MachineHealthCheck_To_v1beta1_MachineHealthCheck(src, dst, nil)
+	if err := Convert_v1alpha4_MachineHealthCheck_To_v1beta1_MachineHealthCheck(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1beta1.MachineHealthCheck{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.ControlPlaneNodeStartupTimeout = restored.Spec.ControlPlaneNodeStartupTimeout
+	dst.Spec.RemediationBackoff = restored.Spec.RemediationBackoff
+	dst.Status.LastRemediations = restored.Status.LastRemediations
+	restoreUnhealthyConditions(dst.Spec.UnhealthyConditions, restored.Spec.UnhealthyConditions)
+
+	return nil
+}
+
+// restoreUnhealthyConditions restores the StatusRegex of the MachineHealthCheck unhealthy conditions which have not
+// been changed while the object was stored using this API version.
+func restoreUnhealthyConditions(dst, restored []v1beta1.UnhealthyCondition) {
+	for i := range dst {
+		if i >= len(restored) || restored[i].StatusRegex == "" {
+			continue
+		}
+		if dst[i].Type == restored[i].Type && dst[i].Status == restored[i].Status {
+			dst[i].StatusRegex = restored[i].StatusRegex
+		}
+	}
 }
 
 func (dst *MachineHealthCheck) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.MachineHealthCheck)
 
-	return Convert_v1beta1_MachineHealthCheck_To_v1alpha4_MachineHealthCheck(src, dst, nil)
+	if err := Convert_v1beta1_MachineHealthCheck_To_v1alpha4_MachineHealthCheck(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *MachineHealthCheckList) ConvertTo(dstRaw conversion.Hub) error {
@@ -217,8 +357,13 @@ func Convert_v1alpha4_MachineStatus_To_v1beta1_MachineStatus(in *MachineStatus,
 	return autoConvert_v1alpha4_MachineStatus_To_v1beta1_MachineStatus(in, out, s)
 }
 
+func Convert_v1beta1_ClusterClass_To_v1alpha4_ClusterClass(in *v1beta1.ClusterClass, out *ClusterClass, s apiconversion.Scope) error {
+	// status has been added with v1beta1.
+	return autoConvert_v1beta1_ClusterClass_To_v1alpha4_ClusterClass(in, out, s)
+}
+
 func Convert_v1beta1_ClusterClassSpec_To_v1alpha4_ClusterClassSpec(in *v1beta1.ClusterClassSpec, out *ClusterClassSpec, s apiconversion.Scope) error {
-	// spec.{variables,patches} has been added with v1beta1.
+	// spec.{inherits,variables,patches} has been added with v1beta1.
 	return autoConvert_v1beta1_ClusterClassSpec_To_v1alpha4_ClusterClassSpec(in, out, s)
 }
 
@@ -226,3 +371,84 @@ func Convert_v1beta1_Topology_To_v1alpha4_Topology(in *v1beta1.Topology, out *To
 	// spec.topology.variables has been added with v1beta1.
 	return autoConvert_v1beta1_Topology_To_v1alpha4_Topology(in, out, s)
 }
+
+func Convert_v1beta1_ClusterStatus_To_v1alpha4_ClusterStatus(in *v1beta1.ClusterStatus, out *ClusterStatus, s apiconversion.Scope) error {
+	// ClusterStatus.Machines has been added with v1beta1.
+	return autoConvert_v1beta1_ClusterStatus_To_v1alpha4_ClusterStatus(in, out, s)
+}
+
+func Convert_v1beta1_MachineDeploymentTopology_To_v1alpha4_MachineDeploymentTopology(in *v1beta1.MachineDeploymentTopology, out *MachineDeploymentTopology, s apiconversion.Scope) error {
+	// MachineDeploymentTopology.{FailureDomain,MachineHealthCheck,RolloutAfter} have been added with v1beta1.
+	return autoConvert_v1beta1_MachineDeploymentTopology_To_v1alpha4_MachineDeploymentTopology(in, out, s)
+}
+
+func Convert_v1beta1_MachineDeploymentClass_To_v1alpha4_MachineDeploymentClass(in *v1beta1.MachineDeploymentClass, out *MachineDeploymentClass, s apiconversion.Scope) error {
+	// MachineDeploymentClass.{EnabledIf,MachineHealthCheck,Constraints} have been added with v1beta1.
+	return autoConvert_v1beta1_MachineDeploymentClass_To_v1alpha4_MachineDeploymentClass(in, out, s)
+}
+
+func Convert_v1beta1_WorkersClass_To_v1alpha4_WorkersClass(in *v1beta1.WorkersClass, out *WorkersClass, s apiconversion.Scope) error {
+	// WorkersClass.MachinePools has been added with v1beta1.
+	return autoConvert_v1beta1_WorkersClass_To_v1alpha4_WorkersClass(in, out, s)
+}
+
+func Convert_v1beta1_WorkersTopology_To_v1alpha4_WorkersTopology(in *v1beta1.WorkersTopology, out *WorkersTopology, s apiconversion.Scope) error {
+	// WorkersTopology.MachinePools has been added with v1beta1.
+	return autoConvert_v1beta1_WorkersTopology_To_v1alpha4_WorkersTopology(in, out, s)
+}
+
+func Convert_v1beta1_ControlPlaneClass_To_v1alpha4_ControlPlaneClass(in *v1beta1.ControlPlaneClass, out *ControlPlaneClass, s apiconversion.Scope) error {
+	// ControlPlaneClass.MachineHealthCheck has been added with v1beta1.
+	return autoConvert_v1beta1_ControlPlaneClass_To_v1alpha4_ControlPlaneClass(in, out, s)
+}
+
+func Convert_v1beta1_ControlPlaneTopology_To_v1alpha4_ControlPlaneTopology(in *v1beta1.ControlPlaneTopology, out *ControlPlaneTopology, s apiconversion.Scope) error {
+	// ControlPlaneTopology.MachineHealthCheck has been added with v1beta1.
+	return autoConvert_v1beta1_ControlPlaneTopology_To_v1alpha4_ControlPlaneTopology(in, out, s)
+}
+
+func Convert_v1beta1_UnhealthyCondition_To_v1alpha4_UnhealthyCondition(in *v1beta1.UnhealthyCondition, out *UnhealthyCondition, s apiconversion.Scope) error {
+	// UnhealthyCondition.StatusRegex has been added with v1beta1.
+	return autoConvert_v1beta1_UnhealthyCondition_To_v1alpha4_UnhealthyCondition(in, out, s)
+}
+
+func Convert_v1beta1_MachineStatus_To_v1alpha4_MachineStatus(in *v1beta1.MachineStatus, out *MachineStatus, s apiconversion.Scope) error {
+	// MachineStatus.CertificatesExpiryDate, MachineStatus.Deletion and MachineStatus.InterruptibleInstance have been added with v1beta1.
+	return autoConvert_v1beta1_MachineStatus_To_v1alpha4_MachineStatus(in, out, s)
+}
+
+func Convert_v1beta1_MachineSpec_To_v1alpha4_MachineSpec(in *v1beta1.MachineSpec, out *MachineSpec, s apiconversion.Scope) error {
+	// MachineSpec.ReadinessGates, MachineSpec.NodeDrainOptions and MachineSpec.AddressPreference have been added with v1beta1.
+	return autoConvert_v1beta1_MachineSpec_To_v1alpha4_MachineSpec(in, out, s)
+}
+
+func Convert_v1beta1_MachineSetSpec_To_v1alpha4_MachineSetSpec(in *v1beta1.MachineSetSpec, out *MachineSetSpec, s apiconversion.Scope) error {
+	// MachineSetSpec.MachineNamingStrategy, MachineSetSpec.InfrastructureFailurePolicy and MachineSetSpec.InterruptionBudget have been added with v1beta1.
+	return autoConvert_v1beta1_MachineSetSpec_To_v1alpha4_MachineSetSpec(in, out, s)
+}
+
+func Convert_v1beta1_MachineSetStatus_To_v1alpha4_MachineSetStatus(in *v1beta1.MachineSetStatus, out *MachineSetStatus, s apiconversion.Scope) error {
+	// MachineSetStatus.InfrastructureFailureRetries and MachineSetStatus.LastInfrastructureFailureRetryTime have been added with v1beta1.
+	return autoConvert_v1beta1_MachineSetStatus_To_v1alpha4_MachineSetStatus(in, out, s)
+}
+
+func Convert_v1beta1_MachineDeploymentStatus_To_v1alpha4_MachineDeploymentStatus(in *v1beta1.MachineDeploymentStatus, out *MachineDeploymentStatus, s apiconversion.Scope) error {
+	// MachineDeploymentStatus.InfrastructureFailureRetries has been added with v1beta1.
+	return autoConvert_v1beta1_MachineDeploymentStatus_To_v1alpha4_MachineDeploymentStatus(in, out, s)
+}
+
+func Convert_v1beta1_MachineDeploymentSpec_To_v1alpha4_MachineDeploymentSpec(in *v1beta1.MachineDeploymentSpec, out *MachineDeploymentSpec, s apiconversion.Scope) error {
+	// MachineDeploymentSpec.MachineNamingStrategy, MachineDeploymentSpec.RolloutAfter,
+	// MachineDeploymentSpec.InfrastructureFailurePolicy and MachineDeploymentSpec.InterruptionBudget have been added with v1beta1.
+	return autoConvert_v1beta1_MachineDeploymentSpec_To_v1alpha4_MachineDeploymentSpec(in, out, s)
+}
+
+func Convert_v1beta1_MachineHealthCheckSpec_To_v1alpha4_MachineHealthCheckSpec(in *v1beta1.MachineHealthCheckSpec, out *MachineHealthCheckSpec, s apiconversion.Scope) error {
+	// NOTE: custom conversion func is required because spec.controlPlaneNodeStartupTimeout and spec.remediationBackoff do not exist in v1alpha4.
+	return autoConvert_v1beta1_MachineHealthCheckSpec_To_v1alpha4_MachineHealthCheckSpec(in, out, s)
+}
+
+func Convert_v1beta1_MachineHealthCheckStatus_To_v1alpha4_MachineHealthCheckStatus(in *v1beta1.MachineHealthCheckStatus, out *MachineHealthCheckStatus, s apiconversion.Scope) error {
+	// NOTE: custom conversion func is required because status.lastRemediations does not exist in v1alpha4.
+	return autoConvert_v1beta1_MachineHealthCheckStatus_To_v1alpha4_MachineHealthCheckStatus(in, out, s)
+}
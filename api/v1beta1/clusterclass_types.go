@@ -20,11 +20,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=clusterclasses,shortName=cc,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of ClusterClass"
 
 // ClusterClass is a template which can be used to create managed topologies.
@@ -32,11 +34,22 @@ type ClusterClass struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ClusterClassSpec `json:"spec,omitempty"`
+	Spec   ClusterClassSpec   `json:"spec,omitempty"`
+	Status ClusterClassStatus `json:"status,omitempty"`
 }
 
 // ClusterClassSpec describes the desired state of the ClusterClass.
 type ClusterClassSpec struct {
+	// Inherits is the name of a ClusterClass in the same namespace this ClusterClass is composed from.
+	// The fields of this ClusterClass take precedence over the ones of the inherited ClusterClass:
+	// the infrastructure and control plane templates, the control plane machine infrastructure and the
+	// MachineHealthChecks replace the inherited ones when set, control plane metadata is merged,
+	// MachineDeployment classes and variables replace the inherited ones with the same name, and
+	// patches are applied after the inherited patches, replacing the inherited patches with the same name.
+	// NOTE: This field is experimental and may change in future releases.
+	// +optional
+	Inherits string `json:"inherits,omitempty"`
+
 	// Infrastructure is a reference to a provider-specific template that holds
 	// the details for provisioning infrastructure specific cluster
 	// for the underlying provider.
@@ -61,6 +74,14 @@ type ClusterClassSpec struct {
 	// +optional
 	Variables []ClusterClassVariable `json:"variables,omitempty"`
 
+	// VariablesDiscovery defines an external DiscoverVariables hook, which is called to discover
+	// additional variables, e.g. variables whose schema depends on the infrastructure like the
+	// instance types available in a region.
+	// Discovered variables are merged with the variables defined in .spec.variables, which take
+	// precedence, and reported in .status.variables.
+	// +optional
+	VariablesDiscovery *VariablesDiscovery `json:"variablesDiscovery,omitempty"`
+
 	// Patches defines the patches which are applied to customize
 	// referenced templates of a ClusterClass.
 	// Note: Patches will be applied in the order of the array.
@@ -89,6 +110,12 @@ type ControlPlaneClass struct {
 	//
 	// +optional
 	MachineInfrastructure *LocalObjectTemplate `json:"machineInfrastructure,omitempty"`
+
+	// MachineHealthCheck defines a MachineHealthCheck for this ControlPlaneClass.
+	// This field is supported if and only if the ControlPlane provider template
+	// referenced above is Machine based and supports setting replicas.
+	// +optional
+	MachineHealthCheck *MachineHealthCheckClass `json:"machineHealthCheck,omitempty"`
 }
 
 // WorkersClass is a collection of deployment classes.
@@ -97,6 +124,12 @@ type WorkersClass struct {
 	// a set of worker nodes.
 	// +optional
 	MachineDeployments []MachineDeploymentClass `json:"machineDeployments,omitempty"`
+
+	// MachinePools is a list of machine pool classes that can be used to create
+	// a set of worker nodes.
+	// NOTE: This field is considered only if the MachinePool feature flag is enabled.
+	// +optional
+	MachinePools []MachinePoolClass `json:"machinePools,omitempty"`
 }
 
 // MachineDeploymentClass serves as a template to define a set of worker nodes of the cluster
@@ -107,9 +140,96 @@ type MachineDeploymentClass struct {
 	// in the Cluster to create a managed MachineDeployment.
 	Class string `json:"class"`
 
+	// EnabledIf is a Go template to be used to calculate if MachineDeployments of this class should be created.
+	// It can reference variables defined in .spec.variables and builtin variables of the Cluster,
+	// e.g. {{ .gpu }} or {{ .builtin.cluster.name }}.
+	// MachineDeployments of this class are created only if the template evaluates to `true`, otherwise they are
+	// omitted (and deleted if already existing); if EnabledIf is not set, MachineDeployments of this class are always created.
+	// +optional
+	EnabledIf *string `json:"enabledIf,omitempty"`
+
 	// Template is a local struct containing a collection of templates for creation of
 	// MachineDeployment objects representing a set of worker nodes.
 	Template MachineDeploymentClassTemplate `json:"template"`
+
+	// MachineHealthCheck defines a MachineHealthCheck for this MachineDeploymentClass.
+	// +optional
+	MachineHealthCheck *MachineHealthCheckClass `json:"machineHealthCheck,omitempty"`
+
+	// Constraints defines the constraints MachineDeployments of this class must satisfy,
+	// which are enforced when validating the Cluster topology.
+	// +optional
+	Constraints *MachineDeploymentClassConstraints `json:"constraints,omitempty"`
+}
+
+// MachineDeploymentClassConstraints defines the constraints for the MachineDeployments of a MachineDeploymentClass.
+type MachineDeploymentClassConstraints struct {
+	// MinReplicas is the minimum number of replicas which can be set in the Cluster topology
+	// for MachineDeployments of this class.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas is the maximum number of replicas which can be set in the Cluster topology
+	// for MachineDeployments of this class.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// MinVersion is the minimum Kubernetes version of Clusters using MachineDeployments of this class, e.g. v1.21.0.
+	// +optional
+	MinVersion *string `json:"minVersion,omitempty"`
+
+	// MaxVersion is the maximum Kubernetes version of Clusters using MachineDeployments of this class, e.g. v1.22.99.
+	// +optional
+	MaxVersion *string `json:"maxVersion,omitempty"`
+}
+
+// MachineHealthCheckClass defines a MachineHealthCheck for a group of Machines.
+type MachineHealthCheckClass struct {
+	// UnhealthyConditions contains a list of the conditions that determine
+	// whether a node is considered unhealthy. The conditions are combined in a
+	// logical OR, i.e. if any of the conditions is met, the node is unhealthy.
+	// +optional
+	UnhealthyConditions []UnhealthyCondition `json:"unhealthyConditions,omitempty"`
+
+	// Any further remediation is only allowed if at most "MaxUnhealthy" machines selected by
+	// "selector" are not healthy.
+	// +optional
+	MaxUnhealthy *intstr.IntOrString `json:"maxUnhealthy,omitempty"`
+
+	// Any further remediation is only allowed if the number of machines selected by "selector" as not healthy
+	// is within the range of "UnhealthyRange". Takes precedence over MaxUnhealthy.
+	// Eg. "[3-5]" - This means that remediation will be allowed only when:
+	// (a) there are at least 3 unhealthy machines (and)
+	// (b) there are at most 5 unhealthy machines
+	// +optional
+	// +kubebuilder:validation:Pattern=^\[[0-9]+-[0-9]+\]$
+	UnhealthyRange *string `json:"unhealthyRange,omitempty"`
+
+	// Machines older than this duration without a node will be considered to have
+	// failed and will be remediated.
+	// If you wish to disable this feature, set the value explicitly to 0.
+	// +optional
+	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
+
+	// RemediationTemplate is a reference to a remediation template
+	// provided by an infrastructure provider.
+	//
+	// This field is completely optional, when filled, the MachineHealthCheck controller
+	// creates a new object from the template referenced and hands off remediation of the machine to
+	// a controller that lives outside of Cluster API.
+	// +optional
+	RemediationTemplate *corev1.ObjectReference `json:"remediationTemplate,omitempty"`
+}
+
+// IsZero returns true if none of the fields of the MachineHealthCheckClass is set.
+func (m MachineHealthCheckClass) IsZero() bool {
+	return len(m.UnhealthyConditions) == 0 &&
+		m.MaxUnhealthy == nil &&
+		m.UnhealthyRange == nil &&
+		m.NodeStartupTimeout == nil &&
+		m.RemediationTemplate == nil
 }
 
 // MachineDeploymentClassTemplate defines how a MachineDeployment generated from a MachineDeploymentClass
@@ -117,6 +237,11 @@ type MachineDeploymentClass struct {
 type MachineDeploymentClassTemplate struct {
 	// Metadata is the metadata applied to the machines of the MachineDeployment.
 	// At runtime this metadata is merged with the corresponding metadata from the topology.
+	//
+	// Label and annotation values can include template tokens in the Go template syntax that
+	// are expanded by the topology controller; the supported tokens are {{ .cluster.name }},
+	// {{ .cluster.namespace }}, {{ .machineDeployment.topologyName }}, {{ .machineDeployment.class }}
+	// and {{ .machineDeployment.failureDomain }}.
 	// +optional
 	Metadata ObjectMeta `json:"metadata,omitempty"`
 
@@ -129,6 +254,36 @@ type MachineDeploymentClassTemplate struct {
 	Infrastructure LocalObjectTemplate `json:"infrastructure"`
 }
 
+// MachinePoolClass serves as a template to define a pool of worker nodes of the cluster
+// provisioned using the `ClusterClass`.
+type MachinePoolClass struct {
+	// Class denotes a type of machine pool present in the cluster,
+	// this name MUST be unique within a ClusterClass and can be referenced
+	// in the Cluster to create a managed MachinePool.
+	Class string `json:"class"`
+
+	// Template is a local struct containing a collection of templates for creation of
+	// MachinePool objects representing a pool of worker nodes.
+	Template MachinePoolClassTemplate `json:"template"`
+}
+
+// MachinePoolClassTemplate defines how a MachinePool generated from a MachinePoolClass
+// should look like.
+type MachinePoolClassTemplate struct {
+	// Metadata is the metadata applied to the MachinePool.
+	// At runtime this metadata is merged with the corresponding metadata from the topology.
+	// +optional
+	Metadata ObjectMeta `json:"metadata,omitempty"`
+
+	// Bootstrap contains the bootstrap template reference to be used
+	// for the creation of the bootstrap config of the MachinePool.
+	Bootstrap LocalObjectTemplate `json:"bootstrap"`
+
+	// Infrastructure contains the infrastructure machine pool template reference to be used
+	// for the creation of the infrastructure machine pool of the MachinePool.
+	Infrastructure LocalObjectTemplate `json:"infrastructure"`
+}
+
 // ClusterClassVariable defines a variable which can
 // be configured in the Cluster topology and used in patches.
 type ClusterClassVariable struct {
@@ -212,11 +367,39 @@ type JSONSchemaProps struct {
 	Default *apiextensionsv1.JSON `json:"default,omitempty"`
 }
 
+// VariablesDiscovery defines an external DiscoverVariables hook.
+type VariablesDiscovery struct {
+	// URL of the DiscoverVariables hook; it must use the https scheme.
+	// The hook receives a DiscoverVariablesRequest and must return a DiscoverVariablesResponse,
+	// as defined in the sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1 package.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle which is used to validate the certificate of the hook.
+	// If unspecified, the system trust roots are used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// TimeoutSeconds is the timeout for calls to the hook.
+	// Defaults to 10 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+}
+
 // ClusterClassPatch defines a patch which is applied to customize the referenced templates.
 type ClusterClassPatch struct {
 	// Name of the patch.
 	Name string `json:"name"`
 
+	// EnabledIf is a Go template to be used to calculate if the patch should be applied.
+	// It can reference variables defined in .spec.variables and builtin variables of the Cluster,
+	// e.g. {{ .gpu }} or {{ .builtin.cluster.name }}.
+	// The patch is applied only if the template evaluates to `true`; if EnabledIf is not set, the patch is always applied.
+	// +optional
+	EnabledIf *string `json:"enabledIf,omitempty"`
+
 	// Definitions define the patches inline.
 	// Note: Patches will be applied in the order of the array.
 	Definitions []PatchDefinition `json:"definitions"`
@@ -321,6 +504,75 @@ type JSONPatchValue struct {
 	Template *string `json:"template,omitempty"`
 }
 
+// VariableDefinitionFromInline is the value of ClusterClassStatusVariable.From for
+// variables defined inline in the ClusterClass spec.
+const VariableDefinitionFromInline = "inline"
+
+// VariableDefinitionFromInherited is the value of ClusterClassStatusVariable.From for
+// variables defined in a ClusterClass inherited by the ClusterClass.
+const VariableDefinitionFromInherited = "inherited"
+
+// VariableDefinitionFromDiscovered is the value of ClusterClassStatusVariable.From for
+// variables discovered via the DiscoverVariables hook defined in .spec.variablesDiscovery.
+const VariableDefinitionFromDiscovered = "discovered"
+
+// DiscoverVariablesHook is the value of ClusterClassStatusExtension.Hook for
+// the DiscoverVariables hook defined in .spec.variablesDiscovery.
+const DiscoverVariablesHook = "DiscoverVariables"
+
+// ClusterClassStatus defines the observed state of the ClusterClass.
+type ClusterClassStatus struct {
+	// Variables is a list of the variables which can be configured in
+	// the topology of Clusters using the ClusterClass.
+	// +optional
+	Variables []ClusterClassStatusVariable `json:"variables,omitempty"`
+
+	// Extensions is a list of the external extensions called for Clusters
+	// using the ClusterClass, including the ones defined in inherited ClusterClasses.
+	// +optional
+	Extensions []ClusterClassStatusExtension `json:"extensions,omitempty"`
+
+	// Conditions defines current observed state of the ClusterClass.
+	// +optional
+	Conditions Conditions `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ClusterClassStatusVariable defines a variable discovered for a ClusterClass.
+type ClusterClassStatusVariable struct {
+	// Name of the variable.
+	Name string `json:"name"`
+
+	// From specifies where the variable has been defined, e.g.
+	// "inline" for variables defined in the ClusterClass spec, "inherited"
+	// for variables defined in an inherited ClusterClass or "discovered"
+	// for variables discovered via the DiscoverVariables hook.
+	From string `json:"from"`
+
+	// Required specifies if the variable is required.
+	Required bool `json:"required"`
+
+	// Schema defines the schema of the variable.
+	Schema VariableSchema `json:"schema"`
+}
+
+// ClusterClassStatusExtension defines an external extension resolved for a ClusterClass.
+type ClusterClassStatusExtension struct {
+	// Hook is the name of the hook implemented by the extension, e.g. "DiscoverVariables".
+	Hook string `json:"hook"`
+
+	// URL is the URL used to call the extension.
+	URL string `json:"url"`
+
+	// From specifies where the extension has been defined, e.g.
+	// "inline" for extensions defined in the ClusterClass spec or "inherited"
+	// for extensions defined in an inherited ClusterClass.
+	From string `json:"from"`
+}
+
 // LocalObjectTemplate defines a template for a topology Class.
 type LocalObjectTemplate struct {
 	// Ref is a required reference to a custom resource
@@ -328,6 +580,16 @@ type LocalObjectTemplate struct {
 	Ref *corev1.ObjectReference `json:"ref"`
 }
 
+// GetConditions returns the set of conditions for this object.
+func (c *ClusterClass) GetConditions() Conditions {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (c *ClusterClass) SetConditions(conditions Conditions) {
+	c.Status.Conditions = conditions
+}
+
 // +kubebuilder:object:root=true
 
 // ClusterClassList contains a list of Cluster.
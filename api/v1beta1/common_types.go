@@ -33,6 +33,10 @@ const (
 	// to track the name of the MachineDeployment topology it represents.
 	ClusterTopologyMachineDeploymentLabelName = "topology.cluster.x-k8s.io/deployment-name"
 
+	// ClusterTopologyMachinePoolLabelName is the label set on the generated MachinePool objects
+	// to track the name of the MachinePool topology it represents.
+	ClusterTopologyMachinePoolLabelName = "topology.cluster.x-k8s.io/pool-name"
+
 	// ProviderLabelName is the label set on components in the provider manifest.
 	// This label allows to easily identify all the components belonging to a provider; the clusterctl
 	// tool uses this label for implementing provider's lifecycle operations.
@@ -86,12 +90,41 @@ const (
 	// MachineSkipRemediationAnnotation is the annotation used to mark the machines that should not be considered for remediation by MachineHealthCheck reconciler.
 	MachineSkipRemediationAnnotation = "cluster.x-k8s.io/skip-remediation"
 
+	// AdoptNodeAnnotation is an annotation that can be applied to a Machine to adopt an existing Node, identified by
+	// the annotation value, into Cluster API management. The provider ID of the Node is used to backfill
+	// Spec.ProviderID on both the Machine and the InfrastructureMachine, thus allowing to migrate Nodes of
+	// clusters not created by Cluster API.
+	AdoptNodeAnnotation = "cluster.x-k8s.io/adopt-node"
+
+	// LastReconcileAnnotation is the annotation set by core controllers on the objects they reconcile, recording
+	// the version of the controller and the outcome of the last reconcile, e.g. "version=v1.0.0,result=Error,error=Conflict".
+	// The annotation is updated only when its value changes, so it does not cause additional writes on steady state.
+	LastReconcileAnnotation = "cluster.x-k8s.io/last-reconcile"
+
+	// MachineCertificatesExpiryDateAnnotation annotation specifies the expiry date of the machine certificates in RFC3339 format.
+	// This annotation can be used on control plane machines to trigger rollout before the certificates expire.
+	// This annotation can be set on BootstrapConfig or Machine objects. The value set on the Machine object takes precedence.
+	// This annotation can only be used on Control Plane Machines.
+	MachineCertificatesExpiryDateAnnotation = "machine.cluster.x-k8s.io/certificates-expiry"
+
 	// ClusterSecretType defines the type of secret created by core components.
 	ClusterSecretType corev1.SecretType = "cluster.x-k8s.io/secret" //nolint:gosec
 
 	// InterruptibleLabel is the label used to mark the nodes that run on interruptible instances.
 	InterruptibleLabel = "cluster.x-k8s.io/interruptible"
 
+	// NonInterruptibleInstanceAnnotation is set by MachineSets on the Machines created once their InterruptionBudget
+	// is reached, and propagated to the infrastructure machines; infrastructure providers supporting interruptible
+	// instances must provision a non-interruptible instance for infrastructure machines with this annotation.
+	NonInterruptibleInstanceAnnotation = "cluster.x-k8s.io/non-interruptible-instance"
+
+	// DrainExcludeWaitLabel is the label used to mark the Pods which are evicted without waiting for them to be deleted
+	// when the Machine controller drains a node, e.g. Pods which are able to terminate gracefully together with the node.
+	DrainExcludeWaitLabel = "cluster.x-k8s.io/drain-exclude-wait"
+
+	// NodeUninitializedTaintKey is the key of the NodeUninitializedTaint.
+	NodeUninitializedTaintKey = "node.cluster.x-k8s.io/uninitialized"
+
 	// ManagedByAnnotation is an annotation that can be applied to InfraCluster resources to signify that
 	// some external system is managing the cluster infrastructure.
 	//
@@ -101,6 +134,15 @@ const (
 	ManagedByAnnotation = "cluster.x-k8s.io/managed-by"
 )
 
+// NodeUninitializedTaint can be added to Nodes at creation via the bootstrap configuration, e.g. by adding it to
+// the KubeadmConfig nodeRegistration taints, to prevent workloads from being scheduled on Nodes before the Machine
+// controller has completed their setup. The Machine controller removes the taint once the Node annotations and,
+// if required, the interruptible label have been set.
+var NodeUninitializedTaint = corev1.Taint{
+	Key:    NodeUninitializedTaintKey,
+	Effect: corev1.TaintEffectNoSchedule,
+}
+
 const (
 	// TemplateSuffix is the object kind suffix used by template types.
 	TemplateSuffix = "Template"
@@ -135,6 +177,32 @@ type MachineAddress struct {
 // MachineAddresses is a slice of MachineAddress items to be used by infrastructure providers.
 type MachineAddresses []MachineAddress
 
+// MachineAddressIPFamily describes the IP family of a MachineAddress.
+// +kubebuilder:validation:Enum=IPv4;IPv6
+type MachineAddressIPFamily string
+
+// Define the MachineAddressIPFamily constants.
+const (
+	MachineAddressIPv4Family MachineAddressIPFamily = "IPv4"
+	MachineAddressIPv6Family MachineAddressIPFamily = "IPv6"
+)
+
+// MachineNamingStrategy defines the naming strategy for the Machines created by a MachineSet or a control plane.
+type MachineNamingStrategy struct {
+	// Template is a Go template used to generate the names of the Machines, which is also used for
+	// their infrastructure and bootstrap objects. The template can reference:
+	// * `.cluster.name`: the name of the Cluster.
+	// * `.machineSet.name`: the name of the MachineSet, for MachineSets and MachineDeployments.
+	// * `.kubeadmControlPlane.name`: the name of the KubeadmControlPlane, for KubeadmControlPlanes.
+	// * `.random`: a random alphanumeric string of 5 characters.
+	// * `.index`: the lowest non-negative integer for which the generated name is not used by other Machines
+	//   of the same Cluster.
+	// The template must reference `.random` or `.index`, and the generated names must be valid DNS labels,
+	// i.e. at most 63 characters.
+	// +kubebuilder:validation:MinLength=1
+	Template string `json:"template"`
+}
+
 // ObjectMeta is metadata that all persisted resources must have, which includes all objects
 // users must create. This is a copy of customizable fields from metav1.ObjectMeta.
 //
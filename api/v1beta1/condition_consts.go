@@ -53,6 +53,32 @@ const (
 	WaitingForInfrastructureFallbackReason = "WaitingForInfrastructure"
 )
 
+const (
+	// NoTerminalFailureCondition documents the absence of terminal failures for a Cluster, Machine or MachineSet.
+	// This condition mirrors the legacy status.failureReason and status.failureMessage fields, so consumers can
+	// migrate to conditions only before those fields are removed; the legacy fields are in turn set from this condition
+	// when a provider or a user reports a terminal failure using the condition only.
+	NoTerminalFailureCondition ConditionType = "NoTerminalFailure"
+
+	// TerminalFailureReason (Severity=Error) documents a terminal failure reported with a failure message only,
+	// without a failure reason.
+	TerminalFailureReason = "TerminalFailure"
+)
+
+const (
+	// PausedCondition is true when the reconciliation of a Cluster API object is paused, either because the
+	// Cluster it belongs to has spec.paused set or because the object has the `cluster.x-k8s.io/paused` annotation.
+	// The condition is removed as soon as reconciliation resumes.
+	PausedCondition ConditionType = "Paused"
+
+	// ClusterPausedReason documents an object whose reconciliation is paused because the Cluster is paused.
+	ClusterPausedReason = "ClusterPaused"
+
+	// PausedAnnotationReason documents an object whose reconciliation is paused because the object
+	// has the `cluster.x-k8s.io/paused` annotation.
+	PausedAnnotationReason = "PausedAnnotation"
+)
+
 // ANCHOR_END: CommonConditions
 
 // Conditions and condition Reasons for the Cluster object.
@@ -89,6 +115,65 @@ const (
 	// NOTE: Having the control plane machine available is a pre-condition for joining additional control planes
 	// or workers nodes.
 	WaitingForControlPlaneAvailableReason = "WaitingForControlPlaneAvailable"
+
+	// KubeconfigCertificateValidCondition reports on the validity of the client certificate of the Kubeconfig generated
+	// for the Cluster; when true, the condition message documents the number of days to the certificate expiry.
+	KubeconfigCertificateValidCondition ConditionType = "KubeconfigCertificateValid"
+
+	// KubeconfigCertificateExpiredReason (Severity=Error) documents a Cluster whose Kubeconfig client certificate is expired.
+	KubeconfigCertificateExpiredReason = "KubeconfigCertificateExpired"
+
+	// KubeconfigCertificateRotationFailedReason (Severity=Warning) documents a Cluster controller failing to rotate
+	// the Kubeconfig client certificate before its expiry.
+	KubeconfigCertificateRotationFailedReason = "KubeconfigCertificateRotationFailed"
+
+	// ControlPlaneEndpointReachableCondition reports the result of the periodic probing of the Cluster control plane
+	// endpoint from the management cluster; the endpoint is probed only after the control plane is initialized.
+	ControlPlaneEndpointReachableCondition ConditionType = "ControlPlaneEndpointReachable"
+
+	// ControlPlaneEndpointNotResolvableReason (Severity=Warning) documents a Cluster whose control plane endpoint host
+	// can't be resolved by the DNS, as seen from the management cluster.
+	ControlPlaneEndpointNotResolvableReason = "ControlPlaneEndpointNotResolvable"
+
+	// ControlPlaneEndpointUnreachableReason (Severity=Warning) documents a Cluster whose control plane endpoint is not
+	// accepting connections, as seen from the management cluster, e.g. because of a load balancer misconfiguration.
+	ControlPlaneEndpointUnreachableReason = "ControlPlaneEndpointUnreachable"
+
+	// WorkersDeletedCondition reports on the first phase of the Cluster deletion, when the worker Machines are deleted
+	// together with the MachineDeployments, MachineSets and MachinePools managing them.
+	WorkersDeletedCondition ConditionType = "WorkersDeleted"
+
+	// ControlPlaneDeletedCondition reports on the second phase of the Cluster deletion, when the control plane object,
+	// or the control plane Machines if there is no control plane provider, are deleted.
+	ControlPlaneDeletedCondition ConditionType = "ControlPlaneDeleted"
+
+	// InfrastructureDeletedCondition reports on the last phase of the Cluster deletion, when the infrastructure
+	// object is deleted.
+	InfrastructureDeletedCondition ConditionType = "InfrastructureDeleted"
+
+	// DeletionTimedOutReason (Severity=Warning) documents a phase of the Cluster deletion not completed within the
+	// configured timeout; the Cluster deletion moves on with the next phase while the deletion of the remaining
+	// objects continues in the background, except for the infrastructure phase, which keeps waiting.
+	DeletionTimedOutReason = "DeletionTimedOut"
+)
+
+// Conditions and condition Reasons for Clusters with a managed Topology.
+const (
+	// TopologyReconciledCondition provides evidence about the reconciliation of a Cluster topology into
+	// the managed objects of the Cluster.
+	// Status false means that for any reason, the values defined in Cluster.spec.topology are not yet applied to
+	// managed objects on the Cluster; status true means that Cluster.spec.topology have been applied to
+	// the objects in the Cluster (but this does not imply those objects are already reconciled to the spec provided).
+	TopologyReconciledCondition ConditionType = "TopologyReconciled"
+
+	// TopologyReconcileFailedReason (Severity=Error) documents the reconciliation of a Cluster topology
+	// failing due to an error.
+	TopologyReconcileFailedReason = "TopologyReconcileFailed"
+
+	// TopologyReconciledUpgradePendingReason (Severity=Info) documents reconciliation of a Cluster topology
+	// not yet completed because the upgrade of the control plane or of some MachineDeployments is on hold,
+	// waiting for the upgrade or the rollout of other objects to complete.
+	TopologyReconciledUpgradePendingReason = "UpgradePending"
 )
 
 // Conditions and condition Reasons for the Machine object.
@@ -105,6 +190,15 @@ const (
 	// NOTE: This reason is used only as a fallback when the bootstrap object is not reporting its own ready condition.
 	WaitingForDataSecretFallbackReason = "WaitingForDataSecret"
 
+	// BootstrapSucceededCondition reports whether the bootstrap data has been successfully executed on the machine.
+	// This condition is set to false when the infrastructure or the bootstrap provider reports a bootstrap failure,
+	// and set back to true once the machine's Node has been found.
+	BootstrapSucceededCondition ConditionType = "BootstrapSucceeded"
+
+	// BootstrapFailedReason (Severity=Error) documents a machine for which the infrastructure or the bootstrap provider
+	// reported a failure while executing the bootstrap data, e.g. a cloud-init script exiting with an error.
+	BootstrapFailedReason = "BootstrapFailed"
+
 	// DrainingSucceededCondition provide evidence of the status of the node drain operation which happens during the machine
 	// deletion process.
 	DrainingSucceededCondition ConditionType = "DrainingSucceeded"
@@ -124,11 +218,23 @@ const (
 	// WaitingExternalHookReason (Severity=Info) provide evidence that we are waiting for an external hook to complete.
 	WaitingExternalHookReason = "WaitingExternalHook"
 
+	// CordonOnlyReleasedCondition reports a machine with the CordonOnlyAnnotation waiting, after cordoning and
+	// draining the node, for the annotation to be removed before deleting the machine infrastructure.
+	CordonOnlyReleasedCondition ConditionType = "CordonOnlyReleased"
+
+	// WaitingForCordonOnlyReleaseReason (Severity=Info) documents a machine being deleted whose infrastructure is left
+	// intact, after cordoning and draining the node, until the CordonOnlyAnnotation is removed.
+	WaitingForCordonOnlyReleaseReason = "WaitingForCordonOnlyRelease"
+
 	// VolumeDetachSucceededCondition reports a machine waiting for volumes to be detached.
 	VolumeDetachSucceededCondition ConditionType = "VolumeDetachSucceeded"
 
 	// WaitingForVolumeDetachReason (Severity=Info) provide evidence that a machine node waiting for volumes to be attached.
 	WaitingForVolumeDetachReason = "WaitingForVolumeDetach"
+
+	// ReadinessGatesNotReadyReason (Severity=Info) documents a machine's Ready condition not in Status=True because
+	// at least one of the conditions listed in the machine's readiness gates is not True.
+	ReadinessGatesNotReadyReason = "ReadinessGatesNotReady"
 )
 
 const (
@@ -194,6 +300,55 @@ const (
 
 	// NodeConditionsFailedReason (Severity=Warning) documents a node is not in a healthy state due to the failed state of at least 1 Kubelet condition.
 	NodeConditionsFailedReason = "NodeConditionsFailed"
+
+	// MachineNodeReadyCondition mirrors the Ready condition of the Kubernetes node hosted on the machine.
+	MachineNodeReadyCondition ConditionType = "NodeReady"
+
+	// MachineNodeMemoryAvailableCondition mirrors the MemoryPressure condition of the Kubernetes node hosted on the machine;
+	// it is set to True when the node does not report memory pressure.
+	MachineNodeMemoryAvailableCondition ConditionType = "NodeMemoryAvailable"
+
+	// MachineNodeDiskAvailableCondition mirrors the DiskPressure condition of the Kubernetes node hosted on the machine;
+	// it is set to True when the node does not report disk pressure.
+	MachineNodeDiskAvailableCondition ConditionType = "NodeDiskAvailable"
+
+	// NodeConditionUnknownReason (Severity=Info) documents a node condition mirrored on the machine which is unknown
+	// or not reported by the Kubelet yet.
+	NodeConditionUnknownReason = "NodeConditionUnknown"
+)
+
+// Conditions and condition Reasons for the Machine's controller.
+const (
+	// MachineSetOwnedCondition documents that a Machine belonging to a MachineSet, i.e. a Machine selected by a MachineSet or
+	// with the MachineSet or MachineDeployment labels, is controlled by a MachineSet.
+	MachineSetOwnedCondition ConditionType = "MachineSetOwned"
+
+	// MachineOrphanedReason (Severity=Warning) documents a Machine belonging to a MachineSet without a controller reference,
+	// e.g. because the MachineSet has been deleted orphaning its Machines.
+	MachineOrphanedReason = "Orphaned"
+
+	// DuplicateProviderIDCondition is set to true when other Machines in the same Cluster report the same ProviderID
+	// of the Machine, and it is removed once the duplicate is resolved; Machines sharing a ProviderID could be matched
+	// to the wrong Node, so Node matching is skipped while the condition is true.
+	DuplicateProviderIDCondition ConditionType = "DuplicateProviderID"
+
+	// ProviderIDReportedByOtherMachinesReason documents a Machine reporting the same ProviderID of other Machines
+	// in the same Cluster.
+	ProviderIDReportedByOtherMachinesReason = "ProviderIDReportedByOtherMachines"
+
+	// MalformedProviderIDReason (Severity=Unknown) documents a Machine with a malformed ProviderID, for which
+	// duplicates cannot be detected; it is also the reason of the Warning event recorded when the ProviderID is found malformed.
+	MalformedProviderIDReason = "MalformedProviderID"
+
+	// InstanceNotTerminatingCondition documents that the instance hosting the machine is not going to be terminated
+	// by the infrastructure, e.g. because an interruptible instance has been reclaimed; this condition is mirrored from
+	// the InstanceNotTerminating condition of the infrastructure machine, if reported by the infrastructure provider.
+	// Machines controlled by a MachineSet are deleted and replaced as soon as this condition is False.
+	InstanceNotTerminatingCondition ConditionType = "InstanceNotTerminating"
+
+	// TerminationNoticeReceivedReason (Severity=Warning) documents an instance which received a termination notice
+	// from the infrastructure, e.g. an interruptible instance which is going to be reclaimed.
+	TerminationNoticeReceivedReason = "TerminationNoticeReceived"
 )
 
 // Conditions and condition Reasons for the MachineHealthCheck object.
@@ -251,3 +406,26 @@ const (
 	// ScalingDownReason (Severity=Info) documents a MachineSet is decreasing the number of replicas.
 	ScalingDownReason = "ScalingDown"
 )
+
+// Conditions and condition Reasons for ClusterClasses.
+
+const (
+	// ClusterClassRefsResolvedCondition documents that all the templates referenced by the ClusterClass
+	// exist and have been updated to the latest API contract.
+	ClusterClassRefsResolvedCondition ConditionType = "RefsResolved"
+
+	// TemplateNotFoundReason (Severity=Error) documents a ClusterClass referencing templates which do not exist.
+	TemplateNotFoundReason = "TemplateNotFound"
+
+	// RefsResolveFailedReason (Severity=Error) documents a ClusterClass failing to resolve
+	// the referenced templates for reasons other than templates not existing.
+	RefsResolveFailedReason = "RefsResolveFailed"
+
+	// ClusterClassVariablesDiscoveredCondition documents that the variables of a ClusterClass have been
+	// discovered via the DiscoverVariables hook defined in .spec.variablesDiscovery.
+	ClusterClassVariablesDiscoveredCondition ConditionType = "VariablesDiscovered"
+
+	// VariablesDiscoveryFailedReason (Severity=Warning) documents a ClusterClass failing to discover
+	// variables via the DiscoverVariables hook; the variables discovered previously are preserved.
+	VariablesDiscoveryFailedReason = "VariablesDiscoveryFailed"
+)
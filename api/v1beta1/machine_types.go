@@ -32,6 +32,11 @@ const (
 	// ExcludeNodeDrainingAnnotation annotation explicitly skips node draining if set.
 	ExcludeNodeDrainingAnnotation = "machine.cluster.x-k8s.io/exclude-node-draining"
 
+	// CordonOnlyAnnotation annotation, if set on a Machine being deleted, stops the deletion after the node has been
+	// cordoned and drained, leaving the infrastructure, the bootstrap data and the node intact until the annotation
+	// is removed, e.g. to debug the hardware before releasing it.
+	CordonOnlyAnnotation = "machine.cluster.x-k8s.io/cordon-only"
+
 	// MachineSetLabelName is the label set on machines if they're controlled by MachineSet.
 	MachineSetLabelName = "cluster.x-k8s.io/set-name"
 
@@ -95,6 +100,77 @@ type MachineSpec struct {
 	// NOTE: NodeDrainTimeout is different from `kubectl drain --timeout`
 	// +optional
 	NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
+
+	// NodeDrainOptions customizes how the node is drained before the Machine is deleted;
+	// if not set, the node is drained with the default options described in NodeDrainOptions.
+	// +optional
+	NodeDrainOptions *NodeDrainOptions `json:"nodeDrainOptions,omitempty"`
+
+	// ReadinessGates specifies additional conditions to include when evaluating Machine Ready condition;
+	// a Machine is considered Ready, and thus counted as ready and available by its owner, only when all
+	// the conditions listed in ReadinessGates are True.
+	// This allows providers or users to plug in additional checks, e.g. waiting for a CNI agent to be running
+	// or for the Machine to be registered in an external inventory; the corresponding conditions must be set on the
+	// Machine by the provider or the external controller implementing the check.
+	// +optional
+	ReadinessGates []MachineReadinessGate `json:"readinessGates,omitempty"`
+
+	// AddressPreference defines the order of the addresses in the Machine's status.addresses, e.g. for
+	// environments with multiple network interfaces or dual-stack networking where the first InternalIP
+	// reported for the Machine is not the one to be used.
+	// If not set, the addresses are reported in the order defined by the infrastructure provider.
+	// +optional
+	AddressPreference *MachineAddressPreference `json:"addressPreference,omitempty"`
+}
+
+// MachineReadinessGate contains the type of a Machine condition to be used as a readiness gate.
+type MachineReadinessGate struct {
+	// ConditionType refers to a condition in the Machine's condition list with matching type.
+	ConditionType ConditionType `json:"conditionType"`
+}
+
+// MachineAddressPreference defines the order of the addresses of a Machine.
+type MachineAddressPreference struct {
+	// Types lists the preferred address types, in order of preference; addresses of the listed types
+	// are reported first, followed by the addresses of the other types.
+	// +optional
+	Types []MachineAddressType `json:"types,omitempty"`
+
+	// IPFamily is the preferred IP family; in dual-stack environments, IP addresses of the preferred
+	// family are reported before the IP addresses of the other family with the same address type.
+	// +optional
+	IPFamily MachineAddressIPFamily `json:"ipFamily,omitempty"`
+}
+
+// NodeDrainOptions customizes how the node of a Machine is drained.
+type NodeDrainOptions struct {
+	// IgnoreDaemonSets ignores Pods managed by DaemonSets, which are not evicted; if false, the drain
+	// fails when Pods managed by DaemonSets are running on the node.
+	// Defaults to true.
+	// +optional
+	IgnoreDaemonSets *bool `json:"ignoreDaemonSets,omitempty"`
+
+	// DeleteEmptyDirData allows evicting Pods using emptyDir volumes, whose data is lost; if false, the drain
+	// fails when Pods using emptyDir volumes are running on the node.
+	// Defaults to true.
+	// +optional
+	DeleteEmptyDirData *bool `json:"deleteEmptyDirData,omitempty"`
+
+	// ExcludedNamespaces lists the namespaces whose Pods are neither evicted nor waited for
+	// while draining the node.
+	// +optional
+	ExcludedNamespaces []string `json:"excludedNamespaces,omitempty"`
+
+	// ExcludedPodSelector selects the Pods which are neither evicted nor waited for while draining
+	// the node, e.g. Pods which are able to terminate gracefully together with the node.
+	// +optional
+	ExcludedPodSelector *metav1.LabelSelector `json:"excludedPodSelector,omitempty"`
+
+	// GracePeriodSeconds overrides the termination grace period of the evicted Pods;
+	// if not set, the termination grace period of each Pod is used.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	GracePeriodSeconds *int32 `json:"gracePeriodSeconds,omitempty"`
 }
 
 // ANCHOR_END: MachineSpec
@@ -159,6 +235,11 @@ type MachineStatus struct {
 	// +optional
 	Addresses MachineAddresses `json:"addresses,omitempty"`
 
+	// CertificatesExpiryDate is the expiry date of the machine certificates.
+	// This value is only set for control plane machines.
+	// +optional
+	CertificatesExpiryDate *metav1.Time `json:"certificatesExpiryDate,omitempty"`
+
 	// Phase represents the current phase of machine actuation.
 	// E.g. Pending, Running, Terminating, Failed etc.
 	// +optional
@@ -172,6 +253,11 @@ type MachineStatus struct {
 	// +optional
 	InfrastructureReady bool `json:"infrastructureReady"`
 
+	// InterruptibleInstance is true if the instance hosting the machine can be interrupted by the infrastructure,
+	// e.g. because it is a spot instance, as reported in status.interruptible of the infrastructure machine.
+	// +optional
+	InterruptibleInstance bool `json:"interruptibleInstance,omitempty"`
+
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -179,10 +265,31 @@ type MachineStatus struct {
 	// Conditions defines current service state of the Machine.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
+
+	// Deletion contains information about the progress of the deletion of the Machine.
+	// Only present when the Machine has a deletionTimestamp.
+	// +optional
+	Deletion *MachineDeletionStatus `json:"deletion,omitempty"`
 }
 
 // ANCHOR_END: MachineStatus
 
+// MachineDeletionStatus is the deletion state of the Machine.
+// Each field records when the related step of the deletion started, so deletions stuck in a step can be identified.
+type MachineDeletionStatus struct {
+	// NodeDrainStartTime is the time when the drain of the node started.
+	// +optional
+	NodeDrainStartTime *metav1.Time `json:"nodeDrainStartTime,omitempty"`
+
+	// WaitForNodeVolumeDetachStartTime is the time when waiting for the volumes of the node to be detached started.
+	// +optional
+	WaitForNodeVolumeDetachStartTime *metav1.Time `json:"waitForNodeVolumeDetachStartTime,omitempty"`
+
+	// InfrastructureDeletionStartTime is the time when the deletion of the infrastructure of the Machine started.
+	// +optional
+	InfrastructureDeletionStartTime *metav1.Time `json:"infrastructureDeletionStartTime,omitempty"`
+}
+
 // SetTypedPhase sets the Phase field to the string representation of MachinePhase.
 func (m *MachineStatus) SetTypedPhase(p MachinePhase) {
 	m.Phase = string(p)
@@ -233,6 +340,7 @@ type Bootstrap struct {
 // +kubebuilder:printcolumn:name="NodeName",type="string",JSONPath=".status.nodeRef.name",description="Node name associated with this machine"
 // +kubebuilder:printcolumn:name="ProviderID",type="string",JSONPath=".spec.providerID",description="Provider ID"
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Machine status such as Terminating/Pending/Running/Failed etc"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of Machine"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version",description="Kubernetes version associated with this Machine"
 
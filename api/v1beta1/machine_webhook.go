@@ -21,7 +21,9 @@ import (
 	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/cluster-api/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -127,8 +129,67 @@ func (m *Machine) validate(old *Machine) error {
 		}
 	}
 
+	allErrs = append(allErrs, validateMachineAddressPreference(m.Spec.AddressPreference, field.NewPath("spec", "addressPreference"))...)
+	allErrs = append(allErrs, m.Spec.NodeDrainOptions.Validate(field.NewPath("spec", "nodeDrainOptions"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return apierrors.NewInvalid(GroupVersion.WithKind("Machine").GroupKind(), m.Name, allErrs)
 }
+
+// Validate validates the NodeDrainOptions of a Machine or of a Machine template; nil options are valid.
+func (o *NodeDrainOptions) Validate(fldPath *field.Path) field.ErrorList {
+	if o == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for i, namespace := range o.ExcludedNamespaces {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("excludedNamespaces").Index(i), namespace, msg))
+		}
+	}
+	if o.ExcludedPodSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(o.ExcludedPodSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("excludedPodSelector"), o.ExcludedPodSelector, err.Error()))
+		}
+	}
+	if o.GracePeriodSeconds != nil && *o.GracePeriodSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("gracePeriodSeconds"), *o.GracePeriodSeconds, "must be greater than or equal to 0"))
+	}
+	return allErrs
+}
+
+// validateMachineAddressPreference validates the address preference of a Machine or of a Machine template.
+func validateMachineAddressPreference(preference *MachineAddressPreference, fldPath *field.Path) field.ErrorList {
+	if preference == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	supportedTypes := []string{
+		string(MachineHostName),
+		string(MachineExternalIP),
+		string(MachineInternalIP),
+		string(MachineExternalDNS),
+		string(MachineInternalDNS),
+	}
+	seen := map[MachineAddressType]bool{}
+	for i, t := range preference.Types {
+		switch t {
+		case MachineHostName, MachineExternalIP, MachineInternalIP, MachineExternalDNS, MachineInternalDNS:
+		default:
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("types").Index(i), t, supportedTypes))
+			continue
+		}
+		if seen[t] {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Child("types").Index(i), t))
+		}
+		seen[t] = true
+	}
+	switch preference.IPFamily {
+	case "", MachineAddressIPv4Family, MachineAddressIPv6Family:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("ipFamily"), preference.IPFamily, []string{string(MachineAddressIPv4Family), string(MachineAddressIPv6Family)}))
+	}
+	return allErrs
+}
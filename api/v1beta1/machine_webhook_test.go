@@ -246,3 +246,126 @@ func TestMachineVersionValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineAddressPreferenceValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		preference *MachineAddressPreference
+		expectErr  bool
+	}{
+		{
+			name:       "should succeed without address preference",
+			preference: nil,
+			expectErr:  false,
+		},
+		{
+			name: "should succeed when given valid address types and IP family",
+			preference: &MachineAddressPreference{
+				Types:    []MachineAddressType{MachineInternalIP, MachineHostName},
+				IPFamily: MachineAddressIPv6Family,
+			},
+			expectErr: false,
+		},
+		{
+			name:       "should return error when given an unknown address type",
+			preference: &MachineAddressPreference{Types: []MachineAddressType{"PrivateIP"}},
+			expectErr:  true,
+		},
+		{
+			name:       "should return error when given a duplicated address type",
+			preference: &MachineAddressPreference{Types: []MachineAddressType{MachineInternalIP, MachineInternalIP}},
+			expectErr:  true,
+		},
+		{
+			name:       "should return error when given an unknown IP family",
+			preference: &MachineAddressPreference{IPFamily: "IPv5"},
+			expectErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &Machine{
+				Spec: MachineSpec{
+					Bootstrap:         Bootstrap{ConfigRef: nil, DataSecretName: pointer.StringPtr("test")},
+					AddressPreference: tt.preference,
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(m.ValidateCreate()).NotTo(Succeed())
+				g.Expect(m.ValidateUpdate(m)).NotTo(Succeed())
+			} else {
+				g.Expect(m.ValidateCreate()).To(Succeed())
+				g.Expect(m.ValidateUpdate(m)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestMachineNodeDrainOptionsValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   *NodeDrainOptions
+		expectErr bool
+	}{
+		{
+			name:      "should succeed without node drain options",
+			options:   nil,
+			expectErr: false,
+		},
+		{
+			name: "should succeed when given valid node drain options",
+			options: &NodeDrainOptions{
+				ExcludedNamespaces: []string{"kube-system"},
+				ExcludedPodSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"foo"}}},
+				},
+				GracePeriodSeconds: pointer.Int32Ptr(30),
+			},
+			expectErr: false,
+		},
+		{
+			name:      "should return error when given an invalid excluded namespace",
+			options:   &NodeDrainOptions{ExcludedNamespaces: []string{"Kube_System"}},
+			expectErr: true,
+		},
+		{
+			name: "should return error when given an invalid excluded Pod selector",
+			options: &NodeDrainOptions{
+				ExcludedPodSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Like", Values: []string{"foo"}}},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when given a negative grace period",
+			options:   &NodeDrainOptions{GracePeriodSeconds: pointer.Int32Ptr(-1)},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &Machine{
+				Spec: MachineSpec{
+					Bootstrap:        Bootstrap{ConfigRef: nil, DataSecretName: pointer.StringPtr("test")},
+					NodeDrainOptions: tt.options,
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(m.ValidateCreate()).NotTo(Succeed())
+				g.Expect(m.ValidateUpdate(m)).NotTo(Succeed())
+			} else {
+				g.Expect(m.ValidateCreate()).To(Succeed())
+				g.Expect(m.ValidateUpdate(m)).To(Succeed())
+			}
+		})
+	}
+}
@@ -110,6 +110,30 @@ type MachineDeploymentSpec struct {
 	// not be estimated during the time a deployment is paused. Defaults to 600s.
 	// +optional
 	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+
+	// RolloutAfter is a field to indicate a rollout should be performed
+	// after the specified time even if no changes have been made to the
+	// MachineDeployment.
+	// Only the machines created before the specified time are rolled out, so
+	// the rollout happens once; set a new time to trigger another rollout.
+	// +optional
+	RolloutAfter *metav1.Time `json:"rolloutAfter,omitempty"`
+
+	// MachineNamingStrategy allows changing the naming pattern used when creating Machines;
+	// it is propagated to the MachineSets created by the MachineDeployment.
+	// +optional
+	MachineNamingStrategy *MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
+
+	// InfrastructureFailurePolicy defines how Machines whose infrastructure failed to be provisioned
+	// with a transient failure are handled; it is propagated to the MachineSets created by the MachineDeployment.
+	// +optional
+	InfrastructureFailurePolicy *InfrastructureFailurePolicy `json:"infrastructureFailurePolicy,omitempty"`
+
+	// InterruptionBudget is the maximum number of Machines, as an absolute number or a percentage of the desired
+	// replicas, which can run on interruptible instances, e.g. spot instances; it is propagated to the MachineSets
+	// created by the MachineDeployment. If not set, the number of Machines running on interruptible instances is not limited.
+	// +optional
+	InterruptionBudget *intstr.IntOrString `json:"interruptionBudget,omitempty"`
 }
 
 // ANCHOR_END: MachineDeploymentSpec
@@ -142,7 +166,7 @@ type MachineRollingUpdateDeployment struct {
 	// machines (ex: 10%).
 	// Absolute number is calculated from percentage by rounding down.
 	// This can not be 0 if MaxSurge is 0.
-	// Defaults to 0.
+	// Defaults to 0, or to 1 if MaxSurge is 0.
 	// Example: when this is set to 30%, the old MachineSet can be scaled
 	// down to 70% of desired machines immediately when the rolling update
 	// starts. Once new machines are ready, old MachineSet can be scaled
@@ -223,6 +247,11 @@ type MachineDeploymentStatus struct {
 	// +optional
 	Phase string `json:"phase,omitempty"`
 
+	// InfrastructureFailureRetries is the total number of consecutive times Machines with a transient
+	// infrastructure failure have been replaced by the MachineSets targeted by this deployment.
+	// +optional
+	InfrastructureFailureRetries int32 `json:"infrastructureFailureRetries,omitempty"`
+
 	// Conditions defines current service state of the MachineDeployment.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
@@ -278,9 +307,11 @@ func (md *MachineDeploymentStatus) GetTypedPhase() MachineDeploymentPhase {
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
 // +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Total number of non-terminated machines targeted by this MachineDeployment"
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas",description="Total number of ready machines targeted by this MachineDeployment"
+// +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.availableReplicas",description="Total number of available machines (ready for at least minReadySeconds) targeted by this MachineDeployment"
 // +kubebuilder:printcolumn:name="Updated",type=integer,JSONPath=".status.updatedReplicas",description="Total number of non-terminated machines targeted by this deployment that have the desired template spec"
 // +kubebuilder:printcolumn:name="Unavailable",type=integer,JSONPath=".status.unavailableReplicas",description="Total number of unavailable machines targeted by this MachineDeployment"
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="MachineDeployment status such as ScalingUp/ScalingDown/Running/Failed/Unknown"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of MachineDeployment"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.template.spec.version",description="Kubernetes version associated with this MachineDeployment"
 
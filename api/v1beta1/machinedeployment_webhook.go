@@ -124,6 +124,32 @@ func (m *MachineDeployment) validate(old *MachineDeployment) error {
 				)
 			}
 		}
+
+		// Reject a rolling update which can't make progress, because no Machines can be created
+		// above the desired number of replicas and no Machines can be deleted.
+		if isZeroIntOrPercent(m.Spec.Strategy.RollingUpdate.MaxSurge) && isZeroIntOrPercent(m.Spec.Strategy.RollingUpdate.MaxUnavailable) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(field.NewPath("spec", "strategy", "rollingUpdate", "maxUnavailable"),
+					m.Spec.Strategy.RollingUpdate.MaxUnavailable, "must not be 0 when maxSurge is 0"),
+			)
+		}
+	}
+
+	// The Machines are created by cloning the templates referenced by the MachineDeployment.
+	if kind := m.Spec.Template.Spec.InfrastructureRef.Kind; kind != "" && (len(kind) <= len(TemplateSuffix) || !strings.HasSuffix(kind, TemplateSuffix)) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "template", "spec", "infrastructureRef", "kind"),
+				kind, fmt.Sprintf("kind must be of form '<name>%s'", TemplateSuffix)),
+		)
+	}
+	if ref := m.Spec.Template.Spec.Bootstrap.ConfigRef; ref != nil && ref.Kind != "" && (len(ref.Kind) <= len(TemplateSuffix) || !strings.HasSuffix(ref.Kind, TemplateSuffix)) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "template", "spec", "bootstrap", "configRef", "kind"),
+				ref.Kind, fmt.Sprintf("kind must be of form '<name>%s'", TemplateSuffix)),
+		)
 	}
 
 	if m.Spec.Template.Spec.Version != nil {
@@ -132,6 +158,15 @@ func (m *MachineDeployment) validate(old *MachineDeployment) error {
 		}
 	}
 
+	// The names of the MachineSets are generated by appending the hash of the Machine template, which is
+	// at most 10 characters, to the name of the MachineDeployment.
+	machineSetName := objectName(m.ObjectMeta) + "-" + strings.Repeat("x", 10)
+	allErrs = append(allErrs, validateMachineNamingStrategy(m.Spec.MachineNamingStrategy, m.Spec.ClusterName, machineSetName, field.NewPath("spec", "machineNamingStrategy"))...)
+	allErrs = append(allErrs, validateInfrastructureFailurePolicy(m.Spec.InfrastructureFailurePolicy, field.NewPath("spec", "infrastructureFailurePolicy"))...)
+	allErrs = append(allErrs, validateInterruptionBudget(m.Spec.InterruptionBudget, field.NewPath("spec", "interruptionBudget"))...)
+	allErrs = append(allErrs, validateMachineAddressPreference(m.Spec.Template.Spec.AddressPreference, field.NewPath("spec", "template", "spec", "addressPreference"))...)
+	allErrs = append(allErrs, m.Spec.Template.Spec.NodeDrainOptions.Validate(field.NewPath("spec", "template", "spec", "nodeDrainOptions"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -139,6 +174,18 @@ func (m *MachineDeployment) validate(old *MachineDeployment) error {
 	return apierrors.NewInvalid(GroupVersion.WithKind("MachineDeployment").GroupKind(), m.Name, allErrs)
 }
 
+// isZeroIntOrPercent returns true if the value is 0 or 0%.
+// NOTE: nil values are not considered zero, because they are going to be defaulted.
+func isZeroIntOrPercent(v *intstr.IntOrString) bool {
+	if v == nil {
+		return false
+	}
+	if v.Type == intstr.String {
+		return strings.TrimSuffix(v.StrVal, "%") == "0"
+	}
+	return v.IntVal == 0
+}
+
 // PopulateDefaultsMachineDeployment fills in default field values.
 // This is also called during MachineDeployment sync.
 func PopulateDefaultsMachineDeployment(d *MachineDeployment) {
@@ -185,8 +232,13 @@ func PopulateDefaultsMachineDeployment(d *MachineDeployment) {
 			d.Spec.Strategy.RollingUpdate.MaxSurge = &ios1
 		}
 		if d.Spec.Strategy.RollingUpdate.MaxUnavailable == nil {
-			ios0 := intstr.FromInt(0)
-			d.Spec.Strategy.RollingUpdate.MaxUnavailable = &ios0
+			// Allow machines to be deleted before new ones are created when no machines can be created
+			// above the desired number of replicas, e.g. on bare metal where no spare hosts are available.
+			maxUnavailable := intstr.FromInt(0)
+			if isZeroIntOrPercent(d.Spec.Strategy.RollingUpdate.MaxSurge) {
+				maxUnavailable = intstr.FromInt(1)
+			}
+			d.Spec.Strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
 		}
 	}
 
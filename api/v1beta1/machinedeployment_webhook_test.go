@@ -17,9 +17,11 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
@@ -57,6 +59,28 @@ func TestMachineDeploymentDefault(t *testing.T) {
 	g.Expect(*md.Spec.Template.Spec.Version).To(Equal("v1.19.10"))
 }
 
+func TestMachineDeploymentDefaultMaxSurgeZero(t *testing.T) {
+	g := NewWithT(t)
+	maxSurge := intstr.FromInt(0)
+	md := &MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-md",
+		},
+		Spec: MachineDeploymentSpec{
+			Strategy: &MachineDeploymentStrategy{
+				RollingUpdate: &MachineRollingUpdateDeployment{
+					MaxSurge: &maxSurge,
+				},
+			},
+		},
+	}
+	md.Default()
+
+	g.Expect(md.Spec.Strategy.RollingUpdate.MaxSurge.IntValue()).To(Equal(0))
+	g.Expect(md.Spec.Strategy.RollingUpdate.MaxUnavailable.IntValue()).To(Equal(1))
+	g.Expect(md.ValidateCreate()).To(Succeed())
+}
+
 func TestMachineDeploymentValidation(t *testing.T) {
 	badMaxSurge := intstr.FromString("1")
 	badMaxUnavailable := intstr.FromString("0")
@@ -67,6 +91,9 @@ func TestMachineDeploymentValidation(t *testing.T) {
 	goodMaxSurgeInt := intstr.FromInt(1)
 	goodMaxUnavailableInt := intstr.FromInt(0)
 
+	zeroInt := intstr.FromInt(0)
+	zeroPercentage := intstr.FromString("0%")
+
 	tests := []struct {
 		name      string
 		selectors map[string]string
@@ -156,6 +183,32 @@ func TestMachineDeploymentValidation(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name:      "should return error if both maxSurge and maxUnavailable are 0",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: MachineDeploymentStrategy{
+				Type: RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &MachineRollingUpdateDeployment{
+					MaxUnavailable: &zeroInt,
+					MaxSurge:       &zeroInt,
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "should return error if both maxSurge and maxUnavailable are 0%",
+			selectors: map[string]string{"foo": "bar"},
+			labels:    map[string]string{"foo": "bar"},
+			strategy: MachineDeploymentStrategy{
+				Type: RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &MachineRollingUpdateDeployment{
+					MaxUnavailable: &zeroPercentage,
+					MaxSurge:       &zeroInt,
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -185,6 +238,69 @@ func TestMachineDeploymentValidation(t *testing.T) {
 	}
 }
 
+func TestMachineDeploymentTemplateRefValidation(t *testing.T) {
+	tests := []struct {
+		name              string
+		infrastructureRef corev1.ObjectReference
+		configRef         *corev1.ObjectReference
+		expectErr         bool
+	}{
+		{
+			name:              "should succeed when referencing templates",
+			infrastructureRef: corev1.ObjectReference{Kind: "DockerMachineTemplate"},
+			configRef:         &corev1.ObjectReference{Kind: "KubeadmConfigTemplate"},
+			expectErr:         false,
+		},
+		{
+			name:              "should succeed without a bootstrap configRef",
+			infrastructureRef: corev1.ObjectReference{Kind: "DockerMachineTemplate"},
+			expectErr:         false,
+		},
+		{
+			name:              "should return error when infrastructureRef is not a template",
+			infrastructureRef: corev1.ObjectReference{Kind: "DockerMachine"},
+			configRef:         &corev1.ObjectReference{Kind: "KubeadmConfigTemplate"},
+			expectErr:         true,
+		},
+		{
+			name:              "should return error when bootstrap configRef is not a template",
+			infrastructureRef: corev1.ObjectReference{Kind: "DockerMachineTemplate"},
+			configRef:         &corev1.ObjectReference{Kind: "KubeadmConfig"},
+			expectErr:         true,
+		},
+		{
+			name:              "should return error when the kind is only the template suffix",
+			infrastructureRef: corev1.ObjectReference{Kind: "Template"},
+			expectErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			md := &MachineDeployment{
+				Spec: MachineDeploymentSpec{
+					Template: MachineTemplateSpec{
+						Spec: MachineSpec{
+							InfrastructureRef: tt.infrastructureRef,
+							Bootstrap: Bootstrap{
+								ConfigRef: tt.configRef,
+							},
+						},
+					},
+				},
+			}
+			if tt.expectErr {
+				g.Expect(md.ValidateCreate()).NotTo(Succeed())
+				g.Expect(md.ValidateUpdate(md)).NotTo(Succeed())
+			} else {
+				g.Expect(md.ValidateCreate()).To(Succeed())
+				g.Expect(md.ValidateUpdate(md)).To(Succeed())
+			}
+		})
+	}
+}
+
 func TestMachineDeploymentVersionValidation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -244,6 +360,96 @@ func TestMachineDeploymentVersionValidation(t *testing.T) {
 	}
 }
 
+func TestMachineDeploymentMachineNamingStrategyValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		mdName    string
+		template  string
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when the template references .random",
+			mdName:    "md-0",
+			template:  "{{ .machineSet.name }}-{{ .random }}",
+			expectErr: false,
+		},
+		{
+			name:      "should return error when the template does not reference .random or .index",
+			mdName:    "md-0",
+			template:  "{{ .cluster.name }}-worker",
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the names generated from the MachineSet names are longer than 63 characters",
+			mdName:    strings.Repeat("a", 47),
+			template:  "{{ .machineSet.name }}-{{ .random }}",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := &MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.mdName},
+				Spec: MachineDeploymentSpec{
+					ClusterName:           "test",
+					MachineNamingStrategy: &MachineNamingStrategy{Template: tt.template},
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(md.ValidateCreate()).NotTo(Succeed())
+				g.Expect(md.ValidateUpdate(md)).NotTo(Succeed())
+			} else {
+				g.Expect(md.ValidateCreate()).To(Succeed())
+				g.Expect(md.ValidateUpdate(md)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestMachineDeploymentInterruptionBudgetValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		budget    intstr.IntOrString
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when the budget is a percentage",
+			budget:    intstr.FromString("10%"),
+			expectErr: false,
+		},
+		{
+			name:      "should return error when the budget is a negative percentage",
+			budget:    intstr.FromString("-10%"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := &MachineDeployment{
+				Spec: MachineDeploymentSpec{
+					ClusterName:        "test",
+					InterruptionBudget: &tt.budget,
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(md.ValidateCreate()).NotTo(Succeed())
+				g.Expect(md.ValidateUpdate(md)).NotTo(Succeed())
+			} else {
+				g.Expect(md.ValidateCreate()).To(Succeed())
+				g.Expect(md.ValidateUpdate(md)).To(Succeed())
+			}
+		})
+	}
+}
+
 func TestMachineDeploymentWithSpec(t *testing.T) {
 	g := NewWithT(t)
 	md := MachineDeployment{
@@ -17,6 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"regexp"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -61,6 +64,21 @@ type MachineHealthCheckSpec struct {
 	// +optional
 	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
 
+	// Control plane machines older than this duration without a node will be considered to have
+	// failed and will be remediated.
+	// If not set, NodeStartupTimeout applies to control plane machines as well.
+	// If you wish to disable this feature for control plane machines, set the value explicitly to 0.
+	// +optional
+	ControlPlaneNodeStartupTimeout *metav1.Duration `json:"controlPlaneNodeStartupTimeout,omitempty"`
+
+	// RemediationBackoff is the minimum interval between two remediations of machines owned by
+	// the same object, e.g. the same MachineSet or control plane.
+	// Machines failing health check within this interval are remediated only after it elapses, thus
+	// preventing remediation storms when e.g. the machine template is fundamentally broken.
+	// If not set, machines are remediated as soon as they fail health check.
+	// +optional
+	RemediationBackoff *metav1.Duration `json:"remediationBackoff,omitempty"`
+
 	// RemediationTemplate is a reference to a remediation template
 	// provided by an infrastructure provider.
 	//
@@ -78,6 +96,8 @@ type MachineHealthCheckSpec struct {
 // UnhealthyCondition represents a Node condition type and value with a timeout
 // specified as a duration.  When the named condition has been in the given
 // status for at least the timeout value, a node is considered unhealthy.
+// The condition type can be any type reported on the Node, including custom
+// conditions reported by problem detectors, e.g. node-problem-detector.
 type UnhealthyCondition struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:MinLength=1
@@ -87,6 +107,11 @@ type UnhealthyCondition struct {
 	// +kubebuilder:validation:MinLength=1
 	Status corev1.ConditionStatus `json:"status"`
 
+	// StatusRegex is a regular expression matching additional statuses of the Node condition
+	// considered unhealthy, e.g. "Unknown"; the regular expression must match the whole status.
+	// +optional
+	StatusRegex string `json:"statusRegex,omitempty"`
+
 	Timeout metav1.Duration `json:"timeout"`
 }
 
@@ -120,11 +145,28 @@ type MachineHealthCheckStatus struct {
 	// +optional
 	Targets []string `json:"targets,omitempty"`
 
+	// LastRemediations records, for each object owning remediated machines, the last time
+	// one of its machines was remediated; it is used to enforce RemediationBackoff.
+	// +optional
+	LastRemediations []OwnerRemediation `json:"lastRemediations,omitempty"`
+
 	// Conditions defines current service state of the MachineHealthCheck.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
 }
 
+// OwnerRemediation records the last remediation of a machine owned by an object.
+type OwnerRemediation struct {
+	// Kind of the object owning the remediated machine.
+	Kind string `json:"kind"`
+
+	// Name of the object owning the remediated machine.
+	Name string `json:"name"`
+
+	// LastRemediationTime is the last time a machine owned by the object was remediated.
+	LastRemediationTime metav1.Time `json:"lastRemediationTime"`
+}
+
 // ANCHOR_END: MachineHealthCheckStatus
 
 // +kubebuilder:object:root=true
@@ -149,6 +191,12 @@ type MachineHealthCheck struct {
 	Status MachineHealthCheckStatus `json:"status,omitempty"`
 }
 
+// CompileStatusRegex returns the regular expression defined in StatusRegex, anchored so it
+// has to match the whole status of the Node condition.
+func (c *UnhealthyCondition) CompileStatusRegex() (*regexp.Regexp, error) {
+	return regexp.Compile(fmt.Sprintf("^(?:%s)$", c.StatusRegex))
+}
+
 // GetConditions returns the set of conditions for this object.
 func (m *MachineHealthCheck) GetConditions() Conditions {
 	return m.Status.Conditions
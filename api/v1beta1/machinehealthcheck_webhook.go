@@ -135,12 +135,41 @@ func (m *MachineHealthCheck) validate(old *MachineHealthCheck) error {
 		)
 	}
 
+	if m.Spec.ControlPlaneNodeStartupTimeout != nil &&
+		m.Spec.ControlPlaneNodeStartupTimeout.Seconds() != disabledNodeStartupTimeout.Seconds() &&
+		m.Spec.ControlPlaneNodeStartupTimeout.Seconds() < minNodeStartupTimeout.Seconds() {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "controlPlaneNodeStartupTimeout"), m.Spec.ControlPlaneNodeStartupTimeout.Seconds(), "must be at least 30s"),
+		)
+	}
+
+	if m.Spec.RemediationBackoff != nil && m.Spec.RemediationBackoff.Duration < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "remediationBackoff"), m.Spec.RemediationBackoff.Duration.String(), "must be greater than or equal to 0"),
+		)
+	}
+
+	allErrs = append(allErrs, m.ValidateCommonFields(field.NewPath("spec"))...)
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(GroupVersion.WithKind("MachineHealthCheck").GroupKind(), m.Name, allErrs)
+}
+
+// ValidateCommonFields validates the fields of the MachineHealthCheck spec which can also be defined
+// in a MachineHealthCheckClass, e.g. in a ClusterClass.
+func (m *MachineHealthCheck) ValidateCommonFields(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
 	if m.Spec.NodeStartupTimeout != nil &&
 		m.Spec.NodeStartupTimeout.Seconds() != disabledNodeStartupTimeout.Seconds() &&
 		m.Spec.NodeStartupTimeout.Seconds() < minNodeStartupTimeout.Seconds() {
 		allErrs = append(
 			allErrs,
-			field.Invalid(field.NewPath("spec", "nodeStartupTimeout"), m.Spec.NodeStartupTimeout.Seconds(), "must be at least 30s"),
+			field.Invalid(fldPath.Child("nodeStartupTimeout"), m.Spec.NodeStartupTimeout.Seconds(), "must be at least 30s"),
 		)
 	}
 
@@ -148,7 +177,19 @@ func (m *MachineHealthCheck) validate(old *MachineHealthCheck) error {
 		if _, err := intstr.GetScaledValueFromIntOrPercent(m.Spec.MaxUnhealthy, 0, false); err != nil {
 			allErrs = append(
 				allErrs,
-				field.Invalid(field.NewPath("spec", "maxUnhealthy"), m.Spec.MaxUnhealthy, fmt.Sprintf("must be either an int or a percentage: %v", err.Error())),
+				field.Invalid(fldPath.Child("maxUnhealthy"), m.Spec.MaxUnhealthy, fmt.Sprintf("must be either an int or a percentage: %v", err.Error())),
+			)
+		}
+	}
+
+	for i, c := range m.Spec.UnhealthyConditions {
+		if c.StatusRegex == "" {
+			continue
+		}
+		if _, err := c.CompileStatusRegex(); err != nil {
+			allErrs = append(
+				allErrs,
+				field.Invalid(fldPath.Child("unhealthyConditions").Index(i).Child("statusRegex"), c.StatusRegex, fmt.Sprintf("must be a valid regular expression: %v", err.Error())),
 			)
 		}
 	}
@@ -157,15 +198,12 @@ func (m *MachineHealthCheck) validate(old *MachineHealthCheck) error {
 		allErrs = append(
 			allErrs,
 			field.Invalid(
-				field.NewPath("spec", "remediationTemplate", "namespace"),
+				fldPath.Child("remediationTemplate", "namespace"),
 				m.Spec.RemediationTemplate.Namespace,
 				"must match metadata.namespace",
 			),
 		)
 	}
 
-	if len(allErrs) == 0 {
-		return nil
-	}
-	return apierrors.NewInvalid(GroupVersion.WithKind("MachineHealthCheck").GroupKind(), m.Name, allErrs)
+	return allErrs
 }
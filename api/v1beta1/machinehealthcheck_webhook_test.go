@@ -212,6 +212,114 @@ func TestMachineHealthCheckNodeStartupTimeout(t *testing.T) {
 	}
 }
 
+func TestMachineHealthCheckControlPlaneNodeStartupTimeout(t *testing.T) {
+	zero := metav1.Duration{Duration: 0}
+	twentyNineSeconds := metav1.Duration{Duration: 29 * time.Second}
+	oneMinute := metav1.Duration{Duration: 1 * time.Minute}
+
+	tests := []struct {
+		name      string
+		timeout   *metav1.Duration
+		expectErr bool
+	}{
+		{
+			name:      "when the controlPlaneNodeStartupTimeout is not given",
+			timeout:   nil,
+			expectErr: false,
+		},
+		{
+			name:      "when the controlPlaneNodeStartupTimeout is greater than 30s",
+			timeout:   &oneMinute,
+			expectErr: false,
+		},
+		{
+			name:      "when the controlPlaneNodeStartupTimeout is 29s",
+			timeout:   &twentyNineSeconds,
+			expectErr: true,
+		},
+		{
+			name:      "when the controlPlaneNodeStartupTimeout is 0 (disabled)",
+			timeout:   &zero,
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		g := NewWithT(t)
+
+		mhc := &MachineHealthCheck{
+			Spec: MachineHealthCheckSpec{
+				ControlPlaneNodeStartupTimeout: tt.timeout,
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"test": "test",
+					},
+				},
+			},
+		}
+
+		if tt.expectErr {
+			g.Expect(mhc.ValidateCreate()).NotTo(Succeed())
+		} else {
+			g.Expect(mhc.ValidateCreate()).To(Succeed())
+		}
+	}
+}
+
+func TestMachineHealthCheckRemediationBackoff(t *testing.T) {
+	zero := metav1.Duration{Duration: 0}
+	oneMinute := metav1.Duration{Duration: 1 * time.Minute}
+	minusOneMinute := metav1.Duration{Duration: -1 * time.Minute}
+
+	tests := []struct {
+		name      string
+		backoff   *metav1.Duration
+		expectErr bool
+	}{
+		{
+			name:      "when the remediationBackoff is not given",
+			backoff:   nil,
+			expectErr: false,
+		},
+		{
+			name:      "when the remediationBackoff is 0",
+			backoff:   &zero,
+			expectErr: false,
+		},
+		{
+			name:      "when the remediationBackoff is greater than 0",
+			backoff:   &oneMinute,
+			expectErr: false,
+		},
+		{
+			name:      "when the remediationBackoff is less than 0",
+			backoff:   &minusOneMinute,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		g := NewWithT(t)
+
+		mhc := &MachineHealthCheck{
+			Spec: MachineHealthCheckSpec{
+				RemediationBackoff: tt.backoff,
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"test": "test",
+					},
+				},
+			},
+		}
+
+		if tt.expectErr {
+			g.Expect(mhc.ValidateCreate()).NotTo(Succeed())
+		} else {
+			g.Expect(mhc.ValidateCreate()).To(Succeed())
+		}
+	}
+}
+
 func TestMachineHealthCheckMaxUnhealthy(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -265,6 +373,55 @@ func TestMachineHealthCheckMaxUnhealthy(t *testing.T) {
 	}
 }
 
+func TestMachineHealthCheckUnhealthyConditions(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition UnhealthyCondition
+		expectErr bool
+	}{
+		{
+			name:      "when status is set",
+			condition: UnhealthyCondition{Type: corev1.NodeReady, Status: corev1.ConditionFalse},
+			expectErr: false,
+		},
+		{
+			name:      "when status and statusRegex are set",
+			condition: UnhealthyCondition{Type: "KernelDeadlock", Status: corev1.ConditionTrue, StatusRegex: "Unknown"},
+			expectErr: false,
+		},
+		{
+			name:      "when statusRegex is not a valid regular expression",
+			condition: UnhealthyCondition{Type: "KernelDeadlock", Status: corev1.ConditionTrue, StatusRegex: "Unknown|("},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mhc := &MachineHealthCheck{
+				Spec: MachineHealthCheckSpec{
+					Selector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"test": "test",
+						},
+					},
+					UnhealthyConditions: []UnhealthyCondition{tt.condition},
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(mhc.ValidateCreate()).NotTo(Succeed())
+				g.Expect(mhc.ValidateUpdate(mhc)).NotTo(Succeed())
+			} else {
+				g.Expect(mhc.ValidateCreate()).To(Succeed())
+				g.Expect(mhc.ValidateUpdate(mhc)).To(Succeed())
+			}
+		})
+	}
+}
+
 func TestMachineHealthCheckSelectorValidation(t *testing.T) {
 	g := NewWithT(t)
 	mhc := &MachineHealthCheck{}
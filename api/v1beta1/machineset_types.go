@@ -20,6 +20,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 )
@@ -67,10 +68,58 @@ type MachineSetSpec struct {
 	// Object references to custom resources resources are treated as templates.
 	// +optional
 	Template MachineTemplateSpec `json:"template,omitempty"`
+
+	// MachineNamingStrategy allows changing the naming pattern used when creating Machines;
+	// if not set, Machine names are generated from the MachineSet name.
+	// +optional
+	MachineNamingStrategy *MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
+
+	// InfrastructureFailurePolicy defines how Machines whose infrastructure failed to be provisioned
+	// with a transient failure are handled; if not set, such Machines are left failed until they are
+	// remediated, e.g. by a MachineHealthCheck.
+	// +optional
+	InfrastructureFailurePolicy *InfrastructureFailurePolicy `json:"infrastructureFailurePolicy,omitempty"`
+
+	// InterruptionBudget is the maximum number of Machines, as an absolute number or a percentage of the desired
+	// replicas, which can run on interruptible instances, e.g. spot instances; Machines created once the budget is
+	// reached are annotated with cluster.x-k8s.io/non-interruptible-instance, requesting the infrastructure provider
+	// to use a non-interruptible instance. If not set, the number of Machines running on interruptible instances is not limited.
+	// +optional
+	InterruptionBudget *intstr.IntOrString `json:"interruptionBudget,omitempty"`
 }
 
 // ANCHOR_END: MachineSetSpec
 
+// ANCHOR: InfrastructureFailurePolicy
+
+// InfrastructureFailurePolicy defines how the Machines whose infrastructure reports a transient failure
+// before being ready are retried: such Machines are deleted and replaced, waiting an exponentially
+// increasing backoff between consecutive retries.
+type InfrastructureFailurePolicy struct {
+	// RetryableFailureReasons is the list of failure reasons, as reported in the Machine's
+	// status.failureReason, considered transient.
+	// Defaults to "CreateError" and "InsufficientResources".
+	// +optional
+	RetryableFailureReasons []capierrors.MachineStatusError `json:"retryableFailureReasons,omitempty"`
+
+	// MaxRetries is the maximum number of consecutive retries; once reached, failed Machines are left
+	// failed until they are remediated. If not set, Machines are retried indefinitely.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxRetries *int32 `json:"maxRetries,omitempty"`
+
+	// InitialBackoff is the time to wait between the first and the second retry; the backoff is doubled
+	// at each following retry. Defaults to 10s.
+	// +optional
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+
+	// MaxBackoff is the maximum time to wait between consecutive retries. Defaults to 10m.
+	// +optional
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+}
+
+// ANCHOR_END: InfrastructureFailurePolicy
+
 // ANCHOR: MachineTemplateSpec
 
 // MachineTemplateSpec describes the data needed to create a Machine from a template.
@@ -164,6 +213,18 @@ type MachineSetStatus struct {
 	FailureReason *capierrors.MachineSetStatusError `json:"failureReason,omitempty"`
 	// +optional
 	FailureMessage *string `json:"failureMessage,omitempty"`
+
+	// InfrastructureFailureRetries is the number of consecutive times Machines with a transient infrastructure
+	// failure have been replaced according to the InfrastructureFailurePolicy; it is reset once all the
+	// Machines have their infrastructure ready.
+	// +optional
+	InfrastructureFailureRetries int32 `json:"infrastructureFailureRetries,omitempty"`
+
+	// LastInfrastructureFailureRetryTime is the last time Machines with a transient infrastructure failure
+	// have been replaced.
+	// +optional
+	LastInfrastructureFailureRetryTime *metav1.Time `json:"lastInfrastructureFailureRetryTime,omitempty"`
+
 	// Conditions defines current service state of the MachineSet.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
@@ -203,6 +264,7 @@ func (m *MachineSet) Validate() field.ErrorList {
 // +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Total number of non-terminated machines targeted by this machineset"
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas",description="Total number of ready machines targeted by this machineset."
 // +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.availableReplicas",description="Total number of available machines (ready for at least minReadySeconds)"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of MachineSet"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.template.spec.version",description="Kubernetes version associated with this MachineSet"
 
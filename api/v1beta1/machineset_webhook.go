@@ -24,7 +24,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api/util/naming"
 	"sigs.k8s.io/cluster-api/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -124,9 +126,82 @@ func (m *MachineSet) validate(old *MachineSet) error {
 		}
 	}
 
+	allErrs = append(allErrs, validateMachineNamingStrategy(m.Spec.MachineNamingStrategy, m.Spec.ClusterName, objectName(m.ObjectMeta), field.NewPath("spec", "machineNamingStrategy"))...)
+	allErrs = append(allErrs, validateInfrastructureFailurePolicy(m.Spec.InfrastructureFailurePolicy, field.NewPath("spec", "infrastructureFailurePolicy"))...)
+	allErrs = append(allErrs, validateInterruptionBudget(m.Spec.InterruptionBudget, field.NewPath("spec", "interruptionBudget"))...)
+	allErrs = append(allErrs, validateMachineAddressPreference(m.Spec.Template.Spec.AddressPreference, field.NewPath("spec", "template", "spec", "addressPreference"))...)
+	allErrs = append(allErrs, m.Spec.Template.Spec.NodeDrainOptions.Validate(field.NewPath("spec", "template", "spec", "nodeDrainOptions"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 
 	return apierrors.NewInvalid(GroupVersion.WithKind("MachineSet").GroupKind(), m.Name, allErrs)
 }
+
+// objectName returns the name of an object, or a name of the same length as the one which will be generated
+// by the API server if the name of the object is not set yet.
+func objectName(obj metav1.ObjectMeta) string {
+	if obj.Name == "" && obj.GenerateName != "" {
+		return obj.GenerateName + strings.Repeat("x", 5)
+	}
+	return obj.Name
+}
+
+// validateMachineNamingStrategy validates the machine naming strategy of a MachineSet with the given name,
+// or of the MachineSets of a MachineDeployment.
+func validateMachineNamingStrategy(strategy *MachineNamingStrategy, clusterName, machineSetName string, fldPath *field.Path) field.ErrorList {
+	if strategy == nil {
+		return nil
+	}
+	if err := naming.Validate(naming.MachineNameInput{
+		Template:    strategy.Template,
+		ClusterName: clusterName,
+		OwnerKey:    naming.MachineSetOwnerKey,
+		OwnerName:   machineSetName,
+	}); err != nil {
+		return field.ErrorList{field.Invalid(fldPath.Child("template"), strategy.Template, err.Error())}
+	}
+	return nil
+}
+
+// validateInfrastructureFailurePolicy validates the infrastructure failure policy of a MachineSet or MachineDeployment.
+func validateInfrastructureFailurePolicy(policy *InfrastructureFailurePolicy, fldPath *field.Path) field.ErrorList {
+	if policy == nil {
+		return nil
+	}
+	var allErrs field.ErrorList
+	for i, reason := range policy.RetryableFailureReasons {
+		if reason == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("retryableFailureReasons").Index(i), "must not be empty"))
+		}
+	}
+	if policy.MaxRetries != nil && *policy.MaxRetries < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRetries"), *policy.MaxRetries, "must be greater than 0"))
+	}
+	if policy.InitialBackoff != nil && policy.InitialBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialBackoff"), policy.InitialBackoff.Duration.String(), "must be greater than 0"))
+	}
+	if policy.MaxBackoff != nil && policy.MaxBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackoff"), policy.MaxBackoff.Duration.String(), "must be greater than 0"))
+	}
+	if policy.InitialBackoff != nil && policy.MaxBackoff != nil && policy.InitialBackoff.Duration > policy.MaxBackoff.Duration {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxBackoff"), policy.MaxBackoff.Duration.String(), "must be greater than or equal to initialBackoff"))
+	}
+	return allErrs
+}
+
+// validateInterruptionBudget validates the interruption budget of a MachineSet or MachineDeployment.
+func validateInterruptionBudget(budget *intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	if budget == nil {
+		return nil
+	}
+	value, err := intstr.GetScaledValueFromIntOrPercent(budget, 100, true)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, budget.String(), fmt.Sprintf("must be either an int or a percentage: %v", err))}
+	}
+	if value < 0 {
+		return field.ErrorList{field.Invalid(fldPath, budget.String(), "must be greater than or equal to 0")}
+	}
+	return nil
+}
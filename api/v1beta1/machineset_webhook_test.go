@@ -17,11 +17,15 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
+	capierrors "sigs.k8s.io/cluster-api/errors"
 	utildefaulting "sigs.k8s.io/cluster-api/util/defaulting"
 )
 
@@ -217,3 +221,189 @@ func TestMachineSetVersionValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineSetMachineNamingStrategyValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		objMeta   metav1.ObjectMeta
+		template  string
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when the template references .random",
+			objMeta:   metav1.ObjectMeta{Name: "md-0-abcde"},
+			template:  "{{ .machineSet.name }}-{{ .random }}",
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the template references .index",
+			objMeta:   metav1.ObjectMeta{Name: "md-0-abcde"},
+			template:  "{{ .cluster.name }}-worker-{{ .index }}",
+			expectErr: false,
+		},
+		{
+			name:      "should return error when the template does not reference .random or .index",
+			objMeta:   metav1.ObjectMeta{Name: "md-0-abcde"},
+			template:  "{{ .machineSet.name }}",
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the template is invalid",
+			objMeta:   metav1.ObjectMeta{Name: "md-0-abcde"},
+			template:  "{{ .machineSet.name }-{{ .random }}",
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the generated names are longer than 63 characters",
+			objMeta:   metav1.ObjectMeta{Name: strings.Repeat("a", 58)},
+			template:  "{{ .machineSet.name }}-{{ .random }}",
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the names generated from the generated MachineSet name are longer than 63 characters",
+			objMeta:   metav1.ObjectMeta{GenerateName: strings.Repeat("a", 53)},
+			template:  "{{ .machineSet.name }}-{{ .random }}",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ms := &MachineSet{
+				ObjectMeta: tt.objMeta,
+				Spec: MachineSetSpec{
+					ClusterName:           "test",
+					MachineNamingStrategy: &MachineNamingStrategy{Template: tt.template},
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(ms.ValidateCreate()).NotTo(Succeed())
+				g.Expect(ms.ValidateUpdate(ms)).NotTo(Succeed())
+			} else {
+				g.Expect(ms.ValidateCreate()).To(Succeed())
+				g.Expect(ms.ValidateUpdate(ms)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestMachineSetInfrastructureFailurePolicyValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    *InfrastructureFailurePolicy
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when the policy is empty",
+			policy:    &InfrastructureFailurePolicy{},
+			expectErr: false,
+		},
+		{
+			name: "should succeed when the policy is valid",
+			policy: &InfrastructureFailurePolicy{
+				RetryableFailureReasons: []capierrors.MachineStatusError{capierrors.CreateMachineError},
+				MaxRetries:              pointer.Int32Ptr(3),
+				InitialBackoff:          &metav1.Duration{Duration: 30 * time.Second},
+				MaxBackoff:              &metav1.Duration{Duration: 5 * time.Minute},
+			},
+			expectErr: false,
+		},
+		{
+			name:      "should return error when a retryable failure reason is empty",
+			policy:    &InfrastructureFailurePolicy{RetryableFailureReasons: []capierrors.MachineStatusError{""}},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when max retries is 0",
+			policy:    &InfrastructureFailurePolicy{MaxRetries: pointer.Int32Ptr(0)},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the initial backoff is not positive",
+			policy:    &InfrastructureFailurePolicy{InitialBackoff: &metav1.Duration{}},
+			expectErr: true,
+		},
+		{
+			name: "should return error when the max backoff is lower than the initial backoff",
+			policy: &InfrastructureFailurePolicy{
+				InitialBackoff: &metav1.Duration{Duration: time.Minute},
+				MaxBackoff:     &metav1.Duration{Duration: 30 * time.Second},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ms := &MachineSet{
+				Spec: MachineSetSpec{
+					ClusterName:                 "test",
+					InfrastructureFailurePolicy: tt.policy,
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(ms.ValidateCreate()).NotTo(Succeed())
+				g.Expect(ms.ValidateUpdate(ms)).NotTo(Succeed())
+			} else {
+				g.Expect(ms.ValidateCreate()).To(Succeed())
+				g.Expect(ms.ValidateUpdate(ms)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestMachineSetInterruptionBudgetValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		budget    intstr.IntOrString
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when the budget is an integer",
+			budget:    intstr.FromInt(1),
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the budget is a percentage",
+			budget:    intstr.FromString("25%"),
+			expectErr: false,
+		},
+		{
+			name:      "should return error when the budget is negative",
+			budget:    intstr.FromInt(-1),
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the budget is not a percentage",
+			budget:    intstr.FromString("one"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ms := &MachineSet{
+				Spec: MachineSetSpec{
+					ClusterName:        "test",
+					InterruptionBudget: &tt.budget,
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(ms.ValidateCreate()).NotTo(Succeed())
+				g.Expect(ms.ValidateUpdate(ms)).NotTo(Succeed())
+			} else {
+				g.Expect(ms.ValidateCreate()).To(Succeed())
+				g.Expect(ms.ValidateUpdate(ms)).To(Succeed())
+			}
+		})
+	}
+}
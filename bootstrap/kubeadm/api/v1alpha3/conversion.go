@@ -51,6 +51,20 @@ func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = restored.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors
 	}
 
+	if restored.Spec.InitConfiguration != nil && restored.Spec.InitConfiguration.Patches != nil {
+		if dst.Spec.InitConfiguration == nil {
+			dst.Spec.InitConfiguration = &v1beta1.InitConfiguration{}
+		}
+		dst.Spec.InitConfiguration.Patches = restored.Spec.InitConfiguration.Patches
+	}
+
+	if restored.Spec.JoinConfiguration != nil && restored.Spec.JoinConfiguration.Patches != nil {
+		if dst.Spec.JoinConfiguration == nil {
+			dst.Spec.JoinConfiguration = &v1beta1.JoinConfiguration{}
+		}
+		dst.Spec.JoinConfiguration.Patches = restored.Spec.JoinConfiguration.Patches
+	}
+
 	return nil
 }
 
@@ -108,6 +122,20 @@ func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = restored.Spec.Template.Spec.InitConfiguration.NodeRegistration.IgnorePreflightErrors
 	}
 
+	if restored.Spec.Template.Spec.InitConfiguration != nil && restored.Spec.Template.Spec.InitConfiguration.Patches != nil {
+		if dst.Spec.Template.Spec.InitConfiguration == nil {
+			dst.Spec.Template.Spec.InitConfiguration = &v1beta1.InitConfiguration{}
+		}
+		dst.Spec.Template.Spec.InitConfiguration.Patches = restored.Spec.Template.Spec.InitConfiguration.Patches
+	}
+
+	if restored.Spec.Template.Spec.JoinConfiguration != nil && restored.Spec.Template.Spec.JoinConfiguration.Patches != nil {
+		if dst.Spec.Template.Spec.JoinConfiguration == nil {
+			dst.Spec.Template.Spec.JoinConfiguration = &v1beta1.JoinConfiguration{}
+		}
+		dst.Spec.Template.Spec.JoinConfiguration.Patches = restored.Spec.Template.Spec.JoinConfiguration.Patches
+	}
+
 	return nil
 }
 
@@ -17,20 +17,39 @@ limitations under the License.
 package v1alpha4
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
 func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.KubeadmConfig)
 
-	return Convert_v1alpha4_KubeadmConfig_To_v1beta1_KubeadmConfig(src, dst, nil)
+	if err := Convert_v1alpha4_KubeadmConfig_To_v1beta1_KubeadmConfig(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1beta1.KubeadmConfig{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	RestoreKubeadmConfigSpec(&restored.Spec, &dst.Spec)
+
+	return nil
 }
 
 func (dst *KubeadmConfig) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.KubeadmConfig)
 
-	return Convert_v1beta1_KubeadmConfig_To_v1alpha4_KubeadmConfig(src, dst, nil)
+	if err := Convert_v1beta1_KubeadmConfig_To_v1alpha4_KubeadmConfig(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *KubeadmConfigList) ConvertTo(dstRaw conversion.Hub) error {
@@ -48,13 +67,30 @@ func (dst *KubeadmConfigList) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*v1beta1.KubeadmConfigTemplate)
 
-	return Convert_v1alpha4_KubeadmConfigTemplate_To_v1beta1_KubeadmConfigTemplate(src, dst, nil)
+	if err := Convert_v1alpha4_KubeadmConfigTemplate_To_v1beta1_KubeadmConfigTemplate(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1beta1.KubeadmConfigTemplate{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	RestoreKubeadmConfigSpec(&restored.Spec.Template.Spec, &dst.Spec.Template.Spec)
+
+	return nil
 }
 
 func (dst *KubeadmConfigTemplate) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.KubeadmConfigTemplate)
 
-	return Convert_v1beta1_KubeadmConfigTemplate_To_v1alpha4_KubeadmConfigTemplate(src, dst, nil)
+	if err := Convert_v1beta1_KubeadmConfigTemplate_To_v1alpha4_KubeadmConfigTemplate(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *KubeadmConfigTemplateList) ConvertTo(dstRaw conversion.Hub) error {
@@ -68,3 +104,31 @@ func (dst *KubeadmConfigTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
 
 	return Convert_v1beta1_KubeadmConfigTemplateList_To_v1alpha4_KubeadmConfigTemplateList(src, dst, nil)
 }
+
+// RestoreKubeadmConfigSpec restores the fields of a KubeadmConfigSpec which do not exist in v1alpha4,
+// so they are not lost when a v1beta1 object is round-tripped through v1alpha4.
+func RestoreKubeadmConfigSpec(restored *v1beta1.KubeadmConfigSpec, dst *v1beta1.KubeadmConfigSpec) {
+	if restored.InitConfiguration != nil && restored.InitConfiguration.Patches != nil {
+		if dst.InitConfiguration == nil {
+			dst.InitConfiguration = &v1beta1.InitConfiguration{}
+		}
+		dst.InitConfiguration.Patches = restored.InitConfiguration.Patches
+	}
+
+	if restored.JoinConfiguration != nil && restored.JoinConfiguration.Patches != nil {
+		if dst.JoinConfiguration == nil {
+			dst.JoinConfiguration = &v1beta1.JoinConfiguration{}
+		}
+		dst.JoinConfiguration.Patches = restored.JoinConfiguration.Patches
+	}
+}
+
+func Convert_v1beta1_InitConfiguration_To_v1alpha4_InitConfiguration(in *v1beta1.InitConfiguration, out *InitConfiguration, s apiconversion.Scope) error {
+	// InitConfiguration.Patches has been added with v1beta1.
+	return autoConvert_v1beta1_InitConfiguration_To_v1alpha4_InitConfiguration(in, out, s)
+}
+
+func Convert_v1beta1_JoinConfiguration_To_v1alpha4_JoinConfiguration(in *v1beta1.JoinConfiguration, out *JoinConfiguration, s apiconversion.Scope) error {
+	// JoinConfiguration.Patches has been added with v1beta1.
+	return autoConvert_v1beta1_JoinConfiguration_To_v1alpha4_JoinConfiguration(in, out, s)
+}
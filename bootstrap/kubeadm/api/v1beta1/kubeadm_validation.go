@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api/util/version"
+)
+
+var (
+	invalidExtraArgKeyMsg    = "must be a flag name without leading dashes and without values, e.g. \"v\" instead of \"--v=2\""
+	invalidPreflightErrorMsg = "must be the name of a kubeadm preflight check, without whitespaces or commas"
+	allPreflightErrorsMsg    = "\"all\" cannot be used together with the names of individual kubeadm preflight checks"
+)
+
+// kubeadmFeatureGate defines the Kubernetes versions supporting a kubeadm feature gate.
+type kubeadmFeatureGate struct {
+	// minVersion is the first Kubernetes version supporting the feature gate.
+	minVersion semver.Version
+
+	// maxVersion, if set, is the first Kubernetes version not supporting the feature gate anymore.
+	maxVersion *semver.Version
+}
+
+// kubeadmFeatureGates are the kubeadm feature gates with the Kubernetes versions supporting them.
+// NOTE: feature gates not included here are not validated, so feature gates added by new kubeadm releases can be used
+// before being added to this list.
+var kubeadmFeatureGates = map[string]kubeadmFeatureGate{
+	"IPv6DualStack":               {minVersion: semver.MustParse("1.16.0"), maxVersion: semverPtr(semver.MustParse("1.24.0"))},
+	"PublicKeysECDSA":             {minVersion: semver.MustParse("1.19.0")},
+	"RootlessControlPlane":        {minVersion: semver.MustParse("1.22.0")},
+	"UnversionedKubeletConfigMap": {minVersion: semver.MustParse("1.22.0")},
+}
+
+func semverPtr(v semver.Version) *semver.Version {
+	return &v
+}
+
+// validateKubeadmConfiguration detects common mistakes in the kubeadm configuration of a KubeadmConfigSpec,
+// which otherwise surface only when kubeadm runs on the machine.
+func (c *KubeadmConfigSpec) validateKubeadmConfiguration(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if c.ClusterConfiguration != nil {
+		path := pathPrefix.Child("clusterConfiguration")
+		allErrs = append(allErrs, validateExtraArgs(c.ClusterConfiguration.APIServer.ExtraArgs, path.Child("apiServer", "extraArgs"))...)
+		allErrs = append(allErrs, validateExtraArgs(c.ClusterConfiguration.ControllerManager.ExtraArgs, path.Child("controllerManager", "extraArgs"))...)
+		allErrs = append(allErrs, validateExtraArgs(c.ClusterConfiguration.Scheduler.ExtraArgs, path.Child("scheduler", "extraArgs"))...)
+		if c.ClusterConfiguration.Etcd.Local != nil {
+			allErrs = append(allErrs, validateExtraArgs(c.ClusterConfiguration.Etcd.Local.ExtraArgs, path.Child("etcd", "local", "extraArgs"))...)
+		}
+		allErrs = append(allErrs, validateFeatureGates(c.ClusterConfiguration.FeatureGates, c.ClusterConfiguration.KubernetesVersion, path.Child("featureGates"))...)
+	}
+
+	if c.InitConfiguration != nil {
+		allErrs = append(allErrs, validateNodeRegistration(c.InitConfiguration.NodeRegistration, pathPrefix.Child("initConfiguration", "nodeRegistration"))...)
+	}
+	if c.JoinConfiguration != nil {
+		allErrs = append(allErrs, validateNodeRegistration(c.JoinConfiguration.NodeRegistration, pathPrefix.Child("joinConfiguration", "nodeRegistration"))...)
+	}
+
+	return allErrs
+}
+
+func validateNodeRegistration(nodeRegistration NodeRegistrationOptions, path *field.Path) field.ErrorList {
+	allErrs := validateExtraArgs(nodeRegistration.KubeletExtraArgs, path.Child("kubeletExtraArgs"))
+
+	hasAll := false
+	for i, name := range nodeRegistration.IgnorePreflightErrors {
+		if name == "" || strings.ContainsAny(name, ", \t\n") {
+			allErrs = append(allErrs, field.Invalid(path.Child("ignorePreflightErrors").Index(i), name, invalidPreflightErrorMsg))
+		}
+		if strings.EqualFold(name, "all") {
+			hasAll = true
+		}
+	}
+	if hasAll && len(nodeRegistration.IgnorePreflightErrors) > 1 {
+		allErrs = append(allErrs, field.Invalid(path.Child("ignorePreflightErrors"), nodeRegistration.IgnorePreflightErrors, allPreflightErrorsMsg))
+	}
+
+	return allErrs
+}
+
+// validateExtraArgs checks the keys of extraArgs are flag names; kubeadm adds the leading dashes and
+// the value itself, so keys like "--v" or "v=2" generate invalid command lines.
+func validateExtraArgs(extraArgs map[string]string, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Sort the keys, so errors are reported in a stable order.
+	keys := make([]string, 0, len(extraArgs))
+	for key := range extraArgs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if key == "" || strings.HasPrefix(key, "-") || strings.ContainsAny(key, "= \t\n") {
+			allErrs = append(allErrs, field.Invalid(path.Key(key), key, invalidExtraArgKeyMsg))
+		}
+	}
+	return allErrs
+}
+
+// validateFeatureGates checks the kubeadm feature gates are supported by the target Kubernetes version, if known.
+func validateFeatureGates(featureGates map[string]bool, kubernetesVersion string, path *field.Path) field.ErrorList {
+	if len(featureGates) == 0 || kubernetesVersion == "" {
+		return nil
+	}
+	parsed, err := version.ParseMajorMinorPatchTolerant(kubernetesVersion)
+	if err != nil {
+		// Invalid versions, e.g. CI versions like ci/latest, are not validated here.
+		return nil
+	}
+	// Only major and minor are compared, so pre-releases of a minor support the same feature gates.
+	v := semver.Version{Major: parsed.Major, Minor: parsed.Minor}
+
+	var allErrs field.ErrorList
+	names := make([]string, 0, len(featureGates))
+	for name := range featureGates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		gate, ok := kubeadmFeatureGates[name]
+		if !ok {
+			continue
+		}
+		if v.LT(gate.minVersion) {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), featureGates[name],
+				fmt.Sprintf("kubeadm feature gate %s is supported only from Kubernetes v%s, while the target version is %s", name, gate.minVersion, kubernetesVersion)))
+		}
+		if gate.maxVersion != nil && v.GTE(*gate.maxVersion) {
+			allErrs = append(allErrs, field.Invalid(path.Key(name), featureGates[name],
+				fmt.Sprintf("kubeadm feature gate %s has been removed in Kubernetes v%s, while the target version is %s", name, *gate.maxVersion, kubernetesVersion)))
+		}
+	}
+	return allErrs
+}
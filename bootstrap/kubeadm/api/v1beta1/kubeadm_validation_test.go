@@ -0,0 +1,158 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestKubeadmConfigSpecValidateKubeadmConfiguration(t *testing.T) {
+	cases := map[string]struct {
+		spec      KubeadmConfigSpec
+		expectErr bool
+	}{
+		"valid configuration": {
+			spec: KubeadmConfigSpec{
+				ClusterConfiguration: &ClusterConfiguration{
+					KubernetesVersion: "v1.22.0",
+					APIServer: APIServer{
+						ControlPlaneComponent: ControlPlaneComponent{ExtraArgs: map[string]string{"audit-log-maxage": "30"}},
+					},
+					FeatureGates: map[string]bool{"RootlessControlPlane": true, "SomeFutureGate": true},
+				},
+				InitConfiguration: &InitConfiguration{
+					NodeRegistration: NodeRegistrationOptions{
+						KubeletExtraArgs:      map[string]string{"v": "2"},
+						IgnorePreflightErrors: []string{"NumCPU", "Mem"},
+					},
+				},
+			},
+		},
+		"init and join configuration": {
+			// Templates commonly set both, because the same template is used for the first and the other machines.
+			spec: KubeadmConfigSpec{
+				InitConfiguration: &InitConfiguration{},
+				JoinConfiguration: &JoinConfiguration{},
+			},
+		},
+		"extraArgs key with leading dashes": {
+			spec: KubeadmConfigSpec{
+				ClusterConfiguration: &ClusterConfiguration{
+					ControllerManager: ControlPlaneComponent{ExtraArgs: map[string]string{"--v": "2"}},
+				},
+			},
+			expectErr: true,
+		},
+		"extraArgs key with value": {
+			spec: KubeadmConfigSpec{
+				ClusterConfiguration: &ClusterConfiguration{
+					Etcd: Etcd{Local: &LocalEtcd{ExtraArgs: map[string]string{"quota-backend-bytes=8589934592": ""}}},
+				},
+			},
+			expectErr: true,
+		},
+		"kubeletExtraArgs key with leading dashes": {
+			spec: KubeadmConfigSpec{
+				JoinConfiguration: &JoinConfiguration{
+					NodeRegistration: NodeRegistrationOptions{KubeletExtraArgs: map[string]string{"--node-labels": "foo=bar"}},
+				},
+			},
+			expectErr: true,
+		},
+		"ignorePreflightErrors with commas": {
+			spec: KubeadmConfigSpec{
+				JoinConfiguration: &JoinConfiguration{
+					NodeRegistration: NodeRegistrationOptions{IgnorePreflightErrors: []string{"NumCPU,Mem"}},
+				},
+			},
+			expectErr: true,
+		},
+		"ignorePreflightErrors with all and individual checks": {
+			spec: KubeadmConfigSpec{
+				InitConfiguration: &InitConfiguration{
+					NodeRegistration: NodeRegistrationOptions{IgnorePreflightErrors: []string{"all", "NumCPU"}},
+				},
+			},
+			expectErr: true,
+		},
+		"feature gate not supported yet by the target version": {
+			spec: KubeadmConfigSpec{
+				ClusterConfiguration: &ClusterConfiguration{
+					KubernetesVersion: "v1.21.2",
+					FeatureGates:      map[string]bool{"RootlessControlPlane": true},
+				},
+			},
+			expectErr: true,
+		},
+		"feature gate removed in the target version": {
+			spec: KubeadmConfigSpec{
+				ClusterConfiguration: &ClusterConfiguration{
+					KubernetesVersion: "v1.24.0-alpha.1",
+					FeatureGates:      map[string]bool{"IPv6DualStack": true},
+				},
+			},
+			expectErr: true,
+		},
+		"feature gate without target version": {
+			spec: KubeadmConfigSpec{
+				ClusterConfiguration: &ClusterConfiguration{
+					FeatureGates: map[string]bool{"RootlessControlPlane": true},
+				},
+			},
+		},
+	}
+
+	for name, tt := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			config := &KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: metav1.NamespaceDefault},
+				Spec:       tt.spec,
+			}
+			template := &KubeadmConfigTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "baz", Namespace: metav1.NamespaceDefault},
+				Spec:       KubeadmConfigTemplateSpec{Template: KubeadmConfigTemplateResource{Spec: tt.spec}},
+			}
+			if tt.expectErr {
+				g.Expect(config.ValidateCreate()).NotTo(Succeed())
+				g.Expect(config.ValidateUpdate(&KubeadmConfig{})).NotTo(Succeed())
+				g.Expect(template.ValidateCreate()).NotTo(Succeed())
+				g.Expect(template.ValidateUpdate(&KubeadmConfigTemplate{})).NotTo(Succeed())
+
+				// Updates not changing the spec are allowed.
+				g.Expect(config.ValidateUpdate(config.DeepCopy())).To(Succeed())
+				g.Expect(template.ValidateUpdate(template.DeepCopy())).To(Succeed())
+
+				// Objects cloned from a template are allowed.
+				config.Annotations = map[string]string{clusterv1.TemplateClonedFromNameAnnotation: "foo"}
+				template.Annotations = map[string]string{clusterv1.TemplateClonedFromNameAnnotation: "foo"}
+				g.Expect(config.ValidateCreate()).To(Succeed())
+				g.Expect(template.ValidateCreate()).To(Succeed())
+			} else {
+				g.Expect(config.ValidateCreate()).To(Succeed())
+				g.Expect(config.ValidateUpdate(&KubeadmConfig{})).To(Succeed())
+				g.Expect(template.ValidateCreate()).To(Succeed())
+				g.Expect(template.ValidateUpdate(&KubeadmConfigTemplate{})).To(Succeed())
+			}
+		})
+	}
+}
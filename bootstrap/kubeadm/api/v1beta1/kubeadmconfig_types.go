@@ -30,6 +30,11 @@ const (
 	CloudConfig Format = "cloud-config"
 )
 
+// UsersDataSecretSuffix is the suffix appended to the KubeadmConfig name for the Secret where the users data
+// of an already bootstrapped node are stored; this Secret is kept up to date with KubeadmConfigSpec.Users, and node-side
+// agents can apply its content in order to update users and SSH authorized keys without replacing the Machine.
+const UsersDataSecretSuffix = "-users"
+
 // KubeadmConfigSpec defines the desired state of KubeadmConfig.
 // Either ClusterConfiguration and InitConfiguration should be defined or the JoinConfiguration should be defined.
 type KubeadmConfigSpec struct {
@@ -17,9 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"reflect"
+
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -43,12 +47,24 @@ var _ webhook.Validator = &KubeadmConfig{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (c *KubeadmConfig) ValidateCreate() error {
-	return c.Spec.validate(c.Name)
+	allErrs := c.Spec.validate(field.NewPath("spec"))
+	// KubeadmConfigs cloned from a KubeadmConfigTemplate are already validated by the KubeadmConfigTemplate webhook;
+	// skip the kubeadm configuration checks, so Machines can still be created from templates created before they were introduced.
+	if _, ok := c.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]; !ok {
+		allErrs = append(allErrs, c.Spec.validateKubeadmConfiguration(field.NewPath("spec"))...)
+	}
+	return aggregateObjErrors(GroupVersion.WithKind("KubeadmConfig").GroupKind(), c.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (c *KubeadmConfig) ValidateUpdate(old runtime.Object) error {
-	return c.Spec.validate(c.Name)
+	allErrs := c.Spec.validate(field.NewPath("spec"))
+	// The kubeadm configuration checks are skipped if the spec is not changed, so objects created before they
+	// were introduced can still be updated, e.g. to set OwnerReferences.
+	if oldConfig, ok := old.(*KubeadmConfig); !ok || !reflect.DeepEqual(oldConfig.Spec, c.Spec) {
+		allErrs = append(allErrs, c.Spec.validateKubeadmConfiguration(field.NewPath("spec"))...)
+	}
+	return aggregateObjErrors(GroupVersion.WithKind("KubeadmConfig").GroupKind(), c.Name, allErrs)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -56,7 +72,7 @@ func (c *KubeadmConfig) ValidateDelete() error {
 	return nil
 }
 
-func (c *KubeadmConfigSpec) validate(name string) error {
+func (c *KubeadmConfigSpec) validate(pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
 	knownPaths := map[string]struct{}{}
@@ -67,7 +83,7 @@ func (c *KubeadmConfigSpec) validate(name string) error {
 			allErrs = append(
 				allErrs,
 				field.Invalid(
-					field.NewPath("spec", "files").Index(i),
+					pathPrefix.Child("files").Index(i),
 					file,
 					conflictingFileSourceMsg,
 				),
@@ -81,7 +97,7 @@ func (c *KubeadmConfigSpec) validate(name string) error {
 				allErrs = append(
 					allErrs,
 					field.Invalid(
-						field.NewPath("spec", "files").Index(i).Child("contentFrom", "secret", "name"),
+						pathPrefix.Child("files").Index(i).Child("contentFrom", "secret", "name"),
 						file,
 						missingSecretNameMsg,
 					),
@@ -91,7 +107,7 @@ func (c *KubeadmConfigSpec) validate(name string) error {
 				allErrs = append(
 					allErrs,
 					field.Invalid(
-						field.NewPath("spec", "files").Index(i).Child("contentFrom", "secret", "key"),
+						pathPrefix.Child("files").Index(i).Child("contentFrom", "secret", "key"),
 						file,
 						missingSecretKeyMsg,
 					),
@@ -103,7 +119,7 @@ func (c *KubeadmConfigSpec) validate(name string) error {
 			allErrs = append(
 				allErrs,
 				field.Invalid(
-					field.NewPath("spec", "files").Index(i).Child("path"),
+					pathPrefix.Child("files").Index(i).Child("path"),
 					file,
 					pathConflictMsg,
 				),
@@ -112,8 +128,12 @@ func (c *KubeadmConfigSpec) validate(name string) error {
 		knownPaths[file.Path] = struct{}{}
 	}
 
+	return allErrs
+}
+
+func aggregateObjErrors(gk schema.GroupKind, name string, allErrs field.ErrorList) error {
 	if len(allErrs) == 0 {
 		return nil
 	}
-	return apierrors.NewInvalid(GroupVersion.WithKind("KubeadmConfig").GroupKind(), name, allErrs)
+	return apierrors.NewInvalid(gk, name, allErrs)
 }
@@ -17,7 +17,13 @@ limitations under the License.
 package v1beta1
 
 import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 func (r *KubeadmConfigTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -25,3 +31,34 @@ func (r *KubeadmConfigTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error
 		For(r).
 		Complete()
 }
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-bootstrap-cluster-x-k8s-io-v1beta1-kubeadmconfigtemplate,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigtemplates,versions=v1beta1,name=validation.kubeadmconfigtemplate.bootstrap.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+var _ webhook.Validator = &KubeadmConfigTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *KubeadmConfigTemplate) ValidateCreate() error {
+	allErrs := r.Spec.Template.Spec.validate(field.NewPath("spec", "template", "spec"))
+	// KubeadmConfigTemplates cloned from another template, e.g. by the topology controller, are validated when the source
+	// template is created; skip the kubeadm configuration checks, so templates created before they were introduced can still be used.
+	if _, ok := r.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]; !ok {
+		allErrs = append(allErrs, r.Spec.Template.Spec.validateKubeadmConfiguration(field.NewPath("spec", "template", "spec"))...)
+	}
+	return aggregateObjErrors(GroupVersion.WithKind("KubeadmConfigTemplate").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *KubeadmConfigTemplate) ValidateUpdate(old runtime.Object) error {
+	allErrs := r.Spec.Template.Spec.validate(field.NewPath("spec", "template", "spec"))
+	// The kubeadm configuration checks are skipped if the spec is not changed, so objects created before they
+	// were introduced can still be updated, e.g. to set OwnerReferences.
+	if oldTemplate, ok := old.(*KubeadmConfigTemplate); !ok || !reflect.DeepEqual(oldTemplate.Spec, r.Spec) {
+		allErrs = append(allErrs, r.Spec.Template.Spec.validateKubeadmConfiguration(field.NewPath("spec", "template", "spec"))...)
+	}
+	return aggregateObjErrors(GroupVersion.WithKind("KubeadmConfigTemplate").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *KubeadmConfigTemplate) ValidateDelete() error {
+	return nil
+}
@@ -16,7 +16,15 @@ limitations under the License.
 
 package cloudinit
 
+import (
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
 const (
+	usersCloudInit = `{{.Header}}
+{{- template "users" .Users }}
+`
+
 	usersTemplate = `{{ define "users" -}}
 {{- if . }}
 users:{{ range . }}
@@ -58,3 +66,18 @@ users:{{ range . }}
 {{- end -}}
 `
 )
+
+// UsersInput defines the context to generate the users data of a node.
+type UsersInput struct {
+	Header string
+	Users  []bootstrapv1.User
+}
+
+// NewUsers returns the user data string containing only the users section, to be used for updating the users
+// of an existing node without re-running the whole bootstrap process.
+func NewUsers(users []bootstrapv1.User) ([]byte, error) {
+	return generate("Users", usersCloudInit, &UsersInput{
+		Header: cloudConfigHeader,
+		Users:  users,
+	})
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudinit
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+func TestNewUsers(t *testing.T) {
+	g := NewWithT(t)
+
+	out, err := NewUsers([]bootstrapv1.User{
+		{
+			Name:              "capi",
+			SSHAuthorizedKeys: []string{"ssh-rsa AAAA1", "ssh-rsa AAAA2"},
+		},
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(string(out)).To(Equal(`## template: jinja
+#cloud-config
+
+users:
+  - name: capi
+    ssh_authorized_keys:
+      - ssh-rsa AAAA1
+      - ssh-rsa AAAA2
+`))
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package controllers
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"reflect"
 	"strconv"
 	"time"
 
@@ -35,6 +37,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/cloudinit"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/kubelet"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/locking"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	bsutil "sigs.k8s.io/cluster-api/bootstrap/util"
@@ -71,6 +74,7 @@ type InitLocker interface {
 }
 
 // +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigs;kubeadmconfigs/status;kubeadmconfigs/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigtemplates,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status;machines;machines/status;machinepools;machinepools/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets;events;configmaps,verbs=get;list;watch;create;update;patch;delete
 
@@ -114,6 +118,10 @@ func (r *KubeadmConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 		Watches(
 			&source.Kind{Type: &clusterv1.Machine{}},
 			handler.EnqueueRequestsFromMapFunc(r.MachineToBootstrapMapFunc),
+		).
+		Watches(
+			&source.Kind{Type: &bootstrapv1.KubeadmConfigTemplate{}},
+			handler.EnqueueRequestsFromMapFunc(r.KubeadmConfigTemplateToKubeadmConfigs),
 		).WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue))
 
 	if feature.Gates.Enabled(feature.MachinePool) {
@@ -243,6 +251,13 @@ func (r *KubeadmConfigReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, nil
 	// Status is ready means a config has been generated.
 	case config.Status.Ready:
+		// Keep the users data up to date, so users and SSH authorized keys can be updated without a rollout.
+		if err := r.syncUsersFromTemplate(ctx, scope); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileUsersData(ctx, scope); err != nil {
+			return ctrl.Result{}, err
+		}
 		if config.Spec.JoinConfiguration != nil && config.Spec.JoinConfiguration.Discovery.BootstrapToken != nil {
 			if !configOwner.IsInfrastructureReady() {
 				// If the BootstrapToken has been generated for a join and the infrastructure is not ready.
@@ -397,7 +412,7 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 			},
 		}
 	}
-	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(scope.Config.Spec.InitConfiguration, parsedVersion)
+	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(scope.Config.Spec.ClusterConfiguration, scope.Config.Spec.InitConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal init configuration")
 		return ctrl.Result{}, err
@@ -444,6 +459,7 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
 	}
+	files = append(files, kubelet.DropIns(parsedVersion)...)
 
 	cloudInitData, err := cloudinit.NewInitControlPlane(&cloudinit.ControlPlaneInput{
 		BaseUserData: cloudinit.BaseUserData{
@@ -525,6 +541,7 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
 	}
+	files = append(files, kubelet.DropIns(parsedVersion)...)
 
 	cloudJoinData, err := cloudinit.NewNode(&cloudinit.NodeInput{
 		BaseUserData: cloudinit.BaseUserData{
@@ -608,6 +625,7 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
 	}
+	files = append(files, kubelet.DropIns(parsedVersion)...)
 
 	cloudJoinData, err := cloudinit.NewJoinControlPlane(&cloudinit.ControlPlaneJoinInput{
 		JoinConfiguration: joinData,
@@ -739,6 +757,29 @@ func (r *KubeadmConfigReconciler) MachineToBootstrapMapFunc(o client.Object) []c
 	return result
 }
 
+// KubeadmConfigTemplateToKubeadmConfigs is a handler.ToRequestsFunc to be used to enqueue
+// requests for reconciliation of the KubeadmConfigs cloned from a KubeadmConfigTemplate.
+func (r *KubeadmConfigReconciler) KubeadmConfigTemplateToKubeadmConfigs(o client.Object) []ctrl.Request {
+	t, ok := o.(*bootstrapv1.KubeadmConfigTemplate)
+	if !ok {
+		panic(fmt.Sprintf("Expected a KubeadmConfigTemplate but got a %T", o))
+	}
+
+	configList := &bootstrapv1.KubeadmConfigList{}
+	if err := r.Client.List(context.TODO(), configList, client.InNamespace(t.Namespace)); err != nil {
+		return nil
+	}
+
+	result := []ctrl.Request{}
+	for _, c := range configList.Items {
+		if c.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation] == t.Name &&
+			c.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation] == bootstrapv1.GroupVersion.WithKind("KubeadmConfigTemplate").GroupKind().String() {
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: c.Namespace, Name: c.Name}})
+		}
+	}
+	return result
+}
+
 // MachinePoolToBootstrapMapFunc is a handler.ToRequestsFunc to be used to enqueue
 // request for reconciliation of KubeadmConfig.
 func (r *KubeadmConfigReconciler) MachinePoolToBootstrapMapFunc(o client.Object) []ctrl.Request {
@@ -867,12 +908,106 @@ func (r *KubeadmConfigReconciler) reconcileTopLevelObjectSettings(ctx context.Co
 	}
 }
 
+// syncUsersFromTemplate propagates the users defined in the KubeadmConfigTemplate the KubeadmConfig has been cloned from,
+// e.g. by a MachineSet, to the KubeadmConfig, so users of machines not belonging to a control plane can be updated by
+// changing the KubeadmConfigTemplate, without a rollout.
+func (r *KubeadmConfigReconciler) syncUsersFromTemplate(ctx context.Context, scope *Scope) error {
+	templateName, ok := scope.Config.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]
+	if !ok || scope.Config.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation] != bootstrapv1.GroupVersion.WithKind("KubeadmConfigTemplate").GroupKind().String() {
+		return nil
+	}
+
+	template := &bootstrapv1.KubeadmConfigTemplate{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: scope.Config.Namespace, Name: templateName}, template); err != nil {
+		// The template might have been deleted after the machines have been created, e.g. during a rollout.
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get KubeadmConfigTemplate %s/%s", scope.Config.Namespace, templateName)
+	}
+
+	if !reflect.DeepEqual(scope.Config.Spec.Users, template.Spec.Template.Spec.Users) {
+		scope.Info("Updating users from KubeadmConfigTemplate", "KubeadmConfigTemplate", templateName)
+		scope.Config.Spec.Users = template.Spec.Template.Spec.DeepCopy().Users
+	}
+	return nil
+}
+
+// reconcileUsersData creates or updates the secret storing the users data of an already bootstrapped node, if users are
+// defined in the KubeadmConfig or if the secret already exists, e.g. because all the users have been removed.
+func (r *KubeadmConfigReconciler) reconcileUsersData(ctx context.Context, scope *Scope) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	secretName := scope.Config.Name + bootstrapv1.UsersDataSecretSuffix
+	existing := &corev1.Secret{}
+	exists := true
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: scope.Config.Namespace, Name: secretName}, existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get users data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+		}
+		exists = false
+	}
+	if !exists && len(scope.Config.Spec.Users) == 0 {
+		return nil
+	}
+
+	data, err := cloudinit.NewUsers(scope.Config.Spec.Users)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate users data for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+	}
+
+	if !exists {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: scope.Config.Namespace,
+				Labels: map[string]string{
+					clusterv1.ClusterLabelName: scope.Cluster.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: bootstrapv1.GroupVersion.String(),
+						Kind:       "KubeadmConfig",
+						Name:       scope.Config.Name,
+						UID:        scope.Config.UID,
+						Controller: pointer.BoolPtr(true),
+					},
+				},
+			},
+			Data: map[string][]byte{
+				"value": data,
+			},
+			Type: clusterv1.ClusterSecretType,
+		}
+		log.Info("Creating users data secret for KubeadmConfig", "secret", secret.Name)
+		if err := r.Client.Create(ctx, secret); err != nil {
+			return errors.Wrapf(err, "failed to create users data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+		}
+		return nil
+	}
+
+	if bytes.Equal(existing.Data["value"], data) {
+		return nil
+	}
+	log.Info("Updating users data secret for KubeadmConfig", "secret", existing.Name)
+	if existing.Data == nil {
+		existing.Data = map[string][]byte{}
+	}
+	existing.Data["value"] = data
+	if err := r.Client.Update(ctx, existing); err != nil {
+		return errors.Wrapf(err, "failed to update users data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+	}
+	return nil
+}
+
 // storeBootstrapData creates a new secret with the data passed in as input,
 // sets the reference in the configuration status and ready to true.
+// Bootstrap data exceeding the size of a single secret is split across multiple secrets, as described by
+// secret.BootstrapDataSecrets.
 func (r *KubeadmConfigReconciler) storeBootstrapData(ctx context.Context, scope *Scope, data []byte) error {
 	log := ctrl.LoggerFrom(ctx)
 
-	secret := &corev1.Secret{
+	template := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      scope.Config.Name,
 			Namespace: scope.Config.Namespace,
@@ -889,24 +1024,24 @@ func (r *KubeadmConfigReconciler) storeBootstrapData(ctx context.Context, scope
 				},
 			},
 		},
-		Data: map[string][]byte{
-			"value": data,
-		},
 		Type: clusterv1.ClusterSecretType,
 	}
 
-	// as secret creation and scope.Config status patch are not atomic operations
-	// it is possible that secret creation happens but the config.Status patches are not applied
-	if err := r.Client.Create(ctx, secret); err != nil {
-		if !apierrors.IsAlreadyExists(err) {
-			return errors.Wrapf(err, "failed to create bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
-		}
-		log.Info("bootstrap data secret for KubeadmConfig already exists, updating", "secret", secret.Name, "KubeadmConfig", scope.Config.Name)
-		if err := r.Client.Update(ctx, secret); err != nil {
-			return errors.Wrapf(err, "failed to update bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+	// The secret referenced by the status is the last one, so it is written only after all the chunks it refers to.
+	for _, dataSecret := range secret.BootstrapDataSecrets(template, data) {
+		// as secret creation and scope.Config status patch are not atomic operations
+		// it is possible that secret creation happens but the config.Status patches are not applied
+		if err := r.Client.Create(ctx, dataSecret); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return errors.Wrapf(err, "failed to create bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+			}
+			log.Info("bootstrap data secret for KubeadmConfig already exists, updating", "secret", dataSecret.Name, "KubeadmConfig", scope.Config.Name)
+			if err := r.Client.Update(ctx, dataSecret); err != nil {
+				return errors.Wrapf(err, "failed to update bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+			}
 		}
 	}
-	scope.Config.Status.DataSecretName = pointer.StringPtr(secret.Name)
+	scope.Config.Status.DataSecretName = pointer.StringPtr(template.Name)
 	scope.Config.Status.Ready = true
 	conditions.MarkTrue(scope.Config, bootstrapv1.DataSecretAvailableCondition)
 	return nil
@@ -165,6 +165,108 @@ func TestKubeadmConfigReconciler_Reconcile_ReturnEarlyIfMachineHasDataSecretName
 	g.Expect(result.RequeueAfter).To(Equal(time.Duration(0)))
 }
 
+func TestKubeadmConfigReconciler_Reconcile_UpdatesUsersDataIfKubeadmConfigIsReady(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("cluster", metav1.NamespaceDefault)
+	cluster.Status.InfrastructureReady = true
+	machine := newMachine(cluster, "machine", metav1.NamespaceDefault)
+	config := newWorkerJoinKubeadmConfig(machine)
+	config.Spec.Users = []bootstrapv1.User{
+		{
+			Name:              "capi",
+			SSHAuthorizedKeys: []string{"ssh-rsa AAAA1"},
+		},
+	}
+	config.Status.Ready = true
+	config.Status.DataSecretName = pointer.StringPtr(config.Name)
+	machine.Spec.Bootstrap.DataSecretName = pointer.StringPtr(config.Name)
+
+	myclient := fake.NewClientBuilder().WithObjects(cluster, machine, config).Build()
+	k := &KubeadmConfigReconciler{
+		Client: myclient,
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      config.Name,
+		},
+	}
+	secretKey := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: config.Name + bootstrapv1.UsersDataSecretSuffix}
+
+	// The users data secret is created for a ready config with users.
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+	usersSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, secretKey, usersSecret)).To(Succeed())
+	g.Expect(usersSecret.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, cluster.Name))
+	g.Expect(string(usersSecret.Data["value"])).To(ContainSubstring("ssh-rsa AAAA1"))
+
+	// The users data secret is updated when users change, without touching the bootstrap data.
+	updatedConfig := &bootstrapv1.KubeadmConfig{}
+	g.Expect(myclient.Get(ctx, request.NamespacedName, updatedConfig)).To(Succeed())
+	updatedConfig.Spec.Users[0].SSHAuthorizedKeys = []string{"ssh-rsa AAAA2"}
+	g.Expect(myclient.Update(ctx, updatedConfig)).To(Succeed())
+
+	_, err = k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(myclient.Get(ctx, secretKey, usersSecret)).To(Succeed())
+	g.Expect(string(usersSecret.Data["value"])).To(ContainSubstring("ssh-rsa AAAA2"))
+	g.Expect(string(usersSecret.Data["value"])).NotTo(ContainSubstring("ssh-rsa AAAA1"))
+	g.Expect(myclient.Get(ctx, request.NamespacedName, updatedConfig)).To(Succeed())
+	g.Expect(updatedConfig.Status.DataSecretName).To(Equal(pointer.StringPtr(config.Name)))
+}
+
+func TestKubeadmConfigReconciler_Reconcile_UpdatesUsersFromKubeadmConfigTemplate(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := newCluster("cluster", metav1.NamespaceDefault)
+	cluster.Status.InfrastructureReady = true
+	machine := newMachine(cluster, "machine", metav1.NamespaceDefault)
+	template := &bootstrapv1.KubeadmConfigTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "template"},
+		Spec: bootstrapv1.KubeadmConfigTemplateSpec{
+			Template: bootstrapv1.KubeadmConfigTemplateResource{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					Users: []bootstrapv1.User{{Name: "capi", SSHAuthorizedKeys: []string{"ssh-rsa AAAA2"}}},
+				},
+			},
+		},
+	}
+	config := newWorkerJoinKubeadmConfig(machine)
+	config.Annotations = map[string]string{
+		clusterv1.TemplateClonedFromNameAnnotation:      template.Name,
+		clusterv1.TemplateClonedFromGroupKindAnnotation: bootstrapv1.GroupVersion.WithKind("KubeadmConfigTemplate").GroupKind().String(),
+	}
+	config.Spec.Users = []bootstrapv1.User{{Name: "capi", SSHAuthorizedKeys: []string{"ssh-rsa AAAA1"}}}
+	config.Status.Ready = true
+	config.Status.DataSecretName = pointer.StringPtr(config.Name)
+	machine.Spec.Bootstrap.DataSecretName = pointer.StringPtr(config.Name)
+
+	myclient := fake.NewClientBuilder().WithObjects(cluster, machine, config, template).Build()
+	k := &KubeadmConfigReconciler{
+		Client: myclient,
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      config.Name,
+		},
+	}
+
+	g.Expect(k.KubeadmConfigTemplateToKubeadmConfigs(template)).To(ConsistOf(request))
+
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	updatedConfig := &bootstrapv1.KubeadmConfig{}
+	g.Expect(myclient.Get(ctx, request.NamespacedName, updatedConfig)).To(Succeed())
+	g.Expect(updatedConfig.Spec.Users).To(Equal(template.Spec.Template.Spec.Users))
+	usersSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: config.Name + bootstrapv1.UsersDataSecretSuffix}, usersSecret)).To(Succeed())
+	g.Expect(string(usersSecret.Data["value"])).To(ContainSubstring("ssh-rsa AAAA2"))
+}
+
 func TestKubeadmConfigReconciler_ReturnEarlyIfClusterInfraNotReady(t *testing.T) {
 	g := NewWithT(t)
 
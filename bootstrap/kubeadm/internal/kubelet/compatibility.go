@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kubelet implements the kubelet configuration drop-ins adapting the kubelet to the Kubernetes version of a node.
+package kubelet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+const (
+	// CompatibilityDropInPath is the path of the kubelet systemd drop-in managed by CABPK.
+	CompatibilityDropInPath = "/etc/systemd/system/kubelet.service.d/90-cluster-api-compatibility.conf"
+
+	// kubeadmFlagsEnvPath is the file where kubeadm writes the kubelet flags, including the kubeletExtraArgs.
+	kubeadmFlagsEnvPath = "/var/lib/kubelet/kubeadm-flags.env"
+)
+
+// minorCompatibility defines the changes to the kubelet configuration introduced by a Kubernetes minor.
+type minorCompatibility struct {
+	// minor is the Kubernetes minor introducing the changes.
+	minor semver.Version
+
+	// removedFlags are the kubelet flags removed in this minor.
+	removedFlags []string
+}
+
+// compatibilityTable lists the changes to the kubelet configuration for each Kubernetes minor, sorted by minor.
+// NOTE: when adding a new minor, only the changes with respect to the previous entries should be listed.
+var compatibilityTable = []minorCompatibility{
+	{
+		// dockershim has been removed in v1.24, and with it all the flags specific to docker.
+		minor: semver.MustParse("1.24.0"),
+		removedFlags: []string{
+			"cni-bin-dir",
+			"cni-cache-dir",
+			"cni-conf-dir",
+			"docker-endpoint",
+			"experimental-dockershim-root-directory",
+			"image-pull-progress-deadline",
+			"network-plugin",
+			"network-plugin-mtu",
+			"non-masquerade-cidr",
+		},
+	},
+}
+
+// Compatibility defines how the kubelet configuration must be adapted for a Kubernetes version.
+type Compatibility struct {
+	// RemovedFlags are the kubelet flags not supported anymore.
+	RemovedFlags []string
+}
+
+// ForVersion returns the Compatibility for a Kubernetes version, by combining all the entries in
+// the compatibility table for minors lower or equal to the minor of the given version.
+func ForVersion(version semver.Version) Compatibility {
+	// Pre-release and build metadata should not influence which minor a version belongs to.
+	minor := semver.Version{Major: version.Major, Minor: version.Minor}
+
+	c := Compatibility{}
+	for _, entry := range compatibilityTable {
+		if entry.minor.GT(minor) {
+			break
+		}
+		c.RemovedFlags = append(c.RemovedFlags, entry.removedFlags...)
+	}
+	return c
+}
+
+// DropIns returns the kubelet systemd drop-ins to be added to the bootstrap data of a node with the given Kubernetes
+// version, if any.
+// The compatibility drop-in adapts the flags written by kubeadm, including the kubeletExtraArgs of a KubeadmConfigTemplate
+// shared across Kubernetes minors, before the kubelet is started; kubeadm reloads the systemd configuration before
+// starting the kubelet, so the drop-in is picked up. The drop-in removes the flags not supported anymore by the kubelet.
+// NOTE: The cgroup driver is not set, so the value in the KubeletConfiguration generated by kubeadm applies, unless
+// the cgroup driver is set in kubeletExtraArgs; the kubeletExtraArgs in the KubeadmConfig are not modified.
+func DropIns(version semver.Version) []bootstrapv1.File {
+	c := ForVersion(version)
+	if len(c.RemovedFlags) == 0 {
+		return nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Managed by Cluster API: adapts the kubelet flags to Kubernetes v%d.%d.\n[Service]\n", version.Major, version.Minor)
+	flags := strings.Join(c.RemovedFlags, "|")
+	fmt.Fprintf(&sb, `ExecStartPre=-/bin/sed -i -E -e 's/ --(%[1]s)=[^ "]*//g' -e 's/"--(%[1]s)=[^ "]* ?/"/g' %[2]s`+"\n", flags, kubeadmFlagsEnvPath)
+
+	return []bootstrapv1.File{
+		{
+			Path:        CompatibilityDropInPath,
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     sb.String(),
+		},
+	}
+}
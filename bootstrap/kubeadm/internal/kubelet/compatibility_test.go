@@ -0,0 +1,132 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubelet
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/blang/semver"
+	. "github.com/onsi/gomega"
+)
+
+func TestForVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		version         string
+		wantRemovedFlag string
+	}{
+		{
+			name:    "no changes before v1.24",
+			version: "1.23.5",
+		},
+		{
+			name:            "dockershim flags removed starting from v1.24",
+			version:         "1.24.0-rc.0",
+			wantRemovedFlag: "network-plugin",
+		},
+		{
+			name:            "dockershim flags removed in later minors",
+			version:         "1.25.1",
+			wantRemovedFlag: "network-plugin",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := ForVersion(semver.MustParse(tt.version))
+			if tt.wantRemovedFlag == "" {
+				g.Expect(c.RemovedFlags).To(BeEmpty())
+				return
+			}
+			g.Expect(c.RemovedFlags).To(ContainElement(tt.wantRemovedFlag))
+		})
+	}
+}
+
+func TestDropIns(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(DropIns(semver.MustParse("1.20.5"))).To(BeEmpty())
+	g.Expect(DropIns(semver.MustParse("1.23.5"))).To(BeEmpty())
+
+	dropIns := DropIns(semver.MustParse("1.24.1"))
+	g.Expect(dropIns).To(HaveLen(1))
+	g.Expect(dropIns[0].Path).To(Equal(CompatibilityDropInPath))
+	g.Expect(dropIns[0].Content).To(ContainSubstring("[Service]"))
+	g.Expect(dropIns[0].Content).To(ContainSubstring("network-plugin"))
+	g.Expect(dropIns[0].Content).NotTo(ContainSubstring("cgroup-driver"))
+}
+
+func TestDropInsAdaptFlags(t *testing.T) {
+	if _, err := exec.LookPath("sed"); err != nil {
+		t.Skip("sed is not available")
+	}
+
+	tests := []struct {
+		name  string
+		flags string
+		want  string
+	}{
+		{
+			name:  "removes dockershim flags",
+			flags: `--network-plugin=cni --container-runtime=remote --network-plugin-mtu=1500 --node-labels=foo=bar --cni-bin-dir=/opt/cni/bin`,
+			want:  `--container-runtime=remote --node-labels=foo=bar`,
+		},
+		{
+			name:  "does not change the cgroup driver",
+			flags: `--network-plugin=cni --cgroup-driver=cgroupfs --container-runtime=remote`,
+			want:  `--cgroup-driver=cgroupfs --container-runtime=remote`,
+		},
+		{
+			name:  "does not set the cgroup driver",
+			flags: `--container-runtime=remote`,
+			want:  `--container-runtime=remote`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			envFile := filepath.Join(t.TempDir(), "kubeadm-flags.env")
+			g.Expect(os.WriteFile(envFile, []byte(`KUBELET_KUBEADM_ARGS="`+tt.flags+`"`+"\n"), 0600)).To(Succeed())
+
+			// Run the commands of the drop-in against a kubeadm-flags.env file, as the kubelet service would do.
+			dropIns := DropIns(semver.MustParse("1.24.1"))
+			g.Expect(dropIns).To(HaveLen(1))
+			matches := regexp.MustCompile(`ExecStartPre=-/bin/sed (.*) `+regexp.QuoteMeta(kubeadmFlagsEnvPath)).FindAllStringSubmatch(dropIns[0].Content, -1)
+			g.Expect(matches).NotTo(BeEmpty())
+			for _, match := range matches {
+				args := regexp.MustCompile(`'[^']*'|\S+`).FindAllString(match[1], -1)
+				for i := range args {
+					args[i] = strings.Trim(args[i], "'")
+				}
+				out, err := exec.Command("sed", append(args, envFile)...).CombinedOutput() //nolint:gosec
+				g.Expect(err).NotTo(HaveOccurred(), string(out))
+			}
+
+			got, err := os.ReadFile(envFile)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(got)).To(Equal(`KUBELET_KUBEADM_ARGS="` + tt.want + `"` + "\n"))
+		})
+	}
+}
@@ -80,6 +80,7 @@ var (
 	webhookPort                 int
 	webhookCertDir              string
 	healthAddr                  string
+	featureGatesFile            string
 	tokenTTL                    time.Duration
 )
 
@@ -128,6 +129,9 @@ func InitFlags(fs *pflag.FlagSet) {
 		"The address the health endpoint binds to.")
 
 	feature.MutableGates.AddFlag(fs)
+
+	fs.StringVar(&featureGatesFile, "feature-gates-file", "",
+		"Path of a file, usually mounted from a ConfigMap, setting the feature gates as a YAML map of feature gate names to booleans; the file is reloaded on changes and the controller restarts when feature gates change value. Feature gates set with --feature-gates take precedence")
 }
 
 func main() {
@@ -174,8 +178,12 @@ func main() {
 	}
 
 	// Setup the context that's going to be used in controllers and for the manager.
-	ctx := ctrl.SetupSignalHandler()
+	ctx, cancel := context.WithCancel(ctrl.SetupSignalHandler())
 
+	if err := feature.SetupGatesFileWithRestart(mgr, featureGatesFile, cancel); err != nil {
+		setupLog.Error(err, "unable to setup feature gates")
+		os.Exit(1)
+	}
 	setupChecks(mgr)
 	setupWebhooks(mgr)
 	setupReconcilers(ctx, mgr)
@@ -62,6 +62,8 @@ func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 		dnsFuzzer,
 		clusterConfigurationFuzzer,
 		kubeadmNodeRegistrationOptionsFuzzer,
+		kubeadmInitConfigurationFuzzer,
+		kubeadmJoinConfigurationFuzzer,
 	}
 }
 
@@ -86,3 +88,19 @@ func kubeadmNodeRegistrationOptionsFuzzer(obj *v1beta1.NodeRegistrationOptions,
 	// v1alpha4 --> v1beta1 -> v1alpha4 round trip errors.
 	obj.IgnorePreflightErrors = nil
 }
+
+func kubeadmInitConfigurationFuzzer(obj *v1beta1.InitConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// InitConfiguration.Patches does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstreamv1beta1 -> v1beta1 round trip errors.
+	obj.Patches = nil
+}
+
+func kubeadmJoinConfigurationFuzzer(obj *v1beta1.JoinConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// JoinConfiguration.Patches does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstreamv1beta1 -> v1beta1 round trip errors.
+	obj.Patches = nil
+}
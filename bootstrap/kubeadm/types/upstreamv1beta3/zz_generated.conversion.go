@@ -217,6 +217,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*Patches)(nil), (*v1beta1.Patches)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_upstreamv1beta3_Patches_To_v1beta1_Patches(a.(*Patches), b.(*v1beta1.Patches), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1beta1.Patches)(nil), (*Patches)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Patches_To_upstreamv1beta3_Patches(a.(*v1beta1.Patches), b.(*Patches), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*InitConfiguration)(nil), (*v1beta1.InitConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_upstreamv1beta3_InitConfiguration_To_v1beta1_InitConfiguration(a.(*InitConfiguration), b.(*v1beta1.InitConfiguration), scope)
 	}); err != nil {
@@ -634,6 +644,7 @@ func autoConvert_upstreamv1beta3_InitConfiguration_To_v1beta1_InitConfiguration(
 	}
 	// WARNING: in.CertificateKey requires manual conversion: does not exist in peer-type
 	// WARNING: in.SkipPhases requires manual conversion: does not exist in peer-type
+	out.Patches = (*v1beta1.Patches)(unsafe.Pointer(in.Patches))
 	return nil
 }
 
@@ -645,6 +656,7 @@ func autoConvert_v1beta1_InitConfiguration_To_upstreamv1beta3_InitConfiguration(
 	if err := Convert_v1beta1_APIEndpoint_To_upstreamv1beta3_APIEndpoint(&in.LocalAPIEndpoint, &out.LocalAPIEndpoint, s); err != nil {
 		return err
 	}
+	out.Patches = (*Patches)(unsafe.Pointer(in.Patches))
 	return nil
 }
 
@@ -671,6 +683,7 @@ func autoConvert_upstreamv1beta3_JoinConfiguration_To_v1beta1_JoinConfiguration(
 		out.ControlPlane = nil
 	}
 	// WARNING: in.SkipPhases requires manual conversion: does not exist in peer-type
+	out.Patches = (*v1beta1.Patches)(unsafe.Pointer(in.Patches))
 	return nil
 }
 
@@ -691,6 +704,7 @@ func autoConvert_v1beta1_JoinConfiguration_To_upstreamv1beta3_JoinConfiguration(
 	} else {
 		out.ControlPlane = nil
 	}
+	out.Patches = (*Patches)(unsafe.Pointer(in.Patches))
 	return nil
 }
 
@@ -797,3 +811,23 @@ func autoConvert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta3_NodeRegistra
 func Convert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta3_NodeRegistrationOptions(in *v1beta1.NodeRegistrationOptions, out *NodeRegistrationOptions, s conversion.Scope) error {
 	return autoConvert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta3_NodeRegistrationOptions(in, out, s)
 }
+
+func autoConvert_upstreamv1beta3_Patches_To_v1beta1_Patches(in *Patches, out *v1beta1.Patches, s conversion.Scope) error {
+	out.Directory = in.Directory
+	return nil
+}
+
+// Convert_upstreamv1beta3_Patches_To_v1beta1_Patches is an autogenerated conversion function.
+func Convert_upstreamv1beta3_Patches_To_v1beta1_Patches(in *Patches, out *v1beta1.Patches, s conversion.Scope) error {
+	return autoConvert_upstreamv1beta3_Patches_To_v1beta1_Patches(in, out, s)
+}
+
+func autoConvert_v1beta1_Patches_To_upstreamv1beta3_Patches(in *v1beta1.Patches, out *Patches, s conversion.Scope) error {
+	out.Directory = in.Directory
+	return nil
+}
+
+// Convert_v1beta1_Patches_To_upstreamv1beta3_Patches is an autogenerated conversion function.
+func Convert_v1beta1_Patches_To_upstreamv1beta3_Patches(in *v1beta1.Patches, out *Patches, s conversion.Scope) error {
+	return autoConvert_v1beta1_Patches_To_upstreamv1beta3_Patches(in, out, s)
+}
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta4"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/scheme"
 )
@@ -35,26 +36,30 @@ var (
 	v1beta1KubeadmVersion = semver.MustParse("1.13.0")
 	v1beta2KubeadmVersion = semver.MustParse("1.15.0")
 	v1beta3KubeadmVersion = semver.MustParse("1.22.0")
+	v1beta4KubeadmVersion = semver.MustParse("1.31.0")
 
 	clusterConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.ClusterConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.ClusterConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.ClusterConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.ClusterConfiguration{},
 	}
 
 	clusterStatusVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
-		// ClusterStatus has been removed in v1beta3, so we don't need an entry for v1beta3
+		// ClusterStatus has been removed in v1beta3, so we don't need an entry for v1beta3 and v1beta4
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.ClusterStatus{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.ClusterStatus{},
 	}
 
 	initConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.InitConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.InitConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.InitConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.InitConfiguration{},
 	}
 
 	joinConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.JoinConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.JoinConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.JoinConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.JoinConfiguration{},
@@ -72,14 +77,17 @@ func KubeVersionToKubeadmAPIGroupVersion(version semver.Version) (schema.GroupVe
 	case version.LT(v1beta3KubeadmVersion):
 		// NOTE: All the Kubernetes version >= v1.15 and < v1.22 should use the kubeadm API version v1beta2
 		return upstreamv1beta2.GroupVersion, nil
+	case version.LT(v1beta4KubeadmVersion):
+		// NOTE: All the Kubernetes version >= v1.22 and < v1.31 should use the kubeadm API version v1beta3
+		return upstreamv1beta3.GroupVersion, nil
 	default:
-		// NOTE: All the Kubernetes version greater or equal to v1.22 should use the kubeadm API version v1beta3.
-		// Also future Kubernetes versions (not yet released at the time of writing this code) are going to use v1beta3,
-		// no matter if kubeadm API versions newer than v1beta3 could be introduced by those release.
-		// This is acceptable because v1beta3 will be supported by kubeadm until the deprecation cycle completes
+		// NOTE: All the Kubernetes version greater or equal to v1.31 should use the kubeadm API version v1beta4.
+		// Also future Kubernetes versions (not yet released at the time of writing this code) are going to use v1beta4,
+		// no matter if kubeadm API versions newer than v1beta4 could be introduced by those release.
+		// This is acceptable because v1beta4 will be supported by kubeadm until the deprecation cycle completes
 		// (9 months minimum after the deprecation date, not yet announced now); this gives Cluster API project time to
 		// introduce support for newer releases without blocking users to deploy newer version of Kubernetes.
-		return upstreamv1beta3.GroupVersion, nil
+		return upstreamv1beta4.GroupVersion, nil
 	}
 }
 
@@ -98,20 +106,47 @@ func MarshalClusterStatusForVersion(obj *bootstrapv1.ClusterStatus, version semv
 }
 
 // MarshalInitConfigurationForVersion converts a Cluster API InitConfiguration type to the kubeadm API type
-// for the given Kubernetes Version.
+// for the given Kubernetes Version; clusterConfiguration, if not nil, is used to set the fields moved from the
+// ClusterConfiguration to the InitConfiguration in newer kubeadm API versions.
 // NOTE: This assumes Kubernetes Version equals to kubeadm version.
-func MarshalInitConfigurationForVersion(obj *bootstrapv1.InitConfiguration, version semver.Version) (string, error) {
-	return marshalForVersion(obj, version, initConfigurationVersionTypeMap)
+func MarshalInitConfigurationForVersion(clusterConfiguration *bootstrapv1.ClusterConfiguration, obj *bootstrapv1.InitConfiguration, version semver.Version) (string, error) {
+	if err := validatePatchesForVersion(obj.Patches, version); err != nil {
+		return "", err
+	}
+	return marshalForVersion(obj, version, initConfigurationVersionTypeMap, func(kubeadmObj conversion.Convertible) {
+		// The API server timeout has been moved from ClusterConfiguration.APIServer.TimeoutForControlPlane
+		// to InitConfiguration.Timeouts.ControlPlaneComponentHealthCheck in v1beta4.
+		initConfiguration, ok := kubeadmObj.(*upstreamv1beta4.InitConfiguration)
+		if !ok || clusterConfiguration == nil || clusterConfiguration.APIServer.TimeoutForControlPlane == nil {
+			return
+		}
+		if initConfiguration.Timeouts == nil {
+			initConfiguration.Timeouts = &upstreamv1beta4.Timeouts{}
+		}
+		initConfiguration.Timeouts.ControlPlaneComponentHealthCheck = clusterConfiguration.APIServer.TimeoutForControlPlane.DeepCopy()
+	})
 }
 
 // MarshalJoinConfigurationForVersion converts a Cluster API JoinConfiguration type to the kubeadm API type
 // for the given Kubernetes Version.
 // NOTE: This assumes Kubernetes Version equals to kubeadm version.
 func MarshalJoinConfigurationForVersion(obj *bootstrapv1.JoinConfiguration, version semver.Version) (string, error) {
+	if err := validatePatchesForVersion(obj.Patches, version); err != nil {
+		return "", err
+	}
 	return marshalForVersion(obj, version, joinConfigurationVersionTypeMap)
 }
 
-func marshalForVersion(obj conversion.Hub, version semver.Version, kubeadmObjVersionTypeMap map[schema.GroupVersion]conversion.Convertible) (string, error) {
+// validatePatchesForVersion returns an error if patches are set for a Kubernetes version using a kubeadm API which does not support them,
+// so patches are not silently dropped.
+func validatePatchesForVersion(patches *bootstrapv1.Patches, version semver.Version) error {
+	if patches != nil && version.LT(v1beta3KubeadmVersion) {
+		return errors.Errorf("patches are not supported for Kubernetes version %s, they require Kubernetes version %s or greater", version, v1beta3KubeadmVersion)
+	}
+	return nil
+}
+
+func marshalForVersion(obj conversion.Hub, version semver.Version, kubeadmObjVersionTypeMap map[schema.GroupVersion]conversion.Convertible, mutators ...func(conversion.Convertible)) (string, error) {
 	kubeadmAPIGroupVersion, err := KubeVersionToKubeadmAPIGroupVersion(version)
 	if err != nil {
 		return "", err
@@ -126,6 +161,9 @@ func marshalForVersion(obj conversion.Hub, version semver.Version, kubeadmObjVer
 	if err := targetKubeadmObj.ConvertFrom(obj); err != nil {
 		return "", errors.Wrapf(err, "failed to convert to KubeadmAPI type for version %s", kubeadmAPIGroupVersion)
 	}
+	for _, mutate := range mutators {
+		mutate(targetKubeadmObj)
+	}
 
 	codecs, err := getCodecsFor(kubeadmAPIGroupVersion, targetKubeadmObj)
 	if err != nil {
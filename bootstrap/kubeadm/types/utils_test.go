@@ -18,15 +18,18 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta4"
 )
 
 func TestKubeVersionToKubeadmAPIGroupVersion(t *testing.T) {
@@ -90,17 +93,25 @@ func TestKubeVersionToKubeadmAPIGroupVersion(t *testing.T) {
 		{
 			name: "pass with kubernetes version for kubeadm API v1beta3",
 			args: args{
-				version: semver.MustParse("1.23.99"),
+				version: semver.MustParse("1.30.99"),
 			},
 			want:    upstreamv1beta3.GroupVersion,
 			wantErr: false,
 		},
+		{
+			name: "pass with minimum kubernetes version for kubeadm API v1beta4",
+			args: args{
+				version: semver.MustParse("1.31.0"),
+			},
+			want:    upstreamv1beta4.GroupVersion,
+			wantErr: false,
+		},
 		{
 			name: "pass with future kubernetes version",
 			args: args{
 				version: semver.MustParse("99.99.99"),
 			},
-			want:    upstreamv1beta3.GroupVersion,
+			want:    upstreamv1beta4.GroupVersion,
 			wantErr: false,
 		},
 	}
@@ -178,6 +189,34 @@ func TestMarshalClusterConfigurationForVersion(t *testing.T) {
 				"scheduler: {}\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{
+						ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+							ExtraArgs: map[string]string{"foo": "bar", "bar": "baz"},
+						},
+						TimeoutForControlPlane: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiServer:\n" +
+				"  extraArgs:\n" +
+				"  - name: bar\n" +
+				"    value: baz\n" +
+				"  - name: foo\n" +
+				"    value: bar\n" +
+				"apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"controllerManager: {}\n" +
+				"dns: {}\n" +
+				"etcd: {}\n" +
+				"kind: ClusterConfiguration\n" +
+				"networking: {}\n" +
+				"scheduler: {}\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -253,8 +292,9 @@ func TestMarshalClusterStatusForVersion(t *testing.T) {
 
 func TestMarshalInitConfigurationForVersion(t *testing.T) {
 	type args struct {
-		capiObj *bootstrapv1.InitConfiguration
-		version semver.Version
+		clusterConfiguration *bootstrapv1.ClusterConfiguration
+		capiObj              *bootstrapv1.InitConfiguration
+		version              semver.Version
 	}
 	tests := []struct {
 		name    string
@@ -313,12 +353,70 @@ func TestMarshalInitConfigurationForVersion(t *testing.T) {
 				"  taints: null\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				clusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{
+						TimeoutForControlPlane: &metav1.Duration{Duration: 5 * time.Minute},
+					},
+				},
+				capiObj: &bootstrapv1.InitConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						KubeletExtraArgs: map[string]string{"v": "2"},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta4\n" +
+				"kind: InitConfiguration\n" +
+				"localAPIEndpoint: {}\n" +
+				"nodeRegistration:\n" +
+				"  kubeletExtraArgs:\n" +
+				"  - name: v\n" +
+				"    value: \"2\"\n" +
+				"  taints: null\n" +
+				"timeouts:\n" +
+				"  controlPlaneComponentHealthCheck: 5m0s\n",
+			wantErr: false,
+		},
+		{
+			name: "Generates a v1beta3 kubeadm configuration with patches",
+			args: args{
+				capiObj: &bootstrapv1.InitConfiguration{
+					Patches: &bootstrapv1.Patches{
+						Directory: "/etc/kubernetes/patches",
+					},
+				},
+				version: semver.MustParse("1.22.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta3\n" +
+				"kind: InitConfiguration\n" +
+				"localAPIEndpoint: {}\n" +
+				"nodeRegistration:\n" +
+				"  taints: null\n" +
+				"patches:\n" +
+				"  directory: /etc/kubernetes/patches\n",
+			wantErr: false,
+		},
+		{
+			name: "Fails generating a v1beta2 kubeadm configuration with patches",
+			args: args{
+				capiObj: &bootstrapv1.InitConfiguration{
+					Patches: &bootstrapv1.Patches{
+						Directory: "/etc/kubernetes/patches",
+					},
+				},
+				version: semver.MustParse("1.21.0"),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			got, err := MarshalInitConfigurationForVersion(tt.args.capiObj, tt.args.version)
+			got, err := MarshalInitConfigurationForVersion(tt.args.clusterConfiguration, tt.args.capiObj, tt.args.version)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -389,6 +487,56 @@ func TestMarshalJoinConfigurationForVersion(t *testing.T) {
 				"  taints: null\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.JoinConfiguration{
+					Discovery: bootstrapv1.Discovery{
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"discovery: {}\n" +
+				"kind: JoinConfiguration\n" +
+				"nodeRegistration:\n" +
+				"  taints: null\n" +
+				"timeouts:\n" +
+				"  discovery: 10m0s\n",
+			wantErr: false,
+		},
+		{
+			name: "Generates a v1beta3 kubeadm configuration with patches",
+			args: args{
+				capiObj: &bootstrapv1.JoinConfiguration{
+					Patches: &bootstrapv1.Patches{
+						Directory: "/etc/kubernetes/patches",
+					},
+				},
+				version: semver.MustParse("1.22.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta3\n" + "" +
+				"discovery: {}\n" +
+				"kind: JoinConfiguration\n" +
+				"nodeRegistration:\n" +
+				"  taints: null\n" +
+				"patches:\n" +
+				"  directory: /etc/kubernetes/patches\n",
+			wantErr: false,
+		},
+		{
+			name: "Fails generating a v1beta2 kubeadm configuration with patches",
+			args: args{
+				capiObj: &bootstrapv1.JoinConfiguration{
+					Patches: &bootstrapv1.Patches{
+						Directory: "/etc/kubernetes/patches",
+					},
+				},
+				version: semver.MustParse("1.21.0"),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -460,6 +608,30 @@ func TestUnmarshalClusterConfiguration(t *testing.T) {
 			want:    &bootstrapv1.ClusterConfiguration{},
 			wantErr: false,
 		},
+		{
+			name: "Parses a v1beta4 kubeadm configuration",
+			args: args{
+				yaml: "apiServer:\n" +
+					"  extraArgs:\n" +
+					"  - name: foo\n" +
+					"    value: bar\n" +
+					"apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+					"controllerManager: {}\n" +
+					"dns: {}\n" +
+					"etcd: {}\n" +
+					"kind: ClusterConfiguration\n" +
+					"networking: {}\n" +
+					"scheduler: {}\n",
+			},
+			want: &bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{
+					ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+						ExtraArgs: map[string]string{"foo": "bar"},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -19,4 +19,9 @@ package v1alpha3
 const (
 	// CertManagerVersionAnnotation reports the cert manager version installed by clusterctl.
 	CertManagerVersionAnnotation = "cert-manager.clusterctl.cluster.x-k8s.io/version"
+
+	// StandbyAnnotation is set by clusterctl on the objects synced to a standby management cluster;
+	// standby Clusters are kept paused until the standby management cluster is promoted, and the annotation
+	// records the value of spec.paused in the primary management cluster, which is restored on promotion.
+	StandbyAnnotation = "clusterctl.cluster.x-k8s.io/standby"
 )
@@ -31,6 +31,10 @@ const (
 	// ClusterctlCoreLabelCertManagerValue define the value for ClusterctlCoreLabelName to be used for cert-manager objects.
 	ClusterctlCoreLabelCertManagerValue = "cert-manager"
 
+	// ClusterctlCoreLabelUpgradeGuardValue define the value for ClusterctlCoreLabelName to be used for the admission guard
+	// installed while upgrading providers.
+	ClusterctlCoreLabelUpgradeGuardValue = "upgrade-guard"
+
 	// ClusterctlMoveLabelName can be set on CRDs that providers wish to move but that are not part of a Cluster.
 	ClusterctlMoveLabelName = "clusterctl.cluster.x-k8s.io/move"
 
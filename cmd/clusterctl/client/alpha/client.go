@@ -25,11 +25,13 @@ var (
 // Client is the alpha client.
 type Client interface {
 	Rollout() Rollout
+	Fleet() Fleet
 }
 
 // alphaClient implements Client.
 type alphaClient struct {
 	rollout Rollout
+	fleet   Fleet
 }
 
 // ensure alphaClient implements Client.
@@ -45,6 +47,13 @@ func InjectRollout(rollout Rollout) Option {
 	}
 }
 
+// InjectFleet allows to override the fleet implementation to use.
+func InjectFleet(fleet Fleet) Option {
+	return func(c *alphaClient) {
+		c.fleet = fleet
+	}
+}
+
 // New returns a Client.
 func New(options ...Option) Client {
 	return newAlphaClient(options...)
@@ -61,9 +70,18 @@ func newAlphaClient(options ...Option) *alphaClient {
 		client.rollout = newRolloutClient()
 	}
 
+	// if there is an injected fleet, use it, otherwise use a default one
+	if client.fleet == nil {
+		client.fleet = newFleetClient()
+	}
+
 	return client
 }
 
 func (c *alphaClient) Rollout() Rollout {
 	return c.rollout
 }
+
+func (c *alphaClient) Fleet() Fleet {
+	return c.fleet
+}
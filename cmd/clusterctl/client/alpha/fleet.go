@@ -0,0 +1,294 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FleetCheckResult is the result of the evaluation of the health of an object in the fleet.
+type FleetCheckResult string
+
+const (
+	// FleetCheckPass signals that all the checks for an object passed.
+	FleetCheckPass FleetCheckResult = "Pass"
+
+	// FleetCheckWarn signals that some checks for an object did not pass, but none of them is reporting an error,
+	// e.g. because the object is still provisioning.
+	FleetCheckWarn FleetCheckResult = "Warn"
+
+	// FleetCheckFail signals that at least one check for an object is reporting an error.
+	FleetCheckFail FleetCheckResult = "Fail"
+)
+
+// severity returns a number that allows to sort results from the best to the worst.
+func (r FleetCheckResult) severity() int {
+	switch r {
+	case FleetCheckFail:
+		return 2
+	case FleetCheckWarn:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DefaultFleetConditions are the Cluster conditions evaluated when no conditions are specified.
+var DefaultFleetConditions = []clusterv1.ConditionType{
+	clusterv1.ReadyCondition,
+	clusterv1.InfrastructureReadyCondition,
+	clusterv1.ControlPlaneReadyCondition,
+}
+
+// FleetStatusOptions carries the options supported by Fleet.Status.
+type FleetStatusOptions struct {
+	// Namespace where to look for Clusters. If empty, Clusters in all the namespaces are evaluated.
+	Namespace string
+
+	// Conditions to evaluate on each Cluster. If empty, DefaultFleetConditions are used.
+	Conditions []clusterv1.ConditionType
+
+	// CheckControlPlanes instructs to evaluate the Ready condition of the control plane object of each Cluster.
+	CheckControlPlanes bool
+
+	// CheckMachineDeployments instructs to evaluate the Available condition and the ready replicas
+	// of the MachineDeployments of each Cluster.
+	CheckMachineDeployments bool
+}
+
+// FleetObjectStatus is the result of the evaluation of an object in the fleet.
+type FleetObjectStatus struct {
+	// Kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace of the object.
+	Namespace string `json:"namespace"`
+
+	// Name of the object.
+	Name string `json:"name"`
+
+	// ClusterName is the name of the Cluster the object belongs to.
+	ClusterName string `json:"clusterName"`
+
+	// Result of the evaluation.
+	Result FleetCheckResult `json:"result"`
+
+	// Messages explaining why the object did not pass the checks.
+	Messages []string `json:"messages,omitempty"`
+}
+
+// FleetStatusReport is the result of the evaluation of all the objects in the fleet.
+type FleetStatusReport struct {
+	// Objects contains the result of the evaluation of each object, sorted by namespace, cluster name, kind and name.
+	Objects []FleetObjectStatus `json:"objects"`
+}
+
+// Result returns the worst result across all the objects in the report.
+func (r *FleetStatusReport) Result() FleetCheckResult {
+	result := FleetCheckPass
+	for _, o := range r.Objects {
+		if o.Result.severity() > result.severity() {
+			result = o.Result
+		}
+	}
+	return result
+}
+
+// Count returns the number of objects in the report with the given result.
+func (r *FleetStatusReport) Count(result FleetCheckResult) int {
+	count := 0
+	for _, o := range r.Objects {
+		if o.Result == result {
+			count++
+		}
+	}
+	return count
+}
+
+// Fleet defines the behavior of commands operating across all the Clusters in a management cluster.
+type Fleet interface {
+	// Status evaluates the health of all the Clusters in a management cluster.
+	Status(cluster.Proxy, FleetStatusOptions) (*FleetStatusReport, error)
+}
+
+var _ Fleet = &fleet{}
+
+type fleet struct{}
+
+func newFleetClient() Fleet {
+	return &fleet{}
+}
+
+func (f *fleet) Status(proxy cluster.Proxy, options FleetStatusOptions) (*FleetStatusReport, error) {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	conditionTypes := options.Conditions
+	if len(conditionTypes) == 0 {
+		conditionTypes = DefaultFleetConditions
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := c.List(ctx, clusterList, client.InNamespace(options.Namespace)); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	report := &FleetStatusReport{}
+	for i := range clusterList.Items {
+		cl := &clusterList.Items[i]
+		report.Objects = append(report.Objects, evaluateConditions("Cluster", cl.Name, cl, conditionTypes))
+
+		if options.CheckControlPlanes && cl.Spec.ControlPlaneRef != nil {
+			status, err := evaluateControlPlane(c, cl)
+			if err != nil {
+				return nil, err
+			}
+			report.Objects = append(report.Objects, status)
+		}
+
+		if options.CheckMachineDeployments {
+			statuses, err := evaluateMachineDeployments(c, cl)
+			if err != nil {
+				return nil, err
+			}
+			report.Objects = append(report.Objects, statuses...)
+		}
+	}
+
+	sort.SliceStable(report.Objects, func(i, j int) bool {
+		a, b := report.Objects[i], report.Objects[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.ClusterName != b.ClusterName {
+			return a.ClusterName < b.ClusterName
+		}
+		if a.Kind != b.Kind {
+			// Clusters are always reported before the objects belonging to them.
+			if a.Kind == "Cluster" || b.Kind == "Cluster" {
+				return a.Kind == "Cluster"
+			}
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	})
+
+	return report, nil
+}
+
+// evaluateControlPlane evaluates the Ready condition of the control plane object referenced by a Cluster.
+func evaluateControlPlane(c client.Client, cl *clusterv1.Cluster) (FleetObjectStatus, error) {
+	ref := cl.Spec.ControlPlaneRef
+	controlPlane := &unstructured.Unstructured{}
+	controlPlane.SetAPIVersion(ref.APIVersion)
+	controlPlane.SetKind(ref.Kind)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: cl.Namespace, Name: ref.Name}, controlPlane); err != nil {
+		if apierrors.IsNotFound(err) {
+			return FleetObjectStatus{
+				Kind:        ref.Kind,
+				Namespace:   cl.Namespace,
+				Name:        ref.Name,
+				ClusterName: cl.Name,
+				Result:      FleetCheckFail,
+				Messages:    []string{fmt.Sprintf("%s %s does not exist", ref.Kind, ref.Name)},
+			}, nil
+		}
+		return FleetObjectStatus{}, errors.Wrapf(err, "failed to get %s %s/%s", ref.Kind, cl.Namespace, ref.Name)
+	}
+
+	return evaluateConditions(ref.Kind, cl.Name, conditions.UnstructuredGetter(controlPlane), []clusterv1.ConditionType{clusterv1.ReadyCondition}), nil
+}
+
+// evaluateMachineDeployments evaluates the Available condition and the ready replicas of the MachineDeployments
+// belonging to a Cluster.
+func evaluateMachineDeployments(c client.Client, cl *clusterv1.Cluster) ([]FleetObjectStatus, error) {
+	mdList := &clusterv1.MachineDeploymentList{}
+	if err := c.List(ctx, mdList, client.InNamespace(cl.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: cl.Name}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list MachineDeployments for Cluster %s/%s", cl.Namespace, cl.Name)
+	}
+
+	statuses := make([]FleetObjectStatus, 0, len(mdList.Items))
+	for i := range mdList.Items {
+		md := &mdList.Items[i]
+		status := evaluateConditions("MachineDeployment", cl.Name, md, []clusterv1.ConditionType{clusterv1.MachineDeploymentAvailableCondition})
+		if md.Spec.Replicas != nil && md.Status.ReadyReplicas < *md.Spec.Replicas {
+			status.Messages = append(status.Messages, fmt.Sprintf("%d of %d replicas are ready", md.Status.ReadyReplicas, *md.Spec.Replicas))
+			if status.Result == FleetCheckPass {
+				status.Result = FleetCheckWarn
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// evaluateConditions evaluates a list of conditions on an object.
+// A condition which is False with severity Error fails the check; any other condition which is not True,
+// including missing conditions, raises a warning.
+func evaluateConditions(kind, clusterName string, obj conditions.Getter, conditionTypes []clusterv1.ConditionType) FleetObjectStatus {
+	status := FleetObjectStatus{
+		Kind:        kind,
+		Namespace:   obj.GetNamespace(),
+		Name:        obj.GetName(),
+		ClusterName: clusterName,
+		Result:      FleetCheckPass,
+	}
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		status.Result = FleetCheckWarn
+		status.Messages = append(status.Messages, "is being deleted")
+	}
+
+	for _, t := range conditionTypes {
+		c := conditions.Get(obj, t)
+		result := FleetCheckWarn
+		switch {
+		case c == nil:
+			status.Messages = append(status.Messages, fmt.Sprintf("condition %s is not reported", t))
+		case c.Status == corev1.ConditionTrue:
+			result = FleetCheckPass
+		default:
+			if c.Status == corev1.ConditionFalse && c.Severity == clusterv1.ConditionSeverityError {
+				result = FleetCheckFail
+			}
+			message := fmt.Sprintf("condition %s is %s", t, c.Status)
+			if c.Reason != "" {
+				message += fmt.Sprintf(" (%s)", c.Reason)
+			}
+			if c.Message != "" {
+				message += fmt.Sprintf(": %s", c.Message)
+			}
+			status.Messages = append(status.Messages, message)
+		}
+		if result.severity() > status.Result.severity() {
+			status.Result = result
+		}
+	}
+	return status
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_FleetStatus(t *testing.T) {
+	healthyCluster := &clusterv1.Cluster{
+		TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "healthy"},
+	}
+	conditions.MarkTrue(healthyCluster, clusterv1.ReadyCondition)
+	conditions.MarkTrue(healthyCluster, clusterv1.InfrastructureReadyCondition)
+	conditions.MarkTrue(healthyCluster, clusterv1.ControlPlaneReadyCondition)
+
+	provisioningCluster := &clusterv1.Cluster{
+		TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "provisioning"},
+	}
+	conditions.MarkTrue(provisioningCluster, clusterv1.InfrastructureReadyCondition)
+	conditions.MarkFalse(provisioningCluster, clusterv1.ReadyCondition, "Provisioning", clusterv1.ConditionSeverityInfo, "")
+
+	failedCluster := &clusterv1.Cluster{
+		TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "failed"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{
+				APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+				Kind:       "KubeadmControlPlane",
+				Name:       "does-not-exist",
+			},
+		},
+	}
+	conditions.MarkTrue(failedCluster, clusterv1.ReadyCondition)
+	conditions.MarkFalse(failedCluster, clusterv1.InfrastructureReadyCondition, "Failed", clusterv1.ConditionSeverityError, "something went wrong")
+	conditions.MarkTrue(failedCluster, clusterv1.ControlPlaneReadyCondition)
+
+	md := &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{Kind: "MachineDeployment", APIVersion: clusterv1.GroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "md",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "healthy"},
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "healthy",
+			Replicas:    pointer.Int32Ptr(3),
+		},
+		Status: clusterv1.MachineDeploymentStatus{
+			ReadyReplicas: 2,
+		},
+	}
+	conditions.MarkTrue(md, clusterv1.MachineDeploymentAvailableCondition)
+
+	objs := []client.Object{healthyCluster, provisioningCluster, failedCluster, md}
+
+	t.Run("evaluates clusters in all namespaces", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report, err := newFleetClient().Status(test.NewFakeProxy().WithObjs(objs...), FleetStatusOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(report.Objects).To(HaveLen(3))
+
+		g.Expect(report.Objects[0].Name).To(Equal("healthy"))
+		g.Expect(report.Objects[0].Result).To(Equal(FleetCheckPass))
+		g.Expect(report.Objects[0].Messages).To(BeEmpty())
+
+		g.Expect(report.Objects[1].Name).To(Equal("provisioning"))
+		g.Expect(report.Objects[1].Result).To(Equal(FleetCheckWarn))
+		g.Expect(report.Objects[1].Messages).To(ConsistOf(
+			"condition Ready is False (Provisioning)",
+			"condition ControlPlaneReady is not reported",
+		))
+
+		g.Expect(report.Objects[2].Name).To(Equal("failed"))
+		g.Expect(report.Objects[2].Result).To(Equal(FleetCheckFail))
+		g.Expect(report.Objects[2].Messages).To(ConsistOf("condition InfrastructureReady is False (Failed): something went wrong"))
+
+		g.Expect(report.Result()).To(Equal(FleetCheckFail))
+		g.Expect(report.Count(FleetCheckPass)).To(Equal(1))
+		g.Expect(report.Count(FleetCheckWarn)).To(Equal(1))
+		g.Expect(report.Count(FleetCheckFail)).To(Equal(1))
+	})
+
+	t.Run("evaluates only the given namespace and conditions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report, err := newFleetClient().Status(test.NewFakeProxy().WithObjs(objs...), FleetStatusOptions{
+			Namespace:  "ns1",
+			Conditions: []clusterv1.ConditionType{clusterv1.InfrastructureReadyCondition},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(report.Objects).To(HaveLen(2))
+		g.Expect(report.Result()).To(Equal(FleetCheckPass))
+	})
+
+	t.Run("evaluates control planes and machine deployments", func(t *testing.T) {
+		g := NewWithT(t)
+
+		report, err := newFleetClient().Status(test.NewFakeProxy().WithObjs(objs...), FleetStatusOptions{
+			CheckControlPlanes:      true,
+			CheckMachineDeployments: true,
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(report.Objects).To(HaveLen(5))
+
+		g.Expect(report.Objects[1].Kind).To(Equal("MachineDeployment"))
+		g.Expect(report.Objects[1].ClusterName).To(Equal("healthy"))
+		g.Expect(report.Objects[1].Result).To(Equal(FleetCheckWarn))
+		g.Expect(report.Objects[1].Messages).To(ConsistOf("2 of 3 replicas are ready"))
+
+		g.Expect(report.Objects[4].Kind).To(Equal("KubeadmControlPlane"))
+		g.Expect(report.Objects[4].ClusterName).To(Equal("failed"))
+		g.Expect(report.Objects[4].Result).To(Equal(FleetCheckFail))
+	})
+}
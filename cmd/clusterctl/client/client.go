@@ -30,6 +30,9 @@ type Client interface {
 	// GetProvidersConfig returns the list of providers configured for this instance of clusterctl.
 	GetProvidersConfig() ([]Provider, error)
 
+	// RefreshProvidersCache refreshes the local cache of the provider repositories already cached by clusterctl.
+	RefreshProvidersCache() error
+
 	// GetProviderComponents returns the provider components for a given provider with options including targetNamespace.
 	GetProviderComponents(provider string, providerType clusterctlv1.ProviderType, options ComponentsOptions) (Components, error)
 
@@ -75,6 +78,9 @@ type Client interface {
 	// DescribeCluster returns the object tree representing the status of a Cluster API cluster.
 	DescribeCluster(options DescribeClusterOptions) (*tree.ObjectTree, error)
 
+	// DescribeProviders returns the description of the providers installed in a management cluster.
+	DescribeProviders(options DescribeProvidersOptions) ([]ProviderDescription, error)
+
 	// Interface for alpha features in clusterctl
 	AlphaClient
 }
@@ -89,6 +95,16 @@ type AlphaClient interface {
 	RolloutResume(options RolloutOptions) error
 	// RolloutUndo provides rollout rollback of cluster-api resources
 	RolloutUndo(options RolloutOptions) error
+	// FleetStatus evaluates the health of all the Clusters in a management cluster
+	FleetStatus(options FleetStatusOptions) (*alpha.FleetStatusReport, error)
+	// StandbySync copies the Cluster API objects from a management cluster to a standby management cluster
+	StandbySync(options StandbySyncOptions) error
+	// StandbyPromote promotes a standby management cluster, resuming reconciliation of the standby Clusters
+	StandbyPromote(options StandbyPromoteOptions) error
+	// CRDMigrate migrates the objects of the provider CRDs to the storage version
+	CRDMigrate(options CRDMigrateOptions) ([]cluster.CRDMigrationResult, error)
+	// TopologyPlan returns the changes the topology controller would apply for the Clusters affected by modified Cluster, ClusterClass or template objects
+	TopologyPlan(options TopologyPlanOptions) (*cluster.TopologyPlanOutput, error)
 }
 
 // YamlPrinter exposes methods that prints the processed template and
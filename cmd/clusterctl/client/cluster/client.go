@@ -86,6 +86,12 @@ type Client interface {
 
 	// WorkloadCluster has methods for fetching kubeconfig of workload cluster from management cluster.
 	WorkloadCluster() WorkloadCluster
+
+	// CRDMigrator returns a CRDMigrator that supports migrating the objects of the provider CRDs to the storage version.
+	CRDMigrator() CRDMigrator
+
+	// Topology returns a TopologyClient that supports planning the changes to Clusters with a managed topology.
+	Topology() TopologyClient
 }
 
 // PollImmediateWaiter tries a condition func until it returns true, an error, or the timeout is reached.
@@ -147,6 +153,14 @@ func (c *clusterClient) WorkloadCluster() WorkloadCluster {
 	return newWorkloadCluster(c.proxy)
 }
 
+func (c *clusterClient) CRDMigrator() CRDMigrator {
+	return newCRDMigrator(c.proxy)
+}
+
+func (c *clusterClient) Topology() TopologyClient {
+	return newTopologyClient(c.proxy)
+}
+
 // Option is a configuration option supplied to New.
 type Option func(*clusterClient)
 
@@ -251,6 +265,20 @@ func newWriteBackoff() wait.Backoff {
 	}
 }
 
+// newWaitForDeletionBackoff creates a new API Machinery backoff parameter set suitable for waiting for objects
+// with finalizers to be deleted.
+func newWaitForDeletionBackoff() wait.Backoff {
+	// Return a constant backoff configuration which returns durations for a total time of ~10m.
+	// Example: 0, 10s, 10s, 10s, ...
+	// Jitter is added as a random fraction of the duration multiplied by the jitter factor.
+	return wait.Backoff{
+		Duration: 10 * time.Second,
+		Factor:   1,
+		Steps:    60,
+		Jitter:   0.1,
+	}
+}
+
 // newConnectBackoff creates a new API Machinery backoff parameter set suitable for use when clusterctl connect to a cluster.
 func newConnectBackoff() wait.Backoff {
 	// Return a exponential backoff configuration which returns durations for a total time of ~15s.
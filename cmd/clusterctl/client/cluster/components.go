@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -64,6 +65,15 @@ type ComponentsClient interface {
 	// DeleteWebhookNamespace deletes the core provider webhook namespace (eg. capi-webhook-system).
 	// This is required when upgrading to v1alpha4 where webhooks are included in the controller itself.
 	DeleteWebhookNamespace() error
+
+	// ListCustomResources lists all the objects of the Kinds defined by the provider's CRDs, no matter of the namespace
+	// where they are hosted. Those objects are orphaned when the provider is deleted together with its CRDs or with the
+	// namespace where its controllers are hosted.
+	ListCustomResources(provider clusterctlv1.Provider) ([]unstructured.Unstructured, error)
+
+	// DeleteCustomResources deletes all the objects of the Kinds defined by the provider's CRDs and waits for them to
+	// be removed; this must happen while the provider controllers are still running, so finalizers can be processed.
+	DeleteCustomResources(provider clusterctlv1.Provider) error
 }
 
 // providerComponents implements ComponentsClient.
@@ -246,6 +256,85 @@ func (p *providerComponents) DeleteWebhookNamespace() error {
 	return nil
 }
 
+func (p *providerComponents) ListCustomResources(provider clusterctlv1.Provider) ([]unstructured.Unstructured, error) {
+	c, err := p.proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := retryWithExponentialBackoff(newReadBackoff(), func() error {
+		return c.List(ctx, crdList, client.MatchingLabels{clusterv1.ProviderLabelName: provider.ManifestLabel()})
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list CRDs for provider %q", provider.InstanceName())
+	}
+
+	var objs []unstructured.Unstructured
+	for _, crd := range crdList.Items {
+		crdObjs, err := listObjectsForCRD(c, crd.Name)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, crdObjs...)
+	}
+	return objs, nil
+}
+
+func (p *providerComponents) DeleteCustomResources(provider clusterctlv1.Provider) error {
+	log := logf.Log
+
+	objs, err := p.ListCustomResources(provider)
+	if err != nil {
+		return err
+	}
+	if len(objs) == 0 {
+		return nil
+	}
+
+	c, err := p.proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	errList := []error{}
+	for i := range objs {
+		obj := objs[i]
+		if !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		log.V(5).Info("Deleting", logf.UnstructuredToValues(obj)...)
+		if err := retryWithExponentialBackoff(newWriteBackoff(), func() error {
+			if err := c.Delete(ctx, &obj); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			return nil
+		}); err != nil {
+			errList = append(errList, errors.Wrapf(err, "error deleting object %s, %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName()))
+		}
+	}
+	if len(errList) > 0 {
+		return kerrors.NewAggregate(errList)
+	}
+
+	// Wait for the objects to be removed, which might take a while given that providers are usually
+	// cleaning up the corresponding infrastructure before removing finalizers.
+	log.Info("Waiting for the objects of the provider's Kinds to be deleted", "Provider", provider.InstanceName(), "Objects", len(objs))
+	if err := retryWithExponentialBackoff(newWaitForDeletionBackoff(), func() error {
+		remaining, err := p.ListCustomResources(provider)
+		if err != nil {
+			return err
+		}
+		if len(remaining) > 0 {
+			return errors.Errorf("%d objects of the Kinds defined by the %q provider still exist", len(remaining), provider.InstanceName())
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
 // newComponentsClient returns a providerComponents.
 func newComponentsClient(proxy Proxy) *providerComponents {
 	return &providerComponents{
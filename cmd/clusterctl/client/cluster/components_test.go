@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -337,6 +338,82 @@ func Test_providerComponents_DeleteCoreProviderWebhookNamespace(t *testing.T) {
 	})
 }
 
+func Test_providerComponents_CustomResources(t *testing.T) {
+	crd := func(name, kind, provider string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CustomResourceDefinition",
+				APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{clusterv1.ProviderLabelName: provider},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: clusterv1.GroupVersion.Group,
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Kind:     kind,
+					ListKind: kind + "List",
+				},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: clusterv1.GroupVersion.Version, Served: true, Storage: true},
+				},
+			},
+		}
+	}
+	initObjs := []client.Object{
+		crd("clusters.cluster.x-k8s.io", "Cluster", "cluster-api"),
+		// A CRD of another provider (its objects should be ignored).
+		crd("machines.cluster.x-k8s.io", "Machine", "infrastructure-infra"),
+		&clusterv1.Cluster{
+			TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1"},
+		},
+		&clusterv1.Cluster{
+			TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "cluster2"},
+		},
+		&clusterv1.Machine{
+			TypeMeta:   metav1.TypeMeta{Kind: "Machine", APIVersion: clusterv1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "machine1"},
+		},
+	}
+	provider := fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "capi-system")
+
+	t.Run("lists the objects of the Kinds defined by the provider's CRDs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := newComponentsClient(test.NewFakeProxy().WithObjs(initObjs...))
+		objs, err := c.ListCustomResources(provider)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		names := []string{}
+		for _, obj := range objs {
+			names = append(names, fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+		}
+		g.Expect(names).To(ConsistOf("Cluster ns1/cluster1", "Cluster ns2/cluster2"))
+	})
+
+	t.Run("deletes the objects of the Kinds defined by the provider's CRDs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		proxy := test.NewFakeProxy().WithObjs(initObjs...)
+		c := newComponentsClient(proxy)
+		g.Expect(c.DeleteCustomResources(provider)).To(Succeed())
+
+		objs, err := c.ListCustomResources(provider)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(objs).To(BeEmpty())
+
+		// The objects of other providers are preserved.
+		cs, err := proxy.NewClient()
+		g.Expect(err).NotTo(HaveOccurred())
+		machines := &clusterv1.MachineList{}
+		g.Expect(cs.List(ctx, machines)).To(Succeed())
+		g.Expect(machines.Items).To(HaveLen(1))
+	})
+}
+
 func Test_providerComponents_Create(t *testing.T) {
 	labelsOne := map[string]string{
 		clusterv1.ProviderLabelName: "infrastructure-infra",
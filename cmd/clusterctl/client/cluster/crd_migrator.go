@@ -0,0 +1,211 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CRDMigrationResult reports the result of the storage version migration for a CRD.
+type CRDMigrationResult struct {
+	// CRD is the name of the CustomResourceDefinition.
+	CRD string
+
+	// StorageVersion is the version used for storing the CRD objects.
+	StorageVersion string
+
+	// StoredVersions are the versions listed in the CRD status.storedVersions before migration.
+	StoredVersions []string
+
+	// MigratedObjects is the number of objects rewritten to the storage version.
+	MigratedObjects int
+
+	// Migrated is true if the CRD required migration, i.e. status.storedVersions was not
+	// listing only the storage version.
+	Migrated bool
+}
+
+// CRDMigrator has methods to migrate the objects of the Cluster API provider CRDs to the storage version.
+type CRDMigrator interface {
+	// Migrate rewrites all the objects of each provider CRD to the current storage version, updates
+	// status.storedVersions to list only the storage version, and then verifies that no version other than
+	// the storage version is still recorded as stored; this is required before an API version can be
+	// dropped from a CRD.
+	// If dryRun is true, only the CRDs requiring migration are reported, without changing the cluster.
+	Migrate(dryRun bool) ([]CRDMigrationResult, error)
+}
+
+// crdMigrator implements CRDMigrator.
+type crdMigrator struct {
+	proxy Proxy
+}
+
+// newCRDMigrator returns a crdMigrator.
+func newCRDMigrator(proxy Proxy) *crdMigrator {
+	return &crdMigrator{
+		proxy: proxy,
+	}
+}
+
+func (m *crdMigrator) Migrate(dryRun bool) ([]CRDMigrationResult, error) {
+	log := logf.Log
+
+	c, err := m.proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := retryWithExponentialBackoff(newReadBackoff(), func() error {
+		return c.List(ctx, crdList, client.HasLabels{clusterv1.ProviderLabelName})
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list provider CRDs")
+	}
+	sort.Slice(crdList.Items, func(i, j int) bool { return crdList.Items[i].Name < crdList.Items[j].Name })
+
+	results := make([]CRDMigrationResult, 0, len(crdList.Items))
+	for i := range crdList.Items {
+		crd := &crdList.Items[i]
+
+		storageVersion, err := storageVersionForCRD(crd)
+		if err != nil {
+			return nil, err
+		}
+
+		result := CRDMigrationResult{
+			CRD:            crd.Name,
+			StorageVersion: storageVersion,
+			StoredVersions: crd.Status.StoredVersions,
+			Migrated:       !isMigrated(crd, storageVersion),
+		}
+		if !result.Migrated || dryRun {
+			results = append(results, result)
+			continue
+		}
+
+		log.Info("Migrating CRD objects to the storage version", "CRD", crd.Name, "StorageVersion", storageVersion, "StoredVersions", crd.Status.StoredVersions)
+		result.MigratedObjects, err = m.migrateObjects(c, crd, storageVersion)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := m.updateStoredVersions(c, crd, storageVersion); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// migrateObjects rewrites all the objects of a CRD, so the API server stores them using the storage version.
+func (m *crdMigrator) migrateObjects(c client.Client, crd *apiextensionsv1.CustomResourceDefinition, storageVersion string) (int, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: storageVersion,
+		Kind:    listKindForCRD(crd),
+	})
+	if err := retryWithExponentialBackoff(newReadBackoff(), func() error {
+		return c.List(ctx, list)
+	}); err != nil {
+		return 0, errors.Wrapf(err, "failed to list objects for CRD %q", crd.Name)
+	}
+
+	migrated := 0
+	for i := range list.Items {
+		obj := list.Items[i]
+		key := client.ObjectKeyFromObject(&obj)
+
+		// Touch-update the object; the API server writes it back using the storage version.
+		// Nb. The operation is wrapped in a retry loop, re-reading the object in case of conflicts.
+		if err := retryWithExponentialBackoff(newWriteBackoff(), func() error {
+			current := &unstructured.Unstructured{}
+			current.SetGroupVersionKind(obj.GroupVersionKind())
+			if err := c.Get(ctx, key, current); err != nil {
+				if apierrors.IsNotFound(err) {
+					return nil
+				}
+				return err
+			}
+			return c.Update(ctx, current)
+		}); err != nil {
+			return migrated, errors.Wrapf(err, "failed to migrate %s %s", crd.Spec.Names.Kind, key)
+		}
+		migrated++
+	}
+	return migrated, nil
+}
+
+// updateStoredVersions sets the storage version as the only version in status.storedVersions, and then
+// verifies the update has been persisted.
+func (m *crdMigrator) updateStoredVersions(c client.Client, crd *apiextensionsv1.CustomResourceDefinition, storageVersion string) error {
+	if err := retryWithExponentialBackoff(newWriteBackoff(), func() error {
+		current := &apiextensionsv1.CustomResourceDefinition{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(crd), current); err != nil {
+			return err
+		}
+		current.Status.StoredVersions = []string{storageVersion}
+		return c.Status().Update(ctx, current)
+	}); err != nil {
+		return errors.Wrapf(err, "failed to update storedVersions for CRD %q", crd.Name)
+	}
+
+	current := &apiextensionsv1.CustomResourceDefinition{}
+	if err := retryWithExponentialBackoff(newReadBackoff(), func() error {
+		return c.Get(ctx, client.ObjectKeyFromObject(crd), current)
+	}); err != nil {
+		return errors.Wrapf(err, "failed to get CRD %q", crd.Name)
+	}
+	if !isMigrated(current, storageVersion) {
+		return errors.Errorf("CRD %q still has objects stored in versions %v, expected only %q", crd.Name, current.Status.StoredVersions, storageVersion)
+	}
+	return nil
+}
+
+// storageVersionForCRD returns the version used for storing the objects of a CRD.
+func storageVersionForCRD(crd *apiextensionsv1.CustomResourceDefinition) (string, error) {
+	for _, v := range crd.Spec.Versions {
+		if v.Storage {
+			return v.Name, nil
+		}
+	}
+	return "", errors.Errorf("could not find storage version for CRD %q", crd.Name)
+}
+
+// listKindForCRD returns the kind used for listing the objects of a CRD; if not set, ListKind is defaulted
+// to Kind + "List", the same way the API server does.
+func listKindForCRD(crd *apiextensionsv1.CustomResourceDefinition) string {
+	if crd.Spec.Names.ListKind != "" {
+		return crd.Spec.Names.ListKind
+	}
+	return crd.Spec.Names.Kind + "List"
+}
+
+// isMigrated returns true if the storage version is the only version listed in status.storedVersions.
+func isMigrated(crd *apiextensionsv1.CustomResourceDefinition, storageVersion string) bool {
+	return sets.NewString(crd.Status.StoredVersions...).Equal(sets.NewString(storageVersion))
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_crdMigrator_Migrate(t *testing.T) {
+	crd := func(storedVersions ...string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CustomResourceDefinition",
+				APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "clusters.cluster.x-k8s.io",
+				Labels: map[string]string{clusterv1.ProviderLabelName: "cluster-api"},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: clusterv1.GroupVersion.Group,
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Kind:     "Cluster",
+					ListKind: "ClusterList",
+				},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: "v1alpha4", Served: true},
+					{Name: clusterv1.GroupVersion.Version, Served: true, Storage: true},
+				},
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				StoredVersions: storedVersions,
+			},
+		}
+	}
+	cluster := func(name string) *clusterv1.Cluster {
+		return &clusterv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Cluster",
+				APIVersion: clusterv1.GroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "ns1",
+			},
+		}
+	}
+
+	tests := []struct {
+		name               string
+		objs               []client.Object
+		dryRun             bool
+		want               []CRDMigrationResult
+		wantStoredVersions []string
+	}{
+		{
+			name: "no-op if the CRD is already migrated",
+			objs: []client.Object{crd("v1beta1"), cluster("foo")},
+			want: []CRDMigrationResult{
+				{CRD: "clusters.cluster.x-k8s.io", StorageVersion: "v1beta1", StoredVersions: []string{"v1beta1"}},
+			},
+			wantStoredVersions: []string{"v1beta1"},
+		},
+		{
+			name: "migrates objects and updates storedVersions",
+			objs: []client.Object{crd("v1alpha4", "v1beta1"), cluster("foo"), cluster("bar")},
+			want: []CRDMigrationResult{
+				{CRD: "clusters.cluster.x-k8s.io", StorageVersion: "v1beta1", StoredVersions: []string{"v1alpha4", "v1beta1"}, MigratedObjects: 2, Migrated: true},
+			},
+			wantStoredVersions: []string{"v1beta1"},
+		},
+		{
+			name:   "reports the CRDs requiring migration in dry-run",
+			objs:   []client.Object{crd("v1alpha4", "v1beta1"), cluster("foo")},
+			dryRun: true,
+			want: []CRDMigrationResult{
+				{CRD: "clusters.cluster.x-k8s.io", StorageVersion: "v1beta1", StoredVersions: []string{"v1alpha4", "v1beta1"}, Migrated: true},
+			},
+			wantStoredVersions: []string{"v1alpha4", "v1beta1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			proxy := test.NewFakeProxy().WithObjs(tt.objs...)
+			m := newCRDMigrator(proxy)
+
+			got, err := m.Migrate(tt.dryRun)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+
+			c, err := proxy.NewClient()
+			g.Expect(err).ToNot(HaveOccurred())
+			gotCRD := &apiextensionsv1.CustomResourceDefinition{}
+			g.Expect(c.Get(ctx, client.ObjectKey{Name: "clusters.cluster.x-k8s.io"}, gotCRD)).To(Succeed())
+			g.Expect(gotCRD.Status.StoredVersions).To(Equal(tt.wantStoredVersions))
+		})
+	}
+}
+
+func Test_crdMigrator_MigrateFailsWithoutStorageVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "clusters.cluster.x-k8s.io",
+			Labels: map[string]string{clusterv1.ProviderLabelName: "cluster-api"},
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1beta1", Served: true}},
+		},
+	}
+
+	_, err := newCRDMigrator(test.NewFakeProxy().WithObjs(crd)).Migrate(false)
+	g.Expect(err).To(HaveOccurred())
+}
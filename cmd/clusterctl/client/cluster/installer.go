@@ -18,6 +18,8 @@ package cluster
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"sort"
 	"time"
 
@@ -25,9 +27,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
@@ -37,6 +41,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// waitProviderReadyInterval is the interval between checks when waiting for providers to be ready.
+var waitProviderReadyInterval = 1 * time.Second
+
 // ProviderInstaller defines methods for enforcing consistency rules for provider installation.
 type ProviderInstaller interface {
 	// Add adds a provider to the install queue.
@@ -59,10 +66,33 @@ type ProviderInstaller interface {
 
 // InstallOptions defines the options used to configure installation.
 type InstallOptions struct {
-	WaitProviders       bool
+	// WaitProviders instructs the installer to wait for the providers to be ready, that is for the provider
+	// deployments to be available and for the provider webhooks to be serving.
+	WaitProviders bool
+
+	// WaitProviderTimeout sets the timeout for each provider to be ready.
 	WaitProviderTimeout time.Duration
+
+	// RollbackOnFailure instructs the installer to delete the providers it installed if they do not become ready
+	// within WaitProviderTimeout. This option is ignored if WaitProviders is false.
+	RollbackOnFailure bool
+}
+
+// webhookService identifies the service a webhook is served from.
+type webhookService struct {
+	Namespace string
+	Name      string
+	Port      int32
+	Path      string
 }
 
+func (s webhookService) String() string {
+	return fmt.Sprintf("%s/%s:%d%s", s.Namespace, s.Name, s.Port, s.Path)
+}
+
+// webhookServiceProber checks if a webhook service is serving, returning an error if it is not.
+type webhookServiceProber func(ctx context.Context, service webhookService) error
+
 // providerInstaller implements ProviderInstaller.
 type providerInstaller struct {
 	configClient            config.Client
@@ -71,6 +101,10 @@ type providerInstaller struct {
 	providerComponents      ComponentsClient
 	providerInventory       InventoryClient
 	installQueue            []repository.Components
+
+	// probeWebhookService allows to inject a webhook service prober for testing; if not set, the webhook
+	// services are probed via the API server service proxy.
+	probeWebhookService webhookServiceProber
 }
 
 var _ ProviderInstaller = &providerInstaller{}
@@ -123,44 +157,185 @@ func (i *providerInstaller) waitForProvidersReady(opts InstallOptions) error {
 	log := logf.Log
 	log.Info("Waiting for providers to be available...")
 
-	return i.waitManagerDeploymentsReady(opts)
-}
-
-// waitManagerDeploymentsReady waits till the installed manager deployments are ready.
-func (i *providerInstaller) waitManagerDeploymentsReady(opts InstallOptions) error {
 	for _, components := range i.installQueue {
-		for _, obj := range components.Objs() {
-			if util.IsDeploymentWithManager(obj) {
-				if err := i.waitDeploymentReady(obj, opts.WaitProviderTimeout); err != nil {
-					return err
-				}
+		if err := i.waitProviderReady(components, opts.WaitProviderTimeout); err != nil {
+			if opts.RollbackOnFailure {
+				return i.rollback(err)
 			}
+			return err
 		}
 	}
 	return nil
 }
 
-func (i *providerInstaller) waitDeploymentReady(deployment unstructured.Unstructured, timeout time.Duration) error {
-	return wait.Poll(100*time.Millisecond, timeout, func() (bool, error) {
-		c, err := i.proxy.NewClient()
+// waitProviderReady waits till the manager deployments of a provider are available and its webhooks are serving.
+func (i *providerInstaller) waitProviderReady(components repository.Components, timeout time.Duration) error {
+	log := logf.Log
+	log.V(1).Info("Waiting for provider to be ready", "Provider", components.ManifestLabel(), "Timeout", timeout.String())
+
+	var notReady string
+	err := wait.PollImmediate(waitProviderReadyInterval, timeout, func() (bool, error) {
+		var err error
+		notReady, err = i.providerNotReadyReason(components)
 		if err != nil {
 			return false, err
 		}
-		key := client.ObjectKey{
-			Namespace: deployment.GetNamespace(),
-			Name:      deployment.GetName(),
+		if notReady != "" {
+			log.V(5).Info("Provider not ready yet", "Provider", components.ManifestLabel(), "Reason", notReady)
+			return false, nil
+		}
+		return true, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return errors.Errorf("provider %q is not ready after %s: %s", components.ManifestLabel(), timeout, notReady)
+	}
+	return errors.Wrapf(err, "failed to wait for provider %q to be ready", components.ManifestLabel())
+}
+
+// providerNotReadyReason returns the reason why a provider is not ready yet, or an empty string if the provider is ready.
+func (i *providerInstaller) providerNotReadyReason(components repository.Components) (string, error) {
+	c, err := i.proxy.NewClient()
+	if err != nil {
+		return "", err
+	}
+
+	for _, obj := range components.Objs() {
+		if !util.IsDeploymentWithManager(obj) {
+			continue
 		}
 		dep := &appsv1.Deployment{}
-		if err := c.Get(context.TODO(), key, dep); err != nil {
-			return false, err
+		if err := c.Get(ctx, client.ObjectKeyFromObject(&obj), dep); err != nil {
+			return "", err
+		}
+		if !isDeploymentAvailable(dep) {
+			return fmt.Sprintf("deployment %s/%s is not available", obj.GetNamespace(), obj.GetName()), nil
 		}
-		for _, c := range dep.Status.Conditions {
-			if c.Type == appsv1.DeploymentAvailable && c.Status == corev1.ConditionTrue {
-				return true, nil
+	}
+
+	for _, obj := range components.Objs() {
+		kind := obj.GroupVersionKind().Kind
+		if kind != validatingWebhookConfigurationKind && kind != mutatingWebhookConfigurationKind {
+			continue
+		}
+
+		// Reads the webhook configuration from the cluster, because the CA bundle is injected by cert-manager.
+		webhookConfiguration := &unstructured.Unstructured{}
+		webhookConfiguration.SetGroupVersionKind(obj.GroupVersionKind())
+		if err := c.Get(ctx, client.ObjectKeyFromObject(&obj), webhookConfiguration); err != nil {
+			return "", err
+		}
+		webhooks, _, err := unstructured.NestedSlice(webhookConfiguration.Object, "webhooks")
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to get webhooks from %s %s", kind, obj.GetName())
+		}
+		for _, w := range webhooks {
+			webhook, ok := w.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(webhook, "name")
+			if caBundle, _, _ := unstructured.NestedString(webhook, "clientConfig", "caBundle"); caBundle == "" {
+				return fmt.Sprintf("webhook %s in %s %s does not have a CA bundle yet", name, kind, obj.GetName()), nil
+			}
+
+			service, ok := webhookServiceFor(webhook)
+			if !ok {
+				continue
+			}
+			if err := i.webhookServiceProber()(ctx, service); err != nil {
+				return fmt.Sprintf("webhook %s in %s %s is not serving yet: %v", name, kind, obj.GetName(), err), nil
 			}
 		}
-		return false, nil
-	})
+	}
+	return "", nil
+}
+
+func isDeploymentAvailable(dep *appsv1.Deployment) bool {
+	for _, c := range dep.Status.Conditions {
+		if c.Type == appsv1.DeploymentAvailable && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookServiceFor returns the service a webhook is served from, if the webhook is not using an URL.
+func webhookServiceFor(webhook map[string]interface{}) (webhookService, bool) {
+	service, ok, _ := unstructured.NestedMap(webhook, "clientConfig", "service")
+	if !ok {
+		return webhookService{}, false
+	}
+	namespace, _, _ := unstructured.NestedString(service, "namespace")
+	name, _, _ := unstructured.NestedString(service, "name")
+	path, _, _ := unstructured.NestedString(service, "path")
+	port, ok, _ := unstructured.NestedInt64(service, "port")
+	if !ok {
+		port = 443
+	}
+	return webhookService{Namespace: namespace, Name: name, Port: int32(port), Path: path}, true
+}
+
+func (i *providerInstaller) webhookServiceProber() webhookServiceProber {
+	if i.probeWebhookService != nil {
+		return i.probeWebhookService
+	}
+	return i.probeWebhookServiceViaAPIServer
+}
+
+// probeWebhookServiceViaAPIServer probes a webhook service via the API server service proxy, which is also
+// the way the API server reaches the webhooks.
+func (i *providerInstaller) probeWebhookServiceViaAPIServer(ctx context.Context, service webhookService) error {
+	config, err := i.proxy.GetConfig()
+	if err != nil {
+		return err
+	}
+	cs, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the Kubernetes client")
+	}
+
+	var statusCode int
+	result := cs.CoreV1().RESTClient().Get().
+		Namespace(service.Namespace).
+		Resource("services").
+		Name(fmt.Sprintf("https:%s:%d", service.Name, service.Port)).
+		SubResource("proxy").
+		Suffix(service.Path).
+		Do(ctx).
+		StatusCode(&statusCode)
+
+	// Any response from the webhook server, also errors like e.g. 400 for a GET request without an admission review,
+	// means the webhook server is serving; instead the API server responds with 502 or 503 if the service does not
+	// have ready endpoints or if it fails to connect to the webhook server.
+	switch statusCode {
+	case 0:
+		return result.Error()
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return errors.Errorf("service %s responded with status code %d", service, statusCode)
+	}
+	return nil
+}
+
+// rollback deletes the providers in the install queue after a failure, so the management cluster is not left with
+// providers not working.
+// NOTE: The namespaces hosting the providers are not deleted, because they could be shared with other providers.
+func (i *providerInstaller) rollback(failure error) error {
+	log := logf.Log
+	log.Info("Rolling back the installation of providers", "Reason", failure.Error())
+
+	var errList []error
+	for j := len(i.installQueue) - 1; j >= 0; j-- {
+		if err := i.providerComponents.Delete(DeleteOptions{
+			Provider:    i.installQueue[j].InventoryObject(),
+			IncludeCRDs: true,
+		}); err != nil {
+			errList = append(errList, err)
+		}
+	}
+	if len(errList) > 0 {
+		return errors.Wrapf(kerrors.NewAggregate(errList), "failed to roll back the installation of providers after %v", failure)
+	}
+	return errors.Wrap(failure, "the installation of providers has been rolled back")
 }
 
 func (i *providerInstaller) Validate() error {
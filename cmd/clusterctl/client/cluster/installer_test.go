@@ -17,14 +17,25 @@ limitations under the License.
 package cluster
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func Test_providerInstaller_Validate(t *testing.T) {
@@ -259,6 +270,7 @@ func Test_providerInstaller_Validate(t *testing.T) {
 type fakeComponents struct {
 	config.Provider
 	inventoryObject clusterctlv1.Provider
+	objs            []unstructured.Unstructured
 }
 
 func (c *fakeComponents) Version() string {
@@ -282,7 +294,7 @@ func (c *fakeComponents) InventoryObject() clusterctlv1.Provider {
 }
 
 func (c *fakeComponents) Objs() []unstructured.Unstructured {
-	panic("not implemented")
+	return c.objs
 }
 
 func (c *fakeComponents) Yaml() ([]byte, error) {
@@ -296,3 +308,144 @@ func newFakeComponents(name string, providerType clusterctlv1.ProviderType, vers
 		inventoryObject: inventoryObject,
 	}
 }
+
+func Test_providerInstaller_waitForProvidersReady(t *testing.T) {
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabelName: "",
+		clusterv1.ProviderLabelName:      "infrastructure-infra1",
+	}
+	deployment := func(available bool) *appsv1.Deployment {
+		status := corev1.ConditionFalse
+		if available {
+			status = corev1.ConditionTrue
+		}
+		return &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "infra1-controller-manager", Labels: labels},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: status}},
+			},
+		}
+	}
+	webhookConfiguration := func(caBundle string) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		return &admissionregistrationv1.ValidatingWebhookConfiguration{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "admissionregistration.k8s.io/v1", Kind: "ValidatingWebhookConfiguration"},
+			ObjectMeta: metav1.ObjectMeta{Name: "infra1-validating-webhook-configuration", Labels: labels},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{
+				{
+					Name: "validation.infra1.cluster.x-k8s.io",
+					ClientConfig: admissionregistrationv1.WebhookClientConfig{
+						Service: &admissionregistrationv1.ServiceReference{
+							Namespace: "ns1",
+							Name:      "infra1-webhook-service",
+							Path:      pointer.StringPtr("/validate"),
+						},
+						CABundle: []byte(caBundle),
+					},
+				},
+			},
+		}
+	}
+	toUnstructured := func(g *WithT, objs ...client.Object) []unstructured.Unstructured {
+		ret := []unstructured.Unstructured{}
+		for _, o := range objs {
+			u := unstructured.Unstructured{}
+			g.Expect(test.FakeScheme.Convert(o, &u, nil)).To(Succeed())
+			ret = append(ret, u)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name              string
+		objs              []client.Object
+		probeErr          error
+		rollbackOnFailure bool
+		wantErr           string
+		wantDeleted       bool
+	}{
+		{
+			name: "provider ready",
+			objs: []client.Object{deployment(true), webhookConfiguration("ca")},
+		},
+		{
+			name:    "deployment not available",
+			objs:    []client.Object{deployment(false), webhookConfiguration("ca")},
+			wantErr: "deployment ns1/infra1-controller-manager is not available",
+		},
+		{
+			name:    "webhook without CA bundle",
+			objs:    []client.Object{deployment(true), webhookConfiguration("")},
+			wantErr: "does not have a CA bundle yet",
+		},
+		{
+			name:     "webhook not serving",
+			objs:     []client.Object{deployment(true), webhookConfiguration("ca")},
+			probeErr: errors.New("no endpoints available"),
+			wantErr:  "is not serving yet: no endpoints available",
+		},
+		{
+			name:              "provider not ready is rolled back",
+			objs:              []client.Object{deployment(false), webhookConfiguration("ca")},
+			rollbackOnFailure: true,
+			wantErr:           "the installation of providers has been rolled back",
+			wantDeleted:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			proxy := test.NewFakeProxy().WithObjs(tt.objs...)
+			components := newFakeComponents("infra1", clusterctlv1.InfrastructureProviderType, "v1.0.0", "ns1").(*fakeComponents)
+			components.objs = toUnstructured(g, tt.objs...)
+
+			var probed []webhookService
+			i := &providerInstaller{
+				proxy:              proxy,
+				providerComponents: newComponentsClient(proxy),
+				installQueue:       []repository.Components{components},
+				probeWebhookService: func(_ context.Context, service webhookService) error {
+					probed = append(probed, service)
+					return tt.probeErr
+				},
+			}
+
+			err := i.waitForProvidersReady(InstallOptions{
+				WaitProviders:       true,
+				WaitProviderTimeout: 10 * time.Millisecond,
+				RollbackOnFailure:   tt.rollbackOnFailure,
+			})
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(probed).To(ConsistOf(webhookService{Namespace: "ns1", Name: "infra1-webhook-service", Port: 443, Path: "/validate"}))
+			}
+
+			c, err := proxy.NewClient()
+			g.Expect(err).ToNot(HaveOccurred())
+			err = c.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "infra1-controller-manager"}, &appsv1.Deployment{})
+			if tt.wantDeleted {
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+
+	t.Run("does not wait if not requested", func(t *testing.T) {
+		g := NewWithT(t)
+
+		i := &providerInstaller{
+			proxy:        test.NewFakeProxy(),
+			installQueue: []repository.Components{newFakeComponents("infra1", clusterctlv1.InfrastructureProviderType, "v1.0.0", "ns1")},
+		}
+		g.Expect(i.waitForProvidersReady(InstallOptions{WaitProviders: false})).To(Succeed())
+	})
+}
@@ -0,0 +1,362 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun implements a client that applies changes to an in-memory copy of the objects,
+// keeping track of the changes, instead of applying them to a Kubernetes cluster.
+package dryrun
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// ModifiedObject holds the state of an object before and after the changes applied by the dry run client.
+type ModifiedObject struct {
+	Before *unstructured.Unstructured
+	After  *unstructured.Unstructured
+}
+
+// Changes are the changes applied by the dry run client.
+type Changes struct {
+	Created  []*unstructured.Unstructured
+	Modified []*ModifiedObject
+	Deleted  []*unstructured.Unstructured
+}
+
+// Client implements client.Client on top of an in-memory copy of the objects; all the write operations
+// are applied to the in-memory copy only, and are tracked so they can be reported as Changes.
+// Objects not in the in-memory copy are read from an optional reader, e.g. a client for the live
+// management cluster, and then added to the in-memory copy.
+type Client struct {
+	client.Client
+
+	live client.Reader
+
+	// originals holds the state before the dry run of the objects which have been changed.
+	originals map[objectKey]*unstructured.Unstructured
+	// created holds the version of the objects created by the dry run.
+	created map[objectKey]string
+	deleted map[objectKey]*unstructured.Unstructured
+}
+
+// objectKey identifies an object in the in-memory copy.
+type objectKey struct {
+	gk        schema.GroupKind
+	namespace string
+	name      string
+}
+
+func (k objectKey) String() string {
+	return fmt.Sprintf("%s/%s/%s", k.gk, k.namespace, k.name)
+}
+
+// NewClient returns a dry run client with an in-memory copy of the given objects; live is used
+// to read objects not in the in-memory copy, and it can be nil.
+func NewClient(scheme *runtime.Scheme, live client.Reader, objs []client.Object) *Client {
+	return &Client{
+		Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		live:      live,
+		originals: map[objectKey]*unstructured.Unstructured{},
+		created:   map[objectKey]string{},
+		deleted:   map[objectKey]*unstructured.Unstructured{},
+	}
+}
+
+// Get retrieves an object from the in-memory copy or, if not found, from the live reader.
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	err := c.Client.Get(ctx, key, obj)
+	if err == nil || !apierrors.IsNotFound(err) || c.live == nil {
+		return err
+	}
+
+	k, kErr := c.keyFor(obj, key.Namespace, key.Name)
+	if kErr != nil {
+		return kErr
+	}
+	if _, ok := c.deleted[k]; ok {
+		return err
+	}
+
+	if err := c.live.Get(ctx, key, obj); err != nil {
+		return err
+	}
+	// NOTE: Only the metadata of the object has been read, which can't be added to the in-memory copy.
+	if _, ok := obj.(*metav1.PartialObjectMetadata); ok {
+		return nil
+	}
+	if err := c.seed(ctx, obj); err != nil {
+		return err
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+// List lists objects from the in-memory copy, after adding to it the matching objects from the live reader.
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if c.live != nil {
+		liveList, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return errors.Errorf("failed to copy %T", list)
+		}
+		if err := c.live.List(ctx, liveList, opts...); err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(liveList)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok {
+				return errors.Errorf("unexpected item of type %T", item)
+			}
+			// NOTE: The GroupVersionKind of the list items might be empty, so it is inferred from the list.
+			if obj.GetObjectKind().GroupVersionKind().Empty() {
+				gvk, err := apiutil.GVKForObject(list, c.Scheme())
+				if err != nil {
+					return err
+				}
+				gvk.Kind = trimListSuffix(gvk.Kind)
+				obj.GetObjectKind().SetGroupVersionKind(gvk)
+			}
+			k, err := c.keyFor(obj, obj.GetNamespace(), obj.GetName())
+			if err != nil {
+				return err
+			}
+			if _, ok := c.deleted[k]; ok {
+				continue
+			}
+			if err := c.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); err == nil {
+				continue
+			}
+			if err := c.seed(ctx, obj); err != nil {
+				return err
+			}
+		}
+	}
+	return c.Client.List(ctx, list, opts...)
+}
+
+// Create creates an object in the in-memory copy.
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	k, err := c.keyFor(obj, obj.GetNamespace(), obj.GetName())
+	if err != nil {
+		return err
+	}
+
+	// Make sure create fails if the object exists in the live reader.
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj.DeepCopyObject().(client.Object)); err == nil {
+		return apierrors.NewAlreadyExists(schema.GroupResource{Group: k.gk.Group, Resource: k.gk.Kind}, obj.GetName())
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	if err := c.Client.Create(ctx, obj, opts...); err != nil {
+		return err
+	}
+	if original, ok := c.deleted[k]; ok {
+		// The object has been deleted and then re-created; track it as modified.
+		delete(c.deleted, k)
+		c.originals[k] = original
+		return nil
+	}
+	c.created[k] = gvk.Version
+	return nil
+}
+
+// Update updates an object in the in-memory copy.
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if err := c.trackOriginal(ctx, obj); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// Patch patches an object in the in-memory copy.
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if err := c.trackOriginal(ctx, obj); err != nil {
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+// Delete deletes an object from the in-memory copy.
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if err := c.trackOriginal(ctx, obj); err != nil {
+		return err
+	}
+	if err := c.Client.Delete(ctx, obj, opts...); err != nil {
+		return err
+	}
+
+	k, err := c.keyFor(obj, obj.GetNamespace(), obj.GetName())
+	if err != nil {
+		return err
+	}
+	if _, ok := c.created[k]; ok {
+		// The object has been created and then deleted; nothing to track.
+		delete(c.created, k)
+		return nil
+	}
+	c.deleted[k] = c.originals[k]
+	delete(c.originals, k)
+	return nil
+}
+
+// DeleteAllOf is not supported by the dry run client.
+func (c *Client) DeleteAllOf(_ context.Context, _ client.Object, _ ...client.DeleteAllOfOption) error {
+	return errors.New("DeleteAllOf is not supported by the dry run client")
+}
+
+// Changes returns the changes applied to the in-memory copy of the objects, sorted by kind, namespace and name.
+// NOTE: Changes to the object status, as well as to the metadata fields managed by the API server, are ignored.
+func (c *Client) Changes(ctx context.Context) (*Changes, error) {
+	changes := &Changes{}
+
+	createdKeys := make([]objectKey, 0, len(c.created))
+	for k := range c.created {
+		createdKeys = append(createdKeys, k)
+	}
+	for _, k := range sortKeys(createdKeys) {
+		obj, err := c.getUnstructured(ctx, k, c.created[k])
+		if err != nil {
+			return nil, err
+		}
+		changes.Created = append(changes.Created, obj)
+	}
+
+	for _, k := range sortedKeys(c.originals) {
+		before := c.originals[k]
+		after, err := c.getUnstructured(ctx, k, before.GroupVersionKind().Version)
+		if err != nil {
+			return nil, err
+		}
+		if equalIgnoringStatus(before, after) {
+			continue
+		}
+		changes.Modified = append(changes.Modified, &ModifiedObject{Before: before, After: after})
+	}
+
+	for _, k := range sortedKeys(c.deleted) {
+		changes.Deleted = append(changes.Deleted, c.deleted[k])
+	}
+
+	return changes, nil
+}
+
+// seed adds an object read from the live reader to the in-memory copy.
+func (c *Client) seed(ctx context.Context, obj client.Object) error {
+	seed, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return errors.Errorf("failed to copy %T", obj)
+	}
+	seed.SetResourceVersion("")
+	return c.Client.Create(ctx, seed)
+}
+
+// trackOriginal stores the state of an object before the first change applied by the dry run client.
+func (c *Client) trackOriginal(ctx context.Context, obj client.Object) error {
+	k, err := c.keyFor(obj, obj.GetNamespace(), obj.GetName())
+	if err != nil {
+		return err
+	}
+	if _, ok := c.originals[k]; ok {
+		return nil
+	}
+	if _, ok := c.created[k]; ok {
+		return nil
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+	original, err := c.getUnstructured(ctx, k, gvk.Version)
+	if err != nil {
+		return err
+	}
+	c.originals[k] = original
+	return nil
+}
+
+// getUnstructured gets an object from the in-memory copy, converting it to unstructured.
+func (c *Client) getUnstructured(ctx context.Context, k objectKey, version string) (*unstructured.Unstructured, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(k.gk.WithVersion(version))
+	if err := c.Client.Get(ctx, client.ObjectKey{Namespace: k.namespace, Name: k.name}, u); err != nil {
+		return nil, errors.Wrapf(err, "failed to get %s", k)
+	}
+	return u, nil
+}
+
+// keyFor returns the key of an object in the in-memory copy.
+func (c *Client) keyFor(obj runtime.Object, namespace, name string) (objectKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return objectKey{}, err
+	}
+	return objectKey{gk: gvk.GroupKind(), namespace: namespace, name: name}, nil
+}
+
+// equalIgnoringStatus returns true if two objects are equal, ignoring the status and
+// the metadata fields managed by the API server.
+func equalIgnoringStatus(a, b *unstructured.Unstructured) bool {
+	clean := func(u *unstructured.Unstructured) map[string]interface{} {
+		c := u.DeepCopy()
+		unstructured.RemoveNestedField(c.Object, "status")
+		unstructured.RemoveNestedField(c.Object, "metadata", "resourceVersion")
+		unstructured.RemoveNestedField(c.Object, "metadata", "generation")
+		unstructured.RemoveNestedField(c.Object, "metadata", "managedFields")
+		return c.Object
+	}
+	return reflect.DeepEqual(clean(a), clean(b))
+}
+
+func sortedKeys(m map[objectKey]*unstructured.Unstructured) []objectKey {
+	keys := make([]objectKey, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return sortKeys(keys)
+}
+
+func sortKeys(keys []objectKey) []objectKey {
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	return keys
+}
+
+func trimListSuffix(kind string) string {
+	if len(kind) > 4 && kind[len(kind)-4:] == "List" {
+		return kind[:len(kind)-4]
+	}
+	return kind
+}
@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"sync"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/yaml"
@@ -40,11 +43,19 @@ import (
 // ObjectMover defines methods for moving Cluster API objects to another management cluster.
 type ObjectMover interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
-	Move(namespace string, toCluster Client, dryRun bool) error
+	// If checkpointFile is not empty, the progress of the move is recorded in the file, so a move interrupted by a failure
+	// can be resumed by running it again with the same checkpoint file.
+	Move(namespace string, toCluster Client, dryRun bool, checkpointFile string) error
 	// Backup saves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
 	Backup(namespace string, directory string) error
 	// Restore restores all the Cluster API objects existing in a configured directory to a target management cluster.
 	Restore(toCluster Client, directory string) error
+	// Sync copies all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a standby management cluster,
+	// where the Clusters are kept paused until promotion; the objects in the source management cluster are not modified.
+	Sync(namespace string, toCluster Client) error
+	// Promote resumes the reconciliation of the standby Clusters existing in a namespace (or in all the namespaces if empty);
+	// if fromCluster is not nil, the corresponding Clusters in the former primary management cluster are paused on a best-effort basis.
+	Promote(namespace string, fromCluster Client) error
 }
 
 // objectMover implements the ObjectMover interface.
@@ -52,12 +63,30 @@ type objectMover struct {
 	fromProxy             Proxy
 	fromProviderInventory InventoryClient
 	dryRun                bool
+
+	// standby instructs the mover to create objects for a standby management cluster, i.e. without status
+	// and with Clusters paused.
+	standby bool
+
+	// parallelism is the number of objects of the same move group processed in parallel; defaults to defaultMoveParallelism.
+	parallelism int
+
+	// checkpoint records the progress of the move operation, if a checkpoint file is used.
+	checkpoint *moveCheckpoint
+
+	// clients caches the clients for the source and target management clusters, because creating
+	// a client requires to discover the API resources of the cluster.
+	clients     map[Proxy]client.Client
+	clientsLock sync.Mutex
 }
 
+// defaultMoveParallelism is the default number of objects of the same move group processed in parallel.
+const defaultMoveParallelism = 10
+
 // ensure objectMover implements the ObjectMover interface.
 var _ ObjectMover = &objectMover{}
 
-func (o *objectMover) Move(namespace string, toCluster Client, dryRun bool) error {
+func (o *objectMover) Move(namespace string, toCluster Client, dryRun bool, checkpointFile string) error {
 	log := logf.Log
 	log.Info("Performing move...")
 	o.dryRun = dryRun
@@ -74,25 +103,44 @@ func (o *objectMover) Move(namespace string, toCluster Client, dryRun bool) erro
 		}
 	}
 
-	objectGraph, err := o.getObjectGraph(namespace)
+	// OwnerReferences are repaired only when actually moving, while in dry-run mode they are only reported.
+	objectGraph, err := o.getObjectGraph(namespace, !o.dryRun)
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
 
+	// Resume from the checkpoint of a previous, interrupted move, if any.
+	if checkpointFile != "" && !o.dryRun {
+		if o.checkpoint, err = loadMoveCheckpoint(checkpointFile); err != nil {
+			return err
+		}
+		if len(o.checkpoint.Created) > 0 || len(o.checkpoint.Deleted) > 0 {
+			log.Info("Resuming move from checkpoint", "File", checkpointFile, "Created", len(o.checkpoint.Created), "Deleted", len(o.checkpoint.Deleted), "Clusters", len(o.checkpoint.Clusters))
+		}
+	}
+
 	// Move the objects to the target cluster.
 	var proxy Proxy
 	if !o.dryRun {
 		proxy = toCluster.Proxy()
 	}
 
-	return o.move(objectGraph, proxy)
+	if err := o.move(objectGraph, proxy); err != nil {
+		if o.checkpoint != nil {
+			return errors.Wrapf(err, "move interrupted, run it again with the same checkpoint file %q to resume", checkpointFile)
+		}
+		return err
+	}
+
+	return o.checkpoint.remove()
 }
 
 func (o *objectMover) Backup(namespace string, directory string) error {
 	log := logf.Log
 	log.Info("Performing backup...")
 
-	objectGraph, err := o.getObjectGraph(namespace)
+	// A backup must not change the source cluster, so broken OwnerReferences are only reported.
+	objectGraph, err := o.getObjectGraph(namespace, false)
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
@@ -171,20 +219,87 @@ func (o *objectMover) filesToObjs(dir string) ([]unstructured.Unstructured, erro
 	return objs, nil
 }
 
-func (o *objectMover) getObjectGraph(namespace string) (*objectGraph, error) {
-	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
+func (o *objectMover) Sync(namespace string, toCluster Client) error {
+	log := logf.Log
+	log.Info("Performing sync to the standby management cluster...")
+	o.standby = true
 
-	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
-	err := objectGraph.getDiscoveryTypes()
+	// checks that all the required providers in place in the standby cluster.
+	if err := o.checkTargetProviders(toCluster.ProviderInventory()); err != nil {
+		return errors.Wrap(err, "failed to check providers in standby cluster")
+	}
+
+	// NOTE: provisioning is not required to be completed, because objects in the source cluster are not paused;
+	// each sync copies the latest state of the objects, and Clusters in the standby cluster are kept paused.
+	objectGraph, err := o.discoverObjectGraph(namespace)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to retrieve discovery types")
+		return errors.Wrap(err, "failed to get object graph")
 	}
+	objectGraph.checkVirtualNode()
 
-	// Discovery the object graph for the selected types:
-	// - Nodes are defined the Kubernetes objects (Clusters, Machines etc.) identified during the discovery process.
-	// - Edges are derived by the OwnerReferences between nodes.
-	if err := objectGraph.Discovery(namespace); err != nil {
-		return nil, errors.Wrap(err, "failed to discover the object graph")
+	return o.sync(objectGraph, toCluster.Proxy(), namespace)
+}
+
+func (o *objectMover) Promote(namespace string, fromCluster Client) error {
+	log := logf.Log
+	log.Info("Promoting the standby management cluster...")
+
+	// Pause the Clusters in the former primary management cluster, if still reachable, so two management clusters
+	// do not reconcile the same workload clusters.
+	if fromCluster != nil {
+		if err := pauseAllClusters(fromCluster.Proxy(), namespace); err != nil {
+			log.Info("Failed to pause Clusters in the former primary management cluster, continuing with promotion", "Error", err.Error())
+		}
+	}
+
+	c, err := o.fromProxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := c.List(ctx, clusterList, client.InNamespace(namespace)); err != nil {
+		return errors.Wrap(err, "failed to list Clusters")
+	}
+
+	// Remove the standby annotation and restore the pause field on the standby Clusters to the value it had in the
+	// primary management cluster at sync time, so the controllers start reconciling them.
+	promoted := 0
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		paused, ok := cluster.GetAnnotations()[clusterctlv1.StandbyAnnotation]
+		if !ok {
+			continue
+		}
+		log.V(1).Info("Promoting", "Cluster", cluster.Name, "Namespace", cluster.Namespace)
+		patch := client.RawPatch(types.MergePatchType, []byte(fmt.Sprintf("{\"metadata\":{\"annotations\":{%q:null}},\"spec\":{\"paused\":%t}}", clusterctlv1.StandbyAnnotation, paused == "true")))
+		if err := c.Patch(ctx, cluster, patch); err != nil {
+			return errors.Wrapf(err, "error promoting Cluster %s/%s", cluster.Namespace, cluster.Name)
+		}
+		promoted++
+	}
+	log.Info("Standby Clusters promoted", "Clusters", promoted)
+	return nil
+}
+
+// getObjectGraph discovers the object graph for the objects to be moved/backed up; if repair is true, the OwnerReferences
+// of the infrastructure and bootstrap objects of the Machines are repaired in the source cluster, otherwise they are only reported.
+func (o *objectMover) getObjectGraph(namespace string, repair bool) (*objectGraph, error) {
+	objectGraph, err := o.discoverObjectGraph(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Repairs the OwnerReferences of the infrastructure and bootstrap objects of the Machines, so they are moved
+	// together with their Machine; if any OwnerReference has been repaired, the object graph is discovered again.
+	repaired, err := o.repairOwnerReferences(objectGraph, repair)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to repair OwnerReferences")
+	}
+	if repaired {
+		if objectGraph, err = o.discoverObjectGraph(namespace); err != nil {
+			return nil, err
+		}
 	}
 
 	// Checks if Cluster API has already completed the provisioning of the infrastructure for the objects involved in the move/backup operation.
@@ -201,6 +316,26 @@ func (o *objectMover) getObjectGraph(namespace string) (*objectGraph, error) {
 	return objectGraph, nil
 }
 
+// discoverObjectGraph builds the object graph for all the objects existing in a namespace (or in all the namespaces if empty).
+func (o *objectMover) discoverObjectGraph(namespace string) (*objectGraph, error) {
+	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
+
+	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
+	err := objectGraph.getDiscoveryTypes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve discovery types")
+	}
+
+	// Discovery the object graph for the selected types:
+	// - Nodes are defined the Kubernetes objects (Clusters, Machines etc.) identified during the discovery process.
+	// - Edges are derived by the OwnerReferences between nodes.
+	if err := objectGraph.Discovery(namespace); err != nil {
+		return nil, errors.Wrap(err, "failed to discover the object graph")
+	}
+
+	return objectGraph, nil
+}
+
 func newObjectMover(fromProxy Proxy, fromProviderInventory InventoryClient) *objectMover {
 	return &objectMover{
 		fromProxy:             fromProxy,
@@ -208,6 +343,156 @@ func newObjectMover(fromProxy Proxy, fromProviderInventory InventoryClient) *obj
 	}
 }
 
+// newClient returns a client for the cluster, reusing the client previously created for the same proxy, if any.
+func (o *objectMover) newClient(proxy Proxy) (client.Client, error) {
+	o.clientsLock.Lock()
+	defer o.clientsLock.Unlock()
+
+	if c, ok := o.clients[proxy]; ok {
+		return c, nil
+	}
+	c, err := proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	if o.clients == nil {
+		o.clients = map[Proxy]client.Client{}
+	}
+	o.clients[proxy] = c
+	return c, nil
+}
+
+// processGroup calls action for all the nodes in a move group, processing up to parallelism nodes in parallel;
+// the nodes of a group do not depend on each other, because a node is always in a group after its owners.
+// Each action is wrapped in a retry loop to make move more resilient to unexpected conditions.
+func (o *objectMover) processGroup(group moveGroup, action func(*node) error) error {
+	parallelism := o.parallelism
+	if parallelism <= 0 {
+		parallelism = defaultMoveParallelism
+	}
+
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		errList []error
+	)
+	sem := make(chan struct{}, parallelism)
+	for i := range group {
+		n := group[i]
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := retryWithExponentialBackoff(newWriteBackoff(), func() error {
+				return action(n)
+			}); err != nil {
+				lock.Lock()
+				errList = append(errList, err)
+				lock.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return kerrors.NewAggregate(errList)
+}
+
+// repairOwnerReferences ensures the infrastructure and bootstrap objects referenced by the Machines are owned by their Machine,
+// because objects are moved following the OwnerReference chain starting from the Clusters; missing OwnerReferences, or OwnerReferences
+// to Machines which do not exist anymore, e.g. with a stale UID, are repaired in the source cluster if repair is true, or only reported
+// otherwise, e.g. in dry-run mode or when backing up.
+// It returns true if any object has been repaired, and an error listing the objects which cannot be repaired, e.g. because they do not exist.
+func (o *objectMover) repairOwnerReferences(graph *objectGraph, repair bool) (bool, error) {
+	log := logf.Log
+	errList := []error{}
+	repaired := false
+
+	readMachinesBackoff := newReadBackoff()
+	machines := graph.getMachines()
+	for i := range machines {
+		machine := machines[i]
+		// Virtual nodes are Machines referenced by OwnerReferences which do not exist.
+		if machine.virtual {
+			continue
+		}
+		machineObj := &clusterv1.Machine{}
+		if err := retryWithExponentialBackoff(readMachinesBackoff, func() error {
+			return getMachineObj(o.fromProxy, machine, machineObj)
+		}); err != nil {
+			return false, err
+		}
+
+		refs := []*corev1.ObjectReference{&machineObj.Spec.InfrastructureRef}
+		if machineObj.Spec.Bootstrap.ConfigRef != nil {
+			refs = append(refs, machineObj.Spec.Bootstrap.ConfigRef)
+		}
+		for _, ref := range refs {
+			refNode := graph.getNodeByReference(ref, machineObj.Namespace)
+			if refNode == nil {
+				errList = append(errList, errors.Errorf("%s %s/%s referenced by Machine %s/%s is not found among the objects to be moved",
+					ref.Kind, machineObj.Namespace, ref.Name, machineObj.Namespace, machineObj.Name))
+				continue
+			}
+			if refNode.isOwnedBy(machine) {
+				continue
+			}
+
+			if !repair {
+				log.Info("OwnerReference to be repaired", "kind", refNode.identity.Kind, "name", refNode.identity.Name, "namespace", refNode.identity.Namespace, "machine", machineObj.Name)
+				continue
+			}
+			if err := o.setMachineOwnerReference(graph, refNode, machineObj); err != nil {
+				errList = append(errList, err)
+				continue
+			}
+			log.Info("Repaired OwnerReference", "kind", refNode.identity.Kind, "name", refNode.identity.Name, "namespace", refNode.identity.Namespace, "machine", machineObj.Name)
+			repaired = true
+		}
+	}
+
+	return repaired, kerrors.NewAggregate(errList)
+}
+
+// setMachineOwnerReference sets the Machine as the controller owner of the object corresponding to a node,
+// dropping the OwnerReferences to Machines which do not exist anymore.
+func (o *objectMover) setMachineOwnerReference(graph *objectGraph, n *node, machineObj *clusterv1.Machine) error {
+	c, err := o.newClient(o.fromProxy)
+	if err != nil {
+		return err
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(n.identity.APIVersion)
+	obj.SetKind(n.identity.Kind)
+	if err := c.Get(ctx, client.ObjectKey{Namespace: n.identity.Namespace, Name: n.identity.Name}, obj); err != nil {
+		return errors.Wrapf(err, "error reading %s %s/%s", n.identity.Kind, n.identity.Namespace, n.identity.Name)
+	}
+
+	ownerRefs := []metav1.OwnerReference{}
+	for _, ref := range obj.GetOwnerReferences() {
+		owner, ok := graph.uidToNode[ref.UID]
+		if ref.Kind == "Machine" && (!ok || owner.virtual) {
+			continue
+		}
+		if ref.Controller != nil && *ref.Controller {
+			return errors.Errorf("%s %s/%s referenced by Machine %s/%s is controlled by %s %s",
+				n.identity.Kind, n.identity.Namespace, n.identity.Name, machineObj.Namespace, machineObj.Name, ref.Kind, ref.Name)
+		}
+		ownerRefs = append(ownerRefs, ref)
+	}
+	ownerRefs = append(ownerRefs, *metav1.NewControllerRef(machineObj, clusterv1.GroupVersion.WithKind("Machine")))
+
+	patch := client.MergeFrom(obj.DeepCopy())
+	obj.SetOwnerReferences(ownerRefs)
+	if err := c.Patch(ctx, obj, patch); err != nil {
+		return errors.Wrapf(err, "error repairing the OwnerReferences of %s %s/%s", n.identity.Kind, n.identity.Namespace, n.identity.Name)
+	}
+	return nil
+}
+
 // checkProvisioningCompleted checks if Cluster API has already completed the provisioning of the infrastructure for the objects involved in the move operation.
 func (o *objectMover) checkProvisioningCompleted(graph *objectGraph) error {
 	if o.dryRun {
@@ -250,6 +535,9 @@ func (o *objectMover) checkProvisioningCompleted(graph *objectGraph) error {
 	machines := graph.getMachines()
 	for i := range machines {
 		machine := machines[i]
+		if machine.virtual {
+			continue
+		}
 		machineObj := &clusterv1.Machine{}
 		if err := retryWithExponentialBackoff(readMachinesBackoff, func() error {
 			return getMachineObj(o.fromProxy, machine, machineObj)
@@ -308,6 +596,13 @@ func (o *objectMover) move(graph *objectGraph, toProxy Proxy) error {
 	clusters := graph.getClusters()
 	log.Info("Moving Cluster API objects", "Clusters", len(clusters))
 
+	// Record the Clusters in the checkpoint before pausing them, so they are unpaused in the target management cluster
+	// also if the move is resumed after they have been deleted from the source management cluster.
+	o.checkpoint.addClusters(clusters)
+	if err := o.checkpoint.save(); err != nil {
+		return err
+	}
+
 	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
 	log.V(1).Info("Pausing the source cluster")
 	if err := setClusterPause(o.fromProxy, clusters, true, o.dryRun); err != nil {
@@ -343,9 +638,156 @@ func (o *objectMover) move(graph *objectGraph, toProxy Proxy) error {
 		}
 	}
 
-	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
+	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it;
+	// this includes the Clusters moved by a previous, interrupted move, which no longer exist in the source management cluster.
 	log.V(1).Info("Resuming the target cluster")
-	return setClusterPause(toProxy, clusters, false, o.dryRun)
+	clusters = o.checkpoint.clustersToUnpause(clusters)
+	if err := setClusterPause(toProxy, clusters, false, o.dryRun); err != nil {
+		return err
+	}
+	o.checkpoint.setUnpaused(clusters)
+	return o.checkpoint.save()
+}
+
+// sync copies all the objects in the graph to a standby management cluster, without pausing or deleting the source objects;
+// objects previously synced to the standby management cluster and no longer existing in the source cluster are deleted.
+func (o *objectMover) sync(graph *objectGraph, toProxy Proxy, namespace string) error {
+	log := logf.Log
+
+	clusters := graph.getClusters()
+	log.Info("Syncing Cluster API objects", "Clusters", len(clusters))
+
+	// Ensure all the expected target namespaces are in place before creating objects.
+	log.V(1).Info("Creating standby namespaces, if missing")
+	if err := o.ensureNamespaces(graph, toProxy); err != nil {
+		return err
+	}
+
+	// Create or update all objects group by group, ensuring all the ownerReferences are re-created.
+	moveSequence := getMoveSequence(graph)
+	log.Info("Creating or updating objects in the standby cluster")
+	for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
+		if err := o.createGroup(moveSequence.getGroup(groupIndex), toProxy); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Deleting objects removed from the source cluster")
+	return o.deleteStaleStandbyObjects(graph, toProxy, namespace)
+}
+
+// deleteStaleStandbyObjects deletes the objects existing in the standby management cluster which have been synced by a previous
+// sync, i.e. they have the standby annotation, but they do not exist anymore in the source cluster.
+func (o *objectMover) deleteStaleStandbyObjects(graph *objectGraph, toProxy Proxy, namespace string) error {
+	log := logf.Log
+
+	existing := sets.NewString()
+	for _, n := range graph.uidToNode {
+		existing.Insert(standbyObjectKey(n.identity.GroupVersionKind().GroupKind().String(), n.identity.Namespace, n.identity.Name))
+	}
+
+	selectors := []client.ListOption{}
+	if namespace != "" {
+		selectors = append(selectors, client.InNamespace(namespace))
+	}
+
+	cTo, err := o.newClient(toProxy)
+	if err != nil {
+		return err
+	}
+
+	var errList []error
+	for _, discoveryType := range graph.types {
+		objList := new(unstructured.UnstructuredList)
+		if err := retryWithExponentialBackoff(newReadBackoff(), func() error {
+			return getObjList(toProxy, discoveryType.typeMeta, selectors, objList)
+		}); err != nil {
+			return err
+		}
+
+		for i := range objList.Items {
+			obj := &objList.Items[i]
+			if _, ok := obj.GetAnnotations()[clusterctlv1.StandbyAnnotation]; !ok {
+				continue
+			}
+			if existing.Has(standbyObjectKey(obj.GroupVersionKind().GroupKind().String(), obj.GetNamespace(), obj.GetName())) {
+				continue
+			}
+
+			log.V(1).Info("Deleting", obj.GetKind(), obj.GetName(), "Namespace", obj.GetNamespace())
+			if o.dryRun {
+				continue
+			}
+
+			// Finalizers are removed, because the controllers in the standby management cluster are not reconciling the objects.
+			if len(obj.GetFinalizers()) > 0 {
+				if err := cTo.Patch(ctx, obj, removeFinalizersPatch); err != nil && !apierrors.IsNotFound(err) {
+					errList = append(errList, errors.Wrapf(err, "error removing finalizers from %q %s/%s",
+						obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName()))
+					continue
+				}
+			}
+			if err := cTo.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				errList = append(errList, errors.Wrapf(err, "error deleting %q %s/%s",
+					obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName()))
+			}
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+func standbyObjectKey(groupKind, namespace, name string) string {
+	return groupKind + "/" + namespace + "/" + name
+}
+
+// pauseAllClusters sets the paused field on all the Clusters existing in a namespace (or in all the namespaces if empty).
+func pauseAllClusters(proxy Proxy, namespace string) error {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := c.List(ctx, clusterList, client.InNamespace(namespace)); err != nil {
+		return errors.Wrap(err, "failed to list Clusters")
+	}
+
+	patch := client.RawPatch(types.MergePatchType, []byte("{\"spec\":{\"paused\":true}}"))
+	var errList []error
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if err := c.Patch(ctx, cluster, patch); err != nil {
+			errList = append(errList, errors.Wrapf(err, "error pausing Cluster %s/%s", cluster.Namespace, cluster.Name))
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// prepareStandbyObject prepares an object for being created in a standby management cluster by removing
+// the status and by pausing Clusters; objects are also marked with the standby annotation, so they can be
+// deleted by later syncs if removed from the source cluster. On Clusters, the annotation records the value
+// of the pause field in the source cluster, so it can be restored on promotion.
+func prepareStandbyObject(obj *unstructured.Unstructured) error {
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[clusterctlv1.StandbyAnnotation] = ""
+
+	if obj.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("Cluster").GroupKind() {
+		paused, _, err := unstructured.NestedBool(obj.Object, "spec", "paused")
+		if err != nil {
+			return errors.Wrapf(err, "failed to get the paused field of Cluster %s/%s", obj.GetNamespace(), obj.GetName())
+		}
+		annotations[clusterctlv1.StandbyAnnotation] = strconv.FormatBool(paused)
+		if err := unstructured.SetNestedField(obj.Object, true, "spec", "paused"); err != nil {
+			return errors.Wrapf(err, "failed to pause Cluster %s/%s", obj.GetNamespace(), obj.GetName())
+		}
+	}
+	obj.SetAnnotations(annotations)
+	return nil
 }
 
 func (o *objectMover) backup(graph *objectGraph, directory string) error {
@@ -574,7 +1016,7 @@ func (o *objectMover) ensureNamespaces(graph *objectGraph, toProxy Proxy) error
 func (o *objectMover) ensureNamespace(toProxy Proxy, namespace string) error {
 	log := logf.Log
 
-	cs, err := toProxy.NewClient()
+	cs, err := o.newClient(toProxy)
 	if err != nil {
 		return err
 	}
@@ -635,69 +1077,38 @@ func (o *objectMover) ensureNamespace(toProxy Proxy, namespace string) error {
 
 // createGroup creates all the Kubernetes objects into the target management cluster corresponding to the object graph nodes in a moveGroup.
 func (o *objectMover) createGroup(group moveGroup, toProxy Proxy) error {
-	createTargetObjectBackoff := newWriteBackoff()
-	errList := []error{}
-
-	for _, nodeToCreate := range group {
+	err := o.processGroup(group, func(nodeToCreate *node) error {
+		// Skip the objects already created by a previous, interrupted move.
+		if o.checkpoint.isCreated(nodeToCreate) {
+			return nil
+		}
 		// Creates the Kubernetes object corresponding to the nodeToCreate.
-		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
-		err := retryWithExponentialBackoff(createTargetObjectBackoff, func() error {
-			return o.createTargetObject(nodeToCreate, toProxy)
-		})
-		if err != nil {
-			errList = append(errList, err)
+		if err := o.createTargetObject(nodeToCreate, toProxy); err != nil {
+			return err
 		}
-	}
+		o.checkpoint.setCreated(nodeToCreate)
+		return nil
+	})
 
-	if len(errList) > 0 {
-		return kerrors.NewAggregate(errList)
+	// Save the progress, also in case of errors, so the move can be resumed from this group.
+	if saveErr := o.checkpoint.save(); saveErr != nil {
+		return kerrors.NewAggregate([]error{err, saveErr})
 	}
-
-	return nil
+	return err
 }
 
 func (o *objectMover) backupGroup(group moveGroup, directory string) error {
-	backupTargetObjectBackoff := newWriteBackoff()
-	errList := []error{}
-
-	for _, nodeToBackup := range group {
+	return o.processGroup(group, func(nodeToBackup *node) error {
 		// Backs-up the Kubernetes object corresponding to the nodeToBackup.
-		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
-		err := retryWithExponentialBackoff(backupTargetObjectBackoff, func() error {
-			return o.backupTargetObject(nodeToBackup, directory)
-		})
-		if err != nil {
-			errList = append(errList, err)
-		}
-	}
-
-	if len(errList) > 0 {
-		return kerrors.NewAggregate(errList)
-	}
-
-	return nil
+		return o.backupTargetObject(nodeToBackup, directory)
+	})
 }
 
 func (o *objectMover) restoreGroup(group moveGroup, toProxy Proxy) error {
-	restoreTargetObjectBackoff := newWriteBackoff()
-	errList := []error{}
-
-	for _, nodeToRestore := range group {
+	return o.processGroup(group, func(nodeToRestore *node) error {
 		// Creates the Kubernetes object corresponding to the nodeToRestore.
-		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
-		err := retryWithExponentialBackoff(restoreTargetObjectBackoff, func() error {
-			return o.restoreTargetObject(nodeToRestore, toProxy)
-		})
-		if err != nil {
-			errList = append(errList, err)
-		}
-	}
-
-	if len(errList) > 0 {
-		return kerrors.NewAggregate(errList)
-	}
-
-	return nil
+		return o.restoreTargetObject(nodeToRestore, toProxy)
+	})
 }
 
 // createTargetObject creates the Kubernetes object in the target Management cluster corresponding to the object graph node, taking care of restoring the OwnerReference with the owner nodes, if any.
@@ -709,7 +1120,7 @@ func (o *objectMover) createTargetObject(nodeToCreate *node, toProxy Proxy) erro
 		return nil
 	}
 
-	cFrom, err := o.fromProxy.NewClient()
+	cFrom, err := o.newClient(o.fromProxy)
 	if err != nil {
 		return err
 	}
@@ -737,6 +1148,13 @@ func (o *objectMover) createTargetObject(nodeToCreate *node, toProxy Proxy) erro
 	// Rebuild the owne reference chain
 	o.buildOwnerChain(obj, nodeToCreate)
 
+	// Objects in a standby cluster do not carry status, and Clusters are paused.
+	if o.standby {
+		if err := prepareStandbyObject(obj); err != nil {
+			return err
+		}
+	}
+
 	// FIXME Workaround for https://github.com/kubernetes/kubernetes/issues/32220. Remove when the issue is fixed.
 	// If the resource already exists, the API server ordinarily returns an AlreadyExists error. Due to the above issue, if the resource has a non-empty metadata.generateName field, the API server returns a ServerTimeoutError. To ensure that the API server returns an AlreadyExists error, we set the metadata.generateName field to an empty string.
 	if len(obj.GetName()) > 0 && len(obj.GetGenerateName()) > 0 {
@@ -744,7 +1162,7 @@ func (o *objectMover) createTargetObject(nodeToCreate *node, toProxy Proxy) erro
 	}
 
 	// Creates the targetObj into the target management cluster.
-	cTo, err := toProxy.NewClient()
+	cTo, err := o.newClient(toProxy)
 	if err != nil {
 		return err
 	}
@@ -790,7 +1208,7 @@ func (o *objectMover) backupTargetObject(nodeToCreate *node, directory string) e
 	log := logf.Log
 	log.V(1).Info("Saving", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
 
-	cFrom, err := o.fromProxy.NewClient()
+	cFrom, err := o.newClient(o.fromProxy)
 	if err != nil {
 		return err
 	}
@@ -838,7 +1256,7 @@ func (o *objectMover) restoreTargetObject(nodeToCreate *node, toProxy Proxy) err
 	log.V(1).Info("Restoring", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
 
 	// Creates the targetObj into the target management cluster.
-	cTo, err := toProxy.NewClient()
+	cTo, err := o.newClient(toProxy)
 	if err != nil {
 		return err
 	}
@@ -916,23 +1334,24 @@ func (o *objectMover) buildOwnerChain(obj *unstructured.Unstructured, n *node) {
 
 // deleteGroup deletes all the Kubernetes objects from the source management cluster corresponding to the object graph nodes in a moveGroup.
 func (o *objectMover) deleteGroup(group moveGroup) error {
-	deleteSourceObjectBackoff := newWriteBackoff()
-	errList := []error{}
-	for i := range group {
-		nodeToDelete := group[i]
-
+	err := o.processGroup(group, func(nodeToDelete *node) error {
+		// Skip the objects already deleted by a previous, interrupted move.
+		if o.checkpoint.isDeleted(nodeToDelete) {
+			return nil
+		}
 		// Delete the Kubernetes object corresponding to the current node.
-		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
-		err := retryWithExponentialBackoff(deleteSourceObjectBackoff, func() error {
-			return o.deleteSourceObject(nodeToDelete)
-		})
-
-		if err != nil {
-			errList = append(errList, err)
+		if err := o.deleteSourceObject(nodeToDelete); err != nil {
+			return err
 		}
-	}
+		o.checkpoint.setDeleted(nodeToDelete)
+		return nil
+	})
 
-	return kerrors.NewAggregate(errList)
+	// Save the progress, also in case of errors, so the move can be resumed from this group.
+	if saveErr := o.checkpoint.save(); saveErr != nil {
+		return kerrors.NewAggregate([]error{err, saveErr})
+	}
+	return err
 }
 
 var (
@@ -954,7 +1373,7 @@ func (o *objectMover) deleteSourceObject(nodeToDelete *node) error {
 		return nil
 	}
 
-	cFrom, err := o.fromProxy.NewClient()
+	cFrom, err := o.newClient(o.fromProxy)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,235 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// moveCheckpoint records the progress of a move operation in a file, so a move interrupted by a failure
+// can be resumed without processing again the objects already created in the target management cluster
+// or already deleted from the source management cluster.
+// NOTE: all the methods are no-op on a nil moveCheckpoint, so the mover can use it unconditionally.
+type moveCheckpoint struct {
+	path string
+	lock sync.Mutex
+
+	// Created maps the objects already created in the target management cluster to their UID in the target
+	// management cluster, required to rebuild the OwnerReferences of their dependents.
+	Created map[string]types.UID `json:"created,omitempty"`
+
+	// Deleted lists the objects already deleted from the source management cluster.
+	Deleted map[string]bool `json:"deleted,omitempty"`
+
+	// Clusters maps the namespace/name of the Clusters being moved to whether they have already been unpaused in
+	// the target management cluster; Clusters are recorded before being paused, so they can be unpaused when resuming
+	// a move interrupted after they have been deleted from the source management cluster.
+	Clusters map[string]bool `json:"clusters,omitempty"`
+}
+
+// loadMoveCheckpoint reads the checkpoint file at path, or returns an empty checkpoint if the file does not exist yet.
+func loadMoveCheckpoint(path string) (*moveCheckpoint, error) {
+	c := &moveCheckpoint{
+		path:     path,
+		Created:  map[string]types.UID{},
+		Deleted:  map[string]bool{},
+		Clusters: map[string]bool{},
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return c, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read move checkpoint file %q", path)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse move checkpoint file %q", path)
+	}
+	if c.Created == nil {
+		c.Created = map[string]types.UID{}
+	}
+	if c.Deleted == nil {
+		c.Deleted = map[string]bool{}
+	}
+	if c.Clusters == nil {
+		c.Clusters = map[string]bool{}
+	}
+	return c, nil
+}
+
+// checkpointKey returns the key identifying the object corresponding to a node in a checkpoint;
+// the version is not included, so the key does not change if the object is read at a different version.
+func checkpointKey(n *node) string {
+	return fmt.Sprintf("%s/%s/%s", n.identity.GroupVersionKind().GroupKind(), n.identity.Namespace, n.identity.Name)
+}
+
+// isCreated returns true if the object corresponding to the node has already been created in the target management
+// cluster, restoring its new UID on the node.
+func (c *moveCheckpoint) isCreated(n *node) bool {
+	if c == nil {
+		return false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	uid, ok := c.Created[checkpointKey(n)]
+	if ok {
+		n.newUID = uid
+	}
+	return ok
+}
+
+// setCreated records that the object corresponding to the node has been created in the target management cluster.
+func (c *moveCheckpoint) setCreated(n *node) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.Created[checkpointKey(n)] = n.newUID
+}
+
+// isDeleted returns true if the object corresponding to the node has already been deleted from the source management cluster.
+func (c *moveCheckpoint) isDeleted(n *node) bool {
+	if c == nil {
+		return false
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.Deleted[checkpointKey(n)]
+}
+
+// setDeleted records that the object corresponding to the node has been deleted from the source management cluster.
+func (c *moveCheckpoint) setDeleted(n *node) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.Deleted[checkpointKey(n)] = true
+}
+
+// addClusters records the Clusters being moved, if not already recorded by a previous, interrupted move.
+func (c *moveCheckpoint) addClusters(clusters []*node) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, n := range clusters {
+		key := types.NamespacedName{Namespace: n.identity.Namespace, Name: n.identity.Name}.String()
+		if _, ok := c.Clusters[key]; !ok {
+			c.Clusters[key] = false
+		}
+	}
+}
+
+// clustersToUnpause returns the given Clusters together with the Clusters recorded in the checkpoint and not yet
+// unpaused in the target management cluster, e.g. because they were deleted from the source management cluster
+// by a previous, interrupted move.
+func (c *moveCheckpoint) clustersToUnpause(clusters []*node) []*node {
+	if c == nil {
+		return clusters
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	known := map[string]bool{}
+	for _, n := range clusters {
+		known[types.NamespacedName{Namespace: n.identity.Namespace, Name: n.identity.Name}.String()] = true
+	}
+	keys := make([]string, 0, len(c.Clusters))
+	for key, unpaused := range c.Clusters {
+		if !unpaused && !known[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	ret := append([]*node{}, clusters...)
+	for _, key := range keys {
+		namespace, name := "", key
+		if i := strings.Index(key, "/"); i >= 0 {
+			namespace, name = key[:i], key[i+1:]
+		}
+		ret = append(ret, &node{
+			identity: corev1.ObjectReference{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Cluster",
+				Namespace:  namespace,
+				Name:       name,
+			},
+		})
+	}
+	return ret
+}
+
+// setUnpaused records that the Clusters have been unpaused in the target management cluster.
+func (c *moveCheckpoint) setUnpaused(clusters []*node) {
+	if c == nil {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, n := range clusters {
+		c.Clusters[types.NamespacedName{Namespace: n.identity.Namespace, Name: n.identity.Name}.String()] = true
+	}
+}
+
+// save writes the checkpoint to its file.
+func (c *moveCheckpoint) save() error {
+	if c == nil {
+		return nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal move checkpoint")
+	}
+	if err := os.WriteFile(c.path, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write move checkpoint file %q", c.path)
+	}
+	return nil
+}
+
+// remove deletes the checkpoint file, once the move operation is completed.
+func (c *moveCheckpoint) remove() error {
+	if c == nil {
+		return nil
+	}
+	if err := os.Remove(c.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove move checkpoint file %q", c.path)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_moveCheckpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	n := &node{
+		identity: corev1.ObjectReference{APIVersion: "cluster.x-k8s.io/v1beta1", Kind: "Cluster", Namespace: "ns1", Name: "foo"},
+		newUID:   types.UID("new-uid"),
+	}
+
+	// A missing checkpoint file gives an empty checkpoint.
+	c, err := loadMoveCheckpoint(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(c.isCreated(n)).To(BeFalse())
+	g.Expect(c.isDeleted(n)).To(BeFalse())
+
+	c.setCreated(n)
+	c.setDeleted(n)
+	g.Expect(c.save()).To(Succeed())
+
+	// The progress is read back from the checkpoint file, restoring the new UID of the created objects,
+	// also if the objects are read at a different version.
+	c, err = loadMoveCheckpoint(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	other := &node{
+		identity: corev1.ObjectReference{APIVersion: "cluster.x-k8s.io/v1alpha4", Kind: "Cluster", Namespace: "ns1", Name: "foo"},
+	}
+	g.Expect(c.isCreated(other)).To(BeTrue())
+	g.Expect(other.newUID).To(Equal(types.UID("new-uid")))
+	g.Expect(c.isDeleted(other)).To(BeTrue())
+
+	// Clusters recorded in the checkpoint are unpaused also if they are no longer in the object graph,
+	// until they are recorded as unpaused.
+	c.addClusters([]*node{n})
+	g.Expect(c.save()).To(Succeed())
+	c, err = loadMoveCheckpoint(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	toUnpause := c.clustersToUnpause(nil)
+	g.Expect(toUnpause).To(HaveLen(1))
+	g.Expect(toUnpause[0].identity.Namespace).To(Equal("ns1"))
+	g.Expect(toUnpause[0].identity.Name).To(Equal("foo"))
+	g.Expect(c.clustersToUnpause([]*node{other})).To(ConsistOf(other))
+	c.setUnpaused(toUnpause)
+	g.Expect(c.clustersToUnpause(nil)).To(BeEmpty())
+
+	g.Expect(c.remove()).To(Succeed())
+	_, err = os.Stat(path)
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+	g.Expect(c.remove()).To(Succeed())
+
+	// All the methods are no-op on a nil checkpoint.
+	var nilCheckpoint *moveCheckpoint
+	g.Expect(nilCheckpoint.isCreated(n)).To(BeFalse())
+	g.Expect(nilCheckpoint.isDeleted(n)).To(BeFalse())
+	nilCheckpoint.setCreated(n)
+	nilCheckpoint.setDeleted(n)
+	nilCheckpoint.addClusters([]*node{n})
+	g.Expect(nilCheckpoint.clustersToUnpause([]*node{n})).To(ConsistOf(n))
+	nilCheckpoint.setUnpaused([]*node{n})
+	g.Expect(nilCheckpoint.save()).To(Succeed())
+	g.Expect(nilCheckpoint.remove()).To(Succeed())
+}
+
+func Test_objectMover_move_checkpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+	g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+	g.Expect(graph.Discovery("")).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	// Record the kubeconfig secret as already moved by a previous, interrupted move.
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	checkpoint, err := loadMoveCheckpoint(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	var kubeconfig *node
+	for _, n := range graph.uidToNode {
+		if n.identity.Kind == "Secret" && n.identity.Name == "foo-kubeconfig" {
+			kubeconfig = n
+		}
+	}
+	g.Expect(kubeconfig).NotTo(BeNil())
+	checkpoint.setCreated(kubeconfig)
+	checkpoint.setDeleted(kubeconfig)
+
+	mover := objectMover{
+		fromProxy:  graph.proxy,
+		checkpoint: checkpoint,
+	}
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+	csFrom, err := graph.proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	for _, n := range graph.uidToNode {
+		key := client.ObjectKey{Namespace: n.identity.Namespace, Name: n.identity.Name}
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(n.identity.APIVersion)
+		obj.SetKind(n.identity.Kind)
+
+		errFrom := csFrom.Get(ctx, key, obj.DeepCopy())
+		errTo := csTo.Get(ctx, key, obj.DeepCopy())
+		if n == kubeconfig {
+			// Objects already in the checkpoint are not processed again.
+			g.Expect(errFrom).NotTo(HaveOccurred())
+			g.Expect(apierrors.IsNotFound(errTo)).To(BeTrue())
+			continue
+		}
+		g.Expect(apierrors.IsNotFound(errFrom)).To(BeTrue(), "%v not deleted in source cluster", key)
+		g.Expect(errTo).NotTo(HaveOccurred(), "%v not created in target cluster", key)
+
+		// The progress is recorded in the checkpoint file.
+		g.Expect(checkpoint.isCreated(n)).To(BeTrue())
+		g.Expect(checkpoint.isDeleted(n)).To(BeTrue())
+	}
+
+	saved, err := loadMoveCheckpoint(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(saved.Created).To(HaveLen(len(graph.uidToNode)))
+	g.Expect(saved.Deleted).To(HaveLen(len(graph.uidToNode)))
+}
+
+func Test_objectMover_move_checkpointUnpausesMovedClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+	g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+	g.Expect(graph.Discovery("")).To(Succeed())
+
+	// gets a fakeProxy to a cluster with all the required CRDs, and a paused Cluster already moved and deleted from the
+	// source cluster by a previous, interrupted move.
+	toProxy := getFakeProxyWithCRDs()
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+	moved := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "bar"},
+		Spec:       clusterv1.ClusterSpec{Paused: true},
+	}
+	g.Expect(csTo.Create(ctx, moved)).To(Succeed())
+
+	path := filepath.Join(t.TempDir(), "checkpoint")
+	checkpoint, err := loadMoveCheckpoint(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	checkpoint.Clusters["ns1/bar"] = false
+
+	mover := objectMover{
+		fromProxy:  graph.proxy,
+		checkpoint: checkpoint,
+	}
+	g.Expect(mover.move(graph, toProxy)).To(Succeed())
+
+	// Both the Cluster in the object graph and the one moved by the interrupted move are unpaused.
+	for _, name := range []string{"foo", "bar"} {
+		cluster := &clusterv1.Cluster{}
+		g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: name}, cluster)).To(Succeed())
+		g.Expect(cluster.Spec.Paused).To(BeFalse(), "Cluster %s is still paused", name)
+	}
+	saved, err := loadMoveCheckpoint(path)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(saved.Clusters).To(Equal(map[string]bool{"ns1/foo": true, "ns1/bar": true}))
+}
@@ -1118,6 +1118,182 @@ func Test_objectMover_move(t *testing.T) {
 	}
 }
 
+func Test_objectMover_sync(t *testing.T) {
+	// NB. we are testing sync using the same set of moveTests used for move.
+	for _, tt := range moveTests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph := getObjectGraphWithObjs(tt.fields.objs)
+
+			// Get all the types to be considered for discovery
+			g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+
+			// trigger discovery the content of the source cluster
+			g.Expect(graph.Discovery("")).To(Succeed())
+
+			// gets a fakeProxy to an empty cluster with all the required CRDs
+			toProxy := getFakeProxyWithCRDs()
+
+			// Run sync twice, so the second run updates the objects already existing in the standby cluster.
+			mover := objectMover{
+				fromProxy: graph.proxy,
+				standby:   true,
+			}
+
+			err := mover.sync(graph, toProxy, "")
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(mover.sync(graph, toProxy, "")).To(Succeed())
+
+			// check that the objects are preserved in the source cluster and are created in the standby cluster
+			csFrom, err := graph.proxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			csTo, err := toProxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.uidToNode {
+				key := client.ObjectKey{
+					Namespace: node.identity.Namespace,
+					Name:      node.identity.Name,
+				}
+
+				// objects are not deleted from the source cluster
+				oFrom := &unstructured.Unstructured{}
+				oFrom.SetAPIVersion(node.identity.APIVersion)
+				oFrom.SetKind(node.identity.Kind)
+				g.Expect(csFrom.Get(ctx, key, oFrom)).To(Succeed())
+
+				// objects are created in the standby cluster
+				oTo := &unstructured.Unstructured{}
+				oTo.SetAPIVersion(node.identity.APIVersion)
+				oTo.SetKind(node.identity.Kind)
+				g.Expect(csTo.Get(ctx, key, oTo)).To(Succeed())
+
+				// Clusters are paused and marked as standby in the standby cluster, but not in the source cluster
+				if node.identity.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("Cluster").GroupKind() {
+					paused, _, _ := unstructured.NestedBool(oFrom.Object, "spec", "paused")
+					g.Expect(paused).To(BeFalse())
+					g.Expect(oFrom.GetAnnotations()).ToNot(HaveKey(clusterctlv1.StandbyAnnotation))
+
+					paused, _, _ = unstructured.NestedBool(oTo.Object, "spec", "paused")
+					g.Expect(paused).To(BeTrue())
+					g.Expect(oTo.GetAnnotations()).To(HaveKey(clusterctlv1.StandbyAnnotation))
+				}
+			}
+		})
+	}
+}
+
+func Test_objectMover_syncDeletesStaleObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "foo",
+		},
+		Spec: clusterv1.ClusterSpec{Paused: true},
+	}
+	stale := cluster.DeepCopy()
+	stale.Name = "stale"
+	stale.Annotations = map[string]string{clusterctlv1.StandbyAnnotation: "false"}
+	stale.Finalizers = []string{clusterv1.ClusterFinalizer}
+	notSynced := cluster.DeepCopy()
+	notSynced.Name = "not-synced"
+
+	graph := getObjectGraphWithObjs([]client.Object{cluster})
+	g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+	g.Expect(graph.Discovery("")).To(Succeed())
+
+	toProxy := getFakeProxyWithCRDs().WithObjs(stale, notSynced)
+
+	mover := objectMover{
+		fromProxy: graph.proxy,
+		standby:   true,
+	}
+	g.Expect(mover.sync(graph, toProxy, "ns1")).To(Succeed())
+
+	csTo, err := toProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The Cluster in the source cluster is synced, and its pause field is recorded in the standby annotation.
+	got := &clusterv1.Cluster{}
+	g.Expect(csTo.Get(ctx, client.ObjectKeyFromObject(cluster), got)).To(Succeed())
+	g.Expect(got.Annotations).To(HaveKeyWithValue(clusterctlv1.StandbyAnnotation, "true"))
+
+	// Objects synced by a previous sync and deleted from the source cluster are deleted.
+	g.Expect(apierrors.IsNotFound(csTo.Get(ctx, client.ObjectKeyFromObject(stale), got))).To(BeTrue())
+
+	// Objects not created by sync are left untouched.
+	g.Expect(csTo.Get(ctx, client.ObjectKeyFromObject(notSynced), got)).To(Succeed())
+}
+
+func Test_objectMover_Promote(t *testing.T) {
+	g := NewWithT(t)
+
+	standby := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns1",
+			Name:        "standby",
+			Annotations: map[string]string{clusterctlv1.StandbyAnnotation: ""},
+		},
+		Spec: clusterv1.ClusterSpec{Paused: true},
+	}
+	paused := standby.DeepCopy()
+	paused.Name = "paused"
+	paused.Annotations = nil
+	pausedInPrimary := standby.DeepCopy()
+	pausedInPrimary.Name = "paused-in-primary"
+	pausedInPrimary.Annotations = map[string]string{clusterctlv1.StandbyAnnotation: "true"}
+
+	proxy := test.NewFakeProxy().WithObjs(standby, paused, pausedInPrimary)
+	primaryProxy := test.NewFakeProxy().WithObjs(&clusterv1.Cluster{
+		TypeMeta:   standby.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "standby"},
+	})
+
+	mover := newObjectMover(proxy, nil)
+	g.Expect(mover.Promote("ns1", New(Kubeconfig{}, nil, InjectProxy(primaryProxy)))).To(Succeed())
+
+	c, err := proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Standby Clusters are unpaused and the standby annotation is removed.
+	got := &clusterv1.Cluster{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(standby), got)).To(Succeed())
+	g.Expect(got.Spec.Paused).To(BeFalse())
+	g.Expect(got.Annotations).ToNot(HaveKey(clusterctlv1.StandbyAnnotation))
+
+	// Clusters paused by the users are left untouched.
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(paused), got)).To(Succeed())
+	g.Expect(got.Spec.Paused).To(BeTrue())
+
+	// Standby Clusters paused in the primary management cluster at sync time are kept paused.
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pausedInPrimary), got)).To(Succeed())
+	g.Expect(got.Spec.Paused).To(BeTrue())
+	g.Expect(got.Annotations).ToNot(HaveKey(clusterctlv1.StandbyAnnotation))
+
+	// Clusters in the former primary management cluster are paused.
+	primaryClient, err := primaryProxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(primaryClient.Get(ctx, client.ObjectKeyFromObject(standby), got)).To(Succeed())
+	g.Expect(got.Spec.Paused).To(BeTrue())
+}
+
 func Test_objectMover_checkProvisioningCompleted(t *testing.T) {
 	type fields struct {
 		objs []client.Object
@@ -1970,3 +2146,111 @@ func Test_deleteSourceObject(t *testing.T) {
 		})
 	}
 }
+
+func Test_objectMover_repairOwnerReferences(t *testing.T) {
+	// withOwnerReferences returns the objects of a Cluster with a Machine, setting the OwnerReferences of the object of the given kind.
+	withOwnerReferences := func(kind string, ownerRefs []metav1.OwnerReference) []client.Object {
+		objs := test.NewFakeCluster("ns1", "cluster1").WithMachines(test.NewFakeMachine("m1")).Objs()
+		for _, obj := range objs {
+			if obj.GetObjectKind().GroupVersionKind().Kind == kind {
+				obj.SetOwnerReferences(ownerRefs)
+			}
+		}
+		return objs
+	}
+	withoutKind := func(kind string) []client.Object {
+		objs := []client.Object{}
+		for _, obj := range test.NewFakeCluster("ns1", "cluster1").WithMachines(test.NewFakeMachine("m1")).Objs() {
+			if obj.GetObjectKind().GroupVersionKind().Kind != kind {
+				objs = append(objs, obj)
+			}
+		}
+		return objs
+	}
+
+	tests := []struct {
+		name         string
+		objs         []client.Object
+		reportOnly   bool
+		wantRepaired bool
+		wantErr      string
+	}{
+		{
+			name:         "does nothing if OwnerReferences are in place",
+			objs:         test.NewFakeCluster("ns1", "cluster1").WithMachines(test.NewFakeMachine("m1")).Objs(),
+			wantRepaired: false,
+		},
+		{
+			name:         "repairs a missing OwnerReference",
+			objs:         withOwnerReferences("GenericBootstrapConfig", nil),
+			wantRepaired: true,
+		},
+		{
+			name: "repairs an OwnerReference to a Machine which does not exist anymore",
+			objs: withOwnerReferences("GenericInfrastructureMachine", []metav1.OwnerReference{
+				{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: "m1", UID: "stale", Controller: pointer.BoolPtr(true)},
+			}),
+			wantRepaired: true,
+		},
+		{
+			name:         "reports without repairing in report only mode",
+			objs:         withOwnerReferences("GenericBootstrapConfig", nil),
+			reportOnly:   true,
+			wantRepaired: false,
+		},
+		{
+			name: "fails if the object is controlled by another object",
+			objs: withOwnerReferences("GenericBootstrapConfig", []metav1.OwnerReference{
+				{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster", Name: "cluster1", UID: "ns1/cluster1", Controller: pointer.BoolPtr(true)},
+			}),
+			wantErr: "GenericBootstrapConfig ns1/m1 referenced by Machine ns1/m1 is controlled by Cluster cluster1",
+		},
+		{
+			name:    "fails if the object does not exist",
+			objs:    withoutKind("GenericInfrastructureMachine"),
+			wantErr: "GenericInfrastructureMachine ns1/m1 referenced by Machine ns1/m1 is not found among the objects to be moved",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph := getObjectGraphWithObjs(tt.objs)
+
+			// Get all the types to be considered for discovery
+			g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+
+			// trigger discovery the content of the source cluster
+			g.Expect(graph.Discovery("")).To(Succeed())
+
+			o := &objectMover{
+				fromProxy: graph.proxy,
+			}
+			repaired, err := o.repairOwnerReferences(graph, !tt.reportOnly)
+			if tt.wantErr != "" {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.wantErr)))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(repaired).To(Equal(tt.wantRepaired))
+			if !repaired {
+				return
+			}
+
+			// After discovering the graph again, all the objects of the Machine are moved with the Cluster.
+			graph = newObjectGraph(graph.proxy, graph.providerInventory)
+			g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+			g.Expect(graph.Discovery("")).To(Succeed())
+			for _, n := range graph.getNodes() {
+				if n.virtual || n.identity.Kind == "Secret" {
+					continue
+				}
+				g.Expect(n.tenant).ToNot(BeEmpty(), "%s %s is not moved", n.identity.Kind, n.identity.Name)
+				for owner := range n.owners {
+					g.Expect(owner.virtual).To(BeFalse(), "%s %s has a broken OwnerReference", n.identity.Kind, n.identity.Name)
+				}
+			}
+		})
+	}
+}
@@ -415,6 +415,9 @@ func (o *objectGraph) Discovery(namespace string) error {
 	return nil
 }
 
+// listPageSize is the number of objects read from the API server for each page when listing objects.
+const listPageSize = 500
+
 func getObjList(proxy Proxy, typeMeta metav1.TypeMeta, selectors []client.ListOption, objList *unstructured.UnstructuredList) error {
 	c, err := proxy.NewClient()
 	if err != nil {
@@ -424,12 +427,29 @@ func getObjList(proxy Proxy, typeMeta metav1.TypeMeta, selectors []client.ListOp
 	objList.SetAPIVersion(typeMeta.APIVersion)
 	objList.SetKind(typeMeta.Kind)
 
-	if err := c.List(ctx, objList, selectors...); err != nil {
-		if apierrors.IsNotFound(err) {
-			return nil
+	// List the objects in pages, so listing thousands of objects does not require a single, huge response from the API server.
+	items := []unstructured.Unstructured{}
+	continueToken := ""
+	for {
+		page := &unstructured.UnstructuredList{}
+		page.SetAPIVersion(typeMeta.APIVersion)
+		page.SetKind(typeMeta.Kind)
+
+		pageSelectors := append([]client.ListOption{client.Limit(listPageSize), client.Continue(continueToken)}, selectors...)
+		if err := c.List(ctx, page, pageSelectors...); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to list %q resources", objList.GroupVersionKind())
+		}
+		items = append(items, page.Items...)
+
+		continueToken = page.GetContinue()
+		if continueToken == "" {
+			break
 		}
-		return errors.Wrapf(err, "failed to list %q resources", objList.GroupVersionKind())
 	}
+	objList.Items = items
 	return nil
 }
 
@@ -498,6 +518,24 @@ func (o *objectGraph) getMachines() []*node {
 	return machines
 }
 
+// getNodeByReference returns the non virtual node existing in the object graph for an object reference, if any;
+// the version in the APIVersion of the reference is ignored.
+func (o *objectGraph) getNodeByReference(ref *corev1.ObjectReference, namespace string) *node {
+	if ref.Namespace != "" {
+		namespace = ref.Namespace
+	}
+	groupKind := ref.GroupVersionKind().GroupKind()
+	for _, node := range o.uidToNode {
+		if node.virtual {
+			continue
+		}
+		if node.identity.GroupVersionKind().GroupKind() == groupKind && node.identity.Namespace == namespace && node.identity.Name == ref.Name {
+			return node
+		}
+	}
+	return nil
+}
+
 // setSoftOwnership searches for soft ownership relations such as secrets linked to the cluster by a naming convention (without any explicit OwnerReference).
 func (o *objectGraph) setSoftOwnership() {
 	log := logf.Log
@@ -0,0 +1,351 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gobuffalo/flect"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster/internal/dryrun"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/scheme"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/cluster-api/controllers/topology"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TopologyPlanInput defines the input for the Plan function.
+type TopologyPlanInput struct {
+	// Objs are the modified Cluster, ClusterClass and template objects to plan the changes for.
+	Objs []*unstructured.Unstructured
+
+	// TargetNamespace is the namespace used for the input objects without a namespace.
+	// If not specified, the current namespace is used, or the default namespace when running offline.
+	TargetNamespace string
+
+	// Offline, if true, plans the changes using only the input objects, without reading
+	// the state of the management cluster.
+	Offline bool
+}
+
+// ModifiedObject holds the state of an object before and after the changes planned by the topology controller.
+type ModifiedObject = dryrun.ModifiedObject
+
+// TopologyChanges are the changes the topology controller would apply for a Cluster.
+type TopologyChanges = dryrun.Changes
+
+// TopologyPlanOutput defines the output of the Plan function.
+type TopologyPlanOutput struct {
+	// Clusters is the list of Clusters affected by the input objects.
+	Clusters []client.ObjectKey
+
+	// Changes are the changes the topology controller would apply for each affected Cluster.
+	Changes map[client.ObjectKey]*TopologyChanges
+}
+
+// TopologyClient has methods to work with ClusterClass and managed topologies.
+type TopologyClient interface {
+	// Plan runs the topology controller in dry run mode for the Clusters affected by the input objects,
+	// i.e. the Clusters in the input and, unless offline, the Clusters in the management cluster using
+	// one of the input ClusterClasses or templates, and returns the objects the topology controller
+	// would create, update or delete for each of them.
+	Plan(in *TopologyPlanInput) (*TopologyPlanOutput, error)
+}
+
+// topologyClient implements TopologyClient.
+type topologyClient struct {
+	proxy Proxy
+}
+
+// ensure topologyClient implements TopologyClient.
+var _ TopologyClient = &topologyClient{}
+
+// newTopologyClient returns a topologyClient.
+func newTopologyClient(proxy Proxy) *topologyClient {
+	return &topologyClient{
+		proxy: proxy,
+	}
+}
+
+func (t *topologyClient) Plan(in *TopologyPlanInput) (*TopologyPlanOutput, error) {
+	log := logf.Log
+
+	var live client.Client
+	if !in.Offline {
+		c, err := t.proxy.NewClient()
+		if err != nil {
+			return nil, err
+		}
+		live = c
+	}
+
+	namespace := in.TargetNamespace
+	if namespace == "" {
+		if in.Offline {
+			namespace = metav1.NamespaceDefault
+		} else {
+			currentNamespace, err := t.proxy.CurrentNamespace()
+			if err != nil {
+				return nil, err
+			}
+			namespace = currentNamespace
+		}
+	}
+
+	objs := make([]client.Object, 0, len(in.Objs))
+	for _, o := range in.Objs {
+		obj := o.DeepCopy()
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		obj.SetResourceVersion("")
+		objs = append(objs, obj)
+	}
+
+	// When running offline, the CRDs of the provider objects are not available, so CRDs with the contract label
+	// are generated for each of the Kinds in the input objects and for the corresponding non-template Kinds.
+	if in.Offline {
+		objs = append(objs, generateCRDs(objs)...)
+	}
+
+	clusters, err := affectedClusters(live, objs)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &TopologyPlanOutput{
+		Clusters: clusters,
+		Changes:  map[client.ObjectKey]*TopologyChanges{},
+	}
+	for _, cluster := range clusters {
+		log.V(1).Info("Planning topology changes", "Cluster", cluster.Name, "Namespace", cluster.Namespace)
+
+		// NOTE: Each Cluster is reconciled using a fresh in-memory copy of the objects, so the reported changes
+		// are the changes the topology controller would apply for this Cluster only.
+		dryRunClient := dryrun.NewClient(scheme.Scheme, live, copyObjects(objs))
+		r := &topology.ClusterReconciler{
+			Client:                    dryRunClient,
+			APIReader:                 dryRunClient,
+			UnstructuredCachingClient: dryRunClient,
+		}
+		r.SetupForDryRun(&record.FakeRecorder{})
+
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: cluster}); err != nil {
+			return nil, errors.Wrapf(err, "failed to plan topology changes for Cluster %s/%s", cluster.Namespace, cluster.Name)
+		}
+
+		changes, err := dryRunClient.Changes(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get topology changes for Cluster %s/%s", cluster.Namespace, cluster.Name)
+		}
+		out.Changes[cluster] = changes
+	}
+
+	return out, nil
+}
+
+// affectedClusters returns the Clusters with a managed topology affected by the input objects, i.e.
+// - the Clusters in the input objects.
+// - the Clusters in the management cluster using one of the ClusterClasses in the input objects.
+// - the Clusters in the management cluster using a ClusterClass that references one of the templates
+//   in the input objects.
+func affectedClusters(live client.Reader, objs []client.Object) ([]client.ObjectKey, error) {
+	affected := map[client.ObjectKey]bool{}
+	inputClusterClasses := sets.NewString()
+	inputTemplates := sets.NewString()
+
+	for _, obj := range objs {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		switch u.GroupVersionKind().GroupKind() {
+		case clusterv1.GroupVersion.WithKind("Cluster").GroupKind():
+			cluster := &clusterv1.Cluster{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, cluster); err != nil {
+				return nil, errors.Wrapf(err, "failed to convert Cluster %s/%s", u.GetNamespace(), u.GetName())
+			}
+			if cluster.Spec.Topology != nil {
+				affected[client.ObjectKeyFromObject(cluster)] = true
+			}
+		case clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind():
+			inputClusterClasses.Insert(namespacedName(u.GetNamespace(), u.GetName()))
+		default:
+			inputTemplates.Insert(namespacedKindName(u.GetKind(), u.GetNamespace(), u.GetName()))
+		}
+	}
+
+	if live != nil && (inputClusterClasses.Len() > 0 || inputTemplates.Len() > 0) {
+		// Add the ClusterClasses in the management cluster referencing one of the input templates.
+		if inputTemplates.Len() > 0 {
+			clusterClasses := &clusterv1.ClusterClassList{}
+			if err := live.List(ctx, clusterClasses); err != nil {
+				return nil, errors.Wrap(err, "failed to list ClusterClasses")
+			}
+			for i := range clusterClasses.Items {
+				clusterClass := &clusterClasses.Items[i]
+				for _, ref := range clusterClassTemplateRefs(clusterClass) {
+					refNamespace := ref.Namespace
+					if refNamespace == "" {
+						refNamespace = clusterClass.Namespace
+					}
+					if inputTemplates.Has(namespacedKindName(ref.Kind, refNamespace, ref.Name)) {
+						inputClusterClasses.Insert(namespacedName(clusterClass.Namespace, clusterClass.Name))
+						break
+					}
+				}
+			}
+		}
+
+		// Add the Clusters in the management cluster using one of the affected ClusterClasses.
+		clusters := &clusterv1.ClusterList{}
+		if err := live.List(ctx, clusters); err != nil {
+			return nil, errors.Wrap(err, "failed to list Clusters")
+		}
+		for i := range clusters.Items {
+			cluster := &clusters.Items[i]
+			if cluster.Spec.Topology == nil {
+				continue
+			}
+			if inputClusterClasses.Has(namespacedName(cluster.Namespace, cluster.Spec.Topology.Class)) {
+				affected[client.ObjectKeyFromObject(cluster)] = true
+			}
+		}
+	}
+
+	clusters := make([]client.ObjectKey, 0, len(affected))
+	for key := range affected {
+		clusters = append(clusters, key)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].String() < clusters[j].String()
+	})
+	return clusters, nil
+}
+
+// clusterClassTemplateRefs returns the references to the templates used by a ClusterClass.
+func clusterClassTemplateRefs(clusterClass *clusterv1.ClusterClass) []*corev1.ObjectReference {
+	refs := []*corev1.ObjectReference{
+		clusterClass.Spec.Infrastructure.Ref,
+		clusterClass.Spec.ControlPlane.Ref,
+	}
+	if clusterClass.Spec.ControlPlane.MachineInfrastructure != nil {
+		refs = append(refs, clusterClass.Spec.ControlPlane.MachineInfrastructure.Ref)
+	}
+	for _, md := range clusterClass.Spec.Workers.MachineDeployments {
+		refs = append(refs, md.Template.Bootstrap.Ref, md.Template.Infrastructure.Ref)
+	}
+
+	nonNilRefs := make([]*corev1.ObjectReference, 0, len(refs))
+	for _, ref := range refs {
+		if ref != nil {
+			nonNilRefs = append(nonNilRefs, ref)
+		}
+	}
+	return nonNilRefs
+}
+
+// generateCRDs returns a CRD for each Kind in the input objects which is not a Cluster API core type and
+// is not defined by a CRD in the input objects; for template Kinds, a CRD for the corresponding
+// non-template Kind, e.g. DockerCluster for DockerClusterTemplate, is generated as well.
+func generateCRDs(objs []client.Object) []client.Object {
+	existing := sets.NewString()
+	for _, obj := range objs {
+		if obj.GetObjectKind().GroupVersionKind().GroupKind() == apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition").GroupKind() {
+			existing.Insert(obj.GetName())
+		}
+	}
+
+	crds := []client.Object{}
+	for _, obj := range objs {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		if scheme.Scheme.Recognizes(gvk) {
+			continue
+		}
+		gvks := []schema.GroupVersionKind{gvk}
+		if strings.HasSuffix(gvk.Kind, "Template") {
+			gvks = append(gvks, gvk.GroupVersion().WithKind(strings.TrimSuffix(gvk.Kind, "Template")))
+		}
+		for _, gvk := range gvks {
+			plural := flect.Pluralize(strings.ToLower(gvk.Kind))
+			name := fmt.Sprintf("%s.%s", plural, gvk.Group)
+			if existing.Has(name) {
+				continue
+			}
+			existing.Insert(name)
+
+			crd := &apiextensionsv1.CustomResourceDefinition{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+					Kind:       "CustomResourceDefinition",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: name,
+					Labels: map[string]string{
+						utilconversion.ContractLabelName(clusterv1.GroupVersion.Version): gvk.Version,
+					},
+				},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Group: gvk.Group,
+					Scope: apiextensionsv1.NamespaceScoped,
+					Names: apiextensionsv1.CustomResourceDefinitionNames{
+						Kind:   gvk.Kind,
+						Plural: plural,
+					},
+					Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+						{
+							Name:    gvk.Version,
+							Served:  true,
+							Storage: true,
+						},
+					},
+				},
+			}
+			crds = append(crds, crd)
+		}
+	}
+	return crds
+}
+
+func namespacedName(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+func namespacedKindName(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+func copyObjects(objs []client.Object) []client.Object {
+	copies := make([]client.Object, 0, len(objs))
+	for _, o := range objs {
+		copies = append(copies, o.DeepCopyObject().(client.Object))
+	}
+	return copies
+}
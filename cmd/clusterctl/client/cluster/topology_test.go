@@ -0,0 +1,175 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_topologyClient_Plan(t *testing.T) {
+	infrastructureMachineTemplate := builder.InfrastructureMachineTemplate("default", "inframachinetemplate").Build()
+	infrastructureClusterTemplate := builder.InfrastructureClusterTemplate("default", "infraclustertemplate").Build()
+	controlPlaneTemplate := builder.ControlPlaneTemplate("default", "cp1").
+		WithInfrastructureMachineTemplate(infrastructureMachineTemplate).
+		Build()
+	bootstrapTemplate := builder.BootstrapTemplate("default", "bootstraptemplate").Build()
+	modifiedBootstrapTemplate := builder.BootstrapTemplate("default", "bootstraptemplate").
+		WithSpecFields(map[string]interface{}{"spec.template.spec.fakeSetting": true}).
+		Build()
+
+	machineDeploymentClass := builder.MachineDeploymentClass("linux-worker").
+		WithInfrastructureTemplate(infrastructureMachineTemplate).
+		WithBootstrapTemplate(bootstrapTemplate).
+		Build()
+	clusterClass := builder.ClusterClass("default", "class1").
+		WithInfrastructureClusterTemplate(infrastructureClusterTemplate).
+		WithControlPlaneTemplate(controlPlaneTemplate).
+		WithWorkerMachineDeploymentClasses([]clusterv1.MachineDeploymentClass{*machineDeploymentClass}).
+		Build()
+	clusterClass.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("ClusterClass"))
+
+	cluster := builder.Cluster("default", "cluster1").
+		WithTopology(
+			builder.ClusterTopology().
+				WithClass(clusterClass.Name).
+				WithMachineDeployment(builder.MachineDeploymentTopology("md1").WithClass("linux-worker").WithReplicas(1).Build()).
+				WithVersion("v1.22.2").
+				WithControlPlaneReplicas(1).
+				Build()).
+		Build()
+	clusterWithoutTopology := builder.Cluster("default", "cluster2").Build()
+
+	crds := []client.Object{
+		builder.GenericInfrastructureMachineTemplateCRD,
+		builder.GenericInfrastructureClusterTemplateCRD,
+		builder.GenericInfrastructureClusterCRD,
+		builder.GenericControlPlaneTemplateCRD,
+		builder.GenericControlPlaneCRD,
+		builder.GenericBootstrapConfigTemplateCRD,
+	}
+
+	wantClusterChanges := []string{
+		"created GenericBootstrapConfigTemplate",
+		"created GenericControlPlane",
+		"created GenericInfrastructureCluster",
+		"created GenericInfrastructureMachineTemplate",
+		"created MachineDeployment",
+		"modified Cluster",
+	}
+
+	tests := []struct {
+		name         string
+		existingObjs []client.Object
+		in           *TopologyPlanInput
+		wantClusters []client.ObjectKey
+		wantChanges  []string
+	}{
+		{
+			name: "Plan the changes for a new Cluster offline",
+			in: &TopologyPlanInput{
+				Objs: toUnstructured(t,
+					infrastructureMachineTemplate, infrastructureClusterTemplate, controlPlaneTemplate, bootstrapTemplate,
+					clusterClass, cluster, clusterWithoutTopology,
+				),
+				Offline: true,
+			},
+			wantClusters: []client.ObjectKey{{Namespace: "default", Name: "cluster1"}},
+			wantChanges:  wantClusterChanges,
+		},
+		{
+			name: "Plan the changes for the Clusters in the management cluster using a modified template",
+			existingObjs: append(crds,
+				infrastructureMachineTemplate, infrastructureClusterTemplate, controlPlaneTemplate, bootstrapTemplate,
+				clusterClass, cluster, clusterWithoutTopology,
+			),
+			in: &TopologyPlanInput{
+				Objs: toUnstructured(t, modifiedBootstrapTemplate),
+			},
+			wantClusters: []client.ObjectKey{{Namespace: "default", Name: "cluster1"}},
+			wantChanges:  wantClusterChanges,
+		},
+		{
+			name: "No Clusters affected in the management cluster",
+			existingObjs: append(crds,
+				infrastructureMachineTemplate, infrastructureClusterTemplate, controlPlaneTemplate, bootstrapTemplate,
+				clusterClass, clusterWithoutTopology,
+			),
+			in: &TopologyPlanInput{
+				Objs: toUnstructured(t, modifiedBootstrapTemplate),
+			},
+			wantClusters: []client.ObjectKey{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			proxy := test.NewFakeProxy().WithObjs(tt.existingObjs...)
+			c := newTopologyClient(proxy)
+
+			got, err := c.Plan(tt.in)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got.Clusters).To(Equal(tt.wantClusters))
+
+			for _, key := range tt.wantClusters {
+				g.Expect(got.Changes).To(HaveKey(key))
+				g.Expect(changesSummary(got.Changes[key])).To(ConsistOf(tt.wantChanges))
+			}
+		})
+	}
+}
+
+func toUnstructured(t *testing.T, objs ...client.Object) []*unstructured.Unstructured {
+	t.Helper()
+
+	ret := []*unstructured.Unstructured{}
+	for _, obj := range objs {
+		if u, ok := obj.(*unstructured.Unstructured); ok {
+			ret = append(ret, u)
+			continue
+		}
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ret = append(ret, &unstructured.Unstructured{Object: content})
+	}
+	return ret
+}
+
+func changesSummary(changes *TopologyChanges) []string {
+	summary := []string{}
+	for _, obj := range changes.Created {
+		summary = append(summary, fmt.Sprintf("created %s", obj.GetKind()))
+	}
+	for _, obj := range changes.Modified {
+		summary = append(summary, fmt.Sprintf("modified %s", obj.After.GetKind()))
+	}
+	for _, obj := range changes.Deleted {
+		summary = append(summary, fmt.Sprintf("deleted %s", obj.GetKind()))
+	}
+	return summary
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -42,6 +43,10 @@ type ProviderUpgrader interface {
 	//   - Upgrade to the latest version in the the v1alpha4 series: ....
 	Plan() ([]UpgradePlan, error)
 
+	// CurrentContracts returns the API Version of Cluster API (contract) supported by the current version of each provider
+	// in the management cluster, indexed by provider instance name.
+	CurrentContracts() (map[string]string, error)
+
 	// ApplyPlan executes an upgrade following an UpgradePlan generated by clusterctl.
 	ApplyPlan(clusterAPIVersion string) error
 
@@ -148,6 +153,23 @@ func (u *providerUpgrader) Plan() ([]UpgradePlan, error) {
 	return ret, nil
 }
 
+func (u *providerUpgrader) CurrentContracts() (map[string]string, error) {
+	providerList, err := u.providerInventory.List()
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := map[string]string{}
+	for _, provider := range providerList.Items {
+		upgradeInfo, err := u.getUpgradeInfo(provider)
+		if err != nil {
+			return nil, err
+		}
+		contracts[provider.InstanceName()] = upgradeInfo.currentContract
+	}
+	return contracts, nil
+}
+
 func (u *providerUpgrader) ApplyPlan(contract string) error {
 	if contract != clusterv1.GroupVersion.Version {
 		return errors.Errorf("current version of clusterctl could only upgrade to %s contract, requested %s", clusterv1.GroupVersion.Version, contract)
@@ -353,12 +375,79 @@ func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan) error {
 		}
 	}
 
+	// Check the management cluster can be safely upgraded.
+	if err := u.preflightChecks(upgradePlan); err != nil {
+		return err
+	}
+
 	// Ensure Providers are updated in the following order: Core, Bootstrap, ControlPlane, Infrastructure.
 	providers := upgradePlan.Providers
 	sort.Slice(providers, func(a, b int) bool {
 		return providers[a].GetProviderType().Order() < providers[b].GetProviderType().Order()
 	})
 
+	// Gets the provider components for the target versions.
+	// NOTE: This is done before changing the management cluster, so e.g. an unreachable provider repository
+	// does not leave providers scaled down.
+	upgradeComponents := map[string]repository.Components{}
+	for _, upgradeItem := range providers {
+		// If there is not a specified next version, skip it (we are already up-to-date).
+		if upgradeItem.NextVersion == "" {
+			continue
+		}
+
+		components, err := u.getUpgradeComponents(upgradeItem)
+		if err != nil {
+			return err
+		}
+		upgradeComponents[upgradeItem.InstanceName()] = components
+	}
+
+	if err := u.upgradeProviders(providers, upgradeComponents); err != nil {
+		return err
+	}
+
+	// Delete webhook namespace since it's not needed from v1alpha4.
+	if upgradePlan.Contract == clusterv1.GroupVersion.Version {
+		if err := u.providerComponents.DeleteWebhookNamespace(); err != nil {
+			return err
+		}
+	}
+
+	// Migrate the objects of the provider CRDs to the storage version, so stale stored versions
+	// do not block dropping API versions from the CRDs in later upgrades.
+	// Nb. The migration is retried to give time to the new provider's conversion webhooks to start.
+	return u.migrateCRDs()
+}
+
+// upgradeProviders replaces the components of the providers with a next version with the given components,
+// while rejecting the creation and update of the provider objects.
+func (u *providerUpgrader) upgradeProviders(providers []UpgradeItem, upgradeComponents map[string]repository.Components) (reterr error) {
+	// Reject the creation and update of the provider objects until the new provider versions are installed;
+	// this prevents objects from being persisted without defaulting and validation while the webhook
+	// configurations of the providers are being replaced.
+	guard := newUpgradeAdmissionGuard(u.proxy)
+	guardedComponents := make([]repository.Components, 0, len(upgradeComponents))
+	for _, components := range upgradeComponents {
+		guardedComponents = append(guardedComponents, components)
+	}
+	guardedGroups, err := upgradeAdmissionGuardGroups(guardedComponents...)
+	if err != nil {
+		return err
+	}
+	// Allow again the creation and update of the provider objects on all the exit paths, so a failed upgrade does
+	// not leave the management cluster rejecting them; the removal is deferred before installing the guard, given
+	// that a failed Install could have created it anyway.
+	// NOTE: This must happen before migrating CRDs, given that the migration updates the provider objects.
+	defer func() {
+		if err := guard.Remove(); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+	if err := guard.Install(guardedGroups); err != nil {
+		return err
+	}
+
 	// Scale down all providers.
 	// This is done to ensure all Pods of all "old" provider Deployments have been deleted.
 	// Otherwise it can happen that a provider Pod survives the upgrade because we create
@@ -386,11 +475,7 @@ func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan) error {
 			continue
 		}
 
-		// Gets the provider components for the target version.
-		components, err := u.getUpgradeComponents(upgradeItem)
-		if err != nil {
-			return err
-		}
+		components := upgradeComponents[upgradeItem.InstanceName()]
 
 		// Delete the provider, preserving CRD, namespace and the inventory.
 		if err := u.providerComponents.Delete(DeleteOptions{
@@ -408,13 +493,21 @@ func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan) error {
 		}
 	}
 
-	// Delete webhook namespace since it's not needed from v1alpha4.
-	if upgradePlan.Contract == clusterv1.GroupVersion.Version {
-		if err := u.providerComponents.DeleteWebhookNamespace(); err != nil {
-			return err
-		}
-	}
+	return nil
+}
+
+// migrateCRDs migrates the objects of all the provider CRDs to the storage version.
+func (u *providerUpgrader) migrateCRDs() error {
+	log := logf.Log
+	log.Info("Migrating provider CRDs to the storage version")
 
+	migrator := newCRDMigrator(u.proxy)
+	if err := retryWithExponentialBackoff(newWriteBackoff(), func() error {
+		_, err := migrator.Migrate(false)
+		return err
+	}); err != nil {
+		return errors.Wrap(err, "failed to migrate provider CRDs to the storage version; the migration can be retried using \"clusterctl alpha crd-migrate\"")
+	}
 	return nil
 }
 
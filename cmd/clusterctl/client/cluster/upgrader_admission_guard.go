@@ -0,0 +1,191 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// upgradeAdmissionGuardName is the name of the ValidatingWebhookConfiguration installed while upgrading providers.
+	upgradeAdmissionGuardName = "clusterctl-upgrade-admission-guard"
+
+	// upgradeAdmissionGuardWebhookName is the name of the webhook rejecting requests while upgrading providers;
+	// it is surfaced to users in the admission error message.
+	upgradeAdmissionGuardWebhookName = "upgrade-in-progress.clusterctl.cluster.x-k8s.io"
+
+	// upgradeAdmissionGuardURL is an address that never resolves (see RFC 2606), so every request
+	// sent to the guard webhook fails and, given the Fail policy, is rejected.
+	upgradeAdmissionGuardURL = "https://clusterctl-upgrade-in-progress.invalid"
+)
+
+// upgradeAdmissionGuard rejects the creation and update of provider objects while clusterctl
+// upgrades the providers.
+// When upgrading, clusterctl deletes the webhook configurations of the old provider version before
+// creating the ones of the new version; without the guard, objects created or updated in this window
+// would be persisted without being defaulted or validated.
+type upgradeAdmissionGuard struct {
+	proxy Proxy
+}
+
+// newUpgradeAdmissionGuard returns an upgradeAdmissionGuard.
+func newUpgradeAdmissionGuard(proxy Proxy) *upgradeAdmissionGuard {
+	return &upgradeAdmissionGuard{
+		proxy: proxy,
+	}
+}
+
+// Install creates, or updates, a fail-closed ValidatingWebhookConfiguration rejecting the creation and
+// update of the objects in the given API groups.
+func (g *upgradeAdmissionGuard) Install(groups []string) error {
+	log := logf.Log
+
+	if len(groups) == 0 {
+		return nil
+	}
+
+	c, err := g.proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	log.Info("Installing the upgrade admission guard", "APIGroups", groups)
+
+	desired := upgradeAdmissionGuardWebhookConfiguration(groups)
+	return retryWithExponentialBackoff(newWriteBackoff(), func() error {
+		current := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := c.Get(ctx, client.ObjectKey{Name: upgradeAdmissionGuardName}, current); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to get ValidatingWebhookConfiguration %s", upgradeAdmissionGuardName)
+			}
+			if err := c.Create(ctx, desired.DeepCopy()); err != nil {
+				return errors.Wrapf(err, "failed to create ValidatingWebhookConfiguration %s", upgradeAdmissionGuardName)
+			}
+			return nil
+		}
+
+		current.Labels = desired.Labels
+		current.Webhooks = desired.Webhooks
+		if err := c.Update(ctx, current); err != nil {
+			return errors.Wrapf(err, "failed to update ValidatingWebhookConfiguration %s", upgradeAdmissionGuardName)
+		}
+		return nil
+	})
+}
+
+// Remove deletes the ValidatingWebhookConfiguration installed by Install, if any.
+func (g *upgradeAdmissionGuard) Remove() error {
+	log := logf.Log
+
+	c, err := g.proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	return retryWithExponentialBackoff(newWriteBackoff(), func() error {
+		guard := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := c.Get(ctx, client.ObjectKey{Name: upgradeAdmissionGuardName}, guard); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return errors.Wrapf(err, "failed to get ValidatingWebhookConfiguration %s", upgradeAdmissionGuardName)
+		}
+
+		log.Info("Removing the upgrade admission guard")
+		if err := c.Delete(ctx, guard); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete ValidatingWebhookConfiguration %s", upgradeAdmissionGuardName)
+		}
+		return nil
+	})
+}
+
+// upgradeAdmissionGuardWebhookConfiguration returns the ValidatingWebhookConfiguration rejecting the creation
+// and update of the objects in the given API groups.
+func upgradeAdmissionGuardWebhookConfiguration(groups []string) *admissionregistrationv1.ValidatingWebhookConfiguration {
+	failurePolicy := admissionregistrationv1.Fail
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+
+	return &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: upgradeAdmissionGuardName,
+			Labels: map[string]string{
+				clusterctlv1.ClusterctlCoreLabelName: clusterctlv1.ClusterctlCoreLabelUpgradeGuardValue,
+			},
+		},
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{
+				Name: upgradeAdmissionGuardWebhookName,
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					URL: pointer.StringPtr(upgradeAdmissionGuardURL),
+				},
+				Rules: []admissionregistrationv1.RuleWithOperations{
+					{
+						Operations: []admissionregistrationv1.OperationType{
+							admissionregistrationv1.Create,
+							admissionregistrationv1.Update,
+						},
+						// NOTE: Subresources, e.g. status, are not matched by "*".
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   groups,
+							APIVersions: []string{"*"},
+							Resources:   []string{"*"},
+						},
+					},
+				},
+				FailurePolicy:           &failurePolicy,
+				SideEffects:             &sideEffects,
+				TimeoutSeconds:          pointer.Int32Ptr(5),
+				AdmissionReviewVersions: []string{"v1"},
+			},
+		},
+	}
+}
+
+// upgradeAdmissionGuardGroups returns the API groups of the CRDs in the given components; the clusterctl
+// API group is excluded, because clusterctl updates the inventory while upgrading providers.
+func upgradeAdmissionGuardGroups(components ...repository.Components) ([]string, error) {
+	groups := sets.NewString()
+	for _, c := range components {
+		for _, obj := range c.Objs() {
+			if obj.GetKind() != customResourceDefinitionKind {
+				continue
+			}
+
+			// NOTE: spec.group is read from the unstructured object because components could define CRDs
+			// using both the apiextensions v1 and v1beta1 API.
+			group, _, err := unstructured.NestedString(obj.Object, "spec", "group")
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get the API group of CustomResourceDefinition %s", obj.GetName())
+			}
+			if group == "" || group == clusterctlv1.GroupVersion.Group {
+				continue
+			}
+			groups.Insert(group)
+		}
+	}
+	return groups.List(), nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_upgradeAdmissionGuard(t *testing.T) {
+	g := NewWithT(t)
+
+	proxy := test.NewFakeProxy()
+	guard := newUpgradeAdmissionGuard(proxy)
+
+	c, err := proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	getGuard := func() (*admissionregistrationv1.ValidatingWebhookConfiguration, error) {
+		obj := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		err := c.Get(ctx, client.ObjectKey{Name: upgradeAdmissionGuardName}, obj)
+		return obj, err
+	}
+
+	// Install without groups is a no-op.
+	g.Expect(guard.Install(nil)).To(Succeed())
+	_, err = getGuard()
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// Install creates the guard.
+	g.Expect(guard.Install([]string{"cluster.x-k8s.io"})).To(Succeed())
+	obj, err := getGuard()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obj.Labels).To(HaveKeyWithValue(clusterctlv1.ClusterctlCoreLabelName, clusterctlv1.ClusterctlCoreLabelUpgradeGuardValue))
+	g.Expect(obj.Webhooks).To(HaveLen(1))
+	g.Expect(*obj.Webhooks[0].FailurePolicy).To(Equal(admissionregistrationv1.Fail))
+	g.Expect(*obj.Webhooks[0].ClientConfig.URL).To(Equal(upgradeAdmissionGuardURL))
+	g.Expect(obj.Webhooks[0].Rules).To(HaveLen(1))
+	g.Expect(obj.Webhooks[0].Rules[0].Operations).To(ConsistOf(admissionregistrationv1.Create, admissionregistrationv1.Update))
+	g.Expect(obj.Webhooks[0].Rules[0].APIGroups).To(Equal([]string{"cluster.x-k8s.io"}))
+
+	// Install updates an existing guard.
+	g.Expect(guard.Install([]string{"cluster.x-k8s.io", "infrastructure.cluster.x-k8s.io"})).To(Succeed())
+	obj, err = getGuard()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(obj.Webhooks[0].Rules[0].APIGroups).To(Equal([]string{"cluster.x-k8s.io", "infrastructure.cluster.x-k8s.io"}))
+
+	// Remove deletes the guard.
+	g.Expect(guard.Remove()).To(Succeed())
+	_, err = getGuard()
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	// Remove is a no-op if the guard does not exist.
+	g.Expect(guard.Remove()).To(Succeed())
+}
+
+func Test_upgradeAdmissionGuardGroups(t *testing.T) {
+	g := NewWithT(t)
+
+	crd := func(apiVersion, group string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(customResourceDefinitionKind)
+		u.SetName("foos." + group)
+		g.Expect(unstructured.SetNestedField(u.Object, group, "spec", "group")).To(Succeed())
+		return u
+	}
+	deployment := unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetName("manager")
+
+	core := newFakeComponents("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "capi-system").(*fakeComponents)
+	core.objs = []unstructured.Unstructured{
+		crd("apiextensions.k8s.io/v1", "cluster.x-k8s.io"),
+		crd("apiextensions.k8s.io/v1", "addons.cluster.x-k8s.io"),
+		crd("apiextensions.k8s.io/v1", clusterctlv1.GroupVersion.Group),
+		deployment,
+	}
+	infra := newFakeComponents("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system").(*fakeComponents)
+	infra.objs = []unstructured.Unstructured{
+		crd("apiextensions.k8s.io/v1beta1", "infrastructure.cluster.x-k8s.io"),
+		crd("apiextensions.k8s.io/v1", "cluster.x-k8s.io"),
+	}
+
+	got, err := upgradeAdmissionGuardGroups(core, infra)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(Equal([]string{"addons.cluster.x-k8s.io", "cluster.x-k8s.io", "infrastructure.cluster.x-k8s.io"}))
+}
+
+// failingDeleteComponentsClient is a ComponentsClient failing to delete the provider components.
+type failingDeleteComponentsClient struct {
+	ComponentsClient
+}
+
+func (c *failingDeleteComponentsClient) Delete(_ DeleteOptions) error {
+	return errors.New("failed to delete the provider components")
+}
+
+func Test_providerUpgrader_upgradeProvidersRemovesAdmissionGuard(t *testing.T) {
+	g := NewWithT(t)
+
+	crd := unstructured.Unstructured{}
+	crd.SetAPIVersion("apiextensions.k8s.io/v1")
+	crd.SetKind(customResourceDefinitionKind)
+	crd.SetName("clusters.cluster.x-k8s.io")
+	g.Expect(unstructured.SetNestedField(crd.Object, "cluster.x-k8s.io", "spec", "group")).To(Succeed())
+
+	core := newFakeComponents("cluster-api", clusterctlv1.CoreProviderType, "v1.0.1", "capi-system").(*fakeComponents)
+	core.objs = []unstructured.Unstructured{crd}
+
+	proxy := test.NewFakeProxy()
+	u := &providerUpgrader{
+		proxy:              proxy,
+		providerComponents: &failingDeleteComponentsClient{},
+	}
+	providers := []UpgradeItem{
+		{
+			Provider:    fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "capi-system"),
+			NextVersion: "v1.0.1",
+		},
+	}
+
+	err := u.upgradeProviders(providers, map[string]repository.Components{providers[0].InstanceName(): core})
+	g.Expect(err).To(MatchError(ContainSubstring("failed to delete the provider components")))
+
+	c, err := proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	err = c.Get(ctx, client.ObjectKey{Name: upgradeAdmissionGuardName}, &admissionregistrationv1.ValidatingWebhookConfiguration{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+}
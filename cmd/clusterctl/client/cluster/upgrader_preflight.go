@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// preflightChecks verifies the management cluster can be safely upgraded using the given upgrade plan, by checking that:
+//   - all the providers not included in the upgrade plan support the target API Version of Cluster API (contract).
+//   - there are no Clusters or Machines being deleted, given that provider controllers are scaled down during the upgrade.
+//
+// Paused Clusters, e.g. because a move operation is in progress or because they are paused during maintenance,
+// do not block the upgrade, but a warning is logged.
+func (u *providerUpgrader) preflightChecks(upgradePlan *UpgradePlan) error {
+	log := logf.Log
+
+	if err := u.checkContractCompatibility(upgradePlan); err != nil {
+		return err
+	}
+
+	c, err := u.proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	clusters, err := listObjectsForCRD(c, "clusters.cluster.x-k8s.io")
+	if err != nil {
+		return err
+	}
+	var deleting, paused []string
+	for i := range clusters {
+		cluster := &clusters[i]
+		if !cluster.GetDeletionTimestamp().IsZero() {
+			deleting = append(deleting, fmt.Sprintf("Cluster %s/%s", cluster.GetNamespace(), cluster.GetName()))
+		}
+		specPaused, _, _ := unstructured.NestedBool(cluster.Object, "spec", "paused")
+		if specPaused || annotations.HasPausedAnnotation(cluster) {
+			paused = append(paused, fmt.Sprintf("Cluster %s/%s", cluster.GetNamespace(), cluster.GetName()))
+		}
+	}
+
+	machines, err := listObjectsForCRD(c, "machines.cluster.x-k8s.io")
+	if err != nil {
+		return err
+	}
+	for i := range machines {
+		machine := &machines[i]
+		if !machine.GetDeletionTimestamp().IsZero() {
+			deleting = append(deleting, fmt.Sprintf("Machine %s/%s", machine.GetNamespace(), machine.GetName()))
+		}
+	}
+
+	if len(deleting) > 0 {
+		return errors.Errorf("unable to complete that upgrade: the following objects are being deleted, please wait for the deletion to complete before upgrading: %s", strings.Join(deleting, ", "))
+	}
+	if len(paused) > 0 {
+		log.Info("Warning: the following Clusters are paused; if a move operation is in progress, please complete it before upgrading", "Clusters", strings.Join(paused, ", "))
+	}
+	return nil
+}
+
+// checkContractCompatibility checks that all the providers in the upgrade plan without a target version are already
+// supporting the API Version of Cluster API (contract) the management cluster is being upgraded to.
+func (u *providerUpgrader) checkContractCompatibility(upgradePlan *UpgradePlan) error {
+	for _, upgradeItem := range upgradePlan.Providers {
+		if upgradeItem.NextVersion != "" {
+			continue
+		}
+
+		contract, err := u.getProviderContractByVersion(upgradeItem.Provider, upgradeItem.Version)
+		if err != nil {
+			return err
+		}
+		if contract != upgradePlan.Contract {
+			return errors.Errorf("unable to complete that upgrade: the provider %s supports the %s API Version of Cluster API (contract), while the management cluster is being updated to %s, and there is no version of the provider supporting it", upgradeItem.InstanceName(), contract, upgradePlan.Contract)
+		}
+	}
+	return nil
+}
+
+// listObjectsForCRD lists all the objects for a CRD using the storage version, which is always served, so the
+// list works no matter of the API Version of Cluster API (contract) currently installed in the management cluster.
+// If the CRD does not exist, an empty list is returned.
+func listObjectsForCRD(c client.Client, crdName string) ([]unstructured.Unstructured, error) {
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	found := true
+	if err := retryWithExponentialBackoff(newReadBackoff(), func() error {
+		if err := c.Get(ctx, client.ObjectKey{Name: crdName}, crd); err != nil {
+			if apierrors.IsNotFound(err) {
+				found = false
+				return nil
+			}
+			return err
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to get CRD %q", crdName)
+	}
+	if !found {
+		return nil, nil
+	}
+
+	storageVersion, err := storageVersionForCRD(crd)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   crd.Spec.Group,
+		Version: storageVersion,
+		Kind:    listKindForCRD(crd),
+	})
+	if err := retryWithExponentialBackoff(newReadBackoff(), func() error {
+		return c.List(ctx, list)
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list objects for CRD %q", crdName)
+	}
+	return list.Items, nil
+}
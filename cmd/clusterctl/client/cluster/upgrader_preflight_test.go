@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_providerUpgrader_preflightChecks(t *testing.T) {
+	crd := func(name, kind string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CustomResourceDefinition",
+				APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: clusterv1.GroupVersion.Group,
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Kind:     kind,
+					ListKind: kind + "List",
+				},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: clusterv1.GroupVersion.Version, Served: true, Storage: true},
+				},
+			},
+		}
+	}
+	crds := []client.Object{
+		crd("clusters.cluster.x-k8s.io", "Cluster"),
+		crd("machines.cluster.x-k8s.io", "Machine"),
+	}
+	deletionTimestamp := metav1.Now()
+
+	tests := []struct {
+		name     string
+		objs     []client.Object
+		wantErr  bool
+		errorMsg string
+	}{
+		{
+			name: "pass if the Cluster API CRDs are not installed",
+		},
+		{
+			name: "pass if Clusters and Machines are not being deleted nor paused",
+			objs: append([]client.Object{
+				&clusterv1.Cluster{
+					TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1"},
+				},
+				&clusterv1.Machine{
+					TypeMeta:   metav1.TypeMeta{Kind: "Machine", APIVersion: clusterv1.GroupVersion.String()},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "machine1"},
+				},
+			}, crds...),
+		},
+		{
+			name: "fails if a Cluster is being deleted",
+			objs: append([]client.Object{
+				&clusterv1.Cluster{
+					TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1", DeletionTimestamp: &deletionTimestamp, Finalizers: []string{clusterv1.ClusterFinalizer}},
+				},
+			}, crds...),
+			wantErr:  true,
+			errorMsg: "Cluster ns1/cluster1",
+		},
+		{
+			name: "fails if a Machine is being deleted",
+			objs: append([]client.Object{
+				&clusterv1.Machine{
+					TypeMeta:   metav1.TypeMeta{Kind: "Machine", APIVersion: clusterv1.GroupVersion.String()},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "machine1", DeletionTimestamp: &deletionTimestamp, Finalizers: []string{clusterv1.MachineFinalizer}},
+				},
+			}, crds...),
+			wantErr:  true,
+			errorMsg: "Machine ns1/machine1",
+		},
+		{
+			name: "does not fail if a Cluster is paused",
+			objs: append([]client.Object{
+				&clusterv1.Cluster{
+					TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1"},
+					Spec:       clusterv1.ClusterSpec{Paused: true},
+				},
+			}, crds...),
+			wantErr: false,
+		},
+		{
+			name: "does not fail if a Cluster has the paused annotation",
+			objs: append([]client.Object{
+				&clusterv1.Cluster{
+					TypeMeta:   metav1.TypeMeta{Kind: "Cluster", APIVersion: clusterv1.GroupVersion.String()},
+					ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1", Annotations: map[string]string{clusterv1.PausedAnnotation: ""}},
+				},
+			}, crds...),
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			u := &providerUpgrader{
+				proxy: test.NewFakeProxy().WithObjs(tt.objs...),
+			}
+			// All the providers in the plan have a target version, so contract compatibility is not checked.
+			err := u.preflightChecks(&UpgradePlan{
+				Contract: clusterv1.GroupVersion.Version,
+				Providers: []UpgradeItem{
+					{
+						Provider:    fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "cluster-api-system"),
+						NextVersion: "v2.0.0",
+					},
+				},
+			})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(ContainSubstring(tt.errorMsg))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
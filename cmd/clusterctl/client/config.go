@@ -17,8 +17,11 @@ limitations under the License.
 package client
 
 import (
+	"encoding/base64"
 	"io"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/version"
@@ -44,6 +47,20 @@ func (c *clusterctlClient) GetProvidersConfig() ([]Provider, error) {
 	return rr, nil
 }
 
+func (c *clusterctlClient) RefreshProvidersCache() error {
+	providers, err := c.configClient.Providers().List()
+	if err != nil {
+		return err
+	}
+
+	for _, provider := range providers {
+		if err := repository.RefreshCache(provider, c.configClient.Variables()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (c *clusterctlClient) GetProviderComponents(provider string, providerType clusterctlv1.ProviderType, options ComponentsOptions) (Components, error) {
 	components, err := c.getComponentsByName(provider, providerType, repository.ComponentsOptions(options))
 	if err != nil {
@@ -144,6 +161,15 @@ type GetClusterTemplateOptions struct {
 	// It can be set through the cli flag, WORKER_MACHINE_COUNT environment variable or will default to 0
 	WorkerMachineCount *int64
 
+	// EnvFile is the path of a file defining variables to be used in the template, one per line in the
+	// KEY=VALUE format. Variables defined in the file take precedence over os env variables and the
+	// .cluster-api/clusterctl.yaml config file.
+	EnvFile string
+
+	// VariablesFromFiles defines variables whose value is read from a file, e.g. certificates or cloud-init snippets;
+	// they take precedence over variables defined in the EnvFile.
+	VariablesFromFiles []VariableFromFileOptions
+
 	// ListVariablesOnly sets the GetClusterTemplate method to return the list of variables expected by the template
 	// without executing any further processing.
 	ListVariablesOnly bool
@@ -186,6 +212,18 @@ type URLSourceOptions struct {
 	URL string
 }
 
+// VariableFromFileOptions defines a template variable whose value is read from a file.
+type VariableFromFileOptions struct {
+	// Name of the variable.
+	Name string
+
+	// Path of the file to read the value of the variable from.
+	Path string
+
+	// Base64 defines if the content of the file should be base64 encoded before being used as the value of the variable.
+	Base64 bool
+}
+
 // DefaultCustomTemplateConfigMapKey  where the workload cluster template is hosted.
 const DefaultCustomTemplateConfigMapKey = "template"
 
@@ -376,6 +414,12 @@ func (c *clusterctlClient) getTemplateFromURL(cluster cluster.Client, source URL
 
 // templateOptionsToVariables injects some of the templateOptions to the configClient so they can be consumed as a variables from the template.
 func (c *clusterctlClient) templateOptionsToVariables(options GetClusterTemplateOptions) error {
+	// the variables defined in the EnvFile and the VariablesFromFiles are injected first, so they can be
+	// used as a fallback for the options below, e.g. CONTROL_PLANE_MACHINE_COUNT.
+	if err := c.fileVariablesToVariables(options); err != nil {
+		return err
+	}
+
 	// the TargetNamespace, if valid, can be used in templates using the ${ NAMESPACE } variable.
 	if err := validateDNS1123Label(options.TargetNamespace); err != nil {
 		return errors.Wrapf(err, "invalid target-namespace")
@@ -436,3 +480,64 @@ func (c *clusterctlClient) templateOptionsToVariables(options GetClusterTemplate
 
 	return nil
 }
+
+// fileVariablesToVariables injects the variables defined in the EnvFile and the VariablesFromFiles to the configClient
+// so they can be consumed as variables from the template.
+func (c *clusterctlClient) fileVariablesToVariables(options GetClusterTemplateOptions) error {
+	if options.EnvFile != "" {
+		variables, err := readEnvFile(options.EnvFile)
+		if err != nil {
+			return err
+		}
+		for _, v := range variables {
+			c.configClient.Variables().Set(v[0], v[1])
+		}
+	}
+
+	for _, v := range options.VariablesFromFiles {
+		if v.Name == "" {
+			return errors.Errorf("invalid variable from file %q: the variable name must be set", v.Path)
+		}
+		content, err := os.ReadFile(v.Path) //nolint:gosec
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the value of the variable %s from file", v.Name)
+		}
+		value := string(content)
+		if v.Base64 {
+			value = base64.StdEncoding.EncodeToString(content)
+		}
+		c.configClient.Variables().Set(v.Name, value)
+	}
+	return nil
+}
+
+// readEnvFile reads a file defining variables in the KEY=VALUE format, one per line, and returns
+// the list of key/value pairs in the same order of the file. Empty lines and lines starting
+// with # are ignored, the "export " prefix and quotes around values are removed.
+func readEnvFile(path string) ([][2]string, error) {
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read env file %q", path)
+	}
+
+	variables := [][2]string{}
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		kv := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, errors.Errorf("invalid env file %q: line %d is not in the KEY=VALUE format", path, i+1)
+		}
+		value := strings.TrimSpace(kv[1])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		variables = append(variables, [2]string{key, value})
+	}
+	return variables, nil
+}
@@ -26,6 +26,7 @@ import (
 // 2. The configuration of the providers (name, type and URL of the provider repository)
 // 3. Variables used when installing providers/creating clusters. Variables can be read from the environment or from the config file
 // 4. The configuration about image overrides.
+// 5. Named profiles with defaults for working with a management cluster.
 type Client interface {
 	// CertManager provide access to the cert-manager configurations.
 	CertManager() CertManagerClient
@@ -38,6 +39,9 @@ type Client interface {
 
 	// ImageMeta provide access to to image meta configurations.
 	ImageMeta() ImageMetaClient
+
+	// Profiles provide access to management cluster profiles.
+	Profiles() ProfilesClient
 }
 
 // configClient implements Client.
@@ -64,6 +68,10 @@ func (c *configClient) ImageMeta() ImageMetaClient {
 	return newImageMetaClient(c.reader)
 }
 
+func (c *configClient) Profiles() ProfilesClient {
+	return newProfilesClient(c.reader)
+}
+
 // Option is a configuration option supplied to New.
 type Option func(*configClient)
 
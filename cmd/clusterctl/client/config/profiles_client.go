@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ProfilesConfigKey defines the name of the top level config key for management cluster profiles.
+	ProfilesConfigKey = "profiles"
+)
+
+// Profile defines a named set of defaults for working with a management cluster.
+type Profile struct {
+	// Kubeconfig is the path to the kubeconfig file of the management cluster.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+
+	// KubeconfigContext is the context of the kubeconfig file to use.
+	KubeconfigContext string `json:"kubeconfigContext,omitempty"`
+
+	// Namespace is the namespace used by commands that work on a namespace.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Variables are default values for variables; they are overridden by OS environment variables.
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// ProfilesClient has methods to work with management cluster profiles.
+type ProfilesClient interface {
+	// List returns the names of the profiles defined in the clusterctl configuration, sorted alphabetically.
+	List() ([]string, error)
+
+	// Get returns the profile with the given name.
+	// In case the profile is not defined in the clusterctl configuration, it returns an error.
+	Get(name string) (*Profile, error)
+}
+
+// profilesClient implements ProfilesClient.
+type profilesClient struct {
+	reader Reader
+}
+
+// ensure profilesClient implements ProfilesClient.
+var _ ProfilesClient = &profilesClient{}
+
+func newProfilesClient(reader Reader) *profilesClient {
+	return &profilesClient{
+		reader: reader,
+	}
+}
+
+func (p *profilesClient) List() ([]string, error) {
+	profiles, err := p.profiles()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (p *profilesClient) Get(name string) (*Profile, error) {
+	profiles, err := p.profiles()
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: viper lowercases keys, so profile names are matched case-insensitively.
+	for n, profile := range profiles {
+		if strings.EqualFold(n, name) {
+			profile := profile
+			return &profile, nil
+		}
+	}
+	return nil, errors.Errorf("profile %q is not defined in the clusterctl configuration file", name)
+}
+
+func (p *profilesClient) profiles() (map[string]Profile, error) {
+	profiles := map[string]Profile{}
+	if err := p.reader.UnmarshalKey(ProfilesConfigKey, &profiles); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal profiles from the clusterctl configuration file")
+	}
+	return profiles, nil
+}
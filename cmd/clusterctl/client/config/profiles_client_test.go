@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+const profilesConfig = `
+prod:
+  kubeconfig: /home/user/.kube/prod
+  kubeconfigContext: prod-admin
+  namespace: clusters
+  variables:
+    AWS_REGION: eu-west-1
+staging:
+  kubeconfig: /home/user/.kube/staging
+`
+
+func TestProfilesList(t *testing.T) {
+	tests := []struct {
+		name   string
+		reader Reader
+		want   []string
+	}{
+		{
+			name:   "return an empty list if no profiles are defined",
+			reader: test.NewFakeReader(),
+			want:   []string{},
+		},
+		{
+			name:   "return the sorted list of profiles",
+			reader: test.NewFakeReader().WithVar(ProfilesConfigKey, profilesConfig),
+			want:   []string{"prod", "staging"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := newProfilesClient(tt.reader)
+			got, err := p.List()
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestProfilesGet(t *testing.T) {
+	tests := []struct {
+		name        string
+		reader      Reader
+		profileName string
+		want        *Profile
+		wantErr     bool
+	}{
+		{
+			name:        "return the profile",
+			reader:      test.NewFakeReader().WithVar(ProfilesConfigKey, profilesConfig),
+			profileName: "prod",
+			want: &Profile{
+				Kubeconfig:        "/home/user/.kube/prod",
+				KubeconfigContext: "prod-admin",
+				Namespace:         "clusters",
+				Variables: map[string]string{
+					"AWS_REGION": "eu-west-1",
+				},
+			},
+		},
+		{
+			name:        "match profile names case-insensitively",
+			reader:      test.NewFakeReader().WithVar(ProfilesConfigKey, profilesConfig),
+			profileName: "Staging",
+			want: &Profile{
+				Kubeconfig: "/home/user/.kube/staging",
+			},
+		},
+		{
+			name:        "fail if the profile is not defined",
+			reader:      test.NewFakeReader().WithVar(ProfilesConfigKey, profilesConfig),
+			profileName: "dev",
+			wantErr:     true,
+		},
+		{
+			name:        "fail if no profiles are defined",
+			reader:      test.NewFakeReader(),
+			profileName: "prod",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := newProfilesClient(tt.reader)
+			got, err := p.Get(tt.profileName)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
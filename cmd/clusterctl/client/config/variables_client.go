@@ -19,6 +19,14 @@ package config
 const (
 	// GitHubTokenVariable defines a variable hosting the GitHub access token.
 	GitHubTokenVariable = "github-token"
+
+	// CacheTTLVariable defines a variable hosting how long the files of the provider repositories are cached
+	// before being read again from the repositories, e.g. "12h"; the cache is disabled if not set.
+	CacheTTLVariable = "clusterctl-cache-ttl"
+
+	// OfflineVariable defines a variable enabling the offline mode, where the files of the provider repositories
+	// are read only from the cache.
+	OfflineVariable = "clusterctl-offline"
 )
 
 // VariablesClient has methods to work with environment variables and with variables defined in the clusterctl configuration file.
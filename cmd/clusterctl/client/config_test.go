@@ -434,6 +434,60 @@ func Test_clusterctlClient_templateOptionsToVariables_withExistingMachineCountVa
 	}
 }
 
+func Test_clusterctlClient_templateOptionsToVariables_withVariablesFromFiles(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := os.MkdirTemp("", "clusterctl")
+	g.Expect(err).NotTo(HaveOccurred())
+	defer os.RemoveAll(dir)
+
+	envFile := filepath.Join(dir, "cluster.env")
+	g.Expect(os.WriteFile(envFile, []byte(`# comment
+export WORKER_MACHINE_COUNT=5
+KUBERNETES_VERSION="v1.2.3"
+CLOUD_INIT='overridden'
+`), 0600)).To(Succeed())
+	cloudInitFile := filepath.Join(dir, "cloud-init.yaml")
+	g.Expect(os.WriteFile(cloudInitFile, []byte("runcmd:\n- echo hello\n"), 0600)).To(Succeed())
+	caFile := filepath.Join(dir, "ca.crt")
+	g.Expect(os.WriteFile(caFile, []byte("certificate"), 0600)).To(Succeed())
+
+	configClient := newFakeConfig()
+	c := &clusterctlClient{
+		configClient: configClient,
+	}
+	options := GetClusterTemplateOptions{
+		ClusterName:     "foo",
+		TargetNamespace: "bar",
+		EnvFile:         envFile,
+		VariablesFromFiles: []VariableFromFileOptions{
+			{Name: "CLOUD_INIT", Path: cloudInitFile},
+			{Name: "CA_CERT", Path: caFile, Base64: true},
+		},
+	}
+	g.Expect(c.templateOptionsToVariables(options)).To(Succeed())
+
+	wantVars := map[string]string{
+		"KUBERNETES_VERSION":   "v1.2.3",
+		"WORKER_MACHINE_COUNT": "5",
+		"CLOUD_INIT":           "runcmd:\n- echo hello\n",
+		"CA_CERT":              "Y2VydGlmaWNhdGU=",
+	}
+	for name, wantValue := range wantVars {
+		gotValue, err := configClient.Variables().Get(name)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(gotValue).To(Equal(wantValue), "variable %s", name)
+	}
+
+	// Invalid env files and missing files are reported.
+	g.Expect(os.WriteFile(envFile, []byte("NOT_A_VARIABLE\n"), 0600)).To(Succeed())
+	g.Expect(c.templateOptionsToVariables(options)).ToNot(Succeed())
+
+	options.EnvFile = ""
+	options.VariablesFromFiles = []VariableFromFileOptions{{Name: "CLOUD_INIT", Path: filepath.Join(dir, "missing.yaml")}}
+	g.Expect(c.templateOptionsToVariables(options)).ToNot(Succeed())
+}
+
 func Test_clusterctlClient_GetClusterTemplate(t *testing.T) {
 	g := NewWithT(t)
 
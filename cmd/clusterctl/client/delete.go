@@ -17,10 +17,31 @@ limitations under the License.
 package client
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// OrphanPolicy defines how Delete deals with the objects of the Kinds defined by the providers being deleted, which
+// are orphaned when deleting the providers' CRDs or the namespaces where the providers are hosted.
+type OrphanPolicy string
+
+const (
+	// OrphanPolicyBlock blocks the deletion of the providers if any object of the Kinds defined by the providers exists.
+	OrphanPolicyBlock OrphanPolicy = "block"
+
+	// OrphanPolicyCascade deletes the objects of the Kinds defined by the providers before deleting the providers, and
+	// waits for those objects to be removed.
+	OrphanPolicyCascade OrphanPolicy = "cascade"
+
+	// OrphanPolicyIgnore reports the objects of the Kinds defined by the providers, but proceeds with the deletion;
+	// this is the default, preserving the behaviour of --include-crd and --include-namespace.
+	OrphanPolicyIgnore OrphanPolicy = "ignore"
 )
 
 // DeleteOptions carries the options supported by Delete.
@@ -56,6 +77,10 @@ type DeleteOptions struct {
 
 	// SkipInventory forces the deletion of the inventory items used by clusterctl to track providers.
 	SkipInventory bool
+
+	// OrphanPolicy defines how to deal with the objects of the Kinds defined by the providers being deleted, when
+	// IncludeCRDs or IncludeNamespace are set. If unspecified, OrphanPolicyIgnore is used.
+	OrphanPolicy OrphanPolicy
 }
 
 func (c *clusterctlClient) Delete(options DeleteOptions) error {
@@ -113,6 +138,13 @@ func (c *clusterctlClient) Delete(options DeleteOptions) error {
 		}
 	}
 
+	// If deleting CRDs or namespaces, detect objects that are going to be orphaned before deleting any provider.
+	if options.IncludeCRDs || options.IncludeNamespace {
+		if err := checkOrphanedObjects(clusterClient, providersToDelete, options.OrphanPolicy); err != nil {
+			return err
+		}
+	}
+
 	// Delete the selected providers.
 	for _, provider := range providersToDelete {
 		if err := clusterClient.ProviderComponents().Delete(cluster.DeleteOptions{Provider: provider, IncludeNamespace: options.IncludeNamespace, IncludeCRDs: options.IncludeCRDs, SkipInventory: options.SkipInventory}); err != nil {
@@ -123,6 +155,57 @@ func (c *clusterctlClient) Delete(options DeleteOptions) error {
 	return nil
 }
 
+// checkOrphanedObjects detects the objects of the Kinds defined by the providers being deleted, and blocks, cascades
+// or ignores them according to the given OrphanPolicy.
+func checkOrphanedObjects(clusterClient cluster.Client, providers []clusterctlv1.Provider, policy OrphanPolicy) error {
+	log := logf.Log
+
+	if policy == "" {
+		policy = OrphanPolicyIgnore
+	}
+
+	var orphaned []string
+	var providersWithObjects []clusterctlv1.Provider
+	for _, provider := range providers {
+		objs, err := clusterClient.ProviderComponents().ListCustomResources(provider)
+		if err != nil {
+			return err
+		}
+		if len(objs) == 0 {
+			continue
+		}
+		providersWithObjects = append(providersWithObjects, provider)
+		for _, obj := range objs {
+			if obj.GetNamespace() == "" {
+				orphaned = append(orphaned, fmt.Sprintf("%s %s", obj.GetKind(), obj.GetName()))
+				continue
+			}
+			orphaned = append(orphaned, fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+		}
+	}
+	if len(orphaned) == 0 {
+		return nil
+	}
+
+	switch policy {
+	case OrphanPolicyBlock:
+		return errors.Errorf("unable to delete the providers: the following objects would be orphaned, please delete them or use a different orphan policy: %s", strings.Join(orphaned, ", "))
+	case OrphanPolicyIgnore:
+		log.Info("Warning: the following objects are going to be orphaned", "Objects", strings.Join(orphaned, ", "))
+		return nil
+	case OrphanPolicyCascade:
+		log.Info("Deleting objects that would be orphaned", "Objects", strings.Join(orphaned, ", "))
+		for _, provider := range providersWithObjects {
+			if err := clusterClient.ProviderComponents().DeleteCustomResources(provider); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errors.Errorf("invalid orphan policy %q, it must be one of %q, %q or %q", policy, OrphanPolicyBlock, OrphanPolicyCascade, OrphanPolicyIgnore)
+	}
+}
+
 func appendProviders(list []clusterctlv1.Provider, providerType clusterctlv1.ProviderType, names ...string) []clusterctlv1.Provider {
 	for _, name := range names {
 		if name == "" {
@@ -20,9 +20,13 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var namespace = "foobar"
@@ -109,6 +113,92 @@ func Test_clusterctlClient_Delete(t *testing.T) {
 				clusterctlv1.ManifestLabel(infraProviderConfig.Name(), infraProviderConfig.Type())),
 			wantErr: false,
 		},
+		{
+			name: "Delete provider and CRDs with orphaned objects using the default orphan policy",
+			fields: fields{
+				client: fakeClusterForDelete(fakeMachineCRDAndObject()...),
+			},
+			args: args{
+				options: DeleteOptions{
+					Kubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					IncludeCRDs:  true,
+					CoreProvider: capiProviderConfig.Name(),
+				},
+			},
+			wantProviders: sets.NewString(
+				clusterctlv1.ManifestLabel(bootstrapProviderConfig.Name(), bootstrapProviderConfig.Type()),
+				clusterctlv1.ManifestLabel(controlPlaneProviderConfig.Name(), controlPlaneProviderConfig.Type()),
+				clusterctlv1.ManifestLabel(infraProviderConfig.Name(), infraProviderConfig.Type())),
+			wantErr: false,
+		},
+		{
+			name: "Delete provider and CRDs blocked by orphaned objects",
+			fields: fields{
+				client: fakeClusterForDelete(fakeMachineCRDAndObject()...),
+			},
+			args: args{
+				options: DeleteOptions{
+					Kubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					IncludeCRDs:  true,
+					CoreProvider: capiProviderConfig.Name(),
+					OrphanPolicy: OrphanPolicyBlock,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Delete provider and CRDs with invalid orphan policy",
+			fields: fields{
+				client: fakeClusterForDelete(fakeMachineCRDAndObject()...),
+			},
+			args: args{
+				options: DeleteOptions{
+					Kubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					IncludeCRDs:  true,
+					CoreProvider: capiProviderConfig.Name(),
+					OrphanPolicy: "foo",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Delete provider and CRDs ignoring orphaned objects",
+			fields: fields{
+				client: fakeClusterForDelete(fakeMachineCRDAndObject()...),
+			},
+			args: args{
+				options: DeleteOptions{
+					Kubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					IncludeCRDs:  true,
+					CoreProvider: capiProviderConfig.Name(),
+					OrphanPolicy: OrphanPolicyIgnore,
+				},
+			},
+			wantProviders: sets.NewString(
+				clusterctlv1.ManifestLabel(bootstrapProviderConfig.Name(), bootstrapProviderConfig.Type()),
+				clusterctlv1.ManifestLabel(controlPlaneProviderConfig.Name(), controlPlaneProviderConfig.Type()),
+				clusterctlv1.ManifestLabel(infraProviderConfig.Name(), infraProviderConfig.Type())),
+			wantErr: false,
+		},
+		{
+			name: "Delete provider and CRDs cascading to orphaned objects",
+			fields: fields{
+				client: fakeClusterForDelete(fakeMachineCRDAndObject()...),
+			},
+			args: args{
+				options: DeleteOptions{
+					Kubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					IncludeCRDs:  true,
+					CoreProvider: capiProviderConfig.Name(),
+					OrphanPolicy: OrphanPolicyCascade,
+				},
+			},
+			wantProviders: sets.NewString(
+				clusterctlv1.ManifestLabel(bootstrapProviderConfig.Name(), bootstrapProviderConfig.Type()),
+				clusterctlv1.ManifestLabel(controlPlaneProviderConfig.Name(), controlPlaneProviderConfig.Type()),
+				clusterctlv1.ManifestLabel(infraProviderConfig.Name(), infraProviderConfig.Type())),
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -140,7 +230,7 @@ func Test_clusterctlClient_Delete(t *testing.T) {
 }
 
 // clusterctl client for a management cluster with capi and bootstrap provider.
-func fakeClusterForDelete() *fakeClient {
+func fakeClusterForDelete(objs ...client.Object) *fakeClient {
 	config1 := newFakeConfig().
 		WithVar("var", "value").
 		WithProvider(capiProviderConfig).
@@ -159,6 +249,7 @@ func fakeClusterForDelete() *fakeClient {
 	cluster1.fakeProxy.WithProviderInventory(controlPlaneProviderConfig.Name(), controlPlaneProviderConfig.Type(), "v1.0.0", namespace)
 	cluster1.fakeProxy.WithProviderInventory(infraProviderConfig.Name(), infraProviderConfig.Type(), "v1.0.0", namespace)
 	cluster1.fakeProxy.WithFakeCAPISetup()
+	cluster1.fakeProxy.WithObjs(objs...)
 
 	client := newFakeClient(config1).
 		// fake repository for capi, bootstrap, controlplane and infra provider (matching provider's config)
@@ -171,3 +262,36 @@ func fakeClusterForDelete() *fakeClient {
 
 	return client
 }
+
+// fakeMachineCRDAndObject returns the Machine CRD of the core provider and a Machine object.
+func fakeMachineCRDAndObject() []client.Object {
+	return []client.Object{
+		&apiextensionsv1.CustomResourceDefinition{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CustomResourceDefinition",
+				APIVersion: apiextensionsv1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "machines.cluster.x-k8s.io",
+				Labels: map[string]string{
+					clusterctlv1.ClusterctlLabelName: "",
+					clusterv1.ProviderLabelName:      capiProviderConfig.Name(),
+				},
+			},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: clusterv1.GroupVersion.Group,
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Kind:     "Machine",
+					ListKind: "MachineList",
+				},
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: clusterv1.GroupVersion.Version, Served: true, Storage: true},
+				},
+			},
+		},
+		&clusterv1.Machine{
+			TypeMeta:   metav1.TypeMeta{Kind: "Machine", APIVersion: clusterv1.GroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "machine1"},
+		},
+	}
+}
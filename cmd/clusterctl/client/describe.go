@@ -18,7 +18,12 @@ package client
 
 import (
 	"context"
+	"sort"
 
+	clusterv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
+	clusterv1old "sigs.k8s.io/cluster-api/api/v1alpha4"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
 )
 
@@ -86,3 +91,127 @@ func (c *clusterctlClient) DescribeCluster(options DescribeClusterOptions) (*tre
 		DisableGrouping:     options.DisableGrouping,
 	})
 }
+
+// DescribeProvidersOptions carries the options supported by DescribeProviders.
+type DescribeProvidersOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+}
+
+// ProviderDescription describes a provider installed in a management cluster.
+type ProviderDescription struct {
+	// Name of the provider, e.g. aws.
+	Name string `json:"name"`
+
+	// Type of the provider, e.g. InfrastructureProvider.
+	Type string `json:"type"`
+
+	// Namespace where the provider is installed.
+	Namespace string `json:"namespace"`
+
+	// Version of the provider.
+	Version string `json:"version"`
+
+	// WatchedNamespace is the namespace watched by the provider; empty if the provider watches all the namespaces.
+	WatchedNamespace string `json:"watchedNamespace,omitempty"`
+
+	// Contract is the API Version of Cluster API (contract) supported by the current version of the provider.
+	Contract string `json:"contract"`
+
+	// Upgrades are the versions the provider can be upgraded to, one for each contract with a newer version available.
+	Upgrades []ProviderUpgrade `json:"upgrades,omitempty"`
+}
+
+// ProviderUpgrade is a version a provider can be upgraded to.
+type ProviderUpgrade struct {
+	// Contract is the API Version of Cluster API (contract) supported by the version.
+	Contract string `json:"contract"`
+
+	// Version the provider can be upgraded to.
+	Version string `json:"version"`
+}
+
+// DescribeProviders returns the description of the providers installed in a management cluster, read from
+// the provider inventory; the contract of each provider and the pending upgrades are computed
+// from the provider repositories, the same way clusterctl upgrade plan does.
+func (c *clusterctlClient) DescribeProviders(options DescribeProvidersOptions) ([]ProviderDescription, error) {
+	// Get the client for interacting with the management cluster.
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract or the ones
+	// that can be upgraded, like upgrade plan does.
+	if err := clusterClient.ProviderInventory().CheckCAPIContract(
+		cluster.AllowCAPIContract{Contract: clusterv1alpha3.GroupVersion.Version},
+		cluster.AllowCAPIContract{Contract: clusterv1old.GroupVersion.Version},
+	); err != nil {
+		return nil, err
+	}
+
+	// Ensures the custom resource definitions required by clusterctl are in place.
+	if err := clusterClient.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
+		return nil, err
+	}
+
+	providerList, err := clusterClient.ProviderInventory().List()
+	if err != nil {
+		return nil, err
+	}
+
+	contracts, err := clusterClient.ProviderUpgrader().CurrentContracts()
+	if err != nil {
+		return nil, err
+	}
+
+	upgradePlans, err := clusterClient.ProviderUpgrader().Plan()
+	if err != nil {
+		return nil, err
+	}
+	upgrades := map[string][]ProviderUpgrade{}
+	for _, plan := range upgradePlans {
+		for _, item := range plan.Providers {
+			if item.NextVersion == "" {
+				continue
+			}
+			upgrades[item.InstanceName()] = append(upgrades[item.InstanceName()], ProviderUpgrade{
+				Contract: plan.Contract,
+				Version:  item.NextVersion,
+			})
+		}
+	}
+
+	// Sort providers consistently, by type (core provider first), name and namespace.
+	providers := providerList.Items
+	sort.Slice(providers, func(i, j int) bool {
+		return providerLess(&providers[i], &providers[j])
+	})
+
+	descriptions := make([]ProviderDescription, 0, len(providers))
+	for i := range providers {
+		provider := &providers[i]
+		descriptions = append(descriptions, ProviderDescription{
+			Name:             provider.ProviderName,
+			Type:             provider.Type,
+			Namespace:        provider.Namespace,
+			Version:          provider.Version,
+			WatchedNamespace: provider.WatchedNamespace,
+			Contract:         contracts[provider.InstanceName()],
+			Upgrades:         upgrades[provider.InstanceName()],
+		})
+	}
+	return descriptions, nil
+}
+
+// providerLess returns true if the provider a should be listed before the provider b, i.e. by type, name and namespace.
+func providerLess(a, b *clusterctlv1.Provider) bool {
+	if a.GetProviderType().Order() != b.GetProviderType().Order() {
+		return a.GetProviderType().Order() < b.GetProviderType().Order()
+	}
+	if a.ProviderName != b.ProviderName {
+		return a.ProviderName < b.ProviderName
+	}
+	return a.Namespace < b.Namespace
+}
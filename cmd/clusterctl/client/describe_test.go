@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_clusterctlClient_DescribeProviders(t *testing.T) {
+	tests := []struct {
+		name    string
+		options DescribeProvidersOptions
+		want    []ProviderDescription
+		wantErr bool
+	}{
+		{
+			name: "returns the providers with contract and pending upgrades",
+			options: DescribeProvidersOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+			},
+			want: []ProviderDescription{
+				{
+					Name:      "cluster-api",
+					Type:      string(clusterctlv1.CoreProviderType),
+					Namespace: "cluster-api-system",
+					Version:   "v1.0.0",
+					Contract:  test.CurrentCAPIContract,
+					Upgrades:  []ProviderUpgrade{{Contract: test.CurrentCAPIContract, Version: "v1.0.1"}},
+				},
+				{
+					Name:      "infra",
+					Type:      string(clusterctlv1.InfrastructureProviderType),
+					Namespace: "infra-system",
+					Version:   "v2.0.0",
+					Contract:  test.CurrentCAPIContract,
+					Upgrades:  []ProviderUpgrade{{Contract: test.CurrentCAPIContract, Version: "v2.0.1"}},
+				},
+			},
+		},
+		{
+			name: "returns an error if cluster client is not found",
+			options: DescribeProvidersOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "some-other-context"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := fakeClientForUpgrade().DescribeProviders(tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
+)
+
+// FleetStatusOptions carries the options supported by FleetStatus.
+type FleetStatusOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the Clusters are located. If unspecified, Clusters in all the namespaces are evaluated.
+	Namespace string
+
+	// Conditions to evaluate on each Cluster. If unspecified, alpha.DefaultFleetConditions are used.
+	Conditions []string
+
+	// CheckControlPlanes instructs to evaluate the control plane object of each Cluster.
+	CheckControlPlanes bool
+
+	// CheckMachineDeployments instructs to evaluate the MachineDeployments of each Cluster.
+	CheckMachineDeployments bool
+}
+
+func (c *clusterctlClient) FleetStatus(options FleetStatusOptions) (*alpha.FleetStatusReport, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := clusterClient.ProviderInventory().CheckCAPIContract(); err != nil {
+		return nil, err
+	}
+
+	conditionTypes := make([]clusterv1.ConditionType, 0, len(options.Conditions))
+	for _, c := range options.Conditions {
+		conditionTypes = append(conditionTypes, clusterv1.ConditionType(c))
+	}
+
+	return c.alphaClient.Fleet().Status(clusterClient.Proxy(), alpha.FleetStatusOptions{
+		Namespace:               options.Namespace,
+		Conditions:              conditionTypes,
+		CheckControlPlanes:      options.CheckControlPlanes,
+		CheckMachineDeployments: options.CheckMachineDeployments,
+	})
+}
@@ -65,6 +65,10 @@ type InitOptions struct {
 	// WaitProviderTimeout sets the timeout per provider wait installation
 	WaitProviderTimeout time.Duration
 
+	// RollbackOnFailure instructs the init command to delete the providers it installed if they do not become ready
+	// within WaitProviderTimeout. This option is ignored if WaitProviders is false.
+	RollbackOnFailure bool
+
 	// SkipTemplateProcess allows for skipping the call to the template processor, including also variable replacement in the component YAML.
 	// NOTE this works only if the rawYaml is a valid yaml by itself, like e.g when using envsubst/the simple processor.
 	skipTemplateProcess bool
@@ -119,6 +123,7 @@ func (c *clusterctlClient) Init(options InitOptions) ([]Components, error) {
 	installOpts := cluster.InstallOptions{
 		WaitProviders:       options.WaitProviders,
 		WaitProviderTimeout: options.WaitProviderTimeout,
+		RollbackOnFailure:   options.RollbackOnFailure,
 	}
 	components, err := installer.Install(installOpts)
 	if err != nil {
@@ -38,6 +38,10 @@ type MoveOptions struct {
 
 	// DryRun means the move action is a dry run, no real action will be performed
 	DryRun bool
+
+	// CheckpointFile defines the file recording the progress of the move, so a move interrupted by a failure
+	// can be resumed by running it again with the same file. If empty, no checkpoint is recorded.
+	CheckpointFile string
 }
 
 // BackupOptions holds options supported by backup.
@@ -109,7 +113,7 @@ func (c *clusterctlClient) Move(options MoveOptions) error {
 		options.Namespace = currentNamespace
 	}
 
-	return fromCluster.ObjectMover().Move(options.Namespace, toCluster, options.DryRun)
+	return fromCluster.ObjectMover().Move(options.Namespace, toCluster, options.DryRun, options.CheckpointFile)
 }
 
 func (c *clusterctlClient) Backup(options BackupOptions) error {
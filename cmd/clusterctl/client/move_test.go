@@ -252,9 +252,11 @@ type fakeObjectMover struct {
 	moveErr    error
 	backupErr  error
 	restoerErr error
+	syncErr    error
+	promoteErr error
 }
 
-func (f *fakeObjectMover) Move(namespace string, toCluster cluster.Client, dryRun bool) error {
+func (f *fakeObjectMover) Move(namespace string, toCluster cluster.Client, dryRun bool, checkpointFile string) error {
 	return f.moveErr
 }
 
@@ -265,3 +267,11 @@ func (f *fakeObjectMover) Backup(namespace string, directory string) error {
 func (f *fakeObjectMover) Restore(toCluster cluster.Client, directory string) error {
 	return f.restoerErr
 }
+
+func (f *fakeObjectMover) Sync(namespace string, toCluster cluster.Client) error {
+	return f.syncErr
+}
+
+func (f *fakeObjectMover) Promote(namespace string, fromCluster cluster.Client) error {
+	return f.promoteErr
+}
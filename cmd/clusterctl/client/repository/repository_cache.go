@@ -0,0 +1,173 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/homedir"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	cacheFolder    = "cache"
+	cacheFolderKey = "cacheFolder"
+
+	// versionsCacheFile is the name of the file storing the list of versions available in a repository.
+	versionsCacheFile = "versions.yaml"
+)
+
+// repositoryCache implements an on-disk cache for the files read from a remote provider repository,
+// so repeated clusterctl invocations do not hit the repository, and its API rate limits, every time.
+//
+// The cache is opt-in: files are read from and written to the cache only if a cache TTL is configured
+// or clusterctl is running in offline mode; otherwise, e.g. newly published releases would not be
+// discovered until the cached list of versions expires.
+//
+// Files are stored under the cache folder following the same layout of the overrides layer, i.e.
+// <providerType-providerName>/<version>/<fileName>, while the list of versions available in the repository
+// is stored in <providerType-providerName>/versions.yaml.
+type repositoryCache struct {
+	path    string
+	ttl     time.Duration
+	offline bool
+}
+
+// newRepositoryCache returns the cache for the given provider.
+func newRepositoryCache(provider config.Provider, configVariablesClient config.VariablesClient) (*repositoryCache, error) {
+	basepath := filepath.Join(homedir.HomeDir(), config.ConfigFolder, cacheFolder)
+	if f, err := configVariablesClient.Get(cacheFolderKey); err == nil && len(strings.TrimSpace(f)) != 0 {
+		basepath = f
+	}
+
+	cache := &repositoryCache{
+		path: filepath.Join(basepath, provider.ManifestLabel()),
+	}
+
+	if v, err := configVariablesClient.Get(config.CacheTTLVariable); err == nil && len(strings.TrimSpace(v)) != 0 {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for %s", config.CacheTTLVariable)
+		}
+		if ttl < 0 {
+			return nil, errors.Errorf("invalid value for %s: the TTL must be greater than or equal to zero", config.CacheTTLVariable)
+		}
+		cache.ttl = ttl
+	}
+
+	if v, err := configVariablesClient.Get(config.OfflineVariable); err == nil && len(strings.TrimSpace(v)) != 0 {
+		offline, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for %s", config.OfflineVariable)
+		}
+		cache.offline = offline
+	}
+
+	return cache, nil
+}
+
+// Enabled returns true if the cache is enabled, i.e. a cache TTL is configured or the cache is used in offline mode.
+func (c *repositoryCache) Enabled() bool {
+	return c.ttl > 0 || c.offline
+}
+
+// Get returns the content of a cached file, or nil if the file is not cached or the cache is not enabled,
+// and whether the cached file is still fresh, i.e. it was cached within the TTL or the cache is used in offline mode.
+func (c *repositoryCache) Get(name string) ([]byte, bool, error) {
+	if !c.Enabled() {
+		return nil, false, nil
+	}
+
+	path := filepath.Join(c.path, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrapf(err, "failed to read %s from the cache", path)
+	}
+
+	content, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to read %s from the cache", path)
+	}
+	return content, c.offline || time.Since(info.ModTime()) < c.ttl, nil
+}
+
+// Set stores a file in the cache; it is a no-op if the cache is not enabled.
+func (c *repositoryCache) Set(name string, content []byte) error {
+	if !c.Enabled() {
+		return nil
+	}
+
+	path := filepath.Join(c.path, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return errors.Wrapf(err, "failed to create the cache folder for %s", path)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return errors.Wrapf(err, "failed to write %s to the cache", path)
+	}
+	return nil
+}
+
+// Exists returns true if any file has been cached for the provider.
+func (c *repositoryCache) Exists() bool {
+	_, err := os.Stat(c.path)
+	return err == nil
+}
+
+// Purge removes all the cached files for the provider.
+func (c *repositoryCache) Purge() error {
+	if err := os.RemoveAll(c.path); err != nil {
+		return errors.Wrapf(err, "failed to purge the cache folder %s", c.path)
+	}
+	return nil
+}
+
+// RefreshCache refreshes the cache of the given provider, if any, by removing all the cached files and
+// reading again the list of versions available in the provider repository.
+func RefreshCache(provider config.Provider, configVariablesClient config.VariablesClient) error {
+	cache, err := newRepositoryCache(provider, configVariablesClient)
+	if err != nil {
+		return err
+	}
+	if !cache.Exists() {
+		return nil
+	}
+	if cache.offline {
+		return errors.Errorf("failed to refresh the cache for the %s with name %s: the cache cannot be refreshed in offline mode", provider.Type(), provider.Name())
+	}
+
+	if err := cache.Purge(); err != nil {
+		return err
+	}
+
+	// NOTE: only remote repositories are cached, and GitHub is the only remote repository type supported.
+	repo, err := NewGitHubRepository(provider, configVariablesClient)
+	if err != nil {
+		return errors.Wrapf(err, "failed to refresh the cache for the %s with name %s", provider.Type(), provider.Name())
+	}
+	if _, err := repo.GetVersions(); err != nil {
+		return errors.Wrapf(err, "failed to refresh the cache for the %s with name %s", provider.Type(), provider.Name())
+	}
+	return nil
+}
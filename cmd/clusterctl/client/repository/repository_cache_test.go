@@ -0,0 +1,195 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v33/github"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/homedir"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func TestMain(m *testing.M) {
+	// Point the home folder to a temporary folder, so tests do not read or write the user's repository cache.
+	home, err := os.MkdirTemp("", "clusterctl-repository")
+	if err != nil {
+		panic(err)
+	}
+	if err := os.Setenv("HOME", home); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(home)
+	os.Exit(code)
+}
+
+func Test_gitHubRepository_cache(t *testing.T) {
+	client, mux, teardown := test.NewFakeGitHub()
+	defer teardown()
+
+	providerConfig := config.NewProvider("test", "https://github.com/o/r/releases/v0.4.1/file.yaml", clusterctlv1.CoreProviderType)
+
+	requests := 0
+	mux.HandleFunc("/repos/o/r/releases", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `[{"id":1, "tag_name": "v0.4.1"}]`)
+	})
+	mux.HandleFunc("/repos/o/r/releases/tags/v0.4.1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"id":13, "tag_name": "v0.4.1", "assets": [{"id": 1, "name": "file.yaml"}] }`)
+	})
+	mux.HandleFunc("/repos/o/r/releases/assets/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", "attachment; filename=file.yaml")
+		fmt.Fprint(w, "content")
+	})
+
+	t.Run("files are not cached by default", func(t *testing.T) {
+		g := NewWithT(t)
+		resetCaches()
+		requests = 0
+
+		gitHub, err := NewGitHubRepository(providerConfig, test.NewFakeVariableClient(), injectGithubClient(client))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(gitHub.GetVersions()).To(Equal([]string{"v0.4.1"}))
+		g.Expect(gitHub.GetFile("v0.4.1", "file.yaml")).To(Equal([]byte("content")))
+		g.Expect(requests).To(Equal(3))
+		g.Expect(filepath.Join(homedir.HomeDir(), config.ConfigFolder, cacheFolder)).ToNot(BeADirectory())
+	})
+
+	t.Run("files are read from the cache while they are fresh", func(t *testing.T) {
+		g := NewWithT(t)
+		resetCaches()
+		requests = 0
+
+		variables := test.NewFakeVariableClient().WithVar(config.CacheTTLVariable, "24h")
+		gitHub, err := NewGitHubRepository(providerConfig, variables, injectGithubClient(client))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(gitHub.GetVersions()).To(Equal([]string{"v0.4.1"}))
+		g.Expect(gitHub.GetFile("v0.4.1", "file.yaml")).To(Equal([]byte("content")))
+		g.Expect(requests).To(Equal(3))
+
+		// Drop the in-memory caches, so the next calls can only be served by the on-disk cache.
+		cacheVersions = map[string][]string{}
+		cacheFiles = map[string][]byte{}
+
+		gitHub, err = NewGitHubRepository(providerConfig, variables, injectGithubClient(client))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(gitHub.GetVersions()).To(Equal([]string{"v0.4.1"}))
+		g.Expect(gitHub.GetFile("v0.4.1", "file.yaml")).To(Equal([]byte("content")))
+		g.Expect(requests).To(Equal(3))
+	})
+
+	t.Run("expired files are read again from the repository", func(t *testing.T) {
+		g := NewWithT(t)
+		resetCaches()
+		requests = 0
+
+		variables := test.NewFakeVariableClient().WithVar(config.CacheTTLVariable, "1ns")
+		gitHub, err := NewGitHubRepository(providerConfig, variables, injectGithubClient(client))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(gitHub.GetFile("v0.4.1", "file.yaml")).To(Equal([]byte("content")))
+
+		cacheReleases = map[string]*github.RepositoryRelease{}
+		cacheFiles = map[string][]byte{}
+
+		g.Expect(gitHub.GetFile("v0.4.1", "file.yaml")).To(Equal([]byte("content")))
+		g.Expect(requests).To(Equal(4))
+	})
+
+	t.Run("expired files are used if the repository cannot be read", func(t *testing.T) {
+		g := NewWithT(t)
+		resetCaches()
+
+		variables := test.NewFakeVariableClient().WithVar(config.CacheTTLVariable, "1ns")
+		cache, err := newRepositoryCache(providerConfig, variables)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(cache.Set(filepath.Join("v0.4.2", "file.yaml"), []byte("cached content"))).To(Succeed())
+
+		gitHub, err := NewGitHubRepository(providerConfig, variables, injectGithubClient(client))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(gitHub.GetFile("v0.4.2", "file.yaml")).To(Equal([]byte("cached content")))
+	})
+
+	t.Run("only cached files are read in offline mode", func(t *testing.T) {
+		g := NewWithT(t)
+		resetCaches()
+		requests = 0
+
+		variables := test.NewFakeVariableClient().WithVar(config.OfflineVariable, "true")
+		cache, err := newRepositoryCache(providerConfig, variables)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(cache.Set(filepath.Join("v0.4.1", "file.yaml"), []byte("cached content"))).To(Succeed())
+		expired := time.Now().Add(-48 * time.Hour)
+		g.Expect(os.Chtimes(filepath.Join(cache.path, "v0.4.1", "file.yaml"), expired, expired)).To(Succeed())
+
+		gitHub, err := NewGitHubRepository(providerConfig, variables, injectGithubClient(client))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(gitHub.GetFile("v0.4.1", "file.yaml")).To(Equal([]byte("cached content")))
+
+		_, err = gitHub.GetFile("v0.4.1", "other-file.yaml")
+		g.Expect(err).To(HaveOccurred())
+		_, err = gitHub.GetVersions()
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(requests).To(Equal(0))
+	})
+}
+
+func TestRefreshCache(t *testing.T) {
+	providerConfig := config.NewProvider("test", "https://github.com/o/r/releases/v0.4.1/file.yaml", clusterctlv1.CoreProviderType)
+
+	t.Run("providers without a cache are skipped", func(t *testing.T) {
+		g := NewWithT(t)
+		resetCaches()
+
+		g.Expect(RefreshCache(providerConfig, test.NewFakeVariableClient())).To(Succeed())
+	})
+
+	t.Run("the cache can't be refreshed in offline mode", func(t *testing.T) {
+		g := NewWithT(t)
+		resetCaches()
+
+		variables := test.NewFakeVariableClient().WithVar(config.OfflineVariable, "true")
+		cache, err := newRepositoryCache(providerConfig, variables)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(cache.Set(versionsCacheFile, []byte("- v0.4.1"))).To(Succeed())
+
+		g.Expect(RefreshCache(providerConfig, variables)).ToNot(Succeed())
+		g.Expect(cache.Exists()).To(BeTrue())
+	})
+
+	t.Run("invalid cache settings are reported", func(t *testing.T) {
+		g := NewWithT(t)
+		resetCaches()
+
+		variables := test.NewFakeVariableClient().WithVar(config.CacheTTLVariable, "one day")
+		g.Expect(RefreshCache(providerConfig, variables)).ToNot(Succeed())
+
+		variables = test.NewFakeVariableClient().WithVar(config.CacheTTLVariable, "-1h")
+		g.Expect(RefreshCache(providerConfig, variables)).ToNot(Succeed())
+	})
+}
@@ -31,6 +31,8 @@ import (
 	"k8s.io/apimachinery/pkg/util/version"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -63,6 +65,7 @@ type gitHubRepository struct {
 	rootPath                 string
 	componentsPath           string
 	injectClient             *github.Client
+	cache                    *repositoryCache
 }
 
 var _ Repository = &gitHubRepository{}
@@ -101,6 +104,37 @@ func (g *gitHubRepository) ComponentsPath() string {
 
 // GetFile returns a file for a given provider version.
 func (g *gitHubRepository) GetFile(version, path string) ([]byte, error) {
+	log := logf.Log
+
+	cacheName := filepath.Join(version, path)
+	cached, fresh, err := g.cache.Get(cacheName)
+	if err != nil {
+		return nil, err
+	}
+	if fresh {
+		return cached, nil
+	}
+	if g.cache.offline {
+		return nil, errors.Errorf("failed to get file %q from GitHub release %s: the file is not cached and clusterctl is running in offline mode", path, version)
+	}
+
+	files, err := g.getFileFromRelease(version, path)
+	if err != nil {
+		if cached != nil {
+			log.V(1).Info("Using expired cached file", "File", path, "Version", version, "Error", err.Error())
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	if err := g.cache.Set(cacheName, files); err != nil {
+		log.V(1).Info("Failed to cache file", "File", path, "Version", version, "Error", err.Error())
+	}
+	return files, nil
+}
+
+// getFileFromRelease returns a file for a given provider version, reading it from the GitHub release.
+func (g *gitHubRepository) getFileFromRelease(version, path string) ([]byte, error) {
 	release, err := g.getReleaseByTag(version)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get GitHub release %s", version)
@@ -171,6 +205,11 @@ func NewGitHubRepository(providerConfig config.Provider, configVariablesClient c
 		repo.setClientToken(token)
 	}
 
+	repo.cache, err = newRepositoryCache(providerConfig, configVariablesClient)
+	if err != nil {
+		return nil, err
+	}
+
 	if defaultVersion == githubLatestReleaseLabel {
 		repo.defaultVersion, err = latestContractRelease(repo, clusterv1.GroupVersion.Version)
 		if err != nil {
@@ -208,11 +247,53 @@ func (g *gitHubRepository) setClientToken(token string) {
 
 // getVersions returns all the release versions for a github repository.
 func (g *gitHubRepository) getVersions() ([]string, error) {
+	log := logf.Log
+
 	cacheID := fmt.Sprintf("%s/%s", g.owner, g.repository)
 	if versions, ok := cacheVersions[cacheID]; ok {
 		return versions, nil
 	}
 
+	cached, fresh, err := g.cache.Get(versionsCacheFile)
+	if err != nil {
+		return nil, err
+	}
+	var cachedVersions []string
+	if cached != nil {
+		if err := yaml.Unmarshal(cached, &cachedVersions); err != nil {
+			return nil, errors.Wrap(err, "failed to read the list of releases from the cache")
+		}
+	}
+	if fresh {
+		cacheVersions[cacheID] = cachedVersions
+		return cachedVersions, nil
+	}
+	if g.cache.offline {
+		return nil, errors.New("failed to get the list of releases: the list is not cached and clusterctl is running in offline mode")
+	}
+
+	versions, err := g.getVersionsFromReleases()
+	if err != nil {
+		if cached != nil {
+			log.V(1).Info("Using expired cached list of releases", "Error", err.Error())
+			cacheVersions[cacheID] = cachedVersions
+			return cachedVersions, nil
+		}
+		return nil, err
+	}
+
+	if content, err := yaml.Marshal(versions); err == nil {
+		if err := g.cache.Set(versionsCacheFile, content); err != nil {
+			log.V(1).Info("Failed to cache the list of releases", "Error", err.Error())
+		}
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// getVersionsFromReleases returns all the release versions for a github repository, reading them from the GitHub releases.
+func (g *gitHubRepository) getVersionsFromReleases() ([]string, error) {
 	client := g.getClient()
 
 	// get all the releases
@@ -234,8 +315,6 @@ func (g *gitHubRepository) getVersions() ([]string, error) {
 		}
 		versions = append(versions, tagName)
 	}
-
-	cacheVersions[cacheID] = versions
 	return versions, nil
 }
 
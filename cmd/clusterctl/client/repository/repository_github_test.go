@@ -19,10 +19,13 @@ package repository
 import (
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-github/v33/github"
 	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/homedir"
 	"k8s.io/utils/pointer"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
@@ -56,6 +59,9 @@ func Test_githubRepository_newGitHubRepository(t *testing.T) {
 				rootPath:                 ".",
 				componentsPath:           "path",
 				injectClient:             nil,
+				cache: &repositoryCache{
+					path: filepath.Join(homedir.HomeDir(), config.ConfigFolder, cacheFolder, "test"),
+				},
 			},
 			wantErr: false,
 		},
@@ -717,4 +723,6 @@ func resetCaches() {
 	cacheVersions = map[string][]string{}
 	cacheReleases = map[string]*github.RepositoryRelease{}
 	cacheFiles = map[string][]byte{}
+	// NOTE: TestMain points the home folder to a temporary folder, so this is not removing the user's cache.
+	_ = os.RemoveAll(filepath.Join(homedir.HomeDir(), config.ConfigFolder, cacheFolder))
 }
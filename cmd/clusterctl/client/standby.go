@@ -0,0 +1,220 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// StandbySyncOptions carries the options supported by StandbySync.
+type StandbySyncOptions struct {
+	// FromKubeconfig defines the kubeconfig to use for accessing the primary management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	FromKubeconfig Kubeconfig
+
+	// ToKubeconfig defines the kubeconfig to use for accessing the standby management cluster.
+	ToKubeconfig Kubeconfig
+
+	// Namespace where the objects describing the workload clusters exist. If unspecified, the current
+	// namespace will be used.
+	Namespace string
+
+	// Interval defines how often the standby management cluster is synced; if greater than zero, the standby
+	// management cluster is continuously synced until Stop is closed, and sync failures are logged and retried
+	// at the next interval. If zero, the standby management cluster is synced once.
+	Interval time.Duration
+
+	// Stop allows to stop a continuous sync; if nil, a continuous sync never stops.
+	Stop <-chan struct{}
+}
+
+// StandbyPromoteOptions carries the options supported by StandbyPromote.
+type StandbyPromoteOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the standby management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// PrimaryKubeconfig defines the kubeconfig to use for accessing the former primary management cluster;
+	// if set, Clusters in the former primary management cluster are paused on a best-effort basis.
+	PrimaryKubeconfig Kubeconfig
+
+	// Namespace where the standby Clusters exist. If unspecified, the current namespace will be used.
+	Namespace string
+
+	// RepointKubeconfigPath defines the path of a kubeconfig file, e.g. the one used by users and tools for accessing
+	// the management cluster, to be re-pointed to the promoted management cluster; the context used for accessing the
+	// standby management cluster is added to the file and set as the current context. If empty, no kubeconfig file
+	// is re-pointed.
+	RepointKubeconfigPath string
+}
+
+func (c *clusterctlClient) StandbySync(options StandbySyncOptions) error {
+	// Get the client for interacting with the primary management cluster.
+	fromCluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.FromKubeconfig})
+	if err != nil {
+		return err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := fromCluster.ProviderInventory().CheckCAPIContract(); err != nil {
+		return err
+	}
+
+	// Ensures the custom resource definitions required by clusterctl are in place.
+	if err := fromCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
+		return err
+	}
+
+	// Get the client for interacting with the standby management cluster.
+	toCluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.ToKubeconfig})
+	if err != nil {
+		return err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := toCluster.ProviderInventory().CheckCAPIContract(); err != nil {
+		return err
+	}
+
+	// Ensures the custom resource definitions required by clusterctl are in place
+	if err := toCluster.ProviderInventory().EnsureCustomResourceDefinitions(); err != nil {
+		return err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.Namespace == "" {
+		currentNamespace, err := fromCluster.Proxy().CurrentNamespace()
+		if err != nil {
+			return err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	if options.Interval <= 0 {
+		return fromCluster.ObjectMover().Sync(options.Namespace, toCluster)
+	}
+
+	// When syncing continuously, errors are logged and the sync is retried at the next interval,
+	// so temporary failures of one of the management clusters do not stop replication.
+	log := logf.Log
+	stop := options.Stop
+	if stop == nil {
+		stop = wait.NeverStop
+	}
+	wait.Until(func() {
+		if err := fromCluster.ObjectMover().Sync(options.Namespace, toCluster); err != nil {
+			log.Error(err, "Failed to sync the standby management cluster")
+		}
+	}, options.Interval, stop)
+	return nil
+}
+
+func (c *clusterctlClient) StandbyPromote(options StandbyPromoteOptions) error {
+	// Get the client for interacting with the standby management cluster.
+	standbyCluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := standbyCluster.ProviderInventory().CheckCAPIContract(); err != nil {
+		return err
+	}
+
+	// Get the client for interacting with the former primary management cluster, if any.
+	// NOTE: the former primary management cluster is usually not reachable, so no checks are run on it.
+	var primaryCluster cluster.Client
+	if options.PrimaryKubeconfig.Path != "" {
+		primaryCluster, err = c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.PrimaryKubeconfig})
+		if err != nil {
+			return err
+		}
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.Namespace == "" {
+		currentNamespace, err := standbyCluster.Proxy().CurrentNamespace()
+		if err != nil {
+			return err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	if err := standbyCluster.ObjectMover().Promote(options.Namespace, primaryCluster); err != nil {
+		return err
+	}
+
+	if options.RepointKubeconfigPath == "" {
+		return nil
+	}
+	return repointKubeconfig(options.Kubeconfig, options.RepointKubeconfigPath)
+}
+
+// repointKubeconfig adds the context used for accessing the management cluster to the kubeconfig file at path,
+// creating it if it does not exist, and sets it as the current context.
+func repointKubeconfig(kubeconfig Kubeconfig, path string) error {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig.Path != "" {
+		rules.ExplicitPath = kubeconfig.Path
+	}
+	source, err := rules.Load()
+	if err != nil {
+		return errors.Wrap(err, "failed to load the kubeconfig of the promoted management cluster")
+	}
+
+	contextName := source.CurrentContext
+	if kubeconfig.Context != "" {
+		contextName = kubeconfig.Context
+	}
+	context, ok := source.Contexts[contextName]
+	if !ok {
+		return errors.Errorf("failed to get context %q from the kubeconfig of the promoted management cluster", contextName)
+	}
+	cluster, ok := source.Clusters[context.Cluster]
+	if !ok {
+		return errors.Errorf("failed to get cluster %q from the kubeconfig of the promoted management cluster", context.Cluster)
+	}
+	authInfo, ok := source.AuthInfos[context.AuthInfo]
+	if !ok {
+		return errors.Errorf("failed to get user %q from the kubeconfig of the promoted management cluster", context.AuthInfo)
+	}
+
+	target := clientcmdapi.NewConfig()
+	if _, err := os.Stat(path); err == nil {
+		if target, err = clientcmd.LoadFromFile(path); err != nil {
+			return errors.Wrapf(err, "failed to load kubeconfig %q", path)
+		}
+	}
+	target.Clusters[context.Cluster] = cluster
+	target.AuthInfos[context.AuthInfo] = authInfo
+	target.Contexts[contextName] = context
+	target.CurrentContext = contextName
+
+	if err := clientcmd.WriteToFile(*target, path); err != nil {
+		return errors.Wrapf(err, "failed to write kubeconfig %q", path)
+	}
+	logf.Log.Info("Kubeconfig re-pointed to the promoted management cluster", "Path", path, "Context", contextName)
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+func Test_repointKubeconfig(t *testing.T) {
+	newKubeconfig := func(name, server string) *clientcmdapi.Config {
+		config := clientcmdapi.NewConfig()
+		config.Clusters[name] = &clientcmdapi.Cluster{Server: server}
+		config.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: name}
+		config.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+		config.CurrentContext = name
+		return config
+	}
+
+	t.Run("adds the context of the promoted management cluster and sets it as current context", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		standbyPath := filepath.Join(dir, "standby")
+		g.Expect(clientcmd.WriteToFile(*newKubeconfig("standby", "https://standby:6443"), standbyPath)).To(Succeed())
+		path := filepath.Join(dir, "config")
+		g.Expect(clientcmd.WriteToFile(*newKubeconfig("primary", "https://primary:6443"), path)).To(Succeed())
+
+		g.Expect(repointKubeconfig(Kubeconfig{Path: standbyPath}, path)).To(Succeed())
+
+		got, err := clientcmd.LoadFromFile(path)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got.CurrentContext).To(Equal("standby"))
+		g.Expect(got.Clusters["standby"].Server).To(Equal("https://standby:6443"))
+		g.Expect(got.Contexts).To(HaveKey("primary"))
+	})
+
+	t.Run("creates the kubeconfig file if it does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		standbyPath := filepath.Join(dir, "standby")
+		g.Expect(clientcmd.WriteToFile(*newKubeconfig("standby", "https://standby:6443"), standbyPath)).To(Succeed())
+		path := filepath.Join(dir, "config")
+
+		g.Expect(repointKubeconfig(Kubeconfig{Path: standbyPath, Context: "standby"}, path)).To(Succeed())
+
+		_, err := os.Stat(path)
+		g.Expect(err).ToNot(HaveOccurred())
+		got, err := clientcmd.LoadFromFile(path)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got.CurrentContext).To(Equal("standby"))
+	})
+
+	t.Run("fails if the context does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		dir := t.TempDir()
+		standbyPath := filepath.Join(dir, "standby")
+		g.Expect(clientcmd.WriteToFile(*newKubeconfig("standby", "https://standby:6443"), standbyPath)).To(Succeed())
+
+		g.Expect(repointKubeconfig(Kubeconfig{Path: standbyPath, Context: "foo"}, filepath.Join(dir, "config"))).ToNot(Succeed())
+	})
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// TopologyPlanOptions carries the options supported by TopologyPlan.
+type TopologyPlanOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Objs are the modified Cluster, ClusterClass and template objects to plan the changes for.
+	Objs []*unstructured.Unstructured
+
+	// Namespace is the namespace used for the input objects without a namespace. If empty,
+	// the current namespace is used, or the default namespace when running offline.
+	Namespace string
+
+	// Offline, if true, plans the changes using only the input objects, without
+	// reading the state of the management cluster.
+	Offline bool
+}
+
+func (c *clusterctlClient) TopologyPlan(options TopologyPlanOptions) (*cluster.TopologyPlanOutput, error) {
+	// Get the client for interacting with the management cluster.
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	if !options.Offline {
+		// Ensure this command only runs against management clusters with the current Cluster API contract.
+		if err := clusterClient.ProviderInventory().CheckCAPIContract(); err != nil {
+			return nil, err
+		}
+	}
+
+	return clusterClient.Topology().Plan(&cluster.TopologyPlanInput{
+		Objs:            options.Objs,
+		TargetNamespace: options.Namespace,
+		Offline:         options.Offline,
+	})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"sort"
+	"strings"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ObjectNode is a serializable representation of an object in the ObjectTree,
+// designed to be consumed by scripts and dashboards.
+type ObjectNode struct {
+	// Kind of the object, or of the objects in the group in case of group objects.
+	Kind string `json:"kind"`
+
+	// APIVersion of the object; it is empty for virtual objects.
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// Namespace of the object.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the object; it is empty for group objects.
+	Name string `json:"name,omitempty"`
+
+	// MetaName is the meta name used for the object in the presentation layer, e.g. ControlPlane.
+	MetaName string `json:"metaName,omitempty"`
+
+	// Virtual is true if the object does not correspond to any real object, e.g. Workers.
+	Virtual bool `json:"virtual,omitempty"`
+
+	// GroupItems contains the names of the objects represented by a group object.
+	GroupItems []string `json:"groupItems,omitempty"`
+
+	// Deleting is true if the object is being deleted.
+	Deleting bool `json:"deleting,omitempty"`
+
+	// Ready is the ready condition of the object, if any.
+	Ready *clusterv1.Condition `json:"ready,omitempty"`
+
+	// Conditions are all the conditions of the object except the ready condition;
+	// they are reported only for the objects selected by ObjectTreeOptions.ShowOtherConditions.
+	Conditions []clusterv1.Condition `json:"conditions,omitempty"`
+
+	// Children are the dependant objects, sorted by kind and name.
+	Children []*ObjectNode `json:"children,omitempty"`
+}
+
+// ToObjectNode returns a serializable representation of the object tree, starting from the root.
+func (od ObjectTree) ToObjectNode() *ObjectNode {
+	return od.toObjectNode(od.root)
+}
+
+func (od ObjectTree) toObjectNode(obj client.Object) *ObjectNode {
+	node := &ObjectNode{
+		Kind:      obj.GetObjectKind().GroupVersionKind().Kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		MetaName:  GetMetaName(obj),
+		Virtual:   IsVirtualObject(obj),
+		Deleting:  !obj.GetDeletionTimestamp().IsZero(),
+		Ready:     GetReadyCondition(obj),
+	}
+
+	if !node.Virtual {
+		node.APIVersion = obj.GetObjectKind().GroupVersionKind().GroupVersion().String()
+	}
+
+	if IsGroupObject(obj) {
+		node.Kind = strings.TrimSuffix(node.Kind, "Group")
+		node.Name = ""
+		node.GroupItems = strings.Split(GetGroupItems(obj), GroupItemsSeparator)
+	}
+
+	if IsShowConditionsObject(obj) {
+		for _, c := range GetOtherConditions(obj) {
+			node.Conditions = append(node.Conditions, *c)
+		}
+	}
+
+	for _, child := range od.GetObjectsByParent(obj.GetUID()) {
+		node.Children = append(node.Children, od.toObjectNode(child))
+	}
+	sort.Slice(node.Children, func(i, j int) bool {
+		if node.Children[i].Kind != node.Children[j].Kind {
+			return node.Children[i].Kind < node.Children[j].Kind
+		}
+		if node.Children[i].Name != node.Children[j].Name {
+			return node.Children[i].Name < node.Children[j].Name
+		}
+		return strings.Join(node.Children[i].GroupItems, GroupItemsSeparator) < strings.Join(node.Children[j].GroupItems, GroupItemsSeparator)
+	})
+
+	return node
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func Test_ToObjectNode(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := fakeCluster("my-cluster",
+		withClusterCondition(conditions.TrueCondition(clusterv1.ReadyCondition)),
+		withClusterCondition(conditions.FalseCondition("Other", "Reason", clusterv1.ConditionSeverityInfo, "message")),
+	)
+	tree := NewObjectTree(cluster, ObjectTreeOptions{ShowOtherConditions: "Cluster"})
+
+	workers := VirtualObject("ns", "WorkerGroup", "Workers")
+	addAnnotation(workers, GroupingObjectAnnotation, "True")
+	tree.Add(cluster, workers)
+
+	tree.Add(workers, fakeMachine("machine-2", withMachineCondition(conditions.TrueCondition(clusterv1.ReadyCondition))))
+	tree.Add(workers, fakeMachine("machine-1", withMachineCondition(conditions.TrueCondition(clusterv1.ReadyCondition))))
+	tree.Add(workers, fakeMachine("machine-3", withMachineCondition(conditions.FalseCondition(clusterv1.ReadyCondition, "Reason", clusterv1.ConditionSeverityWarning, ""))))
+	tree.Add(cluster, fakeMachine("cp-machine"), ObjectMetaName("ControlPlane"))
+
+	node := tree.ToObjectNode()
+
+	g.Expect(node.Kind).To(Equal("Cluster"))
+	g.Expect(node.Name).To(Equal("my-cluster"))
+	g.Expect(node.Ready).ToNot(BeNil())
+	g.Expect(node.Ready.Status).To(BeEquivalentTo("True"))
+	g.Expect(node.Conditions).To(HaveLen(1))
+	g.Expect(node.Conditions[0].Type).To(BeEquivalentTo("Other"))
+
+	// Children are sorted by kind and name.
+	g.Expect(node.Children).To(HaveLen(2))
+	g.Expect(node.Children[0].Kind).To(Equal("Machine"))
+	g.Expect(node.Children[0].Name).To(Equal("cp-machine"))
+	g.Expect(node.Children[0].MetaName).To(Equal("ControlPlane"))
+	g.Expect(node.Children[0].Conditions).To(BeEmpty())
+
+	workersNode := node.Children[1]
+	g.Expect(workersNode.Name).To(Equal("Workers"))
+	g.Expect(workersNode.Virtual).To(BeTrue())
+	g.Expect(workersNode.APIVersion).To(BeEmpty())
+	g.Expect(workersNode.Children).To(HaveLen(2))
+
+	// Group objects report the kind and the names of the grouped objects.
+	g.Expect(workersNode.Children[0].Kind).To(Equal("Machine"))
+	g.Expect(workersNode.Children[0].Name).To(BeEmpty())
+	g.Expect(workersNode.Children[0].GroupItems).To(Equal([]string{"machine-1", "machine-2"}))
+	g.Expect(workersNode.Children[1].Name).To(Equal("machine-3"))
+	g.Expect(workersNode.Children[1].GroupItems).To(BeEmpty())
+}
@@ -29,6 +29,10 @@ var alphaCmd = &cobra.Command{
 func init() {
 	// Alpha commands should be added here.
 	alphaCmd.AddCommand(rolloutCmd)
+	alphaCmd.AddCommand(fleetCmd)
+	alphaCmd.AddCommand(standbyCmd)
+	alphaCmd.AddCommand(crdMigrateCmd)
+	alphaCmd.AddCommand(topologyCmd)
 
 	RootCmd.AddCommand(alphaCmd)
 }
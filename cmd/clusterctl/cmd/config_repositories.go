@@ -42,7 +42,8 @@ var (
 )
 
 type configRepositoriesOptions struct {
-	output string
+	output  string
+	refresh bool
 }
 
 var cro = &configRepositoriesOptions{}
@@ -55,14 +56,20 @@ var configRepositoryCmd = &cobra.Command{
 		Display the list of providers and their repository configurations.
 
 		clusterctl ships with a list of known providers; if necessary, edit
-		$HOME/.cluster-api/clusterctl.yaml file to add new provider or to customize existing ones.`),
+		$HOME/.cluster-api/clusterctl.yaml file to add new provider or to customize existing ones.
+
+		If a cache TTL is configured, the files read from remote provider repositories are cached in $HOME/.cluster-api/cache;
+		use --refresh to read again the providers already in the cache from their repositories.`),
 
 	Example: Examples(`
 		# Displays the list of available providers.
 		clusterctl config repositories
 
 		# Print the list of available providers in yaml format.
-		clusterctl config repositories -o yaml`),
+		clusterctl config repositories -o yaml
+
+		# Refresh the cache of the provider repositories and display the list of available providers.
+		clusterctl config repositories --refresh`),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runGetRepositories(cfgFile, os.Stdout)
@@ -72,6 +79,8 @@ var configRepositoryCmd = &cobra.Command{
 func init() {
 	configRepositoryCmd.Flags().StringVarP(&cro.output, "output", "o", RepositoriesOutputText,
 		fmt.Sprintf("Output format. Valid values: %v.", RepositoriesOutputs))
+	configRepositoryCmd.Flags().BoolVar(&cro.refresh, "refresh", false,
+		"Refresh the cache of the provider repositories before displaying the list of providers.")
 	configCmd.AddCommand(configRepositoryCmd)
 }
 
@@ -89,6 +98,12 @@ func runGetRepositories(cfgFile string, out io.Writer) error {
 		return err
 	}
 
+	if cro.refresh {
+		if err := c.RefreshProvidersCache(); err != nil {
+			return err
+		}
+	}
+
 	repositoryList, err := c.GetProvidersConfig()
 	if err != nil {
 		return err
@@ -0,0 +1,103 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+type crdMigrateOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	dryRun            bool
+}
+
+var cmo = &crdMigrateOptions{}
+
+var crdMigrateCmd = &cobra.Command{
+	Use:   "crd-migrate",
+	Short: "Migrate the objects of the provider CRDs to the storage version",
+	Long: LongDesc(`
+		Migrate the objects of the provider CRDs to the storage version.
+
+		All the objects of each provider CRD are rewritten, so the API server stores them using the
+		current storage version; then the CRD status.storedVersions is updated to list only the storage
+		version, and it is verified no objects remain stored in other versions.
+
+		This is required before upgrading to a provider release that drops an old API version.`),
+
+	Example: Examples(`
+		# Migrate the objects of the provider CRDs to the storage version.
+		clusterctl alpha crd-migrate
+
+		# List the provider CRDs requiring migration, without changing the management cluster.
+		clusterctl alpha crd-migrate --dry-run`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCRDMigrate(os.Stdout)
+	},
+}
+
+func init() {
+	crdMigrateCmd.Flags().StringVar(&cmo.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	crdMigrateCmd.Flags().StringVar(&cmo.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	crdMigrateCmd.Flags().BoolVar(&cmo.dryRun, "dry-run", false,
+		"List the provider CRDs requiring migration, without changing the management cluster.")
+}
+
+func runCRDMigrate(out io.Writer) error {
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	results, err := c.CRDMigrate(client.CRDMigrateOptions{
+		Kubeconfig: client.Kubeconfig{Path: cmo.kubeconfig, Context: cmo.kubeconfigContext},
+		DryRun:     cmo.dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printCRDMigrationResults(out, results, cmo.dryRun)
+}
+
+func printCRDMigrationResults(out io.Writer, results []cluster.CRDMigrationResult, dryRun bool) error {
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "CRD\tSTORAGE VERSION\tSTORED VERSIONS\tSTATUS")
+	for _, r := range results {
+		status := "Up to date"
+		switch {
+		case r.Migrated && dryRun:
+			status = "Migration required"
+		case r.Migrated:
+			status = fmt.Sprintf("Migrated %d objects", r.MigratedObjects)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.CRD, r.StorageVersion, strings.Join(r.StoredVersions, ","), status)
+	}
+	return w.Flush()
+}
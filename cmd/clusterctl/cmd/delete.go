@@ -31,6 +31,7 @@ type deleteOptions struct {
 	infrastructureProviders []string
 	includeNamespace        bool
 	includeCRDs             bool
+	orphanPolicy            string
 	deleteAll               bool
 }
 
@@ -66,6 +67,11 @@ var deleteCmd = &cobra.Command{
 		# the AWS infrastructure provider are orphaned and there might be ongoing costs incurred as a result of this.
 		clusterctl delete --infrastructure aws --include-crd
 
+		# Delete the AWS infrastructure provider and related CRDs, deleting all the related objects (e.g. AWSClusters,
+		# AWSMachines etc.) first, while the provider is still running, so the corresponding resources on AWS are cleaned up.
+		# Please note that by default such objects are only reported, and they are orphaned.
+		clusterctl delete --infrastructure aws --include-crd --orphan-policy cascade
+
 		# Delete the AWS infrastructure provider and its hosting Namespace. Please note that this forces deletion of
 		# all objects existing in the namespace.
 		# Important! As a consequence of this operation, all the corresponding resources managed by
@@ -92,6 +98,8 @@ func init() {
 		"Forces the deletion of the namespace where the providers are hosted (and of all the contained objects)")
 	deleteCmd.Flags().BoolVar(&dd.includeCRDs, "include-crd", false,
 		"Forces the deletion of the provider's CRDs (and of all the related objects)")
+	deleteCmd.Flags().StringVar(&dd.orphanPolicy, "orphan-policy", string(client.OrphanPolicyIgnore),
+		"Defines how to deal with the objects of the Kinds defined by the providers being deleted when using --include-crd or --include-namespace. One of block, cascade or ignore")
 
 	deleteCmd.Flags().StringVar(&dd.coreProvider, "core", "",
 		"Core provider version (e.g. cluster-api:v0.3.0) to delete from the management cluster")
@@ -127,10 +135,17 @@ func runDelete() error {
 		return errors.New("At least one of --core, --bootstrap, --control-plane, --infrastructure should be specified or the --all flag should be set")
 	}
 
+	switch client.OrphanPolicy(dd.orphanPolicy) {
+	case client.OrphanPolicyBlock, client.OrphanPolicyCascade, client.OrphanPolicyIgnore:
+	default:
+		return errors.Errorf("Invalid value for --orphan-policy %q: it should be one of block, cascade or ignore", dd.orphanPolicy)
+	}
+
 	return c.Delete(client.DeleteOptions{
 		Kubeconfig:              client.Kubeconfig{Path: dd.kubeconfig, Context: dd.kubeconfigContext},
 		IncludeNamespace:        dd.includeNamespace,
 		IncludeCRDs:             dd.includeCRDs,
+		OrphanPolicy:            client.OrphanPolicy(dd.orphanPolicy),
 		CoreProvider:            dd.coreProvider,
 		BootstrapProviders:      dd.bootstrapProviders,
 		InfrastructureProviders: dd.infrastructureProviders,
@@ -22,8 +22,8 @@ import (
 
 var describeCmd = &cobra.Command{
 	Use:   "describe",
-	Short: "Describe workload clusters.",
-	Long:  `Describe the status of workload clusters.`,
+	Short: "Describe workload clusters and providers.",
+	Long:  `Describe the status of workload clusters and the providers installed in a management cluster.`,
 }
 
 func init() {
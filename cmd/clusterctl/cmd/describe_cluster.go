@@ -17,7 +17,10 @@ limitations under the License.
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -25,6 +28,7 @@ import (
 	"github.com/fatih/color"
 	"github.com/gobuffalo/flect"
 	"github.com/gosuri/uitable"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
@@ -32,6 +36,7 @@ import (
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 )
 
 const (
@@ -50,6 +55,20 @@ var (
 	cyan   = color.New(color.FgCyan)
 )
 
+const (
+	// DescribeClusterOutputTree is an option used to print the cluster as a tree view.
+	DescribeClusterOutputTree = "tree"
+	// DescribeClusterOutputJSON is an option used to print the cluster object tree in json format.
+	DescribeClusterOutputJSON = "json"
+	// DescribeClusterOutputYaml is an option used to print the cluster object tree in yaml format.
+	DescribeClusterOutputYaml = "yaml"
+)
+
+var (
+	// DescribeClusterOutputs is a list of valid describe cluster outputs.
+	DescribeClusterOutputs = []string{DescribeClusterOutputTree, DescribeClusterOutputJSON, DescribeClusterOutputYaml}
+)
+
 type describeClusterOptions struct {
 	kubeconfig        string
 	kubeconfigContext string
@@ -58,7 +77,9 @@ type describeClusterOptions struct {
 	showOtherConditions string
 	showMachineSets     bool
 	disableNoEcho       bool
+	grouping            bool
 	disableGrouping     bool
+	output              string
 }
 
 var dc = &describeClusterOptions{}
@@ -83,11 +104,15 @@ var describeClusterClusterCmd = &cobra.Command{
 
 		# Describe the cluster named test-1 disabling automatic grouping of objects with the same ready condition
 		# e.g. un-group all the machines with Ready=true instead of showing a single group node.
-		clusterctl describe cluster test-1 --disable-grouping
+		clusterctl describe cluster test-1 --grouping=false
 
 		# Describe the cluster named test-1 disabling automatic echo suppression
         # e.g. show the infrastructure machine objects, no matter if the current state is already reported by the machine's Ready condition.
-		clusterctl describe cluster test-1`),
+		clusterctl describe cluster test-1 --disable-no-echo
+
+		# Print the object tree of the cluster named test-1, including all the conditions for the Machine objects, in json format
+		# e.g. to feed dashboards or scripts.
+		clusterctl describe cluster test-1 --show-conditions Machine -o json`),
 
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -110,8 +135,15 @@ func init() {
 
 	describeClusterClusterCmd.Flags().BoolVar(&dc.disableNoEcho, "disable-no-echo", false, ""+
 		"Disable hiding of a MachineInfrastructure and BootstrapConfig when ready condition is true or it has the Status, Severity and Reason of the machine's object.")
+	describeClusterClusterCmd.Flags().BoolVar(&dc.grouping, "grouping", true,
+		"Groups machines when ready condition has the same Status, Severity and Reason.")
 	describeClusterClusterCmd.Flags().BoolVar(&dc.disableGrouping, "disable-grouping", false,
 		"Disable grouping machines when ready condition has the same Status, Severity and Reason.")
+	_ = describeClusterClusterCmd.Flags().MarkDeprecated("disable-grouping",
+		"use --grouping instead.")
+
+	describeClusterClusterCmd.Flags().StringVarP(&dc.output, "output", "o", DescribeClusterOutputTree,
+		fmt.Sprintf("Output format. Valid values: %v.", DescribeClusterOutputs))
 
 	// completions
 	describeClusterClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
@@ -126,6 +158,10 @@ func init() {
 }
 
 func runDescribeCluster(name string) error {
+	if dc.output != DescribeClusterOutputTree && dc.output != DescribeClusterOutputJSON && dc.output != DescribeClusterOutputYaml {
+		return errors.Errorf("invalid output format %q. Valid values: %v", dc.output, DescribeClusterOutputs)
+	}
+
 	c, err := client.New(cfgFile)
 	if err != nil {
 		return err
@@ -138,16 +174,43 @@ func runDescribeCluster(name string) error {
 		ShowOtherConditions: dc.showOtherConditions,
 		ShowMachineSets:     dc.showMachineSets,
 		DisableNoEcho:       dc.disableNoEcho,
-		DisableGrouping:     dc.disableGrouping,
+		DisableGrouping:     dc.disableGrouping || !dc.grouping,
 	})
 	if err != nil {
 		return err
 	}
 
+	switch dc.output {
+	case DescribeClusterOutputJSON:
+		return printObjectTreeJSON(os.Stdout, tree)
+	case DescribeClusterOutputYaml:
+		return printObjectTreeYaml(os.Stdout, tree)
+	}
+
 	printObjectTree(tree)
 	return nil
 }
 
+// printObjectTreeJSON prints the cluster object tree in json format.
+func printObjectTreeJSON(w io.Writer, tree *tree.ObjectTree) error {
+	out, err := json.MarshalIndent(tree.ToObjectNode(), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the object tree to json")
+	}
+	_, err = fmt.Fprintln(w, string(out))
+	return err
+}
+
+// printObjectTreeYaml prints the cluster object tree in yaml format.
+func printObjectTreeYaml(w io.Writer, tree *tree.ObjectTree) error {
+	out, err := yaml.Marshal(tree.ToObjectNode())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the object tree to yaml")
+	}
+	_, err = fmt.Fprint(w, string(out))
+	return err
+}
+
 // printObjectTree prints the cluster status to stdout.
 func printObjectTree(tree *tree.ObjectTree) {
 	// Creates the output table
@@ -0,0 +1,156 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DescribeProvidersOutputText is an option used to print the providers as a table.
+	DescribeProvidersOutputText = "text"
+	// DescribeProvidersOutputJSON is an option used to print the providers in json format.
+	DescribeProvidersOutputJSON = "json"
+	// DescribeProvidersOutputYaml is an option used to print the providers in yaml format.
+	DescribeProvidersOutputYaml = "yaml"
+)
+
+var (
+	// DescribeProvidersOutputs is a list of valid describe providers outputs.
+	DescribeProvidersOutputs = []string{DescribeProvidersOutputText, DescribeProvidersOutputJSON, DescribeProvidersOutputYaml}
+)
+
+type describeProvidersOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	output            string
+}
+
+var dp = &describeProvidersOptions{}
+
+var describeProvidersCmd = &cobra.Command{
+	Use:   "providers",
+	Args:  cobra.NoArgs,
+	Short: "Describe the providers installed in a management cluster",
+	Long: LongDesc(`
+		Describe the providers installed in a management cluster.
+
+		For each provider, the version, the namespace where it is installed, the namespace it watches,
+		the API Version of Cluster API (contract) it supports and the versions available for upgrading it,
+		for the current and the next contract, are provided.`),
+
+	Example: Examples(`
+		# Describe the providers installed in the management cluster.
+		clusterctl describe providers
+
+		# Describe the providers installed in the management cluster in json format.
+		clusterctl describe providers -o json`),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDescribeProviders(os.Stdout)
+	},
+}
+
+func init() {
+	describeProvidersCmd.Flags().StringVar(&dp.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If empty, default discovery rules apply.")
+	describeProvidersCmd.Flags().StringVar(&dp.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	describeProvidersCmd.Flags().StringVarP(&dp.output, "output", "o", DescribeProvidersOutputText,
+		fmt.Sprintf("Output format. Valid values: %v.", DescribeProvidersOutputs))
+
+	describeCmd.AddCommand(describeProvidersCmd)
+}
+
+func runDescribeProviders(out io.Writer) error {
+	if dp.output != DescribeProvidersOutputText && dp.output != DescribeProvidersOutputJSON && dp.output != DescribeProvidersOutputYaml {
+		return errors.Errorf("invalid output format %q. Valid values: %v", dp.output, DescribeProvidersOutputs)
+	}
+
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	providers, err := c.DescribeProviders(client.DescribeProvidersOptions{
+		Kubeconfig: client.Kubeconfig{Path: dp.kubeconfig, Context: dp.kubeconfigContext},
+	})
+	if err != nil {
+		return err
+	}
+
+	return printProviders(out, providers, dp.output)
+}
+
+func printProviders(out io.Writer, providers []client.ProviderDescription, output string) error {
+	switch output {
+	case DescribeProvidersOutputJSON:
+		j, err := json.MarshalIndent(providers, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(j))
+		return nil
+	case DescribeProvidersOutputYaml:
+		y, err := yaml.Marshal(providers)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(y))
+		return nil
+	}
+
+	if len(providers) == 0 {
+		fmt.Fprintln(out, "There are no providers in the cluster. Please use clusterctl init to initialize a Cluster API management cluster.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tNAMESPACE\tTYPE\tVERSION\tCONTRACT\tWATCHED NAMESPACE\tUPGRADES")
+	for _, p := range providers {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", p.Name, p.Namespace, p.Type, p.Version, p.Contract, prettifyWatchedNamespace(p.WatchedNamespace), prettifyUpgrades(p.Upgrades))
+	}
+	return w.Flush()
+}
+
+func prettifyWatchedNamespace(namespace string) string {
+	if namespace == "" {
+		return "(all)"
+	}
+	return namespace
+}
+
+func prettifyUpgrades(upgrades []client.ProviderUpgrade) string {
+	if len(upgrades) == 0 {
+		return "Already up to date"
+	}
+	res := make([]string, 0, len(upgrades))
+	for _, u := range upgrades {
+		res = append(res, fmt.Sprintf("%s (%s)", u.Version, u.Contract))
+	}
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+func Test_printProviders(t *testing.T) {
+	g := NewWithT(t)
+
+	providers := []client.ProviderDescription{
+		{
+			Name:      "cluster-api",
+			Type:      "CoreProvider",
+			Namespace: "capi-system",
+			Version:   "v1.0.0",
+			Contract:  "v1beta1",
+			Upgrades:  []client.ProviderUpgrade{{Contract: "v1beta1", Version: "v1.0.1"}},
+		},
+		{
+			Name:             "docker",
+			Type:             "InfrastructureProvider",
+			Namespace:        "capd-system",
+			Version:          "v1.0.1",
+			WatchedNamespace: "foo",
+			Contract:         "v1beta1",
+		},
+	}
+
+	out := &bytes.Buffer{}
+	g.Expect(printProviders(out, providers, DescribeProvidersOutputText)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("WATCHED NAMESPACE"))
+	g.Expect(out.String()).To(MatchRegexp(`cluster-api\s+capi-system\s+CoreProvider\s+v1.0.0\s+v1beta1\s+\(all\)\s+v1.0.1 \(v1beta1\)`))
+	g.Expect(out.String()).To(MatchRegexp(`docker\s+capd-system\s+InfrastructureProvider\s+v1.0.1\s+v1beta1\s+foo\s+Already up to date`))
+
+	out.Reset()
+	g.Expect(printProviders(out, providers, DescribeProvidersOutputJSON)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring(`"watchedNamespace": "foo"`))
+	g.Expect(out.String()).To(ContainSubstring(`"upgrades": [`))
+}
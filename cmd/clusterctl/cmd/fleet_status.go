@@ -0,0 +1,189 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// FleetStatusOutputText is an option used to print the fleet status as a table.
+	FleetStatusOutputText = "text"
+	// FleetStatusOutputJSON is an option used to print the fleet status in json format.
+	FleetStatusOutputJSON = "json"
+	// FleetStatusOutputYaml is an option used to print the fleet status in yaml format.
+	FleetStatusOutputYaml = "yaml"
+)
+
+const (
+	// FleetStatusWarnExitCode is the exit code used when some objects in the fleet raise warnings, but none of them fails.
+	FleetStatusWarnExitCode = 2
+	// FleetStatusFailExitCode is the exit code used when at least one object in the fleet fails the checks.
+	FleetStatusFailExitCode = 3
+)
+
+var (
+	// FleetStatusOutputs is a list of valid fleet status outputs.
+	FleetStatusOutputs = []string{FleetStatusOutputText, FleetStatusOutputJSON, FleetStatusOutputYaml}
+)
+
+type fleetStatusOptions struct {
+	kubeconfig              string
+	kubeconfigContext       string
+	namespace               string
+	conditions              []string
+	checkControlPlanes      bool
+	checkMachineDeployments bool
+	output                  string
+}
+
+var fso = &fleetStatusOptions{}
+
+var fleetStatusCmd = &cobra.Command{
+	Use:   "status",
+	Args:  cobra.NoArgs,
+	Short: "Summarize the health of all the workload clusters",
+	Long: LongDesc(`
+		Summarize the health of all the workload clusters in a management cluster.
+
+		A configurable set of conditions is evaluated on each Cluster and, optionally, on the
+		control plane and on the MachineDeployments of each Cluster. An object passes the checks
+		if all the conditions are true; it fails if any condition is false with severity Error;
+		otherwise it raises a warning, e.g. when it is still provisioning.
+
+		The command exits with code 0 if all the objects pass the checks, with code 2 if some
+		objects raise warnings and with code 3 if at least one object fails, thus allowing to
+		use it as a health gate in CI jobs or cron jobs.`),
+
+	Example: Examples(`
+		# Summarize the health of all the workload clusters.
+		clusterctl alpha fleet status
+
+		# Summarize the health of the workload clusters in the foo namespace, including their control planes and MachineDeployments.
+		clusterctl alpha fleet status -n foo --control-planes --machine-deployments
+
+		# Evaluate only the Ready and the InfrastructureReady conditions of each Cluster.
+		clusterctl alpha fleet status --conditions Ready,InfrastructureReady
+
+		# Print the result of the evaluation in json format.
+		clusterctl alpha fleet status -o json`),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFleetStatus(os.Stdout)
+	},
+}
+
+func init() {
+	fleetStatusCmd.Flags().StringVar(&fso.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	fleetStatusCmd.Flags().StringVar(&fso.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	fleetStatusCmd.Flags().StringVarP(&fso.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are located. If unspecified, clusters in all the namespaces are evaluated.")
+	fleetStatusCmd.Flags().StringSliceVar(&fso.conditions, "conditions", nil,
+		fmt.Sprintf("The list of conditions to evaluate on each Cluster. If unspecified, %v are evaluated.", alpha.DefaultFleetConditions))
+	fleetStatusCmd.Flags().BoolVar(&fso.checkControlPlanes, "control-planes", false,
+		"Evaluate the Ready condition of the control plane of each Cluster.")
+	fleetStatusCmd.Flags().BoolVar(&fso.checkMachineDeployments, "machine-deployments", false,
+		"Evaluate the Available condition and the ready replicas of the MachineDeployments of each Cluster.")
+	fleetStatusCmd.Flags().StringVarP(&fso.output, "output", "o", FleetStatusOutputText,
+		fmt.Sprintf("Output format. Valid values: %v.", FleetStatusOutputs))
+
+	fleetCmd.AddCommand(fleetStatusCmd)
+}
+
+func runFleetStatus(out io.Writer) error {
+	if fso.output != FleetStatusOutputText && fso.output != FleetStatusOutputJSON && fso.output != FleetStatusOutputYaml {
+		return errors.Errorf("invalid output format %q. Valid values: %v", fso.output, FleetStatusOutputs)
+	}
+
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	report, err := c.FleetStatus(client.FleetStatusOptions{
+		Kubeconfig:              client.Kubeconfig{Path: fso.kubeconfig, Context: fso.kubeconfigContext},
+		Namespace:               fso.namespace,
+		Conditions:              fso.conditions,
+		CheckControlPlanes:      fso.checkControlPlanes,
+		CheckMachineDeployments: fso.checkMachineDeployments,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := printFleetStatus(out, report, fso.output); err != nil {
+		return err
+	}
+
+	return fleetStatusExitError(report)
+}
+
+func printFleetStatus(out io.Writer, report *alpha.FleetStatusReport, output string) error {
+	switch output {
+	case FleetStatusOutputJSON:
+		j, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, string(j))
+		return nil
+	case FleetStatusOutputYaml:
+		y, err := yaml.Marshal(report)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(out, string(y))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tCLUSTER\tOBJECT\tRESULT\tMESSAGE")
+	for _, o := range report.Objects {
+		fmt.Fprintf(w, "%s\t%s\t%s/%s\t%s\t%s\n", o.Namespace, o.ClusterName, o.Kind, o.Name, o.Result, strings.Join(o.Messages, "; "))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\n%d passed, %d warnings, %d failed\n",
+		report.Count(alpha.FleetCheckPass), report.Count(alpha.FleetCheckWarn), report.Count(alpha.FleetCheckFail))
+	return nil
+}
+
+// fleetStatusExitError returns an error with the exit code matching the overall result of the report, if the result is not Pass.
+func fleetStatusExitError(report *alpha.FleetStatusReport) error {
+	switch report.Result() {
+	case alpha.FleetCheckFail:
+		return &exitCodeError{error: errors.Errorf("%d objects failed the fleet checks", report.Count(alpha.FleetCheckFail)), code: FleetStatusFailExitCode}
+	case alpha.FleetCheckWarn:
+		return &exitCodeError{error: errors.Errorf("%d objects raised warnings during the fleet checks", report.Count(alpha.FleetCheckWarn)), code: FleetStatusWarnExitCode}
+	default:
+		return nil
+	}
+}
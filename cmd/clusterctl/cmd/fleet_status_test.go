@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
+)
+
+func Test_fleetStatusExitError(t *testing.T) {
+	tests := []struct {
+		name     string
+		results  []alpha.FleetCheckResult
+		wantCode int
+	}{
+		{
+			name:     "no error if all the objects pass",
+			results:  []alpha.FleetCheckResult{alpha.FleetCheckPass, alpha.FleetCheckPass},
+			wantCode: 0,
+		},
+		{
+			name:     "warn exit code if some objects raise warnings",
+			results:  []alpha.FleetCheckResult{alpha.FleetCheckPass, alpha.FleetCheckWarn},
+			wantCode: FleetStatusWarnExitCode,
+		},
+		{
+			name:     "fail exit code if at least one object fails",
+			results:  []alpha.FleetCheckResult{alpha.FleetCheckFail, alpha.FleetCheckWarn},
+			wantCode: FleetStatusFailExitCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			report := &alpha.FleetStatusReport{}
+			for _, r := range tt.results {
+				report.Objects = append(report.Objects, alpha.FleetObjectStatus{Kind: "Cluster", Name: "c", Result: r})
+			}
+
+			err := fleetStatusExitError(report)
+			if tt.wantCode == 0 {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			var exitErr *exitCodeError
+			g.Expect(errors.As(err, &exitErr)).To(BeTrue())
+			g.Expect(exitErr.code).To(Equal(tt.wantCode))
+		})
+	}
+}
+
+func Test_printFleetStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	report := &alpha.FleetStatusReport{
+		Objects: []alpha.FleetObjectStatus{
+			{Kind: "Cluster", Namespace: "ns1", Name: "c1", ClusterName: "c1", Result: alpha.FleetCheckPass},
+			{Kind: "Cluster", Namespace: "ns1", Name: "c2", ClusterName: "c2", Result: alpha.FleetCheckWarn, Messages: []string{"condition Ready is not reported"}},
+		},
+	}
+
+	out := &bytes.Buffer{}
+	g.Expect(printFleetStatus(out, report, FleetStatusOutputText)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("Cluster/c2"))
+	g.Expect(out.String()).To(ContainSubstring("condition Ready is not reported"))
+	g.Expect(out.String()).To(ContainSubstring("1 passed, 1 warnings, 0 failed"))
+
+	out.Reset()
+	g.Expect(printFleetStatus(out, report, FleetStatusOutputYaml)).To(Succeed())
+	g.Expect(out.String()).To(ContainSubstring("result: Warn"))
+}
@@ -18,7 +18,9 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
 )
@@ -39,6 +41,10 @@ type generateClusterOptions struct {
 	configMapName      string
 	configMapDataKey   string
 
+	envFile              string
+	variablesFromFile    []string
+	variablesFromFileB64 []string
+
 	listVariables bool
 }
 
@@ -84,6 +90,15 @@ var generateClusterClusterCmd = &cobra.Command{
 		# Generates a yaml file for creating workload clusters using a template stored locally.
 		clusterctl generate cluster my-cluster --from ~/workspace/cluster-template.yaml
 
+		# Generates a yaml file for creating workload clusters using the variables defined in a file,
+		# one per line in the KEY=VALUE format.
+		clusterctl generate cluster my-cluster --env-file ~/workspace/my-cluster.env
+
+		# Generates a yaml file for creating workload clusters reading the value of some variables from files,
+		# optionally base64 encoded.
+		clusterctl generate cluster my-cluster --from-file CLOUD_INIT=./cloud-init.yaml \
+			--from-file-base64 CA_CERT=./ca.crt
+
 		# Prints the list of variables required by the yaml file for creating workload cluster.
 		clusterctl generate cluster my-cluster --list-variables`),
 
@@ -127,6 +142,14 @@ func init() {
 	generateClusterClusterCmd.Flags().StringVar(&gc.configMapDataKey, "from-config-map-key", "",
 		fmt.Sprintf("The ConfigMap.Data key where the workload cluster template is hosted. If unspecified, %q will be used", client.DefaultCustomTemplateConfigMapKey))
 
+	// flags for the variables read from files
+	generateClusterClusterCmd.Flags().StringVar(&gc.envFile, "env-file", "",
+		"Path to a file defining the variables to be used in the template, one per line in the KEY=VALUE format. Variables defined in the file take precedence over OS environment variables and the .cluster-api/clusterctl.yaml config file.")
+	generateClusterClusterCmd.Flags().StringArrayVar(&gc.variablesFromFile, "from-file", nil,
+		"A variable to be used in the template in the NAME=PATH format, whose value is the content of the file at PATH. Can be specified multiple times.")
+	generateClusterClusterCmd.Flags().StringArrayVar(&gc.variablesFromFileB64, "from-file-base64", nil,
+		"A variable to be used in the template in the NAME=PATH format, whose value is the base64 encoded content of the file at PATH. Can be specified multiple times.")
+
 	// other flags
 	generateClusterClusterCmd.Flags().BoolVar(&gc.listVariables, "list-variables", false,
 		"Returns the list of variables expected by the template instead of the template yaml")
@@ -145,9 +168,20 @@ func runGenerateClusterTemplate(cmd *cobra.Command, name string) error {
 		ClusterName:       name,
 		TargetNamespace:   gc.targetNamespace,
 		KubernetesVersion: gc.kubernetesVersion,
+		EnvFile:           gc.envFile,
 		ListVariablesOnly: gc.listVariables,
 	}
 
+	variablesFromFiles, err := parseVariablesFromFiles(gc.variablesFromFile, false)
+	if err != nil {
+		return err
+	}
+	variablesFromFilesB64, err := parseVariablesFromFiles(gc.variablesFromFileB64, true)
+	if err != nil {
+		return err
+	}
+	templateOptions.VariablesFromFiles = append(variablesFromFiles, variablesFromFilesB64...)
+
 	if cmd.Flags().Changed("control-plane-machine-count") {
 		templateOptions.ControlPlaneMachineCount = &gc.controlPlaneMachineCount
 	}
@@ -187,3 +221,20 @@ func runGenerateClusterTemplate(cmd *cobra.Command, name string) error {
 
 	return printYamlOutput(template)
 }
+
+// parseVariablesFromFiles parses a list of variables in the NAME=PATH format.
+func parseVariablesFromFiles(values []string, base64 bool) ([]client.VariableFromFileOptions, error) {
+	variables := []client.VariableFromFileOptions{}
+	for _, v := range values {
+		nameAndPath := strings.SplitN(v, "=", 2)
+		if len(nameAndPath) != 2 || nameAndPath[0] == "" || nameAndPath[1] == "" {
+			return nil, errors.Errorf("invalid variable from file %q: the value must be in the NAME=PATH format", v)
+		}
+		variables = append(variables, client.VariableFromFileOptions{
+			Name:   nameAndPath[0],
+			Path:   nameAndPath[1],
+			Base64: base64,
+		})
+	}
+	return variables, nil
+}
@@ -35,6 +35,7 @@ type initOptions struct {
 	listImages              bool
 	waitProviders           bool
 	waitProviderTimeout     int
+	rollbackOnFailure       bool
 }
 
 var initOpts = &initOptions{}
@@ -104,9 +105,11 @@ func init() {
 	initCmd.Flags().StringVar(&initOpts.targetNamespace, "target-namespace", "",
 		"The target namespace where the providers should be deployed. If unspecified, the provider components' default namespace is used.")
 	initCmd.Flags().BoolVar(&initOpts.waitProviders, "wait-providers", false,
-		"Wait for providers to be installed.")
+		"Wait for providers to be installed, that is for the provider deployments to be available and for the provider webhooks to be serving.")
 	initCmd.Flags().IntVar(&initOpts.waitProviderTimeout, "wait-provider-timeout", 5*60,
 		"Wait timeout per provider installation in seconds. This value is ignored if --wait-providers is false")
+	initCmd.Flags().BoolVar(&initOpts.rollbackOnFailure, "rollback-on-failure", false,
+		"Delete the providers installed by this command if they are not ready within --wait-provider-timeout. This value is ignored if --wait-providers is false")
 
 	// TODO: Move this to a sub-command or similar, it shouldn't really be a flag.
 	initCmd.Flags().BoolVar(&initOpts.listImages, "list-images", false,
@@ -131,6 +134,7 @@ func runInit() error {
 		LogUsageInstructions:    true,
 		WaitProviders:           initOpts.waitProviders,
 		WaitProviderTimeout:     time.Duration(initOpts.waitProviderTimeout) * time.Second,
+		RollbackOnFailure:       initOpts.rollbackOnFailure,
 	}
 
 	if initOpts.listImages {
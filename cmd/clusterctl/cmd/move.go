@@ -29,6 +29,7 @@ type moveOptions struct {
 	toKubeconfigContext   string
 	namespace             string
 	dryRun                bool
+	checkpointFile        string
 }
 
 var mo = &moveOptions{}
@@ -63,6 +64,8 @@ func init() {
 		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used.")
 	moveCmd.Flags().BoolVar(&mo.dryRun, "dry-run", false,
 		"Enable dry run, don't really perform the move actions")
+	moveCmd.Flags().StringVar(&mo.checkpointFile, "checkpoint-file", "",
+		"Path to a file recording the progress of the move, so a move interrupted by a failure can be resumed by running it again with the same file.")
 
 	RootCmd.AddCommand(moveCmd)
 }
@@ -83,5 +86,6 @@ func runMove() error {
 		ToKubeconfig:   client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
 		Namespace:      mo.namespace,
 		DryRun:         mo.dryRun,
+		CheckpointFile: mo.checkpointFile,
 	})
 }
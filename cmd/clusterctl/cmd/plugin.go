@@ -0,0 +1,206 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// PluginFilenamePrefix is the prefix of the executables which are surfaced as clusterctl sub-commands,
+// e.g. the clusterctl-aws executable is invoked by running clusterctl aws.
+const PluginFilenamePrefix = "clusterctl-"
+
+// PluginHandler is capable of looking up and executing clusterctl plugins.
+type PluginHandler interface {
+	// Lookup receives a potential plugin name and returns the full path of the executable, if any.
+	Lookup(name string) (string, bool)
+
+	// Execute runs the plugin executable at the given path, passing it the given arguments and environment.
+	Execute(executablePath string, args, environment []string) error
+}
+
+// DefaultPluginHandler implements PluginHandler by looking up plugins in the PATH.
+type DefaultPluginHandler struct{}
+
+var _ PluginHandler = &DefaultPluginHandler{}
+
+// Lookup implements PluginHandler.
+func (h *DefaultPluginHandler) Lookup(name string) (string, bool) {
+	path, err := exec.LookPath(PluginFilenamePrefix + name)
+	if err != nil || path == "" {
+		return "", false
+	}
+	return path, true
+}
+
+// Execute implements PluginHandler.
+// If the plugin exits with a non-zero exit code, an error carrying the same exit code is returned,
+// so clusterctl exits with the same code.
+func (h *DefaultPluginHandler) Execute(executablePath string, args, environment []string) error {
+	c := exec.Command(executablePath, args...) //nolint:gosec
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	c.Env = environment
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return &exitCodeError{error: errors.Errorf("plugin %s failed", filepath.Base(executablePath)), code: exitErr.ExitCode()}
+		}
+		return errors.Wrapf(err, "failed to run plugin %s", filepath.Base(executablePath))
+	}
+	return nil
+}
+
+// HandlePluginCommand looks up the plugin matching the longest sequence of the given arguments not being flags,
+// e.g. for clusterctl aws bootstrap iam --flag, clusterctl-aws-bootstrap-iam, clusterctl-aws-bootstrap and clusterctl-aws
+// are tried in this order; if a plugin is found, it is executed with all the remaining arguments.
+// It returns false if no plugin is found.
+func HandlePluginCommand(pluginHandler PluginHandler, args []string) (bool, error) {
+	var nameParts []string
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		// Dashes are used for separating name parts, so they are replaced by underscores, like in kubectl.
+		nameParts = append(nameParts, strings.ReplaceAll(arg, "-", "_"))
+	}
+
+	for len(nameParts) > 0 {
+		path, found := pluginHandler.Lookup(strings.Join(nameParts, "-"))
+		if found {
+			return true, pluginHandler.Execute(path, args[len(nameParts):], os.Environ())
+		}
+		nameParts = nameParts[:len(nameParts)-1]
+	}
+	return false, nil
+}
+
+// AddPluginCommand allows programs embedding clusterctl to add a sub-command, e.g. to ship a companion CLI for a provider
+// in the same binary. It fails if a sub-command with the same name already exists.
+func AddPluginCommand(c *cobra.Command) error {
+	for _, existing := range RootCmd.Commands() {
+		if existing.Name() == c.Name() {
+			return errors.Errorf("clusterctl already has a sub-command named %q", c.Name())
+		}
+	}
+	RootCmd.AddCommand(c)
+	return nil
+}
+
+// ListPlugins returns the full path of all the plugin executables in the PATH, sorted by PATH precedence
+// and in lexical order within each directory; plugins shadowed by another plugin with the same name are listed too.
+func ListPlugins() []string {
+	var plugins []string
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), PluginFilenamePrefix) {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			plugins = append(plugins, path)
+		}
+	}
+	return plugins
+}
+
+// PluginName returns the name of the sub-command implemented by a plugin executable.
+func PluginName(path string) string {
+	name := strings.TrimPrefix(filepath.Base(path), PluginFilenamePrefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(name, "-", " "), "_", "-")
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd" || ext == ".com"
+	}
+	return info.Mode()&0111 != 0
+}
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin SUBCOMMAND",
+	Short: "Provides utilities for interacting with plugins",
+	Long: LongDesc(`
+		Provides utilities for interacting with plugins.
+
+		Plugins are executables named clusterctl-<name> available in the PATH, which are invoked by running
+		clusterctl <name>; dashes in the executable name define nested sub-commands, e.g. the
+		clusterctl-aws-bootstrap executable is invoked by running clusterctl aws bootstrap.`),
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all the plugins available in the PATH",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPluginList()
+	},
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	RootCmd.AddCommand(pluginCmd)
+}
+
+func runPluginList() error {
+	plugins := ListPlugins()
+	if len(plugins) == 0 {
+		return errors.New("unable to find any clusterctl plugins in your PATH")
+	}
+
+	seen := map[string]string{}
+	fmt.Fprintln(os.Stdout, "The following clusterctl plugins are available:")
+	for _, path := range plugins {
+		name := PluginName(path)
+		fmt.Fprintln(os.Stdout, path)
+		if shadowedBy, ok := seen[name]; ok {
+			fmt.Fprintf(os.Stderr, "  - warning: %s is overshadowed by a similarly named plugin: %s\n", path, shadowedBy)
+			continue
+		}
+		seen[name] = path
+		if c, _, err := RootCmd.Find(strings.Split(name, " ")); err == nil && c.CommandPath() == RootCmd.Name()+" "+name {
+			fmt.Fprintf(os.Stderr, "  - warning: %s is ignored because it has the same name of the existing command: %s\n", path, c.CommandPath())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+)
+
+type fakePluginHandler struct {
+	plugins      map[string]string
+	executedPath string
+	executedArgs []string
+}
+
+func (h *fakePluginHandler) Lookup(name string) (string, bool) {
+	path, ok := h.plugins[name]
+	return path, ok
+}
+
+func (h *fakePluginHandler) Execute(executablePath string, args, environment []string) error {
+	h.executedPath = executablePath
+	h.executedArgs = args
+	return nil
+}
+
+func Test_HandlePluginCommand(t *testing.T) {
+	plugins := map[string]string{
+		"aws":           "/bin/clusterctl-aws",
+		"aws-bootstrap": "/bin/clusterctl-aws-bootstrap",
+		"my_plugin":     "/bin/clusterctl-my_plugin",
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantPath string
+		wantArgs []string
+	}{
+		{
+			name:     "plugin without arguments",
+			args:     []string{"aws"},
+			wantPath: "/bin/clusterctl-aws",
+			wantArgs: []string{},
+		},
+		{
+			name:     "plugin with arguments",
+			args:     []string{"aws", "iam", "--flag", "value"},
+			wantPath: "/bin/clusterctl-aws",
+			wantArgs: []string{"iam", "--flag", "value"},
+		},
+		{
+			name:     "longest match wins",
+			args:     []string{"aws", "bootstrap", "iam"},
+			wantPath: "/bin/clusterctl-aws-bootstrap",
+			wantArgs: []string{"iam"},
+		},
+		{
+			name:     "args after flags are not used for matching",
+			args:     []string{"aws", "--flag", "bootstrap"},
+			wantPath: "/bin/clusterctl-aws",
+			wantArgs: []string{"--flag", "bootstrap"},
+		},
+		{
+			name:     "dashes in arguments match underscores in plugin names",
+			args:     []string{"my-plugin"},
+			wantPath: "/bin/clusterctl-my_plugin",
+			wantArgs: []string{},
+		},
+		{
+			name: "no plugin found",
+			args: []string{"gcp", "bootstrap"},
+		},
+		{
+			name: "no plugin found when starting with flags",
+			args: []string{"--flag", "aws"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			h := &fakePluginHandler{plugins: plugins}
+			found, err := HandlePluginCommand(h, tt.args)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(found).To(Equal(tt.wantPath != ""))
+			g.Expect(h.executedPath).To(Equal(tt.wantPath))
+			if tt.wantPath != "" {
+				g.Expect(h.executedArgs).To(Equal(tt.wantArgs))
+			}
+		})
+	}
+}
+
+func Test_ListPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin executables are detected by extension on windows")
+	}
+	g := NewWithT(t)
+
+	dir1 := t.TempDir()
+	dir2 := t.TempDir()
+	g.Expect(os.WriteFile(filepath.Join(dir1, "clusterctl-foo"), []byte("#!/bin/sh"), 0o755)).To(Succeed()) //nolint:gosec
+	g.Expect(os.WriteFile(filepath.Join(dir1, "clusterctl-not-executable"), []byte(""), 0o600)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir1, "kubectl-foo"), []byte("#!/bin/sh"), 0o755)).To(Succeed())        //nolint:gosec
+	g.Expect(os.WriteFile(filepath.Join(dir2, "clusterctl-bar-baz"), []byte("#!/bin/sh"), 0o755)).To(Succeed()) //nolint:gosec
+
+	path := os.Getenv("PATH")
+	defer os.Setenv("PATH", path)
+	g.Expect(os.Setenv("PATH", dir1+string(filepath.ListSeparator)+dir2)).To(Succeed())
+
+	plugins := ListPlugins()
+	g.Expect(plugins).To(Equal([]string{
+		filepath.Join(dir1, "clusterctl-foo"),
+		filepath.Join(dir2, "clusterctl-bar-baz"),
+	}))
+	g.Expect(PluginName(plugins[1])).To(Equal("bar baz"))
+}
+
+func Test_AddPluginCommand(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(AddPluginCommand(&cobra.Command{Use: "move"})).ToNot(Succeed())
+
+	c := &cobra.Command{Use: "test-plugin-command"}
+	g.Expect(AddPluginCommand(c)).To(Succeed())
+	defer RootCmd.RemoveCommand(c)
+
+	found, _, err := RootCmd.Find([]string{"test-plugin-command"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(found).To(Equal(c))
+}
@@ -36,8 +36,15 @@ type stackTracer interface {
 	StackTrace() errors.StackTrace
 }
 
+// exitCodeError is an error requiring clusterctl to exit with a specific exit code.
+type exitCodeError struct {
+	error
+	code int
+}
+
 var (
 	cfgFile   string
+	profile   string
 	verbosity *int
 )
 
@@ -57,6 +64,10 @@ var RootCmd = &cobra.Command{
 				return errors.Wrapf(err, "failed to create the clusterctl config directory: %s", configFolderPath)
 			}
 		}
+
+		if profile != "" {
+			return applyProfile(cmd, profile)
+		}
 		return nil
 	},
 	PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
@@ -90,7 +101,28 @@ var RootCmd = &cobra.Command{
 
 // Execute executes the root command.
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	// If the arguments do not match any existing command, try to run a plugin.
+	if len(os.Args) > 1 {
+		if _, _, err := RootCmd.Find(os.Args[1:]); err != nil {
+			found, err := HandlePluginCommand(&DefaultPluginHandler{}, os.Args[1:])
+			if found {
+				// Errors from the plugin are already reported by the plugin itself.
+				var exitErr *exitCodeError
+				if err != nil && !errors.As(err, &exitErr) {
+					fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				}
+				handleExecuteError(err)
+				return
+			}
+		}
+	}
+
+	handleExecuteError(RootCmd.Execute())
+}
+
+// handleExecuteError exits with the exit code corresponding to the error, if any.
+func handleExecuteError(err error) {
+	if err != nil {
 		if verbosity != nil && *verbosity >= 5 {
 			if err, ok := err.(stackTracer); ok {
 				for _, f := range err.StackTrace() {
@@ -98,6 +130,10 @@ func Execute() {
 				}
 			}
 		}
+		var exitErr *exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		// TODO: print cmd help if validation error
 		os.Exit(1)
 	}
@@ -111,6 +147,8 @@ func init() {
 	RootCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
 	RootCmd.PersistentFlags().StringVar(&cfgFile, "config", "",
 		"Path to clusterctl configuration (default is `$HOME/.cluster-api/clusterctl.yaml`) or to a remote location (i.e. https://example.com/clusterctl.yaml)")
+	RootCmd.PersistentFlags().StringVar(&profile, "profile", "",
+		"Name of the management cluster profile defined in the clusterctl configuration to use for defaulting the kubeconfig, kubeconfig context, namespace and variables")
 
 	cobra.OnInitialize(initConfig, registerCompletionFuncForCommonFlags)
 }
@@ -135,7 +173,50 @@ func initConfig() {
 	logf.SetLogger(logf.NewLogger(logf.WithThreshold(verbosity)))
 }
 
+// applyProfile sets the flags of cmd not explicitly set by the user, and the variables not already
+// defined as OS environment variables, to the values defined in the given profile.
+func applyProfile(cmd *cobra.Command, name string) error {
+	configClient, err := config.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	p, err := configClient.Profiles().Get(name)
+	if err != nil {
+		return err
+	}
+
+	flagValues := map[string]string{
+		"kubeconfig":         p.Kubeconfig,
+		"kubeconfig-context": p.KubeconfigContext,
+		"namespace":          p.Namespace,
+		"target-namespace":   p.Namespace,
+	}
+	for flagName, value := range flagValues {
+		f := cmd.Flags().Lookup(flagName)
+		if f == nil || f.Changed || value == "" {
+			continue
+		}
+		if err := cmd.Flags().Set(flagName, value); err != nil {
+			return errors.Wrapf(err, "failed to set the %q flag from profile %q", flagName, name)
+		}
+	}
+
+	// NOTE: Variables are set as overrides in the configuration, which is shared by all the config clients created
+	// afterwards; variables defined as OS environment variables are skipped so they keep taking precedence.
+	envReplacer := strings.NewReplacer("-", "_")
+	for key, value := range p.Variables {
+		if _, ok := os.LookupEnv(envReplacer.Replace(strings.ToUpper(key))); ok {
+			continue
+		}
+		configClient.Variables().Set(key, value)
+	}
+	return nil
+}
+
 func registerCompletionFuncForCommonFlags() {
+	_ = RootCmd.RegisterFlagCompletionFunc("profile", profileCompletionFunc())
+
 	visitCommands(RootCmd, func(cmd *cobra.Command) {
 		if kubeconfigFlag := cmd.Flags().Lookup("kubeconfig"); kubeconfigFlag != nil {
 			// context in kubeconfig
@@ -0,0 +1,32 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var standbyCmd = &cobra.Command{
+	Use:   "standby SUBCOMMAND",
+	Short: "Manage a standby management cluster",
+	Long: LongDesc(`
+		Manage a standby management cluster.
+
+		A standby management cluster holds a copy of the Cluster API objects of a primary management
+		cluster, with all the Clusters paused; when the primary management cluster is lost, the standby
+		management cluster can be promoted, thus resuming reconciliation of the workload clusters.`),
+}
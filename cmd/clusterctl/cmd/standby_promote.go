@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+type standbyPromoteOptions struct {
+	kubeconfig               string
+	kubeconfigContext        string
+	primaryKubeconfig        string
+	primaryKubeconfigContext string
+	namespace                string
+	repointKubeconfig        string
+}
+
+var spo = &standbyPromoteOptions{}
+
+var standbyPromoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Promote a standby management cluster",
+	Long: LongDesc(`
+		Promote a standby management cluster, by unpausing all the Clusters copied by clusterctl alpha standby sync.
+
+		If the former primary management cluster is still reachable, use the --primary-kubeconfig flag to pause
+		its Clusters before promotion, thus preventing two management clusters from reconciling the same workload
+		clusters; failures in pausing the former primary management cluster do not block promotion.
+
+		After promotion, tools and users should be re-pointed to the kubeconfig of the promoted management cluster;
+		use the --repoint-kubeconfig flag to add the context of the promoted management cluster to a kubeconfig file,
+		e.g. the one used by tools and users, and to set it as the current context.`),
+
+	Example: Examples(`
+		# Promote a standby management cluster.
+		clusterctl alpha standby promote --kubeconfig=standby-kubeconfig.yaml
+
+		# Promote a standby management cluster, pausing the Clusters in the former primary management cluster.
+		clusterctl alpha standby promote --kubeconfig=standby-kubeconfig.yaml --primary-kubeconfig=primary-kubeconfig.yaml
+
+		# Promote a standby management cluster, and re-point the default kubeconfig file to it.
+		clusterctl alpha standby promote --kubeconfig=standby-kubeconfig.yaml --repoint-kubeconfig=$HOME/.kube/config`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStandbyPromote()
+	},
+}
+
+func init() {
+	standbyPromoteCmd.Flags().StringVar(&spo.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file for the standby management cluster. If unspecified, default discovery rules apply.")
+	standbyPromoteCmd.Flags().StringVar(&spo.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file for the standby management cluster. If empty, current context will be used.")
+	standbyPromoteCmd.Flags().StringVar(&spo.primaryKubeconfig, "primary-kubeconfig", "",
+		"Path to the kubeconfig file for the former primary management cluster. If unspecified, the former primary management cluster is not paused.")
+	standbyPromoteCmd.Flags().StringVar(&spo.primaryKubeconfigContext, "primary-kubeconfig-context", "",
+		"Context to be used within the kubeconfig file for the former primary management cluster. If empty, current context will be used.")
+	standbyPromoteCmd.Flags().StringVarP(&spo.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are hosted. If unspecified, the current context's namespace is used.")
+	standbyPromoteCmd.Flags().StringVar(&spo.repointKubeconfig, "repoint-kubeconfig", "",
+		"Path to a kubeconfig file to be re-pointed to the promoted management cluster. If unspecified, no kubeconfig file is re-pointed.")
+}
+
+func runStandbyPromote() error {
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	return c.StandbyPromote(client.StandbyPromoteOptions{
+		Kubeconfig:            client.Kubeconfig{Path: spo.kubeconfig, Context: spo.kubeconfigContext},
+		PrimaryKubeconfig:     client.Kubeconfig{Path: spo.primaryKubeconfig, Context: spo.primaryKubeconfigContext},
+		Namespace:             spo.namespace,
+		RepointKubeconfigPath: spo.repointKubeconfig,
+	})
+}
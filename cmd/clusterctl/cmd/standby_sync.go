@@ -0,0 +1,118 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+type standbySyncOptions struct {
+	fromKubeconfig        string
+	fromKubeconfigContext string
+	toKubeconfig          string
+	toKubeconfigContext   string
+	namespace             string
+	interval              time.Duration
+}
+
+var sso = &standbySyncOptions{}
+
+var standbySyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Copy Cluster API objects to a standby management cluster",
+	Long: LongDesc(`
+		Copy Cluster API objects and all dependencies to a standby management cluster.
+
+		Objects are copied without status, and Clusters are paused in the standby management cluster,
+		so they are not reconciled until the standby management cluster is promoted. Objects existing
+		in the standby management cluster are updated to the latest state in the primary management cluster,
+		and objects deleted from the primary management cluster are deleted from the standby management cluster.
+
+		Note: The standby cluster MUST have the required provider components installed.
+
+		Note: There is no controller replicating the primary management cluster, so only one sync must run
+		at any time for a standby management cluster, and the outcome of each sync is reported only in the
+		output of this command.`),
+
+	Example: Examples(`
+		# Copy Cluster API objects to a standby management cluster.
+		clusterctl alpha standby sync --to-kubeconfig=standby-kubeconfig.yaml
+
+		# Continuously copy Cluster API objects to a standby management cluster every 5 minutes.
+		clusterctl alpha standby sync --to-kubeconfig=standby-kubeconfig.yaml --interval=5m`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStandbySync()
+	},
+}
+
+func init() {
+	standbySyncCmd.Flags().StringVar(&sso.fromKubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file for the primary management cluster. If unspecified, default discovery rules apply.")
+	standbySyncCmd.Flags().StringVar(&sso.toKubeconfig, "to-kubeconfig", "",
+		"Path to the kubeconfig file to use for the standby management cluster.")
+	standbySyncCmd.Flags().StringVar(&sso.fromKubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file for the primary management cluster. If empty, current context will be used.")
+	standbySyncCmd.Flags().StringVar(&sso.toKubeconfigContext, "to-kubeconfig-context", "",
+		"Context to be used within the kubeconfig file for the standby management cluster. If empty, current context will be used.")
+	standbySyncCmd.Flags().StringVarP(&sso.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are hosted. If unspecified, the current context's namespace is used.")
+	standbySyncCmd.Flags().DurationVar(&sso.interval, "interval", 0,
+		"If greater than zero, keep syncing the standby management cluster at the given interval until interrupted.")
+
+	standbyCmd.AddCommand(standbySyncCmd)
+	standbyCmd.AddCommand(standbyPromoteCmd)
+}
+
+func runStandbySync() error {
+	if sso.toKubeconfig == "" {
+		return errors.New("please specify a standby cluster using the --to-kubeconfig flag")
+	}
+
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	options := client.StandbySyncOptions{
+		FromKubeconfig: client.Kubeconfig{Path: sso.fromKubeconfig, Context: sso.fromKubeconfigContext},
+		ToKubeconfig:   client.Kubeconfig{Path: sso.toKubeconfig, Context: sso.toKubeconfigContext},
+		Namespace:      sso.namespace,
+		Interval:       sso.interval,
+	}
+
+	// When syncing continuously, stop at the end of the current sync when interrupted.
+	if sso.interval > 0 {
+		stop := make(chan struct{})
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signals
+			close(stop)
+		}()
+		options.Stop = stop
+	}
+
+	return c.StandbySync(options)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+type topologyPlanOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	files             []string
+	namespace         string
+	outDir            string
+	offline           bool
+}
+
+var tp = &topologyPlanOptions{}
+
+var topologyPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "List the changes to Clusters that use managed topologies for the given input objects",
+	Long: LongDesc(`
+		Provide a list of objects that the topology controller would create, update or delete
+		for the Clusters affected by the given modified Cluster, ClusterClass and template objects,
+		without applying any change to the management cluster.
+
+		The affected Clusters are the Clusters with a managed topology in the input files and, unless
+		--offline is used, the Clusters in the management cluster using one of the ClusterClasses in
+		the input files, or a ClusterClass referencing one of the templates in the input files.
+		Objects not in the input files are read from the management cluster.`),
+
+	Example: Examples(`
+		# List the changes the topology controller would apply to the Clusters using a modified ClusterClass.
+		clusterctl alpha topology plan -f modified-clusterclass.yaml
+
+		# List the changes for a new Cluster using only the input files, without reading the management cluster.
+		clusterctl alpha topology plan -f clusterclass.yaml -f templates.yaml -f cluster.yaml --offline
+
+		# Write the created objects, and the original and modified version of the updated objects, to a directory.
+		clusterctl alpha topology plan -f modified-clusterclass.yaml -o output/`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTopologyPlan(os.Stdout)
+	},
+}
+
+func init() {
+	topologyPlanCmd.Flags().StringVar(&tp.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	topologyPlanCmd.Flags().StringVar(&tp.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	topologyPlanCmd.Flags().StringArrayVarP(&tp.files, "file", "f", nil,
+		"Path to a YAML file with the modified Cluster, ClusterClass or template objects. Can be repeated.")
+	topologyPlanCmd.Flags().StringVarP(&tp.namespace, "namespace", "n", "",
+		"The namespace used for the input objects without a namespace. If unspecified, the current context's namespace is used.")
+	topologyPlanCmd.Flags().StringVarP(&tp.outDir, "output-directory", "o", "",
+		"If specified, the created objects, and the original and modified version of the updated objects, are written to this directory.")
+	topologyPlanCmd.Flags().BoolVar(&tp.offline, "offline", false,
+		"Plan the changes using only the input files, without reading the state of the management cluster.")
+	_ = topologyPlanCmd.MarkFlagRequired("file")
+}
+
+func runTopologyPlan(out io.Writer) error {
+	objs := []*unstructured.Unstructured{}
+	for _, f := range tp.files {
+		raw, err := ioutil.ReadFile(f) //nolint:gosec
+		if err != nil {
+			return errors.Wrapf(err, "failed to read input file %q", f)
+		}
+		fileObjs, err := utilyaml.ToUnstructured(raw)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse input file %q", f)
+		}
+		for i := range fileObjs {
+			objs = append(objs, &fileObjs[i])
+		}
+	}
+
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	plan, err := c.TopologyPlan(client.TopologyPlanOptions{
+		Kubeconfig: client.Kubeconfig{Path: tp.kubeconfig, Context: tp.kubeconfigContext},
+		Objs:       objs,
+		Namespace:  tp.namespace,
+		Offline:    tp.offline,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := printTopologyPlan(out, plan); err != nil {
+		return err
+	}
+
+	if tp.outDir != "" {
+		return writeTopologyPlan(plan, tp.outDir)
+	}
+	return nil
+}
+
+func printTopologyPlan(out io.Writer, plan *cluster.TopologyPlanOutput) error {
+	if len(plan.Clusters) == 0 {
+		fmt.Fprintln(out, "No Clusters affected by the input objects.")
+		return nil
+	}
+
+	for _, key := range plan.Clusters {
+		changes := plan.Changes[key]
+		fmt.Fprintf(out, "Cluster %s/%s:\n", key.Namespace, key.Name)
+		if len(changes.Created)+len(changes.Modified)+len(changes.Deleted) == 0 {
+			fmt.Fprintln(out, "  No changes.")
+			fmt.Fprintln(out)
+			continue
+		}
+
+		w := tabwriter.NewWriter(out, 10, 4, 3, ' ', 0)
+		fmt.Fprintln(w, "ACTION\tKIND\tNAMESPACE\tNAME")
+		for _, obj := range changes.Created {
+			fmt.Fprintf(w, "created\t%s\t%s\t%s\n", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		for _, obj := range changes.Modified {
+			fmt.Fprintf(w, "modified\t%s\t%s\t%s\n", obj.After.GetKind(), obj.After.GetNamespace(), obj.After.GetName())
+		}
+		for _, obj := range changes.Deleted {
+			fmt.Fprintf(w, "deleted\t%s\t%s\t%s\n", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+	return nil
+}
+
+// writeTopologyPlan writes the created objects, and the original and modified version of the updated objects,
+// into a directory for each affected Cluster.
+func writeTopologyPlan(plan *cluster.TopologyPlanOutput, outDir string) error {
+	for _, key := range plan.Clusters {
+		changes := plan.Changes[key]
+		dir := filepath.Join(outDir, key.Namespace, key.Name)
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			return errors.Wrapf(err, "failed to create output directory %q", dir)
+		}
+
+		for _, obj := range changes.Created {
+			if err := writeTopologyPlanObject(dir, "created", obj); err != nil {
+				return err
+			}
+		}
+		for _, obj := range changes.Modified {
+			if err := writeTopologyPlanObject(dir, "original", obj.Before); err != nil {
+				return err
+			}
+			if err := writeTopologyPlanObject(dir, "modified", obj.After); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeTopologyPlanObject(dir, prefix string, obj *unstructured.Unstructured) error {
+	raw, err := utilyaml.FromUnstructured([]unstructured.Unstructured{*obj})
+	if err != nil {
+		return errors.Wrapf(err, "failed to convert %s %s to yaml", obj.GetKind(), obj.GetName())
+	}
+	fileName := filepath.Join(dir, fmt.Sprintf("%s_%s_%s.yaml", prefix, strings.ToLower(obj.GetKind()), obj.GetName()))
+	if err := ioutil.WriteFile(fileName, raw, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %q", fileName)
+	}
+	return nil
+}
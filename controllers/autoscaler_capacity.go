@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+// Annotations consumed by the Cluster API provider of the cluster autoscaler to build the Node of a
+// MachineSet or MachineDeployment scaled to zero.
+const (
+	autoscalerCapacityAnnotationPrefix = "capacity.cluster-autoscaler.kubernetes.io/"
+
+	cpuCapacityAnnotation           = autoscalerCapacityAnnotationPrefix + "cpu"
+	memoryCapacityAnnotation        = autoscalerCapacityAnnotationPrefix + "memory"
+	ephemeralDiskCapacityAnnotation = autoscalerCapacityAnnotationPrefix + "ephemeral-disk"
+	maxPodsCapacityAnnotation       = autoscalerCapacityAnnotationPrefix + "maxPods"
+	gpuTypeCapacityAnnotation       = autoscalerCapacityAnnotationPrefix + "gpu-type"
+	gpuCountCapacityAnnotation      = autoscalerCapacityAnnotationPrefix + "gpu-count"
+)
+
+// capacityFromTemplateAnnotation lists the capacity annotations set from the capacity reported by the infrastructure
+// machine template, so they can be removed when the template does not report the corresponding resource anymore
+// without removing the annotations set manually.
+const capacityFromTemplateAnnotation = "cluster.x-k8s.io/autoscaler-capacity-from-template"
+
+// reconcileCapacityAnnotations sets the scale from zero annotations of the cluster autoscaler on obj, a MachineSet
+// or a MachineDeployment, from the capacity reported in the status of the infrastructure machine template referenced
+// by ref, and watches the template with templateHandler, so the annotations are updated when the capacity changes.
+// Annotations previously set from the template are removed when the template does not report the corresponding
+// resource anymore, while annotations set manually are left untouched.
+// NOTE: Errors reading the template are logged without failing the reconcile, so they do not block the
+// reconciliation of obj; the annotations are left untouched until the template can be read.
+func reconcileCapacityAnnotations(ctx context.Context, c client.Client, tracker *external.ObjectTracker, templateHandler handler.EventHandler, obj client.Object, ref *corev1.ObjectReference) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !strings.HasSuffix(ref.Kind, clusterv1.TemplateSuffix) {
+		return nil
+	}
+
+	template, err := external.Get(ctx, c, ref, obj.GetNamespace())
+	if err != nil {
+		log.Error(err, "Failed to read the infrastructure machine template, the capacity annotations for the cluster autoscaler are not updated", "template", ref.Name)
+		return nil
+	}
+	if err := tracker.Watch(log, template, templateHandler); err != nil {
+		return err
+	}
+	capacity, err := external.CapacityFrom(template)
+	if err != nil {
+		return err
+	}
+
+	// Sort the resources, so the first GPU type is picked if the template reports more than one.
+	names := make([]string, 0, len(capacity))
+	for name := range capacity {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	desired := map[string]string{}
+	for _, name := range names {
+		quantity := capacity[corev1.ResourceName(name)]
+		switch corev1.ResourceName(name) {
+		case corev1.ResourceCPU:
+			desired[cpuCapacityAnnotation] = quantity.String()
+		case corev1.ResourceMemory:
+			desired[memoryCapacityAnnotation] = quantity.String()
+		case corev1.ResourceEphemeralStorage:
+			desired[ephemeralDiskCapacityAnnotation] = quantity.String()
+		case corev1.ResourcePods:
+			desired[maxPodsCapacityAnnotation] = quantity.String()
+		default:
+			// Extended resources for GPUs, e.g. nvidia.com/gpu; the autoscaler supports a single GPU type.
+			if _, ok := desired[gpuTypeCapacityAnnotation]; strings.HasSuffix(name, "/gpu") && !ok {
+				desired[gpuTypeCapacityAnnotation] = name
+				desired[gpuCountCapacityAnnotation] = quantity.String()
+			}
+		}
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	// Remove the annotations set from the template which are not reported anymore.
+	if fromTemplate, ok := annotations[capacityFromTemplateAnnotation]; ok {
+		for _, key := range strings.Split(fromTemplate, ",") {
+			if _, ok := desired[key]; !ok {
+				delete(annotations, key)
+			}
+		}
+		delete(annotations, capacityFromTemplateAnnotation)
+	}
+
+	keys := make([]string, 0, len(desired))
+	for key, value := range desired {
+		annotations[key] = value
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		sort.Strings(keys)
+		annotations[capacityFromTemplateAnnotation] = strings.Join(keys, ",")
+	}
+
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	obj.SetAnnotations(annotations)
+	return nil
+}
+
+// refersToInfrastructureTemplate returns true if ref refers to the infrastructure machine template o.
+func refersToInfrastructureTemplate(ref corev1.ObjectReference, o client.Object) bool {
+	gvk := o.GetObjectKind().GroupVersionKind()
+	return ref.Kind == gvk.Kind && ref.Name == o.GetName() &&
+		schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).Group == gvk.Group
+}
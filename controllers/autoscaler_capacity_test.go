@@ -0,0 +1,209 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+func TestReconcileCapacityAnnotations(t *testing.T) {
+	newTemplate := func(capacity map[string]string) *unstructured.Unstructured {
+		template := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra-template").Build()
+		if capacity != nil {
+			if err := unstructured.SetNestedStringMap(template.Object, capacity, "status", "capacity"); err != nil {
+				panic(err)
+			}
+		}
+		return template
+	}
+
+	tests := []struct {
+		name            string
+		capacity        map[string]string
+		missingTemplate bool
+		annotations     map[string]string
+		wantAnnotations map[string]string
+	}{
+		{
+			name:            "leaves annotations untouched if the template does not report its capacity",
+			annotations:     map[string]string{cpuCapacityAnnotation: "2"},
+			wantAnnotations: map[string]string{cpuCapacityAnnotation: "2"},
+		},
+		{
+			name: "sets annotations from the capacity of the template",
+			capacity: map[string]string{
+				"cpu":               "4",
+				"memory":            "16Gi",
+				"ephemeral-storage": "100Gi",
+				"pods":              "110",
+				"nvidia.com/gpu":    "2",
+			},
+			wantAnnotations: map[string]string{
+				cpuCapacityAnnotation:           "4",
+				memoryCapacityAnnotation:        "16Gi",
+				ephemeralDiskCapacityAnnotation: "100Gi",
+				maxPodsCapacityAnnotation:       "110",
+				gpuTypeCapacityAnnotation:       "nvidia.com/gpu",
+				gpuCountCapacityAnnotation:      "2",
+				capacityFromTemplateAnnotation: cpuCapacityAnnotation + "," + ephemeralDiskCapacityAnnotation + "," + gpuCountCapacityAnnotation + "," +
+					gpuTypeCapacityAnnotation + "," + maxPodsCapacityAnnotation + "," + memoryCapacityAnnotation,
+			},
+		},
+		{
+			name:     "overrides annotations of the resources reported by the template",
+			capacity: map[string]string{"cpu": "4"},
+			annotations: map[string]string{
+				cpuCapacityAnnotation:    "2",
+				memoryCapacityAnnotation: "8Gi",
+				"foo":                    "bar",
+			},
+			wantAnnotations: map[string]string{
+				cpuCapacityAnnotation:          "4",
+				memoryCapacityAnnotation:       "8Gi",
+				"foo":                          "bar",
+				capacityFromTemplateAnnotation: cpuCapacityAnnotation,
+			},
+		},
+		{
+			name:     "removes annotations set from the template which are not reported anymore",
+			capacity: map[string]string{"cpu": "4"},
+			annotations: map[string]string{
+				cpuCapacityAnnotation:          "2",
+				memoryCapacityAnnotation:       "8Gi",
+				maxPodsCapacityAnnotation:      "110",
+				capacityFromTemplateAnnotation: cpuCapacityAnnotation + "," + memoryCapacityAnnotation,
+			},
+			wantAnnotations: map[string]string{
+				cpuCapacityAnnotation:          "4",
+				maxPodsCapacityAnnotation:      "110",
+				capacityFromTemplateAnnotation: cpuCapacityAnnotation,
+			},
+		},
+		{
+			name: "removes all the annotations set from the template if it does not report its capacity anymore",
+			annotations: map[string]string{
+				cpuCapacityAnnotation:          "2",
+				"foo":                          "bar",
+				capacityFromTemplateAnnotation: cpuCapacityAnnotation,
+			},
+			wantAnnotations: map[string]string{
+				"foo": "bar",
+			},
+		},
+		{
+			name:            "leaves annotations untouched if the template cannot be read",
+			missingTemplate: true,
+			annotations: map[string]string{
+				cpuCapacityAnnotation:          "2",
+				capacityFromTemplateAnnotation: cpuCapacityAnnotation,
+			},
+			wantAnnotations: map[string]string{
+				cpuCapacityAnnotation:          "2",
+				capacityFromTemplateAnnotation: cpuCapacityAnnotation,
+			},
+		},
+		{
+			name:     "picks the first GPU type",
+			capacity: map[string]string{"nvidia.com/gpu": "1", "amd.com/gpu": "2"},
+			wantAnnotations: map[string]string{
+				gpuTypeCapacityAnnotation:      "amd.com/gpu",
+				gpuCountCapacityAnnotation:     "2",
+				capacityFromTemplateAnnotation: gpuCountCapacityAnnotation + "," + gpuTypeCapacityAnnotation,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			template := newTemplate(tt.capacity)
+			ms := &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "ms",
+					Namespace:   metav1.NamespaceDefault,
+					Annotations: tt.annotations,
+				},
+				Spec: clusterv1.MachineSetSpec{
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							InfrastructureRef: corev1.ObjectReference{
+								APIVersion: template.GetAPIVersion(),
+								Kind:       template.GetKind(),
+								Name:       template.GetName(),
+							},
+						},
+					},
+				},
+			}
+			objs := []client.Object{}
+			if !tt.missingTemplate {
+				objs = append(objs, template)
+			}
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+
+			g.Expect(reconcileCapacityAnnotations(ctx, c, &external.ObjectTracker{}, &handler.EnqueueRequestForObject{}, ms, &ms.Spec.Template.Spec.InfrastructureRef)).To(Succeed())
+			g.Expect(ms.Annotations).To(Equal(tt.wantAnnotations))
+		})
+	}
+}
+
+func TestInfrastructureTemplateToMachineSets(t *testing.T) {
+	g := NewWithT(t)
+
+	template := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra-template").Build()
+	newMachineSet := func(name string, kind string, templateName string) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Spec: clusterv1.MachineSetSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						InfrastructureRef: corev1.ObjectReference{
+							APIVersion: template.GetAPIVersion(),
+							Kind:       kind,
+							Name:       templateName,
+						},
+					},
+				},
+			},
+		}
+	}
+
+	r := &MachineSetReconciler{
+		Client: fake.NewClientBuilder().WithObjects(
+			newMachineSet("ms1", template.GetKind(), template.GetName()),
+			newMachineSet("ms2", template.GetKind(), "other-template"),
+			newMachineSet("ms3", "OtherMachineTemplate", template.GetName()),
+		).Build(),
+	}
+
+	g.Expect(r.InfrastructureTemplateToMachineSets(template)).To(ConsistOf(
+		ctrl.Request{NamespacedName: client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "ms1"}},
+	))
+}
@@ -21,9 +21,11 @@ import (
 	"fmt"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -68,8 +70,46 @@ type ClusterReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// KubeconfigValidity is the lifespan of the client certificate of the Kubeconfigs generated by the Cluster controller;
+	// if not set, certs.DefaultCertDuration is used.
+	KubeconfigValidity time.Duration
+
+	// KubeconfigRotationThreshold is the remaining validity below which the client certificate of the Kubeconfigs
+	// generated by the Cluster controller is rotated; if not set, half of KubeconfigValidity is used.
+	KubeconfigRotationThreshold time.Duration
+
+	// DeletionTimeouts defines how long to wait for each phase of the Cluster deletion to complete before moving on
+	// with the next phase.
+	DeletionTimeouts ClusterDeletionTimeouts
+
+	// ControlPlaneEndpointProbeInterval is the interval at which the control plane endpoint of the initialized Clusters
+	// is probed from the management cluster; if not set, the control plane endpoint is not probed.
+	ControlPlaneEndpointProbeInterval time.Duration
+
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
+
+	// probeControlPlaneEndpoint allows to override the function used to probe the control plane endpoint; used in tests.
+	probeControlPlaneEndpoint controlPlaneEndpointProbeFunc
+
+	// lastControlPlaneEndpointProbe stores the time of the last control plane endpoint probe for each Cluster,
+	// so the endpoint is probed at most once per ControlPlaneEndpointProbeInterval no matter how often the Cluster is reconciled.
+	lastControlPlaneEndpointProbe sync.Map
+}
+
+// ClusterDeletionTimeouts defines how long to wait for each phase of the Cluster deletion to complete before moving on
+// with the next phase; a zero value means waiting until the phase completes.
+// NOTE: When the workers or the control plane phase times out, the deletion of the remaining objects continues in the background.
+type ClusterDeletionTimeouts struct {
+	// Workers is the timeout for the deletion of the worker Machines, MachineDeployments, MachineSets and MachinePools.
+	Workers time.Duration
+
+	// ControlPlane is the timeout for the deletion of the control plane.
+	ControlPlane time.Duration
+
+	// Infrastructure is the timeout for the deletion of the infrastructure; given that the Cluster is not removed
+	// before the infrastructure is gone, this timeout is only reported in the InfrastructureDeleted condition.
+	Infrastructure time.Duration
 }
 
 func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -77,7 +117,7 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		For(&clusterv1.Cluster{}).
 		Watches(
 			&source.Kind{Type: &clusterv1.Machine{}},
-			handler.EnqueueRequestsFromMapFunc(r.controlPlaneMachineToCluster),
+			handler.EnqueueRequestsFromMapFunc(r.machineToCluster),
 		).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
@@ -89,7 +129,8 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 
 	r.recorder = mgr.GetEventRecorderFor("cluster-controller")
 	r.externalTracker = external.ObjectTracker{
-		Controller: controller,
+		Controller:   controller,
+		MetadataOnly: true,
 	}
 	return nil
 }
@@ -126,6 +167,8 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 		// Always reconcile the Status.Phase field.
 		r.reconcilePhase(ctx, cluster)
 
+		annotations.SetLastReconcile(cluster, reterr)
+
 		// Always attempt to Patch the Cluster object and status after each reconciliation.
 		// Patch ObservedGeneration only if the reconciliation completed successfully
 		patchOpts := []patch.Option{}
@@ -153,6 +196,11 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 }
 
 func patchCluster(ctx context.Context, patchHelper *patch.Helper, cluster *clusterv1.Cluster, options ...patch.Option) error {
+	// Keep the deprecated failureReason/failureMessage fields and the NoTerminalFailure condition in sync.
+	// NOTE: The NoTerminalFailure condition is not owned by this controller, because providers and users can report
+	// terminal failures by setting it.
+	conditions.SyncClusterFailure(cluster)
+
 	// Always update the readyCondition by summarizing the state of other conditions.
 	conditions.SetSummary(cluster,
 		conditions.WithConditions(
@@ -169,6 +217,11 @@ func patchCluster(ctx context.Context, patchHelper *patch.Helper, cluster *clust
 			clusterv1.ReadyCondition,
 			clusterv1.ControlPlaneReadyCondition,
 			clusterv1.InfrastructureReadyCondition,
+			clusterv1.KubeconfigCertificateValidCondition,
+			clusterv1.ControlPlaneEndpointReachableCondition,
+			clusterv1.WorkersDeletedCondition,
+			clusterv1.ControlPlaneDeletedCondition,
+			clusterv1.InfrastructureDeletedCondition,
 		}},
 	)
 	return patchHelper.Patch(ctx, cluster, options...)
@@ -191,6 +244,8 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cl
 		r.reconcileControlPlane,
 		r.reconcileKubeconfig,
 		r.reconcileControlPlaneInitialized,
+		r.reconcileControlPlaneEndpointReachable,
+		r.reconcileMachinesSummary,
 	}
 
 	res := ctrl.Result{}
@@ -210,6 +265,9 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cl
 }
 
 // reconcileDelete handles cluster deletion.
+// The deletion happens in phases, each one reported by a condition on the Cluster: first the worker Machines are
+// deleted, together with the MachineDeployments, MachineSets and MachinePools managing them, then the control plane,
+// and finally the infrastructure. Each phase starts only after the previous one completed or timed out.
 func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Cluster) (reconcile.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -219,108 +277,189 @@ func (r *ClusterReconciler) reconcileDelete(ctx context.Context, cluster *cluste
 		return reconcile.Result{}, err
 	}
 
-	children, err := descendants.filterOwnedDescendants(cluster)
-	if err != nil {
-		log.Error(err, "Failed to extract direct descendants")
-		return reconcile.Result{}, err
+	done, err := r.reconcileDeleteWorkers(ctx, cluster, descendants)
+	if err != nil || !done {
+		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, err
 	}
 
-	if len(children) > 0 {
-		log.Info("Cluster still has children - deleting them first", "count", len(children))
+	done, err = r.reconcileDeleteControlPlane(ctx, cluster, descendants)
+	if err != nil || !done {
+		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, err
+	}
+
+	done, err = r.reconcileDeleteInfrastructure(ctx, cluster)
+	if err != nil || !done {
+		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, err
+	}
+
+	controllerutil.RemoveFinalizer(cluster, clusterv1.ClusterFinalizer)
+	deleteControlPlaneEndpointMetrics(cluster)
+	r.lastControlPlaneEndpointProbe.Delete(util.ObjectKey(cluster))
+	return ctrl.Result{}, nil
+}
+
+// reconcileDeleteWorkers deletes the worker Machines of the Cluster, together with the MachineDeployments, MachineSets
+// and MachinePools managing them; the Machine controller takes care of draining the Nodes.
+func (r *ClusterReconciler) reconcileDeleteWorkers(ctx context.Context, cluster *clusterv1.Cluster, descendants clusterDescendants) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
 
-		var errs []error
+	workers := descendants.workers()
+	if err := r.deleteOwnedDescendants(ctx, cluster, workers); err != nil {
+		return false, err
+	}
 
-		for _, child := range children {
-			if !child.GetDeletionTimestamp().IsZero() {
-				// Don't handle deleted child
-				continue
-			}
-			gvk := child.GetObjectKind().GroupVersionKind().String()
+	if workers.length() > 0 {
+		log.Info("Cluster still has worker descendants - need to requeue", "descendants", workers.descendantNames())
+	}
+	return r.deletionPhaseCompleted(ctx, cluster, clusterv1.WorkersDeletedCondition, r.DeletionTimeouts.Workers, workers.length() == 0, "Waiting for worker Machines to be deleted"), nil
+}
 
-			log.Info("Deleting child object", "gvk", gvk, "name", child.GetName())
-			if err := r.Client.Delete(ctx, child); err != nil {
-				err = errors.Wrapf(err, "error deleting cluster %s/%s: failed to delete %s %s", cluster.Namespace, cluster.Name, gvk, child.GetName())
-				log.Error(err, "Error deleting resource", "gvk", gvk, "name", child.GetName())
-				errs = append(errs, err)
-			}
+// reconcileDeleteControlPlane deletes the control plane object of the Cluster, or the control plane Machines if
+// the Cluster does not have a control plane provider.
+func (r *ClusterReconciler) reconcileDeleteControlPlane(ctx context.Context, cluster *clusterv1.Cluster, descendants clusterDescendants) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if cluster.Spec.ControlPlaneRef == nil {
+		controlPlane := descendants.controlPlane()
+		if err := r.deleteOwnedDescendants(ctx, cluster, controlPlane); err != nil {
+			return false, err
 		}
 
-		if len(errs) > 0 {
-			return ctrl.Result{}, kerrors.NewAggregate(errs)
+		if controlPlane.length() > 0 {
+			log.Info("Cluster still has control plane descendants - need to requeue", "descendants", controlPlane.descendantNames())
 		}
+		return r.deletionPhaseCompleted(ctx, cluster, clusterv1.ControlPlaneDeletedCondition, r.DeletionTimeouts.ControlPlane, controlPlane.length() == 0, "Waiting for control plane Machines to be deleted"), nil
 	}
 
-	if descendantCount := descendants.length(); descendantCount > 0 {
-		indirect := descendantCount - len(children)
-		log.Info("Cluster still has descendants - need to requeue", "descendants", descendants.descendantNames(), "indirect descendants count", indirect)
-		// Requeue so we can check the next time to see if there are still any descendants left.
-		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+	obj, err := external.Get(ctx, r.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	switch {
+	case apierrors.IsNotFound(errors.Cause(err)):
+		// All good - the control plane resource has been deleted
+		conditions.MarkFalse(cluster, clusterv1.ControlPlaneReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+		return r.deletionPhaseCompleted(ctx, cluster, clusterv1.ControlPlaneDeletedCondition, r.DeletionTimeouts.ControlPlane, true, ""), nil
+	case err != nil:
+		return false, errors.Wrapf(err, "failed to get %s %q for Cluster %s/%s",
+			path.Join(cluster.Spec.ControlPlaneRef.APIVersion, cluster.Spec.ControlPlaneRef.Kind),
+			cluster.Spec.ControlPlaneRef.Name, cluster.Namespace, cluster.Name)
 	}
 
-	if cluster.Spec.ControlPlaneRef != nil {
-		obj, err := external.Get(ctx, r.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
-		switch {
-		case apierrors.IsNotFound(errors.Cause(err)):
-			// All good - the control plane resource has been deleted
-			conditions.MarkFalse(cluster, clusterv1.ControlPlaneReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-		case err != nil:
-			return reconcile.Result{}, errors.Wrapf(err, "failed to get %s %q for Cluster %s/%s",
-				path.Join(cluster.Spec.ControlPlaneRef.APIVersion, cluster.Spec.ControlPlaneRef.Kind),
-				cluster.Spec.ControlPlaneRef.Name, cluster.Namespace, cluster.Name)
-		default:
-			// Report a summary of current status of the control plane object defined for this cluster.
-			conditions.SetMirror(cluster, clusterv1.ControlPlaneReadyCondition,
-				conditions.UnstructuredGetter(obj),
-				conditions.WithFallbackValue(false, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
-			)
-
-			// Issue a deletion request for the control plane object.
-			// Once it's been deleted, the cluster will get processed again.
-			if err := r.Client.Delete(ctx, obj); err != nil {
-				return ctrl.Result{}, errors.Wrapf(err,
-					"failed to delete %v %q for Cluster %q in namespace %q",
-					obj.GroupVersionKind(), obj.GetName(), cluster.Name, cluster.Namespace)
-			}
-
-			// Return here so we don't remove the finalizer yet.
-			log.Info("Cluster still has descendants - need to requeue", "controlPlaneRef", cluster.Spec.ControlPlaneRef.Name)
-			return ctrl.Result{}, nil
+	// Report a summary of current status of the control plane object defined for this cluster.
+	conditions.SetMirror(cluster, clusterv1.ControlPlaneReadyCondition,
+		conditions.UnstructuredGetter(obj),
+		conditions.WithFallbackValue(false, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
+	)
+
+	// Issue a deletion request for the control plane object.
+	// Once it's been deleted, the cluster will get processed again.
+	if obj.GetDeletionTimestamp().IsZero() {
+		if err := r.Client.Delete(ctx, obj); err != nil {
+			return false, errors.Wrapf(err,
+				"failed to delete %v %q for Cluster %q in namespace %q",
+				obj.GroupVersionKind(), obj.GetName(), cluster.Name, cluster.Namespace)
 		}
 	}
 
-	if cluster.Spec.InfrastructureRef != nil {
-		obj, err := external.Get(ctx, r.Client, cluster.Spec.InfrastructureRef, cluster.Namespace)
-		switch {
-		case apierrors.IsNotFound(errors.Cause(err)):
-			// All good - the infra resource has been deleted
-			conditions.MarkFalse(cluster, clusterv1.InfrastructureReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-		case err != nil:
-			return ctrl.Result{}, errors.Wrapf(err, "failed to get %s %q for Cluster %s/%s",
-				path.Join(cluster.Spec.InfrastructureRef.APIVersion, cluster.Spec.InfrastructureRef.Kind),
-				cluster.Spec.InfrastructureRef.Name, cluster.Namespace, cluster.Name)
-		default:
-			// Report a summary of current status of the infrastructure object defined for this cluster.
-			conditions.SetMirror(cluster, clusterv1.InfrastructureReadyCondition,
-				conditions.UnstructuredGetter(obj),
-				conditions.WithFallbackValue(false, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
-			)
-
-			// Issue a deletion request for the infrastructure object.
-			// Once it's been deleted, the cluster will get processed again.
-			if err := r.Client.Delete(ctx, obj); err != nil {
-				return ctrl.Result{}, errors.Wrapf(err,
-					"failed to delete %v %q for Cluster %q in namespace %q",
-					obj.GroupVersionKind(), obj.GetName(), cluster.Name, cluster.Namespace)
-			}
-
-			// Return here so we don't remove the finalizer yet.
-			log.Info("Cluster still has descendants - need to requeue", "infrastructureRef", cluster.Spec.InfrastructureRef.Name)
-			return ctrl.Result{}, nil
+	log.Info("Cluster still has descendants - need to requeue", "controlPlaneRef", cluster.Spec.ControlPlaneRef.Name)
+	return r.deletionPhaseCompleted(ctx, cluster, clusterv1.ControlPlaneDeletedCondition, r.DeletionTimeouts.ControlPlane, false, "Waiting for the control plane to be deleted"), nil
+}
+
+// reconcileDeleteInfrastructure deletes the infrastructure object of the Cluster.
+func (r *ClusterReconciler) reconcileDeleteInfrastructure(ctx context.Context, cluster *clusterv1.Cluster) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if cluster.Spec.InfrastructureRef == nil {
+		return r.deletionPhaseCompleted(ctx, cluster, clusterv1.InfrastructureDeletedCondition, r.DeletionTimeouts.Infrastructure, true, ""), nil
+	}
+
+	obj, err := external.Get(ctx, r.Client, cluster.Spec.InfrastructureRef, cluster.Namespace)
+	switch {
+	case apierrors.IsNotFound(errors.Cause(err)):
+		// All good - the infra resource has been deleted
+		conditions.MarkFalse(cluster, clusterv1.InfrastructureReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+		return r.deletionPhaseCompleted(ctx, cluster, clusterv1.InfrastructureDeletedCondition, r.DeletionTimeouts.Infrastructure, true, ""), nil
+	case err != nil:
+		return false, errors.Wrapf(err, "failed to get %s %q for Cluster %s/%s",
+			path.Join(cluster.Spec.InfrastructureRef.APIVersion, cluster.Spec.InfrastructureRef.Kind),
+			cluster.Spec.InfrastructureRef.Name, cluster.Namespace, cluster.Name)
+	}
+
+	// Report a summary of current status of the infrastructure object defined for this cluster.
+	conditions.SetMirror(cluster, clusterv1.InfrastructureReadyCondition,
+		conditions.UnstructuredGetter(obj),
+		conditions.WithFallbackValue(false, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
+	)
+
+	// Issue a deletion request for the infrastructure object.
+	// Once it's been deleted, the cluster will get processed again.
+	if obj.GetDeletionTimestamp().IsZero() {
+		if err := r.Client.Delete(ctx, obj); err != nil {
+			return false, errors.Wrapf(err,
+				"failed to delete %v %q for Cluster %q in namespace %q",
+				obj.GroupVersionKind(), obj.GetName(), cluster.Name, cluster.Namespace)
 		}
 	}
 
-	controllerutil.RemoveFinalizer(cluster, clusterv1.ClusterFinalizer)
-	return ctrl.Result{}, nil
+	log.Info("Cluster still has descendants - need to requeue", "infrastructureRef", cluster.Spec.InfrastructureRef.Name)
+	// NOTE: Removing the Cluster finalizer before the infrastructure is gone would orphan it, so when this phase
+	// times out the timeout is only reported, and the Cluster keeps waiting for the infrastructure to be deleted.
+	r.deletionPhaseCompleted(ctx, cluster, clusterv1.InfrastructureDeletedCondition, r.DeletionTimeouts.Infrastructure, false, "Waiting for the infrastructure to be deleted")
+	return false, nil
+}
+
+// deleteOwnedDescendants issues a deletion request for all the descendants owned by the Cluster
+// which are not already being deleted.
+func (r *ClusterReconciler) deleteOwnedDescendants(ctx context.Context, cluster *clusterv1.Cluster, descendants clusterDescendants) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	children, err := descendants.filterOwnedDescendants(cluster)
+	if err != nil {
+		log.Error(err, "Failed to extract direct descendants")
+		return err
+	}
+
+	var errs []error
+	for _, child := range children {
+		if !child.GetDeletionTimestamp().IsZero() {
+			// Don't handle deleted child
+			continue
+		}
+		gvk := child.GetObjectKind().GroupVersionKind().String()
+
+		log.Info("Deleting child object", "gvk", gvk, "name", child.GetName())
+		if err := r.Client.Delete(ctx, child); err != nil {
+			err = errors.Wrapf(err, "error deleting cluster %s/%s: failed to delete %s %s", cluster.Namespace, cluster.Name, gvk, child.GetName())
+			log.Error(err, "Error deleting resource", "gvk", gvk, "name", child.GetName())
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// deletionPhaseCompleted updates the condition reporting a phase of the Cluster deletion, and returns true if the phase
+// completed, or if it did not complete within the given timeout, thus allowing the deletion to move on with the next phase.
+// NOTE: The timeout is computed from the last transition time of the condition, and for this reason the message
+// reported while the phase is in progress must not change.
+func (r *ClusterReconciler) deletionPhaseCompleted(ctx context.Context, cluster *clusterv1.Cluster, conditionType clusterv1.ConditionType, timeout time.Duration, completed bool, message string) bool {
+	if completed {
+		conditions.MarkTrue(cluster, conditionType)
+		return true
+	}
+
+	if conditions.GetReason(cluster, conditionType) == clusterv1.DeletionTimedOutReason {
+		return true
+	}
+
+	if c := conditions.Get(cluster, conditionType); timeout > 0 && c != nil && c.Reason == clusterv1.DeletingReason && time.Since(c.LastTransitionTime.Time) > timeout {
+		ctrl.LoggerFrom(ctx).Info("Cluster deletion phase timed out, moving on with the next phase", "phase", conditionType, "timeout", timeout.String())
+		conditions.MarkFalse(cluster, conditionType, clusterv1.DeletionTimedOutReason, clusterv1.ConditionSeverityWarning, "%s timed out after %s", message, timeout.String())
+		if r.recorder != nil {
+			r.recorder.Eventf(cluster, corev1.EventTypeWarning, "DeletionTimedOut", "%s timed out after %s, moving on with the next deletion phase", message, timeout.String())
+		}
+		return true
+	}
+
+	conditions.MarkFalse(cluster, conditionType, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, message)
+	return false
 }
 
 type clusterDescendants struct {
@@ -331,6 +470,19 @@ type clusterDescendants struct {
 	machinePools         expv1.MachinePoolList
 }
 
+// workers returns the descendants of the Cluster which are deleted in the first phase of the Cluster deletion,
+// i.e. all the descendants but the control plane Machines.
+func (c clusterDescendants) workers() clusterDescendants {
+	c.controlPlaneMachines = clusterv1.MachineList{}
+	return c
+}
+
+// controlPlane returns the descendants of the Cluster which are deleted in the control plane phase of the Cluster
+// deletion, i.e. the control plane Machines if the Cluster does not have a control plane provider.
+func (c clusterDescendants) controlPlane() clusterDescendants {
+	return clusterDescendants{controlPlaneMachines: c.controlPlaneMachines}
+}
+
 // length returns the number of descendants.
 func (c *clusterDescendants) length() int {
 	return len(c.machineDeployments.Items) +
@@ -494,30 +646,18 @@ func (r *ClusterReconciler) reconcileControlPlaneInitialized(ctx context.Context
 	return ctrl.Result{}, nil
 }
 
-// controlPlaneMachineToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
-// for Cluster to update its status.controlPlaneInitialized field.
-func (r *ClusterReconciler) controlPlaneMachineToCluster(o client.Object) []ctrl.Request {
+// machineToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for Cluster to update its status.controlPlaneInitialized and status.machines fields.
+func (r *ClusterReconciler) machineToCluster(o client.Object) []ctrl.Request {
 	m, ok := o.(*clusterv1.Machine)
 	if !ok {
 		panic(fmt.Sprintf("Expected a Machine but got a %T", o))
 	}
-	if !util.IsControlPlaneMachine(m) {
-		return nil
-	}
-	if m.Status.NodeRef == nil {
-		return nil
-	}
-
-	cluster, err := util.GetClusterByName(context.TODO(), r.Client, m.Namespace, m.Spec.ClusterName)
-	if err != nil {
-		return nil
-	}
-
-	if conditions.IsTrue(cluster, clusterv1.ControlPlaneInitializedCondition) {
+	if m.Spec.ClusterName == "" {
 		return nil
 	}
 
 	return []ctrl.Request{{
-		NamespacedName: util.ObjectKey(cluster),
+		NamespacedName: client.ObjectKey{Namespace: m.Namespace, Name: m.Spec.ClusterName},
 	}}
 }
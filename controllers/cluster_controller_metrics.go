@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// controlPlaneEndpointReachable reports if the control plane endpoint of a Cluster was reachable at the last probe.
+	controlPlaneEndpointReachable = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_cluster_control_plane_endpoint_reachable",
+			Help: "Whether the control plane endpoint of the Cluster was reachable from the management cluster at the last probe (1) or not (0).",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	// controlPlaneEndpointProbeFailures counts the failed probes of the control plane endpoint of a Cluster.
+	controlPlaneEndpointProbeFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capi_cluster_control_plane_endpoint_probe_failures_total",
+			Help: "Total number of failed probes of the control plane endpoint of the Cluster from the management cluster, by reason.",
+		},
+		[]string{"namespace", "name", "reason"},
+	)
+
+	// controlPlaneEndpointProbeDuration reports the duration of the probes of the control plane endpoints.
+	controlPlaneEndpointProbeDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "capi_cluster_control_plane_endpoint_probe_duration_seconds",
+			Help:    "Duration of the probes of the control plane endpoint of the Clusters from the management cluster.",
+			Buckets: []float64{0.005, 0.01, 0.05, 0.1, 0.5, 1, 2.5, 5, 10},
+		},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		controlPlaneEndpointReachable,
+		controlPlaneEndpointProbeFailures,
+		controlPlaneEndpointProbeDuration,
+	)
+}
+
+// deleteControlPlaneEndpointMetrics deletes the control plane endpoint metrics for a Cluster.
+func deleteControlPlaneEndpointMetrics(cluster *clusterv1.Cluster) {
+	controlPlaneEndpointReachable.DeleteLabelValues(cluster.Namespace, cluster.Name)
+	for _, reason := range []string{clusterv1.ControlPlaneEndpointNotResolvableReason, clusterv1.ControlPlaneEndpointUnreachableReason} {
+		controlPlaneEndpointProbeFailures.DeleteLabelValues(cluster.Namespace, cluster.Name, reason)
+	}
+}
@@ -19,22 +19,30 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"net"
 	"time"
 
+	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/requeue"
 	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/cluster-api/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -259,26 +267,282 @@ func (r *ClusterReconciler) reconcileKubeconfig(ctx context.Context, cluster *cl
 		return ctrl.Result{}, nil
 	}
 
-	// Do not generate the Kubeconfig if there is a ControlPlaneRef, since the Control Plane provider is
-	// responsible for the management of the Kubeconfig. We continue to manage it here only for backward
-	// compatibility when a Control Plane provider is not in use.
-	if cluster.Spec.ControlPlaneRef != nil {
-		return ctrl.Result{}, nil
-	}
-
-	_, err := secret.Get(ctx, r.Client, util.ObjectKey(cluster), secret.Kubeconfig)
+	configSecret, err := secret.Get(ctx, r.Client, util.ObjectKey(cluster), secret.Kubeconfig)
 	switch {
 	case apierrors.IsNotFound(err):
-		if err := kubeconfig.CreateSecret(ctx, r.Client, cluster); err != nil {
+		// Do not generate the Kubeconfig if there is a ControlPlaneRef, since the Control Plane provider is
+		// responsible for the management of the Kubeconfig. We continue to manage it here only for backward
+		// compatibility when a Control Plane provider is not in use.
+		if cluster.Spec.ControlPlaneRef != nil {
+			return ctrl.Result{}, nil
+		}
+		err := kubeconfig.CreateSecret(ctx, r.Client, cluster, kubeconfig.WithClientCertValidity(r.KubeconfigValidity))
+		if errors.Is(err, kubeconfig.ErrExternalCA) {
+			// The cluster CA is managed externally, so the Kubeconfig client certificate must be signed by the external CA.
+			err = kubeconfig.CreateSecretFromCSR(ctx, r.Client, util.ObjectKey(cluster), cluster.Spec.ControlPlaneEndpoint.String(), metav1.OwnerReference{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Cluster",
+				Name:       cluster.Name,
+				UID:        cluster.UID,
+			})
+			if errors.Is(err, kubeconfig.ErrCSRNotSigned) {
+				log.Info("Waiting for the Kubeconfig certificate signing request to be signed by the external CA", "secret", secret.Name(cluster.Name, secret.KubeconfigCSR))
+				return requeue.AfterWithJitter(30 * time.Second), nil
+			}
+		}
+		if err != nil {
 			if err == kubeconfig.ErrDependentCertificateNotFound {
 				log.Info("could not find secret for cluster, requeuing", "secret", secret.ClusterCA)
-				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+				return requeue.AfterWithJitter(30 * time.Second), nil
 			}
 			return ctrl.Result{}, err
 		}
+		// Always return if we have just created the Kubeconfig in order to skip rotation checks.
+		return ctrl.Result{}, nil
 	case err != nil:
 		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
 	}
 
+	return r.reconcileKubeconfigRotation(ctx, cluster, configSecret)
+}
+
+// reconcileKubeconfigRotation rotates the Kubeconfig client certificate before its expiry, if the Kubeconfig has been
+// generated by the Cluster controller, and surfaces the certificate expiry in the KubeconfigCertificateValid condition.
+// NOTE: Kubeconfigs managed by a Control Plane provider are rotated by the provider itself.
+func (r *ClusterReconciler) reconcileKubeconfigRotation(ctx context.Context, cluster *clusterv1.Cluster, configSecret *corev1.Secret) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	expiry, err := kubeconfig.ClientCertExpiry(configSecret)
+	if err != nil {
+		// Kubeconfig Secrets could be provided by users or by other controllers, so failing to parse them
+		// should not block the Cluster reconcile.
+		log.Error(err, "Failed to get the client certificate expiry from the Kubeconfig Secret", "secret", configSecret.Name)
+		conditions.Delete(cluster, clusterv1.KubeconfigCertificateValidCondition)
+		return ctrl.Result{}, nil
+	}
+	if expiry == nil {
+		conditions.Delete(cluster, clusterv1.KubeconfigCertificateValidCondition)
+		return ctrl.Result{}, nil
+	}
+
+	ownedByCluster := util.HasOwnerRef(configSecret.OwnerReferences, metav1.OwnerReference{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Cluster",
+		Name:       cluster.Name,
+	})
+	if cluster.Spec.ControlPlaneRef == nil && ownedByCluster && time.Until(*expiry) < r.kubeconfigRotationThreshold() {
+		err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret, kubeconfig.WithClientCertValidity(r.KubeconfigValidity))
+		switch {
+		case errors.Is(err, kubeconfig.ErrExternalCA):
+			// Kubeconfigs signed by an external CA can't be rotated by Cluster API; only the expiry is surfaced.
+			log.Info("Skipping Kubeconfig rotation, the cluster CA is managed externally", "secret", configSecret.Name)
+		case err != nil:
+			conditions.MarkFalse(cluster, clusterv1.KubeconfigCertificateValidCondition, clusterv1.KubeconfigCertificateRotationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return ctrl.Result{}, errors.Wrapf(err, "failed to rotate Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
+		default:
+			if expiry, err = kubeconfig.ClientCertExpiry(configSecret); err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "failed to get the client certificate expiry from the rotated Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
+			}
+			if expiry == nil {
+				return ctrl.Result{}, errors.Errorf("rotated Kubeconfig Secret for Cluster %q in namespace %q has no client certificate", cluster.Name, cluster.Namespace)
+			}
+			log.Info("Rotated Kubeconfig client certificate", "secret", configSecret.Name, "expiry", expiry.Format(time.RFC3339))
+			if r.recorder != nil {
+				r.recorder.Eventf(cluster, corev1.EventTypeNormal, "KubeconfigRotated", "Rotated Kubeconfig client certificate, new certificate expires on %s", expiry.Format(time.RFC3339))
+			}
+		}
+	}
+
+	remaining := time.Until(*expiry)
+	if remaining <= 0 {
+		conditions.MarkFalse(cluster, clusterv1.KubeconfigCertificateValidCondition, clusterv1.KubeconfigCertificateExpiredReason, clusterv1.ConditionSeverityError,
+			"Kubeconfig client certificate expired on %s", expiry.Format(time.RFC3339))
+		return ctrl.Result{}, nil
+	}
+	conditions.Set(cluster, &clusterv1.Condition{
+		Type:    clusterv1.KubeconfigCertificateValidCondition,
+		Status:  corev1.ConditionTrue,
+		Message: fmt.Sprintf("Kubeconfig client certificate expires in %d days", int(remaining.Hours()/24)),
+	})
 	return ctrl.Result{}, nil
 }
+
+// kubeconfigRotationThreshold returns the remaining validity below which the Kubeconfig client certificate is rotated.
+func (r *ClusterReconciler) kubeconfigRotationThreshold() time.Duration {
+	if r.KubeconfigRotationThreshold != 0 {
+		return r.KubeconfigRotationThreshold
+	}
+	if r.KubeconfigValidity != 0 {
+		return r.KubeconfigValidity / 2
+	}
+	return certs.ClientCertificateRenewalDuration
+}
+
+// controlPlaneEndpointProbeTimeout is the timeout for probing the control plane endpoint of a Cluster.
+const controlPlaneEndpointProbeTimeout = 5 * time.Second
+
+// controlPlaneEndpointProbeFunc probes the control plane endpoint of a Cluster; if the endpoint is not reachable,
+// it returns the reason and the error.
+type controlPlaneEndpointProbeFunc func(ctx context.Context, endpoint clusterv1.APIEndpoint) (string, error)
+
+// probeControlPlaneEndpoint checks that the control plane endpoint host can be resolved, and that the endpoint
+// accepts TCP connections.
+func probeControlPlaneEndpoint(ctx context.Context, endpoint clusterv1.APIEndpoint) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, controlPlaneEndpointProbeTimeout)
+	defer cancel()
+
+	if net.ParseIP(endpoint.Host) == nil {
+		if _, err := net.DefaultResolver.LookupHost(ctx, endpoint.Host); err != nil {
+			return clusterv1.ControlPlaneEndpointNotResolvableReason, errors.Wrapf(err, "failed to resolve control plane endpoint host %s", endpoint.Host)
+		}
+	}
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", endpoint.String())
+	if err != nil {
+		return clusterv1.ControlPlaneEndpointUnreachableReason, errors.Wrapf(err, "failed to connect to control plane endpoint %s", endpoint.String())
+	}
+	_ = conn.Close()
+	return "", nil
+}
+
+// reconcileControlPlaneEndpointReachable periodically probes the control plane endpoint of the Cluster from the management cluster,
+// and reports the result in the ControlPlaneEndpointReachable condition, so e.g. DNS or load balancer misconfigurations are surfaced
+// on the Cluster object.
+func (r *ClusterReconciler) reconcileControlPlaneEndpointReachable(ctx context.Context, cluster *clusterv1.Cluster) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// When probing is disabled, remove the results of previous probes, so a stale condition is not reported.
+	if r.ControlPlaneEndpointProbeInterval <= 0 {
+		conditions.Delete(cluster, clusterv1.ControlPlaneEndpointReachableCondition)
+		deleteControlPlaneEndpointMetrics(cluster)
+		r.lastControlPlaneEndpointProbe.Delete(util.ObjectKey(cluster))
+		return ctrl.Result{}, nil
+	}
+
+	// The control plane endpoint is not expected to be reachable until the control plane is initialized.
+	if !cluster.Spec.ControlPlaneEndpoint.IsValid() || !conditions.IsTrue(cluster, clusterv1.ControlPlaneInitializedCondition) {
+		return ctrl.Result{}, nil
+	}
+
+	// Skip the probe if the endpoint has been probed recently; the result of the last probe is preserved in the condition.
+	key := util.ObjectKey(cluster)
+	if last, ok := r.lastControlPlaneEndpointProbe.Load(key); ok {
+		if elapsed := time.Since(last.(time.Time)); elapsed < r.ControlPlaneEndpointProbeInterval {
+			return ctrl.Result{RequeueAfter: r.ControlPlaneEndpointProbeInterval - elapsed}, nil
+		}
+	}
+
+	probe := r.probeControlPlaneEndpoint
+	if probe == nil {
+		probe = probeControlPlaneEndpoint
+	}
+
+	start := time.Now()
+	reason, err := probe(ctx, cluster.Spec.ControlPlaneEndpoint)
+	controlPlaneEndpointProbeDuration.Observe(time.Since(start).Seconds())
+	r.lastControlPlaneEndpointProbe.Store(key, start)
+
+	if err != nil {
+		log.V(2).Info("Control plane endpoint is not reachable", "endpoint", cluster.Spec.ControlPlaneEndpoint.String(), "reason", reason, "err", err.Error())
+		controlPlaneEndpointReachable.WithLabelValues(cluster.Namespace, cluster.Name).Set(0)
+		controlPlaneEndpointProbeFailures.WithLabelValues(cluster.Namespace, cluster.Name, reason).Inc()
+		conditions.MarkFalse(cluster, clusterv1.ControlPlaneEndpointReachableCondition, reason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{RequeueAfter: r.ControlPlaneEndpointProbeInterval}, nil
+	}
+
+	controlPlaneEndpointReachable.WithLabelValues(cluster.Namespace, cluster.Name).Set(1)
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneEndpointReachableCondition)
+	return ctrl.Result{RequeueAfter: r.ControlPlaneEndpointProbeInterval}, nil
+}
+
+// reconcileMachinesSummary computes a summary of the state of the Machines belonging to the Cluster.
+func (r *ClusterReconciler) reconcileMachinesSummary(ctx context.Context, cluster *clusterv1.Cluster) (ctrl.Result, error) {
+	machines, err := collections.GetFilteredMachinesForCluster(ctx, r.Client, cluster)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to list Machines for Cluster %s", cluster.Name)
+	}
+
+	desiredVersion, err := r.getControlPlaneVersion(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	summary := &clusterv1.ClusterMachinesSummary{}
+	var minVersion, maxVersion *semver.Version
+	for _, m := range machines {
+		summary.Total++
+		if m.Status.NodeRef != nil && conditions.IsTrue(m, clusterv1.MachineNodeHealthyCondition) {
+			summary.Ready++
+		}
+
+		if m.Spec.Version == nil {
+			continue
+		}
+		v, err := version.ParseMajorMinorPatchTolerant(*m.Spec.Version)
+		if err != nil {
+			// Machines with an invalid version are not considered when computing the version summary.
+			continue
+		}
+		if minVersion == nil || v.LT(*minVersion) {
+			minVersion = &v
+		}
+		if maxVersion == nil || v.GT(*maxVersion) {
+			maxVersion = &v
+		}
+	}
+
+	if minVersion != nil {
+		summary.MinVersion = pointer.StringPtr("v" + minVersion.String())
+		summary.MaxVersion = pointer.StringPtr("v" + maxVersion.String())
+	}
+
+	// If the control plane does not define a version, Machines are up-to-date when running the highest version.
+	if desiredVersion == nil && maxVersion != nil {
+		desiredVersion = maxVersion
+	}
+	if desiredVersion != nil {
+		for _, m := range machines {
+			if m.Spec.Version == nil {
+				continue
+			}
+			if v, err := version.ParseMajorMinorPatchTolerant(*m.Spec.Version); err == nil && v.EQ(*desiredVersion) {
+				summary.UpToDate++
+			}
+		}
+	}
+
+	cluster.Status.Machines = summary
+	return ctrl.Result{}, nil
+}
+
+// getControlPlaneVersion returns the Kubernetes version defined in the control plane object referenced by the Cluster, if any.
+func (r *ClusterReconciler) getControlPlaneVersion(ctx context.Context, cluster *clusterv1.Cluster) (*semver.Version, error) {
+	if cluster.Spec.ControlPlaneRef == nil {
+		return nil, nil
+	}
+
+	controlPlane, err := external.Get(ctx, r.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	v, ok, err := unstructured.NestedString(controlPlane.Object, "spec", "version")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get version from %s %s", cluster.Spec.ControlPlaneRef.Kind, cluster.Spec.ControlPlaneRef.Name)
+	}
+	// Control plane objects are not required to define a version.
+	if !ok {
+		return nil, nil
+	}
+	parsed, err := version.ParseMajorMinorPatchTolerant(v)
+	if err != nil {
+		// An invalid control plane version must not block the reconcile of the Cluster; it is handled as if
+		// the control plane does not define a version.
+		ctrl.LoggerFrom(ctx).Info("Ignoring invalid control plane version when computing the Machines summary", "version", v, "error", err.Error())
+		return nil, nil
+	}
+	return &parsed, nil
+}
@@ -17,16 +17,27 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
-	"sigs.k8s.io/cluster-api/internal/builder"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -231,6 +242,142 @@ func TestClusterReconcilePhases(t *testing.T) {
 	})
 }
 
+func TestClusterReconciler_reconcileKubeconfigRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	ca := secret.NewCertificatesForWorker("").GetByPurpose(secret.ClusterCA)
+	g.Expect(ca.Generate()).To(Succeed())
+	caCert, err := certs.DecodeCertPEM(ca.KeyPair.Cert)
+	g.Expect(err).NotTo(HaveOccurred())
+	caKey, err := certs.DecodePrivateKeyPEM(ca.KeyPair.Key)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// kubeconfigSecret returns a Kubeconfig secret with a client certificate valid for the given duration.
+	kubeconfigSecret := func(cluster *clusterv1.Cluster, validity time.Duration) *corev1.Secret {
+		clientKey, err := certs.NewPrivateKey()
+		g.Expect(err).NotTo(HaveOccurred())
+		clientCert, err := (&certs.Config{
+			CommonName: "kubernetes-admin",
+			Usages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			Duration:   validity,
+		}).NewSignedCert(clientKey, caCert, caKey)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		data := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: %[1]s
+  cluster:
+    server: https://1.2.3.4:8443
+    certificate-authority-data: %[2]s
+contexts:
+- name: %[1]s-admin@%[1]s
+  context:
+    cluster: %[1]s
+    user: %[1]s-admin
+current-context: %[1]s-admin@%[1]s
+users:
+- name: %[1]s-admin
+  user:
+    client-certificate-data: %[3]s
+    client-key-data: %[4]s
+`, cluster.Name,
+			base64.StdEncoding.EncodeToString(ca.KeyPair.Cert),
+			base64.StdEncoding.EncodeToString(certs.EncodeCertPEM(clientCert)),
+			base64.StdEncoding.EncodeToString(certs.EncodePrivateKeyPEM(clientKey)))
+		return kubeconfig.GenerateSecret(cluster, []byte(data))
+	}
+
+	newCluster := func(controlPlaneRef *corev1.ObjectReference) *clusterv1.Cluster {
+		return &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "1.2.3.4", Port: 8443},
+				ControlPlaneRef:      controlPlaneRef,
+			},
+		}
+	}
+	controlPlaneRef := &corev1.ObjectReference{Kind: "KubeadmControlPlane", Name: "test-cluster"}
+
+	tests := []struct {
+		name            string
+		cluster         *clusterv1.Cluster
+		validity        time.Duration
+		wantStatus      corev1.ConditionStatus
+		wantReason      string
+		wantMessage     string
+		wantRotated     bool
+		wantMinValidity time.Duration
+	}{
+		{
+			name:        "surfaces the days to expiry of Kubeconfigs managed by the control plane provider",
+			cluster:     newCluster(controlPlaneRef),
+			validity:    30*24*time.Hour + time.Hour,
+			wantStatus:  corev1.ConditionTrue,
+			wantMessage: "Kubeconfig client certificate expires in 30 days",
+		},
+		{
+			name:       "does not rotate Kubeconfigs managed by the control plane provider",
+			cluster:    newCluster(controlPlaneRef),
+			validity:   -time.Hour,
+			wantStatus: corev1.ConditionFalse,
+			wantReason: clusterv1.KubeconfigCertificateExpiredReason,
+		},
+		{
+			name:        "does not rotate Kubeconfigs before the rotation threshold",
+			cluster:     newCluster(nil),
+			validity:    300 * 24 * time.Hour,
+			wantStatus:  corev1.ConditionTrue,
+			wantMessage: "Kubeconfig client certificate expires in 299 days",
+		},
+		{
+			name:            "rotates Kubeconfigs after the rotation threshold",
+			cluster:         newCluster(nil),
+			validity:        time.Hour,
+			wantStatus:      corev1.ConditionTrue,
+			wantRotated:     true,
+			wantMinValidity: certs.DefaultCertDuration - time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			configSecret := kubeconfigSecret(tt.cluster, tt.validity)
+			c := fake.NewClientBuilder().
+				WithObjects(tt.cluster, configSecret, ca.AsSecret(util.ObjectKey(tt.cluster), metav1.OwnerReference{})).
+				Build()
+			r := &ClusterReconciler{
+				Client: c,
+			}
+
+			_, err := r.reconcileKubeconfig(ctx, tt.cluster)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			condition := conditions.Get(tt.cluster, clusterv1.KubeconfigCertificateValidCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantStatus))
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+			if tt.wantMessage != "" {
+				g.Expect(condition.Message).To(Equal(tt.wantMessage))
+			}
+
+			gotSecret := &corev1.Secret{}
+			g.Expect(c.Get(ctx, util.ObjectKey(configSecret), gotSecret)).To(Succeed())
+			if !tt.wantRotated {
+				g.Expect(gotSecret.Data).To(Equal(configSecret.Data))
+				return
+			}
+			expiry, err := kubeconfig.ClientCertExpiry(gotSecret)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(time.Until(*expiry)).To(BeNumerically(">", tt.wantMinValidity))
+		})
+	}
+}
+
 func TestClusterReconciler_reconcilePhase(t *testing.T) {
 	cluster := &clusterv1.Cluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -370,3 +517,269 @@ func TestClusterReconciler_reconcilePhase(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterReconciler_reconcileMachinesSummary(t *testing.T) {
+	newMachine := func(name, version string, ready bool, labels map[string]string) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test-namespace",
+				Labels:    map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: "test-cluster",
+			},
+		}
+		for k, v := range labels {
+			m.Labels[k] = v
+		}
+		if version != "" {
+			m.Spec.Version = pointer.StringPtr(version)
+		}
+		if ready {
+			m.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: name}
+			conditions.MarkTrue(m, clusterv1.MachineNodeHealthyCondition)
+		}
+		return m
+	}
+	controlPlaneLabels := map[string]string{clusterv1.MachineControlPlaneLabelName: ""}
+
+	tests := []struct {
+		name         string
+		controlPlane *unstructured.Unstructured
+		machines     []client.Object
+		want         *clusterv1.ClusterMachinesSummary
+	}{
+		{
+			name: "cluster without machines",
+			want: &clusterv1.ClusterMachinesSummary{},
+		},
+		{
+			name:         "machines are up-to-date when running the control plane version",
+			controlPlane: builder.ControlPlane("test-namespace", "cp").WithVersion("v1.22.1").Build(),
+			machines: []client.Object{
+				newMachine("cp-1", "v1.22.1", true, controlPlaneLabels),
+				newMachine("cp-2", "v1.21.3", true, controlPlaneLabels),
+				newMachine("worker-1", "v1.21.3", true, nil),
+				newMachine("worker-2", "v1.22.1", false, nil),
+				newMachine("worker-3", "", false, nil),
+			},
+			want: &clusterv1.ClusterMachinesSummary{
+				Total:      5,
+				Ready:      3,
+				UpToDate:   2,
+				MinVersion: pointer.StringPtr("v1.21.3"),
+				MaxVersion: pointer.StringPtr("v1.22.1"),
+			},
+		},
+		{
+			name: "machines are up-to-date when running the highest version if there is no control plane",
+			machines: []client.Object{
+				newMachine("machine-1", "v1.20.0", true, nil),
+				newMachine("machine-2", "v1.22.0", true, nil),
+				newMachine("machine-3", "v1.22.0", true, nil),
+			},
+			want: &clusterv1.ClusterMachinesSummary{
+				Total:      3,
+				Ready:      3,
+				UpToDate:   2,
+				MinVersion: pointer.StringPtr("v1.20.0"),
+				MaxVersion: pointer.StringPtr("v1.22.0"),
+			},
+		},
+		{
+			name:         "invalid control plane versions are ignored",
+			controlPlane: builder.ControlPlane("test-namespace", "cp").WithVersion("not-a-version").Build(),
+			machines: []client.Object{
+				newMachine("cp-1", "v1.22.1", true, controlPlaneLabels),
+				newMachine("worker-1", "v1.21.3", true, nil),
+			},
+			want: &clusterv1.ClusterMachinesSummary{
+				Total:      2,
+				Ready:      2,
+				UpToDate:   1,
+				MinVersion: pointer.StringPtr("v1.21.3"),
+				MaxVersion: pointer.StringPtr("v1.22.1"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterBuilder := builder.Cluster("test-namespace", "test-cluster")
+			objs := append([]client.Object{}, tt.machines...)
+			if tt.controlPlane != nil {
+				clusterBuilder = clusterBuilder.WithControlPlane(tt.controlPlane)
+				objs = append(objs, tt.controlPlane)
+			}
+			cluster := clusterBuilder.Build()
+
+			r := &ClusterReconciler{
+				Client: fake.NewClientBuilder().WithObjects(objs...).Build(),
+			}
+			_, err := r.reconcileMachinesSummary(ctx, cluster)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(cluster.Status.Machines).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterReconciler_reconcileControlPlaneEndpointReachable(t *testing.T) {
+	newCluster := func(endpoint clusterv1.APIEndpoint, initialized bool) *clusterv1.Cluster {
+		c := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "test-namespace",
+			},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneEndpoint: endpoint,
+			},
+		}
+		if initialized {
+			conditions.MarkTrue(c, clusterv1.ControlPlaneInitializedCondition)
+		}
+		return c
+	}
+	endpoint := clusterv1.APIEndpoint{Host: "cp.example.com", Port: 6443}
+	probedCluster := newCluster(endpoint, true)
+	conditions.MarkTrue(probedCluster, clusterv1.ControlPlaneEndpointReachableCondition)
+
+	tests := []struct {
+		name          string
+		cluster       *clusterv1.Cluster
+		interval      time.Duration
+		probeReason   string
+		probeErr      error
+		wantProbed    bool
+		wantCondition *clusterv1.Condition
+		wantResult    ctrl.Result
+	}{
+		{
+			name:     "does not probe when probing is disabled",
+			cluster:  newCluster(endpoint, true),
+			interval: 0,
+		},
+		{
+			name:     "removes the condition when probing is disabled",
+			cluster:  probedCluster,
+			interval: 0,
+		},
+		{
+			name:     "does not probe when the control plane endpoint is not set",
+			cluster:  newCluster(clusterv1.APIEndpoint{}, true),
+			interval: time.Minute,
+		},
+		{
+			name:     "does not probe when the control plane is not initialized",
+			cluster:  newCluster(endpoint, false),
+			interval: time.Minute,
+		},
+		{
+			name:          "marks the condition true when the control plane endpoint is reachable",
+			cluster:       newCluster(endpoint, true),
+			interval:      time.Minute,
+			wantProbed:    true,
+			wantCondition: conditions.TrueCondition(clusterv1.ControlPlaneEndpointReachableCondition),
+			wantResult:    ctrl.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name:        "marks the condition false when the control plane endpoint cannot be resolved",
+			cluster:     newCluster(endpoint, true),
+			interval:    time.Minute,
+			probeReason: clusterv1.ControlPlaneEndpointNotResolvableReason,
+			probeErr:    errors.New("no such host"),
+			wantProbed:  true,
+			wantCondition: conditions.FalseCondition(clusterv1.ControlPlaneEndpointReachableCondition,
+				clusterv1.ControlPlaneEndpointNotResolvableReason, clusterv1.ConditionSeverityWarning, "no such host"),
+			wantResult: ctrl.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name:        "marks the condition false when the control plane endpoint is not reachable",
+			cluster:     newCluster(endpoint, true),
+			interval:    time.Minute,
+			probeReason: clusterv1.ControlPlaneEndpointUnreachableReason,
+			probeErr:    errors.New("connection refused"),
+			wantProbed:  true,
+			wantCondition: conditions.FalseCondition(clusterv1.ControlPlaneEndpointReachableCondition,
+				clusterv1.ControlPlaneEndpointUnreachableReason, clusterv1.ConditionSeverityWarning, "connection refused"),
+			wantResult: ctrl.Result{RequeueAfter: time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			probed := false
+			r := &ClusterReconciler{
+				ControlPlaneEndpointProbeInterval: tt.interval,
+				probeControlPlaneEndpoint: func(_ context.Context, e clusterv1.APIEndpoint) (string, error) {
+					probed = true
+					g.Expect(e).To(Equal(tt.cluster.Spec.ControlPlaneEndpoint))
+					return tt.probeReason, tt.probeErr
+				},
+			}
+
+			res, err := r.reconcileControlPlaneEndpointReachable(ctx, tt.cluster)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(res).To(Equal(tt.wantResult))
+			g.Expect(probed).To(Equal(tt.wantProbed))
+
+			got := conditions.Get(tt.cluster, clusterv1.ControlPlaneEndpointReachableCondition)
+			if tt.wantCondition == nil {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).NotTo(BeNil())
+			g.Expect(got.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(got.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(got.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(got.Message).To(Equal(tt.wantCondition.Message))
+			deleteControlPlaneEndpointMetrics(tt.cluster)
+		})
+	}
+}
+
+func TestClusterReconciler_reconcileControlPlaneEndpointReachableRateLimit(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "test-namespace",
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "cp.example.com", Port: 6443},
+		},
+	}
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+	defer deleteControlPlaneEndpointMetrics(cluster)
+
+	probes := 0
+	r := &ClusterReconciler{
+		ControlPlaneEndpointProbeInterval: time.Minute,
+		probeControlPlaneEndpoint: func(_ context.Context, _ clusterv1.APIEndpoint) (string, error) {
+			probes++
+			return "", nil
+		},
+	}
+
+	res, err := r.reconcileControlPlaneEndpointReachable(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(time.Minute))
+	g.Expect(probes).To(Equal(1))
+
+	// A reconcile within the probe interval does not probe the endpoint again, and requeues for the next probe.
+	res, err = r.reconcileControlPlaneEndpointReachable(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(And(BeNumerically(">", 0), BeNumerically("<=", time.Minute)))
+	g.Expect(probes).To(Equal(1))
+	g.Expect(conditions.IsTrue(cluster, clusterv1.ControlPlaneEndpointReachableCondition)).To(BeTrue())
+
+	// A reconcile after the probe interval probes the endpoint again.
+	r.lastControlPlaneEndpointProbe.Store(util.ObjectKey(cluster), time.Now().Add(-time.Minute))
+	_, err = r.reconcileControlPlaneEndpointReachable(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(probes).To(Equal(2))
+}
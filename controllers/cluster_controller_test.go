@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +30,7 @@ import (
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -453,19 +455,31 @@ func TestClusterReconcilerNodeRef(t *testing.T) {
 				},
 			},
 			{
-				name: "controlplane machine, noderef is not set",
+				name: "controlplane machine, noderef is not set, should return cluster",
 				o:    controlPlaneWithoutNoderef,
-				want: nil,
+				want: []ctrl.Request{
+					{
+						NamespacedName: util.ObjectKey(cluster),
+					},
+				},
 			},
 			{
-				name: "not controlplane machine, noderef is set",
+				name: "not controlplane machine, noderef is set, should return cluster",
 				o:    nonControlPlaneWithNoderef,
-				want: nil,
+				want: []ctrl.Request{
+					{
+						NamespacedName: util.ObjectKey(cluster),
+					},
+				},
 			},
 			{
-				name: "not controlplane machine, noderef is not set",
+				name: "not controlplane machine, noderef is not set, should return cluster",
 				o:    nonControlPlaneWithoutNoderef,
-				want: nil,
+				want: []ctrl.Request{
+					{
+						NamespacedName: util.ObjectKey(cluster),
+					},
+				},
 			},
 		}
 		for _, tt := range tests {
@@ -475,7 +489,7 @@ func TestClusterReconcilerNodeRef(t *testing.T) {
 				r := &ClusterReconciler{
 					Client: fake.NewClientBuilder().WithObjects(cluster, controlPlaneWithNoderef, controlPlaneWithoutNoderef, nonControlPlaneWithNoderef, nonControlPlaneWithoutNoderef).Build(),
 				}
-				requests := r.controlPlaneMachineToCluster(tt.o)
+				requests := r.machineToCluster(tt.o)
 				g.Expect(requests).To(Equal(tt.want))
 			})
 		}
@@ -753,3 +767,162 @@ func TestReconcileControlPlaneInitializedControlPlaneRef(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(conditions.Has(c, clusterv1.ControlPlaneInitializedCondition)).To(BeFalse())
 }
+
+func TestClusterReconcilerReconcileDelete(t *testing.T) {
+	newCluster := func() *clusterv1.Cluster {
+		return &clusterv1.Cluster{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "Cluster",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-cluster",
+				Namespace:  "test-namespace",
+				Finalizers: []string{clusterv1.ClusterFinalizer},
+			},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneRef: &corev1.ObjectReference{
+					APIVersion: builder.ControlPlaneGroupVersion.String(),
+					Kind:       builder.GenericControlPlaneKind,
+					Name:       "test-control-plane",
+				},
+				InfrastructureRef: &corev1.ObjectReference{
+					APIVersion: builder.InfrastructureGroupVersion.String(),
+					Kind:       builder.GenericInfrastructureClusterKind,
+					Name:       "test-infrastructure",
+				},
+			},
+		}
+	}
+	newMachineDeployment := func(cluster *clusterv1.Cluster, finalizers ...string) *clusterv1.MachineDeployment {
+		md := newMachineDeploymentBuilder().named("test-md").ownedBy(cluster).build()
+		md.Namespace = cluster.Namespace
+		md.Labels = map[string]string{clusterv1.ClusterLabelName: cluster.Name}
+		md.Finalizers = finalizers
+		return &md
+	}
+	controlPlane := builder.ControlPlane("test-namespace", "test-control-plane").Build()
+	infrastructure := builder.InfrastructureCluster("test-namespace", "test-infrastructure").Build()
+
+	t.Run("deletes workers, control plane and infrastructure in order", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		c := fake.NewClientBuilder().WithObjects(cluster, newMachineDeployment(cluster), controlPlane.DeepCopy(), infrastructure.DeepCopy()).Build()
+		r := &ClusterReconciler{Client: c}
+
+		// First phase: the MachineDeployment is deleted, while the control plane is not.
+		res, err := r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(deleteRequeueAfter))
+		g.Expect(conditions.IsFalse(cluster, clusterv1.WorkersDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(cluster, clusterv1.WorkersDeletedCondition)).To(Equal(clusterv1.DeletingReason))
+		g.Expect(conditions.Has(cluster, clusterv1.ControlPlaneDeletedCondition)).To(BeFalse())
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane.DeepCopy())).To(Succeed())
+
+		// Second phase: workers are gone, so the control plane is deleted, while the infrastructure is not.
+		res, err = r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(deleteRequeueAfter))
+		g.Expect(conditions.IsTrue(cluster, clusterv1.WorkersDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(cluster, clusterv1.ControlPlaneDeletedCondition)).To(Equal(clusterv1.DeletingReason))
+		g.Expect(conditions.Has(cluster, clusterv1.InfrastructureDeletedCondition)).To(BeFalse())
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(infrastructure), infrastructure.DeepCopy())).To(Succeed())
+
+		// Third phase: the control plane is gone, so the infrastructure is deleted.
+		res, err = r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(deleteRequeueAfter))
+		g.Expect(conditions.IsTrue(cluster, clusterv1.ControlPlaneDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(cluster, clusterv1.InfrastructureDeletedCondition)).To(Equal(clusterv1.DeletingReason))
+		g.Expect(cluster.Finalizers).To(ContainElement(clusterv1.ClusterFinalizer))
+
+		// Everything is gone, so the finalizer is removed.
+		res, err = r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		g.Expect(conditions.IsTrue(cluster, clusterv1.InfrastructureDeletedCondition)).To(BeTrue())
+		g.Expect(cluster.Finalizers).ToNot(ContainElement(clusterv1.ClusterFinalizer))
+	})
+
+	t.Run("waits for workers to be deleted if the timeout is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		conditions.Set(cluster, &clusterv1.Condition{
+			Type:               clusterv1.WorkersDeletedCondition,
+			Status:             corev1.ConditionFalse,
+			Severity:           clusterv1.ConditionSeverityInfo,
+			Reason:             clusterv1.DeletingReason,
+			Message:            "Waiting for worker Machines to be deleted",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+		})
+		c := fake.NewClientBuilder().WithObjects(cluster, newMachineDeployment(cluster, "test-finalizer"), controlPlane.DeepCopy(), infrastructure.DeepCopy()).Build()
+		r := &ClusterReconciler{Client: c}
+
+		_, err := r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions.GetReason(cluster, clusterv1.WorkersDeletedCondition)).To(Equal(clusterv1.DeletingReason))
+		g.Expect(conditions.Has(cluster, clusterv1.ControlPlaneDeletedCondition)).To(BeFalse())
+	})
+
+	t.Run("moves on with the control plane when the workers deletion times out", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		conditions.Set(cluster, &clusterv1.Condition{
+			Type:               clusterv1.WorkersDeletedCondition,
+			Status:             corev1.ConditionFalse,
+			Severity:           clusterv1.ConditionSeverityInfo,
+			Reason:             clusterv1.DeletingReason,
+			Message:            "Waiting for worker Machines to be deleted",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+		})
+		c := fake.NewClientBuilder().WithObjects(cluster, newMachineDeployment(cluster, "test-finalizer"), controlPlane.DeepCopy(), infrastructure.DeepCopy()).Build()
+		r := &ClusterReconciler{
+			Client: c,
+			DeletionTimeouts: ClusterDeletionTimeouts{
+				Workers: 10 * time.Minute,
+			},
+		}
+
+		_, err := r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions.IsFalse(cluster, clusterv1.WorkersDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(cluster, clusterv1.WorkersDeletedCondition)).To(Equal(clusterv1.DeletionTimedOutReason))
+		g.Expect(*conditions.GetSeverity(cluster, clusterv1.WorkersDeletedCondition)).To(Equal(clusterv1.ConditionSeverityWarning))
+		g.Expect(conditions.GetReason(cluster, clusterv1.ControlPlaneDeletedCondition)).To(Equal(clusterv1.DeletingReason))
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane.DeepCopy())).ToNot(Succeed())
+	})
+
+	t.Run("keeps the finalizer when the infrastructure deletion times out", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		conditions.Set(cluster, &clusterv1.Condition{
+			Type:               clusterv1.InfrastructureDeletedCondition,
+			Status:             corev1.ConditionFalse,
+			Severity:           clusterv1.ConditionSeverityInfo,
+			Reason:             clusterv1.DeletingReason,
+			Message:            "Waiting for the infrastructure to be deleted",
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-1 * time.Hour)),
+		})
+		infrastructureWithFinalizer := infrastructure.DeepCopy()
+		infrastructureWithFinalizer.SetFinalizers([]string{"test-finalizer"})
+		c := fake.NewClientBuilder().WithObjects(cluster, infrastructureWithFinalizer).Build()
+		r := &ClusterReconciler{
+			Client: c,
+			DeletionTimeouts: ClusterDeletionTimeouts{
+				Infrastructure: 10 * time.Minute,
+			},
+		}
+
+		for i := 0; i < 2; i++ {
+			res, err := r.reconcileDelete(ctx, cluster)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.RequeueAfter).To(Equal(deleteRequeueAfter))
+			g.Expect(conditions.GetReason(cluster, clusterv1.InfrastructureDeletedCondition)).To(Equal(clusterv1.DeletionTimedOutReason))
+			g.Expect(cluster.Finalizers).To(ContainElement(clusterv1.ClusterFinalizer))
+		}
+	})
+}
@@ -21,9 +21,11 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -34,6 +36,16 @@ type ObjectTracker struct {
 	m sync.Map
 
 	Controller controller.Controller
+
+	// MetadataOnly configures the tracker to watch only the metadata of external objects, thus
+	// caching PartialObjectMetadata instead of the full objects; this drastically reduces the memory
+	// used for external objects with a large spec or status.
+	// NOTE: Events are still triggered by any change to the external objects, including status changes,
+	// but the objects can't be read from the cache; it should be used only by controllers reading
+	// external objects with a non-caching client.
+	// NOTE: Field selectors are not used, because the API server supports only metadata.name and metadata.namespace
+	// as field selectors for custom resources; the watch is scoped by the namespace the manager is restricted to, if any.
+	MetadataOnly bool
 }
 
 // Watch uses the controller to issue a Watch only if the object hasn't been seen before.
@@ -49,12 +61,20 @@ func (o *ObjectTracker) Watch(log logr.Logger, obj runtime.Object, handler handl
 		return nil
 	}
 
-	u := &unstructured.Unstructured{}
-	u.SetGroupVersionKind(gvk)
+	var watchObj client.Object
+	if o.MetadataOnly {
+		m := &metav1.PartialObjectMetadata{}
+		m.SetGroupVersionKind(gvk)
+		watchObj = m
+	} else {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		watchObj = u
+	}
 
-	log.Info("Adding watcher on external object", "GroupVersionKind", gvk.String())
+	log.Info("Adding watcher on external object", "GroupVersionKind", gvk.String(), "metadataOnly", o.MetadataOnly)
 	err := o.Controller.Watch(
-		&source.Kind{Type: u},
+		&source.Kind{Type: watchObj},
 		handler,
 		predicates.ResourceNotPaused(log),
 	)
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -45,6 +46,8 @@ type watchCountController struct {
 	// no.of times Watch was called
 	count      int
 	raiseError bool
+	// source passed to the last call to Watch
+	source source.Source
 }
 
 func newWatchCountController(raiseError bool) *watchCountController {
@@ -53,8 +56,9 @@ func newWatchCountController(raiseError bool) *watchCountController {
 	}
 }
 
-func (c *watchCountController) Watch(_ source.Source, _ handler.EventHandler, _ ...predicate.Predicate) error {
+func (c *watchCountController) Watch(s source.Source, _ handler.EventHandler, _ ...predicate.Predicate) error {
 	c.count++
+	c.source = s
 	if c.raiseError {
 		return errors.New("injected failure")
 	}
@@ -94,3 +98,36 @@ func TestWatchMultipleTimes(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(ctrl.count).Should(Equal(1))
 }
+
+func TestWatchMetadataOnly(t *testing.T) {
+	obj := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+	}
+
+	t.Run("watches the full object by default", func(t *testing.T) {
+		g := NewWithT(t)
+		ctrl := &watchCountController{}
+		tracker := ObjectTracker{Controller: ctrl}
+
+		g.Expect(tracker.Watch(logger, obj, nil)).To(Succeed())
+		g.Expect(ctrl.source).To(BeAssignableToTypeOf(&source.Kind{}))
+		watched := ctrl.source.(*source.Kind).Type
+		g.Expect(watched).To(BeAssignableToTypeOf(&unstructured.Unstructured{}))
+		g.Expect(watched.GetObjectKind().GroupVersionKind()).To(Equal(clusterv1.GroupVersion.WithKind("Cluster")))
+	})
+
+	t.Run("watches only the metadata if MetadataOnly is set", func(t *testing.T) {
+		g := NewWithT(t)
+		ctrl := &watchCountController{}
+		tracker := ObjectTracker{Controller: ctrl, MetadataOnly: true}
+
+		g.Expect(tracker.Watch(logger, obj, nil)).To(Succeed())
+		g.Expect(ctrl.source).To(BeAssignableToTypeOf(&source.Kind{}))
+		watched := ctrl.source.(*source.Kind).Type
+		g.Expect(watched).To(BeAssignableToTypeOf(&metav1.PartialObjectMetadata{}))
+		g.Expect(watched.GetObjectKind().GroupVersionKind()).To(Equal(clusterv1.GroupVersion.WithKind("Cluster")))
+	})
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apiserver/pkg/storage/names"
@@ -66,6 +67,10 @@ type CloneTemplateInput struct {
 	// TemplateRef is a reference to the template that needs to be cloned.
 	TemplateRef *corev1.ObjectReference
 
+	// Name is an optional name of the cloned object; if not set, a name is generated from the template name.
+	// +optional
+	Name string
+
 	// Namespace is the Kubernetes namespace the cloned object should be created into.
 	Namespace string
 
@@ -94,6 +99,7 @@ func CloneTemplate(ctx context.Context, in *CloneTemplateInput) (*corev1.ObjectR
 	generateTemplateInput := &GenerateTemplateInput{
 		Template:    from,
 		TemplateRef: in.TemplateRef,
+		Name:        in.Name,
 		Namespace:   in.Namespace,
 		ClusterName: in.ClusterName,
 		OwnerRef:    in.OwnerRef,
@@ -121,6 +127,10 @@ type GenerateTemplateInput struct {
 	// TemplateRef is a reference to the template that needs to be cloned.
 	TemplateRef *corev1.ObjectReference
 
+	// Name is an optional name of the cloned object; if not set, a name is generated from the template name.
+	// +optional
+	Name string
+
 	// Namespace is the Kubernetes namespace the cloned object should be created into.
 	Namespace string
 
@@ -155,7 +165,11 @@ func GenerateTemplate(in *GenerateTemplateInput) (*unstructured.Unstructured, er
 	to.SetFinalizers(nil)
 	to.SetUID("")
 	to.SetSelfLink("")
-	to.SetName(names.SimpleNameGenerator.GenerateName(in.Template.GetName() + "-"))
+	if in.Name != "" {
+		to.SetName(in.Name)
+	} else {
+		to.SetName(names.SimpleNameGenerator.GenerateName(in.Template.GetName() + "-"))
+	}
 	to.SetNamespace(in.Namespace)
 
 	// Set annotations.
@@ -224,6 +238,21 @@ func FailuresFrom(obj *unstructured.Unstructured) (string, string, error) {
 	return failureReason, failureMessage, nil
 }
 
+// BootstrapFailureFrom returns the BootstrapFailureReason and BootstrapFailureOutput fields from the external object status.
+func BootstrapFailureFrom(obj *unstructured.Unstructured) (string, string, error) {
+	reason, _, err := unstructured.NestedString(obj.Object, "status", "bootstrapFailureReason")
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to determine bootstrapFailureReason on %v %q",
+			obj.GroupVersionKind(), obj.GetName())
+	}
+	output, _, err := unstructured.NestedString(obj.Object, "status", "bootstrapFailureOutput")
+	if err != nil {
+		return "", "", errors.Wrapf(err, "failed to determine bootstrapFailureOutput on %v %q",
+			obj.GroupVersionKind(), obj.GetName())
+	}
+	return reason, output, nil
+}
+
 // IsReady returns true if the Status.Ready field on an external object is true.
 func IsReady(obj *unstructured.Unstructured) (bool, error) {
 	ready, found, err := unstructured.NestedBool(obj.Object, "status", "ready")
@@ -243,3 +272,26 @@ func IsInitialized(obj *unstructured.Unstructured) (bool, error) {
 	}
 	return initialized && found, nil
 }
+
+// CapacityFrom returns the resources in the Status.Capacity field of an external object, e.g. the CPU, memory and GPUs
+// of the machines created from an infrastructure machine template, or nil if the field is not set.
+func CapacityFrom(obj *unstructured.Unstructured) (corev1.ResourceList, error) {
+	values, found, err := unstructured.NestedStringMap(obj.Object, "status", "capacity")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to determine capacity on %v %q",
+			obj.GroupVersionKind(), obj.GetName())
+	}
+	if !found {
+		return nil, nil
+	}
+	capacity := corev1.ResourceList{}
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse capacity %q on %v %q",
+				name, obj.GroupVersionKind(), obj.GetName())
+		}
+		capacity[corev1.ResourceName(name)] = quantity
+	}
+	return capacity, nil
+}
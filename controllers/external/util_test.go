@@ -23,6 +23,7 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -286,6 +287,52 @@ func TestCloneTemplateResourceFoundNoOwner(t *testing.T) {
 	g.Expect(cloneSpec).To(Equal(expectedSpec))
 }
 
+func TestCloneTemplateWithName(t *testing.T) {
+	g := NewWithT(t)
+
+	template := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       "YellowTemplate",
+			"apiVersion": "yellow.io/v1",
+			"metadata": map[string]interface{}{
+				"name":      "yellowTemplate",
+				"namespace": metav1.NamespaceDefault,
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"hello": "world",
+					},
+				},
+			},
+		},
+	}
+
+	templateRef := &corev1.ObjectReference{
+		Kind:       template.GetKind(),
+		APIVersion: template.GetAPIVersion(),
+		Name:       template.GetName(),
+		Namespace:  metav1.NamespaceDefault,
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(template.DeepCopy()).Build()
+
+	ref, err := CloneTemplate(ctx, &CloneTemplateInput{
+		Client:      fakeClient,
+		TemplateRef: templateRef,
+		Name:        "foo-worker-0",
+		Namespace:   metav1.NamespaceDefault,
+		ClusterName: testClusterName,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ref.Name).To(Equal("foo-worker-0"))
+
+	clone := &unstructured.Unstructured{}
+	clone.SetKind(ref.Kind)
+	clone.SetAPIVersion(ref.APIVersion)
+	g.Expect(fakeClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: ref.Namespace}, clone)).To(Succeed())
+}
+
 func TestCloneTemplateMissingSpecTemplate(t *testing.T) {
 	g := NewWithT(t)
 
@@ -322,3 +369,68 @@ func TestCloneTemplateMissingSpecTemplate(t *testing.T) {
 	})
 	g.Expect(err).To(HaveOccurred())
 }
+
+func TestBootstrapFailureFrom(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{},
+		},
+	}
+	reason, output, err := BootstrapFailureFrom(obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reason).To(BeEmpty())
+	g.Expect(output).To(BeEmpty())
+
+	obj.Object["status"] = map[string]interface{}{
+		"bootstrapFailureReason": "CloudInitFailed",
+		"bootstrapFailureOutput": "kubeadm join failed",
+	}
+	reason, output, err = BootstrapFailureFrom(obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(reason).To(Equal("CloudInitFailed"))
+	g.Expect(output).To(Equal("kubeadm join failed"))
+
+	obj.Object["status"] = map[string]interface{}{
+		"bootstrapFailureReason": true,
+	}
+	_, _, err = BootstrapFailureFrom(obj)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestCapacityFrom(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{},
+		},
+	}
+	capacity, err := CapacityFrom(obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(capacity).To(BeNil())
+
+	obj.Object["status"] = map[string]interface{}{
+		"capacity": map[string]interface{}{
+			"cpu":            "4",
+			"memory":         "16Gi",
+			"nvidia.com/gpu": "1",
+		},
+	}
+	capacity, err = CapacityFrom(obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(capacity).To(Equal(corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("4"),
+		corev1.ResourceMemory: resource.MustParse("16Gi"),
+		"nvidia.com/gpu":      resource.MustParse("1"),
+	}))
+
+	obj.Object["status"] = map[string]interface{}{
+		"capacity": map[string]interface{}{
+			"cpu": "four",
+		},
+	}
+	_, err = CapacityFrom(obj)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -132,6 +132,9 @@ var annotationsToSkip = map[string]bool{
 	//
 	// See https://github.com/kubernetes-sigs/cluster-api/pull/3010#issue-413767831 for more details.
 	conversion.DataAnnotation: true,
+
+	// Exclude the last reconcile annotation, which is set by each controller on the objects it reconciles.
+	clusterv1.LastReconcileAnnotation: true,
 }
 
 // skipCopyAnnotation returns true if we should skip copying the annotation with the given annotation key
@@ -392,29 +395,44 @@ func EqualMachineTemplate(template1, template2 *clusterv1.MachineTemplateSpec) b
 }
 
 // FindNewMachineSet returns the new MS this given deployment targets (the one with the same machine template).
-func FindNewMachineSet(deployment *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet) *clusterv1.MachineSet {
+// If the deployment's RolloutAfter is before the reconciliationTime, MachineSets created before RolloutAfter
+// are not considered as new, so the deployment rolls out its Machines even if the template did not change.
+func FindNewMachineSet(deployment *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet, reconciliationTime *metav1.Time) *clusterv1.MachineSet {
 	sort.Sort(MachineSetsByCreationTimestamp(msList))
+	var matchingMSs []*clusterv1.MachineSet
 	for i := range msList {
-		if EqualMachineTemplate(&msList[i].Spec.Template, &deployment.Spec.Template) {
-			// In rare cases, such as after cluster upgrades, Deployment may end up with
-			// having more than one new MachineSets that have the same template,
-			// see https://github.com/kubernetes/kubernetes/issues/40415
-			// We deterministically choose the oldest new MachineSet with matching template hash.
-			return msList[i]
+		if !EqualMachineTemplate(&msList[i].Spec.Template, &deployment.Spec.Template) {
+			continue
 		}
+		if RolloutAfterExpired(deployment, reconciliationTime) && msList[i].CreationTimestamp.Before(deployment.Spec.RolloutAfter) {
+			continue
+		}
+		matchingMSs = append(matchingMSs, msList[i])
 	}
-	// new MachineSet does not exist.
-	return nil
+	if len(matchingMSs) == 0 {
+		// new MachineSet does not exist.
+		return nil
+	}
+	// In rare cases, such as after cluster upgrades, Deployment may end up with
+	// having more than one new MachineSets that have the same template,
+	// see https://github.com/kubernetes/kubernetes/issues/40415
+	// We deterministically choose the oldest new MachineSet with matching template hash.
+	return matchingMSs[0]
+}
+
+// RolloutAfterExpired returns true if the RolloutAfter of the given deployment is set and before the reconciliationTime.
+func RolloutAfterExpired(deployment *clusterv1.MachineDeployment, reconciliationTime *metav1.Time) bool {
+	return reconciliationTime != nil && deployment.Spec.RolloutAfter != nil && deployment.Spec.RolloutAfter.Before(reconciliationTime)
 }
 
 // FindOldMachineSets returns the old machine sets targeted by the given Deployment, with the given slice of MSes.
 // Returns two list of machine sets
 //  - the first contains all old machine sets with all non-zero replicas
 //  - the second contains all old machine sets
-func FindOldMachineSets(deployment *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet) ([]*clusterv1.MachineSet, []*clusterv1.MachineSet) {
+func FindOldMachineSets(deployment *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet, reconciliationTime *metav1.Time) ([]*clusterv1.MachineSet, []*clusterv1.MachineSet) {
 	var requiredMSs []*clusterv1.MachineSet
 	allMSs := make([]*clusterv1.MachineSet, 0, len(msList))
-	newMS := FindNewMachineSet(deployment, msList)
+	newMS := FindNewMachineSet(deployment, msList, reconciliationTime)
 	for _, ms := range msList {
 		// Filter out new machine set
 		if newMS != nil && ms.UID == newMS.UID {
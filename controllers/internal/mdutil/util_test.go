@@ -286,11 +286,15 @@ func TestFindNewMachineSet(t *testing.T) {
 	}
 	oldMS.Status.FullyLabeledReplicas = *(oldMS.Spec.Replicas)
 
+	deploymentWithRolloutAfter := deployment.DeepCopy()
+	deploymentWithRolloutAfter.Spec.RolloutAfter = &metav1.Time{Time: now.Add(30 * time.Second)}
+
 	tests := []struct {
-		Name       string
-		deployment clusterv1.MachineDeployment
-		msList     []*clusterv1.MachineSet
-		expected   *clusterv1.MachineSet
+		Name               string
+		deployment         clusterv1.MachineDeployment
+		msList             []*clusterv1.MachineSet
+		reconciliationTime *metav1.Time
+		expected           *clusterv1.MachineSet
 	}{
 		{
 			Name:       "Get new MachineSet with the same template as Deployment spec but different machine-template-hash value",
@@ -310,13 +314,34 @@ func TestFindNewMachineSet(t *testing.T) {
 			msList:     []*clusterv1.MachineSet{&oldMS},
 			expected:   nil,
 		},
+		{
+			Name:               "Get the oldest new MachineSet if RolloutAfter is not expired",
+			deployment:         *deploymentWithRolloutAfter,
+			msList:             []*clusterv1.MachineSet{&newMS, &oldMS, &newMSDup},
+			reconciliationTime: &now,
+			expected:           &newMSDup,
+		},
+		{
+			Name:               "Ignore MachineSets created before RolloutAfter if RolloutAfter is expired",
+			deployment:         *deploymentWithRolloutAfter,
+			msList:             []*clusterv1.MachineSet{&newMS, &oldMS, &newMSDup},
+			reconciliationTime: &later,
+			expected:           &newMS,
+		},
+		{
+			Name:               "Get nil new MachineSet if all MachineSets have been created before an expired RolloutAfter",
+			deployment:         *deploymentWithRolloutAfter,
+			msList:             []*clusterv1.MachineSet{&oldMS, &newMSDup},
+			reconciliationTime: &later,
+			expected:           nil,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			ms := FindNewMachineSet(&test.deployment, test.msList)
+			ms := FindNewMachineSet(&test.deployment, test.msList, test.reconciliationTime)
 			g.Expect(ms).To(Equal(test.expected))
 		})
 	}
@@ -400,7 +425,7 @@ func TestFindOldMachineSets(t *testing.T) {
 		t.Run(test.Name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			requireMS, allMS := FindOldMachineSets(&test.deployment, test.msList)
+			requireMS, allMS := FindOldMachineSets(&test.deployment, test.msList, nil)
 			g.Expect(allMS).To(ConsistOf(test.expected))
 			// MSs are getting filtered correctly by ms.spec.replicas
 			g.Expect(requireMS).To(ConsistOf(test.expectedRequire))
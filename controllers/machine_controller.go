@@ -19,6 +19,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -26,8 +28,11 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/record"
@@ -43,7 +48,9 @@ import (
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/requeue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -84,6 +91,10 @@ type MachineReconciler struct {
 	controller      controller.Controller
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
+
+	// drainFailures keeps track of the consecutive node drain failures for each Machine, so drains are retried
+	// with drainRetryBackoff.
+	drainFailures requeue.FailureTracker
 }
 
 func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -106,7 +117,10 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		handler.EnqueueRequestsFromMapFunc(clusterToMachines),
 		// TODO: should this wait for Cluster.Status.InfrastructureReady similar to Infra Machine resources?
 		predicates.All(ctrl.LoggerFrom(ctx),
-			predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+			predicates.Any(ctrl.LoggerFrom(ctx),
+				predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+				predicates.ClusterPausedTransitions(ctrl.LoggerFrom(ctx)),
+			),
 			predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
 		),
 	)
@@ -118,7 +132,8 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 
 	r.recorder = mgr.GetEventRecorderFor("machine-controller")
 	r.externalTracker = external.ObjectTracker{
-		Controller: controller,
+		Controller:   controller,
+		MetadataOnly: true,
 	}
 	return nil
 }
@@ -145,8 +160,12 @@ func (r *MachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 			m.Spec.ClusterName, m.Name, m.Namespace)
 	}
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, m) {
+	// Return early if the object or Cluster is paused, reporting it in the Paused condition.
+	isPaused, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, m)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -160,6 +179,8 @@ func (r *MachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 	defer func() {
 		r.reconcilePhase(ctx, m)
 
+		annotations.SetLastReconcile(m, reterr)
+
 		// Always attempt to patch the object and status after each reconciliation.
 		// Patch ObservedGeneration only if the reconciliation completed successfully
 		patchOpts := []patch.Option{}
@@ -193,6 +214,9 @@ func (r *MachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 }
 
 func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clusterv1.Machine, options ...patch.Option) error {
+	// Keep the deprecated failureReason/failureMessage fields and the NoTerminalFailure condition in sync.
+	conditions.SyncMachineFailure(machine)
+
 	// Always update the readyCondition by summarizing the state of other conditions.
 	// A step counter is added to represent progress during the provisioning process (instead we are hiding it
 	// after provisioning - e.g. when a MHC condition exists - or during the deletion process).
@@ -200,8 +224,12 @@ func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clust
 		conditions.WithConditions(
 			// Infrastructure problems should take precedence over all the other conditions
 			clusterv1.InfrastructureReadyCondition,
+			// Instances going to be terminated by the infrastructure are not going to be ready for long.
+			clusterv1.InstanceNotTerminatingCondition,
 			// Boostrap comes after, but it is relevant only during initial machine provisioning.
 			clusterv1.BootstrapReadyCondition,
+			// Bootstrap failures reported by providers explain why a machine never gets a Node.
+			clusterv1.BootstrapSucceededCondition,
 			// MHC reported condition should take precedence over the remediation progress
 			clusterv1.MachineHealthCheckSuccededCondition,
 			clusterv1.MachineOwnerRemediatedCondition,
@@ -213,6 +241,13 @@ func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clust
 		),
 	)
 
+	// If the machine is otherwise ready, make sure all the conditions listed in readiness gates are true as well.
+	if conditions.IsTrue(machine, clusterv1.ReadyCondition) {
+		if notReady := notReadyReadinessGates(machine); len(notReady) > 0 {
+			conditions.MarkFalse(machine, clusterv1.ReadyCondition, clusterv1.ReadinessGatesNotReadyReason, clusterv1.ConditionSeverityInfo, "Readiness gates not ready: %s", strings.Join(notReady, ", "))
+		}
+	}
+
 	// Patch the object, ignoring conflicts on the conditions owned by this controller.
 	// Also, if requested, we are adding additional options like e.g. Patch ObservedGeneration when issuing the
 	// patch at the end of the reconcile loop.
@@ -220,16 +255,35 @@ func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clust
 		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 			clusterv1.ReadyCondition,
 			clusterv1.BootstrapReadyCondition,
+			clusterv1.BootstrapSucceededCondition,
 			clusterv1.InfrastructureReadyCondition,
+			clusterv1.InstanceNotTerminatingCondition,
 			clusterv1.DrainingSucceededCondition,
+			clusterv1.CordonOnlyReleasedCondition,
 			clusterv1.MachineHealthCheckSuccededCondition,
 			clusterv1.MachineOwnerRemediatedCondition,
+			clusterv1.MachineSetOwnedCondition,
+			clusterv1.DuplicateProviderIDCondition,
+			clusterv1.MachineNodeReadyCondition,
+			clusterv1.MachineNodeMemoryAvailableCondition,
+			clusterv1.MachineNodeDiskAvailableCondition,
 		}},
 	)
 
 	return patchHelper.Patch(ctx, machine, options...)
 }
 
+// notReadyReadinessGates returns the condition types listed in the machine's readiness gates which are not True.
+func notReadyReadinessGates(machine *clusterv1.Machine) []string {
+	var notReady []string
+	for _, gate := range machine.Spec.ReadinessGates {
+		if !conditions.IsTrue(machine, gate.ConditionType) {
+			notReady = append(notReady, string(gate.ConditionType))
+		}
+	}
+	return notReady
+}
+
 func (r *MachineReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.Machine) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -251,10 +305,15 @@ func (r *MachineReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cl
 	}
 
 	phases := []func(context.Context, *clusterv1.Cluster, *clusterv1.Machine) (ctrl.Result, error){
+		r.reconcileNodeAdoption,
+		r.reconcileOrphan,
 		r.reconcileBootstrap,
 		r.reconcileInfrastructure,
+		r.reconcileProviderID,
+		r.reconcileCertificateExpiry,
 		r.reconcileNode,
 		r.reconcileInterruptibleNodeLabel,
+		r.reconcileNodeUninitializedTaint,
 	}
 
 	res := ctrl.Result{}
@@ -310,12 +369,15 @@ func (r *MachineReconciler) reconcileDelete(ctx context.Context, cluster *cluste
 			if conditions.Get(m, clusterv1.DrainingSucceededCondition) == nil {
 				conditions.MarkFalse(m, clusterv1.DrainingSucceededCondition, clusterv1.DrainingReason, clusterv1.ConditionSeverityInfo, "Draining the node before deletion")
 			}
+			if deletionStatus(m).NodeDrainStartTime == nil {
+				deletionStatus(m).NodeDrainStartTime = &metav1.Time{Time: time.Now()}
+			}
 
 			if err := patchMachine(ctx, patchHelper, m); err != nil {
 				return ctrl.Result{}, errors.Wrap(err, "failed to patch Machine")
 			}
 
-			if result, err := r.drainNode(ctx, cluster, m.Status.NodeRef.Name); !result.IsZero() || err != nil {
+			if result, err := r.drainNode(ctx, cluster, m); !result.IsZero() || err != nil {
 				if err != nil {
 					conditions.MarkFalse(m, clusterv1.DrainingSucceededCondition, clusterv1.DrainingFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 					r.recorder.Eventf(m, corev1.EventTypeWarning, "FailedDrainNode", "error draining Machine's node %q: %v", m.Status.NodeRef.Name, err)
@@ -330,6 +392,9 @@ func (r *MachineReconciler) reconcileDelete(ctx context.Context, cluster *cluste
 			if conditions.Get(m, clusterv1.VolumeDetachSucceededCondition) == nil {
 				conditions.MarkFalse(m, clusterv1.VolumeDetachSucceededCondition, clusterv1.WaitingForVolumeDetachReason, clusterv1.ConditionSeverityInfo, "Waiting for node volumes to be detached")
 			}
+			if deletionStatus(m).WaitForNodeVolumeDetachStartTime == nil {
+				deletionStatus(m).WaitForNodeVolumeDetachStartTime = &metav1.Time{Time: time.Now()}
+			}
 			if ok, err := r.shouldWaitForNodeVolumes(ctx, cluster, m.Status.NodeRef.Name, m.Name); ok || err != nil {
 				if err != nil {
 					r.recorder.Eventf(m, corev1.EventTypeWarning, "FailedWaitForVolumeDetach", "error wait for volume detach, node %q: %v", m.Status.NodeRef.Name, err)
@@ -343,6 +408,20 @@ func (r *MachineReconciler) reconcileDelete(ctx context.Context, cluster *cluste
 		}
 	}
 
+	// cordon-only: the node has been cordoned and drained, the infrastructure is left intact until an operator
+	// removes the annotation. Return early without error, will requeue if/when the annotation is removed.
+	if annotations.HasCordonOnlyAnnotation(m) {
+		if !conditions.IsFalse(m, clusterv1.CordonOnlyReleasedCondition) {
+			log.Info("Waiting for the cordon-only annotation to be removed before deleting the Machine infrastructure")
+			r.recorder.Eventf(m, corev1.EventTypeNormal, "CordonOnly", "Machine's node cordoned and drained, waiting for the %s annotation to be removed", clusterv1.CordonOnlyAnnotation)
+		}
+		conditions.MarkFalse(m, clusterv1.CordonOnlyReleasedCondition, clusterv1.WaitingForCordonOnlyReleaseReason, clusterv1.ConditionSeverityInfo, "Waiting for the %s annotation to be removed", clusterv1.CordonOnlyAnnotation)
+		return ctrl.Result{}, nil
+	}
+	if conditions.Has(m, clusterv1.CordonOnlyReleasedCondition) {
+		conditions.MarkTrue(m, clusterv1.CordonOnlyReleasedCondition)
+	}
+
 	// pre-term.delete lifecycle hook
 	// Return early without error, will requeue if/when the hook owner removes the annotation.
 	if annotations.HasWithPrefix(clusterv1.PreTerminateDeleteHookAnnotationPrefix, m.ObjectMeta.Annotations) {
@@ -359,6 +438,9 @@ func (r *MachineReconciler) reconcileDelete(ctx context.Context, cluster *cluste
 		return ctrl.Result{}, err
 	}
 	conditions.MarkFalse(m, clusterv1.MachineNodeHealthyCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, "")
+	if deletionStatus(m).InfrastructureDeletionStartTime == nil {
+		deletionStatus(m).InfrastructureDeletionStartTime = &metav1.Time{Time: time.Now()}
+	}
 	if err := patchMachine(ctx, patchHelper, m); err != nil {
 		conditions.MarkFalse(m, clusterv1.MachineNodeHealthyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityInfo, "")
 		return ctrl.Result{}, errors.Wrap(err, "failed to patch Machine")
@@ -391,12 +473,13 @@ func (r *MachineReconciler) reconcileDelete(ctx context.Context, cluster *cluste
 		}
 	}
 
+	r.drainFailures.Reset(m)
 	controllerutil.RemoveFinalizer(m, clusterv1.MachineFinalizer)
 	return ctrl.Result{}, nil
 }
 
 func (r *MachineReconciler) isNodeDrainAllowed(m *clusterv1.Machine) bool {
-	if _, exists := m.ObjectMeta.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]; exists {
+	if annotations.HasExcludeNodeDrainingAnnotation(m) {
 		return false
 	}
 
@@ -413,17 +496,31 @@ func (r *MachineReconciler) nodeDrainTimeoutExceeded(machine *clusterv1.Machine)
 		return false
 	}
 
-	// if the draining succeeded condition does not exist
-	if conditions.Get(machine, clusterv1.DrainingSucceededCondition) == nil {
+	// Use the time recorded in the deletion status or, for drains started before it was introduced,
+	// the transition time of the draining succeeded condition.
+	var firstTimeDrain *metav1.Time
+	switch {
+	case machine.Status.Deletion != nil && machine.Status.Deletion.NodeDrainStartTime != nil:
+		firstTimeDrain = machine.Status.Deletion.NodeDrainStartTime
+	case conditions.Get(machine, clusterv1.DrainingSucceededCondition) != nil:
+		firstTimeDrain = conditions.GetLastTransitionTime(machine, clusterv1.DrainingSucceededCondition)
+	default:
 		return false
 	}
 
 	now := time.Now()
-	firstTimeDrain := conditions.GetLastTransitionTime(machine, clusterv1.DrainingSucceededCondition)
 	diff := now.Sub(firstTimeDrain.Time)
 	return diff.Seconds() >= machine.Spec.NodeDrainTimeout.Seconds()
 }
 
+// deletionStatus returns the deletion status of the Machine, initializing it if not set.
+func deletionStatus(m *clusterv1.Machine) *clusterv1.MachineDeletionStatus {
+	if m.Status.Deletion == nil {
+		m.Status.Deletion = &clusterv1.MachineDeletionStatus{}
+	}
+	return m.Status.Deletion
+}
+
 // isDeleteNodeAllowed returns nil only if the Machine's NodeRef is not nil
 // and if the Machine is not the last control plane node in the cluster.
 func (r *MachineReconciler) isDeleteNodeAllowed(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) error {
@@ -486,7 +583,8 @@ func (r *MachineReconciler) isDeleteNodeAllowed(ctx context.Context, cluster *cl
 	return nil
 }
 
-func (r *MachineReconciler) drainNode(ctx context.Context, cluster *clusterv1.Cluster, nodeName string) (ctrl.Result, error) {
+func (r *MachineReconciler) drainNode(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) (ctrl.Result, error) {
+	nodeName := machine.Status.NodeRef.Name
 	log := ctrl.LoggerFrom(ctx, "cluster", cluster.Name, "node", nodeName)
 
 	restConfig, err := remote.RESTConfig(ctx, MachineControllerName, r.Client, util.ObjectKey(cluster))
@@ -532,6 +630,12 @@ func (r *MachineReconciler) drainNode(ctx context.Context, cluster *clusterv1.Cl
 		ErrOut: writer{klog.Error},
 	}
 
+	// NOTE: Invalid node drain options are rejected by the webhooks, but they could have been set before the validation
+	// was introduced; the error is surfaced in the DrainingSucceeded condition, and the drain is retried once fixed.
+	if err := applyNodeDrainOptions(drainer, machine.Spec.NodeDrainOptions); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "invalid node drain options")
+	}
+
 	if noderefutil.IsNodeUnreachable(node) {
 		// When the node is unreachable and some pods are not evicted for as long as this timeout, we ignore them.
 		drainer.SkipWaitForDeleteTimeoutSeconds = 60 * 5 // 5 minutes
@@ -543,16 +647,208 @@ func (r *MachineReconciler) drainNode(ctx context.Context, cluster *clusterv1.Cl
 		return ctrl.Result{}, errors.Errorf("unable to cordon node %s: %v", node.Name, err)
 	}
 
-	if err := kubedrain.RunNodeDrain(drainer, node.Name); err != nil {
-		// Machine will be re-reconciled after a drain failure.
-		log.Error(err, "Drain failed, retry in 20s")
-		return ctrl.Result{RequeueAfter: 20 * time.Second}, nil
+	if err := runNodeDrain(drainer, node.Name, drainStartTime(machine), time.Now()); err != nil {
+		// Machine will be re-reconciled after a drain failure, with an exponential backoff.
+		result := r.drainRetryResult(machine)
+		log.Error(err, "Drain failed, retrying", "after", result.RequeueAfter.Round(time.Second).String())
+		if message := drainBlockingPodsMessage(drainer, node.Name, drainStartTime(machine), time.Now()); message != "" {
+			conditions.MarkFalse(machine, clusterv1.DrainingSucceededCondition, clusterv1.DrainingReason, clusterv1.ConditionSeverityInfo,
+				"Draining the node before deletion; %s", message)
+		}
+		return result, nil
 	}
 
 	log.Info("Drain successful")
+	r.drainFailures.Reset(machine)
 	return ctrl.Result{}, nil
 }
 
+// drainRetryBackoff is the backoff used to retry draining a node; the first retry happens after 20 seconds,
+// and the following ones are spaced out so Machines with Pods not being evicted for a long time, e.g. because of
+// PodDisruptionBudgets, do not cause frequent resyncs.
+var drainRetryBackoff = requeue.Backoff{
+	Duration: 20 * time.Second,
+	Factor:   2,
+	Jitter:   requeue.DefaultJitterFactor,
+	Cap:      2 * time.Minute,
+}
+
+// drainRetryResult records a drain failure for the Machine and returns the result to retry the drain with drainRetryBackoff.
+func (r *MachineReconciler) drainRetryResult(machine *clusterv1.Machine) ctrl.Result {
+	return r.drainFailures.AfterFailure(machine, drainRetryBackoff)
+}
+
+// applyNodeDrainOptions customizes the drainer according to the Machine's NodeDrainOptions;
+// options which are not set keep the defaults of the drainer.
+func applyNodeDrainOptions(drainer *kubedrain.Helper, options *clusterv1.NodeDrainOptions) error {
+	if options == nil {
+		return nil
+	}
+
+	if options.IgnoreDaemonSets != nil {
+		drainer.IgnoreAllDaemonSets = *options.IgnoreDaemonSets
+	}
+	if options.DeleteEmptyDirData != nil {
+		drainer.DeleteEmptyDirData = *options.DeleteEmptyDirData
+	}
+	if options.GracePeriodSeconds != nil {
+		drainer.GracePeriodSeconds = int(*options.GracePeriodSeconds)
+	}
+
+	excludedNamespaces := sets.NewString(options.ExcludedNamespaces...)
+	excludedPods := labels.Nothing()
+	if options.ExcludedPodSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(options.ExcludedPodSelector)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse the excluded Pod selector")
+		}
+		excludedPods = selector
+	}
+	if excludedNamespaces.Len() > 0 || options.ExcludedPodSelector != nil {
+		drainer.AdditionalFilters = append(drainer.AdditionalFilters, func(pod corev1.Pod) kubedrain.PodDeleteStatus {
+			if excludedNamespaces.Has(pod.Namespace) || excludedPods.Matches(labels.Set(pod.Labels)) {
+				return kubedrain.MakePodDeleteStatusSkip()
+			}
+			return kubedrain.MakePodDeleteStatusOkay()
+		})
+	}
+	return nil
+}
+
+// excludeWaitEvictionTimeout is how long after the drain started the eviction of Pods with the DrainExcludeWaitLabel
+// is retried; after this timeout, failing evictions of those Pods do not block the drain anymore.
+const excludeWaitEvictionTimeout = 5 * time.Minute
+
+// runNodeDrain evicts the Pods running on the node like kubedrain.RunNodeDrain, except for Pods with the
+// DrainExcludeWaitLabel, which are evicted without waiting for them to be deleted.
+// The eviction of Pods with the DrainExcludeWaitLabel is best-effort: failures do not prevent the other Pods from
+// being evicted, and they fail the drain only until excludeWaitEvictionTimeout is elapsed since the drain started.
+func runNodeDrain(drainer *kubedrain.Helper, nodeName string, drainStart, now time.Time) error {
+	list, errs := drainer.GetPodsForDeletion(nodeName)
+	if errs != nil {
+		return kerrors.NewAggregate(errs)
+	}
+	if warnings := list.Warnings(); warnings != "" {
+		fmt.Fprintf(drainer.ErrOut, "WARNING: %s\n", warnings)
+	}
+
+	pods, excludeWaitPods := splitExcludeWaitPods(list.Pods())
+	excludeWaitErr := evictPodsWithoutWaiting(drainer, excludeWaitPods)
+	if excludeWaitErr != nil {
+		fmt.Fprintf(drainer.ErrOut, "WARNING: failed to evict Pods excluded from waiting: %v\n", excludeWaitErr)
+	}
+
+	if err := drainer.DeleteOrEvictPods(pods); err != nil {
+		return err
+	}
+	if excludeWaitErr != nil && now.Sub(drainStart) < excludeWaitEvictionTimeout {
+		return excludeWaitErr
+	}
+	return nil
+}
+
+// splitExcludeWaitPods splits the Pods to be waited for from the Pods with the DrainExcludeWaitLabel.
+func splitExcludeWaitPods(pods []corev1.Pod) (wait, excludeWait []corev1.Pod) {
+	for _, pod := range pods {
+		if _, ok := pod.Labels[clusterv1.DrainExcludeWaitLabel]; ok {
+			excludeWait = append(excludeWait, pod)
+			continue
+		}
+		wait = append(wait, pod)
+	}
+	return wait, excludeWait
+}
+
+// evictPodsWithoutWaiting evicts, or deletes if eviction is disabled or not supported, the Pods
+// which are not already being deleted.
+func evictPodsWithoutWaiting(drainer *kubedrain.Helper, pods []corev1.Pod) error {
+	if len(pods) == 0 {
+		return nil
+	}
+
+	var evictionGroupVersion schema.GroupVersion
+	if !drainer.DisableEviction {
+		gv, err := kubedrain.CheckEvictionSupport(drainer.Client)
+		if err != nil {
+			return err
+		}
+		evictionGroupVersion = gv
+	}
+
+	var errs []error
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		var err error
+		if evictionGroupVersion.Empty() {
+			err = drainer.DeletePod(pod)
+		} else {
+			err = drainer.EvictPod(pod, evictionGroupVersion)
+		}
+		if err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to evict Pod %s/%s", pod.Namespace, pod.Name))
+			continue
+		}
+		if drainer.OnPodDeletedOrEvicted != nil {
+			drainer.OnPodDeletedOrEvicted(&pod, !evictionGroupVersion.Empty())
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// maxDrainBlockingPods is the maximum number of Pods blocking the drain listed in the DrainingSucceeded condition.
+const maxDrainBlockingPods = 5
+
+// drainBlockingPodsMessage returns a message listing the Pods still blocking the drain of the node, together with
+// how long the drain has been waiting for each of them; Pods with the DrainExcludeWaitLabel are not included.
+func drainBlockingPodsMessage(drainer *kubedrain.Helper, nodeName string, drainStart, now time.Time) string {
+	list, errs := drainer.GetPodsForDeletion(nodeName)
+	if errs != nil {
+		return ""
+	}
+	pods, _ := splitExcludeWaitPods(list.Pods())
+	return formatDrainBlockingPods(pods, drainStart, now)
+}
+
+// formatDrainBlockingPods formats the Pods blocking the drain, sorted by namespace and name, with the time the drain
+// has been waiting for each of them, i.e. for how long the Pod has been terminating or, if the Pod has not been
+// deleted yet, for how long the node has been draining.
+func formatDrainBlockingPods(pods []corev1.Pod, drainStart, now time.Time) string {
+	if len(pods) == 0 {
+		return ""
+	}
+
+	sort.Slice(pods, func(i, j int) bool {
+		if pods[i].Namespace != pods[j].Namespace {
+			return pods[i].Namespace < pods[j].Namespace
+		}
+		return pods[i].Name < pods[j].Name
+	})
+
+	var blocking []string
+	for i, pod := range pods {
+		if i == maxDrainBlockingPods {
+			blocking = append(blocking, fmt.Sprintf("and %d more", len(pods)-maxDrainBlockingPods))
+			break
+		}
+		if !pod.DeletionTimestamp.IsZero() {
+			blocking = append(blocking, fmt.Sprintf("%s/%s (terminating for %s)", pod.Namespace, pod.Name, now.Sub(pod.DeletionTimestamp.Time).Round(time.Second)))
+			continue
+		}
+		blocking = append(blocking, fmt.Sprintf("%s/%s (not evicted for %s)", pod.Namespace, pod.Name, now.Sub(drainStart).Round(time.Second)))
+	}
+	return fmt.Sprintf("waiting for Pods %s", strings.Join(blocking, ", "))
+}
+
+// drainStartTime returns the time the drain of the Machine's node started, as recorded in the Machine's deletion status.
+func drainStartTime(machine *clusterv1.Machine) time.Time {
+	if machine.Status.Deletion != nil && machine.Status.Deletion.NodeDrainStartTime != nil {
+		return machine.Status.Deletion.NodeDrainStartTime.Time
+	}
+	return time.Now()
+}
+
 // shouldWaitForNodeVolumes returns true if node status still have volumes attached
 // pod deletion and volume detach happen asynchronously, so pod could be deleted before volume detached from the node
 // this could cause issue for some storage provisioner, for example, vsphere-volume this is problematic
@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// sortMachineAddresses orders the addresses according to the Machine's address preference:
+// addresses of the preferred types come first, in the order of the preferred types, followed by the addresses
+// of the other types, in the order each type is first reported. Within the same type, IP addresses of the
+// preferred IP family come before the IP addresses of the other family; addresses are otherwise kept in the
+// order they have been reported.
+func sortMachineAddresses(addresses clusterv1.MachineAddresses, preference *clusterv1.MachineAddressPreference) clusterv1.MachineAddresses {
+	if preference == nil || len(addresses) < 2 {
+		return addresses
+	}
+
+	typeRank := map[clusterv1.MachineAddressType]int{}
+	for _, t := range preference.Types {
+		if _, ok := typeRank[t]; !ok {
+			typeRank[t] = len(typeRank)
+		}
+	}
+	for _, address := range addresses {
+		if _, ok := typeRank[address.Type]; !ok {
+			typeRank[address.Type] = len(typeRank)
+		}
+	}
+
+	familyRank := func(address string) int {
+		ip := net.ParseIP(address)
+		if preference.IPFamily == "" || ip == nil {
+			return 0
+		}
+		if (ip.To4() != nil) == (preference.IPFamily == clusterv1.MachineAddressIPv4Family) {
+			return 0
+		}
+		return 1
+	}
+
+	sorted := make(clusterv1.MachineAddresses, len(addresses))
+	copy(sorted, addresses)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ti, tj := typeRank[sorted[i].Type], typeRank[sorted[j].Type]; ti != tj {
+			return ti < tj
+		}
+		return familyRank(sorted[i].Address) < familyRank(sorted[j].Address)
+	})
+	return sorted
+}
+
+// matchNodeByAddress returns the Node, among nodes, matching the Machine's addresses of the types preferred by
+// preference: the Machine's addresses are checked in the order defined by the preference, and the first address
+// reported by exactly one Node, with the same type, identifies the Node. IP addresses are compared by value, so
+// e.g. different representations of the same IPv6 address match.
+// It returns nil if the preference does not define address types, or if no address identifies a single Node.
+func matchNodeByAddress(nodes []corev1.Node, addresses clusterv1.MachineAddresses, preference *clusterv1.MachineAddressPreference) *corev1.Node {
+	if preference == nil || len(preference.Types) == 0 {
+		return nil
+	}
+
+	preferred := map[clusterv1.MachineAddressType]bool{}
+	for _, t := range preference.Types {
+		preferred[t] = true
+	}
+
+	for _, address := range sortMachineAddresses(addresses, preference) {
+		if !preferred[address.Type] {
+			continue
+		}
+
+		var match *corev1.Node
+		matches := 0
+		for i := range nodes {
+			for _, nodeAddress := range nodes[i].Status.Addresses {
+				if clusterv1.MachineAddressType(nodeAddress.Type) == address.Type && sameAddress(nodeAddress.Address, address.Address) {
+					match = &nodes[i]
+					matches++
+					break
+				}
+			}
+		}
+		if matches == 1 {
+			return match
+		}
+	}
+	return nil
+}
+
+// sameAddress returns true if two addresses are the same, comparing IP addresses by value.
+func sameAddress(a, b string) bool {
+	if ipA, ipB := net.ParseIP(a), net.ParseIP(b); ipA != nil && ipB != nil {
+		return ipA.Equal(ipB)
+	}
+	return a == b
+}
+
+// machineAddressesFromNode returns the addresses of a Node as Machine addresses.
+func machineAddressesFromNode(node *corev1.Node) clusterv1.MachineAddresses {
+	addresses := make(clusterv1.MachineAddresses, 0, len(node.Status.Addresses))
+	for _, address := range node.Status.Addresses {
+		addresses = append(addresses, clusterv1.MachineAddress{
+			Type:    clusterv1.MachineAddressType(address.Type),
+			Address: address.Address,
+		})
+	}
+	return addresses
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSortMachineAddresses(t *testing.T) {
+	hostname := clusterv1.MachineAddress{Type: clusterv1.MachineHostName, Address: "machine-1"}
+	internalIPv4 := clusterv1.MachineAddress{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}
+	otherInternalIPv4 := clusterv1.MachineAddress{Type: clusterv1.MachineInternalIP, Address: "192.168.0.1"}
+	internalIPv6 := clusterv1.MachineAddress{Type: clusterv1.MachineInternalIP, Address: "fd00::1"}
+	externalIPv4 := clusterv1.MachineAddress{Type: clusterv1.MachineExternalIP, Address: "1.2.3.4"}
+	externalIPv6 := clusterv1.MachineAddress{Type: clusterv1.MachineExternalIP, Address: "2001:db8::1"}
+
+	tests := []struct {
+		name       string
+		addresses  clusterv1.MachineAddresses
+		preference *clusterv1.MachineAddressPreference
+		want       clusterv1.MachineAddresses
+	}{
+		{
+			name:      "without preference the order is preserved",
+			addresses: clusterv1.MachineAddresses{internalIPv6, hostname, externalIPv4, internalIPv4},
+			want:      clusterv1.MachineAddresses{internalIPv6, hostname, externalIPv4, internalIPv4},
+		},
+		{
+			name:       "preferred types come first",
+			addresses:  clusterv1.MachineAddresses{hostname, internalIPv4, externalIPv4, otherInternalIPv4},
+			preference: &clusterv1.MachineAddressPreference{Types: []clusterv1.MachineAddressType{clusterv1.MachineExternalIP, clusterv1.MachineInternalIP}},
+			want:       clusterv1.MachineAddresses{externalIPv4, internalIPv4, otherInternalIPv4, hostname},
+		},
+		{
+			name:       "preferred IP family comes first within the same type",
+			addresses:  clusterv1.MachineAddresses{hostname, internalIPv4, internalIPv6, externalIPv4, externalIPv6},
+			preference: &clusterv1.MachineAddressPreference{IPFamily: clusterv1.MachineAddressIPv6Family},
+			want:       clusterv1.MachineAddresses{hostname, internalIPv6, internalIPv4, externalIPv6, externalIPv4},
+		},
+		{
+			name:      "preferred types and IP family",
+			addresses: clusterv1.MachineAddresses{internalIPv6, externalIPv6, internalIPv4, hostname, externalIPv4, otherInternalIPv4},
+			preference: &clusterv1.MachineAddressPreference{
+				Types:    []clusterv1.MachineAddressType{clusterv1.MachineInternalIP},
+				IPFamily: clusterv1.MachineAddressIPv4Family,
+			},
+			want: clusterv1.MachineAddresses{internalIPv4, otherInternalIPv4, internalIPv6, externalIPv4, externalIPv6, hostname},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			addresses := make(clusterv1.MachineAddresses, len(tt.addresses))
+			copy(addresses, tt.addresses)
+
+			g.Expect(sortMachineAddresses(addresses, tt.preference)).To(Equal(tt.want))
+			// The input addresses are not changed.
+			g.Expect(addresses).To(Equal(tt.addresses))
+		})
+	}
+}
+
+func TestMachineAddressesFromNode(t *testing.T) {
+	g := NewWithT(t)
+
+	node := &corev1.Node{
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "node-1"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+	g.Expect(machineAddressesFromNode(node)).To(Equal(clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineHostName, Address: "node-1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+	}))
+}
+
+func TestMatchNodeByAddress(t *testing.T) {
+	newNode := func(name string, addresses ...corev1.NodeAddress) corev1.Node {
+		return corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Addresses: addresses},
+		}
+	}
+	nodes := []corev1.Node{
+		newNode("node-1",
+			corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+			corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "fd00::1"},
+			corev1.NodeAddress{Type: corev1.NodeHostName, Address: "shared"},
+		),
+		newNode("node-2",
+			corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.2"},
+			corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "10.0.0.1"},
+			corev1.NodeAddress{Type: corev1.NodeHostName, Address: "shared"},
+		),
+	}
+
+	tests := []struct {
+		name       string
+		addresses  clusterv1.MachineAddresses
+		preference *clusterv1.MachineAddressPreference
+		want       string
+	}{
+		{
+			name:      "does not match without preferred address types",
+			addresses: clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}},
+			preference: &clusterv1.MachineAddressPreference{
+				IPFamily: clusterv1.MachineAddressIPv4Family,
+			},
+		},
+		{
+			name:       "matches by an address of the preferred type",
+			addresses:  clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}},
+			preference: &clusterv1.MachineAddressPreference{Types: []clusterv1.MachineAddressType{clusterv1.MachineInternalIP}},
+			want:       "node-1",
+		},
+		{
+			name:       "matches only addresses of the same type",
+			addresses:  clusterv1.MachineAddresses{{Type: clusterv1.MachineExternalIP, Address: "10.0.0.1"}},
+			preference: &clusterv1.MachineAddressPreference{Types: []clusterv1.MachineAddressType{clusterv1.MachineExternalIP}},
+			want:       "node-2",
+		},
+		{
+			name:       "ignores addresses of types which are not preferred",
+			addresses:  clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"}},
+			preference: &clusterv1.MachineAddressPreference{Types: []clusterv1.MachineAddressType{clusterv1.MachineExternalIP}},
+		},
+		{
+			name: "skips addresses matching more than one node",
+			addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineHostName, Address: "shared"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.2"},
+			},
+			preference: &clusterv1.MachineAddressPreference{Types: []clusterv1.MachineAddressType{clusterv1.MachineHostName, clusterv1.MachineInternalIP}},
+			want:       "node-2",
+		},
+		{
+			name: "compares IPv6 addresses by value, in the order of the preferred IP family",
+			addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.99"},
+				{Type: clusterv1.MachineInternalIP, Address: "fd00:0:0::1"},
+			},
+			preference: &clusterv1.MachineAddressPreference{
+				Types:    []clusterv1.MachineAddressType{clusterv1.MachineInternalIP},
+				IPFamily: clusterv1.MachineAddressIPv6Family,
+			},
+			want: "node-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			node := matchNodeByAddress(nodes, tt.addresses, tt.preference)
+			if tt.want == "" {
+				g.Expect(node).To(BeNil())
+				return
+			}
+			g.Expect(node).ToNot(BeNil())
+			g.Expect(node.Name).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestGetNodeByAddress(t *testing.T) {
+	g := NewWithT(t)
+
+	nodeWithProviderID := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-with-provider-id"},
+		Spec:       corev1.NodeSpec{ProviderID: "test://id-1"},
+		Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}}},
+	}
+	nodeWithoutProviderID := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-without-provider-id"},
+		Status:     corev1.NodeStatus{Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.2"}}},
+	}
+	r := &MachineReconciler{}
+	c := fake.NewClientBuilder().WithObjects(nodeWithProviderID, nodeWithoutProviderID).Build()
+
+	newMachine := func(address string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				AddressPreference: &clusterv1.MachineAddressPreference{Types: []clusterv1.MachineAddressType{clusterv1.MachineInternalIP}},
+			},
+			Status: clusterv1.MachineStatus{
+				Addresses: clusterv1.MachineAddresses{{Type: clusterv1.MachineInternalIP, Address: address}},
+			},
+		}
+	}
+
+	node, err := r.getNodeByAddress(ctx, c, newMachine("10.0.0.2"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(node.Name).To(Equal(nodeWithoutProviderID.Name))
+
+	// Nodes reporting a ProviderID are never matched by address.
+	_, err = r.getNodeByAddress(ctx, c, newMachine("10.0.0.1"))
+	g.Expect(err).To(Equal(ErrNodeNotFound))
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileNodeAdoption adopts an existing Node into Cluster API management when the Machine has the
+// AdoptNodeAnnotation, by backfilling the provider ID of the Node into the Machine and the InfrastructureMachine.
+// Once the provider ID is set, the Node is linked to the Machine by reconcileNode, like for any other Machine.
+func (r *MachineReconciler) reconcileNodeAdoption(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) (ctrl.Result, error) {
+	nodeName := annotations.GetAdoptNode(machine)
+	// Check that the Machine hasn't been deleted or in the process, that it should adopt a Node,
+	// and that the Node hasn't been adopted yet.
+	if !machine.DeletionTimestamp.IsZero() || nodeName == "" || machine.Status.NodeRef != nil {
+		return ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx, "cluster", cluster.Name, "nodename", nodeName)
+
+	remoteClient, err := r.Tracker.GetClient(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	node := &corev1.Node{}
+	if err := remoteClient.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get Node %q to be adopted by Machine %q in namespace %q", nodeName, machine.Name, machine.Namespace)
+	}
+
+	providerID := node.Spec.ProviderID
+	if providerID == "" {
+		return ctrl.Result{}, errors.Errorf("Node %q to be adopted by Machine %q in namespace %q has an empty ProviderID", nodeName, machine.Name, machine.Namespace)
+	}
+	if machine.Spec.ProviderID != nil && *machine.Spec.ProviderID != "" && *machine.Spec.ProviderID != providerID {
+		return ctrl.Result{}, errors.Errorf("ProviderID %q of Machine %q in namespace %q does not match ProviderID %q of Node %q", *machine.Spec.ProviderID, machine.Name, machine.Namespace, providerID, nodeName)
+	}
+
+	// Backfill the provider ID into the InfrastructureMachine, so the infrastructure provider can
+	// take over the existing instance instead of creating a new one.
+	infra, err := external.Get(ctx, r.Client, &machine.Spec.InfrastructureRef, machine.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("Could not find infrastructure machine for the Node to be adopted, requeuing", "infraRef", machine.Spec.InfrastructureRef.Name)
+			return ctrl.Result{RequeueAfter: externalReadyWait}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	infraProviderID, _, err := unstructured.NestedString(infra.Object, "spec", "providerID")
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve Spec.ProviderID from infrastructure provider for Machine %q in namespace %q", machine.Name, machine.Namespace)
+	}
+	switch infraProviderID {
+	case providerID: // no-op
+	case "":
+		patchHelper, err := patch.NewHelper(infra, r.Client)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := unstructured.SetNestedField(infra.Object, providerID, "spec", "providerID"); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to set Spec.ProviderID on infrastructure provider for Machine %q in namespace %q", machine.Name, machine.Namespace)
+		}
+		if err := patchHelper.Patch(ctx, infra); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to patch Spec.ProviderID on infrastructure provider for Machine %q in namespace %q", machine.Name, machine.Namespace)
+		}
+	default:
+		return ctrl.Result{}, errors.Errorf("ProviderID %q of infrastructure provider for Machine %q in namespace %q does not match ProviderID %q of Node %q", infraProviderID, machine.Name, machine.Namespace, providerID, nodeName)
+	}
+
+	if machine.Spec.ProviderID == nil || *machine.Spec.ProviderID == "" {
+		machine.Spec.ProviderID = pointer.StringPtr(providerID)
+		log.Info("Backfilled Machine's ProviderID from the Node to be adopted", "providerID", providerID)
+		r.recorder.Event(machine, corev1.EventTypeNormal, "SuccessfulAdoptNode", nodeName)
+	}
+
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestReconcileNodeAdoption(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-1",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec:       corev1.NodeSpec{ProviderID: "test://id-1"},
+	}
+
+	tests := []struct {
+		name                string
+		annotations         map[string]string
+		machineProviderID   *string
+		infraProviderID     string
+		wantErr             bool
+		wantProviderID      *string
+		wantInfraProviderID string
+	}{
+		{
+			name:           "no-op when the Machine does not have the adopt-node annotation",
+			wantProviderID: nil,
+		},
+		{
+			name:                "backfills the ProviderID into the Machine and the InfrastructureMachine",
+			annotations:         map[string]string{clusterv1.AdoptNodeAnnotation: "node-1"},
+			wantProviderID:      pointer.StringPtr("test://id-1"),
+			wantInfraProviderID: "test://id-1",
+		},
+		{
+			name:                "backfills the ProviderID into the Machine when already set on the InfrastructureMachine",
+			annotations:         map[string]string{clusterv1.AdoptNodeAnnotation: "node-1"},
+			infraProviderID:     "test://id-1",
+			wantProviderID:      pointer.StringPtr("test://id-1"),
+			wantInfraProviderID: "test://id-1",
+		},
+		{
+			name:                "fails when the InfrastructureMachine ProviderID does not match the Node",
+			annotations:         map[string]string{clusterv1.AdoptNodeAnnotation: "node-1"},
+			infraProviderID:     "test://id-2",
+			wantErr:             true,
+			wantInfraProviderID: "test://id-2",
+		},
+		{
+			name:              "fails when the Machine ProviderID does not match the Node",
+			annotations:       map[string]string{clusterv1.AdoptNodeAnnotation: "node-1"},
+			machineProviderID: pointer.StringPtr("test://id-2"),
+			wantErr:           true,
+			wantProviderID:    pointer.StringPtr("test://id-2"),
+		},
+		{
+			name:        "fails when the Node does not exist",
+			annotations: map[string]string{clusterv1.AdoptNodeAnnotation: "node-2"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "machine-test",
+					Namespace:   metav1.NamespaceDefault,
+					Annotations: tt.annotations,
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: cluster.Name,
+					ProviderID:  tt.machineProviderID,
+					InfrastructureRef: corev1.ObjectReference{
+						APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+						Kind:       "GenericInfrastructureMachine",
+						Name:       "infra-config1",
+					},
+				},
+			}
+
+			infraMachine := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind":       "GenericInfrastructureMachine",
+					"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+					"metadata": map[string]interface{}{
+						"name":      "infra-config1",
+						"namespace": metav1.NamespaceDefault,
+					},
+					"spec": map[string]interface{}{},
+				},
+			}
+			if tt.infraProviderID != "" {
+				g.Expect(unstructured.SetNestedField(infraMachine.Object, tt.infraProviderID, "spec", "providerID")).To(Succeed())
+			}
+
+			c := fake.NewClientBuilder().WithObjects(
+				cluster.DeepCopy(),
+				machine.DeepCopy(),
+				node.DeepCopy(),
+				builder.GenericInfrastructureMachineCRD.DeepCopy(),
+				infraMachine,
+			).Build()
+
+			r := &MachineReconciler{
+				Client:   c,
+				Tracker:  remote.NewTestClusterCacheTracker(log.NullLogger{}, c, scheme.Scheme, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			_, err := r.reconcileNodeAdoption(ctx, cluster, machine)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(machine.Spec.ProviderID).To(Equal(tt.wantProviderID))
+
+			gotInfraMachine := infraMachine.DeepCopy()
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(infraMachine), gotInfraMachine)).To(Succeed())
+			gotInfraProviderID, _, err := unstructured.NestedString(gotInfraMachine.Object, "spec", "providerID")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(gotInfraProviderID).To(Equal(tt.wantInfraProviderID))
+		})
+	}
+}
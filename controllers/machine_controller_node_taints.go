@@ -0,0 +1,127 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/patch"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileNodeUninitializedTaint removes the NodeUninitializedTaint from the Machine's Node, if any, once
+// the Machine controller has completed the Node setup.
+func (r *MachineReconciler) reconcileNodeUninitializedTaint(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) (ctrl.Result, error) {
+	// Check that the Machine hasn't been deleted or in the process
+	// and that the Machine has a NodeRef.
+	if !machine.DeletionTimestamp.IsZero() || machine.Status.NodeRef == nil {
+		return ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+
+	remoteClient, err := r.Tracker.GetClient(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	node := &corev1.Node{}
+	if err := remoteClient.Get(ctx, client.ObjectKey{Name: machine.Status.NodeRef.Name}, node); err != nil {
+		// If the Node is gone there is nothing to do; this is going to be detected by the MachineHealthCheck, if any.
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !hasTaint(node.Spec.Taints, clusterv1.NodeUninitializedTaint) {
+		return ctrl.Result{}, nil
+	}
+
+	// Get interruptible instance status from the infrastructure provider, so we can check the interruptible label
+	// has been set before removing the taint.
+	infra, err := external.Get(ctx, r.Client, &machine.Spec.InfrastructureRef, machine.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	interruptible, _, _ := unstructured.NestedBool(infra.Object, "status", "interruptible")
+
+	if !isNodeInitialized(node, machine, interruptible) {
+		// No need to requeue here. The Node is updated by the previous phases, and
+		// Nodes emit an event that triggers reconciliation.
+		log.V(3).Info("Waiting for the Node setup to complete before removing the uninitialized taint", "nodename", node.Name)
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(node, remoteClient)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	node.Spec.Taints = removeTaint(node.Spec.Taints, clusterv1.NodeUninitializedTaint)
+	if err := patchHelper.Patch(ctx, node); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	log.V(3).Info("Removed uninitialized taint from Machine's Node", "nodename", node.Name)
+	r.recorder.Event(machine, corev1.EventTypeNormal, "SuccessfulRemoveNodeUninitializedTaint", node.Name)
+
+	return ctrl.Result{}, nil
+}
+
+// isNodeInitialized returns true if the Machine controller has completed the Node setup, i.e. the Node has
+// the annotations linking it to the Machine and, for interruptible instances, the interruptible label.
+func isNodeInitialized(node *corev1.Node, machine *clusterv1.Machine, interruptible bool) bool {
+	annotations := node.GetAnnotations()
+	if annotations[clusterv1.ClusterNameAnnotation] != machine.Spec.ClusterName ||
+		annotations[clusterv1.ClusterNamespaceAnnotation] != machine.Namespace ||
+		annotations[clusterv1.MachineAnnotation] != machine.Name {
+		return false
+	}
+	if interruptible {
+		if _, ok := node.GetLabels()[clusterv1.InterruptibleLabel]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func hasTaint(taints []corev1.Taint, taint corev1.Taint) bool {
+	for i := range taints {
+		if taints[i].MatchTaint(&taint) {
+			return true
+		}
+	}
+	return false
+}
+
+func removeTaint(taints []corev1.Taint, taint corev1.Taint) []corev1.Taint {
+	var result []corev1.Taint
+	for i := range taints {
+		if taints[i].MatchTaint(&taint) {
+			continue
+		}
+		result = append(result, taints[i])
+	}
+	return result
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func TestReconcileNodeUninitializedTaint(t *testing.T) {
+	otherTaint := corev1.Taint{Key: "other", Effect: corev1.TaintEffectNoExecute}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-1",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-test",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: cluster.Name,
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Kind:       "GenericInfrastructureMachine",
+				Name:       "infra-config1",
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "node-1",
+			},
+		},
+	}
+
+	initializedAnnotations := map[string]string{
+		clusterv1.ClusterNameAnnotation:      cluster.Name,
+		clusterv1.ClusterNamespaceAnnotation: machine.Namespace,
+		clusterv1.MachineAnnotation:          machine.Name,
+	}
+
+	tests := []struct {
+		name          string
+		interruptible bool
+		node          *corev1.Node
+		wantTaints    []corev1.Taint
+	}{
+		{
+			name: "removes the taint when the Node setup is completed",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1", Annotations: initializedAnnotations},
+				Spec:       corev1.NodeSpec{Taints: []corev1.Taint{otherTaint, clusterv1.NodeUninitializedTaint}},
+			},
+			wantTaints: []corev1.Taint{otherTaint},
+		},
+		{
+			name: "preserves the taint when the Node annotations are not set yet",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+				Spec:       corev1.NodeSpec{Taints: []corev1.Taint{clusterv1.NodeUninitializedTaint}},
+			},
+			wantTaints: []corev1.Taint{clusterv1.NodeUninitializedTaint},
+		},
+		{
+			name:          "preserves the taint when the interruptible label is not set yet",
+			interruptible: true,
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-1", Annotations: initializedAnnotations},
+				Spec:       corev1.NodeSpec{Taints: []corev1.Taint{clusterv1.NodeUninitializedTaint}},
+			},
+			wantTaints: []corev1.Taint{clusterv1.NodeUninitializedTaint},
+		},
+		{
+			name:          "removes the taint when the interruptible label is set",
+			interruptible: true,
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "node-1",
+					Annotations: initializedAnnotations,
+					Labels:      map[string]string{clusterv1.InterruptibleLabel: ""},
+				},
+				Spec: corev1.NodeSpec{Taints: []corev1.Taint{clusterv1.NodeUninitializedTaint}},
+			},
+			wantTaints: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			infraMachine := &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"kind":       "GenericInfrastructureMachine",
+					"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+					"metadata": map[string]interface{}{
+						"name":      "infra-config1",
+						"namespace": metav1.NamespaceDefault,
+					},
+					"status": map[string]interface{}{
+						"interruptible": tt.interruptible,
+					},
+				},
+			}
+
+			c := fake.NewClientBuilder().WithObjects(
+				cluster.DeepCopy(),
+				machine.DeepCopy(),
+				tt.node,
+				builder.GenericInfrastructureMachineCRD.DeepCopy(),
+				infraMachine,
+			).Build()
+
+			r := &MachineReconciler{
+				Client:   c,
+				Tracker:  remote.NewTestClusterCacheTracker(log.NullLogger{}, c, scheme.Scheme, client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			_, err := r.reconcileNodeUninitializedTaint(ctx, cluster, machine)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			node := &corev1.Node{}
+			g.Expect(c.Get(ctx, client.ObjectKey{Name: tt.node.Name}, node)).To(Succeed())
+			g.Expect(node.Spec.Taints).To(Equal(tt.wantTaints))
+		})
+	}
+}
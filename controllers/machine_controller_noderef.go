@@ -50,6 +50,12 @@ func (r *MachineReconciler) reconcileNode(ctx context.Context, cluster *clusterv
 		return ctrl.Result{}, nil
 	}
 
+	// Skip Node matching if other Machines report the same ProviderID, because the Node could belong to any of them.
+	if conditions.IsTrue(machine, clusterv1.DuplicateProviderIDCondition) {
+		log.Info("Cannot reconcile Machine's Node, ProviderID is not unique")
+		return ctrl.Result{}, nil
+	}
+
 	providerID, err := noderefutil.NewProviderID(*machine.Spec.ProviderID)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -62,12 +68,20 @@ func (r *MachineReconciler) reconcileNode(ctx context.Context, cluster *clusterv
 
 	// Even if Status.NodeRef exists, continue to do the following checks to make sure Node is healthy
 	node, err := r.getNode(ctx, remoteClient, providerID)
+	if err == ErrNodeNotFound && machine.Spec.AddressPreference != nil && len(machine.Spec.AddressPreference.Types) > 0 {
+		// If no Node reports the Machine's ProviderID, e.g. because the kubelet is not configured with it,
+		// fall back to matching the Node by the Machine's addresses of the preferred types.
+		node, err = r.getNodeByAddress(ctx, remoteClient, machine)
+	}
 	if err != nil {
 		if err == ErrNodeNotFound {
 			// While a NodeRef is set in the status, failing to get that node means the node is deleted.
 			// If Status.NodeRef is not set before, node still can be in the provisioning state.
 			if machine.Status.NodeRef != nil {
 				conditions.MarkFalse(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.NodeNotFoundReason, clusterv1.ConditionSeverityError, "")
+				for _, m := range mirroredNodeConditions {
+					conditions.MarkUnknown(machine, m.machineCondition, clusterv1.NodeNotFoundReason, "")
+				}
 				return ctrl.Result{}, errors.Wrapf(err, "no matching Node for Machine %q in namespace %q", machine.Name, machine.Namespace)
 			}
 			conditions.MarkFalse(machine, clusterv1.MachineNodeHealthyCondition, clusterv1.NodeProvisioningReason, clusterv1.ConditionSeverityWarning, "")
@@ -91,8 +105,19 @@ func (r *MachineReconciler) reconcileNode(ctx context.Context, cluster *clusterv
 		r.recorder.Event(machine, corev1.EventTypeNormal, "SuccessfulSetNodeRef", machine.Status.NodeRef.Name)
 	}
 
-	// Set the NodeSystemInfo.
+	// A Node has been found, so any bootstrap failure previously reported by providers has been overcome.
+	if conditions.Has(machine, clusterv1.BootstrapSucceededCondition) {
+		conditions.MarkTrue(machine, clusterv1.BootstrapSucceededCondition)
+	}
+
+	// Set the NodeSystemInfo, and mirror the key Node conditions, so the Node health can be observed from the management cluster.
 	machine.Status.NodeInfo = &node.Status.NodeInfo
+	mirrorNodeConditions(machine, node)
+
+	// If the infrastructure provider does not report the Machine addresses, use the addresses of the Node.
+	if len(machine.Status.Addresses) == 0 {
+		machine.Status.Addresses = sortMachineAddresses(machineAddressesFromNode(node), machine.Spec.AddressPreference)
+	}
 
 	// Reconcile node annotations.
 	patchHelper, err := patch.NewHelper(node, remoteClient)
@@ -172,6 +197,78 @@ func summarizeNodeConditions(node *corev1.Node) (corev1.ConditionStatus, string)
 	return corev1.ConditionUnknown, message
 }
 
+// mirroredNodeConditions are the Node conditions mirrored on the Machine; inverted is true for the Node
+// conditions reporting a problem when True, which are mirrored with a positive polarity on the Machine.
+var mirroredNodeConditions = []struct {
+	nodeCondition    corev1.NodeConditionType
+	machineCondition clusterv1.ConditionType
+	inverted         bool
+}{
+	{nodeCondition: corev1.NodeReady, machineCondition: clusterv1.MachineNodeReadyCondition},
+	{nodeCondition: corev1.NodeMemoryPressure, machineCondition: clusterv1.MachineNodeMemoryAvailableCondition, inverted: true},
+	{nodeCondition: corev1.NodeDiskPressure, machineCondition: clusterv1.MachineNodeDiskAvailableCondition, inverted: true},
+}
+
+// mirrorNodeConditions sets the Machine conditions mirroring the key conditions of its Node, preserving the reason
+// and the message reported by the Kubelet.
+func mirrorNodeConditions(machine *clusterv1.Machine, node *corev1.Node) {
+	for _, m := range mirroredNodeConditions {
+		var nodeCondition *corev1.NodeCondition
+		for i := range node.Status.Conditions {
+			if node.Status.Conditions[i].Type == m.nodeCondition {
+				nodeCondition = &node.Status.Conditions[i]
+				break
+			}
+		}
+
+		if nodeCondition == nil || nodeCondition.Status == corev1.ConditionUnknown {
+			conditions.MarkUnknown(machine, m.machineCondition, clusterv1.NodeConditionUnknownReason, "Node condition %s is Unknown", m.nodeCondition)
+			continue
+		}
+
+		if (nodeCondition.Status == corev1.ConditionTrue) != m.inverted {
+			conditions.MarkTrue(machine, m.machineCondition)
+			continue
+		}
+
+		reason := nodeCondition.Reason
+		if reason == "" {
+			reason = clusterv1.NodeConditionsFailedReason
+		}
+		conditions.MarkFalse(machine, m.machineCondition, reason, clusterv1.ConditionSeverityWarning, "%s", nodeCondition.Message)
+	}
+}
+
+// getNodeByAddress returns the Node without a ProviderID matching the Machine's addresses of the types preferred
+// by the Machine's address preference; see matchNodeByAddress.
+func (r *MachineReconciler) getNodeByAddress(ctx context.Context, c client.Reader, machine *clusterv1.Machine) (*corev1.Node, error) {
+	if len(machine.Status.Addresses) == 0 {
+		return nil, ErrNodeNotFound
+	}
+
+	// Nodes reporting a ProviderID are matched only by ProviderID, so they are never matched to another Machine.
+	var candidates []corev1.Node
+	nl := corev1.NodeList{}
+	for {
+		if err := c.List(ctx, &nl, client.Continue(nl.Continue)); err != nil {
+			return nil, err
+		}
+		for i := range nl.Items {
+			if nl.Items[i].Spec.ProviderID == "" {
+				candidates = append(candidates, nl.Items[i])
+			}
+		}
+		if nl.Continue == "" {
+			break
+		}
+	}
+
+	if node := matchNodeByAddress(candidates, machine.Status.Addresses, machine.Spec.AddressPreference); node != nil {
+		return node, nil
+	}
+	return nil, ErrNodeNotFound
+}
+
 func (r *MachineReconciler) getNode(ctx context.Context, c client.Reader, providerID *noderefutil.ProviderID) (*corev1.Node, error) {
 	log := ctrl.LoggerFrom(ctx, "providerID", providerID)
 	nodeList := corev1.NodeList{}
@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -226,3 +227,43 @@ func TestSummarizeNodeConditions(t *testing.T) {
 		})
 	}
 }
+
+func TestMirrorNodeConditions(t *testing.T) {
+	g := NewWithT(t)
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionTrue, Reason: "KubeletHasInsufficientMemory", Message: "kubelet has insufficient memory available"},
+			},
+		},
+	}
+	machine := &clusterv1.Machine{}
+
+	mirrorNodeConditions(machine, node)
+
+	g.Expect(conditions.IsTrue(machine, clusterv1.MachineNodeReadyCondition)).To(BeTrue())
+	g.Expect(conditions.IsFalse(machine, clusterv1.MachineNodeMemoryAvailableCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(machine, clusterv1.MachineNodeMemoryAvailableCondition)).To(Equal("KubeletHasInsufficientMemory"))
+	g.Expect(conditions.GetMessage(machine, clusterv1.MachineNodeMemoryAvailableCondition)).To(Equal("kubelet has insufficient memory available"))
+	g.Expect(conditions.IsUnknown(machine, clusterv1.MachineNodeDiskAvailableCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(machine, clusterv1.MachineNodeDiskAvailableCondition)).To(Equal(clusterv1.NodeConditionUnknownReason))
+
+	// Conditions are updated as the Node conditions change.
+	node.Status.Conditions = []corev1.NodeCondition{
+		{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Reason: "KubeletNotReady"},
+		{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+		{Type: corev1.NodeDiskPressure, Status: corev1.ConditionFalse},
+	}
+
+	mirrorNodeConditions(machine, node)
+
+	g.Expect(conditions.IsFalse(machine, clusterv1.MachineNodeReadyCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(machine, clusterv1.MachineNodeReadyCondition)).To(Equal("KubeletNotReady"))
+	g.Expect(conditions.IsTrue(machine, clusterv1.MachineNodeMemoryAvailableCondition)).To(BeTrue())
+	g.Expect(conditions.IsTrue(machine, clusterv1.MachineNodeDiskAvailableCondition)).To(BeTrue())
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileOrphan flags Machines belonging to a MachineSet without a controller reference, e.g. because the MachineSet
+// has been deleted orphaning its Machines, by setting the MachineSetOwnedCondition to false.
+// NOTE: Orphaned Machines are adopted by the MachineSet selecting them, if any, unless orphan adoption is disabled
+// in the MachineSet controller; the condition is set to true as soon as the Machine is adopted.
+func (r *MachineReconciler) reconcileOrphan(ctx context.Context, _ *clusterv1.Cluster, m *clusterv1.Machine) (ctrl.Result, error) {
+	// Control plane Machines are managed by the control plane provider.
+	if util.IsControlPlaneMachine(m) {
+		return ctrl.Result{}, nil
+	}
+
+	if metav1.GetControllerOf(m) != nil {
+		if belongsToMachineSet(m) || conditions.Has(m, clusterv1.MachineSetOwnedCondition) {
+			conditions.MarkTrue(m, clusterv1.MachineSetOwnedCondition)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	machineSets, err := r.getMachineSetsSelectingMachine(ctx, m)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Machines without a controller reference which are not selected by any MachineSet and which have never been
+	// created by a MachineSet are standalone Machines.
+	if len(machineSets) == 0 && !belongsToMachineSet(m) {
+		conditions.Delete(m, clusterv1.MachineSetOwnedCondition)
+		return ctrl.Result{}, nil
+	}
+
+	log := ctrl.LoggerFrom(ctx)
+	if len(machineSets) == 0 {
+		log.Info("Machine is orphaned and it is not selected by any MachineSet")
+		conditions.MarkFalse(m, clusterv1.MachineSetOwnedCondition, clusterv1.MachineOrphanedReason, clusterv1.ConditionSeverityWarning,
+			"Machine is not controlled by any MachineSet and no MachineSet selects it")
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("Machine is orphaned", "machinesets", strings.Join(machineSets, ","))
+	conditions.MarkFalse(m, clusterv1.MachineSetOwnedCondition, clusterv1.MachineOrphanedReason, clusterv1.ConditionSeverityWarning,
+		"Machine is not controlled by any MachineSet; it is selected by MachineSet(s) %s", strings.Join(machineSets, ", "))
+	return ctrl.Result{}, nil
+}
+
+// getMachineSetsSelectingMachine returns the names of the MachineSets in the Machine's cluster whose selector matches
+// the Machine's labels.
+func (r *MachineReconciler) getMachineSetsSelectingMachine(ctx context.Context, m *clusterv1.Machine) ([]string, error) {
+	msList := &clusterv1.MachineSetList{}
+	if err := r.Client.List(ctx, msList, client.InNamespace(m.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: m.Spec.ClusterName}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list MachineSets")
+	}
+
+	var names []string
+	for i := range msList.Items {
+		ms := &msList.Items[i]
+		if !ms.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if hasMatchingLabels(ms.Spec.Selector, m.Labels) {
+			names = append(names, ms.Name)
+		}
+	}
+	return names, nil
+}
+
+// belongsToMachineSet returns true if the Machine has the labels applied to Machines created by MachineSets and
+// MachineDeployments.
+func belongsToMachineSet(m *clusterv1.Machine) bool {
+	for _, label := range []string{
+		clusterv1.MachineSetLabelName,
+		clusterv1.MachineDeploymentLabelName,
+		clusterv1.ClusterTopologyMachineDeploymentLabelName,
+	} {
+		if _, ok := m.Labels[label]; ok {
+			return true
+		}
+	}
+	return false
+}
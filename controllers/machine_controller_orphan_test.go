@@ -0,0 +1,135 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileOrphan(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-1",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	machineSet := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ms-1",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+		},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: cluster.Name,
+			Selector:    metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}},
+		},
+	}
+
+	controllerRef := *metav1.NewControllerRef(machineSet, clusterv1.GroupVersion.WithKind("MachineSet"))
+
+	tests := []struct {
+		name          string
+		labels        map[string]string
+		ownerRefs     []metav1.OwnerReference
+		conditions    clusterv1.Conditions
+		wantCondition *clusterv1.Condition
+	}{
+		{
+			name:          "standalone Machines do not get the condition",
+			labels:        map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+			wantCondition: nil,
+		},
+		{
+			name:          "control plane Machines do not get the condition",
+			labels:        map[string]string{clusterv1.ClusterLabelName: cluster.Name, clusterv1.MachineControlPlaneLabelName: "", "pool": "a"},
+			wantCondition: nil,
+		},
+		{
+			name:          "Machines controlled by a MachineSet are flagged as owned",
+			labels:        map[string]string{clusterv1.ClusterLabelName: cluster.Name, "pool": "a", clusterv1.MachineSetLabelName: "ms-1"},
+			ownerRefs:     []metav1.OwnerReference{controllerRef},
+			wantCondition: conditions.TrueCondition(clusterv1.MachineSetOwnedCondition),
+		},
+		{
+			name:   "orphaned Machines selected by a MachineSet are flagged as orphaned",
+			labels: map[string]string{clusterv1.ClusterLabelName: cluster.Name, "pool": "a"},
+			wantCondition: conditions.FalseCondition(clusterv1.MachineSetOwnedCondition, clusterv1.MachineOrphanedReason, clusterv1.ConditionSeverityWarning,
+				"Machine is not controlled by any MachineSet; it is selected by MachineSet(s) ms-1"),
+		},
+		{
+			name:   "orphaned Machines created by a MachineSet are flagged as orphaned",
+			labels: map[string]string{clusterv1.ClusterLabelName: cluster.Name, clusterv1.MachineSetLabelName: "ms-deleted"},
+			wantCondition: conditions.FalseCondition(clusterv1.MachineSetOwnedCondition, clusterv1.MachineOrphanedReason, clusterv1.ConditionSeverityWarning,
+				"Machine is not controlled by any MachineSet and no MachineSet selects it"),
+		},
+		{
+			name:   "condition is removed when the Machine becomes standalone",
+			labels: map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+			conditions: clusterv1.Conditions{
+				*conditions.FalseCondition(clusterv1.MachineSetOwnedCondition, clusterv1.MachineOrphanedReason, clusterv1.ConditionSeverityWarning, ""),
+			},
+			wantCondition: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "machine-1",
+					Namespace:       metav1.NamespaceDefault,
+					Labels:          tt.labels,
+					OwnerReferences: tt.ownerRefs,
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName:       cluster.Name,
+					InfrastructureRef: corev1.ObjectReference{Name: "infra-1"},
+				},
+				Status: clusterv1.MachineStatus{
+					Conditions: tt.conditions,
+				},
+			}
+
+			r := &MachineReconciler{
+				Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(machineSet.DeepCopy()).Build(),
+			}
+
+			_, err := r.reconcileOrphan(ctx, cluster, machine)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			got := conditions.Get(machine, clusterv1.MachineSetOwnedCondition)
+			if tt.wantCondition == nil {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).ToNot(BeNil())
+			g.Expect(got.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(got.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(got.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(got.Message).To(Equal(tt.wantCondition.Message))
+		})
+	}
+}
@@ -19,7 +19,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -45,6 +47,10 @@ var (
 	externalReadyWait = 30 * time.Second
 )
 
+// bootstrapFailureOutputMaxLength is the maximum length of the bootstrap failure output reported by providers
+// that is surfaced in the Machine's BootstrapSucceeded condition message.
+const bootstrapFailureOutputMaxLength = 1024
+
 func (r *MachineReconciler) reconcilePhase(_ context.Context, m *clusterv1.Machine) {
 	originalPhase := m.Status.Phase // nolint:ifshort
 
@@ -166,9 +172,48 @@ func (r *MachineReconciler) reconcileExternal(ctx context.Context, cluster *clus
 		)
 	}
 
+	// Set the bootstrap failure reported by the provider, if any.
+	if err := reconcileBootstrapFailure(m, obj); err != nil {
+		return external.ReconcileOutput{}, err
+	}
+
 	return external.ReconcileOutput{Result: obj}, nil
 }
 
+// reconcileBootstrapFailure surfaces a bootstrap failure reported by an external object in the Machine's
+// BootstrapSucceeded condition. Once the Machine's Node has been found bootstrap is considered successful,
+// and failures reported by providers are ignored.
+func reconcileBootstrapFailure(m *clusterv1.Machine, obj *unstructured.Unstructured) error {
+	if m.Status.NodeRef != nil {
+		return nil
+	}
+
+	reason, output, err := external.BootstrapFailureFrom(obj)
+	if err != nil {
+		return err
+	}
+	if reason == "" {
+		return nil
+	}
+
+	message := fmt.Sprintf("Bootstrap failure detected from referenced resource %v with name %q: %s",
+		obj.GroupVersionKind(), obj.GetName(), reason)
+	if output = strings.TrimSpace(output); output != "" {
+		// Keep the tail of the output, which is usually where the error that caused the failure is.
+		// Nb. the cut is moved forward to the first rune boundary, so multi-byte characters are never split.
+		if len(output) > bootstrapFailureOutputMaxLength {
+			start := len(output) - bootstrapFailureOutputMaxLength
+			for start < len(output) && !utf8.RuneStart(output[start]) {
+				start++
+			}
+			output = "..." + output[start:]
+		}
+		message = fmt.Sprintf("%s\n%s", message, output)
+	}
+	conditions.MarkFalse(m, clusterv1.BootstrapSucceededCondition, clusterv1.BootstrapFailedReason, clusterv1.ConditionSeverityError, "%s", message)
+	return nil
+}
+
 // reconcileBootstrap reconciles the Spec.Bootstrap.ConfigRef object on a Machine.
 func (r *MachineReconciler) reconcileBootstrap(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.Machine) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx, "cluster", cluster.Name)
@@ -277,6 +322,17 @@ func (r *MachineReconciler) reconcileInfrastructure(ctx context.Context, cluster
 		conditions.WithFallbackValue(ready, clusterv1.WaitingForInfrastructureFallbackReason, clusterv1.ConditionSeverityInfo, ""),
 	)
 
+	// Report if the instance is going to be terminated by the infrastructure, e.g. because an interruptible
+	// instance has been reclaimed, so Machines controlled by a MachineSet can be replaced.
+	r.reconcileInstanceNotTerminating(m, infraConfig)
+
+	// Get and set Status.InterruptibleInstance from the infrastructure provider.
+	interruptible, _, err := unstructured.NestedBool(infraConfig.Object, "status", "interruptible")
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve Status.Interruptible from infrastructure provider for Machine %q in namespace %q", m.Name, m.Namespace)
+	}
+	m.Status.InterruptibleInstance = interruptible
+
 	// If the infrastructure provider is not ready, return early.
 	if !ready {
 		log.Info("Infrastructure provider is not ready, requeuing")
@@ -296,6 +352,7 @@ func (r *MachineReconciler) reconcileInfrastructure(ctx context.Context, cluster
 	if err != nil && err != util.ErrUnstructuredFieldNotFound {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve addresses from infrastructure provider for Machine %q in namespace %q", m.Name, m.Namespace)
 	}
+	m.Status.Addresses = sortMachineAddresses(m.Status.Addresses, m.Spec.AddressPreference)
 
 	// Get and set the failure domain from the infrastructure provider.
 	var failureDomain string
@@ -311,3 +368,49 @@ func (r *MachineReconciler) reconcileInfrastructure(ctx context.Context, cluster
 	m.Spec.ProviderID = pointer.StringPtr(providerID)
 	return ctrl.Result{}, nil
 }
+
+// reconcileInstanceNotTerminating mirrors the InstanceNotTerminating condition of the infrastructure machine on the
+// Machine, if reported by the infrastructure provider, emitting an event when the instance receives a termination notice.
+func (r *MachineReconciler) reconcileInstanceNotTerminating(m *clusterv1.Machine, infraConfig *unstructured.Unstructured) {
+	condition := conditions.Get(conditions.UnstructuredGetter(infraConfig), clusterv1.InstanceNotTerminatingCondition)
+	if condition == nil {
+		conditions.Delete(m, clusterv1.InstanceNotTerminatingCondition)
+		return
+	}
+
+	wasTerminating := conditions.IsFalse(m, clusterv1.InstanceNotTerminatingCondition)
+	conditions.Set(m, condition.DeepCopy())
+	if !wasTerminating && conditions.IsFalse(m, clusterv1.InstanceNotTerminatingCondition) {
+		r.recorder.Eventf(m, corev1.EventTypeWarning, clusterv1.TerminationNoticeReceivedReason,
+			"Instance is going to be terminated by the infrastructure: %s", condition.Message)
+	}
+}
+
+// reconcileCertificateExpiry surfaces the expiry date of the certificates of control plane machines in
+// Machine.Status.CertificatesExpiryDate; the MachineCertificatesExpiryDateAnnotation on the Machine takes
+// precedence over the one set on the bootstrap config, e.g. by the KubeadmControlPlane controller.
+func (r *MachineReconciler) reconcileCertificateExpiry(ctx context.Context, _ *clusterv1.Cluster, m *clusterv1.Machine) (ctrl.Result, error) {
+	if !util.IsControlPlaneMachine(m) {
+		return ctrl.Result{}, nil
+	}
+
+	expiry, err := annotations.GetCertificatesExpiryDate(m)
+	source := fmt.Sprintf("Machine %q", m.Name)
+	if err == nil && expiry == nil && m.Spec.Bootstrap.ConfigRef != nil {
+		bootstrapConfig, getErr := external.Get(ctx, r.Client, m.Spec.Bootstrap.ConfigRef, m.Namespace)
+		if getErr != nil && !apierrors.IsNotFound(errors.Cause(getErr)) {
+			return ctrl.Result{}, errors.Wrapf(getErr, "failed to reconcile certificates expiry: failed to read bootstrap config for Machine %q in namespace %q", m.Name, m.Namespace)
+		}
+		if getErr == nil {
+			expiry, err = annotations.GetCertificatesExpiryDate(bootstrapConfig)
+			source = fmt.Sprintf("%s %q", bootstrapConfig.GetKind(), bootstrapConfig.GetName())
+		}
+	}
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificates expiry: failed to parse expiry date from annotation on %s", source)
+	}
+
+	// If the certificates expiry information is not found on the machine nor on the bootstrap config, it is reset.
+	m.Status.CertificatesExpiryDate = expiry
+	return ctrl.Result{}, nil
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package controllers
 
 import (
+	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -31,9 +33,9 @@ import (
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -973,6 +975,30 @@ func TestReconcileInfrastructure(t *testing.T) {
 				g.Expect(m.GetOwnerReferences()).NotTo(ContainRefOfGroupKind("cluster.x-k8s.io", "MachineSet"))
 			},
 		},
+		{
+			name: "new machine, infrastructure reports an interruptible instance",
+			infraConfig: map[string]interface{}{
+				"kind":       "GenericInfrastructureMachine",
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "infra-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": map[string]interface{}{
+					"providerID": "test://id-1",
+				},
+				"status": map[string]interface{}{
+					"ready":         true,
+					"interruptible": true,
+				},
+			},
+			expectResult:  ctrl.Result{},
+			expectError:   false,
+			expectChanged: true,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.InterruptibleInstance).To(BeTrue())
+			},
+		},
 		{
 			name: "ready bootstrap, infra, and nodeRef, machine is running, infra object is deleted, expect failed",
 			machine: &clusterv1.Machine{
@@ -1027,6 +1053,106 @@ func TestReconcileInfrastructure(t *testing.T) {
 				g.Expect(m.Status.GetTypedPhase()).To(Equal(clusterv1.MachinePhaseFailed))
 			},
 		},
+		{
+			name: "new machine, infrastructure reports a bootstrap failure",
+			infraConfig: map[string]interface{}{
+				"kind":       "GenericInfrastructureMachine",
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "infra-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": map[string]interface{}{
+					"providerID": "test://id-1",
+				},
+				"status": map[string]interface{}{
+					"ready":                  true,
+					"bootstrapFailureReason": "CloudInitFailed",
+					"bootstrapFailureOutput": strings.Repeat("a", 2*bootstrapFailureOutputMaxLength) + "kubeadm join failed\n",
+				},
+			},
+			expectResult:  ctrl.Result{},
+			expectError:   false,
+			expectChanged: true,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.InfrastructureReady).To(BeTrue())
+				g.Expect(m.Status.FailureReason).To(BeNil())
+				g.Expect(conditions.IsFalse(m, clusterv1.BootstrapSucceededCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(m, clusterv1.BootstrapSucceededCondition)).To(Equal(clusterv1.BootstrapFailedReason))
+				g.Expect(*conditions.GetSeverity(m, clusterv1.BootstrapSucceededCondition)).To(Equal(clusterv1.ConditionSeverityError))
+				message := conditions.GetMessage(m, clusterv1.BootstrapSucceededCondition)
+				g.Expect(message).To(ContainSubstring("CloudInitFailed"))
+				g.Expect(message).To(HaveSuffix("kubeadm join failed"))
+				g.Expect(len(message)).To(BeNumerically("<", 2*bootstrapFailureOutputMaxLength))
+			},
+		},
+		{
+			name: "new machine, bootstrap failure output is truncated on rune boundaries",
+			infraConfig: map[string]interface{}{
+				"kind":       "GenericInfrastructureMachine",
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "infra-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": map[string]interface{}{
+					"providerID": "test://id-1",
+				},
+				"status": map[string]interface{}{
+					"ready":                  true,
+					"bootstrapFailureReason": "CloudInitFailed",
+					"bootstrapFailureOutput": "a" + strings.Repeat("ü", bootstrapFailureOutputMaxLength) + " 100% failed!",
+				},
+			},
+			expectResult:  ctrl.Result{},
+			expectError:   false,
+			expectChanged: true,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				message := conditions.GetMessage(m, clusterv1.BootstrapSucceededCondition)
+				g.Expect(utf8.ValidString(message)).To(BeTrue())
+				g.Expect(message).To(HaveSuffix("ü 100% failed!"))
+			},
+		},
+		{
+			name: "running machine, bootstrap failures reported by infrastructure are ignored",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "machine-test",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineSpec{
+					InfrastructureRef: corev1.ObjectReference{
+						APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+						Kind:       "GenericInfrastructureMachine",
+						Name:       "infra-config1",
+					},
+				},
+				Status: clusterv1.MachineStatus{
+					NodeRef: &corev1.ObjectReference{Kind: "Node", Name: "machine-test-node"},
+				},
+			},
+			infraConfig: map[string]interface{}{
+				"kind":       "GenericInfrastructureMachine",
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "infra-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": map[string]interface{}{
+					"providerID": "test://id-1",
+				},
+				"status": map[string]interface{}{
+					"ready":                  true,
+					"bootstrapFailureReason": "CloudInitFailed",
+				},
+			},
+			expectResult:  ctrl.Result{},
+			expectError:   false,
+			expectChanged: true,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(conditions.Has(m, clusterv1.BootstrapSucceededCondition)).To(BeFalse())
+			},
+		},
 		{
 			name: "infrastructure ref is paused",
 			infraConfig: map[string]interface{}{
@@ -1098,3 +1224,230 @@ func TestReconcileInfrastructure(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileCertificateExpiry(t *testing.T) {
+	fakeTimeString := "2020-01-01T00:00:00Z"
+	fakeTime, _ := time.Parse(time.RFC3339, fakeTimeString)
+	fakeMetaTime := &metav1.Time{Time: fakeTime}
+
+	fakeTimeString2 := "2020-02-02T00:00:00Z"
+	fakeTime2, _ := time.Parse(time.RFC3339, fakeTimeString2)
+	fakeMetaTime2 := &metav1.Time{Time: fakeTime2}
+
+	bootstrapConfigWithExpiry := map[string]interface{}{
+		"kind":       "GenericBootstrapConfig",
+		"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+		"metadata": map[string]interface{}{
+			"name":      "bootstrap-config-with-expiry",
+			"namespace": metav1.NamespaceDefault,
+			"annotations": map[string]interface{}{
+				clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString,
+			},
+		},
+		"spec":   map[string]interface{}{},
+		"status": map[string]interface{}{},
+	}
+
+	bootstrapConfigWithoutExpiry := map[string]interface{}{
+		"kind":       "GenericBootstrapConfig",
+		"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+		"metadata": map[string]interface{}{
+			"name":      "bootstrap-config-without-expiry",
+			"namespace": metav1.NamespaceDefault,
+		},
+		"spec":   map[string]interface{}{},
+		"status": map[string]interface{}{},
+	}
+
+	newMachine := func(controlPlane bool, annotations map[string]string, bootstrapConfigName string) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "machine-test",
+				Namespace:   metav1.NamespaceDefault,
+				Labels:      map[string]string{clusterv1.ClusterLabelName: "test-cluster"},
+				Annotations: annotations,
+			},
+			Spec: clusterv1.MachineSpec{
+				Bootstrap: clusterv1.Bootstrap{
+					ConfigRef: &corev1.ObjectReference{
+						APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+						Kind:       "GenericBootstrapConfig",
+						Name:       bootstrapConfigName,
+					},
+				},
+			},
+		}
+		if controlPlane {
+			m.Labels[clusterv1.MachineControlPlaneLabelName] = ""
+		}
+		return m
+	}
+
+	tests := []struct {
+		name        string
+		machine     *clusterv1.Machine
+		expected    *metav1.Time
+		expectError bool
+	}{
+		{
+			name:     "worker machine with certificate expiry annotation should not set certificate expiry",
+			machine:  newMachine(false, map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString}, "bootstrap-config-with-expiry"),
+			expected: nil,
+		},
+		{
+			name:     "control plane machine with certificate expiry annotation should set certificate expiry",
+			machine:  newMachine(true, map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString}, "bootstrap-config-without-expiry"),
+			expected: fakeMetaTime,
+		},
+		{
+			name:     "control plane machine with certificate expiry annotation in bootstrap config should set certificate expiry",
+			machine:  newMachine(true, nil, "bootstrap-config-with-expiry"),
+			expected: fakeMetaTime,
+		},
+		{
+			name:     "control plane machine with certificate expiry annotation in machine should take precedence over bootstrap config",
+			machine:  newMachine(true, map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString2}, "bootstrap-config-with-expiry"),
+			expected: fakeMetaTime2,
+		},
+		{
+			name: "reset certificates expiry information in machine status if the information is not available on the machine and the bootstrap config",
+			machine: func() *clusterv1.Machine {
+				m := newMachine(true, nil, "bootstrap-config-without-expiry")
+				m.Status.CertificatesExpiryDate = fakeMetaTime
+				return m
+			}(),
+			expected: nil,
+		},
+		{
+			name:        "control plane machine with an invalid certificate expiry annotation should fail",
+			machine:     newMachine(true, map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: "not-a-date"}, "bootstrap-config-without-expiry"),
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &MachineReconciler{
+				Client: fake.NewClientBuilder().
+					WithObjects(
+						tc.machine,
+						&unstructured.Unstructured{Object: bootstrapConfigWithExpiry},
+						&unstructured.Unstructured{Object: bootstrapConfigWithoutExpiry},
+					).Build(),
+			}
+
+			_, err := r.reconcileCertificateExpiry(ctx, nil, tc.machine)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tc.expected == nil {
+				g.Expect(tc.machine.Status.CertificatesExpiryDate).To(BeNil())
+			} else {
+				g.Expect(tc.machine.Status.CertificatesExpiryDate).NotTo(BeNil())
+				g.Expect(tc.machine.Status.CertificatesExpiryDate.Time.Equal(tc.expected.Time)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestReconcileInstanceNotTerminating(t *testing.T) {
+	newInfraConfig := func(conds ...interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind":       "GenericInfrastructureMachine",
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "infra-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"status": map[string]interface{}{
+					"conditions": conds,
+				},
+			},
+		}
+	}
+	notTerminating := map[string]interface{}{
+		"type":   string(clusterv1.InstanceNotTerminatingCondition),
+		"status": "True",
+	}
+	terminating := map[string]interface{}{
+		"type":     string(clusterv1.InstanceNotTerminatingCondition),
+		"status":   "False",
+		"severity": string(clusterv1.ConditionSeverityWarning),
+		"reason":   clusterv1.TerminationNoticeReceivedReason,
+		"message":  "spot instance interruption",
+	}
+
+	tests := []struct {
+		name         string
+		machine      *clusterv1.Machine
+		infraConfig  *unstructured.Unstructured
+		expectStatus *corev1.ConditionStatus
+		expectEvents int
+	}{
+		{
+			name:        "does not set the condition if the infrastructure does not report it",
+			machine:     &clusterv1.Machine{},
+			infraConfig: newInfraConfig(),
+		},
+		{
+			name: "removes the condition if the infrastructure stopped reporting it",
+			machine: &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					Conditions: clusterv1.Conditions{*conditions.TrueCondition(clusterv1.InstanceNotTerminatingCondition)},
+				},
+			},
+			infraConfig: newInfraConfig(),
+		},
+		{
+			name:         "mirrors the condition when the instance is not terminating",
+			machine:      &clusterv1.Machine{},
+			infraConfig:  newInfraConfig(notTerminating),
+			expectStatus: statusPtr(corev1.ConditionTrue),
+		},
+		{
+			name:         "mirrors the condition and records an event when a termination notice is received",
+			machine:      &clusterv1.Machine{},
+			infraConfig:  newInfraConfig(terminating),
+			expectStatus: statusPtr(corev1.ConditionFalse),
+			expectEvents: 1,
+		},
+		{
+			name: "does not record an event again for a known termination notice",
+			machine: &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					Conditions: clusterv1.Conditions{*conditions.FalseCondition(clusterv1.InstanceNotTerminatingCondition, clusterv1.TerminationNoticeReceivedReason, clusterv1.ConditionSeverityWarning, "")},
+				},
+			},
+			infraConfig:  newInfraConfig(terminating),
+			expectStatus: statusPtr(corev1.ConditionFalse),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			recorder := record.NewFakeRecorder(32)
+			r := &MachineReconciler{recorder: recorder}
+			r.reconcileInstanceNotTerminating(tt.machine, tt.infraConfig)
+
+			condition := conditions.Get(tt.machine, clusterv1.InstanceNotTerminatingCondition)
+			if tt.expectStatus == nil {
+				g.Expect(condition).To(BeNil())
+			} else {
+				g.Expect(condition).ToNot(BeNil())
+				g.Expect(condition.Status).To(Equal(*tt.expectStatus))
+			}
+			g.Expect(recorder.Events).To(HaveLen(tt.expectEvents))
+		})
+	}
+}
+
+func statusPtr(s corev1.ConditionStatus) *corev1.ConditionStatus {
+	return &s
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileProviderID flags Machines reporting the same ProviderID of other Machines in the same Cluster by setting
+// the DuplicateProviderIDCondition to true; Node matching is skipped for those Machines, given that a ProviderID
+// should identify a single Node.
+// NOTE: Duplicates are rejected by the Machine webhook, but they can still be introduced by providers setting the
+// ProviderID of Machines concurrently.
+func (r *MachineReconciler) reconcileProviderID(ctx context.Context, _ *clusterv1.Cluster, m *clusterv1.Machine) (ctrl.Result, error) {
+	if m.Spec.ProviderID == nil || *m.Spec.ProviderID == "" {
+		conditions.Delete(m, clusterv1.DuplicateProviderIDCondition)
+		return ctrl.Result{}, nil
+	}
+
+	// Events are recorded only when the condition transitions, so they are not repeated at every reconcile.
+	previous := conditions.Get(m, clusterv1.DuplicateProviderIDCondition)
+
+	providerID, err := noderefutil.NewProviderID(*m.Spec.ProviderID)
+	if err != nil {
+		message := fmt.Sprintf("ProviderID %s is malformed: %v", *m.Spec.ProviderID, err)
+		if previous == nil || previous.Reason != clusterv1.MalformedProviderIDReason || previous.Message != message {
+			r.recorder.Event(m, corev1.EventTypeWarning, clusterv1.MalformedProviderIDReason, message)
+		}
+		conditions.Set(m, &clusterv1.Condition{
+			Type:    clusterv1.DuplicateProviderIDCondition,
+			Status:  corev1.ConditionUnknown,
+			Reason:  clusterv1.MalformedProviderIDReason,
+			Message: message,
+		})
+		return ctrl.Result{}, nil
+	}
+
+	duplicates, err := r.getMachinesWithProviderID(ctx, m, providerID)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if len(duplicates) == 0 {
+		conditions.Delete(m, clusterv1.DuplicateProviderIDCondition)
+		return ctrl.Result{}, nil
+	}
+
+	message := fmt.Sprintf("ProviderID %s is also reported by Machine(s) %s", *m.Spec.ProviderID, strings.Join(duplicates, ", "))
+	if previous == nil || previous.Reason != clusterv1.ProviderIDReportedByOtherMachinesReason || previous.Message != message {
+		ctrl.LoggerFrom(ctx).Info("Machine reports the same ProviderID of other Machines", "providerID", *m.Spec.ProviderID, "machines", strings.Join(duplicates, ","))
+		r.recorder.Event(m, corev1.EventTypeWarning, clusterv1.ProviderIDReportedByOtherMachinesReason, message)
+	}
+	conditions.Set(m, &clusterv1.Condition{
+		Type:    clusterv1.DuplicateProviderIDCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  clusterv1.ProviderIDReportedByOtherMachinesReason,
+		Message: message,
+	})
+	return ctrl.Result{}, nil
+}
+
+// getMachinesWithProviderID returns the names of the other Machines in the Machine's cluster reporting the same ProviderID.
+func (r *MachineReconciler) getMachinesWithProviderID(ctx context.Context, m *clusterv1.Machine, providerID *noderefutil.ProviderID) ([]string, error) {
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machineList, client.InNamespace(m.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: m.Spec.ClusterName}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Machines")
+	}
+
+	var names []string
+	for i := range machineList.Items {
+		other := &machineList.Items[i]
+		if other.Name == m.Name || other.Spec.ProviderID == nil || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		otherProviderID, err := noderefutil.NewProviderID(*other.Spec.ProviderID)
+		if err != nil {
+			continue
+		}
+		if otherProviderID.IndexKey() == providerID.IndexKey() {
+			names = append(names, other.Name)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReconcileProviderID(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster-1",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	newMachine := func(name, clusterName string, providerID *string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+				Labels:    map[string]string{clusterv1.ClusterLabelName: clusterName},
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: clusterName,
+				ProviderID:  providerID,
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		providerID    *string
+		conditions    clusterv1.Conditions
+		others        []client.Object
+		wantCondition *clusterv1.Condition
+		wantEvents    int
+	}{
+		{
+			name:          "Machines without a ProviderID do not get the condition",
+			others:        []client.Object{newMachine("machine-2", cluster.Name, pointer.String("aws:///us-east-1/i-1"))},
+			wantCondition: nil,
+		},
+		{
+			name:          "Machines with a unique ProviderID do not get the condition",
+			providerID:    pointer.String("aws:///us-east-1/i-1"),
+			others:        []client.Object{newMachine("machine-2", cluster.Name, pointer.String("aws:///us-east-1/i-2"))},
+			wantCondition: nil,
+		},
+		{
+			name:          "Machines in other Clusters are ignored",
+			providerID:    pointer.String("aws:///us-east-1/i-1"),
+			others:        []client.Object{newMachine("machine-2", "cluster-2", pointer.String("aws:///us-east-1/i-1"))},
+			wantCondition: nil,
+		},
+		{
+			name:       "Machines with a malformed ProviderID get the condition with unknown status",
+			providerID: pointer.String("i-1"),
+			others:     []client.Object{newMachine("machine-2", cluster.Name, pointer.String("i-1"))},
+			wantCondition: &clusterv1.Condition{
+				Type:    clusterv1.DuplicateProviderIDCondition,
+				Status:  corev1.ConditionUnknown,
+				Reason:  clusterv1.MalformedProviderIDReason,
+				Message: "ProviderID i-1 is malformed: providerID must be of the form <cloudProvider>://<optional>/<segments>/<provider id>",
+			},
+			wantEvents: 1,
+		},
+		{
+			name:       "Machines with a malformed ProviderID already flagged do not get new events",
+			providerID: pointer.String("i-1"),
+			conditions: clusterv1.Conditions{{
+				Type:    clusterv1.DuplicateProviderIDCondition,
+				Status:  corev1.ConditionUnknown,
+				Reason:  clusterv1.MalformedProviderIDReason,
+				Message: "ProviderID i-1 is malformed: providerID must be of the form <cloudProvider>://<optional>/<segments>/<provider id>",
+			}},
+			wantCondition: &clusterv1.Condition{
+				Type:    clusterv1.DuplicateProviderIDCondition,
+				Status:  corev1.ConditionUnknown,
+				Reason:  clusterv1.MalformedProviderIDReason,
+				Message: "ProviderID i-1 is malformed: providerID must be of the form <cloudProvider>://<optional>/<segments>/<provider id>",
+			},
+			wantEvents: 0,
+		},
+		{
+			name:       "Machines with a duplicate ProviderID are flagged as duplicate",
+			providerID: pointer.String("aws:///us-east-1/i-1"),
+			others: []client.Object{
+				newMachine("machine-2", cluster.Name, pointer.String("aws:////i-1")),
+				newMachine("machine-3", cluster.Name, pointer.String("aws:///us-east-1/i-2")),
+			},
+			wantCondition: &clusterv1.Condition{
+				Type:    clusterv1.DuplicateProviderIDCondition,
+				Status:  corev1.ConditionTrue,
+				Reason:  clusterv1.ProviderIDReportedByOtherMachinesReason,
+				Message: "ProviderID aws:///us-east-1/i-1 is also reported by Machine(s) machine-2",
+			},
+			wantEvents: 1,
+		},
+		{
+			name:       "condition is removed when the duplicate is resolved",
+			providerID: pointer.String("aws:///us-east-1/i-1"),
+			conditions: clusterv1.Conditions{{
+				Type:   clusterv1.DuplicateProviderIDCondition,
+				Status: corev1.ConditionTrue,
+				Reason: clusterv1.ProviderIDReportedByOtherMachinesReason,
+			}},
+			wantCondition: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := newMachine("machine-1", cluster.Name, tt.providerID)
+			machine.Status.Conditions = tt.conditions
+
+			recorder := record.NewFakeRecorder(32)
+			r := &MachineReconciler{
+				Client:   fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(append(tt.others, machine.DeepCopy())...).Build(),
+				recorder: recorder,
+			}
+
+			_, err := r.reconcileProviderID(ctx, cluster, machine)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(recorder.Events).To(HaveLen(tt.wantEvents))
+
+			got := conditions.Get(machine, clusterv1.DuplicateProviderIDCondition)
+			if tt.wantCondition == nil {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).ToNot(BeNil())
+			g.Expect(got.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(got.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(got.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(got.Message).To(Equal(tt.wantCondition.Message))
+		})
+	}
+}
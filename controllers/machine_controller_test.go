@@ -21,18 +21,25 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	kubedrain "k8s.io/kubectl/pkg/drain"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -938,6 +945,31 @@ func TestMachineConditions(t *testing.T) {
 				conditions.FalseCondition(clusterv1.ReadyCondition, clusterv1.NodeNotFoundReason, clusterv1.ConditionSeverityWarning, ""),
 			},
 		},
+		{
+			name:           "ready condition is false if readiness gates are not satisfied",
+			infraReady:     true,
+			bootstrapReady: true,
+			beforeFunc: func(bootstrap, infra *unstructured.Unstructured, m *clusterv1.Machine) {
+				m.Spec.ReadinessGates = []clusterv1.MachineReadinessGate{{ConditionType: "CNIReady"}, {ConditionType: "Registered"}}
+				conditions.MarkTrue(m, "CNIReady")
+			},
+			conditionsToAssert: []*clusterv1.Condition{
+				conditions.TrueCondition("CNIReady"),
+				conditions.FalseCondition(clusterv1.ReadyCondition, clusterv1.ReadinessGatesNotReadyReason, clusterv1.ConditionSeverityInfo, "Readiness gates not ready: Registered"),
+			},
+		},
+		{
+			name:           "ready condition is true if readiness gates are satisfied",
+			infraReady:     true,
+			bootstrapReady: true,
+			beforeFunc: func(bootstrap, infra *unstructured.Unstructured, m *clusterv1.Machine) {
+				m.Spec.ReadinessGates = []clusterv1.MachineReadinessGate{{ConditionType: "CNIReady"}}
+				conditions.MarkTrue(m, "CNIReady")
+			},
+			conditionsToAssert: []*clusterv1.Condition{
+				conditions.TrueCondition(clusterv1.ReadyCondition),
+			},
+		},
 	}
 
 	for _, tt := range testcases {
@@ -1102,6 +1134,73 @@ func TestRemoveMachineFinalizerAfterDeleteReconcile(t *testing.T) {
 	var actual clusterv1.Machine
 	g.Expect(mr.Client.Get(ctx, key, &actual)).To(Succeed())
 	g.Expect(actual.ObjectMeta.Finalizers).To(Equal([]string{"test"}))
+	g.Expect(actual.Status.Deletion).ToNot(BeNil())
+	g.Expect(actual.Status.Deletion.InfrastructureDeletionStartTime).ToNot(BeNil())
+	g.Expect(actual.Status.Deletion.NodeDrainStartTime).To(BeNil())
+}
+
+func TestReconcileDeleteCordonOnly(t *testing.T) {
+	g := NewWithT(t)
+
+	dt := metav1.Now()
+
+	testCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-cluster"},
+	}
+
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "delete123",
+			Namespace:         metav1.NamespaceDefault,
+			Finalizers:        []string{clusterv1.MachineFinalizer, "test"},
+			DeletionTimestamp: &dt,
+			Annotations: map[string]string{
+				clusterv1.CordonOnlyAnnotation: "",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test-cluster",
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Kind:       "GenericInfrastructureMachine",
+				Name:       "infra-config1",
+			},
+			Bootstrap: clusterv1.Bootstrap{DataSecretName: pointer.StringPtr("data")},
+		},
+	}
+	key := client.ObjectKey{Namespace: m.Namespace, Name: m.Name}
+	recorder := record.NewFakeRecorder(10)
+	mr := &MachineReconciler{
+		Client:   fake.NewClientBuilder().WithObjects(testCluster, m).Build(),
+		recorder: recorder,
+	}
+
+	// The deletion is stopped before deleting the infrastructure while the annotation is set.
+	_, err := mr.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = mr.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	var actual clusterv1.Machine
+	g.Expect(mr.Client.Get(ctx, key, &actual)).To(Succeed())
+	g.Expect(actual.ObjectMeta.Finalizers).To(ContainElement(clusterv1.MachineFinalizer))
+	g.Expect(conditions.IsFalse(&actual, clusterv1.CordonOnlyReleasedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(&actual, clusterv1.CordonOnlyReleasedCondition)).To(Equal(clusterv1.WaitingForCordonOnlyReleaseReason))
+	g.Expect(conditions.Has(&actual, clusterv1.PreTerminateDeleteHookSucceededCondition)).To(BeFalse())
+	g.Expect(actual.Status.Deletion == nil || actual.Status.Deletion.InfrastructureDeletionStartTime == nil).To(BeTrue())
+	// The event is recorded only once.
+	g.Expect(recorder.Events).To(HaveLen(1))
+
+	// The deletion completes once the annotation is removed.
+	delete(actual.Annotations, clusterv1.CordonOnlyAnnotation)
+	g.Expect(mr.Client.Update(ctx, &actual)).To(Succeed())
+	_, err = mr.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(mr.Client.Get(ctx, key, &actual)).To(Succeed())
+	g.Expect(actual.ObjectMeta.Finalizers).To(Equal([]string{"test"}))
+	g.Expect(conditions.IsTrue(&actual, clusterv1.CordonOnlyReleasedCondition)).To(BeTrue())
+	g.Expect(conditions.IsTrue(&actual, clusterv1.PreTerminateDeleteHookSucceededCondition)).To(BeTrue())
 }
 
 func TestIsNodeDrainedAllowed(t *testing.T) {
@@ -1186,6 +1285,35 @@ func TestIsNodeDrainedAllowed(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "Node draining timeout is over according to the deletion status",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-machine",
+					Namespace:  metav1.NamespaceDefault,
+					Finalizers: []string{clusterv1.MachineFinalizer},
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName:       "test-cluster",
+					InfrastructureRef: corev1.ObjectReference{},
+					Bootstrap:         clusterv1.Bootstrap{DataSecretName: pointer.StringPtr("data")},
+					NodeDrainTimeout:  &metav1.Duration{Duration: time.Second * 60},
+				},
+				Status: clusterv1.MachineStatus{
+					Conditions: clusterv1.Conditions{
+						{
+							Type:               clusterv1.DrainingSucceededCondition,
+							Status:             corev1.ConditionFalse,
+							LastTransitionTime: metav1.Time{Time: time.Now().Add(-(time.Second * 30)).UTC()},
+						},
+					},
+					Deletion: &clusterv1.MachineDeletionStatus{
+						NodeDrainStartTime: &metav1.Time{Time: time.Now().Add(-(time.Second * 70)).UTC()},
+					},
+				},
+			},
+			expected: false,
+		},
 		{
 			name: "NodeDrainTimeout option is set to its default value 0",
 			machine: &clusterv1.Machine{
@@ -1229,6 +1357,239 @@ func TestIsNodeDrainedAllowed(t *testing.T) {
 	}
 }
 
+func TestApplyNodeDrainOptions(t *testing.T) {
+	pod := func(namespace string, labels map[string]string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: "pod", Labels: labels}}
+	}
+
+	t.Run("keeps the defaults if no options are set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		drainer := &kubedrain.Helper{IgnoreAllDaemonSets: true, DeleteEmptyDirData: true, GracePeriodSeconds: -1}
+		g.Expect(applyNodeDrainOptions(drainer, nil)).To(Succeed())
+		g.Expect(applyNodeDrainOptions(drainer, &clusterv1.NodeDrainOptions{})).To(Succeed())
+		g.Expect(drainer.IgnoreAllDaemonSets).To(BeTrue())
+		g.Expect(drainer.DeleteEmptyDirData).To(BeTrue())
+		g.Expect(drainer.GracePeriodSeconds).To(Equal(-1))
+		g.Expect(drainer.AdditionalFilters).To(BeEmpty())
+	})
+
+	t.Run("applies the options", func(t *testing.T) {
+		g := NewWithT(t)
+
+		drainer := &kubedrain.Helper{IgnoreAllDaemonSets: true, DeleteEmptyDirData: true, GracePeriodSeconds: -1}
+		g.Expect(applyNodeDrainOptions(drainer, &clusterv1.NodeDrainOptions{
+			IgnoreDaemonSets:    pointer.Bool(false),
+			DeleteEmptyDirData:  pointer.Bool(false),
+			GracePeriodSeconds:  pointer.Int32(30),
+			ExcludedNamespaces:  []string{"kube-system"},
+			ExcludedPodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"drain": "skip"}},
+		})).To(Succeed())
+		g.Expect(drainer.IgnoreAllDaemonSets).To(BeFalse())
+		g.Expect(drainer.DeleteEmptyDirData).To(BeFalse())
+		g.Expect(drainer.GracePeriodSeconds).To(Equal(30))
+		g.Expect(drainer.AdditionalFilters).To(HaveLen(1))
+
+		filter := drainer.AdditionalFilters[0]
+		g.Expect(filter(pod("kube-system", nil)).Delete).To(BeFalse())
+		g.Expect(filter(pod(metav1.NamespaceDefault, map[string]string{"drain": "skip"})).Delete).To(BeFalse())
+		g.Expect(filter(pod(metav1.NamespaceDefault, map[string]string{"drain": "evict"})).Delete).To(BeTrue())
+	})
+
+	t.Run("fails with an invalid Pod selector", func(t *testing.T) {
+		g := NewWithT(t)
+
+		drainer := &kubedrain.Helper{}
+		g.Expect(applyNodeDrainOptions(drainer, &clusterv1.NodeDrainOptions{
+			ExcludedPodSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "drain", Operator: "Invalid"}}},
+		})).ToNot(Succeed())
+	})
+}
+
+func TestRunNodeDrain(t *testing.T) {
+	g := NewWithT(t)
+
+	newPod := func(name string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+				Labels:    labels,
+				// Pods without a controller are deleted only with Force.
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", Controller: pointer.Bool(true)}},
+			},
+			Spec: corev1.PodSpec{NodeName: "node-1"},
+		}
+	}
+	blocking := newPod("blocking", nil)
+	excludeWait := newPod("exclude-wait", map[string]string{clusterv1.DrainExcludeWaitLabel: ""})
+
+	kubeClient := fakeclientset.NewSimpleClientset(blocking, excludeWait)
+	// Simulate a Pod which does not terminate by not deleting it.
+	kubeClient.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return action.(k8stesting.DeleteAction).GetName() == blocking.Name, nil, nil
+	})
+
+	drainer := &kubedrain.Helper{
+		Client:             kubeClient,
+		Ctx:                ctx,
+		DisableEviction:    true,
+		GracePeriodSeconds: -1,
+		Timeout:            time.Second,
+		Out:                writer{klog.Info},
+		ErrOut:             writer{klog.Error},
+	}
+	g.Expect(runNodeDrain(drainer, "node-1", time.Now(), time.Now())).ToNot(Succeed())
+
+	_, err := kubeClient.CoreV1().Pods(metav1.NamespaceDefault).Get(ctx, excludeWait.Name, metav1.GetOptions{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+	drainStart := time.Now().Add(-time.Minute)
+	g.Expect(drainBlockingPodsMessage(drainer, "node-1", drainStart, drainStart.Add(90*time.Second))).To(Equal("waiting for Pods default/blocking (not evicted for 1m30s)"))
+}
+
+func TestRunNodeDrainExcludeWaitEvictionFailure(t *testing.T) {
+	newPod := func(name string, labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:       metav1.NamespaceDefault,
+				Name:            name,
+				Labels:          labels,
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs", Controller: pointer.Bool(true)}},
+			},
+			Spec: corev1.PodSpec{NodeName: "node-1"},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		drainStart time.Time
+		wantErr    bool
+	}{
+		{
+			name:       "fails the drain while the eviction of Pods excluded from waiting is retried",
+			drainStart: time.Now(),
+			wantErr:    true,
+		},
+		{
+			name:       "does not fail the drain once the eviction of Pods excluded from waiting timed out",
+			drainStart: time.Now().Add(-excludeWaitEvictionTimeout),
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			pod := newPod("pod", nil)
+			excludeWait := newPod("exclude-wait", map[string]string{clusterv1.DrainExcludeWaitLabel: ""})
+
+			kubeClient := fakeclientset.NewSimpleClientset(pod, excludeWait)
+			// Simulate a Pod excluded from waiting which cannot be evicted.
+			kubeClient.PrependReactor("delete", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				if action.(k8stesting.DeleteAction).GetName() == excludeWait.Name {
+					return true, nil, errors.New("eviction failed")
+				}
+				return false, nil, nil
+			})
+
+			drainer := &kubedrain.Helper{
+				Client:             kubeClient,
+				Ctx:                ctx,
+				DisableEviction:    true,
+				GracePeriodSeconds: -1,
+				Timeout:            time.Second,
+				Out:                writer{klog.Info},
+				ErrOut:             writer{klog.Error},
+			}
+			err := runNodeDrain(drainer, "node-1", tt.drainStart, time.Now())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			// The other Pods are evicted anyway.
+			_, err = kubeClient.CoreV1().Pods(metav1.NamespaceDefault).Get(ctx, pod.Name, metav1.GetOptions{})
+			g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	}
+}
+
+func TestDrainRetryResult(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &MachineReconciler{}
+	m := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: metav1.NamespaceDefault, UID: "machine-uid"}}
+
+	// The retry interval grows with the number of consecutive drain failures, up to the cap.
+	result := r.drainRetryResult(m)
+	g.Expect(result.RequeueAfter).To(BeNumerically(">=", 20*time.Second))
+	g.Expect(result.RequeueAfter).To(BeNumerically("<", 23*time.Second))
+	g.Expect(r.drainFailures.Failures(m)).To(Equal(1))
+
+	result = r.drainRetryResult(m)
+	g.Expect(result.RequeueAfter).To(BeNumerically(">=", 40*time.Second))
+	g.Expect(result.RequeueAfter).To(BeNumerically("<", 45*time.Second))
+	g.Expect(r.drainFailures.Failures(m)).To(Equal(2))
+
+	for i := 0; i < 5; i++ {
+		result = r.drainRetryResult(m)
+	}
+	g.Expect(result.RequeueAfter).To(BeNumerically(">=", 2*time.Minute))
+	g.Expect(result.RequeueAfter).To(BeNumerically("<", 2*time.Minute+13*time.Second))
+
+	// Failures are not persisted on the Machine, so they do not trigger new reconciles.
+	g.Expect(m.GetAnnotations()).To(BeEmpty())
+
+	// Failures are cleared once the drain succeeds.
+	r.drainFailures.Reset(m)
+	g.Expect(r.drainFailures.Failures(m)).To(Equal(0))
+}
+
+func TestFormatDrainBlockingPods(t *testing.T) {
+	now := time.Now()
+	drainStart := now.Add(-2 * time.Minute)
+
+	newPod := func(namespace, name string, deletionTimestamp *metav1.Time) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, DeletionTimestamp: deletionTimestamp}}
+	}
+
+	tests := []struct {
+		name string
+		pods []corev1.Pod
+		want string
+	}{
+		{
+			name: "no Pods",
+			want: "",
+		},
+		{
+			name: "Pods sorted by namespace and name with their wait durations",
+			pods: []corev1.Pod{
+				newPod("b", "pod-1", nil),
+				newPod("a", "pod-2", &metav1.Time{Time: now.Add(-30 * time.Second)}),
+			},
+			want: "waiting for Pods a/pod-2 (terminating for 30s), b/pod-1 (not evicted for 2m0s)",
+		},
+		{
+			name: "too many Pods",
+			pods: []corev1.Pod{
+				newPod("a", "pod-1", nil), newPod("a", "pod-2", nil), newPod("a", "pod-3", nil),
+				newPod("a", "pod-4", nil), newPod("a", "pod-5", nil), newPod("a", "pod-6", nil), newPod("a", "pod-7", nil),
+			},
+			want: "waiting for Pods a/pod-1 (not evicted for 2m0s), a/pod-2 (not evicted for 2m0s), a/pod-3 (not evicted for 2m0s), " +
+				"a/pod-4 (not evicted for 2m0s), a/pod-5 (not evicted for 2m0s), and 2 more",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(formatDrainBlockingPods(tt.pods, drainStart, now)).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestIsDeleteNodeAllowed(t *testing.T) {
 	deletionts := metav1.Now()
 
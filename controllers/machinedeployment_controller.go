@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -28,10 +29,12 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,7 +61,8 @@ type MachineDeploymentReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
-	recorder record.EventRecorder
+	recorder        record.EventRecorder
+	externalTracker external.ObjectTracker
 }
 
 func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -86,7 +90,10 @@ func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr
 		handler.EnqueueRequestsFromMapFunc(clusterToMachineDeployments),
 		// TODO: should this wait for Cluster.Status.InfrastructureReady similar to Infra Machine resources?
 		predicates.All(ctrl.LoggerFrom(ctx),
-			predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+			predicates.Any(ctrl.LoggerFrom(ctx),
+				predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+				predicates.ClusterPausedTransitions(ctrl.LoggerFrom(ctx)),
+			),
 			predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
 		),
 	)
@@ -95,6 +102,9 @@ func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr
 	}
 
 	r.recorder = mgr.GetEventRecorderFor("machinedeployment-controller")
+	r.externalTracker = external.ObjectTracker{
+		Controller: c,
+	}
 	return nil
 }
 
@@ -118,8 +128,12 @@ func (r *MachineDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, deployment) {
+	// Return early if the object or Cluster is paused, reporting it in the Paused condition.
+	isPaused, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -131,6 +145,8 @@ func (r *MachineDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	defer func() {
+		annotations.SetLastReconcile(deployment, reterr)
+
 		// Always attempt to patch the object and status after each reconciliation.
 		// Patch ObservedGeneration only if the reconciliation completed successfully
 		patchOpts := []patch.Option{}
@@ -212,6 +228,12 @@ func (r *MachineDeploymentReconciler) reconcile(ctx context.Context, cluster *cl
 		}
 	}
 
+	// Surface the capacity of the machines, if reported by the infrastructure template, for the cluster autoscaler.
+	templateHandler := handler.EnqueueRequestsFromMapFunc(r.InfrastructureTemplateToMachineDeployments)
+	if err := reconcileCapacityAnnotations(ctx, r.Client, &r.externalTracker, templateHandler, d, &d.Spec.Template.Spec.InfrastructureRef); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	msList, err := r.getMachineSetsForDeployment(ctx, d)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -229,16 +251,25 @@ func (r *MachineDeploymentReconciler) reconcile(ctx context.Context, cluster *cl
 		if d.Spec.Strategy.RollingUpdate == nil {
 			return ctrl.Result{}, errors.Errorf("missing MachineDeployment settings for strategy type: %s", d.Spec.Strategy.Type)
 		}
-		return ctrl.Result{}, r.rolloutRolling(ctx, d, msList)
+		return rolloutAfterResult(d, time.Now()), r.rolloutRolling(ctx, d, msList)
 	}
 
 	if d.Spec.Strategy.Type == clusterv1.OnDeleteMachineDeploymentStrategyType {
-		return ctrl.Result{}, r.rolloutOnDelete(ctx, d, msList)
+		return rolloutAfterResult(d, time.Now()), r.rolloutOnDelete(ctx, d, msList)
 	}
 
 	return ctrl.Result{}, errors.Errorf("unexpected deployment strategy type: %s", d.Spec.Strategy.Type)
 }
 
+// rolloutAfterResult returns a result requeueing the MachineDeployment when MachineDeployment.Spec.RolloutAfter expires,
+// if it is set in the future.
+func rolloutAfterResult(d *clusterv1.MachineDeployment, now time.Time) ctrl.Result {
+	if d.Spec.RolloutAfter == nil || !d.Spec.RolloutAfter.After(now) {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: d.Spec.RolloutAfter.Sub(now)}
+}
+
 // getMachineSetsForDeployment returns a list of MachineSets associated with a MachineDeployment.
 func (r *MachineDeploymentReconciler) getMachineSetsForDeployment(ctx context.Context, d *clusterv1.MachineDeployment) ([]*clusterv1.MachineSet, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -333,6 +364,28 @@ func (r *MachineDeploymentReconciler) getMachineDeploymentsForMachineSet(ctx con
 	return deployments
 }
 
+// InfrastructureTemplateToMachineDeployments is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for MachineDeployments referencing an infrastructure machine template, so the capacity annotations are kept up to date.
+func (r *MachineDeploymentReconciler) InfrastructureTemplateToMachineDeployments(o client.Object) []ctrl.Request {
+	ctx := context.Background()
+	// This won't log unless the global logger is set
+	log := ctrl.LoggerFrom(ctx, "object", client.ObjectKeyFromObject(o))
+
+	mdList := &clusterv1.MachineDeploymentList{}
+	if err := r.Client.List(ctx, mdList, client.InNamespace(o.GetNamespace())); err != nil {
+		log.Error(err, "Failed getting MachineDeployments for the infrastructure machine template")
+		return nil
+	}
+
+	var result []ctrl.Request
+	for _, md := range mdList.Items {
+		if refersToInfrastructureTemplate(md.Spec.Template.Spec.InfrastructureRef, o) {
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: md.Namespace, Name: md.Name}})
+		}
+	}
+	return result
+}
+
 // MachineSetToDeployments is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
 // for MachineDeployments that might adopt an orphaned MachineSet.
 func (r *MachineDeploymentReconciler) MachineSetToDeployments(o client.Object) []ctrl.Request {
@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -735,3 +736,19 @@ func TestGetMachineSetsForDeployment(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineDeploymentRolloutAfterResult(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	md := &clusterv1.MachineDeployment{}
+	g.Expect(rolloutAfterResult(md, now)).To(Equal(reconcile.Result{}))
+
+	past := metav1.NewTime(now.Add(-time.Hour))
+	md.Spec.RolloutAfter = &past
+	g.Expect(rolloutAfterResult(md, now)).To(Equal(reconcile.Result{}))
+
+	future := metav1.NewTime(now.Add(time.Hour))
+	md.Spec.RolloutAfter = &future
+	g.Expect(rolloutAfterResult(md, now)).To(Equal(reconcile.Result{RequeueAfter: future.Sub(now)}))
+}
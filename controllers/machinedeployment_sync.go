@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
 
@@ -26,6 +27,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	apirand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/retry"
@@ -75,10 +77,11 @@ func (r *MachineDeploymentReconciler) sync(ctx context.Context, d *clusterv1.Mac
 // Note that currently the deployment controller is using caches to avoid querying the server for reads.
 // This may lead to stale reads of machine sets, thus incorrect deployment status.
 func (r *MachineDeploymentReconciler) getAllMachineSetsAndSyncRevision(ctx context.Context, d *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet, createIfNotExisted bool) (*clusterv1.MachineSet, []*clusterv1.MachineSet, error) {
-	_, allOldMSs := mdutil.FindOldMachineSets(d, msList)
+	reconciliationTime := metav1.Now()
+	_, allOldMSs := mdutil.FindOldMachineSets(d, msList, &reconciliationTime)
 
 	// Get new machine set with the updated revision number
-	newMS, err := r.getNewMachineSet(ctx, d, msList, allOldMSs, createIfNotExisted)
+	newMS, err := r.getNewMachineSet(ctx, d, msList, allOldMSs, createIfNotExisted, &reconciliationTime)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -91,10 +94,10 @@ func (r *MachineDeploymentReconciler) getAllMachineSetsAndSyncRevision(ctx conte
 // 2. If there's existing new MS, update its revision number if it's smaller than (maxOldRevision + 1), where maxOldRevision is the max revision number among all old MSes.
 // 3. If there's no existing new MS and createIfNotExisted is true, create one with appropriate revision number (maxOldRevision + 1) and replicas.
 // Note that the machine-template-hash will be added to adopted MSes and machines.
-func (r *MachineDeploymentReconciler) getNewMachineSet(ctx context.Context, d *clusterv1.MachineDeployment, msList, oldMSs []*clusterv1.MachineSet, createIfNotExisted bool) (*clusterv1.MachineSet, error) {
+func (r *MachineDeploymentReconciler) getNewMachineSet(ctx context.Context, d *clusterv1.MachineDeployment, msList, oldMSs []*clusterv1.MachineSet, createIfNotExisted bool, reconciliationTime *metav1.Time) (*clusterv1.MachineSet, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	existingNewMS := mdutil.FindNewMachineSet(d, msList)
+	existingNewMS := mdutil.FindNewMachineSet(d, msList, reconciliationTime)
 
 	// Calculate the max revision number among all old MSes
 	maxOldRevision := mdutil.MaxRevision(oldMSs, log)
@@ -118,8 +121,14 @@ func (r *MachineDeploymentReconciler) getNewMachineSet(ctx context.Context, d *c
 
 		minReadySecondsNeedsUpdate := msCopy.Spec.MinReadySeconds != *d.Spec.MinReadySeconds
 		deletePolicyNeedsUpdate := d.Spec.Strategy.RollingUpdate.DeletePolicy != nil && msCopy.Spec.DeletePolicy != *d.Spec.Strategy.RollingUpdate.DeletePolicy
-		if annotationsUpdated || minReadySecondsNeedsUpdate || deletePolicyNeedsUpdate {
+		machineNamingStrategyNeedsUpdate := !reflect.DeepEqual(msCopy.Spec.MachineNamingStrategy, d.Spec.MachineNamingStrategy)
+		infrastructureFailurePolicyNeedsUpdate := !reflect.DeepEqual(msCopy.Spec.InfrastructureFailurePolicy, d.Spec.InfrastructureFailurePolicy)
+		interruptionBudgetNeedsUpdate := !reflect.DeepEqual(msCopy.Spec.InterruptionBudget, d.Spec.InterruptionBudget)
+		if annotationsUpdated || minReadySecondsNeedsUpdate || deletePolicyNeedsUpdate || machineNamingStrategyNeedsUpdate || infrastructureFailurePolicyNeedsUpdate || interruptionBudgetNeedsUpdate {
 			msCopy.Spec.MinReadySeconds = *d.Spec.MinReadySeconds
+			msCopy.Spec.MachineNamingStrategy = d.Spec.MachineNamingStrategy.DeepCopy()
+			msCopy.Spec.InfrastructureFailurePolicy = d.Spec.InfrastructureFailurePolicy.DeepCopy()
+			msCopy.Spec.InterruptionBudget = copyIntOrString(d.Spec.InterruptionBudget)
 
 			if deletePolicyNeedsUpdate {
 				msCopy.Spec.DeletePolicy = *d.Spec.Strategy.RollingUpdate.DeletePolicy
@@ -141,7 +150,13 @@ func (r *MachineDeploymentReconciler) getNewMachineSet(ctx context.Context, d *c
 
 	// new MachineSet does not exist, create one.
 	newMSTemplate := *d.Spec.Template.DeepCopy()
-	hash, err := mdutil.ComputeSpewHash(&newMSTemplate)
+	var hashedObject interface{} = &newMSTemplate
+	if mdutil.RolloutAfterExpired(d, reconciliationTime) {
+		// Include RolloutAfter in the hash, so the new MachineSet does not clash with
+		// the existing MachineSet created from the same template before RolloutAfter.
+		hashedObject = []interface{}{&newMSTemplate, d.Spec.RolloutAfter}
+	}
+	hash, err := mdutil.ComputeSpewHash(hashedObject)
 	if err != nil {
 		return nil, err
 	}
@@ -168,11 +183,14 @@ func (r *MachineDeploymentReconciler) getNewMachineSet(ctx context.Context, d *c
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(d, machineDeploymentKind)},
 		},
 		Spec: clusterv1.MachineSetSpec{
-			ClusterName:     d.Spec.ClusterName,
-			Replicas:        new(int32),
-			MinReadySeconds: minReadySeconds,
-			Selector:        *newMSSelector,
-			Template:        newMSTemplate,
+			ClusterName:                 d.Spec.ClusterName,
+			Replicas:                    new(int32),
+			MinReadySeconds:             minReadySeconds,
+			Selector:                    *newMSSelector,
+			Template:                    newMSTemplate,
+			MachineNamingStrategy:       d.Spec.MachineNamingStrategy.DeepCopy(),
+			InfrastructureFailurePolicy: d.Spec.InfrastructureFailurePolicy.DeepCopy(),
+			InterruptionBudget:          copyIntOrString(d.Spec.InterruptionBudget),
 		},
 	}
 
@@ -408,16 +426,28 @@ func calculateStatus(allMSs []*clusterv1.MachineSet, newMS *clusterv1.MachineSet
 		Conditions:          deployment.Status.Conditions,
 	}
 
-	if *deployment.Spec.Replicas == status.ReadyReplicas {
-		status.Phase = string(clusterv1.MachineDeploymentPhaseRunning)
+	// The phase is derived comparing the desired replicas with the actual and ready replicas; while a rollout is in
+	// progress, i.e. some Machines are not up-to-date, Machines exceeding the desired replicas by up to maxSurge are
+	// expected, so they are not reported as a scale down.
+	desiredReplicas := *deployment.Spec.Replicas
+	maxReplicas := desiredReplicas
+	if status.UpdatedReplicas < status.Replicas && deployment.Spec.Strategy != nil && deployment.Spec.Strategy.RollingUpdate != nil {
+		maxReplicas += mdutil.MaxSurge(*deployment)
 	}
-	if *deployment.Spec.Replicas > status.ReadyReplicas {
+	switch {
+	case status.Replicas < desiredReplicas || status.ReadyReplicas < desiredReplicas:
 		status.Phase = string(clusterv1.MachineDeploymentPhaseScalingUp)
-	}
-	// This is the same as unavailableReplicas, but we have to recalculate because unavailableReplicas
-	// would have been reset to zero above if it was negative
-	if totalReplicas-availableReplicas < 0 {
+	case status.Replicas > maxReplicas || totalReplicas-availableReplicas < 0:
+		// NOTE: totalReplicas-availableReplicas is the same as unavailableReplicas, but we have to recalculate
+		// because unavailableReplicas would have been reset to zero above if it was negative.
 		status.Phase = string(clusterv1.MachineDeploymentPhaseScalingDown)
+	default:
+		status.Phase = string(clusterv1.MachineDeploymentPhaseRunning)
+	}
+	for _, ms := range allMSs {
+		if ms != nil {
+			status.InfrastructureFailureRetries += ms.Status.InfrastructureFailureRetries
+		}
 	}
 	for _, ms := range allMSs {
 		if ms != nil {
@@ -543,3 +573,12 @@ func updateMachineDeployment(ctx context.Context, c client.Client, d *clusterv1.
 		return patchHelper.Patch(ctx, d)
 	})
 }
+
+// copyIntOrString returns a copy of an optional IntOrString, so MachineSets do not share it with their MachineDeployment.
+func copyIntOrString(in *intstr.IntOrString) *intstr.IntOrString {
+	if in == nil {
+		return nil
+	}
+	out := *in
+	return &out
+}
@@ -172,6 +172,298 @@ func TestCalculateStatus(t *testing.T) {
 				Phase:               "ScalingDown",
 			},
 		},
+		"scaling down by deleting machines": {
+			machineSets: []*clusterv1.MachineSet{{
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: pointer.Int32Ptr(1),
+				},
+				Status: clusterv1.MachineSetStatus{
+					Selector:           "",
+					AvailableReplicas:  2,
+					ReadyReplicas:      2,
+					Replicas:           2,
+					ObservedGeneration: 1,
+				},
+			}},
+			newMachineSet: &clusterv1.MachineSet{
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: pointer.Int32Ptr(1),
+				},
+				Status: clusterv1.MachineSetStatus{
+					Selector:           "",
+					AvailableReplicas:  2,
+					ReadyReplicas:      2,
+					Replicas:           2,
+					ObservedGeneration: 1,
+				},
+			},
+			deployment: &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 2,
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas: pointer.Int32Ptr(1),
+				},
+			},
+			expectedStatus: clusterv1.MachineDeploymentStatus{
+				ObservedGeneration:  2,
+				Replicas:            2,
+				UpdatedReplicas:     2,
+				ReadyReplicas:       2,
+				AvailableReplicas:   2,
+				UnavailableReplicas: 0,
+				Phase:               "ScalingDown",
+			},
+		},
+		"rolling out with maxSurge": {
+			machineSets: []*clusterv1.MachineSet{
+				{
+					Spec: clusterv1.MachineSetSpec{
+						Replicas: pointer.Int32Ptr(2),
+					},
+					Status: clusterv1.MachineSetStatus{
+						Selector:           "",
+						AvailableReplicas:  2,
+						ReadyReplicas:      2,
+						Replicas:           2,
+						ObservedGeneration: 1,
+					},
+				},
+				{
+					Spec: clusterv1.MachineSetSpec{
+						Replicas: pointer.Int32Ptr(1),
+					},
+					Status: clusterv1.MachineSetStatus{
+						Selector:           "",
+						AvailableReplicas:  1,
+						ReadyReplicas:      1,
+						Replicas:           1,
+						ObservedGeneration: 1,
+					},
+				},
+			},
+			newMachineSet: &clusterv1.MachineSet{
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: pointer.Int32Ptr(1),
+				},
+				Status: clusterv1.MachineSetStatus{
+					Selector:           "",
+					AvailableReplicas:  1,
+					ReadyReplicas:      1,
+					Replicas:           1,
+					ObservedGeneration: 1,
+				},
+			},
+			deployment: &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 2,
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas: pointer.Int32Ptr(2),
+					Strategy: &clusterv1.MachineDeploymentStrategy{
+						Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+						RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+							MaxUnavailable: intOrStrPtr(0),
+							MaxSurge:       intOrStrPtr(1),
+						},
+					},
+				},
+			},
+			expectedStatus: clusterv1.MachineDeploymentStatus{
+				ObservedGeneration:  2,
+				Replicas:            3,
+				UpdatedReplicas:     1,
+				ReadyReplicas:       3,
+				AvailableReplicas:   3,
+				UnavailableReplicas: 0,
+				Phase:               "Running",
+			},
+		},
+		"rolling out while scaling up": {
+			machineSets: []*clusterv1.MachineSet{
+				{
+					Spec: clusterv1.MachineSetSpec{
+						Replicas: pointer.Int32Ptr(2),
+					},
+					Status: clusterv1.MachineSetStatus{
+						Selector:           "",
+						AvailableReplicas:  2,
+						ReadyReplicas:      2,
+						Replicas:           2,
+						ObservedGeneration: 1,
+					},
+				},
+				{
+					Spec: clusterv1.MachineSetSpec{
+						Replicas: pointer.Int32Ptr(1),
+					},
+					Status: clusterv1.MachineSetStatus{
+						Selector:           "",
+						AvailableReplicas:  1,
+						ReadyReplicas:      1,
+						Replicas:           1,
+						ObservedGeneration: 1,
+					},
+				},
+			},
+			newMachineSet: &clusterv1.MachineSet{
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: pointer.Int32Ptr(1),
+				},
+				Status: clusterv1.MachineSetStatus{
+					Selector:           "",
+					AvailableReplicas:  1,
+					ReadyReplicas:      1,
+					Replicas:           1,
+					ObservedGeneration: 1,
+				},
+			},
+			deployment: &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 2,
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas: pointer.Int32Ptr(4),
+				},
+			},
+			expectedStatus: clusterv1.MachineDeploymentStatus{
+				ObservedGeneration:  2,
+				Replicas:            3,
+				UpdatedReplicas:     1,
+				ReadyReplicas:       3,
+				AvailableReplicas:   3,
+				UnavailableReplicas: 0,
+				Phase:               "ScalingUp",
+			},
+		},
+		"rolling out with Machines not ready": {
+			machineSets: []*clusterv1.MachineSet{
+				{
+					Spec: clusterv1.MachineSetSpec{
+						Replicas: pointer.Int32Ptr(2),
+					},
+					Status: clusterv1.MachineSetStatus{
+						Selector:           "",
+						AvailableReplicas:  2,
+						ReadyReplicas:      2,
+						Replicas:           2,
+						ObservedGeneration: 1,
+					},
+				},
+				{
+					Spec: clusterv1.MachineSetSpec{
+						Replicas: pointer.Int32Ptr(1),
+					},
+					Status: clusterv1.MachineSetStatus{
+						Selector:           "",
+						AvailableReplicas:  0,
+						ReadyReplicas:      0,
+						Replicas:           1,
+						ObservedGeneration: 1,
+					},
+				},
+			},
+			newMachineSet: &clusterv1.MachineSet{
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: pointer.Int32Ptr(1),
+				},
+				Status: clusterv1.MachineSetStatus{
+					Selector:           "",
+					AvailableReplicas:  0,
+					ReadyReplicas:      0,
+					Replicas:           1,
+					ObservedGeneration: 1,
+				},
+			},
+			deployment: &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 2,
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas: pointer.Int32Ptr(3),
+					Strategy: &clusterv1.MachineDeploymentStrategy{
+						Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+						RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+							MaxUnavailable: intOrStrPtr(0),
+							MaxSurge:       intOrStrPtr(1),
+						},
+					},
+				},
+			},
+			expectedStatus: clusterv1.MachineDeploymentStatus{
+				ObservedGeneration:  2,
+				Replicas:            3,
+				UpdatedReplicas:     1,
+				ReadyReplicas:       2,
+				AvailableReplicas:   2,
+				UnavailableReplicas: 1,
+				Phase:               "ScalingUp",
+			},
+		},
+		"rolling out while scaling down": {
+			machineSets: []*clusterv1.MachineSet{
+				{
+					Spec: clusterv1.MachineSetSpec{
+						Replicas: pointer.Int32Ptr(1),
+					},
+					Status: clusterv1.MachineSetStatus{
+						Selector:           "",
+						AvailableReplicas:  3,
+						ReadyReplicas:      3,
+						Replicas:           3,
+						ObservedGeneration: 1,
+					},
+				},
+				{
+					Spec: clusterv1.MachineSetSpec{
+						Replicas: pointer.Int32Ptr(1),
+					},
+					Status: clusterv1.MachineSetStatus{
+						Selector:           "",
+						AvailableReplicas:  1,
+						ReadyReplicas:      1,
+						Replicas:           1,
+						ObservedGeneration: 1,
+					},
+				},
+			},
+			newMachineSet: &clusterv1.MachineSet{
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: pointer.Int32Ptr(1),
+				},
+				Status: clusterv1.MachineSetStatus{
+					Selector:           "",
+					AvailableReplicas:  1,
+					ReadyReplicas:      1,
+					Replicas:           1,
+					ObservedGeneration: 1,
+				},
+			},
+			deployment: &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Generation: 2,
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas: pointer.Int32Ptr(1),
+					Strategy: &clusterv1.MachineDeploymentStrategy{
+						Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+						RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+							MaxUnavailable: intOrStrPtr(0),
+							MaxSurge:       intOrStrPtr(1),
+						},
+					},
+				},
+			},
+			expectedStatus: clusterv1.MachineDeploymentStatus{
+				ObservedGeneration:  2,
+				Replicas:            4,
+				UpdatedReplicas:     1,
+				ReadyReplicas:       4,
+				AvailableReplicas:   4,
+				UnavailableReplicas: 0,
+				Phase:               "ScalingDown",
+			},
+		},
 		"MachineSet failed": {
 			machineSets: []*clusterv1.MachineSet{{
 				Spec: clusterv1.MachineSetSpec{
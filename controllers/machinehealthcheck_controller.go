@@ -58,6 +58,10 @@ const (
 	// is restricted by remediation circuit shorting logic.
 	EventRemediationRestricted string = "RemediationRestricted"
 
+	// EventRemediationBackedOff is emitted in case machine remediation
+	// is delayed by the remediation backoff.
+	EventRemediationBackedOff string = "RemediationBackedOff"
+
 	maxUnhealthyKeyLog     = "max unhealthy"
 	unhealthyTargetsKeyLog = "unhealthy targets"
 	unhealthyRangeKeyLog   = "unhealthy range"
@@ -310,7 +314,12 @@ func (r *MachineHealthCheckReconciler) reconcile(ctx context.Context, logger log
 	m.Status.RemediationsAllowed = remediationCount
 	conditions.MarkTrue(m, clusterv1.RemediationAllowedCondition)
 
+	// Delay remediation of machines whose owner had another machine remediated within the remediation backoff.
+	unhealthy, delayed, backoffCheckTimes := r.applyRemediationBackoff(ctx, m, unhealthy, cluster)
+	nextCheckTimes = append(nextCheckTimes, backoffCheckTimes...)
+
 	errList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)
+	errList = append(errList, r.patchDelayedTargets(ctx, logger, delayed, m)...)
 	errList = append(errList, r.patchHealthyTargets(ctx, logger, healthy, m)...)
 
 	// handle update errors
@@ -444,6 +453,96 @@ func (r *MachineHealthCheckReconciler) patchUnhealthyTargets(ctx context.Context
 	return errList
 }
 
+// applyRemediationBackoff splits unhealthy targets into the ones to be remediated and the ones whose remediation must
+// be delayed because another machine with the same owner was remediated within spec.remediationBackoff; the latter
+// are returned together with the durations after which they should be checked again.
+// New remediations are recorded in status.lastRemediations, while records which are older than the backoff are dropped.
+// NOTE: Machines without an owner, paused machines and machines whose remediation is already in progress are never delayed.
+func (r *MachineHealthCheckReconciler) applyRemediationBackoff(ctx context.Context, m *clusterv1.MachineHealthCheck, unhealthy []healthCheckTarget, cluster *clusterv1.Cluster) ([]healthCheckTarget, []healthCheckTarget, []time.Duration) {
+	if m.Spec.RemediationBackoff == nil || m.Spec.RemediationBackoff.Duration == 0 {
+		m.Status.LastRemediations = nil
+		return unhealthy, nil, nil
+	}
+
+	backoff := m.Spec.RemediationBackoff.Duration
+	now := time.Now()
+
+	lastRemediations := []clusterv1.OwnerRemediation{}
+	for _, remediation := range m.Status.LastRemediations {
+		if remediation.LastRemediationTime.Add(backoff).After(now) {
+			lastRemediations = append(lastRemediations, remediation)
+		}
+	}
+
+	var remediate, delayed []healthCheckTarget
+	var nextCheckTimes []time.Duration
+	for _, t := range unhealthy {
+		owner := metav1.GetControllerOf(t.Machine)
+		if owner == nil || annotations.IsPaused(cluster, t.Machine) || r.remediationInProgress(ctx, m, t.Machine) {
+			remediate = append(remediate, t)
+			continue
+		}
+
+		if i := indexOwnerRemediation(lastRemediations, owner); i >= 0 {
+			delayed = append(delayed, t)
+			nextCheckTimes = append(nextCheckTimes, lastRemediations[i].LastRemediationTime.Add(backoff).Sub(now)+time.Second)
+			continue
+		}
+
+		lastRemediations = append(lastRemediations, clusterv1.OwnerRemediation{
+			Kind:                owner.Kind,
+			Name:                owner.Name,
+			LastRemediationTime: metav1.NewTime(now),
+		})
+		remediate = append(remediate, t)
+	}
+
+	if len(lastRemediations) == 0 {
+		lastRemediations = nil
+	}
+	m.Status.LastRemediations = lastRemediations
+	return remediate, delayed, nextCheckTimes
+}
+
+// indexOwnerRemediation returns the index of the remediation record for the owner, or -1 if there is none.
+func indexOwnerRemediation(remediations []clusterv1.OwnerRemediation, owner *metav1.OwnerReference) int {
+	for i := range remediations {
+		if remediations[i].Kind == owner.Kind && remediations[i].Name == owner.Name {
+			return i
+		}
+	}
+	return -1
+}
+
+// remediationInProgress returns true if the remediation of the machine has already been triggered.
+func (r *MachineHealthCheckReconciler) remediationInProgress(ctx context.Context, m *clusterv1.MachineHealthCheck, machine *clusterv1.Machine) bool {
+	if m.Spec.RemediationTemplate != nil {
+		return r.externalRemediationRequestExists(ctx, m, machine.Name)
+	}
+	return conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition)
+}
+
+// patchDelayedTargets patches unhealthy machines whose remediation is delayed by the remediation backoff.
+func (r *MachineHealthCheckReconciler) patchDelayedTargets(ctx context.Context, logger logr.Logger, delayed []healthCheckTarget, m *clusterv1.MachineHealthCheck) []error {
+	errList := []error{}
+	for _, t := range delayed {
+		logger.Info("Target has failed health check, but remediation is delayed by the remediation backoff", "target", t.string(), "remediationBackoff", m.Spec.RemediationBackoff.Duration.String())
+		if err := t.patchHelper.Patch(ctx, t.Machine); err != nil {
+			errList = append(errList, errors.Wrapf(err, "failed to patch unhealthy machine status for machine: %s/%s", t.Machine.Namespace, t.Machine.Name))
+			continue
+		}
+		r.recorder.Eventf(
+			t.Machine,
+			corev1.EventTypeNormal,
+			EventRemediationBackedOff,
+			"Remediation of Machine %v is delayed by the remediation backoff of %s",
+			t.string(),
+			m.Spec.RemediationBackoff.Duration.String(),
+		)
+	}
+	return errList
+}
+
 // clusterToMachineHealthCheck maps events from Cluster objects to
 // MachineHealthCheck objects that belong to the Cluster.
 func (r *MachineHealthCheckReconciler) clusterToMachineHealthCheck(o client.Object) []reconcile.Request {
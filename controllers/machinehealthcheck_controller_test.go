@@ -37,10 +37,10 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	capierrors "sigs.k8s.io/cluster-api/errors"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -2562,6 +2562,117 @@ func newMachineHealthCheck(namespace, clusterName string) *clusterv1.MachineHeal
 	}
 }
 
+func TestApplyRemediationBackoff(t *testing.T) {
+	namespace := metav1.NamespaceDefault
+	clusterName := testClusterName
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterName,
+			Namespace: namespace,
+		},
+	}
+	labels := map[string]string{"cluster": "foo", "nodepool": "bar"}
+
+	newTarget := func(name, owner string) healthCheckTarget {
+		machine := newTestMachine(name, namespace, clusterName, "nodeName", labels)
+		if owner != "" {
+			machine.OwnerReferences = []metav1.OwnerReference{
+				{APIVersion: clusterv1.GroupVersion.String(), Kind: "MachineSet", Name: owner, Controller: pointer.BoolPtr(true)},
+			}
+		}
+		return healthCheckTarget{Machine: machine}
+	}
+	targetNames := func(targets []healthCheckTarget) []string {
+		names := []string{}
+		for _, t := range targets {
+			names = append(names, t.Machine.Name)
+		}
+		return names
+	}
+
+	remediationInProgress := newTarget("machine-in-progress", "ms1")
+	conditions.MarkFalse(remediationInProgress.Machine, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "")
+
+	tests := []struct {
+		name                 string
+		backoff              *metav1.Duration
+		lastRemediations     []clusterv1.OwnerRemediation
+		unhealthy            []healthCheckTarget
+		wantRemediate        []string
+		wantDelayed          []string
+		wantLastRemediations []string
+	}{
+		{
+			name:                 "remediates all the machines if the backoff is not set",
+			lastRemediations:     []clusterv1.OwnerRemediation{{Kind: "MachineSet", Name: "ms1", LastRemediationTime: metav1.Now()}},
+			unhealthy:            []healthCheckTarget{newTarget("machine1", "ms1"), newTarget("machine2", "ms1")},
+			wantRemediate:        []string{"machine1", "machine2"},
+			wantDelayed:          []string{},
+			wantLastRemediations: []string{},
+		},
+		{
+			name:                 "remediates a single machine for each owner",
+			backoff:              &metav1.Duration{Duration: time.Hour},
+			unhealthy:            []healthCheckTarget{newTarget("machine1", "ms1"), newTarget("machine2", "ms1"), newTarget("machine3", "ms2")},
+			wantRemediate:        []string{"machine1", "machine3"},
+			wantDelayed:          []string{"machine2"},
+			wantLastRemediations: []string{"ms1", "ms2"},
+		},
+		{
+			name:                 "delays remediation if a machine with the same owner was remediated within the backoff",
+			backoff:              &metav1.Duration{Duration: time.Hour},
+			lastRemediations:     []clusterv1.OwnerRemediation{{Kind: "MachineSet", Name: "ms1", LastRemediationTime: metav1.NewTime(time.Now().Add(-30 * time.Minute))}},
+			unhealthy:            []healthCheckTarget{newTarget("machine1", "ms1")},
+			wantRemediate:        []string{},
+			wantDelayed:          []string{"machine1"},
+			wantLastRemediations: []string{"ms1"},
+		},
+		{
+			name:                 "remediates if a machine with the same owner was remediated before the backoff",
+			backoff:              &metav1.Duration{Duration: time.Hour},
+			lastRemediations:     []clusterv1.OwnerRemediation{{Kind: "MachineSet", Name: "ms1", LastRemediationTime: metav1.NewTime(time.Now().Add(-2 * time.Hour))}},
+			unhealthy:            []healthCheckTarget{newTarget("machine1", "ms1")},
+			wantRemediate:        []string{"machine1"},
+			wantDelayed:          []string{},
+			wantLastRemediations: []string{"ms1"},
+		},
+		{
+			name:                 "does not delay machines without an owner or whose remediation is in progress",
+			backoff:              &metav1.Duration{Duration: time.Hour},
+			lastRemediations:     []clusterv1.OwnerRemediation{{Kind: "MachineSet", Name: "ms1", LastRemediationTime: metav1.Now()}},
+			unhealthy:            []healthCheckTarget{newTarget("machine1", ""), remediationInProgress},
+			wantRemediate:        []string{"machine1", "machine-in-progress"},
+			wantDelayed:          []string{},
+			wantLastRemediations: []string{"ms1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mhc := newMachineHealthCheckWithLabels("mhc", namespace, clusterName, labels)
+			mhc.Spec.RemediationBackoff = tt.backoff
+			mhc.Status.LastRemediations = tt.lastRemediations
+
+			r := &MachineHealthCheckReconciler{}
+			remediate, delayed, nextCheckTimes := r.applyRemediationBackoff(ctx, mhc, tt.unhealthy, cluster)
+			g.Expect(targetNames(remediate)).To(Equal(tt.wantRemediate))
+			g.Expect(targetNames(delayed)).To(Equal(tt.wantDelayed))
+			g.Expect(nextCheckTimes).To(HaveLen(len(tt.wantDelayed)))
+			for _, d := range nextCheckTimes {
+				g.Expect(d).To(BeNumerically(">", 0))
+				g.Expect(d).To(BeNumerically("<=", tt.backoff.Duration+time.Second))
+			}
+
+			owners := []string{}
+			for _, remediation := range mhc.Status.LastRemediations {
+				owners = append(owners, remediation.Name)
+			}
+			g.Expect(owners).To(Equal(tt.wantLastRemediations))
+		})
+	}
+}
+
 func TestPatchTargets(t *testing.T) {
 	g := NewWithT(t)
 
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -164,15 +165,15 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 
 		// Skip when current node condition is different from the one reported
 		// in the MachineHealthCheck.
-		if nodeCondition == nil || nodeCondition.Status != c.Status {
+		if nodeCondition == nil || !unhealthyConditionMatches(logger, c, nodeCondition.Status) {
 			continue
 		}
 
 		// If the condition has been in the unhealthy state for longer than the
 		// timeout, return true with no requeue time.
 		if nodeCondition.LastTransitionTime.Add(c.Timeout.Duration).Before(now) {
-			conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSuccededCondition, clusterv1.UnhealthyNodeConditionReason, clusterv1.ConditionSeverityWarning, "Condition %s on node is reporting status %s for more than %s", c.Type, c.Status, c.Timeout.Duration.String())
-			logger.V(3).Info("Target is unhealthy: condition is in state longer than allowed timeout", "condition", c.Type, "state", c.Status, "timeout", c.Timeout.Duration.String())
+			conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSuccededCondition, clusterv1.UnhealthyNodeConditionReason, clusterv1.ConditionSeverityWarning, "Condition %s on node is reporting status %s for more than %s", c.Type, nodeCondition.Status, c.Timeout.Duration.String())
+			logger.V(3).Info("Target is unhealthy: condition is in state longer than allowed timeout", "condition", c.Type, "state", nodeCondition.Status, "timeout", c.Timeout.Duration.String())
 			return true, time.Duration(0)
 		}
 
@@ -280,7 +281,11 @@ func (r *MachineHealthCheckReconciler) healthCheckTargets(targets []healthCheckT
 	for _, t := range targets {
 		logger = logger.WithValues("Target", t.string())
 		logger.V(3).Info("Health checking target")
-		needsRemediation, nextCheck := t.needsRemediation(logger, timeoutForMachineToHaveNode)
+		timeout := timeoutForMachineToHaveNode
+		if util.IsControlPlaneMachine(t.Machine) && t.MHC.Spec.ControlPlaneNodeStartupTimeout != nil {
+			timeout = *t.MHC.Spec.ControlPlaneNodeStartupTimeout
+		}
+		needsRemediation, nextCheck := t.needsRemediation(logger, timeout)
 
 		if needsRemediation {
 			unhealthy = append(unhealthy, t)
@@ -319,6 +324,24 @@ func getNodeCondition(node *corev1.Node, conditionType corev1.NodeConditionType)
 	return nil
 }
 
+// unhealthyConditionMatches returns true if the status of a node condition matches the status, or the
+// status regular expression, of an unhealthy condition defined in a MachineHealthCheck.
+func unhealthyConditionMatches(logger logr.Logger, c clusterv1.UnhealthyCondition, status corev1.ConditionStatus) bool {
+	if status == c.Status {
+		return true
+	}
+	if c.StatusRegex == "" {
+		return false
+	}
+
+	re, err := c.CompileStatusRegex()
+	if err != nil {
+		logger.Error(err, "Failed to compile unhealthy condition status regex, skipping", "condition", c.Type, "statusRegex", c.StatusRegex)
+		return false
+	}
+	return re.MatchString(string(status))
+}
+
 func minDuration(durations []time.Duration) time.Duration {
 	if len(durations) == 0 {
 		return time.Duration(0)
@@ -231,6 +231,12 @@ func TestHealthCheckTargets(t *testing.T) {
 					Status:  corev1.ConditionFalse,
 					Timeout: metav1.Duration{Duration: 5 * time.Minute},
 				},
+				{
+					Type:        "KernelDeadlock",
+					Status:      corev1.ConditionUnknown,
+					StatusRegex: "True",
+					Timeout:     metav1.Duration{Duration: 5 * time.Minute},
+				},
 			},
 		},
 	}
@@ -260,6 +266,25 @@ func TestHealthCheckTargets(t *testing.T) {
 		Node:    nil,
 	}
 
+	// Targets for when the node has not yet been seen, using a MHC with a longer startup timeout for control plane machines
+	testMHCWithControlPlaneTimeout := testMHC.DeepCopy()
+	testMHCWithControlPlaneTimeout.Spec.ControlPlaneNodeStartupTimeout = &metav1.Duration{Duration: 30 * time.Minute}
+
+	controlPlaneNodeNotYetStartedTarget1200s := healthCheckTarget{
+		Cluster: cluster,
+		MHC:     testMHCWithControlPlaneTimeout,
+		Machine: testMachineCreated1200s.DeepCopy(),
+		Node:    nil,
+	}
+	controlPlaneNodeNotYetStartedTarget1200s.Machine.Labels[clusterv1.MachineControlPlaneLabelName] = ""
+
+	workerNodeNotYetStartedTarget1200s := healthCheckTarget{
+		Cluster: cluster,
+		MHC:     testMHCWithControlPlaneTimeout,
+		Machine: testMachineCreated1200s,
+		Node:    nil,
+	}
+
 	// Target for when the Node has been seen, but has now gone
 	nodeGoneAway := healthCheckTarget{
 		Cluster:     cluster,
@@ -299,6 +324,26 @@ func TestHealthCheckTargets(t *testing.T) {
 		nodeMissing: false,
 	}
 
+	// Target for when a custom node condition matching the status regex has been reported for longer than the timeout
+	testNodeKernelDeadlock400 := newTestUnhealthyNode("node1", "KernelDeadlock", corev1.ConditionTrue, 400*time.Second)
+	nodeKernelDeadlock400 := healthCheckTarget{
+		Cluster:     cluster,
+		MHC:         testMHC,
+		Machine:     testMachine,
+		Node:        testNodeKernelDeadlock400,
+		nodeMissing: false,
+	}
+
+	// Target for when a custom node condition not matching the status regex has been reported for longer than the timeout
+	testNodeNoKernelDeadlock400 := newTestUnhealthyNode("node1", "KernelDeadlock", corev1.ConditionFalse, 400*time.Second)
+	nodeNoKernelDeadlock400 := healthCheckTarget{
+		Cluster:     cluster,
+		MHC:         testMHC,
+		Machine:     testMachine,
+		Node:        testNodeNoKernelDeadlock400,
+		nodeMissing: false,
+	}
+
 	// Target for when a node is healthy
 	testNodeHealthy := newTestNode("node1")
 	testNodeHealthy.UID = "12345"
@@ -332,6 +377,20 @@ func TestHealthCheckTargets(t *testing.T) {
 			expectedNeedsRemediation: []healthCheckTarget{nodeNotYetStartedTarget1200s},
 			expectedNextCheckTimes:   []time.Duration{},
 		},
+		{
+			desc:                     "when the control plane node has not yet started for shorter than the control plane timeout",
+			targets:                  []healthCheckTarget{controlPlaneNodeNotYetStartedTarget1200s},
+			expectedHealthy:          []healthCheckTarget{},
+			expectedNeedsRemediation: []healthCheckTarget{},
+			expectedNextCheckTimes:   []time.Duration{30*time.Minute - 1200*time.Second},
+		},
+		{
+			desc:                     "when the worker node has not yet started for longer than the timeout, and the control plane timeout is longer",
+			targets:                  []healthCheckTarget{workerNodeNotYetStartedTarget1200s},
+			expectedHealthy:          []healthCheckTarget{},
+			expectedNeedsRemediation: []healthCheckTarget{workerNodeNotYetStartedTarget1200s},
+			expectedNextCheckTimes:   []time.Duration{},
+		},
 		{
 			desc:                     "when the node has gone away",
 			targets:                  []healthCheckTarget{nodeGoneAway},
@@ -353,6 +412,20 @@ func TestHealthCheckTargets(t *testing.T) {
 			expectedNeedsRemediation: []healthCheckTarget{nodeUnknown400},
 			expectedNextCheckTimes:   []time.Duration{},
 		},
+		{
+			desc:                     "when a custom node condition matches the status regex for longer than the timeout",
+			targets:                  []healthCheckTarget{nodeKernelDeadlock400},
+			expectedHealthy:          []healthCheckTarget{},
+			expectedNeedsRemediation: []healthCheckTarget{nodeKernelDeadlock400},
+			expectedNextCheckTimes:   []time.Duration{},
+		},
+		{
+			desc:                     "when a custom node condition does not match the status regex",
+			targets:                  []healthCheckTarget{nodeNoKernelDeadlock400},
+			expectedHealthy:          []healthCheckTarget{nodeNoKernelDeadlock400},
+			expectedNeedsRemediation: []healthCheckTarget{},
+			expectedNextCheckTimes:   []time.Duration{},
+		},
 		{
 			desc:                     "when the node is healthy",
 			targets:                  []healthCheckTarget{nodeHealthy},
@@ -411,6 +484,66 @@ func TestHealthCheckTargets(t *testing.T) {
 	}
 }
 
+func TestUnhealthyConditionMatches(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		condition clusterv1.UnhealthyCondition
+		status    corev1.ConditionStatus
+		expected  bool
+	}{
+		{
+			desc:      "status matches",
+			condition: clusterv1.UnhealthyCondition{Status: corev1.ConditionFalse},
+			status:    corev1.ConditionFalse,
+			expected:  true,
+		},
+		{
+			desc:      "status does not match",
+			condition: clusterv1.UnhealthyCondition{Status: corev1.ConditionFalse},
+			status:    corev1.ConditionTrue,
+			expected:  false,
+		},
+		{
+			desc:      "status matches when status regex is set",
+			condition: clusterv1.UnhealthyCondition{Status: corev1.ConditionTrue, StatusRegex: "Unknown"},
+			status:    corev1.ConditionTrue,
+			expected:  true,
+		},
+		{
+			desc:      "status regex matches",
+			condition: clusterv1.UnhealthyCondition{Status: corev1.ConditionTrue, StatusRegex: "Unknown|Degraded"},
+			status:    corev1.ConditionUnknown,
+			expected:  true,
+		},
+		{
+			desc:      "status regex does not match",
+			condition: clusterv1.UnhealthyCondition{Status: corev1.ConditionTrue, StatusRegex: "Unknown|Degraded"},
+			status:    corev1.ConditionFalse,
+			expected:  false,
+		},
+		{
+			desc:      "status regex must match the whole status",
+			condition: clusterv1.UnhealthyCondition{Status: corev1.ConditionFalse, StatusRegex: "Tru"},
+			status:    corev1.ConditionTrue,
+			expected:  false,
+		},
+		{
+			desc:      "invalid status regex never matches",
+			condition: clusterv1.UnhealthyCondition{Status: corev1.ConditionFalse, StatusRegex: "True|("},
+			status:    corev1.ConditionTrue,
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(unhealthyConditionMatches(ctrl.LoggerFrom(ctx), tc.condition, tc.status)).To(Equal(tc.expected))
+		})
+	}
+}
+
 func newTestMachine(name, namespace, clusterName, nodeName string, labels map[string]string) *clusterv1.Machine {
 	// Copy the labels so that the map is unique to each test Machine
 	l := make(map[string]string)
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
@@ -38,7 +39,10 @@ import (
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	"sigs.k8s.io/cluster-api/util/failuredomains"
+	"sigs.k8s.io/cluster-api/util/naming"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -73,7 +77,12 @@ type MachineSetReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
-	recorder record.EventRecorder
+	// DisableOrphanAdoption prevents MachineSets from adopting orphaned Machines matching their selector;
+	// orphaned Machines are then only flagged with the MachineSetOwnedCondition by the Machine controller.
+	DisableOrphanAdoption bool
+
+	recorder        record.EventRecorder
+	externalTracker external.ObjectTracker
 }
 
 func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -101,7 +110,10 @@ func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		handler.EnqueueRequestsFromMapFunc(clusterToMachineSets),
 		// TODO: should this wait for Cluster.Status.InfrastructureReady similar to Infra Machine resources?
 		predicates.All(ctrl.LoggerFrom(ctx),
-			predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+			predicates.Any(ctrl.LoggerFrom(ctx),
+				predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+				predicates.ClusterPausedTransitions(ctrl.LoggerFrom(ctx)),
+			),
 			predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
 		),
 	)
@@ -110,6 +122,9 @@ func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 	}
 
 	r.recorder = mgr.GetEventRecorderFor("machineset-controller")
+	r.externalTracker = external.ObjectTracker{
+		Controller: c,
+	}
 	return nil
 }
 
@@ -132,8 +147,12 @@ func (r *MachineSetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, machineSet) {
+	// Return early if the object or Cluster is paused, reporting it in the Paused condition.
+	isPaused, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, machineSet)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -145,6 +164,8 @@ func (r *MachineSetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	defer func() {
+		annotations.SetLastReconcile(machineSet, reterr)
+
 		// Always attempt to patch the object and status after each reconciliation.
 		if err := patchMachineSet(ctx, patchHelper, machineSet); err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
@@ -166,6 +187,9 @@ func (r *MachineSetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 }
 
 func patchMachineSet(ctx context.Context, patchHelper *patch.Helper, machineSet *clusterv1.MachineSet, options ...patch.Option) error {
+	// Keep the deprecated failureReason/failureMessage fields and the NoTerminalFailure condition in sync.
+	conditions.SyncMachineSetFailure(machineSet)
+
 	// Always update the readyCondition by summarizing the state of other conditions.
 	conditions.SetSummary(machineSet,
 		conditions.WithConditions(
@@ -217,6 +241,12 @@ func (r *MachineSetReconciler) reconcile(ctx context.Context, cluster *clusterv1
 		}
 	}
 
+	// Surface the capacity of the machines, if reported by the infrastructure template, for the cluster autoscaler.
+	templateHandler := handler.EnqueueRequestsFromMapFunc(r.InfrastructureTemplateToMachineSets)
+	if err := reconcileCapacityAnnotations(ctx, r.Client, &r.externalTracker, templateHandler, machineSet, &machineSet.Spec.Template.Spec.InfrastructureRef); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Make sure selector and template to be in the same cluster.
 	if machineSet.Spec.Selector.MatchLabels == nil {
 		machineSet.Spec.Selector.MatchLabels = make(map[string]string)
@@ -255,6 +285,10 @@ func (r *MachineSetReconciler) reconcile(ctx context.Context, cluster *clusterv1
 
 		// Attempt to adopt machine if it meets previous conditions and it has no controller references.
 		if metav1.GetControllerOf(machine) == nil {
+			if r.DisableOrphanAdoption {
+				log.V(4).Info("Skipping orphaned Machine, orphan adoption is disabled", "machine", machine.Name)
+				continue
+			}
 			if err := r.adoptOrphan(ctx, machineSet, machine); err != nil {
 				log.Error(err, "Failed to adopt Machine", "machine", machine.Name)
 				r.recorder.Eventf(machineSet, corev1.EventTypeWarning, "FailedAdopt", "Failed to adopt Machine %q: %v", machine.Name, err)
@@ -294,7 +328,16 @@ func (r *MachineSetReconciler) reconcile(ctx context.Context, cluster *clusterv1
 		return ctrl.Result{}, errors.Wrap(err, "failed to remediate machines")
 	}
 
-	syncErr := r.syncReplicas(ctx, machineSet, filteredMachines)
+	if err := r.reconcileTerminationNotices(ctx, machineSet, filteredMachines); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to replace machines with a termination notice")
+	}
+
+	retryResult, err := r.reconcileInfrastructureFailures(ctx, machineSet, filteredMachines)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to retry machines with infrastructure failures")
+	}
+
+	syncErr := r.syncReplicas(ctx, cluster, machineSet, filteredMachines)
 
 	// Always updates status as machines come up or die.
 	if err := r.updateStatus(ctx, cluster, machineSet, filteredMachines); err != nil {
@@ -320,25 +363,33 @@ func (r *MachineSetReconciler) reconcile(ctx context.Context, cluster *clusterv1
 	if machineSet.Spec.MinReadySeconds > 0 &&
 		machineSet.Status.ReadyReplicas == replicas &&
 		machineSet.Status.AvailableReplicas != replicas {
-		return ctrl.Result{RequeueAfter: time.Duration(machineSet.Spec.MinReadySeconds) * time.Second}, nil
+		return util.LowestNonZeroResult(retryResult, ctrl.Result{RequeueAfter: time.Duration(machineSet.Spec.MinReadySeconds) * time.Second}), nil
 	}
 
 	// Quickly reconcile until the nodes become Ready.
 	if machineSet.Status.ReadyReplicas != replicas {
 		log.V(4).Info("Some nodes are not ready yet, requeuing until they are ready")
-		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
+		return util.LowestNonZeroResult(retryResult, ctrl.Result{RequeueAfter: 15 * time.Second}), nil
 	}
 
-	return ctrl.Result{}, nil
+	return retryResult, nil
 }
 
 // syncReplicas scales Machine resources up or down.
-func (r *MachineSetReconciler) syncReplicas(ctx context.Context, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) error {
+// If the machine template does not set a failure domain, machines are spread across all the failure domains
+// of the Cluster, including the ones suitable for control plane machines, creating machines in the failure domains with fewest machines and deleting machines from
+// the failure domains with most machines.
+func (r *MachineSetReconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) error {
 	log := ctrl.LoggerFrom(ctx)
 	if ms.Spec.Replicas == nil {
 		return errors.Errorf("the Replicas field in Spec for machineset %v is nil, this should not be allowed", ms.Name)
 	}
 	diff := len(machines) - int(*(ms.Spec.Replicas))
+
+	var failureDomains clusterv1.FailureDomains
+	if ms.Spec.Template.Spec.FailureDomain == nil {
+		failureDomains = cluster.Status.FailureDomains
+	}
 	switch {
 	case diff < 0:
 		diff *= -1
@@ -354,22 +405,44 @@ func (r *MachineSetReconciler) syncReplicas(ctx context.Context, ms *clusterv1.M
 			errs        []error
 		)
 
+		machineNames, err := r.getMachineNames(ctx, ms)
+		if err != nil {
+			return err
+		}
+		machinesInFailureDomains := collections.FromMachines(machines...).Filter(collections.Not(collections.HasDeletionTimestamp))
+		interruptibleAllowance, err := interruptibleInstancesAllowance(ms, machines)
+		if err != nil {
+			return err
+		}
+
 		for i := 0; i < diff; i++ {
 			log.Info(fmt.Sprintf("Creating machine %d of %d, ( spec.replicas(%d) > currentMachineCount(%d) )",
 				i+1, diff, *(ms.Spec.Replicas), len(machines)))
 
-			machine := r.getNewMachine(ms)
+			machine, err := r.getNewMachine(ms, machineNames)
+			if err != nil {
+				conditions.MarkFalse(ms, clusterv1.MachinesCreatedCondition, clusterv1.MachineCreationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+				return err
+			}
+			machineNames.Insert(machine.Name)
+			// Request a non-interruptible instance once the MachineSet's interruption budget is reached.
+			if interruptibleAllowance > 0 {
+				interruptibleAllowance--
+			} else {
+				setNonInterruptibleInstanceAnnotation(machine)
+			}
+			if len(failureDomains) > 0 {
+				machine.Spec.FailureDomain = failuredomains.PickFewest(failureDomains, machinesInFailureDomains)
+			}
 
 			// Clone and set the infrastructure and bootstrap references.
-			var (
-				infraRef, bootstrapRef *corev1.ObjectReference
-				err                    error
-			)
+			var infraRef, bootstrapRef *corev1.ObjectReference
 
 			if machine.Spec.Bootstrap.ConfigRef != nil {
 				bootstrapRef, err = external.CloneTemplate(ctx, &external.CloneTemplateInput{
 					Client:      r.Client,
 					TemplateRef: machine.Spec.Bootstrap.ConfigRef,
+					Name:        machine.Name,
 					Namespace:   machine.Namespace,
 					ClusterName: machine.Spec.ClusterName,
 					Labels:      machine.Labels,
@@ -385,6 +458,7 @@ func (r *MachineSetReconciler) syncReplicas(ctx context.Context, ms *clusterv1.M
 			infraRef, err = external.CloneTemplate(ctx, &external.CloneTemplateInput{
 				Client:      r.Client,
 				TemplateRef: &machine.Spec.InfrastructureRef,
+				Name:        machine.Name,
 				Namespace:   machine.Namespace,
 				ClusterName: machine.Spec.ClusterName,
 				Labels:      machine.Labels,
@@ -416,6 +490,7 @@ func (r *MachineSetReconciler) syncReplicas(ctx context.Context, ms *clusterv1.M
 			}
 
 			log.Info(fmt.Sprintf("Created machine %d of %d with name %q", i+1, diff, machine.Name))
+			machinesInFailureDomains.Insert(machine)
 			r.recorder.Eventf(ms, corev1.EventTypeNormal, "SuccessfulCreate", "Created machine %q", machine.Name)
 			machineList = append(machineList, machine)
 		}
@@ -434,7 +509,7 @@ func (r *MachineSetReconciler) syncReplicas(ctx context.Context, ms *clusterv1.M
 		log.Info("Found delete policy", "delete-policy", ms.Spec.DeletePolicy)
 
 		var errs []error
-		machinesToDelete := getMachinesToDeletePrioritized(machines, diff, deletePriorityFunc)
+		machinesToDelete := getMachinesToDeleteSpreadingFailureDomains(machines, diff, deletePriorityFunc, failureDomains)
 		for _, machine := range machinesToDelete {
 			if err := r.Client.Delete(ctx, machine); err != nil {
 				log.Error(err, "Unable to delete Machine", "machine", machine.Name)
@@ -456,8 +531,9 @@ func (r *MachineSetReconciler) syncReplicas(ctx context.Context, ms *clusterv1.M
 }
 
 // getNewMachine creates a new Machine object. The name of the newly created resource is going
-// to be created by the API server, we set the generateName field.
-func (r *MachineSetReconciler) getNewMachine(machineSet *clusterv1.MachineSet) *clusterv1.Machine {
+// to be created by the API server, we set the generateName field, unless the MachineSet defines a
+// machine naming strategy; in this case the name is generated not to be one of the existing names.
+func (r *MachineSetReconciler) getNewMachine(machineSet *clusterv1.MachineSet, existingNames sets.String) (*clusterv1.Machine, error) {
 	gv := clusterv1.GroupVersion
 	machine := &clusterv1.Machine{
 		ObjectMeta: metav1.ObjectMeta{
@@ -477,7 +553,42 @@ func (r *MachineSetReconciler) getNewMachine(machineSet *clusterv1.MachineSet) *
 	if machine.Labels == nil {
 		machine.Labels = make(map[string]string)
 	}
-	return machine
+
+	if machineSet.Spec.MachineNamingStrategy != nil {
+		name, err := naming.Generate(naming.MachineNameInput{
+			Template:    machineSet.Spec.MachineNamingStrategy.Template,
+			ClusterName: machineSet.Spec.ClusterName,
+			OwnerKey:    naming.MachineSetOwnerKey,
+			OwnerName:   machineSet.Name,
+		}, existingNames)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to generate the name of a new Machine for MachineSet %q in namespace %q", machineSet.Name, machineSet.Namespace)
+		}
+		machine.GenerateName = ""
+		machine.Name = name
+	}
+	return machine, nil
+}
+
+// getMachineNames returns the names of all the Machines of the Cluster of the MachineSet, used to generate
+// unique names for new Machines when the MachineSet defines a machine naming strategy.
+func (r *MachineSetReconciler) getMachineNames(ctx context.Context, machineSet *clusterv1.MachineSet) (sets.String, error) {
+	machineNames := sets.NewString()
+	if machineSet.Spec.MachineNamingStrategy == nil {
+		return machineNames, nil
+	}
+
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machineList,
+		client.InNamespace(machineSet.Namespace),
+		client.MatchingLabels{clusterv1.ClusterLabelName: machineSet.Spec.ClusterName},
+	); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Machines of Cluster %q in namespace %q", machineSet.Spec.ClusterName, machineSet.Namespace)
+	}
+	for i := range machineList.Items {
+		machineNames.Insert(machineList.Items[i].Name)
+	}
+	return machineNames, nil
 }
 
 // shouldExcludeMachine returns true if the machine should be filtered out, false otherwise.
@@ -546,6 +657,28 @@ func (r *MachineSetReconciler) waitForMachineDeletion(ctx context.Context, machi
 	return nil
 }
 
+// InfrastructureTemplateToMachineSets is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for MachineSets referencing an infrastructure machine template, so the capacity annotations are kept up to date.
+func (r *MachineSetReconciler) InfrastructureTemplateToMachineSets(o client.Object) []ctrl.Request {
+	ctx := context.Background()
+	// This won't log unless the global logger is set
+	log := ctrl.LoggerFrom(ctx, "object", client.ObjectKeyFromObject(o))
+
+	msList := &clusterv1.MachineSetList{}
+	if err := r.Client.List(ctx, msList, client.InNamespace(o.GetNamespace())); err != nil {
+		log.Error(err, "Failed getting MachineSets for the infrastructure machine template")
+		return nil
+	}
+
+	var result []ctrl.Request
+	for _, ms := range msList.Items {
+		if refersToInfrastructureTemplate(ms.Spec.Template.Spec.InfrastructureRef, o) {
+			result = append(result, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: ms.Namespace, Name: ms.Name}})
+		}
+	}
+	return result
+}
+
 // MachineToMachineSets is a handler.ToRequestsFunc to be used to enqeue requests for reconciliation
 // for MachineSets that might adopt an orphaned Machine.
 func (r *MachineSetReconciler) MachineToMachineSets(o client.Object) []ctrl.Request {
@@ -650,7 +783,8 @@ func (r *MachineSetReconciler) updateStatus(ctx context.Context, cluster *cluste
 			continue
 		}
 
-		if noderefutil.IsNodeReady(node) {
+		// A machine with readiness gates not satisfied is not considered ready, no matter of the node status.
+		if noderefutil.IsNodeReady(node) && collections.HasReadinessGatesSatisfied(machine) {
 			readyReplicasCount++
 			if noderefutil.IsNodeAvailable(node, ms.Spec.MinReadySeconds, metav1.Now()) {
 				availableReplicasCount++
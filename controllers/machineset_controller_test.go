@@ -25,12 +25,14 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -884,3 +886,125 @@ func TestMachineSetReconciler_updateStatusResizedCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineSetReconciler_getNewMachineWithMachineNamingStrategy(t *testing.T) {
+	ms := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ms-foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: "foo",
+		},
+	}
+
+	t.Run("generates a name from the GenerateName when no machine naming strategy is set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		machine, err := (&MachineSetReconciler{}).getNewMachine(ms, sets.NewString())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(machine.Name).To(BeEmpty())
+		g.Expect(machine.GenerateName).To(Equal("ms-foo-"))
+	})
+
+	t.Run("generates a name not already in use from the machine naming strategy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		msWithStrategy := ms.DeepCopy()
+		msWithStrategy.Spec.MachineNamingStrategy = &clusterv1.MachineNamingStrategy{Template: "{{ .cluster.name }}-worker-{{ .index }}"}
+
+		machine, err := (&MachineSetReconciler{}).getNewMachine(msWithStrategy, sets.NewString("foo-worker-0"))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(machine.Name).To(Equal("foo-worker-1"))
+		g.Expect(machine.GenerateName).To(BeEmpty())
+	})
+
+	t.Run("fails when the machine naming strategy generates an invalid name", func(t *testing.T) {
+		g := NewWithT(t)
+
+		msWithStrategy := ms.DeepCopy()
+		msWithStrategy.Spec.MachineNamingStrategy = &clusterv1.MachineNamingStrategy{Template: "{{ .cluster.name }}_{{ .index }}"}
+
+		_, err := (&MachineSetReconciler{}).getNewMachine(msWithStrategy, sets.NewString())
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestMachineSetReconciler_syncReplicasSpreadingFailureDomains(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Status: clusterv1.ClusterStatus{
+			FailureDomains: clusterv1.FailureDomains{
+				"control-plane": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"one":           clusterv1.FailureDomainSpec{},
+				"two":           clusterv1.FailureDomainSpec{},
+			},
+		},
+	}
+	infraTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra-template").Build()
+	replicas := int32(6)
+	ms := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ms-foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: cluster.Name,
+			Replicas:    &replicas,
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					ClusterName: cluster.Name,
+					InfrastructureRef: corev1.ObjectReference{
+						APIVersion: infraTemplate.GetAPIVersion(),
+						Kind:       infraTemplate.GetKind(),
+						Name:       infraTemplate.GetName(),
+						Namespace:  infraTemplate.GetNamespace(),
+					},
+				},
+			},
+		},
+	}
+	existing := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "ms-foo-existing",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.MachineSpec{FailureDomain: pointer.StringPtr("one")},
+	}
+
+	countFailureDomains := func(g *WithT, c client.Client) map[string]int {
+		machines := &clusterv1.MachineList{}
+		g.Expect(c.List(ctx, machines)).To(Succeed())
+		counts := map[string]int{}
+		for _, m := range machines.Items {
+			g.Expect(m.Spec.FailureDomain).ToNot(BeNil())
+			counts[*m.Spec.FailureDomain]++
+		}
+		return counts
+	}
+
+	t.Run("creates machines in the failure domains with fewest machines", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := fake.NewClientBuilder().WithObjects(cluster, ms, infraTemplate, existing).Build()
+		r := &MachineSetReconciler{Client: c, recorder: record.NewFakeRecorder(32)}
+
+		g.Expect(r.syncReplicas(ctx, cluster, ms, []*clusterv1.Machine{existing})).To(Succeed())
+		g.Expect(countFailureDomains(g, c)).To(Equal(map[string]int{"control-plane": 2, "one": 2, "two": 2}))
+	})
+
+	t.Run("creates machines in the failure domain of the machine template if set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		msWithFailureDomain := ms.DeepCopy()
+		msWithFailureDomain.Spec.Template.Spec.FailureDomain = pointer.StringPtr("one")
+		c := fake.NewClientBuilder().WithObjects(cluster, msWithFailureDomain, infraTemplate, existing).Build()
+		r := &MachineSetReconciler{Client: c, recorder: record.NewFakeRecorder(32)}
+
+		g.Expect(r.syncReplicas(ctx, cluster, msWithFailureDomain, []*clusterv1.Machine{existing})).To(Succeed())
+		g.Expect(countFailureDomains(g, c)).To(Equal(map[string]int{"one": 6}))
+	})
+}
@@ -23,6 +23,9 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/failuredomains"
 )
 
 type (
@@ -44,7 +47,7 @@ func oldestDeletePriority(machine *clusterv1.Machine) deletePriority {
 	if !machine.DeletionTimestamp.IsZero() {
 		return mustDelete
 	}
-	if _, ok := machine.ObjectMeta.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
+	if annotations.HasDeleteMachineAnnotation(machine) {
 		return mustDelete
 	}
 	if machine.Status.NodeRef == nil {
@@ -67,7 +70,7 @@ func newestDeletePriority(machine *clusterv1.Machine) deletePriority {
 	if !machine.DeletionTimestamp.IsZero() {
 		return mustDelete
 	}
-	if _, ok := machine.ObjectMeta.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
+	if annotations.HasDeleteMachineAnnotation(machine) {
 		return mustDelete
 	}
 	if machine.Status.NodeRef == nil {
@@ -83,7 +86,7 @@ func randomDeletePolicy(machine *clusterv1.Machine) deletePriority {
 	if !machine.DeletionTimestamp.IsZero() {
 		return mustDelete
 	}
-	if _, ok := machine.ObjectMeta.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
+	if annotations.HasDeleteMachineAnnotation(machine) {
 		return betterDelete
 	}
 	if machine.Status.NodeRef == nil {
@@ -122,6 +125,48 @@ func getMachinesToDeletePrioritized(filteredMachines []*clusterv1.Machine, diff
 	return sortable.machines[:diff]
 }
 
+// getMachinesToDeleteSpreadingFailureDomains returns the machines to delete, preserving the spreading of the remaining
+// machines across the failure domains: machines which should be deleted first regardless of the delete policy, e.g.
+// machines marked for deletion or failed, are picked first, then machines not in one of the failure domains, and then
+// machines in the failure domain with most machines; the delete policy is used to pick among the candidates.
+func getMachinesToDeleteSpreadingFailureDomains(filteredMachines []*clusterv1.Machine, diff int, fun deletePriorityFunc, failureDomains clusterv1.FailureDomains) []*clusterv1.Machine {
+	if len(failureDomains) == 0 || diff >= len(filteredMachines) || diff <= 0 {
+		return getMachinesToDeletePrioritized(filteredMachines, diff, fun)
+	}
+
+	remaining := collections.FromMachines(filteredMachines...)
+	machinesToDelete := make([]*clusterv1.Machine, 0, diff)
+	for len(machinesToDelete) < diff {
+		candidates := remaining.Filter(hasDeletePrecedence)
+		if len(candidates) == 0 {
+			candidates = remaining.Filter(collections.Not(collections.InFailureDomains(failureDomains.GetIDs()...)))
+		}
+		if len(candidates) == 0 {
+			fd := failuredomains.PickMost(failureDomains, remaining, remaining)
+			candidates = remaining.Filter(collections.InFailureDomains(fd))
+		}
+		machine := getMachinesToDeletePrioritized(candidates.UnsortedList(), 1, fun)[0]
+		machinesToDelete = append(machinesToDelete, machine)
+		delete(remaining, machine.Name)
+	}
+	return machinesToDelete
+}
+
+// hasDeletePrecedence returns true if the machine should be deleted before any other machine, regardless of its
+// failure domain.
+func hasDeletePrecedence(machine *clusterv1.Machine) bool {
+	if !machine.DeletionTimestamp.IsZero() {
+		return true
+	}
+	if annotations.HasDeleteMachineAnnotation(machine) {
+		return true
+	}
+	if machine.Status.NodeRef == nil {
+		return true
+	}
+	return machine.Status.FailureReason != nil || machine.Status.FailureMessage != nil
+}
+
 func getDeletePriorityFunc(ms *clusterv1.MachineSet) (deletePriorityFunc, error) {
 	// Map the Spec.DeletePolicy value to the appropriate delete priority function
 	switch msdp := clusterv1.MachineSetDeletePolicy(ms.Spec.DeletePolicy); msdp {
@@ -18,10 +18,12 @@ package controllers
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 )
@@ -395,3 +397,83 @@ func TestMachineOldestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineToDeleteSpreadingFailureDomains(t *testing.T) {
+	nodeRef := &corev1.ObjectReference{Name: "some-node"}
+	machine := func(name string, failureDomain *string, createdMinutesAgo int) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Duration(createdMinutesAgo) * time.Minute)),
+			},
+			Spec:   clusterv1.MachineSpec{FailureDomain: failureDomain},
+			Status: clusterv1.MachineStatus{NodeRef: nodeRef},
+		}
+	}
+	failureDomains := clusterv1.FailureDomains{
+		"one": clusterv1.FailureDomainSpec{},
+		"two": clusterv1.FailureDomainSpec{},
+	}
+	one, two := pointer.StringPtr("one"), pointer.StringPtr("two")
+
+	m1 := machine("m1", one, 10)
+	m2 := machine("m2", one, 20)
+	m3 := machine("m3", one, 30)
+	m4 := machine("m4", two, 40)
+	m5 := machine("m5", nil, 5)
+	m6 := machine("m6", two, 1)
+	m6.Annotations = map[string]string{clusterv1.DeleteMachineAnnotation: ""}
+
+	tests := []struct {
+		desc           string
+		machines       []*clusterv1.Machine
+		failureDomains clusterv1.FailureDomains
+		diff           int
+		expect         []*clusterv1.Machine
+	}{
+		{
+			desc:     "deletes from the failure domain with most machines, respecting the delete policy",
+			machines: []*clusterv1.Machine{m1, m2, m3, m4},
+			diff:     2,
+			expect:   []*clusterv1.Machine{m3, m2},
+		},
+		{
+			desc:     "deletes machines not in a failure domain first",
+			machines: []*clusterv1.Machine{m1, m2, m4, m5},
+			diff:     2,
+			expect:   []*clusterv1.Machine{m5, m2},
+		},
+		{
+			desc:     "deletes machines marked for deletion first",
+			machines: []*clusterv1.Machine{m1, m2, m3, m6},
+			diff:     2,
+			expect:   []*clusterv1.Machine{m6, m3},
+		},
+		{
+			desc:     "deletes all the machines if diff is greater than the number of machines",
+			machines: []*clusterv1.Machine{m1, m4},
+			diff:     3,
+			expect:   []*clusterv1.Machine{m1, m4},
+		},
+		{
+			desc:           "falls back to the delete policy without failure domains",
+			machines:       []*clusterv1.Machine{m1, m2, m3, m4},
+			failureDomains: clusterv1.FailureDomains{},
+			diff:           2,
+			expect:         []*clusterv1.Machine{m4, m3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fds := failureDomains
+			if test.failureDomains != nil {
+				fds = test.failureDomains
+			}
+			result := getMachinesToDeleteSpreadingFailureDomains(test.machines, test.diff, oldestDeletePriority, fds)
+			g.Expect(result).To(Equal(test.expect))
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	defaultInfrastructureFailureInitialBackoff = 10 * time.Second
+	defaultInfrastructureFailureMaxBackoff     = 10 * time.Minute
+)
+
+// defaultRetryableInfrastructureFailureReasons are the failure reasons considered transient when
+// the InfrastructureFailurePolicy does not set any.
+var defaultRetryableInfrastructureFailureReasons = []capierrors.MachineStatusError{
+	capierrors.CreateMachineError,
+	capierrors.InsufficientResourcesMachineError,
+}
+
+// reconcileInfrastructureFailures deletes the Machines whose infrastructure failed to be provisioned with one of the
+// retryable failure reasons of the MachineSet's InfrastructureFailurePolicy, so they are replaced by syncReplicas.
+// Consecutive retries are delayed by an exponential backoff and counted in the MachineSet status; the counter
+// is reset once all the Machines have their infrastructure ready.
+func (r *MachineSetReconciler) reconcileInfrastructureFailures(ctx context.Context, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	policy := ms.Spec.InfrastructureFailurePolicy
+	if policy == nil {
+		return ctrl.Result{}, nil
+	}
+
+	var failed []*clusterv1.Machine
+	allInfrastructureReady := true
+	for _, machine := range machines {
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if !machine.Status.InfrastructureReady {
+			allInfrastructureReady = false
+		}
+		if hasRetryableInfrastructureFailure(machine, policy) {
+			failed = append(failed, machine)
+		}
+	}
+
+	if len(failed) == 0 {
+		if allInfrastructureReady {
+			ms.Status.InfrastructureFailureRetries = 0
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if policy.MaxRetries != nil && ms.Status.InfrastructureFailureRetries >= *policy.MaxRetries {
+		log.Info("Not retrying Machines with infrastructure failures, max retries reached", "retries", ms.Status.InfrastructureFailureRetries)
+		return ctrl.Result{}, nil
+	}
+
+	if ms.Status.LastInfrastructureFailureRetryTime != nil && ms.Status.InfrastructureFailureRetries > 0 {
+		backoff := infrastructureFailureBackoff(policy, ms.Status.InfrastructureFailureRetries)
+		if wait := time.Until(ms.Status.LastInfrastructureFailureRetryTime.Add(backoff)); wait > 0 {
+			log.V(4).Info("Waiting before retrying Machines with infrastructure failures", "retries", ms.Status.InfrastructureFailureRetries, "after", wait)
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	for _, machine := range failed {
+		log.Info("Deleting Machine with infrastructure failure to be replaced", "machine", machine.Name, "reason", *machine.Status.FailureReason)
+		if err := r.Client.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to delete Machine %q", machine.Name)
+		}
+		r.recorder.Eventf(ms, corev1.EventTypeNormal, "InfrastructureFailureRetry", "Deleted Machine %q with infrastructure failure %q to be replaced", machine.Name, *machine.Status.FailureReason)
+	}
+
+	now := metav1.Now()
+	ms.Status.InfrastructureFailureRetries++
+	ms.Status.LastInfrastructureFailureRetryTime = &now
+	return ctrl.Result{}, nil
+}
+
+// hasRetryableInfrastructureFailure returns true if the Machine failed, with one of the retryable failure reasons
+// of the policy, before its infrastructure has been ready.
+func hasRetryableInfrastructureFailure(machine *clusterv1.Machine, policy *clusterv1.InfrastructureFailurePolicy) bool {
+	if machine.Status.InfrastructureReady || machine.Status.FailureReason == nil {
+		return false
+	}
+	reasons := policy.RetryableFailureReasons
+	if len(reasons) == 0 {
+		reasons = defaultRetryableInfrastructureFailureReasons
+	}
+	for _, reason := range reasons {
+		if *machine.Status.FailureReason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// infrastructureFailureBackoff returns the time to wait after the given number of retries before retrying again,
+// doubling the initial backoff at each retry up to the max backoff.
+func infrastructureFailureBackoff(policy *clusterv1.InfrastructureFailurePolicy, retries int32) time.Duration {
+	backoff := defaultInfrastructureFailureInitialBackoff
+	if policy.InitialBackoff != nil {
+		backoff = policy.InitialBackoff.Duration
+	}
+	maxBackoff := defaultInfrastructureFailureMaxBackoff
+	if policy.MaxBackoff != nil {
+		maxBackoff = policy.MaxBackoff.Duration
+	}
+	for i := int32(1); i < retries && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
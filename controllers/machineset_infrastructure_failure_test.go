@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMachineSetReconciler_reconcileInfrastructureFailures(t *testing.T) {
+	newMachine := func(name string, infrastructureReady bool, failureReason *capierrors.MachineStatusError) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Status: clusterv1.MachineStatus{
+				InfrastructureReady: infrastructureReady,
+				FailureReason:       failureReason,
+			},
+		}
+	}
+	newMachineSet := func(policy *clusterv1.InfrastructureFailurePolicy, retries int32, lastRetry time.Duration) *clusterv1.MachineSet {
+		ms := &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "ms", Namespace: metav1.NamespaceDefault},
+			Spec:       clusterv1.MachineSetSpec{InfrastructureFailurePolicy: policy},
+			Status:     clusterv1.MachineSetStatus{InfrastructureFailureRetries: retries},
+		}
+		if lastRetry != 0 {
+			ms.Status.LastInfrastructureFailureRetryTime = &metav1.Time{Time: time.Now().Add(-lastRetry)}
+		}
+		return ms
+	}
+
+	tests := []struct {
+		name        string
+		ms          *clusterv1.MachineSet
+		machines    []*clusterv1.Machine
+		wantDeleted []string
+		wantRetries int32
+		wantRequeue bool
+	}{
+		{
+			name:        "does nothing without a policy",
+			ms:          newMachineSet(nil, 0, 0),
+			machines:    []*clusterv1.Machine{newMachine("failed", false, capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError))},
+			wantRetries: 0,
+		},
+		{
+			name: "deletes machines failed with a default retryable reason",
+			ms:   newMachineSet(&clusterv1.InfrastructureFailurePolicy{}, 0, 0),
+			machines: []*clusterv1.Machine{
+				newMachine("failed", false, capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError)),
+				newMachine("insufficient-resources", false, capierrors.MachineStatusErrorPtr(capierrors.InsufficientResourcesMachineError)),
+				newMachine("invalid", false, capierrors.MachineStatusErrorPtr(capierrors.InvalidConfigurationMachineError)),
+				newMachine("failed-after-ready", true, capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError)),
+				newMachine("provisioning", false, nil),
+			},
+			wantDeleted: []string{"failed", "insufficient-resources"},
+			wantRetries: 1,
+		},
+		{
+			name: "deletes machines failed with a configured retryable reason",
+			ms: newMachineSet(&clusterv1.InfrastructureFailurePolicy{
+				RetryableFailureReasons: []capierrors.MachineStatusError{capierrors.InvalidConfigurationMachineError},
+			}, 0, 0),
+			machines: []*clusterv1.Machine{
+				newMachine("failed", false, capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError)),
+				newMachine("invalid", false, capierrors.MachineStatusErrorPtr(capierrors.InvalidConfigurationMachineError)),
+			},
+			wantDeleted: []string{"invalid"},
+			wantRetries: 1,
+		},
+		{
+			name: "waits for the backoff before retrying again",
+			ms: newMachineSet(&clusterv1.InfrastructureFailurePolicy{
+				InitialBackoff: &metav1.Duration{Duration: time.Minute},
+			}, 2, time.Minute),
+			machines:    []*clusterv1.Machine{newMachine("failed", false, capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError))},
+			wantRetries: 2,
+			wantRequeue: true,
+		},
+		{
+			name: "retries again after the backoff",
+			ms: newMachineSet(&clusterv1.InfrastructureFailurePolicy{
+				InitialBackoff: &metav1.Duration{Duration: time.Minute},
+			}, 2, 3*time.Minute),
+			machines:    []*clusterv1.Machine{newMachine("failed", false, capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError))},
+			wantDeleted: []string{"failed"},
+			wantRetries: 3,
+		},
+		{
+			name: "does not retry after max retries",
+			ms: newMachineSet(&clusterv1.InfrastructureFailurePolicy{
+				MaxRetries: pointer.Int32Ptr(2),
+			}, 2, time.Hour),
+			machines:    []*clusterv1.Machine{newMachine("failed", false, capierrors.MachineStatusErrorPtr(capierrors.CreateMachineError))},
+			wantRetries: 2,
+		},
+		{
+			name: "does not reset retries while machines are provisioning",
+			ms:   newMachineSet(&clusterv1.InfrastructureFailurePolicy{}, 2, time.Hour),
+			machines: []*clusterv1.Machine{
+				newMachine("ready", true, nil),
+				newMachine("provisioning", false, nil),
+			},
+			wantRetries: 2,
+		},
+		{
+			name:        "resets retries once all machines have their infrastructure ready",
+			ms:          newMachineSet(&clusterv1.InfrastructureFailurePolicy{}, 2, time.Hour),
+			machines:    []*clusterv1.Machine{newMachine("ready", true, nil)},
+			wantRetries: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := []client.Object{tt.ms}
+			for _, m := range tt.machines {
+				objs = append(objs, m)
+			}
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+			r := &MachineSetReconciler{Client: c, recorder: record.NewFakeRecorder(32)}
+
+			result, err := r.reconcileInfrastructureFailures(ctx, tt.ms, tt.machines)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.RequeueAfter > 0).To(Equal(tt.wantRequeue))
+			g.Expect(tt.ms.Status.InfrastructureFailureRetries).To(Equal(tt.wantRetries))
+
+			var deleted []string
+			for _, m := range tt.machines {
+				if err := c.Get(ctx, client.ObjectKeyFromObject(m), &clusterv1.Machine{}); apierrors.IsNotFound(err) {
+					deleted = append(deleted, m.Name)
+				}
+			}
+			g.Expect(deleted).To(Equal(tt.wantDeleted))
+		})
+	}
+}
+
+func TestInfrastructureFailureBackoff(t *testing.T) {
+	g := NewWithT(t)
+
+	policy := &clusterv1.InfrastructureFailurePolicy{
+		InitialBackoff: &metav1.Duration{Duration: 10 * time.Second},
+		MaxBackoff:     &metav1.Duration{Duration: time.Minute},
+	}
+	g.Expect(infrastructureFailureBackoff(policy, 1)).To(Equal(10 * time.Second))
+	g.Expect(infrastructureFailureBackoff(policy, 2)).To(Equal(20 * time.Second))
+	g.Expect(infrastructureFailureBackoff(policy, 3)).To(Equal(40 * time.Second))
+	g.Expect(infrastructureFailureBackoff(policy, 4)).To(Equal(time.Minute))
+	g.Expect(infrastructureFailureBackoff(&clusterv1.InfrastructureFailurePolicy{}, 1)).To(Equal(defaultInfrastructureFailureInitialBackoff))
+}
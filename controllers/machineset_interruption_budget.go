@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// interruptibleInstancesAllowance returns how many new Machines of the MachineSet can run on interruptible instances
+// without exceeding its InterruptionBudget; if the InterruptionBudget is not set, the allowance is not limited.
+func interruptibleInstancesAllowance(ms *clusterv1.MachineSet, machines []*clusterv1.Machine) (int, error) {
+	if ms.Spec.InterruptionBudget == nil {
+		return math.MaxInt32, nil
+	}
+	replicas := 0
+	if ms.Spec.Replicas != nil {
+		replicas = int(*ms.Spec.Replicas)
+	}
+	budget, err := intstr.GetScaledValueFromIntOrPercent(ms.Spec.InterruptionBudget, replicas, false)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to calculate the interruption budget")
+	}
+
+	allowance := budget
+	for _, machine := range machines {
+		if mayRunOnInterruptibleInstance(machine) {
+			allowance--
+		}
+	}
+	if allowance < 0 {
+		return 0, nil
+	}
+	return allowance, nil
+}
+
+// mayRunOnInterruptibleInstance returns true if the Machine runs on an interruptible instance, or if it may do so
+// once provisioned, i.e. it was created without the NonInterruptibleInstanceAnnotation and its infrastructure is
+// not ready yet. Machines being deleted are not considered, so their replacements can take their place in the budget.
+func mayRunOnInterruptibleInstance(machine *clusterv1.Machine) bool {
+	if !machine.DeletionTimestamp.IsZero() {
+		return false
+	}
+	if machine.Status.InterruptibleInstance {
+		return true
+	}
+	if _, ok := machine.Annotations[clusterv1.NonInterruptibleInstanceAnnotation]; ok {
+		return false
+	}
+	return !machine.Status.InfrastructureReady
+}
+
+// setNonInterruptibleInstanceAnnotation requests a non-interruptible instance for a new Machine; the annotations
+// are copied, given they are shared with the MachineSet's template.
+func setNonInterruptibleInstanceAnnotation(machine *clusterv1.Machine) {
+	annotations := make(map[string]string, len(machine.Annotations)+1)
+	for k, v := range machine.Annotations {
+		annotations[k] = v
+	}
+	annotations[clusterv1.NonInterruptibleInstanceAnnotation] = ""
+	machine.Annotations = annotations
+}
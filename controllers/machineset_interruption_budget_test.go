@@ -0,0 +1,129 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestInterruptibleInstancesAllowance(t *testing.T) {
+	interruptible := &clusterv1.Machine{
+		Status: clusterv1.MachineStatus{InfrastructureReady: true, InterruptibleInstance: true},
+	}
+	nonInterruptible := &clusterv1.Machine{
+		Status: clusterv1.MachineStatus{InfrastructureReady: true},
+	}
+	provisioning := &clusterv1.Machine{}
+	provisioningNonInterruptible := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{clusterv1.NonInterruptibleInstanceAnnotation: ""}},
+	}
+	deleting := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{Time: time.Now()}},
+		Status:     clusterv1.MachineStatus{InfrastructureReady: true, InterruptibleInstance: true},
+	}
+	intOrStr := func(v intstr.IntOrString) *intstr.IntOrString {
+		return &v
+	}
+
+	tests := []struct {
+		name      string
+		budget    *intstr.IntOrString
+		machines  []*clusterv1.Machine
+		want      int
+		wantError bool
+	}{
+		{
+			name:     "is not limited without a budget",
+			machines: []*clusterv1.Machine{interruptible, interruptible},
+			want:     math.MaxInt32,
+		},
+		{
+			name:     "subtracts the machines running on interruptible instances",
+			budget:   intOrStr(intstr.FromInt(3)),
+			machines: []*clusterv1.Machine{interruptible, nonInterruptible},
+			want:     2,
+		},
+		{
+			name:     "subtracts the machines which may be provisioned on interruptible instances",
+			budget:   intOrStr(intstr.FromInt(3)),
+			machines: []*clusterv1.Machine{provisioning, provisioningNonInterruptible},
+			want:     2,
+		},
+		{
+			name:     "does not subtract machines being deleted",
+			budget:   intOrStr(intstr.FromInt(1)),
+			machines: []*clusterv1.Machine{deleting},
+			want:     1,
+		},
+		{
+			name:     "rounds down percentages of the desired replicas",
+			budget:   intOrStr(intstr.FromString("50%")),
+			machines: []*clusterv1.Machine{interruptible},
+			want:     1,
+		},
+		{
+			name:     "returns zero when the budget is exceeded",
+			budget:   intOrStr(intstr.FromInt(1)),
+			machines: []*clusterv1.Machine{interruptible, interruptible},
+			want:     0,
+		},
+		{
+			name:      "fails with an invalid budget",
+			budget:    intOrStr(intstr.FromString("one")),
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ms := &clusterv1.MachineSet{
+				Spec: clusterv1.MachineSetSpec{
+					Replicas:           pointer.Int32Ptr(5),
+					InterruptionBudget: tt.budget,
+				},
+			}
+			allowance, err := interruptibleInstancesAllowance(ms, tt.machines)
+			if tt.wantError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(allowance).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSetNonInterruptibleInstanceAnnotation(t *testing.T) {
+	g := NewWithT(t)
+
+	templateAnnotations := map[string]string{"foo": "bar"}
+	machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Annotations: templateAnnotations}}
+
+	setNonInterruptibleInstanceAnnotation(machine)
+	g.Expect(machine.Annotations).To(Equal(map[string]string{"foo": "bar", clusterv1.NonInterruptibleInstanceAnnotation: ""}))
+	g.Expect(templateAnnotations).To(Equal(map[string]string{"foo": "bar"}))
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// reconcileTerminationNotices deletes the Machines whose instance received a termination notice from the infrastructure,
+// i.e. reporting the InstanceNotTerminating condition False, so they are drained before the instance is terminated and
+// replaced by syncReplicas.
+func (r *MachineSetReconciler) reconcileTerminationNotices(ctx context.Context, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	var errs []error
+	for _, machine := range machines {
+		if !conditions.IsFalse(machine, clusterv1.InstanceNotTerminatingCondition) || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		log.Info("Deleting Machine with a termination notice to be replaced", "machine", machine.Name)
+		if err := r.Client.Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, errors.Wrapf(err, "failed to delete Machine %q", machine.Name))
+			continue
+		}
+		r.recorder.Eventf(ms, corev1.EventTypeNormal, "TerminationNoticeReplacement", "Deleted Machine %q with a termination notice to be replaced", machine.Name)
+	}
+	return kerrors.NewAggregate(errs)
+}
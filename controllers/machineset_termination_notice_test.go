@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMachineSetReconciler_reconcileTerminationNotices(t *testing.T) {
+	newMachine := func(name string, noticeAge time.Duration, deleting bool) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+		}
+		if noticeAge != 0 {
+			conditions.Set(m, &clusterv1.Condition{
+				Type:               clusterv1.InstanceNotTerminatingCondition,
+				Status:             "False",
+				Severity:           clusterv1.ConditionSeverityWarning,
+				Reason:             clusterv1.TerminationNoticeReceivedReason,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-noticeAge)),
+			})
+		} else {
+			conditions.MarkTrue(m, clusterv1.InstanceNotTerminatingCondition)
+		}
+		if deleting {
+			m.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			m.Finalizers = []string{clusterv1.MachineFinalizer}
+		}
+		return m
+	}
+	ms := &clusterv1.MachineSet{ObjectMeta: metav1.ObjectMeta{Name: "ms", Namespace: metav1.NamespaceDefault}}
+
+	tests := []struct {
+		name        string
+		machines    []*clusterv1.Machine
+		wantDeleted []string
+	}{
+		{
+			name: "does nothing without termination notices",
+			machines: []*clusterv1.Machine{
+				newMachine("running", 0, false),
+				{ObjectMeta: metav1.ObjectMeta{Name: "no-condition", Namespace: metav1.NamespaceDefault}},
+			},
+		},
+		{
+			name: "deletes all machines with a termination notice",
+			machines: []*clusterv1.Machine{
+				newMachine("running", 0, false),
+				newMachine("terminating-1", time.Minute, false),
+				newMachine("terminating-2", time.Second, false),
+			},
+			wantDeleted: []string{"terminating-1", "terminating-2"},
+		},
+		{
+			name: "skips machines already being deleted",
+			machines: []*clusterv1.Machine{
+				newMachine("deleting", time.Hour, true),
+				newMachine("terminating", time.Minute, false),
+			},
+			wantDeleted: []string{"terminating"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			objs := []client.Object{ms}
+			for _, m := range tt.machines {
+				objs = append(objs, m)
+			}
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+			r := &MachineSetReconciler{Client: c, recorder: record.NewFakeRecorder(32)}
+
+			g.Expect(r.reconcileTerminationNotices(ctx, ms, tt.machines)).To(Succeed())
+
+			var deleted []string
+			for _, m := range tt.machines {
+				if !m.DeletionTimestamp.IsZero() {
+					continue
+				}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(m), &clusterv1.Machine{}); apierrors.IsNotFound(err) {
+					deleted = append(deleted, m.Name)
+				}
+			}
+			g.Expect(deleted).To(ConsistOf(tt.wantDeleted))
+		})
+	}
+}
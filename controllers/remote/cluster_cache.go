@@ -17,6 +17,7 @@ limitations under the License.
 package remote
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sync"
@@ -48,9 +49,13 @@ const (
 	healthCheckPollInterval       = 10 * time.Second
 	healthCheckRequestTimeout     = 5 * time.Second
 	healthCheckUnhealthyThreshold = 10
+	kubeconfigCheckInterval       = 5 * time.Minute
 	clusterCacheControllerName    = "cluster-cache-tracker"
 )
 
+// errKubeconfigChanged signals that the Kubeconfig of a cluster has changed, and the clusterAccessor must be recreated.
+var errKubeconfigChanged = errors.New("kubeconfig has changed")
+
 // ClusterCacheTracker manages client caches for workload clusters.
 type ClusterCacheTracker struct {
 	log                   logr.Logger
@@ -306,6 +311,10 @@ type healthCheckInput struct {
 	requestTimeout     time.Duration
 	unhealthyThreshold int
 	path               string
+
+	// kubeconfigCheckInterval is the interval at which the Kubeconfig of the cluster is checked for changes,
+	// e.g. for the client certificate being rotated.
+	kubeconfigCheckInterval time.Duration
 }
 
 // setDefaults sets default values if optional parameters are not set.
@@ -322,16 +331,22 @@ func (h *healthCheckInput) setDefaults() {
 	if h.path == "" {
 		h.path = "/"
 	}
+	if h.kubeconfigCheckInterval == 0 {
+		h.kubeconfigCheckInterval = kubeconfigCheckInterval
+	}
 }
 
 // healthCheckCluster will poll the cluster's API at the path given and, if there are
 // `unhealthyThreshold` consecutive failures, will deem the cluster unhealthy.
 // Once the cluster is deemed unhealthy, the cluster's cache is stopped and removed.
+// The cluster's cache is also stopped and removed when the cluster's Kubeconfig changes, e.g. because
+// the client certificate has been rotated, so a new one using the current Kubeconfig is created on the next access.
 func (t *ClusterCacheTracker) healthCheckCluster(ctx context.Context, in *healthCheckInput) {
 	// populate optional params for healthCheckInput
 	in.setDefaults()
 
 	unhealthyCount := 0
+	lastKubeconfigCheck := time.Now()
 
 	// This gets us a client that can make raw http(s) calls to the remote apiserver. We only need to create it once
 	// and we can reuse it inside the polling loop.
@@ -366,6 +381,14 @@ func (t *ClusterCacheTracker) healthCheckCluster(ctx context.Context, in *health
 			return true, nil
 		}
 
+		if time.Since(lastKubeconfigCheck) >= in.kubeconfigCheckInterval {
+			lastKubeconfigCheck = time.Now()
+			// Errors are ignored here; failing to read the Kubeconfig should not affect the current clusterAccessor.
+			if cfg, err := RESTConfig(ctx, clusterCacheControllerName, t.client, in.cluster); err == nil && restConfigChanged(in.cfg, cfg) {
+				return false, errKubeconfigChanged
+			}
+		}
+
 		// An error here means there was either an issue connecting or the API returned an error.
 		// If no error occurs, reset the unhealthy counter.
 		_, err := restClient.Get().AbsPath(in.path).Timeout(in.requestTimeout).DoRaw(ctx)
@@ -388,8 +411,22 @@ func (t *ClusterCacheTracker) healthCheckCluster(ctx context.Context, in *health
 	// times for the cluster to be considered unhealthy
 	// NB. we are ignoring ErrWaitTimeout because this error happens when the channel is close, that in this case
 	// happens when the cache is explicitly stopped.
+	if err == errKubeconfigChanged {
+		t.log.V(2).Info("Kubeconfig has changed, the clusterAccessor will be recreated on next access", "cluster", in.cluster.String())
+		t.deleteAccessor(in.cluster)
+		return
+	}
 	if err != nil && err != wait.ErrWaitTimeout {
 		t.log.Error(err, "Error health checking cluster", "cluster", in.cluster.String())
 		t.deleteAccessor(in.cluster)
 	}
 }
+
+// restConfigChanged returns true if the endpoint or the credentials of the given rest configs differ.
+func restConfigChanged(old, new *rest.Config) bool {
+	return old.Host != new.Host ||
+		old.BearerToken != new.BearerToken ||
+		!bytes.Equal(old.CAData, new.CAData) ||
+		!bytes.Equal(old.CertData, new.CertData) ||
+		!bytes.Equal(old.KeyData, new.KeyData)
+}
@@ -195,3 +195,55 @@ func TestClusterCacheHealthCheck(t *testing.T) {
 		})
 	})
 }
+
+func TestRestConfigChanged(t *testing.T) {
+	config := &rest.Config{
+		Host: "https://127.0.0.1:6443",
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData:   []byte("ca"),
+			CertData: []byte("cert"),
+			KeyData:  []byte("key"),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*rest.Config)
+		want   bool
+	}{
+		{
+			name:   "unchanged config",
+			mutate: func(*rest.Config) {},
+			want:   false,
+		},
+		{
+			name:   "rotated client certificate",
+			mutate: func(c *rest.Config) { c.CertData = []byte("new-cert"); c.KeyData = []byte("new-key") },
+			want:   true,
+		},
+		{
+			name:   "rotated CA",
+			mutate: func(c *rest.Config) { c.CAData = []byte("new-ca") },
+			want:   true,
+		},
+		{
+			name:   "changed endpoint",
+			mutate: func(c *rest.Config) { c.Host = "https://127.0.0.1:7443" },
+			want:   true,
+		},
+		{
+			name:   "changed user agent",
+			mutate: func(c *rest.Config) { c.UserAgent = "test" },
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			newConfig := rest.CopyConfig(config)
+			tt.mutate(newConfig)
+			g.Expect(restConfigChanged(config, newConfig)).To(Equal(tt.want))
+		})
+	}
+}
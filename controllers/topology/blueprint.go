@@ -20,9 +20,13 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/topology/inheritance"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -34,6 +38,7 @@ func (r *ClusterReconciler) getBlueprint(ctx context.Context, cluster *clusterv1
 		Topology:           cluster.Spec.Topology,
 		ClusterClass:       &clusterv1.ClusterClass{},
 		MachineDeployments: map[string]*scope.MachineDeploymentBlueprint{},
+		MachinePools:       map[string]*scope.MachinePoolBlueprint{},
 	}
 
 	// Get ClusterClass.
@@ -42,7 +47,34 @@ func (r *ClusterReconciler) getBlueprint(ctx context.Context, cluster *clusterv1
 		return nil, errors.Wrapf(err, "failed to retrieve ClusterClass/%s", cluster.Spec.Topology.Class)
 	}
 
-	var err error
+	// Compose the ClusterClass with the ClusterClasses it inherits from, if any.
+	chain, err := inheritance.Chain(ctx, r.Client, blueprint.ClusterClass)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to resolve the ClusterClasses inherited by ClusterClass/%s", cluster.Spec.Topology.Class)
+	}
+
+	// Use the cached blueprint, if the ClusterClass and the ClusterClasses it inherits from did not change since it was cached.
+	uid := blueprint.ClusterClass.UID
+	blueprint.ClusterClassKey = clusterClassKey(chain)
+	if r.blueprintCache != nil && blueprint.ClusterClassKey != "" {
+		if cached, ok := r.blueprintCache.Get(uid, blueprint.ClusterClassKey); ok {
+			unchanged, err := r.blueprintTemplatesUnchanged(ctx, cached)
+			if err != nil {
+				return nil, err
+			}
+			if unchanged {
+				cached.Topology = cluster.Spec.Topology
+				return cached, nil
+			}
+		}
+		defer func() {
+			if reterr == nil {
+				r.blueprintCache.Add(uid, blueprint)
+			}
+		}()
+	}
+	blueprint.ClusterClass = inheritance.ResolveChain(chain)
+
 	// Get ClusterClass.spec.infrastructure.
 	blueprint.InfrastructureClusterTemplate, err = r.getReference(ctx, blueprint.ClusterClass.Spec.Infrastructure.Ref)
 	if err != nil {
@@ -50,7 +82,9 @@ func (r *ClusterReconciler) getBlueprint(ctx context.Context, cluster *clusterv1
 	}
 
 	// Get ClusterClass.spec.controlPlane.
-	blueprint.ControlPlane = &scope.ControlPlaneBlueprint{}
+	blueprint.ControlPlane = &scope.ControlPlaneBlueprint{
+		MachineHealthCheck: blueprint.ClusterClass.Spec.ControlPlane.MachineHealthCheck,
+	}
 	blueprint.ControlPlane.Template, err = r.getReference(ctx, blueprint.ClusterClass.Spec.ControlPlane.Ref)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get control plane template for %s", tlog.KObj{Obj: blueprint.ClusterClass})
@@ -73,6 +107,8 @@ func (r *ClusterReconciler) getBlueprint(ctx context.Context, cluster *clusterv1
 		// with the additional metadata defined in the Cluster's topology section
 		// for the MachineDeployment that is created or updated.
 		machineDeploymentClass.Template.Metadata.DeepCopyInto(&machineDeploymentBlueprint.Metadata)
+		machineDeploymentBlueprint.EnabledIf = machineDeploymentClass.EnabledIf
+		machineDeploymentBlueprint.MachineHealthCheck = machineDeploymentClass.MachineHealthCheck
 
 		// Get the infrastructure machine template.
 		machineDeploymentBlueprint.InfrastructureMachineTemplate, err = r.getReference(ctx, machineDeploymentClass.Template.Infrastructure.Ref)
@@ -89,5 +125,73 @@ func (r *ClusterReconciler) getBlueprint(ctx context.Context, cluster *clusterv1
 		blueprint.MachineDeployments[machineDeploymentClass.Class] = machineDeploymentBlueprint
 	}
 
+	// Loop over the machine pool classes in ClusterClass and fetch the related templates.
+	// NOTE: MachinePools are supported only if the MachinePool feature flag is enabled.
+	if feature.Gates.Enabled(feature.MachinePool) {
+		for _, machinePoolClass := range blueprint.ClusterClass.Spec.Workers.MachinePools {
+			machinePoolBlueprint := &scope.MachinePoolBlueprint{}
+
+			// Make sure to copy the metadata from the blueprint, which is later layered
+			// with the additional metadata defined in the Cluster's topology section
+			// for the MachinePool that is created or updated.
+			machinePoolClass.Template.Metadata.DeepCopyInto(&machinePoolBlueprint.Metadata)
+
+			// Get the infrastructure machine pool template.
+			machinePoolBlueprint.InfrastructureMachinePoolTemplate, err = r.getReference(ctx, machinePoolClass.Template.Infrastructure.Ref)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get infrastructure machine pool template for %s, MachinePool class %q", tlog.KObj{Obj: blueprint.ClusterClass}, machinePoolClass.Class)
+			}
+
+			// Get the bootstrap config template.
+			machinePoolBlueprint.BootstrapTemplate, err = r.getReference(ctx, machinePoolClass.Template.Bootstrap.Ref)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get bootstrap config template for %s, MachinePool class %q", tlog.KObj{Obj: blueprint.ClusterClass}, machinePoolClass.Class)
+			}
+
+			blueprint.MachinePools[machinePoolClass.Class] = machinePoolBlueprint
+		}
+	}
+
 	return blueprint, nil
 }
+
+// blueprintTemplatesUnchanged returns true if none of the templates of a cached ClusterBlueprint has been changed or
+// deleted since the blueprint was cached, comparing their resourceVersions with the ones of the current templates.
+func (r *ClusterReconciler) blueprintTemplatesUnchanged(ctx context.Context, blueprint *scope.ClusterBlueprint) (bool, error) {
+	for _, template := range blueprintTemplates(blueprint) {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(template.GroupVersionKind())
+		if err := r.UnstructuredCachingClient.Get(ctx, client.ObjectKeyFromObject(template), current); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, errors.Wrapf(err, "failed to retrieve %s %q in namespace %q", template.GetKind(), template.GetName(), template.GetNamespace())
+		}
+		if current.GetResourceVersion() != template.GetResourceVersion() {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// blueprintTemplates returns all the templates referenced by a ClusterBlueprint.
+func blueprintTemplates(blueprint *scope.ClusterBlueprint) []*unstructured.Unstructured {
+	templates := []*unstructured.Unstructured{blueprint.InfrastructureClusterTemplate}
+	if blueprint.ControlPlane != nil {
+		templates = append(templates, blueprint.ControlPlane.Template, blueprint.ControlPlane.InfrastructureMachineTemplate)
+	}
+	for _, machineDeployment := range blueprint.MachineDeployments {
+		templates = append(templates, machineDeployment.InfrastructureMachineTemplate, machineDeployment.BootstrapTemplate)
+	}
+	for _, machinePool := range blueprint.MachinePools {
+		templates = append(templates, machinePool.InfrastructureMachinePoolTemplate, machinePool.BootstrapTemplate)
+	}
+
+	nonNil := make([]*unstructured.Unstructured, 0, len(templates))
+	for _, template := range templates {
+		if template != nil {
+			nonNil = append(nonNil, template)
+		}
+	}
+	return nonNil
+}
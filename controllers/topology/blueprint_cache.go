@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+)
+
+// blueprintCache caches the part of the ClusterBlueprints derived from a ClusterClass, i.e. the resolved ClusterClass
+// and the referenced templates, so Clusters using the same ClusterClass do not fetch and convert the same templates
+// on every reconcile.
+// NOTE: Entries are valid only for the generation of the ClusterClass, and of the ClusterClasses it inherits from,
+// they were computed for; getBlueprint additionally checks that the resourceVersions of the cached templates did not
+// change before using an entry. Entries are evicted when the ClusterClass, or one it inherits from, is deleted.
+// NOTE: Cached blueprints are shared across reconciles and must be treated as read-only.
+type blueprintCache struct {
+	lock sync.RWMutex
+	// entries are keyed by ClusterClass UID, so there is at most one entry for each ClusterClass.
+	entries map[types.UID]*scope.ClusterBlueprint
+}
+
+func newBlueprintCache() *blueprintCache {
+	return &blueprintCache{
+		entries: map[types.UID]*scope.ClusterBlueprint{},
+	}
+}
+
+// Get returns a copy of the cached blueprint for the ClusterClass with the given UID and key, if any.
+// NOTE: The copy shares the ClusterClass and the templates with the cached blueprint, and has no Topology set.
+func (c *blueprintCache) Get(uid types.UID, clusterClassKey string) (*scope.ClusterBlueprint, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	cached, ok := c.entries[uid]
+	if !ok || cached.ClusterClassKey != clusterClassKey {
+		return nil, false
+	}
+	blueprint := *cached
+	return &blueprint, true
+}
+
+// Add adds a blueprint to the cache, replacing any blueprint cached for a previous generation of the same ClusterClass.
+func (c *blueprintCache) Add(uid types.UID, blueprint *scope.ClusterBlueprint) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	cached := *blueprint
+	cached.Topology = nil
+	c.entries[uid] = &cached
+}
+
+// Delete evicts the blueprints cached for the ClusterClass with the given UID and for the ClusterClasses inheriting from it.
+func (c *blueprintCache) Delete(uid types.UID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for entryUID, cached := range c.entries {
+		if entryUID == uid || clusterClassKeyContains(cached.ClusterClassKey, uid) {
+			delete(c.entries, entryUID)
+		}
+	}
+}
+
+// clusterClassKey returns a key identifying the generations of a chain of ClusterClasses as returned by inheritance.Chain;
+// it returns an empty key if any of the ClusterClasses has no UID, e.g. because it was not read from the API server.
+func clusterClassKey(chain []*clusterv1.ClusterClass) string {
+	parts := make([]string, 0, len(chain))
+	for _, clusterClass := range chain {
+		if clusterClass.UID == "" {
+			return ""
+		}
+		parts = append(parts, fmt.Sprintf("%s/%d", clusterClass.UID, clusterClass.Generation))
+	}
+	return strings.Join(parts, ",")
+}
+
+// clusterClassKeyContains returns true if the key returned by clusterClassKey includes the ClusterClass with the given UID.
+func clusterClassKeyContains(key string, uid types.UID) bool {
+	for _, part := range strings.Split(key, ",") {
+		if strings.HasPrefix(part, string(uid)+"/") {
+			return true
+		}
+	}
+	return false
+}
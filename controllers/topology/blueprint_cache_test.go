@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+)
+
+func TestBlueprintCache(t *testing.T) {
+	g := NewWithT(t)
+
+	c := newBlueprintCache()
+
+	_, ok := c.Get("uid", "uid/1")
+	g.Expect(ok).To(BeFalse())
+
+	blueprint := &scope.ClusterBlueprint{
+		Topology:        &clusterv1.Topology{Class: "class1"},
+		ClusterClass:    &clusterv1.ClusterClass{},
+		ClusterClassKey: "uid/1",
+	}
+	c.Add("uid", blueprint)
+
+	got, ok := c.Get("uid", "uid/1")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(got).NotTo(BeIdenticalTo(blueprint))
+	g.Expect(got.ClusterClass).To(BeIdenticalTo(blueprint.ClusterClass))
+	g.Expect(got.Topology).To(BeNil())
+
+	// A blueprint cached for another generation is not returned.
+	_, ok = c.Get("uid", "uid/2")
+	g.Expect(ok).To(BeFalse())
+
+	// Adding a blueprint for a new generation replaces the previous one.
+	c.Add("uid", &scope.ClusterBlueprint{ClusterClassKey: "uid/2"})
+	_, ok = c.Get("uid", "uid/1")
+	g.Expect(ok).To(BeFalse())
+	_, ok = c.Get("uid", "uid/2")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(c.entries).To(HaveLen(1))
+}
+
+func TestBlueprintCacheDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	c := newBlueprintCache()
+	c.Add("parent", &scope.ClusterBlueprint{ClusterClassKey: "parent/1"})
+	c.Add("child", &scope.ClusterBlueprint{ClusterClassKey: "child/1,parent/1"})
+	c.Add("other", &scope.ClusterBlueprint{ClusterClassKey: "other/1"})
+
+	// Deleting a ClusterClass evicts its blueprint and the blueprints of the ClusterClasses inheriting from it.
+	c.Delete("parent")
+	g.Expect(c.entries).To(HaveLen(1))
+	_, ok := c.Get("other", "other/1")
+	g.Expect(ok).To(BeTrue())
+
+	c.Delete("other")
+	g.Expect(c.entries).To(BeEmpty())
+}
+
+func TestClusterClassKey(t *testing.T) {
+	newClusterClass := func(uid string, generation int64) *clusterv1.ClusterClass {
+		return &clusterv1.ClusterClass{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), Generation: generation},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		chain []*clusterv1.ClusterClass
+		want  string
+	}{
+		{
+			name:  "ClusterClass not inheriting from other ClusterClasses",
+			chain: []*clusterv1.ClusterClass{newClusterClass("a", 1)},
+			want:  "a/1",
+		},
+		{
+			name:  "ClusterClass inheriting from other ClusterClasses",
+			chain: []*clusterv1.ClusterClass{newClusterClass("a", 1), newClusterClass("b", 3), newClusterClass("c", 2)},
+			want:  "a/1,b/3,c/2",
+		},
+		{
+			name:  "ClusterClass without UID",
+			chain: []*clusterv1.ClusterClass{newClusterClass("a", 1), newClusterClass("", 3)},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(clusterClassKey(tt.chain)).To(Equal(tt.want))
+		})
+	}
+}
@@ -23,8 +23,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	. "sigs.k8s.io/cluster-api/internal/matchers"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -299,3 +299,78 @@ func TestGetBlueprint(t *testing.T) {
 		})
 	}
 }
+
+func TestGetBlueprintWithCache(t *testing.T) {
+	g := NewWithT(t)
+
+	infraClusterTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infraclustertemplate1").
+		Build()
+	controlPlaneTemplate := builder.ControlPlaneTemplate(metav1.NamespaceDefault, "controlplanetemplate1").
+		Build()
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithInfrastructureClusterTemplate(infraClusterTemplate).
+		WithControlPlaneTemplate(controlPlaneTemplate).
+		Build()
+	clusterClass.UID = "class1-uid"
+	clusterClass.Generation = 1
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+	cluster.Spec.Topology = &clusterv1.Topology{
+		Class: clusterClass.Name,
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(fakeScheme).
+		WithObjects(
+			builder.GenericInfrastructureClusterTemplateCRD,
+			builder.GenericControlPlaneTemplateCRD,
+			clusterClass,
+			infraClusterTemplate,
+			controlPlaneTemplate,
+		).
+		Build()
+	r := &ClusterReconciler{
+		Client:                    fakeClient,
+		UnstructuredCachingClient: fakeClient,
+		blueprintCache:            newBlueprintCache(),
+	}
+
+	got, err := r.getBlueprint(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.ClusterClassKey).To(Equal("class1-uid/1"))
+
+	// The blueprint is read from the cache if neither the ClusterClass nor the templates changed.
+	cached, err := r.getBlueprint(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(cached.Topology).To(Equal(cluster.Spec.Topology))
+	g.Expect(cached.ClusterClass).To(BeIdenticalTo(got.ClusterClass))
+
+	// Change a template; the blueprint is read again because the resourceVersion of the template changed.
+	currentInfraClusterTemplate := infraClusterTemplate.DeepCopy()
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(infraClusterTemplate), currentInfraClusterTemplate)).To(Succeed())
+	currentInfraClusterTemplate.SetLabels(map[string]string{"foo": "bar"})
+	g.Expect(fakeClient.Update(ctx, currentInfraClusterTemplate)).To(Succeed())
+
+	got, err = r.getBlueprint(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.ClusterClass).NotTo(BeIdenticalTo(cached.ClusterClass))
+	g.Expect(got.InfrastructureClusterTemplate.GetLabels()).To(HaveKeyWithValue("foo", "bar"))
+
+	// Bump the generation of the ClusterClass; the cached blueprint is not used anymore.
+	currentClusterClass := &clusterv1.ClusterClass{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(clusterClass), currentClusterClass)).To(Succeed())
+	currentClusterClass.Generation = 2
+	g.Expect(fakeClient.Update(ctx, currentClusterClass)).To(Succeed())
+
+	cached = got
+	got, err = r.getBlueprint(ctx, cluster)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.ClusterClassKey).To(Equal("class1-uid/2"))
+	g.Expect(got.ClusterClass).NotTo(BeIdenticalTo(cached.ClusterClass))
+
+	// Delete a template; the cached blueprint is not used anymore, so reading the deleted template fails.
+	g.Expect(fakeClient.Delete(ctx, infraClusterTemplate)).To(Succeed())
+
+	_, err = r.getBlueprint(ctx, cluster)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -21,19 +21,28 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/api/v1beta1/index"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/patches"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/topology/inheritance"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -42,6 +51,8 @@ import (
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclasses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinehealthchecks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // ClusterReconciler reconciles a managed topology for a Cluster object.
@@ -59,23 +70,41 @@ type ClusterReconciler struct {
 	UnstructuredCachingClient client.Client
 
 	externalTracker external.ObjectTracker
+	recorder        record.EventRecorder
 
 	// patchEngine is used to apply patches during computeDesiredState.
 	patchEngine patches.Engine
+
+	// blueprintCache caches the ClusterClass and the referenced templates across reconciles.
+	blueprintCache *blueprintCache
 }
 
 func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
-	c, err := ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1.Cluster{}).
 		Named("topology/cluster").
 		Watches(
 			&source.Kind{Type: &clusterv1.ClusterClass{}},
 			handler.EnqueueRequestsFromMapFunc(r.clusterClassToCluster),
 		).
+		Watches(
+			&source.Kind{Type: &clusterv1.ClusterClass{}},
+			handler.Funcs{DeleteFunc: r.clusterClassDeleted},
+		).
 		Watches(
 			&source.Kind{Type: &clusterv1.MachineDeployment{}},
 			handler.EnqueueRequestsFromMapFunc(r.machineDeploymentToCluster),
-		).
+		)
+
+	// MachinePools are supported only if the MachinePool feature flag is enabled.
+	if feature.Gates.Enabled(feature.MachinePool) {
+		b = b.Watches(
+			&source.Kind{Type: &expv1.MachinePool{}},
+			handler.EnqueueRequestsFromMapFunc(r.machinePoolToCluster),
+		)
+	}
+
+	c, err := b.
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Build(r)
@@ -88,10 +117,19 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		Controller: c,
 	}
 	r.patchEngine = patches.NewEngine()
+	r.blueprintCache = newBlueprintCache()
+	r.recorder = mgr.GetEventRecorderFor("topology/cluster")
 
 	return nil
 }
 
+// SetupForDryRun prepares the ClusterReconciler for a dry run execution, e.g. by clusterctl alpha topology plan;
+// no watches are created, and events are recorded using the given recorder.
+func (r *ClusterReconciler) SetupForDryRun(recorder record.EventRecorder) {
+	r.patchEngine = patches.NewEngine()
+	r.recorder = recorder
+}
+
 func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -122,8 +160,6 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 		return ctrl.Result{}, nil
 	}
 
-	// TODO: Add patching as soon as we define how to report managed topology state into conditions
-
 	// In case the object is deleted, the managed topology stops to reconcile;
 	// (the other controllers will take care of deletion).
 	if !cluster.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -132,12 +168,30 @@ func (r *ClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_
 		return ctrl.Result{}, nil
 	}
 
+	patchHelper, err := patch.NewHelper(cluster, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Create a scope initialized with only the cluster; during reconcile
 	// additional information will be added about the Cluster blueprint, current state and desired state.
-	scope := scope.New(cluster)
+	s := scope.New(cluster)
+
+	defer func() {
+		if err := r.reconcileTopologyReconciledCondition(s, cluster, reterr); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, errors.Wrap(err, "failed to reconcile the TopologyReconciled condition")})
+			return
+		}
+		options := []patch.Option{
+			patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{clusterv1.TopologyReconciledCondition}},
+		}
+		if err := patchHelper.Patch(ctx, cluster, options...); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
 
 	// Handle normal reconciliation loop.
-	return r.reconcile(ctx, scope)
+	return r.reconcile(ctx, s)
 }
 
 // reconcile handles cluster reconciliation.
@@ -176,6 +230,16 @@ func (r *ClusterReconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl
 	return ctrl.Result{}, nil
 }
 
+// recordReconcileFailure records a Warning event on the Cluster when reconciling the topology fails,
+// using a distinct reason when the API server rejected one of the objects of the topology.
+func (r *ClusterReconciler) recordReconcileFailure(cluster *clusterv1.Cluster, err error) {
+	reason := reconcileFailedEventReason
+	if apierrors.IsInvalid(err) || apierrors.IsForbidden(err) || apierrors.IsBadRequest(err) {
+		reason = admissionRejectedEventReason
+	}
+	recordEvent(r.recorder, cluster, corev1.EventTypeWarning, reason, "%v", err)
+}
+
 // setupDynamicWatches create watches for InfrastructureCluster and ControlPlane CRs when they exist.
 func (r *ClusterReconciler) setupDynamicWatches(ctx context.Context, s *scope.Scope) error {
 	if s.Current.InfrastructureCluster != nil {
@@ -193,29 +257,47 @@ func (r *ClusterReconciler) setupDynamicWatches(ctx context.Context, s *scope.Sc
 	return nil
 }
 
+// clusterClassDeleted evicts the blueprint and the patch generators cached for a deleted ClusterClass.
+func (r *ClusterReconciler) clusterClassDeleted(e event.DeleteEvent, _ workqueue.RateLimitingInterface) {
+	if r.blueprintCache != nil {
+		r.blueprintCache.Delete(e.Object.GetUID())
+	}
+	if r.patchEngine != nil {
+		r.patchEngine.Evict(e.Object.GetUID())
+	}
+}
+
 // clusterClassToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
-// for Cluster to update when its own ClusterClass gets updated.
+// for Cluster to update when its own ClusterClass, or a ClusterClass it inherits from, gets updated.
 func (r *ClusterReconciler) clusterClassToCluster(o client.Object) []ctrl.Request {
 	clusterClass, ok := o.(*clusterv1.ClusterClass)
 	if !ok {
 		panic(fmt.Sprintf("Expected a ClusterClass but got a %T", o))
 	}
 
-	clusterList := &clusterv1.ClusterList{}
-	if err := r.Client.List(
-		context.TODO(),
-		clusterList,
-		client.MatchingFields{index.ClusterClassNameField: clusterClass.Name},
-		client.InNamespace(clusterClass.Namespace),
-	); err != nil {
+	clusterClassList := &clusterv1.ClusterClassList{}
+	if err := r.Client.List(context.TODO(), clusterClassList, client.InNamespace(clusterClass.Namespace)); err != nil {
 		return nil
 	}
+	clusterClassNames := inheritance.InheritingClusterClasses(clusterClassList.Items, clusterClass.Name).Insert(clusterClass.Name)
 
 	// There can be more than one cluster using the same cluster class.
 	// create a request for each of the clusters.
 	requests := []ctrl.Request{}
-	for i := range clusterList.Items {
-		requests = append(requests, ctrl.Request{NamespacedName: util.ObjectKey(&clusterList.Items[i])})
+	for _, clusterClassName := range clusterClassNames.List() {
+		clusterList := &clusterv1.ClusterList{}
+		if err := r.Client.List(
+			context.TODO(),
+			clusterList,
+			client.MatchingFields{index.ClusterClassNameField: clusterClassName},
+			client.InNamespace(clusterClass.Namespace),
+		); err != nil {
+			return nil
+		}
+
+		for i := range clusterList.Items {
+			requests = append(requests, ctrl.Request{NamespacedName: util.ObjectKey(&clusterList.Items[i])})
+		}
 	}
 	return requests
 }
@@ -238,3 +320,22 @@ func (r *ClusterReconciler) machineDeploymentToCluster(o client.Object) []ctrl.R
 		},
 	}}
 }
+
+// machinePoolToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for Cluster to update when one of its own MachinePools gets updated.
+func (r *ClusterReconciler) machinePoolToCluster(o client.Object) []ctrl.Request {
+	mp, ok := o.(*expv1.MachinePool)
+	if !ok {
+		panic(fmt.Sprintf("Expected a MachinePool but got a %T", o))
+	}
+	if mp.Spec.ClusterName == "" {
+		return nil
+	}
+
+	return []ctrl.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: mp.Namespace,
+			Name:      mp.Spec.ClusterName,
+		},
+	}}
+}
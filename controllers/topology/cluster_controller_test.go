@@ -22,14 +22,19 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/contract"
 	"sigs.k8s.io/cluster-api/feature"
-	"sigs.k8s.io/cluster-api/internal/builder"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -654,3 +659,28 @@ func referenceExistsWithCorrectKindAndAPIVersion(reference *corev1.ObjectReferen
 	}
 	return nil
 }
+
+func TestClusterReconciler_recordReconcileFailure(t *testing.T) {
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+
+	t.Run("records a TopologyAdmissionRejected event when an object of the topology is rejected", func(t *testing.T) {
+		g := NewWithT(t)
+
+		recorder := record.NewFakeRecorder(10)
+		r := &ClusterReconciler{recorder: recorder}
+		rejection := apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("MachineDeployment").GroupKind(), "md", field.ErrorList{
+			field.Invalid(field.NewPath("spec", "replicas"), -1, "must be greater than or equal to 0"),
+		})
+		r.recordReconcileFailure(cluster, errors.Wrap(rejection, "failed to create MachineDeployment/md"))
+		g.Expect(<-recorder.Events).To(HavePrefix("Warning TopologyAdmissionRejected failed to create MachineDeployment/md"))
+	})
+
+	t.Run("records a TopologyReconcileFailed event for other errors", func(t *testing.T) {
+		g := NewWithT(t)
+
+		recorder := record.NewFakeRecorder(10)
+		r := &ClusterReconciler{recorder: recorder}
+		r.recordReconcileFailure(cluster, errors.New("connection refused"))
+		g.Expect(<-recorder.Events).To(Equal("Warning TopologyReconcileFailed connection refused"))
+	})
+}
@@ -19,6 +19,7 @@ package topology
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -27,8 +28,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/discovery"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/topology/inheritance"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
@@ -36,10 +41,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;update;patch
-// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclasses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusterclasses;clusterclasses/status,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch
 
 // ClusterClassReconciler reconciles the ClusterClass object.
@@ -52,12 +59,27 @@ type ClusterClassReconciler struct {
 	// UnstructuredCachingClient provides a client that forces caching of unstructured objects,
 	// thus allowing to optimize reads for templates or provider specific objects.
 	UnstructuredCachingClient client.Client
+
+	// VariablesDiscoveryURLs are the URLs of the DiscoverVariables hooks ClusterClasses are allowed to use,
+	// as registered by the administrator of the management cluster.
+	VariablesDiscoveryURLs []string
+
+	// variablesDiscoverer calls the DiscoverVariables hooks defined in ClusterClasses.
+	variablesDiscoverer discovery.Discoverer
 }
 
 func (r *ClusterClassReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.variablesDiscoverer == nil {
+		r.variablesDiscoverer = discovery.New(r.VariablesDiscoveryURLs)
+	}
+
 	err := ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1.ClusterClass{}).
 		Named("topology/clusterclass").
+		Watches(
+			&source.Kind{Type: &clusterv1.ClusterClass{}},
+			handler.EnqueueRequestsFromMapFunc(r.clusterClassToInheritingClusterClasses),
+		).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Complete(r)
@@ -96,7 +118,17 @@ func (r *ClusterClassReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	defer func() {
-		if err := patchHelper.Patch(ctx, clusterClass); err != nil {
+		// Patch ObservedGeneration only if the reconciliation completed successfully.
+		patchOpts := []patch.Option{
+			patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+				clusterv1.ClusterClassRefsResolvedCondition,
+				clusterv1.ClusterClassVariablesDiscoveredCondition,
+			}},
+		}
+		if reterr == nil {
+			patchOpts = append(patchOpts, patch.WithStatusObservedGeneration{})
+		}
+		if err := patchHelper.Patch(ctx, clusterClass, patchOpts...); err != nil {
 			reterr = kerrors.NewAggregate([]error{
 				reterr,
 				errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: clusterClass})},
@@ -108,6 +140,20 @@ func (r *ClusterClassReconciler) Reconcile(ctx context.Context, req ctrl.Request
 }
 
 func (r *ClusterClassReconciler) reconcile(ctx context.Context, clusterClass *clusterv1.ClusterClass) (ctrl.Result, error) {
+	// Report the variables which can be configured in the Cluster topology, including the ones
+	// defined in the ClusterClasses it inherits from.
+	resolvedClusterClass, err := inheritance.Resolve(ctx, r.Client, clusterClass)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to resolve the ClusterClasses inherited by %s", tlog.KObj{Obj: clusterClass})
+	}
+	errs := []error{}
+	discoveredVariables, err := r.discoverVariables(ctx, clusterClass, resolvedClusterClass)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	reconcileVariables(clusterClass, resolvedClusterClass, discoveredVariables)
+	reconcileExtensions(clusterClass, resolvedClusterClass)
+
 	// Collect all the reference from the ClusterClass to templates.
 	refs := []*corev1.ObjectReference{}
 
@@ -136,19 +182,146 @@ func (r *ClusterClassReconciler) reconcile(ctx context.Context, clusterClass *cl
 	// Nb. Some external objects can be referenced multiple times in the ClusterClass. We
 	// update the API contracts of all the references but we set the owner reference on the unique
 	// external object only once.
-	errs := []error{}
+	notFoundRefs := []string{}
 	patchedRefs := sets.NewString()
+	refErrs := []error{}
 	for i := range refs {
 		ref := refs[i]
 		uniqueKey := uniqueObjectRefKey(ref)
 		if err := r.reconcileExternal(ctx, clusterClass, ref, !patchedRefs.Has(uniqueKey)); err != nil {
-			errs = append(errs, err)
+			if apierrors.IsNotFound(errors.Cause(err)) {
+				notFoundRefs = append(notFoundRefs, tlog.KRef{Ref: ref}.String())
+			}
+			refErrs = append(refErrs, err)
 			continue
 		}
 		patchedRefs.Insert(uniqueKey)
 	}
 
-	return ctrl.Result{}, kerrors.NewAggregate(errs)
+	// Report whether all the referenced templates have been resolved, so users can tell if the ClusterClass
+	// is usable before creating Clusters.
+	switch {
+	case len(notFoundRefs) > 0:
+		conditions.MarkFalse(clusterClass, clusterv1.ClusterClassRefsResolvedCondition, clusterv1.TemplateNotFoundReason, clusterv1.ConditionSeverityError,
+			"Could not find referenced templates: %s", strings.Join(notFoundRefs, ", "))
+	case len(refErrs) > 0:
+		conditions.MarkFalse(clusterClass, clusterv1.ClusterClassRefsResolvedCondition, clusterv1.RefsResolveFailedReason, clusterv1.ConditionSeverityError,
+			"Failed to resolve referenced templates: %v", kerrors.NewAggregate(refErrs))
+	default:
+		conditions.MarkTrue(clusterClass, clusterv1.ClusterClassRefsResolvedCondition)
+	}
+
+	return ctrl.Result{}, kerrors.NewAggregate(append(errs, refErrs...))
+}
+
+// discoverVariables calls the DiscoverVariables hook of the ClusterClass, if any, and returns the discovered variables.
+// If the hook fails, the variables discovered previously are returned, so Clusters using the ClusterClass can still be
+// defaulted and validated, together with the error.
+func (r *ClusterClassReconciler) discoverVariables(ctx context.Context, clusterClass, resolvedClusterClass *clusterv1.ClusterClass) ([]clusterv1.ClusterClassVariable, error) {
+	// NOTE: Variables discovery is behind the ClusterClassVariablesDiscovery feature gate flag; hooks are not called
+	// in case the feature flag is disabled.
+	hook := resolvedClusterClass.Spec.VariablesDiscovery
+	if hook == nil || !feature.Gates.Enabled(feature.ClusterClassVariablesDiscovery) {
+		conditions.Delete(clusterClass, clusterv1.ClusterClassVariablesDiscoveredCondition)
+		return nil, nil
+	}
+
+	variables, err := r.variablesDiscoverer.DiscoverVariables(ctx, clusterClass, hook, resolvedClusterClass.Spec.Variables)
+	if err != nil {
+		conditions.MarkFalse(clusterClass, clusterv1.ClusterClassVariablesDiscoveredCondition, clusterv1.VariablesDiscoveryFailedReason, clusterv1.ConditionSeverityWarning,
+			"Failed to discover variables: %v", err)
+
+		var previous []clusterv1.ClusterClassVariable
+		for _, v := range clusterClass.Status.Variables {
+			if v.From == clusterv1.VariableDefinitionFromDiscovered {
+				previous = append(previous, clusterv1.ClusterClassVariable{Name: v.Name, Required: v.Required, Schema: *v.Schema.DeepCopy()})
+			}
+		}
+		return previous, errors.Wrapf(err, "failed to discover the variables of %s", tlog.KObj{Obj: clusterClass})
+	}
+
+	conditions.MarkTrue(clusterClass, clusterv1.ClusterClassVariablesDiscoveredCondition)
+	return variables, nil
+}
+
+// reconcileVariables sets the variables which can be configured in the Cluster topology in the ClusterClass status;
+// resolvedClusterClass is the ClusterClass composed with the ClusterClasses it inherits from, discoveredVariables are
+// the variables discovered via the DiscoverVariables hook, which are ignored if a variable with the same name is
+// defined in the ClusterClasses.
+func reconcileVariables(clusterClass, resolvedClusterClass *clusterv1.ClusterClass, discoveredVariables []clusterv1.ClusterClassVariable) {
+	inline := sets.NewString()
+	for _, v := range clusterClass.Spec.Variables {
+		inline.Insert(v.Name)
+	}
+
+	var variables []clusterv1.ClusterClassStatusVariable
+	for _, v := range resolvedClusterClass.Spec.Variables {
+		from := clusterv1.VariableDefinitionFromInherited
+		if inline.Has(v.Name) {
+			from = clusterv1.VariableDefinitionFromInline
+		}
+		variables = append(variables, clusterv1.ClusterClassStatusVariable{
+			Name:     v.Name,
+			From:     from,
+			Required: v.Required,
+			Schema:   *v.Schema.DeepCopy(),
+		})
+	}
+
+	defined := sets.NewString()
+	for _, v := range resolvedClusterClass.Spec.Variables {
+		defined.Insert(v.Name)
+	}
+	for _, v := range discoveredVariables {
+		if defined.Has(v.Name) {
+			continue
+		}
+		variables = append(variables, clusterv1.ClusterClassStatusVariable{
+			Name:     v.Name,
+			From:     clusterv1.VariableDefinitionFromDiscovered,
+			Required: v.Required,
+			Schema:   *v.Schema.DeepCopy(),
+		})
+	}
+	clusterClass.Status.Variables = variables
+}
+
+// reconcileExtensions sets the external extensions called for Clusters using the ClusterClass in the ClusterClass status;
+// resolvedClusterClass is the ClusterClass composed with the ClusterClasses it inherits from.
+func reconcileExtensions(clusterClass, resolvedClusterClass *clusterv1.ClusterClass) {
+	var extensions []clusterv1.ClusterClassStatusExtension
+	if hook := resolvedClusterClass.Spec.VariablesDiscovery; hook != nil {
+		from := clusterv1.VariableDefinitionFromInherited
+		if clusterClass.Spec.VariablesDiscovery != nil {
+			from = clusterv1.VariableDefinitionFromInline
+		}
+		extensions = append(extensions, clusterv1.ClusterClassStatusExtension{
+			Hook: clusterv1.DiscoverVariablesHook,
+			URL:  hook.URL,
+			From: from,
+		})
+	}
+	clusterClass.Status.Extensions = extensions
+}
+
+// clusterClassToInheritingClusterClasses is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for the ClusterClasses inheriting from a ClusterClass when it gets updated.
+func (r *ClusterClassReconciler) clusterClassToInheritingClusterClasses(o client.Object) []ctrl.Request {
+	clusterClass, ok := o.(*clusterv1.ClusterClass)
+	if !ok {
+		panic(fmt.Sprintf("Expected a ClusterClass but got a %T", o))
+	}
+
+	clusterClassList := &clusterv1.ClusterClassList{}
+	if err := r.Client.List(context.TODO(), clusterClassList, client.InNamespace(clusterClass.Namespace)); err != nil {
+		return nil
+	}
+
+	requests := []ctrl.Request{}
+	for _, name := range inheritance.InheritingClusterClasses(clusterClassList.Items, clusterClass.Name).List() {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: clusterClass.Namespace, Name: name}})
+	}
+	return requests
 }
 
 func (r *ClusterClassReconciler) reconcileExternal(ctx context.Context, clusterClass *clusterv1.ClusterClass, ref *corev1.ObjectReference, setOwnerRef bool) error {
@@ -29,8 +29,10 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
 	"sigs.k8s.io/cluster-api/feature"
-	"sigs.k8s.io/cluster-api/internal/builder"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func TestClusterClassReconciler_reconcile(t *testing.T) {
@@ -108,6 +110,9 @@ func TestClusterClassReconciler_reconcile(t *testing.T) {
 
 		g.Expect(assertMachineDeploymentClasses(ctx, actualClusterClass, ns)).Should(Succeed())
 
+		g.Expect(conditions.IsTrue(actualClusterClass, clusterv1.ClusterClassRefsResolvedCondition)).To(BeTrue())
+		g.Expect(actualClusterClass.Status.ObservedGeneration).To(Equal(actualClusterClass.Generation))
+
 		return nil
 	}, timeout).Should(Succeed())
 }
@@ -273,3 +278,250 @@ func ownerRefereceTo(obj client.Object) metav1.OwnerReference {
 		UID:        obj.GetUID(),
 	}
 }
+
+func TestClusterClassReconciler_reconcileStatus(t *testing.T) {
+	crds := []client.Object{
+		builder.GenericInfrastructureClusterTemplateCRD,
+		builder.GenericControlPlaneTemplateCRD,
+	}
+
+	infraClusterTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infraclustertemplate1").Build()
+	controlPlaneTemplate := builder.ControlPlaneTemplate(metav1.NamespaceDefault, "controlplanetemplate1").Build()
+
+	variable := clusterv1.ClusterClassVariable{
+		Name:     "cpu",
+		Required: true,
+		Schema: clusterv1.VariableSchema{
+			OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "integer"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		wantErr    bool
+		wantStatus corev1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "RefsResolved is true when all the templates exist",
+			objects:    []client.Object{infraClusterTemplate, controlPlaneTemplate},
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name:       "RefsResolved is false when a template does not exist",
+			objects:    []client.Object{infraClusterTemplate},
+			wantErr:    true,
+			wantStatus: corev1.ConditionFalse,
+			wantReason: clusterv1.TemplateNotFoundReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(infraClusterTemplate).
+				WithControlPlaneTemplate(controlPlaneTemplate).
+				Build()
+			clusterClass.Spec.Variables = []clusterv1.ClusterClassVariable{variable}
+
+			objs := []client.Object{}
+			objs = append(objs, crds...)
+			objs = append(objs, tt.objects...)
+			objs = append(objs, clusterClass)
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(objs...).
+				Build()
+
+			r := &ClusterClassReconciler{
+				Client:                    fakeClient,
+				UnstructuredCachingClient: fakeClient,
+			}
+			_, err := r.reconcile(ctx, clusterClass)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			g.Expect(clusterClass.Status.Variables).To(Equal([]clusterv1.ClusterClassStatusVariable{
+				{
+					Name:     variable.Name,
+					From:     clusterv1.VariableDefinitionFromInline,
+					Required: variable.Required,
+					Schema:   variable.Schema,
+				},
+			}))
+
+			condition := conditions.Get(clusterClass, clusterv1.ClusterClassRefsResolvedCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantStatus))
+			g.Expect(condition.Reason).To(Equal(tt.wantReason))
+		})
+	}
+}
+
+func TestReconcileVariables(t *testing.T) {
+	g := NewWithT(t)
+
+	variable := func(name string, required bool) clusterv1.ClusterClassVariable {
+		return clusterv1.ClusterClassVariable{Name: name, Required: required, Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"}}}
+	}
+	base := builder.ClusterClass(metav1.NamespaceDefault, "base").Build()
+	base.Spec.Variables = []clusterv1.ClusterClassVariable{variable("region", false), variable("flavor", false)}
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "derived").Build()
+	clusterClass.Spec.Inherits = base.Name
+	clusterClass.Spec.Variables = []clusterv1.ClusterClassVariable{variable("region", true)}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(base, clusterClass).Build()
+	r := &ClusterClassReconciler{
+		Client:                    fakeClient,
+		UnstructuredCachingClient: fakeClient,
+	}
+	_, _ = r.reconcile(ctx, clusterClass)
+
+	g.Expect(clusterClass.Status.Variables).To(Equal([]clusterv1.ClusterClassStatusVariable{
+		{
+			Name:     "region",
+			From:     clusterv1.VariableDefinitionFromInline,
+			Required: true,
+			Schema:   variable("region", true).Schema,
+		},
+		{
+			Name:     "flavor",
+			From:     clusterv1.VariableDefinitionFromInherited,
+			Required: false,
+			Schema:   variable("flavor", false).Schema,
+		},
+	}))
+}
+
+func TestReconcileExtensions(t *testing.T) {
+	g := NewWithT(t)
+
+	base := builder.ClusterClass(metav1.NamespaceDefault, "base").Build()
+	base.Spec.VariablesDiscovery = &clusterv1.VariablesDiscovery{URL: "https://discovery.example.com"}
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "derived").Build()
+	clusterClass.Spec.Inherits = base.Name
+
+	fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(base, clusterClass).Build()
+	r := &ClusterClassReconciler{
+		Client:                    fakeClient,
+		UnstructuredCachingClient: fakeClient,
+		variablesDiscoverer:       &fakeVariablesDiscoverer{},
+	}
+	_, _ = r.reconcile(ctx, clusterClass)
+
+	g.Expect(clusterClass.Status.Extensions).To(Equal([]clusterv1.ClusterClassStatusExtension{
+		{
+			Hook: clusterv1.DiscoverVariablesHook,
+			URL:  "https://discovery.example.com",
+			From: clusterv1.VariableDefinitionFromInherited,
+		},
+	}))
+}
+
+type fakeVariablesDiscoverer struct {
+	variables []clusterv1.ClusterClassVariable
+	err       error
+}
+
+func (d *fakeVariablesDiscoverer) DiscoverVariables(_ context.Context, _ *clusterv1.ClusterClass, _ *clusterv1.VariablesDiscovery, _ []clusterv1.ClusterClassVariable) ([]clusterv1.ClusterClassVariable, error) {
+	return d.variables, d.err
+}
+
+func TestReconcileDiscoveredVariables(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassVariablesDiscovery, true)()
+
+	variable := func(name string, required bool) clusterv1.ClusterClassVariable {
+		return clusterv1.ClusterClassVariable{Name: name, Required: required, Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"}}}
+	}
+	statusVariable := func(name, from string, required bool) clusterv1.ClusterClassStatusVariable {
+		return clusterv1.ClusterClassStatusVariable{Name: name, From: from, Required: required, Schema: variable(name, required).Schema}
+	}
+
+	tests := []struct {
+		name           string
+		discoverer     *fakeVariablesDiscoverer
+		previousStatus []clusterv1.ClusterClassStatusVariable
+		wantErr        bool
+		wantVariables  []clusterv1.ClusterClassStatusVariable
+		wantStatus     corev1.ConditionStatus
+	}{
+		{
+			name:       "discovered variables are merged with the inline variables, which take precedence",
+			discoverer: &fakeVariablesDiscoverer{variables: []clusterv1.ClusterClassVariable{variable("region", false), variable("instanceType", true)}},
+			wantVariables: []clusterv1.ClusterClassStatusVariable{
+				statusVariable("region", clusterv1.VariableDefinitionFromInline, true),
+				statusVariable("instanceType", clusterv1.VariableDefinitionFromDiscovered, true),
+			},
+			wantStatus: corev1.ConditionTrue,
+		},
+		{
+			name:       "previously discovered variables are preserved if the hook fails",
+			discoverer: &fakeVariablesDiscoverer{err: fmt.Errorf("connection refused")},
+			previousStatus: []clusterv1.ClusterClassStatusVariable{
+				statusVariable("region", clusterv1.VariableDefinitionFromInline, true),
+				statusVariable("instanceType", clusterv1.VariableDefinitionFromDiscovered, true),
+			},
+			wantErr: true,
+			wantVariables: []clusterv1.ClusterClassStatusVariable{
+				statusVariable("region", clusterv1.VariableDefinitionFromInline, true),
+				statusVariable("instanceType", clusterv1.VariableDefinitionFromDiscovered, true),
+			},
+			wantStatus: corev1.ConditionFalse,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class").Build()
+			clusterClass.Spec.Variables = []clusterv1.ClusterClassVariable{variable("region", true)}
+			clusterClass.Spec.VariablesDiscovery = &clusterv1.VariablesDiscovery{URL: "https://discovery.example.com"}
+			clusterClass.Status.Variables = tt.previousStatus
+
+			fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(clusterClass).Build()
+			r := &ClusterClassReconciler{
+				Client:                    fakeClient,
+				UnstructuredCachingClient: fakeClient,
+				variablesDiscoverer:       tt.discoverer,
+			}
+			_, err := r.reconcile(ctx, clusterClass)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+
+			g.Expect(clusterClass.Status.Variables).To(Equal(tt.wantVariables))
+			condition := conditions.Get(clusterClass, clusterv1.ClusterClassVariablesDiscoveredCondition)
+			g.Expect(condition).NotTo(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantStatus))
+		})
+	}
+}
+
+func TestReconcileDiscoveredVariablesFeatureGated(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class").Build()
+	clusterClass.Spec.VariablesDiscovery = &clusterv1.VariablesDiscovery{URL: "https://discovery.example.com"}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(clusterClass).Build()
+	r := &ClusterClassReconciler{
+		Client:                    fakeClient,
+		UnstructuredCachingClient: fakeClient,
+		variablesDiscoverer: &fakeVariablesDiscoverer{
+			variables: []clusterv1.ClusterClassVariable{{Name: "instanceType"}},
+		},
+	}
+	_, err := r.reconcile(ctx, clusterClass)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The hook is not called if the ClusterClassVariablesDiscovery feature gate is disabled.
+	g.Expect(clusterClass.Status.Variables).To(BeEmpty())
+	g.Expect(conditions.Has(clusterClass, clusterv1.ClusterClassVariablesDiscoveredCondition)).To(BeFalse())
+}
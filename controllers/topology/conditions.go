@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/contract"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileTopologyReconciledCondition sets the TopologyReconciled condition on the cluster.
+// The TopologyReconciled condition is considered true if spec of all the objects associated with the
+// cluster are in sync with the topology defined in the cluster.
+// The condition is false under the following conditions:
+// - An error occurred during the reconcile process of the cluster topology.
+// - The control plane or some of the MachineDeployments are not yet picking up the topology version,
+//   because the upgrade is on hold waiting for other objects to complete their upgrade or rollout.
+func (r *ClusterReconciler) reconcileTopologyReconciledCondition(s *scope.Scope, cluster *clusterv1.Cluster, reconcileErr error) error {
+	// If an error occurred during reconciliation set the TopologyReconciled condition to false.
+	// NOTE: The error is reported in a Warning event recorded on the Cluster, while the condition message is kept stable,
+	// so errors with changing details, e.g. resource versions, do not continuously change the Cluster object.
+	if reconcileErr != nil {
+		r.recordReconcileFailure(cluster, reconcileErr)
+		conditions.MarkFalse(
+			cluster,
+			clusterv1.TopologyReconciledCondition,
+			clusterv1.TopologyReconcileFailedReason,
+			clusterv1.ConditionSeverityError,
+			"Failed to reconcile the Cluster topology, see the Cluster events for details",
+		)
+		return nil
+	}
+
+	// If the upgrade of the control plane or of some MachineDeployments is on hold, set the TopologyReconciled
+	// condition to false, and report in the message what is pending and why.
+	if s.UpgradeTracker.ControlPlane.PendingUpgrade || s.UpgradeTracker.MachineDeployments.PendingUpgrade() {
+		message, err := pendingUpgradeMessage(s)
+		if err != nil {
+			return err
+		}
+		conditions.MarkFalse(
+			cluster,
+			clusterv1.TopologyReconciledCondition,
+			clusterv1.TopologyReconciledUpgradePendingReason,
+			clusterv1.ConditionSeverityInfo,
+			message,
+		)
+		return nil
+	}
+
+	// If there are no errors while reconciling and no upgrades pending, set the TopologyReconciled condition to true.
+	conditions.MarkTrue(cluster, clusterv1.TopologyReconciledCondition)
+	return nil
+}
+
+// pendingUpgradeMessage returns a human readable summary of the upgrades on hold, e.g.
+// "Control plane upgrade to v1.22.0 on hold. MachineDeployment(s) md1 are rolling out".
+func pendingUpgradeMessage(s *scope.Scope) (string, error) {
+	msgBuilder := &strings.Builder{}
+	var reason string
+
+	switch {
+	case s.UpgradeTracker.ControlPlane.PendingUpgrade:
+		fmt.Fprintf(msgBuilder, "Control plane upgrade to %s on hold.", s.Blueprint.Topology.Version)
+	default:
+		fmt.Fprintf(msgBuilder, "MachineDeployment(s) %s upgrade to version %s on hold.",
+			strings.Join(s.UpgradeTracker.MachineDeployments.PendingUpgradeNames(), ", "),
+			s.Blueprint.Topology.Version,
+		)
+	}
+
+	// Add the reason the upgrade is on hold; this mirrors the checks used when computing the desired versions.
+	if s.Current.ControlPlane != nil && s.Current.ControlPlane.Object != nil {
+		cpVersion, err := contract.ControlPlane().Version().Get(s.Current.ControlPlane.Object)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get the version from control plane spec")
+		}
+		cpUpgrading, err := contract.ControlPlane().IsUpgrading(s.Current.ControlPlane.Object)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to check if control plane is upgrading")
+		}
+		cpScaling := false
+		if s.Blueprint.Topology.ControlPlane.Replicas != nil {
+			cpScaling, err = contract.ControlPlane().IsScaling(s.Current.ControlPlane.Object)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to check if the control plane is scaling")
+			}
+		}
+
+		switch {
+		case cpUpgrading:
+			reason = fmt.Sprintf("Control plane is upgrading to version %s", *cpVersion)
+		case cpScaling:
+			reason = "Control plane is scaling"
+		case !s.UpgradeTracker.ControlPlane.PendingUpgrade && *cpVersion != s.Blueprint.Topology.Version:
+			reason = fmt.Sprintf("Control plane is going to be upgraded to version %s first", s.Blueprint.Topology.Version)
+		}
+	}
+	if reason == "" {
+		if rollingOut := s.Current.MachineDeployments.RollingOut(); len(rollingOut) > 0 {
+			reason = fmt.Sprintf("MachineDeployment(s) %s are rolling out", strings.Join(rollingOut, ", "))
+		}
+	}
+	if reason == "" && s.UpgradeTracker.MachineDeployments.PendingUpgrade() {
+		reason = "MachineDeployment(s) are upgraded one at a time"
+	}
+	if reason != "" {
+		fmt.Fprintf(msgBuilder, " %s", reason)
+	}
+
+	return msgBuilder.String(), nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package topology
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+)
+
+func TestReconcileTopologyReconciledCondition(t *testing.T) {
+	controlPlaneStable := builder.ControlPlane("test1", "cp1").
+		WithSpecFields(map[string]interface{}{
+			"spec.version":  "v1.2.2",
+			"spec.replicas": int64(2),
+		}).
+		WithStatusFields(map[string]interface{}{
+			"status.version":         "v1.2.2",
+			"status.replicas":        int64(2),
+			"status.updatedReplicas": int64(2),
+			"status.readyReplicas":   int64(2),
+		}).
+		Build()
+	controlPlaneUpgrading := builder.ControlPlane("test1", "cp1").
+		WithSpecFields(map[string]interface{}{
+			"spec.version":  "v1.2.2",
+			"spec.replicas": int64(2),
+		}).
+		WithStatusFields(map[string]interface{}{
+			"status.version":         "v1.2.1",
+			"status.replicas":        int64(2),
+			"status.updatedReplicas": int64(2),
+			"status.readyReplicas":   int64(2),
+		}).
+		Build()
+	machineDeploymentRollingOut := builder.MachineDeployment("test-namespace", "md1").
+		WithGeneration(int64(1)).
+		WithReplicas(int32(2)).
+		WithStatus(clusterv1.MachineDeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           1,
+			UpdatedReplicas:    1,
+			AvailableReplicas:  1,
+			ReadyReplicas:      1,
+		}).
+		Build()
+
+	tests := []struct {
+		name                    string
+		reconcileErr            error
+		controlPlane            *unstructured.Unstructured
+		machineDeploymentsState scope.MachineDeploymentsStateMap
+		controlPlanePending     bool
+		pendingMachineDeploys   []string
+		wantCondition           *clusterv1.Condition
+	}{
+		{
+			name:          "should set the condition to false if there is a reconcile error",
+			reconcileErr:  errors.New("reconcile error"),
+			controlPlane:  controlPlaneStable,
+			wantCondition: conditions.FalseCondition(clusterv1.TopologyReconciledCondition, clusterv1.TopologyReconcileFailedReason, clusterv1.ConditionSeverityError, "Failed to reconcile the Cluster topology, see the Cluster events for details"),
+		},
+		{
+			name:                "should report the control plane upgrade on hold while the control plane is upgrading",
+			controlPlane:        controlPlaneUpgrading,
+			controlPlanePending: true,
+			wantCondition: conditions.FalseCondition(clusterv1.TopologyReconciledCondition, clusterv1.TopologyReconciledUpgradePendingReason, clusterv1.ConditionSeverityInfo,
+				"Control plane upgrade to v1.2.3 on hold. Control plane is upgrading to version v1.2.2"),
+		},
+		{
+			name:         "should report the control plane upgrade on hold while MachineDeployments are rolling out",
+			controlPlane: controlPlaneStable,
+			machineDeploymentsState: scope.MachineDeploymentsStateMap{
+				"md1": &scope.MachineDeploymentState{Object: machineDeploymentRollingOut},
+			},
+			controlPlanePending: true,
+			wantCondition: conditions.FalseCondition(clusterv1.TopologyReconciledCondition, clusterv1.TopologyReconciledUpgradePendingReason, clusterv1.ConditionSeverityInfo,
+				"Control plane upgrade to v1.2.3 on hold. MachineDeployment(s) md1 are rolling out"),
+		},
+		{
+			name:                  "should report the MachineDeployments upgrade on hold while the control plane is upgrading",
+			controlPlane:          controlPlaneUpgrading,
+			pendingMachineDeploys: []string{"md1", "md2"},
+			wantCondition: conditions.FalseCondition(clusterv1.TopologyReconciledCondition, clusterv1.TopologyReconciledUpgradePendingReason, clusterv1.ConditionSeverityInfo,
+				"MachineDeployment(s) md1, md2 upgrade to version v1.2.3 on hold. Control plane is upgrading to version v1.2.2"),
+		},
+		{
+			name:                  "should report the MachineDeployments upgrade on hold while the control plane is going to be upgraded",
+			controlPlane:          controlPlaneStable,
+			pendingMachineDeploys: []string{"md1"},
+			wantCondition: conditions.FalseCondition(clusterv1.TopologyReconciledCondition, clusterv1.TopologyReconciledUpgradePendingReason, clusterv1.ConditionSeverityInfo,
+				"MachineDeployment(s) md1 upgrade to version v1.2.3 on hold. Control plane is going to be upgraded to version v1.2.3 first"),
+		},
+		{
+			name:          "should set the condition to true if there are no errors and no upgrades pending",
+			controlPlane:  controlPlaneStable,
+			wantCondition: conditions.TrueCondition(clusterv1.TopologyReconciledCondition),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := builder.Cluster("test-namespace", "cluster1").Build()
+			s := scope.New(cluster)
+			s.Blueprint.Topology = &clusterv1.Topology{
+				Version: "v1.2.3",
+				ControlPlane: clusterv1.ControlPlaneTopology{
+					Replicas: pointer.Int32(2),
+				},
+			}
+			s.Current.ControlPlane = &scope.ControlPlaneState{Object: tt.controlPlane}
+			s.Current.MachineDeployments = tt.machineDeploymentsState
+			s.UpgradeTracker.ControlPlane.PendingUpgrade = tt.controlPlanePending
+			for _, name := range tt.pendingMachineDeploys {
+				s.UpgradeTracker.MachineDeployments.MarkPendingUpgrade(name)
+			}
+
+			recorder := record.NewFakeRecorder(10)
+			r := &ClusterReconciler{recorder: recorder}
+			g.Expect(r.reconcileTopologyReconciledCondition(s, cluster, tt.reconcileErr)).To(Succeed())
+
+			// The error is reported in an event, given that the condition message does not include it.
+			if tt.reconcileErr != nil {
+				g.Expect(recorder.Events).To(Receive(Equal("Warning TopologyReconcileFailed " + tt.reconcileErr.Error())))
+			} else {
+				g.Expect(recorder.Events).ToNot(Receive())
+			}
+
+			got := conditions.Get(cluster, clusterv1.TopologyReconciledCondition)
+			g.Expect(got).ToNot(BeNil())
+			g.Expect(got.Status).To(Equal(tt.wantCondition.Status))
+			g.Expect(got.Reason).To(Equal(tt.wantCondition.Reason))
+			g.Expect(got.Severity).To(Equal(tt.wantCondition.Severity))
+			g.Expect(got.Message).To(Equal(tt.wantCondition.Message))
+		})
+	}
+}
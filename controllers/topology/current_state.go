@@ -21,11 +21,14 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/contract"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -65,6 +68,16 @@ func (r *ClusterReconciler) getCurrentState(ctx context.Context, s *scope.Scope)
 	}
 	currentState.MachineDeployments = m
 
+	// A Cluster may have zero or more MachinePools; MachinePools are read only if the MachinePool feature flag
+	// is enabled, because otherwise the MachinePool CRD might not be installed.
+	if feature.Gates.Enabled(feature.MachinePool) {
+		mp, err := r.getCurrentMachinePoolState(ctx, currentState.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		currentState.MachinePools = mp
+	}
+
 	return currentState, nil
 }
 
@@ -118,9 +131,35 @@ func (r *ClusterReconciler) getCurrentControlPlaneState(ctx context.Context, clu
 		return nil, errors.Wrapf(err, "failed to get InfrastructureMachineTemplate for %s", tlog.KObj{Obj: res.Object})
 	}
 
+	// Get the MachineHealthCheck for the control plane machines, if any.
+	res.MachineHealthCheck, err = r.getCurrentMachineHealthCheck(ctx, res.Object)
+	if err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
+// getCurrentMachineHealthCheck gets the MachineHealthCheck for the machines controlled by healthCheckTarget;
+// the MachineHealthCheck has the same name and namespace as healthCheckTarget. If the MachineHealthCheck is
+// not found, nil is returned.
+func (r *ClusterReconciler) getCurrentMachineHealthCheck(ctx context.Context, healthCheckTarget client.Object) (*clusterv1.MachineHealthCheck, error) {
+	mhc := &clusterv1.MachineHealthCheck{}
+	if err := r.Client.Get(ctx, client.ObjectKeyFromObject(healthCheckTarget), mhc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read MachineHealthCheck for %s", tlog.KObj{Obj: healthCheckTarget})
+	}
+	// check that the MachineHealthCheck has the ClusterTopologyOwnedLabel label.
+	// Nb. This is to make sure that a managed topology cluster does not take over a MachineHealthCheck that is not
+	// owned by the topology.
+	if !labels.IsTopologyOwned(mhc) {
+		return nil, fmt.Errorf("MachineHealthCheck %s is not topology owned", tlog.KObj{Obj: mhc})
+	}
+	return mhc, nil
+}
+
 // getCurrentMachineDeploymentState queries for all MachineDeployments and filters them for their linked Cluster and
 // whether they are managed by a ClusterClass using labels. A Cluster may have zero or more MachineDeployments. Zero is
 // expected on first reconcile. If MachineDeployments are found for the Cluster their Infrastructure and Bootstrap references
@@ -180,10 +219,83 @@ func (r *ClusterReconciler) getCurrentMachineDeploymentState(ctx context.Context
 			return nil, errors.Wrap(err, fmt.Sprintf("%s Infrastructure reference could not be retrieved", tlog.KObj{Obj: m}))
 		}
 
+		// Gets the MachineHealthCheck, if any.
+		mhc, err := r.getCurrentMachineHealthCheck(ctx, m)
+		if err != nil {
+			return nil, err
+		}
+
 		state[mdTopologyName] = &scope.MachineDeploymentState{
 			Object:                        m,
 			BootstrapTemplate:             b,
 			InfrastructureMachineTemplate: i,
+			MachineHealthCheck:            mhc,
+		}
+	}
+	return state, nil
+}
+
+// getCurrentMachinePoolState queries for all MachinePools and filters them for their linked Cluster and
+// whether they are managed by a ClusterClass using labels. A Cluster may have zero or more MachinePools. Zero is
+// expected on first reconcile. If MachinePools are found for the Cluster their Infrastructure and Bootstrap references
+// are inspected. Where these are not found the function will throw an error.
+func (r *ClusterReconciler) getCurrentMachinePoolState(ctx context.Context, cluster *clusterv1.Cluster) (scope.MachinePoolsStateMap, error) {
+	state := make(scope.MachinePoolsStateMap)
+
+	// List all the machine pools in the current cluster and in a managed topology.
+	mp := &expv1.MachinePoolList{}
+	err := r.APIReader.List(ctx, mp,
+		client.MatchingLabels{
+			clusterv1.ClusterLabelName:          cluster.Name,
+			clusterv1.ClusterTopologyOwnedLabel: "",
+		},
+		client.InNamespace(cluster.Namespace),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read MachinePools for managed topology")
+	}
+
+	// Loop over each machine pool and create the current
+	// state by retrieving all required references.
+	for i := range mp.Items {
+		m := &mp.Items[i]
+
+		// Retrieve the name which is assigned in Cluster's topology
+		// from a well-defined label.
+		mpTopologyName, ok := m.ObjectMeta.Labels[clusterv1.ClusterTopologyMachinePoolLabelName]
+		if !ok || len(mpTopologyName) == 0 {
+			return nil, fmt.Errorf("failed to find label %s in %s", clusterv1.ClusterTopologyMachinePoolLabelName, tlog.KObj{Obj: m})
+		}
+
+		// Make sure that the name of the MachinePool stays unique.
+		if _, ok := state[mpTopologyName]; ok {
+			return nil, fmt.Errorf("duplicate %s found for label %s: %s", tlog.KObj{Obj: m}, clusterv1.ClusterTopologyMachinePoolLabelName, mpTopologyName)
+		}
+
+		// Gets the bootstrap config.
+		bootstrapRef := m.Spec.Template.Spec.Bootstrap.ConfigRef
+		if bootstrapRef == nil {
+			return nil, fmt.Errorf("%s does not have a reference to a Bootstrap Config", tlog.KObj{Obj: m})
+		}
+		b, err := r.getReference(ctx, bootstrapRef)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("%s Bootstrap reference could not be retrieved", tlog.KObj{Obj: m}))
+		}
+
+		// Gets the InfrastructureMachinePool.
+		infraRef := m.Spec.Template.Spec.InfrastructureRef
+		if infraRef.Name == "" {
+			return nil, fmt.Errorf("%s does not have a reference to a InfrastructureMachinePool", tlog.KObj{Obj: m})
+		}
+		i, err := r.getReference(ctx, &infraRef)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("%s Infrastructure reference could not be retrieved", tlog.KObj{Obj: m}))
+		}
+
+		state[mpTopologyName] = &scope.MachinePoolState{
+			Object:                          m,
+			BootstrapObject:                 b,
+			InfrastructureMachinePoolObject: i,
 		}
 	}
 	return state, nil
@@ -23,8 +23,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	. "sigs.k8s.io/cluster-api/internal/matchers"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -482,3 +482,69 @@ func TestGetCurrentState(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCurrentMachineHealthCheck(t *testing.T) {
+	md := builder.MachineDeployment(metav1.NamespaceDefault, "md1").Build()
+
+	mhc := &clusterv1.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      md.Name,
+			Namespace: md.Namespace,
+			Labels:    map[string]string{clusterv1.ClusterTopologyOwnedLabel: ""},
+		},
+	}
+	mhcNotTopologyOwned := &clusterv1.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      md.Name,
+			Namespace: md.Namespace,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		wantMHC bool
+		wantErr bool
+	}{
+		{
+			name:    "Returns nil if the MachineHealthCheck does not exist",
+			wantMHC: false,
+		},
+		{
+			name:    "Returns the MachineHealthCheck with the same name of the target",
+			objects: []client.Object{mhc},
+			wantMHC: true,
+		},
+		{
+			name:    "Fails if the MachineHealthCheck is not topology owned",
+			objects: []client.Object{mhcNotTopologyOwned},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			r := &ClusterReconciler{
+				Client: fakeClient,
+			}
+			got, err := r.getCurrentMachineHealthCheck(ctx, md)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			if !tt.wantMHC {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).ToNot(BeNil())
+			g.Expect(got.Name).To(Equal(md.Name))
+		})
+	}
+}
@@ -29,8 +29,13 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/contract"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/patches/variables"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/topology/enabledif"
+	"sigs.k8s.io/cluster-api/internal/topology/metadata"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -62,6 +67,19 @@ func (r *ClusterReconciler) computeDesiredState(ctx context.Context, s *scope.Sc
 		return nil, errors.Wrapf(err, "failed to compute ControlPlane")
 	}
 
+	// If required, compute the desired state of the MachineHealthCheck for the control plane machines.
+	if mhcClass := s.Blueprint.ControlPlaneMachineHealthCheckClass(); mhcClass != nil {
+		desiredState.ControlPlane.MachineHealthCheck = computeMachineHealthCheck(
+			desiredState.ControlPlane.Object,
+			map[string]string{
+				clusterv1.ClusterLabelName:             s.Current.Cluster.Name,
+				clusterv1.MachineControlPlaneLabelName: "",
+			},
+			s.Current.Cluster.Name,
+			mhcClass,
+		)
+	}
+
 	// Compute the desired state for the Cluster object adding a reference to the
 	// InfrastructureCluster and the ControlPlane objects generated by the previous step.
 	desiredState.Cluster = computeCluster(ctx, s, desiredState.InfrastructureCluster, desiredState.ControlPlane.Object)
@@ -75,6 +93,16 @@ func (r *ClusterReconciler) computeDesiredState(ctx context.Context, s *scope.Sc
 		}
 	}
 
+	// If required, compute the desired state of the MachinePools from the list of MachinePoolTopologies
+	// defined in the cluster.
+	// NOTE: MachinePools are supported only if the MachinePool feature flag is enabled.
+	if feature.Gates.Enabled(feature.MachinePool) && s.Blueprint.HasMachinePools() {
+		desiredState.MachinePools, err = computeMachinePools(ctx, s, desiredState.ControlPlane)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute MachinePools")
+		}
+	}
+
 	// Apply patches the desired state according to the patches from the ClusterClass, variables from the Cluster
 	// and builtin variables.
 	// NOTE: We have to make sure all spec fields that were explicitly set in desired objects during the computation above
@@ -197,6 +225,9 @@ func computeControlPlane(_ context.Context, s *scope.Scope, infrastructureMachin
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to compute version of control plane")
 	}
+	if version != s.Blueprint.Topology.Version {
+		s.UpgradeTracker.ControlPlane.PendingUpgrade = true
+	}
 	if err := contract.ControlPlane().Version().Set(controlPlane, version); err != nil {
 		return nil, errors.Wrap(err, "failed to set spec.version in the ControlPlane object")
 	}
@@ -290,8 +321,26 @@ func computeCluster(_ context.Context, s *scope.Scope, infrastructureCluster, co
 
 // computeMachineDeployments computes the desired state of the list of MachineDeployments.
 func computeMachineDeployments(ctx context.Context, s *scope.Scope, desiredControlPlaneState *scope.ControlPlaneState) (scope.MachineDeploymentsStateMap, error) {
+	// Calculate the variables used for evaluating if MachineDeployment classes are enabled.
+	globalVariables, err := variables.Global(s.Blueprint.Topology, s.Current.Cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to calculate variables")
+	}
+
 	machineDeploymentsStateMap := make(scope.MachineDeploymentsStateMap)
 	for _, mdTopology := range s.Blueprint.Topology.Workers.MachineDeployments {
+		// Skip MachineDeployments using a class which is not enabled for this Cluster; if the MachineDeployment
+		// already exists, it will be deleted.
+		if machineDeploymentBlueprint, ok := s.Blueprint.MachineDeployments[mdTopology.Class]; ok {
+			enabled, err := enabledif.Evaluate(machineDeploymentBlueprint.EnabledIf, globalVariables)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to calculate if MachineDeployment class %s is enabled", mdTopology.Class)
+			}
+			if !enabled {
+				continue
+			}
+		}
+
 		desiredMachineDeployment, err := computeMachineDeployment(ctx, s, desiredControlPlaneState, mdTopology)
 		if err != nil {
 			return nil, err
@@ -360,6 +409,25 @@ func computeMachineDeployment(_ context.Context, s *scope.Scope, desiredControlP
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to compute version for %s", machineDeploymentTopology.Name)
 	}
+	if version != s.Blueprint.Topology.Version {
+		s.UpgradeTracker.MachineDeployments.MarkPendingUpgrade(currentMachineDeployment.Object.Name)
+	}
+
+	// Expand the template tokens in the metadata from the MachineDeployment class.
+	failureDomain := ""
+	if machineDeploymentTopology.FailureDomain != nil {
+		failureDomain = *machineDeploymentTopology.FailureDomain
+	}
+	machineDeploymentClassMetadata, err := metadata.ExpandMachineDeploymentClassMetadata(machineDeploymentBlueprint.Metadata, metadata.MachineDeploymentValues{
+		ClusterName:      s.Current.Cluster.Name,
+		ClusterNamespace: s.Current.Cluster.Namespace,
+		TopologyName:     machineDeploymentTopology.Name,
+		Class:            className,
+		FailureDomain:    failureDomain,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute metadata for %s", machineDeploymentTopology.Name)
+	}
 
 	// Compute the MachineDeployment object.
 	gv := clusterv1.GroupVersion
@@ -376,14 +444,15 @@ func computeMachineDeployment(_ context.Context, s *scope.Scope, desiredControlP
 			ClusterName: s.Current.Cluster.Name,
 			Template: clusterv1.MachineTemplateSpec{
 				ObjectMeta: clusterv1.ObjectMeta{
-					Labels:      mergeMap(machineDeploymentTopology.Metadata.Labels, machineDeploymentBlueprint.Metadata.Labels),
-					Annotations: mergeMap(machineDeploymentTopology.Metadata.Annotations, machineDeploymentBlueprint.Metadata.Annotations),
+					Labels:      mergeMap(machineDeploymentTopology.Metadata.Labels, machineDeploymentClassMetadata.Labels),
+					Annotations: mergeMap(machineDeploymentTopology.Metadata.Annotations, machineDeploymentClassMetadata.Annotations),
 				},
 				Spec: clusterv1.MachineSpec{
 					ClusterName:       s.Current.Cluster.Name,
 					Version:           pointer.String(version),
 					Bootstrap:         clusterv1.Bootstrap{ConfigRef: contract.ObjToRef(desiredMachineDeployment.BootstrapTemplate)},
 					InfrastructureRef: *contract.ObjToRef(desiredMachineDeployment.InfrastructureMachineTemplate),
+					FailureDomain:     machineDeploymentTopology.FailureDomain,
 				},
 			},
 		},
@@ -432,10 +501,67 @@ func computeMachineDeployment(_ context.Context, s *scope.Scope, desiredControlP
 	// Set the desired replicas.
 	desiredMachineDeploymentObj.Spec.Replicas = machineDeploymentTopology.Replicas
 
+	// Set the rolloutAfter, if any.
+	desiredMachineDeploymentObj.Spec.RolloutAfter = machineDeploymentTopology.RolloutAfter
+
 	desiredMachineDeployment.Object = desiredMachineDeploymentObj
+
+	// If required, compute the desired state of the MachineHealthCheck for the MachineDeployment machines.
+	if mhcClass := s.Blueprint.MachineDeploymentMachineHealthCheckClass(&machineDeploymentTopology); mhcClass != nil {
+		desiredMachineDeployment.MachineHealthCheck = computeMachineHealthCheck(
+			desiredMachineDeploymentObj,
+			desiredMachineDeploymentObj.Spec.Selector.MatchLabels,
+			s.Current.Cluster.Name,
+			mhcClass,
+		)
+		desiredMachineDeployment.MachineHealthCheck.Labels[clusterv1.ClusterTopologyMachineDeploymentLabelName] = machineDeploymentTopology.Name
+	}
+
 	return desiredMachineDeployment, nil
 }
 
+// computeMachineHealthCheck computes the desired state of the MachineHealthCheck for the machines controlled by
+// healthCheckTarget, using the given MachineHealthCheckClass and selecting machines with the given labels.
+// The MachineHealthCheck has the same name and namespace as healthCheckTarget.
+// NOTE: OwnerRef isn't set, because the MachineHealthCheck controller adds an OwnerReference to the Cluster,
+// which is enough for ensuring the MachineHealthCheck is garbage collected together with the Cluster.
+func computeMachineHealthCheck(healthCheckTarget metav1.Object, selectorLabels map[string]string, clusterName string, mhcClass *clusterv1.MachineHealthCheckClass) *clusterv1.MachineHealthCheck {
+	mhcClass = mhcClass.DeepCopy()
+	matchLabels := map[string]string{}
+	for k, v := range selectorLabels {
+		matchLabels[k] = v
+	}
+
+	mhc := &clusterv1.MachineHealthCheck{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       clusterv1.GroupVersion.WithKind("MachineHealthCheck").Kind,
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      healthCheckTarget.GetName(),
+			Namespace: healthCheckTarget.GetNamespace(),
+			Labels: map[string]string{
+				clusterv1.ClusterTopologyOwnedLabel: "",
+			},
+		},
+		Spec: clusterv1.MachineHealthCheckSpec{
+			ClusterName:         clusterName,
+			Selector:            metav1.LabelSelector{MatchLabels: matchLabels},
+			UnhealthyConditions: mhcClass.UnhealthyConditions,
+			MaxUnhealthy:        mhcClass.MaxUnhealthy,
+			UnhealthyRange:      mhcClass.UnhealthyRange,
+			NodeStartupTimeout:  mhcClass.NodeStartupTimeout,
+			RemediationTemplate: mhcClass.RemediationTemplate,
+		},
+	}
+
+	// Default all the fields in the MachineHealthCheck using the same func called by the webhook; this ensures
+	// the desired state doesn't differ from the current state only because of webhook defaulting.
+	mhc.Default()
+
+	return mhc
+}
+
 // computeMachineDeploymentVersion calculates the version of the desired machine deployment.
 // The version is calculated using the state of the current machine deployments,
 // the current control plane and the version defined in the topology.
@@ -465,67 +591,237 @@ func computeMachineDeploymentVersion(s *scope.Scope, desiredControlPlaneState *s
 		return currentVersion, nil
 	}
 
-	// If the control plane is being created (current control plane is nil), do not perform
-	// any machine deployment upgrade in this case.
-	// Return the current version of the machine deployment.
-	// NOTE: this case should never happen (upgrading a MachineDeployment) before creating a CP,
+	// If the control plane is not stable (being created, upgrading, scaling or about to be upgraded),
+	// do not perform any machine deployment upgrade yet; return the current version of the machine deployment.
+	// We will pick up the new version after the control plane is stable.
+	cpStable, err := isControlPlaneStable(s, desiredControlPlaneState)
+	if err != nil {
+		return "", err
+	}
+	if !cpStable {
+		return currentVersion, nil
+	}
+
+	// At this point the control plane is stable (not scaling, not upgrading, not being upgraded).
+	// Checking to see if the machine deployments are also stable.
+	// If any of the MachineDeployments is rolling out, do not upgrade the machine deployment yet.
+	if s.Current.MachineDeployments.IsAnyRollingOut() {
+		return currentVersion, nil
+	}
+
+	// Control plane and machine deployments are stable.
+	// Ready to pick up the topology version.
+	s.UpgradeTracker.MachineDeployments.Insert(currentMDState.Object.Name)
+	return desiredVersion, nil
+}
+
+// isControlPlaneStable returns true if the control plane exists and it is not upgrading, not scaling and not
+// about to be upgraded, thus worker nodes can pick up the topology version.
+func isControlPlaneStable(s *scope.Scope, desiredControlPlaneState *scope.ControlPlaneState) (bool, error) {
+	// If the control plane is being created (current control plane is nil), the control plane is not stable.
+	// NOTE: this case should never happen (upgrading workers) before creating a CP,
 	// but we are implementing this check for extra safety.
 	if s.Current.ControlPlane == nil || s.Current.ControlPlane.Object == nil {
-		return currentVersion, nil
+		return false, nil
 	}
 
-	// If the current control plane is upgrading, then do not pick up the desiredVersion yet.
-	// Return the current version of the machine deployment. We will pick up the new version after the control
-	// plane is stable.
+	// If the current control plane is upgrading, the control plane is not stable.
 	cpUpgrading, err := contract.ControlPlane().IsUpgrading(s.Current.ControlPlane.Object)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to check if control plane is upgrading")
+		return false, errors.Wrap(err, "failed to check if control plane is upgrading")
 	}
 	if cpUpgrading {
-		return currentVersion, nil
+		return false, nil
 	}
 
 	// If control plane supports replicas, check if the control plane is in the middle of a scale operation.
-	// If the current control plane is scaling, then do not pick up the desiredVersion yet.
-	// Return the current version of the machine deployment. We will pick up the new version after the control
-	// plane is stable.
 	if s.Blueprint.Topology.ControlPlane.Replicas != nil {
 		cpScaling, err := contract.ControlPlane().IsScaling(s.Current.ControlPlane.Object)
 		if err != nil {
-			return "", errors.Wrap(err, "failed to check if the control plane is scaling")
+			return false, errors.Wrap(err, "failed to check if the control plane is scaling")
 		}
 		if cpScaling {
-			return currentVersion, nil
+			return false, nil
 		}
 	}
 
-	// Check if we are about to upgrade the control plane. In that case, do not upgrade the machine deployment yet.
-	// Wait for the new upgrade operation on the control plane to finish before picking up the new version for the
-	// machine deployment.
+	// Check if we are about to upgrade the control plane; in that case the control plane is not stable.
 	currentCPVersion, err := contract.ControlPlane().Version().Get(s.Current.ControlPlane.Object)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get version of current control plane")
+		return false, errors.Wrap(err, "failed to get version of current control plane")
 	}
 	desiredCPVersion, err := contract.ControlPlane().Version().Get(desiredControlPlaneState.Object)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get version of desired control plane")
+		return false, errors.Wrap(err, "failed to get version of desired control plane")
 	}
 	if *currentCPVersion != *desiredCPVersion {
 		// The versions of the current and desired control planes do no match,
 		// implies we are about to upgrade the control plane.
-		return currentVersion, nil
+		return false, nil
 	}
 
-	// At this point the control plane is stable (not scaling, not upgrading, not being upgraded).
-	// Checking to see if the machine deployments are also stable.
-	// If any of the MachineDeployments is rolling out, do not upgrade the machine deployment yet.
-	if s.Current.MachineDeployments.IsAnyRollingOut() {
+	return true, nil
+}
+
+// computeMachinePools computes the desired state of the list of MachinePools.
+func computeMachinePools(ctx context.Context, s *scope.Scope, desiredControlPlaneState *scope.ControlPlaneState) (scope.MachinePoolsStateMap, error) {
+	machinePoolsStateMap := make(scope.MachinePoolsStateMap)
+	for _, mpTopology := range s.Blueprint.Topology.Workers.MachinePools {
+		desiredMachinePool, err := computeMachinePool(ctx, s, desiredControlPlaneState, mpTopology)
+		if err != nil {
+			return nil, err
+		}
+		machinePoolsStateMap[mpTopology.Name] = desiredMachinePool
+	}
+	return machinePoolsStateMap, nil
+}
+
+// computeMachinePool computes the desired state for a MachinePoolTopology.
+// The generated machinePool object is calculated using the values from the machinePoolTopology and
+// the machinePool class.
+// NOTE: Differently from MachineDeployments, the bootstrap config and the infrastructure machine pool
+// are generated from the templates in the ClusterClass and they are updated in place.
+func computeMachinePool(_ context.Context, s *scope.Scope, desiredControlPlaneState *scope.ControlPlaneState, machinePoolTopology clusterv1.MachinePoolTopology) (*scope.MachinePoolState, error) {
+	desiredMachinePool := &scope.MachinePoolState{}
+
+	// Gets the blueprint for the MachinePool class.
+	className := machinePoolTopology.Class
+	machinePoolBlueprint, ok := s.Blueprint.MachinePools[className]
+	if !ok {
+		return nil, errors.Errorf("MachinePool class %s not found in %s", className, tlog.KObj{Obj: s.Blueprint.ClusterClass})
+	}
+
+	// Compute the bootstrap config.
+	currentMachinePool := s.Current.MachinePools[machinePoolTopology.Name]
+	var currentBootstrapConfigRef *corev1.ObjectReference
+	if currentMachinePool != nil && currentMachinePool.BootstrapObject != nil {
+		currentBootstrapConfigRef = currentMachinePool.Object.Spec.Template.Spec.Bootstrap.ConfigRef
+	}
+	var err error
+	desiredMachinePool.BootstrapObject, err = templateToObject(templateToInput{
+		template:              machinePoolBlueprint.BootstrapTemplate,
+		templateClonedFromRef: contract.ObjToRef(machinePoolBlueprint.BootstrapTemplate),
+		cluster:               s.Current.Cluster,
+		namePrefix:            bootstrapConfigNamePrefix(s.Current.Cluster.Name, machinePoolTopology.Name),
+		currentObjectRef:      currentBootstrapConfigRef,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute bootstrap object for topology %q", machinePoolTopology.Name)
+	}
+
+	bootstrapObjectLabels := desiredMachinePool.BootstrapObject.GetLabels()
+	if bootstrapObjectLabels == nil {
+		bootstrapObjectLabels = map[string]string{}
+	}
+	// Add ClusterTopologyMachinePoolLabel to the generated bootstrap config.
+	bootstrapObjectLabels[clusterv1.ClusterTopologyMachinePoolLabelName] = machinePoolTopology.Name
+	desiredMachinePool.BootstrapObject.SetLabels(bootstrapObjectLabels)
+
+	// Compute the infrastructure machine pool.
+	var currentInfraMachinePoolRef *corev1.ObjectReference
+	if currentMachinePool != nil && currentMachinePool.InfrastructureMachinePoolObject != nil {
+		currentInfraMachinePoolRef = &currentMachinePool.Object.Spec.Template.Spec.InfrastructureRef
+	}
+	desiredMachinePool.InfrastructureMachinePoolObject, err = templateToObject(templateToInput{
+		template:              machinePoolBlueprint.InfrastructureMachinePoolTemplate,
+		templateClonedFromRef: contract.ObjToRef(machinePoolBlueprint.InfrastructureMachinePoolTemplate),
+		cluster:               s.Current.Cluster,
+		namePrefix:            infrastructureMachinePoolNamePrefix(s.Current.Cluster.Name, machinePoolTopology.Name),
+		currentObjectRef:      currentInfraMachinePoolRef,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute infrastructure object for topology %q", machinePoolTopology.Name)
+	}
+
+	infraMachinePoolObjectLabels := desiredMachinePool.InfrastructureMachinePoolObject.GetLabels()
+	if infraMachinePoolObjectLabels == nil {
+		infraMachinePoolObjectLabels = map[string]string{}
+	}
+	// Add ClusterTopologyMachinePoolLabel to the generated infrastructure machine pool.
+	infraMachinePoolObjectLabels[clusterv1.ClusterTopologyMachinePoolLabelName] = machinePoolTopology.Name
+	desiredMachinePool.InfrastructureMachinePoolObject.SetLabels(infraMachinePoolObjectLabels)
+
+	version, err := computeMachinePoolVersion(s, desiredControlPlaneState, currentMachinePool)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute version for %s", machinePoolTopology.Name)
+	}
+
+	// Compute the MachinePool object.
+	gv := expv1.GroupVersion
+	desiredMachinePoolObj := &expv1.MachinePool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       gv.WithKind("MachinePool").Kind,
+			APIVersion: gv.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        names.SimpleNameGenerator.GenerateName(fmt.Sprintf("%s-%s-", s.Current.Cluster.Name, machinePoolTopology.Name)),
+			Namespace:   s.Current.Cluster.Namespace,
+			Annotations: mergeMap(machinePoolTopology.Metadata.Annotations, machinePoolBlueprint.Metadata.Annotations),
+		},
+		Spec: expv1.MachinePoolSpec{
+			ClusterName:    s.Current.Cluster.Name,
+			FailureDomains: machinePoolTopology.FailureDomains,
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					ClusterName:       s.Current.Cluster.Name,
+					Version:           pointer.String(version),
+					Bootstrap:         clusterv1.Bootstrap{ConfigRef: contract.ObjToRef(desiredMachinePool.BootstrapObject)},
+					InfrastructureRef: *contract.ObjToRef(desiredMachinePool.InfrastructureMachinePoolObject),
+				},
+			},
+		},
+	}
+
+	// If an existing MachinePool is present, override the MachinePool generate name
+	// re-using the existing name (this will help in reconcile).
+	if currentMachinePool != nil && currentMachinePool.Object != nil {
+		desiredMachinePoolObj.SetName(currentMachinePool.Object.Name)
+	}
+
+	// Apply Labels
+	// NOTE: On top of all the labels applied to managed objects we are applying the ClusterTopologyMachinePoolLabel
+	// keeping track of the MachinePool name from the Topology; this will be used to identify the object in next reconcile loops.
+	labels := mergeMap(machinePoolTopology.Metadata.Labels, machinePoolBlueprint.Metadata.Labels)
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[clusterv1.ClusterLabelName] = s.Current.Cluster.Name
+	labels[clusterv1.ClusterTopologyOwnedLabel] = ""
+	labels[clusterv1.ClusterTopologyMachinePoolLabelName] = machinePoolTopology.Name
+	desiredMachinePoolObj.SetLabels(labels)
+
+	// Set the desired replicas.
+	desiredMachinePoolObj.Spec.Replicas = machinePoolTopology.Replicas
+
+	desiredMachinePool.Object = desiredMachinePoolObj
+
+	return desiredMachinePool, nil
+}
+
+// computeMachinePoolVersion calculates the version of the desired machine pool.
+// The version is calculated using the state of the current machine pool, the current control plane
+// and the version defined in the topology.
+func computeMachinePoolVersion(s *scope.Scope, desiredControlPlaneState *scope.ControlPlaneState, currentMPState *scope.MachinePoolState) (string, error) {
+	desiredVersion := s.Blueprint.Topology.Version
+	// If creating a new machine pool, we can pick up the desired version.
+	if currentMPState == nil || currentMPState.Object == nil || currentMPState.Object.Spec.Template.Spec.Version == nil {
+		return desiredVersion, nil
+	}
+
+	// Return early if the current version is already equal to the desired version.
+	currentVersion := *currentMPState.Object.Spec.Template.Spec.Version
+	if currentVersion == desiredVersion {
 		return currentVersion, nil
 	}
 
-	// Control plane and machine deployments are stable.
-	// Ready to pick up the topology version.
-	s.UpgradeTracker.MachineDeployments.Insert(currentMDState.Object.Name)
+	// Do not upgrade the machine pool until the control plane is stable.
+	cpStable, err := isControlPlaneStable(s, desiredControlPlaneState)
+	if err != nil {
+		return "", err
+	}
+	if !cpStable {
+		return currentVersion, nil
+	}
 	return desiredVersion, nil
 }
 
@@ -19,16 +19,18 @@ package topology
 import (
 	"strings"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/contract"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
-	"sigs.k8s.io/cluster-api/internal/builder"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
@@ -747,6 +749,100 @@ func TestComputeMachineDeployment(t *testing.T) {
 		g.Expect(actualMd.Spec.Template.ObjectMeta.Labels).To(HaveKey(clusterv1.ClusterTopologyOwnedLabel))
 		g.Expect(actualMd.Spec.Template.Spec.InfrastructureRef.Name).ToNot(Equal("linux-worker-inframachinetemplate"))
 		g.Expect(actualMd.Spec.Template.Spec.Bootstrap.ConfigRef.Name).ToNot(Equal("linux-worker-bootstraptemplate"))
+
+		g.Expect(actual.MachineHealthCheck).To(BeNil())
+	})
+
+	t.Run("Generates the MachineHealthCheck defined in the MachineDeployment class", func(t *testing.T) {
+		g := NewWithT(t)
+		s := scope.New(cluster)
+		s.Blueprint = &scope.ClusterBlueprint{
+			Topology:     cluster.Spec.Topology,
+			ClusterClass: fakeClass,
+			MachineDeployments: map[string]*scope.MachineDeploymentBlueprint{
+				"linux-worker": {
+					BootstrapTemplate:             workerBootstrapTemplate,
+					InfrastructureMachineTemplate: workerInfrastructureMachineTemplate,
+					MachineHealthCheck: &clusterv1.MachineHealthCheckClass{
+						UnhealthyConditions: []clusterv1.UnhealthyCondition{
+							{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+						},
+					},
+				},
+			},
+		}
+
+		actual, err := computeMachineDeployment(ctx, s, nil, mdTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		actualMHC := actual.MachineHealthCheck
+		g.Expect(actualMHC).ToNot(BeNil())
+		g.Expect(actualMHC.Name).To(Equal(actual.Object.Name))
+		g.Expect(actualMHC.Namespace).To(Equal(actual.Object.Namespace))
+		g.Expect(actualMHC.Labels).To(HaveKey(clusterv1.ClusterTopologyOwnedLabel))
+		g.Expect(actualMHC.Labels).To(HaveKeyWithValue(clusterv1.ClusterTopologyMachineDeploymentLabelName, "big-pool-of-machines"))
+		g.Expect(actualMHC.Spec.ClusterName).To(Equal("cluster1"))
+		g.Expect(actualMHC.Spec.Selector.MatchLabels).To(Equal(actual.Object.Spec.Selector.MatchLabels))
+		g.Expect(actualMHC.Spec.UnhealthyConditions).To(HaveLen(1))
+		// Fields not set in the MachineHealthCheckClass are defaulted.
+		g.Expect(actualMHC.Spec.MaxUnhealthy).ToNot(BeNil())
+		g.Expect(actualMHC.Spec.NodeStartupTimeout).ToNot(BeNil())
+
+		// The MachineHealthCheck is not generated if disabled in the topology.
+		mdTopologyWithMHCDisabled := mdTopology.DeepCopy()
+		mdTopologyWithMHCDisabled.MachineHealthCheck = &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(false)}
+
+		actual, err = computeMachineDeployment(ctx, s, nil, *mdTopologyWithMHCDisabled)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(actual.MachineHealthCheck).To(BeNil())
+	})
+
+	t.Run("Sets the rolloutAfter defined in the MachineDeployment topology", func(t *testing.T) {
+		g := NewWithT(t)
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprint
+
+		rolloutAfter := metav1.Now()
+		mdTopologyWithRolloutAfter := mdTopology.DeepCopy()
+		mdTopologyWithRolloutAfter.RolloutAfter = &rolloutAfter
+
+		actual, err := computeMachineDeployment(ctx, scope, nil, *mdTopologyWithRolloutAfter)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(actual.Object.Spec.RolloutAfter).To(Equal(&rolloutAfter))
+	})
+
+	t.Run("Expands template tokens in the metadata from the MachineDeployment class", func(t *testing.T) {
+		g := NewWithT(t)
+		s := scope.New(cluster)
+		s.Blueprint = &scope.ClusterBlueprint{
+			Topology:     cluster.Spec.Topology,
+			ClusterClass: fakeClass,
+			MachineDeployments: map[string]*scope.MachineDeploymentBlueprint{
+				"linux-worker": {
+					Metadata: clusterv1.ObjectMeta{
+						Labels: map[string]string{
+							"pool":     "{{ .cluster.name }}-{{ .machineDeployment.topologyName }}",
+							"location": "{{ .machineDeployment.failureDomain }}",
+						},
+						Annotations: map[string]string{"class": "{{ .cluster.namespace }}/{{ .machineDeployment.class }}"},
+					},
+					BootstrapTemplate:             workerBootstrapTemplate,
+					InfrastructureMachineTemplate: workerInfrastructureMachineTemplate,
+				},
+			},
+		}
+
+		mdTopologyWithFailureDomain := mdTopology.DeepCopy()
+		mdTopologyWithFailureDomain.FailureDomain = pointer.String("fd1")
+
+		actual, err := computeMachineDeployment(ctx, s, nil, *mdTopologyWithFailureDomain)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		actualMd := actual.Object
+		g.Expect(actualMd.Spec.Template.Spec.FailureDomain).To(Equal(pointer.String("fd1")))
+		g.Expect(actualMd.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue("pool", "cluster1-big-pool-of-machines"))
+		g.Expect(actualMd.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue("location", "fd1"))
+		g.Expect(actualMd.Spec.Template.ObjectMeta.Annotations).To(HaveKeyWithValue("class", "default/linux-worker"))
 	})
 
 	t.Run("If there is already a machine deployment, it preserves the object name and the reference names", func(t *testing.T) {
@@ -934,6 +1030,69 @@ func TestComputeMachineDeployment(t *testing.T) {
 	})
 }
 
+func TestComputeMachineDeployments(t *testing.T) {
+	g := NewWithT(t)
+
+	workerInfrastructureMachineTemplate := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "linux-worker-inframachinetemplate").
+		Build()
+	workerBootstrapTemplate := builder.BootstrapTemplate(metav1.NamespaceDefault, "linux-worker-bootstraptemplate").
+		Build()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Version: "v1.21.2",
+				Workers: &clusterv1.WorkersTopology{
+					MachineDeployments: []clusterv1.MachineDeploymentTopology{
+						{Class: "linux-worker", Name: "workers"},
+						{Class: "gpu-worker", Name: "gpu-workers"},
+						{Class: "arm-worker", Name: "arm-workers"},
+					},
+				},
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "gpu", Value: apiextensionsv1.JSON{Raw: []byte(`true`)}},
+					{Name: "arm", Value: apiextensionsv1.JSON{Raw: []byte(`false`)}},
+				},
+			},
+		},
+	}
+
+	blueprint := &scope.ClusterBlueprint{
+		Topology:     cluster.Spec.Topology,
+		ClusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").Build(),
+		MachineDeployments: map[string]*scope.MachineDeploymentBlueprint{
+			"linux-worker": {
+				BootstrapTemplate:             workerBootstrapTemplate,
+				InfrastructureMachineTemplate: workerInfrastructureMachineTemplate,
+			},
+			"gpu-worker": {
+				EnabledIf:                     pointer.String("{{ .gpu }}"),
+				BootstrapTemplate:             workerBootstrapTemplate,
+				InfrastructureMachineTemplate: workerInfrastructureMachineTemplate,
+			},
+			"arm-worker": {
+				EnabledIf:                     pointer.String("{{ .arm }}"),
+				BootstrapTemplate:             workerBootstrapTemplate,
+				InfrastructureMachineTemplate: workerInfrastructureMachineTemplate,
+			},
+		},
+	}
+
+	s := scope.New(cluster)
+	s.Blueprint = blueprint
+
+	// MachineDeployments using classes not enabled for the Cluster are omitted.
+	actual, err := computeMachineDeployments(ctx, s, nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(actual).To(HaveLen(2))
+	g.Expect(actual).To(HaveKey("workers"))
+	g.Expect(actual).To(HaveKey("gpu-workers"))
+}
+
 func TestComputeMachineDeploymentVersion(t *testing.T) {
 	controlPlaneStable122 := builder.ControlPlane("test1", "cp1").
 		WithSpecFields(map[string]interface{}{
@@ -1114,6 +1273,210 @@ func TestComputeMachineDeploymentVersion(t *testing.T) {
 	}
 }
 
+func TestComputeMachinePool(t *testing.T) {
+	workerInfrastructureMachinePoolTemplate := builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "linux-worker-inframachinepooltemplate").
+		Build()
+	workerBootstrapTemplate := builder.BootstrapTemplate(metav1.NamespaceDefault, "linux-worker-bootstraptemplate").
+		Build()
+	labels := map[string]string{"fizz": "buzz", "foo": "bar"}
+	annotations := map[string]string{"annotation-1": "annotation-1-val"}
+
+	mp1 := builder.MachinePoolClass("linux-worker").
+		WithLabels(labels).
+		WithAnnotations(annotations).
+		WithInfrastructureTemplate(workerInfrastructureMachinePoolTemplate).
+		WithBootstrapTemplate(workerBootstrapTemplate).
+		Build()
+	fakeClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithWorkerMachinePoolClasses([]clusterv1.MachinePoolClass{*mp1}).
+		Build()
+
+	version := "v1.21.2"
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Version: version,
+			},
+		},
+	}
+
+	blueprint := &scope.ClusterBlueprint{
+		Topology:     cluster.Spec.Topology,
+		ClusterClass: fakeClass,
+		MachinePools: map[string]*scope.MachinePoolBlueprint{
+			"linux-worker": {
+				Metadata: clusterv1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				BootstrapTemplate:                 workerBootstrapTemplate,
+				InfrastructureMachinePoolTemplate: workerInfrastructureMachinePoolTemplate,
+			},
+		},
+	}
+
+	replicas := int32(5)
+	mpTopology := clusterv1.MachinePoolTopology{
+		Metadata: clusterv1.ObjectMeta{
+			Labels: map[string]string{"foo": "baz"},
+		},
+		Class:          "linux-worker",
+		Name:           "big-pool-of-machines",
+		FailureDomains: []string{"fd1", "fd2"},
+		Replicas:       &replicas,
+	}
+
+	t.Run("Generates the machine pool and the referenced objects", func(t *testing.T) {
+		g := NewWithT(t)
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprint
+
+		actual, err := computeMachinePool(ctx, scope, nil, mpTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(actual.BootstrapObject.GetKind()).To(Equal(builder.GenericBootstrapConfigKind))
+		g.Expect(actual.BootstrapObject.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterTopologyMachinePoolLabelName, "big-pool-of-machines"))
+		g.Expect(actual.InfrastructureMachinePoolObject.GetKind()).To(Equal(builder.GenericInfrastructureMachinePoolKind))
+		g.Expect(actual.InfrastructureMachinePoolObject.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterTopologyMachinePoolLabelName, "big-pool-of-machines"))
+
+		actualMp := actual.Object
+		g.Expect(*actualMp.Spec.Replicas).To(Equal(replicas))
+		g.Expect(actualMp.Spec.FailureDomains).To(Equal([]string{"fd1", "fd2"}))
+		g.Expect(actualMp.Spec.ClusterName).To(Equal("cluster1"))
+		g.Expect(actualMp.Name).To(ContainSubstring("cluster1"))
+		g.Expect(actualMp.Name).To(ContainSubstring("big-pool-of-machines"))
+
+		g.Expect(actualMp.Labels).To(HaveKeyWithValue(clusterv1.ClusterTopologyMachinePoolLabelName, "big-pool-of-machines"))
+		g.Expect(actualMp.Labels).To(HaveKey(clusterv1.ClusterTopologyOwnedLabel))
+		g.Expect(actualMp.Labels).To(HaveKeyWithValue("foo", "baz"))
+		g.Expect(actualMp.Labels).To(HaveKeyWithValue("fizz", "buzz"))
+		g.Expect(actualMp.Annotations).To(HaveKeyWithValue("annotation-1", "annotation-1-val"))
+
+		g.Expect(*actualMp.Spec.Template.Spec.Version).To(Equal(version))
+		g.Expect(actualMp.Spec.Template.Spec.InfrastructureRef.Name).To(Equal(actual.InfrastructureMachinePoolObject.GetName()))
+		g.Expect(actualMp.Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(Equal(actual.BootstrapObject.GetName()))
+	})
+
+	t.Run("Reuses the names of the current machine pool and referenced objects", func(t *testing.T) {
+		g := NewWithT(t)
+		currentBootstrap := builder.BootstrapConfig(metav1.NamespaceDefault, "current-bootstrap").Build()
+		currentInfra := builder.InfrastructureMachinePool(metav1.NamespaceDefault, "current-infra").Build()
+		currentMp := builder.MachinePool(metav1.NamespaceDefault, "current-mp").
+			WithBootstrap(currentBootstrap).
+			WithInfrastructure(currentInfra).
+			WithVersion(version).
+			Build()
+
+		s := scope.New(cluster)
+		s.Blueprint = blueprint
+		s.Current.MachinePools = scope.MachinePoolsStateMap{
+			"big-pool-of-machines": {
+				Object:                          currentMp,
+				BootstrapObject:                 currentBootstrap,
+				InfrastructureMachinePoolObject: currentInfra,
+			},
+		}
+
+		actual, err := computeMachinePool(ctx, s, nil, mpTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(actual.Object.Name).To(Equal("current-mp"))
+		g.Expect(actual.BootstrapObject.GetName()).To(Equal("current-bootstrap"))
+		g.Expect(actual.InfrastructureMachinePoolObject.GetName()).To(Equal("current-infra"))
+	})
+
+	t.Run("Fails for unknown MachinePool classes", func(t *testing.T) {
+		g := NewWithT(t)
+		s := scope.New(cluster)
+		s.Blueprint = blueprint
+
+		mpTopology := mpTopology.DeepCopy()
+		mpTopology.Class = "unknown"
+		_, err := computeMachinePool(ctx, s, nil, *mpTopology)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestComputeMachinePoolVersion(t *testing.T) {
+	controlPlaneStable123 := builder.ControlPlane("test1", "cp1").
+		WithSpecFields(map[string]interface{}{
+			"spec.version":  "v1.2.3",
+			"spec.replicas": int64(2),
+		}).
+		WithStatusFields(map[string]interface{}{
+			"status.version":         "v1.2.3",
+			"status.replicas":        int64(2),
+			"status.updatedReplicas": int64(2),
+			"status.readyReplicas":   int64(2),
+		}).
+		Build()
+	controlPlaneUpgrading := builder.ControlPlane("test1", "cp1").
+		WithSpecFields(map[string]interface{}{
+			"spec.version": "v1.2.3",
+		}).
+		WithStatusFields(map[string]interface{}{
+			"status.version": "v1.2.1",
+		}).
+		Build()
+	controlPlaneDesired := builder.ControlPlane("test1", "cp1").
+		WithSpecFields(map[string]interface{}{
+			"spec.version": "v1.2.3",
+		}).
+		Build()
+
+	tests := []struct {
+		name                    string
+		currentMachinePoolState *scope.MachinePoolState
+		currentControlPlane     *unstructured.Unstructured
+		expectedVersion         string
+	}{
+		{
+			name:                    "should return cluster.spec.topology.version if creating a new machine pool",
+			currentMachinePoolState: nil,
+			expectedVersion:         "v1.2.3",
+		},
+		{
+			name:                    "should return machine pool's spec.template.spec.version if control plane is upgrading",
+			currentMachinePoolState: &scope.MachinePoolState{Object: builder.MachinePool("test1", "mp-current").WithVersion("v1.2.2").Build()},
+			currentControlPlane:     controlPlaneUpgrading,
+			expectedVersion:         "v1.2.2",
+		},
+		{
+			name:                    "should return cluster.spec.topology.version if the control plane is stable",
+			currentMachinePoolState: &scope.MachinePoolState{Object: builder.MachinePool("test1", "mp-current").WithVersion("v1.2.2").Build()},
+			currentControlPlane:     controlPlaneStable123,
+			expectedVersion:         "v1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &scope.Scope{
+				Blueprint: &scope.ClusterBlueprint{Topology: &clusterv1.Topology{
+					Version: "v1.2.3",
+					ControlPlane: clusterv1.ControlPlaneTopology{
+						Replicas: pointer.Int32(2),
+					},
+				}},
+				Current: &scope.ClusterState{
+					ControlPlane: &scope.ControlPlaneState{Object: tt.currentControlPlane},
+				},
+				UpgradeTracker: scope.NewUpgradeTracker(),
+			}
+			desiredControlPlaneState := &scope.ControlPlaneState{Object: controlPlaneDesired}
+			version, err := computeMachinePoolVersion(s, desiredControlPlaneState, tt.currentMachinePoolState)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(version).To(Equal(tt.expectedVersion))
+		})
+	}
+}
+
 func TestTemplateToObject(t *testing.T) {
 	template := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infrastructureClusterTemplate").
 		WithSpecFields(map[string]interface{}{"spec.template.spec.fakeSetting": true}).
@@ -0,0 +1,163 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package discovery implements the client of the DiscoverVariables hook.
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+
+	// maxResponseSize is the maximum size of a response of the hook.
+	maxResponseSize = 1 << 20
+)
+
+// Discoverer discovers the variables of a ClusterClass.
+type Discoverer interface {
+	// DiscoverVariables calls the DiscoverVariables hook defined in the ClusterClass and returns the discovered variables;
+	// variables is the list of the variables defined in the ClusterClass and in the ClusterClasses it inherits from.
+	DiscoverVariables(ctx context.Context, clusterClass *clusterv1.ClusterClass, hook *clusterv1.VariablesDiscovery, variables []clusterv1.ClusterClassVariable) ([]clusterv1.ClusterClassVariable, error)
+}
+
+// New returns a Discoverer calling the DiscoverVariables hook via HTTPS; only the hooks whose URL is one of the
+// allowedURLs registered by the administrator of the management cluster are called.
+func New(allowedURLs []string) Discoverer {
+	return &httpDiscoverer{
+		allowedURLs: sets.NewString(allowedURLs...),
+		clients:     map[string]*http.Client{},
+	}
+}
+
+type httpDiscoverer struct {
+	allowedURLs sets.String
+
+	// clients caches the HTTP clients by CA bundle, so connections to the hooks are reused across calls.
+	lock    sync.Mutex
+	clients map[string]*http.Client
+}
+
+func (d *httpDiscoverer) DiscoverVariables(ctx context.Context, clusterClass *clusterv1.ClusterClass, hook *clusterv1.VariablesDiscovery, variables []clusterv1.ClusterClassVariable) ([]clusterv1.ClusterClassVariable, error) {
+	if !d.allowedURLs.Has(hook.URL) {
+		return nil, errors.Errorf("URL %q is not registered as a variables discovery URL", hook.URL)
+	}
+	u, err := url.Parse(hook.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid URL %q", hook.URL)
+	}
+	if u.Scheme != "https" {
+		return nil, errors.Errorf("invalid URL %q: the scheme must be https", hook.URL)
+	}
+
+	httpClient, err := d.getClient(hook.CABundle)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := defaultTimeout
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request := &runtimehooksv1.DiscoverVariablesRequest{
+		ClusterClass: runtimehooksv1.ObjectReference{Namespace: clusterClass.Namespace, Name: clusterClass.Name},
+		Variables:    variables,
+	}
+	request.APIVersion = runtimehooksv1.GroupVersion
+	request.Kind = "DiscoverVariablesRequest"
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the DiscoverVariablesRequest")
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the DiscoverVariables request")
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := httpClient.Do(httpRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call the DiscoverVariables hook")
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("DiscoverVariables hook returned HTTP status %d", httpResponse.StatusCode)
+	}
+
+	response := &runtimehooksv1.DiscoverVariablesResponse{}
+	if err := json.NewDecoder(io.LimitReader(httpResponse.Body, maxResponseSize)).Decode(response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the DiscoverVariablesResponse")
+	}
+	if response.Status != runtimehooksv1.ResponseStatusSuccess {
+		return nil, errors.Errorf("DiscoverVariables hook failed: %s", response.Message)
+	}
+
+	names := map[string]bool{}
+	for _, v := range response.Variables {
+		if v.Name == "" {
+			return nil, errors.New("DiscoverVariables hook returned a variable without name")
+		}
+		if names[v.Name] {
+			return nil, errors.Errorf("DiscoverVariables hook returned the variable %q more than once", v.Name)
+		}
+		names[v.Name] = true
+	}
+	return response.Variables, nil
+}
+
+// getClient returns the HTTP client for the given CA bundle, creating it if it does not exist yet.
+func (d *httpDiscoverer) getClient(caBundle []byte) (*http.Client, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if c, ok := d.clients[string(caBundle)]; ok {
+		return c, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, errors.New("invalid caBundle: no PEM encoded certificates found")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	c := &http.Client{
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+	}
+	d.clients[string(caBundle)] = c
+	return c, nil
+}
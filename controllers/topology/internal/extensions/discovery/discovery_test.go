@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+)
+
+func TestDiscoverVariables(t *testing.T) {
+	clusterClass := &clusterv1.ClusterClass{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "class"}}
+	inline := []clusterv1.ClusterClassVariable{{Name: "region", Required: true}}
+
+	tests := []struct {
+		name          string
+		response      interface{}
+		statusCode    int
+		wantVariables []clusterv1.ClusterClassVariable
+		wantErr       bool
+	}{
+		{
+			name: "returns the discovered variables",
+			response: &runtimehooksv1.DiscoverVariablesResponse{
+				Status:    runtimehooksv1.ResponseStatusSuccess,
+				Variables: []clusterv1.ClusterClassVariable{{Name: "instanceType"}},
+			},
+			statusCode:    http.StatusOK,
+			wantVariables: []clusterv1.ClusterClassVariable{{Name: "instanceType"}},
+		},
+		{
+			name: "fails if the hook reports a failure",
+			response: &runtimehooksv1.DiscoverVariablesResponse{
+				Status:  runtimehooksv1.ResponseStatusFailure,
+				Message: "region not supported",
+			},
+			statusCode: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:       "fails if the hook returns an HTTP error",
+			response:   &runtimehooksv1.DiscoverVariablesResponse{},
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+		{
+			name: "fails if the hook returns duplicate variables",
+			response: &runtimehooksv1.DiscoverVariablesResponse{
+				Status:    runtimehooksv1.ResponseStatusSuccess,
+				Variables: []clusterv1.ClusterClassVariable{{Name: "instanceType"}, {Name: "instanceType"}},
+			},
+			statusCode: http.StatusOK,
+			wantErr:    true,
+		},
+		{
+			name:       "fails if the response is invalid",
+			response:   "not a response",
+			statusCode: http.StatusOK,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				request := &runtimehooksv1.DiscoverVariablesRequest{}
+				g.Expect(json.NewDecoder(r.Body).Decode(request)).To(Succeed())
+				g.Expect(request.Kind).To(Equal("DiscoverVariablesRequest"))
+				g.Expect(request.ClusterClass).To(Equal(runtimehooksv1.ObjectReference{Namespace: "default", Name: "class"}))
+				g.Expect(request.Variables).To(Equal(inline))
+
+				w.WriteHeader(tt.statusCode)
+				g.Expect(json.NewEncoder(w).Encode(tt.response)).To(Succeed())
+			}))
+			defer server.Close()
+
+			hook := &clusterv1.VariablesDiscovery{
+				URL:      server.URL,
+				CABundle: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+			}
+			variables, err := New([]string{server.URL}).DiscoverVariables(context.Background(), clusterClass, hook, inline)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(variables).To(Equal(tt.wantVariables))
+		})
+	}
+}
+
+func TestDiscoverVariablesInvalidHook(t *testing.T) {
+	clusterClass := &clusterv1.ClusterClass{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "class"}}
+
+	tests := []struct {
+		name string
+		hook *clusterv1.VariablesDiscovery
+	}{
+		{
+			name: "the URL must use the https scheme",
+			hook: &clusterv1.VariablesDiscovery{URL: "http://discovery.example.com"},
+		},
+		{
+			name: "the caBundle must contain PEM encoded certificates",
+			hook: &clusterv1.VariablesDiscovery{URL: "https://discovery.example.com", CABundle: []byte("foo")},
+		},
+		{
+			name: "the URL must be registered",
+			hook: &clusterv1.VariablesDiscovery{URL: "https://not-registered.example.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := New([]string{"http://discovery.example.com", "https://discovery.example.com"}).DiscoverVariables(context.Background(), clusterClass, tt.hook, nil)
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
+
+func TestDiscoverVariablesReusesClients(t *testing.T) {
+	g := NewWithT(t)
+
+	d := New(nil).(*httpDiscoverer)
+	c1, err := d.getClient(nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	c2, err := d.getClient(nil)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c1).To(BeIdenticalTo(c2))
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	c3, err := d.getClient(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c3).ToNot(BeIdenticalTo(c1))
+}
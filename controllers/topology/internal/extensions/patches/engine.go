@@ -19,26 +19,36 @@ package patches
 
 import (
 	"context"
+	"sync"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/contract"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/patches/api"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/patches/inline"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/patches/variables"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+	"sigs.k8s.io/cluster-api/internal/topology/enabledif"
 )
 
 // Engine is a patch engine which applies patches defined in a ClusterBlueprint to a ClusterState.
 type Engine interface {
 	Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, desired *scope.ClusterState) error
+
+	// Evict drops the patch generators cached for the ClusterClass with the given UID, e.g. when it is deleted.
+	Evict(clusterClassUID types.UID)
 }
 
 // NewEngine creates a new patch engine.
 func NewEngine() Engine {
 	return &engine{
 		createPatchGenerator: createPatchGenerator,
+		generatorCache: &generatorCache{
+			entries: map[types.UID]*generatorCacheEntry{},
+		},
 	}
 }
 
@@ -48,6 +58,23 @@ type engine struct {
 	// based on a ClusterClassPatch.
 	// Note: This field is also used to inject patches in unit tests.
 	createPatchGenerator func(patch *clusterv1.ClusterClassPatch) (api.Generator, error)
+
+	// generatorCache caches the patch generators for the patches of a ClusterClass, if not nil.
+	generatorCache *generatorCache
+}
+
+// generatorCache caches the patch generators created for the patches of a ClusterClass, so e.g. value templates
+// are compiled once for each generation of the ClusterClass instead of on every reconcile.
+type generatorCache struct {
+	lock sync.Mutex
+	// entries are keyed by ClusterClass UID, so there is at most one entry for each ClusterClass.
+	entries map[types.UID]*generatorCacheEntry
+}
+
+// generatorCacheEntry holds the patch generators for a generation of a ClusterClass, keyed by patch index.
+type generatorCacheEntry struct {
+	clusterClassKey string
+	generators      map[int]api.Generator
 }
 
 // Apply applies patches to the desired state according to the patches from the ClusterClass, variables from the Cluster
@@ -76,10 +103,20 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 		clusterClassPatch := blueprint.ClusterClass.Spec.Patches[i]
 		ctx, log = log.WithValues("patch", clusterClassPatch.Name).Into(ctx)
 
+		// Skip the patch if it is not enabled for this Cluster.
+		enabled, err := enabledif.Evaluate(clusterClassPatch.EnabledIf, req.Variables)
+		if err != nil {
+			return errors.Wrapf(err, "failed to calculate if patch %q is enabled", clusterClassPatch.Name)
+		}
+		if !enabled {
+			log.V(5).Infof("Skipping patch because it is not enabled")
+			continue
+		}
+
 		log.V(5).Infof("Applying patch to templates")
 
 		// Create patch generator for the current patch.
-		generator, err := e.createPatchGenerator(&clusterClassPatch)
+		generator, err := e.patchGenerator(blueprint, i)
 		if err != nil {
 			return err
 		}
@@ -90,7 +127,7 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 		// version of the request (including the patched version of the templates).
 		resp, err := generator.Generate(ctx, req)
 		if err != nil {
-			return errors.Errorf("failed to generate patches for patch %q", clusterClassPatch.Name)
+			return errors.Wrapf(err, "failed to generate patches for patch %q", clusterClassPatch.Name)
 		}
 
 		// Apply patches to the request.
@@ -108,6 +145,49 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 	return nil
 }
 
+// Evict drops the patch generators cached for the ClusterClass with the given UID.
+func (e *engine) Evict(clusterClassUID types.UID) {
+	if e.generatorCache == nil {
+		return
+	}
+
+	e.generatorCache.lock.Lock()
+	defer e.generatorCache.lock.Unlock()
+
+	delete(e.generatorCache.entries, clusterClassUID)
+}
+
+// patchGenerator returns the patch generator for the patch with the given index in the ClusterClass of the blueprint,
+// using the generator cache when possible.
+func (e *engine) patchGenerator(blueprint *scope.ClusterBlueprint, i int) (api.Generator, error) {
+	clusterClassPatch := blueprint.ClusterClass.Spec.Patches[i]
+	if e.generatorCache == nil || blueprint.ClusterClassKey == "" {
+		return e.createPatchGenerator(&clusterClassPatch)
+	}
+
+	e.generatorCache.lock.Lock()
+	defer e.generatorCache.lock.Unlock()
+
+	entry, ok := e.generatorCache.entries[blueprint.ClusterClass.UID]
+	if !ok || entry.clusterClassKey != blueprint.ClusterClassKey {
+		entry = &generatorCacheEntry{
+			clusterClassKey: blueprint.ClusterClassKey,
+			generators:      map[int]api.Generator{},
+		}
+		e.generatorCache.entries[blueprint.ClusterClass.UID] = entry
+	}
+	if generator, ok := entry.generators[i]; ok {
+		return generator, nil
+	}
+
+	generator, err := e.createPatchGenerator(&clusterClassPatch)
+	if err != nil {
+		return nil, err
+	}
+	entry.generators[i] = generator
+	return generator, nil
+}
+
 // createRequest creates a GenerateRequest based on the ClusterBlueprint and the desired state.
 // ClusterBlueprint supplies the templates. Desired state is used to calculate variables which are later used
 // as input for the patch generation.
@@ -235,9 +315,7 @@ func lookupMDTopology(topology *clusterv1.Topology, mdTopologyName string) (*clu
 func createPatchGenerator(patch *clusterv1.ClusterClassPatch) (api.Generator, error) {
 	// Return a JSONPatchGenerator if there are PatchDefinitions in the patch.
 	if len(patch.Definitions) > 0 {
-		// TODO(sbueringer): will be implemented in a follow-up PR
-		// return inline.NewJSONPatchGenerator(patch), nil
-		return nil, nil
+		return inline.NewJSONPatchGenerator(patch), nil
 	}
 
 	return nil, errors.Errorf("failed to create patch generator for patch %q", patch.Name)
@@ -27,18 +27,20 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/patches/api"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	. "sigs.k8s.io/cluster-api/internal/matchers"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 )
 
 func TestApply(t *testing.T) {
 	type patch struct {
-		name    string
-		patches []api.GenerateResponsePatch
+		name      string
+		enabledIf *string
+		patches   []api.GenerateResponsePatch
 	}
 	type expectedFields struct {
 		infrastructureCluster                          map[string]interface{}
@@ -285,6 +287,50 @@ func TestApply(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Should skip patches which are not enabled",
+			patches: []patch{
+				{
+					name:      "fake-patch1",
+					enabledIf: pointer.String(`{{ eq .builtin.cluster.name "cluster1" }}`),
+					patches: []api.GenerateResponsePatch{
+						{
+							TemplateRef: api.TemplateRef{
+								APIVersion:   builder.ControlPlaneGroupVersion.String(),
+								Kind:         builder.GenericControlPlaneTemplateKind,
+								TemplateType: api.ControlPlaneTemplateType,
+							},
+							Patch: apiextensionsv1.JSON{Raw: []byte(`
+[{"op":"add","path":"/spec/template/spec/clusterName","value":"cluster1"}]
+							`)},
+							PatchType: api.JSONPatchType,
+						},
+					},
+				},
+				{
+					name:      "fake-patch2",
+					enabledIf: pointer.String(`{{ eq .builtin.cluster.name "cluster2" }}`),
+					patches: []api.GenerateResponsePatch{
+						{
+							TemplateRef: api.TemplateRef{
+								APIVersion:   builder.ControlPlaneGroupVersion.String(),
+								Kind:         builder.GenericControlPlaneTemplateKind,
+								TemplateType: api.ControlPlaneTemplateType,
+							},
+							Patch: apiextensionsv1.JSON{Raw: []byte(`
+[{"op":"replace","path":"/spec/template/spec/clusterName","value":"cluster2"}]
+`)},
+							PatchType: api.JSONPatchType,
+						},
+					},
+				},
+			},
+			expectedFields: expectedFields{
+				controlPlane: map[string]interface{}{
+					"spec.clusterName": "cluster1",
+				},
+			},
+		},
 		{
 			name: "Should apply JSON patches and preserve ControlPlane fields",
 			patches: []patch{
@@ -383,7 +429,7 @@ func TestApply(t *testing.T) {
 			if len(tt.patches) > 0 {
 				for _, patch := range tt.patches {
 					// Add the patches to ensure the patch generator is called.
-					blueprint.ClusterClass.Spec.Patches = append(blueprint.ClusterClass.Spec.Patches, clusterv1.ClusterClassPatch{Name: patch.name})
+					blueprint.ClusterClass.Spec.Patches = append(blueprint.ClusterClass.Spec.Patches, clusterv1.ClusterClassPatch{Name: patch.name, EnabledIf: patch.enabledIf})
 				}
 
 				patchEngine.createPatchGenerator = func(patch *clusterv1.ClusterClassPatch) (api.Generator, error) {
@@ -443,6 +489,59 @@ func TestApply(t *testing.T) {
 	}
 }
 
+func TestPatchGeneratorCache(t *testing.T) {
+	g := NewWithT(t)
+
+	created := 0
+	patchEngine := &engine{
+		createPatchGenerator: func(patch *clusterv1.ClusterClassPatch) (api.Generator, error) {
+			created++
+			return &fakePatchGenerator{}, nil
+		},
+		generatorCache: &generatorCache{
+			entries: map[types.UID]*generatorCacheEntry{},
+		},
+	}
+
+	blueprint := &scope.ClusterBlueprint{
+		ClusterClass: &clusterv1.ClusterClass{
+			ObjectMeta: metav1.ObjectMeta{UID: "uid", Generation: 1},
+			Spec: clusterv1.ClusterClassSpec{
+				Patches: []clusterv1.ClusterClassPatch{{Name: "patch1"}, {Name: "patch2"}},
+			},
+		},
+		ClusterClassKey: "uid/1",
+	}
+
+	// Generators are created once for each patch of a ClusterClass generation.
+	for i := 0; i < 2; i++ {
+		for j := range blueprint.ClusterClass.Spec.Patches {
+			_, err := patchEngine.patchGenerator(blueprint, j)
+			g.Expect(err).NotTo(HaveOccurred())
+		}
+	}
+	g.Expect(created).To(Equal(2))
+
+	// Generators are created again for a new ClusterClass generation.
+	blueprint.ClusterClassKey = "uid/2"
+	_, err := patchEngine.patchGenerator(blueprint, 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created).To(Equal(3))
+	g.Expect(patchEngine.generatorCache.entries).To(HaveLen(1))
+
+	// Generators are not cached for blueprints without a ClusterClass key.
+	blueprint.ClusterClassKey = ""
+	_, err = patchEngine.patchGenerator(blueprint, 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	_, err = patchEngine.patchGenerator(blueprint, 0)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(created).To(Equal(5))
+
+	// Generators are dropped when the ClusterClass is evicted.
+	patchEngine.Evict("uid")
+	g.Expect(patchEngine.generatorCache.entries).To(BeEmpty())
+}
+
 func setupTestObjects() (*scope.ClusterBlueprint, *scope.ClusterState) {
 	infrastructureClusterTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infraClusterTemplate1").
 		Build()
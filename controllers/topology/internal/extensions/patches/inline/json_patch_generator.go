@@ -0,0 +1,212 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inline implements the inline JSON patch generator.
+package inline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/patches/api"
+	"sigs.k8s.io/cluster-api/internal/topology/enabledif"
+	"sigs.k8s.io/yaml"
+)
+
+// jsonPatchGenerator generates JSON patches for a GenerateRequest based on a ClusterClassPatch.
+type jsonPatchGenerator struct {
+	patch *clusterv1.ClusterClassPatch
+
+	// valueTemplates holds the value templates of the patch, compiled when the generator is created.
+	// NOTE: Value templates failing to compile are not included, so the error is reported by Generate.
+	valueTemplates map[string]*template.Template
+}
+
+// NewJSONPatchGenerator returns a new inline json patch generator.
+// NOTE: The returned generator is safe for concurrent use, so it can be reused across reconciles.
+func NewJSONPatchGenerator(patch *clusterv1.ClusterClassPatch) api.Generator {
+	valueTemplates := map[string]*template.Template{}
+	for _, definition := range patch.Definitions {
+		for _, jsonPatch := range definition.JSONPatches {
+			if jsonPatch.ValueFrom == nil || jsonPatch.ValueFrom.Template == nil {
+				continue
+			}
+			if tpl, err := parseValueTemplate(*jsonPatch.ValueFrom.Template); err == nil {
+				valueTemplates[*jsonPatch.ValueFrom.Template] = tpl
+			}
+		}
+	}
+
+	return &jsonPatchGenerator{
+		patch:          patch,
+		valueTemplates: valueTemplates,
+	}
+}
+
+var _ api.Generator = &jsonPatchGenerator{}
+
+// Generate generates JSON patches for the given GenerateRequest based on a ClusterClassPatch.
+// For each template matching the selector of at least one of the patch definitions, a single JSON patch
+// is generated with the operations of all the matching definitions, respecting the order in which they are defined.
+func (j *jsonPatchGenerator) Generate(_ context.Context, req *api.GenerateRequest) (*api.GenerateResponse, error) {
+	resp := &api.GenerateResponse{}
+
+	for _, template := range req.Items {
+		var operations []jsonPatchOperation
+
+		for _, definition := range j.patch.Definitions {
+			if !templateMatchesSelector(&template.TemplateRef, definition.Selector) {
+				continue
+			}
+
+			// Template specific variables, e.g. the version of a MachineDeployment, take precedence over global variables.
+			variables := mergeVariables(req.Variables, template.Variables)
+			for _, jsonPatch := range definition.JSONPatches {
+				value, err := j.calculateValue(jsonPatch, variables)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to calculate value for patch %s %s", jsonPatch.Op, jsonPatch.Path)
+				}
+				operations = append(operations, jsonPatchOperation{
+					Op:    jsonPatch.Op,
+					Path:  jsonPatch.Path,
+					Value: value,
+				})
+			}
+		}
+
+		if len(operations) == 0 {
+			continue
+		}
+
+		patch, err := json.Marshal(operations)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to marshal JSON patch for patch %q", j.patch.Name)
+		}
+		resp.Items = append(resp.Items, api.GenerateResponsePatch{
+			TemplateRef: template.TemplateRef,
+			Patch:       apiextensionsv1.JSON{Raw: patch},
+			PatchType:   api.JSONPatchType,
+		})
+	}
+
+	return resp, nil
+}
+
+// jsonPatchOperation is an operation of a JSON patch (RFC6902).
+type jsonPatchOperation struct {
+	Op    string                `json:"op"`
+	Path  string                `json:"path"`
+	Value *apiextensionsv1.JSON `json:"value,omitempty"`
+}
+
+// templateMatchesSelector returns true if the template identified by templateRef matches the selector.
+// NOTE: apiVersion and kind must always match, then the results of the matchResources fields are ORed.
+func templateMatchesSelector(templateRef *api.TemplateRef, selector clusterv1.PatchSelector) bool {
+	if templateRef.APIVersion != selector.APIVersion || templateRef.Kind != selector.Kind {
+		return false
+	}
+
+	match := selector.MatchResources
+	switch templateRef.TemplateType {
+	case api.InfrastructureClusterTemplateType:
+		return match.InfrastructureCluster != nil && *match.InfrastructureCluster
+	case api.ControlPlaneTemplateType, api.ControlPlaneInfrastructureMachineTemplateType:
+		return match.ControlPlane != nil && *match.ControlPlane
+	case api.MachineDeploymentBootstrapConfigTemplateType, api.MachineDeploymentInfrastructureMachineTemplateType:
+		if match.MachineDeploymentClass == nil {
+			return false
+		}
+		for _, name := range match.MachineDeploymentClass.Names {
+			if name == templateRef.MachineDeploymentRef.Class {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// calculateValue calculates the value of a JSON patch, either from Value, from the variable referenced
+// in ValueFrom.Variable or by executing the Go template in ValueFrom.Template.
+func (j *jsonPatchGenerator) calculateValue(patch clusterv1.JSONPatch, variables map[string]apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+	switch {
+	case patch.Value != nil:
+		return patch.Value, nil
+	case patch.ValueFrom != nil && patch.ValueFrom.Variable != nil:
+		value, ok := variables[*patch.ValueFrom.Variable]
+		if !ok {
+			return nil, errors.Errorf("variable %q not set", *patch.ValueFrom.Variable)
+		}
+		return &value, nil
+	case patch.ValueFrom != nil && patch.ValueFrom.Template != nil:
+		tpl, ok := j.valueTemplates[*patch.ValueFrom.Template]
+		if !ok {
+			var err error
+			if tpl, err = parseValueTemplate(*patch.ValueFrom.Template); err != nil {
+				return nil, err
+			}
+		}
+		return renderValueTemplate(*patch.ValueFrom.Template, tpl, variables)
+	}
+	return nil, nil
+}
+
+// parseValueTemplate compiles a value template.
+func parseValueTemplate(valueTemplate string) (*template.Template, error) {
+	tpl, err := template.New("valueTemplate").Option("missingkey=error").Parse(valueTemplate)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse template %q", valueTemplate)
+	}
+	return tpl, nil
+}
+
+// renderValueTemplate executes a compiled value template, using the variables as data, and converts the result, which must be
+// valid YAML or JSON, into a JSON value.
+// Variables are available in the template using their name, e.g. {{ .gpu }}; builtin variables are available as nested
+// values, e.g. {{ .builtin.cluster.name }} or {{ .builtin.machineDeployment.version }}.
+func renderValueTemplate(valueTemplate string, tpl *template.Template, variables map[string]apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+	data, err := enabledif.TemplateData(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, errors.Wrapf(err, "failed to execute template %q", valueTemplate)
+	}
+
+	value, err := yaml.YAMLToJSON([]byte(strings.TrimSpace(buf.String())))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert the output of template %q to JSON", valueTemplate)
+	}
+	return &apiextensionsv1.JSON{Raw: value}, nil
+}
+
+// mergeVariables merges the variables in order, later variables take precedence.
+func mergeVariables(variableMaps ...map[string]apiextensionsv1.JSON) map[string]apiextensionsv1.JSON {
+	res := map[string]apiextensionsv1.JSON{}
+	for _, variableMap := range variableMaps {
+		for name, value := range variableMap {
+			res[name] = value
+		}
+	}
+	return res
+}
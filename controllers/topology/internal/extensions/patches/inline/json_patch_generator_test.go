@@ -0,0 +1,225 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inline
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/topology/internal/extensions/patches/api"
+)
+
+func TestGenerate(t *testing.T) {
+	controlPlaneTemplate := &api.GenerateRequestTemplate{
+		TemplateRef: api.TemplateRef{
+			APIVersion:   "controlplane.cluster.x-k8s.io/v1beta1",
+			Kind:         "ControlPlaneTemplate",
+			TemplateType: api.ControlPlaneTemplateType,
+		},
+		Variables: map[string]apiextensionsv1.JSON{
+			"builtin.controlPlane.version": {Raw: []byte(`"v1.21.1"`)},
+		},
+	}
+	mdTemplate := func(class, version string) *api.GenerateRequestTemplate {
+		return &api.GenerateRequestTemplate{
+			TemplateRef: api.TemplateRef{
+				APIVersion:   "infrastructure.cluster.x-k8s.io/v1beta1",
+				Kind:         "MachineTemplate",
+				TemplateType: api.MachineDeploymentInfrastructureMachineTemplateType,
+				MachineDeploymentRef: api.MachineDeploymentRef{
+					TopologyName: class + "-topology",
+					Class:        class,
+				},
+			},
+			Variables: map[string]apiextensionsv1.JSON{
+				"builtin.machineDeployment.class":   {Raw: []byte(`"` + class + `"`)},
+				"builtin.machineDeployment.version": {Raw: []byte(`"` + version + `"`)},
+			},
+		}
+	}
+	req := &api.GenerateRequest{
+		Variables: map[string]apiextensionsv1.JSON{
+			"builtin.cluster.name": {Raw: []byte(`"cluster1"`)},
+			"imageRepository":      {Raw: []byte(`"registry.example.com"`)},
+		},
+		Items: []*api.GenerateRequestTemplate{controlPlaneTemplate, mdTemplate("default-worker", "v1.21.0"), mdTemplate("gpu-worker", "v1.21.0")},
+	}
+
+	tests := []struct {
+		name    string
+		patch   *clusterv1.ClusterClassPatch
+		want    []api.GenerateResponsePatch
+		wantErr bool
+	}{
+		{
+			name: "generates patches with values, variables and templates using builtin variables",
+			patch: &clusterv1.ClusterClassPatch{
+				Name: "patch1",
+				Definitions: []clusterv1.PatchDefinition{
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion:     "controlplane.cluster.x-k8s.io/v1beta1",
+							Kind:           "ControlPlaneTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{ControlPlane: pointer.BoolPtr(true)},
+						},
+						JSONPatches: []clusterv1.JSONPatch{
+							{
+								Op:        "add",
+								Path:      "/spec/template/spec/imageRepository",
+								ValueFrom: &clusterv1.JSONPatchValue{Variable: pointer.StringPtr("imageRepository")},
+							},
+							{
+								Op:        "add",
+								Path:      "/spec/template/spec/clusterName",
+								ValueFrom: &clusterv1.JSONPatchValue{Variable: pointer.StringPtr("builtin.cluster.name")},
+							},
+						},
+					},
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+							Kind:       "MachineTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{
+								MachineDeploymentClass: &clusterv1.PatchSelectorMatchMachineDeploymentClass{Names: []string{"default-worker"}},
+							},
+						},
+						JSONPatches: []clusterv1.JSONPatch{
+							{
+								Op:        "replace",
+								Path:      "/spec/template/spec/image",
+								ValueFrom: &clusterv1.JSONPatchValue{Template: pointer.StringPtr("{{ .builtin.cluster.name }}-{{ .builtin.machineDeployment.class }}-{{ .builtin.machineDeployment.version }}")},
+							},
+							{
+								Op:    "add",
+								Path:  "/spec/template/spec/tags",
+								Value: &apiextensionsv1.JSON{Raw: []byte(`["a","b"]`)},
+							},
+							{
+								Op:   "remove",
+								Path: "/spec/template/spec/deprecated",
+							},
+						},
+					},
+				},
+			},
+			want: []api.GenerateResponsePatch{
+				{
+					TemplateRef: controlPlaneTemplate.TemplateRef,
+					Patch:       apiextensionsv1.JSON{Raw: []byte(`[{"op":"add","path":"/spec/template/spec/imageRepository","value":"registry.example.com"},{"op":"add","path":"/spec/template/spec/clusterName","value":"cluster1"}]`)},
+					PatchType:   api.JSONPatchType,
+				},
+				{
+					TemplateRef: mdTemplate("default-worker", "v1.21.0").TemplateRef,
+					Patch:       apiextensionsv1.JSON{Raw: []byte(`[{"op":"replace","path":"/spec/template/spec/image","value":"cluster1-default-worker-v1.21.0"},{"op":"add","path":"/spec/template/spec/tags","value":["a","b"]},{"op":"remove","path":"/spec/template/spec/deprecated"}]`)},
+					PatchType:   api.JSONPatchType,
+				},
+			},
+		},
+		{
+			name: "templates can render structured values",
+			patch: &clusterv1.ClusterClassPatch{
+				Name: "patch1",
+				Definitions: []clusterv1.PatchDefinition{
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion:     "controlplane.cluster.x-k8s.io/v1beta1",
+							Kind:           "ControlPlaneTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{ControlPlane: pointer.BoolPtr(true)},
+						},
+						JSONPatches: []clusterv1.JSONPatch{
+							{
+								Op:        "add",
+								Path:      "/spec/template/spec/labels",
+								ValueFrom: &clusterv1.JSONPatchValue{Template: pointer.StringPtr("cluster: {{ .builtin.cluster.name }}\nversion: {{ .builtin.controlPlane.version }}")},
+							},
+						},
+					},
+				},
+			},
+			want: []api.GenerateResponsePatch{
+				{
+					TemplateRef: controlPlaneTemplate.TemplateRef,
+					Patch:       apiextensionsv1.JSON{Raw: []byte(`[{"op":"add","path":"/spec/template/spec/labels","value":{"cluster":"cluster1","version":"v1.21.1"}}]`)},
+					PatchType:   api.JSONPatchType,
+				},
+			},
+		},
+		{
+			name: "fails for variables not set",
+			patch: &clusterv1.ClusterClassPatch{
+				Name: "patch1",
+				Definitions: []clusterv1.PatchDefinition{
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion:     "controlplane.cluster.x-k8s.io/v1beta1",
+							Kind:           "ControlPlaneTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{ControlPlane: pointer.BoolPtr(true)},
+						},
+						JSONPatches: []clusterv1.JSONPatch{
+							{
+								Op:        "add",
+								Path:      "/spec/template/spec/version",
+								ValueFrom: &clusterv1.JSONPatchValue{Template: pointer.StringPtr("{{ .builtin.machineDeployment.version }}")},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails for templates which cannot be parsed",
+			patch: &clusterv1.ClusterClassPatch{
+				Name: "patch1",
+				Definitions: []clusterv1.PatchDefinition{
+					{
+						Selector: clusterv1.PatchSelector{
+							APIVersion:     "controlplane.cluster.x-k8s.io/v1beta1",
+							Kind:           "ControlPlaneTemplate",
+							MatchResources: clusterv1.PatchSelectorMatch{ControlPlane: pointer.BoolPtr(true)},
+						},
+						JSONPatches: []clusterv1.JSONPatch{
+							{
+								Op:        "add",
+								Path:      "/spec/template/spec/version",
+								ValueFrom: &clusterv1.JSONPatchValue{Template: pointer.StringPtr("{{ .builtin.cluster.name ")},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := NewJSONPatchGenerator(tt.patch).Generate(context.Background(), req)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Items).To(Equal(tt.want))
+		})
+	}
+}
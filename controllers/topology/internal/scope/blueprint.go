@@ -38,6 +38,13 @@ type ClusterBlueprint struct {
 
 	// MachineDeployments holds the MachineDeploymentBlueprints derived from ClusterClass.
 	MachineDeployments map[string]*MachineDeploymentBlueprint
+
+	// MachinePools holds the MachinePoolBlueprints derived from ClusterClass.
+	MachinePools map[string]*MachinePoolBlueprint
+
+	// ClusterClassKey identifies the generation of the ClusterClass, and of the ClusterClasses it inherits from,
+	// the blueprint is derived from; it is empty if the blueprint should not be cached.
+	ClusterClassKey string
 }
 
 // ControlPlaneBlueprint holds the templates required for computing the desired state of a managed control plane.
@@ -47,6 +54,10 @@ type ControlPlaneBlueprint struct {
 
 	// InfrastructureMachineTemplate holds the infrastructure machine template for the control plane, if defined in the ClusterClass.
 	InfrastructureMachineTemplate *unstructured.Unstructured
+
+	// MachineHealthCheck holds the MachineHealthCheckClass for this ControlPlane, if defined in the ClusterClass.
+	// NOTE: This is a convenience copy of the machineHealthCheck field from ClusterClass.Spec.ControlPlane.
+	MachineHealthCheck *clusterv1.MachineHealthCheckClass
 }
 
 // MachineDeploymentBlueprint holds the templates required for computing the desired state of a managed MachineDeployment;
@@ -57,11 +68,34 @@ type MachineDeploymentBlueprint struct {
 	// NOTE: This is a convenience copy of the metadata field from Cluster.Spec.Topology.Workers.MachineDeployments[x].
 	Metadata clusterv1.ObjectMeta
 
+	// EnabledIf holds the template used to calculate if the MachineDeployment should be created.
+	// NOTE: This is a convenience copy of the enabledIf field from ClusterClass.Spec.Workers.MachineDeployments[x].
+	EnabledIf *string
+
 	// BootstrapTemplate holds the bootstrap template for a MachineDeployment referenced from ClusterClass.
 	BootstrapTemplate *unstructured.Unstructured
 
 	// InfrastructureMachineTemplate holds the infrastructure machine template for a MachineDeployment referenced from ClusterClass.
 	InfrastructureMachineTemplate *unstructured.Unstructured
+
+	// MachineHealthCheck holds the MachineHealthCheckClass for this MachineDeployment, if defined in the ClusterClass.
+	// NOTE: This is a convenience copy of the machineHealthCheck field from ClusterClass.Spec.Workers.MachineDeployments[x].
+	MachineHealthCheck *clusterv1.MachineHealthCheckClass
+}
+
+// MachinePoolBlueprint holds the templates required for computing the desired state of a managed MachinePool;
+// it also holds a copy of the MachinePool metadata from ClusterClass, thus providing all the required info
+// in a single place.
+type MachinePoolBlueprint struct {
+	// Metadata holds the metadata for a MachinePool.
+	// NOTE: This is a convenience copy of the metadata field from ClusterClass.Spec.Workers.MachinePools[x].
+	Metadata clusterv1.ObjectMeta
+
+	// BootstrapTemplate holds the bootstrap template for a MachinePool referenced from ClusterClass.
+	BootstrapTemplate *unstructured.Unstructured
+
+	// InfrastructureMachinePoolTemplate holds the infrastructure machine pool template for a MachinePool referenced from ClusterClass.
+	InfrastructureMachinePoolTemplate *unstructured.Unstructured
 }
 
 // HasControlPlaneInfrastructureMachine checks whether the clusterClass mandates the controlPlane has infrastructureMachines.
@@ -73,3 +107,48 @@ func (b *ClusterBlueprint) HasControlPlaneInfrastructureMachine() bool {
 func (b *ClusterBlueprint) HasMachineDeployments() bool {
 	return b.Topology.Workers != nil && len(b.Topology.Workers.MachineDeployments) > 0
 }
+
+// HasMachinePools checks whether the topology has MachinePools.
+func (b *ClusterBlueprint) HasMachinePools() bool {
+	return b.Topology.Workers != nil && len(b.Topology.Workers.MachinePools) > 0
+}
+
+// ControlPlaneMachineHealthCheckClass returns the MachineHealthCheckClass to be used for the control plane, if any.
+// A MachineHealthCheckClass defined in the Cluster topology entirely overrides the one defined in the ClusterClass;
+// if the MachineHealthCheck is disabled in the Cluster topology, or if the control plane machines are not managed
+// by the topology, nil is returned.
+func (b *ClusterBlueprint) ControlPlaneMachineHealthCheckClass() *clusterv1.MachineHealthCheckClass {
+	if !b.HasControlPlaneInfrastructureMachine() {
+		return nil
+	}
+	var classMHC *clusterv1.MachineHealthCheckClass
+	if b.ControlPlane != nil {
+		classMHC = b.ControlPlane.MachineHealthCheck
+	}
+	return machineHealthCheckClass(b.Topology.ControlPlane.MachineHealthCheck, classMHC)
+}
+
+// MachineDeploymentMachineHealthCheckClass returns the MachineHealthCheckClass to be used for a MachineDeployment
+// topology, if any.
+// A MachineHealthCheckClass defined in the Cluster topology entirely overrides the one defined in the ClusterClass;
+// if the MachineHealthCheck is disabled in the Cluster topology, nil is returned.
+func (b *ClusterBlueprint) MachineDeploymentMachineHealthCheckClass(md *clusterv1.MachineDeploymentTopology) *clusterv1.MachineHealthCheckClass {
+	var classMHC *clusterv1.MachineHealthCheckClass
+	if mdBlueprint, ok := b.MachineDeployments[md.Class]; ok {
+		classMHC = mdBlueprint.MachineHealthCheck
+	}
+	return machineHealthCheckClass(md.MachineHealthCheck, classMHC)
+}
+
+func machineHealthCheckClass(topologyMHC *clusterv1.MachineHealthCheckTopology, classMHC *clusterv1.MachineHealthCheckClass) *clusterv1.MachineHealthCheckClass {
+	if topologyMHC == nil {
+		return classMHC
+	}
+	if topologyMHC.Enable != nil && !*topologyMHC.Enable {
+		return nil
+	}
+	if !topologyMHC.MachineHealthCheckClass.IsZero() {
+		return &topologyMHC.MachineHealthCheckClass
+	}
+	return classMHC
+}
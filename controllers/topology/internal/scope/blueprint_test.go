@@ -0,0 +1,134 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestMachineDeploymentMachineHealthCheckClass(t *testing.T) {
+	classMHC := &clusterv1.MachineHealthCheckClass{
+		UnhealthyConditions: []clusterv1.UnhealthyCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+		},
+	}
+	topologyMHC := clusterv1.MachineHealthCheckClass{
+		UnhealthyConditions: []clusterv1.UnhealthyCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionFalse, Timeout: metav1.Duration{Duration: 10 * time.Minute}},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		classMHC    *clusterv1.MachineHealthCheckClass
+		topologyMHC *clusterv1.MachineHealthCheckTopology
+		want        *clusterv1.MachineHealthCheckClass
+	}{
+		{
+			name: "No MachineHealthCheck if not defined in the ClusterClass nor in the topology",
+			want: nil,
+		},
+		{
+			name:     "MachineHealthCheck from the ClusterClass",
+			classMHC: classMHC,
+			want:     classMHC,
+		},
+		{
+			name:        "MachineHealthCheck from the ClusterClass if the topology only enables it",
+			classMHC:    classMHC,
+			topologyMHC: &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(true)},
+			want:        classMHC,
+		},
+		{
+			name:        "No MachineHealthCheck if disabled in the topology",
+			classMHC:    classMHC,
+			topologyMHC: &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(false), MachineHealthCheckClass: topologyMHC},
+			want:        nil,
+		},
+		{
+			name:        "MachineHealthCheck from the topology overrides the ClusterClass",
+			classMHC:    classMHC,
+			topologyMHC: &clusterv1.MachineHealthCheckTopology{MachineHealthCheckClass: topologyMHC},
+			want:        &topologyMHC,
+		},
+		{
+			name:        "MachineHealthCheck from the topology if force enabled",
+			topologyMHC: &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(true), MachineHealthCheckClass: topologyMHC},
+			want:        &topologyMHC,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			b := &ClusterBlueprint{
+				MachineDeployments: map[string]*MachineDeploymentBlueprint{
+					"class1": {MachineHealthCheck: tt.classMHC},
+				},
+			}
+			md := &clusterv1.MachineDeploymentTopology{Class: "class1", Name: "md1", MachineHealthCheck: tt.topologyMHC}
+
+			g.Expect(b.MachineDeploymentMachineHealthCheckClass(md)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestControlPlaneMachineHealthCheckClass(t *testing.T) {
+	classMHC := &clusterv1.MachineHealthCheckClass{
+		UnhealthyConditions: []clusterv1.UnhealthyCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+		},
+	}
+
+	t.Run("No MachineHealthCheck if the control plane has no infrastructure machines", func(t *testing.T) {
+		g := NewWithT(t)
+
+		b := &ClusterBlueprint{
+			Topology:     &clusterv1.Topology{},
+			ClusterClass: &clusterv1.ClusterClass{},
+			ControlPlane: &ControlPlaneBlueprint{MachineHealthCheck: classMHC},
+		}
+		g.Expect(b.ControlPlaneMachineHealthCheckClass()).To(BeNil())
+	})
+
+	t.Run("MachineHealthCheck from the ClusterClass unless disabled in the topology", func(t *testing.T) {
+		g := NewWithT(t)
+
+		b := &ClusterBlueprint{
+			Topology: &clusterv1.Topology{},
+			ClusterClass: &clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						MachineInfrastructure: &clusterv1.LocalObjectTemplate{Ref: &corev1.ObjectReference{}},
+					},
+				},
+			},
+			ControlPlane: &ControlPlaneBlueprint{MachineHealthCheck: classMHC},
+		}
+		g.Expect(b.ControlPlaneMachineHealthCheckClass()).To(Equal(classMHC))
+
+		b.Topology.ControlPlane.MachineHealthCheck = &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(false)}
+		g.Expect(b.ControlPlaneMachineHealthCheckClass()).To(BeNil())
+	})
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package scope
 
 import (
+	"sort"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/internal/mdutil"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 )
 
 // ClusterState holds all the objects representing the state of a managed Cluster topology.
@@ -37,6 +40,9 @@ type ClusterState struct {
 
 	// MachineDeployments holds the machine deployments in the Cluster.
 	MachineDeployments MachineDeploymentsStateMap
+
+	// MachinePools holds the machine pools in the Cluster.
+	MachinePools MachinePoolsStateMap
 }
 
 // ControlPlaneState holds all the objects representing the state of a managed control plane.
@@ -46,6 +52,9 @@ type ControlPlaneState struct {
 
 	// InfrastructureMachineTemplate holds the infrastructure template referenced by the ControlPlane object.
 	InfrastructureMachineTemplate *unstructured.Unstructured
+
+	// MachineHealthCheck holds the MachineHealthCheck for the control plane machines, if any.
+	MachineHealthCheck *clusterv1.MachineHealthCheck
 }
 
 // MachineDeploymentsStateMap holds a collection of MachineDeployment states.
@@ -62,6 +71,19 @@ func (mds MachineDeploymentsStateMap) IsAnyRollingOut() bool {
 	return false
 }
 
+// RollingOut returns the sorted list of the names of the machine deployments
+// which are upgrading.
+func (mds MachineDeploymentsStateMap) RollingOut() []string {
+	names := []string{}
+	for _, md := range mds {
+		if md.IsRollingOut() {
+			names = append(names, md.Object.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // MachineDeploymentState holds all the objects representing the state of a managed deployment.
 type MachineDeploymentState struct {
 	// Object holds the MachineDeployment object.
@@ -72,6 +94,9 @@ type MachineDeploymentState struct {
 
 	// InfrastructureMachineTemplate holds the infrastructure machine template referenced by the MachineDeployment object.
 	InfrastructureMachineTemplate *unstructured.Unstructured
+
+	// MachineHealthCheck holds the MachineHealthCheck for the MachineDeployment machines, if any.
+	MachineHealthCheck *clusterv1.MachineHealthCheck
 }
 
 // IsRollingOut determines if the machine deployment is upgrading.
@@ -80,3 +105,18 @@ type MachineDeploymentState struct {
 func (md *MachineDeploymentState) IsRollingOut() bool {
 	return !mdutil.DeploymentComplete(md.Object, &md.Object.Status) || *md.Object.Spec.Replicas != md.Object.Status.ReadyReplicas
 }
+
+// MachinePoolsStateMap holds a collection of MachinePool states.
+type MachinePoolsStateMap map[string]*MachinePoolState
+
+// MachinePoolState holds all the objects representing the state of a managed pool.
+type MachinePoolState struct {
+	// Object holds the MachinePool object.
+	Object *expv1.MachinePool
+
+	// BootstrapObject holds the bootstrap config referenced by the MachinePool object.
+	BootstrapObject *unstructured.Unstructured
+
+	// InfrastructureMachinePoolObject holds the infrastructure machine pool referenced by the MachinePool object.
+	InfrastructureMachinePoolObject *unstructured.Unstructured
+}
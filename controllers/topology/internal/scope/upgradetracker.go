@@ -22,20 +22,30 @@ const maxMachineDeploymentUpgradeConcurrency = 1
 
 // UpgradeTracker is a helper to capture the upgrade status and make upgrade decisions.
 type UpgradeTracker struct {
+	ControlPlane       ControlPlaneUpgradeTracker
 	MachineDeployments MachineDeploymentUpgradeTracker
 }
 
+// ControlPlaneUpgradeTracker holds the current upgrade status of the control plane.
+type ControlPlaneUpgradeTracker struct {
+	// PendingUpgrade is true if the control plane should be upgraded to the topology version,
+	// but the upgrade is on hold.
+	PendingUpgrade bool
+}
+
 // MachineDeploymentUpgradeTracker holds the current upgrade status and makes upgrade
 // decisions for MachineDeployments.
 type MachineDeploymentUpgradeTracker struct {
-	names sets.String
+	names        sets.String
+	pendingNames sets.String
 }
 
 // NewUpgradeTracker returns an upgrade tracker with empty tracking information.
 func NewUpgradeTracker() *UpgradeTracker {
 	return &UpgradeTracker{
 		MachineDeployments: MachineDeploymentUpgradeTracker{
-			names: sets.NewString(),
+			names:        sets.NewString(),
+			pendingNames: sets.NewString(),
 		},
 	}
 }
@@ -50,3 +60,19 @@ func (m *MachineDeploymentUpgradeTracker) Insert(name string) {
 func (m *MachineDeploymentUpgradeTracker) AllowUpgrade() bool {
 	return m.names.Len() < maxMachineDeploymentUpgradeConcurrency
 }
+
+// MarkPendingUpgrade adds name to the set of MachineDeployments which should be upgraded to the topology
+// version, but the upgrade is on hold.
+func (m *MachineDeploymentUpgradeTracker) MarkPendingUpgrade(name string) {
+	m.pendingNames.Insert(name)
+}
+
+// PendingUpgradeNames returns the sorted list of MachineDeployments with the upgrade on hold.
+func (m *MachineDeploymentUpgradeTracker) PendingUpgradeNames() []string {
+	return m.pendingNames.List()
+}
+
+// PendingUpgrade returns true if the upgrade of at least one MachineDeployment is on hold.
+func (m *MachineDeploymentUpgradeTracker) PendingUpgrade() bool {
+	return m.pendingNames.Len() > 0
+}
@@ -20,7 +20,9 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
 	"sigs.k8s.io/cluster-api/util"
@@ -48,6 +50,8 @@ type MachineDeploymentReconciler struct {
 	// race conditions caused by an outdated cache.
 	APIReader        client.Reader
 	WatchFilterValue string
+
+	recorder record.EventRecorder
 }
 
 func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -64,6 +68,7 @@ func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
+	r.recorder = mgr.GetEventRecorderFor("topology/machinedeployment")
 	return nil
 }
 
@@ -105,7 +110,7 @@ func (r *MachineDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 	// Handle deletion reconciliation loop.
 	if !md.ObjectMeta.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, md)
+		return r.reconcileDelete(ctx, cluster, md)
 	}
 
 	// Nothing to do.
@@ -114,7 +119,7 @@ func (r *MachineDeploymentReconciler) Reconcile(ctx context.Context, req ctrl.Re
 
 // reconcileDelete deletes templates referenced in a MachineDeployment, if the templates are not used by other
 // MachineDeployments or MachineSets.
-func (r *MachineDeploymentReconciler) reconcileDelete(ctx context.Context, md *clusterv1.MachineDeployment) (ctrl.Result, error) {
+func (r *MachineDeploymentReconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Cluster, md *clusterv1.MachineDeployment) (ctrl.Result, error) {
 	// Get the corresponding MachineSets.
 	msList, err := getMachineSetsForDeployment(ctx, r.APIReader, client.ObjectKeyFromObject(md))
 	if err != nil {
@@ -129,13 +134,21 @@ func (r *MachineDeploymentReconciler) reconcileDelete(ctx context.Context, md *c
 
 	// Delete unused templates.
 	ref := md.Spec.Template.Spec.Bootstrap.ConfigRef
-	if err := deleteTemplateIfUnused(ctx, r.Client, templatesInUse, ref); err != nil {
+	deleted, err := deleteTemplateIfUnused(ctx, r.Client, templatesInUse, ref)
+	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to delete bootstrap template for %s", tlog.KObj{Obj: md})
 	}
+	if deleted {
+		recordEvent(r.recorder, cluster, corev1.EventTypeNormal, deleteEventReason, "Deleted %s", tlog.KRef{Ref: ref})
+	}
 	ref = &md.Spec.Template.Spec.InfrastructureRef
-	if err := deleteTemplateIfUnused(ctx, r.Client, templatesInUse, ref); err != nil {
+	deleted, err = deleteTemplateIfUnused(ctx, r.Client, templatesInUse, ref)
+	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to delete infrastructure template for %s", tlog.KObj{Obj: md})
 	}
+	if deleted {
+		recordEvent(r.recorder, cluster, corev1.EventTypeNormal, deleteEventReason, "Deleted %s", tlog.KRef{Ref: ref})
+	}
 
 	// Remove the finalizer so the MachineDeployment can be garbage collected by Kubernetes.
 	patchHelper, err := patch.NewHelper(md, r.Client)
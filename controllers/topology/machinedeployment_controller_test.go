@@ -24,8 +24,9 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
-	"sigs.k8s.io/cluster-api/internal/builder"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -34,6 +35,7 @@ import (
 func TestMachineDeploymentReconciler_ReconcileDelete(t *testing.T) {
 	deletionTimeStamp := metav1.Now()
 
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
 	mdBT := builder.BootstrapTemplate(metav1.NamespaceDefault, "mdBT").Build()
 	mdIMT := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "mdIMT").Build()
 	md := builder.MachineDeployment(metav1.NamespaceDefault, "md").
@@ -50,11 +52,13 @@ func TestMachineDeploymentReconciler_ReconcileDelete(t *testing.T) {
 			WithObjects(md, mdBT, mdIMT).
 			Build()
 
+		recorder := record.NewFakeRecorder(10)
 		r := &MachineDeploymentReconciler{
 			Client:    fakeClient,
 			APIReader: fakeClient,
+			recorder:  recorder,
 		}
-		_, err := r.reconcileDelete(ctx, md)
+		_, err := r.reconcileDelete(ctx, cluster, md)
 		g.Expect(err).ToNot(HaveOccurred())
 
 		afterMD := &clusterv1.MachineDeployment{}
@@ -63,6 +67,11 @@ func TestMachineDeploymentReconciler_ReconcileDelete(t *testing.T) {
 		g.Expect(controllerutil.ContainsFinalizer(afterMD, clusterv1.MachineDeploymentTopologyFinalizer)).To(BeFalse())
 		g.Expect(templateExists(fakeClient, mdBT)).To(BeFalse())
 		g.Expect(templateExists(fakeClient, mdIMT)).To(BeFalse())
+
+		// An event is recorded on the Cluster for each deleted template.
+		g.Expect(recorder.Events).To(HaveLen(2))
+		g.Expect(<-recorder.Events).To(Equal("Normal TopologyDelete Deleted GenericBootstrapConfigTemplate/mdBT"))
+		g.Expect(<-recorder.Events).To(Equal("Normal TopologyDelete Deleted GenericInfrastructureMachineTemplate/mdIMT"))
 	})
 
 	t.Run("Should delete infra template of a MachineDeployment without a bootstrap template", func(t *testing.T) {
@@ -83,7 +92,7 @@ func TestMachineDeploymentReconciler_ReconcileDelete(t *testing.T) {
 			Client:    fakeClient,
 			APIReader: fakeClient,
 		}
-		_, err := r.reconcileDelete(ctx, mdWithoutBootstrapTemplate)
+		_, err := r.reconcileDelete(ctx, cluster, mdWithoutBootstrapTemplate)
 		g.Expect(err).ToNot(HaveOccurred())
 
 		afterMD := &clusterv1.MachineDeployment{}
@@ -113,7 +122,7 @@ func TestMachineDeploymentReconciler_ReconcileDelete(t *testing.T) {
 			Client:    fakeClient,
 			APIReader: fakeClient,
 		}
-		_, err := r.reconcileDelete(ctx, md)
+		_, err := r.reconcileDelete(ctx, cluster, md)
 		g.Expect(err).ToNot(HaveOccurred())
 
 		afterMD := &clusterv1.MachineDeployment{}
@@ -20,9 +20,11 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
 	"sigs.k8s.io/cluster-api/util"
@@ -50,6 +52,8 @@ type MachineSetReconciler struct {
 	// race conditions caused by an outdated cache.
 	APIReader        client.Reader
 	WatchFilterValue string
+
+	recorder record.EventRecorder
 }
 
 func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -66,6 +70,7 @@ func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
+	r.recorder = mgr.GetEventRecorderFor("topology/machineset")
 	return nil
 }
 
@@ -107,7 +112,7 @@ func (r *MachineSetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 	// Handle deletion reconciliation loop.
 	if !ms.ObjectMeta.DeletionTimestamp.IsZero() {
-		return r.reconcileDelete(ctx, ms)
+		return r.reconcileDelete(ctx, cluster, ms)
 	}
 
 	// Nothing to do.
@@ -116,7 +121,7 @@ func (r *MachineSetReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 // reconcileDelete deletes templates referenced in a MachineSet, if the templates are not used by other
 // MachineDeployments or MachineSets.
-func (r *MachineSetReconciler) reconcileDelete(ctx context.Context, ms *clusterv1.MachineSet) (ctrl.Result, error) {
+func (r *MachineSetReconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet) (ctrl.Result, error) {
 	// Gets the name of the MachineDeployment that controls this MachineSet.
 	mdName, err := getMachineDeploymentName(ms)
 	if err != nil {
@@ -149,13 +154,21 @@ func (r *MachineSetReconciler) reconcileDelete(ctx context.Context, ms *clusterv
 
 	// Delete unused templates.
 	ref := ms.Spec.Template.Spec.Bootstrap.ConfigRef
-	if err := deleteTemplateIfUnused(ctx, r.Client, templatesInUse, ref); err != nil {
+	deleted, err := deleteTemplateIfUnused(ctx, r.Client, templatesInUse, ref)
+	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to delete bootstrap template for %s", tlog.KObj{Obj: ms})
 	}
+	if deleted {
+		recordEvent(r.recorder, cluster, corev1.EventTypeNormal, deleteEventReason, "Deleted %s", tlog.KRef{Ref: ref})
+	}
 	ref = &ms.Spec.Template.Spec.InfrastructureRef
-	if err := deleteTemplateIfUnused(ctx, r.Client, templatesInUse, ref); err != nil {
+	deleted, err = deleteTemplateIfUnused(ctx, r.Client, templatesInUse, ref)
+	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to delete infrastructure template for %s", tlog.KObj{Obj: ms})
 	}
+	if deleted {
+		recordEvent(r.recorder, cluster, corev1.EventTypeNormal, deleteEventReason, "Deleted %s", tlog.KRef{Ref: ref})
+	}
 
 	// Remove the finalizer so the MachineSet can be garbage collected by Kubernetes.
 	patchHelper, err := patch.NewHelper(ms, r.Client)
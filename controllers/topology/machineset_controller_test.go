@@ -22,7 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
-	"sigs.k8s.io/cluster-api/internal/builder"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -32,6 +32,7 @@ func TestMachineSetReconciler_ReconcileDelete(t *testing.T) {
 	deletionTimeStamp := metav1.Now()
 
 	mdName := "md"
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
 
 	msBT := builder.BootstrapTemplate(metav1.NamespaceDefault, "msBT").Build()
 	msIMT := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "msIMT").Build()
@@ -63,7 +64,7 @@ func TestMachineSetReconciler_ReconcileDelete(t *testing.T) {
 			Client:    fakeClient,
 			APIReader: fakeClient,
 		}
-		_, err := r.reconcileDelete(ctx, ms)
+		_, err := r.reconcileDelete(ctx, cluster, ms)
 		g.Expect(err).ToNot(HaveOccurred())
 
 		afterMS := &clusterv1.MachineSet{}
@@ -102,7 +103,7 @@ func TestMachineSetReconciler_ReconcileDelete(t *testing.T) {
 			Client:    fakeClient,
 			APIReader: fakeClient,
 		}
-		_, err := r.reconcileDelete(ctx, msWithoutBootstrapTemplate)
+		_, err := r.reconcileDelete(ctx, cluster, msWithoutBootstrapTemplate)
 		g.Expect(err).ToNot(HaveOccurred())
 
 		afterMS := &clusterv1.MachineSet{}
@@ -129,7 +130,7 @@ func TestMachineSetReconciler_ReconcileDelete(t *testing.T) {
 			Client:    fakeClient,
 			APIReader: fakeClient,
 		}
-		_, err := r.reconcileDelete(ctx, ms)
+		_, err := r.reconcileDelete(ctx, cluster, ms)
 		g.Expect(err).ToNot(HaveOccurred())
 
 		afterMS := &clusterv1.MachineSet{}
@@ -175,7 +176,7 @@ func TestMachineSetReconciler_ReconcileDelete(t *testing.T) {
 			Client:    fakeClient,
 			APIReader: fakeClient,
 		}
-		_, err := r.reconcileDelete(ctx, ms)
+		_, err := r.reconcileDelete(ctx, cluster, ms)
 		g.Expect(err).ToNot(HaveOccurred())
 
 		afterMS := &clusterv1.MachineSet{}
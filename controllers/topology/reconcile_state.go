@@ -25,14 +25,28 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apiserver/pkg/storage/names"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/contract"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/mergepatch"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/topology/check"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	createEventReason = "TopologyCreate"
+	updateEventReason = "TopologyUpdate"
+	deleteEventReason = "TopologyDelete"
+
+	// reconcileFailedEventReason is used for Warning events recorded when reconciling the topology fails.
+	reconcileFailedEventReason = "TopologyReconcileFailed"
+	// admissionRejectedEventReason is used for Warning events recorded when the API server rejects the creation
+	// or the update of an object of the topology, e.g. because of a validating webhook.
+	admissionRejectedEventReason = "TopologyAdmissionRejected"
+)
+
 // reconcileState reconciles the current and desired state of the managed Cluster topology.
 // NOTE: We are assuming all the required objects are provided as input; also, in case of any error,
 // the entire reconcile operation will fail. This might be improved in the future if support for reconciling
@@ -57,13 +71,22 @@ func (r *ClusterReconciler) reconcileState(ctx context.Context, s *scope.Scope)
 	}
 
 	// Reconcile desired state of the MachineDeployment objects.
-	return r.reconcileMachineDeployments(ctx, s)
+	if err := r.reconcileMachineDeployments(ctx, s); err != nil {
+		return err
+	}
+
+	// Reconcile desired state of the MachinePool objects.
+	// NOTE: MachinePools are supported only if the MachinePool feature flag is enabled.
+	if feature.Gates.Enabled(feature.MachinePool) {
+		return r.reconcileMachinePools(ctx, s)
+	}
+	return nil
 }
 
 // reconcileInfrastructureCluster reconciles the desired state of the InfrastructureCluster object.
 func (r *ClusterReconciler) reconcileInfrastructureCluster(ctx context.Context, s *scope.Scope) error {
 	ctx, _ = tlog.LoggerFrom(ctx).WithObject(s.Desired.InfrastructureCluster).Into(ctx)
-	return r.reconcileReferencedObject(ctx, s.Current.InfrastructureCluster, s.Desired.InfrastructureCluster, mergepatch.IgnorePaths(contract.InfrastructureCluster().IgnorePaths()))
+	return r.reconcileReferencedObject(ctx, s.Current.Cluster, s.Current.InfrastructureCluster, s.Desired.InfrastructureCluster, mergepatch.IgnorePaths(contract.InfrastructureCluster().IgnorePaths()))
 }
 
 // reconcileControlPlane works to bring the current state of a managed topology in line with the desired state. This involves
@@ -80,6 +103,7 @@ func (r *ClusterReconciler) reconcileControlPlane(ctx context.Context, s *scope.
 
 		// Create or update the MachineInfrastructureTemplate of the control plane.
 		err = r.reconcileReferencedTemplate(ctx, reconcileReferencedTemplateInput{
+			cluster:              s.Current.Cluster,
 			ref:                  cpInfraRef,
 			current:              s.Current.ControlPlane.InfrastructureMachineTemplate,
 			desired:              s.Desired.ControlPlane.InfrastructureMachineTemplate,
@@ -100,7 +124,7 @@ func (r *ClusterReconciler) reconcileControlPlane(ctx context.Context, s *scope.
 
 	// Create or update the ControlPlaneObject for the ControlPlaneState.
 	ctx, _ = tlog.LoggerFrom(ctx).WithObject(s.Desired.ControlPlane.Object).Into(ctx)
-	if err := r.reconcileReferencedObject(ctx, s.Current.ControlPlane.Object, s.Desired.ControlPlane.Object, mergepatch.AuthoritativePaths{
+	if err := r.reconcileReferencedObject(ctx, s.Current.Cluster, s.Current.ControlPlane.Object, s.Desired.ControlPlane.Object, mergepatch.AuthoritativePaths{
 		// Note: we want to be authoritative WRT machine's metadata labels and annotations.
 		// This has the nice benefit that it greatly simplify the UX around ControlPlaneClass.Metadata and
 		// ControlPlaneTopology.Metadata, given that changes are reflected into generated objects without
@@ -112,6 +136,59 @@ func (r *ClusterReconciler) reconcileControlPlane(ctx context.Context, s *scope.
 		return errors.Wrapf(err, "failed to update %s", tlog.KObj{Obj: s.Desired.ControlPlane.Object})
 	}
 
+	// Create, update or delete the MachineHealthCheck for the control plane machines.
+	if err := r.reconcileMachineHealthCheck(ctx, s.Current.Cluster, s.Current.ControlPlane.MachineHealthCheck, s.Desired.ControlPlane.MachineHealthCheck); err != nil {
+		return errors.Wrapf(err, "failed to reconcile MachineHealthCheck for %s", tlog.KObj{Obj: s.Desired.ControlPlane.Object})
+	}
+
+	return nil
+}
+
+// reconcileMachineHealthCheck creates, patches or deletes a MachineHealthCheck in order to bring the current
+// state in line with the desired state; a nil desired state means that the MachineHealthCheck should not exist.
+func (r *ClusterReconciler) reconcileMachineHealthCheck(ctx context.Context, cluster *clusterv1.Cluster, current, desired *clusterv1.MachineHealthCheck) error {
+	log := tlog.LoggerFrom(ctx)
+
+	// If a current MachineHealthCheck doesn't exist but there is a desired MachineHealthCheck, create it.
+	if current == nil && desired != nil {
+		log.Infof("Creating %s", tlog.KObj{Obj: desired})
+		if err := r.Client.Create(ctx, desired.DeepCopy()); err != nil {
+			return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: desired})
+		}
+		r.recordEvent(cluster, createEventReason, "Created %s", tlog.KObj{Obj: desired})
+		return nil
+	}
+
+	// If a current MachineHealthCheck exists but there is no desired MachineHealthCheck, delete it.
+	if current != nil && desired == nil {
+		log.Infof("Deleting %s", tlog.KObj{Obj: current})
+		if err := r.Client.Delete(ctx, current); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete %s", tlog.KObj{Obj: current})
+		}
+		r.recordEvent(cluster, deleteEventReason, "Deleted %s", tlog.KObj{Obj: current})
+		return nil
+	}
+
+	// If there is no current and no desired MachineHealthCheck, there is nothing to do.
+	if current == nil && desired == nil {
+		return nil
+	}
+
+	// Check differences between current and desired MachineHealthCheck, and eventually patch the current object.
+	patchHelper, err := mergepatch.NewHelper(current, desired, r.Client)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create patch helper for %s", tlog.KObj{Obj: current})
+	}
+	if !patchHelper.HasChanges() {
+		log.V(3).Infof("No changes for %s", tlog.KObj{Obj: current})
+		return nil
+	}
+
+	log.Infof("Patching %s", tlog.KObj{Obj: current})
+	if err := patchHelper.Patch(ctx); err != nil {
+		return errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: current})
+	}
+	r.recordEvent(cluster, updateEventReason, "Updated %s", tlog.KObj{Obj: current})
 	return nil
 }
 
@@ -123,7 +200,9 @@ func (r *ClusterReconciler) reconcileCluster(ctx context.Context, s *scope.Scope
 	ctx, log := tlog.LoggerFrom(ctx).WithObject(s.Desired.Cluster).Into(ctx)
 
 	// Check differences between current and desired state, and eventually patch the current object.
-	patchHelper, err := mergepatch.NewHelper(s.Current.Cluster, s.Desired.Cluster, r.Client)
+	// NOTE: A copy of the current Cluster is patched, so the Cluster object used for patching the TopologyReconciled
+	// condition at the end of the reconcile is not modified (including the resourceVersion).
+	patchHelper, err := mergepatch.NewHelper(s.Current.Cluster.DeepCopy(), s.Desired.Cluster, r.Client)
 	if err != nil {
 		return errors.Wrapf(err, "failed to create patch helper for %s", tlog.KObj{Obj: s.Current.Cluster})
 	}
@@ -136,6 +215,7 @@ func (r *ClusterReconciler) reconcileCluster(ctx context.Context, s *scope.Scope
 	if err := patchHelper.Patch(ctx); err != nil {
 		return errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: s.Current.Cluster})
 	}
+	r.recordEvent(s.Current.Cluster, updateEventReason, "Updated %s", tlog.KObj{Obj: s.Current.Cluster})
 	return nil
 }
 
@@ -146,24 +226,34 @@ func (r *ClusterReconciler) reconcileMachineDeployments(ctx context.Context, s *
 	// Create MachineDeployments.
 	for _, mdTopologyName := range diff.toCreate {
 		md := s.Desired.MachineDeployments[mdTopologyName]
-		if err := r.createMachineDeployment(ctx, md); err != nil {
+		if err := r.createMachineDeployment(ctx, s.Current.Cluster, md); err != nil {
 			return err
 		}
+		if err := r.reconcileMachineHealthCheck(ctx, s.Current.Cluster, nil, md.MachineHealthCheck); err != nil {
+			return errors.Wrapf(err, "failed to reconcile MachineHealthCheck for %s", tlog.KObj{Obj: md.Object})
+		}
 	}
 
 	// Update MachineDeployments.
 	for _, mdTopologyName := range diff.toUpdate {
 		currentMD := s.Current.MachineDeployments[mdTopologyName]
 		desiredMD := s.Desired.MachineDeployments[mdTopologyName]
-		if err := r.updateMachineDeployment(ctx, s.Current.Cluster.Name, mdTopologyName, currentMD, desiredMD); err != nil {
+		if err := r.updateMachineDeployment(ctx, s.Current.Cluster, mdTopologyName, currentMD, desiredMD); err != nil {
 			return err
 		}
+		if err := r.reconcileMachineHealthCheck(ctx, s.Current.Cluster, currentMD.MachineHealthCheck, desiredMD.MachineHealthCheck); err != nil {
+			return errors.Wrapf(err, "failed to reconcile MachineHealthCheck for %s", tlog.KObj{Obj: currentMD.Object})
+		}
 	}
 
 	// Delete MachineDeployments.
 	for _, mdTopologyName := range diff.toDelete {
 		md := s.Current.MachineDeployments[mdTopologyName]
-		if err := r.deleteMachineDeployment(ctx, md); err != nil {
+		// Delete the MachineHealthCheck first, so it doesn't remediate machines while the MachineDeployment is deleted.
+		if err := r.reconcileMachineHealthCheck(ctx, s.Current.Cluster, md.MachineHealthCheck, nil); err != nil {
+			return errors.Wrapf(err, "failed to delete MachineHealthCheck for %s", tlog.KObj{Obj: md.Object})
+		}
+		if err := r.deleteMachineDeployment(ctx, s.Current.Cluster, md); err != nil {
 			return err
 		}
 	}
@@ -172,11 +262,12 @@ func (r *ClusterReconciler) reconcileMachineDeployments(ctx context.Context, s *
 }
 
 // createMachineDeployment creates a MachineDeployment and the corresponding Templates.
-func (r *ClusterReconciler) createMachineDeployment(ctx context.Context, md *scope.MachineDeploymentState) error {
+func (r *ClusterReconciler) createMachineDeployment(ctx context.Context, cluster *clusterv1.Cluster, md *scope.MachineDeploymentState) error {
 	log := tlog.LoggerFrom(ctx).WithMachineDeployment(md.Object)
 
 	ctx, _ = log.WithObject(md.InfrastructureMachineTemplate).Into(ctx)
 	if err := r.reconcileReferencedTemplate(ctx, reconcileReferencedTemplateInput{
+		cluster: cluster,
 		desired: md.InfrastructureMachineTemplate,
 	}); err != nil {
 		return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: md.Object})
@@ -184,6 +275,7 @@ func (r *ClusterReconciler) createMachineDeployment(ctx context.Context, md *sco
 
 	ctx, _ = log.WithObject(md.BootstrapTemplate).Into(ctx)
 	if err := r.reconcileReferencedTemplate(ctx, reconcileReferencedTemplateInput{
+		cluster: cluster,
 		desired: md.BootstrapTemplate,
 	}); err != nil {
 		return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: md.Object})
@@ -194,19 +286,21 @@ func (r *ClusterReconciler) createMachineDeployment(ctx context.Context, md *sco
 	if err := r.Client.Create(ctx, md.Object.DeepCopy()); err != nil {
 		return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: md.Object})
 	}
+	r.recordEvent(cluster, createEventReason, "Created %s", tlog.KObj{Obj: md.Object})
 	return nil
 }
 
 // updateMachineDeployment updates a MachineDeployment. Also rotates the corresponding Templates if necessary.
-func (r *ClusterReconciler) updateMachineDeployment(ctx context.Context, clusterName string, mdTopologyName string, currentMD, desiredMD *scope.MachineDeploymentState) error {
+func (r *ClusterReconciler) updateMachineDeployment(ctx context.Context, cluster *clusterv1.Cluster, mdTopologyName string, currentMD, desiredMD *scope.MachineDeploymentState) error {
 	log := tlog.LoggerFrom(ctx).WithMachineDeployment(desiredMD.Object)
 
 	ctx, _ = log.WithObject(desiredMD.InfrastructureMachineTemplate).Into(ctx)
 	if err := r.reconcileReferencedTemplate(ctx, reconcileReferencedTemplateInput{
+		cluster:              cluster,
 		ref:                  &desiredMD.Object.Spec.Template.Spec.InfrastructureRef,
 		current:              currentMD.InfrastructureMachineTemplate,
 		desired:              desiredMD.InfrastructureMachineTemplate,
-		templateNamePrefix:   infrastructureMachineTemplateNamePrefix(clusterName, mdTopologyName),
+		templateNamePrefix:   infrastructureMachineTemplateNamePrefix(cluster.Name, mdTopologyName),
 		compatibilityChecker: check.ReferencedObjectsAreCompatible,
 	}); err != nil {
 		return errors.Wrapf(err, "failed to update %s", tlog.KObj{Obj: currentMD.Object})
@@ -214,10 +308,11 @@ func (r *ClusterReconciler) updateMachineDeployment(ctx context.Context, cluster
 
 	ctx, _ = log.WithObject(desiredMD.BootstrapTemplate).Into(ctx)
 	if err := r.reconcileReferencedTemplate(ctx, reconcileReferencedTemplateInput{
+		cluster:              cluster,
 		ref:                  desiredMD.Object.Spec.Template.Spec.Bootstrap.ConfigRef,
 		current:              currentMD.BootstrapTemplate,
 		desired:              desiredMD.BootstrapTemplate,
-		templateNamePrefix:   bootstrapTemplateNamePrefix(clusterName, mdTopologyName),
+		templateNamePrefix:   bootstrapTemplateNamePrefix(cluster.Name, mdTopologyName),
 		compatibilityChecker: check.ObjectsAreInTheSameNamespace,
 	}); err != nil {
 		return errors.Wrapf(err, "failed to update %s", tlog.KObj{Obj: currentMD.Object})
@@ -246,19 +341,21 @@ func (r *ClusterReconciler) updateMachineDeployment(ctx context.Context, cluster
 	if err := patchHelper.Patch(ctx); err != nil {
 		return errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: currentMD.Object})
 	}
+	r.recordEvent(cluster, updateEventReason, "Updated %s", tlog.KObj{Obj: currentMD.Object})
 
 	// We want to call both cleanup functions even if one of them fails to clean up as much as possible.
 	return nil
 }
 
 // deleteMachineDeployment deletes a MachineDeployment.
-func (r *ClusterReconciler) deleteMachineDeployment(ctx context.Context, md *scope.MachineDeploymentState) error {
+func (r *ClusterReconciler) deleteMachineDeployment(ctx context.Context, cluster *clusterv1.Cluster, md *scope.MachineDeploymentState) error {
 	log := tlog.LoggerFrom(ctx).WithMachineDeployment(md.Object).WithObject(md.Object)
 
 	log.Infof("Deleting %s", tlog.KObj{Obj: md.Object})
 	if err := r.Client.Delete(ctx, md.Object); err != nil && !apierrors.IsNotFound(err) {
 		return errors.Wrapf(err, "failed to delete %s", tlog.KObj{Obj: md.Object})
 	}
+	r.recordEvent(cluster, deleteEventReason, "Deleted %s", tlog.KObj{Obj: md.Object})
 	return nil
 }
 
@@ -288,10 +385,138 @@ func calculateMachineDeploymentDiff(current, desired map[string]*scope.MachineDe
 	return diff
 }
 
+// reconcileMachinePools reconciles the desired state of the MachinePool objects.
+func (r *ClusterReconciler) reconcileMachinePools(ctx context.Context, s *scope.Scope) error {
+	diff := calculateMachinePoolDiff(s.Current.MachinePools, s.Desired.MachinePools)
+
+	// Create MachinePools.
+	for _, mpTopologyName := range diff.toCreate {
+		mp := s.Desired.MachinePools[mpTopologyName]
+		if err := r.createMachinePool(ctx, s.Current.Cluster, mp); err != nil {
+			return err
+		}
+	}
+
+	// Update MachinePools.
+	for _, mpTopologyName := range diff.toUpdate {
+		currentMP := s.Current.MachinePools[mpTopologyName]
+		desiredMP := s.Desired.MachinePools[mpTopologyName]
+		if err := r.updateMachinePool(ctx, s.Current.Cluster, currentMP, desiredMP); err != nil {
+			return err
+		}
+	}
+
+	// Delete MachinePools.
+	for _, mpTopologyName := range diff.toDelete {
+		mp := s.Current.MachinePools[mpTopologyName]
+		if err := r.deleteMachinePool(ctx, s.Current.Cluster, mp); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createMachinePool creates a MachinePool and the corresponding bootstrap config and infrastructure machine pool.
+func (r *ClusterReconciler) createMachinePool(ctx context.Context, cluster *clusterv1.Cluster, mp *scope.MachinePoolState) error {
+	log := tlog.LoggerFrom(ctx).WithMachinePool(mp.Object)
+
+	ctx, _ = log.WithObject(mp.InfrastructureMachinePoolObject).Into(ctx)
+	if err := r.reconcileReferencedObject(ctx, cluster, nil, mp.InfrastructureMachinePoolObject); err != nil {
+		return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: mp.Object})
+	}
+
+	ctx, _ = log.WithObject(mp.BootstrapObject).Into(ctx)
+	if err := r.reconcileReferencedObject(ctx, cluster, nil, mp.BootstrapObject); err != nil {
+		return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: mp.Object})
+	}
+
+	log = log.WithObject(mp.Object)
+	log.Infof(fmt.Sprintf("Creating %s", tlog.KObj{Obj: mp.Object}))
+	if err := r.Client.Create(ctx, mp.Object.DeepCopy()); err != nil {
+		return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: mp.Object})
+	}
+	r.recordEvent(cluster, createEventReason, "Created %s", tlog.KObj{Obj: mp.Object})
+	return nil
+}
+
+// updateMachinePool updates a MachinePool and the corresponding bootstrap config and infrastructure machine pool.
+// NOTE: Differently from MachineDeployments, the referenced objects are not templates and they are updated in place.
+func (r *ClusterReconciler) updateMachinePool(ctx context.Context, cluster *clusterv1.Cluster, currentMP, desiredMP *scope.MachinePoolState) error {
+	log := tlog.LoggerFrom(ctx).WithMachinePool(desiredMP.Object)
+
+	ctx, _ = log.WithObject(desiredMP.InfrastructureMachinePoolObject).Into(ctx)
+	if err := r.reconcileReferencedObject(ctx, cluster, currentMP.InfrastructureMachinePoolObject, desiredMP.InfrastructureMachinePoolObject); err != nil {
+		return errors.Wrapf(err, "failed to update %s", tlog.KObj{Obj: currentMP.Object})
+	}
+
+	ctx, _ = log.WithObject(desiredMP.BootstrapObject).Into(ctx)
+	if err := r.reconcileReferencedObject(ctx, cluster, currentMP.BootstrapObject, desiredMP.BootstrapObject); err != nil {
+		return errors.Wrapf(err, "failed to update %s", tlog.KObj{Obj: currentMP.Object})
+	}
+
+	// Check differences between current and desired MachinePool, and eventually patch the current object.
+	log = log.WithObject(desiredMP.Object)
+	patchHelper, err := mergepatch.NewHelper(currentMP.Object, desiredMP.Object, r.Client)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create patch helper for %s", tlog.KObj{Obj: currentMP.Object})
+	}
+	if !patchHelper.HasChanges() {
+		log.V(3).Infof("No changes for %s", tlog.KObj{Obj: currentMP.Object})
+		return nil
+	}
+
+	log.Infof("Patching %s", tlog.KObj{Obj: currentMP.Object})
+	if err := patchHelper.Patch(ctx); err != nil {
+		return errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: currentMP.Object})
+	}
+	r.recordEvent(cluster, updateEventReason, "Updated %s", tlog.KObj{Obj: currentMP.Object})
+	return nil
+}
+
+// deleteMachinePool deletes a MachinePool.
+// NOTE: The bootstrap config and the infrastructure machine pool are deleted by the MachinePool controller.
+func (r *ClusterReconciler) deleteMachinePool(ctx context.Context, cluster *clusterv1.Cluster, mp *scope.MachinePoolState) error {
+	log := tlog.LoggerFrom(ctx).WithMachinePool(mp.Object).WithObject(mp.Object)
+
+	log.Infof("Deleting %s", tlog.KObj{Obj: mp.Object})
+	if err := r.Client.Delete(ctx, mp.Object); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete %s", tlog.KObj{Obj: mp.Object})
+	}
+	r.recordEvent(cluster, deleteEventReason, "Deleted %s", tlog.KObj{Obj: mp.Object})
+	return nil
+}
+
+type machinePoolDiff struct {
+	toCreate, toUpdate, toDelete []string
+}
+
+// calculateMachinePoolDiff compares two maps of MachinePoolState and calculates which
+// MachinePools should be created, updated or deleted.
+func calculateMachinePoolDiff(current, desired map[string]*scope.MachinePoolState) machinePoolDiff {
+	var diff machinePoolDiff
+
+	for mp := range desired {
+		if _, ok := current[mp]; ok {
+			diff.toUpdate = append(diff.toUpdate, mp)
+		} else {
+			diff.toCreate = append(diff.toCreate, mp)
+		}
+	}
+
+	for mp := range current {
+		if _, ok := desired[mp]; !ok {
+			diff.toDelete = append(diff.toDelete, mp)
+		}
+	}
+
+	return diff
+}
+
 // reconcileReferencedObject reconciles the desired state of the referenced object.
 // NOTE: After a referenced object is created it is assumed that the reference should
 // never change (only the content of the object can eventually change). Thus, we are checking for strict compatibility.
-func (r *ClusterReconciler) reconcileReferencedObject(ctx context.Context, current, desired *unstructured.Unstructured, opts ...mergepatch.HelperOption) error {
+func (r *ClusterReconciler) reconcileReferencedObject(ctx context.Context, cluster *clusterv1.Cluster, current, desired *unstructured.Unstructured, opts ...mergepatch.HelperOption) error {
 	log := tlog.LoggerFrom(ctx)
 
 	// If there is no current object, create it.
@@ -300,6 +525,7 @@ func (r *ClusterReconciler) reconcileReferencedObject(ctx context.Context, curre
 		if err := r.Client.Create(ctx, desired.DeepCopy()); err != nil {
 			return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: desired})
 		}
+		r.recordEvent(cluster, createEventReason, "Created %s", tlog.KObj{Obj: desired})
 		return nil
 	}
 
@@ -322,10 +548,12 @@ func (r *ClusterReconciler) reconcileReferencedObject(ctx context.Context, curre
 	if err := patchHelper.Patch(ctx); err != nil {
 		return errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: current})
 	}
+	r.recordEvent(cluster, updateEventReason, "Updated %s", tlog.KObj{Obj: desired})
 	return nil
 }
 
 type reconcileReferencedTemplateInput struct {
+	cluster              *clusterv1.Cluster
 	ref                  *corev1.ObjectReference
 	current              *unstructured.Unstructured
 	desired              *unstructured.Unstructured
@@ -350,6 +578,7 @@ func (r *ClusterReconciler) reconcileReferencedTemplate(ctx context.Context, in
 		if err := r.Client.Create(ctx, in.desired.DeepCopy()); err != nil {
 			return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: in.desired})
 		}
+		r.recordEvent(in.cluster, createEventReason, "Created %s", tlog.KObj{Obj: in.desired})
 		return nil
 	}
 
@@ -381,6 +610,7 @@ func (r *ClusterReconciler) reconcileReferencedTemplate(ctx context.Context, in
 		if err := patchHelper.Patch(ctx); err != nil {
 			return errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: in.desired})
 		}
+		r.recordEvent(in.cluster, updateEventReason, "Updated %s", tlog.KObj{Obj: in.desired})
 		return nil
 	}
 
@@ -396,6 +626,7 @@ func (r *ClusterReconciler) reconcileReferencedTemplate(ctx context.Context, in
 	if err := r.Client.Create(ctx, in.desired.DeepCopy()); err != nil {
 		return errors.Wrapf(err, "failed to create %s", tlog.KObj{Obj: in.desired})
 	}
+	r.recordEvent(in.cluster, createEventReason, "Created %s, rotating %s", tlog.KObj{Obj: in.desired}, tlog.KObj{Obj: in.current})
 
 	// Update the reference with the new name.
 	// NOTE: Updating the object hosting reference to the template is executed outside this func.
@@ -404,3 +635,8 @@ func (r *ClusterReconciler) reconcileReferencedTemplate(ctx context.Context, in
 
 	return nil
 }
+
+// recordEvent records a Normal event on the Cluster for an operation performed on one of the objects of its managed topology.
+func (r *ClusterReconciler) recordEvent(cluster *clusterv1.Cluster, reason, messageFmt string, args ...interface{}) {
+	recordEvent(r.recorder, cluster, corev1.EventTypeNormal, reason, messageFmt, args...)
+}
@@ -20,15 +20,20 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/contract"
 	"sigs.k8s.io/cluster-api/controllers/topology/internal/scope"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	. "sigs.k8s.io/cluster-api/internal/matchers"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -819,6 +824,104 @@ func TestReconcileMachineDeployments(t *testing.T) {
 	}
 }
 
+func TestReconcileMachineHealthCheck(t *testing.T) {
+	mhcClass := &clusterv1.MachineHealthCheckClass{
+		UnhealthyConditions: []clusterv1.UnhealthyCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+		},
+	}
+	md := builder.MachineDeployment(metav1.NamespaceDefault, "md1").Build()
+	selectorLabels := map[string]string{clusterv1.ClusterTopologyMachineDeploymentLabelName: "md1-topology"}
+
+	mhc := computeMachineHealthCheck(md, selectorLabels, "cluster1", mhcClass)
+
+	mhcWithMaxUnhealthy := mhc.DeepCopy()
+	maxUnhealthy := intstr.FromString("45%")
+	mhcWithMaxUnhealthy.Spec.MaxUnhealthy = &maxUnhealthy
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").Build()
+
+	tests := []struct {
+		name       string
+		current    *clusterv1.MachineHealthCheck
+		desired    *clusterv1.MachineHealthCheck
+		want       *clusterv1.MachineHealthCheck
+		wantEvents []string
+	}{
+		{
+			name:       "Create a MachineHealthCheck",
+			current:    nil,
+			desired:    mhc,
+			want:       mhc,
+			wantEvents: []string{"Normal TopologyCreate Created MachineHealthCheck/md1"},
+		},
+		{
+			name:       "Update a MachineHealthCheck",
+			current:    mhc,
+			desired:    mhcWithMaxUnhealthy,
+			want:       mhcWithMaxUnhealthy,
+			wantEvents: []string{"Normal TopologyUpdate Updated MachineHealthCheck/md1"},
+		},
+		{
+			name:       "Delete a MachineHealthCheck",
+			current:    mhc,
+			desired:    nil,
+			want:       nil,
+			wantEvents: []string{"Normal TopologyDelete Deleted MachineHealthCheck/md1"},
+		},
+		{
+			name:    "No-op if there is no current and no desired MachineHealthCheck",
+			current: nil,
+			desired: nil,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeObjs := make([]client.Object, 0)
+			if tt.current != nil {
+				fakeObjs = append(fakeObjs, tt.current.DeepCopy())
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(fakeObjs...).
+				Build()
+
+			recorder := record.NewFakeRecorder(32)
+			r := ClusterReconciler{
+				Client:   fakeClient,
+				recorder: recorder,
+			}
+
+			var current *clusterv1.MachineHealthCheck
+			if tt.current != nil {
+				current = &clusterv1.MachineHealthCheck{}
+				g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(tt.current), current)).To(Succeed())
+			}
+			g.Expect(r.reconcileMachineHealthCheck(ctx, cluster, current, tt.desired)).To(Succeed())
+
+			close(recorder.Events)
+			gotEvents := []string{}
+			for event := range recorder.Events {
+				gotEvents = append(gotEvents, event)
+			}
+			g.Expect(gotEvents).To(ConsistOf(tt.wantEvents))
+
+			got := &clusterv1.MachineHealthCheck{}
+			err := fakeClient.Get(ctx, client.ObjectKeyFromObject(mhc), got)
+			if tt.want == nil {
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got.Labels).To(Equal(tt.want.Labels))
+			g.Expect(got.Spec).To(Equal(tt.want.Spec))
+		})
+	}
+}
+
 func newFakeMachineDeploymentTopologyState(name string, infrastructureMachineTemplate, bootstrapTemplate *unstructured.Unstructured) *scope.MachineDeploymentState {
 	return &scope.MachineDeploymentState{
 		Object: builder.MachineDeployment(metav1.NamespaceDefault, name).
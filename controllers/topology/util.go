@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	tlog "sigs.k8s.io/cluster-api/controllers/topology/internal/log"
@@ -43,6 +44,16 @@ func infrastructureMachineTemplateNamePrefix(clusterName, machineDeploymentTopol
 	return fmt.Sprintf("%s-%s-infra-", clusterName, machineDeploymentTopologyName)
 }
 
+// bootstrapConfigNamePrefix calculates the name prefix for a BootstrapConfig.
+func bootstrapConfigNamePrefix(clusterName, machinePoolTopologyName string) string {
+	return fmt.Sprintf("%s-%s-bootstrap-", clusterName, machinePoolTopologyName)
+}
+
+// infrastructureMachinePoolNamePrefix calculates the name prefix for a InfrastructureMachinePool.
+func infrastructureMachinePoolNamePrefix(clusterName, machinePoolTopologyName string) string {
+	return fmt.Sprintf("%s-%s-infra-", clusterName, machinePoolTopologyName)
+}
+
 // infrastructureMachineTemplateNamePrefix calculates the name prefix for a InfrastructureMachineTemplate.
 func controlPlaneInfrastructureMachineTemplateNamePrefix(clusterName string) string {
 	return fmt.Sprintf("%s-control-plane-", clusterName)
@@ -125,30 +136,43 @@ func calculateTemplatesInUse(md *clusterv1.MachineDeployment, msList []*clusterv
 }
 
 // deleteTemplateIfUnused deletes the template (ref), if it is not in use (i.e. in templatesInUse).
-func deleteTemplateIfUnused(ctx context.Context, c client.Client, templatesInUse map[string]bool, ref *corev1.ObjectReference) error {
+// It returns true if the template has been deleted.
+func deleteTemplateIfUnused(ctx context.Context, c client.Client, templatesInUse map[string]bool, ref *corev1.ObjectReference) (bool, error) {
 	// If ref is nil, do nothing (this can happen, because bootstrap templates are optional).
 	if ref == nil {
-		return nil
+		return false, nil
 	}
 
 	log := tlog.LoggerFrom(ctx).WithRef(ref)
 
 	refID, err := templateRefID(ref)
 	if err != nil {
-		return errors.Wrapf(err, "failed to calculate templateRefID")
+		return false, errors.Wrapf(err, "failed to calculate templateRefID")
 	}
 
 	// If the template is still in use, do nothing.
 	if templatesInUse[refID] {
 		log.V(3).Infof("Not deleting %s, because it's still in use", tlog.KRef{Ref: ref})
-		return nil
+		return false, nil
 	}
 
 	log.Infof("Deleting %s", tlog.KRef{Ref: ref})
-	if err := external.Delete(ctx, c, ref); err != nil && !apierrors.IsNotFound(err) {
-		return errors.Wrapf(err, "failed to delete %s", tlog.KRef{Ref: ref})
+	if err := external.Delete(ctx, c, ref); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to delete %s", tlog.KRef{Ref: ref})
 	}
-	return nil
+	return true, nil
+}
+
+// recordEvent records an event on the Cluster for an operation performed on one of the objects of its managed topology,
+// e.g. "Created MachineDeployment/my-cluster-md-0-abcde"; it is a no-op if the recorder is not set, e.g. in unit tests.
+func recordEvent(recorder record.EventRecorder, cluster *clusterv1.Cluster, eventType, reason, messageFmt string, args ...interface{}) {
+	if recorder == nil || cluster == nil {
+		return
+	}
+	recorder.Eventf(cluster, eventType, reason, messageFmt, args...)
 }
 
 // addTemplateRef adds the refs to the refMap with the templateRefID as key.
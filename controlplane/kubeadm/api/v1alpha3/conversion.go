@@ -38,7 +38,16 @@ func (src *KubeadmControlPlane) ConvertTo(destRaw conversion.Hub) error {
 	}
 
 	dest.Spec.MachineTemplate.ObjectMeta = restored.Spec.MachineTemplate.ObjectMeta
+	dest.Spec.MachineTemplate.ReadinessGates = restored.Spec.MachineTemplate.ReadinessGates
+	dest.Spec.MachineTemplate.NodeDrainOptions = restored.Spec.MachineTemplate.NodeDrainOptions
+	dest.Spec.EndpointManagement = restored.Spec.EndpointManagement
+	dest.Spec.CertificateAuthoritiesRotation = restored.Spec.CertificateAuthoritiesRotation
+	dest.Spec.MachineNamingStrategy = restored.Spec.MachineNamingStrategy
+	dest.Spec.RolloutBefore = restored.Spec.RolloutBefore
 	dest.Status.Version = restored.Status.Version
+	dest.Status.CertificateAuthoritiesRotation = restored.Status.CertificateAuthoritiesRotation
+	dest.Status.MachinesSpecDiff = restored.Status.MachinesSpecDiff
+	dest.Status.ComponentsHealth = restored.Status.ComponentsHealth
 
 	if restored.Spec.KubeadmConfigSpec.JoinConfiguration != nil && restored.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.IgnorePreflightErrors != nil {
 		if dest.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
@@ -54,6 +63,20 @@ func (src *KubeadmControlPlane) ConvertTo(destRaw conversion.Hub) error {
 		dest.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = restored.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.IgnorePreflightErrors
 	}
 
+	if restored.Spec.KubeadmConfigSpec.InitConfiguration != nil && restored.Spec.KubeadmConfigSpec.InitConfiguration.Patches != nil {
+		if dest.Spec.KubeadmConfigSpec.InitConfiguration == nil {
+			dest.Spec.KubeadmConfigSpec.InitConfiguration = &kubeadmbootstrapv1.InitConfiguration{}
+		}
+		dest.Spec.KubeadmConfigSpec.InitConfiguration.Patches = restored.Spec.KubeadmConfigSpec.InitConfiguration.Patches
+	}
+
+	if restored.Spec.KubeadmConfigSpec.JoinConfiguration != nil && restored.Spec.KubeadmConfigSpec.JoinConfiguration.Patches != nil {
+		if dest.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
+			dest.Spec.KubeadmConfigSpec.JoinConfiguration = &kubeadmbootstrapv1.JoinConfiguration{}
+		}
+		dest.Spec.KubeadmConfigSpec.JoinConfiguration.Patches = restored.Spec.KubeadmConfigSpec.JoinConfiguration.Patches
+	}
+
 	return nil
 }
 
@@ -17,20 +17,49 @@ limitations under the License.
 package v1alpha4
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
+	bootstrapv1alpha4 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1alpha4"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 )
 
 func (src *KubeadmControlPlane) ConvertTo(destRaw conversion.Hub) error {
 	dest := destRaw.(*v1beta1.KubeadmControlPlane)
 
-	return Convert_v1alpha4_KubeadmControlPlane_To_v1beta1_KubeadmControlPlane(src, dest, nil)
+	if err := Convert_v1alpha4_KubeadmControlPlane_To_v1beta1_KubeadmControlPlane(src, dest, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1beta1.KubeadmControlPlane{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	bootstrapv1alpha4.RestoreKubeadmConfigSpec(&restored.Spec.KubeadmConfigSpec, &dest.Spec.KubeadmConfigSpec)
+	dest.Spec.EndpointManagement = restored.Spec.EndpointManagement
+	dest.Spec.CertificateAuthoritiesRotation = restored.Spec.CertificateAuthoritiesRotation
+	dest.Spec.MachineNamingStrategy = restored.Spec.MachineNamingStrategy
+	dest.Spec.RolloutBefore = restored.Spec.RolloutBefore
+	dest.Spec.MachineTemplate.ReadinessGates = restored.Spec.MachineTemplate.ReadinessGates
+	dest.Spec.MachineTemplate.NodeDrainOptions = restored.Spec.MachineTemplate.NodeDrainOptions
+	dest.Status.CertificateAuthoritiesRotation = restored.Status.CertificateAuthoritiesRotation
+	dest.Status.MachinesSpecDiff = restored.Status.MachinesSpecDiff
+	dest.Status.ComponentsHealth = restored.Status.ComponentsHealth
+
+	return nil
 }
 
 func (dest *KubeadmControlPlane) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.KubeadmControlPlane)
 
-	return Convert_v1beta1_KubeadmControlPlane_To_v1alpha4_KubeadmControlPlane(src, dest, nil)
+	if err := Convert_v1beta1_KubeadmControlPlane_To_v1alpha4_KubeadmControlPlane(src, dest, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dest)
 }
 
 func (src *KubeadmControlPlaneList) ConvertTo(destRaw conversion.Hub) error {
@@ -48,13 +77,36 @@ func (dest *KubeadmControlPlaneList) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *KubeadmControlPlaneTemplate) ConvertTo(destRaw conversion.Hub) error {
 	dest := destRaw.(*v1beta1.KubeadmControlPlaneTemplate)
 
-	return Convert_v1alpha4_KubeadmControlPlaneTemplate_To_v1beta1_KubeadmControlPlaneTemplate(src, dest, nil)
+	if err := Convert_v1alpha4_KubeadmControlPlaneTemplate_To_v1beta1_KubeadmControlPlaneTemplate(src, dest, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &v1beta1.KubeadmControlPlaneTemplate{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	bootstrapv1alpha4.RestoreKubeadmConfigSpec(&restored.Spec.Template.Spec.KubeadmConfigSpec, &dest.Spec.Template.Spec.KubeadmConfigSpec)
+	dest.Spec.Template.Spec.EndpointManagement = restored.Spec.Template.Spec.EndpointManagement
+	dest.Spec.Template.Spec.CertificateAuthoritiesRotation = restored.Spec.Template.Spec.CertificateAuthoritiesRotation
+	dest.Spec.Template.Spec.MachineNamingStrategy = restored.Spec.Template.Spec.MachineNamingStrategy
+	dest.Spec.Template.Spec.RolloutBefore = restored.Spec.Template.Spec.RolloutBefore
+	dest.Spec.Template.Spec.MachineTemplate.ReadinessGates = restored.Spec.Template.Spec.MachineTemplate.ReadinessGates
+	dest.Spec.Template.Spec.MachineTemplate.NodeDrainOptions = restored.Spec.Template.Spec.MachineTemplate.NodeDrainOptions
+
+	return nil
 }
 
 func (dest *KubeadmControlPlaneTemplate) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*v1beta1.KubeadmControlPlaneTemplate)
 
-	return Convert_v1beta1_KubeadmControlPlaneTemplate_To_v1alpha4_KubeadmControlPlaneTemplate(src, dest, nil)
+	if err := Convert_v1beta1_KubeadmControlPlaneTemplate_To_v1alpha4_KubeadmControlPlaneTemplate(src, dest, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dest)
 }
 
 func (src *KubeadmControlPlaneTemplateList) ConvertTo(destRaw conversion.Hub) error {
@@ -68,3 +120,18 @@ func (dest *KubeadmControlPlaneTemplateList) ConvertFrom(srcRaw conversion.Hub)
 
 	return Convert_v1beta1_KubeadmControlPlaneTemplateList_To_v1alpha4_KubeadmControlPlaneTemplateList(src, dest, nil)
 }
+
+func Convert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in *v1beta1.KubeadmControlPlaneSpec, out *KubeadmControlPlaneSpec, s apiconversion.Scope) error {
+	// KubeadmControlPlaneSpec.{EndpointManagement,CertificateAuthoritiesRotation,MachineNamingStrategy,RolloutBefore} have been added with v1beta1.
+	return autoConvert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in, out, s)
+}
+
+func Convert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPlaneStatus(in *v1beta1.KubeadmControlPlaneStatus, out *KubeadmControlPlaneStatus, s apiconversion.Scope) error {
+	// KubeadmControlPlaneStatus.{CertificateAuthoritiesRotation,MachinesSpecDiff,ComponentsHealth} have been added with v1beta1.
+	return autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPlaneStatus(in, out, s)
+}
+
+func Convert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmControlPlaneMachineTemplate(in *v1beta1.KubeadmControlPlaneMachineTemplate, out *KubeadmControlPlaneMachineTemplate, s apiconversion.Scope) error {
+	// KubeadmControlPlaneMachineTemplate.ReadinessGates and KubeadmControlPlaneMachineTemplate.NodeDrainOptions have been added with v1beta1.
+	return autoConvert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmControlPlaneMachineTemplate(in, out, s)
+}
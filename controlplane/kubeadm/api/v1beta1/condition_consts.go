@@ -132,6 +132,18 @@ const (
 	// EtcdMemberUnhealthyReason (Severity=Error) documents a Machine's etcd member is unhealthy.
 	EtcdMemberUnhealthyReason = "EtcdMemberUnhealthy"
 
+	// ExternalEtcdHealthyCondition documents the health of the external etcd cluster, as probed by connecting to the
+	// external etcd endpoints from the management cluster with the apiserver-etcd-client certificate.
+	// NOTE: This condition exists only if an external etcd cluster is used.
+	ExternalEtcdHealthyCondition clusterv1.ConditionType = "ExternalEtcdHealthy"
+
+	// ExternalEtcdEndpointsUnreachableReason (Severity=Warning) documents external etcd endpoints which cannot be
+	// reached from the management cluster.
+	ExternalEtcdEndpointsUnreachableReason = "ExternalEtcdEndpointsUnreachable"
+
+	// ExternalEtcdUnhealthyReason (Severity=Error) documents external etcd endpoints reporting errors or alarms.
+	ExternalEtcdUnhealthyReason = "ExternalEtcdUnhealthy"
+
 	// MachinesCreatedCondition documents that the machines controlled by the KubeadmControlPlane are created.
 	// When this condition is false, it indicates that there was an error when cloning the infrastructure/bootstrap template or
 	// when generating the machine object.
@@ -149,3 +161,36 @@ const (
 	// generate a machine object.
 	MachineGenerationFailedReason = "MachineGenerationFailed"
 )
+
+// Conditions and condition Reasons reporting the phases of a certificate authorities rotation.
+
+const (
+	// NewCertificateAuthoritiesTrustedCondition documents that all the control plane machines trust the
+	// new certificate authorities generated during a certificate authorities rotation.
+	NewCertificateAuthoritiesTrustedCondition clusterv1.ConditionType = "NewCertificateAuthoritiesTrusted"
+
+	// NewCertificateAuthoritiesSigningCondition documents that all the control plane machines use certificates
+	// signed by the new certificate authorities generated during a certificate authorities rotation.
+	NewCertificateAuthoritiesSigningCondition clusterv1.ConditionType = "NewCertificateAuthoritiesSigning"
+
+	// OldCertificateAuthoritiesRemovedCondition documents that the old certificate authorities are not trusted
+	// anymore by the control plane machines at the end of a certificate authorities rotation.
+	OldCertificateAuthoritiesRemovedCondition clusterv1.ConditionType = "OldCertificateAuthoritiesRemoved"
+
+	// CertificateAuthoritiesRotationPendingReason (Severity=Info) documents a phase of a certificate authorities
+	// rotation waiting for the previous phases to complete.
+	CertificateAuthoritiesRotationPendingReason = "CertificateAuthoritiesRotationPending"
+
+	// CertificateAuthoritiesRotationInProgressReason (Severity=Info) documents a phase of a certificate authorities
+	// rotation rolling out the control plane machines.
+	CertificateAuthoritiesRotationInProgressReason = "CertificateAuthoritiesRotationInProgress"
+
+	// WaitingForWorkerMachinesRolloutReason (Severity=Warning) documents a phase of a certificate authorities
+	// rotation waiting for worker machines created before the phase started to be rolled out.
+	WaitingForWorkerMachinesRolloutReason = "WaitingForWorkerMachinesRollout"
+
+	// CertificateAuthoritiesRotationFailedReason (Severity=Warning) documents a KubeadmControlPlane controller detecting
+	// an error while executing a phase of a certificate authorities rotation; those kind of errors are usually temporary
+	// and the controller automatically recover from them.
+	CertificateAuthoritiesRotationFailedReason = "CertificateAuthoritiesRotationFailed"
+)
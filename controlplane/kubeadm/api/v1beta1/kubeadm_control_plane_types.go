@@ -48,6 +48,40 @@ const (
 	// KubeadmClusterConfigurationAnnotation is a machine annotation that stores the json-marshalled string of KCP ClusterConfiguration.
 	// This annotation is used to detect any changes in ClusterConfiguration and trigger machine rollout in KCP.
 	KubeadmClusterConfigurationAnnotation = "controlplane.cluster.x-k8s.io/kubeadm-cluster-configuration"
+
+	// EndpointManagementAnnotation is a machine annotation that stores the json-marshalled string of KCP EndpointManagement.
+	// This annotation is used to detect any changes in EndpointManagement and trigger machine rollout in KCP.
+	EndpointManagementAnnotation = "controlplane.cluster.x-k8s.io/endpoint-management"
+
+	// CertificateAuthoritiesHashAnnotation is a machine annotation that stores a hash of the cluster and etcd
+	// certificate authorities trusted when the machine was created.
+	// This annotation is used to detect machines that must be rolled out while rotating the certificate authorities.
+	CertificateAuthoritiesHashAnnotation = "controlplane.cluster.x-k8s.io/certificate-authorities-hash"
+
+	// JoinGatedAnnotation is a KubeadmConfig annotation documenting that the KubeadmConfig has been paused by KCP
+	// to gate the join of its machine, because control plane machines created at once must join etcd one at a time.
+	// KCP removes both this annotation and the paused annotation when the existing control plane machines are healthy.
+	JoinGatedAnnotation = "controlplane.cluster.x-k8s.io/join-gated"
+)
+
+// CertificateAuthoritiesRotationPhase defines the phases of a certificate authorities rotation.
+type CertificateAuthoritiesRotationPhase string
+
+const (
+	// CertificateAuthoritiesRotationTrustNewPhase is the phase where the new certificate authorities are added
+	// to the trusted bundles, while certificates are still signed by the old ones.
+	CertificateAuthoritiesRotationTrustNewPhase CertificateAuthoritiesRotationPhase = "TrustNew"
+
+	// CertificateAuthoritiesRotationSignWithNewPhase is the phase where certificates are signed by the new
+	// certificate authorities, while the old ones are still trusted.
+	CertificateAuthoritiesRotationSignWithNewPhase CertificateAuthoritiesRotationPhase = "SignWithNew"
+
+	// CertificateAuthoritiesRotationRemoveOldPhase is the phase where the old certificate authorities are removed
+	// from the trusted bundles.
+	CertificateAuthoritiesRotationRemoveOldPhase CertificateAuthoritiesRotationPhase = "RemoveOld"
+
+	// CertificateAuthoritiesRotationCompletedPhase is the phase of a completed rotation.
+	CertificateAuthoritiesRotationCompletedPhase CertificateAuthoritiesRotationPhase = "Completed"
 )
 
 // KubeadmControlPlaneSpec defines the desired state of KubeadmControlPlane.
@@ -69,9 +103,21 @@ type KubeadmControlPlaneSpec struct {
 	// to use for initializing and joining machines to the control plane.
 	KubeadmConfigSpec cabpkv1.KubeadmConfigSpec `json:"kubeadmConfigSpec"`
 
+	// EndpointManagement defines files and commands to be added to the bootstrap data of all the
+	// control plane machines, e.g. to configure a VIP manager like kube-vip for the control plane endpoint.
+	// +optional
+	EndpointManagement *EndpointManagement `json:"endpointManagement,omitempty"`
+
+	// RolloutBefore is a field to indicate a rollout should be performed
+	// if the specified criteria is met.
+	// +optional
+	RolloutBefore *RolloutBefore `json:"rolloutBefore,omitempty"`
+
 	// RolloutAfter is a field to indicate a rollout should be performed
 	// after the specified time even if no changes have been made to the
 	// KubeadmControlPlane.
+	// Only the machines created before the specified time are rolled out, so
+	// the rollout happens once; set a new time to trigger another rollout.
 	//
 	// +optional
 	RolloutAfter *metav1.Time `json:"rolloutAfter,omitempty"`
@@ -81,6 +127,16 @@ type KubeadmControlPlaneSpec struct {
 	// +optional
 	// +kubebuilder:default={type: "RollingUpdate", rollingUpdate: {maxSurge: 1}}
 	RolloutStrategy *RolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// CertificateAuthoritiesRotation is a field to request the rotation of the cluster and etcd
+	// certificate authorities generated by the KubeadmControlPlane.
+	// +optional
+	CertificateAuthoritiesRotation *CertificateAuthoritiesRotation `json:"certificateAuthoritiesRotation,omitempty"`
+
+	// MachineNamingStrategy allows changing the naming pattern used when creating control plane Machines;
+	// if not set, Machine names are generated from the KubeadmControlPlane name.
+	// +optional
+	MachineNamingStrategy *clusterv1.MachineNamingStrategy `json:"machineNamingStrategy,omitempty"`
 }
 
 // KubeadmControlPlaneMachineTemplate defines the template for Machines
@@ -100,6 +156,54 @@ type KubeadmControlPlaneMachineTemplate struct {
 	// NOTE: NodeDrainTimeout is different from `kubectl drain --timeout`
 	// +optional
 	NodeDrainTimeout *metav1.Duration `json:"nodeDrainTimeout,omitempty"`
+
+	// NodeDrainOptions customizes how controlplane nodes are drained before the machines are deleted.
+	// +optional
+	NodeDrainOptions *clusterv1.NodeDrainOptions `json:"nodeDrainOptions,omitempty"`
+
+	// ReadinessGates specifies additional conditions to include when evaluating the Ready condition of the
+	// control plane machines; machines are counted as ready replicas only when all of them are True.
+	// +optional
+	ReadinessGates []clusterv1.MachineReadinessGate `json:"readinessGates,omitempty"`
+}
+
+// EndpointManagement defines files and commands to be added to the bootstrap data of the control plane machines.
+// File contents and commands are Go templates, which can use the following values:
+// - {{ .ClusterName }}: the name of the Cluster.
+// - {{ .ControlPlaneEndpoint.Host }}: the host of the control plane endpoint of the Cluster.
+// - {{ .ControlPlaneEndpoint.Port }}: the port of the control plane endpoint of the Cluster.
+// Any change to EndpointManagement triggers a rollout of the control plane machines.
+type EndpointManagement struct {
+	// Files specifies extra files to be passed to user_data upon creation;
+	// they are added after the files defined in KubeadmConfigSpec.
+	// +optional
+	Files []cabpkv1.File `json:"files,omitempty"`
+
+	// PreKubeadmCommands specifies extra commands to run before kubeadm runs;
+	// they are run before the PreKubeadmCommands defined in KubeadmConfigSpec.
+	// +optional
+	PreKubeadmCommands []string `json:"preKubeadmCommands,omitempty"`
+
+	// PostKubeadmCommands specifies extra commands to run after kubeadm runs;
+	// they are run after the PostKubeadmCommands defined in KubeadmConfigSpec.
+	// +optional
+	PostKubeadmCommands []string `json:"postKubeadmCommands,omitempty"`
+}
+
+// EndpointManagementTemplateData is the data available when rendering the EndpointManagement templates.
+// +kubebuilder:object:generate=false
+type EndpointManagementTemplateData struct {
+	ClusterName          string
+	ControlPlaneEndpoint clusterv1.APIEndpoint
+}
+
+// RolloutBefore describes when a rollout should be performed on the KCP machines.
+type RolloutBefore struct {
+	// CertificatesExpiryDays indicates a rollout needs to be performed if the
+	// certificates of the machine will expire within the specified days.
+	// +kubebuilder:validation:Minimum=7
+	// +optional
+	CertificatesExpiryDays *int32 `json:"certificatesExpiryDays,omitempty"`
 }
 
 // RolloutStrategy describes how to replace existing machines
@@ -125,10 +229,37 @@ type RollingUpdate struct {
 	// Defaults to 1.
 	// Example: when this is set to 1, the control plane can be scaled
 	// up immediately when the rolling update starts.
+	// When this is set to 0, an outdated machine is deleted before its replacement is created;
+	// this requires at least 3 replicas, so etcd does not lose quorum during the rollout.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
 }
 
+// CertificateAuthoritiesRotation defines a request to rotate the certificate authorities of a KubeadmControlPlane.
+// The rotation is executed in phases, each one rolling out all the control plane machines:
+// first the new certificate authorities are trusted, then certificates are signed with the new
+// certificate authorities, and finally the old certificate authorities are removed.
+// NOTE: only certificate authorities generated by the KubeadmControlPlane are rotated.
+type CertificateAuthoritiesRotation struct {
+	// RotateAfter is the time after which the certificate authorities should be rotated.
+	// A rotation is performed once for each value of RotateAfter; set a newer time to
+	// request another rotation.
+	RotateAfter metav1.Time `json:"rotateAfter"`
+}
+
+// CertificateAuthoritiesRotationStatus defines the observed state of a certificate authorities rotation.
+type CertificateAuthoritiesRotationStatus struct {
+	// RotateAfter is the value of spec.certificateAuthoritiesRotation.rotateAfter for the last rotation started.
+	RotateAfter metav1.Time `json:"rotateAfter"`
+
+	// Phase is the current phase of the rotation.
+	Phase CertificateAuthoritiesRotationPhase `json:"phase"`
+
+	// LastPhaseTransitionTime is the time the rotation entered the current phase.
+	// +optional
+	LastPhaseTransitionTime metav1.Time `json:"lastPhaseTransitionTime,omitempty"`
+}
+
 // KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
 type KubeadmControlPlaneStatus struct {
 	// Selector is the label selector in string format to avoid introspection
@@ -194,6 +325,94 @@ type KubeadmControlPlaneStatus struct {
 	// Conditions defines current service state of the KubeadmControlPlane.
 	// +optional
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+
+	// CertificateAuthoritiesRotation reports the progress of the last certificate authorities rotation.
+	// +optional
+	CertificateAuthoritiesRotation *CertificateAuthoritiesRotationStatus `json:"certificateAuthoritiesRotation,omitempty"`
+
+	// MachinesSpecDiff reports, for each control plane machine not up to date with the KubeadmControlPlane,
+	// the changes which have been detected, classified by whether they require a rollout of the machine.
+	// +optional
+	MachinesSpecDiff []MachineSpecDiff `json:"machinesSpecDiff,omitempty"`
+
+	// ComponentsHealth reports, for each control plane machine, the result of the last health check of the
+	// control plane components hosted on the machine, i.e. the same information used by the preflight checks
+	// before scaling or rolling out the control plane; it is not reported before the control plane is initialized.
+	// NOTE: this is updated only when the health of a component changes, so it does not cause additional
+	// writes on steady state.
+	// +optional
+	ComponentsHealth []MachineComponentsHealth `json:"componentsHealth,omitempty"`
+}
+
+// ControlPlaneComponent is the name of a control plane component whose health is checked by KubeadmControlPlane.
+type ControlPlaneComponent string
+
+const (
+	// APIServerComponent is the kube-apiserver static pod.
+	APIServerComponent = ControlPlaneComponent("kube-apiserver")
+
+	// ControllerManagerComponent is the kube-controller-manager static pod.
+	ControllerManagerComponent = ControlPlaneComponent("kube-controller-manager")
+
+	// SchedulerComponent is the kube-scheduler static pod.
+	SchedulerComponent = ControlPlaneComponent("kube-scheduler")
+
+	// EtcdPodComponent is the etcd static pod; it is checked only if etcd is managed by KubeadmControlPlane.
+	EtcdPodComponent = ControlPlaneComponent("etcd")
+
+	// EtcdMemberComponent is the etcd member; it is checked only if etcd is managed by KubeadmControlPlane.
+	EtcdMemberComponent = ControlPlaneComponent("etcd-member")
+)
+
+// MachineComponentsHealth reports the health of the control plane components hosted on a control plane machine.
+type MachineComponentsHealth struct {
+	// MachineName is the name of the machine.
+	MachineName string `json:"machineName"`
+
+	// Components reports the health of each control plane component hosted on the machine.
+	// +optional
+	Components []ComponentHealth `json:"components,omitempty"`
+}
+
+// ComponentHealth reports the health of a control plane component.
+type ComponentHealth struct {
+	// Component is the name of the control plane component.
+	// +kubebuilder:validation:Enum=kube-apiserver;kube-controller-manager;kube-scheduler;etcd;etcd-member
+	Component ControlPlaneComponent `json:"component"`
+
+	// Status is True if the component is healthy, False if it is not, Unknown if its health can't be
+	// determined, e.g. because the machine does not have a node yet.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// Reason is a CamelCase reason for the component not being healthy.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable message indicating details about the health of the component.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the last time the health of the component changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// MachineSpecDiff reports the changes between the KubeadmControlPlane and a control plane machine.
+type MachineSpecDiff struct {
+	// MachineName is the name of the machine.
+	MachineName string `json:"machineName"`
+
+	// RolloutFields are the fields, e.g. spec.version or
+	// spec.kubeadmConfigSpec.clusterConfiguration.apiServer.extraArgs, whose changes require replacing the machine.
+	// +optional
+	RolloutFields []string `json:"rolloutFields,omitempty"`
+
+	// InPlaceFields are the fields, e.g. spec.machineTemplate.metadata, whose changes could be applied to the
+	// machine in place.
+	// NOTE: machines are currently rolled out for changes to these fields as well; this is reported
+	// so it is possible to tell a rollout required only by in place changes apart from the others.
+	// +optional
+	InPlaceFields []string `json:"inPlaceFields,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -208,6 +427,7 @@ type KubeadmControlPlaneStatus struct {
 // +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=".status.readyReplicas",description="Total number of fully running and ready control plane machines"
 // +kubebuilder:printcolumn:name="Updated",type=integer,JSONPath=".status.updatedReplicas",description="Total number of non-terminated machines targeted by this control plane that have the desired template spec"
 // +kubebuilder:printcolumn:name="Unavailable",type=integer,JSONPath=".status.unavailableReplicas",description="Total number of unavailable machines targeted by this control plane"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of KubeadmControlPlane"
 // +kubebuilder:printcolumn:name="Version",type=string,JSONPath=".spec.version",description="Kubernetes version associated with this control plane"
 
@@ -19,7 +19,9 @@ package v1beta1
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
+	"text/template"
 
 	"github.com/blang/semver"
 	"github.com/coredns/corefile-migration/migration"
@@ -29,7 +31,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/container"
+	"sigs.k8s.io/cluster-api/util/naming"
 	"sigs.k8s.io/cluster-api/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -91,6 +95,7 @@ func (in *KubeadmControlPlane) ValidateCreate() error {
 	spec := in.Spec
 	allErrs := validateKubeadmControlPlaneSpec(spec, in.Namespace, field.NewPath("spec"))
 	allErrs = append(allErrs, validateEtcd(&spec, nil)...)
+	allErrs = append(allErrs, validateMachineNamingStrategy(spec.MachineNamingStrategy, in.Labels[clusterv1.ClusterLabelName], in.Name, field.NewPath("spec", "machineNamingStrategy"))...)
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(GroupVersion.WithKind("KubeadmControlPlane").GroupKind(), in.Name, allErrs)
 	}
@@ -112,6 +117,7 @@ const (
 	controllerManager    = "controllerManager"
 	scheduler            = "scheduler"
 	ntp                  = "ntp"
+	endpointManagement   = "endpointManagement"
 )
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -140,11 +146,21 @@ func (in *KubeadmControlPlane) ValidateUpdate(old runtime.Object) error {
 		{spec, "machineTemplate", "metadata", "*"},
 		{spec, "machineTemplate", "infrastructureRef", "apiVersion"},
 		{spec, "machineTemplate", "infrastructureRef", "name"},
+		{spec, "machineTemplate", "readinessGates"},
+		{spec, "machineTemplate", "readinessGates", "*"},
 		{spec, "replicas"},
 		{spec, "version"},
 		{spec, "rolloutAfter"},
+		{spec, "rolloutBefore"},
+		{spec, "rolloutBefore", "*"},
 		{spec, "nodeDrainTimeout"},
 		{spec, "rolloutStrategy", "*"},
+		{spec, endpointManagement},
+		{spec, endpointManagement, "*"},
+		{spec, "certificateAuthoritiesRotation"},
+		{spec, "certificateAuthoritiesRotation", "*"},
+		{spec, "machineNamingStrategy"},
+		{spec, "machineNamingStrategy", "*"},
 	}
 
 	allErrs := validateKubeadmControlPlaneSpec(in.Spec, in.Namespace, field.NewPath("spec"))
@@ -191,6 +207,7 @@ func (in *KubeadmControlPlane) ValidateUpdate(old runtime.Object) error {
 	allErrs = append(allErrs, in.validateVersion(prev.Spec.Version)...)
 	allErrs = append(allErrs, validateEtcd(&in.Spec, &prev.Spec)...)
 	allErrs = append(allErrs, in.validateCoreDNSVersion(prev)...)
+	allErrs = append(allErrs, validateMachineNamingStrategy(in.Spec.MachineNamingStrategy, in.Labels[clusterv1.ClusterLabelName], in.Name, field.NewPath("spec", "machineNamingStrategy"))...)
 
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(GroupVersion.WithKind("KubeadmControlPlane").GroupKind(), in.Name, allErrs)
@@ -322,6 +339,9 @@ func validateKubeadmControlPlaneSpec(s KubeadmControlPlaneSpec, namespace string
 		}
 	}
 
+	allErrs = append(allErrs, validateEndpointManagement(s.EndpointManagement, pathPrefix.Child(endpointManagement))...)
+	allErrs = append(allErrs, s.MachineTemplate.NodeDrainOptions.Validate(pathPrefix.Child("machineTemplate", "nodeDrainOptions"))...)
+
 	if s.KubeadmConfigSpec.ClusterConfiguration == nil {
 		return allErrs
 	}
@@ -339,6 +359,63 @@ func validateKubeadmControlPlaneSpec(s KubeadmControlPlaneSpec, namespace string
 	return allErrs
 }
 
+// validateMachineNamingStrategy validates the machine naming strategy of a KubeadmControlPlane; the cluster name and
+// the name of the KubeadmControlPlane are empty when not known yet, e.g. for KubeadmControlPlaneTemplates, and sample
+// names are used instead.
+func validateMachineNamingStrategy(strategy *clusterv1.MachineNamingStrategy, clusterName, name string, pathPrefix *field.Path) field.ErrorList {
+	if strategy == nil {
+		return nil
+	}
+	if err := naming.Validate(naming.MachineNameInput{
+		Template:    strategy.Template,
+		ClusterName: clusterName,
+		OwnerKey:    naming.KubeadmControlPlaneOwnerKey,
+		OwnerName:   name,
+	}); err != nil {
+		return field.ErrorList{field.Invalid(pathPrefix.Child("template"), strategy.Template, err.Error())}
+	}
+	return nil
+}
+
+// endpointManagementSampleData is the sample data used for validating the EndpointManagement templates.
+var endpointManagementSampleData = EndpointManagementTemplateData{
+	ClusterName: "cluster",
+	ControlPlaneEndpoint: clusterv1.APIEndpoint{
+		Host: "cluster.example.com",
+		Port: 6443,
+	},
+}
+
+// validateEndpointManagement validates that file contents and commands in EndpointManagement are valid Go templates,
+// which can be rendered with the data available when creating the control plane machines.
+func validateEndpointManagement(em *EndpointManagement, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if em == nil {
+		return allErrs
+	}
+
+	validateTemplate := func(path *field.Path, value string) {
+		tpl, err := template.New(path.String()).Option("missingkey=error").Parse(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path, value, fmt.Sprintf("must be a valid template: %v", err)))
+			return
+		}
+		if err := tpl.Execute(io.Discard, endpointManagementSampleData); err != nil {
+			allErrs = append(allErrs, field.Invalid(path, value, fmt.Sprintf("must be a template which can be rendered: %v", err)))
+		}
+	}
+	for i, f := range em.Files {
+		validateTemplate(pathPrefix.Child(files).Index(i).Child("content"), f.Content)
+	}
+	for i, c := range em.PreKubeadmCommands {
+		validateTemplate(pathPrefix.Child(preKubeadmCommands).Index(i), c)
+	}
+	for i, c := range em.PostKubeadmCommands {
+		validateTemplate(pathPrefix.Child(postKubeadmCommands).Index(i), c)
+	}
+	return allErrs
+}
+
 func validateEtcd(s, prev *KubeadmControlPlaneSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	utildefaulting "sigs.k8s.io/cluster-api/util/defaulting"
 )
@@ -117,6 +118,15 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 		},
 	}
 
+	evenReplicasLocalEtcd := evenReplicas.DeepCopy()
+	evenReplicasLocalEtcd.Spec.KubeadmConfigSpec = bootstrapv1.KubeadmConfigSpec{
+		ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+			Etcd: bootstrapv1.Etcd{
+				Local: &bootstrapv1.LocalEtcd{},
+			},
+		},
+	}
+
 	validVersion := valid.DeepCopy()
 	validVersion.Spec.Version = "v1.16.6"
 
@@ -126,6 +136,36 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	invalidVersion2 := valid.DeepCopy()
 	invalidVersion2.Spec.Version = "1.16.6"
 
+	validEndpointManagement := valid.DeepCopy()
+	validEndpointManagement.Spec.EndpointManagement = &EndpointManagement{
+		Files: []bootstrapv1.File{
+			{Path: "/etc/kubernetes/manifests/kube-vip.yaml", Content: "address: {{ .ControlPlaneEndpoint.Host }}"},
+		},
+		PreKubeadmCommands: []string{"echo {{ .ClusterName }}"},
+	}
+
+	invalidEndpointManagement := valid.DeepCopy()
+	invalidEndpointManagement.Spec.EndpointManagement = &EndpointManagement{
+		PostKubeadmCommands: []string{"echo {{ .ClusterName "},
+	}
+
+	unknownFieldEndpointManagement := valid.DeepCopy()
+	unknownFieldEndpointManagement.Spec.EndpointManagement = &EndpointManagement{
+		Files: []bootstrapv1.File{
+			{Path: "/etc/kubernetes/manifests/kube-vip.yaml", Content: "address: {{ .ControlPlaneEndpoint.Address }}"},
+		},
+	}
+
+	validMachineNamingStrategy := valid.DeepCopy()
+	validMachineNamingStrategy.Spec.MachineNamingStrategy = &clusterv1.MachineNamingStrategy{
+		Template: "{{ .kubeadmControlPlane.name }}-{{ .index }}",
+	}
+
+	invalidMachineNamingStrategy := valid.DeepCopy()
+	invalidMachineNamingStrategy.Spec.MachineNamingStrategy = &clusterv1.MachineNamingStrategy{
+		Template: "{{ .kubeadmControlPlane.name }}",
+	}
+
 	tests := []struct {
 		name      string
 		expectErr bool
@@ -156,6 +196,11 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: true,
 			kcp:       evenReplicas,
 		},
+		{
+			name:      "should return error when replicas is even and local etcd is configured",
+			expectErr: true,
+			kcp:       evenReplicasLocalEtcd,
+		},
 		{
 			name:      "should allow even replicas when using external etcd",
 			expectErr: false,
@@ -181,6 +226,31 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: true,
 			kcp:       invalidMaxSurge,
 		},
+		{
+			name:      "should succeed when endpointManagement contains valid templates",
+			expectErr: false,
+			kcp:       validEndpointManagement,
+		},
+		{
+			name:      "should return error when endpointManagement contains invalid templates",
+			expectErr: true,
+			kcp:       invalidEndpointManagement,
+		},
+		{
+			name:      "should return error when endpointManagement contains templates using unknown fields",
+			expectErr: true,
+			kcp:       unknownFieldEndpointManagement,
+		},
+		{
+			name:      "should succeed when machineNamingStrategy generates unique names",
+			expectErr: false,
+			kcp:       validMachineNamingStrategy,
+		},
+		{
+			name:      "should return error when machineNamingStrategy does not reference .random or .index",
+			expectErr: true,
+			kcp:       invalidMachineNamingStrategy,
+		},
 	}
 
 	for _, tt := range tests {
@@ -332,6 +402,9 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	validUpdate.Spec.Replicas = pointer.Int32Ptr(5)
 	now := metav1.NewTime(time.Now())
 	validUpdate.Spec.RolloutAfter = &now
+	validUpdate.Spec.RolloutBefore = &RolloutBefore{
+		CertificatesExpiryDays: pointer.Int32Ptr(14),
+	}
 
 	scaleToZero := before.DeepCopy()
 	scaleToZero.Spec.Replicas = pointer.Int32Ptr(0)
@@ -503,6 +576,9 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	scaleToEvenExternalEtcdCluster := beforeExternalEtcdCluster.DeepCopy()
 	scaleToEvenExternalEtcdCluster.Spec.Replicas = pointer.Int32Ptr(2)
 
+	scaleToEvenLocalEtcdCluster := localDataDir.DeepCopy()
+	scaleToEvenLocalEtcdCluster.Spec.Replicas = pointer.Int32Ptr(2)
+
 	beforeInvalidEtcdCluster := before.DeepCopy()
 	beforeInvalidEtcdCluster.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd = bootstrapv1.Etcd{
 		Local: &bootstrapv1.LocalEtcd{
@@ -538,6 +614,24 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	disableNTPServers := before.DeepCopy()
 	disableNTPServers.Spec.KubeadmConfigSpec.NTP.Enabled = pointer.BoolPtr(false)
 
+	withEndpointManagement := before.DeepCopy()
+	withEndpointManagement.Spec.EndpointManagement = &EndpointManagement{
+		Files: []bootstrapv1.File{
+			{Path: "/etc/kubernetes/manifests/kube-vip.yaml", Content: "address: {{ .ControlPlaneEndpoint.Host }}"},
+		},
+		PreKubeadmCommands: []string{"echo {{ .ClusterName }}"},
+	}
+
+	withCertificateAuthoritiesRotation := before.DeepCopy()
+	withCertificateAuthoritiesRotation.Spec.CertificateAuthoritiesRotation = &CertificateAuthoritiesRotation{
+		RotateAfter: metav1.Now(),
+	}
+
+	withReadinessGates := before.DeepCopy()
+	withReadinessGates.Spec.MachineTemplate.ReadinessGates = []clusterv1.MachineReadinessGate{
+		{ConditionType: "NetworkReady"},
+	}
+
 	tests := []struct {
 		name      string
 		expectErr bool
@@ -550,6 +644,30 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       validUpdate,
 		},
+		{
+			name:      "should succeed when adding endpointManagement",
+			expectErr: false,
+			before:    before,
+			kcp:       withEndpointManagement,
+		},
+		{
+			name:      "should succeed when removing endpointManagement",
+			expectErr: false,
+			before:    withEndpointManagement,
+			kcp:       before,
+		},
+		{
+			name:      "should succeed when requesting a certificate authorities rotation",
+			expectErr: false,
+			before:    before,
+			kcp:       withCertificateAuthoritiesRotation,
+		},
+		{
+			name:      "should succeed when changing the machine template readiness gates",
+			expectErr: false,
+			before:    before,
+			kcp:       withReadinessGates,
+		},
 		{
 			name:      "should return error when trying to mutate the kubeadmconfigspec initconfiguration",
 			expectErr: true,
@@ -604,6 +722,12 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       missingReplicas,
 		},
+		{
+			name:      "should return error when trying to scale to an even number with local etcd defined in ClusterConfiguration",
+			expectErr: true,
+			before:    localDataDir,
+			kcp:       scaleToEvenLocalEtcdCluster,
+		},
 		{
 			name:      "should succeed when trying to scale to an even number with external etcd defined in ClusterConfiguration",
 			expectErr: false,
@@ -63,6 +63,7 @@ func (r *KubeadmControlPlaneTemplate) ValidateCreate() error {
 	spec := r.Spec.Template.Spec
 	allErrs := validateKubeadmControlPlaneSpec(spec, r.Namespace, field.NewPath("spec", "template", "spec"))
 	allErrs = append(allErrs, validateEtcd(&spec, nil)...)
+	allErrs = append(allErrs, validateMachineNamingStrategy(spec.MachineNamingStrategy, "", "", field.NewPath("spec", "template", "spec", "machineNamingStrategy"))...)
 	if len(allErrs) > 0 {
 		return apierrors.NewInvalid(GroupVersion.WithKind("KubeadmControlPlaneTemplate").GroupKind(), r.Name, allErrs)
 	}
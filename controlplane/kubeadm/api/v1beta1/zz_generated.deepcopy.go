@@ -25,8 +25,90 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	kubeadmapiv1beta1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAuthoritiesRotation) DeepCopyInto(out *CertificateAuthoritiesRotation) {
+	*out = *in
+	in.RotateAfter.DeepCopyInto(&out.RotateAfter)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAuthoritiesRotation.
+func (in *CertificateAuthoritiesRotation) DeepCopy() *CertificateAuthoritiesRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAuthoritiesRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateAuthoritiesRotationStatus) DeepCopyInto(out *CertificateAuthoritiesRotationStatus) {
+	*out = *in
+	in.RotateAfter.DeepCopyInto(&out.RotateAfter)
+	in.LastPhaseTransitionTime.DeepCopyInto(&out.LastPhaseTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateAuthoritiesRotationStatus.
+func (in *CertificateAuthoritiesRotationStatus) DeepCopy() *CertificateAuthoritiesRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateAuthoritiesRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentHealth) DeepCopyInto(out *ComponentHealth) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentHealth.
+func (in *ComponentHealth) DeepCopy() *ComponentHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointManagement) DeepCopyInto(out *EndpointManagement) {
+	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]kubeadmapiv1beta1.File, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreKubeadmCommands != nil {
+		in, out := &in.PreKubeadmCommands, &out.PreKubeadmCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PostKubeadmCommands != nil {
+		in, out := &in.PostKubeadmCommands, &out.PostKubeadmCommands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointManagement.
+func (in *EndpointManagement) DeepCopy() *EndpointManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlane) DeepCopyInto(out *KubeadmControlPlane) {
 	*out = *in
@@ -96,6 +178,16 @@ func (in *KubeadmControlPlaneMachineTemplate) DeepCopyInto(out *KubeadmControlPl
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.NodeDrainOptions != nil {
+		in, out := &in.NodeDrainOptions, &out.NodeDrainOptions
+		*out = new(apiv1beta1.NodeDrainOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessGates != nil {
+		in, out := &in.ReadinessGates, &out.ReadinessGates
+		*out = make([]apiv1beta1.MachineReadinessGate, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneMachineTemplate.
@@ -118,6 +210,16 @@ func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 	}
 	in.MachineTemplate.DeepCopyInto(&out.MachineTemplate)
 	in.KubeadmConfigSpec.DeepCopyInto(&out.KubeadmConfigSpec)
+	if in.EndpointManagement != nil {
+		in, out := &in.EndpointManagement, &out.EndpointManagement
+		*out = new(EndpointManagement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutBefore != nil {
+		in, out := &in.RolloutBefore, &out.RolloutBefore
+		*out = new(RolloutBefore)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RolloutAfter != nil {
 		in, out := &in.RolloutAfter, &out.RolloutAfter
 		*out = (*in).DeepCopy()
@@ -127,6 +229,16 @@ func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 		*out = new(RolloutStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CertificateAuthoritiesRotation != nil {
+		in, out := &in.CertificateAuthoritiesRotation, &out.CertificateAuthoritiesRotation
+		*out = new(CertificateAuthoritiesRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineNamingStrategy != nil {
+		in, out := &in.MachineNamingStrategy, &out.MachineNamingStrategy
+		*out = new(apiv1beta1.MachineNamingStrategy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneSpec.
@@ -159,6 +271,25 @@ func (in *KubeadmControlPlaneStatus) DeepCopyInto(out *KubeadmControlPlaneStatus
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CertificateAuthoritiesRotation != nil {
+		in, out := &in.CertificateAuthoritiesRotation, &out.CertificateAuthoritiesRotation
+		*out = new(CertificateAuthoritiesRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachinesSpecDiff != nil {
+		in, out := &in.MachinesSpecDiff, &out.MachinesSpecDiff
+		*out = make([]MachineSpecDiff, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ComponentsHealth != nil {
+		in, out := &in.ComponentsHealth, &out.ComponentsHealth
+		*out = make([]MachineComponentsHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneStatus.
@@ -261,6 +392,53 @@ func (in *KubeadmControlPlaneTemplateSpec) DeepCopy() *KubeadmControlPlaneTempla
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineComponentsHealth) DeepCopyInto(out *MachineComponentsHealth) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentHealth, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineComponentsHealth.
+func (in *MachineComponentsHealth) DeepCopy() *MachineComponentsHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineComponentsHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineSpecDiff) DeepCopyInto(out *MachineSpecDiff) {
+	*out = *in
+	if in.RolloutFields != nil {
+		in, out := &in.RolloutFields, &out.RolloutFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InPlaceFields != nil {
+		in, out := &in.InPlaceFields, &out.InPlaceFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSpecDiff.
+func (in *MachineSpecDiff) DeepCopy() *MachineSpecDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineSpecDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 	*out = *in
@@ -281,6 +459,26 @@ func (in *RollingUpdate) DeepCopy() *RollingUpdate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutBefore) DeepCopyInto(out *RolloutBefore) {
+	*out = *in
+	if in.CertificatesExpiryDays != nil {
+		in, out := &in.CertificatesExpiryDays, &out.CertificatesExpiryDays
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutBefore.
+func (in *RolloutBefore) DeepCopy() *RolloutBefore {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutBefore)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
 	*out = *in
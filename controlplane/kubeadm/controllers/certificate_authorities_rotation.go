@@ -0,0 +1,387 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	// previousTLSCrtDataName and previousTLSKeyDataName are the keys used to store the key pair of the certificate
+	// authority being replaced in the secret's data field while a certificate authorities rotation is in progress.
+	previousTLSCrtDataName = "previous-tls.crt"
+	previousTLSKeyDataName = "previous-tls.key"
+
+	// nextTLSCrtDataName and nextTLSKeyDataName are the keys used to store the key pair of the new certificate
+	// authority in the secret's data field while a certificate authorities rotation is in progress.
+	nextTLSCrtDataName = "next-tls.crt"
+	nextTLSKeyDataName = "next-tls.key"
+)
+
+// certificateAuthoritiesRotationConditions maps each phase of a certificate authorities rotation to
+// the condition reporting its progress.
+var certificateAuthoritiesRotationConditions = map[controlplanev1.CertificateAuthoritiesRotationPhase]clusterv1.ConditionType{
+	controlplanev1.CertificateAuthoritiesRotationTrustNewPhase:    controlplanev1.NewCertificateAuthoritiesTrustedCondition,
+	controlplanev1.CertificateAuthoritiesRotationSignWithNewPhase: controlplanev1.NewCertificateAuthoritiesSigningCondition,
+	controlplanev1.CertificateAuthoritiesRotationRemoveOldPhase:   controlplanev1.OldCertificateAuthoritiesRemovedCondition,
+}
+
+// isCertificateAuthoritiesRotationInProgress returns true if a certificate authorities rotation has been started
+// and not completed yet.
+func isCertificateAuthoritiesRotationInProgress(kcp *controlplanev1.KubeadmControlPlane) bool {
+	status := kcp.Status.CertificateAuthoritiesRotation
+	return status != nil && status.Phase != "" && status.Phase != controlplanev1.CertificateAuthoritiesRotationCompletedPhase
+}
+
+// shouldStartCertificateAuthoritiesRotation returns true if a certificate authorities rotation has been requested,
+// its RotateAfter deadline is expired, and it has not been performed yet.
+func shouldStartCertificateAuthoritiesRotation(kcp *controlplanev1.KubeadmControlPlane, now metav1.Time) bool {
+	spec := kcp.Spec.CertificateAuthoritiesRotation
+	if spec == nil || spec.RotateAfter.After(now.Time) {
+		return false
+	}
+	status := kcp.Status.CertificateAuthoritiesRotation
+	return status == nil || !status.RotateAfter.Equal(&spec.RotateAfter)
+}
+
+// nextCertificateAuthoritiesRotationPhase returns the phase following the given one.
+func nextCertificateAuthoritiesRotationPhase(phase controlplanev1.CertificateAuthoritiesRotationPhase) controlplanev1.CertificateAuthoritiesRotationPhase {
+	switch phase {
+	case controlplanev1.CertificateAuthoritiesRotationTrustNewPhase:
+		return controlplanev1.CertificateAuthoritiesRotationSignWithNewPhase
+	case controlplanev1.CertificateAuthoritiesRotationSignWithNewPhase:
+		return controlplanev1.CertificateAuthoritiesRotationRemoveOldPhase
+	default:
+		return controlplanev1.CertificateAuthoritiesRotationCompletedPhase
+	}
+}
+
+// reconcileCertificateAuthoritiesRotation drives the rotation of the cluster and etcd certificate authorities through
+// the following phases, each one of them rolling out all the control plane machines:
+// - TrustNew: the new certificate authorities are added to the trusted bundles, while certificates are still signed by the old ones.
+// - SignWithNew: certificates are signed by the new certificate authorities, while the old ones are still trusted.
+// - RemoveOld: the old certificate authorities are removed from the trusted bundles.
+// NOTE: this func must be called only when the control plane is stable, i.e. there are no machines to be rolled out
+// and the number of machines matches the desired replicas.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthoritiesRotation(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	kcp := controlPlane.KCP
+	now := metav1.Now()
+
+	// The control plane must be healthy before starting or advancing a certificate authorities rotation.
+	if controlPlane.HasDeletingMachine() || !conditions.IsTrue(kcp, controlplanev1.MachinesReadyCondition) {
+		return ctrl.Result{}, nil
+	}
+
+	if !isCertificateAuthoritiesRotationInProgress(kcp) {
+		if !shouldStartCertificateAuthoritiesRotation(kcp, now) {
+			return ctrl.Result{}, nil
+		}
+
+		secrets, err := r.getRotatedCertificateAuthorities(ctx, controlPlane)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(secrets) == 0 {
+			log.Info("No certificate authorities generated by the KubeadmControlPlane before the rotation deadline, skipping rotation")
+			kcp.Status.CertificateAuthoritiesRotation = &controlplanev1.CertificateAuthoritiesRotationStatus{
+				RotateAfter:             kcp.Spec.CertificateAuthoritiesRotation.RotateAfter,
+				Phase:                   controlplanev1.CertificateAuthoritiesRotationCompletedPhase,
+				LastPhaseTransitionTime: now,
+			}
+			return ctrl.Result{}, nil
+		}
+
+		log.Info("Starting certificate authorities rotation", "secrets", secretNames(secrets))
+		kcp.Status.CertificateAuthoritiesRotation = &controlplanev1.CertificateAuthoritiesRotationStatus{
+			RotateAfter:             kcp.Spec.CertificateAuthoritiesRotation.RotateAfter,
+			Phase:                   controlplanev1.CertificateAuthoritiesRotationTrustNewPhase,
+			LastPhaseTransitionTime: now,
+		}
+		conditions.MarkFalse(kcp, controlplanev1.NewCertificateAuthoritiesTrustedCondition, controlplanev1.CertificateAuthoritiesRotationInProgressReason, clusterv1.ConditionSeverityInfo, "Rolling out control plane machines trusting the new certificate authorities")
+		conditions.MarkFalse(kcp, controlplanev1.NewCertificateAuthoritiesSigningCondition, controlplanev1.CertificateAuthoritiesRotationPendingReason, clusterv1.ConditionSeverityInfo, "")
+		conditions.MarkFalse(kcp, controlplanev1.OldCertificateAuthoritiesRemovedCondition, controlplanev1.CertificateAuthoritiesRotationPendingReason, clusterv1.ConditionSeverityInfo, "")
+
+		// Machines will be rolled out at the next reconcile, once the certificate authorities are updated.
+		if _, err := r.applyCertificateAuthoritiesRotationPhase(ctx, controlPlane, workloadCluster, secrets); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.reconcileCertificateAuthoritiesRotationKubeconfig(ctx, controlPlane); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	status := kcp.Status.CertificateAuthoritiesRotation
+	condition := certificateAuthoritiesRotationConditions[status.Phase]
+	secrets, err := r.getRotatedCertificateAuthorities(ctx, controlPlane)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Ensure the certificate authorities and the cluster-info ConfigMap reflect the current phase; this is a no-op
+	// unless the previous reconcile failed while updating them, and in this case machines must be rolled out
+	// before advancing to the next phase.
+	changed, err := r.applyCertificateAuthoritiesRotationPhase(ctx, controlPlane, workloadCluster, secrets)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileCertificateAuthoritiesRotationKubeconfig(ctx, controlPlane); err != nil {
+		return ctrl.Result{}, err
+	}
+	if changed {
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// If we got here, all the control plane machines have been rolled out for the current phase. Before signing
+	// with the new certificate authorities, worker nodes must trust them, and before removing the old certificate
+	// authorities, worker nodes must have certificates signed by the new ones; in both cases this requires worker
+	// machines to be created after the current phase has started.
+	if status.Phase != controlplanev1.CertificateAuthoritiesRotationRemoveOldPhase {
+		workers, err := r.managementCluster.GetMachinesForCluster(ctx, controlPlane.Cluster, collections.Not(collections.ControlPlaneMachines(controlPlane.Cluster.Name)))
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to get worker machines")
+		}
+		outdated := workers.Filter(
+			collections.Not(collections.HasDeletionTimestamp),
+			func(m *clusterv1.Machine) bool {
+				return m.CreationTimestamp.Before(&status.LastPhaseTransitionTime)
+			},
+		)
+		if len(outdated) > 0 {
+			log.Info("Waiting for worker machines to be rolled out before advancing certificate authorities rotation", "phase", status.Phase, "machines", outdated.Names())
+			conditions.MarkFalse(kcp, condition, controlplanev1.WaitingForWorkerMachinesRolloutReason, clusterv1.ConditionSeverityWarning,
+				"%d worker machines created before the %s phase started must be rolled out", len(outdated), status.Phase)
+			return ctrl.Result{RequeueAfter: certificateAuthoritiesRotationRequeueAfter}, nil
+		}
+	}
+
+	next := nextCertificateAuthoritiesRotationPhase(status.Phase)
+	log.Info("Advancing certificate authorities rotation", "phase", next)
+	conditions.MarkTrue(kcp, condition)
+
+	status.Phase = next
+	status.LastPhaseTransitionTime = now
+	if next == controlplanev1.CertificateAuthoritiesRotationCompletedPhase {
+		log.Info("Certificate authorities rotation completed")
+		return ctrl.Result{}, nil
+	}
+	conditions.MarkFalse(kcp, certificateAuthoritiesRotationConditions[next], controlplanev1.CertificateAuthoritiesRotationInProgressReason, clusterv1.ConditionSeverityInfo, "Rolling out control plane machines")
+
+	if _, err := r.applyCertificateAuthoritiesRotationPhase(ctx, controlPlane, workloadCluster, secrets); err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.reconcileCertificateAuthoritiesRotationKubeconfig(ctx, controlPlane); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// getRotatedCertificateAuthorities returns the secrets of the certificate authorities to be rotated, i.e. the
+// certificate authorities generated by the KubeadmControlPlane before the rotation deadline, or the ones with an
+// in progress rotation.
+func (r *KubeadmControlPlaneReconciler) getRotatedCertificateAuthorities(ctx context.Context, controlPlane *internal.ControlPlane) ([]*corev1.Secret, error) {
+	kcp := controlPlane.KCP
+	var secrets []*corev1.Secret
+	for _, purpose := range internal.RotatedCertificateAuthorities {
+		// Use the uncached reader, so changes applied by the previous phase are always visible.
+		s, err := secret.Get(ctx, r.managementClusterUncached, util.ObjectKey(controlPlane.Cluster), purpose)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get %s secret", purpose)
+		}
+		// Certificate authorities provided by the user are not rotated.
+		if !util.IsControlledBy(s, kcp) {
+			continue
+		}
+		if _, ok := s.Data[nextTLSCrtDataName]; !ok && !isCertificateAuthoritiesRotationInProgress(kcp) &&
+			!s.CreationTimestamp.Before(&kcp.Spec.CertificateAuthoritiesRotation.RotateAfter) {
+			continue
+		}
+		secrets = append(secrets, s)
+	}
+	return secrets, nil
+}
+
+// applyCertificateAuthoritiesRotationPhase updates the certificate authorities and the cluster-info ConfigMap
+// in the workload cluster according to the current phase of the rotation; it returns true if any certificate
+// authority has been changed.
+func (r *KubeadmControlPlaneReconciler) applyCertificateAuthoritiesRotationPhase(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster, secrets []*corev1.Secret) (bool, error) {
+	kcp := controlPlane.KCP
+	phase := kcp.Status.CertificateAuthoritiesRotation.Phase
+	condition := certificateAuthoritiesRotationConditions[phase]
+
+	changed := false
+	for _, s := range secrets {
+		original := s.DeepCopy()
+		if phase == controlplanev1.CertificateAuthoritiesRotationTrustNewPhase {
+			if err := prepareCertificateAuthorityRotation(s); err != nil {
+				conditions.MarkFalse(kcp, condition, controlplanev1.CertificateAuthoritiesRotationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+				return false, err
+			}
+		}
+		if err := setCertificateAuthorityRotationPhase(s, phase); err != nil {
+			conditions.MarkFalse(kcp, condition, controlplanev1.CertificateAuthoritiesRotationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return false, err
+		}
+		if !reflect.DeepEqual(original.Data, s.Data) {
+			if err := r.Client.Update(ctx, s); err != nil {
+				return false, errors.Wrapf(err, "failed to update secret %s", s.Name)
+			}
+			// Only changes to the certificates require machines to be rolled out.
+			if !bytes.Equal(original.Data[secret.TLSCrtDataName], s.Data[secret.TLSCrtDataName]) {
+				changed = true
+			}
+		}
+
+		if s.Name == secret.Name(controlPlane.Cluster.Name, secret.ClusterCA) {
+			if err := workloadCluster.UpdateClusterInfoCertificateAuthorities(ctx, s.Data[secret.TLSCrtDataName]); err != nil {
+				conditions.MarkFalse(kcp, condition, controlplanev1.CertificateAuthoritiesRotationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+				return false, err
+			}
+		}
+	}
+	return changed, nil
+}
+
+// reconcileCertificateAuthoritiesRotationKubeconfig regenerates the kubeconfig for the workload cluster, if it is
+// controlled by the KubeadmControlPlane and it does not trust exactly the certificates in the cluster CA bundle;
+// this way management clients trust both the old and the new cluster CA during the TrustNew and SignWithNew phases,
+// when API servers could serve certificates signed by either of them, and only the new one during RemoveOld.
+// The client certificate is signed by the CA currently signing, so it is trusted by all the API servers in each phase.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateAuthoritiesRotationKubeconfig(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	kcp := controlPlane.KCP
+	condition := certificateAuthoritiesRotationConditions[kcp.Status.CertificateAuthoritiesRotation.Phase]
+
+	configSecret, err := secret.GetFromNamespacedName(ctx, r.managementClusterUncached, util.ObjectKey(controlPlane.Cluster), secret.Kubeconfig)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to retrieve kubeconfig Secret")
+	}
+	if !util.IsControlledBy(configSecret, kcp) {
+		return nil
+	}
+	clusterCA, err := secret.GetFromNamespacedName(ctx, r.managementClusterUncached, util.ObjectKey(controlPlane.Cluster), secret.ClusterCA)
+	if err != nil {
+		return errors.Wrap(err, "failed to retrieve cluster CA Secret")
+	}
+
+	needsUpdate, err := kubeconfig.NeedsCertificateAuthorityUpdate(configSecret, clusterCA)
+	if err != nil {
+		conditions.MarkFalse(kcp, condition, controlplanev1.CertificateAuthoritiesRotationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+	if !needsUpdate {
+		return nil
+	}
+	ctrl.LoggerFrom(ctx).Info("Regenerating the kubeconfig for the certificate authorities rotation", "phase", kcp.Status.CertificateAuthoritiesRotation.Phase)
+	if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret); err != nil {
+		conditions.MarkFalse(kcp, condition, controlplanev1.CertificateAuthoritiesRotationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrap(err, "failed to regenerate kubeconfig")
+	}
+	return nil
+}
+
+// prepareCertificateAuthorityRotation generates a new certificate authority and stores it, together with the
+// current one, in the secret's data field; if this has already been done, the stored key pairs are preserved.
+func prepareCertificateAuthorityRotation(s *corev1.Secret) error {
+	if _, ok := s.Data[nextTLSCrtDataName]; ok {
+		return nil
+	}
+	_, purpose, err := secret.ParseSecretName(s.Name)
+	if err != nil {
+		return err
+	}
+	ca := &secret.Certificate{Purpose: purpose}
+	if err := ca.Generate(); err != nil {
+		return errors.Wrapf(err, "failed to generate new %s certificate authority", purpose)
+	}
+	s.Data[previousTLSCrtDataName] = s.Data[secret.TLSCrtDataName]
+	s.Data[previousTLSKeyDataName] = s.Data[secret.TLSKeyDataName]
+	s.Data[nextTLSCrtDataName] = ca.KeyPair.Cert
+	s.Data[nextTLSKeyDataName] = ca.KeyPair.Key
+	return nil
+}
+
+// setCertificateAuthorityRotationPhase sets the certificates and the key of a certificate authority according to
+// a phase of the rotation. The certificate signing with the key is always the first one in the certificates bundle.
+func setCertificateAuthorityRotationPhase(s *corev1.Secret, phase controlplanev1.CertificateAuthoritiesRotationPhase) error {
+	previousCrt, previousKey := s.Data[previousTLSCrtDataName], s.Data[previousTLSKeyDataName]
+	nextCrt, nextKey := s.Data[nextTLSCrtDataName], s.Data[nextTLSKeyDataName]
+	if phase == controlplanev1.CertificateAuthoritiesRotationCompletedPhase ||
+		(phase == controlplanev1.CertificateAuthoritiesRotationRemoveOldPhase && len(nextCrt) == 0) {
+		// Nothing left to do, the rotation data have already been removed.
+		return nil
+	}
+	if len(previousCrt) == 0 || len(previousKey) == 0 || len(nextCrt) == 0 || len(nextKey) == 0 {
+		return errors.Errorf("secret %s is missing the data for the certificate authorities rotation", s.Name)
+	}
+
+	switch phase {
+	case controlplanev1.CertificateAuthoritiesRotationTrustNewPhase:
+		s.Data[secret.TLSCrtDataName] = concatPEM(previousCrt, nextCrt)
+		s.Data[secret.TLSKeyDataName] = previousKey
+	case controlplanev1.CertificateAuthoritiesRotationSignWithNewPhase:
+		s.Data[secret.TLSCrtDataName] = concatPEM(nextCrt, previousCrt)
+		s.Data[secret.TLSKeyDataName] = nextKey
+	case controlplanev1.CertificateAuthoritiesRotationRemoveOldPhase:
+		s.Data[secret.TLSCrtDataName] = nextCrt
+		s.Data[secret.TLSKeyDataName] = nextKey
+		delete(s.Data, previousTLSCrtDataName)
+		delete(s.Data, previousTLSKeyDataName)
+		delete(s.Data, nextTLSCrtDataName)
+		delete(s.Data, nextTLSKeyDataName)
+	default:
+		return errors.Errorf("unknown certificate authorities rotation phase %q", phase)
+	}
+	return nil
+}
+
+// concatPEM concatenates PEM encoded certificates, ensuring each one of them starts on a new line.
+func concatPEM(first, second []byte) []byte {
+	out := append([]byte{}, bytes.TrimRight(first, "\n")...)
+	out = append(out, '\n')
+	return append(out, second...)
+}
+
+func secretNames(secrets []*corev1.Secret) []string {
+	names := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		names = append(names, s.Name)
+	}
+	return names
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/cert"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/secret"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestKubeadmControlPlaneReconciler_reconcileCertificateAuthoritiesRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+	kcp.UID = "kcp-uid"
+	conditions.MarkTrue(kcp, controlplanev1.MachinesReadyCondition)
+	controllerRef := *metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane"))
+
+	objs := []client.Object{cluster.DeepCopy()}
+	for _, purpose := range internal.RotatedCertificateAuthorities {
+		ca := &secret.Certificate{Purpose: purpose}
+		g.Expect(ca.Generate()).To(Succeed())
+		objs = append(objs, ca.AsSecret(util.ObjectKey(cluster), controllerRef))
+	}
+	worker := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "worker",
+			Namespace:         cluster.Namespace,
+			Labels:            map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		},
+	}
+	objs = append(objs, worker)
+
+	fakeClient := fake.NewClientBuilder().WithObjects(objs...).Build()
+	g.Expect(kubeconfig.CreateSecretWithOwner(ctx, fakeClient, util.ObjectKey(cluster), "https://1.2.3.4:6443", controllerRef)).To(Succeed())
+	managementCluster := &fakeManagementCluster{
+		Management: &internal.Management{Client: fakeClient},
+		Reader:     fakeClient,
+	}
+	r := &KubeadmControlPlaneReconciler{
+		Client:                    fakeClient,
+		managementCluster:         managementCluster,
+		managementClusterUncached: managementCluster,
+	}
+	controlPlane := &internal.ControlPlane{
+		KCP:      kcp,
+		Cluster:  cluster,
+		Machines: collections.Machines{},
+	}
+
+	getCA := func(purpose secret.Purpose) *corev1.Secret {
+		s, err := secret.Get(ctx, fakeClient, util.ObjectKey(cluster), purpose)
+		g.Expect(err).ToNot(HaveOccurred())
+		return s
+	}
+	parseCerts := func(s *corev1.Secret) [][]byte {
+		certificates, err := cert.ParseCertsPEM(s.Data[secret.TLSCrtDataName])
+		g.Expect(err).ToNot(HaveOccurred())
+		raw := make([][]byte, 0, len(certificates))
+		for _, c := range certificates {
+			raw = append(raw, c.Raw)
+		}
+		return raw
+	}
+	kubeconfigCerts := func() [][]byte {
+		data, err := kubeconfig.FromSecret(ctx, fakeClient, util.ObjectKey(cluster))
+		g.Expect(err).ToNot(HaveOccurred())
+		config, err := clientcmd.Load(data)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(config.Clusters).To(HaveKey(cluster.Name))
+		certificates, err := cert.ParseCertsPEM(config.Clusters[cluster.Name].CertificateAuthorityData)
+		g.Expect(err).ToNot(HaveOccurred())
+		raw := make([][]byte, 0, len(certificates))
+		for _, c := range certificates {
+			raw = append(raw, c.Raw)
+		}
+		return raw
+	}
+	oldCA := getCA(secret.ClusterCA)
+	oldCerts := parseCerts(oldCA)
+	g.Expect(kubeconfigCerts()).To(Equal(oldCerts))
+
+	// Nothing happens if a rotation is not requested.
+	result, err := r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation).To(BeNil())
+
+	// Nothing happens if the rotation deadline is not expired.
+	kcp.Spec.CertificateAuthoritiesRotation = &controlplanev1.CertificateAuthoritiesRotation{RotateAfter: metav1.NewTime(time.Now().Add(time.Hour))}
+	result, err = r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation).To(BeNil())
+
+	// Starting the rotation adds the new certificate authorities to the trusted bundles.
+	kcp.Spec.CertificateAuthoritiesRotation.RotateAfter = metav1.NewTime(time.Now().Add(-time.Hour))
+	result, err = r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Requeue).To(BeTrue())
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationTrustNewPhase))
+	g.Expect(conditions.GetReason(kcp, controlplanev1.NewCertificateAuthoritiesTrustedCondition)).To(Equal(controlplanev1.CertificateAuthoritiesRotationInProgressReason))
+	g.Expect(conditions.GetReason(kcp, controlplanev1.NewCertificateAuthoritiesSigningCondition)).To(Equal(controlplanev1.CertificateAuthoritiesRotationPendingReason))
+	g.Expect(conditions.GetReason(kcp, controlplanev1.OldCertificateAuthoritiesRemovedCondition)).To(Equal(controlplanev1.CertificateAuthoritiesRotationPendingReason))
+	for _, purpose := range internal.RotatedCertificateAuthorities {
+		s := getCA(purpose)
+		g.Expect(parseCerts(s)).To(HaveLen(2))
+		g.Expect(s.Data).To(HaveKey(nextTLSCrtDataName))
+	}
+	trustNewCA := getCA(secret.ClusterCA)
+	trustNewCerts := parseCerts(trustNewCA)
+	g.Expect(trustNewCerts[0]).To(Equal(oldCerts[0]))
+	g.Expect(trustNewCA.Data[secret.TLSKeyDataName]).To(Equal(oldCA.Data[secret.TLSKeyDataName]))
+	// The kubeconfig trusts both the old and the new cluster CA.
+	g.Expect(kubeconfigCerts()).To(Equal(trustNewCerts))
+	newKey := trustNewCA.Data[nextTLSKeyDataName]
+
+	// The rotation waits for worker machines created before the phase started to be rolled out.
+	result, err = r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(certificateAuthoritiesRotationRequeueAfter))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationTrustNewPhase))
+	g.Expect(conditions.GetReason(kcp, controlplanev1.NewCertificateAuthoritiesTrustedCondition)).To(Equal(controlplanev1.WaitingForWorkerMachinesRolloutReason))
+
+	// Once worker machines are rolled out, certificates are signed with the new certificate authorities.
+	g.Expect(fakeClient.Delete(ctx, worker)).To(Succeed())
+	result, err = r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Requeue).To(BeTrue())
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationSignWithNewPhase))
+	g.Expect(conditions.IsTrue(kcp, controlplanev1.NewCertificateAuthoritiesTrustedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(kcp, controlplanev1.NewCertificateAuthoritiesSigningCondition)).To(Equal(controlplanev1.CertificateAuthoritiesRotationInProgressReason))
+	signWithNewCA := getCA(secret.ClusterCA)
+	signWithNewCerts := parseCerts(signWithNewCA)
+	g.Expect(signWithNewCerts).To(Equal([][]byte{trustNewCerts[1], trustNewCerts[0]}))
+	g.Expect(signWithNewCA.Data[secret.TLSKeyDataName]).To(Equal(newKey))
+	g.Expect(kubeconfigCerts()).To(Equal(signWithNewCerts))
+
+	// Then the old certificate authorities are removed.
+	result, err = r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result.Requeue).To(BeTrue())
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationRemoveOldPhase))
+	g.Expect(conditions.IsTrue(kcp, controlplanev1.NewCertificateAuthoritiesSigningCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(kcp, controlplanev1.OldCertificateAuthoritiesRemovedCondition)).To(Equal(controlplanev1.CertificateAuthoritiesRotationInProgressReason))
+	for _, purpose := range internal.RotatedCertificateAuthorities {
+		s := getCA(purpose)
+		g.Expect(parseCerts(s)).To(HaveLen(1))
+		g.Expect(s.Data).ToNot(HaveKey(previousTLSCrtDataName))
+		g.Expect(s.Data).ToNot(HaveKey(nextTLSCrtDataName))
+	}
+	g.Expect(parseCerts(getCA(secret.ClusterCA))).To(Equal([][]byte{trustNewCerts[1]}))
+	// The kubeconfig trusts only the new cluster CA.
+	g.Expect(kubeconfigCerts()).To(Equal([][]byte{trustNewCerts[1]}))
+
+	// Finally the rotation completes.
+	result, err = r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationCompletedPhase))
+	g.Expect(conditions.IsTrue(kcp, controlplanev1.OldCertificateAuthoritiesRemovedCondition)).To(BeTrue())
+	g.Expect(kubeconfigCerts()).To(Equal([][]byte{trustNewCerts[1]}))
+
+	// A rotation is performed only once for each RotateAfter value.
+	result, err = r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationCompletedPhase))
+}
+
+func TestKubeadmControlPlaneReconciler_reconcileCertificateAuthoritiesRotationSkipsUserProvidedCAs(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+	conditions.MarkTrue(kcp, controlplanev1.MachinesReadyCondition)
+	kcp.Spec.CertificateAuthoritiesRotation = &controlplanev1.CertificateAuthoritiesRotation{RotateAfter: metav1.NewTime(time.Now().Add(-time.Hour))}
+
+	ca := &secret.Certificate{Purpose: secret.ClusterCA}
+	g.Expect(ca.Generate()).To(Succeed())
+	ca.Generated = false
+	userCA := ca.AsSecret(util.ObjectKey(cluster), metav1.OwnerReference{})
+
+	fakeClient := fake.NewClientBuilder().WithObjects(cluster.DeepCopy(), userCA).Build()
+	managementCluster := &fakeManagementCluster{
+		Management: &internal.Management{Client: fakeClient},
+		Reader:     fakeClient,
+	}
+	r := &KubeadmControlPlaneReconciler{
+		Client:                    fakeClient,
+		managementCluster:         managementCluster,
+		managementClusterUncached: managementCluster,
+	}
+	controlPlane := &internal.ControlPlane{
+		KCP:      kcp,
+		Cluster:  cluster,
+		Machines: collections.Machines{},
+	}
+
+	result, err := r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, fakeWorkloadCluster{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(result).To(Equal(ctrl.Result{}))
+	g.Expect(kcp.Status.CertificateAuthoritiesRotation.Phase).To(Equal(controlplanev1.CertificateAuthoritiesRotationCompletedPhase))
+	g.Expect(conditions.Has(kcp, controlplanev1.NewCertificateAuthoritiesTrustedCondition)).To(BeFalse())
+
+	s, err := secret.Get(ctx, fakeClient, util.ObjectKey(cluster), secret.ClusterCA)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s.Data).To(Equal(userCA.Data))
+}
@@ -30,4 +30,8 @@ const (
 	// dependentCertRequeueAfter is how long to wait before checking again to see if
 	// dependent certificates have been created.
 	dependentCertRequeueAfter = 30 * time.Second
+
+	// certificateAuthoritiesRotationRequeueAfter is how long to wait before checking again to see if
+	// worker machines have been rolled out during a certificate authorities rotation.
+	certificateAuthoritiesRotationRequeueAfter = 1 * time.Minute
 )
@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/blang/semver"
@@ -37,11 +38,12 @@ import (
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util"
-	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/requeue"
 	"sigs.k8s.io/cluster-api/util/secret"
 	"sigs.k8s.io/cluster-api/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -71,6 +73,9 @@ type KubeadmControlPlaneReconciler struct {
 
 	managementCluster         internal.ManagementCluster
 	managementClusterUncached internal.ManagementCluster
+
+	// requeueJitter adds a random jitter to requeue intervals; if nil, requeue.Jitter with the default factor is used.
+	requeueJitter func(time.Duration) time.Duration
 }
 
 func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -89,7 +94,10 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 		handler.EnqueueRequestsFromMapFunc(r.ClusterToKubeadmControlPlane),
 		predicates.All(ctrl.LoggerFrom(ctx),
 			predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
-			predicates.ClusterUnpausedAndInfrastructureReady(ctrl.LoggerFrom(ctx)),
+			predicates.Any(ctrl.LoggerFrom(ctx),
+				predicates.ClusterUnpausedAndInfrastructureReady(ctrl.LoggerFrom(ctx)),
+				predicates.ClusterPausedTransitions(ctrl.LoggerFrom(ctx)),
+			),
 		),
 	)
 	if err != nil {
@@ -137,7 +145,12 @@ func (r *KubeadmControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 	log = log.WithValues("cluster", cluster.Name)
 
-	if annotations.IsPaused(cluster, kcp) {
+	// Return early if the object or Cluster is paused, reporting it in the Paused condition.
+	isPaused, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, kcp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -194,7 +207,7 @@ func (r *KubeadmControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.
 		// Only requeue if we are not going in exponential backoff due to error, or if we are not already re-queueing, or if the object has a deletion timestamp.
 		if reterr == nil && !res.Requeue && !(res.RequeueAfter > 0) && kcp.ObjectMeta.DeletionTimestamp.IsZero() {
 			if !kcp.Status.Ready {
-				res = ctrl.Result{RequeueAfter: 20 * time.Second}
+				res = ctrl.Result{RequeueAfter: r.jitter(20 * time.Second)}
 			}
 		}
 	}()
@@ -233,6 +246,9 @@ func patchKubeadmControlPlane(ctx context.Context, patchHelper *patch.Helper, kc
 			controlplanev1.MachinesReadyCondition,
 			controlplanev1.AvailableCondition,
 			controlplanev1.CertificatesAvailableCondition,
+			controlplanev1.NewCertificateAuthoritiesTrustedCondition,
+			controlplanev1.NewCertificateAuthoritiesSigningCondition,
+			controlplanev1.OldCertificateAuthoritiesRemovedCondition,
 		}},
 		patch.WithStatusObservedGeneration{},
 	)
@@ -302,6 +318,15 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, cluster *
 		return ctrl.Result{}, err
 	}
 
+	// While rotating certificate authorities, machines not trusting the current certificate authorities must be rolled out.
+	if isCertificateAuthoritiesRotationInProgress(kcp) {
+		hash, err := internal.CertificateAuthoritiesHash(ctx, r.managementClusterUncached, util.ObjectKey(cluster))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		controlPlane.CertificateAuthoritiesHash = hash
+	}
+
 	// Aggregate the operational state of all the machines; while aggregating we are adding the
 	// source ref (reason@machine/name) so the problem can be easily tracked down to its source machine.
 	conditions.SetAggregate(controlPlane.KCP, controlplanev1.MachinesReadyCondition, ownedMachines.ConditionGetters(), conditions.AddSourceRef(), conditions.WithStepCounterIf(false))
@@ -318,18 +343,35 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, cluster *
 		return result, err
 	}
 
+	// Reconcile certificate expiry for machines that don't have the expiry annotation on KubeadmConfig yet.
+	if result, err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	// Propagates changes to users to the existing machines; this does not require a rollout.
+	if err := r.syncMachinesUsers(ctx, controlPlane); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Reconcile unhealthy machines by triggering deletion and requeue if it is considered safe to remediate,
 	// otherwise continue with the other KCP operations.
 	if result, err := r.reconcileUnhealthyMachines(ctx, controlPlane); err != nil || !result.IsZero() {
 		return result, err
 	}
 
+	// Release the join of the control plane machines created at once during the initial scale up, one at a time.
+	// NOTE: This happens after remediation, so an unhealthy machine blocking the release of the next join can be remediated.
+	if result, err := r.reconcileGatedJoins(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
 	// Control plane machines rollout due to configuration changes (e.g. upgrades) takes precedence over other operations.
 	needRollout := controlPlane.MachinesNeedingRollout()
 	switch {
 	case len(needRollout) > 0:
-		log.Info("Rolling out Control Plane machines", "needRollout", needRollout.Names())
-		conditions.MarkFalse(controlPlane.KCP, controlplanev1.MachinesSpecUpToDateCondition, controlplanev1.RollingUpdateInProgressReason, clusterv1.ConditionSeverityWarning, "Rolling %d replicas with outdated spec (%d replicas up to date)", len(needRollout), len(controlPlane.Machines)-len(needRollout))
+		rolloutFields, inPlaceFields := machinesSpecDiffFields(controlPlane.MachinesSpecDiff())
+		log.Info("Rolling out Control Plane machines", "needRollout", needRollout.Names(), "rolloutFields", rolloutFields, "inPlaceFields", inPlaceFields)
+		conditions.MarkFalse(controlPlane.KCP, controlplanev1.MachinesSpecUpToDateCondition, controlplanev1.RollingUpdateInProgressReason, clusterv1.ConditionSeverityWarning, "Rolling %d replicas with outdated spec (%d replicas up to date); changed fields: %s", len(needRollout), len(controlPlane.Machines)-len(needRollout), strings.Join(append(rolloutFields, inPlaceFields...), ", "))
 		return r.upgradeControlPlane(ctx, cluster, kcp, controlPlane, needRollout)
 	default:
 		// make sure last upgrade operation is marked as completed.
@@ -393,7 +435,22 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, cluster *
 		return ctrl.Result{}, errors.Wrap(err, "failed to update CoreDNS deployment")
 	}
 
-	return ctrl.Result{}, nil
+	// Rotate certificate authorities, if requested.
+	if result, err := r.reconcileCertificateAuthoritiesRotation(ctx, controlPlane, workloadCluster); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	// Requeue at the RolloutAfter deadline, if any, so the rollout starts on time instead of at the next resync.
+	return rolloutAfterResult(kcp, time.Now()), nil
+}
+
+// rolloutAfterResult returns a result requeueing the KubeadmControlPlane when KCP.Spec.RolloutAfter expires,
+// if it is set in the future.
+func rolloutAfterResult(kcp *controlplanev1.KubeadmControlPlane, now time.Time) ctrl.Result {
+	if kcp.Spec.RolloutAfter == nil || !kcp.Spec.RolloutAfter.After(now) {
+		return ctrl.Result{}
+	}
+	return ctrl.Result{RequeueAfter: kcp.Spec.RolloutAfter.Sub(now)}
 }
 
 // reconcileDelete handles KubeadmControlPlane deletion.
@@ -504,6 +561,10 @@ func (r *KubeadmControlPlaneReconciler) reconcileControlPlaneConditions(ctx cont
 	workloadCluster.UpdateStaticPodConditions(ctx, controlPlane)
 	workloadCluster.UpdateEtcdConditions(ctx, controlPlane)
 
+	// Report the health of the control plane components in the KCP status, so it is possible to tell
+	// why preflight checks are failing from the KCP object alone.
+	controlPlane.KCP.Status.ComponentsHealth = controlPlane.ComponentsHealth()
+
 	// Patch machines with the updated conditions.
 	if err := controlPlane.PatchMachines(ctx); err != nil {
 		return ctrl.Result{}, err
@@ -569,6 +630,80 @@ func (r *KubeadmControlPlaneReconciler) reconcileEtcdMembers(ctx context.Context
 	return ctrl.Result{}, nil
 }
 
+// reconcileCertificateExpiries sets the MachineCertificatesExpiryDateAnnotation on the KubeadmConfig of the control plane
+// machines, reading the expiry of the certificate served by the kube-apiserver on the machine's node; the Machine controller
+// surfaces the annotation value in Machine.Status.CertificatesExpiryDate.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateExpiries(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx, "cluster", controlPlane.Cluster.Name)
+
+	// Return if there are no KCP-owned control-plane machines.
+	if controlPlane.Machines.Len() == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	// Return if KCP is not yet initialized (no API server to contact for checking certificate expiration).
+	if !controlPlane.KCP.Status.Initialized {
+		return ctrl.Result{}, nil
+	}
+
+	// Ignore machines which are being deleted.
+	machines := controlPlane.Machines.Filter(collections.Not(collections.HasDeletionTimestamp))
+
+	var workloadCluster internal.WorkloadCluster
+	for _, m := range machines {
+		kubeadmConfig, ok := controlPlane.GetKubeadmConfig(m.Name)
+		if !ok || kubeadmConfig == nil {
+			// Skip if the Machine doesn't have a KubeadmConfig.
+			continue
+		}
+
+		// Skip if the certificate expiry is already known.
+		if _, ok := kubeadmConfig.Annotations[clusterv1.MachineCertificatesExpiryDateAnnotation]; ok {
+			continue
+		}
+
+		// Skip if the Machine doesn't have a Node yet.
+		if m.Status.NodeRef == nil {
+			continue
+		}
+		nodeName := m.Status.NodeRef.Name
+
+		if workloadCluster == nil {
+			var err error
+			workloadCluster, err = r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(controlPlane.Cluster))
+			if err != nil {
+				return ctrl.Result{}, errors.Wrap(err, "cannot get remote client to workload cluster")
+			}
+		}
+
+		log.V(3).Info("Reconciling certificate expiry", "machine", m.Name, "node", nodeName)
+		certificateExpiry, err := workloadCluster.GetAPIServerCertificateExpiry(ctx, kubeadmConfig, nodeName)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificate expiry for Machine/%s", m.Name)
+		}
+		expiry := certificateExpiry.Format(time.RFC3339)
+
+		patchHelper, err := patch.NewHelper(kubeadmConfig, r.Client)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificate expiry for Machine/%s", m.Name)
+		}
+
+		annotations := kubeadmConfig.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[clusterv1.MachineCertificatesExpiryDateAnnotation] = expiry
+		kubeadmConfig.SetAnnotations(annotations)
+
+		if err := patchHelper.Patch(ctx, kubeadmConfig); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificate expiry for Machine/%s", m.Name)
+		}
+		log.V(2).Info("Set certificate expiry", "machine", m.Name, "expiry", expiry)
+	}
+
+	return ctrl.Result{}, nil
+}
+
 func (r *KubeadmControlPlaneReconciler) adoptMachines(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, machines collections.Machines, cluster *clusterv1.Cluster) error {
 	// We do an uncached full quorum read against the KCP to avoid re-adopting Machines the garbage collector just intentionally orphaned
 	// See https://github.com/kubernetes/kubernetes/issues/42639
@@ -680,3 +815,11 @@ func (r *KubeadmControlPlaneReconciler) adoptOwnedSecrets(ctx context.Context, k
 
 	return nil
 }
+
+// jitter adds a random jitter to the requeue interval d.
+func (r *KubeadmControlPlaneReconciler) jitter(d time.Duration) time.Duration {
+	if r.requeueJitter != nil {
+		return r.requeueJitter(d)
+	}
+	return requeue.Jitter(d, requeue.DefaultJitterFactor)
+}
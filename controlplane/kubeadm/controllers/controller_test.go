@@ -41,12 +41,12 @@ import (
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
-	"sigs.k8s.io/cluster-api/internal/builder"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
 	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -375,6 +375,11 @@ func TestReconcilePaused(t *testing.T) {
 	g.Expect(fakeClient.List(ctx, machineList, client.InNamespace(metav1.NamespaceDefault))).To(Succeed())
 	g.Expect(machineList.Items).To(BeEmpty())
 
+	pausedKCP := &controlplanev1.KubeadmControlPlane{}
+	g.Expect(fakeClient.Get(ctx, util.ObjectKey(kcp), pausedKCP)).To(Succeed())
+	g.Expect(conditions.IsTrue(pausedKCP, clusterv1.PausedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(pausedKCP, clusterv1.PausedCondition)).To(Equal(clusterv1.ClusterPausedReason))
+
 	// Test: kcp is paused and cluster is not
 	cluster.Spec.Paused = false
 	kcp.ObjectMeta.Annotations = map[string]string{}
@@ -424,6 +429,8 @@ func TestReconcileClusterNoEndpoints(t *testing.T) {
 			Management: &internal.Management{Client: fakeClient},
 			Workload:   fakeWorkloadCluster{},
 		},
+		// Disable the jitter, so the requeue interval is deterministic.
+		requeueJitter: func(d time.Duration) time.Duration { return d },
 	}
 
 	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: util.ObjectKey(kcp)})
@@ -1200,6 +1207,90 @@ kubernetesVersion: metav1.16.1`,
 	})
 }
 
+func TestKubeadmControlPlaneReconciler_reconcileCertificateExpiries(t *testing.T) {
+	g := NewWithT(t)
+
+	preExistingExpiry := time.Now().Add(5 * 24 * time.Hour)
+	detectedExpiry := time.Now().Add(25 * 24 * time.Hour)
+
+	cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+	kcp.Status.Initialized = true
+
+	newMachineWithConfig := func(name string, withNode bool, annotations map[string]string) (*clusterv1.Machine, *bootstrapv1.KubeadmConfig) {
+		m, _ := createMachineNodePair(name, cluster, kcp, true)
+		if !withNode {
+			m.Status.NodeRef = nil
+		}
+		m.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+			APIVersion: bootstrapv1.GroupVersion.String(),
+			Kind:       "KubeadmConfig",
+			Name:       name,
+			Namespace:  cluster.Namespace,
+		}
+		config := &bootstrapv1.KubeadmConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   cluster.Namespace,
+				Annotations: annotations,
+			},
+		}
+		return m, config
+	}
+
+	// Machine with the certificate expiry already set is not updated.
+	machineWithExpiry, machineWithExpiryConfig := newMachineWithConfig("machine-with-expiry", true, map[string]string{
+		clusterv1.MachineCertificatesExpiryDateAnnotation: preExistingExpiry.Format(time.RFC3339),
+	})
+	// Machine without the certificate expiry gets it set.
+	machineWithoutExpiry, machineWithoutExpiryConfig := newMachineWithConfig("machine-without-expiry", true, nil)
+	// Machine without a Node is skipped.
+	machineWithoutNode, machineWithoutNodeConfig := newMachineWithConfig("machine-without-node", false, nil)
+
+	ownedMachines := collections.FromMachines(machineWithExpiry, machineWithoutExpiry, machineWithoutNode)
+
+	fakeClient := newFakeClient(machineWithExpiryConfig, machineWithoutExpiryConfig, machineWithoutNodeConfig)
+
+	controlPlane, err := internal.NewControlPlane(ctx, fakeClient, cluster, kcp, ownedMachines)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &KubeadmControlPlaneReconciler{
+		Client: fakeClient,
+		managementCluster: &fakeManagementCluster{
+			Workload: fakeWorkloadCluster{
+				APIServerCertificateExpiry: &detectedExpiry,
+			},
+		},
+	}
+
+	_, err = r.reconcileCertificateExpiries(ctx, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	getExpiry := func(config *bootstrapv1.KubeadmConfig) string {
+		c := &bootstrapv1.KubeadmConfig{}
+		g.Expect(fakeClient.Get(ctx, util.ObjectKey(config), c)).To(Succeed())
+		return c.Annotations[clusterv1.MachineCertificatesExpiryDateAnnotation]
+	}
+	g.Expect(getExpiry(machineWithExpiryConfig)).To(Equal(preExistingExpiry.Format(time.RFC3339)))
+	g.Expect(getExpiry(machineWithoutExpiryConfig)).To(Equal(detectedExpiry.Format(time.RFC3339)))
+	g.Expect(getExpiry(machineWithoutNodeConfig)).To(BeEmpty())
+}
+
+func TestRolloutAfterResult(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	g.Expect(rolloutAfterResult(kcp, now)).To(Equal(ctrl.Result{}))
+
+	past := metav1.NewTime(now.Add(-time.Hour))
+	kcp.Spec.RolloutAfter = &past
+	g.Expect(rolloutAfterResult(kcp, now)).To(Equal(ctrl.Result{}))
+
+	future := metav1.NewTime(now.Add(time.Hour))
+	kcp.Spec.RolloutAfter = &future
+	g.Expect(rolloutAfterResult(kcp, now)).To(Equal(ctrl.Result{RequeueAfter: future.Sub(now)}))
+}
+
 func TestKubeadmControlPlaneReconciler_reconcileDelete(t *testing.T) {
 	t.Run("removes all control plane Machines", func(t *testing.T) {
 		g := NewWithT(t)
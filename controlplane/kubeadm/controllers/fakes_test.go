@@ -18,9 +18,11 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/blang/semver"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/collections"
@@ -37,10 +39,16 @@ type fakeManagementCluster struct {
 }
 
 func (f *fakeManagementCluster) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if f.Reader == nil && f.Management != nil {
+		return f.Management.Get(ctx, key, obj)
+	}
 	return f.Reader.Get(ctx, key, obj)
 }
 
 func (f *fakeManagementCluster) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if f.Reader == nil && f.Management != nil {
+		return f.Management.List(ctx, list, opts...)
+	}
 	return f.Reader.List(ctx, list, opts...)
 }
 
@@ -64,8 +72,9 @@ func (f *fakeManagementCluster) GetMachinePoolsForCluster(c context.Context, clu
 
 type fakeWorkloadCluster struct {
 	*internal.Workload
-	Status            internal.ClusterStatus
-	EtcdMembersResult []string
+	Status                     internal.ClusterStatus
+	EtcdMembersResult          []string
+	APIServerCertificateExpiry *time.Time
 }
 
 func (f fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, _ *clusterv1.Machine) error {
@@ -84,6 +93,10 @@ func (f fakeWorkloadCluster) AllowBootstrapTokensToGetNodes(ctx context.Context)
 	return nil
 }
 
+func (f fakeWorkloadCluster) UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error {
+	return nil
+}
+
 func (f fakeWorkloadCluster) ReconcileKubeletRBACRole(ctx context.Context, version semver.Version) error {
 	return nil
 }
@@ -112,6 +125,14 @@ func (f fakeWorkloadCluster) RemoveMachineFromKubeadmConfigMap(ctx context.Conte
 	return nil
 }
 
+func (f fakeWorkloadCluster) GetAPIServerCertificateExpiry(_ context.Context, _ *bootstrapv1.KubeadmConfig, _ string) (*time.Time, error) {
+	if f.APIServerCertificateExpiry == nil {
+		expiry := time.Now().Add(365 * 24 * time.Hour)
+		return &expiry, nil
+	}
+	return f.APIServerCertificateExpiry, nil
+}
+
 func (f fakeWorkloadCluster) EtcdMembers(_ context.Context) ([]string, error) {
 	return f.EtcdMembersResult, nil
 }
@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/storage/names"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -34,13 +35,16 @@ import (
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/naming"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/secret"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (r *KubeadmControlPlaneReconciler) reconcileKubeconfig(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane) (ctrl.Result, error) {
@@ -63,6 +67,20 @@ func (r *KubeadmControlPlaneReconciler) reconcileKubeconfig(ctx context.Context,
 			endpoint.String(),
 			controllerOwnerRef,
 		)
+		if errors.Is(createErr, kubeconfig.ErrExternalCA) {
+			// The cluster CA is managed externally, so the kubeconfig client certificate must be signed by the external CA.
+			createErr = kubeconfig.CreateSecretFromCSR(
+				ctx,
+				r.Client,
+				clusterName,
+				endpoint.String(),
+				controllerOwnerRef,
+			)
+			if errors.Is(createErr, kubeconfig.ErrCSRNotSigned) {
+				log.Info("Waiting for the kubeconfig certificate signing request to be signed by the external CA", "secret", secret.Name(cluster.Name, secret.KubeconfigCSR))
+				return ctrl.Result{RequeueAfter: dependentCertRequeueAfter}, nil
+			}
+		}
 		if errors.Is(createErr, kubeconfig.ErrDependentCertificateNotFound) {
 			return ctrl.Result{RequeueAfter: dependentCertRequeueAfter}, nil
 		}
@@ -93,6 +111,11 @@ func (r *KubeadmControlPlaneReconciler) reconcileKubeconfig(ctx context.Context,
 	if needsRotation {
 		log.Info("rotating kubeconfig secret")
 		if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret); err != nil {
+			// Kubeconfigs signed by an external CA can't be rotated by Cluster API.
+			if errors.Is(err, kubeconfig.ErrExternalCA) {
+				log.Info("Skipping kubeconfig rotation, the cluster CA is managed externally")
+				return ctrl.Result{}, nil
+			}
 			return ctrl.Result{}, errors.Wrap(err, "failed to regenerate kubeconfig")
 		}
 	}
@@ -152,9 +175,30 @@ func (r *KubeadmControlPlaneReconciler) reconcileExternalReference(ctx context.C
 	return patchHelper.Patch(ctx, obj)
 }
 
-func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, bootstrapSpec *bootstrapv1.KubeadmConfigSpec, failureDomain *string) error {
+// cloneConfigsAndGenerateMachine creates a new control plane Machine, together with its infrastructure and bootstrap objects;
+// if gateJoin is true, the bootstrap object is paused until the join of the Machine is released by reconcileGatedJoins.
+func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, bootstrapSpec *bootstrapv1.KubeadmConfigSpec, failureDomain *string, gateJoin bool) error {
 	var errs []error
 
+	// Add the files and commands defined in the KCP EndpointManagement to the bootstrap configuration.
+	// NOTE: bootstrapSpec is always a copy of the KCP KubeadmConfigSpec, so it is safe to modify it.
+	if err := internal.InjectEndpointManagement(bootstrapSpec, kcp, cluster); err != nil {
+		// Safe to return early here since no resources have been created yet.
+		conditions.MarkFalse(kcp, controlplanev1.MachinesCreatedCondition, controlplanev1.BootstrapTemplateCloningFailedReason,
+			clusterv1.ConditionSeverityError, err.Error())
+		return errors.Wrap(err, "failed to inject endpoint management into bootstrap config")
+	}
+
+	// Generate the name of the Machine, which is also used for its infrastructure and bootstrap objects,
+	// if the KubeadmControlPlane defines a machine naming strategy.
+	machineName, err := r.generateMachineName(ctx, kcp, cluster)
+	if err != nil {
+		// Safe to return early here since no resources have been created yet.
+		conditions.MarkFalse(kcp, controlplanev1.MachinesCreatedCondition, controlplanev1.MachineGenerationFailedReason,
+			clusterv1.ConditionSeverityError, err.Error())
+		return errors.Wrap(err, "failed to generate Machine name")
+	}
+
 	// Since the cloned resource should eventually have a controller ref for the Machine, we create an
 	// OwnerReference here without the Controller field set
 	infraCloneOwner := &metav1.OwnerReference{
@@ -168,6 +212,7 @@ func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx conte
 	infraRef, err := external.CloneTemplate(ctx, &external.CloneTemplateInput{
 		Client:      r.Client,
 		TemplateRef: &kcp.Spec.MachineTemplate.InfrastructureRef,
+		Name:        machineName,
 		Namespace:   kcp.Namespace,
 		OwnerRef:    infraCloneOwner,
 		ClusterName: cluster.Name,
@@ -182,7 +227,7 @@ func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx conte
 	}
 
 	// Clone the bootstrap configuration
-	bootstrapRef, err := r.generateKubeadmConfig(ctx, kcp, cluster, bootstrapSpec)
+	bootstrapRef, err := r.generateKubeadmConfig(ctx, kcp, cluster, bootstrapSpec, machineName, gateJoin)
 	if err != nil {
 		conditions.MarkFalse(kcp, controlplanev1.MachinesCreatedCondition, controlplanev1.BootstrapTemplateCloningFailedReason,
 			clusterv1.ConditionSeverityError, err.Error())
@@ -191,7 +236,7 @@ func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx conte
 
 	// Only proceed to generating the Machine if we haven't encountered an error
 	if len(errs) == 0 {
-		if err := r.generateMachine(ctx, kcp, cluster, infraRef, bootstrapRef, failureDomain); err != nil {
+		if err := r.generateMachine(ctx, kcp, cluster, infraRef, bootstrapRef, failureDomain, machineName); err != nil {
 			conditions.MarkFalse(kcp, controlplanev1.MachinesCreatedCondition, controlplanev1.MachineGenerationFailedReason,
 				clusterv1.ConditionSeverityError, err.Error())
 			errs = append(errs, errors.Wrap(err, "failed to create Machine"))
@@ -230,7 +275,32 @@ func (r *KubeadmControlPlaneReconciler) cleanupFromGeneration(ctx context.Contex
 	return kerrors.NewAggregate(errs)
 }
 
-func (r *KubeadmControlPlaneReconciler) generateKubeadmConfig(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster, spec *bootstrapv1.KubeadmConfigSpec) (*corev1.ObjectReference, error) {
+// generateMachineName returns the name of a new Machine generated using the machine naming strategy of the KubeadmControlPlane,
+// or an empty string if the KubeadmControlPlane does not define a machine naming strategy.
+func (r *KubeadmControlPlaneReconciler) generateMachineName(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster) (string, error) {
+	if kcp.Spec.MachineNamingStrategy == nil {
+		return "", nil
+	}
+
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machineList, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterLabelName: cluster.Name}); err != nil {
+		return "", errors.Wrap(err, "failed to list Machines")
+	}
+	machineNames := sets.NewString()
+	for i := range machineList.Items {
+		machineNames.Insert(machineList.Items[i].Name)
+	}
+
+	return naming.Generate(naming.MachineNameInput{
+		Template:    kcp.Spec.MachineNamingStrategy.Template,
+		ClusterName: cluster.Name,
+		OwnerKey:    naming.KubeadmControlPlaneOwnerKey,
+		OwnerName:   kcp.Name,
+	}, machineNames)
+}
+
+// generateKubeadmConfig creates a KubeadmConfig for a new Machine; if name is empty, a name is generated from the KubeadmControlPlane name.
+func (r *KubeadmControlPlaneReconciler) generateKubeadmConfig(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster, spec *bootstrapv1.KubeadmConfigSpec, name string, gateJoin bool) (*corev1.ObjectReference, error) {
 	// Create an owner reference without a controller reference because the owning controller is the machine controller
 	owner := metav1.OwnerReference{
 		APIVersion: controlplanev1.GroupVersion.String(),
@@ -239,9 +309,13 @@ func (r *KubeadmControlPlaneReconciler) generateKubeadmConfig(ctx context.Contex
 		UID:        kcp.UID,
 	}
 
+	if name == "" {
+		name = names.SimpleNameGenerator.GenerateName(kcp.Name + "-")
+	}
+
 	bootstrapConfig := &bootstrapv1.KubeadmConfig{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:            names.SimpleNameGenerator.GenerateName(kcp.Name + "-"),
+			Name:            name,
 			Namespace:       kcp.Namespace,
 			Labels:          internal.ControlPlaneMachineLabelsForCluster(kcp, cluster.Name),
 			Annotations:     kcp.Spec.MachineTemplate.ObjectMeta.Annotations,
@@ -250,6 +324,14 @@ func (r *KubeadmControlPlaneReconciler) generateKubeadmConfig(ctx context.Contex
 		Spec: *spec,
 	}
 
+	// Pause the bootstrap configuration, so the bootstrap data is not generated until the join is released.
+	if gateJoin {
+		annotations.AddAnnotations(bootstrapConfig, map[string]string{
+			clusterv1.PausedAnnotation:         "",
+			controlplanev1.JoinGatedAnnotation: "",
+		})
+	}
+
 	if err := r.Client.Create(ctx, bootstrapConfig); err != nil {
 		return nil, errors.Wrap(err, "Failed to create bootstrap configuration")
 	}
@@ -265,10 +347,15 @@ func (r *KubeadmControlPlaneReconciler) generateKubeadmConfig(ctx context.Contex
 	return bootstrapRef, nil
 }
 
-func (r *KubeadmControlPlaneReconciler) generateMachine(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster, infraRef, bootstrapRef *corev1.ObjectReference, failureDomain *string) error {
+// generateMachine creates a new Machine; if name is empty, a name is generated from the KubeadmControlPlane name.
+func (r *KubeadmControlPlaneReconciler) generateMachine(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster, infraRef, bootstrapRef *corev1.ObjectReference, failureDomain *string, name string) error {
+	if name == "" {
+		name = names.SimpleNameGenerator.GenerateName(kcp.Name + "-")
+	}
+
 	machine := &clusterv1.Machine{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        names.SimpleNameGenerator.GenerateName(kcp.Name + "-"),
+			Name:        name,
 			Namespace:   kcp.Namespace,
 			Labels:      internal.ControlPlaneMachineLabelsForCluster(kcp, cluster.Name),
 			Annotations: map[string]string{},
@@ -285,6 +372,8 @@ func (r *KubeadmControlPlaneReconciler) generateMachine(ctx context.Context, kcp
 			},
 			FailureDomain:    failureDomain,
 			NodeDrainTimeout: kcp.Spec.MachineTemplate.NodeDrainTimeout,
+			NodeDrainOptions: kcp.Spec.MachineTemplate.NodeDrainOptions,
+			ReadinessGates:   kcp.Spec.MachineTemplate.ReadinessGates,
 		},
 	}
 
@@ -295,15 +384,68 @@ func (r *KubeadmControlPlaneReconciler) generateMachine(ctx context.Context, kcp
 		return errors.Wrap(err, "failed to marshal cluster configuration")
 	}
 
+	// We store EndpointManagement as annotation here to detect any changes in KCP EndpointManagement and rollout the machine if any.
+	endpointManagement, hasEndpointManagement, err := internal.EndpointManagementAnnotationValue(kcp)
+	if err != nil {
+		return err
+	}
+
+	// We store the hash of the certificate authorities as annotation here to detect machines to rollout while rotating them.
+	caHash, err := internal.CertificateAuthoritiesHash(ctx, r.managementClusterUncached, util.ObjectKey(cluster))
+	if err != nil {
+		return err
+	}
+
 	// Add the annotations from the MachineTemplate.
 	// Note: we intentionally don't use the map directly to ensure we don't modify the map in KCP.
 	for k, v := range kcp.Spec.MachineTemplate.ObjectMeta.Annotations {
 		machine.Annotations[k] = v
 	}
 	machine.Annotations[controlplanev1.KubeadmClusterConfigurationAnnotation] = string(clusterConfig)
+	if hasEndpointManagement {
+		machine.Annotations[controlplanev1.EndpointManagementAnnotation] = endpointManagement
+	}
+	if caHash != "" {
+		machine.Annotations[controlplanev1.CertificateAuthoritiesHashAnnotation] = caHash
+	}
 
 	if err := r.Client.Create(ctx, machine); err != nil {
 		return errors.Wrap(err, "failed to create machine")
 	}
 	return nil
 }
+
+// syncMachinesUsers propagates the users defined in the KubeadmControlPlane to the KubeadmConfigs of the existing machines,
+// so users and SSH authorized keys can be updated without rolling out the control plane machines.
+// NOTE: CABPK then updates the users data secret of each KubeadmConfig, that can be applied by node-side agents.
+func (r *KubeadmControlPlaneReconciler) syncMachinesUsers(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	errList := []error{}
+	for _, config := range controlPlane.KubeadmConfigsWithOutdatedUsers() {
+		patchHelper, err := patch.NewHelper(config, r.Client)
+		if err != nil {
+			errList = append(errList, errors.Wrapf(err, "failed to create patch helper for KubeadmConfig %s", config.Name))
+			continue
+		}
+
+		log.Info("Updating users of KubeadmConfig", "KubeadmConfig", config.Name)
+		config.Spec.Users = controlPlane.KCP.Spec.KubeadmConfigSpec.DeepCopy().Users
+		if err := patchHelper.Patch(ctx, config); err != nil {
+			errList = append(errList, errors.Wrapf(err, "failed to patch KubeadmConfig %s", config.Name))
+		}
+	}
+	return kerrors.NewAggregate(errList)
+}
+
+// machinesSpecDiffFields returns the sorted fields changed for at least one machine, classified by whether they require
+// a rollout of the machines.
+func machinesSpecDiffFields(diffs []controlplanev1.MachineSpecDiff) (rolloutFields, inPlaceFields []string) {
+	rollout := sets.NewString()
+	inPlace := sets.NewString()
+	for _, diff := range diffs {
+		rollout.Insert(diff.RolloutFields...)
+		inPlace.Insert(diff.InPlaceFields...)
+	}
+	return rollout.List(), inPlace.List()
+}
@@ -371,14 +371,15 @@ func TestCloneConfigsAndGenerateMachine(t *testing.T) {
 	fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), genericMachineTemplate.DeepCopy())
 
 	r := &KubeadmControlPlaneReconciler{
-		Client:   fakeClient,
-		recorder: record.NewFakeRecorder(32),
+		Client:                    fakeClient,
+		managementClusterUncached: &internal.Management{Client: fakeClient},
+		recorder:                  record.NewFakeRecorder(32),
 	}
 
 	bootstrapSpec := &bootstrapv1.KubeadmConfigSpec{
 		JoinConfiguration: &bootstrapv1.JoinConfiguration{},
 	}
-	g.Expect(r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, nil)).To(Succeed())
+	g.Expect(r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, nil, false)).To(Succeed())
 
 	machineList := &clusterv1.MachineList{}
 	g.Expect(fakeClient.List(ctx, machineList, client.InNamespace(cluster.Namespace))).To(Succeed())
@@ -406,6 +407,82 @@ func TestCloneConfigsAndGenerateMachine(t *testing.T) {
 	}
 }
 
+func TestCloneConfigsAndGenerateMachineWithMachineNamingStrategy(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	genericMachineTemplate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       "GenericMachineTemplate",
+			"apiVersion": "generic.io/v1",
+			"metadata": map[string]interface{}{
+				"name":      "infra-foo",
+				"namespace": cluster.Namespace,
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"hello": "world",
+					},
+				},
+			},
+		},
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kcp-foo",
+			Namespace: cluster.Namespace,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{
+					Kind:       genericMachineTemplate.GetKind(),
+					APIVersion: genericMachineTemplate.GetAPIVersion(),
+					Name:       genericMachineTemplate.GetName(),
+					Namespace:  cluster.Namespace,
+				},
+			},
+			Version: "v1.16.6",
+			MachineNamingStrategy: &clusterv1.MachineNamingStrategy{
+				Template: "{{ .cluster.name }}-cp-{{ .index }}",
+			},
+		},
+	}
+
+	existingMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo-cp-0",
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+		},
+	}
+
+	fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), genericMachineTemplate.DeepCopy(), existingMachine.DeepCopy())
+
+	r := &KubeadmControlPlaneReconciler{
+		Client:                    fakeClient,
+		managementClusterUncached: &internal.Management{Client: fakeClient},
+		recorder:                  record.NewFakeRecorder(32),
+	}
+
+	bootstrapSpec := &bootstrapv1.KubeadmConfigSpec{
+		JoinConfiguration: &bootstrapv1.JoinConfiguration{},
+	}
+	g.Expect(r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, nil, false)).To(Succeed())
+
+	m := &clusterv1.Machine{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: "foo-cp-1"}, m)).To(Succeed())
+	g.Expect(m.Spec.InfrastructureRef.Name).To(Equal("foo-cp-1"))
+	g.Expect(m.Spec.Bootstrap.ConfigRef.Name).To(Equal("foo-cp-1"))
+}
+
 func TestCloneConfigsAndGenerateMachineFail(t *testing.T) {
 	g := NewWithT(t)
 
@@ -455,8 +532,9 @@ func TestCloneConfigsAndGenerateMachineFail(t *testing.T) {
 	fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), genericMachineTemplate.DeepCopy())
 
 	r := &KubeadmControlPlaneReconciler{
-		Client:   fakeClient,
-		recorder: record.NewFakeRecorder(32),
+		Client:                    fakeClient,
+		managementClusterUncached: &internal.Management{Client: fakeClient},
+		recorder:                  record.NewFakeRecorder(32),
 	}
 
 	bootstrapSpec := &bootstrapv1.KubeadmConfigSpec{
@@ -465,7 +543,7 @@ func TestCloneConfigsAndGenerateMachineFail(t *testing.T) {
 
 	// Try to break Infra Cloning
 	kcp.Spec.MachineTemplate.InfrastructureRef.Name = "something_invalid"
-	g.Expect(r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, nil)).To(HaveOccurred())
+	g.Expect(r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, nil, false)).To(HaveOccurred())
 	g.Expect(&kcp.GetConditions()[0]).Should(conditions.HaveSameStateOf(&clusterv1.Condition{
 		Type:     controlplanev1.MachinesCreatedCondition,
 		Status:   corev1.ConditionFalse,
@@ -528,11 +606,12 @@ func TestKubeadmControlPlaneReconciler_generateMachine(t *testing.T) {
 		InfrastructureRef: *infraRef.DeepCopy(),
 	}
 	r := &KubeadmControlPlaneReconciler{
-		Client:            fakeClient,
-		managementCluster: &internal.Management{Client: fakeClient},
-		recorder:          record.NewFakeRecorder(32),
+		Client:                    fakeClient,
+		managementClusterUncached: &internal.Management{Client: fakeClient},
+		managementCluster:         &internal.Management{Client: fakeClient},
+		recorder:                  record.NewFakeRecorder(32),
 	}
-	g.Expect(r.generateMachine(ctx, kcp, cluster, infraRef, bootstrapRef, nil)).To(Succeed())
+	g.Expect(r.generateMachine(ctx, kcp, cluster, infraRef, bootstrapRef, nil, "")).To(Succeed())
 
 	machineList := &clusterv1.MachineList{}
 	g.Expect(fakeClient.List(ctx, machineList, client.InNamespace(cluster.Namespace))).To(Succeed())
@@ -590,7 +669,7 @@ func TestKubeadmControlPlaneReconciler_generateKubeadmConfig(t *testing.T) {
 		recorder: record.NewFakeRecorder(32),
 	}
 
-	got, err := r.generateKubeadmConfig(ctx, kcp, cluster, spec.DeepCopy())
+	got, err := r.generateKubeadmConfig(ctx, kcp, cluster, spec.DeepCopy(), "", false)
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(got).NotTo(BeNil())
 	g.Expect(got.Name).To(HavePrefix(kcp.Name))
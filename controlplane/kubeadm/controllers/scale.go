@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -53,7 +54,7 @@ func (r *KubeadmControlPlaneReconciler) initializeControlPlane(ctx context.Conte
 
 	bootstrapSpec := controlPlane.InitialControlPlaneConfig()
 	fd := controlPlane.NextFailureDomainForScaleUp()
-	if err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, fd); err != nil {
+	if err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, fd, false); err != nil {
 		logger.Error(err, "Failed to create initial control plane Machine")
 		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedInitialization", "Failed to create initial control plane Machine for cluster %s/%s control plane: %v", cluster.Namespace, cluster.Name, err)
 		return ctrl.Result{}, err
@@ -71,19 +72,81 @@ func (r *KubeadmControlPlaneReconciler) scaleUpControlPlane(ctx context.Context,
 		return result, err
 	}
 
-	// Create the bootstrap configuration
-	bootstrapSpec := controlPlane.JoinControlPlaneConfig()
-	fd := controlPlane.NextFailureDomainForScaleUp()
-	if err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, fd); err != nil {
-		logger.Error(err, "Failed to create additional control plane Machine")
-		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedScaleUp", "Failed to create additional control plane Machine for cluster %s/%s control plane: %v", cluster.Namespace, cluster.Name, err)
-		return ctrl.Result{}, err
+	machinesToCreate := 1
+	if isInitialScaleUp(kcp, controlPlane) {
+		machinesToCreate = int(*kcp.Spec.Replicas) - controlPlane.Machines.Len()
+		logger.Info("Creating the remaining control plane Machines", "count", machinesToCreate)
+	}
+
+	for i, fd := range controlPlane.NextFailureDomainsForScaleUp(machinesToCreate) {
+		// Create the bootstrap configuration; all the Machines but the first one wait for their join to be released,
+		// so etcd members are added one at a time.
+		bootstrapSpec := controlPlane.JoinControlPlaneConfig()
+		if err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, fd, i > 0); err != nil {
+			logger.Error(err, "Failed to create additional control plane Machine")
+			r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedScaleUp", "Failed to create additional control plane Machine for cluster %s/%s control plane: %v", cluster.Namespace, cluster.Name, err)
+			return ctrl.Result{}, err
+		}
 	}
 
 	// Requeue the control plane, in case there are other operations to perform
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// isInitialScaleUp returns true if the first control plane machine has been initialized and it is the only machine
+// of a control plane with more replicas; in this case, all the remaining replicas are created at once instead of one
+// at a time, which reduces the provisioning time of the control plane.
+// NOTE: etcd members must still join one at a time, because etcd rejects adding a member when the members already added
+// are not started yet; the joins are gated by pausing the KubeadmConfigs and released one at a time by reconcileGatedJoins.
+func isInitialScaleUp(kcp *controlplanev1.KubeadmControlPlane, controlPlane *internal.ControlPlane) bool {
+	return kcp.Status.Initialized &&
+		controlPlane.Machines.Len() == 1 &&
+		int(*kcp.Spec.Replicas) > 1 &&
+		controlPlane.MachinesNeedingRollout().Len() == 0
+}
+
+// reconcileGatedJoins releases the join of the control plane machines created at once during the initial scale up
+// one at a time, when all the other machines, i.e. the machines whose join has already been released, pass the preflight
+// checks; this ensures a new etcd member is added only when the previously added members are healthy.
+func (r *KubeadmControlPlaneReconciler) reconcileGatedJoins(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	logger := controlPlane.Logger()
+
+	gated := collections.New()
+	for _, machine := range controlPlane.Machines {
+		kubeadmConfig, ok := controlPlane.GetKubeadmConfig(machine.Name)
+		if !ok {
+			continue
+		}
+		if _, isGated := kubeadmConfig.GetAnnotations()[controlplanev1.JoinGatedAnnotation]; isGated {
+			gated.Insert(machine)
+		}
+	}
+	if gated.Len() == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	// Wait for the machines which already joined the control plane to be healthy.
+	if result, err := r.preflightChecks(ctx, controlPlane, gated.UnsortedList()...); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	machine := gated.Oldest()
+	kubeadmConfig, _ := controlPlane.GetKubeadmConfig(machine.Name)
+	patchHelper, err := patch.NewHelper(kubeadmConfig, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create patch helper for KubeadmConfig %s", kubeadmConfig.Name)
+	}
+	delete(kubeadmConfig.Annotations, clusterv1.PausedAnnotation)
+	delete(kubeadmConfig.Annotations, controlplanev1.JoinGatedAnnotation)
+	if err := patchHelper.Patch(ctx, kubeadmConfig); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to release the join of Machine %s", machine.Name)
+	}
+	logger.Info("Released the join of control plane Machine", "Machine", machine.Name, "remaining", gated.Len()-1)
+
+	// Requeue the control plane, so the next join is released once this machine is healthy.
+	return ctrl.Result{Requeue: true}, nil
+}
+
 func (r *KubeadmControlPlaneReconciler) scaleDownControlPlane(
 	ctx context.Context,
 	cluster *clusterv1.Cluster,
@@ -23,8 +23,11 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -102,6 +105,7 @@ func TestKubeadmControlPlaneReconciler_scaleUpControlPlane(t *testing.T) {
 		}
 
 		fakeClient := newFakeClient(initObjs...)
+		fmc.Reader = fakeClient
 
 		r := &KubeadmControlPlaneReconciler{
 			Client:                    fakeClient,
@@ -123,6 +127,75 @@ func TestKubeadmControlPlaneReconciler_scaleUpControlPlane(t *testing.T) {
 		g.Expect(fakeClient.List(ctx, &controlPlaneMachines)).To(Succeed())
 		g.Expect(controlPlaneMachines.Items).To(HaveLen(3))
 	})
+	t.Run("creates all the remaining control plane Machines after the first one is initialized", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, genericMachineTemplate := createClusterWithControlPlane(metav1.NamespaceDefault)
+		cluster.Status.FailureDomains = clusterv1.FailureDomains{
+			"one":   clusterv1.FailureDomainSpec{ControlPlane: true},
+			"two":   clusterv1.FailureDomainSpec{ControlPlane: true},
+			"three": clusterv1.FailureDomainSpec{ControlPlane: true},
+		}
+		kcp.Status.Initialized = true
+		setKCPHealthy(kcp)
+		initObjs := []client.Object{cluster.DeepCopy(), kcp.DeepCopy(), genericMachineTemplate.DeepCopy()}
+
+		fmc := &fakeManagementCluster{
+			Machines: collections.New(),
+			Workload: fakeWorkloadCluster{},
+		}
+
+		m, _ := createMachineNodePair("test-0", cluster, kcp, true)
+		m.Spec.Version = pointer.String(kcp.Spec.Version)
+		m.Spec.FailureDomain = pointer.String("one")
+		setMachineHealthy(m)
+		fmc.Machines.Insert(m)
+		initObjs = append(initObjs, m.DeepCopy())
+
+		fakeClient := newFakeClient(initObjs...)
+		fmc.Reader = fakeClient
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:                    fakeClient,
+			managementCluster:         fmc,
+			managementClusterUncached: fmc,
+			recorder:                  record.NewFakeRecorder(32),
+		}
+		controlPlane := &internal.ControlPlane{
+			KCP:      kcp,
+			Cluster:  cluster,
+			Machines: fmc.Machines,
+		}
+
+		result, err := r.scaleUpControlPlane(ctx, cluster, kcp, controlPlane)
+		g.Expect(result).To(Equal(ctrl.Result{Requeue: true}))
+		g.Expect(err).ToNot(HaveOccurred())
+
+		controlPlaneMachines := clusterv1.MachineList{}
+		g.Expect(fakeClient.List(ctx, &controlPlaneMachines)).To(Succeed())
+		g.Expect(controlPlaneMachines.Items).To(HaveLen(3))
+
+		// The new Machines are spread across the failure domains.
+		failureDomains := sets.NewString()
+		for _, machine := range controlPlaneMachines.Items {
+			g.Expect(machine.Spec.FailureDomain).ToNot(BeNil())
+			failureDomains.Insert(*machine.Spec.FailureDomain)
+		}
+		g.Expect(failureDomains.List()).To(ConsistOf("one", "two", "three"))
+
+		// Only the first new Machine joins right away, the join of the other one is gated.
+		kubeadmConfigs := bootstrapv1.KubeadmConfigList{}
+		g.Expect(fakeClient.List(ctx, &kubeadmConfigs)).To(Succeed())
+		g.Expect(kubeadmConfigs.Items).To(HaveLen(2))
+		gated := 0
+		for _, kubeadmConfig := range kubeadmConfigs.Items {
+			if _, ok := kubeadmConfig.Annotations[controlplanev1.JoinGatedAnnotation]; ok {
+				g.Expect(kubeadmConfig.Annotations).To(HaveKey(clusterv1.PausedAnnotation))
+				gated++
+			}
+		}
+		g.Expect(gated).To(Equal(1))
+	})
 	t.Run("does not create a control plane Machine if preflight checks fail", func(t *testing.T) {
 		cluster, kcp, genericMachineTemplate := createClusterWithControlPlane(metav1.NamespaceDefault)
 		initObjs := []client.Object{fakeGenericMachineTemplateCRD, cluster.DeepCopy(), kcp.DeepCopy(), genericMachineTemplate.DeepCopy()}
@@ -574,3 +647,113 @@ func withTimestamp(t time.Time) machineOpt {
 		m.CreationTimestamp = metav1.NewTime(t)
 	}
 }
+
+func TestKubeadmControlPlaneReconciler_reconcileGatedJoins(t *testing.T) {
+	newMachineWithConfig := func(name string, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, healthy, gated bool, created time.Time) (*clusterv1.Machine, *bootstrapv1.KubeadmConfig) {
+		kubeadmConfig := &bootstrapv1.KubeadmConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Namespace,
+				Name:      name,
+			},
+		}
+		if gated {
+			kubeadmConfig.Annotations = map[string]string{
+				clusterv1.PausedAnnotation:         "",
+				controlplanev1.JoinGatedAnnotation: "",
+			}
+		}
+
+		m, _ := createMachineNodePair(name, cluster, kcp, healthy)
+		m.CreationTimestamp = metav1.Time{Time: created}
+		m.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+			APIVersion: bootstrapv1.GroupVersion.String(),
+			Kind:       "KubeadmConfig",
+			Name:       kubeadmConfig.Name,
+		}
+		if healthy {
+			setMachineHealthy(m)
+		}
+		return m, kubeadmConfig
+	}
+
+	isGated := func(g *WithT, c client.Client, name string) bool {
+		kubeadmConfig := &bootstrapv1.KubeadmConfig{}
+		g.Expect(c.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, kubeadmConfig)).To(Succeed())
+		_, gated := kubeadmConfig.Annotations[controlplanev1.JoinGatedAnnotation]
+		_, paused := kubeadmConfig.Annotations[clusterv1.PausedAnnotation]
+		g.Expect(paused).To(Equal(gated))
+		return gated
+	}
+
+	tests := []struct {
+		name          string
+		joinedHealthy bool
+		wantResult    ctrl.Result
+		wantGated     []string
+		wantNotGated  []string
+	}{
+		{
+			name:          "releases the join of the oldest gated Machine when the joined Machines are healthy",
+			joinedHealthy: true,
+			wantResult:    ctrl.Result{Requeue: true},
+			wantGated:     []string{"machine-3"},
+			wantNotGated:  []string{"machine-2"},
+		},
+		{
+			name:          "does not release any join while the joined Machines are not healthy",
+			joinedHealthy: false,
+			wantResult:    ctrl.Result{RequeueAfter: preflightFailedRequeueAfter},
+			wantGated:     []string{"machine-2", "machine-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+			now := time.Now()
+			m1, c1 := newMachineWithConfig("machine-1", cluster, kcp, tt.joinedHealthy, false, now.Add(-3*time.Minute))
+			m2, c2 := newMachineWithConfig("machine-2", cluster, kcp, false, true, now.Add(-2*time.Minute))
+			m3, c3 := newMachineWithConfig("machine-3", cluster, kcp, false, true, now.Add(-time.Minute))
+
+			fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), m1.DeepCopy(), m2.DeepCopy(), m3.DeepCopy(), c1, c2, c3)
+			controlPlane, err := internal.NewControlPlane(ctx, fakeClient, cluster, kcp, collections.FromMachines(m1, m2, m3))
+			g.Expect(err).ToNot(HaveOccurred())
+
+			r := &KubeadmControlPlaneReconciler{
+				Client:   fakeClient,
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			result, err := r.reconcileGatedJoins(ctx, controlPlane)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(tt.wantResult))
+			for _, name := range tt.wantGated {
+				g.Expect(isGated(g, fakeClient, name)).To(BeTrue())
+			}
+			for _, name := range tt.wantNotGated {
+				g.Expect(isGated(g, fakeClient, name)).To(BeFalse())
+			}
+		})
+	}
+
+	t.Run("does nothing without gated Machines", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		m1, c1 := newMachineWithConfig("machine-1", cluster, kcp, false, false, time.Now())
+
+		fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), m1.DeepCopy(), c1)
+		controlPlane, err := internal.NewControlPlane(ctx, fakeClient, cluster, kcp, collections.FromMachines(m1))
+		g.Expect(err).ToNot(HaveOccurred())
+
+		r := &KubeadmControlPlaneReconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+
+		result, err := r.reconcileGatedJoins(ctx, controlPlane)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.IsZero()).To(BeTrue())
+	})
+}
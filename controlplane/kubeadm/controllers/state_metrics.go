@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/exp/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// KubeadmControlPlaneStateMetrics returns the state metrics of KubeadmControlPlanes.
+func KubeadmControlPlaneStateMetrics() metrics.ObjectMetrics {
+	replicas := metrics.NewDesc("capi_kcp_spec_replicas", "The desired number of Machines of the KubeadmControlPlane.", "cluster_name")
+	replicasCurrent := metrics.NewDesc("capi_kcp_replicas", "The number of Machines of the KubeadmControlPlane.", "cluster_name")
+	replicasReady := metrics.NewDesc("capi_kcp_replicas_ready", "The number of ready Machines of the KubeadmControlPlane.", "cluster_name")
+	replicasUpdated := metrics.NewDesc("capi_kcp_replicas_updated", "The number of Machines of the KubeadmControlPlane with the desired spec.", "cluster_name")
+	replicasUnavailable := metrics.NewDesc("capi_kcp_replicas_unavailable", "The number of unavailable Machines of the KubeadmControlPlane.", "cluster_name")
+	initialized := metrics.NewDesc("capi_kcp_status_initialized", "Whether the control plane of the KubeadmControlPlane has been initialized (1) or not (0).", "cluster_name")
+	condition := metrics.NewDesc("capi_kcp_status_condition", "The status of the conditions of the KubeadmControlPlane.", "cluster_name", "type", "status")
+	return metrics.ObjectMetrics{
+		NewList: func() client.ObjectList { return &controlplanev1.KubeadmControlPlaneList{} },
+		Descs:   []*prometheus.Desc{replicas, replicasCurrent, replicasReady, replicasUpdated, replicasUnavailable, initialized, condition},
+		Collect: func(obj client.Object, ch chan<- prometheus.Metric) {
+			kcp := obj.(*controlplanev1.KubeadmControlPlane)
+			clusterName := kcp.Labels[clusterv1.ClusterLabelName]
+			metrics.Gauge(ch, replicas, metrics.Int32(kcp.Spec.Replicas), kcp.Namespace, kcp.Name, clusterName)
+			metrics.Gauge(ch, replicasCurrent, float64(kcp.Status.Replicas), kcp.Namespace, kcp.Name, clusterName)
+			metrics.Gauge(ch, replicasReady, float64(kcp.Status.ReadyReplicas), kcp.Namespace, kcp.Name, clusterName)
+			metrics.Gauge(ch, replicasUpdated, float64(kcp.Status.UpdatedReplicas), kcp.Namespace, kcp.Name, clusterName)
+			metrics.Gauge(ch, replicasUnavailable, float64(kcp.Status.UnavailableReplicas), kcp.Namespace, kcp.Name, clusterName)
+			metrics.Gauge(ch, initialized, metrics.Bool(kcp.Status.Initialized), kcp.Namespace, kcp.Name, clusterName)
+			metrics.Conditions(ch, condition, kcp.Status.Conditions, kcp.Namespace, kcp.Name, clusterName)
+		},
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
@@ -50,6 +51,7 @@ func (r *KubeadmControlPlaneReconciler) updateStatus(ctx context.Context, kcp *c
 		return err
 	}
 	kcp.Status.UpdatedReplicas = int32(len(controlPlane.UpToDateMachines()))
+	kcp.Status.MachinesSpecDiff = controlPlane.MachinesSpecDiff()
 
 	replicas := int32(len(ownedMachines))
 	desiredReplicas := *kcp.Spec.Replicas
@@ -102,8 +104,16 @@ func (r *KubeadmControlPlaneReconciler) updateStatus(ctx context.Context, kcp *c
 	if err != nil {
 		return err
 	}
-	kcp.Status.ReadyReplicas = status.ReadyNodes
-	kcp.Status.UnavailableReplicas = replicas - status.ReadyNodes
+	// Machines with readiness gates not satisfied are not considered ready, no matter of the node status.
+	readyNodeNames := sets.NewString(status.ReadyNodeNames...)
+	readyReplicas := status.ReadyNodes
+	for _, m := range ownedMachines {
+		if m.Status.NodeRef != nil && readyNodeNames.Has(m.Status.NodeRef.Name) && !collections.HasReadinessGatesSatisfied(m) {
+			readyReplicas--
+		}
+	}
+	kcp.Status.ReadyReplicas = readyReplicas
+	kcp.Status.UnavailableReplicas = replicas - readyReplicas
 
 	// This only gets initialized once and does not change if the kubeadm config map goes away.
 	if status.HasKubeadmConfig {
@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RotatedCertificateAuthorities lists the certificate authorities a KubeadmControlPlane can rotate.
+var RotatedCertificateAuthorities = []secret.Purpose{secret.ClusterCA, secret.EtcdCA}
+
+// CertificateAuthoritiesHash returns a hash of the certificates currently trusted as cluster CA and etcd CA
+// for a cluster; certificate authorities not existing yet are ignored, and an empty string is returned if none exist.
+func CertificateAuthoritiesHash(ctx context.Context, c client.Reader, clusterName client.ObjectKey) (string, error) {
+	hasher := sha256.New()
+	found := false
+	for _, purpose := range RotatedCertificateAuthorities {
+		s, err := secret.Get(ctx, c, clusterName, purpose)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", errors.Wrapf(err, "failed to get %s secret", purpose)
+		}
+		found = true
+		_, _ = hasher.Write([]byte(purpose))
+		_, _ = hasher.Write(s.Data[secret.TLSCrtDataName])
+	}
+	if !found {
+		return "", nil
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// MatchesCertificateAuthoritiesHash returns a filter to find all machines created while the given certificate
+// authorities were trusted, as recorded in the CertificateAuthoritiesHashAnnotation.
+func MatchesCertificateAuthoritiesHash(hash string) collections.Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		return machine.GetAnnotations()[controlplanev1.CertificateAuthoritiesHashAnnotation] == hash
+	}
+}
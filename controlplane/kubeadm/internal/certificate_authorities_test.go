@@ -0,0 +1,93 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/secret"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCertificateAuthoritiesHash(t *testing.T) {
+	g := NewWithT(t)
+
+	clusterName := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"}
+	caSecret := func(purpose secret.Purpose, crt string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: clusterName.Namespace, Name: secret.Name(clusterName.Name, purpose)},
+			Data:       map[string][]byte{secret.TLSCrtDataName: []byte(crt), secret.TLSKeyDataName: []byte("key")},
+		}
+	}
+
+	hash, err := CertificateAuthoritiesHash(ctx, fake.NewClientBuilder().Build(), clusterName)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hash).To(BeEmpty())
+
+	hash, err = CertificateAuthoritiesHash(ctx, fake.NewClientBuilder().WithObjects(caSecret(secret.ClusterCA, "ca"), caSecret(secret.EtcdCA, "etcd")).Build(), clusterName)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(hash).ToNot(BeEmpty())
+
+	rotatedHash, err := CertificateAuthoritiesHash(ctx, fake.NewClientBuilder().WithObjects(caSecret(secret.ClusterCA, "ca"), caSecret(secret.EtcdCA, "etcd+new-etcd")).Build(), clusterName)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rotatedHash).ToNot(Equal(hash))
+
+	// The same certificates for a different certificate authority should not result in the same hash.
+	swappedHash, err := CertificateAuthoritiesHash(ctx, fake.NewClientBuilder().WithObjects(caSecret(secret.ClusterCA, "etcd"), caSecret(secret.EtcdCA, "ca")).Build(), clusterName)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(swappedHash).ToNot(Equal(hash))
+}
+
+func TestMachinesNeedingRolloutWithCertificateAuthoritiesRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	version := "v1.22.0"
+	withVersion := func(m *clusterv1.Machine) {
+		m.Spec.Version = &version
+	}
+	withCAHash := func(hash string) machineOpt {
+		return func(m *clusterv1.Machine) {
+			m.Annotations = map[string]string{controlplanev1.CertificateAuthoritiesHashAnnotation: hash}
+		}
+	}
+
+	controlPlane := &ControlPlane{
+		KCP: &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{Version: version},
+		},
+		Machines: collections.FromMachines(
+			machine("machine-1", withVersion, withCAHash("old")),
+			machine("machine-2", withVersion, withCAHash("new")),
+			machine("machine-3", withVersion),
+		),
+	}
+
+	// Certificate authorities are not considered while no rotation is in progress.
+	g.Expect(controlPlane.MachinesNeedingRollout()).To(BeEmpty())
+	g.Expect(controlPlane.UpToDateMachines().Names()).To(ConsistOf("machine-1", "machine-2", "machine-3"))
+
+	controlPlane.CertificateAuthoritiesHash = "new"
+	g.Expect(controlPlane.MachinesNeedingRollout().Names()).To(ConsistOf("machine-1", "machine-3"))
+	g.Expect(controlPlane.UpToDateMachines().Names()).To(ConsistOf("machine-2"))
+}
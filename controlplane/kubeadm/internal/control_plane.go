@@ -18,6 +18,9 @@ package internal
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"sort"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -32,7 +35,9 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/failuredomains"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -50,6 +55,11 @@ type ControlPlane struct {
 	Machines             collections.Machines
 	machinesPatchHelpers map[string]*patch.Helper
 
+	// CertificateAuthoritiesHash is the hash of the certificate authorities to be trusted by all the machines
+	// while a certificate authorities rotation is in progress; machines created while different certificate
+	// authorities were trusted require rollout. It is empty when no rotation is in progress.
+	CertificateAuthoritiesHash string
+
 	// reconciliationTime is the time of the current reconciliation, and should be used for all "now" calculations
 	reconciliationTime metav1.Time
 
@@ -145,7 +155,9 @@ func (c *ControlPlane) MachineInFailureDomainWithMostMachines(machines collectio
 // MachineWithDeleteAnnotation returns a machine that has been annotated with DeleteMachineAnnotation key.
 func (c *ControlPlane) MachineWithDeleteAnnotation(machines collections.Machines) collections.Machines {
 	// See if there are any machines with DeleteMachineAnnotation key.
-	annotatedMachines := machines.Filter(collections.HasAnnotationKey(clusterv1.DeleteMachineAnnotation))
+	annotatedMachines := machines.Filter(func(machine *clusterv1.Machine) bool {
+		return annotations.HasDeleteMachineAnnotation(machine)
+	})
 	// If there are, return list of annotated machines.
 	return annotatedMachines
 }
@@ -174,6 +186,30 @@ func (c *ControlPlane) NextFailureDomainForScaleUp() *string {
 	return failuredomains.PickFewest(c.FailureDomains().FilterControlPlane(), c.UpToDateMachines())
 }
 
+// NextFailureDomainsForScaleUp returns the failure domains where to create the given number of machines at once,
+// spreading them across the failure domains as NextFailureDomainForScaleUp does when machines are created one by one.
+func (c *ControlPlane) NextFailureDomainsForScaleUp(count int) []*string {
+	failureDomains := make([]*string, 0, count)
+	if len(c.Cluster.Status.FailureDomains.FilterControlPlane()) == 0 {
+		for i := 0; i < count; i++ {
+			failureDomains = append(failureDomains, nil)
+		}
+		return failureDomains
+	}
+
+	// Account for the machines to be created, so the following picks are spread across the remaining failure domains.
+	machines := c.UpToDateMachines()
+	for i := 0; i < count; i++ {
+		failureDomain := failuredomains.PickFewest(c.FailureDomains().FilterControlPlane(), machines)
+		failureDomains = append(failureDomains, failureDomain)
+		machines.Insert(&clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: names.SimpleNameGenerator.GenerateName("scale-up-")},
+			Spec:       clusterv1.MachineSpec{FailureDomain: failureDomain},
+		})
+	}
+	return failureDomains
+}
+
 // InitialControlPlaneConfig returns a new KubeadmConfigSpec that is to be used for an initializing control plane.
 func (c *ControlPlane) InitialControlPlaneConfig() *bootstrapv1.KubeadmConfigSpec {
 	bootstrapSpec := c.KCP.Spec.KubeadmConfigSpec.DeepCopy()
@@ -260,24 +296,139 @@ func (c *ControlPlane) MachinesNeedingRollout() collections.Machines {
 
 	// Return machines if they are scheduled for rollout or if with an outdated configuration.
 	return machines.AnyFilter(
+		// Machines whose certificates are about to expire.
+		collections.ShouldRolloutBefore(&c.reconciliationTime, c.KCP.Spec.RolloutBefore),
 		// Machines that are scheduled for rollout (KCP.Spec.RolloutAfter set, the RolloutAfter deadline is expired, and the machine was created before the deadline).
 		collections.ShouldRolloutAfter(&c.reconciliationTime, c.KCP.Spec.RolloutAfter),
 		// Machines that do not match with KCP config.
 		collections.Not(MatchesMachineSpec(c.infraResources, c.kubeadmConfigs, c.KCP)),
+		// Machines that do not trust the certificate authorities of an in progress rotation.
+		collections.Not(c.matchesCertificateAuthorities()),
 	)
 }
 
+// MachinesSpecDiff returns, for each machine needing rollout, the changes between the KubeadmControlPlane and the
+// machine, classified by whether they require a rollout of the machine; the result is sorted by machine name.
+func (c *ControlPlane) MachinesSpecDiff() []controlplanev1.MachineSpecDiff {
+	var diffs []controlplanev1.MachineSpecDiff
+	machines := c.MachinesNeedingRollout()
+	names := machines.Names()
+	sort.Strings(names)
+	for _, name := range names {
+		machine := machines[name]
+		rolloutFields, inPlaceFields := MachineSpecDiff(c.infraResources, c.kubeadmConfigs, c.KCP, machine)
+		if collections.ShouldRolloutBefore(&c.reconciliationTime, c.KCP.Spec.RolloutBefore)(machine) {
+			rolloutFields = append(rolloutFields, "spec.rolloutBefore")
+		}
+		if collections.ShouldRolloutAfter(&c.reconciliationTime, c.KCP.Spec.RolloutAfter)(machine) {
+			rolloutFields = append(rolloutFields, "spec.rolloutAfter")
+		}
+		if !c.matchesCertificateAuthorities()(machine) {
+			rolloutFields = append(rolloutFields, "spec.certificateAuthoritiesRotation")
+		}
+		diffs = append(diffs, controlplanev1.MachineSpecDiff{
+			MachineName:   name,
+			RolloutFields: rolloutFields,
+			InPlaceFields: inPlaceFields,
+		})
+	}
+	return diffs
+}
+
+// componentHealthConditions maps the control plane components to the machine conditions reporting their health.
+var componentHealthConditions = map[controlplanev1.ControlPlaneComponent]clusterv1.ConditionType{
+	controlplanev1.APIServerComponent:         controlplanev1.MachineAPIServerPodHealthyCondition,
+	controlplanev1.ControllerManagerComponent: controlplanev1.MachineControllerManagerPodHealthyCondition,
+	controlplanev1.SchedulerComponent:         controlplanev1.MachineSchedulerPodHealthyCondition,
+	controlplanev1.EtcdPodComponent:           controlplanev1.MachineEtcdPodHealthyCondition,
+	controlplanev1.EtcdMemberComponent:        controlplanev1.MachineEtcdMemberHealthyCondition,
+}
+
+// ComponentsHealth returns, for each machine, the health of the control plane components hosted on the machine
+// as reported by the machine conditions; the result is sorted by machine name.
+// NOTE: etcd components are reported only if etcd is managed by KubeadmControlPlane.
+func (c *ControlPlane) ComponentsHealth() []controlplanev1.MachineComponentsHealth {
+	components := []controlplanev1.ControlPlaneComponent{
+		controlplanev1.APIServerComponent,
+		controlplanev1.ControllerManagerComponent,
+		controlplanev1.SchedulerComponent,
+	}
+	if c.IsEtcdManaged() {
+		components = append(components, controlplanev1.EtcdPodComponent, controlplanev1.EtcdMemberComponent)
+	}
+
+	var health []controlplanev1.MachineComponentsHealth
+	names := c.Machines.Names()
+	sort.Strings(names)
+	for _, name := range names {
+		machine := c.Machines[name]
+		machineHealth := controlplanev1.MachineComponentsHealth{MachineName: name}
+		for _, component := range components {
+			conditionType := componentHealthConditions[component]
+			componentHealth := controlplanev1.ComponentHealth{
+				Component: component,
+				Status:    corev1.ConditionUnknown,
+				Message:   fmt.Sprintf("%s condition not yet reported", conditionType),
+			}
+			if condition := conditions.Get(machine, conditionType); condition != nil {
+				componentHealth.Status = condition.Status
+				componentHealth.Reason = condition.Reason
+				componentHealth.Message = condition.Message
+				componentHealth.LastTransitionTime = condition.LastTransitionTime
+			}
+			machineHealth.Components = append(machineHealth.Components, componentHealth)
+		}
+		health = append(health, machineHealth)
+	}
+	return health
+}
+
 // UpToDateMachines returns the machines that are up to date with the control
 // plane's configuration and therefore do not require rollout.
 func (c *ControlPlane) UpToDateMachines() collections.Machines {
 	return c.Machines.Filter(
+		// Machines whose certificates are not about to expire.
+		collections.Not(collections.ShouldRolloutBefore(&c.reconciliationTime, c.KCP.Spec.RolloutBefore)),
 		// Machines that shouldn't be rolled out after the deadline has expired.
 		collections.Not(collections.ShouldRolloutAfter(&c.reconciliationTime, c.KCP.Spec.RolloutAfter)),
 		// Machines that match with KCP config.
 		MatchesMachineSpec(c.infraResources, c.kubeadmConfigs, c.KCP),
+		// Machines that trust the certificate authorities of an in progress rotation.
+		c.matchesCertificateAuthorities(),
 	)
 }
 
+// matchesCertificateAuthorities returns a filter to find all the machines trusting the certificate authorities
+// of an in progress rotation; if no rotation is in progress, all the machines match.
+func (c *ControlPlane) matchesCertificateAuthorities() collections.Func {
+	if c.CertificateAuthoritiesHash == "" {
+		return func(_ *clusterv1.Machine) bool { return true }
+	}
+	return MatchesCertificateAuthoritiesHash(c.CertificateAuthoritiesHash)
+}
+
+// GetKubeadmConfig returns the KubeadmConfig of a given machine.
+func (c *ControlPlane) GetKubeadmConfig(machineName string) (*bootstrapv1.KubeadmConfig, bool) {
+	kubeadmConfig, ok := c.kubeadmConfigs[machineName]
+	return kubeadmConfig, ok
+}
+
+// KubeadmConfigsWithOutdatedUsers returns the KubeadmConfigs of the machines, excluding the ones being deleted, whose users
+// differ from the users defined in the KubeadmControlPlane.
+func (c *ControlPlane) KubeadmConfigsWithOutdatedUsers() []*bootstrapv1.KubeadmConfig {
+	var configs []*bootstrapv1.KubeadmConfig
+	for _, machine := range c.Machines.Filter(collections.Not(collections.HasDeletionTimestamp)).SortedByCreationTimestamp() {
+		config, ok := c.kubeadmConfigs[machine.Name]
+		if !ok || config == nil {
+			continue
+		}
+		if !reflect.DeepEqual(config.Spec.Users, c.KCP.Spec.KubeadmConfigSpec.Users) {
+			configs = append(configs, config)
+		}
+	}
+	return configs
+}
+
 // getInfraResources fetches the external infrastructure resource for each machine in the collection and returns a map of machine.Name -> infraResource.
 func getInfraResources(ctx context.Context, cl client.Client, machines collections.Machines) (map[string]*unstructured.Unstructured, error) {
 	result := map[string]*unstructured.Unstructured{}
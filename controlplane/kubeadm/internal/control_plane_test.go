@@ -18,6 +18,7 @@ package internal
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -93,6 +94,176 @@ func TestControlPlane(t *testing.T) {
 	})
 }
 
+func TestKubeadmConfigsWithOutdatedUsers(t *testing.T) {
+	g := NewWithT(t)
+
+	users := []bootstrapv1.User{{Name: "capi", SSHAuthorizedKeys: []string{"ssh-rsa AAAA2"}}}
+	deletionTimestamp := metav1.Now()
+	deletingMachine := machine("machine-3")
+	deletingMachine.DeletionTimestamp = &deletionTimestamp
+
+	c := ControlPlane{
+		KCP: &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{Users: users},
+			},
+		},
+		Machines: collections.FromMachines(
+			machine("machine-1"),
+			machine("machine-2"),
+			deletingMachine,
+		),
+		kubeadmConfigs: map[string]*bootstrapv1.KubeadmConfig{
+			"machine-1": {
+				ObjectMeta: metav1.ObjectMeta{Name: "config-1"},
+				Spec:       bootstrapv1.KubeadmConfigSpec{Users: users},
+			},
+			"machine-2": {
+				ObjectMeta: metav1.ObjectMeta{Name: "config-2"},
+				Spec:       bootstrapv1.KubeadmConfigSpec{Users: []bootstrapv1.User{{Name: "capi", SSHAuthorizedKeys: []string{"ssh-rsa AAAA1"}}}},
+			},
+			"machine-3": {
+				ObjectMeta: metav1.ObjectMeta{Name: "config-3"},
+			},
+		},
+	}
+
+	configs := c.KubeadmConfigsWithOutdatedUsers()
+	g.Expect(configs).To(HaveLen(1))
+	g.Expect(configs[0].Name).To(Equal("config-2"))
+}
+
+func TestMachinesSpecDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	withVersion := func(version string) machineOpt {
+		return func(m *clusterv1.Machine) {
+			m.Spec.Version = pointer.String(version)
+			m.CreationTimestamp = metav1.NewTime(time.Now().Add(-time.Hour))
+		}
+	}
+	rolloutAfter := metav1.Now()
+
+	c := ControlPlane{
+		KCP: &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version:      "v1.22.2",
+				RolloutAfter: &rolloutAfter,
+			},
+		},
+		Machines: collections.FromMachines(
+			machine("machine-2", withVersion("v1.22.2")),
+			machine("machine-1", withVersion("v1.22.1")),
+		),
+		reconciliationTime: metav1.Now(),
+	}
+
+	g.Expect(c.MachinesSpecDiff()).To(Equal([]controlplanev1.MachineSpecDiff{
+		{
+			MachineName:   "machine-1",
+			RolloutFields: []string{"spec.version", "spec.rolloutAfter"},
+		},
+		{
+			MachineName:   "machine-2",
+			RolloutFields: []string{"spec.rolloutAfter"},
+		},
+	}))
+
+	c.KCP.Spec.RolloutAfter = nil
+	g.Expect(c.MachinesSpecDiff()).To(Equal([]controlplanev1.MachineSpecDiff{
+		{
+			MachineName:   "machine-1",
+			RolloutFields: []string{"spec.version"},
+		},
+	}))
+}
+
+func TestComponentsHealth(t *testing.T) {
+	g := NewWithT(t)
+
+	withConditions := func(conds ...*clusterv1.Condition) machineOpt {
+		return func(m *clusterv1.Machine) {
+			for _, c := range conds {
+				conditions.Set(m, c)
+			}
+		}
+	}
+	m1 := machine("machine-1", withConditions(
+		conditions.TrueCondition(controlplanev1.MachineAPIServerPodHealthyCondition),
+		conditions.TrueCondition(controlplanev1.MachineControllerManagerPodHealthyCondition),
+		conditions.FalseCondition(controlplanev1.MachineSchedulerPodHealthyCondition, controlplanev1.PodFailedReason, clusterv1.ConditionSeverityError, "CrashLoopBackOff"),
+		conditions.TrueCondition(controlplanev1.MachineEtcdPodHealthyCondition),
+		conditions.UnknownCondition(controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberInspectionFailedReason, "Failed to connect to the etcd pod on the node"),
+	))
+	m2 := machine("machine-2")
+
+	c := ControlPlane{
+		KCP:      &controlplanev1.KubeadmControlPlane{},
+		Machines: collections.FromMachines(m2, m1),
+	}
+
+	lastTransitionTime := func(m *clusterv1.Machine, t clusterv1.ConditionType) metav1.Time {
+		return conditions.Get(m, t).LastTransitionTime
+	}
+	g.Expect(c.ComponentsHealth()).To(Equal([]controlplanev1.MachineComponentsHealth{
+		{
+			MachineName: "machine-1",
+			Components: []controlplanev1.ComponentHealth{
+				{
+					Component:          controlplanev1.APIServerComponent,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: lastTransitionTime(m1, controlplanev1.MachineAPIServerPodHealthyCondition),
+				},
+				{
+					Component:          controlplanev1.ControllerManagerComponent,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: lastTransitionTime(m1, controlplanev1.MachineControllerManagerPodHealthyCondition),
+				},
+				{
+					Component:          controlplanev1.SchedulerComponent,
+					Status:             corev1.ConditionFalse,
+					Reason:             controlplanev1.PodFailedReason,
+					Message:            "CrashLoopBackOff",
+					LastTransitionTime: lastTransitionTime(m1, controlplanev1.MachineSchedulerPodHealthyCondition),
+				},
+				{
+					Component:          controlplanev1.EtcdPodComponent,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: lastTransitionTime(m1, controlplanev1.MachineEtcdPodHealthyCondition),
+				},
+				{
+					Component:          controlplanev1.EtcdMemberComponent,
+					Status:             corev1.ConditionUnknown,
+					Reason:             controlplanev1.EtcdMemberInspectionFailedReason,
+					Message:            "Failed to connect to the etcd pod on the node",
+					LastTransitionTime: lastTransitionTime(m1, controlplanev1.MachineEtcdMemberHealthyCondition),
+				},
+			},
+		},
+		{
+			MachineName: "machine-2",
+			Components: []controlplanev1.ComponentHealth{
+				{Component: controlplanev1.APIServerComponent, Status: corev1.ConditionUnknown, Message: "APIServerPodHealthy condition not yet reported"},
+				{Component: controlplanev1.ControllerManagerComponent, Status: corev1.ConditionUnknown, Message: "ControllerManagerPodHealthy condition not yet reported"},
+				{Component: controlplanev1.SchedulerComponent, Status: corev1.ConditionUnknown, Message: "SchedulerPodHealthy condition not yet reported"},
+				{Component: controlplanev1.EtcdPodComponent, Status: corev1.ConditionUnknown, Message: "EtcdPodHealthy condition not yet reported"},
+				{Component: controlplanev1.EtcdMemberComponent, Status: corev1.ConditionUnknown, Message: "EtcdMemberHealthy condition not yet reported"},
+			},
+		},
+	}))
+
+	// etcd components are not reported if etcd is external.
+	c.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+		Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{}},
+	}
+	for _, machineHealth := range c.ComponentsHealth() {
+		g.Expect(machineHealth.Components).To(HaveLen(3))
+		for _, componentHealth := range machineHealth.Components {
+			g.Expect(componentHealth.Component).ToNot(Or(Equal(controlplanev1.EtcdPodComponent), Equal(controlplanev1.EtcdMemberComponent)))
+		}
+	}
+}
+
 func TestHasUnhealthyMachine(t *testing.T) {
 	// healthy machine (without MachineHealthCheckSucceded condition)
 	healthyMachine1 := &clusterv1.Machine{}
@@ -120,6 +291,42 @@ func TestHasUnhealthyMachine(t *testing.T) {
 	g.Expect(c.HasUnhealthyMachine()).To(BeTrue())
 }
 
+func TestMachinesNeedingRollout(t *testing.T) {
+	now := time.Now()
+	rolloutAfter := metav1.NewTime(now.Add(-time.Hour))
+
+	createdAt := func(created time.Time) machineOpt {
+		return func(m *clusterv1.Machine) {
+			m.CreationTimestamp = metav1.NewTime(created)
+			m.Spec.Version = pointer.StringPtr("v1.22.0")
+		}
+	}
+
+	c := ControlPlane{
+		KCP: &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version:      "v1.22.0",
+				RolloutAfter: &rolloutAfter,
+			},
+		},
+		Machines: collections.FromMachines(
+			machine("created-before-rollout-after", createdAt(now.Add(-2*time.Hour))),
+			machine("created-after-rollout-after", createdAt(now.Add(-30*time.Minute))),
+		),
+		reconciliationTime: metav1.NewTime(now),
+	}
+
+	g := NewWithT(t)
+	g.Expect(c.MachinesNeedingRollout().Names()).To(ConsistOf("created-before-rollout-after"))
+	g.Expect(c.UpToDateMachines().Names()).To(ConsistOf("created-after-rollout-after"))
+
+	// Nothing is rolled out if RolloutAfter is in the future.
+	future := metav1.NewTime(now.Add(time.Hour))
+	c.KCP.Spec.RolloutAfter = &future
+	g.Expect(c.MachinesNeedingRollout()).To(BeEmpty())
+	g.Expect(c.UpToDateMachines().Names()).To(ConsistOf("created-before-rollout-after", "created-after-rollout-after"))
+}
+
 type machineOpt func(*clusterv1.Machine)
 
 func failureDomain(controlPlane bool) clusterv1.FailureDomainSpec {
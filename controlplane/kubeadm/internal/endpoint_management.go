@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+
+	"github.com/pkg/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+// InjectEndpointManagement renders the files and commands defined in the KCP EndpointManagement and adds them
+// to the KubeadmConfigSpec of a control plane machine; files and post kubeadm commands are appended, while
+// pre kubeadm commands are prepended.
+// NOTE: this func modifies spec in place, so it should be called on a copy of the KCP KubeadmConfigSpec.
+func InjectEndpointManagement(spec *bootstrapv1.KubeadmConfigSpec, kcp *controlplanev1.KubeadmControlPlane, cluster *clusterv1.Cluster) error {
+	em := kcp.Spec.EndpointManagement
+	if em == nil {
+		return nil
+	}
+
+	data := controlplanev1.EndpointManagementTemplateData{
+		ClusterName:          cluster.Name,
+		ControlPlaneEndpoint: cluster.Spec.ControlPlaneEndpoint,
+	}
+
+	files := make([]bootstrapv1.File, 0, len(em.Files))
+	for i := range em.Files {
+		f := em.Files[i].DeepCopy()
+		content, err := renderEndpointManagementTemplate(f.Path, f.Content, data)
+		if err != nil {
+			return err
+		}
+		f.Content = content
+		files = append(files, *f)
+	}
+
+	preKubeadmCommands, err := renderEndpointManagementCommands(em.PreKubeadmCommands, data)
+	if err != nil {
+		return err
+	}
+	postKubeadmCommands, err := renderEndpointManagementCommands(em.PostKubeadmCommands, data)
+	if err != nil {
+		return err
+	}
+
+	spec.Files = append(spec.Files, files...)
+	spec.PreKubeadmCommands = append(preKubeadmCommands, spec.PreKubeadmCommands...)
+	spec.PostKubeadmCommands = append(spec.PostKubeadmCommands, postKubeadmCommands...)
+	return nil
+}
+
+// EndpointManagementAnnotationValue returns the value of the EndpointManagementAnnotation for a KCP, if any.
+func EndpointManagementAnnotationValue(kcp *controlplanev1.KubeadmControlPlane) (string, bool, error) {
+	if kcp.Spec.EndpointManagement == nil {
+		return "", false, nil
+	}
+	value, err := json.Marshal(kcp.Spec.EndpointManagement)
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to marshal endpoint management")
+	}
+	return string(value), true, nil
+}
+
+// machineEndpointManagement returns the EndpointManagement used when creating a machine, as recorded in the
+// EndpointManagementAnnotation. If the annotation is not present, the machine was created without EndpointManagement.
+func machineEndpointManagement(annotations map[string]string) (*controlplanev1.EndpointManagement, error) {
+	value, ok := annotations[controlplanev1.EndpointManagementAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	em := &controlplanev1.EndpointManagement{}
+	if err := json.Unmarshal([]byte(value), em); err != nil {
+		return nil, err
+	}
+	return em, nil
+}
+
+// removeEndpointManagement removes from a KubeadmConfigSpec the files and commands added by InjectEndpointManagement.
+func removeEndpointManagement(spec *bootstrapv1.KubeadmConfigSpec, em *controlplanev1.EndpointManagement) {
+	if em == nil {
+		return
+	}
+	spec.Files = trimTail(spec.Files, len(em.Files))
+	spec.PostKubeadmCommands = trimTailStrings(spec.PostKubeadmCommands, len(em.PostKubeadmCommands))
+	if n := len(em.PreKubeadmCommands); n > 0 && len(spec.PreKubeadmCommands) >= n {
+		spec.PreKubeadmCommands = spec.PreKubeadmCommands[n:]
+	}
+	// Restore nil slices, so the spec can be compared with the KCP KubeadmConfigSpec.
+	if len(spec.Files) == 0 {
+		spec.Files = nil
+	}
+	if len(spec.PreKubeadmCommands) == 0 {
+		spec.PreKubeadmCommands = nil
+	}
+	if len(spec.PostKubeadmCommands) == 0 {
+		spec.PostKubeadmCommands = nil
+	}
+}
+
+func trimTail(files []bootstrapv1.File, n int) []bootstrapv1.File {
+	if n > 0 && len(files) >= n {
+		return files[:len(files)-n]
+	}
+	return files
+}
+
+func trimTailStrings(values []string, n int) []string {
+	if n > 0 && len(values) >= n {
+		return values[:len(values)-n]
+	}
+	return values
+}
+
+func renderEndpointManagementCommands(commands []string, data controlplanev1.EndpointManagementTemplateData) ([]string, error) {
+	rendered := make([]string, 0, len(commands))
+	for i, c := range commands {
+		r, err := renderEndpointManagementTemplate("command", c, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render command %d", i)
+		}
+		rendered = append(rendered, r)
+	}
+	return rendered, nil
+}
+
+func renderEndpointManagementTemplate(name, text string, data controlplanev1.EndpointManagementTemplateData) (string, error) {
+	tpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse endpoint management template %q", name)
+	}
+	var out bytes.Buffer
+	if err := tpl.Execute(&out, data); err != nil {
+		return "", errors.Wrapf(err, "failed to render endpoint management template %q", name)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+func TestInjectEndpointManagement(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "10.0.0.1", Port: 6443},
+		},
+	}
+
+	t.Run("no-op when KCP does not define EndpointManagement", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := &controlplanev1.KubeadmControlPlane{}
+		spec := &bootstrapv1.KubeadmConfigSpec{PreKubeadmCommands: []string{"echo pre"}}
+
+		g.Expect(InjectEndpointManagement(spec, kcp, cluster)).To(Succeed())
+		g.Expect(spec).To(Equal(&bootstrapv1.KubeadmConfigSpec{PreKubeadmCommands: []string{"echo pre"}}))
+	})
+
+	t.Run("renders and adds files and commands", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				EndpointManagement: &controlplanev1.EndpointManagement{
+					Files: []bootstrapv1.File{
+						{Path: "/etc/kubernetes/manifests/kube-vip.yaml", Content: "address: {{ .ControlPlaneEndpoint.Host }}:{{ .ControlPlaneEndpoint.Port }}"},
+					},
+					PreKubeadmCommands:  []string{"echo {{ .ClusterName }}"},
+					PostKubeadmCommands: []string{"echo done"},
+				},
+			},
+		}
+		spec := &bootstrapv1.KubeadmConfigSpec{
+			Files:               []bootstrapv1.File{{Path: "/etc/foo", Content: "{{ ds.meta_data.local_hostname }}"}},
+			PreKubeadmCommands:  []string{"echo pre"},
+			PostKubeadmCommands: []string{"echo post"},
+		}
+
+		g.Expect(InjectEndpointManagement(spec, kcp, cluster)).To(Succeed())
+		g.Expect(spec.Files).To(Equal([]bootstrapv1.File{
+			{Path: "/etc/foo", Content: "{{ ds.meta_data.local_hostname }}"},
+			{Path: "/etc/kubernetes/manifests/kube-vip.yaml", Content: "address: 10.0.0.1:6443"},
+		}))
+		g.Expect(spec.PreKubeadmCommands).To(Equal([]string{"echo cluster1", "echo pre"}))
+		g.Expect(spec.PostKubeadmCommands).To(Equal([]string{"echo post", "echo done"}))
+
+		// Files and commands injected from EndpointManagement can be removed for comparing the spec with KCP.
+		removeEndpointManagement(spec, kcp.Spec.EndpointManagement)
+		g.Expect(spec).To(Equal(&bootstrapv1.KubeadmConfigSpec{
+			Files:               []bootstrapv1.File{{Path: "/etc/foo", Content: "{{ ds.meta_data.local_hostname }}"}},
+			PreKubeadmCommands:  []string{"echo pre"},
+			PostKubeadmCommands: []string{"echo post"},
+		}))
+	})
+
+	t.Run("fails for unknown values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				EndpointManagement: &controlplanev1.EndpointManagement{
+					PreKubeadmCommands: []string{"echo {{ .Unknown }}"},
+				},
+			},
+		}
+		g.Expect(InjectEndpointManagement(&bootstrapv1.KubeadmConfigSpec{}, kcp, cluster)).ToNot(Succeed())
+	})
+}
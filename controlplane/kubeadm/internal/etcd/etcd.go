@@ -148,6 +148,24 @@ func NewClient(ctx context.Context, endpoints []string, p proxy.Proxy, tlsConfig
 	return newEtcdClient(ctx, etcdClient)
 }
 
+// NewDirectClient creates a new etcd client connecting directly to the endpoints, e.g. the endpoints of an external
+// etcd cluster, with a TLS configuration.
+func NewDirectClient(ctx context.Context, endpoints []string, tlsConfig *tls.Config) (*Client, error) {
+	etcdClient, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: etcdTimeout,
+		DialOptions: []grpc.DialOption{
+			grpc.WithBlock(), // block until the underlying connection is up
+		},
+		TLS: tlsConfig,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create etcd client")
+	}
+
+	return newEtcdClient(ctx, etcdClient)
+}
+
 func newEtcdClient(ctx context.Context, etcdClient etcd) (*Client, error) {
 	endpoints := etcdClient.Endpoints()
 	if len(endpoints) == 0 {
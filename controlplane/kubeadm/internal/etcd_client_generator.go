@@ -33,6 +33,9 @@ type EtcdClientGenerator struct {
 	restConfig   *rest.Config
 	tlsConfig    *tls.Config
 	createClient clientCreator
+
+	// createDirectClient creates clients connecting directly to etcd endpoints, e.g. to external etcd members.
+	createDirectClient clientCreator
 }
 
 type clientCreator func(ctx context.Context, endpoints []string) (*etcd.Client, error)
@@ -52,6 +55,14 @@ func NewEtcdClientGenerator(restConfig *rest.Config, tlsConfig *tls.Config) *Etc
 		return etcd.NewClient(ctx, endpoints, p, ecg.tlsConfig)
 	}
 
+	ecg.createDirectClient = func(ctx context.Context, endpoints []string) (*etcd.Client, error) {
+		// Contrary to the etcd pods reached through the API server proxy, endpoints reached directly
+		// must present a certificate signed by the etcd CA.
+		directTLSConfig := ecg.tlsConfig.Clone()
+		directTLSConfig.InsecureSkipVerify = false
+		return etcd.NewDirectClient(ctx, endpoints, directTLSConfig)
+	}
+
 	return ecg
 }
 
@@ -95,3 +106,12 @@ func (c *EtcdClientGenerator) forLeader(ctx context.Context, nodeNames []string)
 
 	return nil, errors.Wrap(kerrors.NewAggregate(errs), "could not establish a connection to the etcd leader")
 }
+
+// forEndpoint returns a client connecting directly to the etcd endpoint, e.g. an external etcd endpoint.
+func (c *EtcdClientGenerator) forEndpoint(ctx context.Context, endpoint string) (*etcd.Client, error) {
+	client, err := c.createDirectClient(ctx, []string{endpoint})
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not establish a connection to the etcd endpoint %s", endpoint)
+	}
+	return client, nil
+}
@@ -39,6 +39,7 @@ func TestNewEtcdClientGenerator(t *testing.T) {
 	g := NewWithT(t)
 	subject = NewEtcdClientGenerator(&rest.Config{}, &tls.Config{MinVersion: tls.VersionTLS12})
 	g.Expect(subject.createClient).To(Not(BeNil()))
+	g.Expect(subject.createDirectClient).To(Not(BeNil()))
 }
 
 func TestForNodes(t *testing.T) {
@@ -192,3 +193,47 @@ func TestForLeader(t *testing.T) {
 		}
 	}
 }
+
+func TestForEndpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	tests := []struct {
+		name     string
+		endpoint string
+		cc       clientCreator
+
+		expectedErr    string
+		expectedClient etcd.Client
+	}{
+		{
+			name:     "Returns client successfully",
+			endpoint: "https://etcd-1:2379",
+			cc: func(ctx context.Context, endpoints []string) (*etcd.Client, error) {
+				return &etcd.Client{Endpoint: endpoints[0]}, nil
+			},
+			expectedClient: etcd.Client{Endpoint: "https://etcd-1:2379"},
+		},
+		{
+			name:     "Returns error",
+			endpoint: "https://etcd-1:2379",
+			cc: func(ctx context.Context, endpoints []string) (*etcd.Client, error) {
+				return nil, errors.New("connection refused")
+			},
+			expectedErr: "could not establish a connection to the etcd endpoint https://etcd-1:2379: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		subject = NewEtcdClientGenerator(&rest.Config{}, &tls.Config{MinVersion: tls.VersionTLS12})
+		subject.createDirectClient = tt.cc
+
+		client, err := subject.forEndpoint(ctx, tt.endpoint)
+
+		if tt.expectedErr != "" {
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(err.Error()).Should(Equal(tt.expectedErr))
+		} else {
+			g.Expect(*client).Should(Equal(tt.expectedClient))
+		}
+	}
+}
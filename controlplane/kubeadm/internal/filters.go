@@ -19,6 +19,7 @@ package internal
 import (
 	"encoding/json"
 	"reflect"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -41,6 +42,85 @@ func MatchesMachineSpec(infraConfigs map[string]*unstructured.Unstructured, mach
 	)
 }
 
+// MachineSpecDiff returns the KubeadmControlPlane fields which differ from the spec of a machine, classified in fields
+// whose changes require a rollout of the machine and fields whose changes could be applied to the machine in place.
+// NOTE: The comparison is the same as in MatchesMachineSpec, so a machine matches if and only if no fields are returned;
+// changes to the ClusterConfiguration and to the KubeadmConfigSpec are reported down to the nested field which changed,
+// e.g. spec.kubeadmConfigSpec.clusterConfiguration.apiServer.extraArgs.
+func MachineSpecDiff(infraConfigs map[string]*unstructured.Unstructured, machineConfigs map[string]*bootstrapv1.KubeadmConfig, kcp *controlplanev1.KubeadmControlPlane, machine *clusterv1.Machine) (rolloutFields, inPlaceFields []string) {
+	metadataChanged := !matchMachineTemplateMetadata(kcp, machine)
+
+	if !collections.MatchesKubernetesVersion(kcp.Spec.Version)(machine) {
+		rolloutFields = append(rolloutFields, "spec.version")
+	}
+
+	if infraObj, found := infraConfigs[machine.Name]; found && hasTemplateClonedFromAnnotations(infraObj) {
+		if !matchInfrastructureTemplate(kcp, infraObj) {
+			rolloutFields = append(rolloutFields, "spec.machineTemplate.infrastructureRef")
+		}
+		if !matchMachineTemplateMetadata(kcp, infraObj) {
+			metadataChanged = true
+		}
+	}
+
+	if machineClusterConfig, kcpClusterConfig, ok, err := clusterConfigurationsToCompare(kcp, machine); ok {
+		const path = "spec.kubeadmConfigSpec.clusterConfiguration"
+		if err != nil {
+			rolloutFields = append(rolloutFields, path)
+		} else {
+			rolloutFields = append(rolloutFields, diffFields(path, reflect.ValueOf(kcpClusterConfig), reflect.ValueOf(machineClusterConfig), 3)...)
+		}
+	}
+
+	if !matchEndpointManagement(kcp, machine) {
+		rolloutFields = append(rolloutFields, "spec.endpointManagement")
+	}
+
+	if machineConfig, found := machineConfigs[machine.Name]; found && machineConfig != nil && machine.Spec.Bootstrap.ConfigRef != nil {
+		if !matchMachineTemplateMetadata(kcp, machineConfig) {
+			metadataChanged = true
+		}
+		machineConfigSpec, kcpConfig := kubeadmConfigSpecsToCompare(machine, machineConfig, kcp)
+		rolloutFields = append(rolloutFields, diffFields("spec.kubeadmConfigSpec", reflect.ValueOf(kcpConfig), reflect.ValueOf(machineConfigSpec), 3)...)
+	}
+
+	if metadataChanged {
+		inPlaceFields = append(inPlaceFields, "spec.machineTemplate.metadata")
+	}
+	return rolloutFields, inPlaceFields
+}
+
+// diffFields returns the paths of the fields which differ between a and b, descending into nested structs up to
+// depth levels; fields are identified by their JSON name, and fields of inlined structs by the path of the parent.
+func diffFields(path string, a, b reflect.Value, depth int) []string {
+	if reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return nil
+	}
+	if a.Kind() == reflect.Ptr {
+		if a.IsNil() || b.IsNil() {
+			return []string{path}
+		}
+		a, b = a.Elem(), b.Elem()
+	}
+	if depth == 0 || a.Kind() != reflect.Struct {
+		return []string{path}
+	}
+
+	var fields []string
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fieldPath := path
+		if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+			fieldPath = path + "." + name
+		}
+		fields = append(fields, diffFields(fieldPath, a.Field(i), b.Field(i), depth-1)...)
+	}
+	return fields
+}
+
 // MatchesTemplateClonedFrom returns a filter to find all machines that match a given KCP infra template.
 func MatchesTemplateClonedFrom(infraConfigs map[string]*unstructured.Unstructured, kcp *controlplanev1.KubeadmControlPlane) collections.Func {
 	return func(machine *clusterv1.Machine) bool {
@@ -53,9 +133,7 @@ func MatchesTemplateClonedFrom(infraConfigs map[string]*unstructured.Unstructure
 			return true
 		}
 
-		clonedFromName, ok1 := infraObj.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]
-		clonedFromGroupKind, ok2 := infraObj.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation]
-		if !ok1 || !ok2 {
+		if !hasTemplateClonedFromAnnotations(infraObj) {
 			// All kcp cloned infra machines should have this annotation.
 			// Missing the annotation may be due to older version machines or adopted machines.
 			// Should not be considered as mismatch.
@@ -63,8 +141,7 @@ func MatchesTemplateClonedFrom(infraConfigs map[string]*unstructured.Unstructure
 		}
 
 		// Check if the machine's infrastructure reference has been created from the current KCP infrastructure template.
-		if clonedFromName != kcp.Spec.MachineTemplate.InfrastructureRef.Name ||
-			clonedFromGroupKind != kcp.Spec.MachineTemplate.InfrastructureRef.GroupVersionKind().GroupKind().String() {
+		if !matchInfrastructureTemplate(kcp, infraObj) {
 			return false
 		}
 
@@ -76,6 +153,21 @@ func MatchesTemplateClonedFrom(infraConfigs map[string]*unstructured.Unstructure
 	}
 }
 
+// hasTemplateClonedFromAnnotations returns true if the infrastructure object of a machine records the template it
+// has been cloned from.
+func hasTemplateClonedFromAnnotations(infraObj *unstructured.Unstructured) bool {
+	_, ok1 := infraObj.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]
+	_, ok2 := infraObj.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation]
+	return ok1 && ok2
+}
+
+// matchInfrastructureTemplate verifies if the infrastructure object of a machine has been cloned from the current
+// KCP infrastructure template.
+func matchInfrastructureTemplate(kcp *controlplanev1.KubeadmControlPlane, infraObj *unstructured.Unstructured) bool {
+	return infraObj.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation] == kcp.Spec.MachineTemplate.InfrastructureRef.Name &&
+		infraObj.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation] == kcp.Spec.MachineTemplate.InfrastructureRef.GroupVersionKind().GroupKind().String()
+}
+
 // MatchesKubeadmBootstrapConfig checks if machine's KubeadmConfigSpec is equivalent with KCP's KubeadmConfigSpec.
 func MatchesKubeadmBootstrapConfig(machineConfigs map[string]*bootstrapv1.KubeadmConfig, kcp *controlplanev1.KubeadmControlPlane) collections.Func {
 	return func(machine *clusterv1.Machine) bool {
@@ -88,6 +180,11 @@ func MatchesKubeadmBootstrapConfig(machineConfigs map[string]*bootstrapv1.Kubead
 			return false
 		}
 
+		// Check if KCP and machine EndpointManagement matches, if not return
+		if match := matchEndpointManagement(kcp, machine); !match {
+			return false
+		}
+
 		bootstrapRef := machine.Spec.Bootstrap.ConfigRef
 		if bootstrapRef == nil {
 			// Missing bootstrap reference should not be considered as unmatching.
@@ -110,7 +207,7 @@ func MatchesKubeadmBootstrapConfig(machineConfigs map[string]*bootstrapv1.Kubead
 		// Check if KCP and machine InitConfiguration or JoinConfiguration matches
 		// NOTE: only one between init configuration and join configuration is set on a machine, depending
 		// on the fact that the machine was the initial control plane node or a joining control plane node.
-		return matchInitOrJoinConfiguration(machineConfig, kcp)
+		return matchInitOrJoinConfiguration(machine, machineConfig, kcp)
 	}
 }
 
@@ -120,51 +217,93 @@ func MatchesKubeadmBootstrapConfig(machineConfigs map[string]*bootstrapv1.Kubead
 // made in KCP's ClusterConfiguration given that we don't have enough information to make a decision.
 // Users should use KCP.Spec.RolloutAfter field to force a rollout in this case.
 func matchClusterConfiguration(kcp *controlplanev1.KubeadmControlPlane, machine *clusterv1.Machine) bool {
-	machineClusterConfigStr, ok := machine.GetAnnotations()[controlplanev1.KubeadmClusterConfigurationAnnotation]
+	machineClusterConfig, kcpClusterConfig, ok, err := clusterConfigurationsToCompare(kcp, machine)
 	if !ok {
 		// We don't have enough information to make a decision; don't' trigger a roll out.
 		return true
 	}
+	if err != nil {
+		// ClusterConfiguration annotation is not correct, only solution is to rollout.
+		return false
+	}
+
+	// Compare and return.
+	return reflect.DeepEqual(machineClusterConfig, kcpClusterConfig)
+}
+
+// clusterConfigurationsToCompare returns the ClusterConfiguration stored in the KubeadmClusterConfigurationAnnotation
+// of the machine and the KCP ClusterConfiguration, both defaulted to an empty ClusterConfiguration if nil;
+// ok is false if the machine does not have the annotation.
+func clusterConfigurationsToCompare(kcp *controlplanev1.KubeadmControlPlane, machine *clusterv1.Machine) (machineClusterConfig, kcpClusterConfig *bootstrapv1.ClusterConfiguration, ok bool, err error) {
+	machineClusterConfigStr, ok := machine.GetAnnotations()[controlplanev1.KubeadmClusterConfigurationAnnotation]
+	if !ok {
+		return nil, nil, false, nil
+	}
 
-	machineClusterConfig := &bootstrapv1.ClusterConfiguration{}
-	// ClusterConfiguration annotation is not correct, only solution is to rollout.
+	machineClusterConfig = &bootstrapv1.ClusterConfiguration{}
 	// The call to json.Unmarshal has to take a pointer to the pointer struct defined above,
 	// otherwise we won't be able to handle a nil ClusterConfiguration (that is serialized into "null").
 	// See https://github.com/kubernetes-sigs/cluster-api/issues/3353.
 	if err := json.Unmarshal([]byte(machineClusterConfigStr), &machineClusterConfig); err != nil {
-		return false
+		return nil, nil, true, err
 	}
 
 	// If any of the compared values are nil, treat them the same as an empty ClusterConfiguration.
 	if machineClusterConfig == nil {
 		machineClusterConfig = &bootstrapv1.ClusterConfiguration{}
 	}
-	kcpLocalClusterConfiguration := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
-	if kcpLocalClusterConfiguration == nil {
-		kcpLocalClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+	kcpClusterConfig = kcp.Spec.KubeadmConfigSpec.ClusterConfiguration
+	if kcpClusterConfig == nil {
+		kcpClusterConfig = &bootstrapv1.ClusterConfiguration{}
 	}
+	return machineClusterConfig, kcpClusterConfig, true, nil
+}
 
-	// Compare and return.
-	return reflect.DeepEqual(machineClusterConfig, kcpLocalClusterConfiguration)
+// matchEndpointManagement verifies if KCP and machine EndpointManagement matches.
+// NOTE: Machines without the EndpointManagementAnnotation have been created without EndpointManagement (or they
+// have been adopted), so they match only if KCP does not define EndpointManagement.
+func matchEndpointManagement(kcp *controlplanev1.KubeadmControlPlane, machine *clusterv1.Machine) bool {
+	machineEndpointManagement, err := machineEndpointManagement(machine.GetAnnotations())
+	if err != nil {
+		// EndpointManagement annotation is not correct, only solution is to rollout.
+		return false
+	}
+	return reflect.DeepEqual(machineEndpointManagement, kcp.Spec.EndpointManagement)
 }
 
 // matchInitOrJoinConfiguration verifies if KCP and machine InitConfiguration or JoinConfiguration matches.
 // NOTE: By extension this method takes care of detecting changes in other fields of the KubeadmConfig configuration (e.g. Files, Mounts etc.)
-func matchInitOrJoinConfiguration(machineConfig *bootstrapv1.KubeadmConfig, kcp *controlplanev1.KubeadmControlPlane) bool {
+func matchInitOrJoinConfiguration(machine *clusterv1.Machine, machineConfig *bootstrapv1.KubeadmConfig, kcp *controlplanev1.KubeadmControlPlane) bool {
 	if machineConfig == nil {
 		// Return true here because failing to get KubeadmConfig should not be considered as unmatching.
 		// This is a safety precaution to avoid rolling out machines if the client or the api-server is misbehaving.
 		return true
 	}
 
+	machineConfigSpec, kcpConfig := kubeadmConfigSpecsToCompare(machine, machineConfig, kcp)
+	return reflect.DeepEqual(machineConfigSpec, kcpConfig)
+}
+
+// kubeadmConfigSpecsToCompare returns the KubeadmConfigSpec of the machine and the KCP KubeadmConfigSpec,
+// both transformed to allow a comparison.
+func kubeadmConfigSpecsToCompare(machine *clusterv1.Machine, machineConfig *bootstrapv1.KubeadmConfig, kcp *controlplanev1.KubeadmControlPlane) (machineConfigSpec, kcpConfig *bootstrapv1.KubeadmConfigSpec) {
+	// removes the files and commands injected from the EndpointManagement recorded on the machine when it was created,
+	// which is compared separately; if the annotation is not correct, nothing is removed, given that the machine
+	// is rolled out anyway.
+	// NOTE: a copy is used because the KubeadmConfig is shared across filters.
+	machineConfig = machineConfig.DeepCopy()
+	if em, err := machineEndpointManagement(machine.GetAnnotations()); err == nil {
+		removeEndpointManagement(&machineConfig.Spec, em)
+	}
+
 	// takes the KubeadmConfigSpec from KCP and applies the transformations required
 	// to allow a comparison with the KubeadmConfig referenced from the machine.
-	kcpConfig := getAdjustedKcpConfig(kcp, machineConfig)
+	kcpConfig = getAdjustedKcpConfig(kcp, machineConfig)
 
 	// cleanups all the fields that are not relevant for the comparison.
 	cleanupConfigFields(kcpConfig, machineConfig)
 
-	return reflect.DeepEqual(&machineConfig.Spec, kcpConfig)
+	return &machineConfig.Spec, kcpConfig
 }
 
 // getAdjustedKcpConfig takes the KubeadmConfigSpec from KCP and applies the transformations required
@@ -225,6 +364,11 @@ func cleanupConfigFields(kcpConfig *bootstrapv1.KubeadmConfigSpec, machineConfig
 		machineConfig.Spec.JoinConfiguration.NodeRegistration = emptyNodeRegistration
 	}
 
+	// Users are propagated to the KubeadmConfigs of existing machines without triggering a rollout, and then applied
+	// to the nodes by using the users data secrets generated by CABPK, so they are cleaned up from the comparison.
+	kcpConfig.Users = nil
+	machineConfig.Spec.Users = nil
+
 	// Clear up the TypeMeta information from the comparison.
 	// NOTE: KCP types don't carry this information.
 	if machineConfig.Spec.InitConfiguration != nil && kcpConfig.InitConfiguration != nil {
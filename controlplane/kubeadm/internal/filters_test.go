@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -161,6 +162,20 @@ func TestCleanupConfigFields(t *testing.T) {
 		g.Expect(kcpConfig.ClusterConfiguration).To(BeNil())
 		g.Expect(machineConfig.Spec.ClusterConfiguration).To(BeNil())
 	})
+	t.Run("Users get removed from KcpConfig and MachineConfig because they are updated without rollout", func(t *testing.T) {
+		g := NewWithT(t)
+		kcpConfig := &bootstrapv1.KubeadmConfigSpec{
+			Users: []bootstrapv1.User{{Name: "capi", SSHAuthorizedKeys: []string{"ssh-rsa AAAA2"}}},
+		}
+		machineConfig := &bootstrapv1.KubeadmConfig{
+			Spec: bootstrapv1.KubeadmConfigSpec{
+				Users: []bootstrapv1.User{{Name: "capi", SSHAuthorizedKeys: []string{"ssh-rsa AAAA1"}}},
+			},
+		}
+		cleanupConfigFields(kcpConfig, machineConfig)
+		g.Expect(kcpConfig.Users).To(BeNil())
+		g.Expect(machineConfig.Spec.Users).To(BeNil())
+	})
 	t.Run("JoinConfiguration gets removed from MachineConfig if it was not derived by KCPConfig", func(t *testing.T) {
 		g := NewWithT(t)
 		kcpConfig := &bootstrapv1.KubeadmConfigSpec{
@@ -273,12 +288,12 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
 	t.Run("returns true if the machine does not have a bootstrap config", func(t *testing.T) {
 		g := NewWithT(t)
 		kcp := &controlplanev1.KubeadmControlPlane{}
-		g.Expect(matchInitOrJoinConfiguration(nil, kcp)).To(BeTrue())
+		g.Expect(matchInitOrJoinConfiguration(&clusterv1.Machine{}, nil, kcp)).To(BeTrue())
 	})
 	t.Run("returns true if the there are problems reading the bootstrap config", func(t *testing.T) {
 		g := NewWithT(t)
 		kcp := &controlplanev1.KubeadmControlPlane{}
-		g.Expect(matchInitOrJoinConfiguration(nil, kcp)).To(BeTrue())
+		g.Expect(matchInitOrJoinConfiguration(&clusterv1.Machine{}, nil, kcp)).To(BeTrue())
 	})
 	t.Run("returns true if InitConfiguration is equal", func(t *testing.T) {
 		g := NewWithT(t)
@@ -326,7 +341,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
 				},
 			},
 		}
-		g.Expect(matchInitOrJoinConfiguration(machineConfigs[m.Name], kcp)).To(BeTrue())
+		g.Expect(matchInitOrJoinConfiguration(m, machineConfigs[m.Name], kcp)).To(BeTrue())
 	})
 	t.Run("returns false if InitConfiguration is NOT equal", func(t *testing.T) {
 		g := NewWithT(t)
@@ -378,7 +393,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
 				},
 			},
 		}
-		g.Expect(matchInitOrJoinConfiguration(machineConfigs[m.Name], kcp)).To(BeFalse())
+		g.Expect(matchInitOrJoinConfiguration(m, machineConfigs[m.Name], kcp)).To(BeFalse())
 	})
 	t.Run("returns true if JoinConfiguration is equal", func(t *testing.T) {
 		g := NewWithT(t)
@@ -426,7 +441,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
 				},
 			},
 		}
-		g.Expect(matchInitOrJoinConfiguration(machineConfigs[m.Name], kcp)).To(BeTrue())
+		g.Expect(matchInitOrJoinConfiguration(m, machineConfigs[m.Name], kcp)).To(BeTrue())
 	})
 	t.Run("returns false if JoinConfiguration is NOT equal", func(t *testing.T) {
 		g := NewWithT(t)
@@ -478,7 +493,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
 				},
 			},
 		}
-		g.Expect(matchInitOrJoinConfiguration(machineConfigs[m.Name], kcp)).To(BeFalse())
+		g.Expect(matchInitOrJoinConfiguration(m, machineConfigs[m.Name], kcp)).To(BeFalse())
 	})
 	t.Run("returns false if some other configurations are not equal", func(t *testing.T) {
 		g := NewWithT(t)
@@ -527,7 +542,7 @@ func TestMatchInitOrJoinConfiguration(t *testing.T) {
 				},
 			},
 		}
-		g.Expect(matchInitOrJoinConfiguration(machineConfigs[m.Name], kcp)).To(BeFalse())
+		g.Expect(matchInitOrJoinConfiguration(m, machineConfigs[m.Name], kcp)).To(BeFalse())
 	})
 }
 
@@ -580,6 +595,81 @@ func TestMatchesKubeadmBootstrapConfig(t *testing.T) {
 		f := MatchesKubeadmBootstrapConfig(machineConfigs, kcp)
 		g.Expect(f(m)).To(BeFalse())
 	})
+	t.Run("returns true if EndpointManagement is equal", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					PreKubeadmCommands: []string{"echo pre"},
+				},
+				EndpointManagement: &controlplanev1.EndpointManagement{
+					PreKubeadmCommands: []string{"echo {{ .ClusterName }}"},
+				},
+			},
+		}
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					controlplanev1.EndpointManagementAnnotation: "{\"preKubeadmCommands\":[\"echo {{ .ClusterName }}\"]}",
+				},
+			},
+		}
+		machineConfigs := map[string]*bootstrapv1.KubeadmConfig{
+			m.Name: {
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					PreKubeadmCommands: []string{"echo cluster1", "echo pre"},
+				},
+			},
+		}
+		f := MatchesKubeadmBootstrapConfig(machineConfigs, kcp)
+		g.Expect(f(m)).To(BeTrue())
+		// Calling the filter again must return the same result.
+		g.Expect(f(m)).To(BeTrue())
+	})
+	t.Run("compares the KubeadmConfig without the EndpointManagement recorded on the machine", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					PreKubeadmCommands: []string{"echo pre"},
+				},
+				EndpointManagement: &controlplanev1.EndpointManagement{
+					PreKubeadmCommands: []string{"echo {{ .ClusterName }}", "echo changed"},
+				},
+			},
+		}
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					controlplanev1.EndpointManagementAnnotation: "{\"preKubeadmCommands\":[\"echo {{ .ClusterName }}\"]}",
+				},
+			},
+		}
+		machineConfig := &bootstrapv1.KubeadmConfig{
+			Spec: bootstrapv1.KubeadmConfigSpec{
+				PreKubeadmCommands: []string{"echo cluster1", "echo pre"},
+			},
+		}
+		// The machine is rolled out because of the EndpointManagement change only, not because of a KubeadmConfig change.
+		g.Expect(matchEndpointManagement(kcp, m)).To(BeFalse())
+		g.Expect(matchInitOrJoinConfiguration(m, machineConfig, kcp)).To(BeTrue())
+	})
+	t.Run("returns false if EndpointManagement is NOT equal", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				EndpointManagement: &controlplanev1.EndpointManagement{
+					PreKubeadmCommands: []string{"echo {{ .ClusterName }}"},
+				},
+			},
+		}
+		m := &clusterv1.Machine{}
+		machineConfigs := map[string]*bootstrapv1.KubeadmConfig{
+			m.Name: {},
+		}
+		f := MatchesKubeadmBootstrapConfig(machineConfigs, kcp)
+		g.Expect(f(m)).To(BeFalse())
+	})
 	t.Run("returns true if InitConfiguration is equal", func(t *testing.T) {
 		g := NewWithT(t)
 		kcp := &controlplanev1.KubeadmControlPlane{
@@ -1130,3 +1220,166 @@ func TestMatchesTemplateClonedFrom_WithClonedFromAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineSpecDiff(t *testing.T) {
+	newKCP := func() *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+			},
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.22.2",
+				MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+					ObjectMeta: clusterv1.ObjectMeta{
+						Labels: map[string]string{"foo": "bar"},
+					},
+					InfrastructureRef: corev1.ObjectReference{
+						Kind:       "GenericMachineTemplate",
+						Namespace:  "default",
+						Name:       "infra-foo",
+						APIVersion: "generic.io/v1",
+					},
+				},
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						APIServer: bootstrapv1.APIServer{
+							ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+								ExtraArgs: map[string]string{"audit-log-maxage": "10"},
+							},
+						},
+					},
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{
+						NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+							KubeletExtraArgs: map[string]string{"max-pods": "100"},
+						},
+					},
+				},
+			},
+		}
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "machine1",
+			Labels: map[string]string{"foo": "bar"},
+			Annotations: map[string]string{
+				controlplanev1.KubeadmClusterConfigurationAnnotation: `{"apiServer":{"extraArgs":{"audit-log-maxage":"10"}}}`,
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			Version: pointer.String("v1.22.2"),
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					Namespace:  "default",
+					Name:       "machine1",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+				},
+			},
+		},
+	}
+	machineConfigs := map[string]*bootstrapv1.KubeadmConfig{
+		machine.Name: {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "machine1",
+				Labels: map[string]string{"foo": "bar"},
+			},
+			Spec: bootstrapv1.KubeadmConfigSpec{
+				JoinConfiguration: &bootstrapv1.JoinConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						KubeletExtraArgs: map[string]string{"max-pods": "100"},
+					},
+				},
+			},
+		},
+	}
+	infraConfigs := map[string]*unstructured.Unstructured{
+		machine.Name: {
+			Object: map[string]interface{}{
+				"kind":       "GenericMachine",
+				"apiVersion": "generic.io/v1",
+				"metadata": map[string]interface{}{
+					"name":      "machine1",
+					"namespace": "default",
+					"labels": map[string]interface{}{
+						"foo": "bar",
+					},
+					"annotations": map[string]interface{}{
+						clusterv1.TemplateClonedFromNameAnnotation:      "infra-foo",
+						clusterv1.TemplateClonedFromGroupKindAnnotation: "GenericMachineTemplate.generic.io",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		changeKCP           func(kcp *controlplanev1.KubeadmControlPlane)
+		expectRolloutFields []string
+		expectInPlaceFields []string
+	}{
+		{
+			name:      "no changes",
+			changeKCP: func(kcp *controlplanev1.KubeadmControlPlane) {},
+		},
+		{
+			name: "version changes require a rollout",
+			changeKCP: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Version = "v1.22.3"
+			},
+			expectRolloutFields: []string{"spec.version"},
+		},
+		{
+			name: "infrastructure template changes require a rollout",
+			changeKCP: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.MachineTemplate.InfrastructureRef.Name = "infra-bar"
+			},
+			expectRolloutFields: []string{"spec.machineTemplate.infrastructureRef"},
+		},
+		{
+			name: "apiServer extraArgs changes require a rollout",
+			changeKCP: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraArgs["audit-log-maxage"] = "20"
+			},
+			expectRolloutFields: []string{"spec.kubeadmConfigSpec.clusterConfiguration.apiServer.extraArgs"},
+		},
+		{
+			name: "kubeletExtraArgs changes require a rollout",
+			changeKCP: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.KubeletExtraArgs["max-pods"] = "200"
+			},
+			expectRolloutFields: []string{"spec.kubeadmConfigSpec.joinConfiguration.nodeRegistration.kubeletExtraArgs"},
+		},
+		{
+			name: "machine template metadata changes could be applied in place",
+			changeKCP: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.MachineTemplate.ObjectMeta.Labels["foo"] = "baz"
+			},
+			expectInPlaceFields: []string{"spec.machineTemplate.metadata"},
+		},
+		{
+			name: "changes are reported all together",
+			changeKCP: func(kcp *controlplanev1.KubeadmControlPlane) {
+				kcp.Spec.Version = "v1.22.3"
+				kcp.Spec.MachineTemplate.ObjectMeta.Annotations = map[string]string{"foo": "bar"}
+				kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands = []string{"echo hello"}
+			},
+			expectRolloutFields: []string{"spec.version", "spec.kubeadmConfigSpec.preKubeadmCommands"},
+			expectInPlaceFields: []string{"spec.machineTemplate.metadata"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			kcp := newKCP()
+			tt.changeKCP(kcp)
+
+			rolloutFields, inPlaceFields := MachineSpecDiff(infraConfigs, machineConfigs, kcp, machine)
+			g.Expect(rolloutFields).To(Equal(tt.expectRolloutFields))
+			g.Expect(inPlaceFields).To(Equal(tt.expectInPlaceFields))
+
+			// The diff is consistent with MatchesMachineSpec.
+			g.Expect(MatchesMachineSpec(infraConfigs, machineConfigs, kcp)(machine)).To(Equal(len(rolloutFields)+len(inPlaceFields) == 0))
+		})
+	}
+}
@@ -36,11 +36,13 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/proxy"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/certs"
 	containerutil "sigs.k8s.io/cluster-api/util/container"
@@ -105,6 +107,8 @@ type WorkloadCluster interface {
 	RemoveNodeFromKubeadmConfigMap(ctx context.Context, nodeName string, version semver.Version) error
 	ForwardEtcdLeadership(ctx context.Context, machine *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error
 	AllowBootstrapTokensToGetNodes(ctx context.Context) error
+	UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error
+	GetAPIServerCertificateExpiry(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig, nodeName string) (*time.Time, error)
 
 	// State recovery tasks.
 	ReconcileEtcdMembers(ctx context.Context, nodeNames []string, version semver.Version) ([]string, error)
@@ -115,6 +119,7 @@ type Workload struct {
 	Client              ctrlclient.Client
 	CoreDNSMigrator     coreDNSMigrator
 	etcdClientGenerator etcdClientFor
+	restConfig          *rest.Config
 }
 
 var _ WorkloadCluster = &Workload{}
@@ -354,6 +359,8 @@ type ClusterStatus struct {
 	Nodes int32
 	// ReadyNodes are the count of nodes that are reporting ready
 	ReadyNodes int32
+	// ReadyNodeNames are the names of the nodes that are reporting ready.
+	ReadyNodeNames []string
 	// HasKubeadmConfig will be true if the kubeadm config map has been uploaded, false otherwise.
 	HasKubeadmConfig bool
 }
@@ -373,6 +380,7 @@ func (w *Workload) ClusterStatus(ctx context.Context) (ClusterStatus, error) {
 		status.Nodes++
 		if util.IsNodeReady(&nodeCopy) {
 			status.ReadyNodes++
+			status.ReadyNodeNames = append(status.ReadyNodeNames, node.Name)
 		}
 	}
 
@@ -436,6 +444,59 @@ func newClientCert(caCert *x509.Certificate, key *rsa.PrivateKey, caKey crypto.S
 	return c, errors.WithStack(err)
 }
 
+// GetAPIServerCertificateExpiry returns the certificate expiry of the apiserver on the given node.
+func (w *Workload) GetAPIServerCertificateExpiry(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig, nodeName string) (*time.Time, error) {
+	// Create a proxy to the kube-apiserver Pod running on the node.
+	p := proxy.Proxy{
+		Kind:       "pods",
+		Namespace:  metav1.NamespaceSystem,
+		KubeConfig: w.restConfig,
+		Port:       int(calculateAPIServerPort(kubeadmConfig)),
+	}
+	dialer, err := proxy.NewDialer(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get certificate expiry of kube-apiserver on Node/%s: failed to create dialer", nodeName)
+	}
+
+	rawConn, err := dialer.DialContextWithAddr(ctx, staticPodName("kube-apiserver", nodeName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get certificate expiry of kube-apiserver on Node/%s: unable to dial to kube-apiserver", nodeName)
+	}
+
+	// Execute a TLS handshake over the connection to read the certificate served by the kube-apiserver.
+	conn := tls.Client(rawConn, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec // The certificate is only read, not trusted.
+	if err := conn.Handshake(); err != nil {
+		_ = rawConn.Close()
+		return nil, errors.Wrapf(err, "unable to get certificate expiry of kube-apiserver on Node/%s: TLS handshake with the kube-apiserver failed", nodeName)
+	}
+	defer conn.Close()
+
+	// Return the expiry of the peer certificate with cn=kube-apiserver, which is the one generated by kubeadm.
+	for _, cert := range conn.ConnectionState().PeerCertificates {
+		if cert.Subject.CommonName == "kube-apiserver" {
+			return &cert.NotAfter, nil
+		}
+	}
+	return nil, errors.Errorf("unable to get certificate expiry of kube-apiserver on Node/%s: couldn't get peer certificate with cn=kube-apiserver", nodeName)
+}
+
+// calculateAPIServerPort calculates the kube-apiserver bind port based
+// on a KubeadmConfig.
+func calculateAPIServerPort(config *bootstrapv1.KubeadmConfig) int32 {
+	if config.Spec.InitConfiguration != nil &&
+		config.Spec.InitConfiguration.LocalAPIEndpoint.BindPort != 0 {
+		return config.Spec.InitConfiguration.LocalAPIEndpoint.BindPort
+	}
+
+	if config.Spec.JoinConfiguration != nil &&
+		config.Spec.JoinConfiguration.ControlPlane != nil &&
+		config.Spec.JoinConfiguration.ControlPlane.LocalAPIEndpoint.BindPort != 0 {
+		return config.Spec.JoinConfiguration.ControlPlane.LocalAPIEndpoint.BindPort
+	}
+
+	return 6443
+}
+
 func staticPodName(component, nodeName string) string {
 	return fmt.Sprintf("%s-%s", component, nodeName)
 }
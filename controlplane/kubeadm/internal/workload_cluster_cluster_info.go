@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	clusterInfoConfigMapName      = "cluster-info"
+	clusterInfoConfigMapNamespace = "kube-public"
+	clusterInfoKubeconfigKey      = "kubeconfig"
+	certificateAuthorityDataKey   = "certificate-authority-data"
+)
+
+// UpdateClusterInfoCertificateAuthorities sets the certificate authorities in the kubeconfig stored in the
+// cluster-info ConfigMap, which is used by kubeadm to discover and trust the cluster when joining new nodes.
+func (w *Workload) UpdateClusterInfoCertificateAuthorities(ctx context.Context, caData []byte) error {
+	cm, err := w.getConfigMap(ctx, ctrlclient.ObjectKey{Name: clusterInfoConfigMapName, Namespace: clusterInfoConfigMapNamespace})
+	if err != nil {
+		return errors.Wrap(err, "failed to get cluster-info ConfigMap")
+	}
+
+	data, ok := cm.Data[clusterInfoKubeconfigKey]
+	if !ok {
+		return errors.Errorf("unable to find %q key in %s ConfigMap", clusterInfoKubeconfigKey, cm.Name)
+	}
+	kubeconfig, err := yamlToUnstructured([]byte(data))
+	if err != nil {
+		return errors.Wrapf(err, "unable to decode %s ConfigMap's %q content to Unstructured object", cm.Name, clusterInfoKubeconfigKey)
+	}
+
+	clusters, _, err := unstructured.NestedSlice(kubeconfig.UnstructuredContent(), "clusters")
+	if err != nil {
+		return errors.Wrapf(err, "unable to extract clusters from %s ConfigMap's %q", cm.Name, clusterInfoKubeconfigKey)
+	}
+	encoded := base64.StdEncoding.EncodeToString(caData)
+	changed := false
+	for i := range clusters {
+		c, ok := clusters[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		current, _, _ := unstructured.NestedString(c, "cluster", certificateAuthorityDataKey)
+		if decoded, err := base64.StdEncoding.DecodeString(current); err == nil && bytes.Equal(decoded, caData) {
+			continue
+		}
+		if err := unstructured.SetNestedField(c, encoded, "cluster", certificateAuthorityDataKey); err != nil {
+			return errors.Wrapf(err, "unable to update %q in %s ConfigMap's %q", certificateAuthorityDataKey, cm.Name, clusterInfoKubeconfigKey)
+		}
+		clusters[i] = c
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	if err := unstructured.SetNestedSlice(kubeconfig.UnstructuredContent(), clusters, "clusters"); err != nil {
+		return errors.Wrapf(err, "unable to update clusters in %s ConfigMap's %q", cm.Name, clusterInfoKubeconfigKey)
+	}
+
+	updated, err := yaml.Marshal(kubeconfig)
+	if err != nil {
+		return errors.Wrapf(err, "unable to encode %s ConfigMap's %q to YAML", cm.Name, clusterInfoKubeconfigKey)
+	}
+
+	cm.Data[clusterInfoKubeconfigKey] = string(updated)
+	if err := w.Client.Update(ctx, cm); err != nil {
+		return errors.Wrap(err, "failed to update cluster-info ConfigMap")
+	}
+	return nil
+}
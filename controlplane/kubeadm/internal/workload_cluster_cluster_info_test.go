@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"encoding/base64"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestUpdateClusterInfoCertificateAuthorities(t *testing.T) {
+	clusterInfo := func(caData string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterInfoConfigMapName,
+				Namespace: clusterInfoConfigMapNamespace,
+			},
+			Data: map[string]string{
+				clusterInfoKubeconfigKey: `apiVersion: v1
+clusters:
+- cluster:
+    certificate-authority-data: ` + base64.StdEncoding.EncodeToString([]byte(caData)) + `
+    server: https://10.0.0.1:6443
+  name: ""
+contexts: null
+current-context: ""
+kind: Config
+preferences: {}
+users: null
+`,
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		objs      []client.Object
+		caData    string
+		expectErr bool
+	}{
+		{
+			name:      "fails if the cluster-info ConfigMap does not exist",
+			caData:    "new",
+			expectErr: true,
+		},
+		{
+			name:   "sets the certificate authorities",
+			objs:   []client.Object{clusterInfo("old")},
+			caData: "old+new",
+		},
+		{
+			name:   "no-op if the certificate authorities are already set",
+			objs:   []client.Object{clusterInfo("new")},
+			caData: "new",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			w := &Workload{
+				Client: fakeClient,
+			}
+			err := w.UpdateClusterInfoCertificateAuthorities(ctx, []byte(tt.caData))
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKey{Name: clusterInfoConfigMapName, Namespace: clusterInfoConfigMapNamespace}, cm)).To(Succeed())
+			kubeconfig, err := yamlToUnstructured([]byte(cm.Data[clusterInfoKubeconfigKey]))
+			g.Expect(err).ToNot(HaveOccurred())
+			clusters, _, err := unstructured.NestedSlice(kubeconfig.UnstructuredContent(), "clusters")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(clusters).To(HaveLen(1))
+			caData, _, err := unstructured.NestedString(clusters[0].(map[string]interface{}), "cluster", certificateAuthorityDataKey)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(caData).To(Equal(base64.StdEncoding.EncodeToString([]byte(tt.caData))))
+			server, _, err := unstructured.NestedString(clusters[0].(map[string]interface{}), "cluster", "server")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(server).To(Equal("https://10.0.0.1:6443"))
+		})
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -45,13 +47,87 @@ func (w *Workload) UpdateEtcdConditions(ctx context.Context, controlPlane *Contr
 	w.updateExternalEtcdConditions(ctx, controlPlane)
 }
 
-func (w *Workload) updateExternalEtcdConditions(ctx context.Context, controlPlane *ControlPlane) { //nolint:unparam
+// externalEtcdProbeTimeout is the overall timeout for probing the external etcd endpoints of a KubeadmControlPlane.
+const externalEtcdProbeTimeout = 10 * time.Second
+
+func (w *Workload) updateExternalEtcdConditions(ctx context.Context, controlPlane *ControlPlane) {
 	// When KCP is not responsible for external etcd, we are reporting only health at KCP level.
 	conditions.MarkTrue(controlPlane.KCP, controlplanev1.EtcdClusterHealthyCondition)
 
-	// TODO: check external etcd for alarms an possibly also for member errors
-	// this requires implementing an new type of etcd client generator given that it is not possible to use nodes
-	// as a source for the etcd endpoint address; the address of the external etcd should be available on the kubeadm configuration.
+	// NOTE: The external etcd endpoints are probed from the management cluster, where they might not be reachable;
+	// for this reason problems are surfaced only in the ExternalEtcdHealthy condition, which does not contribute
+	// to the KCP Ready condition.
+	endpoints := controlPlane.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External.Endpoints
+	if len(endpoints) == 0 {
+		conditions.Delete(controlPlane.KCP, controlplanev1.ExternalEtcdHealthyCondition)
+		return
+	}
+
+	// The endpoints are probed concurrently and within an overall deadline, so unreachable endpoints do not delay
+	// the reconciliation of the KubeadmControlPlane by the sum of their connection timeouts.
+	ctx, cancel := context.WithTimeout(ctx, externalEtcdProbeTimeout)
+	defer cancel()
+
+	etcdClients := make([]*etcd.Client, len(endpoints))
+	var wg sync.WaitGroup
+	for i := range endpoints {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if etcdClient, err := w.etcdClientGenerator.forEndpoint(ctx, endpoints[i]); err == nil {
+				etcdClients[i] = etcdClient
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	var (
+		// unreachable is used to store the endpoints which cannot be reached.
+		unreachable []string
+		// unhealthy is used to store the errors and alarms reported by the endpoints.
+		unhealthy []string
+		// alarmsChecked is used to check the alarms only once, given that they are reported for the whole etcd cluster.
+		alarmsChecked bool
+	)
+	for i, endpoint := range endpoints {
+		etcdClient := etcdClients[i]
+		if etcdClient == nil {
+			unreachable = append(unreachable, endpoint)
+			continue
+		}
+		defer etcdClient.Close()
+
+		// While creating a new client, forEndpoint retrieves the status for the endpoint; check if the endpoint has errors.
+		if len(etcdClient.Errors) > 0 {
+			unhealthy = append(unhealthy, fmt.Sprintf("endpoint %s reports errors: %s", endpoint, strings.Join(etcdClient.Errors, ", ")))
+			continue
+		}
+
+		if alarmsChecked {
+			continue
+		}
+		alarms, err := etcdClient.Alarms(ctx)
+		if err != nil {
+			unhealthy = append(unhealthy, fmt.Sprintf("failed to get alarms from endpoint %s: %s", endpoint, err))
+			continue
+		}
+		alarmsChecked = true
+		for _, alarm := range alarms {
+			if alarm.Type == etcd.AlarmOK {
+				continue
+			}
+			unhealthy = append(unhealthy, fmt.Sprintf("member %d reports alarm %s", alarm.MemberID, etcd.AlarmTypeName[alarm.Type]))
+		}
+	}
+
+	switch {
+	case len(unhealthy) > 0:
+		conditions.MarkFalse(controlPlane.KCP, controlplanev1.ExternalEtcdHealthyCondition, controlplanev1.ExternalEtcdUnhealthyReason, clusterv1.ConditionSeverityError, "External etcd is unhealthy: %s", strings.Join(unhealthy, "; "))
+	case len(unreachable) > 0:
+		conditions.MarkFalse(controlPlane.KCP, controlplanev1.ExternalEtcdHealthyCondition, controlplanev1.ExternalEtcdEndpointsUnreachableReason, clusterv1.ConditionSeverityWarning, "Failed to connect to the external etcd endpoints: %s", strings.Join(unreachable, ", "))
+	default:
+		conditions.MarkTrue(controlPlane.KCP, controlplanev1.ExternalEtcdHealthyCondition)
+	}
 }
 
 func (w *Workload) updateManagedEtcdConditions(ctx context.Context, controlPlane *ControlPlane) {
@@ -17,7 +17,9 @@ limitations under the License.
 package internal
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -489,6 +491,143 @@ func TestUpdateEtcdConditions(t *testing.T) {
 	}
 }
 
+func TestUpdateExternalEtcdConditions(t *testing.T) {
+	externalEtcdKCP := func(endpoints ...string) *controlplanev1.KubeadmControlPlane {
+		return &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						Etcd: bootstrapv1.Etcd{
+							External: &bootstrapv1.ExternalEtcd{Endpoints: endpoints},
+						},
+					},
+				},
+			},
+		}
+	}
+	healthyClient := func(endpoint string) *etcd.Client {
+		return &etcd.Client{
+			Endpoint: endpoint,
+			EtcdClient: &fake2.FakeEtcdClient{
+				EtcdEndpoints: []string{endpoint},
+				AlarmResponse: &clientv3.AlarmResponse{},
+			},
+		}
+	}
+
+	// concurrentClientFunc returns a client only if all the endpoints are probed at the same time.
+	concurrentClientFunc := func(endpoints int) func(endpoint string) (*etcd.Client, error) {
+		var wg sync.WaitGroup
+		wg.Add(endpoints)
+		allProbing := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(allProbing)
+		}()
+		return func(endpoint string) (*etcd.Client, error) {
+			wg.Done()
+			select {
+			case <-allProbing:
+				return healthyClient(endpoint), nil
+			case <-time.After(5 * time.Second):
+				return nil, errors.New("timed out")
+			}
+		}
+	}
+
+	tests := []struct {
+		name                      string
+		kcp                       *controlplanev1.KubeadmControlPlane
+		injectEtcdClientGenerator etcdClientFor
+		expectedKCPCondition      *clusterv1.Condition
+	}{
+		{
+			name:                 "should not set the condition without external etcd endpoints",
+			kcp:                  externalEtcdKCP(),
+			expectedKCPCondition: nil,
+		},
+		{
+			name: "should report healthy endpoints",
+			kcp:  externalEtcdKCP("https://etcd-1:2379", "https://etcd-2:2379"),
+			injectEtcdClientGenerator: &fakeEtcdClientGenerator{
+				forEndpointClientFunc: func(endpoint string) (*etcd.Client, error) {
+					return healthyClient(endpoint), nil
+				},
+			},
+			expectedKCPCondition: conditions.TrueCondition(controlplanev1.ExternalEtcdHealthyCondition),
+		},
+		{
+			name: "should probe the endpoints concurrently",
+			kcp:  externalEtcdKCP("https://etcd-1:2379", "https://etcd-2:2379", "https://etcd-3:2379"),
+			injectEtcdClientGenerator: &fakeEtcdClientGenerator{
+				forEndpointClientFunc: concurrentClientFunc(3),
+			},
+			expectedKCPCondition: conditions.TrueCondition(controlplanev1.ExternalEtcdHealthyCondition),
+		},
+		{
+			name: "should report unreachable endpoints",
+			kcp:  externalEtcdKCP("https://etcd-1:2379", "https://etcd-2:2379"),
+			injectEtcdClientGenerator: &fakeEtcdClientGenerator{
+				forEndpointClientFunc: func(endpoint string) (*etcd.Client, error) {
+					if endpoint == "https://etcd-2:2379" {
+						return nil, errors.New("connection refused")
+					}
+					return healthyClient(endpoint), nil
+				},
+			},
+			expectedKCPCondition: conditions.FalseCondition(controlplanev1.ExternalEtcdHealthyCondition, controlplanev1.ExternalEtcdEndpointsUnreachableReason, clusterv1.ConditionSeverityWarning, "Failed to connect to the external etcd endpoints: https://etcd-2:2379"),
+		},
+		{
+			name: "should report endpoints with errors",
+			kcp:  externalEtcdKCP("https://etcd-1:2379"),
+			injectEtcdClientGenerator: &fakeEtcdClientGenerator{
+				forEndpointClientFunc: func(endpoint string) (*etcd.Client, error) {
+					client := healthyClient(endpoint)
+					client.Errors = []string{"some errors"}
+					return client, nil
+				},
+			},
+			expectedKCPCondition: conditions.FalseCondition(controlplanev1.ExternalEtcdHealthyCondition, controlplanev1.ExternalEtcdUnhealthyReason, clusterv1.ConditionSeverityError, "External etcd is unhealthy: endpoint https://etcd-1:2379 reports errors: some errors"),
+		},
+		{
+			name: "should report alarms",
+			kcp:  externalEtcdKCP("https://etcd-1:2379"),
+			injectEtcdClientGenerator: &fakeEtcdClientGenerator{
+				forEndpointClientFunc: func(endpoint string) (*etcd.Client, error) {
+					client := healthyClient(endpoint)
+					client.EtcdClient.(*fake2.FakeEtcdClient).AlarmResponse = &clientv3.AlarmResponse{
+						Alarms: []*pb.AlarmMember{
+							{MemberID: uint64(1), Alarm: 1}, // NOSPACE
+						},
+					}
+					return client, nil
+				},
+			},
+			expectedKCPCondition: conditions.FalseCondition(controlplanev1.ExternalEtcdHealthyCondition, controlplanev1.ExternalEtcdUnhealthyReason, clusterv1.ConditionSeverityError, "External etcd is unhealthy: member 1 reports alarm NOSPACE"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			w := &Workload{
+				etcdClientGenerator: tt.injectEtcdClientGenerator,
+			}
+			controlPane := &ControlPlane{
+				KCP: tt.kcp,
+			}
+			w.UpdateEtcdConditions(ctx, controlPane)
+
+			g.Expect(*conditions.Get(tt.kcp, controlplanev1.EtcdClusterHealthyCondition)).To(conditions.MatchCondition(*conditions.TrueCondition(controlplanev1.EtcdClusterHealthyCondition)))
+			if tt.expectedKCPCondition == nil {
+				g.Expect(conditions.Has(tt.kcp, controlplanev1.ExternalEtcdHealthyCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(*conditions.Get(tt.kcp, controlplanev1.ExternalEtcdHealthyCondition)).To(conditions.MatchCondition(*tt.expectedKCPCondition))
+		})
+	}
+}
+
 func TestUpdateStaticPodConditions(t *testing.T) {
 	n1APIServerPodName := staticPodName("kube-apiserver", "n1")
 	n1APIServerPodkey := client.ObjectKey{
@@ -778,6 +778,8 @@ type fakeEtcdClientGenerator struct {
 	forLeaderClient    *etcd.Client
 	forNodesErr        error
 	forLeaderErr       error
+
+	forEndpointClientFunc func(string) (*etcd.Client, error)
 }
 
 func (c *fakeEtcdClientGenerator) forFirstAvailableNode(_ context.Context, n []string) (*etcd.Client, error) {
@@ -791,6 +793,10 @@ func (c *fakeEtcdClientGenerator) forLeader(_ context.Context, _ []string) (*etc
 	return c.forLeaderClient, c.forLeaderErr
 }
 
+func (c *fakeEtcdClientGenerator) forEndpoint(_ context.Context, endpoint string) (*etcd.Client, error) {
+	return c.forEndpointClientFunc(endpoint)
+}
+
 func defaultMachine(transforms ...func(m *clusterv1.Machine)) *clusterv1.Machine {
 	m := &clusterv1.Machine{
 		Status: clusterv1.MachineStatus{
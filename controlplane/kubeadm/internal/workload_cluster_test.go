@@ -1148,6 +1148,7 @@ func TestClusterStatus(t *testing.T) {
 			g.Expect(err).ToNot(HaveOccurred())
 			g.Expect(status.Nodes).To(BeEquivalentTo(2))
 			g.Expect(status.ReadyNodes).To(BeEquivalentTo(1))
+			g.Expect(status.ReadyNodeNames).To(HaveLen(1))
 			if tt.expectHasConf {
 				g.Expect(status.HasKubeadmConfig).To(BeTrue())
 				return
@@ -1199,3 +1200,47 @@ func newKubeProxyDSWithImage(image string) appsv1.DaemonSet {
 	ds.Spec.Template.Spec.Containers[0].Image = image
 	return ds
 }
+
+func TestCalculateAPIServerPort(t *testing.T) {
+	tests := []struct {
+		name   string
+		config *bootstrapv1.KubeadmConfig
+		want   int32
+	}{
+		{
+			name:   "defaults to 6443",
+			config: &bootstrapv1.KubeadmConfig{},
+			want:   6443,
+		},
+		{
+			name: "uses the bind port of the InitConfiguration",
+			config: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					InitConfiguration: &bootstrapv1.InitConfiguration{
+						LocalAPIEndpoint: bootstrapv1.APIEndpoint{BindPort: 7443},
+					},
+				},
+			},
+			want: 7443,
+		},
+		{
+			name: "uses the bind port of the JoinConfiguration",
+			config: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{
+						ControlPlane: &bootstrapv1.JoinControlPlane{
+							LocalAPIEndpoint: bootstrapv1.APIEndpoint{BindPort: 8443},
+						},
+					},
+				},
+			},
+			want: 8443,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(calculateAPIServerPort(tt.config)).To(Equal(tt.want))
+		})
+	}
+}
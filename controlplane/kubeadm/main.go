@@ -44,11 +44,14 @@ import (
 	kcpv1alpha4 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha4"
 	kcpv1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	kubeadmcontrolplanecontrollers "sigs.k8s.io/cluster-api/controlplane/kubeadm/controllers"
+	expmetrics "sigs.k8s.io/cluster-api/exp/metrics"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/flags"
 	"sigs.k8s.io/cluster-api/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var (
@@ -70,19 +73,20 @@ func init() {
 }
 
 var (
-	metricsBindAddr                string
-	enableLeaderElection           bool
-	leaderElectionLeaseDuration    time.Duration
-	leaderElectionRenewDeadline    time.Duration
-	leaderElectionRetryPeriod      time.Duration
-	watchFilterValue               string
-	watchNamespace                 string
-	profilerAddress                string
-	kubeadmControlPlaneConcurrency int
-	syncPeriod                     time.Duration
-	webhookPort                    int
-	webhookCertDir                 string
-	healthAddr                     string
+	metricsBindAddr             string
+	enableLeaderElection        bool
+	leaderElectionLeaseDuration time.Duration
+	leaderElectionRenewDeadline time.Duration
+	leaderElectionRetryPeriod   time.Duration
+	watchFilterValue            string
+	watchNamespace              string
+	profilerAddress             string
+	kubeadmControlPlaneOptions  flags.ControllerOptions
+	syncPeriod                  time.Duration
+	webhookPort                 int
+	webhookCertDir              string
+	healthAddr                  string
+	featureGatesFile            string
 )
 
 // InitFlags initializes the flags.
@@ -108,8 +112,7 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&profilerAddress, "profiler-address", "",
 		"Bind address to expose the pprof profiler (e.g. localhost:6060)")
 
-	fs.IntVar(&kubeadmControlPlaneConcurrency, "kubeadmcontrolplane-concurrency", 10,
-		"Number of kubeadm control planes to process simultaneously")
+	flags.AddControllerOptionsFlags(fs, "kubeadmcontrolplane", "kubeadm control planes", &kubeadmControlPlaneOptions)
 
 	fs.DurationVar(&syncPeriod, "sync-period", 10*time.Minute,
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)")
@@ -127,6 +130,9 @@ func InitFlags(fs *pflag.FlagSet) {
 		"The address the health endpoint binds to.")
 
 	feature.MutableGates.AddFlag(fs)
+
+	fs.StringVar(&featureGatesFile, "feature-gates-file", "",
+		"Path of a file, usually mounted from a ConfigMap, setting the feature gates as a YAML map of feature gate names to booleans; the file is reloaded on changes and the controller restarts when feature gates change value. Feature gates set with --feature-gates take precedence")
 }
 func main() {
 	rand.Seed(time.Now().UnixNano())
@@ -138,6 +144,11 @@ func main() {
 
 	ctrl.SetLogger(klogr.New())
 
+	if err := kubeadmControlPlaneOptions.Validate("kubeadmcontrolplane"); err != nil {
+		setupLog.Error(err, "invalid controller options")
+		os.Exit(1)
+	}
+
 	if profilerAddress != "" {
 		klog.Infof("Profiler listening for requests at %s", profilerAddress)
 		go func() {
@@ -172,11 +183,16 @@ func main() {
 	}
 
 	// Setup the context that's going to be used in controllers and for the manager.
-	ctx := ctrl.SetupSignalHandler()
+	ctx, cancel := context.WithCancel(ctrl.SetupSignalHandler())
 
+	if err := feature.SetupGatesFileWithRestart(mgr, featureGatesFile, cancel); err != nil {
+		setupLog.Error(err, "unable to setup feature gates")
+		os.Exit(1)
+	}
 	setupChecks(mgr)
 	setupReconcilers(ctx, mgr)
 	setupWebhooks(mgr)
+	setupStateMetrics(mgr)
 
 	// +kubebuilder:scaffold:builder
 	setupLog.Info("starting manager", "version", version.Get().String())
@@ -220,16 +236,17 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		Log:              ctrl.Log.WithName("remote").WithName("ClusterCacheReconciler"),
 		Tracker:          tracker,
 		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
+	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneOptions.Concurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterCacheReconciler")
 		os.Exit(1)
 	}
 
-	if err := (&kubeadmcontrolplanecontrollers.KubeadmControlPlaneReconciler{
+	kubeadmControlPlaneReconciler := &kubeadmcontrolplanecontrollers.KubeadmControlPlaneReconciler{
 		Client:           mgr.GetClient(),
 		Tracker:          tracker,
 		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
+	}
+	if err := kubeadmControlPlaneReconciler.SetupWithManager(ctx, mgr, kubeadmControlPlaneOptions.ForReconciler(kubeadmControlPlaneReconciler)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeadmControlPlane")
 		os.Exit(1)
 	}
@@ -246,6 +263,15 @@ func setupWebhooks(mgr ctrl.Manager) {
 	}
 }
 
+func setupStateMetrics(mgr ctrl.Manager) {
+	if !feature.Gates.Enabled(feature.StateMetrics) {
+		return
+	}
+	metrics.Registry.MustRegister(expmetrics.NewCollector(mgr.GetClient(),
+		kubeadmcontrolplanecontrollers.KubeadmControlPlaneStateMetrics(),
+	))
+}
+
 func concurrency(c int) controller.Options {
 	return controller.Options{MaxConcurrentReconciles: c}
 }
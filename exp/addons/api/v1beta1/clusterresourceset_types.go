@@ -17,6 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"strings"
+
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -40,6 +43,14 @@ type ClusterResourceSetSpec struct {
 	// Label selector cannot be empty.
 	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
 
+	// NamespaceSelector is a label selector for the namespaces of the Clusters affected by this ClusterResourceSet.
+	// If not set, only Clusters in the same namespace as the ClusterResourceSet are selected; an empty selector
+	// selects Clusters in all namespaces. Selecting Clusters in other namespaces requires permissions to manage
+	// ClusterResourceSets in all namespaces. Resources are always read from the namespace of the ClusterResourceSet.
+	// This field is immutable.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
 	// Resources is a list of Secrets/ConfigMaps where each contains 1 or more resources to be applied to remote clusters.
 	// +optional
 	Resources []ResourceRef `json:"resources,omitempty"`
@@ -48,6 +59,12 @@ type ClusterResourceSetSpec struct {
 	// +kubebuilder:validation:Enum=ApplyOnce
 	// +optional
 	Strategy string `json:"strategy,omitempty"`
+
+	// Priority of the ClusterResourceSet. A ClusterResourceSet is applied to a Cluster only after all the resources of
+	// the ClusterResourceSets with a higher priority selecting the same Cluster have been applied, e.g. to apply
+	// CustomResourceDefinitions before the custom resources using them. Defaults to 0.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
 }
 
 // ANCHOR_END: ClusterResourceSetSpec
@@ -70,6 +87,17 @@ type ResourceRef struct {
 	// Kind of the resource. Supported kinds are: Secrets and ConfigMaps.
 	// +kubebuilder:validation:Enum=Secret;ConfigMap
 	Kind string `json:"kind"`
+
+	// DependsOn is a list of names of other resources of the ClusterResourceSet which must be applied to a Cluster
+	// before this resource, e.g. the resource with the Namespaces or the CustomResourceDefinitions for the objects
+	// in this resource. It is not used in ClusterResourceSetBindings.
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// Matches returns true if the ResourceRef refers to the same resource as the other ResourceRef.
+func (r ResourceRef) Matches(other ResourceRef) bool {
+	return r.Name == other.Name && r.Kind == other.Kind
 }
 
 // ClusterResourceSetStrategy is a string representation of a ClusterResourceSet Strategy.
@@ -81,6 +109,51 @@ const (
 	ClusterResourceSetStrategyApplyOnce ClusterResourceSetStrategy = "ApplyOnce"
 )
 
+// SortedResources returns the resources sorted so that each resource comes after the resources it depends on,
+// otherwise preserving their order; it returns an error if the dependencies between the resources are circular.
+func (c *ClusterResourceSetSpec) SortedResources() ([]ResourceRef, error) {
+	// A dependency is satisfied once all the resources with its name are sorted.
+	remaining := map[string]int{}
+	for _, resource := range c.Resources {
+		remaining[resource.Name]++
+	}
+
+	sorted := make([]ResourceRef, 0, len(c.Resources))
+	isSorted := make([]bool, len(c.Resources))
+	for len(sorted) < len(c.Resources) {
+		progress := false
+		for i, resource := range c.Resources {
+			if isSorted[i] {
+				continue
+			}
+			satisfied := true
+			for _, dependency := range resource.DependsOn {
+				if remaining[dependency] > 0 {
+					satisfied = false
+					break
+				}
+			}
+			if !satisfied {
+				continue
+			}
+			sorted = append(sorted, resource)
+			isSorted[i] = true
+			remaining[resource.Name]--
+			progress = true
+		}
+		if !progress {
+			var circular []string
+			for i, resource := range c.Resources {
+				if !isSorted[i] {
+					circular = append(circular, resource.Name)
+				}
+			}
+			return nil, errors.Errorf("circular dependencies between resources %s", strings.Join(circular, ", "))
+		}
+	}
+	return sorted, nil
+}
+
 // SetTypedStrategy sets the Strategy field to the string representation of ClusterResourceSetStrategy.
 func (c *ClusterResourceSetSpec) SetTypedStrategy(p ClusterResourceSetStrategy) {
 	c.Strategy = string(p)
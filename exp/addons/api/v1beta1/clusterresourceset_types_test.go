@@ -0,0 +1,90 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSortedResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []ResourceRef
+		want      []string
+		expectErr bool
+	}{
+		{
+			name: "preserves the order of resources without dependencies",
+			resources: []ResourceRef{
+				{Name: "b", Kind: "ConfigMap"},
+				{Name: "a", Kind: "Secret"},
+			},
+			want: []string{"b", "a"},
+		},
+		{
+			name: "sorts the resources after their dependencies",
+			resources: []ResourceRef{
+				{Name: "cni", Kind: "ConfigMap", DependsOn: []string{"crds", "namespaces"}},
+				{Name: "crds", Kind: "ConfigMap", DependsOn: []string{"namespaces"}},
+				{Name: "storage", Kind: "ConfigMap"},
+				{Name: "namespaces", Kind: "Secret"},
+			},
+			want: []string{"storage", "namespaces", "crds", "cni"},
+		},
+		{
+			name: "depends on all the resources with the same name",
+			resources: []ResourceRef{
+				{Name: "cni", Kind: "ConfigMap", DependsOn: []string{"crds"}},
+				{Name: "crds", Kind: "ConfigMap"},
+				{Name: "crds", Kind: "Secret"},
+			},
+			want: []string{"crds", "crds", "cni"},
+		},
+		{
+			name: "returns an error for circular dependencies",
+			resources: []ResourceRef{
+				{Name: "a", Kind: "ConfigMap", DependsOn: []string{"b"}},
+				{Name: "b", Kind: "ConfigMap", DependsOn: []string{"a"}},
+				{Name: "c", Kind: "ConfigMap"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			spec := &ClusterResourceSetSpec{Resources: tt.resources}
+			got, err := spec.SortedResources()
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(err.Error()).To(Equal("circular dependencies between resources a, b"))
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			names := make([]string, 0, len(got))
+			for _, r := range got {
+				names = append(names, r.Name)
+			}
+			g.Expect(names).To(Equal(tt.want))
+		})
+	}
+}
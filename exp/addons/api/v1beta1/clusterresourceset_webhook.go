@@ -23,6 +23,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -87,6 +88,18 @@ func (m *ClusterResourceSet) validate(old *ClusterResourceSet) error {
 		)
 	}
 
+	// Validate the namespace selector parses as Selector.
+	if m.Spec.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(m.Spec.NamespaceSelector); err != nil {
+			allErrs = append(
+				allErrs,
+				field.Invalid(field.NewPath("spec", "namespaceSelector"), m.Spec.NamespaceSelector, err.Error()),
+			)
+		}
+	}
+
+	allErrs = append(allErrs, validateResourceDependencies(&m.Spec, field.NewPath("spec", "resources"))...)
+
 	if old != nil && old.Spec.Strategy != "" && old.Spec.Strategy != m.Spec.Strategy {
 		allErrs = append(
 			allErrs,
@@ -101,8 +114,44 @@ func (m *ClusterResourceSet) validate(old *ClusterResourceSet) error {
 		)
 	}
 
+	if old != nil && !reflect.DeepEqual(old.Spec.NamespaceSelector, m.Spec.NamespaceSelector) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(field.NewPath("spec", "namespaceSelector"), m.Spec.NamespaceSelector, "field is immutable"),
+		)
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return apierrors.NewInvalid(GroupVersion.WithKind("ClusterResourceSet").GroupKind(), m.Name, allErrs)
 }
+
+// validateResourceDependencies validates that the resources depend only on other resources of the ClusterResourceSet,
+// without circular dependencies.
+func validateResourceDependencies(spec *ClusterResourceSetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := sets.NewString()
+	for _, resource := range spec.Resources {
+		names.Insert(resource.Name)
+	}
+	for i, resource := range spec.Resources {
+		for j, dependency := range resource.DependsOn {
+			switch {
+			case dependency == resource.Name:
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("dependsOn").Index(j), dependency, "a resource cannot depend on itself"))
+			case !names.Has(dependency):
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("dependsOn").Index(j), dependency, "must be the name of another resource of the ClusterResourceSet"))
+			}
+		}
+	}
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	if _, err := spec.SortedResources(); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath, spec.Resources, err.Error()))
+	}
+	return allErrs
+}
@@ -189,3 +189,135 @@ func TestClusterResourceSetSelectorNotEmptyValidation(t *testing.T) {
 	g.Expect(err).ToNot(BeNil())
 	g.Expect(err.Error()).To(ContainSubstring("selector must not be empty"))
 }
+
+func TestClusterResourceSetNamespaceSelectorValidation(t *testing.T) {
+	tests := []struct {
+		name                 string
+		update               bool
+		oldNamespaceSelector *metav1.LabelSelector
+		newNamespaceSelector *metav1.LabelSelector
+		expectErr            bool
+	}{
+		{
+			name:                 "should not return error for a valid namespace selector",
+			newNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}},
+			expectErr:            false,
+		},
+		{
+			name:                 "should not return error for an empty namespace selector",
+			newNamespaceSelector: &metav1.LabelSelector{},
+			expectErr:            false,
+		},
+		{
+			name:                 "should return error for an invalid namespace selector",
+			newNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"-123-foo": "bar"}},
+			expectErr:            true,
+		},
+		{
+			name:                 "should not return error when the namespace selector has not changed",
+			update:               true,
+			oldNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}},
+			newNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}},
+			expectErr:            false,
+		},
+		{
+			name:                 "should return error when the namespace selector has changed",
+			update:               true,
+			oldNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}},
+			newNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "bar"}},
+			expectErr:            true,
+		},
+		{
+			name:                 "should return error when the namespace selector is added",
+			update:               true,
+			newNamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "foo"}},
+			expectErr:            true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			newClusterResourceSet := &ClusterResourceSet{
+				Spec: ClusterResourceSetSpec{
+					ClusterSelector:   metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+					NamespaceSelector: tt.newNamespaceSelector,
+				},
+			}
+
+			var err error
+			if !tt.update {
+				err = newClusterResourceSet.ValidateCreate()
+			} else {
+				oldClusterResourceSet := newClusterResourceSet.DeepCopy()
+				oldClusterResourceSet.Spec.NamespaceSelector = tt.oldNamespaceSelector
+				err = newClusterResourceSet.ValidateUpdate(oldClusterResourceSet)
+			}
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func TestClusterResourceSetResourceDependenciesValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []ResourceRef
+		expectErr bool
+	}{
+		{
+			name: "should not return error for valid dependencies",
+			resources: []ResourceRef{
+				{Name: "calico", Kind: "ConfigMap", DependsOn: []string{"calico-crds"}},
+				{Name: "calico-crds", Kind: "ConfigMap"},
+			},
+			expectErr: false,
+		},
+		{
+			name: "should return error for a dependency on a missing resource",
+			resources: []ResourceRef{
+				{Name: "calico", Kind: "ConfigMap", DependsOn: []string{"calico-crds"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error for a dependency on itself",
+			resources: []ResourceRef{
+				{Name: "calico", Kind: "ConfigMap", DependsOn: []string{"calico"}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error for circular dependencies",
+			resources: []ResourceRef{
+				{Name: "a", Kind: "ConfigMap", DependsOn: []string{"b"}},
+				{Name: "b", Kind: "Secret", DependsOn: []string{"a"}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := &ClusterResourceSet{
+				Spec: ClusterResourceSetSpec{
+					ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"foo": "bar"}},
+					Resources:       tt.resources,
+				},
+			}
+
+			err := clusterResourceSet.ValidateCreate()
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
@@ -17,8 +17,6 @@ limitations under the License.
 package v1beta1
 
 import (
-	"reflect"
-
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -49,6 +47,11 @@ type ResourceSetBinding struct {
 	// ClusterResourceSetName is the name of the ClusterResourceSet that is applied to the owner cluster of the binding.
 	ClusterResourceSetName string `json:"clusterResourceSetName"`
 
+	// ClusterResourceSetNamespace is the namespace of the ClusterResourceSet that is applied to the owner cluster of the binding.
+	// If empty, the ClusterResourceSet is in the same namespace as the binding.
+	// +optional
+	ClusterResourceSetNamespace string `json:"clusterResourceSetNamespace,omitempty"`
+
 	// Resources is a list of resources that the ClusterResourceSet has.
 	// +optional
 	Resources []ResourceBinding `json:"resources,omitempty"`
@@ -57,7 +60,7 @@ type ResourceSetBinding struct {
 // IsApplied returns true if the resource is applied to the cluster by checking the cluster's binding.
 func (r *ResourceSetBinding) IsApplied(resourceRef ResourceRef) bool {
 	for _, resource := range r.Resources {
-		if reflect.DeepEqual(resource.ResourceRef, resourceRef) {
+		if resource.ResourceRef.Matches(resourceRef) {
 			if resource.Applied {
 				return true
 			}
@@ -70,7 +73,7 @@ func (r *ResourceSetBinding) IsApplied(resourceRef ResourceRef) bool {
 // creating a new one.
 func (r *ResourceSetBinding) SetBinding(resourceBinding ResourceBinding) {
 	for i := range r.Resources {
-		if reflect.DeepEqual(r.Resources[i].ResourceRef, resourceBinding.ResourceRef) {
+		if r.Resources[i].ResourceRef.Matches(resourceBinding.ResourceRef) {
 			r.Resources[i] = resourceBinding
 			return
 		}
@@ -82,11 +85,14 @@ func (r *ResourceSetBinding) SetBinding(resourceBinding ResourceBinding) {
 // otherwise creates one and updates ClusterResourceSet with it.
 func (c *ClusterResourceSetBinding) GetOrCreateBinding(clusterResourceSet *ClusterResourceSet) *ResourceSetBinding {
 	for _, binding := range c.Spec.Bindings {
-		if binding.ClusterResourceSetName == clusterResourceSet.Name {
+		if c.isBindingFor(binding, clusterResourceSet) {
 			return binding
 		}
 	}
 	binding := &ResourceSetBinding{ClusterResourceSetName: clusterResourceSet.Name, Resources: []ResourceBinding{}}
+	if clusterResourceSet.Namespace != c.Namespace {
+		binding.ClusterResourceSetNamespace = clusterResourceSet.Namespace
+	}
 	c.Spec.Bindings = append(c.Spec.Bindings, binding)
 	return binding
 }
@@ -94,7 +100,7 @@ func (c *ClusterResourceSetBinding) GetOrCreateBinding(clusterResourceSet *Clust
 // DeleteBinding removes the ClusterResourceSet from the ClusterResourceSetBinding Bindings list.
 func (c *ClusterResourceSetBinding) DeleteBinding(clusterResourceSet *ClusterResourceSet) {
 	for i, binding := range c.Spec.Bindings {
-		if binding.ClusterResourceSetName == clusterResourceSet.Name {
+		if c.isBindingFor(binding, clusterResourceSet) {
 			copy(c.Spec.Bindings[i:], c.Spec.Bindings[i+1:])
 			c.Spec.Bindings = c.Spec.Bindings[:len(c.Spec.Bindings)-1]
 			break
@@ -102,6 +108,15 @@ func (c *ClusterResourceSetBinding) DeleteBinding(clusterResourceSet *ClusterRes
 	}
 }
 
+// isBindingFor returns true if a ResourceSetBinding refers to the given ClusterResourceSet.
+func (c *ClusterResourceSetBinding) isBindingFor(binding *ResourceSetBinding, clusterResourceSet *ClusterResourceSet) bool {
+	namespace := binding.ClusterResourceSetNamespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+	return binding.ClusterResourceSetName == clusterResourceSet.Name && namespace == clusterResourceSet.Namespace
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=clusterresourcesetbindings,scope=Namespaced,categories=cluster-api
 // +kubebuilder:subresource:status
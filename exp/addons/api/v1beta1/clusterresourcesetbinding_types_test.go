@@ -80,6 +80,12 @@ func TestIsResourceApplied(t *testing.T) {
 			resourceRef:        resourceRefNotExist,
 			isApplied:          false,
 		},
+		{
+			name:               "should ignore the dependencies of the resource",
+			resourceSetBinding: CRSBinding,
+			resourceRef:        ResourceRef{Name: resourceRefApplySucceeded.Name, Kind: resourceRefApplySucceeded.Kind, DependsOn: []string{"crds"}},
+			isApplied:          true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -156,3 +162,26 @@ func TestSetResourceBinding(t *testing.T) {
 		})
 	}
 }
+
+func TestGetOrCreateAndDeleteBinding(t *testing.T) {
+	g := NewWithT(t)
+
+	binding := &ClusterResourceSetBinding{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "cluster"},
+	}
+	sameNamespaceCRS := &ClusterResourceSet{ObjectMeta: metav1.ObjectMeta{Namespace: "tenant", Name: "calico"}}
+	otherNamespaceCRS := &ClusterResourceSet{ObjectMeta: metav1.ObjectMeta{Namespace: "addons", Name: "calico"}}
+
+	sameNamespaceBinding := binding.GetOrCreateBinding(sameNamespaceCRS)
+	g.Expect(sameNamespaceBinding.ClusterResourceSetNamespace).To(BeEmpty())
+	otherNamespaceBinding := binding.GetOrCreateBinding(otherNamespaceCRS)
+	g.Expect(otherNamespaceBinding.ClusterResourceSetNamespace).To(Equal("addons"))
+	g.Expect(binding.Spec.Bindings).To(HaveLen(2))
+
+	// Getting the binding again returns the existing one.
+	g.Expect(binding.GetOrCreateBinding(otherNamespaceCRS)).To(BeIdenticalTo(otherNamespaceBinding))
+	g.Expect(binding.Spec.Bindings).To(HaveLen(2))
+
+	binding.DeleteBinding(otherNamespaceCRS)
+	g.Expect(binding.Spec.Bindings).To(ConsistOf(sameNamespaceBinding))
+}
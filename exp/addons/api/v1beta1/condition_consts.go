@@ -39,4 +39,12 @@ const (
 
 	// WrongSecretTypeReason (Severity=Warning) documents at least one of the Secret's type in the resource list is not supported.
 	WrongSecretTypeReason = "WrongSecretType"
+
+	// WaitingForDependenciesReason (Severity=Info) documents at least one of the resources waiting for the resources
+	// it depends on to be applied to one of the matching clusters.
+	WaitingForDependenciesReason = "WaitingForDependencies"
+
+	// WaitingForClusterResourceSetsReason (Severity=Info) documents the resources waiting for the ClusterResourceSets
+	// with a higher priority to be applied to one of the matching clusters.
+	WaitingForClusterResourceSetsReason = "WaitingForClusterResourceSets"
 )
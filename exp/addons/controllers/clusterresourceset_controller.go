@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -31,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
@@ -39,6 +41,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/requeue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -54,8 +57,13 @@ var (
 	ErrSecretTypeNotSupported = errors.New("unsupported secret type")
 )
 
+// priorityRequeueAfter is how long to wait before checking again if the ClusterResourceSets with a higher
+// priority have been applied to a Cluster.
+const priorityRequeueAfter = 10 * time.Second
+
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=addons.cluster.x-k8s.io,resources=clusterresourcesets/status;clusterresourcesets/finalizers,verbs=get;update;patch
 
@@ -75,6 +83,10 @@ func (r *ClusterResourceSetReconciler) SetupWithManager(ctx context.Context, mgr
 			&source.Kind{Type: &clusterv1.Cluster{}},
 			handler.EnqueueRequestsFromMapFunc(r.clusterToClusterResourceSet),
 		).
+		Watches(
+			&source.Kind{Type: &corev1.Namespace{}},
+			handler.EnqueueRequestsFromMapFunc(r.namespaceToClusterResourceSet),
+		).
 		Watches(
 			&source.Kind{Type: &corev1.ConfigMap{}},
 			handler.EnqueueRequestsFromMapFunc(r.resourceToClusterResourceSet),
@@ -148,12 +160,29 @@ func (r *ClusterResourceSetReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return r.reconcileDelete(ctx, clusters, clusterResourceSet)
 	}
 
+	var waiting []string
 	for _, cluster := range clusters {
+		pending, err := r.getPendingClusterResourceSets(ctx, cluster, clusterResourceSet)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if len(pending) > 0 {
+			log.V(4).Info("Waiting for ClusterResourceSets with a higher priority to be applied", "cluster", cluster.Name, "ClusterResourceSets", pending)
+			waiting = append(waiting, fmt.Sprintf("%s (%s)", cluster.Name, strings.Join(pending, ", ")))
+			continue
+		}
+
 		if err := r.ApplyClusterResourceSet(ctx, cluster, clusterResourceSet); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
 
+	if len(waiting) > 0 {
+		conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedCondition, addonsv1.WaitingForClusterResourceSetsReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for ClusterResourceSets with a higher priority to be applied to Clusters %s", strings.Join(waiting, ", "))
+		return requeue.AfterWithJitter(priorityRequeueAfter), nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -199,7 +228,8 @@ func (r *ClusterResourceSetReconciler) reconcileDelete(ctx context.Context, clus
 	return ctrl.Result{}, nil
 }
 
-// getClustersByClusterResourceSetSelector fetches Clusters matched by the ClusterResourceSet's label selector that are in the same namespace as the ClusterResourceSet object.
+// getClustersByClusterResourceSetSelector fetches Clusters matched by the ClusterResourceSet's label selector that are in the same namespace as the ClusterResourceSet object,
+// or in the namespaces matched by the ClusterResourceSet's namespace selector, if any.
 func (r *ClusterResourceSetReconciler) getClustersByClusterResourceSetSelector(ctx context.Context, clusterResourceSet *addonsv1.ClusterResourceSet) ([]*clusterv1.Cluster, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -215,20 +245,53 @@ func (r *ClusterResourceSetReconciler) getClustersByClusterResourceSetSelector(c
 		return nil, nil
 	}
 
-	if err := r.Client.List(ctx, clusterList, client.InNamespace(clusterResourceSet.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+	namespaces, err := r.getNamespacesByClusterResourceSetSelector(ctx, clusterResourceSet)
+	if err != nil {
+		return nil, err
+	}
+
+	listOptions := []client.ListOption{client.MatchingLabelsSelector{Selector: selector}}
+	if clusterResourceSet.Spec.NamespaceSelector == nil {
+		listOptions = append(listOptions, client.InNamespace(clusterResourceSet.Namespace))
+	}
+	if err := r.Client.List(ctx, clusterList, listOptions...); err != nil {
 		return nil, errors.Wrap(err, "failed to list clusters")
 	}
 
 	clusters := []*clusterv1.Cluster{}
 	for i := range clusterList.Items {
 		c := &clusterList.Items[i]
-		if c.DeletionTimestamp.IsZero() {
+		if c.DeletionTimestamp.IsZero() && namespaces.Has(c.Namespace) {
 			clusters = append(clusters, c)
 		}
 	}
 	return clusters, nil
 }
 
+// getNamespacesByClusterResourceSetSelector returns the namespaces where the ClusterResourceSet can select Clusters, that is
+// the namespace of the ClusterResourceSet or, if the ClusterResourceSet has a namespace selector, the namespaces it matches.
+func (r *ClusterResourceSetReconciler) getNamespacesByClusterResourceSetSelector(ctx context.Context, clusterResourceSet *addonsv1.ClusterResourceSet) (sets.String, error) {
+	if clusterResourceSet.Spec.NamespaceSelector == nil {
+		return sets.NewString(clusterResourceSet.Namespace), nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(clusterResourceSet.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to convert namespace selector")
+	}
+
+	namespaceList := &corev1.NamespaceList{}
+	if err := r.Client.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, errors.Wrap(err, "failed to list namespaces")
+	}
+
+	namespaces := sets.NewString()
+	for i := range namespaceList.Items {
+		namespaces.Insert(namespaceList.Items[i].Name)
+	}
+	return namespaces, nil
+}
+
 // ApplyClusterResourceSet applies resources in a ClusterResourceSet to a Cluster. Once applied, a record will be added to the
 // cluster's ClusterResourceSetBinding.
 // In ApplyOnce strategy, resources are applied only once to a particular cluster. ClusterResourceSetBinding is used to check if a resource is applied before.
@@ -265,14 +328,30 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 	errList := []error{}
 	resourceSetBinding := clusterResourceSetBinding.GetOrCreateBinding(clusterResourceSet)
 
+	// Apply the resources after the resources they depend on; the dependencies are validated by the webhook.
+	resources, err := clusterResourceSet.Spec.SortedResources()
+	if err != nil {
+		return err
+	}
+
 	// Iterate all resources and apply them to the cluster and update the resource status in the ClusterResourceSetBinding object.
-	for _, resource := range clusterResourceSet.Spec.Resources {
+	var waitingForDependencies []string
+	for _, resource := range resources {
 		// If resource is already applied successfully and clusterResourceSet mode is "ApplyOnce", continue. (No need to check hash changes here)
 		if resourceSetBinding.IsApplied(resource) {
 			continue
 		}
 
-		unstructuredObj, err := r.getResource(ctx, resource, cluster.GetNamespace())
+		// Wait for the resources this resource depends on to be applied.
+		if !dependenciesApplied(resourceSetBinding, clusterResourceSet.Spec.Resources, resource) {
+			waitingForDependencies = append(waitingForDependencies, resource.Name)
+			continue
+		}
+
+		// The dependencies of the resources are not tracked in the ClusterResourceSetBinding.
+		resourceRef := addonsv1.ResourceRef{Name: resource.Name, Kind: resource.Kind}
+
+		unstructuredObj, err := r.getResource(ctx, resource, clusterResourceSet.GetNamespace())
 		if err != nil {
 			if err == ErrSecretTypeNotSupported {
 				conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedCondition, addonsv1.WrongSecretTypeReason, clusterv1.ConditionSeverityWarning, err.Error())
@@ -291,7 +370,7 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 		// Set status in ClusterResourceSetBinding in case of early continue due to a failure.
 		// Set only when resource is retrieved successfully.
 		resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
-			ResourceRef:     resource,
+			ResourceRef:     resourceRef,
 			Hash:            "",
 			Applied:         false,
 			LastAppliedTime: &metav1.Time{Time: time.Now().UTC()},
@@ -349,7 +428,7 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 		}
 
 		resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
-			ResourceRef:     resource,
+			ResourceRef:     resourceRef,
 			Hash:            computeHash(dataList),
 			Applied:         isSuccessful,
 			LastAppliedTime: &metav1.Time{Time: time.Now().UTC()},
@@ -359,14 +438,69 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 		return kerrors.NewAggregate(errList)
 	}
 
+	if len(waitingForDependencies) > 0 {
+		conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedCondition, addonsv1.WaitingForDependenciesReason, clusterv1.ConditionSeverityInfo,
+			"Resources %s are waiting for their dependencies to be applied to Cluster %s", strings.Join(waitingForDependencies, ", "), cluster.Name)
+		return nil
+	}
+
 	conditions.MarkTrue(clusterResourceSet, addonsv1.ResourcesAppliedCondition)
 
 	return nil
 }
 
+// dependenciesApplied returns true if all the resources the given resource depends on are applied to the cluster.
+func dependenciesApplied(resourceSetBinding *addonsv1.ResourceSetBinding, resources []addonsv1.ResourceRef, resource addonsv1.ResourceRef) bool {
+	for _, dependency := range resource.DependsOn {
+		for _, r := range resources {
+			if r.Name == dependency && !resourceSetBinding.IsApplied(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// getPendingClusterResourceSets returns the ClusterResourceSets selecting the Cluster with a higher priority than the given
+// ClusterResourceSet which are not yet applied to the Cluster, i.e. with resources not applied successfully.
+func (r *ClusterResourceSetReconciler) getPendingClusterResourceSets(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet) ([]string, error) {
+	clusterResourceSets, err := r.getClusterResourceSetsForCluster(ctx, cluster)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get ClusterResourceSets for Cluster %s/%s", cluster.Namespace, cluster.Name)
+	}
+
+	var higherPriority []*addonsv1.ClusterResourceSet
+	for _, crs := range clusterResourceSets {
+		if crs.Spec.Priority > clusterResourceSet.Spec.Priority && crs.DeletionTimestamp.IsZero() {
+			higherPriority = append(higherPriority, crs)
+		}
+	}
+	if len(higherPriority) == 0 {
+		return nil, nil
+	}
+
+	clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{}
+	if err := r.Client.Get(ctx, util.ObjectKey(cluster), clusterResourceSetBinding); err != nil && !apierrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "failed to get ClusterResourceSetBinding for Cluster %s/%s", cluster.Namespace, cluster.Name)
+	}
+
+	var pending []string
+	for _, crs := range higherPriority {
+		// NOTE: the binding is not persisted, so creating a missing ResourceSetBinding has no side effects.
+		resourceSetBinding := clusterResourceSetBinding.GetOrCreateBinding(crs)
+		for _, resource := range crs.Spec.Resources {
+			if !resourceSetBinding.IsApplied(resource) {
+				pending = append(pending, fmt.Sprintf("%s/%s", crs.Namespace, crs.Name))
+				break
+			}
+		}
+	}
+	return pending, nil
+}
+
 // getResource retrieves the requested resource and convert it to unstructured type.
 // Unsupported resource kinds are not denied by validation webhook, hence no need to check here.
-// Only supports Secrets/Configmaps as resource types and allow using resources in the same namespace with the cluster.
+// Only supports Secrets/Configmaps as resource types and allow using resources in the same namespace with the ClusterResourceSet.
 func (r *ClusterResourceSetReconciler) getResource(ctx context.Context, resourceRef addonsv1.ResourceRef, namespace string) (*unstructured.Unstructured, error) {
 	resourceName := types.NamespacedName{Name: resourceRef.Name, Namespace: namespace}
 
@@ -428,30 +562,87 @@ func (r *ClusterResourceSetReconciler) clusterToClusterResourceSet(o client.Obje
 		panic(fmt.Sprintf("Expected a Cluster but got a %T", o))
 	}
 
-	resourceList := &addonsv1.ClusterResourceSetList{}
-	if err := r.Client.List(context.TODO(), resourceList, client.InNamespace(cluster.Namespace)); err != nil {
+	clusterResourceSets, err := r.getClusterResourceSetsForCluster(context.TODO(), cluster)
+	if err != nil {
 		return nil
 	}
+	for _, rs := range clusterResourceSets {
+		name := client.ObjectKey{Namespace: rs.Namespace, Name: rs.Name}
+		result = append(result, ctrl.Request{NamespacedName: name})
+	}
+	return result
+}
+
+// getClusterResourceSetsForCluster returns the ClusterResourceSets selecting the Cluster.
+func (r *ClusterResourceSetReconciler) getClusterResourceSetsForCluster(ctx context.Context, cluster *clusterv1.Cluster) ([]*addonsv1.ClusterResourceSet, error) {
+	result := []*addonsv1.ClusterResourceSet{}
+
+	// List ClusterResourceSets in all namespaces, because ClusterResourceSets with a namespace selector can select Clusters in other namespaces.
+	resourceList := &addonsv1.ClusterResourceSetList{}
+	if err := r.Client.List(ctx, resourceList); err != nil {
+		return nil, err
+	}
 
+	var namespaceLabels labels.Set
 	labels := labels.Set(cluster.GetLabels())
 	for i := range resourceList.Items {
 		rs := &resourceList.Items[i]
 
+		if rs.Spec.NamespaceSelector == nil && rs.Namespace != cluster.Namespace {
+			continue
+		}
+		if rs.Spec.NamespaceSelector != nil {
+			if namespaceLabels == nil {
+				namespace := &corev1.Namespace{}
+				if err := r.Client.Get(ctx, client.ObjectKey{Name: cluster.Namespace}, namespace); err != nil {
+					continue
+				}
+				namespaceLabels = namespace.GetLabels()
+				if namespaceLabels == nil {
+					namespaceLabels = map[string]string{}
+				}
+			}
+			namespaceSelector, err := metav1.LabelSelectorAsSelector(rs.Spec.NamespaceSelector)
+			if err != nil || !namespaceSelector.Matches(namespaceLabels) {
+				continue
+			}
+		}
+
 		selector, err := metav1.LabelSelectorAsSelector(&rs.Spec.ClusterSelector)
 		if err != nil {
-			return nil
+			continue
 		}
 
 		// If a ClusterResourceSet has a nil or empty selector, it should match nothing, not everything.
 		if selector.Empty() {
-			return nil
+			continue
 		}
 
 		if !selector.Matches(labels) {
 			continue
 		}
 
-		name := client.ObjectKey{Namespace: rs.Namespace, Name: rs.Name}
+		result = append(result, rs)
+	}
+	return result, nil
+}
+
+// namespaceToClusterResourceSet is mapper function that maps namespaces to the ClusterResourceSets with a namespace selector,
+// so Clusters are selected or unselected when the labels of their namespace change.
+func (r *ClusterResourceSetReconciler) namespaceToClusterResourceSet(o client.Object) []ctrl.Request {
+	result := []ctrl.Request{}
+
+	crsList := &addonsv1.ClusterResourceSetList{}
+	if err := r.Client.List(context.TODO(), crsList); err != nil {
+		return nil
+	}
+
+	for i := range crsList.Items {
+		crs := &crsList.Items[i]
+		if crs.Spec.NamespaceSelector == nil {
+			continue
+		}
+		name := client.ObjectKey{Namespace: crs.Namespace, Name: crs.Name}
 		result = append(result, ctrl.Request{NamespacedName: name})
 	}
 	return result
@@ -82,7 +82,7 @@ func apply(ctx context.Context, c client.Client, data []byte) error {
 	errList := []error{}
 	sortedObjs := utilresource.SortForCreate(objs)
 	for i := range sortedObjs {
-		if err := applyUnstructured(ctx, c, &objs[i]); err != nil {
+		if err := applyUnstructured(ctx, c, &sortedObjs[i]); err != nil {
 			errList = append(errList, err)
 		}
 	}
@@ -128,7 +128,10 @@ func (r *ClusterResourceSetReconciler) getOrCreateClusterResourceSetBinding(ctx
 			Name:       cluster.Name,
 			UID:        cluster.UID,
 		})
-		clusterResourceSetBinding.OwnerReferences = util.EnsureOwnerRef(clusterResourceSetBinding.OwnerReferences, *metav1.NewControllerRef(clusterResourceSet, clusterResourceSet.GroupVersionKind()))
+		// Owner references across namespaces are not allowed, so ClusterResourceSets in other namespaces are tracked only in the bindings list.
+		if clusterResourceSet.Namespace == cluster.Namespace {
+			clusterResourceSetBinding.OwnerReferences = util.EnsureOwnerRef(clusterResourceSetBinding.OwnerReferences, *metav1.NewControllerRef(clusterResourceSet, clusterResourceSet.GroupVersionKind()))
+		}
 
 		clusterResourceSetBinding.Spec.Bindings = []*addonsv1.ResourceSetBinding{}
 		if err := r.Client.Create(ctx, clusterResourceSetBinding); err != nil {
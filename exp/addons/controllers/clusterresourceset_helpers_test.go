@@ -28,6 +28,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
@@ -218,3 +220,254 @@ func TestGetConfigMapFromNamespacedName(t *testing.T) {
 		})
 	}
 }
+
+func TestGetClustersByClusterResourceSetSelector(t *testing.T) {
+	newNamespace := func(name string, labels map[string]string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	newCluster := func(namespace, name string) *clusterv1.Cluster {
+		return &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+				Labels:    map[string]string{"cni": "calico"},
+			},
+		}
+	}
+	newClusterResourceSet := func(namespaceSelector *metav1.LabelSelector) *addonsv1.ClusterResourceSet {
+		return &addonsv1.ClusterResourceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "addons",
+				Name:      "calico",
+			},
+			Spec: addonsv1.ClusterResourceSetSpec{
+				ClusterSelector:   metav1.LabelSelector{MatchLabels: map[string]string{"cni": "calico"}},
+				NamespaceSelector: namespaceSelector,
+			},
+		}
+	}
+
+	objs := []client.Object{
+		newNamespace("addons", nil),
+		newNamespace("tenant-1", map[string]string{"tenant": ""}),
+		newNamespace("tenant-2", map[string]string{"tenant": ""}),
+		newNamespace("other", nil),
+		newCluster("addons", "cluster"),
+		newCluster("tenant-1", "cluster"),
+		newCluster("tenant-2", "cluster"),
+		newCluster("other", "cluster"),
+	}
+
+	tests := []struct {
+		name               string
+		clusterResourceSet *addonsv1.ClusterResourceSet
+		want               []string
+	}{
+		{
+			name:               "selects Clusters in the namespace of the ClusterResourceSet without a namespace selector",
+			clusterResourceSet: newClusterResourceSet(nil),
+			want:               []string{"addons/cluster"},
+		},
+		{
+			name:               "selects Clusters in the namespaces matching the namespace selector",
+			clusterResourceSet: newClusterResourceSet(&metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tenant", Operator: metav1.LabelSelectorOpExists}}}),
+			want:               []string{"tenant-1/cluster", "tenant-2/cluster"},
+		},
+		{
+			name:               "selects Clusters in all namespaces with an empty namespace selector",
+			clusterResourceSet: newClusterResourceSet(&metav1.LabelSelector{}),
+			want:               []string{"addons/cluster", "other/cluster", "tenant-1/cluster", "tenant-2/cluster"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &ClusterResourceSetReconciler{
+				Client: fake.NewClientBuilder().WithObjects(objs...).Build(),
+			}
+
+			clusters, err := r.getClustersByClusterResourceSetSelector(ctx, tt.clusterResourceSet)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			got := []string{}
+			for _, c := range clusters {
+				got = append(got, c.Namespace+"/"+c.Name)
+			}
+			g.Expect(got).To(ConsistOf(tt.want))
+		})
+	}
+}
+
+func TestClusterToClusterResourceSet(t *testing.T) {
+	g := NewWithT(t)
+
+	tenantNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-1", Labels: map[string]string{"tenant": ""}}}
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: tenantNamespace.Name,
+			Name:      "cluster",
+			Labels:    map[string]string{"cni": "calico"},
+		},
+	}
+	newClusterResourceSet := func(namespace, name string, namespaceSelector *metav1.LabelSelector) *addonsv1.ClusterResourceSet {
+		return &addonsv1.ClusterResourceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: addonsv1.ClusterResourceSetSpec{
+				ClusterSelector:   metav1.LabelSelector{MatchLabels: map[string]string{"cni": "calico"}},
+				NamespaceSelector: namespaceSelector,
+			},
+		}
+	}
+
+	r := &ClusterResourceSetReconciler{
+		Client: fake.NewClientBuilder().WithObjects(
+			tenantNamespace,
+			cluster,
+			newClusterResourceSet(tenantNamespace.Name, "same-namespace", nil),
+			newClusterResourceSet("addons", "other-namespace", nil),
+			newClusterResourceSet("addons", "matching-namespace-selector", &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": ""}}),
+			newClusterResourceSet("addons", "not-matching-namespace-selector", &metav1.LabelSelector{MatchLabels: map[string]string{"team": ""}}),
+			&addonsv1.ClusterResourceSet{ObjectMeta: metav1.ObjectMeta{Namespace: "addons", Name: "empty-selector"}, Spec: addonsv1.ClusterResourceSetSpec{NamespaceSelector: &metav1.LabelSelector{}}},
+		).Build(),
+	}
+
+	got := r.clusterToClusterResourceSet(cluster)
+	g.Expect(got).To(ConsistOf(
+		ctrl.Request{NamespacedName: types.NamespacedName{Namespace: tenantNamespace.Name, Name: "same-namespace"}},
+		ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "addons", Name: "matching-namespace-selector"}},
+	))
+}
+
+func TestGetPendingClusterResourceSets(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "cluster",
+			Labels:    map[string]string{"cni": "calico"},
+		},
+	}
+	newClusterResourceSet := func(name string, priority int32, resources ...string) *addonsv1.ClusterResourceSet {
+		crs := &addonsv1.ClusterResourceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceDefault,
+				Name:      name,
+			},
+			Spec: addonsv1.ClusterResourceSetSpec{
+				ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"cni": "calico"}},
+				Priority:        priority,
+			},
+		}
+		for _, resource := range resources {
+			crs.Spec.Resources = append(crs.Spec.Resources, addonsv1.ResourceRef{Name: resource, Kind: "ConfigMap"})
+		}
+		return crs
+	}
+	binding := &addonsv1.ClusterResourceSetBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      cluster.Name,
+		},
+		Spec: addonsv1.ClusterResourceSetBindingSpec{
+			Bindings: []*addonsv1.ResourceSetBinding{
+				{
+					ClusterResourceSetName: "crds-applied",
+					Resources: []addonsv1.ResourceBinding{
+						{ResourceRef: addonsv1.ResourceRef{Name: "crds", Kind: "ConfigMap"}, Applied: true},
+					},
+				},
+				{
+					ClusterResourceSetName: "crds-failed",
+					Resources: []addonsv1.ResourceBinding{
+						{ResourceRef: addonsv1.ResourceRef{Name: "crds", Kind: "ConfigMap"}, Applied: false},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		clusterResourceSets []client.Object
+		want                []string
+	}{
+		{
+			name: "should not wait for ClusterResourceSets with the same or a lower priority",
+			clusterResourceSets: []client.Object{
+				newClusterResourceSet("same-priority", 0, "crds"),
+				newClusterResourceSet("lower-priority", -1, "crds"),
+			},
+		},
+		{
+			name: "should not wait for ClusterResourceSets with a higher priority which are applied",
+			clusterResourceSets: []client.Object{
+				newClusterResourceSet("crds-applied", 10, "crds"),
+			},
+		},
+		{
+			name: "should wait for ClusterResourceSets with a higher priority which are not applied",
+			clusterResourceSets: []client.Object{
+				newClusterResourceSet("crds-applied", 10, "crds"),
+				newClusterResourceSet("crds-failed", 10, "crds"),
+				newClusterResourceSet("crds-not-applied", 5, "crds"),
+			},
+			want: []string{"default/crds-failed", "default/crds-not-applied"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterResourceSet := newClusterResourceSet("cni", 0, "calico")
+			objs := append([]client.Object{cluster, binding, clusterResourceSet}, tt.clusterResourceSets...)
+			r := &ClusterResourceSetReconciler{
+				Client: fake.NewClientBuilder().WithObjects(objs...).Build(),
+			}
+
+			got, err := r.getPendingClusterResourceSets(ctx, cluster, clusterResourceSet)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(ConsistOf(tt.want))
+		})
+	}
+}
+
+func TestApplySortsObjects(t *testing.T) {
+	g := NewWithT(t)
+
+	data := []byte(`apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: widget
+  namespace: widgets
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: widgets
+`)
+
+	c := &createRecorder{Client: fake.NewClientBuilder().Build()}
+	g.Expect(apply(ctx, c, data)).To(Succeed())
+	g.Expect(c.created).To(Equal([]string{"Namespace", "CustomResourceDefinition", "Widget"}))
+}
+
+// createRecorder records the kinds of the objects created through the client.
+type createRecorder struct {
+	client.Client
+	created []string
+}
+
+func (c *createRecorder) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	c.created = append(c.created, obj.GetObjectKind().GroupVersionKind().Kind)
+	return nil
+}
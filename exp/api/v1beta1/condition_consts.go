@@ -27,4 +27,16 @@ const (
 	// WaitingForReplicasReadyReason (Severity=Info) documents a machinepool waiting for the required replicas
 	// to be ready.
 	WaitingForReplicasReadyReason = "WaitingForReplicasReady"
+
+	// ReplicasUpToDateCondition reports whether all the replicas controlled by the MachinePool are running the
+	// latest version of the template; it is set only if the infrastructure provider reports status.upToDateReplicas.
+	ReplicasUpToDateCondition clusterv1.ConditionType = "ReplicasUpToDate"
+
+	// RollingUpdateInProgressReason (Severity=Info) documents a machinepool whose replicas are being replaced
+	// by the infrastructure provider using a rolling update.
+	RollingUpdateInProgressReason = "RollingUpdateInProgress"
+
+	// WaitingForReplicasDeletionReason (Severity=Info) documents a machinepool with the OnDelete strategy whose
+	// replicas running an old version of the template are waiting to be deleted.
+	WaitingForReplicasDeletionReason = "WaitingForReplicasDeletion"
 )
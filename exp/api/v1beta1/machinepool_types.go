@@ -19,6 +19,7 @@ package v1beta1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 )
@@ -59,10 +60,76 @@ type MachinePoolSpec struct {
 	// FailureDomains is the list of failure domains this MachinePool should be attached to.
 	// +optional
 	FailureDomains []string `json:"failureDomains,omitempty"`
+
+	// Strategy describes how the infrastructure provider replaces the machine instances
+	// of the MachinePool when the template changes.
+	// +optional
+	Strategy *MachinePoolStrategy `json:"strategy,omitempty"`
 }
 
 // ANCHOR_END: MachinePoolSpec
 
+// MachinePoolStrategyType defines the type of MachinePool rollout strategies.
+type MachinePoolStrategyType string
+
+const (
+	// RollingUpdateMachinePoolStrategyType replaces the machine instances of the MachinePool
+	// using a rolling update, i.e. gradually replacing old instances with new ones, within the
+	// bounds of MaxUnavailable and MaxSurge.
+	RollingUpdateMachinePoolStrategyType MachinePoolStrategyType = "RollingUpdate"
+
+	// OnDeleteMachinePoolStrategyType replaces the machine instances of the MachinePool only
+	// when the old instances are deleted, e.g. by the user or by a MachineHealthCheck.
+	OnDeleteMachinePoolStrategyType MachinePoolStrategyType = "OnDelete"
+)
+
+// ANCHOR: MachinePoolStrategy
+
+// MachinePoolStrategy describes how to replace the machine instances of a MachinePool.
+type MachinePoolStrategy struct {
+	// Type of rollout.
+	// Default is RollingUpdate.
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+	// +optional
+	Type MachinePoolStrategyType `json:"type,omitempty"`
+
+	// Rolling update config params. Present only if
+	// MachinePoolStrategyType = RollingUpdate.
+	// +optional
+	RollingUpdate *MachinePoolRollingUpdate `json:"rollingUpdate,omitempty"`
+}
+
+// ANCHOR_END: MachinePoolStrategy
+
+// ANCHOR: MachinePoolRollingUpdate
+
+// MachinePoolRollingUpdate is used to control the desired behavior of rolling update.
+// The rollout is performed by the infrastructure provider, which is expected to honor
+// these bounds; the resolved absolute values can be computed with the
+// ResolveMachinePoolRollingUpdate func in the exp/util package.
+type MachinePoolRollingUpdate struct {
+	// The maximum number of machine instances that can be unavailable during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of desired
+	// machine instances (ex: 10%).
+	// Absolute number is calculated from percentage by rounding down.
+	// This can not be 0 if MaxSurge is 0.
+	// Defaults to 0, or to 1 if MaxSurge is 0.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// The maximum number of machine instances that can be created above the
+	// desired number of machine instances.
+	// Value can be an absolute number (ex: 5) or a percentage of
+	// desired machine instances (ex: 10%).
+	// This can not be 0 if MaxUnavailable is 0.
+	// Absolute number is calculated from percentage by rounding up.
+	// Defaults to 1.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+// ANCHOR_END: MachinePoolRollingUpdate
+
 // ANCHOR: MachinePoolStatus
 
 // MachinePoolStatus defines the observed state of MachinePool.
@@ -91,6 +158,11 @@ type MachinePoolStatus struct {
 	// +optional
 	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
 
+	// Total number of machine instances targeted by this machine pool that are running
+	// the latest version of the template, as reported by the infrastructure provider.
+	// +optional
+	UpToDateReplicas int32 `json:"upToDateReplicas,omitempty"`
+
 	// FailureReason indicates that there is a problem reconciling the state, and
 	// will be set to a token value suitable for programmatic interpretation.
 	// +optional
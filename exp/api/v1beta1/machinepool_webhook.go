@@ -18,9 +18,11 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -55,6 +57,32 @@ func (m *MachinePool) Default() {
 		m.Spec.MinReadySeconds = pointer.Int32Ptr(0)
 	}
 
+	if m.Spec.Strategy == nil {
+		m.Spec.Strategy = &MachinePoolStrategy{}
+	}
+
+	if m.Spec.Strategy.Type == "" {
+		m.Spec.Strategy.Type = RollingUpdateMachinePoolStrategyType
+	}
+
+	// Default RollingUpdate strategy only if strategy type is RollingUpdate.
+	if m.Spec.Strategy.Type == RollingUpdateMachinePoolStrategyType {
+		if m.Spec.Strategy.RollingUpdate == nil {
+			m.Spec.Strategy.RollingUpdate = &MachinePoolRollingUpdate{}
+		}
+		if m.Spec.Strategy.RollingUpdate.MaxSurge == nil {
+			ios1 := intstr.FromInt(1)
+			m.Spec.Strategy.RollingUpdate.MaxSurge = &ios1
+		}
+		if m.Spec.Strategy.RollingUpdate.MaxUnavailable == nil {
+			maxUnavailable := intstr.FromInt(0)
+			if isZeroIntOrPercent(m.Spec.Strategy.RollingUpdate.MaxSurge) {
+				maxUnavailable = intstr.FromInt(1)
+			}
+			m.Spec.Strategy.RollingUpdate.MaxUnavailable = &maxUnavailable
+		}
+	}
+
 	if m.Spec.Template.Spec.Bootstrap.ConfigRef != nil && len(m.Spec.Template.Spec.Bootstrap.ConfigRef.Namespace) == 0 {
 		m.Spec.Template.Spec.Bootstrap.ConfigRef.Namespace = m.Namespace
 	}
@@ -124,8 +152,59 @@ func (m *MachinePool) validate(old *MachinePool) error {
 		)
 	}
 
+	if m.Spec.Strategy != nil && m.Spec.Strategy.RollingUpdate != nil {
+		total := 1
+		if m.Spec.Replicas != nil {
+			total = int(*m.Spec.Replicas)
+		}
+
+		if m.Spec.Strategy.RollingUpdate.MaxSurge != nil {
+			if _, err := intstr.GetScaledValueFromIntOrPercent(m.Spec.Strategy.RollingUpdate.MaxSurge, total, true); err != nil {
+				allErrs = append(
+					allErrs,
+					field.Invalid(field.NewPath("spec", "strategy", "rollingUpdate", "maxSurge"),
+						m.Spec.Strategy.RollingUpdate.MaxSurge, fmt.Sprintf("must be either an int or a percentage: %v", err.Error())),
+				)
+			}
+		}
+
+		if m.Spec.Strategy.RollingUpdate.MaxUnavailable != nil {
+			if _, err := intstr.GetScaledValueFromIntOrPercent(m.Spec.Strategy.RollingUpdate.MaxUnavailable, total, true); err != nil {
+				allErrs = append(
+					allErrs,
+					field.Invalid(field.NewPath("spec", "strategy", "rollingUpdate", "maxUnavailable"),
+						m.Spec.Strategy.RollingUpdate.MaxUnavailable, fmt.Sprintf("must be either an int or a percentage: %v", err.Error())),
+				)
+			}
+		}
+
+		// Reject a rolling update which can't make progress, because no machine instances can be created
+		// above the desired number of replicas and no machine instances can be deleted.
+		if isZeroIntOrPercent(m.Spec.Strategy.RollingUpdate.MaxSurge) && isZeroIntOrPercent(m.Spec.Strategy.RollingUpdate.MaxUnavailable) {
+			allErrs = append(
+				allErrs,
+				field.Invalid(field.NewPath("spec", "strategy", "rollingUpdate", "maxUnavailable"),
+					m.Spec.Strategy.RollingUpdate.MaxUnavailable, "must not be 0 when maxSurge is 0"),
+			)
+		}
+	}
+
+	allErrs = append(allErrs, m.Spec.Template.Spec.NodeDrainOptions.Validate(field.NewPath("spec", "template", "spec", "nodeDrainOptions"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return apierrors.NewInvalid(GroupVersion.WithKind("MachinePool").GroupKind(), m.Name, allErrs)
 }
+
+// isZeroIntOrPercent returns true if the value is 0 or 0%.
+// NOTE: nil values are not considered zero, because they are going to be defaulted.
+func isZeroIntOrPercent(v *intstr.IntOrString) bool {
+	if v == nil {
+		return false
+	}
+	if v.Type == intstr.String {
+		return strings.TrimSuffix(v.StrVal, "%") == "0"
+	}
+	return v.IntVal == 0
+}
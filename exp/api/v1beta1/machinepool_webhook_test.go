@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	utildefaulting "sigs.k8s.io/cluster-api/util/defaulting"
@@ -50,6 +51,35 @@ func TestMachinePoolDefault(t *testing.T) {
 	g.Expect(m.Spec.MinReadySeconds).To(Equal(pointer.Int32Ptr(0)))
 	g.Expect(m.Spec.Template.Spec.Bootstrap.ConfigRef.Namespace).To(Equal(m.Namespace))
 	g.Expect(m.Spec.Template.Spec.InfrastructureRef.Namespace).To(Equal(m.Namespace))
+	g.Expect(m.Spec.Strategy.Type).To(Equal(RollingUpdateMachinePoolStrategyType))
+	g.Expect(m.Spec.Strategy.RollingUpdate.MaxSurge.IntValue()).To(Equal(1))
+	g.Expect(m.Spec.Strategy.RollingUpdate.MaxUnavailable.IntValue()).To(Equal(0))
+}
+
+func TestMachinePoolDefaultStrategy(t *testing.T) {
+	g := NewWithT(t)
+
+	zero := intstr.FromInt(0)
+	m := &MachinePool{
+		Spec: MachinePoolSpec{
+			Strategy: &MachinePoolStrategy{
+				RollingUpdate: &MachinePoolRollingUpdate{MaxSurge: &zero},
+			},
+		},
+	}
+	m.Default()
+
+	// Allow machine instances to be deleted before new ones are created when no surge is allowed.
+	g.Expect(m.Spec.Strategy.RollingUpdate.MaxUnavailable.IntValue()).To(Equal(1))
+
+	onDelete := &MachinePool{
+		Spec: MachinePoolSpec{
+			Strategy: &MachinePoolStrategy{Type: OnDeleteMachinePoolStrategyType},
+		},
+	}
+	onDelete.Default()
+
+	g.Expect(onDelete.Spec.Strategy.RollingUpdate).To(BeNil())
 }
 
 func TestMachinePoolBootstrapValidation(t *testing.T) {
@@ -218,3 +248,69 @@ func TestMachinePoolClusterNameImmutable(t *testing.T) {
 		})
 	}
 }
+
+func TestMachinePoolStrategyValidation(t *testing.T) {
+	intOrStr := func(s string) *intstr.IntOrString {
+		v := intstr.Parse(s)
+		return &v
+	}
+
+	tests := []struct {
+		name          string
+		rollingUpdate *MachinePoolRollingUpdate
+		expectErr     bool
+	}{
+		{
+			name:          "valid absolute values",
+			rollingUpdate: &MachinePoolRollingUpdate{MaxSurge: intOrStr("1"), MaxUnavailable: intOrStr("0")},
+			expectErr:     false,
+		},
+		{
+			name:          "valid percentages",
+			rollingUpdate: &MachinePoolRollingUpdate{MaxSurge: intOrStr("0%"), MaxUnavailable: intOrStr("25%")},
+			expectErr:     false,
+		},
+		{
+			name:          "invalid maxSurge",
+			rollingUpdate: &MachinePoolRollingUpdate{MaxSurge: intOrStr("foo")},
+			expectErr:     true,
+		},
+		{
+			name:          "invalid maxUnavailable",
+			rollingUpdate: &MachinePoolRollingUpdate{MaxUnavailable: intOrStr("bar")},
+			expectErr:     true,
+		},
+		{
+			name:          "both maxSurge and maxUnavailable are zero",
+			rollingUpdate: &MachinePoolRollingUpdate{MaxSurge: intOrStr("0"), MaxUnavailable: intOrStr("0%")},
+			expectErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &MachinePool{
+				Spec: MachinePoolSpec{
+					Replicas: pointer.Int32Ptr(4),
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							Bootstrap: clusterv1.Bootstrap{ConfigRef: &corev1.ObjectReference{}},
+						},
+					},
+					Strategy: &MachinePoolStrategy{
+						Type:          RollingUpdateMachinePoolStrategyType,
+						RollingUpdate: tt.rollingUpdate,
+					},
+				},
+			}
+
+			if tt.expectErr {
+				g.Expect(m.ValidateCreate()).NotTo(Succeed())
+			} else {
+				g.Expect(m.ValidateCreate()).To(Succeed())
+			}
+		})
+	}
+}
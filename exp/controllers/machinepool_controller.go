@@ -18,7 +18,6 @@ package controllers
 
 import (
 	"context"
-	"sync"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -62,9 +61,8 @@ type MachinePoolReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
-	controller       controller.Controller
-	recorder         record.EventRecorder
-	externalWatchers sync.Map
+	recorder        record.EventRecorder
+	externalTracker external.ObjectTracker
 }
 
 func (r *MachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -94,8 +92,11 @@ func (r *MachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.M
 		return errors.Wrap(err, "failed adding Watch for Cluster to controller manager")
 	}
 
-	r.controller = c
 	r.recorder = mgr.GetEventRecorderFor("machinepool-controller")
+	r.externalTracker = external.ObjectTracker{
+		Controller:   c,
+		MetadataOnly: true,
+	}
 	return nil
 }
 
@@ -156,6 +157,7 @@ func (r *MachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 					clusterv1.BootstrapReadyCondition,
 					clusterv1.InfrastructureReadyCondition,
 					expv1.ReplicasReadyCondition,
+					expv1.ReplicasUpToDateCondition,
 				}},
 			)
 		}
@@ -38,7 +38,6 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
-	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 var (
@@ -130,18 +129,9 @@ func (r *MachinePoolReconciler) reconcileExternal(ctx context.Context, cluster *
 		return external.ReconcileOutput{}, err
 	}
 
-	// Add watcher for external object, if there isn't one already.
-	_, loaded := r.externalWatchers.LoadOrStore(obj.GroupVersionKind().String(), struct{}{})
-	if !loaded && r.controller != nil {
-		log.Info("Adding watcher on external object", "gvk", obj.GroupVersionKind())
-		err := r.controller.Watch(
-			&source.Kind{Type: obj},
-			&handler.EnqueueRequestForOwner{OwnerType: &expv1.MachinePool{}},
-		)
-		if err != nil {
-			r.externalWatchers.Delete(obj.GroupVersionKind().String())
-			return external.ReconcileOutput{}, errors.Wrapf(err, "failed to add watcher on external object %q", obj.GroupVersionKind())
-		}
+	// Ensure we add a watcher to the external object.
+	if err := r.externalTracker.Watch(log, obj, &handler.EnqueueRequestForOwner{OwnerType: &expv1.MachinePool{}}); err != nil {
+		return external.ReconcileOutput{}, err
 	}
 
 	// Set failure reason and message, if any.
@@ -291,6 +281,11 @@ func (r *MachinePoolReconciler) reconcileInfrastructure(ctx context.Context, clu
 		return ctrl.Result{RequeueAfter: externalReadyWait}, nil
 	}
 
+	// Get and set Status.UpToDateReplicas from the infrastructure provider, if reported.
+	if err := reconcileUpToDateReplicas(mp, infraConfig); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve up-to-date replicas from infrastructure provider for MachinePool %q in namespace %q", mp.Name, mp.Namespace)
+	}
+
 	if !reflect.DeepEqual(mp.Spec.ProviderIDList, providerIDList) {
 		mp.Spec.ProviderIDList = providerIDList
 		mp.Status.ReadyReplicas = 0
@@ -300,3 +295,35 @@ func (r *MachinePoolReconciler) reconcileInfrastructure(ctx context.Context, clu
 
 	return ctrl.Result{}, nil
 }
+
+// reconcileUpToDateReplicas sets Status.UpToDateReplicas and the ReplicasUpToDate condition from the optional
+// status.upToDateReplicas field of the infrastructure object, which reports the progress of a rollout.
+// Infrastructure providers not reporting the field are not affected.
+func reconcileUpToDateReplicas(mp *expv1.MachinePool, infraConfig *unstructured.Unstructured) error {
+	var upToDateReplicas int32
+	if err := util.UnstructuredUnmarshalField(infraConfig, &upToDateReplicas, "status", "upToDateReplicas"); err != nil {
+		if err != util.ErrUnstructuredFieldNotFound {
+			return err
+		}
+		mp.Status.UpToDateReplicas = 0
+		conditions.Delete(mp, expv1.ReplicasUpToDateCondition)
+		return nil
+	}
+	mp.Status.UpToDateReplicas = upToDateReplicas
+
+	desiredReplicas := int32(1)
+	if mp.Spec.Replicas != nil {
+		desiredReplicas = *mp.Spec.Replicas
+	}
+	if upToDateReplicas >= desiredReplicas {
+		conditions.MarkTrue(mp, expv1.ReplicasUpToDateCondition)
+		return nil
+	}
+
+	reason := expv1.RollingUpdateInProgressReason
+	if mp.Spec.Strategy != nil && mp.Spec.Strategy.Type == expv1.OnDeleteMachinePoolStrategyType {
+		reason = expv1.WaitingForReplicasDeletionReason
+	}
+	conditions.MarkFalse(mp, expv1.ReplicasUpToDateCondition, reason, clusterv1.ConditionSeverityInfo, "%d of %d replicas are up to date", upToDateReplicas, desiredReplicas)
+	return nil
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -924,3 +925,70 @@ func TestReconcileMachinePoolInfrastructure(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileUpToDateReplicas(t *testing.T) {
+	testCases := []struct {
+		name            string
+		strategy        *expv1.MachinePoolStrategy
+		infraStatus     map[string]interface{}
+		expectUpToDate  int32
+		expectCondition *clusterv1.Condition
+	}{
+		{
+			name:           "infrastructure provider not reporting up-to-date replicas",
+			infraStatus:    map[string]interface{}{"ready": true},
+			expectUpToDate: 0,
+		},
+		{
+			name:            "all replicas up to date",
+			infraStatus:     map[string]interface{}{"upToDateReplicas": int64(3)},
+			expectUpToDate:  3,
+			expectCondition: conditions.TrueCondition(expv1.ReplicasUpToDateCondition),
+		},
+		{
+			name:            "rolling update in progress",
+			infraStatus:     map[string]interface{}{"upToDateReplicas": int64(1)},
+			expectUpToDate:  1,
+			expectCondition: conditions.FalseCondition(expv1.ReplicasUpToDateCondition, expv1.RollingUpdateInProgressReason, clusterv1.ConditionSeverityInfo, "1 of 3 replicas are up to date"),
+		},
+		{
+			name:            "replicas waiting for deletion with the OnDelete strategy",
+			strategy:        &expv1.MachinePoolStrategy{Type: expv1.OnDeleteMachinePoolStrategyType},
+			infraStatus:     map[string]interface{}{"upToDateReplicas": int64(2)},
+			expectUpToDate:  2,
+			expectCondition: conditions.FalseCondition(expv1.ReplicasUpToDateCondition, expv1.WaitingForReplicasDeletionReason, clusterv1.ConditionSeverityInfo, "2 of 3 replicas are up to date"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mp := &expv1.MachinePool{
+				Spec: expv1.MachinePoolSpec{
+					Replicas: pointer.Int32Ptr(3),
+					Strategy: tc.strategy,
+				},
+				Status: expv1.MachinePoolStatus{
+					// A stale value and condition must be cleaned up if the field is not reported.
+					UpToDateReplicas: 5,
+					Conditions:       clusterv1.Conditions{*conditions.TrueCondition(expv1.ReplicasUpToDateCondition)},
+				},
+			}
+			infraConfig := &unstructured.Unstructured{Object: map[string]interface{}{"status": tc.infraStatus}}
+
+			g.Expect(reconcileUpToDateReplicas(mp, infraConfig)).To(Succeed())
+			g.Expect(mp.Status.UpToDateReplicas).To(Equal(tc.expectUpToDate))
+			if tc.expectCondition == nil {
+				g.Expect(conditions.Has(mp, expv1.ReplicasUpToDateCondition)).To(BeFalse())
+				return
+			}
+			c := conditions.Get(mp, expv1.ReplicasUpToDateCondition)
+			g.Expect(c).ToNot(BeNil())
+			g.Expect(c.Status).To(Equal(tc.expectCondition.Status))
+			g.Expect(c.Reason).To(Equal(tc.expectCondition.Reason))
+			g.Expect(c.Severity).To(Equal(tc.expectCondition.Severity))
+			g.Expect(c.Message).To(Equal(tc.expectCondition.Message))
+		})
+	}
+}
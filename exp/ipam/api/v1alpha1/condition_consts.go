@@ -0,0 +1,32 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+// Conditions and condition Reasons for the IPAddressClaim object.
+
+const (
+	// AddressAllocatedCondition documents that an IPAddress has been allocated for the IPAddressClaim by the IPAM provider.
+	AddressAllocatedCondition clusterv1.ConditionType = "AddressAllocated"
+
+	// PoolNotFoundReason (Severity=Error) documents that the pool referenced by the IPAddressClaim does not exist.
+	PoolNotFoundReason = "PoolNotFound"
+
+	// PoolExhaustedReason (Severity=Warning) documents that the pool referenced by the IPAddressClaim has no free addresses.
+	PoolExhaustedReason = "PoolExhausted"
+)
@@ -14,5 +14,5 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package builder implements builder and CRDs for creating API objects for testing.
-package builder
+// Package controllers implements experimental IPAM controllers.
+package controllers
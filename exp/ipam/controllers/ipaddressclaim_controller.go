@@ -0,0 +1,174 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch
+
+// IPAddressClaimReconciler tracks IPAddressClaims with the lifecycle of the Machines the addresses are claimed for.
+type IPAddressClaimReconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+func (r *IPAddressClaimReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&ipamv1.IPAddressClaim{}).
+		Watches(
+			&source.Kind{Type: &clusterv1.Machine{}},
+			handler.EnqueueRequestsFromMapFunc(r.machineToIPAddressClaims),
+		).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Complete(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	return nil
+}
+
+func (r *IPAddressClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Fetch the IPAddressClaim instance.
+	claim := &ipamv1.IPAddressClaim{}
+	if err := r.Client.Get(ctx, req.NamespacedName, claim); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// The address is already being released by the IPAM provider.
+	if !claim.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// If the claim is already tracked, release the address once the Machine no longer exists.
+	if machineName, ok := claim.Labels[ipamv1.MachineNameLabel]; ok {
+		if _, err := util.GetMachineByName(ctx, r.Client, claim.Namespace, machineName); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+			log.Info("Deleting IPAddressClaim because the Machine it was claimed for no longer exists", "machine", machineName)
+			if err := r.Client.Delete(ctx, claim); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, errors.Wrapf(err, "failed to delete IPAddressClaim %s", klog.KObj(claim))
+			}
+		}
+		return ctrl.Result{}, nil
+	}
+
+	machine, err := r.getMachineForClaim(ctx, claim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// Claims not related to a Machine, e.g. claims for the control plane endpoint, are not tracked.
+	if machine == nil {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(claim, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if claim.Labels == nil {
+		claim.Labels = map[string]string{}
+	}
+	claim.Labels[ipamv1.MachineNameLabel] = machine.Name
+	claim.Labels[clusterv1.ClusterLabelName] = machine.Spec.ClusterName
+	if err := patchHelper.Patch(ctx, claim); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to patch IPAddressClaim %s", klog.KObj(claim))
+	}
+	return ctrl.Result{}, nil
+}
+
+// getMachineForClaim returns the Machine an IPAddressClaim is claimed for, i.e. the Machine owning the claim, or
+// owning the object owning the claim, e.g. the InfrastructureMachine; nil is returned if there is no such Machine.
+func (r *IPAddressClaimReconciler) getMachineForClaim(ctx context.Context, claim *ipamv1.IPAddressClaim) (*clusterv1.Machine, error) {
+	machine, err := util.GetOwnerMachine(ctx, r.Client, claim.ObjectMeta)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if machine != nil {
+		return machine, nil
+	}
+
+	for _, ref := range claim.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		if gv.Group == clusterv1.GroupVersion.Group {
+			continue
+		}
+
+		owner, err := external.Get(ctx, r.Client, &corev1.ObjectReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name}, claim.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		machine, err := util.GetOwnerMachine(ctx, r.Client, metav1.ObjectMeta{Namespace: owner.GetNamespace(), OwnerReferences: owner.GetOwnerReferences()})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if machine != nil {
+			return machine, nil
+		}
+	}
+	return nil, nil
+}
+
+// machineToIPAddressClaims maps a Machine to the IPAddressClaims tracked for it.
+func (r *IPAddressClaimReconciler) machineToIPAddressClaims(o client.Object) []ctrl.Request {
+	claims := &ipamv1.IPAddressClaimList{}
+	if err := r.Client.List(context.TODO(), claims, client.InNamespace(o.GetNamespace()), client.MatchingLabels{ipamv1.MachineNameLabel: o.GetName()}); err != nil {
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(claims.Items))
+	for _, claim := range claims.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: claim.Namespace, Name: claim.Name}})
+	}
+	return requests
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestIPAddressClaimReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = ipamv1.AddToScheme(scheme)
+
+	machine := &clusterv1.Machine{
+		TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine"},
+		ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: metav1.NamespaceDefault},
+		Spec:       clusterv1.MachineSpec{ClusterName: "cluster"},
+	}
+	machineOwnerRef := metav1.OwnerReference{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: machine.Name}
+	infraMachine := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       "GenericInfrastructureMachine",
+			"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+			"metadata": map[string]interface{}{
+				"name":      "infra-machine",
+				"namespace": metav1.NamespaceDefault,
+			},
+		},
+	}
+	infraMachine.SetOwnerReferences([]metav1.OwnerReference{machineOwnerRef})
+	infraMachineOwnerRef := metav1.OwnerReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "GenericInfrastructureMachine", Name: infraMachine.GetName()}
+
+	newClaim := func(labels map[string]string, ownerRefs ...metav1.OwnerReference) *ipamv1.IPAddressClaim {
+		return &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "claim",
+				Namespace:       metav1.NamespaceDefault,
+				Labels:          labels,
+				OwnerReferences: ownerRefs,
+			},
+			Spec: ipamv1.IPAddressClaimSpec{
+				PoolRef: corev1.TypedLocalObjectReference{Kind: "InClusterIPPool", Name: "pool"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		claim       *ipamv1.IPAddressClaim
+		objs        []client.Object
+		wantLabels  map[string]string
+		wantDeleted bool
+	}{
+		{
+			name:       "tracks a claim owned by a Machine",
+			claim:      newClaim(nil, machineOwnerRef),
+			objs:       []client.Object{machine.DeepCopy()},
+			wantLabels: map[string]string{ipamv1.MachineNameLabel: machine.Name, clusterv1.ClusterLabelName: "cluster"},
+		},
+		{
+			name:       "tracks a claim owned by an InfrastructureMachine",
+			claim:      newClaim(nil, infraMachineOwnerRef),
+			objs:       []client.Object{machine.DeepCopy(), infraMachine.DeepCopy()},
+			wantLabels: map[string]string{ipamv1.MachineNameLabel: machine.Name, clusterv1.ClusterLabelName: "cluster"},
+		},
+		{
+			name:  "does not track a claim not related to a Machine",
+			claim: newClaim(nil),
+			objs:  []client.Object{machine.DeepCopy()},
+		},
+		{
+			name:       "does not delete a claim tracked for an existing Machine",
+			claim:      newClaim(map[string]string{ipamv1.MachineNameLabel: machine.Name}),
+			objs:       []client.Object{machine.DeepCopy()},
+			wantLabels: map[string]string{ipamv1.MachineNameLabel: machine.Name},
+		},
+		{
+			name:        "deletes a claim tracked for a Machine which no longer exists",
+			claim:       newClaim(map[string]string{ipamv1.MachineNameLabel: machine.Name}),
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(tt.objs, tt.claim)...).Build()
+			r := &IPAddressClaimReconciler{Client: c}
+
+			_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tt.claim)})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			claim := &ipamv1.IPAddressClaim{}
+			err = c.Get(context.Background(), client.ObjectKeyFromObject(tt.claim), claim)
+			if tt.wantDeleted {
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.wantLabels == nil {
+				g.Expect(claim.Labels).To(BeEmpty())
+				return
+			}
+			g.Expect(claim.Labels).To(Equal(tt.wantLabels))
+		})
+	}
+}
+
+func TestMachineToIPAddressClaims(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = ipamv1.AddToScheme(scheme)
+
+	tracked := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tracked",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{ipamv1.MachineNameLabel: "machine"},
+		},
+	}
+	other := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{ipamv1.MachineNameLabel: "other-machine"},
+		},
+	}
+	r := &IPAddressClaimReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tracked, other).Build()}
+
+	machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine", Namespace: metav1.NamespaceDefault}}
+	g.Expect(r.machineToIPAddressClaims(machine)).To(ConsistOf(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tracked)}))
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics implements a Prometheus collector exposing kube-state-metrics style metrics for Cluster API objects.
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// collectTimeout is the timeout for listing the objects at every scrape.
+const collectTimeout = 10 * time.Second
+
+// ObjectMetrics defines the metrics exposed for each object of a kind.
+type ObjectMetrics struct {
+	// NewList returns an empty list of the objects.
+	NewList func() client.ObjectList
+
+	// Descs are the descriptors of all the metrics of an object.
+	Descs []*prometheus.Desc
+
+	// Collect sends the metrics of an object to the channel.
+	Collect func(obj client.Object, ch chan<- prometheus.Metric)
+}
+
+// Collector is a prometheus.Collector exposing the state metrics of Cluster API objects; metrics are computed
+// at every scrape from the objects read by the client, which should be backed by the manager cache.
+type Collector struct {
+	client  client.Reader
+	objects []ObjectMetrics
+}
+
+// NewCollector returns a Collector exposing the given metrics.
+func NewCollector(c client.Reader, objects ...ObjectMetrics) *Collector {
+	return &Collector{client: c, objects: objects}
+}
+
+var _ prometheus.Collector = &Collector{}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, o := range c.objects {
+		for _, desc := range o.Descs {
+			ch <- desc
+		}
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	log := ctrl.Log.WithName("state-metrics")
+	for _, o := range c.objects {
+		list := o.NewList()
+		if err := c.client.List(ctx, list); err != nil {
+			log.Error(err, "Failed to list objects to collect state metrics")
+			continue
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			log.Error(err, "Failed to extract objects to collect state metrics")
+			continue
+		}
+		for _, item := range items {
+			if obj, ok := item.(client.Object); ok {
+				o.Collect(obj, ch)
+			}
+		}
+	}
+}
+
+// NewDesc returns the descriptor of a metric with the namespace and name labels followed by the given labels.
+func NewDesc(name, help string, labels ...string) *prometheus.Desc {
+	return prometheus.NewDesc(name, help, append([]string{"namespace", "name"}, labels...), nil)
+}
+
+// Gauge sends a gauge with the given value and label values to the channel.
+func Gauge(ch chan<- prometheus.Metric, desc *prometheus.Desc, value float64, labelValues ...string) {
+	ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labelValues...)
+}
+
+// Phase sends a gauge for each of the phases to the channel, with value 1 for the current phase
+// and 0 for the others; the phase is appended to the label values.
+func Phase(ch chan<- prometheus.Metric, desc *prometheus.Desc, phases []string, phase string, labelValues ...string) {
+	for _, p := range phases {
+		Gauge(ch, desc, Bool(p == phase), append(labelValues, p)...)
+	}
+}
+
+// Conditions sends three gauges for each of the conditions to the channel, one for each condition status,
+// with value 1 for the current status and 0 for the others; the condition type and the status,
+// in lowercase, are appended to the label values.
+func Conditions(ch chan<- prometheus.Metric, desc *prometheus.Desc, conditions clusterv1.Conditions, labelValues ...string) {
+	for _, c := range conditions {
+		for _, status := range []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown} {
+			Gauge(ch, desc, Bool(c.Status == status), append(labelValues, string(c.Type), strings.ToLower(string(status)))...)
+		}
+	}
+}
+
+// Int32 returns the value of an optional integer, or 0 if not set.
+func Int32(i *int32) float64 {
+	if i == nil {
+		return 0
+	}
+	return float64(*i)
+}
+
+// Bool returns 1 if the value is true, 0 otherwise.
+func Bool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
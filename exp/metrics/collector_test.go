@@ -0,0 +1,85 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCollector(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+		Status: clusterv1.ClusterStatus{
+			Phase:      string(clusterv1.ClusterPhaseProvisioned),
+			Conditions: clusterv1.Conditions{{Type: clusterv1.ReadyCondition, Status: corev1.ConditionTrue}},
+		},
+	}
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "md"},
+		Spec:       clusterv1.MachineDeploymentSpec{ClusterName: "foo", Replicas: pointer.Int32(3)},
+		Status:     clusterv1.MachineDeploymentStatus{Replicas: 3, ReadyReplicas: 2, UpdatedReplicas: 3, UnavailableReplicas: 1},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, md).Build()
+	collector := NewCollector(c, ClusterMetrics(), MachineDeploymentMetrics())
+
+	g.Expect(testutil.CollectAndCompare(collector, strings.NewReader(`
+# HELP capi_cluster_status_phase The phase of the Cluster.
+# TYPE capi_cluster_status_phase gauge
+capi_cluster_status_phase{name="foo",namespace="default",phase="Deleting"} 0
+capi_cluster_status_phase{name="foo",namespace="default",phase="Failed"} 0
+capi_cluster_status_phase{name="foo",namespace="default",phase="Pending"} 0
+capi_cluster_status_phase{name="foo",namespace="default",phase="Provisioned"} 1
+capi_cluster_status_phase{name="foo",namespace="default",phase="Provisioning"} 0
+capi_cluster_status_phase{name="foo",namespace="default",phase="Unknown"} 0
+# HELP capi_cluster_status_condition The status of the conditions of the Cluster.
+# TYPE capi_cluster_status_condition gauge
+capi_cluster_status_condition{name="foo",namespace="default",status="false",type="Ready"} 0
+capi_cluster_status_condition{name="foo",namespace="default",status="true",type="Ready"} 1
+capi_cluster_status_condition{name="foo",namespace="default",status="unknown",type="Ready"} 0
+# HELP capi_machinedeployment_spec_replicas The desired number of Machines of the MachineDeployment.
+# TYPE capi_machinedeployment_spec_replicas gauge
+capi_machinedeployment_spec_replicas{cluster_name="foo",name="md",namespace="default"} 3
+# HELP capi_machinedeployment_replicas_ready The number of ready Machines of the MachineDeployment.
+# TYPE capi_machinedeployment_replicas_ready gauge
+capi_machinedeployment_replicas_ready{cluster_name="foo",name="md",namespace="default"} 2
+# HELP capi_machinedeployment_replicas_unavailable The number of unavailable Machines of the MachineDeployment.
+# TYPE capi_machinedeployment_replicas_unavailable gauge
+capi_machinedeployment_replicas_unavailable{cluster_name="foo",name="md",namespace="default"} 1
+`),
+		"capi_cluster_status_phase",
+		"capi_cluster_status_condition",
+		"capi_machinedeployment_spec_replicas",
+		"capi_machinedeployment_replicas_ready",
+		"capi_machinedeployment_replicas_unavailable",
+	)).To(Succeed())
+}
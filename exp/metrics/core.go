@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	clusterPhases = []string{
+		string(clusterv1.ClusterPhasePending),
+		string(clusterv1.ClusterPhaseProvisioning),
+		string(clusterv1.ClusterPhaseProvisioned),
+		string(clusterv1.ClusterPhaseDeleting),
+		string(clusterv1.ClusterPhaseFailed),
+		string(clusterv1.ClusterPhaseUnknown),
+	}
+
+	machinePhases = []string{
+		string(clusterv1.MachinePhasePending),
+		string(clusterv1.MachinePhaseProvisioning),
+		string(clusterv1.MachinePhaseProvisioned),
+		string(clusterv1.MachinePhaseRunning),
+		string(clusterv1.MachinePhaseDeleting),
+		string(clusterv1.MachinePhaseDeleted),
+		string(clusterv1.MachinePhaseFailed),
+		string(clusterv1.MachinePhaseUnknown),
+	}
+
+	machineDeploymentPhases = []string{
+		string(clusterv1.MachineDeploymentPhaseScalingUp),
+		string(clusterv1.MachineDeploymentPhaseScalingDown),
+		string(clusterv1.MachineDeploymentPhaseRunning),
+		string(clusterv1.MachineDeploymentPhaseFailed),
+		string(clusterv1.MachineDeploymentPhaseUnknown),
+	}
+)
+
+// ClusterMetrics returns the state metrics of Clusters.
+func ClusterMetrics() ObjectMetrics {
+	phase := NewDesc("capi_cluster_status_phase", "The phase of the Cluster.", "phase")
+	condition := NewDesc("capi_cluster_status_condition", "The status of the conditions of the Cluster.", "type", "status")
+	paused := NewDesc("capi_cluster_spec_paused", "Whether the Cluster is paused (1) or not (0).")
+	return ObjectMetrics{
+		NewList: func() client.ObjectList { return &clusterv1.ClusterList{} },
+		Descs:   []*prometheus.Desc{phase, condition, paused},
+		Collect: func(obj client.Object, ch chan<- prometheus.Metric) {
+			cluster := obj.(*clusterv1.Cluster)
+			Phase(ch, phase, clusterPhases, cluster.Status.Phase, cluster.Namespace, cluster.Name)
+			Conditions(ch, condition, cluster.Status.Conditions, cluster.Namespace, cluster.Name)
+			Gauge(ch, paused, Bool(cluster.Spec.Paused), cluster.Namespace, cluster.Name)
+		},
+	}
+}
+
+// MachineMetrics returns the state metrics of Machines.
+func MachineMetrics() ObjectMetrics {
+	phase := NewDesc("capi_machine_status_phase", "The phase of the Machine.", "cluster_name", "phase")
+	condition := NewDesc("capi_machine_status_condition", "The status of the conditions of the Machine.", "cluster_name", "type", "status")
+	return ObjectMetrics{
+		NewList: func() client.ObjectList { return &clusterv1.MachineList{} },
+		Descs:   []*prometheus.Desc{phase, condition},
+		Collect: func(obj client.Object, ch chan<- prometheus.Metric) {
+			machine := obj.(*clusterv1.Machine)
+			Phase(ch, phase, machinePhases, machine.Status.Phase, machine.Namespace, machine.Name, machine.Spec.ClusterName)
+			Conditions(ch, condition, machine.Status.Conditions, machine.Namespace, machine.Name, machine.Spec.ClusterName)
+		},
+	}
+}
+
+// MachineSetMetrics returns the state metrics of MachineSets.
+func MachineSetMetrics() ObjectMetrics {
+	replicas := NewDesc("capi_machineset_spec_replicas", "The desired number of Machines of the MachineSet.", "cluster_name")
+	replicasCurrent := NewDesc("capi_machineset_replicas", "The number of Machines of the MachineSet.", "cluster_name")
+	replicasReady := NewDesc("capi_machineset_replicas_ready", "The number of ready Machines of the MachineSet.", "cluster_name")
+	replicasAvailable := NewDesc("capi_machineset_replicas_available", "The number of available Machines of the MachineSet.", "cluster_name")
+	condition := NewDesc("capi_machineset_status_condition", "The status of the conditions of the MachineSet.", "cluster_name", "type", "status")
+	return ObjectMetrics{
+		NewList: func() client.ObjectList { return &clusterv1.MachineSetList{} },
+		Descs:   []*prometheus.Desc{replicas, replicasCurrent, replicasReady, replicasAvailable, condition},
+		Collect: func(obj client.Object, ch chan<- prometheus.Metric) {
+			ms := obj.(*clusterv1.MachineSet)
+			Gauge(ch, replicas, Int32(ms.Spec.Replicas), ms.Namespace, ms.Name, ms.Spec.ClusterName)
+			Gauge(ch, replicasCurrent, float64(ms.Status.Replicas), ms.Namespace, ms.Name, ms.Spec.ClusterName)
+			Gauge(ch, replicasReady, float64(ms.Status.ReadyReplicas), ms.Namespace, ms.Name, ms.Spec.ClusterName)
+			Gauge(ch, replicasAvailable, float64(ms.Status.AvailableReplicas), ms.Namespace, ms.Name, ms.Spec.ClusterName)
+			Conditions(ch, condition, ms.Status.Conditions, ms.Namespace, ms.Name, ms.Spec.ClusterName)
+		},
+	}
+}
+
+// MachineDeploymentMetrics returns the state metrics of MachineDeployments.
+func MachineDeploymentMetrics() ObjectMetrics {
+	phase := NewDesc("capi_machinedeployment_status_phase", "The phase of the MachineDeployment.", "cluster_name", "phase")
+	replicas := NewDesc("capi_machinedeployment_spec_replicas", "The desired number of Machines of the MachineDeployment.", "cluster_name")
+	replicasCurrent := NewDesc("capi_machinedeployment_replicas", "The number of Machines of the MachineDeployment.", "cluster_name")
+	replicasReady := NewDesc("capi_machinedeployment_replicas_ready", "The number of ready Machines of the MachineDeployment.", "cluster_name")
+	replicasUpdated := NewDesc("capi_machinedeployment_replicas_updated", "The number of Machines of the MachineDeployment with the desired template.", "cluster_name")
+	replicasUnavailable := NewDesc("capi_machinedeployment_replicas_unavailable", "The number of unavailable Machines of the MachineDeployment.", "cluster_name")
+	condition := NewDesc("capi_machinedeployment_status_condition", "The status of the conditions of the MachineDeployment.", "cluster_name", "type", "status")
+	return ObjectMetrics{
+		NewList: func() client.ObjectList { return &clusterv1.MachineDeploymentList{} },
+		Descs:   []*prometheus.Desc{phase, replicas, replicasCurrent, replicasReady, replicasUpdated, replicasUnavailable, condition},
+		Collect: func(obj client.Object, ch chan<- prometheus.Metric) {
+			md := obj.(*clusterv1.MachineDeployment)
+			Phase(ch, phase, machineDeploymentPhases, md.Status.Phase, md.Namespace, md.Name, md.Spec.ClusterName)
+			Gauge(ch, replicas, Int32(md.Spec.Replicas), md.Namespace, md.Name, md.Spec.ClusterName)
+			Gauge(ch, replicasCurrent, float64(md.Status.Replicas), md.Namespace, md.Name, md.Spec.ClusterName)
+			Gauge(ch, replicasReady, float64(md.Status.ReadyReplicas), md.Namespace, md.Name, md.Spec.ClusterName)
+			Gauge(ch, replicasUpdated, float64(md.Status.UpdatedReplicas), md.Namespace, md.Name, md.Spec.ClusterName)
+			Gauge(ch, replicasUnavailable, float64(md.Status.UnavailableReplicas), md.Namespace, md.Name, md.Spec.ClusterName)
+			Conditions(ch, condition, md.Status.Conditions, md.Namespace, md.Name, md.Spec.ClusterName)
+		},
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+// Conditions and condition Reasons for the provider objects.
+
+const (
+	// ProviderInstalledCondition documents that the components of the provider version defined in the spec
+	// are installed in the management cluster.
+	ProviderInstalledCondition clusterv1.ConditionType = "ProviderInstalled"
+
+	// ConfigSecretNotFoundReason (Severity=Warning) documents the Secret holding the provider variables cannot be read.
+	ConfigSecretNotFoundReason = "ConfigSecretNotFound"
+
+	// ComponentsFetchFailedReason (Severity=Warning) documents the provider components cannot be read
+	// from the provider repository.
+	ComponentsFetchFailedReason = "ComponentsFetchFailed"
+
+	// ComponentsApplyFailedReason (Severity=Warning) documents at least one of the provider components
+	// cannot be applied to the management cluster.
+	ComponentsApplyFailedReason = "ComponentsApplyFailed"
+)
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=infrastructureproviders,scope=Namespaced,categories=cluster-api
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version",description="Version of the provider"
+// +kubebuilder:printcolumn:name="InstalledVersion",type="string",JSONPath=".status.installedVersion",description="Version of the provider installed in the management cluster"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Provider installed"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of InfrastructureProvider"
+
+// InfrastructureProvider is the Schema for the infrastructureproviders API.
+// It installs an infrastructure provider in its namespace, the name of the object being the provider name, e.g. aws.
+type InfrastructureProvider struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ProviderSpec   `json:"spec,omitempty"`
+	Status ProviderStatus `json:"status,omitempty"`
+}
+
+// GetProviderType returns the clusterctl type of the provider.
+func (p *InfrastructureProvider) GetProviderType() clusterctlv1.ProviderType {
+	return clusterctlv1.InfrastructureProviderType
+}
+
+// GetSpec returns the spec of the provider.
+func (p *InfrastructureProvider) GetSpec() ProviderSpec {
+	return p.Spec
+}
+
+// GetStatus returns the status of the provider.
+func (p *InfrastructureProvider) GetStatus() ProviderStatus {
+	return p.Status
+}
+
+// SetStatus sets the status of the provider.
+func (p *InfrastructureProvider) SetStatus(status ProviderStatus) {
+	p.Status = status
+}
+
+// GetConditions returns the set of conditions for this object.
+func (p *InfrastructureProvider) GetConditions() clusterv1.Conditions {
+	return p.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (p *InfrastructureProvider) SetConditions(conditions clusterv1.Conditions) {
+	p.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// InfrastructureProviderList contains a list of InfrastructureProvider.
+type InfrastructureProviderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InfrastructureProvider `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&InfrastructureProvider{}, &InfrastructureProviderList{})
+}
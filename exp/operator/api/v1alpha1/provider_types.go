@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ProviderFinalizer is the finalizer used by the provider controllers to delete the provider components
+	// before removing the provider objects.
+	ProviderFinalizer = "provider.operator.cluster.x-k8s.io"
+)
+
+// ProviderSpec defines the desired state of a provider.
+type ProviderSpec struct {
+	// Version of the provider to be installed, e.g. v1.0.0.
+	// Changing the version upgrades the provider components.
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// FetchConfig defines where the provider components are read from.
+	// If not set, the components are read from the repository clusterctl uses by default for
+	// the provider with the same name as the provider object.
+	// +optional
+	FetchConfig *FetchConfiguration `json:"fetchConfig,omitempty"`
+
+	// ConfigSecret is the reference to a Secret in the same namespace of the provider object,
+	// holding the variables to be used when processing the provider components, e.g. the credentials
+	// used by an infrastructure provider.
+	// +optional
+	ConfigSecret *corev1.LocalObjectReference `json:"configSecret,omitempty"`
+}
+
+// FetchConfiguration defines where the provider components are read from.
+type FetchConfiguration struct {
+	// URL of the provider components in a provider repository, using the same format of the
+	// providers URLs in the clusterctl configuration file, e.g.
+	// https://github.com/kubernetes-sigs/cluster-api-provider-aws/releases/latest/infrastructure-components.yaml.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+}
+
+// ProviderStatus defines the observed state of a provider.
+type ProviderStatus struct {
+	// InstalledVersion is the version of the provider components installed in the management cluster.
+	// +optional
+	InstalledVersion *string `json:"installedVersion,omitempty"`
+
+	// Contract is the Cluster API contract implemented by the installed version of the provider, e.g. v1beta1.
+	// +optional
+	Contract *string `json:"contract,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines current service state of the provider.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// GenericProvider is implemented by all the provider objects, so they can be reconciled by the same controller.
+// +kubebuilder:object:generate=false
+type GenericProvider interface {
+	client.Object
+
+	// GetProviderType returns the clusterctl type of the provider.
+	GetProviderType() clusterctlv1.ProviderType
+
+	// GetSpec returns the spec of the provider.
+	GetSpec() ProviderSpec
+
+	// GetStatus returns the status of the provider.
+	GetStatus() ProviderStatus
+
+	// SetStatus sets the status of the provider.
+	SetStatus(status ProviderStatus)
+
+	// GetConditions returns the set of conditions for the provider.
+	GetConditions() clusterv1.Conditions
+
+	// SetConditions sets the conditions on the provider.
+	SetConditions(conditions clusterv1.Conditions)
+}
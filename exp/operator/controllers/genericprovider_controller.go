@@ -0,0 +1,335 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	operatorv1 "sigs.k8s.io/cluster-api/exp/operator/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	utilresource "sigs.k8s.io/cluster-api/util/resource"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// NOTE: the provider components can contain any kind of object, including RBAC rules, so installing them requires
+// full access to the management cluster; the corresponding ClusterRole is not part of the manager role and must be
+// deployed explicitly, see config/rbac/provider-operator.
+// +kubebuilder:rbac:groups=operator.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=clusterctl.cluster.x-k8s.io,resources=providers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+
+// GenericProviderReconciler reconciles a provider object, installing or upgrading the provider components
+// in the management cluster.
+type GenericProviderReconciler struct {
+	Client client.Client
+
+	// Provider is an empty object of the kind reconciled by this reconciler, e.g. &operatorv1.CoreProvider{}.
+	Provider operatorv1.GenericProvider
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	// repositoryClientFactory returns the client used to read the provider components.
+	// It is defined as a field so it can be overridden in unit tests.
+	repositoryClientFactory func(provider config.Provider, configClient config.Client, options ...repository.Option) (repository.Client, error)
+}
+
+func (r *GenericProviderReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.repositoryClientFactory == nil {
+		r.repositoryClientFactory = repository.New
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(r.Provider).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Complete(r)
+
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	return nil
+}
+
+func (r *GenericProviderReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	// Fetch the provider instance.
+	provider := r.Provider.DeepCopyObject().(operatorv1.GenericProvider)
+	if err := r.Client.Get(ctx, req.NamespacedName, provider); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Initialize the patch helper.
+	patchHelper, err := patch.NewHelper(provider, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the provider object and status after each reconciliation.
+		conditions.SetSummary(provider, conditions.WithConditions(operatorv1.ProviderInstalledCondition))
+		patchOpts := []patch.Option{
+			patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+				clusterv1.ReadyCondition,
+				operatorv1.ProviderInstalledCondition,
+			}},
+			patch.WithStatusObservedGeneration{},
+		}
+		if err := patchHelper.Patch(ctx, provider, patchOpts...); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	// Add finalizer first if not exist to avoid the race condition between init and delete
+	if !controllerutil.ContainsFinalizer(provider, operatorv1.ProviderFinalizer) {
+		controllerutil.AddFinalizer(provider, operatorv1.ProviderFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	// Handle deletion reconciliation loop.
+	if !provider.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, r.reconcileDelete(ctx, provider)
+	}
+
+	return ctrl.Result{}, r.reconcileNormal(ctx, provider)
+}
+
+// reconcileNormal installs the version of the provider components defined in the spec,
+// or upgrades the installed components to it.
+func (r *GenericProviderReconciler) reconcileNormal(ctx context.Context, provider operatorv1.GenericProvider) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	spec := provider.GetSpec()
+	status := provider.GetStatus()
+
+	// Skip reading the components again if the version defined in the spec has already been installed.
+	if status.InstalledVersion != nil && *status.InstalledVersion == spec.Version &&
+		status.ObservedGeneration == provider.GetGeneration() && conditions.IsTrue(provider, operatorv1.ProviderInstalledCondition) {
+		return nil
+	}
+
+	repo, err := r.getRepositoryClient(ctx, provider)
+	if err != nil {
+		return err
+	}
+
+	components, err := repo.Components().Get(repository.ComponentsOptions{
+		Version:         spec.Version,
+		TargetNamespace: provider.GetNamespace(),
+	})
+	if err != nil {
+		conditions.MarkFalse(provider, operatorv1.ProviderInstalledCondition, operatorv1.ComponentsFetchFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return errors.Wrapf(err, "failed to read the components of the provider %s version %s", provider.GetName(), spec.Version)
+	}
+
+	contract, err := getContract(repo, spec.Version)
+	if err != nil {
+		conditions.MarkFalse(provider, operatorv1.ProviderInstalledCondition, operatorv1.ComponentsFetchFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	log.Info("Installing provider components", "provider", provider.GetName(), "version", spec.Version)
+	for _, obj := range utilresource.SortForCreate(components.Objs()) {
+		if err := r.applyObj(ctx, obj); err != nil {
+			conditions.MarkFalse(provider, operatorv1.ProviderInstalledCondition, operatorv1.ComponentsApplyFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return err
+		}
+	}
+
+	// Update the clusterctl inventory, so clusterctl is aware of the installed provider, e.g. when moving
+	// the Cluster API objects or when checking the upgrade plan.
+	if err := r.applyInventoryObj(ctx, components.InventoryObject()); err != nil {
+		conditions.MarkFalse(provider, operatorv1.ProviderInstalledCondition, operatorv1.ComponentsApplyFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return err
+	}
+
+	status.InstalledVersion = &spec.Version
+	status.Contract = &contract
+	provider.SetStatus(status)
+	conditions.MarkTrue(provider, operatorv1.ProviderInstalledCondition)
+	return nil
+}
+
+// reconcileDelete deletes the installed provider components, except CRDs and namespaces, so the objects
+// created by the users and the namespaces hosting them are preserved.
+func (r *GenericProviderReconciler) reconcileDelete(ctx context.Context, provider operatorv1.GenericProvider) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	status := provider.GetStatus()
+	if status.InstalledVersion != nil {
+		repo, err := r.getRepositoryClient(ctx, provider)
+		if err != nil {
+			return err
+		}
+
+		components, err := repo.Components().Get(repository.ComponentsOptions{
+			Version:         *status.InstalledVersion,
+			TargetNamespace: provider.GetNamespace(),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to read the components of the provider %s version %s", provider.GetName(), *status.InstalledVersion)
+		}
+
+		log.Info("Deleting provider components", "provider", provider.GetName(), "version", *status.InstalledVersion)
+		var errList []error
+		objs := components.Objs()
+		for i := range objs {
+			obj := objs[i]
+			if obj.GetKind() == "CustomResourceDefinition" || obj.GetKind() == "Namespace" {
+				continue
+			}
+			if err := r.Client.Delete(ctx, &obj); err != nil && !apierrors.IsNotFound(err) {
+				errList = append(errList, errors.Wrapf(err, "failed to delete %s %s/%s", obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName()))
+			}
+		}
+		inventoryObj := components.InventoryObject()
+		if err := r.Client.Delete(ctx, &inventoryObj); err != nil && !apierrors.IsNotFound(err) {
+			errList = append(errList, errors.Wrapf(err, "failed to delete the clusterctl inventory object %s/%s", inventoryObj.Namespace, inventoryObj.Name))
+		}
+		if len(errList) > 0 {
+			return kerrors.NewAggregate(errList)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(provider, operatorv1.ProviderFinalizer)
+	return nil
+}
+
+// getRepositoryClient returns the client for reading the provider components, using the fetch configuration
+// and the variables defined in the provider spec.
+func (r *GenericProviderReconciler) getRepositoryClient(ctx context.Context, provider operatorv1.GenericProvider) (repository.Client, error) {
+	spec := provider.GetSpec()
+
+	reader := config.NewMemoryReader()
+	if spec.ConfigSecret != nil {
+		secret := &corev1.Secret{}
+		key := client.ObjectKey{Namespace: provider.GetNamespace(), Name: spec.ConfigSecret.Name}
+		if err := r.Client.Get(ctx, key, secret); err != nil {
+			conditions.MarkFalse(provider, operatorv1.ProviderInstalledCondition, operatorv1.ConfigSecretNotFoundReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return nil, errors.Wrapf(err, "failed to read the config secret %s", key)
+		}
+		for k, v := range secret.Data {
+			reader.Set(k, string(v))
+		}
+	}
+	if spec.FetchConfig != nil {
+		if _, err := reader.AddProvider(provider.GetName(), provider.GetProviderType(), spec.FetchConfig.URL); err != nil {
+			return nil, err
+		}
+	}
+
+	configClient, err := config.New("", config.InjectReader(reader))
+	if err != nil {
+		return nil, err
+	}
+
+	providerConfig, err := configClient.Providers().Get(provider.GetName(), provider.GetProviderType())
+	if err != nil {
+		conditions.MarkFalse(provider, operatorv1.ProviderInstalledCondition, operatorv1.ComponentsFetchFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return nil, errors.Wrapf(err, "failed to get the configuration of the provider %s", provider.GetName())
+	}
+
+	repo, err := r.repositoryClientFactory(providerConfig, configClient)
+	if err != nil {
+		conditions.MarkFalse(provider, operatorv1.ProviderInstalledCondition, operatorv1.ComponentsFetchFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return nil, errors.Wrapf(err, "failed to get the repository of the provider %s", provider.GetName())
+	}
+	return repo, nil
+}
+
+// getContract returns the Cluster API contract implemented by a provider version, as defined in the provider metadata.
+func getContract(repo repository.Client, providerVersion string) (string, error) {
+	metadata, err := repo.Metadata(providerVersion).Get()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read the metadata of version %s", providerVersion)
+	}
+
+	parsedVersion, err := version.ParseSemantic(providerVersion)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse version %s", providerVersion)
+	}
+
+	releaseSeries := metadata.GetReleaseSeriesForVersion(parsedVersion)
+	if releaseSeries == nil {
+		return "", errors.Errorf("version %s is not defined in the provider metadata", providerVersion)
+	}
+	return releaseSeries.Contract, nil
+}
+
+// applyObj creates an object or, if it already exists, patches it with the desired state.
+func (r *GenericProviderReconciler) applyObj(ctx context.Context, obj unstructured.Unstructured) error {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+
+	key := client.ObjectKey{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+	if err := r.Client.Get(ctx, key, current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get %s %s", obj.GroupVersionKind(), key)
+		}
+		if err := r.Client.Create(ctx, &obj); err != nil {
+			return errors.Wrapf(err, "failed to create %s %s", obj.GroupVersionKind(), key)
+		}
+		return nil
+	}
+
+	// NB. using a merge patch so the desired state gets compared with the current one server side.
+	obj.SetResourceVersion(current.GetResourceVersion())
+	if err := r.Client.Patch(ctx, &obj, client.Merge); err != nil {
+		return errors.Wrapf(err, "failed to patch %s %s", obj.GroupVersionKind(), key)
+	}
+	return nil
+}
+
+// applyInventoryObj creates the clusterctl inventory object for a provider or, if it already exists, patches it
+// with the installed version.
+func (r *GenericProviderReconciler) applyInventoryObj(ctx context.Context, obj clusterctlv1.Provider) error {
+	current := &clusterctlv1.Provider{}
+	key := client.ObjectKey{Namespace: obj.Namespace, Name: obj.Name}
+	if err := r.Client.Get(ctx, key, current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to get the clusterctl inventory object %s", key)
+		}
+		if err := r.Client.Create(ctx, &obj); err != nil {
+			return errors.Wrapf(err, "failed to create the clusterctl inventory object %s", key)
+		}
+		return nil
+	}
+
+	obj.SetResourceVersion(current.GetResourceVersion())
+	if err := r.Client.Patch(ctx, &obj, client.Merge); err != nil {
+		return errors.Wrapf(err, "failed to patch the clusterctl inventory object %s", key)
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	operatorv1 "sigs.k8s.io/cluster-api/exp/operator/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var ctx = ctrl.SetupSignalHandler()
+
+func componentsYAML(configValue string) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Namespace
+metadata:
+  name: capi-system
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: manager-config
+  namespace: capi-system
+data:
+  value: "${MANAGER_VALUE}"
+  version: "%s"
+`, configValue))
+}
+
+func TestGenericProviderReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = operatorv1.AddToScheme(scheme)
+	_ = clusterctlv1.AddToScheme(scheme)
+
+	metadata := &clusterctlv1.Metadata{
+		ReleaseSeries: []clusterctlv1.ReleaseSeries{
+			{Major: 1, Minor: 0, Contract: "v1beta1"},
+			{Major: 1, Minor: 1, Contract: "v1beta1"},
+		},
+	}
+	memoryRepository := repository.NewMemoryRepository().
+		WithPaths("root", "components.yaml").
+		WithDefaultVersion("v1.0.0").
+		WithFile("v1.0.0", "components.yaml", componentsYAML("v1.0.0")).
+		WithFile("v1.1.0", "components.yaml", componentsYAML("v1.1.0")).
+		WithMetadata("v1.0.0", metadata).
+		WithMetadata("v1.1.0", metadata)
+
+	newReconciler := func(objs ...client.Object) *GenericProviderReconciler {
+		return &GenericProviderReconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+			Provider: &operatorv1.CoreProvider{},
+			repositoryClientFactory: func(provider config.Provider, configClient config.Client, options ...repository.Option) (repository.Client, error) {
+				return repository.New(provider, configClient, repository.InjectRepository(memoryRepository))
+			},
+		}
+	}
+
+	newProvider := func() *operatorv1.CoreProvider {
+		return &operatorv1.CoreProvider{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "cluster-api",
+				Namespace:  "capi-system",
+				Finalizers: []string{operatorv1.ProviderFinalizer},
+			},
+			Spec: operatorv1.ProviderSpec{
+				Version:      "v1.0.0",
+				ConfigSecret: &corev1.LocalObjectReference{Name: "capi-variables"},
+			},
+		}
+	}
+
+	configSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "capi-variables", Namespace: "capi-system"},
+		Data:       map[string][]byte{"MANAGER_VALUE": []byte("foo")},
+	}
+
+	reconcile := func(g *WithT, r *GenericProviderReconciler) (*operatorv1.CoreProvider, error) {
+		key := client.ObjectKey{Namespace: "capi-system", Name: "cluster-api"}
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		provider := &operatorv1.CoreProvider{}
+		if getErr := r.Client.Get(ctx, key, provider); !apierrors.IsNotFound(getErr) {
+			g.Expect(getErr).ToNot(HaveOccurred())
+		}
+		return provider, err
+	}
+
+	getConfigMap := func(g *WithT, r *GenericProviderReconciler) *corev1.ConfigMap {
+		configMap := &corev1.ConfigMap{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKey{Namespace: "capi-system", Name: "manager-config"}, configMap)).To(Succeed())
+		return configMap
+	}
+
+	getInventoryObj := func(g *WithT, r *GenericProviderReconciler) *clusterctlv1.Provider {
+		inventoryObj := &clusterctlv1.Provider{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKey{Namespace: "capi-system", Name: "cluster-api"}, inventoryObj)).To(Succeed())
+		return inventoryObj
+	}
+
+	t.Run("installs and upgrades the provider components", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newReconciler(newProvider(), configSecret)
+
+		provider, err := reconcile(g, r)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(provider.Status.InstalledVersion).To(Equal(pointer.StringPtr("v1.0.0")))
+		g.Expect(provider.Status.Contract).To(Equal(pointer.StringPtr("v1beta1")))
+		g.Expect(conditions.IsTrue(provider, operatorv1.ProviderInstalledCondition)).To(BeTrue())
+		g.Expect(getConfigMap(g, r).Data).To(Equal(map[string]string{"value": "foo", "version": "v1.0.0"}))
+		inventoryObj := getInventoryObj(g, r)
+		g.Expect(inventoryObj.ProviderName).To(Equal("cluster-api"))
+		g.Expect(inventoryObj.Type).To(Equal(string(clusterctlv1.CoreProviderType)))
+		g.Expect(inventoryObj.Version).To(Equal("v1.0.0"))
+
+		provider.Spec.Version = "v1.1.0"
+		provider.Generation++
+		g.Expect(r.Client.Update(ctx, provider)).To(Succeed())
+
+		provider, err = reconcile(g, r)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(provider.Status.InstalledVersion).To(Equal(pointer.StringPtr("v1.1.0")))
+		g.Expect(getConfigMap(g, r).Data).To(Equal(map[string]string{"value": "foo", "version": "v1.1.0"}))
+		g.Expect(getInventoryObj(g, r).Version).To(Equal("v1.1.0"))
+	})
+
+	t.Run("reports a missing config secret", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newReconciler(newProvider())
+
+		provider, err := reconcile(g, r)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(provider.Status.InstalledVersion).To(BeNil())
+		g.Expect(conditions.GetReason(provider, operatorv1.ProviderInstalledCondition)).To(Equal(operatorv1.ConfigSecretNotFoundReason))
+	})
+
+	t.Run("reports missing variables", func(t *testing.T) {
+		g := NewWithT(t)
+		provider := newProvider()
+		provider.Spec.ConfigSecret = nil
+		r := newReconciler(provider)
+
+		provider, err := reconcile(g, r)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(conditions.GetReason(provider, operatorv1.ProviderInstalledCondition)).To(Equal(operatorv1.ComponentsFetchFailedReason))
+	})
+
+	t.Run("deletes the provider components except namespaces, and the inventory object", func(t *testing.T) {
+		g := NewWithT(t)
+		r := newReconciler(newProvider(), configSecret)
+
+		provider, err := reconcile(g, r)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(r.Client.Delete(ctx, provider)).To(Succeed())
+
+		_, err = reconcile(g, r)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(apierrors.IsNotFound(r.Client.Get(ctx, client.ObjectKey{Namespace: "capi-system", Name: "manager-config"}, &corev1.ConfigMap{}))).To(BeTrue())
+		g.Expect(r.Client.Get(ctx, client.ObjectKey{Name: "capi-system"}, &corev1.Namespace{})).To(Succeed())
+		g.Expect(apierrors.IsNotFound(r.Client.Get(ctx, client.ObjectKey{Namespace: "capi-system", Name: "cluster-api"}, &clusterctlv1.Provider{}))).To(BeTrue())
+		g.Expect(apierrors.IsNotFound(r.Client.Get(ctx, client.ObjectKeyFromObject(provider), &operatorv1.CoreProvider{}))).To(BeTrue())
+	})
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the types of the requests and responses of the runtime hooks,
+// i.e. of the external services called by Cluster API controllers to extend their behavior.
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// GroupVersion is the group version of the runtime hooks requests and responses.
+const GroupVersion = "hooks.runtime.cluster.x-k8s.io/v1alpha1"
+
+// ResponseStatus represents the status of a hook response.
+type ResponseStatus string
+
+const (
+	// ResponseStatusSuccess represents a successful response.
+	ResponseStatusSuccess ResponseStatus = "Success"
+
+	// ResponseStatusFailure represents a failed response.
+	ResponseStatusFailure ResponseStatus = "Failure"
+)
+
+// DiscoverVariablesRequest is the request of the DiscoverVariables hook.
+// The hook is called via an HTTP POST request with a JSON encoded DiscoverVariablesRequest body
+// when reconciling a ClusterClass with .spec.variablesDiscovery set.
+type DiscoverVariablesRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ClusterClass is the ClusterClass whose variables are discovered.
+	ClusterClass ObjectReference `json:"clusterClass"`
+
+	// Variables are the variables defined in the ClusterClass and in the ClusterClasses it
+	// inherits from; they take precedence over discovered variables with the same name.
+	Variables []clusterv1.ClusterClassVariable `json:"variables,omitempty"`
+}
+
+// DiscoverVariablesResponse is the response of the DiscoverVariables hook.
+type DiscoverVariablesResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Status of the call, either Success or Failure.
+	Status ResponseStatus `json:"status"`
+
+	// Message is a human-readable description of the status of the call, e.g. the reason of a failure.
+	Message string `json:"message,omitempty"`
+
+	// Variables are the discovered variables; their schema can define a default value, which is
+	// used for Clusters not setting the variable.
+	Variables []clusterv1.ClusterClassVariable `json:"variables,omitempty"`
+}
+
+// ObjectReference identifies an object in the management cluster.
+type ObjectReference struct {
+	// Namespace of the object.
+	Namespace string `json:"namespace"`
+
+	// Name of the object.
+	Name string `json:"name"`
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -89,3 +90,47 @@ func MachinePoolToInfrastructureMapFunc(gvk schema.GroupVersionKind, log logr.Lo
 		}
 	}
 }
+
+// ResolveMachinePoolRollingUpdate returns the absolute values of maxSurge and maxUnavailable for the rolling
+// update of a MachinePool, using the same semantic as the rolling update of MachineDeployments: maxSurge is
+// rounded up, maxUnavailable is rounded down and, if both resolve to zero, maxUnavailable is set to 1.
+// Infrastructure providers can use it to bound the number of machine instances replaced at the same time.
+// It returns an error if the MachinePool does not use the RollingUpdate strategy.
+func ResolveMachinePoolRollingUpdate(mp *clusterv1exp.MachinePool) (maxSurge, maxUnavailable int32, err error) {
+	if mp.Spec.Strategy != nil && mp.Spec.Strategy.Type != "" && mp.Spec.Strategy.Type != clusterv1exp.RollingUpdateMachinePoolStrategyType {
+		return 0, 0, errors.Errorf("MachinePool %s/%s uses the %s strategy", mp.Namespace, mp.Name, mp.Spec.Strategy.Type)
+	}
+
+	desired := 1
+	if mp.Spec.Replicas != nil {
+		desired = int(*mp.Spec.Replicas)
+	}
+
+	// Use the defaults of the MachinePool webhook if the rolling update is not set.
+	surgeValue, unavailableValue := intstr.FromInt(1), intstr.FromInt(0)
+	if mp.Spec.Strategy != nil && mp.Spec.Strategy.RollingUpdate != nil {
+		if mp.Spec.Strategy.RollingUpdate.MaxSurge != nil {
+			surgeValue = *mp.Spec.Strategy.RollingUpdate.MaxSurge
+		}
+		if mp.Spec.Strategy.RollingUpdate.MaxUnavailable != nil {
+			unavailableValue = *mp.Spec.Strategy.RollingUpdate.MaxUnavailable
+		}
+	}
+
+	surge, err := intstr.GetScaledValueFromIntOrPercent(&surgeValue, desired, true)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to resolve maxSurge")
+	}
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(&unavailableValue, desired, false)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "failed to resolve maxUnavailable")
+	}
+
+	if surge == 0 && unavailable == 0 {
+		// Due to rounding down maxUnavailable may resolve to zero; in this case allow one machine instance
+		// to be unavailable, on the theory that surge might not work due to quota.
+		unavailable = 1
+	}
+
+	return int32(surge), int32(unavailable), nil
+}
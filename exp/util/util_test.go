@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+)
+
+func TestResolveMachinePoolRollingUpdate(t *testing.T) {
+	intOrStr := func(s string) *intstr.IntOrString {
+		v := intstr.Parse(s)
+		return &v
+	}
+
+	tests := []struct {
+		name               string
+		replicas           *int32
+		strategy           *clusterv1exp.MachinePoolStrategy
+		wantMaxSurge       int32
+		wantMaxUnavailable int32
+		wantErr            bool
+	}{
+		{
+			name:               "defaults if the strategy is not set",
+			replicas:           pointer.Int32Ptr(3),
+			wantMaxSurge:       1,
+			wantMaxUnavailable: 0,
+		},
+		{
+			name:     "absolute values",
+			replicas: pointer.Int32Ptr(5),
+			strategy: &clusterv1exp.MachinePoolStrategy{
+				Type:          clusterv1exp.RollingUpdateMachinePoolStrategyType,
+				RollingUpdate: &clusterv1exp.MachinePoolRollingUpdate{MaxSurge: intOrStr("2"), MaxUnavailable: intOrStr("1")},
+			},
+			wantMaxSurge:       2,
+			wantMaxUnavailable: 1,
+		},
+		{
+			name:     "percentages round maxSurge up and maxUnavailable down",
+			replicas: pointer.Int32Ptr(10),
+			strategy: &clusterv1exp.MachinePoolStrategy{
+				RollingUpdate: &clusterv1exp.MachinePoolRollingUpdate{MaxSurge: intOrStr("25%"), MaxUnavailable: intOrStr("25%")},
+			},
+			wantMaxSurge:       3,
+			wantMaxUnavailable: 2,
+		},
+		{
+			name:     "maxUnavailable is 1 if both values resolve to zero",
+			replicas: pointer.Int32Ptr(2),
+			strategy: &clusterv1exp.MachinePoolStrategy{
+				RollingUpdate: &clusterv1exp.MachinePoolRollingUpdate{MaxSurge: intOrStr("0"), MaxUnavailable: intOrStr("10%")},
+			},
+			wantMaxSurge:       0,
+			wantMaxUnavailable: 1,
+		},
+		{
+			name:     "error with the OnDelete strategy",
+			replicas: pointer.Int32Ptr(2),
+			strategy: &clusterv1exp.MachinePoolStrategy{Type: clusterv1exp.OnDeleteMachinePoolStrategyType},
+			wantErr:  true,
+		},
+		{
+			name:     "error with an invalid value",
+			replicas: pointer.Int32Ptr(2),
+			strategy: &clusterv1exp.MachinePoolStrategy{
+				RollingUpdate: &clusterv1exp.MachinePoolRollingUpdate{MaxSurge: intOrStr("foo")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mp := &clusterv1exp.MachinePool{
+				Spec: clusterv1exp.MachinePoolSpec{
+					Replicas: tt.replicas,
+					Strategy: tt.strategy,
+				},
+			}
+			maxSurge, maxUnavailable, err := ResolveMachinePoolRollingUpdate(mp)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(maxSurge).To(Equal(tt.wantMaxSurge))
+			g.Expect(maxUnavailable).To(Equal(tt.wantMaxUnavailable))
+		})
+	}
+}
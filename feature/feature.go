@@ -44,6 +44,33 @@ const (
 	//
 	// alpha: v0.4
 	ClusterTopology featuregate.Feature = "ClusterTopology"
+
+	// StateMetrics is a feature gate for the metrics exposing the state of the Cluster API objects.
+	//
+	// alpha: v1.0
+	StateMetrics featuregate.Feature = "StateMetrics"
+
+	// ProviderOperator is a feature gate for the provider objects managing the lifecycle of the providers
+	// installed in the management cluster.
+	//
+	// alpha: v1.0
+	ProviderOperator featuregate.Feature = "ProviderOperator"
+
+	// ClusterClassInheritance is a feature gate for composing a ClusterClass from the ClusterClass it inherits from.
+	//
+	// alpha: v1.0
+	ClusterClassInheritance featuregate.Feature = "ClusterClassInheritance"
+
+	// ClusterClassVariablesDiscovery is a feature gate for discovering the variables of a ClusterClass by calling
+	// an external DiscoverVariables hook.
+	//
+	// alpha: v1.0
+	ClusterClassVariablesDiscovery featuregate.Feature = "ClusterClassVariablesDiscovery"
+
+	// IPAM is a feature gate for the IPAddressClaim and IPAddress functionality.
+	//
+	// alpha: v1.0
+	IPAM featuregate.Feature = "IPAM"
 )
 
 func init() {
@@ -54,7 +81,12 @@ func init() {
 // To add a new feature, define a key for it above and add it here.
 var defaultClusterAPIFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	// Every feature should be initiated here:
-	MachinePool:        {Default: false, PreRelease: featuregate.Alpha},
-	ClusterResourceSet: {Default: true, PreRelease: featuregate.Beta},
-	ClusterTopology:    {Default: false, PreRelease: featuregate.Alpha},
+	MachinePool:                    {Default: false, PreRelease: featuregate.Alpha},
+	ClusterResourceSet:             {Default: true, PreRelease: featuregate.Beta},
+	ClusterTopology:                {Default: false, PreRelease: featuregate.Alpha},
+	StateMetrics:                   {Default: false, PreRelease: featuregate.Alpha},
+	ProviderOperator:               {Default: false, PreRelease: featuregate.Alpha},
+	ClusterClassInheritance:        {Default: false, PreRelease: featuregate.Alpha},
+	ClusterClassVariablesDiscovery: {Default: false, PreRelease: featuregate.Alpha},
+	IPAM:                           {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package feature
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/component-base/featuregate"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultGatesFileInterval is the default interval at which the feature gates file is checked for changes.
+const DefaultGatesFileInterval = 10 * time.Second
+
+const (
+	allAlpha = "AllAlpha"
+	allBeta  = "AllBeta"
+)
+
+// GatesFile sets the feature gates from a file, usually mounted from a ConfigMap, containing a YAML map
+// of feature gate names to booleans, e.g. "{MachinePool: true, ClusterTopology: true}".
+// Feature gates set with the --feature-gates flag take precedence over the ones set in the file; feature gates
+// set neither in the flag nor in the file get their default value.
+type GatesFile struct {
+	// Path is the path of the file.
+	Path string
+
+	// Interval is the interval at which the file is checked for changes.
+	Interval time.Duration
+
+	// OnChange is called with the names of the feature gates that changed value after the file has been reloaded.
+	OnChange func(changed []string)
+
+	gates     featuregate.MutableFeatureGate
+	overrides map[string]bool
+	content   []byte
+}
+
+// NewGatesFile returns a GatesFile setting gates from the file at path; the feature gates already set
+// in gates, e.g. by the --feature-gates flag, are preserved across reloads.
+func NewGatesFile(path string, gates featuregate.MutableFeatureGate) (*GatesFile, error) {
+	// The feature gates implementation prints the feature gates explicitly set, in the format of the --feature-gates flag.
+	overrides := map[string]bool{}
+	if s, ok := gates.(fmt.Stringer); ok {
+		var err error
+		if overrides, err = parseGates(s.String()); err != nil {
+			return nil, err
+		}
+	}
+	return &GatesFile{
+		Path:      path,
+		Interval:  DefaultGatesFileInterval,
+		gates:     gates,
+		overrides: overrides,
+	}, nil
+}
+
+// Load reads the file and sets the feature gates accordingly, returning the names of the feature gates
+// that changed value.
+func (f *GatesFile) Load() ([]string, error) {
+	content, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read feature gates file %q", f.Path)
+	}
+	if f.content != nil && bytes.Equal(content, f.content) {
+		return nil, nil
+	}
+
+	fromFile := map[string]bool{}
+	if err := yaml.UnmarshalStrict(content, &fromFile); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse feature gates file %q", f.Path)
+	}
+
+	known := f.gates.GetAll()
+	for name := range fromFile {
+		if _, ok := known[featuregate.Feature(name)]; !ok {
+			return nil, errors.Errorf("unrecognized feature gate %q in feature gates file %q", name, f.Path)
+		}
+	}
+
+	// Compute the value of every feature gate, so feature gates removed from the file get back their default value.
+	values := map[string]bool{}
+	var changed []string
+	for feature, spec := range known {
+		name := string(feature)
+		if name == allAlpha || name == allBeta {
+			continue
+		}
+		value := spec.Default
+		for _, layer := range []map[string]bool{f.overrides, fromFile} {
+			if v, ok := layer[name]; ok {
+				value = v
+				break
+			}
+			if v, ok := layer[allAlpha]; ok && spec.PreRelease == featuregate.Alpha {
+				value = v
+				break
+			}
+			if v, ok := layer[allBeta]; ok && spec.PreRelease == featuregate.Beta {
+				value = v
+				break
+			}
+		}
+		values[name] = value
+		if f.gates.Enabled(feature) != value {
+			changed = append(changed, name)
+		}
+	}
+	if err := f.gates.SetFromMap(values); err != nil {
+		return nil, errors.Wrapf(err, "failed to set feature gates from file %q", f.Path)
+	}
+	f.content = content
+	RecordMetrics(f.gates)
+
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// Start checks the file for changes at every Interval until ctx is done, reloading it and calling OnChange
+// when feature gates change value; errors are logged and the previous feature gates are preserved.
+func (f *GatesFile) Start(ctx context.Context) error {
+	log := ctrl.LoggerFrom(ctx).WithValues("path", f.Path)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		changed, err := f.Load()
+		if err != nil {
+			log.Error(err, "Failed to reload feature gates")
+			return
+		}
+		if len(changed) == 0 {
+			return
+		}
+		log.Info("Feature gates changed", "changed", changed)
+		if f.OnChange != nil {
+			f.OnChange(changed)
+		}
+	}, f.Interval)
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, so all the replicas of a controller
+// reload the feature gates.
+func (f *GatesFile) NeedLeaderElection() bool {
+	return false
+}
+
+// parseGates parses feature gates in the format of the --feature-gates flag, e.g. "A=true,B=false".
+func parseGates(s string) (map[string]bool, error) {
+	gates := map[string]bool{}
+	for _, s := range strings.Split(s, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		kv := strings.SplitN(s, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("invalid feature gate %q", s)
+		}
+		v, err := strconv.ParseBool(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value of feature gate %q", s)
+		}
+		gates[strings.TrimSpace(kv[0])] = v
+	}
+	return gates, nil
+}
+
+// SetupGatesFile loads the feature gates from the file at path, if not empty, and adds to mgr a runnable reloading
+// the file when it changes; onChange is called when feature gates change value, e.g. to restart the controller
+// manager so the controllers and webhooks behind the feature gates are set up again.
+// It also records the effective feature gates in the capi_feature_enabled metric.
+func SetupGatesFile(mgr manager.Manager, path string, onChange func(changed []string)) error {
+	RecordMetrics(MutableGates)
+	if path == "" {
+		return nil
+	}
+
+	f, err := NewGatesFile(path, MutableGates)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Load(); err != nil {
+		return err
+	}
+	f.OnChange = onChange
+	return mgr.Add(f)
+}
+
+// SetupGatesFileWithRestart is like SetupGatesFile, but it calls cancel, stopping the controller manager, when feature
+// gates change value; controllers and webhooks behind feature gates are set up only at startup, so the controller
+// must be restarted with the new feature gates.
+func SetupGatesFileWithRestart(mgr manager.Manager, path string, cancel context.CancelFunc) error {
+	log := ctrl.Log.WithName("feature-gates")
+	return SetupGatesFile(mgr, path, func(changed []string) {
+		log.Info("feature gates changed, stopping the manager to restart with the new feature gates", "changed", changed)
+		cancel()
+	})
+}
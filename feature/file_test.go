@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package feature
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/component-base/featuregate"
+)
+
+func TestGatesFile(t *testing.T) {
+	newGates := func(g *WithT, flag string) featuregate.MutableFeatureGate {
+		gates := featuregate.NewFeatureGate()
+		g.Expect(gates.Add(defaultClusterAPIFeatureGates)).To(Succeed())
+		if flag != "" {
+			g.Expect(gates.Set(flag)).To(Succeed())
+		}
+		return gates
+	}
+	writeFile := func(g *WithT, path, content string) {
+		g.Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+	}
+
+	t.Run("sets the feature gates from the file and reloads them on changes", func(t *testing.T) {
+		g := NewWithT(t)
+		path := filepath.Join(t.TempDir(), "feature-gates.yaml")
+		gates := newGates(g, "")
+
+		writeFile(g, path, "MachinePool: true\nClusterResourceSet: false\n")
+		f, err := NewGatesFile(path, gates)
+		g.Expect(err).ToNot(HaveOccurred())
+		changed, err := f.Load()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(Equal([]string{"ClusterResourceSet", "MachinePool"}))
+		g.Expect(gates.Enabled(MachinePool)).To(BeTrue())
+		g.Expect(gates.Enabled(ClusterResourceSet)).To(BeFalse())
+
+		changed, err = f.Load()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(BeEmpty())
+
+		// Feature gates removed from the file get back their default value.
+		writeFile(g, path, "ClusterTopology: true\n")
+		changed, err = f.Load()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(changed).To(Equal([]string{"ClusterResourceSet", "ClusterTopology", "MachinePool"}))
+		g.Expect(gates.Enabled(MachinePool)).To(BeFalse())
+		g.Expect(gates.Enabled(ClusterResourceSet)).To(BeTrue())
+		g.Expect(gates.Enabled(ClusterTopology)).To(BeTrue())
+	})
+
+	t.Run("feature gates set by flag take precedence over the file", func(t *testing.T) {
+		g := NewWithT(t)
+		path := filepath.Join(t.TempDir(), "feature-gates.yaml")
+		gates := newGates(g, "MachinePool=false,AllAlpha=true")
+
+		writeFile(g, path, "MachinePool: true\nClusterTopology: false\n")
+		f, err := NewGatesFile(path, gates)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = f.Load()
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(gates.Enabled(MachinePool)).To(BeFalse())
+		g.Expect(gates.Enabled(ClusterTopology)).To(BeTrue())
+	})
+
+	t.Run("invalid files do not change the feature gates", func(t *testing.T) {
+		g := NewWithT(t)
+		path := filepath.Join(t.TempDir(), "feature-gates.yaml")
+		gates := newGates(g, "")
+
+		writeFile(g, path, "MachinePool: true\n")
+		f, err := NewGatesFile(path, gates)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = f.Load()
+		g.Expect(err).ToNot(HaveOccurred())
+
+		writeFile(g, path, "MachinePool: false\nUnknownFeature: true\n")
+		_, err = f.Load()
+		g.Expect(err).To(HaveOccurred())
+		writeFile(g, path, "MachinePool: maybe\n")
+		_, err = f.Load()
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(gates.Enabled(MachinePool)).To(BeTrue())
+	})
+}
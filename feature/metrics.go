@@ -0,0 +1,54 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package feature
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/component-base/featuregate"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// featureEnabled reports the effective value of the feature gates.
+var featureEnabled = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "capi_feature_enabled",
+		Help: "Whether the feature gate is enabled (1) or not (0), by name and stage.",
+	},
+	[]string{"name", "stage"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(featureEnabled)
+}
+
+// RecordMetrics records the effective value of the feature gates in the capi_feature_enabled metric.
+func RecordMetrics(gates featuregate.MutableFeatureGate) {
+	for feature, spec := range gates.GetAll() {
+		if feature == allAlpha || feature == allBeta {
+			continue
+		}
+		value := 0.0
+		if gates.Enabled(feature) {
+			value = 1
+		}
+		stage := string(spec.PreRelease)
+		if stage == "" {
+			stage = "GA"
+		}
+		featureEnabled.WithLabelValues(string(feature), stage).Set(value)
+	}
+}
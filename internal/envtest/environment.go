@@ -49,8 +49,9 @@ import (
 	kcpv1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	addonv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
-	"sigs.k8s.io/cluster-api/internal/builder"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/test/builder"
 	"sigs.k8s.io/cluster-api/webhooks"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -76,6 +77,7 @@ func init() {
 	utilruntime.Must(expv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(addonv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(kcpv1.AddToScheme(scheme.Scheme))
+	utilruntime.Must(ipamv1.AddToScheme(scheme.Scheme))
 	utilruntime.Must(admissionv1.AddToScheme(scheme.Scheme))
 }
 
@@ -168,6 +170,8 @@ func new(uncachedObjs ...client.Object) *Environment {
 			*builder.GenericControlPlaneTemplateCRD.DeepCopy(),
 			*builder.GenericInfrastructureMachineCRD.DeepCopy(),
 			*builder.GenericInfrastructureMachineTemplateCRD.DeepCopy(),
+			*builder.GenericInfrastructureMachinePoolCRD.DeepCopy(),
+			*builder.GenericInfrastructureMachinePoolTemplateCRD.DeepCopy(),
 			*builder.GenericInfrastructureClusterCRD.DeepCopy(),
 			*builder.GenericInfrastructureClusterTemplateCRD.DeepCopy(),
 			*builder.GenericRemediationCRD.DeepCopy(),
@@ -219,12 +223,15 @@ func new(uncachedObjs ...client.Object) *Environment {
 	if err := (&webhooks.Cluster{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
 		klog.Fatalf("unable to create webhook: %+v", err)
 	}
-	if err := (&webhooks.ClusterClass{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&webhooks.ClusterClass{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
 		klog.Fatalf("unable to create webhook: %+v", err)
 	}
 	if err := (&clusterv1.Machine{}).SetupWebhookWithManager(mgr); err != nil {
 		klog.Fatalf("unable to create webhook: %+v", err)
 	}
+	if err := (&webhooks.Machine{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+		klog.Fatalf("unable to create webhook for machine providerid: %+v", err)
+	}
 	if err := (&clusterv1.MachineHealthCheck{}).SetupWebhookWithManager(mgr); err != nil {
 		klog.Fatalf("unable to create webhook: %+v", err)
 	}
@@ -234,6 +241,9 @@ func new(uncachedObjs ...client.Object) *Environment {
 	if err := (&clusterv1.MachineSet{}).SetupWebhookWithManager(mgr); err != nil {
 		klog.Fatalf("unable to create webhook: %+v", err)
 	}
+	if err := (&webhooks.MachineSet{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+		klog.Fatalf("unable to create webhook for machineset selector: %+v", err)
+	}
 	if err := (&clusterv1.MachineDeployment{}).SetupWebhookWithManager(mgr); err != nil {
 		klog.Fatalf("unable to create webhook: %+v", err)
 	}
@@ -252,9 +262,24 @@ func new(uncachedObjs ...client.Object) *Environment {
 	if err := (&addonv1.ClusterResourceSet{}).SetupWebhookWithManager(mgr); err != nil {
 		klog.Fatalf("unable to create webhook for crs: %+v", err)
 	}
+	if err := (&webhooks.ClusterResourceSet{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+		klog.Fatalf("unable to create webhook for crs namespace selector: %+v", err)
+	}
 	if err := (&expv1.MachinePool{}).SetupWebhookWithManager(mgr); err != nil {
 		klog.Fatalf("unable to create webhook for machinepool: %+v", err)
 	}
+	if err := (&webhooks.IPAddress{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+		klog.Fatalf("unable to create webhook for ipaddress: %+v", err)
+	}
+	if err := (&webhooks.IPAddressClaim{}).SetupWebhookWithManager(mgr); err != nil {
+		klog.Fatalf("unable to create webhook for ipaddressclaim: %+v", err)
+	}
+	if err := (&webhooks.ShardLabel{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+		klog.Fatalf("unable to create webhook for shard label: %+v", err)
+	}
+	if err := (&webhooks.WellKnownAnnotations{}).SetupWebhookWithManager(mgr); err != nil {
+		klog.Fatalf("unable to create webhook for annotations: %+v", err)
+	}
 
 	return &Environment{
 		Manager: mgr,
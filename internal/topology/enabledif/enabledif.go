@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package enabledif implements the evaluation of the enabledIf templates defined in a ClusterClass.
+package enabledif
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// Validate checks that an enabledIf template can be parsed.
+func Validate(enabledIf string) error {
+	_, err := parse(enabledIf)
+	return err
+}
+
+// Evaluate returns true if an enabledIf template evaluates to true using the given variables;
+// a nil enabledIf always evaluates to true.
+// Variables are available in the template using their name, e.g. {{ .gpu }}; variables with dots in the name,
+// like builtin variables, are available as nested values, e.g. {{ .builtin.cluster.name }}.
+// NOTE: Variables not set evaluate to an empty value, so e.g. {{ .gpu }} evaluates to false if gpu is not set.
+func Evaluate(enabledIf *string, variables map[string]apiextensionsv1.JSON) (bool, error) {
+	if enabledIf == nil {
+		return true, nil
+	}
+
+	tpl, err := parse(*enabledIf)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := TemplateData(variables)
+	if err != nil {
+		return false, err
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return false, errors.Wrapf(err, "failed to execute enabledIf template %q", *enabledIf)
+	}
+	return strings.TrimSpace(buf.String()) == "true", nil
+}
+
+func parse(enabledIf string) (*template.Template, error) {
+	tpl, err := template.New("enabledIf").Parse(enabledIf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse enabledIf template %q", enabledIf)
+	}
+	return tpl, nil
+}
+
+// TemplateData returns the data the templates referencing variables are executed against, by unmarshalling the value
+// of each variable and by nesting variables with dots in the name, e.g. builtin.cluster.name.
+// NOTE: This func is also used to execute the templates computing the value of ClusterClass patches.
+func TemplateData(variables map[string]apiextensionsv1.JSON) (map[string]interface{}, error) {
+	data := map[string]interface{}{}
+	for name, value := range variables {
+		var v interface{}
+		if err := json.Unmarshal(value.Raw, &v); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal variable %q", name)
+		}
+
+		parts := strings.Split(name, ".")
+		parent := data
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := parent[part]
+			if !ok {
+				child = map[string]interface{}{}
+				parent[part] = child
+			}
+			childMap, ok := child.(map[string]interface{})
+			if !ok {
+				return nil, errors.Errorf("failed to set variable %q: %q is not an object", name, part)
+			}
+			parent = childMap
+		}
+		parent[parts[len(parts)-1]] = v
+	}
+	return data, nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package enabledif
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestEvaluate(t *testing.T) {
+	variables := map[string]apiextensionsv1.JSON{
+		"gpu":                  {Raw: []byte(`true`)},
+		"cpu":                  {Raw: []byte(`false`)},
+		"region":               {Raw: []byte(`"us-east-1"`)},
+		"builtin.cluster.name": {Raw: []byte(`"cluster1"`)},
+	}
+
+	tests := []struct {
+		name      string
+		enabledIf *string
+		want      bool
+		wantErr   bool
+	}{
+		{
+			name:      "nil is enabled",
+			enabledIf: nil,
+			want:      true,
+		},
+		{
+			name:      "true constant",
+			enabledIf: pointer.String("true"),
+			want:      true,
+		},
+		{
+			name:      "boolean variable set to true",
+			enabledIf: pointer.String("{{ .gpu }}"),
+			want:      true,
+		},
+		{
+			name:      "boolean variable set to false",
+			enabledIf: pointer.String("{{ .cpu }}"),
+			want:      false,
+		},
+		{
+			name:      "variable not set",
+			enabledIf: pointer.String("{{ .unknown }}"),
+			want:      false,
+		},
+		{
+			name:      "expression on a string variable",
+			enabledIf: pointer.String(`{{ if eq .region "us-east-1" }}true{{ end }}`),
+			want:      true,
+		},
+		{
+			name:      "expression on a builtin variable",
+			enabledIf: pointer.String(`{{ eq .builtin.cluster.name "cluster2" }}`),
+			want:      false,
+		},
+		{
+			name:      "surrounding whitespaces are ignored",
+			enabledIf: pointer.String(" {{ .gpu }}\n"),
+			want:      true,
+		},
+		{
+			name:      "invalid template",
+			enabledIf: pointer.String("{{ .gpu "),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := Evaluate(tt.enabledIf, variables)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Validate("{{ .gpu }}")).To(Succeed())
+	g.Expect(Validate("{{ .gpu ")).ToNot(Succeed())
+}
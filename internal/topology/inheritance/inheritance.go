@@ -0,0 +1,205 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inheritance implements the composition of a ClusterClass from the ClusterClass it inherits from.
+package inheritance
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrCycle is returned when a ClusterClass directly or indirectly inherits from itself.
+var ErrCycle = errors.New("ClusterClass inheritance cycle detected")
+
+// Resolve returns the ClusterClass resulting from merging a ClusterClass with the chain of ClusterClasses it inherits from,
+// which are read from the same namespace of the ClusterClass. The returned ClusterClass does not inherit from any other
+// ClusterClass; if the ClusterClass does not inherit from any other ClusterClass, a copy of it is returned.
+func Resolve(ctx context.Context, c client.Reader, clusterClass *clusterv1.ClusterClass) (*clusterv1.ClusterClass, error) {
+	chain, err := Chain(ctx, c, clusterClass)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveChain(chain), nil
+}
+
+// Chain returns the chain of ClusterClasses a ClusterClass inherits from, which are read from the same namespace
+// of the ClusterClass; the first item is the ClusterClass itself, and each item inherits from the next one.
+func Chain(ctx context.Context, c client.Reader, clusterClass *clusterv1.ClusterClass) ([]*clusterv1.ClusterClass, error) {
+	chain := []*clusterv1.ClusterClass{clusterClass}
+	visited := sets.NewString(clusterClass.Name)
+	for current := clusterClass; current.Spec.Inherits != ""; {
+		if visited.Has(current.Spec.Inherits) {
+			return nil, errors.Wrapf(ErrCycle, "ClusterClass %s inherits from ClusterClass %s", current.Name, current.Spec.Inherits)
+		}
+
+		base := &clusterv1.ClusterClass{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: clusterClass.Namespace, Name: current.Spec.Inherits}, base); err != nil {
+			return nil, errors.Wrapf(err, "failed to get ClusterClass %s inherited by ClusterClass %s", current.Spec.Inherits, current.Name)
+		}
+		visited.Insert(base.Name)
+		chain = append(chain, base)
+		current = base
+	}
+	return chain, nil
+}
+
+// ResolveChain returns the ClusterClass resulting from merging a chain of ClusterClasses as returned by Chain.
+func ResolveChain(chain []*clusterv1.ClusterClass) *clusterv1.ClusterClass {
+	resolved := chain[len(chain)-1].DeepCopy()
+	for i := len(chain) - 2; i >= 0; i-- {
+		resolved = Merge(resolved, chain[i])
+	}
+	return resolved
+}
+
+// InheritingClusterClasses returns the names of the ClusterClasses in the list which directly or indirectly
+// inherit from the ClusterClass with the given name.
+func InheritingClusterClasses(clusterClasses []clusterv1.ClusterClass, name string) sets.String {
+	inheriting := sets.NewString()
+	queue := []string{name}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for i := range clusterClasses {
+			cc := &clusterClasses[i]
+			if cc.Spec.Inherits != current || cc.Name == name || inheriting.Has(cc.Name) {
+				continue
+			}
+			inheriting.Insert(cc.Name)
+			queue = append(queue, cc.Name)
+		}
+	}
+	return inheriting
+}
+
+// Merge returns the ClusterClass resulting from merging a derived ClusterClass on top of the base ClusterClass it inherits from;
+// the metadata and the status of the returned ClusterClass are the ones of the derived ClusterClass.
+// The fields of the derived ClusterClass take precedence over the fields of the base ClusterClass:
+// - The infrastructure and control plane templates, the control plane machine infrastructure and the control plane
+//   MachineHealthCheck replace the base ones, if set.
+// - Control plane labels and annotations are merged, with the derived values replacing the base values for the same key.
+// - MachineDeployment classes, MachinePool classes and variables replace the base ones with the same name; new ones are appended.
+// - Patches replace the base ones with the same name; new ones are appended, so they are applied after the base patches.
+// - The variables discovery hook replaces the base one, if set.
+func Merge(base, derived *clusterv1.ClusterClass) *clusterv1.ClusterClass {
+	merged := &clusterv1.ClusterClass{
+		TypeMeta:   derived.TypeMeta,
+		ObjectMeta: *derived.ObjectMeta.DeepCopy(),
+		Spec:       *base.Spec.DeepCopy(),
+		Status:     *derived.Status.DeepCopy(),
+	}
+	derivedSpec := derived.Spec.DeepCopy()
+	merged.Spec.Inherits = ""
+
+	if derivedSpec.Infrastructure.Ref != nil {
+		merged.Spec.Infrastructure = derivedSpec.Infrastructure
+	}
+
+	if derivedSpec.ControlPlane.Ref != nil {
+		merged.Spec.ControlPlane.LocalObjectTemplate = derivedSpec.ControlPlane.LocalObjectTemplate
+	}
+	merged.Spec.ControlPlane.Metadata.Labels = mergeMap(merged.Spec.ControlPlane.Metadata.Labels, derivedSpec.ControlPlane.Metadata.Labels)
+	merged.Spec.ControlPlane.Metadata.Annotations = mergeMap(merged.Spec.ControlPlane.Metadata.Annotations, derivedSpec.ControlPlane.Metadata.Annotations)
+	if derivedSpec.ControlPlane.MachineInfrastructure != nil {
+		merged.Spec.ControlPlane.MachineInfrastructure = derivedSpec.ControlPlane.MachineInfrastructure
+	}
+	if derivedSpec.ControlPlane.MachineHealthCheck != nil {
+		merged.Spec.ControlPlane.MachineHealthCheck = derivedSpec.ControlPlane.MachineHealthCheck
+	}
+
+	for _, mdClass := range derivedSpec.Workers.MachineDeployments {
+		replaced := false
+		for i := range merged.Spec.Workers.MachineDeployments {
+			if merged.Spec.Workers.MachineDeployments[i].Class == mdClass.Class {
+				merged.Spec.Workers.MachineDeployments[i] = mdClass
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Spec.Workers.MachineDeployments = append(merged.Spec.Workers.MachineDeployments, mdClass)
+		}
+	}
+
+	for _, mpClass := range derivedSpec.Workers.MachinePools {
+		replaced := false
+		for i := range merged.Spec.Workers.MachinePools {
+			if merged.Spec.Workers.MachinePools[i].Class == mpClass.Class {
+				merged.Spec.Workers.MachinePools[i] = mpClass
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Spec.Workers.MachinePools = append(merged.Spec.Workers.MachinePools, mpClass)
+		}
+	}
+
+	for _, variable := range derivedSpec.Variables {
+		replaced := false
+		for i := range merged.Spec.Variables {
+			if merged.Spec.Variables[i].Name == variable.Name {
+				merged.Spec.Variables[i] = variable
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Spec.Variables = append(merged.Spec.Variables, variable)
+		}
+	}
+
+	if derivedSpec.VariablesDiscovery != nil {
+		merged.Spec.VariablesDiscovery = derivedSpec.VariablesDiscovery
+	}
+
+	for _, patch := range derivedSpec.Patches {
+		replaced := false
+		for i := range merged.Spec.Patches {
+			if merged.Spec.Patches[i].Name == patch.Name {
+				merged.Spec.Patches[i] = patch
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged.Spec.Patches = append(merged.Spec.Patches, patch)
+		}
+	}
+
+	return merged
+}
+
+// mergeMap returns a map with the values of both the maps; the values of the derived map replace the values
+// of the base map for the same key.
+func mergeMap(base, derived map[string]string) map[string]string {
+	if len(derived) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(derived))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range derived {
+		merged[k] = v
+	}
+	return merged
+}
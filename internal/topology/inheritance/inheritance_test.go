@@ -0,0 +1,238 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inheritance
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMerge(t *testing.T) {
+	ref := func(name string) *corev1.ObjectReference {
+		return &corev1.ObjectReference{APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1", Kind: "GenericTemplate", Name: name}
+	}
+	mdClass := func(class, infra string) clusterv1.MachineDeploymentClass {
+		return clusterv1.MachineDeploymentClass{
+			Class: class,
+			Template: clusterv1.MachineDeploymentClassTemplate{
+				Bootstrap:      clusterv1.LocalObjectTemplate{Ref: ref("bootstrap")},
+				Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref(infra)},
+			},
+		}
+	}
+	mpClass := func(class, infra string) clusterv1.MachinePoolClass {
+		return clusterv1.MachinePoolClass{
+			Class: class,
+			Template: clusterv1.MachinePoolClassTemplate{
+				Bootstrap:      clusterv1.LocalObjectTemplate{Ref: ref("bootstrap")},
+				Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref(infra)},
+			},
+		}
+	}
+	variable := func(name string, required bool) clusterv1.ClusterClassVariable {
+		return clusterv1.ClusterClassVariable{Name: name, Required: required, Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"}}}
+	}
+	patch := func(name, kind string) clusterv1.ClusterClassPatch {
+		return clusterv1.ClusterClassPatch{Name: name, Definitions: []clusterv1.PatchDefinition{{Selector: clusterv1.PatchSelector{Kind: kind}}}}
+	}
+
+	base := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "base"},
+		Spec: clusterv1.ClusterClassSpec{
+			Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref("base-infra")},
+			ControlPlane: clusterv1.ControlPlaneClass{
+				Metadata:              clusterv1.ObjectMeta{Labels: map[string]string{"tier": "base", "base": ""}},
+				LocalObjectTemplate:   clusterv1.LocalObjectTemplate{Ref: ref("base-cp")},
+				MachineInfrastructure: &clusterv1.LocalObjectTemplate{Ref: ref("base-cp-machine")},
+			},
+			Workers: clusterv1.WorkersClass{
+				MachineDeployments: []clusterv1.MachineDeploymentClass{mdClass("default-worker", "base-worker"), mdClass("gpu-worker", "base-gpu")},
+				MachinePools:       []clusterv1.MachinePoolClass{mpClass("default-pool", "base-pool")},
+			},
+			Variables:          []clusterv1.ClusterClassVariable{variable("region", false), variable("flavor", false)},
+			VariablesDiscovery: &clusterv1.VariablesDiscovery{URL: "https://discovery.example.com"},
+			Patches:            []clusterv1.ClusterClassPatch{patch("region", "GenericTemplate"), patch("flavor", "GenericTemplate")},
+		},
+	}
+	derived := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "derived"},
+		Spec: clusterv1.ClusterClassSpec{
+			Inherits: "base",
+			ControlPlane: clusterv1.ControlPlaneClass{
+				Metadata:            clusterv1.ObjectMeta{Labels: map[string]string{"tier": "prod"}},
+				LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref("prod-cp")},
+			},
+			Workers: clusterv1.WorkersClass{
+				MachineDeployments: []clusterv1.MachineDeploymentClass{mdClass("gpu-worker", "prod-gpu"), mdClass("arm-worker", "prod-arm")},
+				MachinePools:       []clusterv1.MachinePoolClass{mpClass("default-pool", "prod-pool"), mpClass("spot-pool", "prod-spot")},
+			},
+			Variables: []clusterv1.ClusterClassVariable{variable("region", true), variable("zone", false)},
+			Patches:   []clusterv1.ClusterClassPatch{patch("flavor", "GenericControlPlaneTemplate"), patch("zone", "GenericTemplate")},
+		},
+	}
+
+	g := NewWithT(t)
+
+	merged := Merge(base, derived)
+
+	g.Expect(merged.ObjectMeta).To(Equal(derived.ObjectMeta))
+	g.Expect(merged.Spec.Inherits).To(BeEmpty())
+
+	// Fields not set in the derived ClusterClass are inherited.
+	g.Expect(merged.Spec.Infrastructure.Ref).To(Equal(ref("base-infra")))
+	g.Expect(merged.Spec.ControlPlane.MachineInfrastructure.Ref).To(Equal(ref("base-cp-machine")))
+	g.Expect(merged.Spec.VariablesDiscovery).To(Equal(&clusterv1.VariablesDiscovery{URL: "https://discovery.example.com"}))
+
+	// Fields set in the derived ClusterClass take precedence.
+	g.Expect(merged.Spec.ControlPlane.Ref).To(Equal(ref("prod-cp")))
+	g.Expect(merged.Spec.ControlPlane.Metadata.Labels).To(Equal(map[string]string{"tier": "prod", "base": ""}))
+
+	// MachineDeployment classes, MachinePool classes, variables and patches with the same name are replaced in place, new ones are appended.
+	g.Expect(merged.Spec.Workers.MachineDeployments).To(Equal([]clusterv1.MachineDeploymentClass{
+		mdClass("default-worker", "base-worker"), mdClass("gpu-worker", "prod-gpu"), mdClass("arm-worker", "prod-arm"),
+	}))
+	g.Expect(merged.Spec.Workers.MachinePools).To(Equal([]clusterv1.MachinePoolClass{
+		mpClass("default-pool", "prod-pool"), mpClass("spot-pool", "prod-spot"),
+	}))
+	g.Expect(merged.Spec.Variables).To(Equal([]clusterv1.ClusterClassVariable{
+		variable("region", true), variable("flavor", false), variable("zone", false),
+	}))
+	g.Expect(merged.Spec.Patches).To(Equal([]clusterv1.ClusterClassPatch{
+		patch("region", "GenericTemplate"), patch("flavor", "GenericControlPlaneTemplate"), patch("zone", "GenericTemplate"),
+	}))
+
+	// The input ClusterClasses are not modified.
+	g.Expect(base.Spec.Workers.MachineDeployments).To(HaveLen(2))
+	g.Expect(base.Spec.ControlPlane.Metadata.Labels).To(Equal(map[string]string{"tier": "base", "base": ""}))
+}
+
+func TestResolve(t *testing.T) {
+	newClusterClass := func(name, inherits string, variables ...string) *clusterv1.ClusterClass {
+		cc := &clusterv1.ClusterClass{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       clusterv1.ClusterClassSpec{Inherits: inherits},
+		}
+		for _, v := range variables {
+			cc.Spec.Variables = append(cc.Spec.Variables, clusterv1.ClusterClassVariable{Name: v})
+		}
+		return cc
+	}
+
+	tests := []struct {
+		name          string
+		objs          []client.Object
+		clusterClass  *clusterv1.ClusterClass
+		wantVariables []string
+		wantErr       func(error) bool
+	}{
+		{
+			name:          "returns a copy of a ClusterClass not inheriting from other ClusterClasses",
+			clusterClass:  newClusterClass("standalone", "", "a"),
+			wantVariables: []string{"a"},
+		},
+		{
+			name: "resolves a chain of inherited ClusterClasses",
+			objs: []client.Object{
+				newClusterClass("root", "", "a"),
+				newClusterClass("base", "root", "b"),
+			},
+			clusterClass:  newClusterClass("derived", "base", "c"),
+			wantVariables: []string{"a", "b", "c"},
+		},
+		{
+			name:         "fails when the inherited ClusterClass does not exist",
+			clusterClass: newClusterClass("derived", "base"),
+			wantErr: func(err error) bool {
+				return apierrors.IsNotFound(errors.Cause(err))
+			},
+		},
+		{
+			name: "fails when there is an inheritance cycle",
+			objs: []client.Object{
+				newClusterClass("a", "b"),
+				newClusterClass("b", "a"),
+			},
+			clusterClass: newClusterClass("a", "b"),
+			wantErr: func(err error) bool {
+				return errors.Cause(err) == ErrCycle
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objs...).Build()
+
+			got, err := Resolve(context.Background(), c, tt.clusterClass)
+			if tt.wantErr != nil {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(tt.wantErr(err)).To(BeTrue())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).NotTo(BeIdenticalTo(tt.clusterClass))
+			g.Expect(got.Name).To(Equal(tt.clusterClass.Name))
+			g.Expect(got.Spec.Inherits).To(BeEmpty())
+
+			variables := []string{}
+			for _, v := range got.Spec.Variables {
+				variables = append(variables, v.Name)
+			}
+			g.Expect(variables).To(Equal(tt.wantVariables))
+		})
+	}
+}
+
+func TestInheritingClusterClasses(t *testing.T) {
+	g := NewWithT(t)
+
+	newClusterClass := func(name, inherits string) clusterv1.ClusterClass {
+		return clusterv1.ClusterClass{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       clusterv1.ClusterClassSpec{Inherits: inherits},
+		}
+	}
+	clusterClasses := []clusterv1.ClusterClass{
+		newClusterClass("root", ""),
+		newClusterClass("base", "root"),
+		newClusterClass("dev", "base"),
+		newClusterClass("prod", "base"),
+		newClusterClass("other", ""),
+		newClusterClass("cycle-a", "cycle-b"),
+		newClusterClass("cycle-b", "cycle-a"),
+	}
+
+	g.Expect(InheritingClusterClasses(clusterClasses, "root").List()).To(Equal([]string{"base", "dev", "prod"}))
+	g.Expect(InheritingClusterClasses(clusterClasses, "base").List()).To(Equal([]string{"dev", "prod"}))
+	g.Expect(InheritingClusterClasses(clusterClasses, "dev").List()).To(BeEmpty())
+	g.Expect(InheritingClusterClasses(clusterClasses, "cycle-a").List()).To(Equal([]string{"cycle-b"}))
+}
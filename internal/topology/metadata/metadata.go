@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metadata implements the expansion of template tokens in the metadata defined in a ClusterClass.
+package metadata
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MachineDeploymentValues are the values available when expanding template tokens
+// in the metadata of a MachineDeployment class.
+type MachineDeploymentValues struct {
+	ClusterName      string
+	ClusterNamespace string
+	TopologyName     string
+	Class            string
+	FailureDomain    string
+}
+
+// data returns the data the templates are executed against.
+// NOTE: keys are lower case so tokens look like {{ .cluster.name }}.
+func (v MachineDeploymentValues) data() map[string]interface{} {
+	return map[string]interface{}{
+		"cluster": map[string]interface{}{
+			"name":      v.ClusterName,
+			"namespace": v.ClusterNamespace,
+		},
+		"machineDeployment": map[string]interface{}{
+			"topologyName":  v.TopologyName,
+			"class":         v.Class,
+			"failureDomain": v.FailureDomain,
+		},
+	}
+}
+
+// ValidateMachineDeploymentClassMetadata validates the template tokens used in label and annotation values of
+// a MachineDeployment class, by expanding them with placeholder values.
+func ValidateMachineDeploymentClassMetadata(metadata clusterv1.ObjectMeta) error {
+	placeholders := MachineDeploymentValues{
+		ClusterName:      "cluster",
+		ClusterNamespace: "namespace",
+		TopologyName:     "topology",
+		Class:            "class",
+		FailureDomain:    "failure-domain",
+	}
+	_, err := ExpandMachineDeploymentClassMetadata(metadata, placeholders)
+	return err
+}
+
+// ExpandMachineDeploymentClassMetadata returns a copy of the metadata of a MachineDeployment class with all the
+// template tokens in label and annotation values expanded using the given values.
+// NOTE: Values without template tokens are returned unchanged.
+func ExpandMachineDeploymentClassMetadata(metadata clusterv1.ObjectMeta, values MachineDeploymentValues) (clusterv1.ObjectMeta, error) {
+	data := values.data()
+
+	var errs []error
+	labels, err := expandMap(metadata.Labels, data)
+	if err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to expand labels"))
+	}
+	for k, v := range labels {
+		if msgs := validation.IsValidLabelValue(v); len(msgs) > 0 {
+			errs = append(errs, errors.Errorf("label %q has invalid value %q after expansion: %s", k, v, strings.Join(msgs, "; ")))
+		}
+	}
+
+	annotations, err := expandMap(metadata.Annotations, data)
+	if err != nil {
+		errs = append(errs, errors.Wrap(err, "failed to expand annotations"))
+	}
+
+	if len(errs) > 0 {
+		return clusterv1.ObjectMeta{}, kerrors.NewAggregate(errs)
+	}
+	return clusterv1.ObjectMeta{Labels: labels, Annotations: annotations}, nil
+}
+
+func expandMap(in map[string]string, data map[string]interface{}) (map[string]string, error) {
+	if in == nil {
+		return nil, nil
+	}
+
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		expanded, err := expand(v, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to expand value of %q", k)
+		}
+		out[k] = expanded
+	}
+	return out, nil
+}
+
+func expand(value string, data map[string]interface{}) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+
+	tpl, err := template.New("value").Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse template")
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "failed to execute template")
+	}
+	return buf.String(), nil
+}
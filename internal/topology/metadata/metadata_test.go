@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestExpandMachineDeploymentClassMetadata(t *testing.T) {
+	values := MachineDeploymentValues{
+		ClusterName:      "cluster1",
+		ClusterNamespace: "ns1",
+		TopologyName:     "md1",
+		Class:            "linux-worker",
+		FailureDomain:    "fd1",
+	}
+
+	tests := []struct {
+		name     string
+		metadata clusterv1.ObjectMeta
+		want     clusterv1.ObjectMeta
+		wantErr  bool
+	}{
+		{
+			name:     "empty metadata",
+			metadata: clusterv1.ObjectMeta{},
+			want:     clusterv1.ObjectMeta{},
+		},
+		{
+			name: "values without tokens are preserved",
+			metadata: clusterv1.ObjectMeta{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"baz": "{ not a token }"},
+			},
+			want: clusterv1.ObjectMeta{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"baz": "{ not a token }"},
+			},
+		},
+		{
+			name: "tokens are expanded",
+			metadata: clusterv1.ObjectMeta{
+				Labels: map[string]string{
+					"cluster":  "{{ .cluster.name }}",
+					"pool":     "{{ .machineDeployment.class }}-{{ .machineDeployment.topologyName }}",
+					"location": "{{ .machineDeployment.failureDomain }}",
+				},
+				Annotations: map[string]string{
+					"owner": "{{ .cluster.namespace }}/{{ .cluster.name }}",
+				},
+			},
+			want: clusterv1.ObjectMeta{
+				Labels: map[string]string{
+					"cluster":  "cluster1",
+					"pool":     "linux-worker-md1",
+					"location": "fd1",
+				},
+				Annotations: map[string]string{
+					"owner": "ns1/cluster1",
+				},
+			},
+		},
+		{
+			name: "fails for unknown tokens",
+			metadata: clusterv1.ObjectMeta{
+				Labels: map[string]string{"foo": "{{ .cluster.unknown }}"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails for invalid templates",
+			metadata: clusterv1.ObjectMeta{
+				Annotations: map[string]string{"foo": "{{ .cluster.name "},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails for labels with invalid values after expansion",
+			metadata: clusterv1.ObjectMeta{
+				Labels: map[string]string{"owner": "{{ .cluster.namespace }}/{{ .cluster.name }}"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := ExpandMachineDeploymentClassMetadata(tt.metadata, values)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestValidateMachineDeploymentClassMetadata(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ValidateMachineDeploymentClassMetadata(clusterv1.ObjectMeta{
+		Labels: map[string]string{"pool": "{{ .machineDeployment.class }}"},
+	})).To(Succeed())
+	g.Expect(ValidateMachineDeploymentClassMetadata(clusterv1.ObjectMeta{
+		Labels: map[string]string{"pool": "{{ .machineDeployment.unknown }}"},
+	})).ToNot(Succeed())
+}
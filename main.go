@@ -39,6 +39,7 @@ import (
 	clusterv1alpha4 "sigs.k8s.io/cluster-api/api/v1alpha4"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/api/v1beta1/index"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/controllers"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/controllers/topology"
@@ -50,12 +51,21 @@ import (
 	expv1alpha4 "sigs.k8s.io/cluster-api/exp/api/v1alpha4"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	expcontrollers "sigs.k8s.io/cluster-api/exp/controllers"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	ipamcontrollers "sigs.k8s.io/cluster-api/exp/ipam/controllers"
+	expmetrics "sigs.k8s.io/cluster-api/exp/metrics"
+	operatorv1 "sigs.k8s.io/cluster-api/exp/operator/api/v1alpha1"
+	operatorcontrollers "sigs.k8s.io/cluster-api/exp/operator/controllers"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/flags"
+	"sigs.k8s.io/cluster-api/util/shard"
 	"sigs.k8s.io/cluster-api/version"
 	"sigs.k8s.io/cluster-api/webhooks"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 var (
@@ -70,20 +80,29 @@ var (
 	leaderElectionRetryPeriod     time.Duration
 	watchNamespace                string
 	watchFilterValue              string
+	watchFilterShards             []string
 	profilerAddress               string
 	clusterTopologyConcurrency    int
 	clusterClassConcurrency       int
-	clusterConcurrency            int
-	machineConcurrency            int
-	machineSetConcurrency         int
-	machineDeploymentConcurrency  int
+	variablesDiscoveryURLs        []string
+	clusterOptions                flags.ControllerOptions
+	machineOptions                flags.ControllerOptions
+	machineSetOptions             flags.ControllerOptions
+	machineSetOrphanAdoption      bool
+	machineDeploymentOptions      flags.ControllerOptions
 	machinePoolConcurrency        int
 	clusterResourceSetConcurrency int
 	machineHealthCheckConcurrency int
+	ipAddressClaimConcurrency     int
 	syncPeriod                    time.Duration
+	kubeconfigValidity            time.Duration
+	kubeconfigRotationThreshold   time.Duration
+	clusterDeletionTimeouts       controllers.ClusterDeletionTimeouts
+	controlPlaneEndpointProbe     time.Duration
 	webhookPort                   int
 	webhookCertDir                string
 	healthAddr                    string
+	featureGatesFile              string
 )
 
 func init() {
@@ -103,6 +122,9 @@ func init() {
 	_ = addonsv1alpha3.AddToScheme(scheme)
 	_ = addonsv1alpha4.AddToScheme(scheme)
 	_ = addonsv1.AddToScheme(scheme)
+	_ = operatorv1.AddToScheme(scheme)
+	_ = clusterctlv1.AddToScheme(scheme)
+	_ = ipamv1.AddToScheme(scheme)
 
 	// +kubebuilder:scaffold:scheme
 }
@@ -130,6 +152,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&watchFilterValue, "watch-filter", "",
 		fmt.Sprintf("Label value that the controller watches to reconcile cluster-api objects. Label key is always %s. If unspecified, the controller watches for all cluster-api objects.", clusterv1.WatchLabel))
 
+	fs.StringSliceVar(&watchFilterShards, "watch-filter-shards", nil,
+		fmt.Sprintf("Comma-separated list of the label values watched by all the instances of the controller, each one started with one of them as --watch-filter value. If set, the webhook defaults the %s label of new cluster-api objects to the shard of their Cluster, assigned by consistent hashing.", clusterv1.WatchLabel))
+
 	fs.StringVar(&profilerAddress, "profiler-address", "",
 		"Bind address to expose the pprof profiler (e.g. localhost:6060)")
 
@@ -139,17 +164,19 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&clusterClassConcurrency, "clusterclass-concurrency", 10,
 		"Number of cluster classes to process simultaneously")
 
-	fs.IntVar(&clusterConcurrency, "cluster-concurrency", 10,
-		"Number of clusters to process simultaneously")
+	fs.StringSliceVar(&variablesDiscoveryURLs, "clusterclass-variables-discovery-urls", nil,
+		"Comma-separated list of the URLs of the DiscoverVariables hooks ClusterClasses are allowed to use. Requires the ClusterClassVariablesDiscovery feature gate.")
+
+	flags.AddControllerOptionsFlags(fs, "cluster", "clusters", &clusterOptions)
 
-	fs.IntVar(&machineConcurrency, "machine-concurrency", 10,
-		"Number of machines to process simultaneously")
+	flags.AddControllerOptionsFlags(fs, "machine", "machines", &machineOptions)
 
-	fs.IntVar(&machineSetConcurrency, "machineset-concurrency", 10,
-		"Number of machine sets to process simultaneously")
+	flags.AddControllerOptionsFlags(fs, "machineset", "machine sets", &machineSetOptions)
 
-	fs.IntVar(&machineDeploymentConcurrency, "machinedeployment-concurrency", 10,
-		"Number of machine deployments to process simultaneously")
+	fs.BoolVar(&machineSetOrphanAdoption, "machineset-orphan-adoption", true,
+		"If true, MachineSets adopt orphaned Machines matching their selector; otherwise orphaned Machines are only flagged with the MachineSetOwned condition")
+
+	flags.AddControllerOptionsFlags(fs, "machinedeployment", "machine deployments", &machineDeploymentOptions)
 
 	fs.IntVar(&machinePoolConcurrency, "machinepool-concurrency", 10,
 		"Number of machine pools to process simultaneously")
@@ -160,9 +187,30 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&machineHealthCheckConcurrency, "machinehealthcheck-concurrency", 10,
 		"Number of machine health checks to process simultaneously")
 
+	fs.IntVar(&ipAddressClaimConcurrency, "ipaddressclaim-concurrency", 10,
+		"Number of IP address claims to process simultaneously")
+
 	fs.DurationVar(&syncPeriod, "sync-period", 10*time.Minute,
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)")
 
+	fs.DurationVar(&kubeconfigValidity, "kubeconfig-validity", certs.DefaultCertDuration,
+		"The lifespan of the client certificate of the Kubeconfigs generated for Clusters without a control plane provider")
+
+	fs.DurationVar(&kubeconfigRotationThreshold, "kubeconfig-rotation-threshold", 0,
+		"The remaining validity below which the client certificate of the Kubeconfigs generated for Clusters without a control plane provider is rotated; must be lower than --kubeconfig-validity, defaults to half of it")
+
+	fs.DurationVar(&clusterDeletionTimeouts.Workers, "cluster-deletion-workers-timeout", 0,
+		"How long to wait for the worker Machines of a Cluster being deleted to go away before deleting its control plane; defaults to 0, which means waiting until all the worker Machines are deleted")
+
+	fs.DurationVar(&clusterDeletionTimeouts.ControlPlane, "cluster-deletion-control-plane-timeout", 0,
+		"How long to wait for the control plane of a Cluster being deleted to go away before deleting its infrastructure; defaults to 0, which means waiting until the control plane is deleted")
+
+	fs.DurationVar(&clusterDeletionTimeouts.Infrastructure, "cluster-deletion-infrastructure-timeout", 0,
+		"How long to wait for the infrastructure of a Cluster being deleted to go away before reporting the deletion as timed out; the Cluster is removed only after the infrastructure is deleted")
+
+	fs.DurationVar(&controlPlaneEndpointProbe, "cluster-control-plane-endpoint-probe-interval", 0,
+		"The interval at which the control plane endpoint of the initialized Clusters is probed from the management cluster; defaults to 0, which disables probing")
+
 	fs.IntVar(&webhookPort, "webhook-port", 9443,
 		"Webhook Server port")
 
@@ -173,6 +221,9 @@ func InitFlags(fs *pflag.FlagSet) {
 		"The address the health endpoint binds to.")
 
 	feature.MutableGates.AddFlag(fs)
+
+	fs.StringVar(&featureGatesFile, "feature-gates-file", "",
+		"Path of a file, usually mounted from a ConfigMap, setting the feature gates as a YAML map of feature gate names to booleans; the file is reloaded on changes and the controller restarts when feature gates change value. Feature gates set with --feature-gates take precedence")
 }
 
 func main() {
@@ -185,6 +236,29 @@ func main() {
 
 	ctrl.SetLogger(klogr.New())
 
+	if err := shard.Validate(watchFilterShards, watchFilterValue); err != nil {
+		setupLog.Error(err, "invalid --watch-filter-shards")
+		os.Exit(1)
+	}
+
+	// The client certificate of the generated Kubeconfigs would be rotated at every reconcile otherwise.
+	if kubeconfigValidity <= 0 || kubeconfigRotationThreshold < 0 || kubeconfigRotationThreshold >= kubeconfigValidity {
+		setupLog.Error(fmt.Errorf("--kubeconfig-rotation-threshold (%s) must be lower than --kubeconfig-validity (%s)", kubeconfigRotationThreshold, kubeconfigValidity), "invalid kubeconfig rotation flags")
+		os.Exit(1)
+	}
+
+	for name, o := range map[string]*flags.ControllerOptions{
+		"cluster":           &clusterOptions,
+		"machine":           &machineOptions,
+		"machineset":        &machineSetOptions,
+		"machinedeployment": &machineDeploymentOptions,
+	} {
+		if err := o.Validate(name); err != nil {
+			setupLog.Error(err, "invalid controller options")
+			os.Exit(1)
+		}
+	}
+
 	if profilerAddress != "" {
 		klog.Infof("Profiler listening for requests at %s", profilerAddress)
 		go func() {
@@ -219,12 +293,17 @@ func main() {
 	}
 
 	// Setup the context that's going to be used in controllers and for the manager.
-	ctx := ctrl.SetupSignalHandler()
+	ctx, cancel := context.WithCancel(ctrl.SetupSignalHandler())
 
+	if err := feature.SetupGatesFileWithRestart(mgr, featureGatesFile, cancel); err != nil {
+		setupLog.Error(err, "unable to setup feature gates")
+		os.Exit(1)
+	}
 	setupChecks(mgr)
 	setupIndexes(ctx, mgr)
 	setupReconcilers(ctx, mgr)
 	setupWebhooks(mgr)
+	setupStateMetrics(mgr)
 
 	// +kubebuilder:scaffold:builder
 	setupLog.Info("starting manager", "version", version.Get().String())
@@ -272,7 +351,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		Log:              ctrl.Log.WithName("remote").WithName("ClusterCacheReconciler"),
 		Tracker:          tracker,
 		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(clusterConcurrency)); err != nil {
+	}).SetupWithManager(ctx, mgr, concurrency(clusterOptions.Concurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterCacheReconciler")
 		os.Exit(1)
 	}
@@ -307,6 +386,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			Client:                    mgr.GetClient(),
 			UnstructuredCachingClient: unstructuredCachingClient,
 			WatchFilterValue:          watchFilterValue,
+			VariablesDiscoveryURLs:    variablesDiscoveryURLs,
 		}).SetupWithManager(ctx, mgr, concurrency(clusterClassConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ClusterClass")
 			os.Exit(1)
@@ -330,33 +410,43 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			os.Exit(1)
 		}
 	}
-	if err := (&controllers.ClusterReconciler{
-		Client:           mgr.GetClient(),
-		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(clusterConcurrency)); err != nil {
+	clusterReconciler := &controllers.ClusterReconciler{
+		Client:                      mgr.GetClient(),
+		WatchFilterValue:            watchFilterValue,
+		KubeconfigValidity:          kubeconfigValidity,
+		KubeconfigRotationThreshold: kubeconfigRotationThreshold,
+		DeletionTimeouts:            clusterDeletionTimeouts,
+
+		ControlPlaneEndpointProbeInterval: controlPlaneEndpointProbe,
+	}
+	if err := clusterReconciler.SetupWithManager(ctx, mgr, clusterOptions.ForReconciler(clusterReconciler)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Cluster")
 		os.Exit(1)
 	}
-	if err := (&controllers.MachineReconciler{
+	machineReconciler := &controllers.MachineReconciler{
 		Client:           mgr.GetClient(),
 		Tracker:          tracker,
 		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(machineConcurrency)); err != nil {
+	}
+	if err := machineReconciler.SetupWithManager(ctx, mgr, machineOptions.ForReconciler(machineReconciler)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Machine")
 		os.Exit(1)
 	}
-	if err := (&controllers.MachineSetReconciler{
-		Client:           mgr.GetClient(),
-		Tracker:          tracker,
-		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(machineSetConcurrency)); err != nil {
+	machineSetReconciler := &controllers.MachineSetReconciler{
+		Client:                mgr.GetClient(),
+		Tracker:               tracker,
+		WatchFilterValue:      watchFilterValue,
+		DisableOrphanAdoption: !machineSetOrphanAdoption,
+	}
+	if err := machineSetReconciler.SetupWithManager(ctx, mgr, machineSetOptions.ForReconciler(machineSetReconciler)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachineSet")
 		os.Exit(1)
 	}
-	if err := (&controllers.MachineDeploymentReconciler{
+	machineDeploymentReconciler := &controllers.MachineDeploymentReconciler{
 		Client:           mgr.GetClient(),
 		WatchFilterValue: watchFilterValue,
-	}).SetupWithManager(ctx, mgr, concurrency(machineDeploymentConcurrency)); err != nil {
+	}
+	if err := machineDeploymentReconciler.SetupWithManager(ctx, mgr, machineDeploymentOptions.ForReconciler(machineDeploymentReconciler)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachineDeployment")
 		os.Exit(1)
 	}
@@ -389,6 +479,35 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		}
 	}
 
+	if feature.Gates.Enabled(feature.ProviderOperator) {
+		providers := []operatorv1.GenericProvider{
+			&operatorv1.CoreProvider{},
+			&operatorv1.BootstrapProvider{},
+			&operatorv1.ControlPlaneProvider{},
+			&operatorv1.InfrastructureProvider{},
+		}
+		for _, provider := range providers {
+			if err := (&operatorcontrollers.GenericProviderReconciler{
+				Client:           mgr.GetClient(),
+				Provider:         provider,
+				WatchFilterValue: watchFilterValue,
+			}).SetupWithManager(ctx, mgr, concurrency(1)); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", fmt.Sprintf("%T", provider))
+				os.Exit(1)
+			}
+		}
+	}
+
+	if feature.Gates.Enabled(feature.IPAM) {
+		if err := (&ipamcontrollers.IPAddressClaimReconciler{
+			Client:           mgr.GetClient(),
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, concurrency(ipAddressClaimConcurrency)); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "IPAddressClaim")
+			os.Exit(1)
+		}
+	}
+
 	if err := (&controllers.MachineHealthCheckReconciler{
 		Client:           mgr.GetClient(),
 		Tracker:          tracker,
@@ -402,7 +521,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 func setupWebhooks(mgr ctrl.Manager) {
 	// NOTE: ClusterClass and managed topologies are behind ClusterTopology feature gate flag; the webhook
 	// is going to prevent creating or updating new objects in case the feature flag is disabled.
-	if err := (&webhooks.ClusterClass{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&webhooks.ClusterClass{Client: mgr.GetClient(), VariablesDiscoveryURLs: variablesDiscoveryURLs}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "ClusterClass")
 		os.Exit(1)
 	}
@@ -419,11 +538,21 @@ func setupWebhooks(mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 
+	if err := (&webhooks.Machine{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "MachineProviderID")
+		os.Exit(1)
+	}
+
 	if err := (&clusterv1.MachineSet{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "MachineSet")
 		os.Exit(1)
 	}
 
+	if err := (&webhooks.MachineSet{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "MachineSetSelector")
+		os.Exit(1)
+	}
+
 	if err := (&clusterv1.MachineDeployment{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "MachineDeployment")
 		os.Exit(1)
@@ -441,12 +570,49 @@ func setupWebhooks(mgr ctrl.Manager) {
 			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterResourceSet")
 			os.Exit(1)
 		}
+		if err := (&webhooks.ClusterResourceSet{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterResourceSet")
+			os.Exit(1)
+		}
 	}
 
 	if err := (&clusterv1.MachineHealthCheck{}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "MachineHealthCheck")
 		os.Exit(1)
 	}
+
+	if feature.Gates.Enabled(feature.IPAM) {
+		if err := (&webhooks.IPAddress{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "IPAddress")
+			os.Exit(1)
+		}
+		if err := (&webhooks.IPAddressClaim{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "IPAddressClaim")
+			os.Exit(1)
+		}
+	}
+
+	if err := (&webhooks.ShardLabel{Client: mgr.GetClient(), Shards: watchFilterShards}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "ShardLabel")
+		os.Exit(1)
+	}
+
+	if err := (&webhooks.WellKnownAnnotations{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "WellKnownAnnotations")
+		os.Exit(1)
+	}
+}
+
+func setupStateMetrics(mgr ctrl.Manager) {
+	if !feature.Gates.Enabled(feature.StateMetrics) {
+		return
+	}
+	metrics.Registry.MustRegister(expmetrics.NewCollector(mgr.GetClient(),
+		expmetrics.ClusterMetrics(),
+		expmetrics.MachineMetrics(),
+		expmetrics.MachineSetMetrics(),
+		expmetrics.MachineDeploymentMetrics(),
+	))
 }
 
 func concurrency(c int) controller.Options {
@@ -30,7 +30,6 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
 	"k8s.io/utils/pointer"
 	clusterv1old "sigs.k8s.io/cluster-api/api/v1alpha3"
@@ -78,7 +77,8 @@ type ClusterctlUpgradeSpecInput struct {
 // with the older version of Cluster API and infrastructure provider. It will then create an additional
 // workload cluster (henceforth called secondary workload cluster) from the new management cluster using the default cluster template of the old release
 // then run clusterctl upgrade to the latest version of Cluster API and ensure correct operation by
-// scaling a MachineDeployment.
+// verifying the secondary workload cluster is adopted by the upgraded providers, and by scaling both
+// its control plane and a MachineDeployment.
 //
 // To use this spec the variables INIT_WITH_BINARY and INIT_WITH_PROVIDERS_CONTRACT must be set or specified directly
 // in the spec input. See ClusterctlUpgradeSpecInput for further information.
@@ -308,6 +308,33 @@ func ClusterctlUpgradeSpec(ctx context.Context, inputGetter func() ClusterctlUpg
 		// After upgrading we are sure the version is the latest version of the API,
 		// so it is possible to use the standard helpers
 
+		By("Verifying the test workload cluster is adopted by the upgraded providers")
+		workloadCluster := framework.GetClusterByName(ctx, framework.GetClusterByNameInput{
+			Getter:    managementClusterProxy.GetClient(),
+			Name:      workLoadClusterName,
+			Namespace: testNamespace.Name,
+		})
+		workloadControlPlane := framework.GetKubeadmControlPlaneByCluster(ctx, framework.GetKubeadmControlPlaneByClusterInput{
+			Lister:      managementClusterProxy.GetClient(),
+			ClusterName: workLoadClusterName,
+			Namespace:   testNamespace.Name,
+		})
+		Expect(workloadControlPlane).ToNot(BeNil(), "Failed to get the KubeadmControlPlane for the test workload cluster")
+		framework.WaitForControlPlaneAndMachinesReady(ctx, framework.WaitForControlPlaneAndMachinesReadyInput{
+			GetLister:    managementClusterProxy.GetClient(),
+			Cluster:      workloadCluster,
+			ControlPlane: workloadControlPlane,
+		}, input.E2EConfig.GetIntervals(specName, "wait-control-plane")...)
+
+		By("Scaling the control plane and the MachineDeployment of the test workload cluster")
+		framework.ScaleAndWaitControlPlane(ctx, framework.ScaleAndWaitControlPlaneInput{
+			ClusterProxy:        managementClusterProxy,
+			Cluster:             workloadCluster,
+			ControlPlane:        workloadControlPlane,
+			Replicas:            3,
+			WaitForControlPlane: input.E2EConfig.GetIntervals(specName, "wait-control-plane"),
+		})
+
 		testMachineDeployments := framework.GetMachineDeploymentsByCluster(ctx, framework.GetMachineDeploymentsByClusterInput{
 			Lister:      managementClusterProxy.GetClient(),
 			ClusterName: workLoadClusterName,
@@ -316,7 +343,7 @@ func ClusterctlUpgradeSpec(ctx context.Context, inputGetter func() ClusterctlUpg
 
 		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
 			ClusterProxy:              managementClusterProxy,
-			Cluster:                   &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace.Name}},
+			Cluster:                   workloadCluster,
 			MachineDeployment:         testMachineDeployments[0],
 			Replicas:                  2,
 			WaitForMachineDeployments: input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
@@ -41,6 +41,10 @@ const (
 	EtcdVersionUpgradeTo         = "ETCD_VERSION_UPGRADE_TO"
 	CoreDNSVersionUpgradeTo      = "COREDNS_VERSION_UPGRADE_TO"
 	IPFamily                     = "IP_FAMILY"
+	MaxReconcileErrorRate        = "MAX_RECONCILE_ERROR_RATE"
+	ScaleClusterCount            = "SCALE_CLUSTER_COUNT"
+	ScaleConcurrency             = "SCALE_CONCURRENCY"
+	ScaleProvisioningP95Budget   = "SCALE_PROVISIONING_P95_BUDGET"
 )
 
 func Byf(format string, a ...interface{}) {
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -157,13 +158,20 @@ var _ = SynchronizedAfterSuite(func() {
 }, func() {
 	// After all ParallelNodes.
 
+	// The management cluster is torn down even if the assertions on the controller metrics below fail;
+	// those assertions require the controllers to be still running, so they can't run after the tear down.
+	defer func() {
+		By("Tearing down the management cluster")
+		if !skipCleanup {
+			tearDown(bootstrapClusterProvider, bootstrapClusterProxy)
+		}
+	}()
+
 	By("Dumping logs from the bootstrap cluster")
 	dumpBootstrapClusterLogs(bootstrapClusterProxy)
 
-	By("Tearing down the management cluster")
-	if !skipCleanup {
-		tearDown(bootstrapClusterProvider, bootstrapClusterProxy)
-	}
+	By("Asserting invariants on the metrics of the bootstrap cluster controllers")
+	assertBootstrapClusterControllerMetrics(bootstrapClusterProxy)
 })
 
 func initScheme() *runtime.Scheme {
@@ -266,6 +274,34 @@ func dumpBootstrapClusterLogs(bootstrapClusterProxy framework.ClusterProxy) {
 	}
 }
 
+func assertBootstrapClusterControllerMetrics(bootstrapClusterProxy framework.ClusterProxy) {
+	if bootstrapClusterProxy == nil {
+		return
+	}
+
+	controllersDeployments := framework.GetControllerDeployments(ctx, framework.GetControllerDeploymentsInput{
+		Lister: bootstrapClusterProxy.GetClient(),
+	})
+	if len(controllersDeployments) == 0 {
+		return
+	}
+
+	var maxReconcileErrorRate *float64
+	if e2eConfig.HasVariable(MaxReconcileErrorRate) {
+		rate, err := strconv.ParseFloat(e2eConfig.GetVariable(MaxReconcileErrorRate), 64)
+		Expect(err).ToNot(HaveOccurred(), "Invalid %s variable", MaxReconcileErrorRate)
+		maxReconcileErrorRate = &rate
+	}
+
+	framework.AssertControllerMetrics(ctx, framework.AssertControllerMetricsInput{
+		GetLister:             bootstrapClusterProxy.GetClient(),
+		ClientSet:             bootstrapClusterProxy.GetClientSet(),
+		Deployments:           controllersDeployments,
+		MetricsPath:           filepath.Join(artifactFolder, "clusters", bootstrapClusterProxy.GetName(), "controllers"),
+		MaxReconcileErrorRate: maxReconcileErrorRate,
+	})
+}
+
 func tearDown(bootstrapClusterProvider bootstrap.ClusterProvider, bootstrapClusterProxy framework.ClusterProxy) {
 	if bootstrapClusterProxy != nil {
 		bootstrapClusterProxy.Dispose(ctx)
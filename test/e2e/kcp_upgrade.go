@@ -40,6 +40,9 @@ type KCPUpgradeSpecInput struct {
 	SkipCleanup              bool
 	ControlPlaneMachineCount int64
 	Flavor                   string
+
+	// AddonImages are additional addons whose images are expected to be upgraded together with the control plane.
+	AddonImages []framework.AddonImage
 }
 
 // KCPUpgradeSpec implements a test that verifies KCP to properly upgrade a control plane.
@@ -102,6 +105,7 @@ func KCPUpgradeSpec(ctx context.Context, inputGetter func() KCPUpgradeSpecInput)
 			WaitForMachinesToBeUpgraded: input.E2EConfig.GetIntervals(specName, "wait-machine-upgrade"),
 			WaitForDNSUpgrade:           input.E2EConfig.GetIntervals(specName, "wait-machine-upgrade"),
 			WaitForEtcdUpgrade:          input.E2EConfig.GetIntervals(specName, "wait-machine-upgrade"),
+			AddonImages:                 input.AddonImages,
 		})
 
 		By("PASSED!")
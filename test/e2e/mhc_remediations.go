@@ -52,9 +52,16 @@ type MachineRemediationSpecInput struct {
 	// condition with a short timeout.
 	// If not specified, "md-remediation" is used.
 	MDFlavor *string
+
+	// KCPNodeFailureFlavor, if specified, must refer to a template that has a MachineHealthCheck
+	// resource configured to match the control plane Machines and be configured to treat
+	// the Node Ready condition being "False" or "Unknown" as an unhealthy condition with a short timeout.
+	// If not specified, "node-failure" is used.
+	KCPNodeFailureFlavor *string
 }
 
 // MachineRemediationSpec implements a test that verifies that Machines are remediated by MHC during unhealthy conditions.
+// NOTE: The test stopping the kubelet of a control plane Machine requires Machines provisioned by the docker infrastructure provider.
 func MachineRemediationSpec(ctx context.Context, inputGetter func() MachineRemediationSpecInput) {
 	var (
 		specName         = "mhc-remediation"
@@ -142,6 +149,64 @@ func MachineRemediationSpec(ctx context.Context, inputGetter func() MachineRemed
 		By("PASSED!")
 	})
 
+	It("Should successfully trigger KCP remediation of a control plane Machine whose kubelet has been stopped while maintaining etcd quorum", func() {
+		By("Creating a workload cluster")
+
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: input.BootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:                filepath.Join(input.ArtifactFolder, "clusters", input.BootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:     input.ClusterctlConfigPath,
+				KubeconfigPath:           input.BootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+				Flavor:                   pointer.StringDeref(input.KCPNodeFailureFlavor, "node-failure"),
+				Namespace:                namespace.Name,
+				ClusterName:              fmt.Sprintf("%s-%s", specName, util.RandomString(6)),
+				KubernetesVersion:        input.E2EConfig.GetVariable(KubernetesVersion),
+				ControlPlaneMachineCount: pointer.Int64Ptr(3),
+				WorkerMachineCount:       pointer.Int64Ptr(0),
+			},
+			WaitForClusterIntervals:      input.E2EConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: input.E2EConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		mgmtClient := input.BootstrapClusterProxy.GetClient()
+		machines := framework.GetControlPlaneMachinesByCluster(ctx, framework.GetControlPlaneMachinesByClusterInput{
+			Lister:      mgmtClient,
+			ClusterName: clusterResources.Cluster.Name,
+			Namespace:   clusterResources.Cluster.Namespace,
+		})
+		Expect(machines).To(HaveLen(3))
+		unhealthyMachine := &machines[0]
+
+		By("Stopping the kubelet of a control plane Machine")
+		framework.InjectNodeFailure(ctx, framework.InjectNodeFailureInput{
+			Machine: unhealthyMachine,
+			Failure: framework.NodeFailureStopKubelet,
+		})
+
+		By("Waiting for the MachineHealthCheck to mark the control plane Machine unhealthy")
+		framework.WaitForMachineToBeMarkedUnhealthy(ctx, framework.WaitForMachineToBeMarkedUnhealthyInput{
+			Getter:  mgmtClient,
+			Machine: unhealthyMachine,
+		}, input.E2EConfig.GetIntervals(specName, "wait-machine-unhealthy")...)
+
+		By("Waiting for KubeadmControlPlane remediation while checking etcd quorum is maintained")
+		framework.WaitForControlPlaneMachineToBeRemediated(ctx, framework.WaitForControlPlaneMachineToBeRemediatedInput{
+			Lister:       mgmtClient,
+			ControlPlane: clusterResources.ControlPlane,
+			Machine:      unhealthyMachine,
+		}, input.E2EConfig.GetIntervals(specName, "wait-control-plane-remediation")...)
+		framework.WaitForControlPlaneAndMachinesReady(ctx, framework.WaitForControlPlaneAndMachinesReadyInput{
+			GetLister:    mgmtClient,
+			Cluster:      clusterResources.Cluster,
+			ControlPlane: clusterResources.ControlPlane,
+		}, input.E2EConfig.GetIntervals(specName, "wait-control-plane")...)
+
+		By("PASSED!")
+	})
+
 	AfterEach(func() {
 		// Dumps all the resources in the spec namespace, then cleanups the cluster object and the spec namespace itself.
 		dumpSpecResourcesAndCleanup(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder, namespace, cancelWatches, clusterResources.Cluster, input.E2EConfig.GetIntervals, input.SkipCleanup)
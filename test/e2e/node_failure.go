@@ -0,0 +1,204 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// NodeFailureSpecInput is the input for NodeFailureSpec.
+type NodeFailureSpecInput struct {
+	E2EConfig             *clusterctl.E2EConfig
+	ClusterctlConfigPath  string
+	BootstrapClusterProxy framework.ClusterProxy
+	ArtifactFolder        string
+	SkipCleanup           bool
+
+	// Flavor, if specified, must refer to a template that has MachineHealthCheck resources configured to match
+	// both the control plane Machines and the MachineDeployment managed Machines, and configured to treat
+	// the Node Ready condition being "False" or "Unknown" as an unhealthy condition with a short timeout.
+	// If not specified, "node-failure" is used.
+	Flavor *string
+}
+
+// NodeFailureSpec implements a test that injects failures into the Machines of a workload cluster using the
+// framework chaos helpers, and verifies that MachineHealthCheck remediation and KCP etcd health checks behave as expected.
+// NOTE: This spec requires Machines provisioned by the docker infrastructure provider.
+func NodeFailureSpec(ctx context.Context, inputGetter func() NodeFailureSpecInput) {
+	var (
+		specName         = "node-failure"
+		input            NodeFailureSpecInput
+		namespace        *corev1.Namespace
+		cancelWatches    context.CancelFunc
+		clusterResources *clusterctl.ApplyClusterTemplateAndWaitResult
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		input = inputGetter()
+		Expect(input.E2EConfig).ToNot(BeNil(), "Invalid argument. input.E2EConfig can't be nil when calling %s spec", specName)
+		Expect(input.ClusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. input.ClusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(input.BootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. input.BootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(input.ArtifactFolder, 0750)).To(Succeed(), "Invalid argument. input.ArtifactFolder can't be created for %s spec", specName)
+		Expect(input.E2EConfig.Variables).To(HaveKey(KubernetesVersion))
+
+		// Setup a Namespace where to host objects for this spec and create a watcher for the namespace events.
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder)
+		clusterResources = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	It("Should remediate a worker Machine whose Node has been killed", func() {
+		By("Creating a workload cluster")
+
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: input.BootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:                filepath.Join(input.ArtifactFolder, "clusters", input.BootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:     input.ClusterctlConfigPath,
+				KubeconfigPath:           input.BootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+				Flavor:                   pointer.StringDeref(input.Flavor, "node-failure"),
+				Namespace:                namespace.Name,
+				ClusterName:              fmt.Sprintf("%s-%s", specName, util.RandomString(6)),
+				KubernetesVersion:        input.E2EConfig.GetVariable(KubernetesVersion),
+				ControlPlaneMachineCount: pointer.Int64Ptr(1),
+				WorkerMachineCount:       pointer.Int64Ptr(1),
+			},
+			WaitForClusterIntervals:      input.E2EConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: input.E2EConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		mgmtClient := input.BootstrapClusterProxy.GetClient()
+		machineDeployment := clusterResources.MachineDeployments[0]
+		machines := framework.GetMachinesByMachineDeployments(ctx, framework.GetMachinesByMachineDeploymentsInput{
+			Lister:            mgmtClient,
+			ClusterName:       clusterResources.Cluster.Name,
+			Namespace:         clusterResources.Cluster.Namespace,
+			MachineDeployment: *machineDeployment,
+		})
+		Expect(machines).NotTo(BeEmpty())
+
+		By("Killing the Node of a worker Machine")
+		framework.InjectNodeFailure(ctx, framework.InjectNodeFailureInput{
+			Machine: &machines[0],
+			Failure: framework.NodeFailureKill,
+		})
+
+		By("Waiting for the worker Machine to be remediated")
+		framework.WaitForMachineToBeDeleted(ctx, framework.WaitForMachineToBeDeletedInput{
+			Getter:  mgmtClient,
+			Machine: &machines[0],
+		}, input.E2EConfig.GetIntervals(specName, "wait-machine-remediation")...)
+		framework.WaitForMachineDeploymentNodesToExist(ctx, framework.WaitForMachineDeploymentNodesToExistInput{
+			Lister:            mgmtClient,
+			Cluster:           clusterResources.Cluster,
+			MachineDeployment: machineDeployment,
+		}, input.E2EConfig.GetIntervals(specName, "wait-worker-nodes")...)
+
+		By("PASSED!")
+	})
+
+	It("Should recover from etcd member isolation and remediate a control plane Machine whose kubelet has been stopped", func() {
+		By("Creating a workload cluster")
+
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: input.BootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:                filepath.Join(input.ArtifactFolder, "clusters", input.BootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:     input.ClusterctlConfigPath,
+				KubeconfigPath:           input.BootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+				Flavor:                   pointer.StringDeref(input.Flavor, "node-failure"),
+				Namespace:                namespace.Name,
+				ClusterName:              fmt.Sprintf("%s-%s", specName, util.RandomString(6)),
+				KubernetesVersion:        input.E2EConfig.GetVariable(KubernetesVersion),
+				ControlPlaneMachineCount: pointer.Int64Ptr(3),
+				WorkerMachineCount:       pointer.Int64Ptr(0),
+			},
+			WaitForClusterIntervals:      input.E2EConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: input.E2EConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
+		}, clusterResources)
+
+		mgmtClient := input.BootstrapClusterProxy.GetClient()
+		machines := framework.GetControlPlaneMachinesByCluster(ctx, framework.GetControlPlaneMachinesByClusterInput{
+			Lister:      mgmtClient,
+			ClusterName: clusterResources.Cluster.Name,
+			Namespace:   clusterResources.Cluster.Namespace,
+		})
+		Expect(machines).To(HaveLen(3))
+
+		By("Isolating the etcd member of a control plane Machine")
+		framework.IsolateEtcdMember(ctx, framework.IsolateEtcdMemberInput{
+			Machine: &machines[0],
+		})
+		framework.WaitForKubeadmControlPlaneCondition(ctx, framework.WaitForKubeadmControlPlaneConditionInput{
+			Getter:        mgmtClient,
+			ControlPlane:  clusterResources.ControlPlane,
+			ConditionType: controlplanev1.EtcdClusterHealthyCondition,
+			Status:        corev1.ConditionFalse,
+		}, input.E2EConfig.GetIntervals(specName, "wait-etcd-member-isolated")...)
+
+		By("Restoring the etcd member and waiting for the etcd cluster to recover")
+		framework.RestoreEtcdMember(ctx, framework.RestoreEtcdMemberInput{
+			Machine: &machines[0],
+		})
+		framework.WaitForKubeadmControlPlaneCondition(ctx, framework.WaitForKubeadmControlPlaneConditionInput{
+			Getter:        mgmtClient,
+			ControlPlane:  clusterResources.ControlPlane,
+			ConditionType: controlplanev1.EtcdClusterHealthyCondition,
+			Status:        corev1.ConditionTrue,
+		}, input.E2EConfig.GetIntervals(specName, "wait-etcd-member-restored")...)
+
+		By("Stopping the kubelet of a control plane Machine")
+		framework.InjectNodeFailure(ctx, framework.InjectNodeFailureInput{
+			Machine: &machines[1],
+			Failure: framework.NodeFailureStopKubelet,
+		})
+
+		By("Waiting for the control plane Machine to be remediated")
+		framework.WaitForMachineToBeDeleted(ctx, framework.WaitForMachineToBeDeletedInput{
+			Getter:  mgmtClient,
+			Machine: &machines[1],
+		}, input.E2EConfig.GetIntervals(specName, "wait-machine-remediation")...)
+		framework.WaitForControlPlaneAndMachinesReady(ctx, framework.WaitForControlPlaneAndMachinesReadyInput{
+			GetLister:    mgmtClient,
+			Cluster:      clusterResources.Cluster,
+			ControlPlane: clusterResources.ControlPlane,
+		}, input.E2EConfig.GetIntervals(specName, "wait-control-plane")...)
+
+		By("PASSED!")
+	})
+
+	AfterEach(func() {
+		// Dumps all the resources in the spec namespace, then cleanups the cluster object and the spec namespace itself.
+		dumpSpecResourcesAndCleanup(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder, namespace, cancelWatches, clusterResources.Cluster, input.E2EConfig.GetIntervals, input.SkipCleanup)
+	})
+}
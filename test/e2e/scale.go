@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+const (
+	defaultScaleClusterCount          = 5
+	defaultScaleProvisioningP95Budget = 10 * time.Minute
+)
+
+// ScaleSpecInput is the input for ScaleSpec.
+type ScaleSpecInput struct {
+	E2EConfig             *clusterctl.E2EConfig
+	ClusterctlConfigPath  string
+	BootstrapClusterProxy framework.ClusterProxy
+	ArtifactFolder        string
+	SkipCleanup           bool
+
+	// Flavor to use when creating the clusters for testing, the default flavor is used if not specified.
+	Flavor *string
+
+	// ClusterCount is the number of workload clusters to create; if not set, the SCALE_CLUSTER_COUNT variable
+	// is used, and if it is not defined, 5 clusters are created.
+	ClusterCount *int64
+
+	// Concurrency is the number of workload clusters created in parallel; if not set, the SCALE_CONCURRENCY
+	// variable is used, and if it is not defined, all the clusters are created in parallel.
+	Concurrency *int64
+
+	// ProvisioningP95Budget is the maximum 95th percentile of the time required for provisioning a cluster;
+	// if not set, the SCALE_PROVISIONING_P95_BUDGET variable is used, and if it is not defined, 10m is used.
+	ProvisioningP95Budget *time.Duration
+}
+
+// ScaleSpec implements a spec that creates many lightweight workload clusters concurrently, measures the time
+// required for provisioning them together with the reconcile latency and the work queue depth of the controllers,
+// and checks that the 95th percentile of the provisioning time is within the configured budget.
+func ScaleSpec(ctx context.Context, inputGetter func() ScaleSpecInput) {
+	var (
+		specName      = "scale"
+		input         ScaleSpecInput
+		namespace     *corev1.Namespace
+		cancelWatches context.CancelFunc
+		clusters      []*clusterv1.Cluster
+		clustersLock  sync.Mutex
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		input = inputGetter()
+		Expect(input.E2EConfig).ToNot(BeNil(), "Invalid argument. input.E2EConfig can't be nil when calling %s spec", specName)
+		Expect(input.ClusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. input.ClusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(input.BootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. input.BootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(input.ArtifactFolder, 0750)).To(Succeed(), "Invalid argument. input.ArtifactFolder can't be created for %s spec", specName)
+		Expect(input.E2EConfig.Variables).To(HaveKey(KubernetesVersion))
+
+		// Setup a Namespace where to host objects for this spec and create a watcher for the namespace events.
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder)
+		clusters = nil
+	})
+
+	It("Should create many workload clusters concurrently within the provisioning budget", func() {
+		clusterCount := scaleInt64Setting(input.E2EConfig, input.ClusterCount, ScaleClusterCount, defaultScaleClusterCount)
+		Expect(clusterCount).To(BeNumerically(">", 0), "The number of clusters to create must be greater than zero")
+		concurrency := scaleInt64Setting(input.E2EConfig, input.Concurrency, ScaleConcurrency, clusterCount)
+		Expect(concurrency).To(BeNumerically(">", 0), "The number of clusters to create in parallel must be greater than zero")
+		budget := defaultScaleProvisioningP95Budget
+		switch {
+		case input.ProvisioningP95Budget != nil:
+			budget = *input.ProvisioningP95Budget
+		case input.E2EConfig.HasVariable(ScaleProvisioningP95Budget):
+			var err error
+			budget, err = time.ParseDuration(input.E2EConfig.GetVariable(ScaleProvisioningP95Budget))
+			Expect(err).ToNot(HaveOccurred(), "Invalid %s variable", ScaleProvisioningP95Budget)
+		}
+
+		Byf("Creating %d workload clusters, %d at a time", clusterCount, concurrency)
+		wg := sync.WaitGroup{}
+		sem := make(chan struct{}, concurrency)
+		for i := int64(0); i < clusterCount; i++ {
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				result := new(clusterctl.ApplyClusterTemplateAndWaitResult)
+				clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+					ClusterProxy: input.BootstrapClusterProxy,
+					ConfigCluster: clusterctl.ConfigClusterInput{
+						LogFolder:                filepath.Join(input.ArtifactFolder, "clusters", input.BootstrapClusterProxy.GetName()),
+						ClusterctlConfigPath:     input.ClusterctlConfigPath,
+						KubeconfigPath:           input.BootstrapClusterProxy.GetKubeconfigPath(),
+						InfrastructureProvider:   clusterctl.DefaultInfrastructureProvider,
+						Flavor:                   pointer.StringDeref(input.Flavor, clusterctl.DefaultFlavor),
+						Namespace:                namespace.Name,
+						ClusterName:              fmt.Sprintf("%s-%s", specName, util.RandomString(6)),
+						KubernetesVersion:        input.E2EConfig.GetVariable(KubernetesVersion),
+						ControlPlaneMachineCount: pointer.Int64Ptr(1),
+						WorkerMachineCount:       pointer.Int64Ptr(0),
+					},
+					WaitForClusterIntervals:      input.E2EConfig.GetIntervals(specName, "wait-cluster"),
+					WaitForControlPlaneIntervals: input.E2EConfig.GetIntervals(specName, "wait-control-plane"),
+					WaitForMachineDeployments:    input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
+					WaitForMachinePools:          input.E2EConfig.GetIntervals(specName, "wait-machine-pool-nodes"),
+				}, result)
+
+				clustersLock.Lock()
+				defer clustersLock.Unlock()
+				clusters = append(clusters, result.Cluster)
+			}()
+		}
+		wg.Wait()
+		Expect(clusters).To(HaveLen(int(clusterCount)), "Failed to create all the workload clusters")
+
+		By("Measuring the time required for provisioning the workload clusters")
+		durations := make([]time.Duration, 0, len(clusters))
+		for _, c := range clusters {
+			var d time.Duration
+			Eventually(func() bool {
+				cluster := framework.GetClusterByName(ctx, framework.GetClusterByNameInput{
+					Getter:    input.BootstrapClusterProxy.GetClient(),
+					Namespace: c.Namespace,
+					Name:      c.Name,
+				})
+				var ok bool
+				d, ok = framework.ClusterProvisioningDuration(cluster)
+				return ok
+			}, input.E2EConfig.GetIntervals(specName, "wait-cluster")...).Should(BeTrue(), "Cluster %s/%s is not ready", c.Namespace, c.Name)
+			Byf("Cluster %s/%s has been provisioned in %s", c.Namespace, c.Name, d)
+			durations = append(durations, d)
+		}
+
+		By("Collecting the controllers metrics")
+		controllersDeployments := framework.GetControllerDeployments(ctx, framework.GetControllerDeploymentsInput{
+			Lister: input.BootstrapClusterProxy.GetClient(),
+		})
+		for _, deployment := range controllersDeployments {
+			podMetrics := framework.ScrapeControllerMetrics(ctx, framework.ScrapeControllerMetricsInput{
+				GetLister:   input.BootstrapClusterProxy.GetClient(),
+				ClientSet:   input.BootstrapClusterProxy.GetClientSet(),
+				Deployment:  deployment,
+				MetricsPath: filepath.Join(input.ArtifactFolder, "scale", namespace.Name),
+			})
+			for podName, metrics := range podMetrics {
+				for controller, latency := range metrics.AverageReconcileTimes() {
+					Byf("Controller %s/%s, pod %s: average reconcile time for %s is %s", deployment.Namespace, deployment.Name, podName, controller, latency)
+				}
+				for queue, depth := range metrics.WorkqueueDepths() {
+					Byf("Controller %s/%s, pod %s: work queue depth for %s is %.0f", deployment.Namespace, deployment.Name, podName, queue, depth)
+				}
+			}
+		}
+
+		p95 := framework.DurationPercentile(durations, 95)
+		Byf("The 95th percentile of the provisioning time is %s, the budget is %s", p95, budget)
+		Expect(p95).To(BeNumerically("<=", budget), "The 95th percentile of the provisioning time is above the budget")
+
+		By("PASSED!")
+	})
+
+	AfterEach(func() {
+		// Dump the logs of all the workload clusters but the first, which is dumped together with all the resources
+		// in the spec namespace before cleaning up the clusters and the spec namespace itself.
+		// NOTE: if no cluster has been created, a placeholder is used for cleaning up the clusters which failed to be created.
+		cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: namespace.Name, Name: specName}}
+		if len(clusters) > 0 {
+			cluster = clusters[0]
+			for _, c := range clusters[1:] {
+				input.BootstrapClusterProxy.CollectWorkloadClusterLogs(ctx, c.Namespace, c.Name, filepath.Join(input.ArtifactFolder, "clusters", c.Name))
+			}
+		}
+		dumpSpecResourcesAndCleanup(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder, namespace, cancelWatches, cluster, input.E2EConfig.GetIntervals, input.SkipCleanup)
+	})
+}
+
+// scaleInt64Setting returns the value of a scale spec setting, reading it from the spec input if set,
+// then from the e2e config variables, falling back to the given default.
+func scaleInt64Setting(e2eConfig *clusterctl.E2EConfig, value *int64, variable string, defaultValue int64) int64 {
+	if value != nil {
+		return *value
+	}
+	if e2eConfig.HasVariable(variable) {
+		v, err := strconv.ParseInt(e2eConfig.GetVariable(variable), 10, 64)
+		Expect(err).ToNot(HaveOccurred(), "Invalid %s variable", variable)
+		return v
+	}
+	return defaultValue
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	containerutil "sigs.k8s.io/cluster-api/util/container"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// AddonImage defines the image expected for a container of an addon running in a workload cluster.
+type AddonImage struct {
+	// Kind is the kind of the addon workload; supported values are DaemonSet, Deployment and StatefulSet.
+	Kind string
+
+	// Name and Namespace of the addon workload.
+	Name      string
+	Namespace string
+
+	// Container is the name of the container to check; if empty, the first container of the pod template is checked.
+	Container string
+
+	// Image is the image expected for the container, e.g. k8s.gcr.io/kube-proxy:v1.22.0.
+	// If Image is only a tag, e.g. ":v1.8.4", only the image tag is checked; this is useful for images whose
+	// repository or name changed over time.
+	Image string
+}
+
+func (a AddonImage) String() string {
+	return fmt.Sprintf("%s %s/%s", a.Kind, a.Namespace, a.Name)
+}
+
+// KubeProxyAddonImage returns the AddonImage expected for kube-proxy after an upgrade to the given Kubernetes version.
+func KubeProxyAddonImage(kubernetesVersion string) AddonImage {
+	return AddonImage{
+		Kind:      "DaemonSet",
+		Name:      "kube-proxy",
+		Namespace: metav1.NamespaceSystem,
+		Image:     "k8s.gcr.io/kube-proxy:" + containerutil.SemverToOCIImageTag(kubernetesVersion),
+	}
+}
+
+// CoreDNSAddonImage returns the AddonImage expected for CoreDNS after an upgrade to the given image tag.
+func CoreDNSAddonImage(imageTag string) AddonImage {
+	return AddonImage{
+		Kind:      "Deployment",
+		Name:      "coredns",
+		Namespace: metav1.NamespaceSystem,
+		// NOTE: coredns image name has changed over time (k8s.gcr.io/coredns,
+		// k8s.gcr.io/coredns/coredns), so we are checking only if the version actually changed.
+		Image: ":" + imageTag,
+	}
+}
+
+// WaitForAddonImagesUpgradeInput is the input for WaitForAddonImagesUpgrade.
+type WaitForAddonImagesUpgradeInput struct {
+	Getter Getter
+	Addons []AddonImage
+}
+
+// WaitForAddonImagesUpgrade waits until the containers of all the addons have the expected image.
+func WaitForAddonImagesUpgrade(ctx context.Context, input WaitForAddonImagesUpgradeInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForAddonImagesUpgrade")
+	Expect(input.Getter).ToNot(BeNil(), "Invalid argument. input.Getter can't be nil when calling WaitForAddonImagesUpgrade")
+
+	for _, addon := range input.Addons {
+		addon := addon
+		By(fmt.Sprintf("Ensuring %s has the correct image", addon))
+
+		Eventually(func() (string, error) {
+			return getAddonImage(ctx, input.Getter, addon)
+		}, intervals...).Should(Satisfy(func(image string) bool { return addonImageMatches(addon.Image, image) }),
+			"%s does not have the expected image %s", addon, addon.Image)
+	}
+}
+
+// getAddonImage returns the image of the container of an addon.
+func getAddonImage(ctx context.Context, getter Getter, addon AddonImage) (string, error) {
+	key := client.ObjectKey{Namespace: addon.Namespace, Name: addon.Name}
+
+	var podSpec corev1.PodSpec
+	switch addon.Kind {
+	case "DaemonSet":
+		ds := &appsv1.DaemonSet{}
+		if err := getter.Get(ctx, key, ds); err != nil {
+			return "", err
+		}
+		podSpec = ds.Spec.Template.Spec
+	case "Deployment":
+		d := &appsv1.Deployment{}
+		if err := getter.Get(ctx, key, d); err != nil {
+			return "", err
+		}
+		podSpec = d.Spec.Template.Spec
+	case "StatefulSet":
+		ss := &appsv1.StatefulSet{}
+		if err := getter.Get(ctx, key, ss); err != nil {
+			return "", err
+		}
+		podSpec = ss.Spec.Template.Spec
+	default:
+		return "", errors.Errorf("unsupported kind %q for addon %s/%s", addon.Kind, addon.Namespace, addon.Name)
+	}
+
+	return containerImage(podSpec, addon.Container)
+}
+
+// containerImage returns the image of the container with the given name, or of the first container if name is empty.
+func containerImage(podSpec corev1.PodSpec, name string) (string, error) {
+	for _, c := range podSpec.Containers {
+		if name == "" || c.Name == name {
+			return c.Image, nil
+		}
+	}
+	if name == "" {
+		return "", errors.New("pod template does not have containers")
+	}
+	return "", errors.Errorf("pod template does not have a container named %q", name)
+}
+
+// addonImageMatches returns true if image matches the expected image; if expected is only a tag, e.g. ":v1.8.4",
+// only the tag of the image is compared.
+func addonImageMatches(expected, image string) bool {
+	if strings.HasPrefix(expected, ":") {
+		parsed, err := containerutil.ImageFromString(image)
+		if err != nil {
+			return false
+		}
+		return ":"+parsed.Tag == expected
+	}
+	return image == expected
+}
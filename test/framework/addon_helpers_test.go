@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAddonImageMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		image    string
+		want     bool
+	}{
+		{
+			name:     "same image",
+			expected: "k8s.gcr.io/kube-proxy:v1.22.0",
+			image:    "k8s.gcr.io/kube-proxy:v1.22.0",
+			want:     true,
+		},
+		{
+			name:     "different tag",
+			expected: "k8s.gcr.io/kube-proxy:v1.22.0",
+			image:    "k8s.gcr.io/kube-proxy:v1.21.2",
+			want:     false,
+		},
+		{
+			name:     "different repository",
+			expected: "k8s.gcr.io/kube-proxy:v1.22.0",
+			image:    "gcr.io/k8s-staging-ci-images/kube-proxy:v1.22.0",
+			want:     false,
+		},
+		{
+			name:     "only tag, same tag with a different image name",
+			expected: ":v1.8.4",
+			image:    "k8s.gcr.io/coredns/coredns:v1.8.4",
+			want:     true,
+		},
+		{
+			name:     "only tag, tag with the same suffix",
+			expected: ":1.8.4",
+			image:    "k8s.gcr.io/coredns:v1.8.4",
+			want:     false,
+		},
+		{
+			name:     "only tag, invalid image",
+			expected: ":v1.8.4",
+			image:    "INVALID:v1.8.4",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(addonImageMatches(tt.expected, tt.image)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestContainerImage(t *testing.T) {
+	g := NewWithT(t)
+
+	podSpec := corev1.PodSpec{
+		Containers: []corev1.Container{
+			{Name: "manager", Image: "manager:v1"},
+			{Name: "sidecar", Image: "sidecar:v2"},
+		},
+	}
+
+	image, err := containerImage(podSpec, "")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(image).To(Equal("manager:v1"))
+
+	image, err = containerImage(podSpec, "sidecar")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(image).To(Equal("sidecar:v2"))
+
+	_, err = containerImage(podSpec, "not-found")
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = containerImage(corev1.PodSpec{}, "")
+	g.Expect(err).To(HaveOccurred())
+}
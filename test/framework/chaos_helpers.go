@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/infrastructure/container"
+)
+
+// NOTE: The chaos helpers in this file inject failures by operating on the containers hosting the Machines,
+// and thus they only support Machines provisioned by the docker infrastructure provider (CAPD).
+
+// NodeFailure defines a failure that can be injected into the Node hosting a Machine.
+type NodeFailure string
+
+const (
+	// NodeFailureKill kills the container hosting the Machine, simulating an abrupt loss of the host.
+	NodeFailureKill NodeFailure = "Kill"
+
+	// NodeFailureStopKubelet stops the kubelet on the Machine, simulating a Node that stops reporting its status
+	// while the workloads, including the control plane static pods, keep running.
+	NodeFailureStopKubelet NodeFailure = "StopKubelet"
+)
+
+// chaosIptablesComment is the comment added to the iptables rules created by the chaos helpers, so they can be
+// identified and removed.
+const chaosIptablesComment = "capi-e2e-chaos"
+
+// etcdPeerPort is the port used by etcd members to communicate with each other.
+const etcdPeerPort = "2380"
+
+// InjectNodeFailureInput is the input for InjectNodeFailure.
+type InjectNodeFailureInput struct {
+	Machine *clusterv1.Machine
+	Failure NodeFailure
+}
+
+// InjectNodeFailure injects a failure into the Node hosting a Machine.
+func InjectNodeFailure(ctx context.Context, input InjectNodeFailureInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for InjectNodeFailure")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling InjectNodeFailure")
+
+	containerRuntime, err := container.NewDockerClient()
+	Expect(err).ToNot(HaveOccurred(), "Failed to get the container runtime")
+	containerName := machineContainerName(input.Machine.Spec.ClusterName, input.Machine.Name)
+
+	fmt.Fprintf(GinkgoWriter, "Injecting %s failure into Machine %s/%s\n", input.Failure, input.Machine.Namespace, input.Machine.Name)
+	switch input.Failure {
+	case NodeFailureKill:
+		Expect(containerRuntime.KillContainer(ctx, containerName, "SIGKILL")).To(Succeed(), "Failed to kill container %s", containerName)
+	case NodeFailureStopKubelet:
+		execOnContainer(ctx, containerRuntime, containerName, "systemctl", "stop", "kubelet")
+	default:
+		Fail(fmt.Sprintf("Invalid argument. input.Failure %q is not supported when calling InjectNodeFailure", input.Failure))
+	}
+}
+
+// IsolateEtcdMemberInput is the input for IsolateEtcdMember.
+type IsolateEtcdMemberInput struct {
+	Machine *clusterv1.Machine
+}
+
+// IsolateEtcdMember creates a network partition between the etcd member hosted on a control plane Machine and all the
+// other etcd members, by dropping the etcd peer traffic to and from the Machine.
+// Use RestoreEtcdMember to remove the partition.
+func IsolateEtcdMember(ctx context.Context, input IsolateEtcdMemberInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for IsolateEtcdMember")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling IsolateEtcdMember")
+
+	containerRuntime, err := container.NewDockerClient()
+	Expect(err).ToNot(HaveOccurred(), "Failed to get the container runtime")
+	containerName := machineContainerName(input.Machine.Spec.ClusterName, input.Machine.Name)
+
+	fmt.Fprintf(GinkgoWriter, "Isolating the etcd member on Machine %s/%s\n", input.Machine.Namespace, input.Machine.Name)
+	for _, rule := range etcdIsolationRules() {
+		execOnContainer(ctx, containerRuntime, containerName, "iptables", append([]string{"-I"}, rule...)...)
+	}
+}
+
+// RestoreEtcdMemberInput is the input for RestoreEtcdMember.
+type RestoreEtcdMemberInput struct {
+	Machine *clusterv1.Machine
+}
+
+// RestoreEtcdMember removes the network partition created by IsolateEtcdMember.
+func RestoreEtcdMember(ctx context.Context, input RestoreEtcdMemberInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for RestoreEtcdMember")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling RestoreEtcdMember")
+
+	containerRuntime, err := container.NewDockerClient()
+	Expect(err).ToNot(HaveOccurred(), "Failed to get the container runtime")
+	containerName := machineContainerName(input.Machine.Spec.ClusterName, input.Machine.Name)
+
+	fmt.Fprintf(GinkgoWriter, "Restoring the etcd member on Machine %s/%s\n", input.Machine.Namespace, input.Machine.Name)
+	for _, rule := range etcdIsolationRules() {
+		execOnContainer(ctx, containerRuntime, containerName, "iptables", append([]string{"-D"}, rule...)...)
+	}
+}
+
+// etcdIsolationRules returns the iptables rules, without the operation flag, dropping the etcd peer traffic.
+func etcdIsolationRules() [][]string {
+	return [][]string{
+		{"INPUT", "-p", "tcp", "--dport", etcdPeerPort, "-m", "comment", "--comment", chaosIptablesComment, "-j", "DROP"},
+		{"OUTPUT", "-p", "tcp", "--dport", etcdPeerPort, "-m", "comment", "--comment", chaosIptablesComment, "-j", "DROP"},
+	}
+}
+
+// execOnContainer runs a command on a container, failing if the command fails.
+func execOnContainer(ctx context.Context, containerRuntime container.Runtime, containerName string, command string, args ...string) {
+	var stdout, stderr bytes.Buffer
+	execConfig := container.ExecContainerInput{
+		OutputBuffer: &stdout,
+		ErrorBuffer:  &stderr,
+	}
+	Expect(containerRuntime.ExecContainer(ctx, containerName, &execConfig, command, args...)).To(Succeed(),
+		"Failed to run %s %v on container %s: %s", command, args, containerName, stderr.String())
+}
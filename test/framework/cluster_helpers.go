@@ -164,6 +164,25 @@ func WaitForClusterDeleted(ctx context.Context, input WaitForClusterDeletedInput
 	}, intervals...).Should(BeTrue())
 }
 
+// WaitForAPIServerAvailableInput is the input for WaitForAPIServerAvailable.
+type WaitForAPIServerAvailableInput struct {
+	ClusterProxy ClusterProxy
+}
+
+// WaitForAPIServerAvailable waits until the API server of the cluster reports it is ready to serve requests,
+// e.g. after the API server has been restarted during an upgrade.
+func WaitForAPIServerAvailable(ctx context.Context, input WaitForAPIServerAvailableInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForAPIServerAvailable")
+	Expect(input.ClusterProxy).ToNot(BeNil(), "Invalid argument. input.ClusterProxy can't be nil when calling WaitForAPIServerAvailable")
+
+	By(fmt.Sprintf("Waiting for the API server of cluster %s to be available", input.ClusterProxy.GetName()))
+	clientSet := input.ClusterProxy.GetClientSet()
+	Eventually(func() error {
+		_, err := clientSet.Discovery().RESTClient().Get().AbsPath("/readyz").DoRaw(ctx)
+		return err
+	}, intervals...).Should(Succeed(), "API server of cluster %s is not available", input.ClusterProxy.GetName())
+}
+
 // DiscoveryAndWaitForClusterInput is the input type for DiscoveryAndWaitForCluster.
 type DiscoveryAndWaitForClusterInput struct {
 	Getter    Getter
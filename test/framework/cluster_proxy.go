@@ -28,6 +28,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -55,6 +56,7 @@ type ClusterProxy interface {
 	GetScheme() *runtime.Scheme
 
 	// GetClient returns a controller-runtime client to the Kubernetes cluster.
+	// The client retries requests failed because of conflicts or transient connection failures.
 	GetClient() client.Client
 
 	// GetClientSet returns a client-go client to the Kubernetes cluster.
@@ -98,6 +100,14 @@ func WithMachineLogCollector(logCollector ClusterLogCollector) Option {
 	}
 }
 
+// WithRetryBackoff allows to define the backoff used by the client to retry requests failed because of conflicts
+// or transient connection failures; if not set, DefaultRetryBackoff is used.
+func WithRetryBackoff(backoff wait.Backoff) Option {
+	return func(c *clusterProxy) {
+		c.retryBackoff = backoff
+	}
+}
+
 // clusterProxy provides a base implementation of the ClusterProxy interface.
 type clusterProxy struct {
 	name                    string
@@ -105,6 +115,7 @@ type clusterProxy struct {
 	scheme                  *runtime.Scheme
 	shouldCleanupKubeconfig bool
 	logCollector            ClusterLogCollector
+	retryBackoff            wait.Backoff
 }
 
 // NewClusterProxy returns a clusterProxy given a KubeconfigPath and the scheme defining the types hosted in the cluster.
@@ -121,6 +132,7 @@ func NewClusterProxy(name string, kubeconfigPath string, scheme *runtime.Scheme,
 		kubeconfigPath:          kubeconfigPath,
 		scheme:                  scheme,
 		shouldCleanupKubeconfig: false,
+		retryBackoff:            DefaultRetryBackoff,
 	}
 
 	for _, o := range options {
@@ -130,8 +142,9 @@ func NewClusterProxy(name string, kubeconfigPath string, scheme *runtime.Scheme,
 	return proxy
 }
 
-// newFromAPIConfig returns a clusterProxy given a api.Config and the scheme defining the types hosted in the cluster.
-func newFromAPIConfig(name string, config *api.Config, scheme *runtime.Scheme) ClusterProxy {
+// newFromAPIConfig returns a clusterProxy given a api.Config, the scheme defining the types hosted in the cluster
+// and the backoff used to retry requests.
+func newFromAPIConfig(name string, config *api.Config, scheme *runtime.Scheme, retryBackoff wait.Backoff) ClusterProxy {
 	// NB. the ClusterProvider is responsible for the cleanup of this file
 	f, err := os.CreateTemp("", "e2e-kubeconfig")
 	Expect(err).ToNot(HaveOccurred(), "Failed to create kubeconfig file for the kind cluster %q")
@@ -145,6 +158,7 @@ func newFromAPIConfig(name string, config *api.Config, scheme *runtime.Scheme) C
 		kubeconfigPath:          kubeconfigPath,
 		scheme:                  scheme,
 		shouldCleanupKubeconfig: true,
+		retryBackoff:            retryBackoff,
 	}
 }
 
@@ -164,13 +178,20 @@ func (p *clusterProxy) GetScheme() *runtime.Scheme {
 }
 
 // GetClient returns a controller-runtime client for the cluster.
+// The client retries requests failed because of conflicts or transient connection failures.
 func (p *clusterProxy) GetClient() client.Client {
 	config := p.GetRESTConfig()
 
-	c, err := client.New(config, client.Options{Scheme: p.scheme})
+	// NOTE: creating the client requires the API server to be available for discovering the API resources.
+	var c client.Client
+	err := retryWithBackoff(p.retryBackoff, isTransientError, func() error {
+		var err error
+		c, err = client.New(config, client.Options{Scheme: p.scheme})
+		return err
+	})
 	Expect(err).ToNot(HaveOccurred(), "Failed to get controller-runtime client")
 
-	return c
+	return newRetryingClient(c, p.retryBackoff)
 }
 
 // GetClientSet returns a client-go client for the cluster.
@@ -221,7 +242,7 @@ func (p *clusterProxy) GetWorkloadCluster(ctx context.Context, namespace, name s
 		p.fixConfig(ctx, name, config)
 	}
 
-	return newFromAPIConfig(name, config, p.scheme)
+	return newFromAPIConfig(name, config, p.scheme, p.retryBackoff)
 }
 
 // CollectWorkloadClusterLogs collects machines logs from the workload cluster.
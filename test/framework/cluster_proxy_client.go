@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/cluster-api/test/framework/internal/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultRetryBackoff is the backoff used by the ClusterProxy clients to retry requests failed because of
+// conflicts or transient connection failures, e.g. while the API server is restarted during an upgrade;
+// it retries for about two minutes.
+var DefaultRetryBackoff = wait.Backoff{
+	Duration: 1 * time.Second,
+	Factor:   1.5,
+	Jitter:   0.1,
+	Steps:    10,
+}
+
+// retryingClient is a controller-runtime client retrying requests failed because of conflicts or
+// transient connection failures.
+// NOTE: Update and Patch requests are not retried on conflicts, because they are going to fail again until the object
+// is read again from the API server, or the conflict is a legitimate concurrent change the caller must be aware of.
+type retryingClient struct {
+	client.Client
+	backoff wait.Backoff
+}
+
+var _ client.Client = &retryingClient{}
+
+// newRetryingClient returns a client wrapping c, retrying requests with the given backoff.
+func newRetryingClient(c client.Client, backoff wait.Backoff) client.Client {
+	return &retryingClient{
+		Client:  c,
+		backoff: backoff,
+	}
+}
+
+func (c *retryingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	return retryWithBackoff(c.backoff, isRetryableError, func() error {
+		return c.Client.Get(ctx, key, obj)
+	})
+}
+
+func (c *retryingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return retryWithBackoff(c.backoff, isRetryableError, func() error {
+		return c.Client.List(ctx, list, opts...)
+	})
+}
+
+func (c *retryingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	retried := false
+	return retryWithBackoff(c.backoff, isRetryableError, func() error {
+		err := c.Client.Create(ctx, obj, opts...)
+		// If a previous attempt failed with a transient error after the object has been created,
+		// e.g. because the connection dropped before reading the response, the retry fails with
+		// AlreadyExists; consider this a success.
+		if retried && apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		retried = true
+		return err
+	})
+}
+
+func (c *retryingClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	return retryWithBackoff(c.backoff, isRetryableError, func() error {
+		return c.Client.Delete(ctx, obj, opts...)
+	})
+}
+
+func (c *retryingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return retryWithBackoff(c.backoff, isTransientError, func() error {
+		return c.Client.Update(ctx, obj, opts...)
+	})
+}
+
+func (c *retryingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return retryWithBackoff(c.backoff, isTransientError, func() error {
+		return c.Client.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+func (c *retryingClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	return retryWithBackoff(c.backoff, isRetryableError, func() error {
+		return c.Client.DeleteAllOf(ctx, obj, opts...)
+	})
+}
+
+func (c *retryingClient) Status() client.StatusWriter {
+	return &retryingStatusWriter{
+		StatusWriter: c.Client.Status(),
+		backoff:      c.backoff,
+	}
+}
+
+// retryingStatusWriter is a controller-runtime status writer retrying requests failed because of conflicts or
+// transient connection failures.
+type retryingStatusWriter struct {
+	client.StatusWriter
+	backoff wait.Backoff
+}
+
+func (w *retryingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	return retryWithBackoff(w.backoff, isTransientError, func() error {
+		return w.StatusWriter.Update(ctx, obj, opts...)
+	})
+}
+
+func (w *retryingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return retryWithBackoff(w.backoff, isTransientError, func() error {
+		return w.StatusWriter.Patch(ctx, obj, patch, opts...)
+	})
+}
+
+// retryWithBackoff runs fn until it succeeds, it fails with an error not matching retryable or the backoff is exhausted.
+func retryWithBackoff(backoff wait.Backoff, retryable func(error) bool, fn func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		lastErr = fn()
+		if lastErr == nil {
+			return true, nil
+		}
+		if !retryable(lastErr) {
+			return false, lastErr
+		}
+		log.Logf("Retrying request failed with a transient error: %v", lastErr)
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout && lastErr != nil {
+		return lastErr
+	}
+	return err
+}
+
+// isRetryableError returns true for conflicts and for transient errors.
+func isRetryableError(err error) bool {
+	return apierrors.IsConflict(err) || isTransientError(err)
+}
+
+// isTransientError returns true for errors caused by the API server being temporarily unavailable or overloaded.
+func isTransientError(err error) bool {
+	if apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err) {
+		return true
+	}
+	if utilnet.IsConnectionRefused(err) || utilnet.IsConnectionReset(err) || utilnet.IsProbableEOF(err) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// failingClient is a client failing the first calls to Get, Create, Update and Patch with the given errors.
+type failingClient struct {
+	client.Client
+	errs  []error
+	calls int
+}
+
+func (c *failingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	c.calls++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return err
+	}
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *failingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.calls++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *failingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	c.calls++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return err
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *failingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.calls++
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		return err
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func TestRetryingClient(t *testing.T) {
+	backoff := wait.Backoff{Duration: time.Millisecond, Factor: 1, Steps: 3}
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "cm", nil)
+	alreadyExists := apierrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, "cm")
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cm", Namespace: metav1.NamespaceDefault}}
+
+	tests := []struct {
+		name      string
+		errs      []error
+		verb      string
+		wantErr   bool
+		wantCalls int
+	}{
+		{
+			name:      "retries transient connection failures",
+			errs:      []error{syscall.ECONNREFUSED, apierrors.NewServiceUnavailable("restarting")},
+			wantErr:   false,
+			wantCalls: 3,
+		},
+		{
+			name:      "retries conflicts",
+			errs:      []error{conflict},
+			wantErr:   false,
+			wantCalls: 2,
+		},
+		{
+			name:      "does not retry other errors",
+			errs:      []error{apierrors.NewBadRequest("invalid")},
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:      "gives up when the backoff is exhausted",
+			errs:      []error{syscall.ECONNREFUSED, syscall.ECONNREFUSED, syscall.ECONNREFUSED, syscall.ECONNREFUSED},
+			wantErr:   true,
+			wantCalls: 3,
+		},
+		{
+			name:      "does not retry conflicts on update",
+			errs:      []error{conflict},
+			verb:      "update",
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:      "does not retry conflicts on patch",
+			errs:      []error{conflict},
+			verb:      "patch",
+			wantErr:   true,
+			wantCalls: 1,
+		},
+		{
+			name:      "retries transient connection failures on patch",
+			errs:      []error{syscall.ECONNRESET},
+			verb:      "patch",
+			wantErr:   false,
+			wantCalls: 2,
+		},
+		{
+			name:      "considers already exists after a retried create a success",
+			errs:      []error{syscall.ECONNRESET, alreadyExists},
+			verb:      "create",
+			wantErr:   false,
+			wantCalls: 2,
+		},
+		{
+			name:      "does not consider already exists on the first create a success",
+			errs:      []error{alreadyExists},
+			verb:      "create",
+			wantErr:   true,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := &failingClient{
+				Client: fake.NewClientBuilder().WithObjects(configMap.DeepCopy()).Build(),
+				errs:   tt.errs,
+			}
+			retryingClient := newRetryingClient(c, backoff)
+
+			var err error
+			switch tt.verb {
+			case "update":
+				err = retryingClient.Update(context.Background(), configMap.DeepCopy())
+			case "patch":
+				err = retryingClient.Patch(context.Background(), configMap.DeepCopy(), client.MergeFrom(configMap))
+			case "create":
+				err = retryingClient.Create(context.Background(), configMap.DeepCopy())
+			default:
+				err = retryingClient.Get(context.Background(), client.ObjectKeyFromObject(configMap), &corev1.ConfigMap{})
+			}
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(c.calls).To(Equal(tt.wantCalls))
+		})
+	}
+}
@@ -87,6 +87,10 @@ type E2EConfig struct {
 	// sensitive data in the config file.
 	Variables map[string]string `json:"variables,omitempty"`
 
+	// VariableSchema declares the variables expected by the e2e tests, so missing or invalid variables
+	// are reported when the config file is loaded instead of failing the test specs using them.
+	VariableSchema map[string]VariableDefinition `json:"variableSchema,omitempty"`
+
 	// Intervals to be used for long operations during tests
 	Intervals map[string][]string `json:"intervals,omitempty"`
 }
@@ -107,6 +111,39 @@ type ProviderConfig struct {
 	Files []Files `json:"files,omitempty"`
 }
 
+// VariableType is the type of the value of a variable.
+type VariableType string
+
+const (
+	// StringVariable is a variable accepting any value.
+	StringVariable VariableType = "string"
+
+	// IntegerVariable is a variable accepting integer values.
+	IntegerVariable VariableType = "integer"
+
+	// BooleanVariable is a variable accepting boolean values.
+	BooleanVariable VariableType = "boolean"
+
+	// DurationVariable is a variable accepting durations, e.g. "60s".
+	DurationVariable VariableType = "duration"
+)
+
+// VariableDefinition describes a variable expected by the e2e tests.
+type VariableDefinition struct {
+	// Type is the type of the variable value.
+	// Defaults to string.
+	Type VariableType `json:"type,omitempty"`
+
+	// Required requires the variable to be set, either in the e2e config file or in the environment.
+	Required bool `json:"required,omitempty"`
+
+	// Enum is the list of values allowed for the variable.
+	Enum []string `json:"enum,omitempty"`
+
+	// Default is the value assigned to the variable if not set, either in the e2e config file or in the environment.
+	Default *string `json:"default,omitempty"`
+}
+
 // LoadImageBehavior indicates the behavior when loading an image.
 type LoadImageBehavior string
 
@@ -249,6 +286,8 @@ type Files struct {
 // - Providers version gets type KustomizeSource if not otherwise specified.
 // - Providers file gets targetName = sourceName if not otherwise specified.
 // - Images gets LoadBehavior = MustLoadImage if not otherwise specified.
+// - VariableSchema gets type = string if not otherwise specified.
+// - Variables get the default value from the VariableSchema if not set in the config file nor in the environment.
 func (c *E2EConfig) Defaults() {
 	if c.ManagementClusterName == "" {
 		c.ManagementClusterName = fmt.Sprintf("test-%s", util.RandomString(6))
@@ -282,6 +321,18 @@ func (c *E2EConfig) Defaults() {
 			containerImage.LoadBehavior = MustLoadImage
 		}
 	}
+	for name, definition := range c.VariableSchema {
+		if definition.Type == "" {
+			definition.Type = StringVariable
+			c.VariableSchema[name] = definition
+		}
+		if definition.Default != nil && !c.HasVariable(name) {
+			if c.Variables == nil {
+				c.Variables = map[string]string{}
+			}
+			c.Variables[name] = *definition.Default
+		}
+	}
 }
 
 // AbsPaths makes relative paths absolute using the given base path.
@@ -344,6 +395,7 @@ func errEmptyArg(argName string) error {
 // - There should be one InfraProvider (pick your own).
 // - Image should have name and loadBehavior be one of [mustload, tryload].
 // - Intervals should be valid ginkgo intervals.
+// - Variables should match the VariableSchema.
 func (c *E2EConfig) Validate() error {
 	// ManagementClusterName should not be empty.
 	if c.ManagementClusterName == "" {
@@ -382,9 +434,68 @@ func (c *E2EConfig) Validate() error {
 			}
 		}
 	}
+
+	return c.validateVariables()
+}
+
+// validateVariables validates the variables against the VariableSchema. More specifically:
+// - VariableSchema type should be one of [string, integer, boolean, duration].
+// - Required variables should be set, either in the config file or in the environment.
+// - Variables values should match the type and the enum declared in the VariableSchema.
+func (c *E2EConfig) validateVariables() error {
+	// Sort the variable names, so errors are reported in a predictable order.
+	names := make([]string, 0, len(c.VariableSchema))
+	for name := range c.VariableSchema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		definition := c.VariableSchema[name]
+		switch definition.Type {
+		case StringVariable, IntegerVariable, BooleanVariable, DurationVariable:
+			// Valid
+		default:
+			return errInvalidArg("VariableSchema[%s].Type=%q", name, definition.Type)
+		}
+
+		value, ok := c.lookupVariable(name)
+		if !ok {
+			if definition.Required {
+				return errInvalidArg("variable %s is required, it must be set in the e2e config file or as an environment variable", name)
+			}
+			continue
+		}
+
+		var err error
+		switch definition.Type {
+		case IntegerVariable:
+			_, err = strconv.ParseInt(value, 10, 64)
+		case BooleanVariable:
+			_, err = strconv.ParseBool(value)
+		case DurationVariable:
+			_, err = time.ParseDuration(value)
+		}
+		if err != nil {
+			return errInvalidArg("variable %s=%q is not a valid %s", name, value, definition.Type)
+		}
+
+		if len(definition.Enum) > 0 && !containsString(definition.Enum, value) {
+			return errInvalidArg("variable %s=%q must be one of %q", name, value, definition.Enum)
+		}
+	}
 	return nil
 }
 
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
 // validateProviders validates the provider configuration. More specifically:
 // - Providers name should not be empty.
 // - Providers type should be one of [CoreProvider, BootstrapProvider, ControlPlaneProvider, InfrastructureProvider].
@@ -539,24 +650,28 @@ func (c *E2EConfig) GetIntervals(spec, key string) []interface{} {
 	return intervalsInterfaces
 }
 
+// HasVariable returns true if a variable is set in the environment variables or in the e2e config file.
 func (c *E2EConfig) HasVariable(varName string) bool {
-	if _, ok := os.LookupEnv(varName); ok {
-		return true
-	}
-
-	_, ok := c.Variables[varName]
+	_, ok := c.lookupVariable(varName)
 	return ok
 }
 
 // GetVariable returns a variable from environment variables or from the e2e config file.
 func (c *E2EConfig) GetVariable(varName string) string {
+	value, ok := c.lookupVariable(varName)
+	Expect(ok).NotTo(BeFalse())
+	return value
+}
+
+// lookupVariable returns a variable from environment variables or from the e2e config file,
+// and whether it is set or not.
+func (c *E2EConfig) lookupVariable(varName string) (string, bool) {
 	if value, ok := os.LookupEnv(varName); ok {
-		return value
+		return value, true
 	}
 
 	value, ok := c.Variables[varName]
-	Expect(ok).NotTo(BeFalse())
-	return value
+	return value, ok
 }
 
 // GetInt64PtrVariable returns an Int64Ptr variable from the e2e config file.
@@ -0,0 +1,139 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterctl
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestE2EConfig_VariableSchemaDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	c := &E2EConfig{
+		Variables: map[string]string{
+			"SET": "value",
+		},
+		VariableSchema: map[string]VariableDefinition{
+			"SET":        {Default: pointer.StringPtr("default")},
+			"NOT_SET":    {Default: pointer.StringPtr("default")},
+			"NO_DEFAULT": {Type: IntegerVariable},
+		},
+	}
+	c.Defaults()
+
+	g.Expect(c.Variables).To(Equal(map[string]string{
+		"SET":     "value",
+		"NOT_SET": "default",
+	}))
+	g.Expect(c.VariableSchema["SET"].Type).To(Equal(StringVariable))
+	g.Expect(c.VariableSchema["NO_DEFAULT"].Type).To(Equal(IntegerVariable))
+}
+
+func TestE2EConfig_validateVariables(t *testing.T) {
+	tests := []struct {
+		name       string
+		variables  map[string]string
+		definition VariableDefinition
+		wantErr    bool
+	}{
+		{
+			name:       "optional variables can be unset",
+			definition: VariableDefinition{Type: StringVariable},
+			wantErr:    false,
+		},
+		{
+			name:       "required variables must be set",
+			definition: VariableDefinition{Type: StringVariable, Required: true},
+			wantErr:    true,
+		},
+		{
+			name:       "valid integer",
+			variables:  map[string]string{"VAR": "3"},
+			definition: VariableDefinition{Type: IntegerVariable},
+			wantErr:    false,
+		},
+		{
+			name:       "invalid integer",
+			variables:  map[string]string{"VAR": "three"},
+			definition: VariableDefinition{Type: IntegerVariable},
+			wantErr:    true,
+		},
+		{
+			name:       "valid boolean",
+			variables:  map[string]string{"VAR": "true"},
+			definition: VariableDefinition{Type: BooleanVariable},
+			wantErr:    false,
+		},
+		{
+			name:       "invalid boolean",
+			variables:  map[string]string{"VAR": "yes please"},
+			definition: VariableDefinition{Type: BooleanVariable},
+			wantErr:    true,
+		},
+		{
+			name:       "valid duration",
+			variables:  map[string]string{"VAR": "60s"},
+			definition: VariableDefinition{Type: DurationVariable},
+			wantErr:    false,
+		},
+		{
+			name:       "invalid duration",
+			variables:  map[string]string{"VAR": "60"},
+			definition: VariableDefinition{Type: DurationVariable},
+			wantErr:    true,
+		},
+		{
+			name:       "value in enum",
+			variables:  map[string]string{"VAR": "IPv6"},
+			definition: VariableDefinition{Type: StringVariable, Enum: []string{"IPv4", "IPv6"}},
+			wantErr:    false,
+		},
+		{
+			name:       "value not in enum",
+			variables:  map[string]string{"VAR": "IPv5"},
+			definition: VariableDefinition{Type: StringVariable, Enum: []string{"IPv4", "IPv6"}},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid type",
+			variables:  map[string]string{"VAR": "value"},
+			definition: VariableDefinition{Type: "object"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := &E2EConfig{
+				Variables: tt.variables,
+				VariableSchema: map[string]VariableDefinition{
+					"VAR": tt.definition,
+				},
+			}
+			err := c.validateVariables()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
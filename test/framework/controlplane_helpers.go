@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/utils/pointer"
@@ -29,6 +30,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/test/framework/internal/log"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -281,6 +283,14 @@ type UpgradeControlPlaneAndWaitForUpgradeInput struct {
 	WaitForMachinesToBeUpgraded []interface{}
 	WaitForDNSUpgrade           []interface{}
 	WaitForEtcdUpgrade          []interface{}
+
+	// AddonImages are additional addons, e.g. CNI or CSI drivers, whose images are expected to be upgraded
+	// together with the control plane; kube-proxy and CoreDNS are always checked.
+	AddonImages []AddonImage
+
+	// WaitForAddonImagesUpgrade are the intervals used when waiting for AddonImages; if not set,
+	// WaitForDNSUpgrade intervals are used.
+	WaitForAddonImagesUpgrade []interface{}
 }
 
 // UpgradeControlPlaneAndWaitForUpgrade upgrades a KubeadmControlPlane and waits for it to be upgraded.
@@ -329,8 +339,14 @@ func UpgradeControlPlaneAndWaitForUpgrade(ctx context.Context, input UpgradeCont
 		KubernetesUpgradeVersion: input.KubernetesUpgradeVersion,
 	}, input.WaitForMachinesToBeUpgraded...)
 
-	log.Logf("Waiting for kube-proxy to have the upgraded kubernetes version")
+	// The workload cluster API server is restarted while the control plane machines are replaced, so
+	// wait for it to be available before checking the workload cluster components.
 	workloadCluster := input.ClusterProxy.GetWorkloadCluster(ctx, input.Cluster.Namespace, input.Cluster.Name)
+	WaitForAPIServerAvailable(ctx, WaitForAPIServerAvailableInput{
+		ClusterProxy: workloadCluster,
+	}, input.WaitForMachinesToBeUpgraded...)
+
+	log.Logf("Waiting for kube-proxy to have the upgraded kubernetes version")
 	workloadClient := workloadCluster.GetClient()
 	WaitForKubeProxyUpgrade(ctx, WaitForKubeProxyUpgradeInput{
 		Getter:            workloadClient,
@@ -341,7 +357,19 @@ func UpgradeControlPlaneAndWaitForUpgrade(ctx context.Context, input UpgradeCont
 	WaitForDNSUpgrade(ctx, WaitForDNSUpgradeInput{
 		Getter:     workloadClient,
 		DNSVersion: input.DNSImageTag,
-	})
+	}, input.WaitForDNSUpgrade...)
+
+	if len(input.AddonImages) > 0 {
+		log.Logf("Waiting for addons to have the upgraded images")
+		waitForAddonImagesUpgrade := input.WaitForAddonImagesUpgrade
+		if len(waitForAddonImagesUpgrade) == 0 {
+			waitForAddonImagesUpgrade = input.WaitForDNSUpgrade
+		}
+		WaitForAddonImagesUpgrade(ctx, WaitForAddonImagesUpgradeInput{
+			Getter: workloadClient,
+			Addons: input.AddonImages,
+		}, waitForAddonImagesUpgrade...)
+	}
 
 	log.Logf("Waiting for etcd to have the upgraded image tag")
 	lblSelector, err := labels.Parse("component=etcd")
@@ -407,3 +435,77 @@ func ScaleAndWaitControlPlane(ctx context.Context, input ScaleAndWaitControlPlan
 		return nodeRefCount, nil
 	}, input.WaitForControlPlane...).Should(Equal(int(input.Replicas)))
 }
+
+// WaitForKubeadmControlPlaneConditionInput is the input for WaitForKubeadmControlPlaneCondition.
+type WaitForKubeadmControlPlaneConditionInput struct {
+	Getter        Getter
+	ControlPlane  *controlplanev1.KubeadmControlPlane
+	ConditionType clusterv1.ConditionType
+	Status        corev1.ConditionStatus
+}
+
+// WaitForKubeadmControlPlaneCondition waits until a condition on the KubeadmControlPlane has the expected status,
+// e.g. until EtcdClusterHealthy is false after an etcd member has been isolated.
+func WaitForKubeadmControlPlaneCondition(ctx context.Context, input WaitForKubeadmControlPlaneConditionInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForKubeadmControlPlaneCondition")
+	Expect(input.Getter).ToNot(BeNil(), "Invalid argument. input.Getter can't be nil when calling WaitForKubeadmControlPlaneCondition")
+	Expect(input.ControlPlane).ToNot(BeNil(), "Invalid argument. input.ControlPlane can't be nil when calling WaitForKubeadmControlPlaneCondition")
+
+	log.Logf("Waiting for condition %s on control plane %s/%s to be %s", input.ConditionType, input.ControlPlane.Namespace, input.ControlPlane.Name, input.Status)
+	Eventually(func() (corev1.ConditionStatus, error) {
+		controlplane := &controlplanev1.KubeadmControlPlane{}
+		if err := input.Getter.Get(ctx, client.ObjectKeyFromObject(input.ControlPlane), controlplane); err != nil {
+			return "", err
+		}
+		condition := conditions.Get(controlplane, input.ConditionType)
+		if condition == nil {
+			return corev1.ConditionUnknown, nil
+		}
+		return condition.Status, nil
+	}, intervals...).Should(Equal(input.Status), "Condition %s on control plane %s/%s is not %s", input.ConditionType, input.ControlPlane.Namespace, input.ControlPlane.Name, input.Status)
+}
+
+// WaitForControlPlaneMachineToBeRemediatedInput is the input for WaitForControlPlaneMachineToBeRemediated.
+type WaitForControlPlaneMachineToBeRemediatedInput struct {
+	Lister       Lister
+	ControlPlane *controlplanev1.KubeadmControlPlane
+	Machine      *clusterv1.Machine
+}
+
+// WaitForControlPlaneMachineToBeRemediated waits until an unhealthy control plane Machine has been deleted by the
+// KubeadmControlPlane remediation, failing as soon as the number of control plane Machines not being deleted, excluding
+// the unhealthy one, drops below the quorum required by the etcd cluster.
+func WaitForControlPlaneMachineToBeRemediated(ctx context.Context, input WaitForControlPlaneMachineToBeRemediatedInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForControlPlaneMachineToBeRemediated")
+	Expect(input.Lister).ToNot(BeNil(), "Invalid argument. input.Lister can't be nil when calling WaitForControlPlaneMachineToBeRemediated")
+	Expect(input.ControlPlane).ToNot(BeNil(), "Invalid argument. input.ControlPlane can't be nil when calling WaitForControlPlaneMachineToBeRemediated")
+	Expect(input.ControlPlane.Spec.Replicas).ToNot(BeNil(), "Invalid argument. input.ControlPlane.Spec.Replicas can't be nil when calling WaitForControlPlaneMachineToBeRemediated")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling WaitForControlPlaneMachineToBeRemediated")
+
+	quorum := int(*input.ControlPlane.Spec.Replicas)/2 + 1
+	log.Logf("Waiting for control plane Machine %s/%s to be remediated", input.Machine.Namespace, input.Machine.Name)
+	Eventually(func() (bool, error) {
+		machines := &clusterv1.MachineList{}
+		if err := input.Lister.List(ctx, machines, client.InNamespace(input.Machine.Namespace), client.MatchingLabels{
+			clusterv1.ClusterLabelName:             input.Machine.Spec.ClusterName,
+			clusterv1.MachineControlPlaneLabelName: "",
+		}); err != nil {
+			return false, err
+		}
+
+		found := false
+		healthy := 0
+		for i := range machines.Items {
+			machine := &machines.Items[i]
+			if machine.Name == input.Machine.Name {
+				found = true
+				continue
+			}
+			if machine.DeletionTimestamp.IsZero() && machine.Status.NodeRef != nil {
+				healthy++
+			}
+		}
+		Expect(healthy).To(BeNumerically(">=", quorum), "The etcd cluster lost quorum while remediating control plane Machine %s/%s", input.Machine.Namespace, input.Machine.Name)
+		return !found, nil
+	}, intervals...).Should(BeTrue(), "Control plane Machine %s/%s has not been remediated", input.Machine.Namespace, input.Machine.Name)
+}
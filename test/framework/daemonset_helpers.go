@@ -18,13 +18,6 @@ package framework
 
 import (
 	"context"
-
-	. "github.com/onsi/ginkgo"
-	. "github.com/onsi/gomega"
-	appsv1 "k8s.io/api/apps/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	containerutil "sigs.k8s.io/cluster-api/util/container"
-	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // WaitForKubeProxyUpgradeInput is the input for WaitForKubeProxyUpgrade.
@@ -35,17 +28,8 @@ type WaitForKubeProxyUpgradeInput struct {
 
 // WaitForKubeProxyUpgrade waits until kube-proxy version matches with the kubernetes version. This is called during KCP upgrade.
 func WaitForKubeProxyUpgrade(ctx context.Context, input WaitForKubeProxyUpgradeInput, intervals ...interface{}) {
-	By("Ensuring kube-proxy has the correct image")
-
-	Eventually(func() (bool, error) {
-		ds := &appsv1.DaemonSet{}
-
-		if err := input.Getter.Get(ctx, client.ObjectKey{Name: "kube-proxy", Namespace: metav1.NamespaceSystem}, ds); err != nil {
-			return false, err
-		}
-		if ds.Spec.Template.Spec.Containers[0].Image == "k8s.gcr.io/kube-proxy:"+containerutil.SemverToOCIImageTag(input.KubernetesVersion) {
-			return true, nil
-		}
-		return false, nil
-	}, intervals...).Should(BeTrue())
+	WaitForAddonImagesUpgrade(ctx, WaitForAddonImagesUpgradeInput{
+		Getter: input.Getter,
+		Addons: []AddonImage{KubeProxyAddonImage(input.KubernetesVersion)},
+	}, intervals...)
 }
@@ -165,15 +165,7 @@ func WatchPodMetrics(ctx context.Context, input WatchPodMetricsInput) {
 	Expect(input.ClientSet).NotTo(BeNil(), "input.ClientSet is required for dumpContainerMetrics")
 	Expect(input.Deployment).NotTo(BeNil(), "input.Deployment is required for dumpContainerMetrics")
 
-	deployment := &appsv1.Deployment{}
-	key := client.ObjectKeyFromObject(input.Deployment)
-	Expect(input.GetLister.Get(ctx, key, deployment)).To(Succeed(), "Failed to get deployment %s/%s", input.Deployment.Namespace, input.Deployment.Name)
-
-	selector, err := metav1.LabelSelectorAsMap(deployment.Spec.Selector)
-	Expect(err).NotTo(HaveOccurred(), "Failed to Pods selector for deployment %s/%s", input.Deployment.Namespace, input.Deployment.Name)
-
-	pods := &corev1.PodList{}
-	Expect(input.GetLister.List(ctx, pods, client.InNamespace(input.Deployment.Namespace), client.MatchingLabels(selector))).To(Succeed(), "Failed to list Pods for deployment %s/%s", input.Deployment.Namespace, input.Deployment.Name)
+	pods := getDeploymentPods(ctx, input.GetLister, input.Deployment)
 
 	go func() {
 		defer GinkgoRecover()
@@ -182,7 +174,7 @@ func WatchPodMetrics(ctx context.Context, input WatchPodMetricsInput) {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				dumpPodMetrics(ctx, input.ClientSet, input.MetricsPath, deployment.Name, pods)
+				dumpPodMetrics(ctx, input.ClientSet, input.MetricsPath, input.Deployment.Name, pods)
 			}
 		}
 	}()
@@ -195,15 +187,7 @@ func dumpPodMetrics(ctx context.Context, client *kubernetes.Clientset, metricsPa
 		metricsFile := path.Join(metricsDir, "metrics.txt")
 		Expect(os.MkdirAll(metricsDir, 0750)).To(Succeed())
 
-		res := client.CoreV1().RESTClient().Get().
-			Namespace(pod.Namespace).
-			Resource("pods").
-			Name(fmt.Sprintf("%s:8080", pod.Name)).
-			SubResource("proxy").
-			Suffix("metrics").
-			Do(ctx)
-		data, err := res.Raw()
-
+		data, err := getPodMetrics(ctx, client, pod)
 		if err != nil {
 			// Failing to dump metrics should not cause the test to fail
 			data = []byte(fmt.Sprintf("Error retrieving metrics for pod %s/%s: %v\n%s", pod.Namespace, pod.Name, err, string(data)))
@@ -225,22 +209,10 @@ type WaitForDNSUpgradeInput struct {
 
 // WaitForDNSUpgrade waits until CoreDNS version matches with the CoreDNS upgrade version. This is called during KCP upgrade.
 func WaitForDNSUpgrade(ctx context.Context, input WaitForDNSUpgradeInput, intervals ...interface{}) {
-	By("Ensuring CoreDNS has the correct image")
-
-	Eventually(func() (bool, error) {
-		d := &appsv1.Deployment{}
-
-		if err := input.Getter.Get(ctx, client.ObjectKey{Name: "coredns", Namespace: metav1.NamespaceSystem}, d); err != nil {
-			return false, err
-		}
-
-		// NOTE: coredns image name has changed over time (k8s.gcr.io/coredns,
-		// k8s.gcr.io/coredns/coredns), so we are checking only if the version actually changed.
-		if strings.HasSuffix(d.Spec.Template.Spec.Containers[0].Image, fmt.Sprintf(":%s", input.DNSVersion)) {
-			return true, nil
-		}
-		return false, nil
-	}, intervals...).Should(BeTrue())
+	WaitForAddonImagesUpgrade(ctx, WaitForAddonImagesUpgradeInput{
+		Getter: input.Getter,
+		Addons: []AddonImage{CoreDNSAddonImage(input.DNSVersion)},
+	}, intervals...)
 }
 
 type DeployUnevictablePodInput struct {
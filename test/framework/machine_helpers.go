@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/test/framework/internal/log"
@@ -264,3 +265,23 @@ func MachinePhaseCheck(expectedPhase string) MachineStatusCheck {
 		return nil
 	}
 }
+
+// WaitForMachineToBeDeletedInput is the input for WaitForMachineToBeDeleted.
+type WaitForMachineToBeDeletedInput struct {
+	Getter  Getter
+	Machine *clusterv1.Machine
+}
+
+// WaitForMachineToBeDeleted waits until the machine is deleted, e.g. after being remediated by a MachineHealthCheck.
+func WaitForMachineToBeDeleted(ctx context.Context, input WaitForMachineToBeDeletedInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForMachineToBeDeleted")
+	Expect(input.Getter).ToNot(BeNil(), "Invalid argument. input.Getter can't be nil when calling WaitForMachineToBeDeleted")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling WaitForMachineToBeDeleted")
+
+	log.Logf("Waiting for Machine %s/%s to be deleted", input.Machine.Namespace, input.Machine.Name)
+	Eventually(func() bool {
+		machine := &clusterv1.Machine{}
+		err := input.Getter.Get(ctx, client.ObjectKeyFromObject(input.Machine), machine)
+		return apierrors.IsNotFound(err)
+	}, intervals...).Should(BeTrue(), "Machine %s/%s has not been deleted", input.Machine.Namespace, input.Machine.Name)
+}
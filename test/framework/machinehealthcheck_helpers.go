@@ -27,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -175,3 +176,26 @@ func hasMatchingUnhealthyConditions(machineHealthCheck *clusterv1.MachineHealthC
 	}
 	return false
 }
+
+// WaitForMachineToBeMarkedUnhealthyInput is the input for WaitForMachineToBeMarkedUnhealthy.
+type WaitForMachineToBeMarkedUnhealthyInput struct {
+	Getter  Getter
+	Machine *clusterv1.Machine
+}
+
+// WaitForMachineToBeMarkedUnhealthy waits until a MachineHealthCheck marks a Machine as unhealthy and asks the Machine owner to remediate it.
+func WaitForMachineToBeMarkedUnhealthy(ctx context.Context, input WaitForMachineToBeMarkedUnhealthyInput, intervals ...interface{}) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForMachineToBeMarkedUnhealthy")
+	Expect(input.Getter).ToNot(BeNil(), "Invalid argument. input.Getter can't be nil when calling WaitForMachineToBeMarkedUnhealthy")
+	Expect(input.Machine).ToNot(BeNil(), "Invalid argument. input.Machine can't be nil when calling WaitForMachineToBeMarkedUnhealthy")
+
+	fmt.Fprintf(GinkgoWriter, "Waiting for Machine %s/%s to be marked unhealthy\n", input.Machine.Namespace, input.Machine.Name)
+	Eventually(func() (bool, error) {
+		machine := &clusterv1.Machine{}
+		if err := input.Getter.Get(ctx, client.ObjectKeyFromObject(input.Machine), machine); err != nil {
+			return false, err
+		}
+		return conditions.IsFalse(machine, clusterv1.MachineHealthCheckSuccededCondition) &&
+			conditions.IsFalse(machine, clusterv1.MachineOwnerRemediatedCondition), nil
+	}, intervals...).Should(BeTrue(), "Machine %s/%s has not been marked unhealthy", input.Machine.Namespace, input.Machine.Name)
+}
@@ -0,0 +1,315 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/cluster-api/test/framework/internal/log"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ReconcileTotalMetric is the controller-runtime metric counting reconciles per controller and result.
+	ReconcileTotalMetric = "controller_runtime_reconcile_total"
+
+	// ReconcilePanicsTotalMetric is the controller-runtime metric counting panics recovered during reconciles.
+	ReconcilePanicsTotalMetric = "controller_runtime_reconcile_panics_total"
+
+	// ReconcileTimeMetric is the controller-runtime histogram of the reconcile duration per controller.
+	ReconcileTimeMetric = "controller_runtime_reconcile_time_seconds"
+
+	// WorkqueueDepthMetric is the client-go gauge of the number of items waiting in each work queue.
+	WorkqueueDepthMetric = "workqueue_depth"
+
+	// DefaultMaxReconcileErrorRate is the default maximum ratio of reconciles with result=error over all the reconciles
+	// a controller is allowed to have.
+	DefaultMaxReconcileErrorRate = 0.5
+)
+
+// ControllerMetrics holds the metric families scraped from a single controller pod, indexed by metric name.
+type ControllerMetrics map[string]*dto.MetricFamily
+
+// ScrapeControllerMetricsInput is the input for ScrapeControllerMetrics.
+type ScrapeControllerMetricsInput struct {
+	GetLister   GetLister
+	ClientSet   *kubernetes.Clientset
+	Deployment  *appsv1.Deployment
+	MetricsPath string
+}
+
+// ScrapeControllerMetrics scrapes the metrics endpoint of all the pods belonging to a controller deployment, archives the
+// raw metrics into the MetricsPath folder and returns the parsed metrics, indexed by pod name.
+// It expects to find port 8080 open on the controller.
+func ScrapeControllerMetrics(ctx context.Context, input ScrapeControllerMetricsInput) map[string]ControllerMetrics {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for ScrapeControllerMetrics")
+	Expect(input.GetLister).NotTo(BeNil(), "input.GetLister is required for ScrapeControllerMetrics")
+	Expect(input.ClientSet).NotTo(BeNil(), "input.ClientSet is required for ScrapeControllerMetrics")
+	Expect(input.Deployment).NotTo(BeNil(), "input.Deployment is required for ScrapeControllerMetrics")
+	Expect(input.MetricsPath).NotTo(BeEmpty(), "input.MetricsPath is required for ScrapeControllerMetrics")
+
+	pods := getDeploymentPods(ctx, input.GetLister, input.Deployment)
+
+	ret := map[string]ControllerMetrics{}
+	for _, pod := range pods.Items {
+		metricsDir := filepath.Join(input.MetricsPath, input.Deployment.Name, pod.Name)
+		Expect(os.MkdirAll(metricsDir, 0750)).To(Succeed())
+
+		data, err := getPodMetrics(ctx, input.ClientSet, pod)
+		Expect(err).NotTo(HaveOccurred(), "Failed to scrape metrics for pod %s/%s", pod.Namespace, pod.Name)
+		Expect(os.WriteFile(filepath.Join(metricsDir, "metrics-final.txt"), data, 0600)).To(Succeed(), "Failed to archive metrics for pod %s/%s", pod.Namespace, pod.Name)
+
+		metrics, err := ParseControllerMetrics(data)
+		Expect(err).NotTo(HaveOccurred(), "Failed to parse metrics for pod %s/%s", pod.Namespace, pod.Name)
+		ret[pod.Name] = metrics
+	}
+	return ret
+}
+
+// AssertControllerMetricsInput is the input for AssertControllerMetrics.
+type AssertControllerMetricsInput struct {
+	GetLister   GetLister
+	ClientSet   *kubernetes.Clientset
+	Deployments []*appsv1.Deployment
+	MetricsPath string
+
+	// MaxReconcileErrorRate is the maximum ratio of failed reconciles over all the reconciles that is tolerated for
+	// each controller; if not set, DefaultMaxReconcileErrorRate is used.
+	MaxReconcileErrorRate *float64
+
+	// PanicMetrics is the list of counters that must be zero; if not set, ReconcilePanicsTotalMetric is used.
+	PanicMetrics []string
+}
+
+// AssertControllerMetrics scrapes the metrics of the given controller deployments, archives them into the MetricsPath
+// folder, and asserts that no panics have been recorded and that the reconcile error rate of each controller is below
+// the configured threshold.
+func AssertControllerMetrics(ctx context.Context, input AssertControllerMetricsInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for AssertControllerMetrics")
+	Expect(input.Deployments).NotTo(BeEmpty(), "input.Deployments is required for AssertControllerMetrics")
+
+	maxErrorRate := DefaultMaxReconcileErrorRate
+	if input.MaxReconcileErrorRate != nil {
+		maxErrorRate = *input.MaxReconcileErrorRate
+	}
+	panicMetrics := input.PanicMetrics
+	if len(panicMetrics) == 0 {
+		panicMetrics = []string{ReconcilePanicsTotalMetric}
+	}
+
+	for _, deployment := range input.Deployments {
+		By(fmt.Sprintf("Asserting metrics invariants for controller %s/%s", deployment.Namespace, deployment.Name))
+		podMetrics := ScrapeControllerMetrics(ctx, ScrapeControllerMetricsInput{
+			GetLister:   input.GetLister,
+			ClientSet:   input.ClientSet,
+			Deployment:  deployment,
+			MetricsPath: input.MetricsPath,
+		})
+
+		for podName, metrics := range podMetrics {
+			for _, name := range panicMetrics {
+				Expect(metrics.Sum(name, nil)).To(BeZero(), "Controller %s/%s, pod %s: expected metric %s to be zero", deployment.Namespace, deployment.Name, podName, name)
+			}
+			for controller, rate := range metrics.ReconcileErrorRates() {
+				log.Logf("Controller %s/%s, pod %s: reconcile error rate for %s is %.3f", deployment.Namespace, deployment.Name, podName, controller, rate)
+				Expect(rate).To(BeNumerically("<=", maxErrorRate), "Controller %s/%s, pod %s: reconcile error rate for %s is above %.3f", deployment.Namespace, deployment.Name, podName, controller, maxErrorRate)
+			}
+		}
+	}
+}
+
+// ParseControllerMetrics parses metrics in the Prometheus text exposition format.
+func ParseControllerMetrics(data []byte) (ControllerMetrics, error) {
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse metrics")
+	}
+	return ControllerMetrics(families), nil
+}
+
+// Sum returns the sum of all the counter, gauge or untyped samples of the given metric having all the given labels;
+// if the metric does not exist, 0 is returned.
+func (m ControllerMetrics) Sum(name string, labels map[string]string) float64 {
+	family, ok := m[name]
+	if !ok {
+		return 0
+	}
+
+	var sum float64
+	for _, metric := range family.GetMetric() {
+		if !hasLabels(metric, labels) {
+			continue
+		}
+		switch {
+		case metric.GetCounter() != nil:
+			sum += metric.GetCounter().GetValue()
+		case metric.GetGauge() != nil:
+			sum += metric.GetGauge().GetValue()
+		case metric.GetUntyped() != nil:
+			sum += metric.GetUntyped().GetValue()
+		}
+	}
+	return sum
+}
+
+// ReconcileErrorRates returns the ratio of reconciles with result=error over all the reconciles, indexed by controller.
+// Controllers without reconciles are not included.
+func (m ControllerMetrics) ReconcileErrorRates() map[string]float64 {
+	family, ok := m[ReconcileTotalMetric]
+	if !ok {
+		return nil
+	}
+
+	controllers := map[string]struct{}{}
+	for _, metric := range family.GetMetric() {
+		for _, l := range metric.GetLabel() {
+			if l.GetName() == "controller" {
+				controllers[l.GetValue()] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(controllers))
+	for c := range controllers {
+		names = append(names, c)
+	}
+	sort.Strings(names)
+
+	rates := map[string]float64{}
+	for _, c := range names {
+		total := m.Sum(ReconcileTotalMetric, map[string]string{"controller": c})
+		if total == 0 {
+			continue
+		}
+		rates[c] = m.Sum(ReconcileTotalMetric, map[string]string{"controller": c, "result": "error"}) / total
+	}
+	return rates
+}
+
+// AverageReconcileTimes returns the average reconcile duration, indexed by controller.
+// Controllers without reconciles are not included.
+func (m ControllerMetrics) AverageReconcileTimes() map[string]time.Duration {
+	family, ok := m[ReconcileTimeMetric]
+	if !ok {
+		return nil
+	}
+
+	sums := map[string]float64{}
+	counts := map[string]uint64{}
+	for _, metric := range family.GetMetric() {
+		if metric.GetHistogram() == nil {
+			continue
+		}
+		c := labelValue(metric, "controller")
+		sums[c] += metric.GetHistogram().GetSampleSum()
+		counts[c] += metric.GetHistogram().GetSampleCount()
+	}
+
+	averages := map[string]time.Duration{}
+	for c, count := range counts {
+		if count == 0 {
+			continue
+		}
+		averages[c] = time.Duration(sums[c] / float64(count) * float64(time.Second))
+	}
+	return averages
+}
+
+// WorkqueueDepths returns the number of items waiting in each work queue, indexed by queue name;
+// controller-runtime names work queues after the controllers.
+func (m ControllerMetrics) WorkqueueDepths() map[string]float64 {
+	family, ok := m[WorkqueueDepthMetric]
+	if !ok {
+		return nil
+	}
+
+	depths := map[string]float64{}
+	for _, metric := range family.GetMetric() {
+		if metric.GetGauge() == nil {
+			continue
+		}
+		depths[labelValue(metric, "name")] += metric.GetGauge().GetValue()
+	}
+	return depths
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, l := range metric.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
+
+func hasLabels(metric *dto.Metric, labels map[string]string) bool {
+	for k, v := range labels {
+		found := false
+		for _, l := range metric.GetLabel() {
+			if l.GetName() == k && l.GetValue() == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// getDeploymentPods returns the pods belonging to a deployment.
+func getDeploymentPods(ctx context.Context, getLister GetLister, d *appsv1.Deployment) *corev1.PodList {
+	deployment := &appsv1.Deployment{}
+	key := client.ObjectKeyFromObject(d)
+	Expect(getLister.Get(ctx, key, deployment)).To(Succeed(), "Failed to get deployment %s/%s", d.Namespace, d.Name)
+
+	selector, err := metav1.LabelSelectorAsMap(deployment.Spec.Selector)
+	Expect(err).NotTo(HaveOccurred(), "Failed to Pods selector for deployment %s/%s", d.Namespace, d.Name)
+
+	pods := &corev1.PodList{}
+	Expect(getLister.List(ctx, pods, client.InNamespace(d.Namespace), client.MatchingLabels(selector))).To(Succeed(), "Failed to list Pods for deployment %s/%s", d.Namespace, d.Name)
+	return pods
+}
+
+// getPodMetrics reads the metrics of a pod via the API server proxy. It expects to find port 8080 open on the controller.
+func getPodMetrics(ctx context.Context, clientSet *kubernetes.Clientset, pod corev1.Pod) ([]byte, error) {
+	res := clientSet.CoreV1().RESTClient().Get().
+		Namespace(pod.Namespace).
+		Resource("pods").
+		Name(fmt.Sprintf("%s:8080", pod.Name)).
+		SubResource("proxy").
+		Suffix("metrics").
+		Do(ctx)
+	return res.Raw()
+}
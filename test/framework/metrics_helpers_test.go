@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/cluster-api/test/framework"
+)
+
+const testMetrics = `# HELP controller_runtime_reconcile_total Total number of reconciliations per controller
+# TYPE controller_runtime_reconcile_total counter
+controller_runtime_reconcile_total{controller="cluster",result="error"} 1
+controller_runtime_reconcile_total{controller="cluster",result="requeue"} 0
+controller_runtime_reconcile_total{controller="cluster",result="requeue_after"} 1
+controller_runtime_reconcile_total{controller="cluster",result="success"} 2
+controller_runtime_reconcile_total{controller="machine",result="error"} 0
+controller_runtime_reconcile_total{controller="machine",result="success"} 0
+# HELP controller_runtime_reconcile_panics_total Total number of reconciliation panics per controller
+# TYPE controller_runtime_reconcile_panics_total counter
+controller_runtime_reconcile_panics_total{controller="cluster"} 0
+controller_runtime_reconcile_panics_total{controller="machine"} 2
+# HELP controller_runtime_reconcile_time_seconds Length of time per reconciliation per controller
+# TYPE controller_runtime_reconcile_time_seconds histogram
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="0.5"} 3
+controller_runtime_reconcile_time_seconds_bucket{controller="cluster",le="+Inf"} 4
+controller_runtime_reconcile_time_seconds_sum{controller="cluster"} 2
+controller_runtime_reconcile_time_seconds_count{controller="cluster"} 4
+controller_runtime_reconcile_time_seconds_bucket{controller="machine",le="0.5"} 0
+controller_runtime_reconcile_time_seconds_bucket{controller="machine",le="+Inf"} 0
+controller_runtime_reconcile_time_seconds_sum{controller="machine"} 0
+controller_runtime_reconcile_time_seconds_count{controller="machine"} 0
+# HELP workqueue_depth Current depth of workqueue
+# TYPE workqueue_depth gauge
+workqueue_depth{name="cluster"} 3
+workqueue_depth{name="machine"} 0
+`
+
+func TestParseControllerMetrics(t *testing.T) {
+	g := NewWithT(t)
+
+	metrics, err := framework.ParseControllerMetrics([]byte(testMetrics))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(metrics.Sum(framework.ReconcileTotalMetric, nil)).To(Equal(4.0))
+	g.Expect(metrics.Sum(framework.ReconcileTotalMetric, map[string]string{"result": "success"})).To(Equal(2.0))
+	g.Expect(metrics.Sum(framework.ReconcilePanicsTotalMetric, nil)).To(Equal(2.0))
+	g.Expect(metrics.Sum(framework.ReconcilePanicsTotalMetric, map[string]string{"controller": "cluster"})).To(BeZero())
+	g.Expect(metrics.Sum("does_not_exist", nil)).To(BeZero())
+
+	// Controllers without reconciles must not be reported.
+	g.Expect(metrics.ReconcileErrorRates()).To(Equal(map[string]float64{"cluster": 0.25}))
+	g.Expect(metrics.AverageReconcileTimes()).To(Equal(map[string]time.Duration{"cluster": 500 * time.Millisecond}))
+
+	g.Expect(metrics.WorkqueueDepths()).To(Equal(map[string]float64{"cluster": 3, "machine": 0}))
+
+	_, err = framework.ParseControllerMetrics([]byte("not a metric line {"))
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ClusterProvisioningDuration returns the time elapsed from the creation of a Cluster to its Ready condition
+// becoming true, and false if the Cluster is not ready yet.
+// NOTE: the duration has the same granularity of the object timestamps, which is one second.
+func ClusterProvisioningDuration(cluster *clusterv1.Cluster) (time.Duration, bool) {
+	ready := conditions.Get(cluster, clusterv1.ReadyCondition)
+	if ready == nil || ready.Status != corev1.ConditionTrue {
+		return 0, false
+	}
+	return ready.LastTransitionTime.Sub(cluster.CreationTimestamp.Time), true
+}
+
+// DurationPercentile returns the p-th percentile of durations, with p in the (0, 100] range, using the nearest-rank method;
+// if durations is empty, 0 is returned.
+func DurationPercentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestClusterProvisioningDuration(t *testing.T) {
+	g := NewWithT(t)
+
+	created := time.Date(2021, 10, 1, 10, 0, 0, 0, time.UTC)
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+	}
+
+	_, ok := framework.ClusterProvisioningDuration(cluster)
+	g.Expect(ok).To(BeFalse())
+
+	conditions.MarkFalse(cluster, clusterv1.ReadyCondition, "Provisioning", clusterv1.ConditionSeverityInfo, "")
+	_, ok = framework.ClusterProvisioningDuration(cluster)
+	g.Expect(ok).To(BeFalse())
+
+	cluster.Status.Conditions = clusterv1.Conditions{
+		{
+			Type:               clusterv1.ReadyCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(created.Add(3 * time.Minute)),
+		},
+	}
+	d, ok := framework.ClusterProvisioningDuration(cluster)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(d).To(Equal(3 * time.Minute))
+}
+
+func TestDurationPercentile(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(framework.DurationPercentile(nil, 95)).To(BeZero())
+
+	durations := []time.Duration{}
+	for i := 20; i > 0; i-- {
+		durations = append(durations, time.Duration(i)*time.Second)
+	}
+	g.Expect(framework.DurationPercentile(durations, 95)).To(Equal(19 * time.Second))
+	g.Expect(framework.DurationPercentile(durations, 50)).To(Equal(10 * time.Second))
+	g.Expect(framework.DurationPercentile(durations, 100)).To(Equal(20 * time.Second))
+	g.Expect(framework.DurationPercentile(durations, 0)).To(Equal(1 * time.Second))
+
+	// The input is not modified.
+	g.Expect(durations[0]).To(Equal(20 * time.Second))
+}
@@ -15,6 +15,8 @@ require (
 	github.com/onsi/ginkgo v1.16.4
 	github.com/onsi/gomega v1.16.0
 	github.com/pkg/errors v0.9.1
+	github.com/prometheus/client_model v0.2.0
+	github.com/prometheus/common v0.26.0
 	github.com/spf13/pflag v1.0.5
 	k8s.io/api v0.22.2
 	k8s.io/apiextensions-apiserver v0.22.2
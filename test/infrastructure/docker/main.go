@@ -58,6 +58,7 @@ var (
 	syncPeriod           time.Duration
 	concurrency          int
 	healthAddr           string
+	featureGatesFile     string
 	webhookPort          int
 	webhookCertDir       string
 )
@@ -94,6 +95,9 @@ func initFlags(fs *pflag.FlagSet) {
 		"Webhook cert dir, only used when webhook-port is specified.")
 
 	feature.MutableGates.AddFlag(fs)
+
+	fs.StringVar(&featureGatesFile, "feature-gates-file", "",
+		"Path of a file, usually mounted from a ConfigMap, setting the feature gates as a YAML map of feature gate names to booleans; the file is reloaded on changes and the controller restarts when feature gates change value. Feature gates set with --feature-gates take precedence")
 }
 
 func main() {
@@ -125,8 +129,12 @@ func main() {
 	}
 
 	// Setup the context that's going to be used in controllers and for the manager.
-	ctx := ctrl.SetupSignalHandler()
+	ctx, cancel := context.WithCancel(ctrl.SetupSignalHandler())
 
+	if err := feature.SetupGatesFileWithRestart(mgr, featureGatesFile, cancel); err != nil {
+		setupLog.Error(err, "unable to setup feature gates")
+		os.Exit(1)
+	}
 	setupChecks(mgr)
 	setupReconcilers(ctx, mgr)
 	setupWebhooks(mgr)
@@ -0,0 +1,80 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/version"
+)
+
+const (
+	// LastReconcileResultSuccess is the result recorded when a reconcile completes without errors.
+	LastReconcileResultSuccess = "Success"
+
+	// LastReconcileResultError is the result recorded when a reconcile returns an error.
+	LastReconcileResultError = "Error"
+
+	// unknownErrorClass is the error class recorded for errors not originated by the API server.
+	unknownErrorClass = "Unknown"
+
+	// unknownVersion is the version recorded when the controller has been built without version information.
+	unknownVersion = "unknown"
+)
+
+// SetLastReconcile sets the LastReconcileAnnotation on the object, recording the version of the running
+// controller, the result of the reconcile, and, in case of errors, the error class; the error class is
+// the reason of the first API server error, if any, or Unknown.
+// The error message is deliberately not recorded, so the annotation changes only when the controller version
+// or the outcome of the reconcile change. It returns true if the annotation has changed.
+func SetLastReconcile(o metav1.Object, err error) bool {
+	return AddAnnotations(o, map[string]string{
+		clusterv1.LastReconcileAnnotation: lastReconcileValue(version.Get().GitVersion, err),
+	})
+}
+
+func lastReconcileValue(controllerVersion string, err error) string {
+	if controllerVersion == "" {
+		controllerVersion = unknownVersion
+	}
+	if err == nil {
+		return fmt.Sprintf("version=%s,result=%s", controllerVersion, LastReconcileResultSuccess)
+	}
+	return fmt.Sprintf("version=%s,result=%s,error=%s", controllerVersion, LastReconcileResultError, errorClass(err))
+}
+
+// errorClass returns the reason of the first API server error found in err, or Unknown.
+func errorClass(err error) string {
+	var agg kerrors.Aggregate
+	if errors.As(err, &agg) {
+		for _, e := range agg.Errors() {
+			if class := errorClass(e); class != unknownErrorClass {
+				return class
+			}
+		}
+		return unknownErrorClass
+	}
+	if reason := apierrors.ReasonForError(errors.Cause(err)); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	return unknownErrorClass
+}
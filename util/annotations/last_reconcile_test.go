@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestLastReconcileValue(t *testing.T) {
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "machines"}, "foo", errors.New("object has been modified"))
+
+	tests := []struct {
+		name    string
+		version string
+		err     error
+		want    string
+	}{
+		{
+			name:    "success",
+			version: "v1.0.0",
+			want:    "version=v1.0.0,result=Success",
+		},
+		{
+			name: "success without version information",
+			want: "version=unknown,result=Success",
+		},
+		{
+			name:    "API server error",
+			version: "v1.0.0",
+			err:     conflict,
+			want:    "version=v1.0.0,result=Error,error=Conflict",
+		},
+		{
+			name:    "wrapped API server error",
+			version: "v1.0.0",
+			err:     errors.Wrap(conflict, "failed to patch"),
+			want:    "version=v1.0.0,result=Error,error=Conflict",
+		},
+		{
+			name:    "aggregate error",
+			version: "v1.0.0",
+			err:     kerrors.NewAggregate([]error{errors.New("boom"), conflict}),
+			want:    "version=v1.0.0,result=Error,error=Conflict",
+		},
+		{
+			name:    "other errors",
+			version: "v1.0.0",
+			err:     errors.New("boom"),
+			want:    "version=v1.0.0,result=Error,error=Unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(lastReconcileValue(tt.version, tt.err)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSetLastReconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	node := &corev1.Node{}
+	g.Expect(SetLastReconcile(node, nil)).To(BeTrue())
+	g.Expect(node.GetAnnotations()).To(HaveKey(clusterv1.LastReconcileAnnotation))
+
+	// Setting the same outcome again does not change the annotation.
+	g.Expect(SetLastReconcile(node, nil)).To(BeFalse())
+
+	g.Expect(SetLastReconcile(node, errors.New("boom"))).To(BeTrue())
+	g.Expect(node.GetAnnotations()[clusterv1.LastReconcileAnnotation]).To(HaveSuffix("result=Error,error=Unknown"))
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// deprecatedAnnotations maps the deprecated spellings of well-known annotations, e.g. the ones using the
+// pre-v1alpha2 cluster.k8s.io prefix, to the annotation to be used instead.
+// Deprecated spellings are not honored by the accessors in this package; the webhooks warn about them.
+var deprecatedAnnotations = map[string]string{
+	"cluster.k8s.io/delete-machine": clusterv1.DeleteMachineAnnotation,
+	"cluster.k8s.io/paused":         clusterv1.PausedAnnotation,
+}
+
+// presenceAnnotations are the well-known annotations which are honored when set, regardless of their value.
+var presenceAnnotations = []string{
+	clusterv1.PausedAnnotation,
+	clusterv1.DeleteMachineAnnotation,
+	clusterv1.MachineSkipRemediationAnnotation,
+	clusterv1.ExcludeNodeDrainingAnnotation,
+	clusterv1.CordonOnlyAnnotation,
+	clusterv1.ManagedByAnnotation,
+}
+
+// HasDeleteMachineAnnotation returns true if the object has the `delete-machine` annotation.
+func HasDeleteMachineAnnotation(o metav1.Object) bool {
+	return hasAnnotation(o, clusterv1.DeleteMachineAnnotation)
+}
+
+// HasExcludeNodeDrainingAnnotation returns true if the object has the `exclude-node-draining` annotation.
+func HasExcludeNodeDrainingAnnotation(o metav1.Object) bool {
+	return hasAnnotation(o, clusterv1.ExcludeNodeDrainingAnnotation)
+}
+
+// HasCordonOnlyAnnotation returns true if the object has the `cordon-only` annotation.
+func HasCordonOnlyAnnotation(o metav1.Object) bool {
+	return hasAnnotation(o, clusterv1.CordonOnlyAnnotation)
+}
+
+// GetAdoptNode returns the name of the Node to be adopted by the Machine, as set in the `adopt-node` annotation,
+// or an empty string if the annotation is not set.
+func GetAdoptNode(o metav1.Object) string {
+	return o.GetAnnotations()[clusterv1.AdoptNodeAnnotation]
+}
+
+// GetCertificatesExpiryDate returns the expiry date of the machine certificates set in the `certificates-expiry`
+// annotation; it returns nil if the annotation is not set, and an error if its value is not in RFC3339 format.
+func GetCertificatesExpiryDate(o metav1.Object) (*metav1.Time, error) {
+	value, ok := o.GetAnnotations()[clusterv1.MachineCertificatesExpiryDateAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the %s annotation", clusterv1.MachineCertificatesExpiryDateAnnotation)
+	}
+	return &metav1.Time{Time: expiry}, nil
+}
+
+// ValidateWellKnownAnnotations validates the values of the well-known Cluster API annotations.
+func ValidateWellKnownAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if value, ok := annotations[clusterv1.AdoptNodeAnnotation]; ok {
+		for _, msg := range validation.IsDNS1123Subdomain(value) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(clusterv1.AdoptNodeAnnotation), value, msg))
+		}
+	}
+
+	if value, ok := annotations[clusterv1.MachineCertificatesExpiryDateAnnotation]; ok {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(clusterv1.MachineCertificatesExpiryDateAnnotation), value, "must be a date in RFC3339 format"))
+		}
+	}
+
+	return allErrs
+}
+
+// WellKnownAnnotationsWarnings returns the warnings about the well-known Cluster API annotations, i.e. about
+// deprecated spellings and about annotations honored regardless of their value being set to false.
+func WellKnownAnnotationsWarnings(annotations map[string]string) []string {
+	var warnings []string
+
+	for deprecated, annotation := range deprecatedAnnotations {
+		if _, ok := annotations[deprecated]; ok {
+			warnings = append(warnings, fmt.Sprintf("annotation %q is deprecated, use %q instead", deprecated, annotation))
+		}
+	}
+
+	for _, annotation := range presenceAnnotations {
+		if value, ok := annotations[annotation]; ok && strings.EqualFold(value, "false") {
+			warnings = append(warnings, fmt.Sprintf("annotation %q is honored regardless of its value, remove it instead of setting it to %q", annotation, value))
+		}
+	}
+
+	sort.Strings(warnings)
+	return warnings
+}
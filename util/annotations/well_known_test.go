@@ -0,0 +1,121 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestWellKnownAnnotationAccessors(t *testing.T) {
+	newMachine := func(annotations map[string]string) *clusterv1.Machine {
+		return &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+	}
+
+	t.Run("presence annotations are honored regardless of their value", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(HasDeleteMachineAnnotation(newMachine(nil))).To(BeFalse())
+		g.Expect(HasDeleteMachineAnnotation(newMachine(map[string]string{clusterv1.DeleteMachineAnnotation: ""}))).To(BeTrue())
+		g.Expect(HasExcludeNodeDrainingAnnotation(newMachine(map[string]string{clusterv1.ExcludeNodeDrainingAnnotation: "false"}))).To(BeTrue())
+		g.Expect(HasCordonOnlyAnnotation(newMachine(map[string]string{clusterv1.CordonOnlyAnnotation: "yes"}))).To(BeTrue())
+	})
+
+	t.Run("returns the Node to be adopted", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(GetAdoptNode(newMachine(nil))).To(BeEmpty())
+		g.Expect(GetAdoptNode(newMachine(map[string]string{clusterv1.AdoptNodeAnnotation: "node-1"}))).To(Equal("node-1"))
+	})
+
+	t.Run("returns the certificates expiry date", func(t *testing.T) {
+		g := NewWithT(t)
+
+		expiry, err := GetCertificatesExpiryDate(newMachine(nil))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(expiry).To(BeNil())
+
+		expiry, err = GetCertificatesExpiryDate(newMachine(map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: "2021-12-31T23:59:59Z"}))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(expiry.Time).To(Equal(time.Date(2021, 12, 31, 23, 59, 59, 0, time.UTC)))
+
+		_, err = GetCertificatesExpiryDate(newMachine(map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: "tomorrow"}))
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestValidateWellKnownAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectErr   bool
+	}{
+		{
+			name:        "accepts objects without annotations",
+			annotations: nil,
+		},
+		{
+			name: "accepts valid values",
+			annotations: map[string]string{
+				clusterv1.AdoptNodeAnnotation:                     "node-1.example.com",
+				clusterv1.MachineCertificatesExpiryDateAnnotation: "2021-12-31T23:59:59Z",
+				clusterv1.PausedAnnotation:                        "anything",
+			},
+		},
+		{
+			name:        "rejects an invalid Node name",
+			annotations: map[string]string{clusterv1.AdoptNodeAnnotation: "Node_1"},
+			expectErr:   true,
+		},
+		{
+			name:        "rejects an expiry date not in RFC3339 format",
+			annotations: map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: "2021-12-31"},
+			expectErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := ValidateWellKnownAnnotations(tt.annotations, field.NewPath("metadata", "annotations"))
+			if tt.expectErr {
+				g.Expect(errs).ToNot(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestWellKnownAnnotationsWarnings(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(WellKnownAnnotationsWarnings(map[string]string{clusterv1.PausedAnnotation: ""})).To(BeEmpty())
+	g.Expect(WellKnownAnnotationsWarnings(map[string]string{
+		"cluster.k8s.io/delete-machine": "",
+		clusterv1.PausedAnnotation:      "False",
+	})).To(ConsistOf(
+		ContainSubstring(`"cluster.k8s.io/delete-machine" is deprecated, use "cluster.x-k8s.io/delete-machine" instead`),
+		ContainSubstring(`"cluster.x-k8s.io/paused" is honored regardless of its value`),
+	))
+}
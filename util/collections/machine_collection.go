@@ -104,6 +104,32 @@ func (s Machines) Difference(machines Machines) Machines {
 	})
 }
 
+// Intersection returns a copy with only the machines that are also in the given collection.
+func (s Machines) Intersection(machines Machines) Machines {
+	return s.Filter(func(m *clusterv1.Machine) bool {
+		_, found := machines[m.Name]
+		return found
+	})
+}
+
+// Union returns a copy with the machines in either collection; if a machine is in both collections,
+// the one from the given collection is used.
+func (s Machines) Union(machines Machines) Machines {
+	result := make(Machines, len(s)+len(machines))
+	result.Insert(s.UnsortedList()...)
+	result.Insert(machines.UnsortedList()...)
+	return result
+}
+
+// Has returns true if a machine with the same name is in the collection.
+func (s Machines) Has(machine *clusterv1.Machine) bool {
+	if machine == nil {
+		return false
+	}
+	_, found := s[machine.Name]
+	return found
+}
+
 // SortedByCreationTimestamp returns the machines sorted by creation timestamp.
 func (s Machines) SortedByCreationTimestamp() []*clusterv1.Machine {
 	res := make(util.MachinesByCreationTimestamp, 0, len(s))
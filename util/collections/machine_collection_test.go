@@ -51,6 +51,39 @@ func TestMachineCollection(t *testing.T) {
 			g.Expect(c3.Names()).To(ConsistOf("machine-1"))
 		})
 	})
+	t.Run("Intersection", func(t *testing.T) {
+		t.Run("should return the collection with only the elements in both collections", func(t *testing.T) {
+			g := NewWithT(t)
+			collection := machines()
+			c2 := collections.FromMachines(machine("machine-1"), machine("machine-2"), machine("machine-6"))
+			c3 := collection.Intersection(c2)
+			// does not mutate
+			g.Expect(collection.Names()).To(HaveLen(5))
+			g.Expect(c3.Names()).To(ConsistOf("machine-1", "machine-2"))
+			// keeps the machines from the first collection
+			g.Expect(c3["machine-1"].CreationTimestamp).To(Equal(collection["machine-1"].CreationTimestamp))
+		})
+	})
+	t.Run("Union", func(t *testing.T) {
+		t.Run("should return the collection with the elements in either collection", func(t *testing.T) {
+			g := NewWithT(t)
+			collection := collections.FromMachines(machine("machine-1"), machine("machine-2"))
+			c2 := collections.FromMachines(machine("machine-2"), machine("machine-3"))
+			c3 := collection.Union(c2)
+			// does not mutate
+			g.Expect(collection.Names()).To(ConsistOf("machine-1", "machine-2"))
+			g.Expect(c3.Names()).To(ConsistOf("machine-1", "machine-2", "machine-3"))
+		})
+	})
+	t.Run("Has", func(t *testing.T) {
+		t.Run("should return true only for machines in the collection", func(t *testing.T) {
+			g := NewWithT(t)
+			collection := machines()
+			g.Expect(collection.Has(machine("machine-1"))).To(BeTrue())
+			g.Expect(collection.Has(machine("machine-6"))).To(BeFalse())
+			g.Expect(collection.Has(nil)).To(BeFalse())
+		})
+	})
 	t.Run("Names", func(t *testing.T) {
 		t.Run("should return a slice of names of each machine in the collection", func(t *testing.T) {
 			g := NewWithT(t)
@@ -17,7 +17,10 @@ limitations under the License.
 package collections
 
 import (
+	"time"
+
 	"github.com/blang/semver"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -96,6 +99,18 @@ func InFailureDomains(failureDomains ...*string) Func {
 	}
 }
 
+// InClusterFailureDomains returns a filter to find all machines in any of the given failure domains,
+// e.g. the failure domains reported in the Cluster status; machines without a failure domain are never matched.
+func InClusterFailureDomains(failureDomains clusterv1.FailureDomains) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil || machine.Spec.FailureDomain == nil {
+			return false
+		}
+		_, ok := failureDomains[*machine.Spec.FailureDomain]
+		return ok
+	}
+}
+
 // OwnedMachines returns a filter to find all machines owned by specified owner.
 // Usage: GetFilteredMachinesForCluster(ctx, client, cluster, OwnedMachines(controlPlane)).
 func OwnedMachines(owner client.Object) func(machine *clusterv1.Machine) bool {
@@ -165,6 +180,50 @@ func IsReady() Func {
 	}
 }
 
+// HasReadinessGatesSatisfied returns true if all the conditions listed in the machine's readiness gates are True.
+// NOTE: As in the conditions package, a machine without one of those conditions is considered to have the condition
+// in the Unknown status, and thus the readiness gate is not satisfied.
+func HasReadinessGatesSatisfied(machine *clusterv1.Machine) bool {
+	if machine == nil {
+		return false
+	}
+	for _, gate := range machine.Spec.ReadinessGates {
+		if !conditions.IsTrue(machine, gate.ConditionType) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasConditionStatus returns a filter to find all machines with the given condition in the given status.
+// NOTE: As in the conditions package, a machine without the condition is considered to have the condition
+// in the Unknown status.
+func HasConditionStatus(t clusterv1.ConditionType, status corev1.ConditionStatus) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		switch status {
+		case corev1.ConditionTrue:
+			return conditions.IsTrue(machine, t)
+		case corev1.ConditionFalse:
+			return conditions.IsFalse(machine, t)
+		default:
+			return conditions.IsUnknown(machine, t)
+		}
+	}
+}
+
+// OlderThan returns a filter to find all machines created more than age before reconciliationTime.
+func OlderThan(reconciliationTime time.Time, age time.Duration) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		return machine.CreationTimestamp.Add(age).Before(reconciliationTime)
+	}
+}
+
 // ShouldRolloutAfter returns a filter to find all machines where
 // CreationTimestamp < rolloutAfter < reconciliationTIme.
 func ShouldRolloutAfter(reconciliationTime, rolloutAfter *metav1.Time) Func {
@@ -176,6 +235,21 @@ func ShouldRolloutAfter(reconciliationTime, rolloutAfter *metav1.Time) Func {
 	}
 }
 
+// ShouldRolloutBefore returns a filter to find all machines whose
+// certificates will expire within the days specified in RolloutBefore from now.
+func ShouldRolloutBefore(reconciliationTime *metav1.Time, rolloutBefore *controlplanev1.RolloutBefore) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if reconciliationTime == nil || rolloutBefore == nil || rolloutBefore.CertificatesExpiryDays == nil {
+			return false
+		}
+		if machine == nil || machine.Status.CertificatesExpiryDate == nil {
+			return false
+		}
+		certsExpiryTime := machine.Status.CertificatesExpiryDate.Time
+		return reconciliationTime.Add(time.Duration(*rolloutBefore.CertificatesExpiryDays) * 24 * time.Hour).After(certsExpiryTime)
+	}
+}
+
 // HasAnnotationKey returns a filter to find all machines that have the
 // specified Annotation key present.
 func HasAnnotationKey(key string) Func {
@@ -233,6 +307,21 @@ func WithVersion() Func {
 	}
 }
 
+// InVersionRange returns a filter to find all machines with a valid version in the given range,
+// e.g. semver.MustParseRange(">=1.21.0 <1.22.0").
+func InVersionRange(versionRange semver.Range) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil || machine.Spec.Version == nil {
+			return false
+		}
+		v, err := semver.ParseTolerant(*machine.Spec.Version)
+		if err != nil {
+			return false
+		}
+		return versionRange(v)
+	}
+}
+
 // HealthyAPIServer returns a filter to find all machines that have a MachineAPIServerPodHealthyCondition
 // set to true.
 func HealthyAPIServer() Func {
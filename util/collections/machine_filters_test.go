@@ -20,7 +20,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/blang/semver"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -164,6 +166,53 @@ func TestShouldRolloutAfter(t *testing.T) {
 	})
 }
 
+func TestShouldRolloutBeforeCertificatesExpire(t *testing.T) {
+	reconciliationTime := &metav1.Time{Time: time.Now()}
+	t.Run("if rolloutBefore is nil it should return false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(collections.ShouldRolloutBefore(reconciliationTime, nil)(m)).To(BeFalse())
+	})
+	t.Run("if rolloutBefore.certificatesExpiryDays is nil it should return false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(collections.ShouldRolloutBefore(reconciliationTime, &controlplanev1.RolloutBefore{})(m)).To(BeFalse())
+	})
+	t.Run("if machine is nil it should return false", func(t *testing.T) {
+		g := NewWithT(t)
+		rb := &controlplanev1.RolloutBefore{CertificatesExpiryDays: pointer.Int32Ptr(10)}
+		g.Expect(collections.ShouldRolloutBefore(reconciliationTime, rb)(nil)).To(BeFalse())
+	})
+	t.Run("if the machine certificate expiry information is not available it should return false", func(t *testing.T) {
+		g := NewWithT(t)
+		rb := &controlplanev1.RolloutBefore{CertificatesExpiryDays: pointer.Int32Ptr(10)}
+		m := &clusterv1.Machine{}
+		g.Expect(collections.ShouldRolloutBefore(reconciliationTime, rb)(m)).To(BeFalse())
+	})
+	t.Run("if the machine certificates are not going to expire within the expiry time it should return false", func(t *testing.T) {
+		g := NewWithT(t)
+		rb := &controlplanev1.RolloutBefore{CertificatesExpiryDays: pointer.Int32Ptr(10)}
+		certificateExpiryTime := reconciliationTime.Add(60 * 24 * time.Hour) // certificates will expire in 60 days from 'now'.
+		m := &clusterv1.Machine{
+			Status: clusterv1.MachineStatus{
+				CertificatesExpiryDate: &metav1.Time{Time: certificateExpiryTime},
+			},
+		}
+		g.Expect(collections.ShouldRolloutBefore(reconciliationTime, rb)(m)).To(BeFalse())
+	})
+	t.Run("if machine certificates will expire within the expiry time then it should return true", func(t *testing.T) {
+		g := NewWithT(t)
+		rb := &controlplanev1.RolloutBefore{CertificatesExpiryDays: pointer.Int32Ptr(10)}
+		certificateExpiryTime := reconciliationTime.Add(5 * 24 * time.Hour) // certificates will expire in 5 days from 'now'.
+		m := &clusterv1.Machine{
+			Status: clusterv1.MachineStatus{
+				CertificatesExpiryDate: &metav1.Time{Time: certificateExpiryTime},
+			},
+		}
+		g.Expect(collections.ShouldRolloutBefore(reconciliationTime, rb)(m)).To(BeTrue())
+	})
+}
+
 func TestHashAnnotationKey(t *testing.T) {
 	t.Run("machine with specified annotation returns true", func(t *testing.T) {
 		g := NewWithT(t)
@@ -221,6 +270,97 @@ func TestInFailureDomain(t *testing.T) {
 	})
 }
 
+func TestInClusterFailureDomains(t *testing.T) {
+	failureDomains := clusterv1.FailureDomains{
+		"one": clusterv1.FailureDomainSpec{ControlPlane: true},
+		"two": clusterv1.FailureDomainSpec{},
+	}
+	t.Run("nil machine returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.InClusterFailureDomains(failureDomains)(nil)).To(BeFalse())
+	})
+	t.Run("machine without failure domain returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.InClusterFailureDomains(failureDomains)(&clusterv1.Machine{})).To(BeFalse())
+	})
+	t.Run("machine in one of the failure domains returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{Spec: clusterv1.MachineSpec{FailureDomain: pointer.StringPtr("two")}}
+		g.Expect(collections.InClusterFailureDomains(failureDomains)(m)).To(BeTrue())
+		g.Expect(collections.InClusterFailureDomains(failureDomains.FilterControlPlane())(m)).To(BeFalse())
+	})
+	t.Run("machine in a different failure domain returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{Spec: clusterv1.MachineSpec{FailureDomain: pointer.StringPtr("three")}}
+		g.Expect(collections.InClusterFailureDomains(failureDomains)(m)).To(BeFalse())
+	})
+}
+
+func TestHasConditionStatus(t *testing.T) {
+	t.Run("nil machine returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionTrue)(nil)).To(BeFalse())
+	})
+	t.Run("machine without the condition matches only Unknown", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionTrue)(m)).To(BeFalse())
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionFalse)(m)).To(BeFalse())
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionUnknown)(m)).To(BeTrue())
+	})
+	t.Run("machine with the condition matches its status", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		conditions.MarkFalse(m, clusterv1.ReadyCondition, "reason", clusterv1.ConditionSeverityWarning, "")
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionTrue)(m)).To(BeFalse())
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionFalse)(m)).To(BeTrue())
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionUnknown)(m)).To(BeFalse())
+	})
+}
+
+func TestHasReadinessGatesSatisfied(t *testing.T) {
+	t.Run("nil machine returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.HasReadinessGatesSatisfied(nil)).To(BeFalse())
+	})
+	t.Run("machine without readiness gates returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.HasReadinessGatesSatisfied(&clusterv1.Machine{})).To(BeTrue())
+	})
+	t.Run("machine with readiness gates returns true only if all the conditions are true", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{
+			Spec: clusterv1.MachineSpec{
+				ReadinessGates: []clusterv1.MachineReadinessGate{{ConditionType: "CNIReady"}, {ConditionType: "Registered"}},
+			},
+		}
+		g.Expect(collections.HasReadinessGatesSatisfied(m)).To(BeFalse())
+		conditions.MarkTrue(m, "CNIReady")
+		conditions.MarkFalse(m, "Registered", "reason", clusterv1.ConditionSeverityInfo, "")
+		g.Expect(collections.HasReadinessGatesSatisfied(m)).To(BeFalse())
+		conditions.MarkTrue(m, "Registered")
+		g.Expect(collections.HasReadinessGatesSatisfied(m)).To(BeTrue())
+	})
+}
+
+func TestOlderThan(t *testing.T) {
+	now := time.Now()
+	t.Run("nil machine returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.OlderThan(now, time.Hour)(nil)).To(BeFalse())
+	})
+	t.Run("machine created before the given age returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour))}}
+		g.Expect(collections.OlderThan(now, time.Hour)(m)).To(BeTrue())
+	})
+	t.Run("machine created after the given age returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-30 * time.Minute))}}
+		g.Expect(collections.OlderThan(now, time.Hour)(m)).To(BeFalse())
+	})
+}
+
 func TestActiveMachinesInCluster(t *testing.T) {
 	t.Run("machine with deletion timestamp returns false", func(t *testing.T) {
 		g := NewWithT(t)
@@ -329,6 +469,33 @@ func TestWithVersion(t *testing.T) {
 	})
 }
 
+func TestInVersionRange(t *testing.T) {
+	versionRange := semver.MustParseRange(">=1.21.0 <1.22.0")
+	t.Run("nil machine returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.InVersionRange(versionRange)(nil)).To(BeFalse())
+	})
+	t.Run("machine without version returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.InVersionRange(versionRange)(&clusterv1.Machine{})).To(BeFalse())
+	})
+	t.Run("machine with an invalid version returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{Spec: clusterv1.MachineSpec{Version: pointer.StringPtr("invalid")}}
+		g.Expect(collections.InVersionRange(versionRange)(m)).To(BeFalse())
+	})
+	t.Run("machine with a version in the range returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{Spec: clusterv1.MachineSpec{Version: pointer.StringPtr("v1.21.3")}}
+		g.Expect(collections.InVersionRange(versionRange)(m)).To(BeTrue())
+	})
+	t.Run("machine with a version outside the range returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{Spec: clusterv1.MachineSpec{Version: pointer.StringPtr("v1.22.0")}}
+		g.Expect(collections.InVersionRange(versionRange)(m)).To(BeFalse())
+	})
+}
+
 func TestHealtyAPIServer(t *testing.T) {
 	t.Run("nil machine returns false", func(t *testing.T) {
 		g := NewWithT(t)
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+)
+
+// SyncClusterFailure keeps the legacy status.failureReason and status.failureMessage fields of a Cluster
+// and the NoTerminalFailure condition in sync; see syncFailure for more details.
+func SyncClusterFailure(cluster *clusterv1.Cluster) {
+	var reason *string
+	if cluster.Status.FailureReason != nil {
+		reason = (*string)(cluster.Status.FailureReason)
+	}
+	reason, cluster.Status.FailureMessage = syncFailure(cluster, reason, cluster.Status.FailureMessage)
+	if reason != nil {
+		cluster.Status.FailureReason = (*capierrors.ClusterStatusError)(reason)
+	}
+}
+
+// SyncMachineFailure keeps the legacy status.failureReason and status.failureMessage fields of a Machine
+// and the NoTerminalFailure condition in sync; see syncFailure for more details.
+func SyncMachineFailure(machine *clusterv1.Machine) {
+	var reason *string
+	if machine.Status.FailureReason != nil {
+		reason = (*string)(machine.Status.FailureReason)
+	}
+	reason, machine.Status.FailureMessage = syncFailure(machine, reason, machine.Status.FailureMessage)
+	if reason != nil {
+		machine.Status.FailureReason = (*capierrors.MachineStatusError)(reason)
+	}
+}
+
+// SyncMachineSetFailure keeps the legacy status.failureReason and status.failureMessage fields of a MachineSet
+// and the NoTerminalFailure condition in sync; see syncFailure for more details.
+func SyncMachineSetFailure(machineSet *clusterv1.MachineSet) {
+	var reason *string
+	if machineSet.Status.FailureReason != nil {
+		reason = (*string)(machineSet.Status.FailureReason)
+	}
+	reason, machineSet.Status.FailureMessage = syncFailure(machineSet, reason, machineSet.Status.FailureMessage)
+	if reason != nil {
+		machineSet.Status.FailureReason = (*capierrors.MachineSetStatusError)(reason)
+	}
+}
+
+// syncFailure keeps the legacy failureReason and failureMessage fields and the NoTerminalFailure condition in sync,
+// returning the values for the legacy fields:
+//   - if the legacy fields report a failure, the condition is set to False, using the failureReason as a reason
+//     (or TerminalFailureReason, if only the failureMessage is set) and the failureMessage as a message;
+//   - otherwise, the condition is set to True, so clearing the legacy fields also clears the terminal failure.
+func syncFailure(obj Setter, failureReason, failureMessage *string) (*string, *string) {
+	if failureReason != nil || failureMessage != nil {
+		reason := clusterv1.TerminalFailureReason
+		if failureReason != nil && *failureReason != "" {
+			reason = *failureReason
+		}
+		message := ""
+		if failureMessage != nil {
+			message = *failureMessage
+		}
+		Set(obj, &clusterv1.Condition{
+			Type:     clusterv1.NoTerminalFailureCondition,
+			Status:   corev1.ConditionFalse,
+			Severity: clusterv1.ConditionSeverityError,
+			Reason:   reason,
+			Message:  message,
+		})
+		return failureReason, failureMessage
+	}
+
+	MarkTrue(obj, clusterv1.NoTerminalFailureCondition)
+	return nil, nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	capierrors "sigs.k8s.io/cluster-api/errors"
+)
+
+func TestSyncClusterFailure(t *testing.T) {
+	t.Run("no failure sets the condition to true", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := &clusterv1.Cluster{}
+		SyncClusterFailure(cluster)
+
+		g.Expect(IsTrue(cluster, clusterv1.NoTerminalFailureCondition)).To(BeTrue())
+		g.Expect(cluster.Status.FailureReason).To(BeNil())
+		g.Expect(cluster.Status.FailureMessage).To(BeNil())
+	})
+
+	t.Run("legacy fields are mirrored into the condition", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := &clusterv1.Cluster{}
+		MarkTrue(cluster, clusterv1.NoTerminalFailureCondition)
+		cluster.Status.FailureReason = capierrors.ClusterStatusErrorPtr(capierrors.InvalidConfigurationClusterError)
+		cluster.Status.FailureMessage = pointer.StringPtr("invalid configuration")
+		SyncClusterFailure(cluster)
+
+		c := Get(cluster, clusterv1.NoTerminalFailureCondition)
+		g.Expect(c).ToNot(BeNil())
+		g.Expect(c.Status).To(Equal(corev1.ConditionFalse))
+		g.Expect(c.Severity).To(Equal(clusterv1.ConditionSeverityError))
+		g.Expect(c.Reason).To(Equal(string(capierrors.InvalidConfigurationClusterError)))
+		g.Expect(c.Message).To(Equal("invalid configuration"))
+		g.Expect(*cluster.Status.FailureReason).To(Equal(capierrors.InvalidConfigurationClusterError))
+		g.Expect(*cluster.Status.FailureMessage).To(Equal("invalid configuration"))
+	})
+
+	t.Run("failure message only uses the default reason", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := &clusterv1.Cluster{}
+		cluster.Status.FailureMessage = pointer.StringPtr("something failed")
+		SyncClusterFailure(cluster)
+
+		g.Expect(GetReason(cluster, clusterv1.NoTerminalFailureCondition)).To(Equal(clusterv1.TerminalFailureReason))
+		g.Expect(GetMessage(cluster, clusterv1.NoTerminalFailureCondition)).To(Equal("something failed"))
+		g.Expect(cluster.Status.FailureReason).To(BeNil())
+	})
+
+	t.Run("clearing the legacy fields clears the condition", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := &clusterv1.Cluster{}
+		MarkFalse(cluster, clusterv1.NoTerminalFailureCondition, string(capierrors.CreateClusterError), clusterv1.ConditionSeverityError, "failed to create the load balancer")
+		SyncClusterFailure(cluster)
+
+		g.Expect(IsTrue(cluster, clusterv1.NoTerminalFailureCondition)).To(BeTrue())
+		g.Expect(cluster.Status.FailureReason).To(BeNil())
+		g.Expect(cluster.Status.FailureMessage).To(BeNil())
+	})
+}
+
+func TestSyncMachineFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	machine := &clusterv1.Machine{}
+	machine.Status.FailureReason = capierrors.MachineStatusErrorPtr(capierrors.InvalidConfigurationMachineError)
+	SyncMachineFailure(machine)
+
+	g.Expect(GetReason(machine, clusterv1.NoTerminalFailureCondition)).To(Equal(string(capierrors.InvalidConfigurationMachineError)))
+	g.Expect(GetMessage(machine, clusterv1.NoTerminalFailureCondition)).To(BeEmpty())
+	g.Expect(machine.Status.FailureMessage).To(BeNil())
+
+	machine.Status.FailureReason = nil
+	SyncMachineFailure(machine)
+
+	g.Expect(IsTrue(machine, clusterv1.NoTerminalFailureCondition)).To(BeTrue())
+	g.Expect(machine.Status.FailureReason).To(BeNil())
+}
+
+func TestSyncMachineSetFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	machineSet := &clusterv1.MachineSet{}
+	SyncMachineSetFailure(machineSet)
+	g.Expect(IsTrue(machineSet, clusterv1.NoTerminalFailureCondition)).To(BeTrue())
+
+	failureReason := capierrors.InvalidConfigurationMachineSetError
+	machineSet.Status.FailureReason = &failureReason
+	machineSet.Status.FailureMessage = pointer.StringPtr("invalid selector")
+	SyncMachineSetFailure(machineSet)
+	g.Expect(GetReason(machineSet, clusterv1.NoTerminalFailureCondition)).To(Equal(string(capierrors.InvalidConfigurationMachineSetError)))
+
+	machineSet.Status.FailureReason = nil
+	machineSet.Status.FailureMessage = nil
+	SyncMachineSetFailure(machineSet)
+	g.Expect(IsTrue(machineSet, clusterv1.NoTerminalFailureCondition)).To(BeTrue())
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// contractVersionsSeparator is the separator used in the value of the contract label
+// when a CRD supports a contract with more than one API version, e.g. "v1alpha4_v1beta1".
+const contractVersionsSeparator = "_"
+
+// ContractLabelName returns the name of the label that CRDs use for declaring the API versions
+// supporting a Cluster API contract, e.g. "cluster.x-k8s.io/v1beta1" for the v1beta1 contract.
+func ContractLabelName(contract string) string {
+	return schema.GroupVersion{Group: clusterv1.GroupVersion.Group, Version: contract}.String()
+}
+
+// GetContractVersions returns the API versions of a CRD supporting a Cluster API contract, e.g. "v1beta1",
+// as declared by the contract label on the CRD; the versions are sorted from the oldest to the newest.
+// If the CRD does not support the contract, an empty list is returned.
+func GetContractVersions(crd metav1.Object, contract string) []string {
+	value := crd.GetLabels()[ContractLabelName(contract)]
+	if value == "" {
+		return nil
+	}
+
+	versions := util.KubeAwareAPIVersions{}
+	for _, v := range strings.Split(value, contractVersionsSeparator) {
+		if v != "" {
+			versions = append(versions, v)
+		}
+	}
+	sort.Sort(versions)
+	return versions
+}
+
+// IsContractSupported returns true if a CRD declares at least one API version supporting a Cluster API contract, e.g. "v1beta1".
+func IsContractSupported(crd metav1.Object, contract string) bool {
+	return len(GetContractVersions(crd, contract)) > 0
+}
+
+// GetPreferredContractVersion returns the newest API version of a CRD supporting a Cluster API contract, e.g. "v1beta1".
+func GetPreferredContractVersion(crd metav1.Object, contract string) (string, error) {
+	versions := GetContractVersions(crd, contract)
+	if len(versions) == 0 {
+		return "", errors.Errorf("cannot find any versions matching contract %q for %s as contract version label(s) are either missing or empty", ContractLabelName(contract), crd.GetName())
+	}
+	return versions[len(versions)-1], nil
+}
+
+// ContractFieldPathConversion defines a field which has been moved to a different path in an object
+// between two versions of a Cluster API contract, e.g. ["spec", "oldField"] to ["spec", "newField"].
+type ContractFieldPathConversion struct {
+	// From is the path of the field in the source contract version.
+	From []string
+
+	// To is the path of the field in the target contract version.
+	To []string
+}
+
+// ConvertContractFieldPaths moves the fields of an unstructured object from the source to the target path of each
+// of the given conversions. Fields not existing in the object are ignored; if a field exists both in the source and in
+// the target path, the value in the target path is preserved.
+func ConvertContractFieldPaths(obj *unstructured.Unstructured, conversions ...ContractFieldPathConversion) error {
+	for _, c := range conversions {
+		if len(c.From) == 0 || len(c.To) == 0 {
+			return errors.New("contract field paths must not be empty")
+		}
+
+		value, found, err := unstructured.NestedFieldNoCopy(obj.Object, c.From...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %s from object", strings.Join(c.From, "."))
+		}
+		if !found {
+			continue
+		}
+
+		_, found, err = unstructured.NestedFieldNoCopy(obj.Object, c.To...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %s from object", strings.Join(c.To, "."))
+		}
+		if !found {
+			if err := unstructured.SetNestedField(obj.Object, runtime.DeepCopyJSONValue(value), c.To...); err != nil {
+				return errors.Wrapf(err, "failed to set %s in object", strings.Join(c.To, "."))
+			}
+		}
+		unstructured.RemoveNestedField(obj.Object, c.From...)
+	}
+	return nil
+}
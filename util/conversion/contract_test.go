@@ -0,0 +1,193 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestContractLabelName(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ContractLabelName("v1beta1")).To(Equal("cluster.x-k8s.io/v1beta1"))
+	g.Expect(ContractLabelName("v1alpha4")).To(Equal("cluster.x-k8s.io/v1alpha4"))
+}
+
+func TestGetContractVersions(t *testing.T) {
+	tests := []struct {
+		name                 string
+		labels               map[string]string
+		wantVersions         []string
+		wantSupported        bool
+		wantPreferredVersion string
+	}{
+		{
+			name:          "CRD without contract label",
+			labels:        map[string]string{},
+			wantVersions:  nil,
+			wantSupported: false,
+		},
+		{
+			name:          "CRD with empty contract label",
+			labels:        map[string]string{"cluster.x-k8s.io/v1beta1": ""},
+			wantVersions:  nil,
+			wantSupported: false,
+		},
+		{
+			name:          "CRD with contract label for another contract",
+			labels:        map[string]string{"cluster.x-k8s.io/v1alpha4": "v1alpha4"},
+			wantVersions:  nil,
+			wantSupported: false,
+		},
+		{
+			name:                 "CRD with a single version",
+			labels:               map[string]string{"cluster.x-k8s.io/v1beta1": "v1beta1"},
+			wantVersions:         []string{"v1beta1"},
+			wantSupported:        true,
+			wantPreferredVersion: "v1beta1",
+		},
+		{
+			name:                 "CRD with many versions",
+			labels:               map[string]string{"cluster.x-k8s.io/v1beta1": "v1beta2_v1_v1alpha4_v1beta1"},
+			wantVersions:         []string{"v1alpha4", "v1beta1", "v1beta2", "v1"},
+			wantSupported:        true,
+			wantPreferredVersion: "v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			crd := &apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   "foos.infrastructure.cluster.x-k8s.io",
+					Labels: tt.labels,
+				},
+			}
+
+			g.Expect(GetContractVersions(crd, "v1beta1")).To(Equal(tt.wantVersions))
+			g.Expect(IsContractSupported(crd, "v1beta1")).To(Equal(tt.wantSupported))
+
+			got, err := GetPreferredContractVersion(crd, "v1beta1")
+			if !tt.wantSupported {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got).To(Equal(tt.wantPreferredVersion))
+		})
+	}
+}
+
+func TestConvertContractFieldPaths(t *testing.T) {
+	tests := []struct {
+		name        string
+		obj         map[string]interface{}
+		conversions []ContractFieldPathConversion
+		want        map[string]interface{}
+		wantErr     bool
+	}{
+		{
+			name: "should move a field to the target path",
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"oldField": map[string]interface{}{"foo": "bar"},
+				},
+			},
+			conversions: []ContractFieldPathConversion{
+				{From: []string{"spec", "oldField"}, To: []string{"spec", "new", "field"}},
+			},
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"new": map[string]interface{}{
+						"field": map[string]interface{}{"foo": "bar"},
+					},
+				},
+			},
+		},
+		{
+			name: "should ignore fields not existing in the object",
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"otherField": "foo",
+				},
+			},
+			conversions: []ContractFieldPathConversion{
+				{From: []string{"spec", "oldField"}, To: []string{"spec", "newField"}},
+			},
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"otherField": "foo",
+				},
+			},
+		},
+		{
+			name: "should preserve the value in the target path",
+			obj: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"oldField": "old",
+					"newField": "new",
+				},
+			},
+			conversions: []ContractFieldPathConversion{
+				{From: []string{"spec", "oldField"}, To: []string{"spec", "newField"}},
+			},
+			want: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"newField": "new",
+				},
+			},
+		},
+		{
+			name: "should fail for empty paths",
+			obj:  map[string]interface{}{},
+			conversions: []ContractFieldPathConversion{
+				{From: []string{"spec", "oldField"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "should fail if the source path is not a map",
+			obj: map[string]interface{}{
+				"spec": "foo",
+			},
+			conversions: []ContractFieldPathConversion{
+				{From: []string{"spec", "oldField"}, To: []string{"spec", "newField"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &unstructured.Unstructured{Object: tt.obj}
+			err := ConvertContractFieldPaths(obj, tt.conversions...)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(obj.Object).To(Equal(tt.want))
+		})
+	}
+}
@@ -20,14 +20,11 @@ package conversion
 import (
 	"context"
 	"math/rand"
-	"sort"
-	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	fuzz "github.com/google/gofuzz"
 	"github.com/onsi/gomega"
-	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
@@ -51,7 +48,7 @@ const (
 )
 
 var (
-	contract = clusterv1.GroupVersion.String()
+	contract = ContractLabelName(clusterv1.GroupVersion.Version)
 )
 
 // UpdateReferenceAPIContract takes a client and object reference, queries the API Server for
@@ -76,7 +73,7 @@ func UpdateReferenceAPIContract(ctx context.Context, c client.Client, ref *corev
 		}
 	}
 
-	chosen, err := getLatestAPIVersionFromContract(metadata)
+	chosen, err := GetPreferredContractVersion(metadata, clusterv1.GroupVersion.Version)
 	if err != nil {
 		return err
 	}
@@ -90,21 +87,6 @@ func UpdateReferenceAPIContract(ctx context.Context, c client.Client, ref *corev
 	return nil
 }
 
-func getLatestAPIVersionFromContract(metadata metav1.Object) (string, error) {
-	labels := metadata.GetLabels()
-
-	// If there is no label, return early without changing the reference.
-	supportedVersions, ok := labels[contract]
-	if !ok || supportedVersions == "" {
-		return "", errors.Errorf("cannot find any versions matching contract %q for GVK %v as contract version label(s) are either missing or empty", contract, metadata.GetName())
-	}
-
-	// Pick the latest version in the slice and validate it.
-	kubeVersions := util.KubeAwareAPIVersions(strings.Split(supportedVersions, "_"))
-	sort.Sort(kubeVersions)
-	return kubeVersions[len(kubeVersions)-1], nil
-}
-
 // MarshalData stores the source object as json data in the destination object annotations map.
 // It ignores the metadata of the source object.
 func MarshalData(src metav1.Object, dst metav1.Object) error {
@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package flags implements the command line flags shared by the Cluster API controller managers.
+package flags
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ControllerOptions are the tuning options of a controller.
+type ControllerOptions struct {
+	// Concurrency is the maximum number of objects reconciled concurrently.
+	Concurrency int
+
+	// RateLimiterBaseDelay and RateLimiterMaxDelay are the bounds of the exponential backoff
+	// used to requeue objects failing reconciliation.
+	RateLimiterBaseDelay time.Duration
+	RateLimiterMaxDelay  time.Duration
+
+	// RateLimiterQPS and RateLimiterBurst are the parameters of the token bucket limiting
+	// the overall rate at which objects are requeued.
+	RateLimiterQPS   float64
+	RateLimiterBurst int
+
+	// ResyncPeriod is the interval at which objects reconciled successfully are reconciled again;
+	// if 0, objects are reconciled again only on changes or at the manager sync period.
+	ResyncPeriod time.Duration
+}
+
+// AddControllerOptionsFlags adds the flags to configure the options of the controller with the given name,
+// e.g. --cluster-concurrency for the controller named cluster; the defaults of the rate limiter are the ones
+// of the controller-runtime default rate limiter.
+func AddControllerOptionsFlags(fs *pflag.FlagSet, name, objects string, o *ControllerOptions) {
+	fs.IntVar(&o.Concurrency, name+"-concurrency", 10,
+		fmt.Sprintf("Number of %s to process simultaneously", objects))
+
+	fs.DurationVar(&o.RateLimiterBaseDelay, name+"-rate-limiter-base-delay", 5*time.Millisecond,
+		fmt.Sprintf("The initial delay before requeuing %s failing reconciliation, doubled at every failure", objects))
+
+	fs.DurationVar(&o.RateLimiterMaxDelay, name+"-rate-limiter-max-delay", 1000*time.Second,
+		fmt.Sprintf("The maximum delay before requeuing %s failing reconciliation", objects))
+
+	fs.Float64Var(&o.RateLimiterQPS, name+"-rate-limiter-qps", 10,
+		fmt.Sprintf("The maximum overall rate at which %s are requeued, in requeues per second", objects))
+
+	fs.IntVar(&o.RateLimiterBurst, name+"-rate-limiter-burst", 100,
+		fmt.Sprintf("The maximum burst of requeues of %s exceeding --%s-rate-limiter-qps", objects, name))
+
+	fs.DurationVar(&o.ResyncPeriod, name+"-resync-period", 0,
+		fmt.Sprintf("The interval at which %s reconciled successfully are reconciled again; 0 means %s are reconciled again only on changes or at the manager sync period", objects, objects))
+}
+
+// Validate checks the options of the controller with the given name.
+func (o *ControllerOptions) Validate(name string) error {
+	if o.Concurrency < 1 {
+		return errors.Errorf("--%s-concurrency must be greater than 0", name)
+	}
+	if o.RateLimiterBaseDelay <= 0 || o.RateLimiterMaxDelay < o.RateLimiterBaseDelay {
+		return errors.Errorf("--%s-rate-limiter-base-delay must be greater than 0 and not greater than --%s-rate-limiter-max-delay", name, name)
+	}
+	if o.RateLimiterQPS <= 0 || o.RateLimiterBurst < 1 {
+		return errors.Errorf("--%s-rate-limiter-qps and --%s-rate-limiter-burst must be greater than 0", name, name)
+	}
+	if o.ResyncPeriod < 0 {
+		return errors.Errorf("--%s-resync-period must not be negative", name)
+	}
+	return nil
+}
+
+// ForReconciler returns the controller-runtime options for a controller using the given reconciler.
+func (o *ControllerOptions) ForReconciler(r reconcile.Reconciler) controller.Options {
+	options := controller.Options{
+		MaxConcurrentReconciles: o.Concurrency,
+		RateLimiter: workqueue.NewMaxOfRateLimiter(
+			workqueue.NewItemExponentialFailureRateLimiter(o.RateLimiterBaseDelay, o.RateLimiterMaxDelay),
+			&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(o.RateLimiterQPS), o.RateLimiterBurst)},
+		),
+	}
+	if o.ResyncPeriod > 0 {
+		options.Reconciler = &resyncReconciler{Reconciler: r, resyncPeriod: o.ResyncPeriod}
+	}
+	return options
+}
+
+// resyncReconciler requeues the objects reconciled successfully after the resync period,
+// unless the reconciler already requested to requeue them earlier.
+type resyncReconciler struct {
+	reconcile.Reconciler
+
+	resyncPeriod time.Duration
+}
+
+// Reconcile implements reconcile.Reconciler.
+func (r *resyncReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.Reconciler.Reconcile(ctx, req)
+	if err != nil || result.Requeue {
+		return result, err
+	}
+	if result.RequeueAfter == 0 || result.RequeueAfter > r.resyncPeriod {
+		result.RequeueAfter = r.resyncPeriod
+	}
+	return result, nil
+}
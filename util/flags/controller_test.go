@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flags
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestAddControllerOptionsFlags(t *testing.T) {
+	g := NewWithT(t)
+
+	o := &ControllerOptions{}
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddControllerOptionsFlags(fs, "cluster", "clusters", o)
+
+	g.Expect(fs.Parse(nil)).To(Succeed())
+	g.Expect(o.Validate("cluster")).To(Succeed())
+	g.Expect(o.Concurrency).To(Equal(10))
+	g.Expect(o.ResyncPeriod).To(BeZero())
+
+	g.Expect(fs.Parse([]string{
+		"--cluster-concurrency=50",
+		"--cluster-rate-limiter-base-delay=1s",
+		"--cluster-rate-limiter-max-delay=5m",
+		"--cluster-rate-limiter-qps=50",
+		"--cluster-rate-limiter-burst=500",
+		"--cluster-resync-period=2m",
+	})).To(Succeed())
+	g.Expect(o.Validate("cluster")).To(Succeed())
+	g.Expect(*o).To(Equal(ControllerOptions{
+		Concurrency:          50,
+		RateLimiterBaseDelay: time.Second,
+		RateLimiterMaxDelay:  5 * time.Minute,
+		RateLimiterQPS:       50,
+		RateLimiterBurst:     500,
+		ResyncPeriod:         2 * time.Minute,
+	}))
+
+	g.Expect(fs.Parse([]string{"--cluster-rate-limiter-max-delay=1ms"})).To(Succeed())
+	g.Expect(o.Validate("cluster")).NotTo(Succeed())
+}
+
+func TestForReconciler(t *testing.T) {
+	o := &ControllerOptions{
+		Concurrency:          5,
+		RateLimiterBaseDelay: time.Second,
+		RateLimiterMaxDelay:  time.Minute,
+		RateLimiterQPS:       10,
+		RateLimiterBurst:     100,
+	}
+
+	t.Run("configures the rate limiter", func(t *testing.T) {
+		g := NewWithT(t)
+
+		options := o.ForReconciler(&fakeReconciler{})
+		g.Expect(options.MaxConcurrentReconciles).To(Equal(5))
+		g.Expect(options.Reconciler).To(BeNil())
+		g.Expect(options.RateLimiter.When("item")).To(Equal(time.Second))
+		g.Expect(options.RateLimiter.When("item")).To(Equal(2 * time.Second))
+	})
+
+	tests := []struct {
+		name       string
+		result     reconcile.Result
+		err        error
+		wantResult reconcile.Result
+	}{
+		{
+			name:       "requeues objects reconciled successfully after the resync period",
+			result:     reconcile.Result{},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name:       "preserves earlier requeues",
+			result:     reconcile.Result{RequeueAfter: 10 * time.Second},
+			wantResult: reconcile.Result{RequeueAfter: 10 * time.Second},
+		},
+		{
+			name:       "shortens later requeues to the resync period",
+			result:     reconcile.Result{RequeueAfter: time.Hour},
+			wantResult: reconcile.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name:       "preserves immediate requeues",
+			result:     reconcile.Result{Requeue: true},
+			wantResult: reconcile.Result{Requeue: true},
+		},
+		{
+			name:       "does not requeue objects failing reconciliation",
+			err:        errors.New("failed"),
+			wantResult: reconcile.Result{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			withResync := *o
+			withResync.ResyncPeriod = time.Minute
+			options := withResync.ForReconciler(&fakeReconciler{result: tt.result, err: tt.err})
+			g.Expect(options.Reconciler).NotTo(BeNil())
+
+			result, err := options.Reconciler.Reconcile(context.Background(), reconcile.Request{})
+			if tt.err != nil {
+				g.Expect(err).To(MatchError(tt.err))
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(result).To(Equal(tt.wantResult))
+		})
+	}
+}
+
+type fakeReconciler struct {
+	result reconcile.Result
+	err    error
+}
+
+func (r *fakeReconciler) Reconcile(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	return r.result, r.err
+}
@@ -18,9 +18,13 @@ limitations under the License.
 package kubeconfig
 
 import (
+	"bytes"
 	"context"
 	"crypto"
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
 	"time"
 
@@ -30,6 +34,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/cert"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/certs"
@@ -40,8 +45,38 @@ import (
 var (
 	// ErrDependentCertificateNotFound signals that a CA secret could not be found.
 	ErrDependentCertificateNotFound = errors.New("could not find secret ca")
+
+	// ErrExternalCA signals that the CA secret does not contain the CA private key, because the CA is managed
+	// outside of Cluster API; the Kubeconfig can be generated with CreateSecretFromCSR instead.
+	ErrExternalCA = errors.New("secret ca does not contain the private key of an external CA")
+
+	// ErrCSRNotSigned signals that the Kubeconfig client certificate signing request has not been signed yet.
+	ErrCSRNotSigned = errors.New("kubeconfig client certificate signing request has not been signed yet")
 )
 
+// Option configures the Kubeconfig generated for a Cluster.
+type Option func(*options)
+
+type options struct {
+	clientCertValidity time.Duration
+}
+
+// WithClientCertValidity sets the lifespan of the client certificate of the generated Kubeconfig;
+// if not set, certs.DefaultCertDuration is used.
+func WithClientCertValidity(validity time.Duration) Option {
+	return func(o *options) {
+		o.clientCertValidity = validity
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
 // FromSecret fetches the Kubeconfig for a Cluster.
 func FromSecret(ctx context.Context, c client.Reader, cluster client.ObjectKey) ([]byte, error) {
 	out, err := secret.Get(ctx, c, cluster, secret.Kubeconfig)
@@ -52,11 +87,12 @@ func FromSecret(ctx context.Context, c client.Reader, cluster client.ObjectKey)
 }
 
 // New creates a new Kubeconfig using the cluster name and specified endpoint.
-func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Signer) (*api.Config, error) {
+func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Signer, opts ...Option) (*api.Config, error) {
 	cfg := &certs.Config{
 		CommonName:   "kubernetes-admin",
 		Organization: []string{"system:masters"},
 		Usages:       []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		Duration:     newOptions(opts...).clientCertValidity,
 	}
 
 	clientKey, err := certs.NewPrivateKey()
@@ -69,6 +105,10 @@ func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Si
 		return nil, errors.Wrap(err, "unable to sign certificate")
 	}
 
+	return newConfig(clusterName, endpoint, caCert, certs.EncodePrivateKeyPEM(clientKey), clientCert), nil
+}
+
+func newConfig(clusterName, endpoint string, caCert *x509.Certificate, clientKey []byte, clientCert *x509.Certificate) *api.Config {
 	userName := fmt.Sprintf("%s-admin", clusterName)
 	contextName := fmt.Sprintf("%s@%s", userName, clusterName)
 
@@ -87,29 +127,29 @@ func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Si
 		},
 		AuthInfos: map[string]*api.AuthInfo{
 			userName: {
-				ClientKeyData:         certs.EncodePrivateKeyPEM(clientKey),
+				ClientKeyData:         clientKey,
 				ClientCertificateData: certs.EncodeCertPEM(clientCert),
 			},
 		},
 		CurrentContext: contextName,
-	}, nil
+	}
 }
 
 // CreateSecret creates the Kubeconfig secret for the given cluster.
-func CreateSecret(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) error {
+func CreateSecret(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, opts ...Option) error {
 	name := util.ObjectKey(cluster)
 	return CreateSecretWithOwner(ctx, c, name, cluster.Spec.ControlPlaneEndpoint.String(), metav1.OwnerReference{
 		APIVersion: clusterv1.GroupVersion.String(),
 		Kind:       "Cluster",
 		Name:       cluster.Name,
 		UID:        cluster.UID,
-	})
+	}, opts...)
 }
 
 // CreateSecretWithOwner creates the Kubeconfig secret for the given cluster name, namespace, endpoint, and owner reference.
-func CreateSecretWithOwner(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, owner metav1.OwnerReference) error {
+func CreateSecretWithOwner(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, owner metav1.OwnerReference, opts ...Option) error {
 	server := fmt.Sprintf("https://%s", endpoint)
-	out, err := generateKubeconfig(ctx, c, clusterName, server)
+	out, err := generateKubeconfig(ctx, c, clusterName, server, opts...)
 	if err != nil {
 		return err
 	}
@@ -117,6 +157,103 @@ func CreateSecretWithOwner(ctx context.Context, c client.Client, clusterName cli
 	return c.Create(ctx, GenerateSecretWithOwner(clusterName, out, owner))
 }
 
+// CreateSecretFromCSR creates the Kubeconfig secret for a cluster using an external CA, whose private key is not
+// available to Cluster API. A private key and a certificate signing request for the Kubeconfig client certificate
+// are stored in the <cluster>-kubeconfig-csr secret; once the certificate signed by the external CA is added to
+// the same secret, the Kubeconfig secret is created with the given owner reference.
+// ErrCSRNotSigned is returned until the signed certificate is available.
+func CreateSecretFromCSR(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, owner metav1.OwnerReference) error {
+	clusterCA, err := secret.GetFromNamespacedName(ctx, c, clusterName, secret.ClusterCA)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return ErrDependentCertificateNotFound
+		}
+		return err
+	}
+	caCert, err := certs.DecodeCertPEM(clusterCA.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return errors.Wrap(err, "failed to decode CA Cert")
+	} else if caCert == nil {
+		return errors.New("certificate not found in config")
+	}
+
+	csrSecret, err := secret.GetFromNamespacedName(ctx, c, clusterName, secret.KubeconfigCSR)
+	if apierrors.IsNotFound(err) {
+		csrSecret, err = generateCSRSecret(clusterName, owner)
+		if err != nil {
+			return err
+		}
+		if err := c.Create(ctx, csrSecret); err != nil {
+			return errors.Wrap(err, "failed to create the kubeconfig certificate signing request secret")
+		}
+		return ErrCSRNotSigned
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(csrSecret.Data[secret.TLSCrtDataName]) == 0 {
+		return ErrCSRNotSigned
+	}
+	clientCert, err := certs.DecodeCertPEM(csrSecret.Data[secret.TLSCrtDataName])
+	if err != nil || clientCert == nil {
+		return errors.Errorf("failed to decode the signed certificate in secret %s", csrSecret.Name)
+	}
+	if err := clientCert.CheckSignatureFrom(caCert); err != nil {
+		return errors.Wrapf(err, "the certificate in secret %s is not signed by the cluster CA", csrSecret.Name)
+	}
+	clientKey, err := certs.DecodePrivateKeyPEM(csrSecret.Data[secret.TLSKeyDataName])
+	if err != nil || clientKey == nil {
+		return errors.Errorf("failed to decode the private key in secret %s", csrSecret.Name)
+	}
+	if publicKey, ok := clientKey.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !publicKey.Equal(clientCert.PublicKey) {
+		return errors.Errorf("the certificate in secret %s does not match the private key of the certificate signing request", csrSecret.Name)
+	}
+
+	cfg := newConfig(clusterName.Name, fmt.Sprintf("https://%s", endpoint), caCert, csrSecret.Data[secret.TLSKeyDataName], clientCert)
+	out, err := clientcmd.Write(*cfg)
+	if err != nil {
+		return errors.Wrap(err, "failed to serialize config to yaml")
+	}
+	return c.Create(ctx, GenerateSecretWithOwner(clusterName, out, owner))
+}
+
+// generateCSRSecret returns a secret with a new private key and a certificate signing request
+// for the Kubeconfig client certificate.
+func generateCSRSecret(clusterName client.ObjectKey, owner metav1.OwnerReference) (*corev1.Secret, error) {
+	clientKey, err := certs.NewPrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create private key")
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   "kubernetes-admin",
+			Organization: []string{"system:masters"},
+		},
+	}, clientKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create certificate signing request")
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secret.Name(clusterName.Name, secret.KubeconfigCSR),
+			Namespace: clusterName.Namespace,
+			Labels: map[string]string{
+				clusterv1.ClusterLabelName: clusterName.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				owner,
+			},
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName: certs.EncodePrivateKeyPEM(clientKey),
+			secret.TLSCSRDataName: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr}),
+		},
+		Type: clusterv1.ClusterSecretType,
+	}, nil
+}
+
 // GenerateSecret returns a Kubernetes secret for the given Cluster and kubeconfig data.
 func GenerateSecret(cluster *clusterv1.Cluster, data []byte) *corev1.Secret {
 	name := util.ObjectKey(cluster)
@@ -150,33 +287,84 @@ func GenerateSecretWithOwner(clusterName client.ObjectKey, data []byte, owner me
 
 // NeedsClientCertRotation returns whether any of the Kubeconfig secret's client certificates will expire before the given threshold.
 func NeedsClientCertRotation(configSecret *corev1.Secret, threshold time.Duration) (bool, error) {
-	now := time.Now()
+	expiry, err := ClientCertExpiry(configSecret)
+	if err != nil {
+		return false, err
+	}
+	if expiry == nil {
+		return false, nil
+	}
+	return time.Until(*expiry) < threshold, nil
+}
 
+// ClientCertExpiry returns the expiry of the Kubeconfig secret's client certificate expiring first,
+// or nil if the Kubeconfig does not use client certificates, e.g. because it is using a token.
+func ClientCertExpiry(configSecret *corev1.Secret) (*time.Time, error) {
 	data, err := toKubeconfigBytes(configSecret)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	config, err := clientcmd.Load(data)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+		return nil, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
 	}
 
+	var expiry *time.Time
 	for _, authInfo := range config.AuthInfos {
+		if len(authInfo.ClientCertificateData) == 0 {
+			continue
+		}
 		cert, err := certs.DecodeCertPEM(authInfo.ClientCertificateData)
 		if err != nil {
-			return false, errors.Wrap(err, "failed to decode kubeconfig client certificate")
+			return nil, errors.Wrap(err, "failed to decode kubeconfig client certificate")
+		}
+		if cert == nil {
+			continue
 		}
-		if cert.NotAfter.Sub(now) < threshold {
-			return true, nil
+		if expiry == nil || cert.NotAfter.Before(*expiry) {
+			notAfter := cert.NotAfter
+			expiry = &notAfter
 		}
 	}
 
-	return false, nil
+	return expiry, nil
+}
+
+// NeedsCertificateAuthorityUpdate returns whether the Kubeconfig secret does not trust exactly the certificates in the
+// bundle of the given cluster CA secret, e.g. because the cluster CA is being rotated.
+func NeedsCertificateAuthorityUpdate(configSecret, clusterCA *corev1.Secret) (bool, error) {
+	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to parse secret name")
+	}
+	data, err := toKubeconfigBytes(configSecret)
+	if err != nil {
+		return false, err
+	}
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+	}
+	cluster, ok := config.Clusters[clusterName]
+	if !ok {
+		return false, errors.Errorf("kubeconfig does not contain cluster %q", clusterName)
+	}
+
+	current, err := certificateAuthorityData(cluster.CertificateAuthorityData)
+	if err != nil {
+		return false, err
+	}
+	desired, err := certificateAuthorityData(clusterCA.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(current, desired), nil
 }
 
 // RegenerateSecret creates and stores a new Kubeconfig in the given secret.
-func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret) error {
+func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret, opts ...Option) error {
 	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse secret name")
@@ -192,7 +380,7 @@ func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1
 	}
 	endpoint := config.Clusters[clusterName].Server
 	key := client.ObjectKey{Name: clusterName, Namespace: configSecret.Namespace}
-	out, err := generateKubeconfig(ctx, c, key, endpoint)
+	out, err := generateKubeconfig(ctx, c, key, endpoint, opts...)
 	if err != nil {
 		return err
 	}
@@ -200,7 +388,7 @@ func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1
 	return c.Update(ctx, configSecret)
 }
 
-func generateKubeconfig(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string) ([]byte, error) {
+func generateKubeconfig(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, opts ...Option) ([]byte, error) {
 	clusterCA, err := secret.GetFromNamespacedName(ctx, c, clusterName, secret.ClusterCA)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -216,6 +404,10 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 		return nil, errors.New("certificate not found in config")
 	}
 
+	if len(clusterCA.Data[secret.TLSKeyDataName]) == 0 {
+		return nil, ErrExternalCA
+	}
+
 	key, err := certs.DecodePrivateKeyPEM(clusterCA.Data[secret.TLSKeyDataName])
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decode private key")
@@ -223,11 +415,18 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 		return nil, errors.New("CA private key not found")
 	}
 
-	cfg, err := New(clusterName.Name, endpoint, cert, key)
+	cfg, err := New(clusterName.Name, endpoint, cert, key, opts...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to generate a kubeconfig")
 	}
 
+	// Trust all the certificates in the CA bundle, e.g. both the old and the new CA while the cluster CA is being rotated.
+	caData, err := certificateAuthorityData(clusterCA.Data[secret.TLSCrtDataName])
+	if err != nil {
+		return nil, err
+	}
+	cfg.Clusters[clusterName.Name].CertificateAuthorityData = caData
+
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to serialize config to yaml")
@@ -235,6 +434,19 @@ func generateKubeconfig(ctx context.Context, c client.Client, clusterName client
 	return out, nil
 }
 
+// certificateAuthorityData returns all the certificates in a PEM encoded CA bundle, re-encoded in a canonical form.
+func certificateAuthorityData(bundle []byte) ([]byte, error) {
+	certificates, err := cert.ParseCertsPEM(bundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode CA certificates")
+	}
+	var out []byte
+	for _, c := range certificates {
+		out = append(out, certs.EncodeCertPEM(c)...)
+	}
+	return out, nil
+}
+
 func toKubeconfigBytes(out *corev1.Secret) ([]byte, error) {
 	data, ok := out.Data[secret.KubeconfigDataName]
 	if !ok {
@@ -21,6 +21,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
 	"math/big"
 	"testing"
 	"time"
@@ -346,6 +347,79 @@ func TestCreateSecret(t *testing.T) {
 	g.Expect(restClient.Host).To(Equal("https://localhost:8443"))
 }
 
+func TestCreateSecretFromCSR(t *testing.T) {
+	g := NewWithT(t)
+
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// The CA secret of an external CA does not contain the private key.
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSCrtDataName: certs.EncodeCertPEM(caCert),
+		},
+	}
+
+	c := fake.NewClientBuilder().WithObjects(caSecret).Build()
+
+	owner := metav1.OwnerReference{
+		Name:       "test1",
+		Kind:       "Cluster",
+		APIVersion: clusterv1.GroupVersion.String(),
+	}
+	clusterName := client.ObjectKey{Name: "test1", Namespace: "test"}
+
+	g.Expect(CreateSecretWithOwner(ctx, c, clusterName, "localhost:6443", owner)).To(MatchError(ErrExternalCA))
+
+	// The certificate signing request is created and the Kubeconfig is not generated until it is signed.
+	g.Expect(CreateSecretFromCSR(ctx, c, clusterName, "localhost:6443", owner)).To(MatchError(ErrCSRNotSigned))
+	g.Expect(CreateSecretFromCSR(ctx, c, clusterName, "localhost:6443", owner)).To(MatchError(ErrCSRNotSigned))
+
+	csrSecret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "test1-kubeconfig-csr", Namespace: "test"}, csrSecret)).To(Succeed())
+	g.Expect(csrSecret.OwnerReferences).To(ContainElement(owner))
+	block, _ := pem.Decode(csrSecret.Data[secret.TLSCSRDataName])
+	g.Expect(block).NotTo(BeNil())
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(csr.Subject.CommonName).To(Equal("kubernetes-admin"))
+	g.Expect(csr.Subject.Organization).To(ConsistOf("system:masters"))
+
+	// Sign the certificate signing request with the external CA.
+	clientCert, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      csr.Subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, caCert, csr.PublicKey, caKey)
+	g.Expect(err).NotTo(HaveOccurred())
+	csrSecret.Data[secret.TLSCrtDataName] = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: clientCert})
+	g.Expect(c.Update(ctx, csrSecret)).To(Succeed())
+
+	g.Expect(CreateSecretFromCSR(ctx, c, clusterName, "localhost:6443", owner)).To(Succeed())
+
+	s := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Name: "test1-kubeconfig", Namespace: "test"}, s)).To(Succeed())
+	g.Expect(s.OwnerReferences).To(ContainElement(owner))
+
+	clientConfig, err := clientcmd.NewClientConfigFromBytes(s.Data[secret.KubeconfigDataName])
+	g.Expect(err).NotTo(HaveOccurred())
+	restClient, err := clientConfig.ClientConfig()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(restClient.CAData).To(Equal(certs.EncodeCertPEM(caCert)))
+	g.Expect(restClient.CertData).To(Equal(csrSecret.Data[secret.TLSCrtDataName]))
+	g.Expect(restClient.KeyData).To(Equal(csrSecret.Data[secret.TLSKeyDataName]))
+	g.Expect(restClient.Host).To(Equal("https://localhost:6443"))
+}
+
 func TestNeedsClientCertRotation(t *testing.T) {
 	g := NewWithT(t)
 	caKey, err := certs.NewPrivateKey()
@@ -379,6 +453,87 @@ func TestNeedsClientCertRotation(t *testing.T) {
 	g.Expect(NeedsClientCertRotation(kubeconfigSecret, certs.DefaultCertDuration-time.Hour)).To(BeFalse())
 }
 
+func TestNeedsCertificateAuthorityUpdate(t *testing.T) {
+	g := NewWithT(t)
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newCAKey, err := certs.NewPrivateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newCACert, err := getTestCACert(newCAKey)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := New("test1", "https://127:0.0.1:4003", caCert, caKey)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	out, err := clientcmd.Write(*config)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	owner := metav1.OwnerReference{
+		Name:       "test1",
+		Kind:       "Cluster",
+		APIVersion: clusterv1.GroupVersion.String(),
+	}
+
+	kubeconfigSecret := GenerateSecretWithOwner(
+		client.ObjectKey{
+			Name:      "test1",
+			Namespace: "test",
+		},
+		out,
+		owner,
+	)
+
+	caSecret := func(caCerts ...*x509.Certificate) *corev1.Secret {
+		var bundle []byte
+		for _, c := range caCerts {
+			bundle = append(bundle, certs.EncodeCertPEM(c)...)
+		}
+		return &corev1.Secret{Data: map[string][]byte{secret.TLSCrtDataName: bundle}}
+	}
+
+	g.Expect(NeedsCertificateAuthorityUpdate(kubeconfigSecret, caSecret(caCert))).To(BeFalse())
+	g.Expect(NeedsCertificateAuthorityUpdate(kubeconfigSecret, caSecret(caCert, newCACert))).To(BeTrue())
+	g.Expect(NeedsCertificateAuthorityUpdate(kubeconfigSecret, caSecret(newCACert))).To(BeTrue())
+}
+
+func TestClientCertExpiry(t *testing.T) {
+	g := NewWithT(t)
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := New("foo", "https://127:0.0.1:4003", caCert, caKey, WithClientCertValidity(48*time.Hour))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	out, err := clientcmd.Write(*config)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	kubeconfigSecret := GenerateSecretWithOwner(client.ObjectKey{Name: "foo", Namespace: "test"}, out, metav1.OwnerReference{})
+
+	expiry, err := ClientCertExpiry(kubeconfigSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(expiry).NotTo(BeNil())
+	g.Expect(*expiry).To(BeTemporally("~", time.Now().Add(48*time.Hour), time.Minute))
+
+	// Kubeconfigs without client certificates, e.g. using tokens, have no expiry.
+	config.AuthInfos["foo-admin"] = &api.AuthInfo{Token: "token"}
+	out, err = clientcmd.Write(*config)
+	g.Expect(err).NotTo(HaveOccurred())
+	kubeconfigSecret.Data[secret.KubeconfigDataName] = out
+
+	expiry, err = ClientCertExpiry(kubeconfigSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(expiry).To(BeNil())
+	g.Expect(NeedsClientCertRotation(kubeconfigSecret, certs.DefaultCertDuration)).To(BeFalse())
+}
+
 func TestRegenerateClientCerts(t *testing.T) {
 	g := NewWithT(t)
 	caKey, err := certs.NewPrivateKey()
@@ -415,4 +570,10 @@ func TestRegenerateClientCerts(t *testing.T) {
 	g.Expect(err).NotTo(HaveOccurred())
 
 	g.Expect(newCert.NotAfter).To(BeTemporally(">", oldCert.NotAfter))
+
+	// The validity of the regenerated client certificate can be customized.
+	g.Expect(RegenerateSecret(ctx, c, newSecret, WithClientCertValidity(time.Hour))).To(Succeed())
+	expiry, err := ClientCertExpiry(newSecret)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(*expiry).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
 }
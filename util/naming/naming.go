@@ -0,0 +1,155 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package naming implements the generation of Machine names from a machine naming strategy template.
+package naming
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// RandomLength is the length of the random string which can be referenced in a machine naming template with .random.
+const RandomLength = 5
+
+const (
+	// MachineSetOwnerKey is the key used to reference the name of the MachineSet owning the Machines in a machine naming template.
+	MachineSetOwnerKey = "machineSet"
+
+	// KubeadmControlPlaneOwnerKey is the key used to reference the name of the KubeadmControlPlane owning the Machines
+	// in a machine naming template.
+	KubeadmControlPlaneOwnerKey = "kubeadmControlPlane"
+)
+
+const (
+	// sampleClusterName is the cluster name used to validate templates when the name of the Cluster is not known yet.
+	sampleClusterName = "sample-cluster"
+
+	// sampleOwnerName is the owner name used to validate templates when the name of the owner is not known yet.
+	sampleOwnerName = "sample-owner"
+)
+
+// MachineNameInput is the input to generate Machine names from a machine naming strategy template.
+type MachineNameInput struct {
+	// Template is the Go template used to generate the Machine names.
+	Template string
+
+	// ClusterName is the name of the Cluster the Machines belong to, referenced in the template with .cluster.name.
+	ClusterName string
+
+	// OwnerKey is the key used to reference the name of the object owning the Machines in the template,
+	// e.g. "machineSet" for .machineSet.name.
+	OwnerKey string
+
+	// OwnerName is the name of the object owning the Machines.
+	OwnerName string
+}
+
+// Generate returns a Machine name generated from the template which is not included in the existing names.
+// The template can reference a random string with .random and the lowest non-negative integer for which
+// the generated name is not included in the existing names with .index.
+func Generate(in MachineNameInput, existing sets.String) (string, error) {
+	tpl, err := parse(in.Template)
+	if err != nil {
+		return "", err
+	}
+
+	// NOTE: Trying len(existing)+1 indexes guarantees an index for which the generated name is not existing is
+	// found if the template references .index; otherwise names differ only by the random string.
+	for index := 0; index <= existing.Len(); index++ {
+		name, err := render(tpl, in, index, rand.String(RandomLength))
+		if err != nil {
+			return "", err
+		}
+		if existing.Has(name) {
+			continue
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return "", errors.Errorf("generated Machine name %q is invalid: %s", name, strings.Join(errs, ", "))
+		}
+		return name, nil
+	}
+	return "", errors.Errorf("failed to generate a Machine name from template %q not already in use", in.Template)
+}
+
+// Validate checks that the template is a valid Go template, that it references .random or .index so generated
+// names are unique, and that the names generated for the given input are valid DNS labels, as they are used as Node names.
+// The cluster name and the owner name are replaced by sample names if empty, e.g. when validating templates.
+func Validate(in MachineNameInput) error {
+	tpl, err := parse(in.Template)
+	if err != nil {
+		return err
+	}
+
+	if in.ClusterName == "" {
+		in.ClusterName = sampleClusterName
+	}
+	if in.OwnerName == "" {
+		in.OwnerName = sampleOwnerName
+	}
+
+	name, err := render(tpl, in, 0, strings.Repeat("a", RandomLength))
+	if err != nil {
+		return err
+	}
+	otherName, err := render(tpl, in, 1, strings.Repeat("b", RandomLength))
+	if err != nil {
+		return err
+	}
+	if name == otherName {
+		return errors.New("template must reference .random or .index")
+	}
+
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return errors.Errorf("generated Machine names, e.g. %q, are invalid: %s", name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func parse(text string) (*template.Template, error) {
+	tpl, err := template.New("machineName").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse template %q", text)
+	}
+	return tpl, nil
+}
+
+func render(tpl *template.Template, in MachineNameInput, index int, random string) (string, error) {
+	data := map[string]interface{}{
+		"cluster": map[string]interface{}{
+			"name": in.ClusterName,
+		},
+		"random": random,
+		"index":  index,
+	}
+	if in.OwnerKey != "" {
+		data[in.OwnerKey] = map[string]interface{}{
+			"name": in.OwnerName,
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "failed to render template %q", tpl.Root.String())
+	}
+	return buf.String(), nil
+}
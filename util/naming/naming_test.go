@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package naming
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       MachineNameInput
+		existing sets.String
+		want     string
+		wantLen  int
+		wantErr  bool
+	}{
+		{
+			name:     "uses the lowest index not already in use",
+			in:       MachineNameInput{Template: "{{ .cluster.name }}-cp-{{ .index }}", ClusterName: "prod"},
+			existing: sets.NewString("prod-cp-0", "prod-cp-2"),
+			want:     "prod-cp-1",
+		},
+		{
+			name:     "uses a random string",
+			in:       MachineNameInput{Template: "{{ .machineSet.name }}-{{ .random }}", OwnerKey: "machineSet", OwnerName: "md-0-abcde"},
+			existing: sets.NewString(),
+			wantLen:  len("md-0-abcde-") + RandomLength,
+		},
+		{
+			name:     "fails when a name not already in use cannot be generated",
+			in:       MachineNameInput{Template: "{{ .cluster.name }}-cp", ClusterName: "prod"},
+			existing: sets.NewString("prod-cp"),
+			wantErr:  true,
+		},
+		{
+			name:     "fails when the generated name is not a valid DNS label",
+			in:       MachineNameInput{Template: "{{ .cluster.name }}-{{ .index }}", ClusterName: strings.Repeat("a", 63)},
+			existing: sets.NewString(),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := Generate(tt.in, tt.existing)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			if tt.want != "" {
+				g.Expect(got).To(Equal(tt.want))
+			}
+			if tt.wantLen != 0 {
+				g.Expect(got).To(HaveLen(tt.wantLen))
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      MachineNameInput
+		wantErr bool
+	}{
+		{
+			name: "accepts a template referencing .random",
+			in:   MachineNameInput{Template: "{{ .kubeadmControlPlane.name }}-{{ .random }}", OwnerKey: "kubeadmControlPlane", OwnerName: "cp"},
+		},
+		{
+			name: "accepts a template referencing .index",
+			in:   MachineNameInput{Template: "{{ .cluster.name }}-cp-{{ .index }}", ClusterName: "prod"},
+		},
+		{
+			name: "accepts a template referencing names which are not known yet",
+			in:   MachineNameInput{Template: "{{ .random }}-{{ .cluster.name }}-{{ .kubeadmControlPlane.name }}", OwnerKey: "kubeadmControlPlane"},
+		},
+		{
+			name:    "rejects a template generating invalid names with the sample names",
+			in:      MachineNameInput{Template: "{{ .cluster.name }}-{{ .kubeadmControlPlane.name }}-{{ .random }}_", OwnerKey: "kubeadmControlPlane"},
+			wantErr: true,
+		},
+		{
+			name:    "rejects a template not referencing .random or .index",
+			in:      MachineNameInput{Template: "{{ .cluster.name }}-cp", ClusterName: "prod"},
+			wantErr: true,
+		},
+		{
+			name:    "rejects an invalid template",
+			in:      MachineNameInput{Template: "{{ .cluster.name }-{{ .random }}", ClusterName: "prod"},
+			wantErr: true,
+		},
+		{
+			name:    "rejects a template referencing unknown values",
+			in:      MachineNameInput{Template: "{{ .machineSet.name }}-{{ .random }}", OwnerKey: "kubeadmControlPlane", OwnerName: "cp"},
+			wantErr: true,
+		},
+		{
+			name:    "rejects a template generating names which are not valid DNS labels",
+			in:      MachineNameInput{Template: "{{ .cluster.name }}_{{ .random }}", ClusterName: "prod"},
+			wantErr: true,
+		},
+		{
+			name:    "rejects a template generating names longer than 63 characters",
+			in:      MachineNameInput{Template: "{{ .cluster.name }}-{{ .random }}", ClusterName: strings.Repeat("a", 58)},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := Validate(tt.in)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package paused implements the reporting of the Paused condition on Cluster API objects.
+package paused
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConditionSetter combines the client.Object and conditions.Setter interfaces.
+type ConditionSetter interface {
+	client.Object
+	conditions.Setter
+}
+
+// EnsurePausedCondition sets the Paused condition on the object if either the Cluster or the object are paused,
+// removes it otherwise, and patches the object if the condition changed.
+// It returns true if the reconciliation of the object is paused.
+func EnsurePausedCondition(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, obj ConditionSetter) (bool, error) {
+	isPaused := annotations.IsPaused(cluster, obj)
+
+	// Nothing to do if the object is not paused and the condition was already removed.
+	if !isPaused && !conditions.Has(obj, clusterv1.PausedCondition) {
+		return false, nil
+	}
+
+	patchHelper, err := patch.NewHelper(obj, c)
+	if err != nil {
+		return isPaused, err
+	}
+
+	SetPausedCondition(cluster, obj)
+
+	if err := patchHelper.Patch(ctx, obj, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.PausedCondition,
+	}}); err != nil {
+		return isPaused, errors.Wrapf(err, "failed to patch the %s condition", clusterv1.PausedCondition)
+	}
+	return isPaused, nil
+}
+
+// SetPausedCondition sets the Paused condition on the object if either the Cluster or the object are paused,
+// and removes it otherwise.
+func SetPausedCondition(cluster *clusterv1.Cluster, obj ConditionSetter) {
+	switch {
+	case cluster.Spec.Paused:
+		conditions.Set(obj, &clusterv1.Condition{
+			Type:    clusterv1.PausedCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  clusterv1.ClusterPausedReason,
+			Message: fmt.Sprintf("Reconciliation is paused because Cluster %s is paused", cluster.Name),
+		})
+	case annotations.HasPausedAnnotation(obj):
+		conditions.Set(obj, &clusterv1.Condition{
+			Type:    clusterv1.PausedCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  clusterv1.PausedAnnotationReason,
+			Message: fmt.Sprintf("Reconciliation is paused because the object has the %s annotation", clusterv1.PausedAnnotation),
+		})
+	default:
+		conditions.Delete(obj, clusterv1.PausedCondition)
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paused
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestEnsurePausedCondition(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-cluster",
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "test-machine",
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: cluster.Name,
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machine).Build()
+	ctx := ctrl.SetupSignalHandler()
+
+	getMachine := func() *clusterv1.Machine {
+		m := &clusterv1.Machine{}
+		g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), m)).To(Succeed())
+		return m
+	}
+
+	t.Run("does not set the condition if neither the Cluster nor the object are paused", func(t *testing.T) {
+		g := NewWithT(t)
+
+		m := getMachine()
+		isPaused, err := EnsurePausedCondition(ctx, c, cluster, m)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(isPaused).To(BeFalse())
+		g.Expect(conditions.Has(getMachine(), clusterv1.PausedCondition)).To(BeFalse())
+	})
+
+	t.Run("sets the condition if the Cluster is paused", func(t *testing.T) {
+		g := NewWithT(t)
+
+		pausedCluster := cluster.DeepCopy()
+		pausedCluster.Spec.Paused = true
+
+		m := getMachine()
+		isPaused, err := EnsurePausedCondition(ctx, c, pausedCluster, m)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(isPaused).To(BeTrue())
+
+		m = getMachine()
+		g.Expect(conditions.IsTrue(m, clusterv1.PausedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(m, clusterv1.PausedCondition)).To(Equal(clusterv1.ClusterPausedReason))
+	})
+
+	t.Run("sets the condition if the object has the paused annotation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		m := getMachine()
+		m.SetAnnotations(map[string]string{clusterv1.PausedAnnotation: ""})
+		g.Expect(c.Update(ctx, m)).To(Succeed())
+
+		isPaused, err := EnsurePausedCondition(ctx, c, cluster, m)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(isPaused).To(BeTrue())
+
+		m = getMachine()
+		g.Expect(conditions.IsTrue(m, clusterv1.PausedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(m, clusterv1.PausedCondition)).To(Equal(clusterv1.PausedAnnotationReason))
+	})
+
+	t.Run("removes the condition when reconciliation resumes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		m := getMachine()
+		m.SetAnnotations(nil)
+		g.Expect(c.Update(ctx, m)).To(Succeed())
+
+		isPaused, err := EnsurePausedCondition(ctx, c, cluster, m)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(isPaused).To(BeFalse())
+		g.Expect(conditions.Has(getMachine(), clusterv1.PausedCondition)).To(BeFalse())
+	})
+}
@@ -144,6 +144,36 @@ func ClusterUpdateUnpaused(logger logr.Logger) predicate.Funcs {
 	}
 }
 
+// ClusterPausedTransitions returns a predicate that returns true for an update event when a cluster has Spec.Paused changed
+// in either direction, so that controllers can report the Paused condition on the objects belonging to the Cluster.
+func ClusterPausedTransitions(logger logr.Logger) predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			log := logger.WithValues("predicate", "ClusterPausedTransitions", "eventType", "update")
+
+			oldCluster, ok := e.ObjectOld.(*clusterv1.Cluster)
+			if !ok {
+				log.V(4).Info("Expected Cluster", "type", e.ObjectOld.GetObjectKind().GroupVersionKind().String())
+				return false
+			}
+			log = log.WithValues("namespace", oldCluster.Namespace, "cluster", oldCluster.Name)
+
+			newCluster := e.ObjectNew.(*clusterv1.Cluster)
+
+			if oldCluster.Spec.Paused != newCluster.Spec.Paused {
+				log.V(4).Info("Cluster paused state changed, allowing further processing")
+				return true
+			}
+
+			log.V(6).Info("Cluster paused state did not change, blocking further processing")
+			return false
+		},
+		CreateFunc:  func(e event.CreateEvent) bool { return false },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+		GenericFunc: func(e event.GenericEvent) bool { return false },
+	}
+}
+
 // ClusterUnpaused returns a Predicate that returns true on Cluster creation events where Cluster.Spec.Paused is false
 // and Update events when Cluster.Spec.Paused transitions to false.
 // This implements a common requirement for many cluster-api and provider controllers (such as Cluster Infrastructure
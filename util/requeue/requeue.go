@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package requeue implements helpers for computing requeue intervals with jitter and exponential backoff,
+// so controllers reconciling a large number of objects do not requeue all of them at the same time.
+package requeue
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DefaultJitterFactor is the maximum jitter added to requeue intervals, as a fraction of the interval.
+const DefaultJitterFactor = 0.1
+
+// DefaultBackoff is the backoff used by controllers retrying a failing operation.
+var DefaultBackoff = Backoff{
+	Duration: 5 * time.Second,
+	Factor:   2,
+	Jitter:   DefaultJitterFactor,
+	Cap:      5 * time.Minute,
+}
+
+// AfterWithJitter returns a result requeueing after d plus a random jitter of up to d*DefaultJitterFactor.
+func AfterWithJitter(d time.Duration) ctrl.Result {
+	return ctrl.Result{RequeueAfter: Jitter(d, DefaultJitterFactor)}
+}
+
+// Jitter returns d plus a random jitter of up to d*maxFactor; if maxFactor is not positive,
+// DefaultJitterFactor is used.
+func Jitter(d time.Duration, maxFactor float64) time.Duration {
+	if maxFactor <= 0 {
+		maxFactor = DefaultJitterFactor
+	}
+	return wait.Jitter(d, maxFactor)
+}
+
+// Backoff defines an exponential backoff with jitter.
+type Backoff struct {
+	// Duration is the requeue interval after the first failure.
+	Duration time.Duration
+
+	// Factor multiplies the requeue interval after each consecutive failure.
+	Factor float64
+
+	// Jitter is the maximum jitter added to the requeue interval, as a fraction of the interval.
+	Jitter float64
+
+	// Cap is the maximum requeue interval, before adding jitter; zero means no limit.
+	Cap time.Duration
+}
+
+// Step returns the requeue interval after the given number of consecutive failures, including jitter.
+func (b Backoff) Step(failures int) time.Duration {
+	if failures < 1 {
+		failures = 1
+	}
+	factor := b.Factor
+	if factor < 1 {
+		factor = 1
+	}
+
+	d := float64(b.Duration) * math.Pow(factor, float64(failures-1))
+	if b.Cap > 0 && d > float64(b.Cap) {
+		d = float64(b.Cap)
+	}
+	if b.Jitter > 0 {
+		return wait.Jitter(time.Duration(d), b.Jitter)
+	}
+	return time.Duration(d)
+}
+
+// FailureTracker keeps track of the consecutive failures of an operation retried by a controller for each object,
+// so the operation can be retried with an exponential backoff.
+// NOTE: Failures are kept in memory, so they are not persisted on the objects and do not trigger new reconciles;
+// the backoff restarts from the first step if the controller restarts. The zero value is ready to use.
+type FailureTracker struct {
+	lock     sync.Mutex
+	failures map[types.UID]int
+}
+
+// Failures returns the number of consecutive failures recorded for the object by AfterFailure.
+func (t *FailureTracker) Failures(o metav1.Object) int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.failures[o.GetUID()]
+}
+
+// AfterFailure records a failure for the object and returns a result requeueing after the backoff
+// interval for the resulting number of consecutive failures.
+func (t *FailureTracker) AfterFailure(o metav1.Object, b Backoff) ctrl.Result {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.failures == nil {
+		t.failures = map[types.UID]int{}
+	}
+	t.failures[o.GetUID()]++
+	return ctrl.Result{RequeueAfter: b.Step(t.failures[o.GetUID()])}
+}
+
+// Reset forgets the failures recorded for the object, to be called once the operation succeeds
+// or the object is deleted.
+func (t *FailureTracker) Reset(o metav1.Object) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.failures, o.GetUID())
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requeue
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAfterWithJitter(t *testing.T) {
+	g := NewWithT(t)
+
+	for i := 0; i < 100; i++ {
+		res := AfterWithJitter(30 * time.Second)
+		g.Expect(res.Requeue).To(BeFalse())
+		g.Expect(res.RequeueAfter).To(BeNumerically(">=", 30*time.Second))
+		g.Expect(res.RequeueAfter).To(BeNumerically("<=", 33*time.Second))
+	}
+}
+
+func TestBackoffStep(t *testing.T) {
+	b := Backoff{
+		Duration: 5 * time.Second,
+		Factor:   2,
+		Cap:      time.Minute,
+	}
+
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Duration
+	}{
+		{
+			name:     "first failure",
+			failures: 1,
+			want:     5 * time.Second,
+		},
+		{
+			name:     "zero failures are treated as the first failure",
+			failures: 0,
+			want:     5 * time.Second,
+		},
+		{
+			name:     "consecutive failures",
+			failures: 3,
+			want:     20 * time.Second,
+		},
+		{
+			name:     "capped",
+			failures: 10,
+			want:     time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(b.Step(tt.failures)).To(Equal(tt.want))
+
+			b := b
+			b.Jitter = 0.5
+			got := b.Step(tt.failures)
+			g.Expect(got).To(BeNumerically(">=", tt.want))
+			g.Expect(got).To(BeNumerically("<=", tt.want+tt.want/2))
+		})
+	}
+}
+
+func TestFailureTracker(t *testing.T) {
+	g := NewWithT(t)
+
+	b := Backoff{
+		Duration: time.Second,
+		Factor:   2,
+	}
+	obj := &metav1.ObjectMeta{UID: "obj"}
+	other := &metav1.ObjectMeta{UID: "other"}
+	tracker := &FailureTracker{}
+
+	g.Expect(tracker.Failures(obj)).To(Equal(0))
+	g.Expect(tracker.AfterFailure(obj, b).RequeueAfter).To(Equal(time.Second))
+	g.Expect(tracker.AfterFailure(obj, b).RequeueAfter).To(Equal(2 * time.Second))
+	g.Expect(tracker.AfterFailure(obj, b).RequeueAfter).To(Equal(4 * time.Second))
+	g.Expect(tracker.Failures(obj)).To(Equal(3))
+	g.Expect(obj.GetAnnotations()).To(BeEmpty())
+
+	// Failures are tracked separately for each object.
+	g.Expect(tracker.Failures(other)).To(Equal(0))
+	g.Expect(tracker.AfterFailure(other, b).RequeueAfter).To(Equal(time.Second))
+
+	tracker.Reset(obj)
+	g.Expect(tracker.Failures(obj)).To(Equal(0))
+	g.Expect(tracker.Failures(other)).To(Equal(1))
+	g.Expect(tracker.AfterFailure(obj, b).RequeueAfter).To(Equal(time.Second))
+}
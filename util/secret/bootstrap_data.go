@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// BootstrapDataSecrets returns the secrets storing the given bootstrap data, using the given secret as a template
+// for their name, namespace, labels, annotations, owner references and type.
+// Bootstrap data up to BootstrapDataChunkSize is stored in the BootstrapDataName key of a single secret with the name
+// of the template. Larger bootstrap data is split in chunks, each one stored in the BootstrapDataName key of the secret
+// named as returned by BootstrapDataChunkSecretName, while the secret with the name of the template stores the number
+// of chunks in the BootstrapDataChunksName key.
+// The secret with the name of the template is always the last one, so it can be written after the chunks it refers to.
+func BootstrapDataSecrets(template *corev1.Secret, data []byte) []*corev1.Secret {
+	if len(data) <= BootstrapDataChunkSize {
+		secret := template.DeepCopy()
+		secret.Data = map[string][]byte{BootstrapDataName: data}
+		return []*corev1.Secret{secret}
+	}
+
+	secrets := []*corev1.Secret{}
+	for i := 0; i*BootstrapDataChunkSize < len(data); i++ {
+		end := (i + 1) * BootstrapDataChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk := template.DeepCopy()
+		chunk.Name = BootstrapDataChunkSecretName(template.Name, i)
+		chunk.Data = map[string][]byte{BootstrapDataName: data[i*BootstrapDataChunkSize : end]}
+		secrets = append(secrets, chunk)
+	}
+
+	secret := template.DeepCopy()
+	secret.Data = map[string][]byte{BootstrapDataChunksName: []byte(strconv.Itoa(len(secrets)))}
+	return append(secrets, secret)
+}
+
+// BootstrapDataChunkSecretName returns the name of the secret storing the i-th chunk of the bootstrap data
+// stored in the secret with the given name.
+func BootstrapDataChunkSecretName(name string, i int) string {
+	return fmt.Sprintf("%s-chunk-%d", name, i)
+}
+
+// GetBootstrapData returns the bootstrap data stored in the secret with the given name, reassembling it
+// if it is split across multiple secrets.
+func GetBootstrapData(ctx context.Context, c client.Reader, key client.ObjectKey) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, key, secret); err != nil {
+		return nil, errors.Wrapf(err, "failed to get bootstrap data secret %s", key)
+	}
+
+	chunksValue, ok := secret.Data[BootstrapDataChunksName]
+	if !ok {
+		value, ok := secret.Data[BootstrapDataName]
+		if !ok {
+			return nil, errors.Errorf("bootstrap data secret %s has neither the %q nor the %q key", key, BootstrapDataName, BootstrapDataChunksName)
+		}
+		return value, nil
+	}
+
+	chunks, err := strconv.Atoi(string(chunksValue))
+	if err != nil || chunks < 1 {
+		return nil, errors.Errorf("bootstrap data secret %s has an invalid number of chunks %q", key, string(chunksValue))
+	}
+
+	var data []byte
+	for i := 0; i < chunks; i++ {
+		chunkKey := client.ObjectKey{Namespace: key.Namespace, Name: BootstrapDataChunkSecretName(key.Name, i)}
+		chunk := &corev1.Secret{}
+		if err := c.Get(ctx, chunkKey, chunk); err != nil {
+			return nil, errors.Wrapf(err, "failed to get bootstrap data chunk secret %s", chunkKey)
+		}
+		value, ok := chunk.Data[BootstrapDataName]
+		if !ok {
+			return nil, errors.Errorf("bootstrap data chunk secret %s has no %q key", chunkKey, BootstrapDataName)
+		}
+		data = append(data, value...)
+	}
+	return data, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestBootstrapData(t *testing.T) {
+	template := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bootstrap",
+			Namespace: metav1.NamespaceDefault,
+			Labels:    map[string]string{"foo": "bar"},
+		},
+	}
+	key := client.ObjectKeyFromObject(template)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		wantSecrets []string
+	}{
+		{
+			name:        "small bootstrap data is stored in a single secret",
+			data:        []byte("#cloud-config"),
+			wantSecrets: []string{"bootstrap"},
+		},
+		{
+			name:        "bootstrap data of the max chunk size is stored in a single secret",
+			data:        bytes.Repeat([]byte("a"), BootstrapDataChunkSize),
+			wantSecrets: []string{"bootstrap"},
+		},
+		{
+			name:        "large bootstrap data is split in chunks",
+			data:        append(bytes.Repeat([]byte("a"), 2*BootstrapDataChunkSize), 'b'),
+			wantSecrets: []string{"bootstrap-chunk-0", "bootstrap-chunk-1", "bootstrap-chunk-2", "bootstrap"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().Build()
+			secrets := BootstrapDataSecrets(template, tt.data)
+
+			names := []string{}
+			for _, s := range secrets {
+				names = append(names, s.Name)
+				g.Expect(s.Namespace).To(Equal(template.Namespace))
+				g.Expect(s.Labels).To(Equal(template.Labels))
+				g.Expect(len(s.Data[BootstrapDataName])).To(BeNumerically("<=", BootstrapDataChunkSize))
+				g.Expect(c.Create(context.Background(), s)).To(Succeed())
+			}
+			g.Expect(names).To(Equal(tt.wantSecrets))
+
+			data, err := GetBootstrapData(context.Background(), c, key)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(data).To(Equal(tt.data))
+		})
+	}
+}
+
+func TestGetBootstrapDataErrors(t *testing.T) {
+	key := client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "bootstrap"}
+	secret := func(name string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault}, Data: data}
+	}
+
+	tests := []struct {
+		name string
+		objs []client.Object
+	}{
+		{
+			name: "secret does not exist",
+		},
+		{
+			name: "secret without bootstrap data",
+			objs: []client.Object{secret("bootstrap", map[string][]byte{"foo": []byte("bar")})},
+		},
+		{
+			name: "invalid number of chunks",
+			objs: []client.Object{secret("bootstrap", map[string][]byte{BootstrapDataChunksName: []byte("two")})},
+		},
+		{
+			name: "missing chunk",
+			objs: []client.Object{
+				secret("bootstrap", map[string][]byte{BootstrapDataChunksName: []byte("2")}),
+				secret("bootstrap-chunk-0", map[string][]byte{BootstrapDataName: []byte("foo")}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			_, err := GetBootstrapData(context.Background(), c, key)
+			g.Expect(err).To(HaveOccurred())
+		})
+	}
+}
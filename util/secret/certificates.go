@@ -224,7 +224,7 @@ func (c Certificates) EnsureAllExist() error {
 		if len(certificate.KeyPair.Cert) == 0 {
 			return errors.Wrapf(ErrMissingCrt, "for certificate: %s", certificate.Purpose)
 		}
-		if !certificate.External {
+		if !certificate.External && !certificate.IsExternalCA() {
 			if len(certificate.KeyPair.Key) == 0 {
 				return errors.Wrapf(ErrMissingKey, "for certificate: %s", certificate.Purpose)
 			}
@@ -285,6 +285,16 @@ type Certificate struct {
 	CertFile, KeyFile string
 }
 
+// IsExternalCA returns true if the certificate is a cluster or front proxy CA provided without the private key, because
+// the CA is managed outside of Cluster API; in this case kubeadm runs in external CA mode, and all the certificates
+// signed by the CA must be provided to the machines by other means, e.g. the files of the KubeadmConfig.
+func (c *Certificate) IsExternalCA() bool {
+	if c.Purpose != ClusterCA && c.Purpose != FrontProxyCA {
+		return false
+	}
+	return c.KeyPair != nil && len(c.KeyPair.Cert) > 0 && len(c.KeyPair.Key) == 0
+}
+
 // Hashes hashes all the certificates stored in a CA certificate.
 func (c *Certificate) Hashes() ([]string, error) {
 	certificates, err := cert.ParseCertsPEM(c.KeyPair.Cert)
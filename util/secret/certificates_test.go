@@ -21,6 +21,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/secret"
 )
 
@@ -44,3 +45,24 @@ func TestNewControlPlaneJoinCertsExternal(t *testing.T) {
 	certs := secret.NewControlPlaneJoinCerts(config)
 	g.Expect(certs.GetByPurpose(secret.EtcdCA).KeyFile).To(BeEmpty())
 }
+
+func TestEnsureAllExistWithExternalCA(t *testing.T) {
+	g := NewWithT(t)
+
+	certificates := secret.NewControlPlaneJoinCerts(&bootstrapv1.ClusterConfiguration{})
+	for _, certificate := range certificates {
+		certificate.KeyPair = &certs.KeyPair{Cert: []byte("cert"), Key: []byte("key")}
+	}
+	g.Expect(certificates.EnsureAllExist()).To(Succeed())
+
+	// The cluster CA and the front proxy CA can be provided without the private key.
+	certificates.GetByPurpose(secret.ClusterCA).KeyPair.Key = nil
+	certificates.GetByPurpose(secret.FrontProxyCA).KeyPair.Key = nil
+	g.Expect(certificates.GetByPurpose(secret.ClusterCA).IsExternalCA()).To(BeTrue())
+	g.Expect(certificates.EnsureAllExist()).To(Succeed())
+
+	// Other certificates require the private key.
+	certificates.GetByPurpose(secret.EtcdCA).KeyPair.Key = nil
+	g.Expect(certificates.GetByPurpose(secret.EtcdCA).IsExternalCA()).To(BeFalse())
+	g.Expect(certificates.EnsureAllExist()).ToNot(Succeed())
+}
@@ -23,15 +23,33 @@ const (
 	// KubeconfigDataName is the key used to store a Kubeconfig in the secret's data field.
 	KubeconfigDataName = "value"
 
+	// BootstrapDataName is the key used to store the bootstrap data in the bootstrap data secret's data field.
+	BootstrapDataName = "value"
+
+	// BootstrapDataChunksName is the key used to store the number of chunks in the bootstrap data secret's data field,
+	// when the bootstrap data is split across multiple secrets.
+	BootstrapDataChunksName = "chunks"
+
+	// BootstrapDataChunkSize is the max size of the bootstrap data stored in a single secret; it leaves room for
+	// the secret metadata within the 1MiB size limit of the objects stored in etcd.
+	BootstrapDataChunkSize = 900 * 1024
+
 	// TLSKeyDataName is the key used to store a TLS private key in the secret's data field.
 	TLSKeyDataName = "tls.key"
 
 	// TLSCrtDataName is the key used to store a TLS certificate in the secret's data field.
 	TLSCrtDataName = "tls.crt"
 
+	// TLSCSRDataName is the key used to store a certificate signing request in the secret's data field.
+	TLSCSRDataName = "tls.csr"
+
 	// Kubeconfig is the secret name suffix storing the Cluster Kubeconfig.
 	Kubeconfig = Purpose("kubeconfig")
 
+	// KubeconfigCSR is the secret name suffix storing the certificate signing request for the client certificate
+	// of the Cluster Kubeconfig, when the Cluster uses an external CA.
+	KubeconfigCSR = Purpose("kubeconfig-csr")
+
 	// ClusterCA is the secret name suffix for APIServer CA.
 	ClusterCA = Purpose("ca")
 
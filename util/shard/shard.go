@@ -0,0 +1,70 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shard implements the assignment of Clusters to the shards of multiple instances of the same controllers,
+// each one watching the objects with a different value of the watch-filter label.
+package shard
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Assign returns the shard a Cluster is assigned to, or an empty string if there are no shards.
+// Clusters are assigned using rendezvous hashing, so that adding a shard only moves Clusters to the new shard,
+// and removing a shard only moves the Clusters assigned to the removed shard.
+func Assign(shards []string, namespace, clusterName string) string {
+	var (
+		assigned    string
+		assignedSum uint64
+	)
+	for _, shard := range shards {
+		h := sha256.Sum256([]byte(shard + "/" + namespace + "/" + clusterName))
+		if sum := binary.BigEndian.Uint64(h[:8]); assigned == "" || sum > assignedSum || (sum == assignedSum && shard < assigned) {
+			assigned, assignedSum = shard, sum
+		}
+	}
+	return assigned
+}
+
+// Validate checks that the shards are unique valid label values and, if set, that the watch-filter value
+// of the current instance is one of the shards.
+func Validate(shards []string, watchFilterValue string) error {
+	if len(shards) == 0 {
+		return nil
+	}
+	seen := sets.NewString()
+	for _, shard := range shards {
+		if shard == "" {
+			return errors.New("shards must not be empty")
+		}
+		if errs := validation.IsValidLabelValue(shard); len(errs) > 0 {
+			return errors.Errorf("shard %q is not a valid label value: %v", shard, errs)
+		}
+		if seen.Has(shard) {
+			return errors.Errorf("shard %q is duplicated", shard)
+		}
+		seen.Insert(shard)
+	}
+	if watchFilterValue != "" && !seen.Has(watchFilterValue) {
+		return errors.Errorf("watch-filter value %q is not one of the shards %v", watchFilterValue, shards)
+	}
+	return nil
+}
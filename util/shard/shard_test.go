@@ -0,0 +1,123 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shard
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestAssign(t *testing.T) {
+	t.Run("returns an empty string when there are no shards", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(Assign(nil, "default", "foo")).To(BeEmpty())
+	})
+
+	t.Run("is independent from the order of the shards", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for i := 0; i < 100; i++ {
+			name := fmt.Sprintf("cluster-%d", i)
+			g.Expect(Assign([]string{"a", "b", "c"}, "default", name)).To(Equal(Assign([]string{"c", "a", "b"}, "default", name)))
+		}
+	})
+
+	t.Run("spreads Clusters across all the shards", func(t *testing.T) {
+		g := NewWithT(t)
+
+		counts := map[string]int{}
+		for i := 0; i < 300; i++ {
+			counts[Assign([]string{"a", "b", "c"}, "default", fmt.Sprintf("cluster-%d", i))]++
+		}
+		g.Expect(counts).To(HaveLen(3))
+		for _, count := range counts {
+			g.Expect(count).To(BeNumerically(">", 50))
+		}
+	})
+
+	t.Run("only moves Clusters to a new shard when adding a shard", func(t *testing.T) {
+		g := NewWithT(t)
+
+		for i := 0; i < 300; i++ {
+			name := fmt.Sprintf("cluster-%d", i)
+			before := Assign([]string{"a", "b", "c"}, "default", name)
+			after := Assign([]string{"a", "b", "c", "d"}, "default", name)
+			if after != before {
+				g.Expect(after).To(Equal("d"))
+			}
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name             string
+		shards           []string
+		watchFilterValue string
+		wantErr          bool
+	}{
+		{
+			name:             "accepts no shards",
+			watchFilterValue: "foo",
+		},
+		{
+			name:             "accepts a watch-filter value which is one of the shards",
+			shards:           []string{"shard-0", "shard-1"},
+			watchFilterValue: "shard-1",
+		},
+		{
+			name:   "accepts an empty watch-filter value",
+			shards: []string{"shard-0", "shard-1"},
+		},
+		{
+			name:             "rejects a watch-filter value which is not one of the shards",
+			shards:           []string{"shard-0", "shard-1"},
+			watchFilterValue: "shard-2",
+			wantErr:          true,
+		},
+		{
+			name:    "rejects duplicated shards",
+			shards:  []string{"shard-0", "shard-0"},
+			wantErr: true,
+		},
+		{
+			name:    "rejects shards which are not valid label values",
+			shards:  []string{"shard/0"},
+			wantErr: true,
+		},
+		{
+			name:    "rejects empty shards",
+			shards:  []string{""},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := Validate(tt.shards, tt.watchFilterValue)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -125,6 +126,12 @@ func (c *ClusterTopologyBuilder) WithMachineDeployment(mdc clusterv1.MachineDepl
 	return c
 }
 
+// WithMachinePool passes the full MachinePoolTopology and adds it to an existing list in the ClusterTopologyBuilder.
+func (c *ClusterTopologyBuilder) WithMachinePool(mpc clusterv1.MachinePoolTopology) *ClusterTopologyBuilder {
+	c.workers.MachinePools = append(c.workers.MachinePools, mpc)
+	return c
+}
+
 // Build returns a testable cluster Topology object with any values passed to the builder.
 func (c *ClusterTopologyBuilder) Build() *clusterv1.Topology {
 	return &clusterv1.Topology{
@@ -172,6 +179,41 @@ func (m *MachineDeploymentTopologyBuilder) Build() clusterv1.MachineDeploymentTo
 	}
 }
 
+// MachinePoolTopologyBuilder holds the values needed to create a testable MachinePoolTopology.
+type MachinePoolTopologyBuilder struct {
+	class    string
+	name     string
+	replicas *int32
+}
+
+// MachinePoolTopology returns a builder used to create a testable MachinePoolTopology.
+func MachinePoolTopology(name string) *MachinePoolTopologyBuilder {
+	return &MachinePoolTopologyBuilder{
+		name: name,
+	}
+}
+
+// WithClass adds a class string used as the MachinePoolTopology class.
+func (m *MachinePoolTopologyBuilder) WithClass(class string) *MachinePoolTopologyBuilder {
+	m.class = class
+	return m
+}
+
+// WithReplicas adds a replicas value used as the MachinePoolTopology replicas value.
+func (m *MachinePoolTopologyBuilder) WithReplicas(replicas int32) *MachinePoolTopologyBuilder {
+	m.replicas = &replicas
+	return m
+}
+
+// Build returns a testable MachinePoolTopology with any values passed to the builder.
+func (m *MachinePoolTopologyBuilder) Build() clusterv1.MachinePoolTopology {
+	return clusterv1.MachinePoolTopology{
+		Class:    m.class,
+		Name:     m.name,
+		Replicas: m.replicas,
+	}
+}
+
 // ClusterClassBuilder holds the variables and objects required to build a clusterv1.ClusterClass.
 type ClusterClassBuilder struct {
 	namespace                                 string
@@ -181,6 +223,7 @@ type ClusterClassBuilder struct {
 	controlPlaneTemplate                      *unstructured.Unstructured
 	controlPlaneInfrastructureMachineTemplate *unstructured.Unstructured
 	machineDeploymentClasses                  []clusterv1.MachineDeploymentClass
+	machinePoolClasses                        []clusterv1.MachinePoolClass
 }
 
 // ClusterClass returns a ClusterClassBuilder with the given name and namespace.
@@ -227,6 +270,12 @@ func (c *ClusterClassBuilder) WithWorkerMachineDeploymentClasses(mdcs []clusterv
 	return c
 }
 
+// WithWorkerMachinePoolClasses adds the variables and objects needed to create MachinePoolTemplates for a ClusterClassBuilder.
+func (c *ClusterClassBuilder) WithWorkerMachinePoolClasses(mpcs []clusterv1.MachinePoolClass) *ClusterClassBuilder {
+	c.machinePoolClasses = append(c.machinePoolClasses, mpcs...)
+	return c
+}
+
 // Build takes the objects and variables in the ClusterClass builder and uses them to create a ClusterClass object.
 func (c *ClusterClassBuilder) Build() *clusterv1.ClusterClass {
 	obj := &clusterv1.ClusterClass{
@@ -259,6 +308,7 @@ func (c *ClusterClassBuilder) Build() *clusterv1.ClusterClass {
 		}
 	}
 	obj.Spec.Workers.MachineDeployments = c.machineDeploymentClasses
+	obj.Spec.Workers.MachinePools = c.machinePoolClasses
 	return obj
 }
 
@@ -269,6 +319,7 @@ type MachineDeploymentClassBuilder struct {
 	bootstrapTemplate             *unstructured.Unstructured
 	labels                        map[string]string
 	annotations                   map[string]string
+	constraints                   *clusterv1.MachineDeploymentClassConstraints
 }
 
 // MachineDeploymentClass returns a MachineDeploymentClassBuilder with the given name and namespace.
@@ -302,10 +353,17 @@ func (m *MachineDeploymentClassBuilder) WithAnnotations(annotations map[string]s
 	return m
 }
 
+// WithConstraints sets the constraints for the MachineDeploymentClassBuilder.
+func (m *MachineDeploymentClassBuilder) WithConstraints(constraints *clusterv1.MachineDeploymentClassConstraints) *MachineDeploymentClassBuilder {
+	m.constraints = constraints
+	return m
+}
+
 // Build creates a full MachineDeploymentClass object with the variables passed to the MachineDeploymentClassBuilder.
 func (m *MachineDeploymentClassBuilder) Build() *clusterv1.MachineDeploymentClass {
 	return &clusterv1.MachineDeploymentClass{
-		Class: m.class,
+		Class:       m.class,
+		Constraints: m.constraints,
 		Template: clusterv1.MachineDeploymentClassTemplate{
 			Metadata: clusterv1.ObjectMeta{
 				Labels:      m.labels,
@@ -321,6 +379,65 @@ func (m *MachineDeploymentClassBuilder) Build() *clusterv1.MachineDeploymentClas
 	}
 }
 
+// MachinePoolClassBuilder holds the variables and objects required to build a clusterv1.MachinePoolClass.
+type MachinePoolClassBuilder struct {
+	class                             string
+	infrastructureMachinePoolTemplate *unstructured.Unstructured
+	bootstrapTemplate                 *unstructured.Unstructured
+	labels                            map[string]string
+	annotations                       map[string]string
+}
+
+// MachinePoolClass returns a MachinePoolClassBuilder with the given class.
+func MachinePoolClass(class string) *MachinePoolClassBuilder {
+	return &MachinePoolClassBuilder{
+		class: class,
+	}
+}
+
+// WithInfrastructureTemplate registers the passed Unstructured object as the InfrastructureMachinePoolTemplate for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithInfrastructureTemplate(t *unstructured.Unstructured) *MachinePoolClassBuilder {
+	m.infrastructureMachinePoolTemplate = t
+	return m
+}
+
+// WithBootstrapTemplate registers the passed Unstructured object as the BootstrapTemplate for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithBootstrapTemplate(t *unstructured.Unstructured) *MachinePoolClassBuilder {
+	m.bootstrapTemplate = t
+	return m
+}
+
+// WithLabels sets the labels for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithLabels(labels map[string]string) *MachinePoolClassBuilder {
+	m.labels = labels
+	return m
+}
+
+// WithAnnotations sets the annotations for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithAnnotations(annotations map[string]string) *MachinePoolClassBuilder {
+	m.annotations = annotations
+	return m
+}
+
+// Build creates a full MachinePoolClass object with the variables passed to the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) Build() *clusterv1.MachinePoolClass {
+	return &clusterv1.MachinePoolClass{
+		Class: m.class,
+		Template: clusterv1.MachinePoolClassTemplate{
+			Metadata: clusterv1.ObjectMeta{
+				Labels:      m.labels,
+				Annotations: m.annotations,
+			},
+			Bootstrap: clusterv1.LocalObjectTemplate{
+				Ref: objToRef(m.bootstrapTemplate),
+			},
+			Infrastructure: clusterv1.LocalObjectTemplate{
+				Ref: objToRef(m.infrastructureMachinePoolTemplate),
+			},
+		},
+	}
+}
+
 // InfrastructureMachineTemplateBuilder holds the variables and objects needed to build an InfrastructureMachineTemplate.
 type InfrastructureMachineTemplateBuilder struct {
 	namespace  string
@@ -341,9 +458,9 @@ func InfrastructureMachineTemplate(namespace, name string) *InfrastructureMachin
 //
 // Note: all the paths should start with "spec."
 //
-// Example map: map[string]interface{}{
-//     "spec.version": "v1.2.3",
-// }.
+//	Example map: map[string]interface{}{
+//	    "spec.version": "v1.2.3",
+//	}.
 func (i *InfrastructureMachineTemplateBuilder) WithSpecFields(fields map[string]interface{}) *InfrastructureMachineTemplateBuilder {
 	i.specFields = fields
 	return i
@@ -420,9 +537,9 @@ func InfrastructureClusterTemplate(namespace, name string) *InfrastructureCluste
 //
 // Note: all the paths should start with "spec."
 //
-// Example map: map[string]interface{}{
-//     "spec.version": "v1.2.3",
-// }.
+//	Example map: map[string]interface{}{
+//	    "spec.version": "v1.2.3",
+//	}.
 func (i *InfrastructureClusterTemplateBuilder) WithSpecFields(fields map[string]interface{}) *InfrastructureClusterTemplateBuilder {
 	i.specFields = fields
 	return i
@@ -465,9 +582,9 @@ func ControlPlaneTemplate(namespace, name string) *ControlPlaneTemplateBuilder {
 //
 // Note: all the paths should start with "spec."
 //
-// Example map: map[string]interface{}{
-//     "spec.version": "v1.2.3",
-// }.
+//	Example map: map[string]interface{}{
+//	    "spec.version": "v1.2.3",
+//	}.
 func (c *ControlPlaneTemplateBuilder) WithSpecFields(fields map[string]interface{}) *ControlPlaneTemplateBuilder {
 	c.specFields = fields
 	return c
@@ -512,9 +629,9 @@ type InfrastructureClusterBuilder struct {
 //
 // Note: all the paths should start with "spec."
 //
-// Example map: map[string]interface{}{
-//     "spec.version": "v1.2.3",
-// }.
+//	Example map: map[string]interface{}{
+//	    "spec.version": "v1.2.3",
+//	}.
 func (i *InfrastructureClusterBuilder) WithSpecFields(fields map[string]interface{}) *InfrastructureClusterBuilder {
 	i.specFields = fields
 	return i
@@ -582,9 +699,9 @@ func (f *ControlPlaneBuilder) WithVersion(version string) *ControlPlaneBuilder {
 //
 // Note: all the paths should start with "spec."
 //
-// Example map: map[string]interface{}{
-//     "spec.version": "v1.2.3",
-// }.
+//	Example map: map[string]interface{}{
+//	    "spec.version": "v1.2.3",
+//	}.
 func (f *ControlPlaneBuilder) WithSpecFields(m map[string]interface{}) *ControlPlaneBuilder {
 	f.specFields = m
 	return f
@@ -595,9 +712,9 @@ func (f *ControlPlaneBuilder) WithSpecFields(m map[string]interface{}) *ControlP
 //
 // Note: all the paths should start with "status."
 //
-// Example map: map[string]interface{}{
-//     "status.version": "v1.2.3",
-// }.
+//	Example map: map[string]interface{}{
+//	    "status.version": "v1.2.3",
+//	}.
 func (f *ControlPlaneBuilder) WithStatusFields(m map[string]interface{}) *ControlPlaneBuilder {
 	f.statusFields = m
 	return f
@@ -634,6 +751,45 @@ func (f *ControlPlaneBuilder) Build() *unstructured.Unstructured {
 	return obj
 }
 
+// InfrastructureMachinePoolTemplateBuilder holds the variables and objects needed to build an InfrastructureMachinePoolTemplate.
+type InfrastructureMachinePoolTemplateBuilder struct {
+	namespace  string
+	name       string
+	specFields map[string]interface{}
+}
+
+// InfrastructureMachinePoolTemplate creates an InfrastructureMachinePoolTemplateBuilder with the given name and namespace.
+func InfrastructureMachinePoolTemplate(namespace, name string) *InfrastructureMachinePoolTemplateBuilder {
+	return &InfrastructureMachinePoolTemplateBuilder{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// WithSpecFields sets a map of spec fields on the unstructured object. The keys in the map represent the path and the value corresponds
+// to the value of the spec field.
+//
+// Note: all the paths should start with "spec.".
+func (i *InfrastructureMachinePoolTemplateBuilder) WithSpecFields(fields map[string]interface{}) *InfrastructureMachinePoolTemplateBuilder {
+	i.specFields = fields
+	return i
+}
+
+// Build takes the objects and variables in the InfrastructureMachinePoolTemplateBuilder and generates an unstructured object.
+func (i *InfrastructureMachinePoolTemplateBuilder) Build() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(InfrastructureGroupVersion.String())
+	obj.SetKind(GenericInfrastructureMachinePoolTemplateKind)
+	obj.SetNamespace(i.namespace)
+	obj.SetName(i.name)
+
+	// Initialize the spec.template.spec to make the object valid in reconciliation.
+	setSpecFields(obj, map[string]interface{}{"spec.template.spec": map[string]interface{}{}})
+
+	setSpecFields(obj, i.specFields)
+	return obj
+}
+
 // MachineDeploymentBuilder holds the variables and objects needed to build a generic MachineDeployment.
 type MachineDeploymentBuilder struct {
 	namespace              string
@@ -729,6 +885,91 @@ func (m *MachineDeploymentBuilder) Build() *clusterv1.MachineDeployment {
 	return obj
 }
 
+// MachinePoolBuilder holds the variables and objects needed to build a generic MachinePool.
+type MachinePoolBuilder struct {
+	namespace      string
+	name           string
+	bootstrap      *unstructured.Unstructured
+	infrastructure *unstructured.Unstructured
+	version        *string
+	clusterName    string
+	replicas       *int32
+	labels         map[string]string
+}
+
+// MachinePool creates a MachinePoolBuilder with the given name and namespace.
+func MachinePool(namespace, name string) *MachinePoolBuilder {
+	return &MachinePoolBuilder{
+		name:      name,
+		namespace: namespace,
+	}
+}
+
+// WithBootstrap adds the passed Unstructured object to the MachinePoolBuilder as a bootstrap config.
+func (m *MachinePoolBuilder) WithBootstrap(ref *unstructured.Unstructured) *MachinePoolBuilder {
+	m.bootstrap = ref
+	return m
+}
+
+// WithInfrastructure adds the passed Unstructured object to the MachinePoolBuilder as an infrastructure machine pool.
+func (m *MachinePoolBuilder) WithInfrastructure(ref *unstructured.Unstructured) *MachinePoolBuilder {
+	m.infrastructure = ref
+	return m
+}
+
+// WithLabels adds the given labels to the MachinePoolBuilder.
+func (m *MachinePoolBuilder) WithLabels(labels map[string]string) *MachinePoolBuilder {
+	m.labels = labels
+	return m
+}
+
+// WithVersion sets the passed version on the MachinePool spec.
+func (m *MachinePoolBuilder) WithVersion(version string) *MachinePoolBuilder {
+	m.version = &version
+	return m
+}
+
+// WithClusterName sets the passed clusterName on the MachinePool spec.
+func (m *MachinePoolBuilder) WithClusterName(clusterName string) *MachinePoolBuilder {
+	m.clusterName = clusterName
+	return m
+}
+
+// WithReplicas sets the number of replicas for the MachinePoolBuilder.
+func (m *MachinePoolBuilder) WithReplicas(replicas int32) *MachinePoolBuilder {
+	m.replicas = &replicas
+	return m
+}
+
+// Build creates a new MachinePool with the variables and objects passed to the MachinePoolBuilder.
+func (m *MachinePoolBuilder) Build() *expv1.MachinePool {
+	obj := &expv1.MachinePool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachinePool",
+			APIVersion: expv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.name,
+			Namespace: m.namespace,
+			Labels:    m.labels,
+		},
+		Spec: expv1.MachinePoolSpec{
+			ClusterName: m.clusterName,
+			Replicas:    m.replicas,
+		},
+	}
+	if m.version != nil {
+		obj.Spec.Template.Spec.Version = m.version
+	}
+	if m.bootstrap != nil {
+		obj.Spec.Template.Spec.Bootstrap.ConfigRef = objToRef(m.bootstrap)
+	}
+	if m.infrastructure != nil {
+		obj.Spec.Template.Spec.InfrastructureRef = *objToRef(m.infrastructure)
+	}
+	return obj
+}
+
 // MachineSetBuilder holds the variables and objects needed to build a generic MachineSet.
 type MachineSetBuilder struct {
 	namespace              string
@@ -794,6 +1035,204 @@ func (m *MachineSetBuilder) Build() *clusterv1.MachineSet {
 	return obj
 }
 
+// MachineBuilder holds the variables and objects needed to build a generic Machine.
+type MachineBuilder struct {
+	namespace       string
+	name            string
+	clusterName     string
+	bootstrapConfig *unstructured.Unstructured
+	infrastructure  *unstructured.Unstructured
+	version         *string
+	providerID      *string
+	labels          map[string]string
+}
+
+// Machine creates a MachineBuilder with the given name and namespace.
+func Machine(namespace, name string) *MachineBuilder {
+	return &MachineBuilder{
+		name:      name,
+		namespace: namespace,
+	}
+}
+
+// WithClusterName sets the passed value as the name of the Cluster the Machine belongs to.
+func (m *MachineBuilder) WithClusterName(clusterName string) *MachineBuilder {
+	m.clusterName = clusterName
+	return m
+}
+
+// WithBootstrapConfig adds the passed Unstructured object to the MachineBuilder as a bootstrap config.
+func (m *MachineBuilder) WithBootstrapConfig(ref *unstructured.Unstructured) *MachineBuilder {
+	m.bootstrapConfig = ref
+	return m
+}
+
+// WithInfrastructureMachine adds the passed Unstructured object to the MachineBuilder as an infrastructure machine.
+func (m *MachineBuilder) WithInfrastructureMachine(ref *unstructured.Unstructured) *MachineBuilder {
+	m.infrastructure = ref
+	return m
+}
+
+// WithVersion sets the passed version on the machine spec.
+func (m *MachineBuilder) WithVersion(version string) *MachineBuilder {
+	m.version = &version
+	return m
+}
+
+// WithProviderID sets the passed providerID on the machine spec.
+func (m *MachineBuilder) WithProviderID(providerID string) *MachineBuilder {
+	m.providerID = &providerID
+	return m
+}
+
+// WithLabels adds the given labels to the MachineBuilder.
+func (m *MachineBuilder) WithLabels(labels map[string]string) *MachineBuilder {
+	m.labels = labels
+	return m
+}
+
+// Build creates a new Machine with the variables and objects passed to the MachineBuilder.
+func (m *MachineBuilder) Build() *clusterv1.Machine {
+	obj := &clusterv1.Machine{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Machine",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      m.name,
+			Namespace: m.namespace,
+			Labels:    m.labels,
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: m.clusterName,
+			Version:     m.version,
+			ProviderID:  m.providerID,
+		},
+	}
+	if m.clusterName != "" {
+		if obj.Labels == nil {
+			obj.Labels = map[string]string{}
+		}
+		obj.Labels[clusterv1.ClusterLabelName] = m.clusterName
+	}
+	if m.bootstrapConfig != nil {
+		obj.Spec.Bootstrap.ConfigRef = objToRef(m.bootstrapConfig)
+	}
+	if m.infrastructure != nil {
+		obj.Spec.InfrastructureRef = *objToRef(m.infrastructure)
+	}
+	return obj
+}
+
+// InfrastructureMachineBuilder holds the variables and objects needed to build a generic InfrastructureMachine.
+type InfrastructureMachineBuilder struct {
+	namespace  string
+	name       string
+	specFields map[string]interface{}
+}
+
+// InfrastructureMachine creates an InfrastructureMachineBuilder with the given name and namespace.
+func InfrastructureMachine(namespace, name string) *InfrastructureMachineBuilder {
+	return &InfrastructureMachineBuilder{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// WithSpecFields sets a map of spec fields on the unstructured object. The keys in the map represent the path and the value corresponds
+// to the value of the spec field.
+//
+// Note: all the paths should start with "spec."
+//
+//	Example map: map[string]interface{}{
+//	    "spec.providerID": "test://id-1",
+//	}.
+func (i *InfrastructureMachineBuilder) WithSpecFields(fields map[string]interface{}) *InfrastructureMachineBuilder {
+	i.specFields = fields
+	return i
+}
+
+// Build takes the objects and variables in the InfrastructureMachineBuilder and generates an unstructured object.
+func (i *InfrastructureMachineBuilder) Build() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(InfrastructureGroupVersion.String())
+	obj.SetKind(GenericInfrastructureMachineKind)
+	obj.SetNamespace(i.namespace)
+	obj.SetName(i.name)
+
+	setSpecFields(obj, i.specFields)
+	return obj
+}
+
+// InfrastructureMachinePoolBuilder holds the variables and objects needed to build a generic InfrastructureMachinePool.
+type InfrastructureMachinePoolBuilder struct {
+	namespace  string
+	name       string
+	specFields map[string]interface{}
+}
+
+// InfrastructureMachinePool creates an InfrastructureMachinePoolBuilder with the given name and namespace.
+func InfrastructureMachinePool(namespace, name string) *InfrastructureMachinePoolBuilder {
+	return &InfrastructureMachinePoolBuilder{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// WithSpecFields sets a map of spec fields on the unstructured object. The keys in the map represent the path and the value corresponds
+// to the value of the spec field.
+//
+// Note: all the paths should start with "spec.".
+func (i *InfrastructureMachinePoolBuilder) WithSpecFields(fields map[string]interface{}) *InfrastructureMachinePoolBuilder {
+	i.specFields = fields
+	return i
+}
+
+// Build takes the objects and variables in the InfrastructureMachinePoolBuilder and generates an unstructured object.
+func (i *InfrastructureMachinePoolBuilder) Build() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(InfrastructureGroupVersion.String())
+	obj.SetKind(GenericInfrastructureMachinePoolKind)
+	obj.SetNamespace(i.namespace)
+	obj.SetName(i.name)
+
+	setSpecFields(obj, i.specFields)
+	return obj
+}
+
+// BootstrapConfigBuilder holds the variables needed to build a generic BootstrapConfig.
+type BootstrapConfigBuilder struct {
+	namespace  string
+	name       string
+	specFields map[string]interface{}
+}
+
+// BootstrapConfig creates a BootstrapConfigBuilder with the given name and namespace.
+func BootstrapConfig(namespace, name string) *BootstrapConfigBuilder {
+	return &BootstrapConfigBuilder{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// WithSpecFields will add fields of any type to the object spec. It takes an argument, fields, which is of the form path: object.
+func (b *BootstrapConfigBuilder) WithSpecFields(fields map[string]interface{}) *BootstrapConfigBuilder {
+	b.specFields = fields
+	return b
+}
+
+// Build creates a new Unstructured object with the information passed to the BootstrapConfigBuilder.
+func (b *BootstrapConfigBuilder) Build() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(BootstrapGroupVersion.String())
+	obj.SetKind(GenericBootstrapConfigKind)
+	obj.SetNamespace(b.namespace)
+	obj.SetName(b.name)
+
+	setSpecFields(obj, b.specFields)
+	return obj
+}
+
 // objToRef returns a reference to the given object.
 func objToRef(obj client.Object) *corev1.ObjectReference {
 	gvk := obj.GetObjectKind().GroupVersionKind()
@@ -0,0 +1,22 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package builder implements builder and CRDs for creating API objects for testing.
+//
+// The builders are meant to be used by Cluster API and by provider authors for writing webhook and controller tests,
+// e.g. by creating a Cluster with a generic InfrastructureCluster and a KubeadmControlPlane using the fake client
+// or envtest, without copying fixtures across test files.
+package builder
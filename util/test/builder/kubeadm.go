@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+// KubeadmControlPlaneBuilder holds the variables and objects needed to build a KubeadmControlPlane.
+type KubeadmControlPlaneBuilder struct {
+	namespace                     string
+	name                          string
+	infrastructureMachineTemplate *unstructured.Unstructured
+	replicas                      *int32
+	version                       string
+	kubeadmConfigSpec             *bootstrapv1.KubeadmConfigSpec
+}
+
+// KubeadmControlPlane creates a KubeadmControlPlaneBuilder with the given name and namespace.
+func KubeadmControlPlane(namespace, name string) *KubeadmControlPlaneBuilder {
+	return &KubeadmControlPlaneBuilder{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// WithInfrastructureMachineTemplate adds the passed InfrastructureMachineTemplate to the KubeadmControlPlaneBuilder.
+func (k *KubeadmControlPlaneBuilder) WithInfrastructureMachineTemplate(t *unstructured.Unstructured) *KubeadmControlPlaneBuilder {
+	k.infrastructureMachineTemplate = t
+	return k
+}
+
+// WithReplicas sets the number of replicas for the KubeadmControlPlaneBuilder.
+func (k *KubeadmControlPlaneBuilder) WithReplicas(replicas int32) *KubeadmControlPlaneBuilder {
+	k.replicas = &replicas
+	return k
+}
+
+// WithVersion sets the passed version on the KubeadmControlPlane spec.
+func (k *KubeadmControlPlaneBuilder) WithVersion(version string) *KubeadmControlPlaneBuilder {
+	k.version = version
+	return k
+}
+
+// WithKubeadmConfigSpec sets the passed KubeadmConfigSpec on the KubeadmControlPlane spec.
+func (k *KubeadmControlPlaneBuilder) WithKubeadmConfigSpec(spec bootstrapv1.KubeadmConfigSpec) *KubeadmControlPlaneBuilder {
+	k.kubeadmConfigSpec = &spec
+	return k
+}
+
+// Build creates a new KubeadmControlPlane with the variables and objects passed to the KubeadmControlPlaneBuilder.
+func (k *KubeadmControlPlaneBuilder) Build() *controlplanev1.KubeadmControlPlane {
+	obj := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k.name,
+			Namespace: k.namespace,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Replicas: k.replicas,
+			Version:  k.version,
+		},
+	}
+	if k.infrastructureMachineTemplate != nil {
+		obj.Spec.MachineTemplate.InfrastructureRef = *objToRef(k.infrastructureMachineTemplate)
+	}
+	if k.kubeadmConfigSpec != nil {
+		obj.Spec.KubeadmConfigSpec = *k.kubeadmConfigSpec
+	}
+	return obj
+}
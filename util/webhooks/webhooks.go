@@ -14,6 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package webhooks implements helpers for testing webhooks.
 package webhooks
 
 import (
@@ -22,20 +23,20 @@ import (
 
 	"github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/runtime"
-	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
-// customDefaulterValidator interface is for objects that define both custom defaulting
+// CustomDefaulterValidator interface is for webhooks that define both custom defaulting
 // and custom validating webhooks.
-type customDefaulterValidator interface {
-	webhook.CustomDefaulter
-	webhook.CustomValidator
+type CustomDefaulterValidator interface {
+	admission.CustomDefaulter
+	admission.CustomValidator
 }
 
-// customDefaultValidateTest returns a new testing function to be used in tests to
+// CustomDefaultValidateTest returns a new testing function to be used in tests to
 // make sure custom defaulting webhooks also pass validation tests on create,
 // update and delete.
-func customDefaultValidateTest(ctx context.Context, obj runtime.Object, webhook customDefaulterValidator) func(*testing.T) {
+func CustomDefaultValidateTest(ctx context.Context, obj runtime.Object, webhook CustomDefaulterValidator) func(*testing.T) {
 	return func(t *testing.T) {
 		t.Helper()
 
@@ -53,7 +54,7 @@ func customDefaultValidateTest(ctx context.Context, obj runtime.Object, webhook
 			g := gomega.NewWithT(t)
 			g.Expect(webhook.Default(ctx, defaultingUpdateCopy)).To(gomega.Succeed())
 			g.Expect(webhook.Default(ctx, updateCopy)).To(gomega.Succeed())
-			g.Expect(webhook.ValidateUpdate(ctx, createCopy, defaultingUpdateCopy)).To(gomega.Succeed())
+			g.Expect(webhook.ValidateUpdate(ctx, updateCopy, defaultingUpdateCopy)).To(gomega.Succeed())
 		})
 		t.Run("validate-on-delete", func(t *testing.T) {
 			g := gomega.NewWithT(t)
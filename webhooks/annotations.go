@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// wellKnownAnnotationsWebhookPath is the path of the webhook validating the well-known annotations of Cluster API objects.
+const wellKnownAnnotationsWebhookPath = "/validate-cluster-x-k8s-io-v1beta1-annotations"
+
+// SetupWebhookWithManager sets up the WellKnownAnnotations webhook.
+func (webhook *WellKnownAnnotations) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(wellKnownAnnotationsWebhookPath, &admission.Webhook{Handler: webhook})
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cluster-x-k8s-io-v1beta1-annotations,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusters;machines;machinesets;machinedeployments;machinehealthchecks;machinepools,versions=v1beta1,name=validation-annotations.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// WellKnownAnnotations implements a validating webhook rejecting Cluster API objects with invalid values of the
+// well-known annotations, and warning about deprecated spellings of these annotations.
+// Only annotations added or changed by the request are validated, so existing objects can still be updated.
+type WellKnownAnnotations struct{}
+
+var _ admission.Handler = &WellKnownAnnotations{}
+
+// Handle implements admission.Handler.
+func (webhook *WellKnownAnnotations) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode object"))
+	}
+
+	var oldAnnotations map[string]string
+	if req.Operation == admissionv1.Update {
+		oldObj := &unstructured.Unstructured{}
+		if err := oldObj.UnmarshalJSON(req.OldObject.Raw); err != nil {
+			return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode old object"))
+		}
+		oldAnnotations = oldObj.GetAnnotations()
+	}
+
+	changed := map[string]string{}
+	for k, v := range obj.GetAnnotations() {
+		if old, ok := oldAnnotations[k]; !ok || old != v {
+			changed[k] = v
+		}
+	}
+	if len(changed) == 0 {
+		return admission.Allowed("")
+	}
+
+	warnings := annotations.WellKnownAnnotationsWarnings(changed)
+	if errs := annotations.ValidateWellKnownAnnotations(changed, field.NewPath("metadata", "annotations")); len(errs) > 0 {
+		status := apierrors.NewInvalid(obj.GroupVersionKind().GroupKind(), obj.GetName(), errs).Status()
+		return admission.Response{AdmissionResponse: admissionv1.AdmissionResponse{Allowed: false, Result: &status}}.WithWarnings(warnings...)
+	}
+	return admission.Allowed("").WithWarnings(warnings...)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestWellKnownAnnotationsValidation(t *testing.T) {
+	newMachine := func(annotations map[string]string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "machine", Annotations: annotations},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		operation    admissionv1.Operation
+		oldObj       runtime.Object
+		obj          runtime.Object
+		wantAllowed  bool
+		wantWarnings int
+	}{
+		{
+			name:        "allows objects without annotations",
+			operation:   admissionv1.Create,
+			obj:         newMachine(nil),
+			wantAllowed: true,
+		},
+		{
+			name:        "allows valid annotations",
+			operation:   admissionv1.Create,
+			obj:         newMachine(map[string]string{clusterv1.AdoptNodeAnnotation: "node-1"}),
+			wantAllowed: true,
+		},
+		{
+			name:        "rejects invalid annotations",
+			operation:   admissionv1.Create,
+			obj:         newMachine(map[string]string{clusterv1.AdoptNodeAnnotation: "Node_1"}),
+			wantAllowed: false,
+		},
+		{
+			name:        "allows updates not changing invalid annotations",
+			operation:   admissionv1.Update,
+			oldObj:      newMachine(map[string]string{clusterv1.AdoptNodeAnnotation: "Node_1"}),
+			obj:         newMachine(map[string]string{clusterv1.AdoptNodeAnnotation: "Node_1", "foo": "bar"}),
+			wantAllowed: true,
+		},
+		{
+			name:        "rejects updates changing annotations to invalid values",
+			operation:   admissionv1.Update,
+			oldObj:      newMachine(map[string]string{clusterv1.AdoptNodeAnnotation: "node-1"}),
+			obj:         newMachine(map[string]string{clusterv1.AdoptNodeAnnotation: "Node_1"}),
+			wantAllowed: false,
+		},
+		{
+			name:         "warns about deprecated annotations",
+			operation:    admissionv1.Create,
+			obj:          newMachine(map[string]string{"cluster.k8s.io/delete-machine": ""}),
+			wantAllowed:  true,
+			wantWarnings: 1,
+		},
+		{
+			name:         "warns about annotations set to false",
+			operation:    admissionv1.Update,
+			oldObj:       newMachine(nil),
+			obj:          newMachine(map[string]string{clusterv1.PausedAnnotation: "false"}),
+			wantAllowed:  true,
+			wantWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: tt.operation,
+				Object:    runtime.RawExtension{Raw: mustMarshal(g, tt.obj)},
+			}}
+			if tt.oldObj != nil {
+				req.OldObject = runtime.RawExtension{Raw: mustMarshal(g, tt.oldObj)}
+			}
+
+			resp := (&WellKnownAnnotations{}).Handle(context.Background(), req)
+			g.Expect(resp.Allowed).To(Equal(tt.wantAllowed))
+			g.Expect(resp.Warnings).To(HaveLen(tt.wantWarnings))
+		})
+	}
+}
@@ -19,15 +19,19 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"net"
+	"reflect"
 	"strings"
 
 	"github.com/blang/semver"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/topology/inheritance"
 	"sigs.k8s.io/cluster-api/util/version"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -55,7 +59,7 @@ var _ webhook.CustomDefaulter = &Cluster{}
 var _ webhook.CustomValidator = &Cluster{}
 
 // Default satisfies the defaulting webhook interface.
-func (webhook *Cluster) Default(_ context.Context, obj runtime.Object) error {
+func (webhook *Cluster) Default(ctx context.Context, obj runtime.Object) error {
 	cluster, ok := obj.(*clusterv1.Cluster)
 	if !ok {
 		return apierrors.NewBadRequest(fmt.Sprintf("expected a Cluster but got a %T", obj))
@@ -75,6 +79,17 @@ func (webhook *Cluster) Default(_ context.Context, obj runtime.Object) error {
 		if !strings.HasPrefix(cluster.Spec.Topology.Version, "v") {
 			cluster.Spec.Topology.Version = "v" + cluster.Spec.Topology.Version
 		}
+
+		// Set the variables not set in the topology to their default value, if any; errors getting the ClusterClass
+		// are ignored, because they are reported by the validation webhook.
+		if webhook.Client != nil && feature.Gates.Enabled(feature.ClusterTopology) && feature.Gates.Enabled(feature.ClusterClassVariablesDiscovery) {
+			clusterClass := &clusterv1.ClusterClass{}
+			if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.Topology.Class}, clusterClass); err == nil {
+				if resolvedClusterClass, err := inheritance.Resolve(ctx, webhook.Client, clusterClass); err == nil {
+					defaultTopologyVariables(cluster.Spec.Topology, variableDefinitions(resolvedClusterClass))
+				}
+			}
+		}
 	}
 	return nil
 }
@@ -130,6 +145,12 @@ func (webhook *Cluster) validate(ctx context.Context, old, new *clusterv1.Cluste
 		)
 	}
 
+	// Validate the cluster network, if defined.
+	// NOTE: On update, the cluster network is validated only if changed, so existing Clusters are not blocked.
+	if new.Spec.ClusterNetwork != nil && (old == nil || !reflect.DeepEqual(old.Spec.ClusterNetwork, new.Spec.ClusterNetwork)) {
+		allErrs = append(allErrs, validateClusterNetwork(new.Spec.ClusterNetwork, field.NewPath("spec", "clusterNetwork"))...)
+	}
+
 	// Validate the managed topology, if defined.
 	if new.Spec.Topology != nil {
 		if topologyErrs := webhook.validateTopology(ctx, old, new); len(topologyErrs) > 0 {
@@ -144,6 +165,94 @@ func (webhook *Cluster) validate(ctx context.Context, old, new *clusterv1.Cluste
 	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Cluster").GroupKind(), new.Name, allErrs)
 }
 
+// validateClusterNetwork validates the pod and service CIDR blocks and the service domain of a Cluster, so
+// invalid values are rejected instead of being passed to the bootstrap and control plane providers.
+func validateClusterNetwork(network *clusterv1.ClusterNetwork, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	var podCIDRs, serviceCIDRs []*net.IPNet
+	if network.Pods != nil {
+		var errs field.ErrorList
+		podCIDRs, errs = validateCIDRBlocks(network.Pods.CIDRBlocks, fldPath.Child("pods", "cidrBlocks"))
+		allErrs = append(allErrs, errs...)
+	}
+	if network.Services != nil {
+		var errs field.ErrorList
+		serviceCIDRs, errs = validateCIDRBlocks(network.Services.CIDRBlocks, fldPath.Child("services", "cidrBlocks"))
+		allErrs = append(allErrs, errs...)
+	}
+
+	// Pods and services must use the same primary IP family.
+	if len(podCIDRs) > 0 && len(serviceCIDRs) > 0 && isIPv4CIDR(podCIDRs[0]) != isIPv4CIDR(serviceCIDRs[0]) {
+		allErrs = append(allErrs,
+			field.Invalid(
+				fldPath.Child("services", "cidrBlocks").Index(0),
+				network.Services.CIDRBlocks[0],
+				fmt.Sprintf("must have the same IP family as the first pod CIDR block %q", network.Pods.CIDRBlocks[0]),
+			),
+		)
+	}
+
+	// Pod and service CIDR blocks must not overlap.
+	for i, serviceCIDR := range serviceCIDRs {
+		for j, podCIDR := range podCIDRs {
+			if serviceCIDR.Contains(podCIDR.IP) || podCIDR.Contains(serviceCIDR.IP) {
+				allErrs = append(allErrs,
+					field.Invalid(
+						fldPath.Child("services", "cidrBlocks").Index(i),
+						network.Services.CIDRBlocks[i],
+						fmt.Sprintf("must not overlap with pod CIDR block %q", network.Pods.CIDRBlocks[j]),
+					),
+				)
+			}
+		}
+	}
+
+	if network.ServiceDomain != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(network.ServiceDomain) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceDomain"), network.ServiceDomain, msg))
+		}
+	}
+
+	return allErrs
+}
+
+// validateCIDRBlocks parses a list of CIDR blocks, which must be either a single CIDR block or, for dual-stack
+// networking, one IPv4 and one IPv6 CIDR block.
+// The parsed CIDR blocks are returned only if all of them are valid.
+func validateCIDRBlocks(blocks []string, fldPath *field.Path) ([]*net.IPNet, field.ErrorList) {
+	var allErrs field.ErrorList
+
+	if len(blocks) > 2 {
+		return nil, field.ErrorList{field.TooMany(fldPath, len(blocks), 2)}
+	}
+
+	cidrs := make([]*net.IPNet, 0, len(blocks))
+	for i, block := range blocks {
+		_, cidr, err := net.ParseCIDR(block)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), block, "must be a valid CIDR block"))
+			continue
+		}
+		cidrs = append(cidrs, cidr)
+	}
+	if len(allErrs) > 0 {
+		return nil, allErrs
+	}
+
+	if len(cidrs) == 2 && isIPv4CIDR(cidrs[0]) == isIPv4CIDR(cidrs[1]) {
+		return nil, field.ErrorList{
+			field.Invalid(fldPath, blocks, "dual-stack CIDR blocks must include one IPv4 and one IPv6 CIDR block"),
+		}
+	}
+	return cidrs, nil
+}
+
+// isIPv4CIDR returns true if the CIDR block is an IPv4 CIDR block.
+func isIPv4CIDR(cidr *net.IPNet) bool {
+	return cidr.IP.To4() != nil
+}
+
 func (webhook *Cluster) validateTopology(ctx context.Context, old, new *clusterv1.Cluster) field.ErrorList {
 	// NOTE: ClusterClass and managed topologies are behind ClusterTopology feature gate flag; the web hook
 	// must prevent the usage of Cluster.Topology in case the feature flag is disabled.
@@ -197,6 +306,32 @@ func (webhook *Cluster) validateTopology(ctx context.Context, old, new *clusterv
 			}
 			names.Insert(md.Name)
 		}
+
+		// NOTE: MachinePools are behind MachinePool feature gate flag; the web hook
+		// must prevent the usage of MachinePool topologies in case the feature flag is disabled.
+		if len(new.Spec.Topology.Workers.MachinePools) > 0 && !feature.Gates.Enabled(feature.MachinePool) {
+			allErrs = append(allErrs,
+				field.Forbidden(
+					field.NewPath("spec", "topology", "workers", "machinePools"),
+					"can be set only if the MachinePool feature flag is enabled",
+				),
+			)
+		}
+
+		// MachinePool names must be unique.
+		poolNames := sets.String{}
+		for _, mp := range new.Spec.Topology.Workers.MachinePools {
+			if poolNames.Has(mp.Name) {
+				allErrs = append(allErrs,
+					field.Invalid(
+						field.NewPath("spec", "topology", "workers", "machinePools"),
+						mp,
+						fmt.Sprintf("MachinePool names should be unique. MachinePool with name %q is defined more than once.", mp.Name),
+					),
+				)
+			}
+			poolNames.Insert(mp.Name)
+		}
 	}
 
 	if old != nil { // On update
@@ -248,12 +383,231 @@ func (webhook *Cluster) validateTopology(ctx context.Context, old, new *clusterv
 		}
 	}
 	// Check to see if the ClusterClass referenced in the Cluster currently exists.
-	if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: new.Namespace, Name: new.Spec.Topology.Class}, &clusterv1.ClusterClass{}); err != nil {
+	clusterClass := &clusterv1.ClusterClass{}
+	if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: new.Namespace, Name: new.Spec.Topology.Class}, clusterClass); err != nil {
 		allErrs = append(
 			allErrs, field.Invalid(
 				field.NewPath("spec", "topology", "class"),
 				new.Name,
 				"ClusterClass could not be found"))
+		return allErrs
+	}
+
+	// Compose the ClusterClass with the ClusterClasses it inherits from, if any.
+	resolvedClusterClass, err := inheritance.Resolve(ctx, webhook.Client, clusterClass)
+	if err != nil {
+		allErrs = append(
+			allErrs, field.Invalid(
+				field.NewPath("spec", "topology", "class"),
+				new.Spec.Topology.Class,
+				fmt.Sprintf("ClusterClass could not be composed with the ClusterClasses it inherits from: %v", err)))
+		return allErrs
+	}
+
+	// MachineHealthCheck overrides should be valid.
+	allErrs = append(allErrs, validateMachineHealthChecks(new, resolvedClusterClass)...)
+
+	// MachineDeployment topologies should satisfy the constraints of their MachineDeployment classes.
+	allErrs = append(allErrs, validateMachineDeploymentConstraints(old, new, resolvedClusterClass)...)
+
+	// MachinePool topologies should reference MachinePool classes defined in the ClusterClass.
+	allErrs = append(allErrs, validateMachinePoolClasses(new, resolvedClusterClass)...)
+
+	// Variables should be set if required, and match the schema defined in the ClusterClass or discovered via the
+	// DiscoverVariables hook; they are validated only if changed, so existing Clusters can still be updated when
+	// variables are added to the ClusterClass or discovered.
+	if old == nil || !reflect.DeepEqual(old.Spec.Topology.Variables, new.Spec.Topology.Variables) || old.Spec.Topology.Class != new.Spec.Topology.Class {
+		allErrs = append(allErrs, validateTopologyVariables(new.Spec.Topology, variableDefinitions(resolvedClusterClass), field.NewPath("spec", "topology", "variables"))...)
+	}
+
+	return allErrs
+}
+
+// validateMachineDeploymentConstraints validates the replicas of the MachineDeployment topologies and the version of
+// the Cluster topology against the constraints defined in the corresponding MachineDeployment classes.
+// On update, only the replicas and the version which changed, or the ones of the MachineDeployment topologies which
+// were added or whose class changed, are validated, so existing Clusters are not blocked by changes to the constraints.
+func validateMachineDeploymentConstraints(old, cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+	if cluster.Spec.Topology.Workers == nil {
+		return allErrs
+	}
+
+	oldMDs := map[string]clusterv1.MachineDeploymentTopology{}
+	if old != nil && old.Spec.Topology.Workers != nil {
+		for _, md := range old.Spec.Topology.Workers.MachineDeployments {
+			oldMDs[md.Name] = md
+		}
+	}
+
+	constraints := map[string]*clusterv1.MachineDeploymentClassConstraints{}
+	for _, mdClass := range clusterClass.Spec.Workers.MachineDeployments {
+		if mdClass.Constraints != nil {
+			constraints[mdClass.Class] = mdClass.Constraints
+		}
+	}
+
+	// NOTE: an invalid version is already reported when validating the Cluster topology.
+	clusterVersion, versionErr := semver.ParseTolerant(cluster.Spec.Topology.Version)
+
+	for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+		c, ok := constraints[md.Class]
+		if !ok {
+			continue
+		}
+		fldPath := field.NewPath("spec", "topology", "workers", "machineDeployments").Index(i)
+
+		oldMD, existed := oldMDs[md.Name]
+		changedClass := old == nil || !existed || oldMD.Class != md.Class
+		changedReplicas := changedClass || !reflect.DeepEqual(oldMD.Replicas, md.Replicas)
+		changedVersion := changedClass || old.Spec.Topology.Version != cluster.Spec.Topology.Version
+
+		if md.Replicas != nil && changedReplicas {
+			if c.MinReplicas != nil && *md.Replicas < *c.MinReplicas {
+				allErrs = append(allErrs,
+					field.Invalid(
+						fldPath.Child("replicas"),
+						*md.Replicas,
+						fmt.Sprintf("must be greater than or equal to %d, the minimum defined by MachineDeployment class %q", *c.MinReplicas, md.Class),
+					),
+				)
+			}
+			if c.MaxReplicas != nil && *md.Replicas > *c.MaxReplicas {
+				allErrs = append(allErrs,
+					field.Invalid(
+						fldPath.Child("replicas"),
+						*md.Replicas,
+						fmt.Sprintf("must be less than or equal to %d, the maximum defined by MachineDeployment class %q", *c.MaxReplicas, md.Class),
+					),
+				)
+			}
+		}
+
+		if versionErr != nil || !changedVersion {
+			continue
+		}
+		if c.MinVersion != nil {
+			if minVersion, err := semver.ParseTolerant(*c.MinVersion); err == nil && version.Compare(clusterVersion, minVersion) < 0 {
+				allErrs = append(allErrs,
+					field.Invalid(
+						field.NewPath("spec", "topology", "version"),
+						cluster.Spec.Topology.Version,
+						fmt.Sprintf("must be greater than or equal to %s, the minimum defined by MachineDeployment class %q used by %s", *c.MinVersion, md.Class, fldPath),
+					),
+				)
+			}
+		}
+		if c.MaxVersion != nil {
+			if maxVersion, err := semver.ParseTolerant(*c.MaxVersion); err == nil && version.Compare(clusterVersion, maxVersion) > 0 {
+				allErrs = append(allErrs,
+					field.Invalid(
+						field.NewPath("spec", "topology", "version"),
+						cluster.Spec.Topology.Version,
+						fmt.Sprintf("must be less than or equal to %s, the maximum defined by MachineDeployment class %q used by %s", *c.MaxVersion, md.Class, fldPath),
+					),
+				)
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateMachinePoolClasses validates that the MachinePool topologies defined in the Cluster topology reference
+// MachinePool classes defined in the ClusterClass.
+func validateMachinePoolClasses(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+	if cluster.Spec.Topology.Workers == nil {
+		return allErrs
+	}
+
+	classes := sets.NewString()
+	for _, mpClass := range clusterClass.Spec.Workers.MachinePools {
+		classes.Insert(mpClass.Class)
+	}
+	for i, mp := range cluster.Spec.Topology.Workers.MachinePools {
+		if !classes.Has(mp.Class) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "topology", "workers", "machinePools").Index(i).Child("class"),
+					mp.Class,
+					fmt.Sprintf("MachinePool class %q is not defined in ClusterClass %q", mp.Class, clusterClass.Name),
+				),
+			)
+		}
 	}
 	return allErrs
 }
+
+// validateMachineHealthChecks validates the MachineHealthCheck overrides defined in the Cluster topology.
+func validateMachineHealthChecks(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cluster.Spec.Topology.ControlPlane.MachineHealthCheck != nil {
+		fldPath := field.NewPath("spec", "topology", "controlPlane", "machineHealthCheck")
+		allErrs = append(allErrs, validateMachineHealthCheckTopology(cluster.Spec.Topology.ControlPlane.MachineHealthCheck, clusterClass.Spec.ControlPlane.MachineHealthCheck, cluster.Namespace, fldPath)...)
+
+		// A MachineHealthCheck can be enabled only if the control plane machines are managed by the topology.
+		if clusterClass.Spec.ControlPlane.MachineInfrastructure == nil && isMachineHealthCheckEnabled(cluster.Spec.Topology.ControlPlane.MachineHealthCheck) {
+			allErrs = append(allErrs, field.Forbidden(
+				fldPath,
+				"can be set only if spec.controlPlane.machineInfrastructure is set in the ClusterClass",
+			))
+		}
+	}
+
+	if cluster.Spec.Topology.Workers != nil {
+		for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+			if md.MachineHealthCheck == nil {
+				continue
+			}
+			var classMHC *clusterv1.MachineHealthCheckClass
+			for _, mdClass := range clusterClass.Spec.Workers.MachineDeployments {
+				if mdClass.Class == md.Class {
+					classMHC = mdClass.MachineHealthCheck
+					break
+				}
+			}
+			fldPath := field.NewPath("spec", "topology", "workers", "machineDeployments").Index(i).Child("machineHealthCheck")
+			allErrs = append(allErrs, validateMachineHealthCheckTopology(md.MachineHealthCheck, classMHC, cluster.Namespace, fldPath)...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateMachineHealthCheckTopology validates a MachineHealthCheck override in the Cluster topology against
+// the corresponding MachineHealthCheck definition in the ClusterClass, if any.
+func validateMachineHealthCheckTopology(mhc *clusterv1.MachineHealthCheckTopology, classMHC *clusterv1.MachineHealthCheckClass, namespace string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Overrides are allowed only if the ClusterClass defines a MachineHealthCheck or if the
+	// MachineHealthCheck is explicitly enabled in the topology.
+	if classMHC == nil && (mhc.Enable == nil || !*mhc.Enable) {
+		allErrs = append(allErrs, field.Forbidden(
+			fldPath,
+			"can be set only if a MachineHealthCheck is defined in the ClusterClass or if enable is true",
+		))
+		return allErrs
+	}
+
+	// When the MachineHealthCheck is force enabled without a definition in the ClusterClass, the topology
+	// has to provide one.
+	if classMHC == nil && mhc.MachineHealthCheckClass.IsZero() {
+		allErrs = append(allErrs, field.Forbidden(
+			fldPath,
+			"must define a MachineHealthCheck if enable is true and no MachineHealthCheck is defined in the ClusterClass",
+		))
+		return allErrs
+	}
+
+	if !mhc.MachineHealthCheckClass.IsZero() {
+		allErrs = append(allErrs, validateMachineHealthCheckClass(&mhc.MachineHealthCheckClass, namespace, fldPath)...)
+	}
+
+	return allErrs
+}
+
+// isMachineHealthCheckEnabled returns true if a MachineHealthCheck topology is not explicitly disabled.
+func isMachineHealthCheckEnabled(mhc *clusterv1.MachineHealthCheckTopology) bool {
+	return mhc.Enable == nil || *mhc.Enable
+}
@@ -18,14 +18,18 @@ package webhooks
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
-	"sigs.k8s.io/cluster-api/internal/builder"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+	utilwebhooks "sigs.k8s.io/cluster-api/util/webhooks"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -43,7 +47,7 @@ func TestClusterDefaultNamespaces(t *testing.T) {
 		},
 	}
 	webhook := &Cluster{}
-	t.Run("for Cluster", customDefaultValidateTest(ctx, c, webhook))
+	t.Run("for Cluster", utilwebhooks.CustomDefaultValidateTest(ctx, c, webhook))
 	g.Expect(webhook.Default(ctx, c)).To(Succeed())
 
 	g.Expect(c.Spec.InfrastructureRef.Namespace).To(Equal(c.Namespace))
@@ -78,7 +82,7 @@ func TestClusterDefaultTopologyVersion(t *testing.T) {
 	// Create the webhook and add the fakeClient as its client.
 	webhook := &Cluster{Client: fakeClient}
 
-	t.Run("for Cluster", customDefaultValidateTest(ctx, c, webhook))
+	t.Run("for Cluster", utilwebhooks.CustomDefaultValidateTest(ctx, c, webhook))
 	g.Expect(webhook.Default(ctx, c)).To(Succeed())
 
 	g.Expect(c.Spec.Topology.Version).To(HavePrefix("v"))
@@ -181,6 +185,129 @@ func TestClusterValidation(t *testing.T) {
 	}
 }
 
+func TestClusterNetworkValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		in        *clusterv1.ClusterNetwork
+		old       *clusterv1.ClusterNetwork
+		expectErr bool
+	}{
+		{
+			name:      "should succeed with valid IPv4 CIDR blocks and service domain",
+			expectErr: false,
+			in: &clusterv1.ClusterNetwork{
+				Pods:          &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+				Services:      &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/12"}},
+				ServiceDomain: "cluster.local",
+			},
+		},
+		{
+			name:      "should succeed with valid dual-stack CIDR blocks",
+			expectErr: false,
+			in: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"fd00:100:96::/48", "192.168.0.0/16"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"fd00:100:64::/108", "10.128.0.0/12"}},
+			},
+		},
+		{
+			name:      "should return error when given an invalid CIDR block",
+			expectErr: true,
+			in: &clusterv1.ClusterNetwork{
+				Pods: &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0"}},
+			},
+		},
+		{
+			name:      "should return error when given more than two CIDR blocks",
+			expectErr: true,
+			in: &clusterv1.ClusterNetwork{
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/12", "fd00:100:64::/108", "10.96.0.0/16"}},
+			},
+		},
+		{
+			name:      "should return error when given two CIDR blocks of the same IP family",
+			expectErr: true,
+			in: &clusterv1.ClusterNetwork{
+				Pods: &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16", "172.16.0.0/16"}},
+			},
+		},
+		{
+			name:      "should return error when pods and services use different primary IP families",
+			expectErr: true,
+			in: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16", "fd00:100:96::/48"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"fd00:100:64::/108", "10.128.0.0/12"}},
+			},
+		},
+		{
+			name:      "should return error when pod and service CIDR blocks overlap",
+			expectErr: true,
+			in: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/12"}},
+			},
+		},
+		{
+			name:      "should return error when given an invalid service domain",
+			expectErr: true,
+			in: &clusterv1.ClusterNetwork{
+				ServiceDomain: "Cluster_Local",
+			},
+		},
+		{
+			name:      "should succeed on update when an invalid cluster network is not changed",
+			expectErr: false,
+			in: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/12"}},
+			},
+			old: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/12"}},
+			},
+		},
+		{
+			name:      "should return error on update when the cluster network is changed to an invalid value",
+			expectErr: true,
+			in: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.0.0.0/8"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/12"}},
+			},
+			old: &clusterv1.ClusterNetwork{
+				Pods:     &clusterv1.NetworkRanges{CIDRBlocks: []string{"192.168.0.0/16"}},
+				Services: &clusterv1.NetworkRanges{CIDRBlocks: []string{"10.128.0.0/12"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			in := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "foo"},
+				Spec:       clusterv1.ClusterSpec{ClusterNetwork: tt.in},
+			}
+			var old *clusterv1.Cluster
+			if tt.old != nil {
+				old = &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "foo"},
+					Spec:       clusterv1.ClusterSpec{ClusterNetwork: tt.old},
+				}
+			}
+
+			// Create the webhook.
+			webhook := &Cluster{}
+
+			err := webhook.validate(ctx, old, in)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestClusterTopologyValidation(t *testing.T) {
 	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to set Cluster.Topologies.
 	// Enabling the feature flag temporarily for this test.
@@ -593,3 +720,350 @@ func TestClusterTopologyValidationWithClient(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterTopologyValidationMachineHealthChecks(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	mhcClass := &clusterv1.MachineHealthCheckClass{
+		UnhealthyConditions: []clusterv1.UnhealthyCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+		},
+	}
+
+	classWithoutMHC := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithControlPlaneInfrastructureMachineTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cp-infra").Build()).
+		WithWorkerMachineDeploymentClasses([]clusterv1.MachineDeploymentClass{
+			*builder.MachineDeploymentClass("md-class").
+				WithInfrastructureTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "md-infra").Build()).
+				WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "md-bootstrap").Build()).
+				Build(),
+		}).
+		Build()
+
+	classWithMHC := classWithoutMHC.DeepCopy()
+	classWithMHC.Spec.ControlPlane.MachineHealthCheck = mhcClass.DeepCopy()
+	classWithMHC.Spec.Workers.MachineDeployments[0].MachineHealthCheck = mhcClass.DeepCopy()
+
+	tests := []struct {
+		name      string
+		class     *clusterv1.ClusterClass
+		cpMHC     *clusterv1.MachineHealthCheckTopology
+		mdMHC     *clusterv1.MachineHealthCheckTopology
+		expectErr bool
+	}{
+		{
+			name:  "Accept no overrides",
+			class: classWithoutMHC,
+		},
+		{
+			name:  "Accept disabling MachineHealthChecks defined in the ClusterClass",
+			class: classWithMHC,
+			cpMHC: &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(false)},
+			mdMHC: &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(false)},
+		},
+		{
+			name:  "Accept overriding MachineHealthChecks defined in the ClusterClass",
+			class: classWithMHC,
+			cpMHC: &clusterv1.MachineHealthCheckTopology{MachineHealthCheckClass: *mhcClass},
+			mdMHC: &clusterv1.MachineHealthCheckTopology{MachineHealthCheckClass: *mhcClass},
+		},
+		{
+			name:  "Accept force enabling MachineHealthChecks not defined in the ClusterClass",
+			class: classWithoutMHC,
+			cpMHC: &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(true), MachineHealthCheckClass: *mhcClass},
+			mdMHC: &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(true), MachineHealthCheckClass: *mhcClass},
+		},
+		{
+			name:      "Reject control plane overrides if the ClusterClass does not define a MachineHealthCheck",
+			class:     classWithoutMHC,
+			cpMHC:     &clusterv1.MachineHealthCheckTopology{MachineHealthCheckClass: *mhcClass},
+			expectErr: true,
+		},
+		{
+			name:      "Reject MachineDeployment overrides if the ClusterClass does not define a MachineHealthCheck",
+			class:     classWithoutMHC,
+			mdMHC:     &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(false)},
+			expectErr: true,
+		},
+		{
+			name:      "Reject force enabling without a MachineHealthCheck definition",
+			class:     classWithoutMHC,
+			mdMHC:     &clusterv1.MachineHealthCheckTopology{Enable: pointer.Bool(true)},
+			expectErr: true,
+		},
+		{
+			name:  "Reject invalid overrides",
+			class: classWithMHC,
+			cpMHC: &clusterv1.MachineHealthCheckTopology{
+				MachineHealthCheckClass: clusterv1.MachineHealthCheckClass{
+					NodeStartupTimeout: &metav1.Duration{Duration: 10 * time.Second},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := builder.MachineDeploymentTopology("workers1").WithClass("md-class").Build()
+			md.MachineHealthCheck = tt.mdMHC
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass(tt.class.Name).
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						WithMachineDeployment(md).
+						Build()).
+				Build()
+			cluster.Spec.Topology.ControlPlane.MachineHealthCheck = tt.cpMHC
+
+			fakeClient := fake.NewClientBuilder().
+				WithObjects(tt.class).
+				WithScheme(fakeScheme).
+				Build()
+			c := &Cluster{Client: fakeClient}
+
+			if tt.expectErr {
+				g.Expect(c.ValidateCreate(ctx, cluster)).NotTo(Succeed())
+			} else {
+				g.Expect(c.ValidateCreate(ctx, cluster)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterTopologyValidationMachineDeploymentConstraints(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	class := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithWorkerMachineDeploymentClasses([]clusterv1.MachineDeploymentClass{
+			*builder.MachineDeploymentClass("md-class").
+				WithInfrastructureTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "md-infra").Build()).
+				WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "md-bootstrap").Build()).
+				WithConstraints(&clusterv1.MachineDeploymentClassConstraints{
+					MinReplicas: pointer.Int32(1),
+					MaxReplicas: pointer.Int32(5),
+					MinVersion:  pointer.String("v1.21.0"),
+					MaxVersion:  pointer.String("v1.22.99"),
+				}).
+				Build(),
+			*builder.MachineDeploymentClass("unconstrained-class").
+				WithInfrastructureTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "md-infra").Build()).
+				WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "md-bootstrap").Build()).
+				Build(),
+		}).
+		Build()
+
+	tests := []struct {
+		name           string
+		oldVersion     string
+		oldMD          clusterv1.MachineDeploymentTopology
+		version        string
+		md             clusterv1.MachineDeploymentTopology
+		expectErr      bool
+		expectErrField string
+	}{
+		{
+			name:    "Accept replicas and version within the constraints",
+			version: "v1.22.2",
+			md:      builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(5).Build(),
+		},
+		{
+			name:    "Accept unset replicas",
+			version: "v1.22.2",
+			md:      builder.MachineDeploymentTopology("md1").WithClass("md-class").Build(),
+		},
+		{
+			name:    "Accept any replicas and version for classes without constraints",
+			version: "v1.23.0",
+			md:      builder.MachineDeploymentTopology("md1").WithClass("unconstrained-class").WithReplicas(50).Build(),
+		},
+		{
+			name:           "Reject replicas lower than minReplicas",
+			version:        "v1.22.2",
+			md:             builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(0).Build(),
+			expectErr:      true,
+			expectErrField: "spec.topology.workers.machineDeployments[0].replicas",
+		},
+		{
+			name:           "Reject replicas greater than maxReplicas",
+			version:        "v1.22.2",
+			md:             builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(6).Build(),
+			expectErr:      true,
+			expectErrField: "spec.topology.workers.machineDeployments[0].replicas",
+		},
+		{
+			name:           "Reject versions lower than minVersion",
+			version:        "v1.20.5",
+			md:             builder.MachineDeploymentTopology("md1").WithClass("md-class").Build(),
+			expectErr:      true,
+			expectErrField: "spec.topology.version",
+		},
+		{
+			name:           "Reject versions greater than maxVersion",
+			version:        "v1.23.0",
+			md:             builder.MachineDeploymentTopology("md1").WithClass("md-class").Build(),
+			expectErr:      true,
+			expectErrField: "spec.topology.version",
+		},
+		{
+			name:       "Accept updates not changing replicas and version outside of the constraints",
+			oldVersion: "v1.23.0",
+			oldMD:      builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(6).Build(),
+			version:    "v1.23.0",
+			md:         builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(6).Build(),
+		},
+		{
+			name:           "Reject updates changing replicas outside of the constraints",
+			oldVersion:     "v1.22.2",
+			oldMD:          builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(5).Build(),
+			version:        "v1.22.2",
+			md:             builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(6).Build(),
+			expectErr:      true,
+			expectErrField: "spec.topology.workers.machineDeployments[0].replicas",
+		},
+		{
+			name:           "Reject updates changing version outside of the constraints",
+			oldVersion:     "v1.22.2",
+			oldMD:          builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(6).Build(),
+			version:        "v1.23.0",
+			md:             builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(6).Build(),
+			expectErr:      true,
+			expectErrField: "spec.topology.version",
+		},
+		{
+			name:           "Reject updates changing the class of a MachineDeployment topology outside of the constraints",
+			oldVersion:     "v1.22.2",
+			oldMD:          builder.MachineDeploymentTopology("md1").WithClass("unconstrained-class").WithReplicas(6).Build(),
+			version:        "v1.22.2",
+			md:             builder.MachineDeploymentTopology("md1").WithClass("md-class").WithReplicas(6).Build(),
+			expectErr:      true,
+			expectErrField: "spec.topology.workers.machineDeployments[0].replicas",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass(class.Name).
+						WithVersion(tt.version).
+						WithControlPlaneReplicas(3).
+						WithMachineDeployment(tt.md).
+						Build()).
+				Build()
+
+			fakeClient := fake.NewClientBuilder().
+				WithObjects(class).
+				WithScheme(fakeScheme).
+				Build()
+			c := &Cluster{Client: fakeClient}
+
+			var err error
+			if tt.oldVersion == "" {
+				err = c.ValidateCreate(ctx, cluster)
+			} else {
+				oldCluster := cluster.DeepCopy()
+				oldCluster.Spec.Topology.Version = tt.oldVersion
+				oldCluster.Spec.Topology.Workers.MachineDeployments = []clusterv1.MachineDeploymentTopology{tt.oldMD}
+				err = c.ValidateUpdate(ctx, oldCluster, cluster)
+			}
+			if !tt.expectErr {
+				g.Expect(err).ToNot(HaveOccurred())
+				return
+			}
+			g.Expect(err).To(HaveOccurred())
+			statusErr, ok := err.(*apierrors.StatusError)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(statusErr.ErrStatus.Details.Causes).To(HaveLen(1))
+			g.Expect(statusErr.ErrStatus.Details.Causes[0].Field).To(Equal(tt.expectErrField))
+		})
+	}
+}
+
+func TestClusterTopologyValidationMachinePools(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	class := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithWorkerMachinePoolClasses([]clusterv1.MachinePoolClass{
+			*builder.MachinePoolClass("mp-class").
+				WithInfrastructureTemplate(builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "mp-infra").Build()).
+				WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "mp-bootstrap").Build()).
+				Build(),
+		}).
+		Build()
+
+	tests := []struct {
+		name               string
+		machinePoolEnabled bool
+		machinePools       []clusterv1.MachinePoolTopology
+		expectErr          bool
+	}{
+		{
+			name:               "Accept MachinePools referencing classes defined in the ClusterClass",
+			machinePoolEnabled: true,
+			machinePools: []clusterv1.MachinePoolTopology{
+				builder.MachinePoolTopology("pool1").WithClass("mp-class").Build(),
+				builder.MachinePoolTopology("pool2").WithClass("mp-class").WithReplicas(3).Build(),
+			},
+		},
+		{
+			name:               "Reject MachinePools if the MachinePool feature flag is disabled",
+			machinePoolEnabled: false,
+			machinePools: []clusterv1.MachinePoolTopology{
+				builder.MachinePoolTopology("pool1").WithClass("mp-class").Build(),
+			},
+			expectErr: true,
+		},
+		{
+			name:               "Reject MachinePools with duplicate names",
+			machinePoolEnabled: true,
+			machinePools: []clusterv1.MachinePoolTopology{
+				builder.MachinePoolTopology("pool1").WithClass("mp-class").Build(),
+				builder.MachinePoolTopology("pool1").WithClass("mp-class").Build(),
+			},
+			expectErr: true,
+		},
+		{
+			name:               "Reject MachinePools referencing classes not defined in the ClusterClass",
+			machinePoolEnabled: true,
+			machinePools: []clusterv1.MachinePoolTopology{
+				builder.MachinePoolTopology("pool1").WithClass("unknown-class").Build(),
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePool, tt.machinePoolEnabled)()
+			g := NewWithT(t)
+
+			topology := builder.ClusterTopology().
+				WithClass(class.Name).
+				WithVersion("v1.22.2").
+				WithControlPlaneReplicas(3)
+			for _, mp := range tt.machinePools {
+				topology.WithMachinePool(mp)
+			}
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(topology.Build()).
+				Build()
+
+			fakeClient := fake.NewClientBuilder().
+				WithObjects(class).
+				WithScheme(fakeScheme).
+				Build()
+			c := &Cluster{Client: fakeClient}
+
+			if tt.expectErr {
+				g.Expect(c.ValidateCreate(ctx, cluster)).NotTo(Succeed())
+			} else {
+				g.Expect(c.ValidateCreate(ctx, cluster)).To(Succeed())
+			}
+		})
+	}
+}
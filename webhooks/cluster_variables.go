@@ -0,0 +1,181 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"unicode/utf8"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
+)
+
+// variableDefinitions returns the definitions of the variables which can be set in the topology of the Clusters using
+// a ClusterClass, composed with the ClusterClasses it inherits from: the variables defined in the ClusterClasses and,
+// if the ClusterClassVariablesDiscovery feature gate is enabled, the ones discovered via the DiscoverVariables hook
+// and reported in the ClusterClass status.
+func variableDefinitions(resolvedClusterClass *clusterv1.ClusterClass) []clusterv1.ClusterClassVariable {
+	definitions := append([]clusterv1.ClusterClassVariable{}, resolvedClusterClass.Spec.Variables...)
+	if !feature.Gates.Enabled(feature.ClusterClassVariablesDiscovery) {
+		return definitions
+	}
+	defined := map[string]bool{}
+	for _, v := range definitions {
+		defined[v.Name] = true
+	}
+	for _, v := range resolvedClusterClass.Status.Variables {
+		if v.From != clusterv1.VariableDefinitionFromDiscovered || defined[v.Name] {
+			continue
+		}
+		definitions = append(definitions, clusterv1.ClusterClassVariable{Name: v.Name, Required: v.Required, Schema: v.Schema})
+	}
+	return definitions
+}
+
+// defaultTopologyVariables sets the variables not set in the Cluster topology to the default value defined in their schema, if any.
+func defaultTopologyVariables(topology *clusterv1.Topology, definitions []clusterv1.ClusterClassVariable) {
+	set := map[string]bool{}
+	for _, v := range topology.Variables {
+		set[v.Name] = true
+	}
+	for _, definition := range definitions {
+		if set[definition.Name] || definition.Schema.OpenAPIV3Schema.Default == nil {
+			continue
+		}
+		topology.Variables = append(topology.Variables, clusterv1.ClusterVariable{
+			Name:  definition.Name,
+			Value: *definition.Schema.OpenAPIV3Schema.Default.DeepCopy(),
+		})
+	}
+}
+
+// validateTopologyVariables validates the variables of the Cluster topology against their definitions:
+// required variables must be set, and the values must match the schema of the variables.
+func validateTopologyVariables(topology *clusterv1.Topology, definitions []clusterv1.ClusterClassVariable, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	values := map[string]int{}
+	for i, v := range topology.Variables {
+		if _, ok := values[v.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), v.Name))
+			continue
+		}
+		values[v.Name] = i
+	}
+
+	for _, definition := range definitions {
+		i, ok := values[definition.Name]
+		if !ok {
+			if definition.Required {
+				allErrs = append(allErrs, field.Required(fldPath, fmt.Sprintf("variable %q is required", definition.Name)))
+			}
+			continue
+		}
+		allErrs = append(allErrs, validateVariableValue(topology.Variables[i].Value, &definition.Schema.OpenAPIV3Schema, fldPath.Index(i).Child("value"))...)
+	}
+	return allErrs
+}
+
+// validateVariableValue validates the value of a variable against its schema.
+func validateVariableValue(value apiextensionsv1.JSON, schema *clusterv1.JSONSchemaProps, fldPath *field.Path) field.ErrorList {
+	var v interface{}
+	if err := json.Unmarshal(value.Raw, &v); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, string(value.Raw), fmt.Sprintf("must be valid JSON: %v", err))}
+	}
+
+	if v == nil {
+		if schema.Nullable {
+			return nil
+		}
+		return field.ErrorList{field.Invalid(fldPath, string(value.Raw), "must not be null")}
+	}
+
+	if len(schema.Enum) > 0 {
+		valid := false
+		for _, e := range schema.Enum {
+			var enumValue interface{}
+			if err := json.Unmarshal(e.Raw, &enumValue); err == nil && reflect.DeepEqual(v, enumValue) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return field.ErrorList{field.NotSupported(fldPath, string(value.Raw), enumValues(schema.Enum))}
+		}
+	}
+
+	switch schema.Type {
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return field.ErrorList{field.Invalid(fldPath, string(value.Raw), "must be a string")}
+		}
+		length := int64(utf8.RuneCountInString(s))
+		if schema.MinLength != nil && length < *schema.MinLength {
+			return field.ErrorList{field.Invalid(fldPath, s, fmt.Sprintf("must be at least %d characters long", *schema.MinLength))}
+		}
+		if schema.MaxLength != nil && length > *schema.MaxLength {
+			return field.ErrorList{field.Invalid(fldPath, s, fmt.Sprintf("must be at most %d characters long", *schema.MaxLength))}
+		}
+		if schema.Pattern != "" {
+			re, err := regexp.Compile(schema.Pattern)
+			if err != nil {
+				return field.ErrorList{field.Invalid(fldPath, s, fmt.Sprintf("cannot be validated, invalid pattern %q in the variable schema", schema.Pattern))}
+			}
+			if !re.MatchString(s) {
+				return field.ErrorList{field.Invalid(fldPath, s, fmt.Sprintf("must match the pattern %q", schema.Pattern))}
+			}
+		}
+	case "integer", "number":
+		n, ok := v.(float64)
+		if !ok || (schema.Type == "integer" && n != math.Trunc(n)) {
+			return field.ErrorList{field.Invalid(fldPath, string(value.Raw), fmt.Sprintf("must be of type %s", schema.Type))}
+		}
+		if schema.Minimum != nil && (n < float64(*schema.Minimum) || (schema.ExclusiveMinimum && n == float64(*schema.Minimum))) {
+			return field.ErrorList{field.Invalid(fldPath, string(value.Raw), fmt.Sprintf("must be greater than %s%d", orEqual(!schema.ExclusiveMinimum), *schema.Minimum))}
+		}
+		if schema.Maximum != nil && (n > float64(*schema.Maximum) || (schema.ExclusiveMaximum && n == float64(*schema.Maximum))) {
+			return field.ErrorList{field.Invalid(fldPath, string(value.Raw), fmt.Sprintf("must be less than %s%d", orEqual(!schema.ExclusiveMaximum), *schema.Maximum))}
+		}
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return field.ErrorList{field.Invalid(fldPath, string(value.Raw), "must be a boolean")}
+		}
+	}
+	return nil
+}
+
+func enumValues(enum []apiextensionsv1.JSON) []string {
+	values := make([]string, 0, len(enum))
+	for _, e := range enum {
+		values = append(values, string(e.Raw))
+	}
+	return values
+}
+
+func orEqual(inclusive bool) string {
+	if inclusive {
+		return "or equal to "
+	}
+	return ""
+}
@@ -0,0 +1,242 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestClusterDefaultAndValidateDiscoveredVariables(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassVariablesDiscovery, true)()
+
+	g := NewWithT(t)
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class").Build()
+	clusterClass.Spec.Variables = []clusterv1.ClusterClassVariable{
+		{Name: "region", Required: true, Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{Type: "string"}}},
+	}
+	clusterClass.Status.Variables = []clusterv1.ClusterClassStatusVariable{
+		{
+			Name: "instanceType",
+			From: clusterv1.VariableDefinitionFromDiscovered,
+			Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type:    "string",
+				Enum:    []apiextensionsv1.JSON{{Raw: []byte(`"m5.large"`)}, {Raw: []byte(`"m5.xlarge"`)}},
+				Default: &apiextensionsv1.JSON{Raw: []byte(`"m5.large"`)},
+			}},
+		},
+	}
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").
+		WithTopology(builder.ClusterTopology().
+			WithClass("class").
+			WithVersion("v1.22.2").
+			Build()).
+		Build()
+	cluster.Spec.Topology.Variables = []clusterv1.ClusterVariable{
+		{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east-1"`)}},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(fakeScheme).
+		WithObjects(clusterClass).
+		Build()
+	webhook := &Cluster{Client: fakeClient}
+
+	// The discovered variable is defaulted.
+	g.Expect(webhook.Default(ctx, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Topology.Variables).To(ConsistOf(
+		clusterv1.ClusterVariable{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east-1"`)}},
+		clusterv1.ClusterVariable{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"m5.large"`)}},
+	))
+	g.Expect(webhook.ValidateCreate(ctx, cluster)).To(Succeed())
+
+	// The discovered variable is validated.
+	invalid := cluster.DeepCopy()
+	invalid.Spec.Topology.Variables[1].Value = apiextensionsv1.JSON{Raw: []byte(`"t2.micro"`)}
+	g.Expect(webhook.ValidateCreate(ctx, invalid)).NotTo(Succeed())
+
+	// Required variables must be set.
+	missing := cluster.DeepCopy()
+	missing.Spec.Topology.Variables = missing.Spec.Topology.Variables[1:]
+	g.Expect(webhook.ValidateCreate(ctx, missing)).NotTo(Succeed())
+
+	// Variables are validated on update only if changed, so Clusters created before a variable was added to the
+	// ClusterClass can still be updated.
+	updated := missing.DeepCopy()
+	updated.Spec.Topology.Version = "v1.22.3"
+	g.Expect(webhook.ValidateUpdate(ctx, missing, updated)).To(Succeed())
+	updated.Spec.Topology.Variables[0].Value = apiextensionsv1.JSON{Raw: []byte(`"m5.xlarge"`)}
+	g.Expect(webhook.ValidateUpdate(ctx, missing, updated)).NotTo(Succeed())
+}
+
+func TestClusterDefaultAndValidateDiscoveredVariablesFeatureGateDisabled(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	g := NewWithT(t)
+
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class").Build()
+	clusterClass.Status.Variables = []clusterv1.ClusterClassStatusVariable{
+		{
+			Name:     "instanceType",
+			From:     clusterv1.VariableDefinitionFromDiscovered,
+			Required: true,
+			Schema: clusterv1.VariableSchema{OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type:    "string",
+				Default: &apiextensionsv1.JSON{Raw: []byte(`"m5.large"`)},
+			}},
+		},
+	}
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").
+		WithTopology(builder.ClusterTopology().
+			WithClass("class").
+			WithVersion("v1.22.2").
+			Build()).
+		Build()
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(fakeScheme).
+		WithObjects(clusterClass).
+		Build()
+	webhook := &Cluster{Client: fakeClient}
+
+	// Discovered variables are neither defaulted nor required if the ClusterClassVariablesDiscovery feature gate is disabled.
+	g.Expect(webhook.Default(ctx, cluster)).To(Succeed())
+	g.Expect(cluster.Spec.Topology.Variables).To(BeEmpty())
+	g.Expect(webhook.ValidateCreate(ctx, cluster)).To(Succeed())
+}
+
+func TestValidateVariableValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		schema    clusterv1.JSONSchemaProps
+		expectErr bool
+	}{
+		{
+			name:   "valid string",
+			value:  `"foo"`,
+			schema: clusterv1.JSONSchemaProps{Type: "string", MinLength: pointer.Int64Ptr(1), MaxLength: pointer.Int64Ptr(3), Pattern: "^f"},
+		},
+		{
+			name:      "string too long",
+			value:     `"foobar"`,
+			schema:    clusterv1.JSONSchemaProps{Type: "string", MaxLength: pointer.Int64Ptr(3)},
+			expectErr: true,
+		},
+		{
+			name:      "string not matching the pattern",
+			value:     `"bar"`,
+			schema:    clusterv1.JSONSchemaProps{Type: "string", Pattern: "^f"},
+			expectErr: true,
+		},
+		{
+			name:      "number instead of string",
+			value:     `1`,
+			schema:    clusterv1.JSONSchemaProps{Type: "string"},
+			expectErr: true,
+		},
+		{
+			name:   "valid integer",
+			value:  `3`,
+			schema: clusterv1.JSONSchemaProps{Type: "integer", Minimum: pointer.Int64Ptr(1), Maximum: pointer.Int64Ptr(3)},
+		},
+		{
+			name:      "integer equal to the exclusive maximum",
+			value:     `3`,
+			schema:    clusterv1.JSONSchemaProps{Type: "integer", Maximum: pointer.Int64Ptr(3), ExclusiveMaximum: true},
+			expectErr: true,
+		},
+		{
+			name:      "integer less than the minimum",
+			value:     `0`,
+			schema:    clusterv1.JSONSchemaProps{Type: "integer", Minimum: pointer.Int64Ptr(1)},
+			expectErr: true,
+		},
+		{
+			name:      "number instead of integer",
+			value:     `1.5`,
+			schema:    clusterv1.JSONSchemaProps{Type: "integer"},
+			expectErr: true,
+		},
+		{
+			name:   "valid number",
+			value:  `1.5`,
+			schema: clusterv1.JSONSchemaProps{Type: "number"},
+		},
+		{
+			name:      "string instead of boolean",
+			value:     `"true"`,
+			schema:    clusterv1.JSONSchemaProps{Type: "boolean"},
+			expectErr: true,
+		},
+		{
+			name:   "null value for a nullable variable",
+			value:  `null`,
+			schema: clusterv1.JSONSchemaProps{Type: "string", Nullable: true},
+		},
+		{
+			name:      "null value for a variable which is not nullable",
+			value:     `null`,
+			schema:    clusterv1.JSONSchemaProps{Type: "string"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid JSON",
+			value:     `{`,
+			schema:    clusterv1.JSONSchemaProps{Type: "string"},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			errs := validateVariableValue(apiextensionsv1.JSON{Raw: []byte(tt.value)}, &tt.schema, field.NewPath("value"))
+			if tt.expectErr {
+				g.Expect(errs).NotTo(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestValidateTopologyVariablesDuplicates(t *testing.T) {
+	g := NewWithT(t)
+
+	topology := &clusterv1.Topology{
+		Variables: []clusterv1.ClusterVariable{
+			{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"us-east-1"`)}},
+			{Name: "region", Value: apiextensionsv1.JSON{Raw: []byte(`"us-west-1"`)}},
+		},
+	}
+	g.Expect(validateTopologyVariables(topology, nil, field.NewPath("variables"))).To(HaveLen(1))
+}
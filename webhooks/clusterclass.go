@@ -19,20 +19,33 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
+	"text/template"
 
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/topology/enabledif"
+	"sigs.k8s.io/cluster-api/internal/topology/inheritance"
+	"sigs.k8s.io/cluster-api/internal/topology/metadata"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
+// builtinVariablePrefix is the name prefix reserved to the builtin variables computed by the topology controller,
+// e.g. builtin.cluster.name.
+const builtinVariablePrefix = "builtin"
+
 func (webhook *ClusterClass) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(&clusterv1.ClusterClass{}).
@@ -41,11 +54,18 @@ func (webhook *ClusterClass) SetupWebhookWithManager(mgr ctrl.Manager) error {
 		Complete()
 }
 
-// +kubebuilder:webhook:verbs=create;update,path=/validate-cluster-x-k8s-io-v1beta1-clusterclass,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusterclasses,versions=v1beta1,name=validation.clusterclass.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:webhook:verbs=create;update;delete,path=/validate-cluster-x-k8s-io-v1beta1-clusterclass,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusterclasses,versions=v1beta1,name=validation.clusterclass.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-cluster-x-k8s-io-v1beta1-clusterclass,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusterclasses,versions=v1beta1,name=default.clusterclass.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 // ClusterClass implements a validation and defaulting webhook for ClusterClass.
-type ClusterClass struct{}
+type ClusterClass struct {
+	// Client is used to read the ClusterClasses a ClusterClass inherits from.
+	Client client.Reader
+
+	// VariablesDiscoveryURLs are the URLs of the DiscoverVariables hooks ClusterClasses are allowed to use,
+	// as registered by the administrator of the management cluster.
+	VariablesDiscoveryURLs []string
+}
 
 var _ webhook.CustomDefaulter = &ClusterClass{}
 var _ webhook.CustomValidator = &ClusterClass{}
@@ -68,6 +88,11 @@ func (webhook *ClusterClass) Default(_ context.Context, obj runtime.Object) erro
 		defaultNamespace(in.Spec.Workers.MachineDeployments[i].Template.Bootstrap.Ref, in.Namespace)
 		defaultNamespace(in.Spec.Workers.MachineDeployments[i].Template.Infrastructure.Ref, in.Namespace)
 	}
+
+	for i := range in.Spec.Workers.MachinePools {
+		defaultNamespace(in.Spec.Workers.MachinePools[i].Template.Bootstrap.Ref, in.Namespace)
+		defaultNamespace(in.Spec.Workers.MachinePools[i].Template.Infrastructure.Ref, in.Namespace)
+	}
 	return nil
 }
 
@@ -78,16 +103,20 @@ func defaultNamespace(ref *corev1.ObjectReference, namespace string) {
 }
 
 // ValidateCreate implements validation for ClusterClass create.
-func (webhook *ClusterClass) ValidateCreate(_ context.Context, obj runtime.Object) error {
+func (webhook *ClusterClass) ValidateCreate(ctx context.Context, obj runtime.Object) error {
 	in, ok := obj.(*clusterv1.ClusterClass)
 	if !ok {
 		return apierrors.NewBadRequest(fmt.Sprintf("expected a ClusterClass but got a %T", obj))
 	}
-	return webhook.validate(nil, in)
+	resolved, err := webhook.resolve(ctx, in)
+	if err != nil {
+		return err
+	}
+	return webhook.validate(nil, resolved)
 }
 
 // ValidateUpdate implements validation for ClusterClass update.
-func (webhook *ClusterClass) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) error {
+func (webhook *ClusterClass) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
 	newClusterClass, ok := newObj.(*clusterv1.ClusterClass)
 	if !ok {
 		return apierrors.NewBadRequest(fmt.Sprintf("expected a ClusterClass but got a %T", newObj))
@@ -96,14 +125,151 @@ func (webhook *ClusterClass) ValidateUpdate(_ context.Context, oldObj, newObj ru
 	if !ok {
 		return apierrors.NewBadRequest(fmt.Sprintf("expected a ClusterClass but got a %T", oldObj))
 	}
-	return webhook.validate(oldClusterClass, newClusterClass)
+	resolved, err := webhook.resolve(ctx, newClusterClass)
+	if err != nil {
+		return err
+	}
+	// If the old ClusterClass cannot be composed anymore, e.g. because a ClusterClass it inherited from has been
+	// changed, changes are validated as if the ClusterClass was created.
+	oldResolved, err := webhook.resolve(ctx, oldClusterClass)
+	if err != nil {
+		oldResolved = nil
+	}
+	if err := webhook.validate(oldResolved, resolved); err != nil {
+		return err
+	}
+	return webhook.validateInheritingClusterClasses(ctx, newClusterClass)
 }
 
 // ValidateDelete implements validation for ClusterClass delete.
 func (webhook *ClusterClass) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	in, ok := obj.(*clusterv1.ClusterClass)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a ClusterClass but got a %T", obj))
+	}
+
+	// Prevent deleting a ClusterClass inherited by other ClusterClasses, which could not be composed anymore.
+	// NOTE: ClusterClasses are listed only if the webhook has a client, e.g. it is not set in unit tests.
+	if webhook.Client == nil {
+		return nil
+	}
+	clusterClassList := &clusterv1.ClusterClassList{}
+	if err := webhook.Client.List(ctx, clusterClassList, client.InNamespace(in.Namespace)); err != nil {
+		return apierrors.NewInternalError(errors.Wrap(err, "failed to list ClusterClasses"))
+	}
+	var inheriting []string
+	for _, clusterClass := range clusterClassList.Items {
+		if clusterClass.Spec.Inherits == in.Name {
+			inheriting = append(inheriting, clusterClass.Name)
+		}
+	}
+	if len(inheriting) > 0 {
+		return apierrors.NewForbidden(clusterv1.GroupVersion.WithResource("clusterclasses").GroupResource(), in.Name,
+			errors.Errorf("ClusterClass is inherited by ClusterClasses %s", strings.Join(inheriting, ", ")))
+	}
+	return nil
+}
+
+// resolve returns the ClusterClass composed with the ClusterClasses it inherits from, which is validated in place of
+// the ClusterClass; a ClusterClass not inheriting from other ClusterClasses is returned as is.
+func (webhook *ClusterClass) resolve(ctx context.Context, in *clusterv1.ClusterClass) (*clusterv1.ClusterClass, error) {
+	if in.Spec.Inherits == "" {
+		return in, nil
+	}
+
+	// NOTE: ClusterClass inheritance is behind the ClusterClassInheritance feature gate flag; the web hook
+	// must prevent inheriting from other ClusterClasses in case the feature flag is disabled.
+	if !feature.Gates.Enabled(feature.ClusterClassInheritance) {
+		return nil, apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind(), in.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "inherits"), "can be set only if the ClusterClassInheritance feature flag is enabled"),
+		})
+	}
+
+	if webhook.Client == nil {
+		return nil, apierrors.NewInternalError(errors.New("ClusterClass webhook requires a client to compose ClusterClasses inheriting from other ClusterClasses"))
+	}
+
+	resolved, err := inheritance.Resolve(ctx, webhook.Client, in)
+	if err != nil {
+		fldPath := field.NewPath("spec", "inherits")
+		switch {
+		case apierrors.IsNotFound(errors.Cause(err)):
+			return nil, apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind(), in.Name, field.ErrorList{
+				field.NotFound(fldPath, in.Spec.Inherits),
+			})
+		case errors.Cause(err) == inheritance.ErrCycle:
+			return nil, apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind(), in.Name, field.ErrorList{
+				field.Invalid(fldPath, in.Spec.Inherits, err.Error()),
+			})
+		default:
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
+	return resolved, nil
+}
+
+// validateInheritingClusterClasses validates the ClusterClasses directly or indirectly inheriting from an updated ClusterClass,
+// composed with the updated ClusterClass, so changes to an inherited ClusterClass cannot break the ClusterClasses inheriting from it.
+func (webhook *ClusterClass) validateInheritingClusterClasses(ctx context.Context, in *clusterv1.ClusterClass) error {
+	if !feature.Gates.Enabled(feature.ClusterClassInheritance) || webhook.Client == nil {
+		return nil
+	}
+
+	clusterClassList := &clusterv1.ClusterClassList{}
+	if err := webhook.Client.List(ctx, clusterClassList, client.InNamespace(in.Namespace)); err != nil {
+		return apierrors.NewInternalError(errors.Wrap(err, "failed to list ClusterClasses"))
+	}
+	inheriting := inheritance.InheritingClusterClasses(clusterClassList.Items, in.Name)
+
+	var allErrs field.ErrorList
+	for i := range clusterClassList.Items {
+		clusterClass := &clusterClassList.Items[i]
+		if !inheriting.Has(clusterClass.Name) {
+			continue
+		}
+
+		// Compose the inheriting ClusterClass with the current version of the updated ClusterClass, if possible,
+		// so the compatibility of the changes is validated as well.
+		var old *clusterv1.ClusterClass
+		if oldResolved, err := inheritance.Resolve(ctx, webhook.Client, clusterClass); err == nil {
+			old = oldResolved
+		}
+
+		resolved, err := inheritance.Resolve(ctx, &clusterClassOverlay{Reader: webhook.Client, clusterClass: in}, clusterClass)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), in.Name,
+				fmt.Sprintf("ClusterClass %s inheriting from this ClusterClass could not be composed: %v", clusterClass.Name, err)))
+			continue
+		}
+
+		if err := webhook.validate(old, resolved); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec"), in.Name,
+				fmt.Sprintf("changes are not valid for ClusterClass %s inheriting from this ClusterClass: %v", clusterClass.Name, err)))
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("ClusterClass").GroupKind(), in.Name, allErrs)
+	}
 	return nil
 }
 
+// clusterClassOverlay is a client.Reader returning a ClusterClass in place of the one with the same name
+// read from the underlying Reader.
+type clusterClassOverlay struct {
+	client.Reader
+	clusterClass *clusterv1.ClusterClass
+}
+
+// Get implements client.Reader.
+func (r *clusterClassOverlay) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	if clusterClass, ok := obj.(*clusterv1.ClusterClass); ok && key == client.ObjectKeyFromObject(r.clusterClass) {
+		r.clusterClass.DeepCopyInto(clusterClass)
+		return nil
+	}
+	return r.Reader.Get(ctx, key, obj)
+}
+
 func (webhook *ClusterClass) validate(old, in *clusterv1.ClusterClass) error {
 	// NOTE: ClusterClass and managed topologies are behind ClusterTopology feature gate flag; the web hook
 	// must prevent creating in objects in case the feature flag is disabled.
@@ -116,12 +282,42 @@ func (webhook *ClusterClass) validate(old, in *clusterv1.ClusterClass) error {
 
 	var allErrs field.ErrorList
 
+	// NOTE: MachinePools are behind MachinePool feature gate flag; the web hook
+	// must prevent using MachinePool classes in case the feature flag is disabled.
+	if len(in.Spec.Workers.MachinePools) > 0 && !feature.Gates.Enabled(feature.MachinePool) {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("spec", "workers", "machinePools"),
+			"can be set only if the MachinePool feature flag is enabled",
+		))
+	}
+
 	// Ensure all references are valid.
 	allErrs = append(allErrs, webhook.validateAllRefs(in)...)
 
-	// Ensure all MachineDeployment classes are unique.
+	// Ensure all MachineDeployment and MachinePool classes are unique.
 	allErrs = append(allErrs, webhook.validateUniqueClasses(in.Spec.Workers, field.NewPath("spec", "workers"))...)
 
+	// Ensure template tokens in the metadata of MachineDeployment classes are valid.
+	allErrs = append(allErrs, webhook.validateMachineDeploymentClassesMetadata(in.Spec.Workers, field.NewPath("spec", "workers"))...)
+
+	// Ensure enabledIf templates of MachineDeployment classes and patches are valid.
+	allErrs = append(allErrs, webhook.validateEnabledIf(in)...)
+
+	// Ensure variables do not use the name prefix reserved to builtin variables.
+	allErrs = append(allErrs, webhook.validateVariableNames(in)...)
+
+	// Ensure the variables discovery hook is valid.
+	allErrs = append(allErrs, webhook.validateVariablesDiscovery(in)...)
+
+	// Ensure templates calculating the value of patches are valid.
+	allErrs = append(allErrs, webhook.validatePatchValueTemplates(in)...)
+
+	// Ensure MachineHealthChecks are valid.
+	allErrs = append(allErrs, webhook.validateMachineHealthCheckClasses(in)...)
+
+	// Ensure constraints of MachineDeployment classes are valid.
+	allErrs = append(allErrs, webhook.validateMachineDeploymentClassConstraints(in.Spec.Workers, field.NewPath("spec", "workers"))...)
+
 	// Ensure spec changes are compatible.
 	allErrs = append(allErrs, webhook.validateCompatibleSpecChanges(old, in)...)
 
@@ -145,6 +341,69 @@ func (webhook *ClusterClass) validateAllRefs(in *clusterv1.ClusterClass) field.E
 		allErrs = append(allErrs, webhook.validateTemplate(&class.Template.Infrastructure, in.Namespace, field.NewPath("spec", "workers", "machineDeployments").Index(i).Child("template", "infrastructure"))...)
 	}
 
+	for i, class := range in.Spec.Workers.MachinePools {
+		allErrs = append(allErrs, webhook.validateTemplate(&class.Template.Bootstrap, in.Namespace, field.NewPath("spec", "workers", "machinePools").Index(i).Child("template", "bootstrap"))...)
+		allErrs = append(allErrs, webhook.validateTemplate(&class.Template.Infrastructure, in.Namespace, field.NewPath("spec", "workers", "machinePools").Index(i).Child("template", "infrastructure"))...)
+	}
+
+	return allErrs
+}
+
+func (webhook *ClusterClass) validateMachineHealthCheckClasses(in *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if in.Spec.ControlPlane.MachineHealthCheck != nil {
+		fldPath := field.NewPath("spec", "controlPlane", "machineHealthCheck")
+
+		// A MachineHealthCheck can be defined only if the control plane machines are managed by the topology.
+		if in.Spec.ControlPlane.MachineInfrastructure == nil {
+			allErrs = append(allErrs, field.Forbidden(
+				fldPath,
+				"can be set only if spec.controlPlane.machineInfrastructure is set",
+			))
+		}
+		allErrs = append(allErrs, validateMachineHealthCheckClass(in.Spec.ControlPlane.MachineHealthCheck, in.Namespace, fldPath)...)
+	}
+
+	for i, class := range in.Spec.Workers.MachineDeployments {
+		if class.MachineHealthCheck == nil {
+			continue
+		}
+		allErrs = append(allErrs, validateMachineHealthCheckClass(class.MachineHealthCheck, in.Namespace, field.NewPath("spec", "workers", "machineDeployments").Index(i).Child("machineHealthCheck"))...)
+	}
+
+	return allErrs
+}
+
+// validateMachineHealthCheckClass validates a MachineHealthCheckClass, either defined in a ClusterClass or
+// in a Cluster topology.
+func validateMachineHealthCheckClass(m *clusterv1.MachineHealthCheckClass, namespace string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if len(m.UnhealthyConditions) == 0 {
+		allErrs = append(allErrs, field.Forbidden(
+			fldPath.Child("unhealthyConditions"),
+			"must have at least one value",
+		))
+	}
+
+	// Validate the remaining fields using the same rules applied to MachineHealthCheck objects; the namespace
+	// of the RemediationTemplate is defaulted to the namespace of the MachineHealthCheck when not set.
+	mhc := &clusterv1.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+		Spec: clusterv1.MachineHealthCheckSpec{
+			UnhealthyConditions: m.UnhealthyConditions,
+			MaxUnhealthy:        m.MaxUnhealthy,
+			UnhealthyRange:      m.UnhealthyRange,
+			NodeStartupTimeout:  m.NodeStartupTimeout,
+			RemediationTemplate: m.RemediationTemplate.DeepCopy(),
+		},
+	}
+	if mhc.Spec.RemediationTemplate != nil && mhc.Spec.RemediationTemplate.Namespace == "" {
+		mhc.Spec.RemediationTemplate.Namespace = namespace
+	}
+	allErrs = append(allErrs, mhc.ValidateCommonFields(fldPath)...)
+
 	return allErrs
 }
 
@@ -160,6 +419,9 @@ func (webhook *ClusterClass) validateCompatibleSpecChanges(old, in *clusterv1.Cl
 	// Validate changes to MachineDeployments.
 	allErrs = append(allErrs, webhook.validateMachineDeploymentsCompatibleChanges(old, in)...)
 
+	// Validate changes to MachinePools.
+	allErrs = append(allErrs, webhook.validateMachinePoolsCompatibleChanges(old, in)...)
+
 	// Validate InfrastructureClusterTemplate changes in a compatible way.
 	allErrs = append(allErrs, webhook.validateTemplatesAreCompatible(in.Spec.Infrastructure,
 		old.Spec.Infrastructure,
@@ -187,7 +449,7 @@ func (webhook *ClusterClass) validateMachineDeploymentsCompatibleChanges(old, in
 	var allErrs field.ErrorList
 
 	// Ensure no MachineDeployment class was removed.
-	classes := webhook.classNamesFromWorkerClass(in.Spec.Workers)
+	classes, _ := webhook.classNamesFromWorkerClass(in.Spec.Workers)
 	for _, oldClass := range old.Spec.Workers.MachineDeployments {
 		if !classes.Has(oldClass.Class) {
 			allErrs = append(allErrs,
@@ -220,6 +482,43 @@ func (webhook *ClusterClass) validateMachineDeploymentsCompatibleChanges(old, in
 	return allErrs
 }
 
+func (webhook *ClusterClass) validateMachinePoolsCompatibleChanges(old, in *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Ensure no MachinePool class was removed.
+	_, classes := webhook.classNamesFromWorkerClass(in.Spec.Workers)
+	for _, oldClass := range old.Spec.Workers.MachinePools {
+		if !classes.Has(oldClass.Class) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "workers", "machinePools"),
+					in.Spec.Workers.MachinePools,
+					fmt.Sprintf("The %q MachinePool class can't be removed.", oldClass.Class),
+				),
+			)
+		}
+	}
+
+	// Ensure previous MachinePool class was modified in a compatible way.
+	for i, class := range in.Spec.Workers.MachinePools {
+		for _, oldClass := range old.Spec.Workers.MachinePools {
+			if class.Class == oldClass.Class {
+				// NOTE: class.Template.Metadata and class.Template.Bootstrap are allowed to change;
+				// class.Template.Bootstrap are ensured syntactically correct by validateAllRefs.
+
+				// Validates class.Template.Infrastructure template changes in a compatible way
+				allErrs = append(allErrs, webhook.validateTemplatesAreCompatible(
+					class.Template.Infrastructure,
+					oldClass.Template.Infrastructure,
+					field.NewPath("spec", "workers", "machinePools").Index(i).Child("template", "infrastructure"),
+				)...)
+			}
+		}
+	}
+
+	return allErrs
+}
+
 func (webhook *ClusterClass) validateTemplate(in *clusterv1.LocalObjectTemplate, namespace string, pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -227,7 +526,7 @@ func (webhook *ClusterClass) validateTemplate(in *clusterv1.LocalObjectTemplate,
 	if in.Ref == nil {
 		return field.ErrorList{field.Invalid(
 			pathPrefix.Child("ref"),
-			in.Ref.Name,
+			in.Ref,
 			"cannot be nil",
 		)}
 	}
@@ -351,13 +650,17 @@ func (webhook *ClusterClass) validateTemplatesAreCompatible(old, in clusterv1.Lo
 	return allErrs
 }
 
-// classNames returns the set of MachineDeployment class names.
-func (webhook *ClusterClass) classNamesFromWorkerClass(w clusterv1.WorkersClass) sets.String {
-	classes := sets.NewString()
+// classNamesFromWorkerClass returns the sets of MachineDeployment and MachinePool class names.
+func (webhook *ClusterClass) classNamesFromWorkerClass(w clusterv1.WorkersClass) (sets.String, sets.String) {
+	machineDeploymentClasses := sets.NewString()
 	for _, class := range w.MachineDeployments {
-		classes.Insert(class.Class)
+		machineDeploymentClasses.Insert(class.Class)
+	}
+	machinePoolClasses := sets.NewString()
+	for _, class := range w.MachinePools {
+		machinePoolClasses.Insert(class.Class)
 	}
-	return classes
+	return machineDeploymentClasses, machinePoolClasses
 }
 
 func (webhook *ClusterClass) validateUniqueClasses(w clusterv1.WorkersClass, pathPrefix *field.Path) field.ErrorList {
@@ -377,5 +680,211 @@ func (webhook *ClusterClass) validateUniqueClasses(w clusterv1.WorkersClass, pat
 		classes.Insert(class.Class)
 	}
 
+	poolClasses := sets.NewString()
+	for i, class := range w.MachinePools {
+		if poolClasses.Has(class.Class) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					pathPrefix.Child("machinePools").Index(i).Child("class"),
+					class.Class,
+					fmt.Sprintf("MachinePool class should be unique. MachinePool with class %q is defined more than once.", class.Class),
+				),
+			)
+		}
+		if classes.Has(class.Class) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					pathPrefix.Child("machinePools").Index(i).Child("class"),
+					class.Class,
+					fmt.Sprintf("MachinePool class should be unique. A MachineDeployment class with class %q is already defined.", class.Class),
+				),
+			)
+		}
+		poolClasses.Insert(class.Class)
+	}
+
+	return allErrs
+}
+
+func (webhook *ClusterClass) validateMachineDeploymentClassesMetadata(w clusterv1.WorkersClass, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, class := range w.MachineDeployments {
+		if err := metadata.ValidateMachineDeploymentClassMetadata(class.Template.Metadata); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(
+					pathPrefix.Child("machineDeployments").Index(i).Child("template", "metadata"),
+					class.Template.Metadata,
+					fmt.Sprintf("invalid template tokens: %v", err),
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+func (webhook *ClusterClass) validateMachineDeploymentClassConstraints(w clusterv1.WorkersClass, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, class := range w.MachineDeployments {
+		if class.Constraints == nil {
+			continue
+		}
+		fldPath := pathPrefix.Child("machineDeployments").Index(i).Child("constraints")
+		c := class.Constraints
+
+		if c.MinReplicas != nil && c.MaxReplicas != nil && *c.MinReplicas > *c.MaxReplicas {
+			allErrs = append(allErrs,
+				field.Invalid(
+					fldPath.Child("maxReplicas"),
+					*c.MaxReplicas,
+					fmt.Sprintf("must be greater than or equal to minReplicas (%d)", *c.MinReplicas),
+				),
+			)
+		}
+
+		var minVersion, maxVersion *semver.Version
+		if c.MinVersion != nil {
+			v, err := semver.ParseTolerant(*c.MinVersion)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("minVersion"), *c.MinVersion, "is not a valid version"))
+			} else {
+				minVersion = &v
+			}
+		}
+		if c.MaxVersion != nil {
+			v, err := semver.ParseTolerant(*c.MaxVersion)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("maxVersion"), *c.MaxVersion, "is not a valid version"))
+			} else {
+				maxVersion = &v
+			}
+		}
+		if minVersion != nil && maxVersion != nil && minVersion.GT(*maxVersion) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					fldPath.Child("maxVersion"),
+					*c.MaxVersion,
+					fmt.Sprintf("must be greater than or equal to minVersion (%s)", *c.MinVersion),
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+func (webhook *ClusterClass) validateEnabledIf(in *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, class := range in.Spec.Workers.MachineDeployments {
+		if class.EnabledIf == nil {
+			continue
+		}
+		if err := enabledif.Validate(*class.EnabledIf); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "workers", "machineDeployments").Index(i).Child("enabledIf"),
+					*class.EnabledIf,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	for i, patch := range in.Spec.Patches {
+		if patch.EnabledIf == nil {
+			continue
+		}
+		if err := enabledif.Validate(*patch.EnabledIf); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "patches").Index(i).Child("enabledIf"),
+					*patch.EnabledIf,
+					err.Error(),
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+func (webhook *ClusterClass) validateVariableNames(in *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, variable := range in.Spec.Variables {
+		if variable.Name == builtinVariablePrefix || strings.HasPrefix(variable.Name, builtinVariablePrefix+".") {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "variables").Index(i).Child("name"),
+					variable.Name,
+					fmt.Sprintf("%q is reserved for builtin variables", builtinVariablePrefix),
+				),
+			)
+		}
+	}
+
+	return allErrs
+}
+
+func (webhook *ClusterClass) validateVariablesDiscovery(in *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if in.Spec.VariablesDiscovery == nil {
+		return allErrs
+	}
+
+	// NOTE: Variables discovery is behind the ClusterClassVariablesDiscovery feature gate flag; the web hook
+	// must prevent the usage of variablesDiscovery in case the feature flag is disabled.
+	if !feature.Gates.Enabled(feature.ClusterClassVariablesDiscovery) {
+		return append(allErrs,
+			field.Forbidden(field.NewPath("spec", "variablesDiscovery"), "can be set only if the ClusterClassVariablesDiscovery feature flag is enabled"),
+		)
+	}
+
+	// Only the hooks registered by the administrator of the management cluster can be called, so users allowed to
+	// create ClusterClasses cannot make the controller send requests to arbitrary endpoints.
+	fldPath := field.NewPath("spec", "variablesDiscovery", "url")
+	if !sets.NewString(webhook.VariablesDiscoveryURLs...).Has(in.Spec.VariablesDiscovery.URL) {
+		return append(allErrs, field.NotSupported(fldPath, in.Spec.VariablesDiscovery.URL, webhook.VariablesDiscoveryURLs))
+	}
+	u, err := url.Parse(in.Spec.VariablesDiscovery.URL)
+	if err != nil {
+		return append(allErrs, field.Invalid(fldPath, in.Spec.VariablesDiscovery.URL, fmt.Sprintf("must be a valid URL: %v", err)))
+	}
+	if u.Scheme != "https" {
+		allErrs = append(allErrs, field.Invalid(fldPath, in.Spec.VariablesDiscovery.URL, "must use the https scheme"))
+	}
+	if u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath, in.Spec.VariablesDiscovery.URL, "must have a host"))
+	}
+
+	return allErrs
+}
+
+func (webhook *ClusterClass) validatePatchValueTemplates(in *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, patch := range in.Spec.Patches {
+		for j, definition := range patch.Definitions {
+			for k, jsonPatch := range definition.JSONPatches {
+				if jsonPatch.ValueFrom == nil || jsonPatch.ValueFrom.Template == nil {
+					continue
+				}
+				if _, err := template.New("valueTemplate").Parse(*jsonPatch.ValueFrom.Template); err != nil {
+					allErrs = append(allErrs,
+						field.Invalid(
+							field.NewPath("spec", "patches").Index(i).Child("definitions").Index(j).Child("jsonPatches").Index(k).Child("valueFrom", "template"),
+							*jsonPatch.ValueFrom.Template,
+							err.Error(),
+						),
+					)
+				}
+			}
+		}
+	}
+
 	return allErrs
 }
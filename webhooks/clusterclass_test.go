@@ -18,15 +18,22 @@ package webhooks
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilfeature "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/test/builder"
+	utilwebhooks "sigs.k8s.io/cluster-api/util/webhooks"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var (
@@ -73,8 +80,8 @@ func TestClusterClassDefaultNamespaces(t *testing.T) {
 		},
 	}
 
-	webhook := &ClusterClass{}
-	t.Run("for ClusterClass", customDefaultValidateTest(ctx, in, webhook))
+	webhook := &ClusterClass{Client: fake.NewClientBuilder().WithScheme(fakeScheme).Build()}
+	t.Run("for ClusterClass", utilwebhooks.CustomDefaultValidateTest(ctx, in, webhook))
 
 	g := NewWithT(t)
 	g.Expect(webhook.Default(ctx, in)).To(Succeed())
@@ -198,6 +205,7 @@ func TestClusterClassValidation(t *testing.T) {
 	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to create or update ClusterClasses.
 	// Enabling the feature flag temporarily for this test.
 	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassVariablesDiscovery, true)()
 
 	ref := &corev1.ObjectReference{
 		APIVersion: "group.test.io/foo",
@@ -863,6 +871,295 @@ func TestClusterClassValidation(t *testing.T) {
 			expectErr: true,
 		},
 
+		// metadata template tokens tests
+		{
+			name: "create pass if machine deployment class metadata has valid template tokens",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					Workers: clusterv1.WorkersClass{
+						MachineDeployments: []clusterv1.MachineDeploymentClass{
+							{
+								Class: "aa",
+								Template: clusterv1.MachineDeploymentClassTemplate{
+									Metadata: clusterv1.ObjectMeta{
+										Labels:      map[string]string{"pool": "{{ .cluster.name }}-{{ .machineDeployment.topologyName }}"},
+										Annotations: map[string]string{"class": "{{ .machineDeployment.class }}"},
+									},
+									Bootstrap:      clusterv1.LocalObjectTemplate{Ref: ref},
+									Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "create fail if machine deployment class metadata has invalid template tokens",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					Workers: clusterv1.WorkersClass{
+						MachineDeployments: []clusterv1.MachineDeploymentClass{
+							{
+								Class: "aa",
+								Template: clusterv1.MachineDeploymentClassTemplate{
+									Metadata: clusterv1.ObjectMeta{
+										Labels: map[string]string{"pool": "{{ .cluster.unknown }}"},
+									},
+									Bootstrap:      clusterv1.LocalObjectTemplate{Ref: ref},
+									Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+
+		// enabledIf tests
+		{
+			name: "create pass if enabledIf templates are valid",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					Workers: clusterv1.WorkersClass{
+						MachineDeployments: []clusterv1.MachineDeploymentClass{
+							{
+								Class:     "aa",
+								EnabledIf: pointer.String("{{ .gpu }}"),
+								Template: clusterv1.MachineDeploymentClassTemplate{
+									Bootstrap:      clusterv1.LocalObjectTemplate{Ref: ref},
+									Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+								},
+							},
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name:      "gpu",
+							EnabledIf: pointer.String(`{{ if eq .builtin.cluster.name "gpu" }}true{{ end }}`),
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "create fail if machine deployment class enabledIf is not a valid template",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					Workers: clusterv1.WorkersClass{
+						MachineDeployments: []clusterv1.MachineDeploymentClass{
+							{
+								Class:     "aa",
+								EnabledIf: pointer.String("{{ .gpu "),
+								Template: clusterv1.MachineDeploymentClassTemplate{
+									Bootstrap:      clusterv1.LocalObjectTemplate{Ref: ref},
+									Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "create fail if patch enabledIf is not a valid template",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name:      "gpu",
+							EnabledIf: pointer.String("{{ .gpu "),
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+
+		// builtin variables tests
+		{
+			name: "create fail if a variable uses the builtin prefix",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name: "builtin.cluster.name",
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+
+		// variables discovery tests
+		{
+			name: "create pass if the variables discovery URL is valid",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					VariablesDiscovery: &clusterv1.VariablesDiscovery{
+						URL: "https://variables.example.com/discover",
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "create fail if the variables discovery URL does not use https",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					VariablesDiscovery: &clusterv1.VariablesDiscovery{
+						URL: "http://variables.example.com/discover",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "create fail if the variables discovery URL has no host",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					VariablesDiscovery: &clusterv1.VariablesDiscovery{
+						URL: "https:///discover",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			name: "create pass if patch value templates are valid",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					Variables: []clusterv1.ClusterClassVariable{
+						{
+							Name: "builtinImage",
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "image",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:        "add",
+											Path:      "/spec/template/spec/image",
+											ValueFrom: &clusterv1.JSONPatchValue{Template: pointer.String("{{ .builtinImage }}-{{ .builtin.machineDeployment.version }}")},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "create fail if a patch value template is not a valid template",
+			in: &clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+				},
+				Spec: clusterv1.ClusterClassSpec{
+					Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "image",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:        "add",
+											Path:      "/spec/template/spec/image",
+											ValueFrom: &clusterv1.JSONPatchValue{Template: pointer.String("{{ .builtin.cluster.name ")},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+
 		/*
 			UPDATE Tests
 		*/
@@ -1447,7 +1744,9 @@ func TestClusterClassValidation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			webhook := &ClusterClass{}
+			webhook := &ClusterClass{
+				VariablesDiscoveryURLs: []string{"https://variables.example.com/discover", "http://variables.example.com/discover", "https:///discover"},
+			}
 			if tt.expectErr {
 				g.Expect(webhook.validate(tt.old, tt.in)).NotTo(Succeed())
 			} else {
@@ -1456,3 +1755,528 @@ func TestClusterClassValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterClassValidationVariablesDiscovery(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	ref := &corev1.ObjectReference{
+		APIVersion: "group.test.io/foo",
+		Kind:       "barTemplate",
+		Name:       "baz",
+		Namespace:  "default",
+	}
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+		},
+		Spec: clusterv1.ClusterClassSpec{
+			Infrastructure: clusterv1.LocalObjectTemplate{Ref: ref},
+			ControlPlane: clusterv1.ControlPlaneClass{
+				LocalObjectTemplate: clusterv1.LocalObjectTemplate{Ref: ref},
+			},
+			VariablesDiscovery: &clusterv1.VariablesDiscovery{
+				URL: "https://variables.example.com/discover",
+			},
+		},
+	}
+
+	tests := []struct {
+		name                   string
+		featureGateEnabled     bool
+		variablesDiscoveryURLs []string
+		expectErr              bool
+	}{
+		{
+			name:                   "create fail if the ClusterClassVariablesDiscovery feature gate is disabled",
+			featureGateEnabled:     false,
+			variablesDiscoveryURLs: []string{"https://variables.example.com/discover"},
+			expectErr:              true,
+		},
+		{
+			name:                   "create fail if the variables discovery URL is not registered",
+			featureGateEnabled:     true,
+			variablesDiscoveryURLs: []string{"https://other.example.com/discover"},
+			expectErr:              true,
+		},
+		{
+			name:               "create fail if no variables discovery URL is registered",
+			featureGateEnabled: true,
+			expectErr:          true,
+		},
+		{
+			name:                   "create pass if the variables discovery URL is registered",
+			featureGateEnabled:     true,
+			variablesDiscoveryURLs: []string{"https://other.example.com/discover", "https://variables.example.com/discover"},
+			expectErr:              false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassVariablesDiscovery, tt.featureGateEnabled)()
+
+			g := NewWithT(t)
+			webhook := &ClusterClass{VariablesDiscoveryURLs: tt.variablesDiscoveryURLs}
+			if tt.expectErr {
+				g.Expect(webhook.validate(nil, clusterClass)).NotTo(Succeed())
+			} else {
+				g.Expect(webhook.validate(nil, clusterClass)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterClassValidationMachineHealthChecks(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	unhealthyConditions := []clusterv1.UnhealthyCondition{
+		{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+	}
+
+	tests := []struct {
+		name                      string
+		withMachineInfrastructure bool
+		controlPlaneMHC           *clusterv1.MachineHealthCheckClass
+		machineDeploymentClassMHC *clusterv1.MachineHealthCheckClass
+		expectErr                 bool
+	}{
+		{
+			name:                      "Accept valid MachineHealthChecks",
+			withMachineInfrastructure: true,
+			controlPlaneMHC: &clusterv1.MachineHealthCheckClass{
+				UnhealthyConditions: unhealthyConditions,
+				NodeStartupTimeout:  &metav1.Duration{Duration: 0},
+			},
+			machineDeploymentClassMHC: &clusterv1.MachineHealthCheckClass{
+				UnhealthyConditions: unhealthyConditions,
+				MaxUnhealthy:        &intstr.IntOrString{Type: intstr.String, StrVal: "40%"},
+				RemediationTemplate: &corev1.ObjectReference{APIVersion: "group.test.io/foo", Kind: "barTemplate", Name: "baz"},
+			},
+		},
+		{
+			name:                      "Reject control plane MachineHealthCheck without machineInfrastructure",
+			withMachineInfrastructure: false,
+			controlPlaneMHC:           &clusterv1.MachineHealthCheckClass{UnhealthyConditions: unhealthyConditions},
+			expectErr:                 true,
+		},
+		{
+			name:                      "Reject MachineHealthCheck without unhealthyConditions",
+			withMachineInfrastructure: true,
+			machineDeploymentClassMHC: &clusterv1.MachineHealthCheckClass{},
+			expectErr:                 true,
+		},
+		{
+			name:                      "Reject MachineHealthCheck with nodeStartupTimeout lower than 30s",
+			withMachineInfrastructure: true,
+			controlPlaneMHC: &clusterv1.MachineHealthCheckClass{
+				UnhealthyConditions: unhealthyConditions,
+				NodeStartupTimeout:  &metav1.Duration{Duration: 10 * time.Second},
+			},
+			expectErr: true,
+		},
+		{
+			name:                      "Reject MachineHealthCheck with invalid maxUnhealthy",
+			withMachineInfrastructure: true,
+			machineDeploymentClassMHC: &clusterv1.MachineHealthCheckClass{
+				UnhealthyConditions: unhealthyConditions,
+				MaxUnhealthy:        &intstr.IntOrString{Type: intstr.String, StrVal: "foo"},
+			},
+			expectErr: true,
+		},
+		{
+			name:                      "Reject MachineHealthCheck with a remediationTemplate in another namespace",
+			withMachineInfrastructure: true,
+			machineDeploymentClassMHC: &clusterv1.MachineHealthCheckClass{
+				UnhealthyConditions: unhealthyConditions,
+				RemediationTemplate: &corev1.ObjectReference{APIVersion: "group.test.io/foo", Kind: "barTemplate", Name: "baz", Namespace: "another-namespace"},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			classBuilder := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra").Build()).
+				WithControlPlaneTemplate(builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp").Build()).
+				WithWorkerMachineDeploymentClasses([]clusterv1.MachineDeploymentClass{
+					*builder.MachineDeploymentClass("md-class").
+						WithInfrastructureTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "md-infra").Build()).
+						WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "md-bootstrap").Build()).
+						Build(),
+				})
+			if tt.withMachineInfrastructure {
+				classBuilder = classBuilder.WithControlPlaneInfrastructureMachineTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cp-infra").Build())
+			}
+			in := classBuilder.Build()
+			in.Spec.ControlPlane.MachineHealthCheck = tt.controlPlaneMHC
+			in.Spec.Workers.MachineDeployments[0].MachineHealthCheck = tt.machineDeploymentClassMHC
+
+			webhook := &ClusterClass{}
+			if tt.expectErr {
+				g.Expect(webhook.validate(nil, in)).NotTo(Succeed())
+			} else {
+				g.Expect(webhook.validate(nil, in)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterClassValidationMachinePools(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	mpClass := func(class, infraKind string) clusterv1.MachinePoolClass {
+		infra := builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "mp-infra").Build()
+		infra.SetKind(infraKind)
+		return *builder.MachinePoolClass(class).
+			WithInfrastructureTemplate(infra).
+			WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "mp-bootstrap").Build()).
+			Build()
+	}
+
+	mdClass := func(class string) clusterv1.MachineDeploymentClass {
+		return *builder.MachineDeploymentClass(class).
+			WithInfrastructureTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "md-infra").Build()).
+			WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "md-bootstrap").Build()).
+			Build()
+	}
+
+	tests := []struct {
+		name                     string
+		machinePoolEnabled       bool
+		oldMachinePoolClasses    []clusterv1.MachinePoolClass
+		machineDeploymentClasses []clusterv1.MachineDeploymentClass
+		machinePoolClasses       []clusterv1.MachinePoolClass
+		expectErr                bool
+	}{
+		{
+			name:               "Accept valid MachinePool classes",
+			machinePoolEnabled: true,
+			machinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+				mpClass("mp-class2", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+		},
+		{
+			name:               "Reject MachinePool classes if the MachinePool feature flag is disabled",
+			machinePoolEnabled: false,
+			machinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+			expectErr: true,
+		},
+		{
+			name:               "Reject duplicate MachinePool classes",
+			machinePoolEnabled: true,
+			machinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+			expectErr: true,
+		},
+		{
+			name:                     "Reject MachinePool classes with the same name of MachineDeployment classes",
+			machinePoolEnabled:       true,
+			machineDeploymentClasses: []clusterv1.MachineDeploymentClass{mdClass("class1")},
+			machinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+			expectErr: true,
+		},
+		{
+			name:               "Accept updates adding MachinePool classes",
+			machinePoolEnabled: true,
+			oldMachinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+			machinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+				mpClass("mp-class2", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+		},
+		{
+			name:               "Reject updates removing MachinePool classes",
+			machinePoolEnabled: true,
+			oldMachinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+				mpClass("mp-class2", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+			machinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+			expectErr: true,
+		},
+		{
+			name:               "Reject updates changing the infrastructure template kind of MachinePool classes",
+			machinePoolEnabled: true,
+			oldMachinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+			machinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", "Other"+builder.GenericInfrastructureMachinePoolTemplateKind),
+			},
+			expectErr: true,
+		},
+		{
+			name:               "Reject MachinePool classes referencing objects which are not templates",
+			machinePoolEnabled: true,
+			machinePoolClasses: []clusterv1.MachinePoolClass{
+				mpClass("mp-class1", builder.GenericInfrastructureMachinePoolKind),
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePool, tt.machinePoolEnabled)()
+			g := NewWithT(t)
+
+			in := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra").Build()).
+				WithControlPlaneTemplate(builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp").Build()).
+				WithWorkerMachineDeploymentClasses(tt.machineDeploymentClasses).
+				WithWorkerMachinePoolClasses(tt.machinePoolClasses).
+				Build()
+
+			var old *clusterv1.ClusterClass
+			if tt.oldMachinePoolClasses != nil {
+				old = in.DeepCopy()
+				old.Spec.Workers.MachinePools = tt.oldMachinePoolClasses
+			}
+
+			webhook := &ClusterClass{}
+			if tt.expectErr {
+				g.Expect(webhook.validate(old, in)).NotTo(Succeed())
+			} else {
+				g.Expect(webhook.validate(old, in)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterClassValidationMachineDeploymentConstraints(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	tests := []struct {
+		name        string
+		constraints *clusterv1.MachineDeploymentClassConstraints
+		expectErr   bool
+	}{
+		{
+			name: "Accept valid constraints",
+			constraints: &clusterv1.MachineDeploymentClassConstraints{
+				MinReplicas: pointer.Int32(1),
+				MaxReplicas: pointer.Int32(1),
+				MinVersion:  pointer.String("v1.21.0"),
+				MaxVersion:  pointer.String("v1.22.99"),
+			},
+		},
+		{
+			name: "Reject minReplicas greater than maxReplicas",
+			constraints: &clusterv1.MachineDeploymentClassConstraints{
+				MinReplicas: pointer.Int32(3),
+				MaxReplicas: pointer.Int32(2),
+			},
+			expectErr: true,
+		},
+		{
+			name: "Reject invalid versions",
+			constraints: &clusterv1.MachineDeploymentClassConstraints{
+				MinVersion: pointer.String("not-a-version"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "Reject minVersion greater than maxVersion",
+			constraints: &clusterv1.MachineDeploymentClassConstraints{
+				MinVersion: pointer.String("v1.22.0"),
+				MaxVersion: pointer.String("v1.21.0"),
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			in := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra").Build()).
+				WithControlPlaneTemplate(builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp").Build()).
+				WithWorkerMachineDeploymentClasses([]clusterv1.MachineDeploymentClass{
+					*builder.MachineDeploymentClass("md-class").
+						WithInfrastructureTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "md-infra").Build()).
+						WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "md-bootstrap").Build()).
+						WithConstraints(tt.constraints).
+						Build(),
+				}).
+				Build()
+
+			webhook := &ClusterClass{}
+			if tt.expectErr {
+				g.Expect(webhook.validate(nil, in)).NotTo(Succeed())
+			} else {
+				g.Expect(webhook.validate(nil, in)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterClassValidationWithInheritance(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassInheritance, true)()
+
+	base := builder.ClusterClass(metav1.NamespaceDefault, "base").
+		WithInfrastructureClusterTemplate(builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra").Build()).
+		WithControlPlaneTemplate(builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp").Build()).
+		WithWorkerMachineDeploymentClasses([]clusterv1.MachineDeploymentClass{
+			*builder.MachineDeploymentClass("md-class").
+				WithInfrastructureTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "md-infra").Build()).
+				WithBootstrapTemplate(builder.BootstrapTemplate(metav1.NamespaceDefault, "md-bootstrap").Build()).
+				Build(),
+		}).
+		Build()
+	derived := func(name, inherits string) *clusterv1.ClusterClass {
+		cc := builder.ClusterClass(metav1.NamespaceDefault, name).Build()
+		cc.Spec.Inherits = inherits
+		return cc
+	}
+
+	tests := []struct {
+		name      string
+		objs      []client.Object
+		in        *clusterv1.ClusterClass
+		expectErr bool
+	}{
+		{
+			name: "Accept a ClusterClass inheriting its templates from another ClusterClass",
+			objs: []client.Object{base},
+			in:   derived("derived", "base"),
+		},
+		{
+			name:      "Reject a ClusterClass inheriting from a ClusterClass which does not exist",
+			in:        derived("derived", "base"),
+			expectErr: true,
+		},
+		{
+			name:      "Reject a ClusterClass inheriting from a ClusterClass without templates",
+			objs:      []client.Object{derived("empty", "")},
+			in:        derived("derived", "empty"),
+			expectErr: true,
+		},
+		{
+			name:      "Reject a ClusterClass inheriting from itself",
+			in:        derived("derived", "derived"),
+			expectErr: true,
+		},
+		{
+			name:      "Reject an inheritance cycle",
+			objs:      []client.Object{base, derived("a", "b"), derived("b", "a")},
+			in:        derived("a", "b"),
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &ClusterClass{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(tt.objs...).Build()}
+			if tt.expectErr {
+				g.Expect(webhook.ValidateCreate(ctx, tt.in)).NotTo(Succeed())
+			} else {
+				g.Expect(webhook.ValidateCreate(ctx, tt.in)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterClassValidationWithInheritanceFeatureGated(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+	g := NewWithT(t)
+
+	base := builder.ClusterClass(metav1.NamespaceDefault, "base").
+		WithInfrastructureClusterTemplate(builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra").Build()).
+		WithControlPlaneTemplate(builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp").Build()).
+		Build()
+	derived := builder.ClusterClass(metav1.NamespaceDefault, "derived").Build()
+	derived.Spec.Inherits = base.Name
+
+	// Inheriting from a ClusterClass is rejected if the ClusterClassInheritance feature flag is disabled.
+	webhook := &ClusterClass{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(base).Build()}
+	g.Expect(webhook.ValidateCreate(ctx, derived)).NotTo(Succeed())
+}
+
+func TestClusterClassValidateUpdateWithInheritance(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterClassInheritance, true)()
+
+	base := builder.ClusterClass(metav1.NamespaceDefault, "base").
+		WithInfrastructureClusterTemplate(builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra").Build()).
+		WithControlPlaneTemplate(builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp").Build()).
+		WithControlPlaneInfrastructureMachineTemplate(builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cp-infra").Build()).
+		Build()
+	// The derived ClusterClass defines a control plane MachineHealthCheck, which requires the control plane
+	// machine infrastructure inherited from the base ClusterClass.
+	derived := builder.ClusterClass(metav1.NamespaceDefault, "derived").Build()
+	derived.Spec.Inherits = base.Name
+	derived.Spec.ControlPlane.MachineHealthCheck = &clusterv1.MachineHealthCheckClass{
+		UnhealthyConditions: []clusterv1.UnhealthyCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionUnknown, Timeout: metav1.Duration{Duration: 5 * time.Minute}},
+		},
+	}
+	// The ClusterClass inheriting from the derived ClusterClass is validated too.
+	overlay := builder.ClusterClass(metav1.NamespaceDefault, "overlay").Build()
+	overlay.Spec.Inherits = derived.Name
+
+	tests := []struct {
+		name      string
+		update    func(base *clusterv1.ClusterClass)
+		expectErr bool
+	}{
+		{
+			name: "Accept changes to an inherited ClusterClass which are valid for the ClusterClasses inheriting from it",
+			update: func(base *clusterv1.ClusterClass) {
+				base.Spec.ControlPlane.Metadata.Labels = map[string]string{"foo": "bar"}
+			},
+		},
+		{
+			name: "Reject changes to an inherited ClusterClass which are not valid for the ClusterClasses inheriting from it",
+			update: func(base *clusterv1.ClusterClass) {
+				base.Spec.ControlPlane.MachineInfrastructure = nil
+			},
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &ClusterClass{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(base, derived, overlay).Build()}
+
+			updated := base.DeepCopy()
+			tt.update(updated)
+			if tt.expectErr {
+				g.Expect(webhook.ValidateUpdate(ctx, base, updated)).NotTo(Succeed())
+			} else {
+				g.Expect(webhook.ValidateUpdate(ctx, base, updated)).To(Succeed())
+			}
+		})
+	}
+}
+
+func TestClusterClassValidateDeleteWithInheritance(t *testing.T) {
+	g := NewWithT(t)
+
+	base := builder.ClusterClass(metav1.NamespaceDefault, "base").Build()
+	derived := builder.ClusterClass(metav1.NamespaceDefault, "derived").Build()
+	derived.Spec.Inherits = base.Name
+
+	webhook := &ClusterClass{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(base, derived).Build()}
+
+	// A ClusterClass inherited by other ClusterClasses cannot be deleted.
+	g.Expect(webhook.ValidateDelete(ctx, base)).NotTo(Succeed())
+	// A ClusterClass not inherited by other ClusterClasses can be deleted.
+	g.Expect(webhook.ValidateDelete(ctx, derived)).To(Succeed())
+
+	// Without a client, inheriting ClusterClasses cannot be checked and deletion is allowed.
+	g.Expect((&ClusterClass{}).ValidateDelete(ctx, base)).To(Succeed())
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// clusterResourceSetNamespaceSelectorWebhookPath is the path of the ClusterResourceSet namespace selector validation webhook;
+// it is distinct from the path of the ClusterResourceSet webhook implemented in the API package, which does not require a client.
+const clusterResourceSetNamespaceSelectorWebhookPath = "/validate-addons-cluster-x-k8s-io-v1beta1-clusterresourceset-namespaceselector"
+
+// SetupWebhookWithManager sets up ClusterResourceSet webhooks.
+func (webhook *ClusterResourceSet) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(clusterResourceSetNamespaceSelectorWebhookPath, &admission.Webhook{Handler: webhook})
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-addons-cluster-x-k8s-io-v1beta1-clusterresourceset-namespaceselector,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=addons.cluster.x-k8s.io,resources=clusterresourcesets,versions=v1beta1,name=validation-namespaceselector.clusterresourceset.addons.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// ClusterResourceSet implements a validating webhook allowing only users with permissions to manage ClusterResourceSets
+// in all namespaces to create or update ClusterResourceSets with a namespace selector; this prevents users with permissions
+// limited to a namespace from applying resources to Clusters in other namespaces.
+type ClusterResourceSet struct {
+	Client client.Client
+
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &ClusterResourceSet{}
+var _ admission.DecoderInjector = &ClusterResourceSet{}
+
+// InjectDecoder implements admission.DecoderInjector.
+func (webhook *ClusterResourceSet) InjectDecoder(d *admission.Decoder) error {
+	webhook.decoder = d
+	return nil
+}
+
+// Handle implements admission.Handler.
+func (webhook *ClusterResourceSet) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+
+	crs := &addonsv1.ClusterResourceSet{}
+	if err := webhook.decoder.Decode(req, crs); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode ClusterResourceSet"))
+	}
+
+	var oldCRS *addonsv1.ClusterResourceSet
+	if req.Operation == admissionv1.Update {
+		oldCRS = &addonsv1.ClusterResourceSet{}
+		if err := webhook.decoder.DecodeRaw(req.OldObject, oldCRS); err != nil {
+			return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode old ClusterResourceSet"))
+		}
+	}
+
+	if err := webhook.validate(ctx, req, oldCRS, crs); err != nil {
+		if apiErr, ok := err.(apierrors.APIStatus); ok {
+			status := apiErr.Status()
+			return admission.Response{AdmissionResponse: admissionv1.AdmissionResponse{Allowed: false, Result: &status}}
+		}
+		return admission.Denied(err.Error())
+	}
+	return admission.Allowed("")
+}
+
+func (webhook *ClusterResourceSet) validate(ctx context.Context, req admission.Request, old, new *addonsv1.ClusterResourceSet) error {
+	// ClusterResourceSets without a namespace selector only apply resources to Clusters in their own namespace.
+	if new.Spec.NamespaceSelector == nil {
+		return nil
+	}
+
+	// Only check permissions when the spec changes, so e.g. the controller can add or remove finalizers.
+	if old != nil && reflect.DeepEqual(old.Spec, new.Spec) {
+		return nil
+	}
+
+	verb := strings.ToLower(string(req.Operation))
+	allowed, err := webhook.isAllowedInAllNamespaces(ctx, req, verb)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	if allowed {
+		return nil
+	}
+
+	return apierrors.NewInvalid(addonsv1.GroupVersion.WithKind("ClusterResourceSet").GroupKind(), new.Name, field.ErrorList{
+		field.Forbidden(
+			field.NewPath("spec", "namespaceSelector"),
+			fmt.Sprintf("user %q must be allowed to %s ClusterResourceSets in all namespaces to select Clusters in other namespaces", req.UserInfo.Username, verb),
+		),
+	})
+}
+
+// isAllowedInAllNamespaces checks if the user making the request is allowed to perform the verb on ClusterResourceSets in all namespaces.
+func (webhook *ClusterResourceSet) isAllowedInAllNamespaces(ctx context.Context, req admission.Request, verb string) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: "",
+				Verb:      verb,
+				Group:     addonsv1.GroupVersion.Group,
+				Resource:  "clusterresourcesets",
+			},
+		},
+	}
+	if err := webhook.Client.Create(ctx, sar); err != nil {
+		return false, errors.Wrap(err, "failed to create SubjectAccessReview")
+	}
+	return sar.Status.Allowed, nil
+}
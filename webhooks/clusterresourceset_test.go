@@ -0,0 +1,172 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestClusterResourceSetNamespaceSelectorValidation(t *testing.T) {
+	newCRS := func(namespaceSelector *metav1.LabelSelector, resources ...string) *addonsv1.ClusterResourceSet {
+		crs := &addonsv1.ClusterResourceSet{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: addonsv1.GroupVersion.String(),
+				Kind:       "ClusterResourceSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "addons",
+				Name:      "calico",
+			},
+			Spec: addonsv1.ClusterResourceSetSpec{
+				ClusterSelector:   metav1.LabelSelector{MatchLabels: map[string]string{"cni": "calico"}},
+				NamespaceSelector: namespaceSelector,
+			},
+		}
+		for _, r := range resources {
+			crs.Spec.Resources = append(crs.Spec.Resources, addonsv1.ResourceRef{Kind: "ConfigMap", Name: r})
+		}
+		return crs
+	}
+	namespaceSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": ""}}
+
+	tests := []struct {
+		name        string
+		operation   admissionv1.Operation
+		old         *addonsv1.ClusterResourceSet
+		new         *addonsv1.ClusterResourceSet
+		allowed     bool
+		wantReviews int
+		wantAllowed bool
+	}{
+		{
+			name:        "allows creating a ClusterResourceSet without a namespace selector without checking permissions",
+			operation:   admissionv1.Create,
+			new:         newCRS(nil),
+			allowed:     false,
+			wantReviews: 0,
+			wantAllowed: true,
+		},
+		{
+			name:        "allows creating a ClusterResourceSet with a namespace selector when the user is allowed in all namespaces",
+			operation:   admissionv1.Create,
+			new:         newCRS(namespaceSelector),
+			allowed:     true,
+			wantReviews: 1,
+			wantAllowed: true,
+		},
+		{
+			name:        "denies creating a ClusterResourceSet with a namespace selector when the user is not allowed in all namespaces",
+			operation:   admissionv1.Create,
+			new:         newCRS(namespaceSelector),
+			allowed:     false,
+			wantReviews: 1,
+			wantAllowed: false,
+		},
+		{
+			name:        "denies changing the resources of a ClusterResourceSet with a namespace selector when the user is not allowed in all namespaces",
+			operation:   admissionv1.Update,
+			old:         newCRS(namespaceSelector, "calico"),
+			new:         newCRS(namespaceSelector, "calico", "calico-crds"),
+			allowed:     false,
+			wantReviews: 1,
+			wantAllowed: false,
+		},
+		{
+			name:        "allows updating a ClusterResourceSet with a namespace selector when the spec does not change",
+			operation:   admissionv1.Update,
+			old:         newCRS(namespaceSelector, "calico"),
+			new:         newCRS(namespaceSelector, "calico"),
+			allowed:     false,
+			wantReviews: 0,
+			wantAllowed: true,
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = addonsv1.AddToScheme(scheme)
+	_ = authorizationv1.AddToScheme(scheme)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := &fakeSubjectAccessReviewClient{
+				Client:  fake.NewClientBuilder().WithScheme(scheme).Build(),
+				allowed: tt.allowed,
+			}
+			webhook := &ClusterResourceSet{Client: c}
+			decoder, err := admission.NewDecoder(scheme)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(webhook.InjectDecoder(decoder)).To(Succeed())
+
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: tt.operation,
+				Object:    runtime.RawExtension{Raw: mustMarshal(g, tt.new)},
+				UserInfo:  authenticationv1.UserInfo{Username: "tenant-admin", Groups: []string{"tenants"}},
+			}}
+			if tt.old != nil {
+				req.OldObject = runtime.RawExtension{Raw: mustMarshal(g, tt.old)}
+			}
+
+			resp := webhook.Handle(context.Background(), req)
+			g.Expect(resp.Allowed).To(Equal(tt.wantAllowed))
+			g.Expect(c.reviews).To(HaveLen(tt.wantReviews))
+			for _, review := range c.reviews {
+				g.Expect(review.Spec.User).To(Equal("tenant-admin"))
+				g.Expect(review.Spec.Groups).To(Equal([]string{"tenants"}))
+				g.Expect(review.Spec.ResourceAttributes.Namespace).To(BeEmpty())
+				g.Expect(review.Spec.ResourceAttributes.Resource).To(Equal("clusterresourcesets"))
+			}
+		})
+	}
+}
+
+// fakeSubjectAccessReviewClient is a client answering to SubjectAccessReviews with a fixed result.
+type fakeSubjectAccessReviewClient struct {
+	client.Client
+
+	allowed bool
+	reviews []*authorizationv1.SubjectAccessReview
+}
+
+func (c *fakeSubjectAccessReviewClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if sar, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+		sar.Status.Allowed = c.allowed
+		c.reviews = append(c.reviews, sar)
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func mustMarshal(g *WithT, obj runtime.Object) []byte {
+	raw, err := json.Marshal(obj)
+	g.Expect(err).NotTo(HaveOccurred())
+	return raw
+}
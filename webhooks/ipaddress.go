@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// SetupWebhookWithManager sets up IPAddress webhooks.
+func (webhook *IPAddress) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&ipamv1.IPAddress{}).
+		WithValidator(webhook).
+		Complete()
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-ipam-cluster-x-k8s-io-v1alpha1-ipaddress,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=ipam.cluster.x-k8s.io,resources=ipaddresses,versions=v1alpha1,name=validation.ipaddress.ipam.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// IPAddress implements a validating webhook for IPAddress; it ensures IPAddresses are well-formed, that they
+// are created only for an existing IPAddressClaim referencing the same pool, and that they are not changed
+// after being created.
+type IPAddress struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &IPAddress{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *IPAddress) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ip, ok := obj.(*ipamv1.IPAddress)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an IPAddress but got a %T", obj))
+	}
+	return webhook.validate(ctx, ip)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *IPAddress) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	newIP, ok := newObj.(*ipamv1.IPAddress)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an IPAddress but got a %T", newObj))
+	}
+	oldIP, ok := oldObj.(*ipamv1.IPAddress)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an IPAddress but got a %T", oldObj))
+	}
+
+	if !reflect.DeepEqual(oldIP.Spec, newIP.Spec) {
+		return apierrors.NewInvalid(ipamv1.GroupVersion.WithKind("IPAddress").GroupKind(), newIP.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec"), "the spec of IPAddress is immutable"),
+		})
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *IPAddress) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (webhook *IPAddress) validate(ctx context.Context, ip *ipamv1.IPAddress) error {
+	var allErrs field.ErrorList
+	specPath := field.NewPath("spec")
+
+	addr := net.ParseIP(ip.Spec.Address)
+	if addr == nil {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("address"), ip.Spec.Address, "not a valid IP address"))
+	}
+
+	if addr != nil {
+		maxPrefix := 128
+		if addr.To4() != nil {
+			maxPrefix = 32
+		}
+		if ip.Spec.Prefix < 0 || ip.Spec.Prefix > maxPrefix {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("prefix"), ip.Spec.Prefix, fmt.Sprintf("must be between 0 and %d", maxPrefix)))
+		}
+	}
+
+	if ip.Spec.Gateway != "" {
+		gateway := net.ParseIP(ip.Spec.Gateway)
+		switch {
+		case gateway == nil:
+			allErrs = append(allErrs, field.Invalid(specPath.Child("gateway"), ip.Spec.Gateway, "not a valid IP address"))
+		case addr != nil && (gateway.To4() != nil) != (addr.To4() != nil):
+			allErrs = append(allErrs, field.Invalid(specPath.Child("gateway"), ip.Spec.Gateway, "must have the same IP family as the address"))
+		}
+	}
+
+	allErrs = append(allErrs, validatePoolRef(ip.Spec.PoolRef.APIGroup, specPath.Child("poolRef", "apiGroup"))...)
+
+	if len(allErrs) == 0 && webhook.Client != nil {
+		claim := &ipamv1.IPAddressClaim{}
+		if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: ip.Namespace, Name: ip.Spec.ClaimRef.Name}, claim); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return apierrors.NewInternalError(err)
+			}
+			allErrs = append(allErrs, field.Invalid(specPath.Child("claimRef", "name"), ip.Spec.ClaimRef.Name, "IPAddressClaim could not be found"))
+		} else if !reflect.DeepEqual(claim.Spec.PoolRef, ip.Spec.PoolRef) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("poolRef"), ip.Spec.PoolRef, "must match the poolRef of the referenced IPAddressClaim"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(ipamv1.GroupVersion.WithKind("IPAddress").GroupKind(), ip.Name, allErrs)
+}
+
+// validatePoolRef validates the API group of the pool referenced by an IPAddress or an IPAddressClaim;
+// pools are defined by IPAM providers, so the API group is required.
+func validatePoolRef(apiGroup *string, fldPath *field.Path) field.ErrorList {
+	if apiGroup == nil || *apiGroup == "" {
+		return field.ErrorList{field.Required(fldPath, "the API group of the pool must be set")}
+	}
+	return nil
+}
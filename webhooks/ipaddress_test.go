@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = ipamv1.AddToScheme(fakeScheme)
+}
+
+func TestIPAddressValidation(t *testing.T) {
+	poolRef := corev1.TypedLocalObjectReference{
+		APIGroup: pointer.String("ipam.example.com"),
+		Kind:     "IPPool",
+		Name:     "pool",
+	}
+	claim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: metav1.NamespaceDefault},
+		Spec:       ipamv1.IPAddressClaimSpec{PoolRef: poolRef},
+	}
+	newIPAddress := func(modify func(*ipamv1.IPAddress)) *ipamv1.IPAddress {
+		ip := &ipamv1.IPAddress{
+			ObjectMeta: metav1.ObjectMeta{Name: "address", Namespace: metav1.NamespaceDefault},
+			Spec: ipamv1.IPAddressSpec{
+				ClaimRef: corev1.LocalObjectReference{Name: "claim"},
+				PoolRef:  poolRef,
+				Address:  "10.0.0.10",
+				Prefix:   24,
+				Gateway:  "10.0.0.1",
+			},
+		}
+		if modify != nil {
+			modify(ip)
+		}
+		return ip
+	}
+
+	tests := []struct {
+		name      string
+		ip        *ipamv1.IPAddress
+		expectErr bool
+	}{
+		{
+			name:      "accepts a valid IPv4 address",
+			ip:        newIPAddress(nil),
+			expectErr: false,
+		},
+		{
+			name: "accepts a valid IPv6 address",
+			ip: newIPAddress(func(ip *ipamv1.IPAddress) {
+				ip.Spec.Address = "fd00::10"
+				ip.Spec.Prefix = 64
+				ip.Spec.Gateway = "fd00::1"
+			}),
+			expectErr: false,
+		},
+		{
+			name:      "accepts an address without gateway",
+			ip:        newIPAddress(func(ip *ipamv1.IPAddress) { ip.Spec.Gateway = "" }),
+			expectErr: false,
+		},
+		{
+			name:      "rejects an invalid address",
+			ip:        newIPAddress(func(ip *ipamv1.IPAddress) { ip.Spec.Address = "10.0.0.300" }),
+			expectErr: true,
+		},
+		{
+			name:      "rejects an IPv4 prefix greater than 32",
+			ip:        newIPAddress(func(ip *ipamv1.IPAddress) { ip.Spec.Prefix = 33 }),
+			expectErr: true,
+		},
+		{
+			name:      "rejects an invalid gateway",
+			ip:        newIPAddress(func(ip *ipamv1.IPAddress) { ip.Spec.Gateway = "gateway" }),
+			expectErr: true,
+		},
+		{
+			name:      "rejects a gateway of a different IP family",
+			ip:        newIPAddress(func(ip *ipamv1.IPAddress) { ip.Spec.Gateway = "fd00::1" }),
+			expectErr: true,
+		},
+		{
+			name:      "rejects a pool reference without API group",
+			ip:        newIPAddress(func(ip *ipamv1.IPAddress) { ip.Spec.PoolRef.APIGroup = nil }),
+			expectErr: true,
+		},
+		{
+			name:      "rejects an address for a missing claim",
+			ip:        newIPAddress(func(ip *ipamv1.IPAddress) { ip.Spec.ClaimRef.Name = "missing" }),
+			expectErr: true,
+		},
+		{
+			name:      "rejects an address from a pool not matching the claim",
+			ip:        newIPAddress(func(ip *ipamv1.IPAddress) { ip.Spec.PoolRef.Name = "other-pool" }),
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &IPAddress{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(claim).Build()}
+			err := webhook.ValidateCreate(ctx, tt.ip)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+
+	t.Run("rejects changes to the spec", func(t *testing.T) {
+		g := NewWithT(t)
+
+		webhook := &IPAddress{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(claim).Build()}
+		old := newIPAddress(nil)
+
+		updated := old.DeepCopy()
+		updated.Labels = map[string]string{"foo": "bar"}
+		g.Expect(webhook.ValidateUpdate(ctx, old, updated)).To(Succeed())
+
+		updated.Spec.Address = "10.0.0.11"
+		g.Expect(webhook.ValidateUpdate(ctx, old, updated)).ToNot(Succeed())
+	})
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1alpha1"
+)
+
+func TestIPAddressClaimValidation(t *testing.T) {
+	newClaim := func(apiGroup *string) *ipamv1.IPAddressClaim {
+		return &ipamv1.IPAddressClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "claim", Namespace: metav1.NamespaceDefault},
+			Spec: ipamv1.IPAddressClaimSpec{
+				PoolRef: corev1.TypedLocalObjectReference{
+					APIGroup: apiGroup,
+					Kind:     "IPPool",
+					Name:     "pool",
+				},
+			},
+		}
+	}
+
+	t.Run("validates the pool reference on create", func(t *testing.T) {
+		g := NewWithT(t)
+
+		webhook := &IPAddressClaim{}
+		g.Expect(webhook.ValidateCreate(ctx, newClaim(pointer.String("ipam.example.com")))).To(Succeed())
+		g.Expect(webhook.ValidateCreate(ctx, newClaim(nil))).ToNot(Succeed())
+		g.Expect(webhook.ValidateCreate(ctx, newClaim(pointer.String("")))).ToNot(Succeed())
+	})
+
+	t.Run("rejects changes to the spec", func(t *testing.T) {
+		g := NewWithT(t)
+
+		webhook := &IPAddressClaim{}
+		old := newClaim(pointer.String("ipam.example.com"))
+
+		updated := old.DeepCopy()
+		updated.Labels = map[string]string{"foo": "bar"}
+		g.Expect(webhook.ValidateUpdate(ctx, old, updated)).To(Succeed())
+
+		updated.Spec.PoolRef.Name = "other-pool"
+		g.Expect(webhook.ValidateUpdate(ctx, old, updated)).ToNot(Succeed())
+	})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// machineProviderIDWebhookPath is the path of the Machine ProviderID validation webhook; it is distinct from
+// the path of the Machine webhook implemented in the API package, which does not require a client.
+const machineProviderIDWebhookPath = "/validate-cluster-x-k8s-io-v1beta1-machine-providerid"
+
+// SetupWebhookWithManager sets up Machine webhooks.
+func (webhook *Machine) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(machineProviderIDWebhookPath, admission.WithCustomValidator(&clusterv1.Machine{}, webhook))
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cluster-x-k8s-io-v1beta1-machine-providerid,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=machines,versions=v1beta1,name=validation-providerid.machine.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// Machine implements a validating webhook ensuring the ProviderID of Machines is not reported by other Machines
+// in the same Cluster; duplicate ProviderIDs would lead Machines to be matched to the wrong Node.
+// NOTE: Malformed ProviderIDs are not rejected, given that some infrastructure providers could use ProviderIDs
+// not following the expected format; the Machine controller reports them with a Warning event.
+type Machine struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &Machine{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *Machine) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	m, ok := obj.(*clusterv1.Machine)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Machine but got a %T", obj))
+	}
+	return webhook.validate(ctx, nil, m)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *Machine) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	newM, ok := newObj.(*clusterv1.Machine)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Machine but got a %T", newObj))
+	}
+	oldM, ok := oldObj.(*clusterv1.Machine)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a Machine but got a %T", oldObj))
+	}
+	return webhook.validate(ctx, oldM, newM)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *Machine) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (webhook *Machine) validate(ctx context.Context, old, new *clusterv1.Machine) error {
+	if new.Spec.ProviderID == nil || *new.Spec.ProviderID == "" {
+		return nil
+	}
+
+	// Only validate the ProviderID when it changes, so Machines created before this webhook
+	// was introduced can still be updated.
+	if old != nil && old.Spec.ProviderID != nil && *old.Spec.ProviderID == *new.Spec.ProviderID {
+		return nil
+	}
+
+	// Duplicates cannot be detected for malformed ProviderIDs.
+	path := field.NewPath("spec", "providerID")
+	providerID, err := noderefutil.NewProviderID(*new.Spec.ProviderID)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Info("Skipping duplicate ProviderID check, ProviderID is malformed", "providerID", *new.Spec.ProviderID, "err", err.Error())
+		return nil
+	}
+
+	// Nothing to compare against if the Machine is being deleted or it isn't linked to a Cluster yet.
+	if !new.DeletionTimestamp.IsZero() || new.Spec.ClusterName == "" {
+		return nil
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := webhook.Client.List(ctx, machines, client.InNamespace(new.Namespace)); err != nil {
+		return apierrors.NewInternalError(errors.Wrap(err, "failed to list Machines"))
+	}
+
+	var allErrs field.ErrorList
+	for i := range machines.Items {
+		other := &machines.Items[i]
+		if other.Name == new.Name || other.Spec.ClusterName != new.Spec.ClusterName || other.Spec.ProviderID == nil || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		otherProviderID, err := noderefutil.NewProviderID(*other.Spec.ProviderID)
+		if err != nil {
+			continue
+		}
+		if otherProviderID.IndexKey() == providerID.IndexKey() {
+			allErrs = append(allErrs, field.Invalid(
+				path,
+				*new.Spec.ProviderID,
+				fmt.Sprintf("is already reported by Machine %s in Cluster %s", other.Name, new.Spec.ClusterName),
+			))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Machine").GroupKind(), new.Name, allErrs)
+}
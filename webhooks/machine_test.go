@@ -0,0 +1,112 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMachineProviderIDValidation(t *testing.T) {
+	newMachine := func(name, clusterName string, providerID *string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: clusterName,
+				ProviderID:  providerID,
+			},
+		}
+	}
+	existing := newMachine("machine-1", "cluster-1", pointer.String("aws:///us-east-1/i-1"))
+
+	tests := []struct {
+		name      string
+		machine   *clusterv1.Machine
+		expectErr bool
+	}{
+		{
+			name:      "accepts Machines without a ProviderID",
+			machine:   newMachine("machine-2", "cluster-1", nil),
+			expectErr: false,
+		},
+		{
+			name:      "accepts unique ProviderIDs",
+			machine:   newMachine("machine-2", "cluster-1", pointer.String("aws:///us-east-1/i-2")),
+			expectErr: false,
+		},
+		{
+			name:      "accepts duplicate ProviderIDs in different clusters",
+			machine:   newMachine("machine-2", "cluster-2", pointer.String("aws:///us-east-1/i-1")),
+			expectErr: false,
+		},
+		{
+			name:      "rejects duplicate ProviderIDs",
+			machine:   newMachine("machine-2", "cluster-1", pointer.String("aws:///us-east-1/i-1")),
+			expectErr: true,
+		},
+		{
+			name:      "rejects ProviderIDs identifying the same instance",
+			machine:   newMachine("machine-2", "cluster-1", pointer.String("aws:////i-1")),
+			expectErr: true,
+		},
+		{
+			name:      "accepts malformed ProviderIDs",
+			machine:   newMachine("machine-2", "cluster-1", pointer.String("i-2")),
+			expectErr: false,
+		},
+		{
+			name:      "accepts the Machine itself",
+			machine:   newMachine("machine-1", "cluster-1", pointer.String("aws:///us-east-1/i-1")),
+			expectErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &Machine{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(existing).Build()}
+			err := webhook.ValidateCreate(ctx, tt.machine)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+
+	t.Run("skips the check on update if the ProviderID is unchanged", func(t *testing.T) {
+		g := NewWithT(t)
+
+		duplicate := newMachine("machine-2", "cluster-1", pointer.String("aws:///us-east-1/i-1"))
+		webhook := &Machine{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(existing, duplicate).Build()}
+
+		updated := duplicate.DeepCopy()
+		updated.Labels = map[string]string{"foo": "bar"}
+		g.Expect(webhook.ValidateUpdate(ctx, duplicate, updated)).To(Succeed())
+
+		withoutProviderID := newMachine("machine-2", "cluster-1", nil)
+		g.Expect(webhook.ValidateUpdate(ctx, withoutProviderID, updated)).ToNot(Succeed())
+	})
+}
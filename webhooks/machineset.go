@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// machineSetSelectorWebhookPath is the path of the MachineSet selector validation webhook; it is distinct from
+// the path of the MachineSet webhook implemented in the API package, which does not require a client.
+const machineSetSelectorWebhookPath = "/validate-cluster-x-k8s-io-v1beta1-machineset-selector"
+
+// SetupWebhookWithManager sets up MachineSet webhooks.
+func (webhook *MachineSet) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(machineSetSelectorWebhookPath, admission.WithCustomValidator(&clusterv1.MachineSet{}, webhook))
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-cluster-x-k8s-io-v1beta1-machineset-selector,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=machinesets,versions=v1beta1,name=validation-selector.machineset.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// MachineSet implements a validating webhook preventing MachineSets in the same Cluster from having
+// overlapping selectors; overlapping selectors would lead MachineSets to compete for adopting the same Machines.
+type MachineSet struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &MachineSet{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *MachineSet) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	ms, ok := obj.(*clusterv1.MachineSet)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a MachineSet but got a %T", obj))
+	}
+	return webhook.validate(ctx, nil, ms)
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *MachineSet) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	newMS, ok := newObj.(*clusterv1.MachineSet)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a MachineSet but got a %T", newObj))
+	}
+	oldMS, ok := oldObj.(*clusterv1.MachineSet)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected a MachineSet but got a %T", oldObj))
+	}
+	return webhook.validate(ctx, oldMS, newMS)
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
+func (webhook *MachineSet) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+func (webhook *MachineSet) validate(ctx context.Context, old, new *clusterv1.MachineSet) error {
+	// Only check for overlaps when the selector changes, so MachineSets created before this webhook
+	// was introduced can still be updated.
+	if old != nil && equalSelectors(old.Spec.Selector, new.Spec.Selector) {
+		return nil
+	}
+
+	// Nothing to compare against if the MachineSet is being deleted or it isn't linked to a Cluster yet.
+	if !new.DeletionTimestamp.IsZero() || new.Spec.ClusterName == "" {
+		return nil
+	}
+
+	machineSets := &clusterv1.MachineSetList{}
+	if err := webhook.Client.List(ctx, machineSets, client.InNamespace(new.Namespace)); err != nil {
+		return apierrors.NewInternalError(errors.Wrap(err, "failed to list MachineSets"))
+	}
+
+	var allErrs field.ErrorList
+	for i := range machineSets.Items {
+		other := &machineSets.Items[i]
+		if other.Name == new.Name || other.Spec.ClusterName != new.Spec.ClusterName || !other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		overlap, err := selectorsOverlap(&new.Spec.Selector, &other.Spec.Selector)
+		if err != nil {
+			// Invalid selectors are reported by the MachineSet webhook in the API package.
+			continue
+		}
+		if overlap {
+			allErrs = append(allErrs, field.Invalid(
+				field.NewPath("spec", "selector"),
+				new.Spec.Selector,
+				fmt.Sprintf("overlaps with the selector of MachineSet %s in Cluster %s", other.Name, new.Spec.ClusterName),
+			))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("MachineSet").GroupKind(), new.Name, allErrs)
+}
+
+func equalSelectors(a, b metav1.LabelSelector) bool {
+	return metav1.FormatLabelSelector(&a) == metav1.FormatLabelSelector(&b)
+}
+
+// selectorsOverlap returns true if there could be a set of labels matched by both selectors.
+// Requirements on different keys are independent, so the selectors overlap if the requirements
+// of both selectors can be satisfied at the same time for every key.
+func selectorsOverlap(a, b *metav1.LabelSelector) (bool, error) {
+	requirementsByKey := map[string][]labels.Requirement{}
+	for _, s := range []*metav1.LabelSelector{a, b} {
+		selector, err := metav1.LabelSelectorAsSelector(s)
+		if err != nil {
+			return false, err
+		}
+		requirements, _ := selector.Requirements()
+		for _, r := range requirements {
+			requirementsByKey[r.Key()] = append(requirementsByKey[r.Key()], r)
+		}
+	}
+
+	for _, requirements := range requirementsByKey {
+		if !requirementsSatisfiable(requirements) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// requirementsSatisfiable returns true if there is a value (or the absence of the label)
+// satisfying all the requirements for a single label key.
+func requirementsSatisfiable(requirements []labels.Requirement) bool {
+	var (
+		mustExist    bool
+		mustNotExist bool
+		allowed      sets.String
+		excluded     = sets.NewString()
+	)
+	for _, r := range requirements {
+		switch r.Operator() {
+		case selection.In, selection.Equals, selection.DoubleEquals:
+			mustExist = true
+			values := sets.NewString(r.Values().List()...)
+			if allowed == nil {
+				allowed = values
+			} else {
+				allowed = allowed.Intersection(values)
+			}
+		case selection.NotIn, selection.NotEquals:
+			excluded.Insert(r.Values().List()...)
+		case selection.Exists:
+			mustExist = true
+		case selection.DoesNotExist:
+			mustNotExist = true
+		default:
+			// Other operators are not supported in label selectors; assume they can be satisfied.
+		}
+	}
+
+	if mustExist && mustNotExist {
+		return false
+	}
+	if allowed == nil {
+		// Either the label is absent or it can have any value not explicitly excluded.
+		return true
+	}
+	return allowed.Difference(excluded).Len() > 0
+}
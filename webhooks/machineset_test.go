@@ -0,0 +1,177 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMachineSetSelectorValidation(t *testing.T) {
+	newMachineSet := func(name, clusterName string, selector metav1.LabelSelector) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: clusterv1.MachineSetSpec{
+				ClusterName: clusterName,
+				Selector:    selector,
+			},
+		}
+	}
+	existing := newMachineSet("ms-1", "cluster-1", metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}})
+
+	tests := []struct {
+		name       string
+		machineSet *clusterv1.MachineSet
+		expectErr  bool
+	}{
+		{
+			name:       "accepts disjoint selectors",
+			machineSet: newMachineSet("ms-2", "cluster-1", metav1.LabelSelector{MatchLabels: map[string]string{"pool": "b"}}),
+			expectErr:  false,
+		},
+		{
+			name:       "accepts overlapping selectors in different clusters",
+			machineSet: newMachineSet("ms-2", "cluster-2", metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}}),
+			expectErr:  false,
+		},
+		{
+			name:       "rejects identical selectors",
+			machineSet: newMachineSet("ms-2", "cluster-1", metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}}),
+			expectErr:  true,
+		},
+		{
+			name: "rejects a selector which is a superset of another selector",
+			machineSet: newMachineSet("ms-2", "cluster-1", metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "pool", Operator: metav1.LabelSelectorOpExists}},
+			}),
+			expectErr: true,
+		},
+		{
+			name:       "accepts the MachineSet itself",
+			machineSet: newMachineSet("ms-1", "cluster-1", metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}}),
+			expectErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &MachineSet{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(existing).Build()}
+			err := webhook.ValidateCreate(ctx, tt.machineSet)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+
+	t.Run("skips the check on update if the selector is unchanged", func(t *testing.T) {
+		g := NewWithT(t)
+
+		overlapping := newMachineSet("ms-2", "cluster-1", metav1.LabelSelector{MatchLabels: map[string]string{"pool": "a"}})
+		webhook := &MachineSet{Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(existing, overlapping).Build()}
+
+		updated := overlapping.DeepCopy()
+		updated.Spec.Replicas = pointer.Int32(3)
+		g.Expect(webhook.ValidateUpdate(ctx, overlapping, updated)).To(Succeed())
+
+		updated.Spec.Selector.MatchLabels["zone"] = "us-east-1"
+		g.Expect(webhook.ValidateUpdate(ctx, overlapping, updated)).ToNot(Succeed())
+	})
+}
+
+func TestSelectorsOverlap(t *testing.T) {
+	tests := []struct {
+		name string
+		a    metav1.LabelSelector
+		b    metav1.LabelSelector
+		want bool
+	}{
+		{
+			name: "empty selectors overlap",
+			want: true,
+		},
+		{
+			name: "different keys overlap",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+			b:    metav1.LabelSelector{MatchLabels: map[string]string{"b": "1"}},
+			want: true,
+		},
+		{
+			name: "conflicting values do not overlap",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+			b:    metav1.LabelSelector{MatchLabels: map[string]string{"a": "2"}},
+			want: false,
+		},
+		{
+			name: "intersecting In values overlap",
+			a: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "a", Operator: metav1.LabelSelectorOpIn, Values: []string{"1", "2"}},
+			}},
+			b: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "a", Operator: metav1.LabelSelectorOpIn, Values: []string{"2", "3"}},
+			}},
+			want: true,
+		},
+		{
+			name: "NotIn excluding all the In values does not overlap",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"a": "1"}},
+			b: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "a", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"1"}},
+			}},
+			want: false,
+		},
+		{
+			name: "NotIn and DoesNotExist overlap",
+			a: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "a", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"1"}},
+			}},
+			b: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "a", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}},
+			want: true,
+		},
+		{
+			name: "Exists and DoesNotExist do not overlap",
+			a: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "a", Operator: metav1.LabelSelectorOpExists},
+			}},
+			b: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "a", Operator: metav1.LabelSelectorOpDoesNotExist},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := selectorsOverlap(&tt.a, &tt.b)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
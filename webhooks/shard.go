@@ -0,0 +1,126 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/shard"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// shardLabelWebhookPath is the path of the webhook defaulting the shard label of Cluster API objects.
+const shardLabelWebhookPath = "/mutate-cluster-x-k8s-io-v1beta1-shard-label"
+
+// SetupWebhookWithManager sets up the ShardLabel webhook.
+func (webhook *ShardLabel) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	mgr.GetWebhookServer().Register(shardLabelWebhookPath, &admission.Webhook{Handler: webhook})
+	return nil
+}
+
+// +kubebuilder:webhook:verbs=create,path=/mutate-cluster-x-k8s-io-v1beta1-shard-label,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=clusters;machines;machinesets;machinedeployments;machinehealthchecks;machinepools,versions=v1beta1,name=default-shard-label.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// ShardLabel implements a defaulting webhook setting the watch-filter label of new Cluster API objects to the shard
+// of their Cluster, so that each object is reconciled by the controllers started with the corresponding --watch-filter value.
+// The shard of a Cluster is the value of its watch-filter label, if set, or the shard the Cluster is assigned to
+// by consistent hashing. Objects which already have the watch-filter label are not modified.
+type ShardLabel struct {
+	Client client.Reader
+
+	// Shards are the values of the watch-filter label of all the instances of the controllers;
+	// if empty, objects are not modified.
+	Shards []string
+}
+
+var _ admission.Handler = &ShardLabel{}
+
+// Handle implements admission.Handler.
+func (webhook *ShardLabel) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if len(webhook.Shards) == 0 || req.Operation != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(req.Object.Raw); err != nil {
+		return admission.Errored(http.StatusBadRequest, errors.Wrap(err, "failed to decode object"))
+	}
+	if _, ok := obj.GetLabels()[clusterv1.WatchLabel]; ok {
+		return admission.Allowed("")
+	}
+
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		namespace = req.Namespace
+	}
+	value, err := webhook.shardFor(ctx, obj, namespace)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if value == "" {
+		return admission.Allowed("")
+	}
+
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[clusterv1.WatchLabel] = value
+	obj.SetLabels(labels)
+
+	marshalled, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, errors.Wrap(err, "failed to encode object"))
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshalled)
+}
+
+// shardFor returns the shard of the Cluster an object belongs to, or an empty string if the object does not belong to a Cluster.
+func (webhook *ShardLabel) shardFor(ctx context.Context, obj *unstructured.Unstructured, namespace string) (string, error) {
+	if obj.GetKind() == "Cluster" {
+		return shard.Assign(webhook.Shards, namespace, obj.GetName()), nil
+	}
+
+	// NOTE: The Cluster name label is set by the defaulting webhooks of the Cluster API objects, which could be called
+	// after this webhook; falling back to spec.clusterName.
+	clusterName := obj.GetLabels()[clusterv1.ClusterLabelName]
+	if clusterName == "" {
+		clusterName, _, _ = unstructured.NestedString(obj.Object, "spec", "clusterName")
+	}
+	if clusterName == "" {
+		return "", nil
+	}
+
+	// Objects of a Cluster pinned to a shard by setting its watch-filter label belong to the same shard.
+	cluster := &clusterv1.Cluster{}
+	if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: clusterName}, cluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "failed to get Cluster %s/%s", namespace, clusterName)
+		}
+	} else if value, ok := cluster.Labels[clusterv1.WatchLabel]; ok {
+		return value, nil
+	}
+	return shard.Assign(webhook.Shards, namespace, clusterName), nil
+}
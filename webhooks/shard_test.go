@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/shard"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestShardLabelDefaulting(t *testing.T) {
+	shards := []string{"shard-0", "shard-1", "shard-2"}
+
+	newCluster := func(name string, labels map[string]string) *clusterv1.Cluster {
+		return &clusterv1.Cluster{
+			TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "Cluster"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name, Labels: labels},
+		}
+	}
+	newMachine := func(clusterName string, labels map[string]string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			TypeMeta:   metav1.TypeMeta{APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "machine", Labels: labels},
+			Spec:       clusterv1.MachineSpec{ClusterName: clusterName},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		shards    []string
+		objs      []client.Object
+		obj       runtime.Object
+		wantLabel string
+	}{
+		{
+			name:   "does not modify objects when there are no shards",
+			shards: nil,
+			obj:    newCluster("foo", nil),
+		},
+		{
+			name:      "defaults the label of a Cluster to the shard it is assigned to",
+			shards:    shards,
+			obj:       newCluster("foo", nil),
+			wantLabel: shard.Assign(shards, metav1.NamespaceDefault, "foo"),
+		},
+		{
+			name:   "does not modify a Cluster pinned to a shard",
+			shards: shards,
+			obj:    newCluster("foo", map[string]string{clusterv1.WatchLabel: "shard-1"}),
+		},
+		{
+			name:      "defaults the label of a Machine to the shard its Cluster is assigned to",
+			shards:    shards,
+			obj:       newMachine("foo", nil),
+			wantLabel: shard.Assign(shards, metav1.NamespaceDefault, "foo"),
+		},
+		{
+			name:      "defaults the label of a Machine to the shard its Cluster is pinned to",
+			shards:    shards,
+			objs:      []client.Object{newCluster("foo", map[string]string{clusterv1.WatchLabel: "pinned"})},
+			obj:       newMachine("foo", nil),
+			wantLabel: "pinned",
+		},
+		{
+			name:      "uses the Cluster name label of a Machine",
+			shards:    shards,
+			obj:       newMachine("", map[string]string{clusterv1.ClusterLabelName: "bar"}),
+			wantLabel: shard.Assign(shards, metav1.NamespaceDefault, "bar"),
+		},
+		{
+			name:   "does not modify objects not belonging to a Cluster",
+			shards: shards,
+			obj:    newMachine("", nil),
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &ShardLabel{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objs...).Build(),
+				Shards: tt.shards,
+			}
+
+			raw := mustMarshal(g, tt.obj)
+			resp := webhook.Handle(context.Background(), admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: admissionv1.Create,
+				Object:    runtime.RawExtension{Raw: raw},
+			}})
+			g.Expect(resp.Allowed).To(BeTrue())
+			if tt.wantLabel == "" {
+				g.Expect(resp.Patches).To(BeEmpty())
+				return
+			}
+
+			rawPatch, err := json.Marshal(resp.Patches)
+			g.Expect(err).NotTo(HaveOccurred())
+			patch, err := jsonpatch.DecodePatch(rawPatch)
+			g.Expect(err).NotTo(HaveOccurred())
+			patched, err := patch.Apply(raw)
+			g.Expect(err).NotTo(HaveOccurred())
+			obj := &unstructured.Unstructured{}
+			g.Expect(obj.UnmarshalJSON(patched)).To(Succeed())
+			g.Expect(obj.GetLabels()).To(HaveKeyWithValue(clusterv1.WatchLabel, tt.wantLabel))
+		})
+	}
+}